@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/access"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+)
+
+// envAppPassword supplies the app lock password non-interactively.
+const envAppPassword = "DB_BENCHMIND_APP_PASSWORD"
+
+// appAccess checks permissions against the app lock, asking for the app lock
+// password the first time a restricted command needs it.
+type appAccess struct {
+	uc   *usecase.AccessUseCase
+	once sync.Once
+}
+
+var (
+	accessOnce sync.Once
+	cliAccess  *appAccess
+)
+
+// openAccess returns the access checker shared by the use cases of a command.
+func openAccess() *appAccess {
+	accessOnce.Do(func() {
+		cliAccess = &appAccess{uc: usecase.NewAccessUseCase(repository.NewSettingsRepository(dirs.ConfigPath()))}
+	})
+	return cliAccess
+}
+
+// Require unlocks the app lock if needed and checks permission.
+func (a *appAccess) Require(ctx context.Context, permission access.Permission) error {
+	a.unlockOnce(ctx)
+	return a.uc.Require(ctx, permission)
+}
+
+// unlockOnce unlocks the app lock the first time it is called.
+func (a *appAccess) unlockOnce(ctx context.Context) {
+	a.once.Do(func() { a.unlock(ctx) })
+}
+
+// unlock unlocks the session if the app lock is enabled. Failures leave it
+// locked, so restricted commands fail with the lock error.
+func (a *appAccess) unlock(ctx context.Context) {
+	enabled, err := a.uc.LockEnabled(ctx)
+	if err != nil || !enabled {
+		return
+	}
+	password, err := readSecret(envAppPassword, "App password", false)
+	if err != nil {
+		slog.Warn("Access: Left locked", "error", err)
+		fmt.Fprintf(os.Stderr, "Warning: The app is locked: %v\n", err)
+		return
+	}
+	if _, err := a.uc.Unlock(ctx, password); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to unlock the app: %v\n", err)
+	}
+}
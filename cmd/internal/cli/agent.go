@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/agent"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/tool"
+)
+
+func agentCommand(args []string) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		agentServe(args)
+		return
+	}
+
+	switch args[0] {
+	case "key":
+		agentKey()
+	case "list":
+		agentList()
+	case "add":
+		agentAdd(args[1:])
+	case "remove":
+		agentRemove(args[1:])
+	case "test":
+		agentTest(args[1:])
+	default:
+		fmt.Printf("Unknown agent command: %s\n", args[0])
+		os.Exit(1)
+	}
+}
+
+// agentServe runs this machine as a load-generator agent until interrupted.
+func agentServe(args []string) {
+	fs := flag.NewFlagSet("agent", flag.ExitOnError)
+	listen := fs.String("listen", fmt.Sprintf(":%d", config.DefaultAgentPort), "Address to listen on")
+	workDir := fs.String("work-dir", "", "Directory for the work directories of commands (default: system temp)")
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: db-benchmind agent [--listen ADDR] [--work-dir DIR]")
+		os.Exit(1)
+	}
+
+	server, err := agent.NewServer(agent.ServerConfig{
+		HostKeyPath:        dirs.AgentHostKeyPath(),
+		AuthorizedKeysPath: dirs.AgentAuthorizedKeysPath(),
+		WorkDir:            *workDir,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Agent listening on %s\n", *listen)
+	fmt.Printf("  Host key:        %s\n", server.Fingerprint())
+	fmt.Printf("  Authorized keys: %s\n", dirs.AgentAuthorizedKeysPath())
+	fmt.Println("\nOn the controller, add the output of 'db-benchmind agent key' to the")
+	fmt.Println("authorized keys file above, then register this agent:")
+	fmt.Printf("  db-benchmind agent add NAME HOST:PORT --host-key %s\n\n", server.Fingerprint())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := server.ListenAndServe(ctx, *listen); err != nil {
+		slog.Error("Agent failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// agentKey prints the controller key that agents must authorize.
+func agentKey() {
+	key, err := agent.LoadOrCreateKey(dirs.AgentKeyPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(agent.AuthorizedKey(key))
+}
+
+// newAgentSettings creates the settings use case that stores the agents.
+func newAgentSettings() *usecase.SettingsUseCase {
+	return usecase.NewSettingsUseCase(repository.NewSettingsRepository(dirs.ConfigPath()), tool.NewDetector())
+}
+
+func agentList() {
+	agents, err := newAgentSettings().GetAgents(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to list agents: %v\n", err)
+		os.Exit(1)
+	}
+	if len(agents) == 0 {
+		fmt.Println("No agents configured. Add one with: db-benchmind agent add NAME HOST:PORT --host-key FINGERPRINT")
+		return
+	}
+	for _, a := range agents {
+		fmt.Printf("%-20s %-24s %s\n", a.Name, a.Address, a.HostKey)
+	}
+}
+
+// agentAdd adds an agent, or replaces the agent with the same name.
+func agentAdd(args []string) {
+	fs := flag.NewFlagSet("agent add", flag.ExitOnError)
+	hostKey := fs.String("host-key", "", "Host key fingerprint printed by the agent (SHA256:...)")
+	usage := "Usage: db-benchmind agent add NAME HOST:PORT --host-key FINGERPRINT"
+	if len(args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(1)
+	}
+	fs.Parse(args[2:])
+	if fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	settingsUC := newAgentSettings()
+	agents, err := settingsUC.GetAgents(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	added := config.AgentConfig{Name: args[0], Address: args[1], HostKey: *hostKey}
+	agents = slices.DeleteFunc(agents, func(a config.AgentConfig) bool { return a.Name == added.Name })
+	if err := settingsUC.UpdateAgents(ctx, append(agents, added)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	slog.Info("Agent added", "command", "agent add", "name", added.Name, "address", added.Address)
+	fmt.Printf("Agent %s added. Run benchmarks on it with --agent %s\n", added.Name, added.Name)
+}
+
+func agentRemove(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: db-benchmind agent remove NAME")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	settingsUC := newAgentSettings()
+	agents, err := settingsUC.GetAgents(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	kept := slices.DeleteFunc(slices.Clone(agents), func(a config.AgentConfig) bool { return a.Name == args[0] })
+	if len(kept) == len(agents) {
+		fmt.Fprintf(os.Stderr, "Error: %v: %s\n", usecase.ErrAgentNotFound, args[0])
+		os.Exit(1)
+	}
+	if err := settingsUC.UpdateAgents(ctx, kept); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Agent %s removed\n", args[0])
+}
+
+// agentTest connects to an agent and looks up the benchmark tools on it.
+func agentTest(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: db-benchmind agent test NAME")
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	cfg, err := newAgentSettings().GetAgent(ctx, args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	key, err := agent.LoadOrCreateKey(dirs.AgentKeyPath())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	start := time.Now()
+	client, err := agent.Dial(ctx, cfg.Address, cfg.HostKey, key)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "✗ %s: %v\n", cfg.Name, err)
+		os.Exit(1)
+	}
+	defer client.Close()
+	fmt.Printf("✓ %s: connected to %s in %dms\n", cfg.Name, cfg.Address, time.Since(start).Milliseconds())
+
+	for _, binary := range []string{"sysbench", "hammerdbcli", "charbench"} {
+		if path, err := client.LookPath(ctx, binary); err == nil {
+			fmt.Printf("  %-12s %s\n", binary, path)
+		} else {
+			fmt.Printf("  %-12s not found\n", binary)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/analytics"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+)
+
+func analyticsCommand(args []string) {
+	if len(args) == 0 {
+		fmt.Println("Usage: db-benchmind analytics <load|run|list|show|compare|delete> [options]")
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "load":
+		analyticsLoad(args[1:])
+	case "run":
+		analyticsRun(args[1:])
+	case "list":
+		analyticsList()
+	case "show":
+		analyticsShow(args[1:])
+	case "compare":
+		analyticsCompare(args[1:])
+	case "delete":
+		analyticsDelete(args[1:])
+	default:
+		fmt.Printf("Unknown analytics command: %s\n", args[0])
+		os.Exit(1)
+	}
+}
+
+// analyticsLoad loads the data set into the database of a connection.
+func analyticsLoad(args []string) {
+	fs := flag.NewFlagSet("analytics load", flag.ExitOnError)
+	scale := fs.Float64("scale", analytics.DefaultScaleFactor, "Scale factor of the data set (1 = about 1GB)")
+	dbName := fs.String("database", "", "Database of the data set (default: the connection's, or tpch)")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fmt.Println("Usage: db-benchmind analytics load [--scale SF] [--database D] NAME|ID")
+		os.Exit(1)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	analyticsUC, connUC := newAnalyticsUseCase(ctx, db)
+	conn := mustFindConnection(ctx, connUC, fs.Arg(0))
+	slog.Info("Loading analytical data set", "command", "analytics load", "connection", conn.GetName(), "scale_factor", *scale)
+
+	counts := analytics.RowCounts(*scale)
+	start := time.Now()
+	err := analyticsUC.LoadDataset(ctx, conn.GetID(), *dbName, *scale, func(table string, rows int64) {
+		if want, ok := counts[table]; ok {
+			fmt.Printf("\r%-10s %d/%d rows", table, rows, want)
+		} else {
+			fmt.Printf("\r%-10s %d rows", table, rows)
+		}
+	})
+	fmt.Println()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to load data set: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Data set loaded into %s at scale factor %g in %s\n", conn.GetName(), *scale, time.Since(start).Round(time.Second))
+}
+
+// analyticsRun runs the queries and prints the per-query latencies.
+func analyticsRun(args []string) {
+	fs := flag.NewFlagSet("analytics run", flag.ExitOnError)
+	dbName := fs.String("database", "", "Database of the data set (default: the connection's, or tpch)")
+	name := fs.String("name", "", "Label of the run, e.g. before-index")
+	concurrency := fs.Int("concurrency", 1, "Query streams run at the same time (1 = sequential)")
+	queryList := fs.String("queries", "", "Queries to run, e.g. 1,3,5-7 (default: all 22)")
+	timeout := fs.Duration("timeout", 0, "Timeout of each query, e.g. 10m (default: none)")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fmt.Println("Usage: db-benchmind analytics run [--concurrency N] [--queries LIST] [--timeout D] [--name N] [--database D] NAME|ID")
+		os.Exit(1)
+	}
+	queries, err := analytics.ParseQueryList(*queryList)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	analyticsUC, connUC := newAnalyticsUseCase(ctx, db)
+	conn := mustFindConnection(ctx, connUC, fs.Arg(0))
+	slog.Info("Running analytical queries", "command", "analytics run", "connection", conn.GetName(), "streams", *concurrency)
+
+	run, err := analyticsUC.RunQueries(ctx, usecase.AnalyticsOptions{
+		ConnectionID: conn.GetID(),
+		Database:     *dbName,
+		Name:         *name,
+		Concurrency:  *concurrency,
+		Queries:      queries,
+		QueryTimeout: *timeout,
+	}, func(r analytics.QueryResult) {
+		if r.Error != "" {
+			fmt.Printf("stream %d  Q%-3d failed after %s: %s\n", r.Stream, r.Query, r.Latency.Round(time.Millisecond), r.Error)
+			return
+		}
+		fmt.Printf("stream %d  Q%-3d %10s  %d rows\n", r.Stream, r.Query, r.Latency.Round(time.Millisecond), r.Rows)
+	})
+	if run == nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to run queries: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	printAnalyticsRun(run)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if run.State != execution.StateCompleted || run.Errors() > 0 {
+		os.Exit(1)
+	}
+}
+
+func analyticsList() {
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	analyticsUC, _ := newAnalyticsUseCase(ctx, db)
+
+	runs, err := analyticsUC.ListRuns(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to list analytical runs: %v\n", err)
+		os.Exit(1)
+	}
+	if len(runs) == 0 {
+		fmt.Println("No analytical runs found. Load a data set with: db-benchmind analytics load NAME")
+		return
+	}
+
+	fmt.Printf("\nFound %d analytical run(s):\n", len(runs))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, run := range runs {
+		fmt.Printf("%s  %-20s %-16s SF %-6g %2d streams  %10s  geomean %-9s %-9s %s\n",
+			run.StartedAt.Format("2006-01-02 15:04"), run.ConnectionName, run.Name, run.ScaleFactor, run.Concurrency,
+			run.Duration.Round(time.Second), run.GeoMean().Round(time.Millisecond), run.State, run.ID)
+	}
+}
+
+func analyticsShow(args []string) {
+	if len(args) != 1 {
+		fmt.Println("Usage: db-benchmind analytics show ID")
+		os.Exit(1)
+	}
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	analyticsUC, _ := newAnalyticsUseCase(ctx, db)
+
+	run, err := analyticsUC.GetRun(ctx, args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to load analytical run %s: %v\n", args[0], err)
+		os.Exit(1)
+	}
+	printAnalyticsRun(run)
+}
+
+// analyticsCompare prints or writes the per-query comparison of runs.
+func analyticsCompare(args []string) {
+	fs := flag.NewFlagSet("analytics compare", flag.ExitOnError)
+	format := fs.String("format", "txt", "Output format: txt or markdown")
+	output := fs.String("output", "", "Write the report to this file instead of stdout")
+	fs.Parse(args)
+	if fs.NArg() < 1 || (*format != "txt" && *format != "markdown") {
+		fmt.Println("Usage: db-benchmind analytics compare [--format txt|markdown] [--output FILE] ID [ID...]")
+		os.Exit(1)
+	}
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	analyticsUC, _ := newAnalyticsUseCase(ctx, db)
+
+	comparison, err := analyticsUC.CompareRuns(ctx, fs.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	report := comparison.FormatTXT()
+	if *format == "markdown" {
+		report = comparison.FormatMarkdown()
+	}
+	if *output == "" {
+		fmt.Print(report)
+		return
+	}
+	if err := os.WriteFile(*output, []byte(report), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to write report: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Report written to %s\n", *output)
+}
+
+func analyticsDelete(args []string) {
+	if len(args) != 1 {
+		fmt.Println("Usage: db-benchmind analytics delete ID")
+		os.Exit(1)
+	}
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	analyticsUC, _ := newAnalyticsUseCase(ctx, db)
+
+	if err := analyticsUC.DeleteRun(ctx, args[0]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to delete analytical run: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Analytical run %s deleted\n", args[0])
+}
+
+// newAnalyticsUseCase returns the analytics use case and the connection use case it uses.
+func newAnalyticsUseCase(ctx context.Context, db *sql.DB) (*usecase.AnalyticsUseCase, *usecase.ConnectionUseCase) {
+	connUC := usecase.NewConnectionUseCase(repository.NewSQLiteConnectionRepository(db), openKeyring(ctx))
+	return usecase.NewAnalyticsUseCase(connUC, repository.NewSQLiteAnalyticsRepository(db)), connUC
+}
+
+// printAnalyticsRun prints the summary and the per-query stats of a run.
+func printAnalyticsRun(run *analytics.Run) {
+	fmt.Printf("Run:         %s\n", run.ID)
+	if run.Name != "" {
+		fmt.Printf("Name:        %s\n", run.Name)
+	}
+	fmt.Printf("Connection:  %s (%s, database %s)\n", run.ConnectionName, run.DatabaseType, run.Database)
+	fmt.Printf("Data set:    scale factor %g\n", run.ScaleFactor)
+	fmt.Printf("Streams:     %d\n", run.Concurrency)
+	fmt.Printf("Started:     %s\n", run.StartedAt.Format("2006-01-02 15:04:05"))
+	state := string(run.State)
+	if run.ErrorMessage != "" {
+		state += ": " + run.ErrorMessage
+	}
+	fmt.Printf("State:       %s\n", state)
+	fmt.Printf("Duration:    %s\n", run.Duration.Round(time.Millisecond))
+	fmt.Printf("Geomean:     %s\n", run.GeoMean().Round(time.Millisecond))
+	fmt.Printf("Queries/h:   %.1f (%d errors)\n", run.QueriesPerHour(), run.Errors())
+	fmt.Println()
+	for _, stats := range run.QueryStats() {
+		fmt.Println(stats)
+	}
+}
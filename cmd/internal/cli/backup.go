@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+)
+
+// envBackupPassword supplies the backup password non-interactively.
+const envBackupPassword = "DB_BENCHMIND_BACKUP_PASSWORD"
+
+func backupCommand(args []string) {
+	if len(args) == 0 {
+		fmt.Println("Usage: db-benchmind backup <create|restore> FILE [options]")
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "create":
+		backupCreate(args[1:])
+	case "restore":
+		backupRestore(args[1:])
+	default:
+		fmt.Printf("Unknown backup command: %s\n", args[0])
+		os.Exit(1)
+	}
+}
+
+// backupPaths returns the locations of the backed up state.
+func backupPaths() usecase.BackupPaths {
+	return usecase.BackupPaths{
+		DataDir:    dirs.DataDir(),
+		DBPath:     dirs.DBPath(),
+		ConfigPath: dirs.ConfigPath(),
+		RunsDir:    dirs.RunsDir(),
+	}
+}
+
+// backupCreate writes the database, settings, run artifacts and saved
+// passwords to one archive. Passwords are encrypted with a backup password.
+func backupCreate(args []string) {
+	fs := flag.NewFlagSet("backup create", flag.ExitOnError)
+	noSecrets := fs.Bool("no-secrets", false, "Leave saved passwords out of the backup")
+	file := parseSingleArg(fs, args, "Usage: db-benchmind backup create FILE [--no-secrets]")
+
+	ctx := context.Background()
+	db := openDatabase(ctx)
+	defer db.Close()
+	keyringProvider := openKeyring(ctx)
+
+	var password string
+	if !*noSecrets {
+		var err error
+		password, err = readSecret(envBackupPassword, "Backup password", true)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v (or use --no-secrets)\n", err)
+			os.Exit(1)
+		}
+	}
+
+	slog.Info("Creating backup", "command", "backup create", "file", file, "secrets", !*noSecrets)
+	backupUC := usecase.NewBackupUseCase(backupPaths(), keyringProvider)
+	manifest, err := backupUC.Create(ctx, db, repository.NewSQLiteConnectionRepository(db), file, password)
+	if err != nil {
+		slog.Error("Backup failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to create backup: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Backup created: %s\n", file)
+	printBackupManifest(manifest)
+}
+
+// backupRestore replaces the state in the data directory with a backup and
+// saves its passwords to this machine's keyring.
+func backupRestore(args []string) {
+	fs := flag.NewFlagSet("backup restore", flag.ExitOnError)
+	force := fs.Bool("force", false, "Replace existing data (kept with the .before-restore suffix)")
+	noSecrets := fs.Bool("no-secrets", false, "Do not restore saved passwords")
+	file := parseSingleArg(fs, args, "Usage: db-benchmind backup restore FILE [--force] [--no-secrets]")
+
+	ctx := context.Background()
+	manifest, err := usecase.ReadBackupManifest(file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var password string
+	if manifest.Secrets > 0 && !*noSecrets {
+		password, err = readSecret(envBackupPassword, "Backup password", false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v (or use --no-secrets)\n", err)
+			os.Exit(1)
+		}
+	}
+
+	slog.Info("Restoring backup", "command", "backup restore", "file", file, "force", *force)
+	backupUC := usecase.NewBackupUseCase(backupPaths(), openKeyring(ctx))
+	result, err := backupUC.Restore(ctx, file, password, *force)
+	if errors.Is(err, usecase.ErrBackupTargetExists) {
+		fmt.Fprintf(os.Stderr, "Error: %s already has a database; use --force to replace it\n", dirs.DataDir())
+		os.Exit(1)
+	}
+	if err != nil {
+		slog.Error("Restore failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to restore backup: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Opening the database upgrades the schema of backups from older releases
+	openDatabase(ctx).Close()
+
+	fmt.Printf("Backup restored into %s\n", dirs.DataDir())
+	printBackupManifest(result.Manifest)
+	fmt.Printf("Passwords restored: %d\n", result.Secrets)
+	for _, path := range result.SetAside {
+		fmt.Printf("Previous file kept: %s\n", path)
+	}
+}
+
+// printBackupManifest prints the content of a backup.
+func printBackupManifest(m *usecase.BackupManifest) {
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	fmt.Printf("Created:         %s\n", m.CreatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Schema version:  %d\n", m.SchemaVersion)
+	fmt.Printf("Connections:     %d\n", m.Connections)
+	fmt.Printf("Saved passwords: %d\n", m.Secrets)
+	fmt.Printf("Settings:        %t\n", m.Settings)
+	fmt.Printf("Run artifacts:   %d files\n", m.RunFiles)
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+}
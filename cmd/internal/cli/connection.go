@@ -0,0 +1,515 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+)
+
+// connectionFlags holds the connection fields settable from the command line.
+type connectionFlags struct {
+	name            string
+	dbType          string
+	host            string
+	port            int
+	user            string
+	database        string
+	serviceName     string
+	sid             string
+	sslMode         string
+	sslCA           string
+	sslCert         string
+	sslKey          string
+	trustServerCert bool
+	instance        string
+	encrypt         string
+	readOnlyIntent  bool
+	auth            string
+	protocol        string
+	walletDir       string
+	group           string
+	favorite        bool
+	passwordStdin   bool
+	dsn             string
+	options         fieldList
+	replicas        string
+}
+
+// register adds the connection flags to fs.
+func (f *connectionFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.name, "name", "", "Connection name")
+	fs.StringVar(&f.dbType, "type", "", "Database type: mysql, postgresql, oracle, sqlserver")
+	fs.StringVar(&f.host, "host", "", "Database host")
+	fs.IntVar(&f.port, "port", 0, "Database port (default depends on type)")
+	fs.StringVar(&f.user, "user", "", "Database user")
+	fs.StringVar(&f.database, "database", "", "Database name (MySQL, PostgreSQL, SQL Server)")
+	fs.StringVar(&f.serviceName, "service-name", "", "Oracle service name")
+	fs.StringVar(&f.sid, "sid", "", "Oracle SID")
+	fs.StringVar(&f.sslMode, "ssl-mode", "", "SSL mode (MySQL: disabled, preferred, required, verify-ca, verify-identity; PostgreSQL)")
+	fs.StringVar(&f.sslCA, "ssl-ca", "", "CA certificate file (MySQL)")
+	fs.StringVar(&f.sslCert, "ssl-cert", "", "Client certificate file (MySQL)")
+	fs.StringVar(&f.sslKey, "ssl-key", "", "Client key file (MySQL)")
+	fs.BoolVar(&f.trustServerCert, "trust-server-cert", false, "Trust the server certificate (SQL Server, Oracle TCPS)")
+	fs.StringVar(&f.protocol, "protocol", "", "Oracle Net protocol: tcp or tcps (Oracle)")
+	fs.StringVar(&f.walletDir, "wallet-dir", "", "Directory of an auto-login wallet, requires --service-name (Oracle)")
+	fs.StringVar(&f.instance, "instance", "", "Named instance, also accepted as --host HOST\\INSTANCE (SQL Server)")
+	fs.StringVar(&f.encrypt, "encrypt", "", "Encryption: disable, false, true, strict; detected when testing if unset (SQL Server)")
+	fs.BoolVar(&f.readOnlyIntent, "read-only-intent", false, "Connect with ApplicationIntent=ReadOnly (SQL Server)")
+	fs.StringVar(&f.auth, "auth", "", "Authentication: sql, or windows with a DOMAIN\\user --user or WinRM account (SQL Server)")
+	fs.StringVar(&f.group, "group", "", "Group of the connection in the connection list, e.g. \"Prod EU\" (empty to ungroup)")
+	fs.BoolVar(&f.favorite, "favorite", false, "Star the connection as a favorite (--favorite=false to unstar)")
+	fs.BoolVar(&f.passwordStdin, "password-stdin", false, "Read the database password from stdin")
+	fs.StringVar(&f.dsn, "dsn", "", "PostgreSQL URI, e.g. postgres://user@host:5432/db?connect_timeout=10 (other flags override its fields)")
+	fs.StringVar(&f.replicas, "replicas", "", "Replicas of the primary, HOST[:PORT] comma separated, monitored during benchmarks (MySQL, PostgreSQL)")
+	fs.Var(&f.options, "option", "Additional PostgreSQL connection parameter: NAME=VALUE, an empty VALUE removes it (repeatable)")
+}
+
+func connectionCommand(args []string) {
+	if len(args) == 0 {
+		fmt.Println("Usage: db-benchmind connection <add|edit|set|delete> [options]")
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "add":
+		connectionAdd(args[1:])
+	case "edit":
+		connectionEdit(args[1:])
+	case "set":
+		connectionSet(args[1:])
+	case "delete":
+		connectionDelete(args[1:])
+	default:
+		fmt.Printf("Unknown connection command: %s\n", args[0])
+		os.Exit(1)
+	}
+}
+
+func connectionAdd(args []string) {
+	fs := flag.NewFlagSet("connection add", flag.ExitOnError)
+	var f connectionFlags
+	f.register(fs)
+	fs.Parse(args)
+
+	// Fields of a PostgreSQL URI, unless given as flags
+	set := setFlags(fs)
+	var uri *connection.PostgreSQLConnection
+	if f.dsn != "" {
+		if f.dbType != "" && connection.DatabaseType(strings.ToLower(f.dbType)) != connection.DatabaseTypePostgreSQL {
+			fmt.Fprintln(os.Stderr, "Error: --dsn is only supported for PostgreSQL connections")
+			os.Exit(1)
+		}
+		var err error
+		if uri, err = connection.ParsePostgreSQLURI(f.dsn); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		f.dbType = string(connection.DatabaseTypePostgreSQL)
+		if !set["host"] {
+			f.host = uri.Host
+		}
+		if !set["port"] {
+			f.port = uri.Port
+		}
+		if !set["database"] {
+			f.database = uri.Database
+		}
+		if !set["user"] {
+			f.user = uri.Username
+		}
+		if !set["ssl-mode"] && uri.SSLMode != "" {
+			f.sslMode, set["ssl-mode"] = uri.SSLMode, true
+		}
+	}
+
+	if f.name == "" || f.dbType == "" || f.host == "" {
+		fmt.Fprintln(os.Stderr, "Error: --name, --type and --host are required")
+		os.Exit(1)
+	}
+
+	var conn connection.Connection
+	switch connection.DatabaseType(strings.ToLower(f.dbType)) {
+	case connection.DatabaseTypeMySQL:
+		conn = usecase.NewMySQLConnection(f.name, f.host, f.database, f.user, portOrDefault(f.port, 3306))
+	case connection.DatabaseTypePostgreSQL:
+		conn = usecase.NewPostgreSQLConnection(f.name, f.host, f.database, f.user, portOrDefault(f.port, 5432))
+	case connection.DatabaseTypeOracle:
+		conn = usecase.NewOracleConnection(f.name, f.host, f.serviceName, f.sid, f.user, portOrDefault(f.port, 1521))
+	case connection.DatabaseTypeSQLServer:
+		conn = usecase.NewSQLServerConnection(f.name, f.host, f.database, f.user, portOrDefault(f.port, 1433))
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Unsupported database type: %s\n", f.dbType)
+		os.Exit(1)
+	}
+
+	// Type-specific options
+	switch c := conn.(type) {
+	case *connection.MySQLConnection:
+		if set["ssl-mode"] {
+			c.SSLMode = f.sslMode
+		}
+		c.SSLCA, c.SSLCert, c.SSLKey = f.sslCA, f.sslCert, f.sslKey
+		c.Replicas = connection.ParseReplicas(f.replicas)
+	case *connection.PostgreSQLConnection:
+		if set["ssl-mode"] {
+			c.SSLMode = f.sslMode
+		}
+		c.Replicas = connection.ParseReplicas(f.replicas)
+		if uri != nil {
+			c.Options = uri.Options
+		}
+	case *connection.OracleConnection:
+		c.Protocol, c.WalletDir, c.TrustServerCertificate = f.protocol, f.walletDir, f.trustServerCert
+	case *connection.SQLServerConnection:
+		c.TrustServerCertificate = f.trustServerCert
+		c.Instance, c.Encrypt, c.ReadOnlyIntent, c.Authentication = f.instance, f.encrypt, f.readOnlyIntent, f.auth
+	}
+	applyOptions(conn, f.options)
+	conn.SetGroup(f.group)
+	conn.SetFavorite(f.favorite)
+
+	if f.passwordStdin {
+		setConnectionPassword(conn, readPasswordStdin())
+	} else if uri != nil && uri.Password != "" {
+		setConnectionPassword(conn, uri.Password)
+	}
+	warnDSN(conn)
+
+	slog.Info("Adding connection", "command", "connection add", "name", f.name, "type", conn.GetType())
+	ctx := context.Background()
+	connUC, closeDB := openConnectionUseCase(ctx)
+	defer closeDB()
+
+	if err := connUC.CreateConnection(ctx, conn); err != nil {
+		slog.Error("Add connection failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to add connection: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Connection added: %s (%s)\n", conn.GetName(), conn.GetID())
+}
+
+func connectionEdit(args []string) {
+	fs := flag.NewFlagSet("connection edit", flag.ExitOnError)
+	var f connectionFlags
+	f.register(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: db-benchmind connection edit [options] NAME|ID")
+		os.Exit(1)
+	}
+	set := setFlags(fs)
+	if set["type"] {
+		fmt.Fprintln(os.Stderr, "Error: The type of a connection cannot be changed")
+		os.Exit(1)
+	}
+	if set["dsn"] {
+		fmt.Fprintln(os.Stderr, "Error: --dsn is only supported by connection add")
+		os.Exit(1)
+	}
+
+	slog.Info("Editing connection", "command", "connection edit", "connection", fs.Arg(0))
+	ctx := context.Background()
+	connUC, closeDB := openConnectionUseCase(ctx)
+	defer closeDB()
+
+	conn := mustFindConnection(ctx, connUC, fs.Arg(0))
+
+	// Only flags given on the command line are changed
+	if set["name"] {
+		conn.SetName(f.name)
+	}
+	if set["group"] {
+		conn.SetGroup(f.group)
+	}
+	if set["favorite"] {
+		conn.SetFavorite(f.favorite)
+	}
+	switch c := conn.(type) {
+	case *connection.MySQLConnection:
+		applyFlag(set, "host", &c.Host, f.host)
+		applyFlag(set, "port", &c.Port, f.port)
+		applyFlag(set, "user", &c.Username, f.user)
+		applyFlag(set, "database", &c.Database, f.database)
+		applyFlag(set, "ssl-mode", &c.SSLMode, f.sslMode)
+		applyFlag(set, "ssl-ca", &c.SSLCA, f.sslCA)
+		applyFlag(set, "ssl-cert", &c.SSLCert, f.sslCert)
+		applyFlag(set, "ssl-key", &c.SSLKey, f.sslKey)
+		applyFlag(set, "replicas", &c.Replicas, connection.ParseReplicas(f.replicas))
+	case *connection.PostgreSQLConnection:
+		applyFlag(set, "host", &c.Host, f.host)
+		applyFlag(set, "port", &c.Port, f.port)
+		applyFlag(set, "user", &c.Username, f.user)
+		applyFlag(set, "database", &c.Database, f.database)
+		applyFlag(set, "ssl-mode", &c.SSLMode, f.sslMode)
+		applyFlag(set, "replicas", &c.Replicas, connection.ParseReplicas(f.replicas))
+	case *connection.OracleConnection:
+		applyFlag(set, "host", &c.Host, f.host)
+		applyFlag(set, "port", &c.Port, f.port)
+		applyFlag(set, "user", &c.Username, f.user)
+		applyFlag(set, "service-name", &c.ServiceName, f.serviceName)
+		applyFlag(set, "sid", &c.SID, f.sid)
+		applyFlag(set, "protocol", &c.Protocol, f.protocol)
+		applyFlag(set, "wallet-dir", &c.WalletDir, f.walletDir)
+		applyFlag(set, "trust-server-cert", &c.TrustServerCertificate, f.trustServerCert)
+	case *connection.SQLServerConnection:
+		applyFlag(set, "host", &c.Host, f.host)
+		applyFlag(set, "port", &c.Port, f.port)
+		applyFlag(set, "user", &c.Username, f.user)
+		applyFlag(set, "database", &c.Database, f.database)
+		applyFlag(set, "trust-server-cert", &c.TrustServerCertificate, f.trustServerCert)
+		applyFlag(set, "instance", &c.Instance, f.instance)
+		applyFlag(set, "encrypt", &c.Encrypt, f.encrypt)
+		applyFlag(set, "read-only-intent", &c.ReadOnlyIntent, f.readOnlyIntent)
+		applyFlag(set, "auth", &c.Authentication, f.auth)
+	}
+	applyOptions(conn, f.options)
+
+	if f.passwordStdin {
+		setConnectionPassword(conn, readPasswordStdin())
+	}
+	warnDSN(conn)
+
+	if err := connUC.UpdateConnection(ctx, conn); err != nil {
+		slog.Error("Edit connection failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to update connection: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Connection updated: %s (%s)\n", conn.GetName(), conn.GetID())
+}
+
+// fieldAssignment is a --field NAME=VALUE flag of connection set.
+type fieldAssignment struct {
+	name  string
+	value string
+}
+
+// fieldList collects repeated --field flags.
+type fieldList []fieldAssignment
+
+func (l *fieldList) String() string {
+	parts := make([]string, len(*l))
+	for i, f := range *l {
+		parts[i] = f.name + "=" + f.value
+	}
+	return strings.Join(parts, ",")
+}
+
+func (l *fieldList) Set(value string) error {
+	name, v, ok := strings.Cut(value, "=")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("want NAME=VALUE, got %q", value)
+	}
+	*l = append(*l, fieldAssignment{name: strings.TrimSpace(name), value: v})
+	return nil
+}
+
+// parseSingleArg parses fs from args and returns the one positional
+// argument, which may come before or after the flags. Exits with usage otherwise.
+func parseSingleArg(fs *flag.FlagSet, args []string, usage string) string {
+	var arg string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		arg, args = args[0], args[1:]
+	}
+	fs.Parse(args)
+	if arg == "" && fs.NArg() == 1 {
+		arg = fs.Arg(0)
+	} else if arg == "" || fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(1)
+	}
+	return arg
+}
+
+// connectionSet sets fields of a saved connection by their stored names,
+// e.g. --field host=10.0.0.6 --field ssl_mode=disable or --field ssh.enabled=true.
+// Without --field it lists the settable fields of the connection.
+func connectionSet(args []string) {
+	fs := flag.NewFlagSet("connection set", flag.ExitOnError)
+	var fields fieldList
+	fs.Var(&fields, "field", "Set a field: NAME=VALUE (repeatable)")
+	passwordStdin := fs.Bool("password-stdin", false, "Read the database password from stdin")
+
+	ref := parseSingleArg(fs, args, "Usage: db-benchmind connection set NAME|ID [--field NAME=VALUE]... [--password-stdin]")
+
+	ctx := context.Background()
+	connUC, closeDB := openConnectionUseCase(ctx)
+	defer closeDB()
+
+	conn := mustFindConnection(ctx, connUC, ref)
+	if len(fields) == 0 && !*passwordStdin {
+		fmt.Printf("Settable fields of %s (%s):\n", conn.GetName(), conn.GetType())
+		for _, name := range connection.Fields(conn) {
+			fmt.Printf("  %s\n", name)
+		}
+		return
+	}
+
+	slog.Info("Setting connection fields", "command", "connection set", "connection", ref, "fields", fields.String())
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if err := connection.SetField(conn, f.name, f.value); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		names = append(names, f.name)
+	}
+	if *passwordStdin {
+		setConnectionPassword(conn, readPasswordStdin())
+		names = append(names, "password")
+	}
+
+	// The use case validates the connection before saving it
+	if err := connUC.UpdateConnection(ctx, conn); err != nil {
+		slog.Error("Set connection fields failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to update connection: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Connection updated: %s (%s), set %s\n", conn.GetName(), conn.GetID(), strings.Join(names, ", "))
+}
+
+func connectionDelete(args []string) {
+	fs := flag.NewFlagSet("connection delete", flag.ExitOnError)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: db-benchmind connection delete NAME|ID")
+		os.Exit(1)
+	}
+
+	slog.Info("Deleting connection", "command", "connection delete", "connection", fs.Arg(0))
+	ctx := context.Background()
+	connUC, closeDB := openConnectionUseCase(ctx)
+	defer closeDB()
+
+	conn := mustFindConnection(ctx, connUC, fs.Arg(0))
+	if err := connUC.DeleteConnection(ctx, conn.GetID()); err != nil {
+		slog.Error("Delete connection failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to delete connection: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Connection deleted: %s (%s)\n", conn.GetName(), conn.GetID())
+}
+
+// openConnectionUseCase opens the database and keyring and returns a connection use case.
+// The returned function closes the database.
+func openConnectionUseCase(ctx context.Context) (*usecase.ConnectionUseCase, func()) {
+	db := openDatabase(ctx)
+	connRepo := repository.NewSQLiteConnectionRepository(db)
+	connUC := usecase.NewConnectionUseCase(connRepo, openKeyring(ctx))
+	connUC.SetAccessControl(openAccess())
+	return connUC, func() { db.Close() }
+}
+
+// findConnection returns the saved connection whose ID or name is ref, with its passwords loaded.
+func findConnection(ctx context.Context, connUC *usecase.ConnectionUseCase, ref string) (connection.Connection, error) {
+	conns, err := connUC.ListConnections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list connections: %w", err)
+	}
+	for _, conn := range conns {
+		if conn.GetID() == ref || conn.GetName() == ref {
+			return connUC.GetConnectionByID(ctx, conn.GetID())
+		}
+	}
+	return nil, fmt.Errorf("connection not found: %s", ref)
+}
+
+// mustFindConnection is findConnection that exits on error.
+func mustFindConnection(ctx context.Context, connUC *usecase.ConnectionUseCase, ref string) connection.Connection {
+	conn, err := findConnection(ctx, connUC, ref)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return conn
+}
+
+// setFlags returns the names of the flags given on the command line.
+func setFlags(fs *flag.FlagSet) map[string]bool {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	return set
+}
+
+// applyFlag sets *field to value if the flag was given.
+func applyFlag[T any](set map[string]bool, name string, field *T, value T) {
+	if set[name] {
+		*field = value
+	}
+}
+
+// portOrDefault returns port, or def if port is not set.
+func portOrDefault(port, def int) int {
+	if port == 0 {
+		return def
+	}
+	return port
+}
+
+// applyOptions sets the --option flags as additional connection parameters,
+// removing those with an empty value. Only PostgreSQL connections have them.
+func applyOptions(conn connection.Connection, options fieldList) {
+	if len(options) == 0 {
+		return
+	}
+	if conn.GetType() != connection.DatabaseTypePostgreSQL {
+		fmt.Fprintln(os.Stderr, "Error: --option is only supported for PostgreSQL connections")
+		os.Exit(1)
+	}
+	for _, o := range options {
+		if err := connection.SetField(conn, "options."+o.name, o.value); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --option %s: %v\n", o.name, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// setConnectionPassword sets the database password on a connection.
+func setConnectionPassword(conn connection.Connection, password string) {
+	switch c := conn.(type) {
+	case *connection.MySQLConnection:
+		c.Password = password
+	case *connection.PostgreSQLConnection:
+		c.Password = password
+	case *connection.OracleConnection:
+		c.Password = password
+	case *connection.SQLServerConnection:
+		c.Password = password
+	}
+}
+
+// warnDSN warns about username and password characters that the connection
+// string of conn does not escape. The connection is saved anyway.
+func warnDSN(conn connection.Connection) {
+	for _, w := range connection.CheckDSN(conn) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+}
+
+// readPasswordStdin reads a password from stdin, without the trailing newline.
+func readPasswordStdin() string {
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to read password from stdin: %v\n", err)
+		os.Exit(1)
+	}
+	password := strings.TrimRight(string(data), "\r\n")
+	if password == "" {
+		fmt.Fprintln(os.Stderr, "Error: Empty password on stdin")
+		os.Exit(1)
+	}
+	return password
+}
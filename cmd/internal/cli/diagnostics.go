@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/whhaicheng/DB-BenchMind/cmd/internal/wiring"
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+)
+
+// diagnosticsCommand writes a diagnostics bundle to attach to bug reports.
+// A database that fails to open is left out rather than failing the bundle,
+// as it may be the problem being reported.
+func diagnosticsCommand(args []string) {
+	fs := flag.NewFlagSet("diagnostics", flag.ExitOnError)
+	output := fs.String("output", "", "Bundle file (default: db-benchmind-diagnostics-TIMESTAMP.zip in the export directory)")
+	fs.Parse(args)
+
+	ctx := context.Background()
+	diagnosticsUC := usecase.NewDiagnosticsUseCase(dirs.Home, dirs.LogDir(), dirs.ExportDir(), wiring.NewSettingsUseCase(dirs))
+	diagnosticsUC.SetAppVersion(Version)
+	if db, err := database.InitializeSQLite(ctx, dirs.DBPath()); err != nil {
+		slog.Warn("Diagnostics: Database left out", "error", err)
+		fmt.Fprintf(os.Stderr, "Warning: database left out of the bundle: %v\n", err)
+	} else {
+		defer db.Close()
+		diagnosticsUC.SetDatabase(db)
+		diagnosticsUC.SetHistoryUseCase(usecase.NewHistoryUseCase(repository.NewSQLiteHistoryRepository(db)))
+	}
+
+	dest := *output
+	if dest == "" {
+		dest = diagnosticsUC.BundlePath(ctx)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to create directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	slog.Info("Collecting diagnostics", "command", "diagnostics", "file", dest)
+	system, err := diagnosticsUC.Collect(ctx, dest)
+	if err != nil {
+		slog.Error("Collect diagnostics failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to collect diagnostics: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Diagnostics bundle written: %s\n", dest)
+	fmt.Printf("  Log files:     %d\n", len(system.LogFiles))
+	fmt.Printf("  Crash reports: %d\n", len(system.CrashReports))
+	fmt.Printf("  Recent runs:   %d\n", system.Runs)
+	if system.SchemaVersion > 0 {
+		fmt.Printf("  Schema:        v%d\n", system.SchemaVersion)
+	}
+	fmt.Println("Passwords, webhook URL paths and secret run parameters are left out. Check the logs before sharing the bundle.")
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	sqliterepo "github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/tool"
+)
+
+// exportCommand exports the history records matching the filters, e.g. from
+// cron for periodic report extraction. Also run as "history export".
+func exportCommand(args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	var tags tagList
+	fs.Var(&tags, "tag", "Only export records with this tag (repeatable, or comma separated)")
+	format := fs.String("format", "txt", "Export format: txt, markdown or csv (one table of all records)")
+	since := fs.String("since", "", "Only export runs started on or after this date (2006-01-02, RFC 3339, or an age such as 7d)")
+	until := fs.String("until", "", "Only export runs started on or before this date (2006-01-02 includes the whole day)")
+	connName := fs.String("connection", "", "Only export runs of this connection")
+	templateName := fs.String("template", "", "Only export runs of this template")
+	validOnly := fs.Bool("valid-only", false, "Skip runs that failed their sanity checks")
+	outDir := fs.String("out", "", "Export directory (default: the export directory in the settings)")
+	fileName := fs.String("name", "", "File name template of txt and markdown exports, e.g. {connection}_{template}_{date} (default: the settings)")
+	fs.Parse(args)
+
+	var exportFormat usecase.ExportFormat
+	switch strings.ToLower(*format) {
+	case "txt":
+		exportFormat = usecase.FormatTXT
+	case "markdown", "md":
+		exportFormat = usecase.FormatMarkdown
+	case "csv":
+		exportFormat = usecase.FormatCSV
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Unsupported format: %s\n", *format)
+		os.Exit(1)
+	}
+
+	opts := &repository.ListOptions{
+		ConnectionName: *connName,
+		TemplateName:   *templateName,
+		Tags:           tags,
+		ValidOnly:      *validOnly,
+	}
+	if *since != "" {
+		t, err := parseExportTime(*since, false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --since: %v\n", err)
+			os.Exit(1)
+		}
+		opts.StartTimeAfter = &t
+	}
+	if *until != "" {
+		t, err := parseExportTime(*until, true)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --until: %v\n", err)
+			os.Exit(1)
+		}
+		opts.StartTimeBefore = &t
+	}
+
+	slog.Info("Exporting history", "command", "export", "format", exportFormat, "since", opts.StartTimeAfter,
+		"until", opts.StartTimeBefore, "connection", opts.ConnectionName, "template", opts.TemplateName, "tags", tags)
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	historyUC := usecase.NewHistoryUseCase(sqliterepo.NewSQLiteHistoryRepository(db))
+
+	records, err := historyUC.ListRecords(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to list history: %v\n", err)
+		os.Exit(1)
+	}
+	if len(records) == 0 {
+		fmt.Println("No history records to export.")
+		return
+	}
+
+	settingsUC := usecase.NewSettingsUseCase(sqliterepo.NewSettingsRepository(dirs.ConfigPath()), tool.NewDetector())
+	exportCfg, err := settingsUC.GetExportConfig(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to load export settings: %v\n", err)
+		os.Exit(1)
+	}
+	if *outDir != "" {
+		if exportCfg.Dir, err = filepath.Abs(*outDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *fileName != "" {
+		exportCfg.FileName = *fileName
+	}
+	if err := exportCfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	exportUC := usecase.NewExportUseCase(dirs.ExportDir())
+	exportUC.SetArtifactDir(dirs.RunsDir())
+	exportUC.SetExportConfig(func(context.Context) (*config.ExportConfig, error) { return exportCfg, nil })
+
+	if exportFormat == usecase.FormatCSV {
+		path, err := exportUC.ExportCSV(ctx, records)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Exported %d record(s) to %s\n", len(records), path)
+		return
+	}
+
+	count, dir, err := exportUC.ExportAllRecords(ctx, records, exportFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	fmt.Printf("Exported %d record(s) to %s\n", count, dir)
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+// parseExportTime parses the --since and --until dates: a date in local time,
+// an RFC 3339 time, or an age before now such as 7d. A date is the end of the
+// day if endOfDay is set, otherwise its start.
+func parseExportTime(s string, endOfDay bool) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if age, err := history.ParseAge(s); err == nil {
+		return time.Now().Add(-age), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: use 2006-01-02, RFC 3339 or an age such as 7d", s)
+}
@@ -0,0 +1,623 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database"
+	sqliterepo "github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/tool"
+)
+
+// tagList is a repeatable flag that also accepts comma separated tags.
+type tagList []string
+
+func (t *tagList) String() string {
+	return strings.Join(*t, ",")
+}
+
+func (t *tagList) Set(value string) error {
+	*t = append(*t, history.ParseTags(value)...)
+	return nil
+}
+
+func historyCommand(args []string) {
+	if len(args) == 0 {
+		fmt.Println("Usage: db-benchmind history <list|annotate|validate|config|aggregates|races|trends|export|purge> [options]")
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "list":
+		historyList(args[1:])
+	case "annotate":
+		historyAnnotate(args[1:])
+	case "validate":
+		historyValidate(args[1:])
+	case "config":
+		historyConfig(args[1:])
+	case "aggregates":
+		historyAggregates(args[1:])
+	case "races":
+		historyRaces(args[1:])
+	case "trends":
+		historyTrends(args[1:])
+	case "export":
+		exportCommand(args[1:])
+	case "purge":
+		historyPurge(args[1:])
+	default:
+		fmt.Printf("Unknown history command: %s\n", args[0])
+		os.Exit(1)
+	}
+}
+
+func historyList(args []string) {
+	fs := flag.NewFlagSet("history list", flag.ExitOnError)
+	var tags tagList
+	fs.Var(&tags, "tag", "Only show records with this tag (repeatable, or comma separated)")
+	search := fs.String("search", "", "Only records whose names, purpose, ticket or environment contain this text")
+	validOnly := fs.Bool("valid-only", false, "Skip runs that failed their sanity checks")
+	fs.Parse(args)
+
+	slog.Info("Listing history", "command", "history list", "tags", tags, "search", *search, "valid_only", *validOnly)
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	historyUC := usecase.NewHistoryUseCase(sqliterepo.NewSQLiteHistoryRepository(db))
+
+	records, err := historyUC.ListRecords(ctx, &repository.ListOptions{Tags: tags, Search: *search, ValidOnly: *validOnly})
+	if err != nil {
+		slog.Error("List history failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to list history: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(records) == 0 {
+		fmt.Println("No history records found.")
+		return
+	}
+
+	fmt.Printf("\nFound %d record(s):\n", len(records))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for i, record := range records {
+		fmt.Printf("\n[%d] %s | %s | %d threads | %.2f TPS\n", i+1, record.ConnectionName, record.TemplateName, record.Threads, record.TPSCalculated)
+		fmt.Printf("    ID:    %s\n", record.ID)
+		fmt.Printf("    Start: %s\n", record.StartTime.Format("2006-01-02 15:04:05"))
+		if len(record.Agents) > 0 {
+			fmt.Printf("    Agents: %s\n", strings.Join(record.Agents, ", "))
+		}
+		if record.Purpose != "" {
+			fmt.Printf("    Purpose: %s\n", record.Purpose)
+		}
+		if record.Ticket != "" {
+			fmt.Printf("    Ticket: %s\n", record.Ticket)
+		}
+		if record.Environment != "" {
+			fmt.Printf("    Environment: %s\n", record.Environment)
+		}
+		if len(record.Tags) > 0 {
+			fmt.Printf("    Tags:  %s\n", strings.Join(record.Tags, ", "))
+		}
+		if record.Notes != "" {
+			fmt.Printf("    Notes: %s\n", record.Notes)
+		}
+		if !record.IsValid() {
+			fmt.Printf("    Invalid: failed %s\n", strings.Join(record.Validity.Failed(), ", "))
+		}
+		if record.Assertions != nil {
+			fmt.Printf("    SLA:   %s\n", record.Assertions)
+		}
+	}
+	fmt.Println("\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+}
+
+func historyValidate(args []string) {
+	fs := flag.NewFlagSet("history validate", flag.ExitOnError)
+	fs.Parse(args)
+
+	slog.Info("Validating history", "command", "history validate", "ids", fs.Args())
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	historyUC := usecase.NewHistoryUseCase(sqliterepo.NewSQLiteHistoryRepository(db))
+	settingsUC := usecase.NewSettingsUseCase(sqliterepo.NewSettingsRepository(dirs.ConfigPath()), tool.NewDetector())
+	historyUC.SetSanityChecks(settingsUC.GetSanityChecks)
+
+	checks, err := settingsUC.GetSanityChecks(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to load sanity checks: %v\n", err)
+		os.Exit(1)
+	}
+	if len(checks) == 0 {
+		fmt.Println("No sanity checks configured; all runs count as valid.")
+	}
+
+	// Without IDs, re-evaluate every record, e.g. after changing the checks
+	if fs.NArg() == 0 {
+		invalid, err := historyUC.EvaluateAllRecords(ctx)
+		if err != nil {
+			slog.Error("Validate history failed", "error", err)
+			fmt.Fprintf(os.Stderr, "Error: Failed to validate history: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("All records checked, %d invalid.\n", invalid)
+		return
+	}
+
+	for _, id := range fs.Args() {
+		validity, err := historyUC.EvaluateRecord(ctx, id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Failed to validate record %s: %v\n", id, err)
+			os.Exit(1)
+		}
+		if validity == nil {
+			fmt.Printf("%s: unchecked\n", id)
+			continue
+		}
+		status := "valid"
+		if !validity.Valid {
+			status = "INVALID"
+		}
+		fmt.Printf("%s: %s\n", id, status)
+		for _, r := range validity.Results {
+			mark := "✓"
+			if !r.Passed {
+				mark = "✗"
+			}
+			fmt.Printf("    %s %s (%s): %.2f%s, threshold %g%s", mark, r.Name, r.Kind, r.Value, r.Kind.Unit(), r.Threshold, r.Kind.Unit())
+			if r.Passed && r.Details != "" {
+				fmt.Printf(", %s", r.Details)
+			}
+			fmt.Println()
+		}
+	}
+}
+
+func historyConfig(args []string) {
+	fs := flag.NewFlagSet("history config", flag.ExitOnError)
+	all := fs.Bool("all", false, "Print every setting, not only the server summary")
+	fs.Parse(args)
+
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fmt.Println("Usage: db-benchmind history config [--all] ID [ID2]")
+		os.Exit(1)
+	}
+
+	slog.Info("Showing configuration snapshot", "command", "history config", "ids", fs.Args())
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	historyUC := usecase.NewHistoryUseCase(sqliterepo.NewSQLiteHistoryRepository(db))
+
+	var snapshots []*dbconfig.Snapshot
+	for _, id := range fs.Args() {
+		record, err := historyUC.GetRecordByID(ctx, id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Failed to get record %s: %v\n", id, err)
+			os.Exit(1)
+		}
+		if record.ConfigSnapshot == nil {
+			fmt.Fprintf(os.Stderr, "Error: No configuration snapshot was captured for record %s\n", id)
+			os.Exit(1)
+		}
+		snapshots = append(snapshots, record.ConfigSnapshot)
+	}
+
+	if len(snapshots) == 1 {
+		snapshot := snapshots[0]
+		fmt.Printf("%s\n", snapshot.Summary())
+		fmt.Printf("Captured: %s\n", snapshot.CapturedAt.Format("2006-01-02 15:04:05"))
+		for _, e := range snapshot.Errors {
+			fmt.Printf("Not captured: %s\n", e)
+		}
+		if *all {
+			names := make([]string, 0, len(snapshot.Settings))
+			for name := range snapshot.Settings {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			fmt.Println()
+			for _, name := range names {
+				fmt.Printf("%s = %s\n", name, snapshot.Settings[name])
+			}
+		}
+		return
+	}
+
+	changes := dbconfig.Diff(snapshots[0], snapshots[1])
+	if len(changes) == 0 {
+		fmt.Println("No configuration differences.")
+		return
+	}
+	fmt.Printf("%d difference(s) between %s and %s:\n\n", len(changes), fs.Arg(0), fs.Arg(1))
+	for _, change := range changes {
+		fmt.Printf("%s: %s -> %s\n", change.Name, displaySetting(change.Before), displaySetting(change.After))
+	}
+}
+
+// displaySetting marks settings that exist in one snapshot only.
+func displaySetting(value string) string {
+	if value == "" {
+		return "(unset)"
+	}
+	return value
+}
+
+func historyAnnotate(args []string) {
+	fs := flag.NewFlagSet("history annotate", flag.ExitOnError)
+	var tags tagList
+	fs.Var(&tags, "tag", "Set tag (repeatable, or comma separated); replaces existing tags")
+	notes := fs.String("notes", "", "Set free-text notes")
+	clearTags := fs.Bool("clear-tags", false, "Remove all tags")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Println("Usage: db-benchmind history annotate [--tag T]... [--notes TEXT] [--clear-tags] <record-id>")
+		os.Exit(1)
+	}
+	id := fs.Arg(0)
+
+	// Only change what was given on the command line
+	setFlags := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	slog.Info("Annotating history record", "command", "history annotate", "id", id)
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	historyUC := usecase.NewHistoryUseCase(sqliterepo.NewSQLiteHistoryRepository(db))
+
+	record, err := historyUC.GetRecordByID(ctx, id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to load record %s: %v\n", id, err)
+		os.Exit(1)
+	}
+
+	newTags := record.Tags
+	if setFlags["tag"] {
+		newTags = tags
+	}
+	if *clearTags {
+		newTags = nil
+	}
+	newNotes := record.Notes
+	if setFlags["notes"] {
+		newNotes = *notes
+	}
+
+	if err := historyUC.UpdateAnnotations(ctx, id, newTags, newNotes); err != nil {
+		slog.Error("Annotate history failed", "id", id, "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to update record: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Updated record %s\n", id)
+}
+
+// historyAggregates lists the aggregates of repeated tasks, or shows one in detail.
+func historyAggregates(args []string) {
+	if len(args) > 1 {
+		fmt.Println("Usage: db-benchmind history aggregates [aggregate-id]")
+		os.Exit(1)
+	}
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	aggregateRepo := sqliterepo.NewSQLiteAggregateRepository(db)
+
+	if len(args) == 1 {
+		agg, err := aggregateRepo.GetAggregate(ctx, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Failed to load aggregate %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+		printAggregate(agg)
+		return
+	}
+
+	slog.Info("Listing aggregates", "command", "history aggregates")
+	aggregates, err := aggregateRepo.ListAggregates(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to list aggregates: %v\n", err)
+		os.Exit(1)
+	}
+	if len(aggregates) == 0 {
+		fmt.Println("No aggregates found. Set Repeat above 1 on a task to create one.")
+		return
+	}
+
+	fmt.Printf("\nFound %d aggregate(s):\n", len(aggregates))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, agg := range aggregates {
+		fmt.Printf("%s  %-30s %d/%d runs  %.2f ± %.2f TPS (CV %.1f%%)  %s\n",
+			agg.CreatedAt.Format("2006-01-02 15:04"), agg.Name, len(agg.RunIDs), agg.Repeat,
+			agg.TPS.Mean, agg.TPS.StdDev, agg.TPS.CV*100, agg.ID)
+	}
+}
+
+// printAggregate prints the statistics of a repeated task.
+func printAggregate(agg *history.Aggregate) {
+	fmt.Printf("Aggregate: %s\n", agg.ID)
+	fmt.Printf("Task:      %s (%s, %s, %d threads)\n", agg.Name, agg.ConnectionName, agg.TemplateName, agg.Threads)
+	fmt.Printf("Created:   %s\n", agg.CreatedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Runs:      %d of %d completed, outliers outside ±%.1fσ of TPS excluded\n", len(agg.RunIDs), agg.Repeat, agg.OutlierSigma)
+	fmt.Println()
+	fmt.Printf("%-18s %12s %12s %8s %12s %12s\n", "Metric", "Mean", "StdDev", "CV", "Min", "Max")
+	for _, m := range []struct {
+		name  string
+		stats history.MetricStats
+	}{
+		{"TPS", agg.TPS},
+		{"QPS", agg.QPS},
+		{"Latency avg (ms)", agg.LatencyAvg},
+		{"Latency p95 (ms)", agg.LatencyP95},
+		{"Latency p99 (ms)", agg.LatencyP99},
+	} {
+		fmt.Printf("%-18s %12.2f %12.2f %7.1f%% %12.2f %12.2f\n",
+			m.name, m.stats.Mean, m.stats.StdDev, m.stats.CV*100, m.stats.Min, m.stats.Max)
+	}
+	fmt.Println()
+	for i, id := range agg.RunIDs {
+		mark := ""
+		if agg.IsOutlier(id) {
+			mark = "  (outlier)"
+		}
+		fmt.Printf("  #%d %s%s\n", i+1, id, mark)
+	}
+	fmt.Printf("History:   db-benchmind history list --tag %s\n", agg.Tag())
+}
+
+// historyRaces lists the A/B races, or compares the two runs of one.
+func historyRaces(args []string) {
+	if len(args) > 1 {
+		fmt.Println("Usage: db-benchmind history races [race-id]")
+		os.Exit(1)
+	}
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	raceRepo := sqliterepo.NewSQLiteRaceRepository(db)
+
+	if len(args) == 1 {
+		race, err := raceRepo.GetRace(ctx, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Failed to load race %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+		printRace(race)
+		return
+	}
+
+	slog.Info("Listing races", "command", "history races")
+	races, err := raceRepo.ListRaces(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to list races: %v\n", err)
+		os.Exit(1)
+	}
+	if len(races) == 0 {
+		fmt.Println("No races found. Select a connection under Race Against on the Tasks page to start one.")
+		return
+	}
+
+	fmt.Printf("\nFound %d race(s):\n", len(races))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, race := range races {
+		winner := race.Winner()
+		if winner == "" {
+			winner = "-"
+		}
+		fmt.Printf("%s  %-20s vs %-20s %10.2f vs %-10.2f TPS  winner %s  %s\n",
+			race.CreatedAt.Format("2006-01-02 15:04"), race.A.ConnectionName, race.B.ConnectionName,
+			race.A.TPS, race.B.TPS, winner, race.ID)
+	}
+}
+
+// printRace prints the metrics of both runs of a race.
+func printRace(race *history.Race) {
+	fmt.Printf("Race:      %s\n", race.ID)
+	fmt.Printf("Task:      %s (%s, %d threads)\n", race.Name, race.TemplateName, race.Threads)
+	fmt.Printf("Created:   %s\n", race.CreatedAt.Format("2006-01-02 15:04:05"))
+	for _, side := range []struct {
+		name string
+		side history.RaceSide
+	}{{"A", race.A}, {"B", race.B}} {
+		state := "completed"
+		if !side.side.Completed() {
+			state = side.side.State
+			if side.side.ErrorMessage != "" {
+				state += ": " + side.side.ErrorMessage
+			}
+		}
+		fmt.Printf("%s:         %s (%s), run %s, %s\n", side.name, side.side.ConnectionName, side.side.DatabaseType, side.side.RunID, state)
+	}
+	fmt.Println()
+	fmt.Printf("%-18s %12s %12s %10s\n", "Metric", "A", "B", "B vs A")
+	for _, m := range []struct {
+		name string
+		a, b float64
+	}{
+		{"TPS", race.A.TPS, race.B.TPS},
+		{"QPS", race.A.QPS, race.B.QPS},
+		{"Latency avg (ms)", race.A.LatencyAvg, race.B.LatencyAvg},
+		{"Latency p95 (ms)", race.A.LatencyP95, race.B.LatencyP95},
+		{"Error rate (%)", race.A.ErrorRate, race.B.ErrorRate},
+	} {
+		delta := "-"
+		if m.a != 0 {
+			delta = fmt.Sprintf("%+.1f%%", (m.b-m.a)/m.a*100)
+		}
+		fmt.Printf("%-18s %12.2f %12.2f %10s\n", m.name, m.a, m.b, delta)
+	}
+	fmt.Println()
+	switch winner := race.Winner(); {
+	case winner != "":
+		fmt.Printf("Winner:    %s\n", winner)
+	case race.Completed():
+		fmt.Println("Winner:    tie (TPS within 1%)")
+	}
+	fmt.Printf("History:   db-benchmind history list --tag %s\n", race.Tag())
+}
+
+// historyTrends lists the trends of recurring runs, or shows the runs of one.
+func historyTrends(args []string) {
+	if len(args) > 1 {
+		fmt.Println("Usage: db-benchmind history trends [fingerprint]")
+		os.Exit(1)
+	}
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	historyUC := usecase.NewHistoryUseCase(sqliterepo.NewSQLiteHistoryRepository(db))
+
+	slog.Info("Listing trends", "command", "history trends")
+	trends, err := historyUC.ListTrends(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to list trends: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(args) == 1 {
+		for _, trend := range trends {
+			if trend.Fingerprint == args[0] {
+				printTrend(trend)
+				return
+			}
+		}
+		fmt.Fprintf(os.Stderr, "Error: No trend with fingerprint %s\n", args[0])
+		os.Exit(1)
+	}
+
+	if len(trends) == 0 {
+		fmt.Printf("No trends found. A configuration needs %d completed runs to form one.\n", history.MinTrendRuns)
+		return
+	}
+	fmt.Printf("\nFound %d trend(s):\n", len(trends))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, trend := range trends {
+		fmt.Printf("%s  %-50s %d runs", trend.Fingerprint, trend.Label(), len(trend.Points))
+		if dev, ok := trend.LatestDeviation(); ok {
+			fmt.Printf("  latest: TPS %+.1f%%, p95 %+.1f%%", dev.TPSPercent, dev.LatencyP95Percent)
+		}
+		if n := len(trend.ChangePointTimes()); n > 0 {
+			fmt.Printf("  %d change point(s)", n)
+		}
+		fmt.Println()
+	}
+}
+
+// printTrend prints the runs of a trend with their rolling means and change points.
+func printTrend(trend *history.Trend) {
+	fmt.Printf("Trend:     %s\n", trend.Fingerprint)
+	fmt.Printf("Config:    %s (%s)\n", trend.Label(), trend.DatabaseType)
+	if dev, ok := trend.LatestDeviation(); ok {
+		fmt.Printf("Latest:    TPS %+.1f%%, p95 %+.1f%% against the mean of the %d runs before it\n",
+			dev.TPSPercent, dev.LatencyP95Percent, dev.Baseline)
+	}
+	fmt.Println()
+	fmt.Printf("%-16s %12s %12s %12s %12s\n", "Start", "TPS", "TPS mean", "p95 (ms)", "p95 mean")
+	for _, p := range trend.Points {
+		mark := ""
+		if p.ChangePoint {
+			mark = "  <- change point"
+		}
+		fmt.Printf("%-16s %12.2f %12.2f %12.2f %12.2f  %s%s\n", p.StartTime.Format("2006-01-02 15:04"),
+			p.TPS, p.TPSMean, p.LatencyP95, p.LatencyP95Mean, p.RecordID, mark)
+	}
+	fmt.Printf("\nRolling means over %d runs\n", trend.Window)
+}
+
+func historyPurge(args []string) {
+	fs := flag.NewFlagSet("history purge", flag.ExitOnError)
+	olderThan := fs.String("older-than", "", "Purge records older than this age (e.g. 90d, 12w, 720h)")
+	keep := fs.Int("keep", 0, "Keep only the newest N records")
+	archiveDir := fs.String("archive", defaultArchiveDir(), "Archive purged records to this directory first")
+	noArchive := fs.Bool("no-archive", false, "Delete without archiving")
+	dryRun := fs.Bool("dry-run", false, "Only show what would be purged")
+	fs.Parse(args)
+
+	opts := &usecase.PurgeOptions{KeepLatest: *keep, DryRun: *dryRun}
+	if *olderThan != "" {
+		age, err := history.ParseAge(*olderThan)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts.OlderThan = age
+	}
+	if opts.OlderThan == 0 && opts.KeepLatest <= 0 {
+		fmt.Println("Usage: db-benchmind history purge --older-than 90d [--keep N] [--archive DIR | --no-archive] [--dry-run]")
+		os.Exit(1)
+	}
+	if !*noArchive {
+		opts.ArchiveDir = *archiveDir
+	}
+
+	slog.Info("Purging history", "command", "history purge", "older_than", opts.OlderThan, "keep", opts.KeepLatest, "dry_run", opts.DryRun)
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	historyUC := usecase.NewHistoryUseCase(sqliterepo.NewSQLiteHistoryRepository(db))
+	historyUC.SetArtifactDir(dirs.RunsDir())
+	historyUC.SetLogRepository(sqliterepo.NewSQLiteRunLogRepository(db))
+
+	result, err := historyUC.PurgeRecords(ctx, opts)
+	if err != nil {
+		slog.Error("Purge history failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to purge history: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(result.Records) == 0 {
+		fmt.Println("No history records to purge.")
+		return
+	}
+
+	if opts.DryRun {
+		fmt.Printf("\n%d record(s) would be purged:\n", len(result.Records))
+		for _, record := range result.Records {
+			fmt.Printf("  %s  %s | %s | %s\n", record.StartTime.Format("2006-01-02 15:04:05"), record.ID, record.ConnectionName, record.TemplateName)
+		}
+		return
+	}
+
+	if result.ArchivePath != "" {
+		fmt.Printf("Archived %d record(s) to %s\n", len(result.Records), result.ArchivePath)
+	}
+	fmt.Printf("Purged %d record(s)\n", result.Purged)
+}
+
+// defaultArchiveDir returns the archive directory from the default configuration.
+func defaultArchiveDir() string {
+	return config.DefaultConfig().History.ArchiveDir
+}
+
+// openDatabase opens the application database or exits on failure.
+func openDatabase(ctx context.Context) *sql.DB {
+	db, err := database.InitializeSQLite(ctx, dirs.DBPath())
+	if err != nil {
+		slog.Error("Database init failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to initialize database: %v\n", err)
+		os.Exit(1)
+	}
+	return db
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/tool"
+)
+
+// installCommand installs a benchmark tool. Portable builds are downloaded
+// into the tools directory and their path is saved; for tools that come from
+// the package manager the commands are printed for the user to run.
+func installCommand(args []string) {
+	fs := flag.NewFlagSet("install", flag.ExitOnError)
+	url := fs.String("url", "", "Download the build from this archive (.tar.gz or .zip)")
+	sha := fs.String("sha256", "", "Expected SHA-256 checksum of the archive")
+	name := parseSingleArg(fs, args, "Usage: db-benchmind install TOOL [--url URL] [--sha256 HEX]")
+
+	toolType := config.ToolType(name)
+	if err := toolType.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	settingsRepo := repository.NewSettingsRepository(dirs.ConfigPath())
+	settingsUC := usecase.NewSettingsUseCase(settingsRepo, tool.NewDetector())
+	settingsUC.SetToolsDir(dirs.ToolsDir())
+
+	plan := settingsUC.ToolInstallPlan(toolType)
+	if *url != "" {
+		plan = tool.DownloadPlan(toolType, *url, *sha)
+		if plan.AllowUnverified {
+			fmt.Fprintln(os.Stderr, "Warning: no --sha256 given, the archive is not verified")
+		}
+	}
+	if plan.Method != tool.InstallDownload {
+		printInstallPlan(plan)
+		return
+	}
+
+	slog.Info("Installing tool", "command", "install", "tool", toolType, "url", plan.URL)
+	fmt.Printf("Downloading %s from %s...\n", toolType, plan.URL)
+	toolCfg, err := settingsUC.InstallTool(context.Background(), plan)
+	if err != nil {
+		slog.Error("Install tool failed", "tool", toolType, "error", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✓ %s %s installed\n", toolType, toolCfg.Version)
+	fmt.Printf("  Path: %s\n", toolCfg.Path)
+}
+
+// printInstallPlan prints how to install a tool.
+func printInstallPlan(plan *tool.InstallPlan) {
+	switch plan.Method {
+	case tool.InstallDownload:
+		fmt.Printf("  %s: db-benchmind install %s\n", plan.Type, plan.Type)
+	case tool.InstallPackage:
+		fmt.Printf("  %s:\n", plan.Type)
+		for _, cmd := range plan.Commands {
+			fmt.Printf("    %s\n", cmd)
+		}
+	default:
+		fmt.Printf("  %s: %s\n", plan.Type, plan.Note)
+	}
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/keyring"
+)
+
+// envMasterPassword supplies the keyring master password non-interactively.
+const envMasterPassword = "DB_BENCHMIND_MASTER_PASSWORD"
+
+// openKeyring opens the keyring, asking for the master password if the file
+// fallback is locked. Exits on failure.
+func openKeyring(ctx context.Context) *keyring.GoKeyring {
+	k := keyring.NewGoKeyring(dirs.DataDir())
+	if !k.Available(ctx) {
+		slog.Error("Keyring init failed", "dir", dirs.DataDir())
+		fmt.Fprintf(os.Stderr, "Error: Failed to initialize keyring in %s\n", dirs.DataDir())
+		os.Exit(1)
+	}
+	if !k.Locked() {
+		return k
+	}
+
+	password, err := readMasterPassword(!k.MasterPasswordSet())
+	if err != nil {
+		// Commands that do not need saved passwords still work
+		slog.Warn("Keyring: Left locked", "error", err)
+		fmt.Fprintf(os.Stderr, "Warning: Saved passwords are locked: %v\n", err)
+		return k
+	}
+	if err := k.Unlock(ctx, password); err != nil {
+		slog.Error("Keyring unlock failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to unlock keyring: %v\n", err)
+		os.Exit(1)
+	}
+	return k
+}
+
+// readMasterPassword reads the master password from the environment or the terminal.
+// When create is true a new password is read twice.
+func readMasterPassword(create bool) (string, error) {
+	if create && os.Getenv(envMasterPassword) == "" {
+		fmt.Fprintln(os.Stderr, "The system keyring is not available. Choose a master password to encrypt saved passwords.")
+	}
+	return readSecret(envMasterPassword, "Master password", create)
+}
+
+// readSecret reads a password from the environment variable env, or prompts
+// for it as label on the terminal. When confirm is true it is read twice.
+func readSecret(env, label string, confirm bool) (string, error) {
+	if password := os.Getenv(env); password != "" {
+		return password, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("no terminal to prompt for the %s (set %s)", strings.ToLower(label), env)
+	}
+
+	fmt.Fprintf(os.Stderr, "%s: ", label)
+	password, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	if len(password) == 0 {
+		return "", fmt.Errorf("%s is required", strings.ToLower(label))
+	}
+
+	if confirm {
+		fmt.Fprintf(os.Stderr, "Confirm %s: ", strings.ToLower(label))
+		again, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		if string(again) != string(password) {
+			return "", errors.New("passwords do not match")
+		}
+	}
+	return string(password), nil
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	sqliterepo "github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+)
+
+// logsFollowInterval is how often a followed log is polled for new entries.
+const logsFollowInterval = time.Second
+
+// logsCommand prints the persisted log of a run.
+func logsCommand(args []string) {
+	fs := flag.NewFlagSet("logs", flag.ExitOnError)
+	stream := fs.String("stream", "", "Only these streams, comma separated: stdout, stderr, info, error")
+	search := fs.String("grep", "", "Only entries containing this text (case-insensitive)")
+	tail := fs.Int("tail", 0, "Only the last N entries (0 = all)")
+	follow := fs.Bool("follow", false, "Keep printing new entries until interrupted")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Println("Usage: db-benchmind logs [--stream S] [--grep TEXT] [--tail N] [--follow] RUN_ID")
+		os.Exit(1)
+	}
+	runID := fs.Arg(0)
+
+	filter := usecase.LogFilter{Search: *search, Tail: *tail}
+	for _, s := range strings.Split(*stream, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			filter.Streams = append(filter.Streams, s)
+		}
+	}
+
+	slog.Info("Showing run log", "command", "logs", "run_id", runID, "streams", filter.Streams, "follow", *follow)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	logRepo := sqliterepo.NewSQLiteRunLogRepository(db)
+
+	entries, err := logRepo.FindLogEntries(ctx, runID, filter)
+	if err != nil {
+		slog.Error("Show run log failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to read run log: %v\n", err)
+		os.Exit(1)
+	}
+	if len(entries) == 0 && !*follow {
+		fmt.Printf("No log entries found for run %s.\n", runID)
+		return
+	}
+	printLogEntries(entries, &filter)
+
+	if !*follow {
+		return
+	}
+
+	// Poll for entries written after the last one printed
+	filter.Tail = 0
+	ticker := time.NewTicker(logsFollowInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		entries, err := logRepo.FindLogEntries(ctx, runID, filter)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			fmt.Fprintf(os.Stderr, "Error: Failed to read run log: %v\n", err)
+			os.Exit(1)
+		}
+		printLogEntries(entries, &filter)
+	}
+}
+
+// printLogEntries prints log entries and advances filter past them.
+func printLogEntries(entries []usecase.LogEntry, filter *usecase.LogFilter) {
+	for _, entry := range entries {
+		fmt.Printf("%s [%-6s] %s\n", entry.Timestamp, entry.Stream, entry.Content)
+		filter.AfterID = entry.ID
+	}
+}
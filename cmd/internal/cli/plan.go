@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/whhaicheng/DB-BenchMind/cmd/internal/wiring"
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/dbsnapshot"
+)
+
+// planCommand prints the commands a benchmark would execute, without executing anything.
+func planCommand(args []string) {
+	fs := flag.NewFlagSet("plan", flag.ExitOnError)
+	templateID := fs.String("template", "sysbench-oltp-read-write", "Built-in template ID")
+	phase := fs.String("phase", "all", "Phase to plan: all, prepare, run, cleanup")
+	threads := fs.Int("threads", 8, "Number of threads")
+	duration := fs.Int("time", 60, "Run duration in seconds")
+	warmup := fs.Int("warmup", 0, "Warmup duration in seconds")
+	tables := fs.Int("tables", 10, "Number of tables")
+	tableSize := fs.Int("table-size", 10000, "Rows per table")
+	dbName := fs.String("db-name", "", "Benchmark database name")
+	remote := fs.Bool("remote", false, "Run the tool on the SQL Server host via WinRM")
+	agentName := fs.String("agent", "", "Run the tool on this load-generator agent; a comma separated list runs it on all of them at once")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Println("Usage: db-benchmind plan [options] NAME|ID")
+		os.Exit(1)
+	}
+
+	slog.Info("Planning benchmark", "command", "plan", "connection", fs.Arg(0), "template", *templateID, "phase", *phase)
+	ctx := context.Background()
+
+	connUC, closeDB := openConnectionUseCase(ctx)
+	defer closeDB()
+	conn := mustFindConnection(ctx, connUC, fs.Arg(0))
+
+	benchmarkUC := newBenchmarkUseCase(ctx, connUC)
+
+	task := &execution.BenchmarkTask{
+		ID:           uuid.New().String(),
+		Name:         fmt.Sprintf("%s Benchmark", conn.GetName()),
+		ConnectionID: conn.GetID(),
+		TemplateID:   *templateID,
+		Parameters: map[string]interface{}{
+			"threads":    *threads,
+			"time":       *duration,
+			"tables":     *tables,
+			"table_size": *tableSize,
+			"db_name":    *dbName,
+		},
+		Options: execution.TaskOptions{
+			WarmupTime:  *warmup,
+			DryRun:      true,
+			RemoteWinRM: *remote,
+		},
+		CreatedAt: time.Now(),
+	}
+	if agents := strings.Split(*agentName, ","); len(agents) > 1 {
+		task.Options.Agents = agents
+	} else {
+		task.Options.Agent = *agentName
+	}
+
+	// Single phases are selected the same way as the GUI phase buttons
+	switch *phase {
+	case "all":
+	case "prepare":
+		task.Options.SkipCleanup = true
+		task.Options.WarmupTime = 0
+		task.Parameters["time"] = 0
+		task.Parameters["_original_time"] = *duration
+	case "run":
+		task.Options.SkipPrepare = true
+		task.Options.SkipCleanup = true
+	case "cleanup":
+		task.Options.SkipPrepare = true
+		task.Options.WarmupTime = 0
+		task.Parameters["time"] = 0
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Unknown phase: %s\n", *phase)
+		os.Exit(1)
+	}
+
+	plan, err := benchmarkUC.PlanBenchmark(ctx, task)
+	if err != nil {
+		slog.Error("Plan benchmark failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to plan benchmark: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	fmt.Print(plan.Format())
+	fmt.Println("\nDry run: nothing was executed.")
+
+	if !plan.PreChecksPassed() {
+		os.Exit(1)
+	}
+}
+
+// newBenchmarkUseCase creates a benchmark use case with the built-in templates and adapters.
+// Runs are kept in memory; callers save finished runs to history themselves.
+// The target database configuration is captured with every run.
+func newBenchmarkUseCase(ctx context.Context, connUC *usecase.ConnectionUseCase) *usecase.BenchmarkUseCase {
+	templateUC, err := wiring.NewTemplateUseCase(ctx, dirs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to load templates: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Run the tool executables configured in the settings instead of PATH lookups
+	adapterReg := wiring.NewAdapterRegistry()
+	settingsUC := wiring.NewSettingsUseCase(dirs)
+	wiring.ApplyToolPaths(ctx, settingsUC, adapterReg, dirs)
+
+	benchmarkUC := usecase.NewBenchmarkUseCase(usecase.NewMemoryRunRepository(), adapterReg, connUC, templateUC)
+	benchmarkUC.SetConfigSnapshotter(dbsnapshot.NewCapturer())
+	benchmarkUC.SetServerLogCollector(dbsnapshot.NewServerLogCollector())
+	benchmarkUC.SetAgents(settingsUC.GetAgent, dirs.AgentKeyPath())
+	benchmarkUC.SetAccessControl(openAccess())
+	return benchmarkUC
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/whhaicheng/DB-BenchMind/cmd/internal/wiring"
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/grpcapi"
+)
+
+// defaultAPIListen is the address the gRPC API listens on. Without --tokens
+// or --oidc the API has no authentication, so it is only reachable from this machine by default.
+const defaultAPIListen = "127.0.0.1:50051"
+
+// serveShutdownTimeout is how long open sample streams may take to end on shutdown.
+const serveShutdownTimeout = 10 * time.Second
+
+// serveCommand serves the gRPC API until interrupted.
+func serveCommand(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	listen := fs.String("listen", defaultAPIListen, "Address to serve the gRPC API on")
+	tokenFile := fs.String("tokens", "", "File of API tokens, one \"<role> <token>\" per line (role admin, operator or viewer)")
+	oidcFile := fs.String("oidc", "", "JSON file configuring OIDC tokens: issuer, audience and the roles of groups")
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, "Usage: db-benchmind serve [--listen ADDR] [--tokens FILE] [--oidc FILE]")
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var auth grpcapi.Authenticators
+	if *tokenFile != "" {
+		tokens, err := grpcapi.LoadStaticTokens(*tokenFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: load API tokens: %v\n", err)
+			os.Exit(1)
+		}
+		auth = append(auth, tokens)
+	}
+	if *oidcFile != "" {
+		cfg, err := grpcapi.LoadOIDCConfig(*oidcFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: load OIDC configuration: %v\n", err)
+			os.Exit(1)
+		}
+		oidc, err := grpcapi.NewOIDC(ctx, *cfg, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: OIDC provider %s: %v\n", cfg.Issuer, err)
+			os.Exit(1)
+		}
+		auth = append(auth, oidc)
+	}
+
+	var serverOptions []grpc.ServerOption
+	if len(auth) > 0 {
+		serverOptions = grpcapi.ServerOptions(auth)
+	} else if host, _, err := net.SplitHostPort(*listen); err != nil || !isLoopbackHost(host) {
+		slog.Warn("API server has no authentication and listens beyond this machine; use --tokens or --oidc", "listen", *listen)
+		fmt.Fprintf(os.Stderr, "Warning: the API on %s has no authentication; use --tokens or --oidc\n", *listen)
+	}
+
+	db := openDatabase(ctx)
+	defer db.Close()
+
+	// Unlock the app lock now instead of prompting in the middle of a request
+	accessChecker := openAccess()
+	accessChecker.unlockOnce(ctx)
+	// The role of a request's token restricts it on top of the app lock
+	requestAccess := grpcapi.RequestAccess(accessChecker)
+	connUC := usecase.NewConnectionUseCase(repository.NewSQLiteConnectionRepository(db), openKeyring(ctx))
+	connUC.SetAccessControl(requestAccess)
+
+	runLogRepo := repository.NewSQLiteRunLogRepository(db)
+	benchmarkUC := newBenchmarkUseCase(ctx, connUC)
+	benchmarkUC.SetAccessControl(requestAccess)
+	benchmarkUC.SetArtifactDir(dirs.RunsDir())
+	benchmarkUC.SetLogRepository(runLogRepo)
+	benchmarkUC.SetProcessRepository(repository.NewSQLiteProcessRepository(db))
+	if killed, err := benchmarkUC.ReapOrphanedProcesses(ctx); err != nil {
+		slog.Warn("Failed to clean up orphaned benchmark processes", "error", err)
+	} else if killed > 0 {
+		slog.Info("Orphaned benchmark processes cleaned up", "count", killed)
+	}
+
+	historyUC := wiring.NewHistoryUseCase(repository.NewSQLiteHistoryRepository(db), runLogRepo, wiring.NewSettingsUseCase(dirs), dirs)
+
+	apiServer := grpcapi.NewServer(connUC, benchmarkUC, historyUC)
+	benchmarkUC.SetRealtimeCallback(apiServer.PublishSample)
+	benchmarkUC.SetRunFinishedCallback(apiServer.RunFinished)
+
+	lis, err := net.Listen("tcp", *listen)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	grpcServer := grpc.NewServer(serverOptions...)
+	apiServer.Register(grpcServer)
+
+	go func() {
+		<-ctx.Done()
+		slog.Info("Stopping API server")
+		stopActiveRuns(benchmarkUC)
+		time.AfterFunc(serveShutdownTimeout, grpcServer.Stop)
+		grpcServer.GracefulStop()
+	}()
+
+	slog.Info("API server started", "command", "serve", "listen", lis.Addr().String())
+	fmt.Printf("gRPC API listening on %s (Ctrl+C to stop)\n", lis.Addr())
+	if err := grpcServer.Serve(lis); err != nil {
+		slog.Error("API server failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// stopActiveRuns stops the runs still in progress, so no benchmark keeps
+// running after the server exits.
+func stopActiveRuns(benchmarkUC *usecase.BenchmarkUseCase) {
+	ctx := context.Background()
+	runs, err := benchmarkUC.ListBenchmarks(ctx, usecase.FindOptions{})
+	if err != nil {
+		slog.Warn("Failed to list runs", "error", err)
+		return
+	}
+	for _, run := range runs {
+		if run.State.IsTerminal() {
+			continue
+		}
+		if err := benchmarkUC.StopBenchmark(ctx, run.ID, false); err != nil {
+			slog.Warn("Failed to stop run", "run_id", run.ID, "error", err)
+		}
+	}
+}
+
+// isLoopbackHost reports whether host only accepts connections from this machine.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
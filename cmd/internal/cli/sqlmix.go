@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+// sqlMixWorker runs a phase of a SQL mix for a benchmark run. It is started
+// by the SQL mix adapter with a spec file in the work directory of the run,
+// and writes its output to stdout for the run to parse. Stopping the run
+// with SIGTERM ends the phase; a run still writes its summary.
+func sqlMixWorker(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: db-benchmind %s SPEC_FILE\n", adapter.SQLMixWorkerCommand)
+		os.Exit(1)
+	}
+	spec, err := adapter.ReadSQLMixSpec(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	// The spec holds the password; the run has no use for it any more
+	os.Remove(args[0])
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := adapter.RunSQLMix(ctx, spec, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
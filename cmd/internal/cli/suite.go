@@ -0,0 +1,287 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/cmd/internal/wiring"
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/suite"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+)
+
+func suiteCommand(args []string) {
+	if len(args) == 0 {
+		fmt.Println("Usage: db-benchmind suite <list|save|show|run|runs|delete> [options]")
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "list":
+		suiteList()
+	case "save":
+		suiteSave(args[1:])
+	case "show":
+		suiteShow(args[1:])
+	case "run":
+		suiteRun(args[1:])
+	case "runs":
+		suiteRuns(args[1:])
+	case "delete":
+		suiteDelete(args[1:])
+	default:
+		fmt.Printf("Unknown suite command: %s\n", args[0])
+		os.Exit(1)
+	}
+}
+
+func suiteList() {
+	slog.Info("Listing suites", "command", "suite list")
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+
+	suites, err := newSuiteUseCase(db, nil).ListSuites(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to list suites: %v\n", err)
+		os.Exit(1)
+	}
+	if len(suites) == 0 {
+		fmt.Println("No suites found. Create one with: db-benchmind suite save FILE")
+		return
+	}
+
+	fmt.Printf("\nFound %d suite(s):\n", len(suites))
+	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+	for _, s := range suites {
+		fmt.Printf("%-30s %d steps, %d runs  %s\n", s.Name, len(s.Steps), s.TotalRuns(), s.ID)
+	}
+}
+
+// suiteSave creates or updates a suite from a JSON file. Step connections may
+// be given by name or ID; a suite with the same name is replaced.
+func suiteSave(args []string) {
+	if len(args) != 1 {
+		fmt.Println("Usage: db-benchmind suite save FILE")
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	var s suite.Suite
+	if err := json.Unmarshal(data, &s); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Invalid suite file: %v\n", err)
+		os.Exit(1)
+	}
+
+	slog.Info("Saving suite", "command", "suite save", "file", args[0], "name", s.Name)
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	connUC := usecase.NewConnectionUseCase(repository.NewSQLiteConnectionRepository(db), openKeyring(ctx))
+	for i := range s.Steps {
+		if s.Steps[i].ConnectionID != "" {
+			s.Steps[i].ConnectionID = mustFindConnection(ctx, connUC, s.Steps[i].ConnectionID).GetID()
+		}
+	}
+
+	suiteUC := newSuiteUseCase(db, nil)
+	if existing, err := findSuite(ctx, suiteUC, s.Name); err == nil {
+		s.ID = existing.ID
+		s.CreatedAt = existing.CreatedAt
+	}
+
+	if err := suiteUC.SaveSuite(ctx, &s); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to save suite: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Suite %s saved: %d steps, %d runs (ID %s)\n", s.Name, len(s.Steps), s.TotalRuns(), s.ID)
+}
+
+func suiteShow(args []string) {
+	if len(args) != 1 {
+		fmt.Println("Usage: db-benchmind suite show NAME|ID")
+		os.Exit(1)
+	}
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	s := mustFindSuite(ctx, newSuiteUseCase(db, nil), args[0])
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
+
+// suiteRun runs a suite in the foreground. Ctrl+C stops the current run and skips the rest.
+// exitAssertionsFailed is the exit status of a suite run whose runs completed
+// but missed their SLA targets, so that CI can tell it from failed runs.
+const exitAssertionsFailed = 3
+
+func suiteRun(args []string) {
+	if len(args) != 1 {
+		fmt.Println("Usage: db-benchmind suite run NAME|ID")
+		os.Exit(1)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	connUC := usecase.NewConnectionUseCase(repository.NewSQLiteConnectionRepository(db), openKeyring(ctx))
+
+	runLogRepo := repository.NewSQLiteRunLogRepository(db)
+	benchmarkUC := newBenchmarkUseCase(ctx, connUC)
+	benchmarkUC.SetArtifactDir(dirs.RunsDir())
+	benchmarkUC.SetLogRepository(runLogRepo)
+	benchmarkUC.SetProcessRepository(repository.NewSQLiteProcessRepository(db))
+
+	suiteUC := newSuiteUseCase(db, benchmarkUC)
+	s := mustFindSuite(ctx, suiteUC, args[0])
+	slog.Info("Running suite", "command", "suite run", "suite", s.Name, "runs", s.TotalRuns())
+
+	run, err := suiteUC.RunSuite(ctx, s.ID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to run suite: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println()
+	printSuiteRun(run)
+	if run.State != suite.StateCompleted {
+		os.Exit(1)
+	}
+	if failed := run.FailedAssertionRunIDs(); len(failed) > 0 {
+		fmt.Fprintf(os.Stderr, "Error: %d run(s) missed their SLA targets\n", len(failed))
+		os.Exit(exitAssertionsFailed)
+	}
+}
+
+func suiteRuns(args []string) {
+	if len(args) != 1 {
+		fmt.Println("Usage: db-benchmind suite runs NAME|ID")
+		os.Exit(1)
+	}
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	suiteUC := newSuiteUseCase(db, nil)
+	s := mustFindSuite(ctx, suiteUC, args[0])
+
+	runs, err := suiteUC.ListSuiteRuns(ctx, s.ID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to list suite runs: %v\n", err)
+		os.Exit(1)
+	}
+	if len(runs) == 0 {
+		fmt.Printf("Suite %s has not been run yet.\n", s.Name)
+		return
+	}
+	for _, run := range runs {
+		fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
+		printSuiteRun(run)
+	}
+}
+
+func suiteDelete(args []string) {
+	if len(args) != 1 {
+		fmt.Println("Usage: db-benchmind suite delete NAME|ID")
+		os.Exit(1)
+	}
+	ctx := context.Background()
+
+	db := openDatabase(ctx)
+	defer db.Close()
+	suiteUC := newSuiteUseCase(db, nil)
+	s := mustFindSuite(ctx, suiteUC, args[0])
+
+	if err := suiteUC.DeleteSuite(ctx, s.ID); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Failed to delete suite: %v\n", err)
+		os.Exit(1)
+	}
+	slog.Info("Suite deleted", "command", "suite delete", "suite", s.Name)
+	fmt.Printf("Suite %s deleted. Its history records are kept.\n", s.Name)
+}
+
+// newSuiteUseCase creates a suite use case that saves results to history and
+// exports comparison reports. runner may be nil for commands that do not run suites.
+func newSuiteUseCase(db *sql.DB, runner usecase.BenchmarkRunner) *usecase.SuiteUseCase {
+	historyRepo := repository.NewSQLiteHistoryRepository(db)
+	settingsUC := wiring.NewSettingsUseCase(dirs)
+	historyUC := wiring.NewHistoryUseCase(historyRepo, repository.NewSQLiteRunLogRepository(db), settingsUC, dirs)
+
+	comparisonUC := usecase.NewComparisonUseCase(historyRepo, nil)
+	comparisonUC.SetExportDir(dirs.ExportDir())
+	comparisonUC.SetExportConfig(settingsUC.GetExportConfig)
+
+	suiteUC := usecase.NewSuiteUseCase(repository.NewSQLiteSuiteRepository(db), runner, historyUC)
+	suiteUC.SetComparisonUseCase(comparisonUC)
+	return suiteUC
+}
+
+// findSuite returns the suite whose ID or name is ref.
+func findSuite(ctx context.Context, suiteUC *usecase.SuiteUseCase, ref string) (*suite.Suite, error) {
+	suites, err := suiteUC.ListSuites(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list suites: %w", err)
+	}
+	for _, s := range suites {
+		if s.ID == ref || s.Name == ref {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", usecase.ErrSuiteNotFound, ref)
+}
+
+// mustFindSuite is findSuite that exits on failure.
+func mustFindSuite(ctx context.Context, suiteUC *usecase.SuiteUseCase, ref string) *suite.Suite {
+	s, err := findSuite(ctx, suiteUC, ref)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return s
+}
+
+// printSuiteRun prints the outcome of a suite run.
+func printSuiteRun(run *suite.Run) {
+	fmt.Printf("Suite:    %s\n", run.SuiteName)
+	fmt.Printf("Run ID:   %s\n", run.ID)
+	fmt.Printf("State:    %s\n", run.State)
+	fmt.Printf("Started:  %s\n", run.StartedAt.Format(time.DateTime))
+	fmt.Printf("Runs:     %d of %d completed\n", len(run.CompletedRunIDs()), run.TotalRuns)
+	if run.ErrorMessage != "" {
+		fmt.Printf("Error:    %s\n", run.ErrorMessage)
+	}
+	for _, ref := range run.Runs {
+		fmt.Printf("  step %d #%d  %-12s %s", ref.Step+1, ref.Repetition, ref.State, ref.RunID)
+		if ref.Error != "" {
+			fmt.Printf("  (%s)", firstLine(ref.Error))
+		}
+		if len(ref.FailedAssertions) > 0 {
+			fmt.Printf("  SLA FAIL: %s", strings.Join(ref.FailedAssertions, ", "))
+		}
+		fmt.Println()
+	}
+	fmt.Printf("History:  db-benchmind history list --tag %s\n", run.Tag())
+	if run.ReportPath != "" {
+		fmt.Printf("Report:   %s\n", run.ReportPath)
+	}
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+)
+
+func testCommand(args []string) {
+	fs := flag.NewFlagSet("test", flag.ExitOnError)
+	all := fs.Bool("all", false, "Test every saved connection")
+	concurrency := fs.Int("concurrency", usecase.DefaultTestConcurrency, "Number of connections tested at once")
+	fs.Parse(args)
+
+	if !*all && fs.NArg() == 0 {
+		fmt.Println("Usage: db-benchmind test --all [--concurrency N] | test NAME|ID...")
+		os.Exit(1)
+	}
+
+	slog.Info("Testing connections", "command", "test", "all", *all, "concurrency", *concurrency)
+	ctx := context.Background()
+
+	connUC, closeDB := openConnectionUseCase(ctx)
+	defer closeDB()
+
+	var outcomes []usecase.ConnectionTestOutcome
+	if *all {
+		var err error
+		outcomes, err = connUC.TestAllConnections(ctx, *concurrency)
+		if err != nil {
+			slog.Error("Test connections failed", "error", err)
+			fmt.Fprintf(os.Stderr, "Error: Failed to test connections: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		for _, ref := range fs.Args() {
+			conn := mustFindConnection(ctx, connUC, ref)
+			outcome := usecase.ConnectionTestOutcome{
+				ConnectionID: conn.GetID(),
+				Name:         conn.GetName(),
+				Type:         conn.GetType(),
+			}
+			outcome.Result, outcome.Err = connUC.TestConnection(ctx, conn.GetID())
+			outcomes = append(outcomes, outcome)
+		}
+	}
+
+	if len(outcomes) == 0 {
+		fmt.Println("No connections found.")
+		return
+	}
+
+	failed := printTestSummary(outcomes)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// printTestSummary prints a table of connection test outcomes and returns the number of failures.
+func printTestSummary(outcomes []usecase.ConnectionTestOutcome) int {
+	failed := 0
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTYPE\tSTATUS\tLATENCY\tVERSION / ERROR")
+	for _, o := range outcomes {
+		status, latency, detail := "OK", "-", ""
+		switch {
+		case o.Err != nil:
+			status, detail = "FAIL", o.Err.Error()
+		case !o.Result.Success:
+			status, detail = "FAIL", o.Result.Error
+			latency = fmt.Sprintf("%dms", o.Result.LatencyMs)
+		default:
+			latency = fmt.Sprintf("%dms", o.Result.LatencyMs)
+			detail = o.Result.DatabaseVersion
+		}
+		if status == "FAIL" {
+			failed++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", o.Name, o.Type, status, latency, firstLine(detail))
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d tested, %d succeeded, %d failed\n", len(outcomes), len(outcomes)-failed, failed)
+	return failed
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/whhaicheng/DB-BenchMind/cmd/internal/wiring"
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/update"
+)
+
+// updateCommand checks for a new version and prints its release notes,
+// downloading its binary for this platform with --download. It checks even
+// if automatic update checks are disabled.
+func updateCommand(args []string) {
+	fs := flag.NewFlagSet("update", flag.ExitOnError)
+	download := fs.Bool("download", false, "Download the binary of a new version for this platform")
+	output := fs.String("output", "", "Download directory (default: the updates directory in the data directory)")
+	fs.Parse(args)
+
+	downloadDir := *output
+	if downloadDir == "" {
+		downloadDir = dirs.UpdateDir()
+	}
+	updateUC := usecase.NewUpdateUseCase(update.NewClient("", ""), downloadDir, wiring.NewSettingsUseCase(dirs))
+	updateUC.SetAppVersion(Version)
+
+	ctx := context.Background()
+	info, err := updateUC.Check(ctx)
+	if err != nil {
+		slog.Error("Update check failed", "error", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !info.Available {
+		fmt.Printf("DB-BenchMind %s is up to date (latest release %s)\n", info.Current, info.Release.Version)
+		return
+	}
+
+	fmt.Printf("New version available: %s (running %s)\n", info.Release.Version, info.Current)
+	if info.Release.URL != "" {
+		fmt.Printf("Release page: %s\n", info.Release.URL)
+	}
+	if notes := strings.TrimSpace(info.Release.Notes); notes != "" {
+		fmt.Printf("\n%s\n\n", notes)
+	}
+	if !*download {
+		fmt.Println("Run 'db-benchmind update --download' to download it.")
+		return
+	}
+
+	path, verified, err := updateUC.Download(ctx, info)
+	if err != nil {
+		slog.Error("Update download failed", "version", info.Release.Version, "error", err)
+		fmt.Fprintf(os.Stderr, "Error: Failed to download update: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✓ Downloaded %s\n", path)
+	if !verified {
+		fmt.Println("Warning: the release publishes no checksums; the download was not verified")
+	}
+	fmt.Println("Replace the db-benchmind binary with the downloaded file to update.")
+}
@@ -0,0 +1,32 @@
+package wiring
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime/debug"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/appdir"
+)
+
+// ReportCrash recovers a panic of the calling goroutine, writes a crash
+// report to the log directory, where diagnostics bundles pick it up, and
+// exits with status 2. Defer it in main once logging is set up.
+func ReportCrash(dirs appdir.Dirs, version string) {
+	value := recover()
+	if value == nil {
+		return
+	}
+	stack := debug.Stack()
+	report, err := usecase.WriteCrashReport(dirs.LogDir(), version, value, stack)
+	if err != nil {
+		slog.Error("Crashed", "panic", value, "error", err)
+		fmt.Fprintf(os.Stderr, "panic: %v\n\n%s\nFailed to write crash report: %v\n", value, stack, err)
+		os.Exit(2)
+	}
+
+	slog.Error("Crashed", "panic", value, "crash_report", report)
+	fmt.Fprintf(os.Stderr, "DB-BenchMind crashed: %v\nCrash report: %s\nRun \"db-benchmind diagnostics\" to collect a bundle for a bug report.\n", value, report)
+	os.Exit(2)
+}
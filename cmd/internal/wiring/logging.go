@@ -0,0 +1,262 @@
+package wiring
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/appdir"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/logfile"
+)
+
+// logModulePackages are the packages of config.LogModules. Subpackages
+// belong to the module too (ui/pages is ui).
+var logModulePackages = map[string]string{
+	"ui":      "/internal/transport/ui",
+	"usecase": "/internal/app/usecase",
+	"adapter": "/internal/infra/adapter",
+}
+
+// LogFlags are the --log-format and --log-level command line options,
+// overriding the logging settings. Empty fields keep the settings.
+type LogFlags struct {
+	Format string // text or json
+	Levels string // LEVEL[,MODULE=LEVEL...], e.g. info,adapter=debug
+}
+
+// Logging is the default logger, writing to stdout and the daily log file.
+type Logging struct {
+	File   string // Path of the log file at start
+	file   *logfile.Writer
+	levels *logLevels
+}
+
+// SetupLogging makes the default logger write to stdout and to the daily log
+// file <name>-YYYY-MM-DD.log in the log directory, in the format and at the
+// levels of the advanced settings (advanced.log_format, advanced.log_level
+// and advanced.module_log_levels) as overridden by flags. The log files are
+// rotated, compressed and pruned by advanced.max_log_size_mb,
+// advanced.max_log_files and advanced.max_log_age_days. Close the returned
+// logging on exit.
+func SetupLogging(dirs appdir.Dirs, name string, flags LogFlags) (*Logging, error) {
+	cfg := config.DefaultConfig().Advanced
+	if saved, err := NewSettingsUseCase(dirs).GetAdvancedConfig(context.Background()); err == nil {
+		cfg = *saved
+	}
+	if flags.Format != "" {
+		cfg.LogFormat = flags.Format
+	}
+	if flags.Levels != "" {
+		if err := ParseLogLevels(flags.Levels, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.ValidateLogging(); err != nil {
+		return nil, err
+	}
+
+	file, err := logfile.Open(dirs.LogDir(), name, logLimits(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	// Create multi-writer for both file and console
+	l := &Logging{File: file.Path(), file: file, levels: &logLevels{}}
+	l.levels.set(cfg)
+	slog.SetDefault(slog.New(newMultiHandler(cfg.LogFormat, l.levels, os.Stdout, file)))
+	return l, nil
+}
+
+// Apply changes the levels and log file limits to those of cfg while
+// running. A new log format applies at the next start.
+func (l *Logging) Apply(cfg config.AdvancedConfig) {
+	l.levels.set(cfg)
+	l.file.SetLimits(logLimits(cfg))
+	slog.Info("Logging: Settings applied", "level", cfg.LogLevel, "modules", cfg.ModuleLogLevels)
+}
+
+// logLimits returns the log file limits of cfg.
+func logLimits(cfg config.AdvancedConfig) logfile.Limits {
+	return logfile.Limits{
+		MaxSize:  int64(cfg.MaxLogSizeMB) << 20,
+		MaxFiles: cfg.MaxLogFiles,
+		MaxAge:   time.Duration(cfg.MaxLogAgeDays) * 24 * time.Hour,
+	}
+}
+
+// Close closes the log file.
+func (l *Logging) Close() error {
+	return l.file.Close()
+}
+
+// ParseLogLevels sets the levels of spec, LEVEL[,MODULE=LEVEL...] in any
+// order, on cfg.
+func ParseLogLevels(spec string, cfg *config.AdvancedConfig) error {
+	for _, part := range strings.Split(spec, ",") {
+		module, level, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			cfg.LogLevel = strings.ToLower(module)
+			continue
+		}
+		if !slices.Contains(config.LogModules, module) {
+			return fmt.Errorf("unknown log module %q (modules: %s)", module, strings.Join(config.LogModules, ", "))
+		}
+		if cfg.ModuleLogLevels == nil {
+			cfg.ModuleLogLevels = make(map[string]string)
+		}
+		cfg.ModuleLogLevels[module] = strings.ToLower(level)
+	}
+	return cfg.ValidateLogging()
+}
+
+// parseLevel returns the slog level of a settings level, or info.
+func parseLevel(level string) slog.Level {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo
+	}
+	return l
+}
+
+// logLevels are the global and per-module levels, shared by the handlers and
+// changed while running.
+type logLevels struct {
+	mu      sync.RWMutex
+	global  slog.Level
+	modules map[string]slog.Level
+	minimum slog.Level // Lowest of the levels, for Enabled
+	byPC    sync.Map   // Program counter -> module, "" for none
+}
+
+// set sets the levels of cfg.
+func (l *logLevels) set(cfg config.AdvancedConfig) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.global = parseLevel(cfg.LogLevel)
+	l.minimum = l.global
+	l.modules = make(map[string]slog.Level, len(cfg.ModuleLogLevels))
+	for module, level := range cfg.ModuleLogLevels {
+		l.modules[module] = parseLevel(level)
+		l.minimum = min(l.minimum, l.modules[module])
+	}
+}
+
+// enabled reports whether a record at level may be logged by some module.
+func (l *logLevels) enabled(level slog.Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return level >= l.minimum
+}
+
+// allows reports whether the record r is at or above the level of the
+// module of the function that logged it.
+func (l *logLevels) allows(r slog.Record) bool {
+	module := l.module(r.PC)
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if level, ok := l.modules[module]; ok {
+		return r.Level >= level
+	}
+	return r.Level >= l.global
+}
+
+// module returns the module of the function at pc, or "" if it is in none.
+func (l *logLevels) module(pc uintptr) string {
+	if pc == 0 {
+		return ""
+	}
+	if module, ok := l.byPC.Load(pc); ok {
+		return module.(string)
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	module := moduleOf(frame.Function)
+	l.byPC.Store(pc, module)
+	return module
+}
+
+// moduleOf returns the module of function, a fully qualified function name
+// such as github.com/x/y/internal/app/usecase.(*T).M.
+func moduleOf(function string) string {
+	pkg := function
+	if slash := strings.LastIndex(pkg, "/"); slash >= 0 {
+		if dot := strings.Index(pkg[slash:], "."); dot >= 0 {
+			pkg = pkg[:slash+dot]
+		}
+	}
+	for module, path := range logModulePackages {
+		if strings.HasSuffix(pkg, path) || strings.Contains(pkg, path+"/") {
+			return module
+		}
+	}
+	return ""
+}
+
+// multiHandler writes log records to multiple handlers, filtered by the
+// level of the module logging them.
+type multiHandler struct {
+	handlers []slog.Handler
+	levels   *logLevels
+}
+
+// newMultiHandler creates a new multi-handler that writes to all provided
+// writers in format (text or json) at levels, with secrets redacted.
+func newMultiHandler(format string, levels *logLevels, writers ...io.Writer) slog.Handler {
+	opts := &slog.HandlerOptions{
+		Level:       slog.LevelDebug, // Levels are checked by the multi-handler
+		ReplaceAttr: usecase.RedactLogAttr,
+	}
+	var handlers []slog.Handler
+	for _, w := range writers {
+		if format == config.LogFormatJSON {
+			handlers = append(handlers, slog.NewJSONHandler(w, opts))
+		} else {
+			handlers = append(handlers, slog.NewTextHandler(w, opts))
+		}
+	}
+	return &multiHandler{handlers: handlers, levels: levels}
+}
+
+// Handle handles the log record by forwarding to all handlers.
+func (m *multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	if !m.levels.allows(r) {
+		return nil
+	}
+	for _, h := range m.handlers {
+		if err := h.Handle(ctx, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Enabled reports whether the handler is enabled for the given level.
+func (m *multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return m.levels.enabled(level)
+}
+
+// WithAttrs returns a new handler with the given attributes.
+func (m *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var newHandlers []slog.Handler
+	for _, h := range m.handlers {
+		newHandlers = append(newHandlers, h.WithAttrs(attrs))
+	}
+	return &multiHandler{handlers: newHandlers, levels: m.levels}
+}
+
+// WithGroup returns a new handler with the given group name.
+func (m *multiHandler) WithGroup(name string) slog.Handler {
+	var newHandlers []slog.Handler
+	for _, h := range m.handlers {
+		newHandlers = append(newHandlers, h.WithGroup(name))
+	}
+	return &multiHandler{handlers: newHandlers, levels: m.levels}
+}
@@ -0,0 +1,266 @@
+// Package wiring assembles the repositories, adapters and use cases of
+// DB-BenchMind. The GUI and the headless commands of the db-benchmind binary
+// share it, so the composition is written once.
+package wiring
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/whhaicheng/DB-BenchMind/contracts"
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/appdir"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/dbsnapshot"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/keyring"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/notify"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/tool"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/update"
+)
+
+// NewAdapterRegistry creates the registry of the benchmark tool adapters.
+func NewAdapterRegistry() *adapter.AdapterRegistry {
+	adapterReg := adapter.NewAdapterRegistry()
+	adapterReg.Register(adapter.NewSysbenchAdapter())
+	adapterReg.Register(adapter.NewHammerDBAdapter())
+	adapterReg.Register(adapter.NewSwingbenchAdapter())
+	adapterReg.Register(adapter.NewSQLMixAdapter())
+	return adapterReg
+}
+
+// NewTemplateUseCase creates the template use case and loads the built-in
+// templates, then those of users in the templates directory of dirs. The
+// use case is returned even if loading fails; user templates that cannot be
+// imported are only logged, so one broken file does not stop the commands.
+func NewTemplateUseCase(ctx context.Context, dirs appdir.Dirs) (*usecase.TemplateUseCase, error) {
+	templateUC := usecase.NewTemplateUseCaseFS(usecase.NewMemoryTemplateRepository(), contracts.BuiltinTemplates())
+	if err := templateUC.LoadBuiltinTemplates(ctx); err != nil {
+		return templateUC, fmt.Errorf("load built-in templates: %w", err)
+	}
+	if err := templateUC.LoadTemplateDir(ctx, dirs.TemplatesDir()); err != nil {
+		slog.Warn("Failed to import user templates", "dir", dirs.TemplatesDir(), "error", err)
+	}
+	return templateUC, nil
+}
+
+// NewSettingsUseCase creates the settings use case of the settings file in dirs.
+func NewSettingsUseCase(dirs appdir.Dirs) *usecase.SettingsUseCase {
+	return usecase.NewSettingsUseCase(repository.NewSettingsRepository(dirs.ConfigPath()), tool.NewDetector())
+}
+
+// ApplyToolPaths makes the adapters run the tool executables configured in
+// the settings instead of PATH lookups. Failures are logged.
+func ApplyToolPaths(ctx context.Context, settingsUC *usecase.SettingsUseCase, adapterReg *adapter.AdapterRegistry, dirs appdir.Dirs) {
+	settingsUC.SetAdapterRegistry(adapterReg)
+	settingsUC.SetToolsDir(dirs.ToolsDir())
+	if err := settingsUC.ApplyToolPaths(ctx); err != nil {
+		slog.Warn("Failed to apply configured tool paths", "error", err)
+	}
+}
+
+// NewHistoryUseCase creates the history use case of historyRepo, keeping run
+// artifacts and logs and evaluating the sanity checks of the settings.
+func NewHistoryUseCase(historyRepo *repository.SQLiteHistoryRepository, runLogRepo *repository.SQLiteRunLogRepository, settingsUC *usecase.SettingsUseCase, dirs appdir.Dirs) *usecase.HistoryUseCase {
+	historyUC := usecase.NewHistoryUseCase(historyRepo)
+	historyUC.SetArtifactDir(dirs.RunsDir())
+	historyUC.SetLogRepository(runLogRepo)
+	historyUC.SetSanityChecks(settingsUC.GetSanityChecks)
+	return historyUC
+}
+
+// Services holds the use cases of the GUI, wired to the database in the
+// data directory.
+type Services struct {
+	DB     *sql.DB // Read-write connection
+	ReadDB *sql.DB // Read pool of the pages
+
+	Conn        *usecase.ConnectionUseCase
+	Benchmark   *usecase.BenchmarkUseCase
+	Template    *usecase.TemplateUseCase
+	History     *usecase.HistoryUseCase
+	Export      *usecase.ExportUseCase
+	Comparison  *usecase.ComparisonUseCase
+	Maintenance *usecase.MaintenanceUseCase
+	Settings    *usecase.SettingsUseCase
+	Notify      *usecase.NotificationUseCase
+	Suite       *usecase.SuiteUseCase
+	Repetition  *usecase.RepetitionUseCase
+	Race        *usecase.RaceUseCase
+	Access      *usecase.AccessUseCase
+	Diagnostics *usecase.DiagnosticsUseCase
+	Update      *usecase.UpdateUseCase
+	Demo        *usecase.DemoUseCase
+}
+
+// NewServices opens the database in dirs and creates the use cases of the
+// GUI. It cleans up benchmark processes left behind by a crash and starts
+// the history purge job. Close the services when done.
+func NewServices(ctx context.Context, dirs appdir.Dirs) (*Services, error) {
+	s := &Services{}
+
+	// 1. Initialize database
+	dbPath := dirs.DBPath()
+	db, err := database.InitializeSQLite(ctx, dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("initialize database: %w", err)
+	}
+	s.DB = db
+	slog.Info("Database initialized", "path", dbPath)
+
+	// Pages read through a separate pool so they do not wait behind benchmark writes
+	readDB, err := database.OpenSQLiteReadPool(ctx, dbPath, database.DefaultReadPoolSize)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("open database read pool: %w", err)
+	}
+	s.ReadDB = readDB
+
+	// 2. Initialize repositories
+	connRepo := repository.NewSQLiteConnectionRepository(db)
+	slog.Info("Repositories initialized")
+
+	// 3. Initialize keyring - system keyring, with file fallback when unavailable
+	keyringProvider := keyring.NewGoKeyring(dirs.DataDir())
+	if !keyringProvider.Available(ctx) {
+		s.Close()
+		return nil, fmt.Errorf("initialize keyring in %s", dirs.DataDir())
+	}
+	// Move passwords saved by earlier versions into the system keyring
+	if _, err := keyringProvider.MigrateFallback(ctx); err != nil {
+		slog.Warn("Failed to migrate stored passwords to system keyring", "error", err)
+	}
+	slog.Info("Keyring initialized", "system_keyring", keyringProvider.SystemAvailable())
+
+	// 4. Initialize use cases
+	s.Conn = usecase.NewConnectionUseCase(connRepo, keyringProvider)
+
+	// Create template use case with the built-in and user templates
+	s.Template, err = NewTemplateUseCase(ctx, dirs)
+	if err != nil {
+		slog.Warn("Failed to load built-in templates", "error", err)
+	} else {
+		// Get templates to verify loading
+		templates, _ := s.Template.ListBuiltinTemplates(ctx)
+		slog.Info("Built-in templates loaded", "count", len(templates))
+	}
+
+	adapterReg := NewAdapterRegistry()
+
+	// Create run repository
+	runRepo := usecase.NewMemoryRunRepository()
+
+	// Create benchmark use case
+	s.Benchmark = usecase.NewBenchmarkUseCase(runRepo, adapterReg, s.Conn, s.Template)
+	s.Benchmark.SetArtifactDir(dirs.RunsDir())
+	snapshotter := dbsnapshot.NewCapturer()
+	s.Benchmark.SetConfigSnapshotter(snapshotter)
+	s.Conn.SetConfigSnapshotter(snapshotter)
+	s.Benchmark.SetReplicaMonitor(dbsnapshot.NewReplicaMonitor())
+	s.Benchmark.SetServerLogCollector(dbsnapshot.NewServerLogCollector())
+
+	// Persist run logs; runs themselves are kept in memory
+	runLogRepo := repository.NewSQLiteRunLogRepository(db)
+	runLogRepo.SetReadDB(readDB)
+	s.Benchmark.SetLogRepository(runLogRepo)
+
+	// Record benchmark processes, and clean up any left behind by a crash
+	s.Benchmark.SetProcessRepository(repository.NewSQLiteProcessRepository(db))
+	if killed, err := s.Benchmark.ReapOrphanedProcesses(ctx); err != nil {
+		slog.Warn("Failed to clean up orphaned benchmark processes", "error", err)
+	} else if killed > 0 {
+		slog.Info("Orphaned benchmark processes cleaned up", "count", killed)
+	}
+
+	// Create settings use case
+	settingsRepo := repository.NewSettingsRepository(dirs.ConfigPath())
+	s.Settings = usecase.NewSettingsUseCase(settingsRepo, tool.NewDetector())
+	ApplyToolPaths(ctx, s.Settings, adapterReg, dirs)
+
+	// Create history use case
+	historyRepo := repository.NewSQLiteHistoryRepository(db)
+	historyRepo.SetReadDB(readDB)
+	s.History = NewHistoryUseCase(historyRepo, runLogRepo, s.Settings, dirs)
+
+	// Create export use case
+	s.Export = usecase.NewExportUseCase(dirs.ExportDir())
+	s.Export.SetArtifactDir(dirs.RunsDir())
+
+	// Create comparison use case
+	s.Comparison = usecase.NewComparisonUseCase(historyRepo, runRepo)
+	s.Comparison.SetExportDir(dirs.ExportDir())
+	s.Comparison.SetExportUseCase(s.Export)
+
+	// Create maintenance use case
+	s.Maintenance = usecase.NewMaintenanceUseCase(db, dbPath, historyRepo)
+
+	// Create diagnostics use case - bundles of the logs and settings for bug reports
+	s.Diagnostics = usecase.NewDiagnosticsUseCase(dirs.Home, dirs.LogDir(), dirs.ExportDir(), s.Settings)
+	s.Diagnostics.SetDatabase(db)
+	s.Diagnostics.SetHistoryUseCase(s.History)
+
+	// Create update use case - checks the GitHub releases for new versions
+	s.Update = usecase.NewUpdateUseCase(update.NewClient("", ""), dirs.UpdateDir(), s.Settings)
+
+	// Create demo use case - simulates runs without a database when demo mode is on
+	s.Demo = usecase.NewDemoUseCase(s.Settings, s.History)
+
+	// Write exports to the directory and file names configured in the settings
+	s.Export.SetExportConfig(s.Settings.GetExportConfig)
+	s.Comparison.SetExportConfig(s.Settings.GetExportConfig)
+
+	// Create access use case - the app lock restricts connections and cleanup to the admin role
+	s.Access = usecase.NewAccessUseCase(settingsRepo)
+	s.Conn.SetAccessControl(s.Access)
+	s.Benchmark.SetAccessControl(s.Access)
+
+	// Runs dispatched to load-generator agents
+	s.Benchmark.SetAgents(s.Settings.GetAgent, dirs.AgentKeyPath())
+
+	// Create notification use case - emails and webhooks about run lifecycle events
+	s.Notify = usecase.NewNotificationUseCase(s.Settings, keyringProvider, notify.NewSMTPMailer())
+	s.Notify.SetExportUseCase(s.Export)
+	s.Notify.SetWebhookPoster(notify.NewHTTPWebhookPoster())
+	s.Notify.SetHistoryUseCase(s.History)
+	s.Benchmark.SetRunStartedCallback(func(event usecase.RunEvent) {
+		go func() {
+			if err := s.Notify.NotifyRunStarted(context.Background(), event); err != nil {
+				slog.Warn("Failed to send run notification", "run_id", event.Run.ID, "error", err)
+			}
+		}()
+	})
+	s.Benchmark.SetRunFinishedCallback(func(event usecase.RunEvent) {
+		if err := s.Notify.NotifyRunFinished(context.Background(), event); err != nil {
+			slog.Warn("Failed to send run notification", "run_id", event.Run.ID, "error", err)
+		}
+	})
+
+	// Create suite use case - runs ordered benchmark steps and compares the results
+	s.Suite = usecase.NewSuiteUseCase(repository.NewSQLiteSuiteRepository(db), s.Benchmark, s.History)
+	s.Suite.SetComparisonUseCase(s.Comparison)
+
+	// Create repetition use case - repeats a task N times and aggregates the results
+	s.Repetition = usecase.NewRepetitionUseCase(s.Benchmark, s.History, repository.NewSQLiteAggregateRepository(db))
+
+	// Create race use case - runs a task against two connections at the same time
+	s.Race = usecase.NewRaceUseCase(s.Benchmark, s.History, repository.NewSQLiteRaceRepository(db))
+
+	// Start background history purge job
+	s.History.StartRetentionJob(ctx, s.Settings.GetHistoryConfig)
+
+	slog.Info("Use cases initialized")
+	return s, nil
+}
+
+// Close closes the database connections.
+func (s *Services) Close() {
+	if s.ReadDB != nil {
+		s.ReadDB.Close()
+	}
+	if s.DB != nil {
+		s.DB.Close()
+	}
+}
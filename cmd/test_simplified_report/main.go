@@ -129,7 +129,13 @@ func main() {
 		if report.Findings.ScalingKnee > 0 {
 			fmt.Printf("Scaling Knee: threads=%d\n", report.Findings.ScalingKnee)
 		}
+		for _, f := range report.Findings.Insights {
+			fmt.Printf("- %s: %s\n", f.Claim, f.Evidence)
+		}
 		fmt.Printf("Recommendation: %s\n", report.Findings.Recommendation)
+		for _, rec := range report.Findings.Recommendations {
+			fmt.Printf("- %s: %s\n", rec.Claim, rec.Evidence)
+		}
 	}
 
 	// Print thread groups
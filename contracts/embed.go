@@ -0,0 +1,20 @@
+// Package contracts embeds the built-in benchmark templates so the binaries
+// do not depend on the source tree at runtime.
+package contracts
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed templates/*.json
+var templates embed.FS
+
+// BuiltinTemplates returns the built-in template files (*.json at the root of the returned FS).
+func BuiltinTemplates() fs.FS {
+	sub, err := fs.Sub(templates, "templates")
+	if err != nil {
+		panic(err) // Only fails if the embed pattern above is changed
+	}
+	return sub
+}
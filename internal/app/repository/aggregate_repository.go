@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// AggregateRepository defines the interface for persisting the aggregates of repeated tasks.
+type AggregateRepository interface {
+	// SaveAggregate saves an aggregate; an existing aggregate with the same ID is replaced.
+	SaveAggregate(ctx context.Context, aggregate *history.Aggregate) error
+
+	// GetAggregate retrieves an aggregate by ID.
+	GetAggregate(ctx context.Context, id string) (*history.Aggregate, error)
+
+	// ListAggregates retrieves all aggregates, newest first.
+	ListAggregates(ctx context.Context) ([]*history.Aggregate, error)
+
+	// DeleteAggregate deletes an aggregate. The history records of its runs are kept.
+	DeleteAggregate(ctx context.Context, id string) error
+}
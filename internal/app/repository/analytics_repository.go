@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/analytics"
+)
+
+// AnalyticsRepository defines the interface for persisting analytical query runs.
+type AnalyticsRepository interface {
+	// SaveAnalyticsRun saves a run; an existing run with the same ID is replaced.
+	SaveAnalyticsRun(ctx context.Context, run *analytics.Run) error
+
+	// GetAnalyticsRun retrieves a run by ID.
+	GetAnalyticsRun(ctx context.Context, id string) (*analytics.Run, error)
+
+	// ListAnalyticsRuns retrieves all runs, newest first.
+	ListAnalyticsRuns(ctx context.Context) ([]*analytics.Run, error)
+
+	// DeleteAnalyticsRun deletes a run.
+	DeleteAnalyticsRun(ctx context.Context, id string) error
+}
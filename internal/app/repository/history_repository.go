@@ -3,17 +3,21 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
 )
 
+// ErrRecordNotFound is returned when a history record is not found.
+var ErrRecordNotFound = errors.New("history record not found")
+
 // HistoryRepository defines the interface for history record persistence.
 type HistoryRepository interface {
 	// Save saves a history record.
 	Save(ctx context.Context, record *history.Record) error
 
-	// GetByID retrieves a history record by ID.
+	// GetByID retrieves a history record by ID, or returns ErrRecordNotFound.
 	GetByID(ctx context.Context, id string) (*history.Record, error)
 
 	// GetAll retrieves all history records.
@@ -24,6 +28,18 @@ type HistoryRepository interface {
 
 	// List retrieves history records with pagination and filtering options.
 	List(ctx context.Context, opts *ListOptions) ([]*history.Record, error)
+
+	// Count returns the number of history records matching the filters in opts.
+	Count(ctx context.Context, opts *ListOptions) (int, error)
+
+	// UpdateAnnotations replaces the tags and notes of a history record.
+	UpdateAnnotations(ctx context.Context, id string, tags []string, notes string) error
+
+	// UpdateValidity replaces the sanity check outcome of a history record.
+	UpdateValidity(ctx context.Context, id string, validity *history.Validity) error
+
+	// CompactRecords recompresses stored record data and returns the number of records compacted.
+	CompactRecords(ctx context.Context) (int, error)
 }
 
 // ListOptions defines options for listing history records.
@@ -46,9 +62,22 @@ type ListOptions struct {
 	// DatabaseType filters by database type.
 	DatabaseType string
 
+	// Threads filters by thread count (0 = any).
+	Threads int
+
+	// Search matches connection or template names, purposes, tickets or
+	// environments containing this text.
+	Search string
+
 	// StartTimeAfter filters records with start time after this value.
 	StartTimeAfter *time.Time
 
 	// StartTimeBefore filters records with start time before this value.
 	StartTimeBefore *time.Time
+
+	// Tags filters records that carry all of these tags.
+	Tags []string
+
+	// ValidOnly skips records that failed their sanity checks.
+	ValidOnly bool
 }
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// RaceRepository defines the interface for persisting A/B races.
+type RaceRepository interface {
+	// SaveRace saves a race; an existing race with the same ID is replaced.
+	SaveRace(ctx context.Context, race *history.Race) error
+
+	// GetRace retrieves a race by ID.
+	GetRace(ctx context.Context, id string) (*history.Race, error)
+
+	// ListRaces retrieves all races, newest first.
+	ListRaces(ctx context.Context) ([]*history.Race, error)
+
+	// DeleteRace deletes a race. The history records of its runs are kept.
+	DeleteRace(ctx context.Context, id string) error
+}
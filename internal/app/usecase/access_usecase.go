@@ -0,0 +1,228 @@
+// Package usecase provides the app lock and role-based access control.
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/access"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/keyring"
+)
+
+// MinAppLockPasswordLength is the minimum length of an app lock PIN or password.
+const MinAppLockPasswordLength = 4
+
+var (
+	// ErrAppLocked is returned when a restricted operation is attempted
+	// before the app has been unlocked.
+	ErrAppLocked = errors.New("app is locked")
+
+	// ErrPermissionDenied is returned when the unlocked role lacks a permission.
+	ErrPermissionDenied = errors.New("permission denied")
+
+	// ErrWrongAppPassword is returned when a password unlocks no role.
+	ErrWrongAppPassword = errors.New("wrong app lock password")
+
+	// ErrInvalidAppPassword is returned when a new app lock password is rejected.
+	ErrInvalidAppPassword = errors.New("invalid app lock password")
+)
+
+// AccessChecker checks the permissions of the current session. Use cases
+// without one allow every operation.
+type AccessChecker interface {
+	Require(ctx context.Context, permission access.Permission) error
+}
+
+// requirePermission checks permission with checker, if one is set.
+func requirePermission(ctx context.Context, checker AccessChecker, permission access.Permission) error {
+	if checker == nil {
+		return nil
+	}
+	return checker.Require(ctx, permission)
+}
+
+// AccessUseCase provides the app lock: the session is unlocked with the
+// password of the admin or the operator role, and the other use cases check
+// the permissions of that role. While no admin password is set the app is
+// not locked and every session is admin.
+type AccessUseCase struct {
+	settingsRepo SettingsRepository
+
+	mu   sync.RWMutex
+	role access.Role // Role the session is unlocked as, empty while locked
+}
+
+// NewAccessUseCase creates a new access use case.
+func NewAccessUseCase(settingsRepo SettingsRepository) *AccessUseCase {
+	return &AccessUseCase{settingsRepo: settingsRepo}
+}
+
+// lockConfig returns the saved app lock configuration.
+func (uc *AccessUseCase) lockConfig(ctx context.Context) (*config.AppLockConfig, error) {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get config: %w", err)
+	}
+	return &cfg.AppLock, nil
+}
+
+// LockEnabled reports whether an admin password is set, so the app starts locked.
+func (uc *AccessUseCase) LockEnabled(ctx context.Context) (bool, error) {
+	lock, err := uc.lockConfig(ctx)
+	if err != nil {
+		return false, err
+	}
+	return lock.Enabled(), nil
+}
+
+// OperatorPasswordSet reports whether the operator role can be unlocked.
+func (uc *AccessUseCase) OperatorPasswordSet(ctx context.Context) (bool, error) {
+	lock, err := uc.lockConfig(ctx)
+	if err != nil {
+		return false, err
+	}
+	return lock.OperatorPassword != nil, nil
+}
+
+// Role returns the role of the session: admin while the app lock is disabled,
+// empty while the app is locked.
+func (uc *AccessUseCase) Role(ctx context.Context) (access.Role, error) {
+	enabled, err := uc.LockEnabled(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !enabled {
+		return access.RoleAdmin, nil
+	}
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+	return uc.role, nil
+}
+
+// Unlock unlocks the session with the password of a role and returns that role.
+func (uc *AccessUseCase) Unlock(ctx context.Context, password string) (access.Role, error) {
+	lock, err := uc.lockConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var role access.Role
+	switch {
+	case !lock.Enabled():
+		role = access.RoleAdmin
+	case keyring.VerifyPassword(lock.AdminPassword, password):
+		role = access.RoleAdmin
+	case keyring.VerifyPassword(lock.OperatorPassword, password):
+		role = access.RoleOperator
+	default:
+		slog.Warn("Access: Wrong app lock password")
+		return "", ErrWrongAppPassword
+	}
+
+	uc.mu.Lock()
+	uc.role = role
+	uc.mu.Unlock()
+	slog.Info("Access: Unlocked", "role", role)
+	return role, nil
+}
+
+// Lock locks the session until it is unlocked again.
+func (uc *AccessUseCase) Lock() {
+	uc.mu.Lock()
+	uc.role = ""
+	uc.mu.Unlock()
+	slog.Info("Access: Locked")
+}
+
+// Require returns nil if the session may perform an operation needing
+// permission, ErrAppLocked while locked and ErrPermissionDenied otherwise.
+func (uc *AccessUseCase) Require(ctx context.Context, permission access.Permission) error {
+	role, err := uc.Role(ctx)
+	if err != nil {
+		return err
+	}
+	if role == "" {
+		return ErrAppLocked
+	}
+	if !role.Can(permission) {
+		return fmt.Errorf("%w: the %s role cannot %s", ErrPermissionDenied, role, permission)
+	}
+	return nil
+}
+
+// SetPassword sets the password that unlocks role. Setting the first admin
+// password enables the app lock and keeps the session unlocked as admin;
+// the operator password can only be set once an admin password exists.
+func (uc *AccessUseCase) SetPassword(ctx context.Context, role access.Role, password string) error {
+	if err := uc.Require(ctx, access.PermissionManageAppLock); err != nil {
+		return err
+	}
+	if err := role.Validate(); err != nil {
+		return err
+	}
+	if role != access.RoleAdmin && role != access.RoleOperator {
+		return fmt.Errorf("%w: the app lock has no %s password", ErrInvalidAppPassword, role)
+	}
+	if len(password) < MinAppLockPasswordLength {
+		return fmt.Errorf("%w: the password must be at least %d characters", ErrInvalidAppPassword, MinAppLockPasswordLength)
+	}
+
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+	// Each password must identify one role
+	other := cfg.AppLock.OperatorPassword
+	if role == access.RoleOperator {
+		if !cfg.AppLock.Enabled() {
+			return fmt.Errorf("%w: set the admin password first", ErrInvalidAppPassword)
+		}
+		other = cfg.AppLock.AdminPassword
+	}
+	if keyring.VerifyPassword(other, password) {
+		return fmt.Errorf("%w: the admin and operator passwords must differ", ErrInvalidAppPassword)
+	}
+
+	hash, err := keyring.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("hash password: %w", err)
+	}
+	if role == access.RoleAdmin {
+		cfg.AppLock.AdminPassword = hash
+	} else {
+		cfg.AppLock.OperatorPassword = hash
+	}
+	if err := uc.settingsRepo.SaveConfig(ctx, cfg); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+
+	if role == access.RoleAdmin {
+		uc.mu.Lock()
+		uc.role = access.RoleAdmin
+		uc.mu.Unlock()
+	}
+	slog.Info("Access: App lock password set", "role", role)
+	return nil
+}
+
+// DisableLock removes the admin and operator passwords, so the app is no
+// longer locked.
+func (uc *AccessUseCase) DisableLock(ctx context.Context) error {
+	if err := uc.Require(ctx, access.PermissionManageAppLock); err != nil {
+		return err
+	}
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+	cfg.AppLock = config.AppLockConfig{}
+	if err := uc.settingsRepo.SaveConfig(ctx, cfg); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	slog.Info("Access: App lock disabled")
+	return nil
+}
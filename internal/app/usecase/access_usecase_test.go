@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/access"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+// TestAccessUseCase tests enabling the app lock and unlocking its roles.
+func TestAccessUseCase(t *testing.T) {
+	ctx := context.Background()
+	uc := NewAccessUseCase(newMockSettingsRepository(filepath.Join(t.TempDir(), "config.json")))
+
+	// Without an admin password every session is admin
+	if role, err := uc.Role(ctx); err != nil || role != access.RoleAdmin {
+		t.Fatalf("Role() = %q, %v, want admin", role, err)
+	}
+	if err := uc.SetPassword(ctx, access.RoleOperator, "op-pin"); !errors.Is(err, ErrInvalidAppPassword) {
+		t.Errorf("SetPassword(operator) before admin error = %v, want ErrInvalidAppPassword", err)
+	}
+	if err := uc.SetPassword(ctx, access.RoleAdmin, "123"); !errors.Is(err, ErrInvalidAppPassword) {
+		t.Errorf("SetPassword() with a short password error = %v, want ErrInvalidAppPassword", err)
+	}
+
+	if err := uc.SetPassword(ctx, access.RoleAdmin, "admin-pin"); err != nil {
+		t.Fatalf("SetPassword(admin) error = %v", err)
+	}
+	if enabled, _ := uc.LockEnabled(ctx); !enabled {
+		t.Error("LockEnabled() = false after setting the admin password")
+	}
+	if err := uc.SetPassword(ctx, access.RoleOperator, "admin-pin"); !errors.Is(err, ErrInvalidAppPassword) {
+		t.Errorf("SetPassword(operator) with the admin password error = %v, want ErrInvalidAppPassword", err)
+	}
+	if err := uc.SetPassword(ctx, access.RoleViewer, "viewer-pin"); !errors.Is(err, ErrInvalidAppPassword) {
+		t.Errorf("SetPassword(viewer) error = %v, want ErrInvalidAppPassword", err)
+	}
+	if err := uc.SetPassword(ctx, access.RoleOperator, "op-pin"); err != nil {
+		t.Fatalf("SetPassword(operator) error = %v", err)
+	}
+
+	uc.Lock()
+	if err := uc.Require(ctx, access.PermissionRunBenchmarks); !errors.Is(err, ErrAppLocked) {
+		t.Errorf("Require() while locked error = %v, want ErrAppLocked", err)
+	}
+	if _, err := uc.Unlock(ctx, "wrong"); !errors.Is(err, ErrWrongAppPassword) {
+		t.Errorf("Unlock(wrong) error = %v, want ErrWrongAppPassword", err)
+	}
+
+	if role, err := uc.Unlock(ctx, "op-pin"); err != nil || role != access.RoleOperator {
+		t.Fatalf("Unlock(operator) = %q, %v, want operator", role, err)
+	}
+	if err := uc.Require(ctx, access.PermissionRunBenchmarks); err != nil {
+		t.Errorf("operator Require(run benchmarks) error = %v", err)
+	}
+	if err := uc.Require(ctx, access.PermissionCleanup); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("operator Require(cleanup) error = %v, want ErrPermissionDenied", err)
+	}
+	if err := uc.DisableLock(ctx); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("operator DisableLock() error = %v, want ErrPermissionDenied", err)
+	}
+
+	if role, err := uc.Unlock(ctx, "admin-pin"); err != nil || role != access.RoleAdmin {
+		t.Fatalf("Unlock(admin) = %q, %v, want admin", role, err)
+	}
+	if err := uc.DisableLock(ctx); err != nil {
+		t.Fatalf("DisableLock() error = %v", err)
+	}
+	uc.Lock()
+	if err := uc.Require(ctx, access.PermissionCleanup); err != nil {
+		t.Errorf("Require() after disabling the lock error = %v", err)
+	}
+}
+
+// TestAccessUseCase_Enforced tests that the operator role cannot manage
+// connections or start a cleanup, while prepare and run remain allowed.
+func TestAccessUseCase_Enforced(t *testing.T) {
+	ctx := context.Background()
+	accessUC := NewAccessUseCase(newMockSettingsRepository(filepath.Join(t.TempDir(), "config.json")))
+	if err := accessUC.SetPassword(ctx, access.RoleAdmin, "admin-pin"); err != nil {
+		t.Fatalf("SetPassword(admin) error = %v", err)
+	}
+	if err := accessUC.SetPassword(ctx, access.RoleOperator, "op-pin"); err != nil {
+		t.Fatalf("SetPassword(operator) error = %v", err)
+	}
+	if _, err := accessUC.Unlock(ctx, "op-pin"); err != nil {
+		t.Fatalf("Unlock(operator) error = %v", err)
+	}
+
+	connRepo := NewMockConnectionRepository()
+	connUC := NewConnectionUseCase(connRepo, NewMockKeyring())
+	connUC.SetAccessControl(accessUC)
+	conn := &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "conn-1", Name: "Staging"},
+		Host:           "localhost",
+		Port:           3306,
+		Database:       "sbtest",
+		Username:       "root",
+	}
+	if err := connUC.CreateConnection(ctx, conn); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("operator CreateConnection() error = %v, want ErrPermissionDenied", err)
+	}
+	_ = connRepo.Save(ctx, conn)
+	if err := connUC.DeleteConnection(ctx, conn.ID); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("operator DeleteConnection() error = %v, want ErrPermissionDenied", err)
+	}
+
+	uc := NewBenchmarkUseCase(NewMemoryRunRepository(), adapter.NewAdapterRegistry(), connUC, nil)
+	uc.SetAccessControl(accessUC)
+	task := &execution.BenchmarkTask{
+		ID: "task", Name: "Task", ConnectionID: conn.ID, TemplateID: "sysbench-oltp-read-write",
+		Parameters: map[string]interface{}{"threads": 1, "time": 0},
+		Options:    execution.TaskOptions{SkipPrepare: true},
+		CreatedAt:  time.Now(),
+	}
+	if _, err := uc.StartBenchmark(ctx, task); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("operator cleanup StartBenchmark() error = %v, want ErrPermissionDenied", err)
+	}
+	if err := uc.requireTaskPermissions(ctx, map[string]interface{}{"threads": 1, "time": 60}, execution.TaskOptions{SkipCleanup: true}); err != nil {
+		t.Errorf("operator prepare and run denied: %v", err)
+	}
+}
@@ -0,0 +1,260 @@
+// Package usecase provides multi-agent load generation.
+// This file runs the workload on several load-generator agents at once and
+// aggregates their samples and results into one logical run.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+// agentGroup is a set of agents that generate load together.
+// Commands run through the remoteHost interface (prepare and cleanup) run on
+// the first agent only, since the agents share the benchmark data; the warmup
+// and run phases are started on every agent with startAgentGroup.
+type agentGroup []*agentHost
+
+func (g agentGroup) Name() string {
+	names := make([]string, len(g))
+	for i, h := range g {
+		names[i] = h.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+func (g agentGroup) Via() string { return "agents" }
+
+func (g agentGroup) Run(ctx context.Context, cmd *adapter.Command, stdout, stderr io.Writer) (int, error) {
+	return g[0].Run(ctx, cmd, stdout, stderr)
+}
+
+// LookPath resolves the executable on every agent and returns its path on the first.
+func (g agentGroup) LookPath(ctx context.Context, name string) (string, error) {
+	var path string
+	for _, h := range g {
+		p, err := h.LookPath(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", h.cfg.Name, err)
+		}
+		if path == "" {
+			path = p
+		}
+	}
+	return path, nil
+}
+
+// startAgentGroup starts cmd on every agent of the group at once.
+// The realtime samples of the agents are merged into one stream (see
+// sampleMerger) and their errors are prefixed with the agent name. The tool
+// output of each agent is returned, in group order, for the final results.
+// If an agent fails the others are stopped, since the aggregate would be
+// meaningless; the first error, or nil, is sent to done once every agent
+// has finished.
+func (uc *BenchmarkUseCase) startAgentGroup(
+	ctx context.Context,
+	run *execution.Run,
+	group agentGroup,
+	adapt adapter.BenchmarkAdapter,
+	cmd *adapter.Command,
+	done chan<- error,
+) (<-chan adapter.Sample, <-chan error, []*adapter.OutputBuffer) {
+	groupCtx, cancel := context.WithCancel(ctx)
+	uc.remoteMu.Lock()
+	uc.remoteCancels[run.ID] = cancel
+	uc.remoteMu.Unlock()
+
+	slog.Info("Benchmark: Starting command on agent group", "run_id", run.ID, "agents", group.Name())
+
+	merger := newSampleMerger(groupCtx, len(group))
+	errCh := make(chan error, 10)
+	outputs := make([]*adapter.OutputBuffer, len(group))
+	outputLog := uc.outputLog(run.ID)
+
+	var firstErr error
+	var errOnce sync.Once
+	var commands, collectors sync.WaitGroup
+	for i, host := range group {
+		pr, pw := io.Pipe()
+		stderr := newLineWriter(func(line string) {
+			uc.saveRemoteLog(ctx, run.ID, "stderr", fmt.Sprintf("[%s] %s", host.cfg.Name, line))
+		})
+
+		commands.Add(1)
+		go func() {
+			defer commands.Done()
+			code, err := host.Run(groupCtx, cmd, pw, stderr)
+			stderr.Flush()
+			if err == nil && code != 0 {
+				err = fmt.Errorf("remote exit code %d", code)
+			}
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = fmt.Errorf("agent %s: %w", host.cfg.Name, err)
+					slog.Error("Benchmark: Agent failed, stopping the group", "run_id", run.ID, "agent", host.cfg.Name, "error", err)
+					cancel()
+				})
+			}
+			pw.CloseWithError(err)
+		}()
+
+		// Count the errors the tool reports; the output log labels each line
+		// with the agent it came from
+		errorLines := uc.errorCounter(run.ID).lines()
+		var stdout io.Reader = io.TeeReader(pr, errorLines)
+		var logLines *lineWriter
+		if outputLog != nil {
+			logLines = newLineWriter(func(line string) {
+				fmt.Fprintf(outputLog, "[%s] %s\n", host.cfg.Name, line)
+			})
+			stdout = io.TeeReader(stdout, logLines)
+		}
+
+		samples, toolErrs, output := adapt.StartRealtimeCollection(groupCtx, stdout)
+		outputs[i] = output
+		collectors.Add(2)
+		go func() {
+			defer collectors.Done()
+			for sample := range samples {
+				merger.add(i, sample)
+			}
+			merger.finish(i)
+			errorLines.Flush()
+			if logLines != nil {
+				logLines.Flush()
+			}
+		}()
+		go func() {
+			defer collectors.Done()
+			for err := range toolErrs {
+				errCh <- fmt.Errorf("%s: %w", host.cfg.Name, err)
+			}
+		}()
+	}
+
+	go func() {
+		collectors.Wait()
+		close(errCh)
+	}()
+	go func() {
+		commands.Wait()
+		uc.remoteMu.Lock()
+		delete(uc.remoteCancels, run.ID)
+		uc.remoteMu.Unlock()
+		cancel()
+		done <- firstErr
+	}()
+
+	return merger.out, errCh, outputs
+}
+
+// sampleMerger merges the realtime samples of the agents of a group.
+// The k-th samples of the agents form the k-th merged sample, which is emitted
+// once every agent has reported its k-th sample or finished. The agents start
+// together and report at the same interval, so their k-th samples cover the
+// same period.
+type sampleMerger struct {
+	ctx      context.Context
+	mu       sync.Mutex
+	pending  [][]adapter.Sample // Samples not merged yet, per agent
+	finished []bool
+	running  int // Agents that have not finished
+	out      chan adapter.Sample
+}
+
+// newSampleMerger creates a merger for the given number of agents.
+// Sending merged samples stops when ctx is cancelled.
+func newSampleMerger(ctx context.Context, agents int) *sampleMerger {
+	return &sampleMerger{
+		ctx:      ctx,
+		pending:  make([][]adapter.Sample, agents),
+		finished: make([]bool, agents),
+		running:  agents,
+		out:      make(chan adapter.Sample, 10),
+	}
+}
+
+// add queues a sample of an agent.
+func (m *sampleMerger) add(agent int, sample adapter.Sample) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending[agent] = append(m.pending[agent], sample)
+	m.flush()
+}
+
+// finish marks an agent as finished. The output closes after the last agent.
+func (m *sampleMerger) finish(agent int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.finished[agent] = true
+	m.running--
+	m.flush()
+	if m.running == 0 {
+		close(m.out)
+	}
+}
+
+// flush emits the merged samples that are complete. m.mu must be held.
+func (m *sampleMerger) flush() {
+	for {
+		var interval []adapter.Sample
+		for i, queue := range m.pending {
+			if len(queue) == 0 {
+				if !m.finished[i] {
+					return
+				}
+				continue
+			}
+			interval = append(interval, queue[0])
+		}
+		if len(interval) == 0 {
+			return
+		}
+		for i := range m.pending {
+			if len(m.pending[i]) > 0 {
+				m.pending[i] = m.pending[i][1:]
+			}
+		}
+
+		merged := adapter.MergeSamples(interval)
+		merged.RawLine = mergedReportLine(merged, interval)
+		select {
+		case m.out <- merged:
+		case <-m.ctx.Done():
+		}
+	}
+}
+
+// mergedReportLine formats a merged sample like a sysbench report line,
+// keeping the elapsed second of the agents' lines for the monitor.
+func mergedReportLine(merged adapter.Sample, interval []adapter.Sample) string {
+	line := fmt.Sprintf("agents: %d thds: %d tps: %.2f qps: %.2f lat (ms,avg): %.2f lat (ms,95%%): %.2f",
+		len(interval), merged.ThreadCount, merged.TPS, merged.QPS, merged.LatencyAvg, merged.LatencyP95)
+	if second := reportSecondPattern.FindString(interval[0].RawLine); second != "" {
+		return second + " " + line
+	}
+	return line
+}
+
+// parseFinalResults parses the final results from the tool output of each
+// load generator and merges them.
+func parseFinalResults(ctx context.Context, adapt adapter.BenchmarkAdapter, outputs []*adapter.OutputBuffer) (*adapter.FinalResult, error) {
+	if len(outputs) == 1 {
+		return outputs[0].FinalResults(ctx, adapt)
+	}
+	results := make([]*adapter.FinalResult, 0, len(outputs))
+	for i, output := range outputs {
+		result, err := output.FinalResults(ctx, adapt)
+		if err != nil {
+			return nil, fmt.Errorf("load generator %d: %w", i+1, err)
+		}
+		results = append(results, result)
+	}
+	return adapter.MergeFinalResults(results), nil
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+// TestSampleMerger tests that the k-th samples of the agents are merged once
+// every agent has reported or finished.
+func TestSampleMerger(t *testing.T) {
+	m := newSampleMerger(context.Background(), 2)
+
+	m.add(0, adapter.Sample{TPS: 100, ThreadCount: 4, RawLine: "[ 1s ] thds: 4 tps: 100.00"})
+	if len(m.out) != 0 {
+		t.Fatal("merged a sample before every agent reported")
+	}
+	m.add(1, adapter.Sample{TPS: 50, ThreadCount: 4, RawLine: "[ 1s ] thds: 4 tps: 50.00"})
+	first := <-m.out
+	if first.TPS != 150 || first.ThreadCount != 8 {
+		t.Errorf("first sample TPS = %v, threads = %d, want 150, 8", first.TPS, first.ThreadCount)
+	}
+	if !strings.HasPrefix(first.RawLine, "[ 1s ] agents: 2 thds: 8 tps: 150.00") {
+		t.Errorf("first sample RawLine = %q", first.RawLine)
+	}
+
+	// A finished agent no longer holds back the others
+	m.add(0, adapter.Sample{TPS: 110, RawLine: "[ 2s ] thds: 4 tps: 110.00"})
+	m.finish(1)
+	if second := <-m.out; second.TPS != 110 {
+		t.Errorf("second sample TPS = %v, want 110", second.TPS)
+	}
+	m.finish(0)
+	if _, ok := <-m.out; ok {
+		t.Error("output not closed after the last agent finished")
+	}
+}
+
+// TestResolveRemoteHost_AgentGroup tests that several agents resolve to a group.
+func TestResolveRemoteHost_AgentGroup(t *testing.T) {
+	uc := &BenchmarkUseCase{}
+	uc.SetAgents(func(ctx context.Context, name string) (*config.AgentConfig, error) {
+		return &config.AgentConfig{Name: name, Address: name + ":7422", HostKey: "SHA256:x"}, nil
+	}, "agent_key")
+
+	host, err := uc.resolveRemoteHost(context.Background(), &connection.MySQLConnection{}, execution.TaskOptions{Agents: []string{"lg1", "lg2"}})
+	if err != nil {
+		t.Fatalf("resolveRemoteHost() error = %v", err)
+	}
+	group, ok := host.(agentGroup)
+	if !ok || len(group) != 2 {
+		t.Fatalf("resolveRemoteHost() = %T, want a group of 2 agents", host)
+	}
+	if host.Name() != "lg1 (lg1:7422), lg2 (lg2:7422)" || host.Via() != "agents" {
+		t.Errorf("group = %s via %s", host.Name(), host.Via())
+	}
+
+	host, err = uc.resolveRemoteHost(context.Background(), &connection.MySQLConnection{}, execution.TaskOptions{Agents: []string{"lg1"}})
+	if _, ok := host.(*agentHost); err != nil || !ok {
+		t.Errorf("resolveRemoteHost() with one agent = %T, %v, want *agentHost", host, err)
+	}
+}
+
+// TestStartAgentGroup runs a sysbench-like command on two local agents and
+// checks the aggregated samples and final results.
+func TestStartAgentGroup(t *testing.T) {
+	group := agentGroup{startLocalAgent(t, "lg1"), startLocalAgent(t, "lg2")}
+	uc := &BenchmarkUseCase{remoteCancels: make(map[string]context.CancelFunc)}
+	uc.SetArtifactDir(t.TempDir())
+	run := &execution.Run{ID: "run-1"}
+	uc.openOutputLog(run)
+
+	if _, err := group.LookPath(context.Background(), "sh"); err != nil {
+		t.Fatalf("LookPath(sh) error = %v", err)
+	}
+
+	script := `echo "[ 1s ] thds: 4 tps: 100.00 qps: 2000.00 (r/w/o: 1400.00/400.00/200.00) lat (ms,95%): 10.00 err/s: 0.00 reconn/s: 0.00"
+echo "[ 2s ] thds: 4 tps: 120.00 qps: 2400.00 (r/w/o: 1680.00/480.00/240.00) lat (ms,95%): 12.00 err/s: 0.00 reconn/s: 0.00"
+echo "    transactions:                        220    (110.00 per sec.)"`
+	cmd := &adapter.Command{CmdLine: "sh -c '" + script + "'"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	samples, errs, outputs := uc.startAgentGroup(ctx, run, group, adapter.NewSysbenchAdapter(), cmd, done)
+	go func() {
+		for range errs {
+		}
+	}()
+
+	var tps []float64
+	for sample := range samples {
+		tps = append(tps, sample.TPS)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("agent group error = %v", err)
+	}
+	if len(tps) != 2 || tps[0] != 200 || tps[1] != 240 {
+		t.Errorf("merged TPS = %v, want [200 240]", tps)
+	}
+	if len(outputs) != 2 || !strings.Contains(outputs[1].String(), "[ 2s ]") {
+		t.Errorf("outputs = %v", outputs)
+	}
+	uc.closeOutputLog(run.ID)
+	dir, _ := RunArtifactDir(uc.artifactDir, run.ID)
+	if out, err := readRotatedLog(filepath.Join(dir, ArtifactOutputLog)); err != nil || !strings.Contains(out, "[lg1] [ 2s ]") || !strings.Contains(out, "[lg2] [ 2s ]") {
+		t.Errorf("output log = %q, %v, want the lines of each agent labelled", out, err)
+	}
+	if uc.cancelRemoteCommand(run.ID) {
+		t.Error("group cancel still registered after the agents finished")
+	}
+}
@@ -0,0 +1,396 @@
+// Package usecase provides the analytical query benchmark business logic.
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/analytics"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+var (
+	// ErrAnalyticsNotSupported is returned for connections whose database the
+	// analytical queries do not support.
+	ErrAnalyticsNotSupported = errors.New("analytical benchmarks support MySQL and PostgreSQL only")
+	// ErrNoAnalyticsDataset is returned when the database has no analytical data set.
+	ErrNoAnalyticsDataset = errors.New("no analytical data set")
+)
+
+const (
+	// defaultAnalyticsDatabase is the database of the data set when neither the
+	// task nor the connection names one.
+	defaultAnalyticsDatabase = "tpch"
+	// analyticsBatchRows is the number of rows of one INSERT of the loader.
+	analyticsBatchRows = 500
+	// analyticsSeed seeds the generator, so that every load of a scale factor
+	// loads the same rows.
+	analyticsSeed = 19920101
+	// maxAnalyticsStreams bounds the query streams of a run.
+	maxAnalyticsStreams = 64
+)
+
+// AnalyticsOptions are the options of a run of the analytical queries.
+type AnalyticsOptions struct {
+	ConnectionID string
+	Database     string        // Database of the data set; default the connection's, or tpch
+	Name         string        // Label of the run (optional)
+	Concurrency  int           // Query streams run at the same time; default 1, sequential
+	Queries      []int         // Query numbers each stream runs; default all 22
+	QueryTimeout time.Duration // Timeout of each query; 0 for none
+}
+
+// AnalyticsLoadProgress is called after each batch of rows a load inserts,
+// with the rows of table inserted so far.
+type AnalyticsLoadProgress func(table string, rows int64)
+
+// AnalyticsQueryDone is called when a query execution of a run has finished.
+type AnalyticsQueryDone func(result analytics.QueryResult)
+
+// AnalyticsUseCase loads the TPC-H-like data set into a database, runs the
+// analytical queries against it and compares the per-query latencies of runs.
+// It needs no external tool: the data is generated and the queries are run
+// through database/sql.
+type AnalyticsUseCase struct {
+	connUC *ConnectionUseCase
+	repo   repository.AnalyticsRepository
+}
+
+// NewAnalyticsUseCase creates a new analytics use case.
+func NewAnalyticsUseCase(connUC *ConnectionUseCase, repo repository.AnalyticsRepository) *AnalyticsUseCase {
+	return &AnalyticsUseCase{
+		connUC: connUC,
+		repo:   repo,
+	}
+}
+
+// LoadDataset loads the data set at scaleFactor into database (default the
+// connection's) of a connection, replacing its tables if they exist.
+func (uc *AnalyticsUseCase) LoadDataset(ctx context.Context, connectionID, database string, scaleFactor float64, progress AnalyticsLoadProgress) error {
+	if scaleFactor <= 0 || scaleFactor > 1000 {
+		return fmt.Errorf("invalid scale factor %g (want more than 0, at most 1000)", scaleFactor)
+	}
+	conn, dbName, db, err := uc.openDatabase(ctx, connectionID, database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	slog.Info("Analytics: Loading data set", "connection", conn.GetName(), "database", dbName, "scale_factor", scaleFactor)
+	start := time.Now()
+	if err := loadAnalyticsDataset(ctx, db, analyticsPlaceholder(conn.GetType()), scaleFactor, progress); err != nil {
+		return err
+	}
+	slog.Info("Analytics: Data set loaded", "connection", conn.GetName(), "database", dbName, "duration", time.Since(start))
+	return nil
+}
+
+// RunQueries runs the analytical queries against the data set of a connection
+// and saves the run. Each stream runs the queries once, all streams at the
+// same time; a failed query is recorded and the stream goes on with the next.
+// The run is saved even if ctx is cancelled, which stops the streams.
+func (uc *AnalyticsUseCase) RunQueries(ctx context.Context, opts AnalyticsOptions, onQuery AnalyticsQueryDone) (*analytics.Run, error) {
+	if opts.Concurrency == 0 {
+		opts.Concurrency = 1
+	}
+	if opts.Concurrency < 1 || opts.Concurrency > maxAnalyticsStreams {
+		return nil, fmt.Errorf("invalid concurrency %d (want 1 to %d)", opts.Concurrency, maxAnalyticsStreams)
+	}
+	if len(opts.Queries) == 0 {
+		opts.Queries = analytics.AllQueries()
+	}
+
+	conn, dbName, db, err := uc.openDatabase(ctx, opts.ConnectionID, opts.Database)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(opts.Concurrency)
+	db.SetMaxIdleConns(opts.Concurrency)
+
+	scaleFactor, err := analyticsScaleFactor(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("%w in %s: %v (load it first)", ErrNoAnalyticsDataset, dbName, err)
+	}
+	all := analytics.Queries(scaleFactor)
+	queries := make([]analytics.Query, 0, len(opts.Queries))
+	for _, n := range opts.Queries {
+		if n < 1 || n > analytics.QueryCount {
+			return nil, fmt.Errorf("invalid query %d (want 1 to %d)", n, analytics.QueryCount)
+		}
+		queries = append(queries, all[n-1])
+	}
+
+	run := &analytics.Run{
+		ID:             uuid.New().String(),
+		Name:           opts.Name,
+		ConnectionName: conn.GetName(),
+		DatabaseType:   string(conn.GetType()),
+		Database:       dbName,
+		ScaleFactor:    scaleFactor,
+		Concurrency:    opts.Concurrency,
+		Queries:        opts.Queries,
+		StartedAt:      time.Now(),
+	}
+	slog.Info("Analytics: Run started", "run_id", run.ID, "connection", run.ConnectionName, "database", dbName,
+		"scale_factor", scaleFactor, "streams", opts.Concurrency, "queries", len(queries))
+
+	run.Results = runAnalyticsQueries(ctx, db, queries, opts.Concurrency, opts.QueryTimeout, onQuery)
+	run.Duration = time.Since(run.StartedAt)
+	switch {
+	case ctx.Err() != nil:
+		run.State = execution.StateCancelled
+	case len(run.Results) > 0 && run.Errors() == len(run.Results):
+		run.State = execution.StateFailed
+		run.ErrorMessage = run.Results[0].Error
+	default:
+		run.State = execution.StateCompleted
+	}
+	slog.Info("Analytics: Run finished", "run_id", run.ID, "state", run.State, "duration", run.Duration,
+		"geomean", run.GeoMean(), "errors", run.Errors())
+
+	if err := uc.repo.SaveAnalyticsRun(context.WithoutCancel(ctx), run); err != nil {
+		return run, fmt.Errorf("save analytical run: %w", err)
+	}
+	return run, nil
+}
+
+// ListRuns returns all analytical runs, newest first.
+func (uc *AnalyticsUseCase) ListRuns(ctx context.Context) ([]*analytics.Run, error) {
+	return uc.repo.ListAnalyticsRuns(ctx)
+}
+
+// GetRun returns an analytical run.
+func (uc *AnalyticsUseCase) GetRun(ctx context.Context, id string) (*analytics.Run, error) {
+	return uc.repo.GetAnalyticsRun(ctx, id)
+}
+
+// DeleteRun deletes an analytical run.
+func (uc *AnalyticsUseCase) DeleteRun(ctx context.Context, id string) error {
+	return uc.repo.DeleteAnalyticsRun(ctx, id)
+}
+
+// CompareRuns compares the per-query latencies of runs, the first being the baseline.
+func (uc *AnalyticsUseCase) CompareRuns(ctx context.Context, ids []string) (*analytics.Comparison, error) {
+	runs := make([]*analytics.Run, 0, len(ids))
+	for _, id := range ids {
+		run, err := uc.repo.GetAnalyticsRun(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("get analytical run %s: %w", id, err)
+		}
+		runs = append(runs, run)
+	}
+	return analytics.Compare(runs)
+}
+
+// openDatabase opens the database of the data set of a connection.
+func (uc *AnalyticsUseCase) openDatabase(ctx context.Context, connectionID, database string) (connection.Connection, string, *sql.DB, error) {
+	conn, err := uc.connUC.GetConnectionByID(ctx, connectionID)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("get connection: %w", err)
+	}
+	switch conn.GetType() {
+	case connection.DatabaseTypeMySQL, connection.DatabaseTypePostgreSQL:
+	default:
+		return nil, "", nil, fmt.Errorf("%w: %s", ErrAnalyticsNotSupported, conn.GetType())
+	}
+
+	dbName := database
+	if dbName == "" {
+		dbName = benchmarkDatabase(conn, map[string]interface{}{"db_name": defaultAnalyticsDatabase})
+	}
+	dsn, err := calibrationDSN(conn, dbName)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	db, err := sql.Open(calibrations[conn.GetType()].driver, dsn)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("open database: %w", err)
+	}
+	return conn, dbName, db, nil
+}
+
+// analyticsPlaceholder returns the bind parameter n (from 1) of dbType.
+func analyticsPlaceholder(dbType connection.DatabaseType) func(n int) string {
+	if dbType == connection.DatabaseTypePostgreSQL {
+		return func(n int) string { return fmt.Sprintf("$%d", n) }
+	}
+	return func(int) string { return "?" }
+}
+
+// loadAnalyticsDataset replaces the tables of the data set in db with the
+// rows generated at scaleFactor, then creates the secondary indexes.
+func loadAnalyticsDataset(ctx context.Context, db *sql.DB, placeholder func(n int) string, scaleFactor float64, progress AnalyticsLoadProgress) error {
+	for i := len(analytics.Tables) - 1; i >= 0; i-- {
+		if _, err := db.ExecContext(ctx, "DROP TABLE IF EXISTS "+analytics.Tables[i].Name); err != nil {
+			return fmt.Errorf("drop table %s: %w", analytics.Tables[i].Name, err)
+		}
+	}
+	batches := make(map[string]*analyticsBatch, len(analytics.Tables))
+	for _, table := range analytics.Tables {
+		if _, err := db.ExecContext(ctx, table.DDL); err != nil {
+			return fmt.Errorf("create table %s: %w", table.Name, err)
+		}
+		batches[table.Name] = &analyticsBatch{table: table, placeholder: placeholder}
+	}
+
+	flush := func(b *analyticsBatch) error {
+		if err := b.flush(ctx, db); err != nil {
+			return err
+		}
+		if progress != nil {
+			progress(b.table.Name, b.inserted)
+		}
+		return nil
+	}
+	err := analytics.NewGenerator(scaleFactor, analyticsSeed).Generate(func(table string, row []any) error {
+		b := batches[table]
+		b.rows = append(b.rows, row)
+		if len(b.rows) < analyticsBatchRows {
+			return nil
+		}
+		return flush(b)
+	})
+	if err != nil {
+		return err
+	}
+	for _, table := range analytics.Tables {
+		if b := batches[table.Name]; len(b.rows) > 0 {
+			if err := flush(b); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, index := range analytics.Indexes {
+		if _, err := db.ExecContext(ctx, index); err != nil {
+			return fmt.Errorf("create index: %w", err)
+		}
+	}
+	return nil
+}
+
+// analyticsBatch collects the rows of a table for one multi-row INSERT.
+type analyticsBatch struct {
+	table       analytics.Table
+	placeholder func(n int) string
+	rows        [][]any
+	inserted    int64
+}
+
+// flush inserts the collected rows.
+func (b *analyticsBatch) flush(ctx context.Context, db *sql.DB) error {
+	var query strings.Builder
+	fmt.Fprintf(&query, "INSERT INTO %s (%s) VALUES ", b.table.Name, strings.Join(b.table.Columns, ", "))
+	args := make([]any, 0, len(b.rows)*len(b.table.Columns))
+	for i, row := range b.rows {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteByte('(')
+		for j, value := range row {
+			if j > 0 {
+				query.WriteString(", ")
+			}
+			args = append(args, value)
+			query.WriteString(b.placeholder(len(args)))
+		}
+		query.WriteByte(')')
+	}
+	if _, err := db.ExecContext(ctx, query.String(), args...); err != nil {
+		return fmt.Errorf("insert into %s: %w", b.table.Name, err)
+	}
+	b.inserted += int64(len(b.rows))
+	b.rows = b.rows[:0]
+	return nil
+}
+
+// analyticsScaleFactor returns the scale factor of the data set in db,
+// derived from its suppliers.
+func analyticsScaleFactor(ctx context.Context, db *sql.DB) (float64, error) {
+	var suppliers int64
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM supplier").Scan(&suppliers); err != nil {
+		return 0, err
+	}
+	if suppliers == 0 {
+		return 0, errors.New("the supplier table is empty")
+	}
+	return float64(suppliers) / 10_000, nil
+}
+
+// runAnalyticsQueries runs queries in streams at the same time and returns
+// the executions in completion order. Stream s starts at query s of the list
+// and wraps around, so the streams do not run the same query at once. The
+// executions a cancelled ctx interrupts are left out.
+func runAnalyticsQueries(ctx context.Context, db *sql.DB, queries []analytics.Query, streams int, timeout time.Duration, onQuery AnalyticsQueryDone) []analytics.QueryResult {
+	var (
+		mu      sync.Mutex
+		results []analytics.QueryResult
+		wg      sync.WaitGroup
+	)
+	for stream := 1; stream <= streams; stream++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queries {
+				if ctx.Err() != nil {
+					return
+				}
+				query := queries[(stream-1+i)%len(queries)]
+				result := runAnalyticsQuery(ctx, db, query, timeout)
+				if ctx.Err() != nil {
+					return
+				}
+				result.Stream = stream
+
+				mu.Lock()
+				results = append(results, result)
+				if onQuery != nil {
+					onQuery(result)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// runAnalyticsQuery runs a query and reads all its rows.
+func runAnalyticsQuery(ctx context.Context, db *sql.DB, query analytics.Query, timeout time.Duration) analytics.QueryResult {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result := analytics.QueryResult{Query: query.Number}
+	start := time.Now()
+	rows, err := db.QueryContext(ctx, query.SQL)
+	if err == nil {
+		for rows.Next() {
+			result.Rows++
+		}
+		err = rows.Err()
+		rows.Close()
+	}
+	result.Latency = time.Since(start)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+		result.Error = err.Error()
+		slog.Warn("Analytics: Query failed", "query", query.Number, "error", err)
+	}
+	return result
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/analytics"
+)
+
+// openAnalyticsTestDB opens a SQLite database file, which every connection of
+// the pool shares, unlike an in-memory one.
+func openAnalyticsTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "tpch.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// TestLoadAnalyticsDataset tests loading and reloading the data set.
+func TestLoadAnalyticsDataset(t *testing.T) {
+	ctx := context.Background()
+	db := openAnalyticsTestDB(t)
+	const sf = 0.001
+
+	loaded := make(map[string]int64)
+	progress := func(table string, rows int64) { loaded[table] = rows }
+	for range 2 {
+		if err := loadAnalyticsDataset(ctx, db, analyticsPlaceholder(""), sf, progress); err != nil {
+			t.Fatalf("loadAnalyticsDataset() failed: %v", err)
+		}
+	}
+
+	for table, want := range analytics.RowCounts(sf) {
+		var got int
+		if err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&got); err != nil {
+			t.Fatalf("count %s: %v", table, err)
+		}
+		if got != want || loaded[table] != int64(want) {
+			t.Errorf("%s has %d rows, progress %d, want %d", table, got, loaded[table], want)
+		}
+	}
+
+	got, err := analyticsScaleFactor(ctx, db)
+	if err != nil {
+		t.Fatalf("analyticsScaleFactor() failed: %v", err)
+	}
+	if want := float64(analytics.RowCounts(sf)["supplier"]) / 10_000; got != want {
+		t.Errorf("analyticsScaleFactor() = %v, want %v", got, want)
+	}
+}
+
+// TestRunAnalyticsQueries tests the query streams and failed queries.
+func TestRunAnalyticsQueries(t *testing.T) {
+	ctx := context.Background()
+	db := openAnalyticsTestDB(t)
+	if _, err := db.Exec("CREATE TABLE t (v INTEGER); INSERT INTO t VALUES (1), (2), (3)"); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	queries := []analytics.Query{
+		{Number: 1, SQL: "SELECT v FROM t"},
+		{Number: 2, SQL: "SELECT v FROM missing"},
+		{Number: 3, SQL: "SELECT SUM(v) FROM t"},
+	}
+	var notified int
+	results := runAnalyticsQueries(ctx, db, queries, 2, time.Minute, func(analytics.QueryResult) { notified++ })
+
+	if len(results) != 6 || notified != 6 {
+		t.Fatalf("got %d results, %d notifications, want 6 (3 queries x 2 streams)", len(results), notified)
+	}
+	first := make(map[int]int)
+	for _, r := range results {
+		if _, ok := first[r.Stream]; !ok {
+			first[r.Stream] = r.Query
+		}
+		switch r.Query {
+		case 1:
+			if r.Rows != 3 || r.Error != "" {
+				t.Errorf("Q1 = %+v, want 3 rows", r)
+			}
+		case 2:
+			if !strings.Contains(r.Error, "missing") {
+				t.Errorf("Q2 error = %q, want no such table", r.Error)
+			}
+		case 3:
+			if r.Rows != 1 || r.Error != "" {
+				t.Errorf("Q3 = %+v, want 1 row", r)
+			}
+		}
+	}
+	if first[1] != 1 || first[2] != 2 {
+		t.Errorf("streams started with queries %v, want stream 1 at Q1 and stream 2 at Q2", first)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if results := runAnalyticsQueries(cancelled, db, queries, 1, 0, nil); len(results) != 0 {
+		t.Errorf("cancelled run returned %d results, want none", len(results))
+	}
+}
@@ -0,0 +1,456 @@
+// Package usecase provides backup and restore of the application state.
+package usecase
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/keyring"
+)
+
+// backupFormatVersion is the version of the archive layout written by Create.
+const backupFormatVersion = 1
+
+// Archive entries. Files of the data directory keep their relative path.
+const (
+	backupManifestEntry = "manifest.json"
+	backupSecretsEntry  = "secrets.sealed"
+	backupDBEntry       = "db-benchmind.db"
+	backupConfigEntry   = "config.json"
+	backupRunsEntry     = "runs"
+)
+
+// setAsideSuffix is appended to files replaced by a restore.
+const setAsideSuffix = ".before-restore"
+
+// ErrBackupTargetExists is returned when restoring over existing data without force.
+var ErrBackupTargetExists = errors.New("data directory already contains a database")
+
+// BackupPaths locates the state that is backed up.
+type BackupPaths struct {
+	DataDir    string // Directory of the database, settings and keyring files
+	DBPath     string // SQLite database (connections, templates, history, suites, ...)
+	ConfigPath string // Settings file
+	RunsDir    string // Run artifacts
+}
+
+// BackupManifest describes the content of a backup archive.
+type BackupManifest struct {
+	Version       int       `json:"version"`        // Archive format version
+	CreatedAt     time.Time `json:"created_at"`     // When the backup was created
+	SchemaVersion int       `json:"schema_version"` // Database schema version
+	Connections   int       `json:"connections"`    // Saved connections
+	Secrets       int       `json:"secrets"`        // Saved passwords in the archive (0 if excluded)
+	Settings      bool      `json:"settings"`       // Whether the settings file is included
+	RunFiles      int       `json:"run_files"`      // Run artifact files
+}
+
+// RestoreResult is the outcome of a restore.
+type RestoreResult struct {
+	Manifest *BackupManifest
+	Secrets  int      // Passwords written to the keyring
+	SetAside []string // Existing files renamed with setAsideSuffix
+}
+
+// BackupUseCase creates and restores single-file archives of the application
+// state: the database, the settings file, run artifacts and the saved
+// passwords. Passwords are re-encrypted with a backup password so the archive
+// can be restored into another machine's keyring.
+type BackupUseCase struct {
+	paths   BackupPaths
+	keyring keyring.Provider
+}
+
+// NewBackupUseCase creates a new backup use case.
+func NewBackupUseCase(paths BackupPaths, keyring keyring.Provider) *BackupUseCase {
+	return &BackupUseCase{
+		paths:   paths,
+		keyring: keyring,
+	}
+}
+
+// Create writes a backup archive to dest. db is the open database, connRepo
+// lists the connections whose passwords are saved. With an empty password
+// the saved passwords are left out.
+func (uc *BackupUseCase) Create(ctx context.Context, db *sql.DB, connRepo ConnectionRepository, dest, password string) (*BackupManifest, error) {
+	if _, err := os.Stat(dest); err == nil {
+		return nil, fmt.Errorf("backup file %s already exists", dest)
+	}
+
+	conns, err := connRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list connections: %w", err)
+	}
+	schemaVersion, err := database.SchemaVersion(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &BackupManifest{
+		Version:       backupFormatVersion,
+		CreatedAt:     time.Now(),
+		SchemaVersion: schemaVersion,
+		Connections:   len(conns),
+	}
+
+	// Saved passwords, sealed with the backup password
+	var sealed []byte
+	if password != "" {
+		keys := []string{NotificationPasswordKey}
+		for _, conn := range conns {
+			keys = append(keys, conn.GetID(), conn.GetID()+":ssh", conn.GetID()+":winrm")
+		}
+		secrets := make(map[string]string)
+		for _, key := range keys {
+			secret, err := uc.keyring.Get(ctx, key)
+			if keyring.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("read saved password %s: %w", key, err)
+			}
+			secrets[key] = secret
+		}
+		data, err := json.Marshal(secrets)
+		if err != nil {
+			return nil, fmt.Errorf("marshal secrets: %w", err)
+		}
+		if sealed, err = keyring.Seal(password, data); err != nil {
+			return nil, fmt.Errorf("seal secrets: %w", err)
+		}
+		manifest.Secrets = len(secrets)
+	}
+
+	// Consistent copy of the database
+	tmpDir, err := os.MkdirTemp("", "db-benchmind-backup-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	snapshot := filepath.Join(tmpDir, backupDBEntry)
+	if err := database.Snapshot(ctx, db, snapshot); err != nil {
+		return nil, err
+	}
+
+	_, err = os.Stat(uc.paths.ConfigPath)
+	manifest.Settings = err == nil
+	runFiles, err := listFiles(uc.paths.RunsDir)
+	if err != nil {
+		return nil, fmt.Errorf("list run artifacts: %w", err)
+	}
+	manifest.RunFiles = len(runFiles)
+
+	// Write beside dest and rename, so a failed backup leaves no partial archive
+	tmpDest := dest + ".tmp"
+	if err := uc.writeArchive(tmpDest, manifest, sealed, snapshot, runFiles); err != nil {
+		os.Remove(tmpDest)
+		return nil, err
+	}
+	if err := os.Rename(tmpDest, dest); err != nil {
+		os.Remove(tmpDest)
+		return nil, fmt.Errorf("rename backup file: %w", err)
+	}
+
+	slog.Info("Backup: Created", "file", dest, "schema_version", schemaVersion,
+		"connections", manifest.Connections, "secrets", manifest.Secrets, "run_files", manifest.RunFiles)
+	return manifest, nil
+}
+
+// writeArchive writes the gzip-compressed tar archive of a backup.
+func (uc *BackupUseCase) writeArchive(dest string, manifest *BackupManifest, sealed []byte, snapshot string, runFiles []string) error {
+	file, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("create backup file: %w", err)
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+
+	// The manifest comes first so it can be read without unpacking the archive
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal manifest: %w", err)
+	}
+	if err := writeTarBytes(tw, backupManifestEntry, data); err != nil {
+		return err
+	}
+	if sealed != nil {
+		if err := writeTarBytes(tw, backupSecretsEntry, sealed); err != nil {
+			return err
+		}
+	}
+	if err := writeTarFile(tw, backupDBEntry, snapshot); err != nil {
+		return err
+	}
+	if manifest.Settings {
+		if err := writeTarFile(tw, backupConfigEntry, uc.paths.ConfigPath); err != nil {
+			return err
+		}
+	}
+	for _, rel := range runFiles {
+		name := path.Join(backupRunsEntry, filepath.ToSlash(rel))
+		if err := writeTarFile(tw, name, filepath.Join(uc.paths.RunsDir, rel)); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("write backup file: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("write backup file: %w", err)
+	}
+	return file.Close()
+}
+
+// ReadBackupManifest reads the manifest of a backup archive.
+func ReadBackupManifest(src string) (*BackupManifest, error) {
+	file, err := os.Open(src)
+	if err != nil {
+		return nil, fmt.Errorf("open backup file: %w", err)
+	}
+	defer file.Close()
+
+	tr, err := newTarReader(file)
+	if err != nil {
+		return nil, err
+	}
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != backupManifestEntry {
+		return nil, fmt.Errorf("%s is not a DB-BenchMind backup", src)
+	}
+	return decodeManifest(tr)
+}
+
+// Restore replaces the application state with the content of a backup archive.
+// The database must not be open. Existing files are kept with the
+// ".before-restore" suffix; without force, restoring over an existing
+// database fails with ErrBackupTargetExists. The saved passwords are written
+// to the keyring when password is given, and skipped otherwise.
+func (uc *BackupUseCase) Restore(ctx context.Context, src, password string, force bool) (*RestoreResult, error) {
+	if _, err := os.Stat(uc.paths.DBPath); err == nil && !force {
+		return nil, ErrBackupTargetExists
+	}
+
+	file, err := os.Open(src)
+	if err != nil {
+		return nil, fmt.Errorf("open backup file: %w", err)
+	}
+	defer file.Close()
+
+	// Unpack into a staging directory beside the data, so the files can be
+	// renamed into place once the whole archive has been read
+	staging := filepath.Join(uc.paths.DataDir, ".restore")
+	if err := os.RemoveAll(staging); err != nil {
+		return nil, fmt.Errorf("clear staging directory: %w", err)
+	}
+	if err := os.MkdirAll(staging, 0700); err != nil {
+		return nil, fmt.Errorf("create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	result := &RestoreResult{}
+	var sealed []byte
+	tr, err := newTarReader(file)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read backup file: %w", err)
+		}
+
+		switch {
+		case hdr.Name == backupManifestEntry:
+			if result.Manifest, err = decodeManifest(tr); err != nil {
+				return nil, err
+			}
+		case hdr.Name == backupSecretsEntry:
+			if sealed, err = io.ReadAll(tr); err != nil {
+				return nil, fmt.Errorf("read secrets: %w", err)
+			}
+		case hdr.Typeflag == tar.TypeReg:
+			if err := extractTarFile(tr, staging, hdr.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if result.Manifest == nil {
+		return nil, fmt.Errorf("%s is not a DB-BenchMind backup", src)
+	}
+	if _, err := os.Stat(filepath.Join(staging, backupDBEntry)); err != nil {
+		return nil, fmt.Errorf("backup file contains no database")
+	}
+
+	// Open the secrets before touching any file, so a wrong password changes nothing
+	var secrets map[string]string
+	if sealed != nil && password != "" {
+		data, err := keyring.Unseal(password, sealed)
+		if err != nil {
+			return nil, fmt.Errorf("open saved passwords: %w", err)
+		}
+		if err := json.Unmarshal(data, &secrets); err != nil {
+			return nil, fmt.Errorf("parse saved passwords: %w", err)
+		}
+	}
+
+	// Set the current files aside, including the WAL of the current database
+	targets := map[string]string{
+		uc.paths.DBPath:     backupDBEntry,
+		uc.paths.ConfigPath: backupConfigEntry,
+		uc.paths.RunsDir:    backupRunsEntry,
+	}
+	for _, current := range []string{uc.paths.DBPath, uc.paths.DBPath + "-wal", uc.paths.DBPath + "-shm", uc.paths.ConfigPath, uc.paths.RunsDir} {
+		if _, err := os.Stat(current); err != nil {
+			continue
+		}
+		aside := current + setAsideSuffix
+		if err := os.RemoveAll(aside); err != nil {
+			return nil, fmt.Errorf("remove %s: %w", aside, err)
+		}
+		if err := os.Rename(current, aside); err != nil {
+			return nil, fmt.Errorf("set aside %s: %w", current, err)
+		}
+		result.SetAside = append(result.SetAside, aside)
+	}
+	for target, entry := range targets {
+		staged := filepath.Join(staging, entry)
+		if _, err := os.Stat(staged); err != nil {
+			continue
+		}
+		if err := os.Rename(staged, target); err != nil {
+			return nil, fmt.Errorf("restore %s: %w", target, err)
+		}
+	}
+
+	for key, secret := range secrets {
+		if err := uc.keyring.Set(ctx, key, secret); err != nil {
+			return nil, fmt.Errorf("save password %s: %w", key, err)
+		}
+		result.Secrets++
+	}
+
+	slog.Info("Backup: Restored", "file", src, "schema_version", result.Manifest.SchemaVersion,
+		"secrets", result.Secrets, "set_aside", len(result.SetAside))
+	return result, nil
+}
+
+// newTarReader returns a reader of a gzip-compressed tar archive.
+func newTarReader(r io.Reader) (*tar.Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("read backup file: %w", err)
+	}
+	return tar.NewReader(gz), nil
+}
+
+// decodeManifest parses a manifest and checks that its format is supported.
+func decodeManifest(r io.Reader) (*BackupManifest, error) {
+	var manifest BackupManifest
+	if err := json.NewDecoder(r).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("parse backup manifest: %w", err)
+	}
+	if manifest.Version > backupFormatVersion {
+		return nil, fmt.Errorf("backup format version %d is newer than this release supports (%d)", manifest.Version, backupFormatVersion)
+	}
+	return &manifest, nil
+}
+
+// listFiles returns the regular files below dir, relative to dir.
+// A missing dir has no files.
+func listFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) && p == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.Type().IsRegular() {
+			rel, err := filepath.Rel(dir, p)
+			if err != nil {
+				return err
+			}
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// writeTarBytes adds data to the archive as name.
+func writeTarBytes(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: time.Now()}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeTarFile adds the file at src to the archive as name.
+func writeTarFile(tw *tar.Writer, name, src string) error {
+	file, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", src, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", src, err)
+	}
+	hdr := &tar.Header{Name: name, Mode: 0600, Size: info.Size(), ModTime: info.ModTime()}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	if _, err := io.Copy(tw, file); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+// extractTarFile writes the current archive entry below dir. Entries that
+// would leave dir are rejected.
+func extractTarFile(r io.Reader, dir, name string) error {
+	clean := path.Clean(name)
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("backup file contains invalid path %s", name)
+	}
+	dest := filepath.Join(dir, filepath.FromSlash(clean))
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", name, err)
+	}
+
+	file, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", name, err)
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return fmt.Errorf("extract %s: %w", name, err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/keyring"
+)
+
+// newBackupPaths returns the backup paths of a data directory.
+func newBackupPaths(dir string) BackupPaths {
+	return BackupPaths{
+		DataDir:    dir,
+		DBPath:     filepath.Join(dir, "db-benchmind.db"),
+		ConfigPath: filepath.Join(dir, "config.json"),
+		RunsDir:    filepath.Join(dir, "runs"),
+	}
+}
+
+// TestBackupUseCase_CreateAndRestore tests restoring a backup on another machine.
+func TestBackupUseCase_CreateAndRestore(t *testing.T) {
+	ctx := context.Background()
+
+	// Source machine: one connection with a saved password, settings and a run artifact
+	srcPaths := newBackupPaths(t.TempDir())
+	db, err := database.InitializeSQLite(ctx, srcPaths.DBPath)
+	if err != nil {
+		t.Fatalf("InitializeSQLite() failed: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.ExecContext(ctx, `INSERT INTO settings (key, value, value_type, updated_at) VALUES ('marker', 'v1', 'string', '')`); err != nil {
+		t.Fatalf("insert setting: %v", err)
+	}
+	if err := os.WriteFile(srcPaths.ConfigPath, []byte(`{"theme":"dark"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(srcPaths.RunsDir, "run-1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(srcPaths.RunsDir, "run-1", "stdout.log"), []byte("log"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	connRepo := NewMockConnectionRepository()
+	connRepo.Save(ctx, &connection.MySQLConnection{BaseConnection: connection.BaseConnection{ID: "conn-1", Name: "prod"}})
+	srcKeyring := NewMockKeyring()
+	srcKeyring.Set(ctx, "conn-1", "db-secret")
+	srcKeyring.Set(ctx, NotificationPasswordKey, "smtp-secret")
+
+	archive := filepath.Join(t.TempDir(), "state.tar.gz")
+	manifest, err := NewBackupUseCase(srcPaths, srcKeyring).Create(ctx, db, connRepo, archive, "backup-pw")
+	if err != nil {
+		t.Fatalf("Create() failed: %v", err)
+	}
+	if manifest.Connections != 1 || manifest.Secrets != 2 || !manifest.Settings || manifest.RunFiles != 1 {
+		t.Errorf("Create() manifest = %+v", manifest)
+	}
+
+	read, err := ReadBackupManifest(archive)
+	if err != nil {
+		t.Fatalf("ReadBackupManifest() failed: %v", err)
+	}
+	if read.SchemaVersion != manifest.SchemaVersion || read.Secrets != 2 {
+		t.Errorf("ReadBackupManifest() = %+v, want %+v", read, manifest)
+	}
+
+	// Target machine with a different keyring
+	dstPaths := newBackupPaths(t.TempDir())
+	dstKeyring, err := keyring.NewFileFallback(dstPaths.DataDir, "other-master")
+	if err != nil {
+		t.Fatalf("NewFileFallback() failed: %v", err)
+	}
+	restore := NewBackupUseCase(dstPaths, dstKeyring)
+
+	// A wrong password changes nothing
+	if _, err := restore.Restore(ctx, archive, "wrong", false); !errors.Is(err, keyring.ErrWrongPassword) {
+		t.Fatalf("Restore(wrong password) error = %v, want ErrWrongPassword", err)
+	}
+	if _, err := os.Stat(dstPaths.DBPath); err == nil {
+		t.Fatal("Restore(wrong password) wrote the database")
+	}
+
+	result, err := restore.Restore(ctx, archive, "backup-pw", false)
+	if err != nil {
+		t.Fatalf("Restore() failed: %v", err)
+	}
+	if result.Secrets != 2 || len(result.SetAside) != 0 {
+		t.Errorf("Restore() = %+v", result)
+	}
+
+	if got, err := dstKeyring.Get(ctx, "conn-1"); err != nil || got != "db-secret" {
+		t.Errorf("restored password = %q, %v", got, err)
+	}
+	if got, err := os.ReadFile(filepath.Join(dstPaths.RunsDir, "run-1", "stdout.log")); err != nil || string(got) != "log" {
+		t.Errorf("restored run artifact = %q, %v", got, err)
+	}
+	if got, err := os.ReadFile(dstPaths.ConfigPath); err != nil || !strings.Contains(string(got), "dark") {
+		t.Errorf("restored settings = %q, %v", got, err)
+	}
+
+	restored, err := database.InitializeSQLite(ctx, dstPaths.DBPath)
+	if err != nil {
+		t.Fatalf("open restored database: %v", err)
+	}
+	var value string
+	if err := restored.QueryRowContext(ctx, `SELECT value FROM settings WHERE key = 'marker'`).Scan(&value); err != nil || value != "v1" {
+		t.Errorf("restored setting = %q, %v", value, err)
+	}
+	restored.Close()
+
+	// Restoring again needs force and keeps the current files
+	if _, err := restore.Restore(ctx, archive, "", false); !errors.Is(err, ErrBackupTargetExists) {
+		t.Fatalf("Restore() over existing data error = %v, want ErrBackupTargetExists", err)
+	}
+	result, err = restore.Restore(ctx, archive, "", true)
+	if err != nil {
+		t.Fatalf("Restore(force) failed: %v", err)
+	}
+	if result.Secrets != 0 {
+		t.Errorf("Restore() without password saved %d passwords, want 0", result.Secrets)
+	}
+	if _, err := os.Stat(dstPaths.DBPath + setAsideSuffix); err != nil {
+		t.Errorf("previous database not set aside: %v", err)
+	}
+}
@@ -0,0 +1,304 @@
+// Package usecase provides benchmark dry-run planning.
+// Implements: REQ-EXEC-010
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+// maskedSecret replaces secrets in planned commands.
+const maskedSecret = adapter.MaskedSecret
+
+// PlannedCommand is a command a benchmark run would execute, with secrets masked.
+type PlannedCommand struct {
+	Phase   string   // create-database, prepare, warmup, run or cleanup
+	CmdLine string   // Command line
+	WorkDir string   // Working directory
+	Env     []string // Environment variables set in addition to the inherited environment
+	Stdin   string   // Standard input fed to the process
+}
+
+// PreCheckResult is the outcome of one pre-execution check.
+type PreCheckResult struct {
+	Name string // Check name, e.g. "connection check"
+	Err  error  // Nil if the check passed
+	Fix  string // Suggested remediation if the check fails
+}
+
+// Passed returns true if the check passed.
+func (r PreCheckResult) Passed() bool {
+	return r.Err == nil
+}
+
+// BenchmarkPlan describes what a benchmark task would execute, without executing it.
+type BenchmarkPlan struct {
+	Tool       string           // Benchmark tool
+	Connection string           // Connection name
+	Template   string           // Template name
+	RemoteHost string           // Host the tool would run on and how, empty for local runs
+	Commands   []PlannedCommand // Commands in execution order
+	PreChecks  []PreCheckResult // Pre-check outcomes
+}
+
+// PreChecksPassed returns true if every pre-check passed.
+func (p *BenchmarkPlan) PreChecksPassed() bool {
+	for _, check := range p.PreChecks {
+		if !check.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Format renders the plan as text for display.
+func (p *BenchmarkPlan) Format() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Tool:       %s\n", p.Tool)
+	fmt.Fprintf(&b, "Connection: %s\n", p.Connection)
+	fmt.Fprintf(&b, "Template:   %s\n", p.Template)
+	if p.RemoteHost != "" {
+		fmt.Fprintf(&b, "Runs on:    %s\n", p.RemoteHost)
+	}
+
+	b.WriteString("\nPre-checks:\n")
+	for _, check := range p.PreChecks {
+		if check.Passed() {
+			fmt.Fprintf(&b, "  [OK]   %s\n", check.Name)
+		} else {
+			fmt.Fprintf(&b, "  [FAIL] %s: %v\n", check.Name, check.Err)
+			if check.Fix != "" {
+				fmt.Fprintf(&b, "         fix: %s\n", check.Fix)
+			}
+		}
+	}
+
+	for i, cmd := range p.Commands {
+		fmt.Fprintf(&b, "\n%d. %s\n", i+1, cmd.Phase)
+		fmt.Fprintf(&b, "   $ %s\n", cmd.CmdLine)
+		if cmd.WorkDir != "" {
+			fmt.Fprintf(&b, "   work dir: %s\n", cmd.WorkDir)
+		}
+		for _, env := range cmd.Env {
+			fmt.Fprintf(&b, "   env: %s\n", env)
+		}
+		if cmd.Stdin != "" {
+			b.WriteString("   stdin:\n")
+			for _, line := range strings.Split(strings.TrimRight(cmd.Stdin, "\n"), "\n") {
+				fmt.Fprintf(&b, "     %s\n", line)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// PlanBenchmark builds the commands a task would execute and runs the pre-checks,
+// without executing anything. Passwords are masked in the returned commands.
+// The phases follow executeBenchmark: prepare-only, cleanup-only or a full run.
+// Implements: REQ-EXEC-010 (dry run)
+func (uc *BenchmarkUseCase) PlanBenchmark(ctx context.Context, task *execution.BenchmarkTask) (*BenchmarkPlan, error) {
+	if err := task.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPreCheckFailed, err)
+	}
+
+	conn, err := uc.connUseCase.GetConnectionByID(ctx, task.ConnectionID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+
+	tmpl, err := uc.templateUseCase.GetTemplate(ctx, task.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("get template: %w", err)
+	}
+
+	adapt := uc.adapterReg.GetByTool(tmpl.Tool)
+	if adapt == nil {
+		return nil, fmt.Errorf("adapter not found for tool: %s", tmpl.Tool)
+	}
+
+	plan := &BenchmarkPlan{
+		Tool:       string(tmpl.Tool),
+		Connection: conn.GetName(),
+		Template:   tmpl.Name,
+	}
+
+	target, err := uc.resolveRemoteHost(ctx, conn, task.Options)
+	if err != nil {
+		return nil, fmt.Errorf("remote execution: %w", err)
+	}
+	if target != nil {
+		plan.RemoteHost = fmt.Sprintf("%s (%s)", target.Name(), target.Via())
+	}
+
+	// The work directory is created only when a run starts
+	workDir := uc.workDir("<run-id>", task.Options)
+	options := task.Options
+	options.SampleInterval = options.ResolveSampleInterval(plannedRunTime(task.Parameters))
+	config := &adapter.Config{
+		Connection: conn,
+		Template:   tmpl,
+		Parameters: task.Parameters,
+		Options:    options,
+		WorkDir:    workDir,
+	}
+
+	plan.PreChecks = uc.preCheckResults(ctx, adapt, config, target, os.TempDir())
+
+	runTime, hasTime := task.Parameters["time"].(int)
+	_, hasOriginalTime := task.Parameters["_original_time"].(int)
+
+	var phases []string
+	switch {
+	case hasTime && runTime == 0 && hasOriginalTime:
+		phases = []string{"create-database", "prepare"}
+	case hasTime && runTime == 0 && !task.Options.SkipCleanup:
+		phases = []string{"cleanup"}
+	default:
+		if !task.Options.SkipPrepare {
+			phases = append(phases, "create-database", "prepare")
+		}
+		if task.Options.WarmupTime > 0 {
+			phases = append(phases, "warmup")
+		}
+		phases = append(phases, "run")
+		if !task.Options.SkipCleanup {
+			phases = append(phases, "cleanup")
+		}
+	}
+
+	secrets := planSecrets(conn, task.Parameters)
+	for _, phase := range phases {
+		var cmd *adapter.Command
+		switch phase {
+		case "create-database":
+			creator, ok := adapt.(databaseCreator)
+			if !ok {
+				continue
+			}
+			cmd, err = creator.BuildCreateDatabaseCommand(ctx, config)
+		case "prepare":
+			cmd, err = adapt.BuildPrepareCommand(ctx, config)
+		case "warmup":
+			cmd, err = adapt.BuildRunCommand(ctx, withRunTime(config, task.Options.WarmupTime))
+		case "run":
+			cmd, err = adapt.BuildRunCommand(ctx, config)
+		case "cleanup":
+			cmd, err = adapt.BuildCleanupCommand(ctx, config)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("build %s command: %w", phase, err)
+		}
+		if cmd == nil {
+			continue
+		}
+		plan.Commands = append(plan.Commands, maskCommand(phase, cmd, secrets))
+	}
+
+	return plan, nil
+}
+
+// PreCheckBenchmark runs the pre-checks of a task without starting it, so that
+// failed checks can be shown with their fixes before the run starts.
+func (uc *BenchmarkUseCase) PreCheckBenchmark(ctx context.Context, task *execution.BenchmarkTask) ([]PreCheckResult, error) {
+	if err := task.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPreCheckFailed, err)
+	}
+
+	conn, err := uc.connUseCase.GetConnectionByID(ctx, task.ConnectionID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+
+	tmpl, err := uc.templateUseCase.GetTemplate(ctx, task.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("get template: %w", err)
+	}
+
+	adapt := uc.adapterReg.GetByTool(tmpl.Tool)
+	if adapt == nil {
+		return nil, fmt.Errorf("adapter not found for tool: %s", tmpl.Tool)
+	}
+
+	target, err := uc.resolveRemoteHost(ctx, conn, task.Options)
+	if err != nil {
+		return nil, fmt.Errorf("remote execution: %w", err)
+	}
+
+	config := &adapter.Config{
+		Connection: conn,
+		Template:   tmpl,
+		Parameters: task.Parameters,
+		Options:    task.Options,
+		WorkDir:    uc.workDir("<run-id>", task.Options),
+	}
+
+	// The work directory is created when the run starts; check the disk it goes on
+	return uc.preCheckResults(ctx, adapt, config, target, filepath.Dir(config.WorkDir)), nil
+}
+
+// plannedRunTime returns the planned run duration of a task's parameters.
+func plannedRunTime(params map[string]interface{}) time.Duration {
+	if t, ok := params["_original_time"].(int); ok {
+		return time.Duration(t) * time.Second
+	}
+	if t, ok := params["time"].(int); ok {
+		return time.Duration(t) * time.Second
+	}
+	return 0
+}
+
+// planSecrets collects the secrets that must not appear in a plan.
+func planSecrets(conn connection.Connection, params map[string]interface{}) []string {
+	var secrets []string
+	for _, secret := range []string{getPassword(conn), getSSHPassword(conn), getWinRMPassword(conn)} {
+		if secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	for key, value := range params {
+		if s, ok := value.(string); ok && s != "" && isSecretName(key) {
+			secrets = append(secrets, s)
+		}
+	}
+	return secrets
+}
+
+// isSecretName reports whether a parameter or environment variable name denotes a secret.
+func isSecretName(name string) bool {
+	upper := strings.ToUpper(name)
+	return strings.Contains(upper, "PASS") || strings.Contains(upper, "PWD") || strings.Contains(upper, "SECRET")
+}
+
+// maskCommand returns a planned command with every secret masked.
+func maskCommand(phase string, cmd *adapter.Command, secrets []string) PlannedCommand {
+	mask := func(s string) string {
+		for _, secret := range secrets {
+			s = strings.ReplaceAll(s, secret, maskedSecret)
+		}
+		return cmd.Mask(s)
+	}
+
+	planned := PlannedCommand{
+		Phase:   phase,
+		CmdLine: mask(cmd.CmdLine),
+		WorkDir: cmd.WorkDir,
+		Stdin:   mask(cmd.Stdin),
+	}
+	for _, env := range cmd.Env {
+		if name, _, ok := strings.Cut(env, "="); ok && isSecretName(name) {
+			env = name + "=" + maskedSecret
+		}
+		planned.Env = append(planned.Env, mask(env))
+	}
+	return planned
+}
@@ -0,0 +1,62 @@
+// Package usecase provides helpers to run benchmarks to completion.
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+// runPollInterval is how often waitForRun checks the state of a benchmark run.
+var runPollInterval = time.Second
+
+// runStopTimeout is how long a stopped benchmark run may take to exit before it is force stopped.
+const runStopTimeout = 30 * time.Second
+
+// BenchmarkRunner starts and monitors benchmark runs. Implemented by BenchmarkUseCase.
+type BenchmarkRunner interface {
+	StartBenchmark(ctx context.Context, task *execution.BenchmarkTask) (*execution.Run, error)
+	GetBenchmarkStatus(ctx context.Context, runID string) (*execution.Run, error)
+	StopBenchmark(ctx context.Context, runID string, force bool) error
+}
+
+// waitForRun polls a benchmark run until it reaches a terminal state.
+// If ctx is cancelled the run is stopped, and force stopped if it does not exit in time.
+func waitForRun(ctx context.Context, runner BenchmarkRunner, runID string) *execution.Run {
+	statusCtx := context.WithoutCancel(ctx)
+	ticker := time.NewTicker(runPollInterval)
+	defer ticker.Stop()
+
+	var stopDeadline time.Time
+	forced := false
+	for {
+		status, err := runner.GetBenchmarkStatus(statusCtx, runID)
+		if err != nil {
+			slog.Error("Runner: Failed to get run status", "run_id", runID, "error", err)
+			return nil
+		}
+		if status.State.IsTerminal() {
+			return status
+		}
+
+		if ctx.Err() != nil {
+			switch {
+			case stopDeadline.IsZero():
+				slog.Info("Runner: Stopping run", "run_id", runID)
+				stopDeadline = time.Now().Add(runStopTimeout)
+				if err := runner.StopBenchmark(statusCtx, runID, false); err != nil {
+					slog.Warn("Runner: Failed to stop run", "run_id", runID, "error", err)
+				}
+			case !forced && time.Now().After(stopDeadline):
+				forced = true
+				if err := runner.StopBenchmark(statusCtx, runID, true); err != nil {
+					slog.Warn("Runner: Failed to force stop run", "run_id", runID, "error", err)
+				}
+			}
+		}
+
+		<-ticker.C
+	}
+}
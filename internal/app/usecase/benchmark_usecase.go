@@ -4,6 +4,7 @@ package usecase
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"database/sql"
 	"errors"
@@ -17,12 +18,17 @@ import (
 	"sync"
 	"syscall"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/google/uuid"
 	_ "github.com/lib/pq"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/access"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/replication"
 	domaintemplate "github.com/whhaicheng/DB-BenchMind/internal/domain/template"
 	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
 )
@@ -39,11 +45,51 @@ var (
 
 	// ErrExecutionFailed is returned when benchmark execution fails.
 	ErrExecutionFailed = errors.New("execution failed")
+
+	// ErrConnectionProtected is returned when a task would prepare or clean up
+	// on a connection marked protected.
+	ErrConnectionProtected = errors.New("connection is protected against prepare and cleanup")
+
+	// ErrConnectionObserver is returned when a task would run any benchmark
+	// phase on an observe-only connection.
+	ErrConnectionObserver = errors.New("connection is observe-only")
 )
 
 // RealtimeSampleCallback is called for each realtime sample during benchmark execution.
 type RealtimeSampleCallback func(runID string, sample execution.MetricSample)
 
+// RunLogCallback is called for each log entry saved for a run.
+type RunLogCallback func(runID string, entry LogEntry)
+
+// RunEvent describes a benchmark run lifecycle event.
+type RunEvent struct {
+	Run            *execution.Run
+	ConnectionName string
+	TemplateName   string
+	DatabaseType   string
+}
+
+// RunEventCallback is called when a benchmark run starts or reaches a terminal state.
+type RunEventCallback func(event RunEvent)
+
+// ConfigSnapshotter captures the configuration of the target database of a run.
+type ConfigSnapshotter interface {
+	Capture(ctx context.Context, conn connection.Connection) (*dbconfig.Snapshot, error)
+}
+
+// ReplicaMonitor polls the replication lag and errors of the replicas of the
+// target database until ctx is done.
+type ReplicaMonitor interface {
+	Monitor(ctx context.Context, conn connection.Replicated, interval time.Duration) *replication.Stats
+}
+
+// ServerLogCollector reads the error log of the target database server over
+// SSH or WinRM: Position before the run, Read after it.
+type ServerLogCollector interface {
+	Position(ctx context.Context, conn connection.Connection) (*dbconfig.LogPosition, error)
+	Read(ctx context.Context, conn connection.Connection, pos *dbconfig.LogPosition) (string, error)
+}
+
 // BenchmarkUseCase provides benchmark execution business operations.
 // Implements: REQ-EXEC-001 ~ REQ-EXEC-010
 type BenchmarkUseCase struct {
@@ -51,10 +97,31 @@ type BenchmarkUseCase struct {
 	adapterReg         *adapter.AdapterRegistry
 	connUseCase        *ConnectionUseCase
 	templateUseCase    *TemplateUseCase
-	realtimeCallback   RealtimeSampleCallback // Optional callback for realtime samples
-	realtimeCallbackMu sync.RWMutex           // Protects realtimeCallback
-	runningProcesses   map[string]*exec.Cmd   // Track running processes by run ID
-	runningProcessesMu sync.RWMutex           // Protects runningProcesses
+	realtimeCallback   RealtimeSampleCallback        // Optional callback for realtime samples
+	startedCallback    RunEventCallback              // Optional callback for started runs
+	finishedCallback   RunEventCallback              // Optional callback for finished runs
+	logCallback        RunLogCallback                // Optional callback for saved log entries
+	realtimeCallbackMu sync.RWMutex                  // Protects realtimeCallback, startedCallback, finishedCallback and logCallback
+	runningProcesses   map[string]*exec.Cmd          // Track running processes by run ID
+	runningProcessesMu sync.RWMutex                  // Protects runningProcesses
+	processRepo        ProcessRepository             // Optional record of started processes, for crash cleanup
+	logRepo            RunLogRepository              // Run log storage, the run repository unless set
+	artifactDir        string                        // Directory in which kept run artifacts are stored
+	remoteTargets      map[string]remoteHost         // Hosts of remotely executed runs (WinRM or agent)
+	remoteCancels      map[string]context.CancelFunc // Cancels in-flight remote run commands
+	remoteMu           sync.RWMutex                  // Protects remoteTargets and remoteCancels
+	agentLookup        AgentLookup                   // Finds the agents named in task options
+	agentKeyPath       string                        // Controller key agents authenticate
+	snapshotter        ConfigSnapshotter             // Optional capture of the target database configuration
+	replicaMonitor     ReplicaMonitor                // Optional replica lag monitoring of the run phase
+	serverLogs         ServerLogCollector            // Optional collection of the database error log of runs
+	access             AccessChecker                 // Optional role check of starting runs and cleanups
+	prepareProgress    map[string]*prepareTracker    // Progress of running sysbench prepares
+	prepareMu          sync.Mutex                    // Protects prepareProgress
+	outputLogs         map[string]*rotatingLog       // Output logs of running runs
+	outputLogsMu       sync.Mutex                    // Protects outputLogs
+	errorCounters      map[string]*errorCounter      // Error counts of running run phases
+	errorCountersMu    sync.Mutex                    // Protects errorCounters
 }
 
 // NewBenchmarkUseCase creates a new benchmark use case.
@@ -64,12 +131,19 @@ func NewBenchmarkUseCase(
 	connUseCase *ConnectionUseCase,
 	templateUseCase *TemplateUseCase,
 ) *BenchmarkUseCase {
+	logRepo, _ := runRepo.(RunLogRepository)
 	return &BenchmarkUseCase{
 		runRepo:          runRepo,
+		logRepo:          logRepo,
 		adapterReg:       adapterReg,
 		connUseCase:      connUseCase,
 		templateUseCase:  templateUseCase,
 		runningProcesses: make(map[string]*exec.Cmd),
+		remoteTargets:    make(map[string]remoteHost),
+		remoteCancels:    make(map[string]context.CancelFunc),
+		prepareProgress:  make(map[string]*prepareTracker),
+		outputLogs:       make(map[string]*rotatingLog),
+		errorCounters:    make(map[string]*errorCounter),
 	}
 }
 
@@ -81,6 +155,78 @@ func (uc *BenchmarkUseCase) SetRealtimeCallback(callback RealtimeSampleCallback)
 	uc.realtimeCallback = callback
 }
 
+// SetRunStartedCallback sets a callback function to be notified of started runs.
+// The callback runs on the run's goroutine before the benchmark starts, so it should not block.
+func (uc *BenchmarkUseCase) SetRunStartedCallback(callback RunEventCallback) {
+	uc.realtimeCallbackMu.Lock()
+	defer uc.realtimeCallbackMu.Unlock()
+	uc.startedCallback = callback
+}
+
+// SetRunFinishedCallback sets a callback function to be notified of finished runs.
+// The callback runs on the run's goroutine after its final state is saved.
+func (uc *BenchmarkUseCase) SetRunFinishedCallback(callback RunEventCallback) {
+	uc.realtimeCallbackMu.Lock()
+	defer uc.realtimeCallbackMu.Unlock()
+	uc.finishedCallback = callback
+}
+
+// SetLogCallback sets a callback function to receive run log entries as they are saved,
+// e.g. to follow the tool output of the prepare phase. It should not block.
+func (uc *BenchmarkUseCase) SetLogCallback(callback RunLogCallback) {
+	uc.realtimeCallbackMu.Lock()
+	defer uc.realtimeCallbackMu.Unlock()
+	uc.logCallback = callback
+}
+
+// SetProcessRepository sets the repository in which started benchmark processes are recorded.
+// The records let ReapOrphanedProcesses clean up processes left behind by a crash.
+func (uc *BenchmarkUseCase) SetProcessRepository(repo ProcessRepository) {
+	uc.processRepo = repo
+}
+
+// SetLogRepository sets the repository in which run logs are saved,
+// e.g. to persist them while runs themselves are kept in memory.
+func (uc *BenchmarkUseCase) SetLogRepository(repo RunLogRepository) {
+	uc.logRepo = repo
+}
+
+// SetArtifactDir sets the directory in which the work directories of runs
+// with the KeepArtifacts option are kept, one subdirectory per run.
+func (uc *BenchmarkUseCase) SetArtifactDir(dir string) {
+	uc.artifactDir = dir
+}
+
+// SetConfigSnapshotter sets the snapshotter with which the target database
+// configuration is captured before the run phase and stored with the run.
+func (uc *BenchmarkUseCase) SetConfigSnapshotter(snapshotter ConfigSnapshotter) {
+	uc.snapshotter = snapshotter
+}
+
+// SetReplicaMonitor sets the monitor with which the replicas of the target
+// database are polled during the run phase of tasks with MonitorReplicas.
+func (uc *BenchmarkUseCase) SetReplicaMonitor(monitor ReplicaMonitor) {
+	uc.replicaMonitor = monitor
+}
+
+// SetAccessControl sets the checker with which starting benchmarks, and
+// cleanups in particular, is restricted by role.
+func (uc *BenchmarkUseCase) SetAccessControl(checker AccessChecker) {
+	uc.access = checker
+}
+
+// requireTaskPermissions checks that the session may run a task, and its
+// cleanup phase if it has one.
+func (uc *BenchmarkUseCase) requireTaskPermissions(ctx context.Context, params map[string]interface{}, options execution.TaskOptions) error {
+	if err := requirePermission(ctx, uc.access, access.PermissionRunBenchmarks); err != nil {
+		return err
+	}
+	if taskSchemaPhases(params, options).cleans {
+		return requirePermission(ctx, uc.access, access.PermissionCleanup)
+	}
+	return nil
+}
+
 // =============================================================================
 // Benchmark Execution
 // Implements: REQ-EXEC-001 ~ REQ-EXEC-009
@@ -94,6 +240,20 @@ func (uc *BenchmarkUseCase) StartBenchmark(ctx context.Context, task *execution.
 		return nil, fmt.Errorf("%w: %v", ErrPreCheckFailed, err)
 	}
 
+	// Dry runs must never execute anything
+	if task.Options.DryRun {
+		return nil, fmt.Errorf("%w: dry run tasks are previewed with PlanBenchmark", ErrInvalidState)
+	}
+
+	// Repeated tasks start one run per repetition
+	if task.Options.Repetitions() > 1 {
+		return nil, fmt.Errorf("%w: repeated tasks are run with RepetitionUseCase", ErrInvalidState)
+	}
+
+	if err := uc.requireTaskPermissions(ctx, task.Parameters, task.Options); err != nil {
+		return nil, err
+	}
+
 	// Get connection
 	conn, err := uc.connUseCase.GetConnectionByID(ctx, task.ConnectionID)
 	if err != nil {
@@ -114,12 +274,15 @@ func (uc *BenchmarkUseCase) StartBenchmark(ctx context.Context, task *execution.
 
 	// Create run
 	run := &execution.Run{
-		ID:        uuid.New().String(),
-		TaskID:    task.ID,
-		State:     execution.StatePending,
-		CreatedAt: time.Now(),
-		WorkDir:   filepath.Join(os.TempDir(), fmt.Sprintf("db-benchmind-%s", uuid.New().String())),
+		ID:         uuid.New().String(),
+		TaskID:     task.ID,
+		State:      execution.StatePending,
+		CreatedAt:  time.Now(),
+		Parameters: runParameters(tmpl, task.Parameters),
+		Metadata:   task.Metadata,
+		Assertions: tmpl.Assertions.Override(task.Assertions),
 	}
+	run.WorkDir = uc.workDir(run.ID, task.Options)
 
 	// Save initial run
 	if err := uc.runRepo.Save(ctx, run); err != nil {
@@ -132,6 +295,24 @@ func (uc *BenchmarkUseCase) StartBenchmark(ctx context.Context, task *execution.
 	return run, nil
 }
 
+// runParameters returns the template parameters a run uses, formatted for
+// display: the template defaults overridden by the task parameters. Internal
+// parameters (prefixed with "_") and passwords are left out.
+func runParameters(tmpl *domaintemplate.Template, overrides map[string]interface{}) map[string]string {
+	params := make(map[string]string, len(tmpl.Parameters)+len(overrides))
+	for name, param := range tmpl.Parameters {
+		if param.Default != nil {
+			params[name] = fmt.Sprint(param.Default)
+		}
+	}
+	for name, value := range overrides {
+		if !strings.HasPrefix(name, "_") && !strings.Contains(name, "password") && value != nil {
+			params[name] = fmt.Sprint(value)
+		}
+	}
+	return params
+}
+
 // executeBenchmark executes the benchmark run.
 // This runs in a goroutine.
 func (uc *BenchmarkUseCase) executeBenchmark(
@@ -142,12 +323,29 @@ func (uc *BenchmarkUseCase) executeBenchmark(
 	adapt adapter.BenchmarkAdapter,
 	task *execution.BenchmarkTask,
 ) {
+	uc.notifyRunStarted(run, conn, tmpl)
+	defer uc.notifyRunFinished(ctx, run.ID, conn, tmpl)
+
 	// Create work directory
 	if err := os.MkdirAll(run.WorkDir, 0755); err != nil {
 		uc.markAsFailed(ctx, run.ID, fmt.Sprintf("create work dir: %v", err))
 		return
 	}
-	defer os.RemoveAll(run.WorkDir)
+	if uc.keepsArtifacts(run) {
+		slog.Info("Benchmark: Keeping run artifacts", "run_id", run.ID, "dir", run.WorkDir)
+		defer removeCredentialFiles(run.WorkDir)
+	} else {
+		defer os.RemoveAll(run.WorkDir)
+	}
+
+	// Capture the full tool output to data/runs/<run-id>/output.log
+	uc.openOutputLog(run)
+	defer uc.closeOutputLog(run.ID)
+
+	// Select the report/sample interval from the planned duration unless the task overrides it
+	task.Options.SampleInterval = task.Options.ResolveSampleInterval(plannedRunTime(task.Parameters))
+	run.SampleInterval = task.Options.SampleInterval
+	uc.runRepo.Save(ctx, run)
 
 	// Build adapter config
 	config := &adapter.Config{
@@ -162,7 +360,22 @@ func (uc *BenchmarkUseCase) executeBenchmark(
 		"run_id", run.ID,
 		"skip_prepare", task.Options.SkipPrepare,
 		"skip_cleanup", task.Options.SkipCleanup,
-		"warmup_time", task.Options.WarmupTime)
+		"warmup_time", task.Options.WarmupTime,
+		"sample_interval", task.Options.SampleInterval,
+		"remote_winrm", task.Options.RemoteWinRM,
+		"agents", task.Options.LoadGenerators())
+
+	// Resolve remote execution target (SQL Server hosts via WinRM, or an agent)
+	target, err := uc.resolveRemoteHost(ctx, conn, task.Options)
+	if err != nil {
+		uc.markAsFailed(ctx, run.ID, fmt.Sprintf("remote execution: %v", err))
+		return
+	}
+	if target != nil {
+		uc.setRemoteTarget(run.ID, target)
+		defer uc.clearRemoteTarget(run.ID)
+		slog.Info("Benchmark: Tool will run on remote host", "run_id", run.ID, "host", target.Name(), "via", target.Via())
+	}
 
 	// Run pre-checks
 	slog.Info("Benchmark: Running pre-checks", "run_id", run.ID)
@@ -214,7 +427,14 @@ func (uc *BenchmarkUseCase) executeBenchmark(
 			return
 		}
 
+		uc.startPrepareProgress(run.ID, adapt, config)
+		defer uc.stopPrepareProgress(run.ID)
+
 		if err := uc.executeCommand(ctx, run, cmd); err != nil {
+			if uc.cancelledDuring(ctx, run.ID, "prepare") {
+				return
+			}
+
 			// Check if error is "table already exists" (MySQL error 1050)
 			errMsg := err.Error()
 			slog.Info("Benchmark: Prepare command failed, checking error type", "run_id", run.ID, "error", errMsg)
@@ -232,22 +452,22 @@ func (uc *BenchmarkUseCase) executeBenchmark(
 				// Save log entries
 				msg1 := "✓ Table data already exists - skipping prepare phase"
 				msg2 := "Info: The benchmark tables are already prepared and ready to use."
-				uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+				uc.saveLogEntry(ctx, run.ID, LogEntry{
 					Timestamp: time.Now().Format(time.RFC3339),
 					Stream:    "info",
 					Content:   strings.Repeat("=", 60),
 				})
-				uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+				uc.saveLogEntry(ctx, run.ID, LogEntry{
 					Timestamp: time.Now().Format(time.RFC3339),
 					Stream:    "info",
 					Content:   msg1,
 				})
-				uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+				uc.saveLogEntry(ctx, run.ID, LogEntry{
 					Timestamp: time.Now().Format(time.RFC3339),
 					Stream:    "info",
 					Content:   msg2,
 				})
-				uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+				uc.saveLogEntry(ctx, run.ID, LogEntry{
 					Timestamp: time.Now().Format(time.RFC3339),
 					Stream:    "info",
 					Content:   strings.Repeat("=", 60),
@@ -261,28 +481,31 @@ func (uc *BenchmarkUseCase) executeBenchmark(
 			// Prepare completed successfully
 			msg1 := "✓ Prepare phase completed successfully"
 			msg2 := "Info: All tables created and data loaded successfully."
-			uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+			uc.saveLogEntry(ctx, run.ID, LogEntry{
 				Timestamp: time.Now().Format(time.RFC3339),
 				Stream:    "info",
 				Content:   strings.Repeat("=", 60),
 			})
-			uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+			uc.saveLogEntry(ctx, run.ID, LogEntry{
 				Timestamp: time.Now().Format(time.RFC3339),
 				Stream:    "info",
 				Content:   msg1,
 			})
-			uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+			uc.saveLogEntry(ctx, run.ID, LogEntry{
 				Timestamp: time.Now().Format(time.RFC3339),
 				Stream:    "info",
 				Content:   msg2,
 			})
-			uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+			uc.saveLogEntry(ctx, run.ID, LogEntry{
 				Timestamp: time.Now().Format(time.RFC3339),
 				Stream:    "info",
 				Content:   strings.Repeat("=", 60),
 			})
+			uc.recordDataset(ctx, adapt, config, true)
 		}
 
+		uc.recordSOESchema(ctx, adapt, conn, task.Parameters, true)
+
 		// For prepare-only mode, mark as completed directly (bypassing StatePrepared)
 		uc.markAsCompleted(ctx, run.ID, 0)
 		return
@@ -305,43 +528,49 @@ func (uc *BenchmarkUseCase) executeBenchmark(
 		}
 
 		if err := uc.executeCommand(ctx, run, cmd); err != nil {
-			uc.markAsFailed(ctx, run.ID, fmt.Sprintf("cleanup: %v", err))
+			if !uc.cancelledDuring(ctx, run.ID, "cleanup") {
+				uc.markAsFailed(ctx, run.ID, fmt.Sprintf("cleanup: %v", err))
+			}
 			return
 		}
 
 		// Cleanup completed successfully - add friendly message
 		msg1 := "✓ Cleanup phase completed successfully"
 		msg2 := "Info: All benchmark tables and data have been removed."
-		uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+		uc.saveLogEntry(ctx, run.ID, LogEntry{
 			Timestamp: time.Now().Format(time.RFC3339),
 			Stream:    "info",
 			Content:   strings.Repeat("=", 60),
 		})
-		uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+		uc.saveLogEntry(ctx, run.ID, LogEntry{
 			Timestamp: time.Now().Format(time.RFC3339),
 			Stream:    "info",
 			Content:   msg1,
 		})
-		uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+		uc.saveLogEntry(ctx, run.ID, LogEntry{
 			Timestamp: time.Now().Format(time.RFC3339),
 			Stream:    "info",
 			Content:   msg2,
 		})
-		uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+		uc.saveLogEntry(ctx, run.ID, LogEntry{
 			Timestamp: time.Now().Format(time.RFC3339),
 			Stream:    "info",
 			Content:   strings.Repeat("=", 60),
 		})
 
+		uc.recordSOESchema(ctx, adapt, conn, task.Parameters, false)
+		uc.recordDataset(ctx, adapt, config, false)
+
 		// For cleanup-only mode, mark as completed directly (bypassing StatePrepared)
 		uc.markAsCompleted(ctx, run.ID, 0)
 		return
 	}
 
 	// Full benchmark execution (prepare + run + cleanup)
+	skipPrepare := uc.reuseDataset(ctx, run, adapt, config)
 
 	// Create database if needed (before prepare phase)
-	if !task.Options.SkipPrepare {
+	if !skipPrepare {
 		if err := uc.createDatabaseIfNeeded(ctx, run, adapt, config); err != nil {
 			uc.markAsFailed(ctx, run.ID, fmt.Sprintf("create database: %v", err))
 			return
@@ -349,8 +578,12 @@ func (uc *BenchmarkUseCase) executeBenchmark(
 	}
 
 	// Prepare phase
-	if !task.Options.SkipPrepare {
+	if !skipPrepare {
 		if err := uc.executePhase(ctx, run, adapt, config, "prepare", execution.StatePreparing, execution.StatePrepared); err != nil {
+			if uc.cancelledDuring(ctx, run.ID, "prepare") {
+				return
+			}
+
 			// Check if error is "table already exists" (MySQL error 1050)
 			// This is OK - means data was already prepared, we can continue
 			if strings.Contains(err.Error(), "1050") || strings.Contains(err.Error(), "already exists") {
@@ -363,26 +596,57 @@ func (uc *BenchmarkUseCase) executeBenchmark(
 				uc.markAsFailed(ctx, run.ID, fmt.Sprintf("prepare: %v", err))
 				return
 			}
+		} else {
+			uc.recordDataset(ctx, adapt, config, true)
 		}
+		uc.recordSOESchema(ctx, adapt, conn, task.Parameters, true)
 	} else {
 		uc.updateState(ctx, run.ID, execution.StatePrepared)
 	}
 
+	// A stop between the phases ends the run before the workload starts
+	if uc.isStopped(ctx, run.ID) {
+		slog.Info("Benchmark: Run stopped before the run phase", "run_id", run.ID)
+		return
+	}
+
+	// Capture the database configuration the run is measured against
+	uc.captureConfigSnapshot(ctx, run, conn)
+
+	// Mark the end of the database error log; what follows covers the run
+	collectServerLog := uc.startServerLogCollection(ctx, run, conn, task.Options)
+	defer collectServerLog()
+
 	// Warmup phase
 	if task.Options.WarmupTime > 0 {
 		if err := uc.executeWarmup(ctx, run, adapt, config, task.Options.WarmupTime); err != nil {
 			uc.markAsFailed(ctx, run.ID, fmt.Sprintf("warmup: %v", err))
 			return
 		}
+		if uc.isStopped(ctx, run.ID) {
+			slog.Info("Benchmark: Run stopped during warmup", "run_id", run.ID)
+			return
+		}
 	}
 
-	// Run phase
+	// Run phase, in steps if the rate limit follows a profile
+	stopReplicaMonitor := uc.startReplicaMonitor(ctx, run, conn, task.Options)
+	stopErrorCount := uc.startErrorCount(ctx, run)
 	startTime := time.Now()
-	if err := uc.executeRun(ctx, run, adapt, config, task.Options.RunTimeout, conn, tmpl); err != nil {
-		uc.markAsFailed(ctx, run.ID, fmt.Sprintf("run: %v", err))
-		return
+	var runErr error
+	if profile := task.Options.RateProfile; profile != nil {
+		runErr = uc.executeRateProfile(ctx, run, adapt, config, profile, conn, tmpl)
+	} else {
+		runErr = uc.executeRun(ctx, run, adapt, config, task.Options.RunTimeout, conn, tmpl)
 	}
 	duration := time.Since(startTime)
+	stopErrorCount()
+	stopReplicaMonitor()
+	collectServerLog()
+	if runErr != nil {
+		uc.markAsFailed(ctx, run.ID, fmt.Sprintf("run: %v", runErr))
+		return
+	}
 
 	// Cleanup phase
 	if !task.Options.SkipCleanup {
@@ -393,41 +657,331 @@ func (uc *BenchmarkUseCase) executeBenchmark(
 	uc.markAsCompleted(ctx, run.ID, duration)
 }
 
+// captureConfigSnapshot captures the target database configuration and stores
+// it with the run. A failed capture is logged and does not fail the run.
+func (uc *BenchmarkUseCase) captureConfigSnapshot(ctx context.Context, run *execution.Run, conn connection.Connection) {
+	if uc.snapshotter == nil {
+		return
+	}
+
+	snapshot, err := uc.snapshotter.Capture(ctx, conn)
+	if err != nil {
+		slog.Warn("Benchmark: Failed to capture database configuration", "run_id", run.ID, "error", err)
+		uc.saveLogEntry(ctx, run.ID, LogEntry{
+			Timestamp: time.Now().Format(time.RFC3339),
+			Stream:    "info",
+			Content:   fmt.Sprintf("Warning: database configuration not captured: %v", err),
+		})
+		return
+	}
+	run.ConfigSnapshot = snapshot
+
+	// Save onto the stored run, whose state the phases have advanced
+	current, err := uc.runRepo.FindByID(ctx, run.ID)
+	if err != nil {
+		slog.Warn("Benchmark: Failed to load run for configuration snapshot", "run_id", run.ID, "error", err)
+		return
+	}
+	current.ConfigSnapshot = snapshot
+	if err := uc.runRepo.Save(ctx, current); err != nil {
+		slog.Warn("Benchmark: Failed to save configuration snapshot", "run_id", run.ID, "error", err)
+	}
+}
+
+// startReplicaMonitor starts polling the replicas of conn if the task asks for
+// it. The returned function stops the polling and stores the replication
+// statistics with the run.
+func (uc *BenchmarkUseCase) startReplicaMonitor(ctx context.Context, run *execution.Run, conn connection.Connection, options execution.TaskOptions) func() {
+	if !options.MonitorReplicas || uc.replicaMonitor == nil {
+		return func() {}
+	}
+	replicated, ok := conn.(connection.Replicated)
+	if !ok || len(replicated.ReplicaAddresses()) == 0 {
+		uc.saveLogEntry(ctx, run.ID, LogEntry{
+			Timestamp: time.Now().Format(time.RFC3339),
+			Stream:    "info",
+			Content:   "Warning: replica monitoring skipped: the connection has no replicas",
+		})
+		return func() {}
+	}
+
+	interval := max(run.SampleInterval, time.Second)
+	monitorCtx, cancel := context.WithCancel(ctx)
+	done := make(chan *replication.Stats, 1)
+	go func() {
+		done <- uc.replicaMonitor.Monitor(monitorCtx, replicated, interval)
+	}()
+	slog.Info("Benchmark: Monitoring replicas", "run_id", run.ID, "replicas", replicated.ReplicaAddresses(), "interval", interval)
+
+	return func() {
+		cancel()
+		stats := <-done
+		run.Replication = stats
+		uc.saveLogEntry(ctx, run.ID, LogEntry{
+			Timestamp: time.Now().Format(time.RFC3339),
+			Stream:    "info",
+			Content: fmt.Sprintf("Replicas: max lag %.1fs over %d replicas, %d failed polls",
+				stats.MaxLag(), len(stats.Replicas), stats.Failures()),
+		})
+
+		// Save onto the stored run, whose state the phases have advanced
+		current, err := uc.runRepo.FindByID(context.WithoutCancel(ctx), run.ID)
+		if err != nil {
+			slog.Warn("Benchmark: Failed to load run for replication statistics", "run_id", run.ID, "error", err)
+			return
+		}
+		current.Replication = stats
+		if err := uc.runRepo.Save(context.WithoutCancel(ctx), current); err != nil {
+			slog.Warn("Benchmark: Failed to save replication statistics", "run_id", run.ID, "error", err)
+		}
+	}
+}
+
+// SetServerLogCollector sets the collector with which the database error log
+// written during runs of tasks with CollectServerLog is saved to their artifacts.
+func (uc *BenchmarkUseCase) SetServerLogCollector(collector ServerLogCollector) {
+	uc.serverLogs = collector
+}
+
+// startServerLogCollection takes the position of the database error log if
+// the task asks for its collection. The returned function reads what the
+// server logged since into the ArtifactServerLog of the run. Failures are
+// logged as warnings and do not fail the run.
+func (uc *BenchmarkUseCase) startServerLogCollection(ctx context.Context, run *execution.Run, conn connection.Connection, options execution.TaskOptions) func() {
+	if !options.CollectServerLog || uc.serverLogs == nil {
+		return func() {}
+	}
+	warn := func(format string, args ...any) {
+		slog.Warn("Benchmark: "+fmt.Sprintf(format, args...), "run_id", run.ID)
+		uc.saveLogEntry(context.WithoutCancel(ctx), run.ID, LogEntry{
+			Timestamp: time.Now().Format(time.RFC3339),
+			Stream:    "info",
+			Content:   "Warning: " + fmt.Sprintf(format, args...),
+		})
+	}
+	dir, err := RunArtifactDir(uc.artifactDir, run.ID)
+	if uc.artifactDir == "" || err != nil {
+		warn("server log not collected: no artifact directory")
+		return func() {}
+	}
+
+	pos, err := uc.serverLogs.Position(ctx, conn)
+	if err != nil {
+		warn("server log not collected: %v", err)
+		return func() {}
+	}
+
+	done := false
+	return func() {
+		if done {
+			return
+		}
+		done = true
+		end := time.Now()
+		content, err := uc.serverLogs.Read(context.WithoutCancel(ctx), conn, pos)
+		if err != nil {
+			warn("server log not collected: %v", err)
+			return
+		}
+		if err := writeServerLog(dir, pos, end, content); err != nil {
+			warn("server log not saved: %v", err)
+			return
+		}
+		uc.saveLogEntry(context.WithoutCancel(ctx), run.ID, LogEntry{
+			Timestamp: time.Now().Format(time.RFC3339),
+			Stream:    "info",
+			Content:   fmt.Sprintf("Server log: %d bytes of %s saved to %s", len(content), pos.Path, ArtifactServerLog),
+		})
+	}
+}
+
 // preChecks performs pre-execution checks.
+// Every failed check is saved to the run log with its suggested fix.
 // Implements: REQ-EXEC-001
 func (uc *BenchmarkUseCase) preChecks(ctx context.Context, run *execution.Run, adapt adapter.BenchmarkAdapter, config *adapter.Config) error {
-	// Validate config
-	if err := adapt.ValidateConfig(ctx, config); err != nil {
-		return fmt.Errorf("config validation: %w", err)
+	var failed []string
+	for _, check := range uc.preCheckResults(ctx, adapt, config, uc.remoteTarget(run.ID), run.WorkDir) {
+		if check.Passed() {
+			continue
+		}
+		failed = append(failed, fmt.Sprintf("%s: %v", check.Name, check.Err))
+		uc.saveLogEntry(ctx, run.ID, LogEntry{
+			Timestamp: time.Now().Format(time.RFC3339),
+			Stream:    "error",
+			Content:   fmt.Sprintf("Pre-check failed: %s: %v\n  Fix: %s", check.Name, check.Err, check.Fix),
+		})
+	}
+	if len(failed) > 0 {
+		return errors.New(strings.Join(failed, "; "))
 	}
+	return nil
+}
+
+// preCheckResults runs every pre-execution check and reports each outcome.
+// target is the host the tool runs on, or nil for local runs.
+// The schema and privilege checks need a working connection and are left out without one.
+func (uc *BenchmarkUseCase) preCheckResults(ctx context.Context, adapt adapter.BenchmarkAdapter, config *adapter.Config, target remoteHost, workDir string) []PreCheckResult {
+	var results []PreCheckResult
+
+	// Validate config
+	results = append(results, PreCheckResult{
+		Name: "config validation",
+		Err:  adapt.ValidateConfig(ctx, config),
+		Fix:  "Check the connection settings and the template parameters",
+	})
 
 	// Check tool availability
-	if !uc.checkToolAvailable(ctx, adapt) {
-		return fmt.Errorf("tool %s not available", adapt.Type())
+	if target != nil {
+		results = append(results, PreCheckResult{
+			Name: "remote tool check",
+			Err:  uc.checkRemoteTool(ctx, target, adapt),
+			Fix:  fmt.Sprintf("Install %s on %s and add it to PATH, or check the %s settings", remoteToolBinary(adapt), target.Name(), target.Via()),
+		})
+	} else {
+		results = append(results, PreCheckResult{
+			Name: "tool check",
+			Err:  uc.checkToolAvailable(ctx, adapt),
+			Fix:  fmt.Sprintf("Install %s and add it to PATH, or set its path in the settings", adapt.Type()),
+		})
 	}
 
-	// Check connection
-	if err := uc.checkConnection(ctx, config.Connection); err != nil {
-		return fmt.Errorf("connection check: %w", err)
+	// Rate profiles re-run the workload with a new --rate per step
+	if config.Options.RateProfile != nil && adapt.Type() != adapter.AdapterTypeSysbench {
+		results = append(results, PreCheckResult{
+			Name: "rate profile check",
+			Err:  fmt.Errorf("rate profiles are only supported for sysbench, not %s", adapt.Type()),
+			Fix:  "Use a fixed rate, or a sysbench template",
+		})
+	}
+
+	// The role of the session may be denied the task or its cleanup
+	if err := uc.requireTaskPermissions(ctx, config.Parameters, config.Options); err != nil {
+		results = append(results, PreCheckResult{
+			Name: "permission check",
+			Err:  err,
+			Fix:  "Unlock the app as admin, or run with cleanup skipped",
+		})
+	}
+
+	// Observer connections are never loaded; protected connections never get tables created or dropped
+	if config.Connection.IsObserver() {
+		results = append(results, PreCheckResult{
+			Name: "observer connection check",
+			Err:  fmt.Errorf("%w: %s", ErrConnectionObserver, config.Connection.GetName()),
+			Fix:  "Benchmark another connection, or clear the Observer flag of the connection on the Connections page",
+		})
+	} else if phases := taskSchemaPhases(config.Parameters, config.Options); config.Connection.IsProtected() && (phases.prepares || phases.cleans) {
+		results = append(results, PreCheckResult{
+			Name: "protected connection check",
+			Err:  fmt.Errorf("%w: %s", ErrConnectionProtected, config.Connection.GetName()),
+			Fix:  "Run with prepare and cleanup skipped, or clear the Protected flag of the connection on the Connections page",
+		})
 	}
 
+	// Check connection
+	connErr := uc.checkConnection(ctx, config.Connection)
+	results = append(results, PreCheckResult{
+		Name: "connection check",
+		Err:  connErr,
+		Fix:  "Test the connection on the Connections page; check the host, port, credentials and firewall",
+	})
+
 	// Check disk space
-	if err := uc.checkDiskSpace(run.WorkDir, 1024*1024*1024); err != nil {
-		return fmt.Errorf("disk space check: %w", err)
+	results = append(results, PreCheckResult{
+		Name: "disk space check",
+		Err:  uc.checkDiskSpace(workDir, 1024*1024*1024),
+		Fix:  fmt.Sprintf("Free at least 1 GB in %s", workDir),
+	})
+
+	// Check privileges and the benchmark schema
+	if connErr == nil && (adapt.Type() == adapter.AdapterTypeSysbench || adapt.Type() == adapter.AdapterTypeHammerDB) {
+		phases := taskSchemaPhases(config.Parameters, config.Options)
+		dbName := benchmarkDatabase(config.Connection, config.Parameters)
+		if required := requiredPrivileges(phases); len(required) > 0 {
+			results = append(results, PreCheckResult{
+				Name: "privilege check",
+				Err:  uc.checkPrivileges(ctx, adapt, config.Connection, dbName, required),
+				Fix:  "Grant the missing privileges to the connection user, or run with a user that has them",
+			})
+		}
+		if adapt.Type() == adapter.AdapterTypeSysbench && phases.runs && !phases.prepares {
+			check := PreCheckResult{
+				Name: "schema check",
+				Fix:  "Run the Prepare phase first to create the benchmark tables",
+			}
+			if !uc.checkTablesExist(ctx, config.Connection, config.Parameters) {
+				check.Err = fmt.Errorf("benchmark tables do not exist in database %s", dbName)
+			}
+			results = append(results, check)
+		}
 	}
 
-	return nil
+	// Check that the swingbench schema was installed
+	if adapt.Type() == adapter.AdapterTypeSwingbench {
+		if phases := taskSchemaPhases(config.Parameters, config.Options); phases.runs && !phases.prepares {
+			results = append(results, PreCheckResult{
+				Name: "SOE schema check",
+				Err:  checkSOESchema(config.Connection),
+				Fix:  "Install the SOE schema with \"Install SOE Schema\" on the Tasks & Monitor page",
+			})
+		}
+	}
+
+	return results
+}
+
+// schemaPhases are the phases of a task that touch the benchmark schema.
+type schemaPhases struct {
+	prepares bool // Creates and loads the tables
+	runs     bool // Runs the workload on the tables
+	cleans   bool // Drops the tables
+}
+
+// taskSchemaPhases returns the phases a task executes, following the modes of executeBenchmark.
+func taskSchemaPhases(params map[string]interface{}, options execution.TaskOptions) schemaPhases {
+	runTime, hasTime := params["time"].(int)
+	_, hasOriginalTime := params["_original_time"].(int)
+	switch {
+	case hasTime && runTime == 0 && hasOriginalTime:
+		return schemaPhases{prepares: true} // Prepare only
+	case hasTime && runTime == 0:
+		return schemaPhases{cleans: !options.SkipCleanup} // Cleanup only
+	default:
+		return schemaPhases{prepares: !options.SkipPrepare, runs: true, cleans: !options.SkipCleanup}
+	}
+}
+
+// benchmarkDatabase returns the database the benchmark tables are created in:
+// the connection's database, the db_name parameter or the sysbench default.
+func benchmarkDatabase(conn connection.Connection, params map[string]interface{}) string {
+	var dbName string
+	switch c := conn.(type) {
+	case *connection.MySQLConnection:
+		dbName = c.Database
+	case *connection.PostgreSQLConnection:
+		dbName = c.Database
+	}
+	if dbName == "" {
+		if db, ok := params["db_name"].(string); ok && db != "" {
+			dbName = db
+		}
+	}
+	if dbName == "" {
+		dbName = "sbtest"
+	}
+	return dbName
+}
+
+// databaseCreator is implemented by adapters that can create the benchmark database.
+type databaseCreator interface {
+	BuildCreateDatabaseCommand(ctx context.Context, config *adapter.Config) (*adapter.Command, error)
 }
 
 // createDatabaseIfNeeded creates the database if it doesn't exist.
 // This runs before the prepare phase to ensure sysbench can connect to the database.
 func (uc *BenchmarkUseCase) createDatabaseIfNeeded(ctx context.Context, run *execution.Run, adapt adapter.BenchmarkAdapter, config *adapter.Config) error {
 	// Check if adapter supports database creation
-	type DatabaseCreator interface {
-		BuildCreateDatabaseCommand(ctx context.Context, config *adapter.Config) (*adapter.Command, error)
-	}
-
-	creator, ok := adapt.(DatabaseCreator)
+	creator, ok := adapt.(databaseCreator)
 	if !ok {
 		// Adapter doesn't support database creation, skip
 		slog.Info("Benchmark: Adapter does not support database creation, skipping", "adapter", adapt.Type())
@@ -443,7 +997,7 @@ func (uc *BenchmarkUseCase) createDatabaseIfNeeded(ctx context.Context, run *exe
 	// Execute command (ignore errors if database already exists)
 	slog.Info("Benchmark: Creating database if not exists",
 		"work_dir", run.WorkDir,
-		"cmd_line", cmd.CmdLine,
+		"cmd_line", cmd.MaskedCmdLine(),
 		"env_vars", len(cmd.Env))
 	if err := uc.executeCommand(ctx, run, cmd); err != nil {
 		// Log error but don't fail - database might already exist
@@ -494,9 +1048,15 @@ func (uc *BenchmarkUseCase) executePhase(
 
 	slog.Info("Benchmark: Executing phase command",
 		"phase", phase,
-		"cmd", cmd.CmdLine,
+		"cmd", cmd.MaskedCmdLine(),
 		"run_id", run.ID)
 
+	// Follow the prepare output to report its progress
+	if phase == "prepare" {
+		uc.startPrepareProgress(run.ID, adapt, config)
+		defer uc.stopPrepareProgress(run.ID)
+	}
+
 	// Execute command
 	if err := uc.executeCommand(ctx, run, cmd); err != nil {
 		slog.Warn("Benchmark: Phase command failed",
@@ -516,6 +1076,9 @@ func (uc *BenchmarkUseCase) executePhase(
 }
 
 // executeWarmup executes the warmup phase.
+// It runs the run-phase workload for warmupTime seconds. Its samples are saved
+// with Phase "warmup" so reports and results only use the measured window,
+// which starts when executeRun begins.
 func (uc *BenchmarkUseCase) executeWarmup(
 	ctx context.Context,
 	run *execution.Run,
@@ -525,22 +1088,112 @@ func (uc *BenchmarkUseCase) executeWarmup(
 ) error {
 	uc.updateState(ctx, run.ID, execution.StateWarmingUp)
 
-	// Build warmup command (same as run but with shorter time)
-	cmd, err := adapt.BuildRunCommand(ctx, config)
+	// Build warmup command: the run command with the warmup duration
+	cmd, err := adapt.BuildRunCommand(ctx, withRunTime(config, warmupTime))
 	if err != nil {
-		return err
+		return fmt.Errorf("build warmup command: %w", err)
+	}
+
+	slog.Info("Benchmark: Warmup started", "run_id", run.ID, "warmup_time", warmupTime)
+	uc.saveLogEntry(ctx, run.ID, LogEntry{
+		Timestamp: time.Now().Format(time.RFC3339),
+		Stream:    "info",
+		Content:   fmt.Sprintf("Warmup: running workload for %ds (excluded from results)", warmupTime),
+	})
+
+	// Safety net against hangs, like the GUI's run timeout
+	warmupCtx, cancel := context.WithTimeout(ctx, time.Duration(warmupTime*2+60)*time.Second)
+	defer cancel()
+
+	// Start command (locally, on the remote host via WinRM or an agent, or on
+	// every agent of a group)
+	var process *exec.Cmd
+	var stdout io.ReadCloser
+	var sampleCh <-chan adapter.Sample
+	var errCh <-chan error
+	done := make(chan error, 1)
+
+	target := uc.remoteTarget(run.ID)
+	outputLog := uc.outputLogSection(run.ID, "warmup")
+	switch group, isGroup := target.(agentGroup); {
+	case isGroup:
+		sampleCh, errCh, _ = uc.startAgentGroup(warmupCtx, run, group, adapt, cmd, done)
+	case target != nil:
+		stdout, err = uc.startRemoteCommand(warmupCtx, run, target, cmd, done)
+		if err != nil {
+			return fmt.Errorf("start remote command: %w", err)
+		}
+	default:
+		process, stdout, _, err = uc.startCommand(warmupCtx, cmd)
+		if err != nil {
+			return fmt.Errorf("start command: %w", err)
+		}
+
+		// Save process reference so the warmup can be stopped
+		uc.trackProcess(run.ID, process, cmd)
+		defer uc.untrackProcess(run.ID, process)
+	}
+	if stdout != nil {
+		defer stdout.Close()
+		sampleCh, errCh, _ = adapt.StartRealtimeCollection(warmupCtx, teeOutput(stdout, outputLog))
 	}
 
-	// Modify time for warmup
-	// This is a simplified version - real implementation would parse and modify the command
-	_ = cmd
-	_ = warmupTime
+	// Collect warmup samples until the output ends, then wait for the process.
+	// A local process is waited for only after its output is read, since Wait
+	// closes the stdout pipe; the context kills it on timeout.
+	ctxDone := warmupCtx.Done()
+	for sampleCh != nil || errCh != nil {
+		select {
+		case sample, ok := <-sampleCh:
+			if !ok {
+				sampleCh = nil
+				continue
+			}
+			uc.recordSample(ctx, run.ID, sample, "warmup")
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+				continue
+			}
+			uc.saveLogEntry(ctx, run.ID, LogEntry{
+				Timestamp: time.Now().Format(time.RFC3339),
+				Stream:    "stderr",
+				Content:   err.Error(),
+			})
+		case <-ctxDone:
+			if process == nil {
+				return warmupCtx.Err()
+			}
+			ctxDone = nil
+		}
+	}
+	if process != nil {
+		done <- process.Wait()
+	}
+	if err := <-done; err != nil {
+		if warmupCtx.Err() != nil {
+			return warmupCtx.Err()
+		}
+		return fmt.Errorf("warmup process error: %w", err)
+	}
 
-	// TODO: Execute warmup
+	slog.Info("Benchmark: Warmup completed", "run_id", run.ID)
 	uc.updateState(ctx, run.ID, execution.StateRunning)
 	return nil
 }
 
+// withRunTime returns a copy of config whose run time is seconds.
+// The original parameters are left unchanged.
+func withRunTime(config *adapter.Config, seconds int) *adapter.Config {
+	copied := *config
+	copied.Parameters = make(map[string]interface{}, len(config.Parameters))
+	for k, v := range config.Parameters {
+		copied.Parameters[k] = v
+	}
+	copied.Parameters["time"] = seconds
+	return &copied
+}
+
 // executeRun executes the main benchmark run with realtime monitoring.
 // Implements: REQ-EXEC-002, REQ-EXEC-004, REQ-EXEC-005
 func (uc *BenchmarkUseCase) executeRun(
@@ -555,10 +1208,13 @@ func (uc *BenchmarkUseCase) executeRun(
 	// Update state
 	uc.updateState(ctx, run.ID, execution.StateRunning)
 
-	// Update started_at
-	now := time.Now()
-	run.StartedAt = &now
-	uc.runRepo.Save(ctx, run)
+	// Update started_at; later steps of a rate profile continue the run phase
+	offset := paramInt(config.Parameters, "_time_offset", 0)
+	if offset == 0 {
+		now := time.Now()
+		run.StartedAt = &now
+		uc.runRepo.Save(ctx, run)
+	}
 
 	// Build run command
 	cmd, err := adapt.BuildRunCommand(ctx, config)
@@ -574,38 +1230,77 @@ func (uc *BenchmarkUseCase) executeRun(
 		defer cancel()
 	}
 
-	// Start command
-	process, stdout, _, err := uc.startCommand(runCtx, cmd)
-	if err != nil {
-		return fmt.Errorf("start command: %w", err)
-	}
+	// Start command (locally, on the remote host via WinRM or an agent, or on
+	// every agent of a group)
+	var process *exec.Cmd
+	var stdout io.ReadCloser
+	var sampleCh <-chan adapter.Sample
+	var errCh <-chan error
+	var outputs []*adapter.OutputBuffer
+	done := make(chan error, 1)
 
-	// Save process reference for later stop operations
-	uc.runningProcessesMu.Lock()
-	uc.runningProcesses[run.ID] = process
-	uc.runningProcessesMu.Unlock()
+	target := uc.remoteTarget(run.ID)
+	outputLog := uc.outputLogSection(run.ID, "run")
+	switch group, isGroup := target.(agentGroup); {
+	case isGroup:
+		sampleCh, errCh, outputs = uc.startAgentGroup(runCtx, run, group, adapt, cmd, done)
+	case target != nil:
+		stdout, err = uc.startRemoteCommand(runCtx, run, target, cmd, done)
+		if err != nil {
+			return fmt.Errorf("start remote command: %w", err)
+		}
+	default:
+		// The output goes to a file, so the tool survives a crash of the
+		// application and can be re-attached to (see ReattachRun)
+		outputFile := filepath.Join(run.WorkDir, RunOutputFile)
+		process, err = startCommandToFile(runCtx, cmd, outputFile)
+		if err != nil {
+			return fmt.Errorf("start command: %w", err)
+		}
 
-	// Clean up process reference when done
-	defer func() {
-		uc.runningProcessesMu.Lock()
-		delete(uc.runningProcesses, run.ID)
-		uc.runningProcessesMu.Unlock()
-	}()
+		// Save process reference for later stop operations
+		uc.trackRecoverableProcess(run.ID, process, cmd, outputFile, runRecovery(run, conn, tmpl, config))
+
+		// Clean up process reference when done
+		defer uc.untrackProcess(run.ID, process)
+		if outputLog != nil {
+			// The output log keeps the output once the run phase is over
+			defer os.Remove(outputFile)
+		}
 
-	// We'll read stderr after process completes
-	// Don't close stderr here - we'll read it after process.Wait()
-	defer stdout.Close()
+		// Follow the output file until the process exits
+		exited := make(chan struct{})
+		stdout, err = followFile(outputFile, exited)
+		if err != nil {
+			return fmt.Errorf("open command output: %w", err)
+		}
+		go func() {
+			err := process.Wait()
+			close(exited)
+			done <- err
+		}()
+	}
 
-	// Start realtime collection from stdout only
-	sampleCh, errCh, stdoutBuf := adapt.StartRealtimeCollection(runCtx, stdout)
+	if stdout != nil {
+		// We'll read stderr after process completes
+		// Don't close stderr here - we'll read it after process.Wait()
+		defer stdout.Close()
 
-	// Monitor process
-	done := make(chan error, 1)
-	go func() {
-		done <- process.Wait()
-	}()
+		// Count the errors the tool reports; the output of local commands
+		// includes their stderr
+		errorLines := uc.errorCounter(run.ID).lines()
+		defer errorLines.Flush()
+		stdout = teeOutput(stdout, errorLines)
 
-	// Collect samples and monitor for completion
+		// Start realtime collection from stdout only
+		var stdoutBuf *adapter.OutputBuffer
+		sampleCh, errCh, stdoutBuf = adapt.StartRealtimeCollection(runCtx, teeOutput(stdout, outputLog))
+		outputs = []*adapter.OutputBuffer{stdoutBuf}
+	}
+
+	// Collect samples and monitor for completion. Local and remote commands
+	// report to done when they exit.
+	processDone := done
 	for {
 		select {
 		case sample, ok := <-sampleCh:
@@ -632,17 +1327,17 @@ func (uc *BenchmarkUseCase) executeRun(
 						uc.runRepo.Save(ctx, run)
 
 						// Save error to logs
-						uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+						uc.saveLogEntry(ctx, run.ID, LogEntry{
 							Timestamp: time.Now().Format(time.RFC3339),
 							Stream:    "error",
 							Content:   "============================================================",
 						})
-						uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+						uc.saveLogEntry(ctx, run.ID, LogEntry{
 							Timestamp: time.Now().Format(time.RFC3339),
 							Stream:    "error",
 							Content:   run.Message,
 						})
-						uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+						uc.saveLogEntry(ctx, run.ID, LogEntry{
 							Timestamp: time.Now().Format(time.RFC3339),
 							Stream:    "error",
 							Content:   "============================================================",
@@ -653,7 +1348,7 @@ func (uc *BenchmarkUseCase) executeRun(
 
 				// Process completed successfully, parse final results
 				slog.Info("Benchmark: Process completed successfully, parsing final results", "run_id", run.ID)
-				finalResult, err := adapt.ParseFinalResults(ctx, stdoutBuf.String())
+				finalResult, err := parseFinalResults(ctx, adapt, outputs)
 				slog.Info("Benchmark: ParseFinalResults returned", "run_id", run.ID, "err", err, "finalResult_nil", finalResult == nil)
 				if err != nil {
 					slog.Error("Benchmark: Failed to parse final results", "run_id", run.ID, "error", err)
@@ -677,42 +1372,21 @@ func (uc *BenchmarkUseCase) executeRun(
 
 					// Convert finalResult to BenchmarkResult and save to run
 					slog.Info("Benchmark: Creating BenchmarkResult", "run_id", run.ID)
-					result := &execution.BenchmarkResult{
-						RunID:             run.ID,
-						TPSCalculated:     finalResult.TransactionsPerSec,
-						LatencyAvg:        finalResult.LatencyAvg,
-						LatencyMin:        finalResult.LatencyMin,
-						LatencyMax:        finalResult.LatencyMax,
-						LatencyP95:        finalResult.LatencyP95,
-						LatencyP99:        finalResult.LatencyP99,
-						LatencySum:        finalResult.LatencySum,
-						TotalTransactions: finalResult.TotalTransactions,
-						TotalQueries:      finalResult.TotalQueries,
-						Duration:          time.Duration(finalResult.TotalTime) * time.Second,
-
-						// SQL Statistics
-						ReadQueries:   finalResult.ReadQueries,
-						WriteQueries:  finalResult.WriteQueries,
-						OtherQueries:  finalResult.OtherQueries,
-						IgnoredErrors: finalResult.IgnoredErrors,
-						Reconnects:    finalResult.Reconnects,
-
-						// General Statistics
-						TotalTime:   finalResult.TotalTime,
-						TotalEvents: finalResult.TotalEvents,
-
-						// Threads Fairness
-						EventsAvg:      finalResult.EventsAvg,
-						EventsStddev:   finalResult.EventsStddev,
-						ExecTimeAvg:    finalResult.ExecTimeAvg,
-						ExecTimeStddev: finalResult.ExecTimeStddev,
-
-						// Connection and Template Info (for History)
-						ConnectionName: conn.GetName(),
-						TemplateName:   tmpl.Name,
-						DatabaseType:   string(conn.GetType()),
-						Threads:        threads,
-						StartTime:      *run.StartedAt,
+					result := newBenchmarkResult(run, finalResult)
+
+					// Connection and Template Info (for History)
+					result.ConnectionName = conn.GetName()
+					result.TemplateName = tmpl.Name
+					result.DatabaseType = string(conn.GetType())
+					result.Threads = threads
+					result.Agents = config.Options.LoadGenerators()
+					if adapter.IsSysbenchTPCC(tmpl) {
+						result.TpmC = adapter.TPCCTpmC(result.TPSCalculated)
+					}
+
+					// Attach the time series; warmup samples keep Phase "warmup"
+					if samples, err := uc.runRepo.GetMetricSamples(ctx, run.ID); err == nil {
+						result.TimeSeries = samples
 					}
 
 					slog.Info("Benchmark: Saving result to run", "run_id", run.ID)
@@ -726,45 +1400,10 @@ func (uc *BenchmarkUseCase) executeRun(
 				}
 				return nil
 			}
-			// Save metric sample with error handling
-			func() {
-				defer func() {
-					if r := recover(); r != nil {
-						slog.Error("Benchmark: Panic in SaveMetricSample", "run_id", run.ID, "panic", r)
-					}
-				}()
-				metricSample := execution.MetricSample{
-					Timestamp:  sample.Timestamp,
-					Phase:      "run",
-					TPS:        sample.TPS,
-					QPS:        sample.QPS,
-					LatencyAvg: sample.LatencyAvg,
-					LatencyP95: sample.LatencyP95,
-					LatencyP99: sample.LatencyP99,
-					ErrorRate:  sample.ErrorRate,
-					RawLine:    sample.RawLine,
-				}
-				if err := uc.runRepo.SaveMetricSample(ctx, run.ID, metricSample); err != nil {
-					slog.Error("Benchmark: Failed to save metric sample", "run_id", run.ID, "error", err)
-				}
-
-				// Invoke realtime callback if set (for UI streaming)
-				uc.realtimeCallbackMu.RLock()
-				callback := uc.realtimeCallback
-				uc.realtimeCallbackMu.RUnlock()
-
-				if callback != nil {
-					// Call callback in goroutine to avoid blocking sample processing
-					go func() {
-						defer func() {
-							if r := recover(); r != nil {
-								slog.Error("Benchmark: Panic in realtime callback", "run_id", run.ID, "panic", r)
-							}
-						}()
-						callback(run.ID, metricSample)
-					}()
-				}
-			}()
+			if offset > 0 {
+				sample.RawLine = shiftReportSecond(sample.RawLine, offset)
+			}
+			uc.recordSample(ctx, run.ID, sample, "run")
 
 		case err, ok := <-errCh:
 			if !ok {
@@ -778,84 +1417,75 @@ func (uc *BenchmarkUseCase) executeRun(
 						slog.Error("Benchmark: Panic in SaveLogEntry", "run_id", run.ID, "panic", r)
 					}
 				}()
-				uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
+				uc.saveLogEntry(ctx, run.ID, LogEntry{
 					Timestamp: time.Now().Format(time.RFC3339),
 					Stream:    "stderr",
 					Content:   err.Error(),
 				})
 			}()
 
-		case err := <-done:
-			if err != nil {
-				// Check if error is "table does not exist"
-				errMsg := err.Error()
-				slog.Info("Benchmark: Run command failed, checking error type", "run_id", run.ID, "error", errMsg)
-
-				if strings.Contains(errMsg, "1146") || // Table doesn't exist
-					strings.Contains(errMsg, "Table.*doesn't exist") ||
-					strings.Contains(errMsg, "Table.*not exist") ||
-					strings.Contains(errMsg, "no such table") {
-					// Table does not exist - set user-friendly message
-					slog.Info("Benchmark: Run phase - tables do not exist", "run_id", run.ID)
-					run.Message = "✗ Error: Benchmark tables do not exist\n\nPlease run the Prepare phase first to create the tables and load data.\n\nGo to Task Configuration and click the '📦 Prepare' button."
-					uc.runRepo.Save(ctx, run)
-
-					// Save log entries
-					msg1 := "✗ Error: Benchmark tables do not exist"
-					msg2 := "Please run the Prepare phase first to create the tables and load data."
-					msg3 := "Go to Task Configuration and click the '📦 Prepare' button."
-					uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
-						Timestamp: time.Now().Format(time.RFC3339),
-						Stream:    "error",
-						Content:   strings.Repeat("=", 60),
-					})
-					uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
-						Timestamp: time.Now().Format(time.RFC3339),
-						Stream:    "error",
-						Content:   msg1,
-					})
-					uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
-						Timestamp: time.Now().Format(time.RFC3339),
-						Stream:    "info",
-						Content:   msg2,
-					})
-					uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
-						Timestamp: time.Now().Format(time.RFC3339),
-						Stream:    "info",
-						Content:   msg3,
-					})
-					uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
-						Timestamp: time.Now().Format(time.RFC3339),
-						Stream:    "error",
-						Content:   strings.Repeat("=", 60),
-					})
-				}
-				return fmt.Errorf("process error: %w", err)
+		case err := <-processDone:
+			if err == nil {
+				// The rest of the output may not be read yet; the closed
+				// sample channel finishes the run with the final results
+				done <- nil
+				processDone = nil
+				continue
 			}
-			// Process completed successfully, parse final results
-			finalResult, err := adapt.ParseFinalResults(ctx, stdoutBuf.String())
-			if err != nil {
-				slog.Warn("Benchmark: Failed to parse final results", "run_id", run.ID, "error", err)
-			} else {
-				// Save final results to run
-				slog.Info("Benchmark: Final results parsed",
-					"run_id", run.ID,
-					"tps", finalResult.TransactionsPerSec,
-					"qps", finalResult.QueriesPerSec,
-					"latency_avg", finalResult.LatencyAvg,
-					"latency_p95", finalResult.LatencyP95)
-				// TODO: Save finalResult to run object or database
+			// Check if error is "table does not exist"
+			errMsg := err.Error()
+			slog.Info("Benchmark: Run command failed, checking error type", "run_id", run.ID, "error", errMsg)
+
+			if strings.Contains(errMsg, "1146") || // Table doesn't exist
+				strings.Contains(errMsg, "Table.*doesn't exist") ||
+				strings.Contains(errMsg, "Table.*not exist") ||
+				strings.Contains(errMsg, "no such table") {
+				// Table does not exist - set user-friendly message
+				slog.Info("Benchmark: Run phase - tables do not exist", "run_id", run.ID)
+				run.Message = "✗ Error: Benchmark tables do not exist\n\nPlease run the Prepare phase first to create the tables and load data.\n\nGo to Task Configuration and click the '📦 Prepare' button."
+				uc.runRepo.Save(ctx, run)
+
+				// Save log entries
+				msg1 := "✗ Error: Benchmark tables do not exist"
+				msg2 := "Please run the Prepare phase first to create the tables and load data."
+				msg3 := "Go to Task Configuration and click the '📦 Prepare' button."
+				uc.saveLogEntry(ctx, run.ID, LogEntry{
+					Timestamp: time.Now().Format(time.RFC3339),
+					Stream:    "error",
+					Content:   strings.Repeat("=", 60),
+				})
+				uc.saveLogEntry(ctx, run.ID, LogEntry{
+					Timestamp: time.Now().Format(time.RFC3339),
+					Stream:    "error",
+					Content:   msg1,
+				})
+				uc.saveLogEntry(ctx, run.ID, LogEntry{
+					Timestamp: time.Now().Format(time.RFC3339),
+					Stream:    "info",
+					Content:   msg2,
+				})
+				uc.saveLogEntry(ctx, run.ID, LogEntry{
+					Timestamp: time.Now().Format(time.RFC3339),
+					Stream:    "info",
+					Content:   msg3,
+				})
+				uc.saveLogEntry(ctx, run.ID, LogEntry{
+					Timestamp: time.Now().Format(time.RFC3339),
+					Stream:    "error",
+					Content:   strings.Repeat("=", 60),
+				})
 			}
-			return nil
+			return fmt.Errorf("process error: %w", err)
 
 		case <-runCtx.Done():
 			// Timeout or cancellation
-			if process.Process != nil {
-				process.Process.Signal(syscall.SIGTERM)
+			// Remote commands are terminated by the cancelled context
+			if process != nil && process.Process != nil {
+				signalProcessGroup(process.Process.Pid, syscall.SIGTERM)
 				select {
 				case <-time.After(30 * time.Second):
 					// Force kill after 30 seconds
-					process.Process.Signal(syscall.SIGKILL)
+					signalProcessGroup(process.Process.Pid, syscall.SIGKILL)
 				case <-done:
 				}
 			}
@@ -864,6 +1494,84 @@ func (uc *BenchmarkUseCase) executeRun(
 	}
 }
 
+// newBenchmarkResult converts the final results of a run phase into the result
+// of run. The caller sets the connection, template and load generator fields.
+func newBenchmarkResult(run *execution.Run, finalResult *adapter.FinalResult) *execution.BenchmarkResult {
+	return &execution.BenchmarkResult{
+		RunID:             run.ID,
+		TPSCalculated:     finalResult.TransactionsPerSec,
+		LatencyAvg:        finalResult.LatencyAvg,
+		LatencyMin:        finalResult.LatencyMin,
+		LatencyMax:        finalResult.LatencyMax,
+		LatencyP95:        finalResult.LatencyP95,
+		LatencyP99:        finalResult.LatencyP99,
+		LatencySum:        finalResult.LatencySum,
+		TotalTransactions: finalResult.TotalTransactions,
+		TotalQueries:      finalResult.TotalQueries,
+		Duration:          time.Duration(finalResult.TotalTime) * time.Second,
+
+		// SQL Statistics
+		ReadQueries:   finalResult.ReadQueries,
+		WriteQueries:  finalResult.WriteQueries,
+		OtherQueries:  finalResult.OtherQueries,
+		IgnoredErrors: finalResult.IgnoredErrors,
+		Reconnects:    finalResult.Reconnects,
+
+		// General Statistics
+		TotalTime:   finalResult.TotalTime,
+		TotalEvents: finalResult.TotalEvents,
+
+		// Threads Fairness
+		EventsAvg:      finalResult.EventsAvg,
+		EventsStddev:   finalResult.EventsStddev,
+		ExecTimeAvg:    finalResult.ExecTimeAvg,
+		ExecTimeStddev: finalResult.ExecTimeStddev,
+
+		StartTime:      *run.StartedAt,
+		SampleInterval: run.SampleInterval,
+	}
+}
+
+// recordSample saves a realtime sample under the given phase and forwards it to the realtime callback.
+func (uc *BenchmarkUseCase) recordSample(ctx context.Context, runID string, sample adapter.Sample, phase string) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Benchmark: Panic in SaveMetricSample", "run_id", runID, "panic", r)
+		}
+	}()
+	metricSample := execution.MetricSample{
+		Timestamp:  sample.Timestamp,
+		Phase:      phase,
+		TPS:        sample.TPS,
+		QPS:        sample.QPS,
+		LatencyAvg: sample.LatencyAvg,
+		LatencyP95: sample.LatencyP95,
+		LatencyP99: sample.LatencyP99,
+		ErrorRate:  sample.ErrorRate,
+		RawLine:    sample.RawLine,
+	}
+	if err := uc.runRepo.SaveMetricSample(ctx, runID, metricSample); err != nil {
+		slog.Error("Benchmark: Failed to save metric sample", "run_id", runID, "error", err)
+	}
+
+	// Invoke realtime callback if set (for UI streaming)
+	uc.realtimeCallbackMu.RLock()
+	callback := uc.realtimeCallback
+	uc.realtimeCallbackMu.RUnlock()
+
+	if callback != nil {
+		// Call callback in goroutine to avoid blocking sample processing
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					slog.Error("Benchmark: Panic in realtime callback", "run_id", runID, "panic", r)
+				}
+			}()
+			callback(runID, metricSample)
+		}()
+	}
+}
+
 // executeCleanup executes the cleanup phase (non-blocking).
 func (uc *BenchmarkUseCase) executeCleanup(
 	ctx context.Context,
@@ -878,22 +1586,37 @@ func (uc *BenchmarkUseCase) executeCleanup(
 
 	// Execute without blocking
 	go func() {
-		uc.executeCommand(context.Background(), run, cmd)
+		if err := uc.executeCommand(context.Background(), run, cmd); err == nil {
+			uc.recordSOESchema(context.Background(), adapt, config.Connection, config.Parameters, false)
+			uc.recordDataset(context.Background(), adapt, config, false)
+		}
 	}()
 }
 
 // executeCommand executes a command and saves logs.
 func (uc *BenchmarkUseCase) executeCommand(ctx context.Context, run *execution.Run, cmd *adapter.Command) error {
+	// Run on the database host if this is a remote run
+	if target := uc.remoteTarget(run.ID); target != nil {
+		return uc.executeRemoteCommand(ctx, run, target, cmd)
+	}
+
 	// Parse command line
 	parts, err := parseCommandLine(cmd.CmdLine)
 	if err != nil {
 		return err
 	}
+	if err := writeCredentialFiles(cmd); err != nil {
+		return err
+	}
 
 	// Create command
 	execCmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
 	execCmd.Dir = cmd.WorkDir
 	execCmd.Env = append(os.Environ(), cmd.Env...)
+	if cmd.Stdin != "" {
+		execCmd.Stdin = strings.NewReader(cmd.Stdin)
+	}
+	setProcessGroup(execCmd)
 
 	// Debug: Log command execution with environment details
 	hasMYSQL_PWD := false
@@ -910,66 +1633,115 @@ func (uc *BenchmarkUseCase) executeCommand(ctx context.Context, run *execution.R
 	// Log the actual command that will be executed
 	slog.Info("Benchmark: === EXECUTING COMMAND ===",
 		"run_id", run.ID,
-		"binary", parts[0],
-		"arguments", parts[1:],
+		"cmd", cmd.MaskedCmdLine(),
 		"work_dir", execCmd.Dir,
 		"env_count", len(execCmd.Env),
 		"has_mysql_pwd", hasMYSQL_PWD,
 		"has_pgpassword", hasPGPASSWORD)
 
-	// Use CombinedOutput to capture both stdout and stderr
-	// This avoids the race condition of reading both pipes concurrently
-	output, err := execCmd.CombinedOutput()
-
-	// Split output into lines and save to repository
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
-		// Determine if this is stderr (error messages) by checking content
-		stream := "stdout"
-		lineLower := strings.ToLower(line)
-		if strings.Contains(lineLower, "error") ||
-			strings.Contains(lineLower, "failed") ||
-			strings.Contains(lineLower, "fatal") ||
-			strings.Contains(lineLower, "warning") {
-			stream = "stderr"
-		}
-
-		// Save to repository
-		uc.runRepo.SaveLogEntry(ctx, run.ID, LogEntry{
-			Timestamp: time.Now().Format(time.RFC3339),
-			Stream:    stream,
-			Content:   line,
-		})
-
-		// Also log important messages to slog
-		if stream == "stderr" {
-			slog.Info("Benchmark: command output", "run_id", run.ID, "stream", stream, "line", line)
-		}
-	}
+	// Capture both stdout and stderr in one buffer, saving each line to the
+	// run log as it arrives. Sharing one writer makes exec use a single pipe,
+	// which avoids the race condition of reading both pipes concurrently.
+	var outputBuf bytes.Buffer
+	lines := newLineWriter(func(line string) {
+		uc.saveOutputLine(ctx, run.ID, line)
+	})
+	writers := []io.Writer{&outputBuf, lines}
+	if outputLog := uc.outputLogSection(run.ID, filepath.Base(parts[0])); outputLog != nil {
+		writers = append(writers, outputLog)
+	}
+	output := io.MultiWriter(writers...)
+	execCmd.Stdout = output
+	execCmd.Stderr = output
+	err = execCmd.Start()
+	if err == nil {
+		uc.trackProcess(run.ID, execCmd, cmd)
+		err = execCmd.Wait()
+		uc.untrackProcess(run.ID, execCmd)
+	}
+	lines.Flush()
 
 	// If command failed, return error with output
 	if err != nil {
-		slog.Error("Benchmark: Command failed", "run_id", run.ID, "exit_error", err, "output", string(output))
+		slog.Error("Benchmark: Command failed", "run_id", run.ID, "exit_error", err, "output", outputBuf.String())
 		// Return error that includes output information
-		return fmt.Errorf("command failed with exit status %v: %w", err, fmt.Errorf("output:\n%s", string(output)))
+		return fmt.Errorf("command failed with exit status %v: %w", err, fmt.Errorf("output:\n%s", outputBuf.String()))
 	}
 
 	return nil
 }
 
+// saveOutputLine saves a line of local command output to the run log.
+func (uc *BenchmarkUseCase) saveOutputLine(ctx context.Context, runID, line string) {
+	uc.trackPrepareLine(runID, line)
+
+	// Determine if this is stderr (error messages) by checking content
+	stream := "stdout"
+	lineLower := strings.ToLower(line)
+	if strings.Contains(lineLower, "error") ||
+		strings.Contains(lineLower, "failed") ||
+		strings.Contains(lineLower, "fatal") ||
+		strings.Contains(lineLower, "warning") {
+		stream = "stderr"
+	}
+
+	// Save to repository
+	uc.saveLogEntry(ctx, runID, LogEntry{
+		Timestamp: time.Now().Format(time.RFC3339),
+		Stream:    stream,
+		Content:   line,
+	})
+
+	// Also log important messages to slog
+	if stream == "stderr" {
+		slog.Info("Benchmark: command output", "run_id", runID, "stream", stream, "line", line)
+	}
+}
+
 // startCommand starts a command and returns the process and pipes.
 func (uc *BenchmarkUseCase) startCommand(ctx context.Context, cmd *adapter.Command) (*exec.Cmd, io.ReadCloser, io.ReadCloser, error) {
-	parts, err := parseCommandLine(cmd.CmdLine)
+	execCmd, err := newCommand(ctx, cmd)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	stdout, err := execCmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	stderr, err := execCmd.StderrPipe()
+	if err != nil {
+		stdout.Close()
+		return nil, nil, nil, err
+	}
+
+	if err := execCmd.Start(); err != nil {
+		stdout.Close()
+		stderr.Close()
+		return nil, nil, nil, fmt.Errorf("start command: %w", err)
+	}
+
+	return execCmd, stdout, stderr, nil
+}
+
+// newCommand creates the local process of cmd in its own process group.
+func newCommand(ctx context.Context, cmd *adapter.Command) (*exec.Cmd, error) {
+	parts, err := parseCommandLine(cmd.CmdLine)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeCredentialFiles(cmd); err != nil {
+		return nil, err
+	}
+
 	execCmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
 	execCmd.Dir = cmd.WorkDir
 	execCmd.Env = append(os.Environ(), cmd.Env...)
+	if cmd.Stdin != "" {
+		execCmd.Stdin = strings.NewReader(cmd.Stdin)
+	}
+	setProcessGroup(execCmd)
 
 	// Debug: Log command execution with environment details
 	hasMYSQL_PWD := false
@@ -980,29 +1752,34 @@ func (uc *BenchmarkUseCase) startCommand(ctx context.Context, cmd *adapter.Comma
 		}
 	}
 	slog.Info("Benchmark: Starting command",
-		"cmd", execCmd.String(),
+		"cmd", cmd.MaskedCmdLine(),
 		"work_dir", execCmd.Dir,
 		"env_count", len(execCmd.Env),
 		"has_mysql_pwd", hasMYSQL_PWD)
 
-	stdout, err := execCmd.StdoutPipe()
-	if err != nil {
-		return nil, nil, nil, err
-	}
+	return execCmd, nil
+}
 
-	stderr, err := execCmd.StderrPipe()
-	if err != nil {
-		stdout.Close()
-		return nil, nil, nil, err
+// writeCredentialFiles creates the credential files of cmd in its work
+// directory, readable only by the user.
+func writeCredentialFiles(cmd *adapter.Command) error {
+	for name, content := range cmd.Files {
+		if err := os.WriteFile(filepath.Join(cmd.WorkDir, name), []byte(content), 0600); err != nil {
+			return fmt.Errorf("write credential file: %w", err)
+		}
 	}
+	return nil
+}
 
-	if err := execCmd.Start(); err != nil {
-		stdout.Close()
-		stderr.Close()
-		return nil, nil, nil, fmt.Errorf("start command: %w", err)
+// removeCredentialFiles removes the credential files of the commands of a run
+// from its work directory.
+func removeCredentialFiles(workDir string) {
+	files, _ := filepath.Glob(filepath.Join(workDir, adapter.CredentialFilePrefix+"*"))
+	for _, file := range files {
+		if err := os.Remove(file); err != nil {
+			slog.Warn("Benchmark: Failed to remove credential file", "file", file, "error", err)
+		}
 	}
-
-	return execCmd, stdout, stderr, nil
 }
 
 // captureOutput captures and saves command output.
@@ -1010,7 +1787,7 @@ func (uc *BenchmarkUseCase) captureOutput(ctx context.Context, runID, stream str
 	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
 		line := scanner.Text()
-		uc.runRepo.SaveLogEntry(ctx, runID, LogEntry{
+		uc.saveLogEntry(ctx, runID, LogEntry{
 			Timestamp: time.Now().Format(time.RFC3339),
 			Stream:    stream,
 			Content:   line,
@@ -1023,7 +1800,11 @@ func (uc *BenchmarkUseCase) captureOutput(ctx context.Context, runID, stream str
 // Implements: REQ-EXEC-006, REQ-EXEC-007, REQ-EXEC-009
 // =============================================================================
 
-// StopBenchmark stops a running benchmark.
+// StopBenchmark stops a benchmark in any phase, including prepare and cleanup.
+// The run is marked cancelled (force stopped for a forced stop of the run phase)
+// before its process group receives SIGTERM, so the phase that fails because of
+// the stop leaves the run in that state instead of failing it. A forced stop
+// sends SIGKILL if the process is still alive after 2 seconds.
 // Implements: REQ-EXEC-006 (graceful stop)
 func (uc *BenchmarkUseCase) StopBenchmark(ctx context.Context, runID string, force bool) error {
 	slog.Info("Benchmark: StopBenchmark called", "run_id", runID, "force", force)
@@ -1041,10 +1822,19 @@ func (uc *BenchmarkUseCase) StopBenchmark(ctx context.Context, runID string, for
 	slog.Info("Benchmark: Run state", "run_id", runID, "state", run.State)
 
 	// Check state
-	if run.State != execution.StateRunning && run.State != execution.StateWarmingUp {
+	if run.State.IsTerminal() {
 		return fmt.Errorf("%w: run is not running", ErrInvalidState)
 	}
 
+	// Only the run phase can be force stopped; other phases are cancelled
+	stopState := execution.StateCancelled
+	if force && run.State == execution.StateRunning {
+		stopState = execution.StateForceStopped
+	}
+	if err := uc.updateState(ctx, runID, stopState); err != nil {
+		return fmt.Errorf("update state: %w", err)
+	}
+
 	// Get the running process and kill it
 	uc.runningProcessesMu.Lock()
 	process := uc.runningProcesses[runID]
@@ -1055,8 +1845,9 @@ func (uc *BenchmarkUseCase) StopBenchmark(ctx context.Context, runID string, for
 	if process != nil && process.Process != nil {
 		slog.Info("Benchmark: Stopping process", "run_id", runID, "force", force, "pid", process.Process.Pid)
 
-		// Send SIGTERM first (graceful shutdown)
-		if err := process.Process.Signal(syscall.SIGTERM); err != nil {
+		// Send SIGTERM first (graceful shutdown) to the whole process group,
+		// so child processes of the tool are stopped as well
+		if err := signalProcessGroup(process.Process.Pid, syscall.SIGTERM); err != nil {
 			slog.Error("Benchmark: Failed to send SIGTERM", "run_id", runID, "error", err)
 		} else {
 			slog.Info("Benchmark: SIGTERM sent successfully", "run_id", runID)
@@ -1065,20 +1856,164 @@ func (uc *BenchmarkUseCase) StopBenchmark(ctx context.Context, runID string, for
 		// If force stopping, wait a bit then send SIGKILL if needed
 		if force {
 			time.Sleep(2 * time.Second)
-			if err := process.Process.Signal(syscall.SIGKILL); err != nil {
+			if err := signalProcessGroup(process.Process.Pid, syscall.SIGKILL); err != nil {
 				slog.Error("Benchmark: Failed to send SIGKILL", "run_id", runID, "error", err)
 			} else {
 				slog.Info("Benchmark: SIGKILL sent successfully", "run_id", runID)
 			}
 		}
+	} else if uc.cancelRemoteCommand(runID) {
+		slog.Info("Benchmark: Remote command cancelled", "run_id", runID, "force", force)
+	} else if uc.stopReattachedRun(ctx, runID, force) {
+		slog.Info("Benchmark: Re-attached process stopped", "run_id", runID, "force", force)
 	} else {
 		slog.Error("Benchmark: Process not found in map or Process is nil", "run_id", runID)
 	}
 
-	if force {
-		return uc.updateState(ctx, runID, execution.StateForceStopped)
+	return nil
+}
+
+// isStopped returns true if the run was stopped with StopBenchmark.
+func (uc *BenchmarkUseCase) isStopped(ctx context.Context, runID string) bool {
+	run, err := uc.runRepo.FindByID(ctx, runID)
+	if err != nil {
+		return false
+	}
+	return run.State == execution.StateCancelled || run.State == execution.StateForceStopped
+}
+
+// cancelledDuring returns true if a phase failed because the run was stopped.
+// It then records what the interrupted phase left behind in the run log and
+// the run message, and completes the run in its cancelled state.
+func (uc *BenchmarkUseCase) cancelledDuring(ctx context.Context, runID, phase string) bool {
+	run, err := uc.runRepo.FindByID(ctx, runID)
+	if err != nil || (run.State != execution.StateCancelled && run.State != execution.StateForceStopped) {
+		return false
+	}
+
+	message := upperFirst(phase) + " phase cancelled"
+	switch phase {
+	case "prepare":
+		message += ": the benchmark tables may be partially loaded, run Cleanup before preparing again"
+	case "cleanup":
+		message += ": some benchmark tables may remain, run Cleanup again to remove them"
+	}
+	slog.Info("Benchmark: Phase cancelled", "run_id", runID, "phase", phase)
+	uc.saveLogEntry(ctx, runID, LogEntry{
+		Timestamp: time.Now().Format(time.RFC3339),
+		Stream:    "info",
+		Content:   message,
+	})
+
+	now := time.Now()
+	run.Message = message
+	if run.CompletedAt == nil {
+		run.CompletedAt = &now
+	}
+	run.CalculateDuration()
+	uc.runRepo.Save(ctx, run)
+	return true
+}
+
+// upperFirst returns s with its first letter in upper case.
+func upperFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// trackProcess registers a started local process of a run, so it can be stopped,
+// and records it in the process repository for cleanup after a crash. The
+// record holds the command line of cmd with its secrets masked.
+func (uc *BenchmarkUseCase) trackProcess(runID string, process *exec.Cmd, cmd *adapter.Command) {
+	uc.trackRecoverableProcess(runID, process, cmd, "", nil)
+}
+
+// trackRecoverableProcess is trackProcess for a run phase process writing its
+// output to outputFile; the record lets the GUI re-attach to it after a crash.
+func (uc *BenchmarkUseCase) trackRecoverableProcess(runID string, process *exec.Cmd, cmd *adapter.Command, outputFile string, recovery *RunRecovery) {
+	uc.runningProcessesMu.Lock()
+	uc.runningProcesses[runID] = process
+	uc.runningProcessesMu.Unlock()
+
+	if uc.processRepo == nil {
+		return
+	}
+	proc := RunProcess{
+		PID:       process.Process.Pid,
+		RunID:     runID,
+		Command:   cmd.Mask(process.String()),
+		BootID:    currentBootID(),
+		StartedAt: time.Now(),
+
+		OutputFile: outputFile,
+		Recovery:   recovery,
+	}
+	if err := uc.processRepo.Save(context.Background(), proc); err != nil {
+		slog.Warn("Benchmark: Failed to record process", "run_id", runID, "pid", proc.PID, "error", err)
+	}
+}
+
+// untrackProcess removes a finished process registered with trackProcess.
+func (uc *BenchmarkUseCase) untrackProcess(runID string, process *exec.Cmd) {
+	uc.runningProcessesMu.Lock()
+	if uc.runningProcesses[runID] == process {
+		delete(uc.runningProcesses, runID)
+	}
+	uc.runningProcessesMu.Unlock()
+
+	if uc.processRepo == nil {
+		return
+	}
+	if err := uc.processRepo.Delete(context.Background(), process.Process.Pid); err != nil {
+		slog.Warn("Benchmark: Failed to remove process record", "run_id", runID, "pid", process.Process.Pid, "error", err)
 	}
-	return uc.updateState(ctx, runID, execution.StateCancelled)
+}
+
+// ReapOrphanedProcesses kills the process groups of benchmark processes that were
+// recorded but never finished, e.g. because the application crashed during a run.
+// It should be called on startup, before any benchmark is started.
+// Records from an earlier system boot are dropped without signalling, since their
+// process IDs may have been reused. Run phase processes of this boot that can be
+// re-attached to are left for OrphanedRuns. Returns the number of process groups killed.
+func (uc *BenchmarkUseCase) ReapOrphanedProcesses(ctx context.Context) (int, error) {
+	if uc.processRepo == nil {
+		return 0, nil
+	}
+
+	procs, err := uc.processRepo.FindAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list recorded processes: %w", err)
+	}
+
+	bootID := currentBootID()
+	killed := 0
+	for _, proc := range procs {
+		switch {
+		case proc.BootID != bootID:
+			slog.Info("Benchmark: Dropping process record from earlier boot", "run_id", proc.RunID, "pid", proc.PID)
+		case proc.Recoverable():
+			slog.Info("Benchmark: Keeping orphaned run for recovery", "run_id", proc.RunID, "pid", proc.PID)
+			continue
+		case !processGroupExists(proc.PID):
+			slog.Info("Benchmark: Recorded process already exited", "run_id", proc.RunID, "pid", proc.PID)
+		default:
+			slog.Warn("Benchmark: Killing orphaned process group", "run_id", proc.RunID, "pid", proc.PID, "cmd", proc.Command)
+			if err := signalProcessGroup(proc.PID, syscall.SIGKILL); err != nil && !errors.Is(err, os.ErrProcessDone) {
+				slog.Error("Benchmark: Failed to kill orphaned process group", "run_id", proc.RunID, "pid", proc.PID, "error", err)
+				continue
+			}
+			killed++
+		}
+
+		if err := uc.processRepo.Delete(ctx, proc.PID); err != nil {
+			return killed, fmt.Errorf("delete process record: %w", err)
+		}
+	}
+
+	return killed, nil
 }
 
 // GetBenchmarkStatus returns the current status of a benchmark run.
@@ -1100,6 +2035,45 @@ func (uc *BenchmarkUseCase) updateState(ctx context.Context, runID string, state
 	return uc.runRepo.UpdateState(ctx, runID, state)
 }
 
+// notifyRunStarted invokes the run started callback.
+func (uc *BenchmarkUseCase) notifyRunStarted(run *execution.Run, conn connection.Connection, tmpl *domaintemplate.Template) {
+	uc.realtimeCallbackMu.RLock()
+	callback := uc.startedCallback
+	uc.realtimeCallbackMu.RUnlock()
+	if callback == nil {
+		return
+	}
+
+	snapshot := *run // The run is updated while the benchmark executes
+	callback(RunEvent{
+		Run:            &snapshot,
+		ConnectionName: conn.GetName(),
+		TemplateName:   tmpl.Name,
+		DatabaseType:   string(conn.GetType()),
+	})
+}
+
+// notifyRunFinished invokes the run finished callback if the run reached a terminal state.
+func (uc *BenchmarkUseCase) notifyRunFinished(ctx context.Context, runID string, conn connection.Connection, tmpl *domaintemplate.Template) {
+	uc.realtimeCallbackMu.RLock()
+	callback := uc.finishedCallback
+	uc.realtimeCallbackMu.RUnlock()
+	if callback == nil {
+		return
+	}
+
+	run, err := uc.runRepo.FindByID(ctx, runID)
+	if err != nil || !run.State.IsTerminal() {
+		return
+	}
+	callback(RunEvent{
+		Run:            run,
+		ConnectionName: conn.GetName(),
+		TemplateName:   tmpl.Name,
+		DatabaseType:   string(conn.GetType()),
+	})
+}
+
 // markAsFailed marks a run as failed with an error message.
 func (uc *BenchmarkUseCase) markAsFailed(ctx context.Context, runID string, errMsg string) {
 	if uc.runRepo == nil {
@@ -1161,6 +2135,7 @@ func (uc *BenchmarkUseCase) markAsCompleted(ctx context.Context, runID string, d
 		run.State = execution.StateCompleted
 		run.CompletedAt = &now
 		run.Duration = &duration
+		uc.evaluateAssertions(run)
 		if err := uc.runRepo.Save(ctx, run); err != nil {
 			slog.Error("Benchmark: markAsCompleted failed to save", "run_id", runID, "error", err)
 		} else {
@@ -1171,30 +2146,68 @@ func (uc *BenchmarkUseCase) markAsCompleted(ctx context.Context, runID string, d
 	}
 }
 
-// checkToolAvailable checks if the benchmark tool is available.
-func (uc *BenchmarkUseCase) checkToolAvailable(ctx context.Context, adapt adapter.BenchmarkAdapter) bool {
-	// TODO: Implement tool availability check
-	// For now, return true
-	return true
+// evaluateAssertions evaluates the SLA targets of a completed run. A run
+// missing its targets still completes; only its outcome says it failed.
+func (uc *BenchmarkUseCase) evaluateAssertions(run *execution.Run) {
+	run.EvaluateAssertions()
+	if outcome := run.AssertionOutcome; outcome != nil && !outcome.Passed {
+		slog.Info("Benchmark: Run failed its SLA targets", "run_id", run.ID, "failed", outcome.Failed())
+	}
+}
+
+// checkToolAvailable checks that the executable of the benchmark tool is
+// installed: a configured path must exist, a bare name must be in PATH.
+func (uc *BenchmarkUseCase) checkToolAvailable(ctx context.Context, adapt adapter.BenchmarkAdapter) error {
+	binary := localToolBinary(adapt)
+	if binary == "" {
+		return nil
+	}
+	path, err := exec.LookPath(binary)
+	if err != nil {
+		return fmt.Errorf("tool %s not available: %w", adapt.Type(), err)
+	}
+
+	slog.Info("Benchmark: Tool found", "tool", adapt.Type(), "path", path)
+	return nil
+}
+
+// localToolBinary returns the configured executable a local run of adapt
+// starts, or "" for adapters whose executable is unknown.
+func localToolBinary(adapt adapter.BenchmarkAdapter) string {
+	switch a := adapt.(type) {
+	case *adapter.SysbenchAdapter:
+		return a.SysbenchPath
+	case *adapter.HammerDBAdapter:
+		return a.HammerDBPath
+	case *adapter.SwingbenchAdapter:
+		return a.SwingbenchPath
+	case *adapter.SQLMixAdapter:
+		return a.Executable
+	default:
+		return ""
+	}
 }
 
 // checkConnection checks if the database connection is working.
 func (uc *BenchmarkUseCase) checkConnection(ctx context.Context, conn connection.Connection) error {
-	// Use connection's Test method
-	_, err := conn.Test(ctx)
-	return err
+	// Use connection's Test method; a failed test is reported in the result, not as an error
+	result, err := conn.Test(ctx)
+	if err != nil {
+		return err
+	}
+	if result != nil && !result.Success {
+		return errors.New(result.Error)
+	}
+	return nil
 }
 
 // checkDiskSpace checks if there's enough disk space.
 func (uc *BenchmarkUseCase) checkDiskSpace(path string, requiredBytes int64) error {
-	var stat syscall.Statfs_t
-	if err := syscall.Statfs(path, &stat); err != nil {
+	available, err := availableDiskSpace(path)
+	if err != nil {
 		return err
 	}
 
-	// Calculate available space in bytes
-	available := stat.Bavail * uint64(stat.Bsize)
-
 	if uint64(requiredBytes) > available {
 		return fmt.Errorf("insufficient disk space: need %d bytes, available %d bytes", requiredBytes, available)
 	}
@@ -1204,11 +2217,7 @@ func (uc *BenchmarkUseCase) checkDiskSpace(path string, requiredBytes int64) err
 
 // checkTablesExist checks if the benchmark tables exist in the database
 func (uc *BenchmarkUseCase) checkTablesExist(ctx context.Context, conn connection.Connection, params map[string]interface{}) bool {
-	// Get database name
-	dbName := "sbtest"
-	if db, ok := params["db_name"].(string); ok && db != "" {
-		dbName = db
-	}
+	dbName := benchmarkDatabase(conn, params)
 
 	// Check based on connection type
 	switch c := conn.(type) {
@@ -1225,12 +2234,17 @@ func (uc *BenchmarkUseCase) checkTablesExist(ctx context.Context, conn connectio
 // checkMySQLTablesExist checks if sbtest tables exist in MySQL
 func (uc *BenchmarkUseCase) checkMySQLTablesExist(ctx context.Context, conn *connection.MySQLConnection, dbName string) bool {
 	// Build connection string
+	tlsParams, err := conn.DSNTLSParams()
+	if err != nil {
+		slog.Warn("checkMySQLTablesExist: Failed to load TLS settings", "error", err)
+		return true // Assume tables exist if we can't check
+	}
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s",
 		conn.Username,
 		conn.Password,
 		conn.Host,
 		conn.Port,
-		dbName)
+		dbName) + tlsParams
 
 	// Open database connection
 	db, err := sql.Open("mysql", dsn)
@@ -1260,7 +2274,7 @@ func (uc *BenchmarkUseCase) checkPostgreSQLTablesExist(ctx context.Context, conn
 		dbName,
 		conn.Username,
 		conn.Password,
-		conn.SSLMode)
+		conn.SSLMode) + conn.OptionsDSN()
 
 	// Open database connection
 	db, err := sql.Open("postgres", dsn)
@@ -1360,10 +2374,27 @@ func parseCommandLine(cmdLine string) ([]string, error) {
 	return parts, nil
 }
 
-// GetRunLogs retrieves log entries for a run.
-func (uc *BenchmarkUseCase) GetRunLogs(ctx context.Context, runID string, stream string, limit int) ([]LogEntry, error) {
-	// TODO: Implement log retrieval from run_logs table
-	return []LogEntry{}, nil
+// GetRunLogs retrieves the log entries of a run matching filter, oldest first.
+func (uc *BenchmarkUseCase) GetRunLogs(ctx context.Context, runID string, filter LogFilter) ([]LogEntry, error) {
+	if uc.logRepo == nil {
+		return []LogEntry{}, nil
+	}
+	return uc.logRepo.FindLogEntries(ctx, runID, filter)
+}
+
+// saveLogEntry saves a log entry for a run to the log repository.
+func (uc *BenchmarkUseCase) saveLogEntry(ctx context.Context, runID string, entry LogEntry) error {
+	uc.realtimeCallbackMu.RLock()
+	callback := uc.logCallback
+	uc.realtimeCallbackMu.RUnlock()
+	if callback != nil {
+		callback(runID, entry)
+	}
+
+	if uc.logRepo != nil {
+		return uc.logRepo.SaveLogEntry(ctx, runID, entry)
+	}
+	return uc.runRepo.SaveLogEntry(ctx, runID, entry)
 }
 
 // GetMetricSamples retrieves metric samples for a run.
@@ -1393,11 +2424,11 @@ func (e *BenchmarkExecutor) Stop(force bool) error {
 	}
 
 	if force {
-		return e.cmd.Process.Signal(syscall.SIGKILL)
+		return signalProcessGroup(e.cmd.Process.Pid, syscall.SIGKILL)
 	}
 
 	// Graceful shutdown: SIGTERM
-	if err := e.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+	if err := signalProcessGroup(e.cmd.Process.Pid, syscall.SIGTERM); err != nil {
 		return err
 	}
 
@@ -1412,7 +2443,7 @@ func (e *BenchmarkExecutor) Stop(force bool) error {
 		return nil
 	case <-time.After(30 * time.Second):
 		// Force kill after timeout
-		return e.cmd.Process.Signal(syscall.SIGKILL)
+		return signalProcessGroup(e.cmd.Process.Pid, syscall.SIGKILL)
 	}
 }
 
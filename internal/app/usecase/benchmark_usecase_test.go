@@ -2,13 +2,24 @@
 package usecase
 
 import (
+	"bufio"
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dberror"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/replication"
 	domaintemplate "github.com/whhaicheng/DB-BenchMind/internal/domain/template"
 	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
 )
@@ -190,6 +201,47 @@ func TestBenchmarkUseCase_StopBenchmark(t *testing.T) {
 	}
 }
 
+// TestBenchmarkUseCase_StopBenchmark_Prepare tests cancelling the prepare phase.
+func TestBenchmarkUseCase_StopBenchmark_Prepare(t *testing.T) {
+	ctx := context.Background()
+
+	runRepo := newMockRunRepository()
+	uc := NewBenchmarkUseCase(runRepo, adapter.NewAdapterRegistry(), nil, nil)
+
+	// Prepare-only runs stay pending while the prepare executes
+	for _, state := range []execution.RunState{execution.StatePending, execution.StatePreparing} {
+		run := &execution.Run{
+			ID:        "prepare-" + string(state),
+			TaskID:    "test-task-1",
+			State:     state,
+			CreatedAt: time.Now(),
+		}
+		runRepo.Save(ctx, run)
+
+		// Only the run phase is force stopped
+		if err := uc.StopBenchmark(ctx, run.ID, true); err != nil {
+			t.Fatalf("StopBenchmark() in %s failed: %v", state, err)
+		}
+		if !uc.cancelledDuring(ctx, run.ID, "prepare") {
+			t.Fatalf("cancelledDuring() = false after stopping in %s", state)
+		}
+
+		stopped, _ := runRepo.FindByID(ctx, run.ID)
+		if stopped.State != execution.StateCancelled {
+			t.Errorf("State = %s, want cancelled", stopped.State)
+		}
+		if !strings.HasPrefix(stopped.Message, "Prepare phase cancelled: ") || !strings.Contains(stopped.Message, "run Cleanup") || stopped.CompletedAt == nil {
+			t.Errorf("Message = %q, CompletedAt = %v, want cleanup advice and completion time", stopped.Message, stopped.CompletedAt)
+		}
+
+		// The failing prepare no longer changes the cancelled state
+		uc.markAsFailed(ctx, run.ID, "prepare: signal: terminated")
+		if failed, _ := runRepo.FindByID(ctx, run.ID); failed.State != execution.StateCancelled {
+			t.Errorf("State after markAsFailed = %s, want cancelled", failed.State)
+		}
+	}
+}
+
 // TestBenchmarkUseCase_StopBenchmark_InvalidState tests stopping a non-running benchmark.
 func TestBenchmarkUseCase_StopBenchmark_InvalidState(t *testing.T) {
 	ctx := context.Background()
@@ -367,6 +419,31 @@ func TestCheckDiskSpace(t *testing.T) {
 	}
 }
 
+// TestCheckToolAvailable tests resolving the executable of the benchmark tool.
+func TestCheckToolAvailable(t *testing.T) {
+	ctx := context.Background()
+	uc := &BenchmarkUseCase{}
+
+	// A configured path that does not exist fails the check
+	missing := &adapter.SysbenchAdapter{SysbenchPath: filepath.Join(t.TempDir(), "sysbench")}
+	if err := uc.checkToolAvailable(ctx, missing); err == nil {
+		t.Error("checkToolAvailable() with a missing sysbench = nil, want error")
+	}
+	missingInPath := &adapter.HammerDBAdapter{HammerDBPath: "db-benchmind-no-such-tool"}
+	if err := uc.checkToolAvailable(ctx, missingInPath); err == nil {
+		t.Error("checkToolAvailable() with a hammerdbcli missing from PATH = nil, want error")
+	}
+
+	// An executable file passes
+	exe := filepath.Join(t.TempDir(), "charbench")
+	if err := os.WriteFile(exe, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := uc.checkToolAvailable(ctx, &adapter.SwingbenchAdapter{SwingbenchPath: exe}); err != nil {
+		t.Errorf("checkToolAvailable() with an installed charbench = %v, want nil", err)
+	}
+}
+
 // TestMarkAsFailed tests marking a run as failed.
 func TestMarkAsFailed(t *testing.T) {
 	ctx := context.Background()
@@ -445,6 +522,14 @@ func (m *mockConnectionRepository) Save(ctx context.Context, conn connection.Con
 	return nil
 }
 
+func (m *mockConnectionRepository) Update(ctx context.Context, conn connection.Connection) error {
+	if _, ok := m.connections[conn.GetID()]; !ok {
+		return ErrConnectionNotFound
+	}
+	m.connections[conn.GetID()] = conn
+	return nil
+}
+
 func (m *mockConnectionRepository) FindByID(ctx context.Context, id string) (connection.Connection, error) {
 	conn, ok := m.connections[id]
 	if !ok {
@@ -526,3 +611,555 @@ func (m *mockTemplateRepositoryForBenchmark) LoadBuiltinTemplates(ctx context.Co
 	}
 	return nil
 }
+
+// warmupTestAdapter runs echo as its workload and reports one sample per output line.
+type warmupTestAdapter struct {
+	adapter.BenchmarkAdapter
+	runTime int // "time" parameter of the last built run command
+}
+
+func (a *warmupTestAdapter) BuildRunCommand(ctx context.Context, config *adapter.Config) (*adapter.Command, error) {
+	a.runTime, _ = config.Parameters["time"].(int)
+	return &adapter.Command{CmdLine: "echo warmup-line", WorkDir: config.WorkDir}, nil
+}
+
+func (a *warmupTestAdapter) StartRealtimeCollection(ctx context.Context, stdout io.Reader) (<-chan adapter.Sample, <-chan error, *adapter.OutputBuffer) {
+	sampleCh := make(chan adapter.Sample)
+	errCh := make(chan error)
+	go func() {
+		defer close(sampleCh)
+		defer close(errCh)
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			sampleCh <- adapter.Sample{Timestamp: time.Now(), TPS: 100, RawLine: scanner.Text()}
+		}
+	}()
+	return sampleCh, errCh, adapter.NewOutputBuffer(0)
+}
+
+// TestExecuteWarmup tests that warmup runs the workload for the warmup time and labels its samples.
+func TestExecuteWarmup(t *testing.T) {
+	if _, err := exec.LookPath("echo"); err != nil {
+		t.Skip("echo not available")
+	}
+	ctx := context.Background()
+	runRepo := NewMemoryRunRepository()
+	uc := NewBenchmarkUseCase(runRepo, nil, nil, nil)
+
+	run := &execution.Run{ID: "warmup-run", State: execution.StatePrepared, WorkDir: t.TempDir()}
+	_ = runRepo.Save(ctx, run)
+
+	adapt := &warmupTestAdapter{}
+	config := &adapter.Config{Parameters: map[string]interface{}{"time": 60}, WorkDir: run.WorkDir}
+
+	if err := uc.executeWarmup(ctx, run, adapt, config, 5); err != nil {
+		t.Fatalf("executeWarmup() error = %v", err)
+	}
+
+	if adapt.runTime != 5 {
+		t.Errorf("warmup command time = %d, want 5", adapt.runTime)
+	}
+	if config.Parameters["time"] != 60 {
+		t.Errorf("run config time = %v, want 60 (unchanged)", config.Parameters["time"])
+	}
+
+	samples, _ := runRepo.GetMetricSamples(ctx, run.ID)
+	if len(samples) != 1 {
+		t.Fatalf("saved %d samples, want 1", len(samples))
+	}
+	if samples[0].Phase != "warmup" {
+		t.Errorf("sample phase = %q, want warmup", samples[0].Phase)
+	}
+	if got, _ := runRepo.FindByID(ctx, run.ID); got.State != execution.StateRunning {
+		t.Errorf("state after warmup = %s, want running", got.State)
+	}
+}
+
+// TestBenchmarkUseCase_PlanBenchmark tests that a dry run plans commands without executing them.
+func TestBenchmarkUseCase_PlanBenchmark(t *testing.T) {
+	ctx := context.Background()
+
+	runRepo := newMockRunRepository()
+	adapterReg := adapter.NewAdapterRegistry()
+	adapterReg.Register(adapter.NewSysbenchAdapter())
+
+	connRepo := newMockConnectionRepository()
+	connRepo.Save(ctx, &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "plan-conn", Name: "Plan Connection"},
+		Host:           "127.0.0.1",
+		Port:           1,
+		Database:       "sbtest",
+		Username:       "root",
+		Password:       "s3cret-pw",
+	})
+
+	templateRepo := newMockTemplateRepositoryForBenchmark()
+	templateRepo.Save(ctx, &domaintemplate.Template{
+		ID:            "sysbench-oltp-read-write",
+		Name:          "Sysbench OLTP",
+		Tool:          "sysbench",
+		DatabaseTypes: []string{"mysql"},
+		CommandTemplate: domaintemplate.CommandTemplate{
+			Prepare: "oltp_read_write prepare",
+			Run:     "oltp_read_write run",
+			Cleanup: "oltp_read_write cleanup",
+		},
+	})
+
+	uc := NewBenchmarkUseCase(runRepo, adapterReg, NewConnectionUseCase(connRepo, nil), NewTemplateUseCase(templateRepo, ""))
+
+	task := &execution.BenchmarkTask{
+		ID:           "plan-task",
+		Name:         "Plan",
+		ConnectionID: "plan-conn",
+		TemplateID:   "sysbench-oltp-read-write",
+		Parameters:   map[string]interface{}{"threads": 4, "time": 60, "db_name": "sbtest"},
+		Options:      execution.TaskOptions{WarmupTime: 10, DryRun: true},
+		CreatedAt:    time.Now(),
+	}
+
+	// A dry run task is never started
+	if _, err := uc.StartBenchmark(ctx, task); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("StartBenchmark() of dry run error = %v, want ErrInvalidState", err)
+	}
+
+	plan, err := uc.PlanBenchmark(ctx, task)
+	if err != nil {
+		t.Fatalf("PlanBenchmark() failed: %v", err)
+	}
+
+	var phases []string
+	for _, cmd := range plan.Commands {
+		phases = append(phases, cmd.Phase)
+		all := cmd.CmdLine + cmd.Stdin + strings.Join(cmd.Env, " ")
+		if strings.Contains(all, "s3cret-pw") {
+			t.Errorf("%s command leaks the password: %+v", cmd.Phase, cmd)
+		}
+	}
+	if got, want := strings.Join(phases, ","), "create-database,prepare,warmup,run,cleanup"; got != want {
+		t.Errorf("phases = %s, want %s", got, want)
+	}
+
+	// The warmup command runs for the warmup time, the run command for the full time
+	for _, cmd := range plan.Commands {
+		switch cmd.Phase {
+		case "warmup":
+			if !strings.Contains(cmd.CmdLine, "--time=10") {
+				t.Errorf("warmup command = %q, want --time=10", cmd.CmdLine)
+			}
+		case "run":
+			if !strings.Contains(cmd.CmdLine, "--time=60") {
+				t.Errorf("run command = %q, want --time=60", cmd.CmdLine)
+			}
+		}
+	}
+	if task.Parameters["time"] != 60 {
+		t.Errorf("task time parameter changed to %v", task.Parameters["time"])
+	}
+
+	// Nothing listens on port 1, so the connection check fails
+	if plan.PreChecksPassed() {
+		t.Error("PreChecksPassed() = true, want false for an unreachable database")
+	}
+
+	// Failed checks come with a fix; the schema checks need a connection
+	checks, err := uc.PreCheckBenchmark(ctx, task)
+	if err != nil {
+		t.Fatalf("PreCheckBenchmark() failed: %v", err)
+	}
+	for _, check := range checks {
+		if check.Name == "connection check" && (check.Passed() || check.Fix == "") {
+			t.Errorf("connection check = %+v, want a failure with a fix", check)
+		}
+		if check.Name == "privilege check" || check.Name == "schema check" {
+			t.Errorf("%s run without a working connection", check.Name)
+		}
+	}
+	if !strings.Contains(plan.Format(), "fix: Test the connection") {
+		t.Errorf("Format() does not show the fix:\n%s", plan.Format())
+	}
+	if len(runRepo.runs) != 0 {
+		t.Errorf("runs created = %d, want 0", len(runRepo.runs))
+	}
+}
+
+// TestBenchmarkUseCase_PreCheckProtectedConnection tests that prepare and
+// cleanup are blocked on a protected connection, and a bare run is not.
+func TestBenchmarkUseCase_PreCheckProtectedConnection(t *testing.T) {
+	ctx := context.Background()
+
+	adapterReg := adapter.NewAdapterRegistry()
+	adapterReg.Register(adapter.NewSysbenchAdapter())
+
+	connRepo := newMockConnectionRepository()
+	connRepo.Save(ctx, &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "prod-conn", Name: "Production", Protected: true},
+		Host:           "127.0.0.1",
+		Port:           1,
+		Database:       "app",
+		Username:       "root",
+	})
+
+	templateRepo := newMockTemplateRepositoryForBenchmark()
+	templateRepo.Save(ctx, &domaintemplate.Template{
+		ID:            "sysbench-oltp-read-write",
+		Name:          "Sysbench OLTP",
+		Tool:          "sysbench",
+		DatabaseTypes: []string{"mysql"},
+	})
+
+	uc := NewBenchmarkUseCase(newMockRunRepository(), adapterReg, NewConnectionUseCase(connRepo, nil), NewTemplateUseCase(templateRepo, ""))
+
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		options execution.TaskOptions
+		blocked bool
+	}{
+		{"prepare", map[string]interface{}{"threads": 1, "time": 0, "_original_time": 60}, execution.TaskOptions{SkipCleanup: true}, true},
+		{"cleanup", map[string]interface{}{"threads": 1, "time": 0}, execution.TaskOptions{SkipPrepare: true}, true},
+		{"full task", map[string]interface{}{"threads": 1, "time": 60}, execution.TaskOptions{}, true},
+		{"run only", map[string]interface{}{"threads": 1, "time": 60}, execution.TaskOptions{SkipPrepare: true, SkipCleanup: true}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &execution.BenchmarkTask{
+				ID: "task", Name: "Task", ConnectionID: "prod-conn", TemplateID: "sysbench-oltp-read-write",
+				Parameters: tt.params, Options: tt.options, CreatedAt: time.Now(),
+			}
+			checks, err := uc.PreCheckBenchmark(ctx, task)
+			if err != nil {
+				t.Fatalf("PreCheckBenchmark() failed: %v", err)
+			}
+			blocked := false
+			for _, check := range checks {
+				if check.Name == "protected connection check" {
+					blocked = errors.Is(check.Err, ErrConnectionProtected)
+				}
+			}
+			if blocked != tt.blocked {
+				t.Errorf("blocked = %v, want %v", blocked, tt.blocked)
+			}
+		})
+	}
+}
+
+// TestBenchmarkUseCase_PreCheckObserverConnection tests that observer
+// connections block every phase, including a run without prepare and cleanup.
+func TestBenchmarkUseCase_PreCheckObserverConnection(t *testing.T) {
+	ctx := context.Background()
+
+	adapterReg := adapter.NewAdapterRegistry()
+	adapterReg.Register(adapter.NewSysbenchAdapter())
+
+	connRepo := newMockConnectionRepository()
+	connRepo.Save(ctx, &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "prod-conn", Name: "Production", Observer: true},
+		Host:           "127.0.0.1",
+		Port:           1,
+		Database:       "app",
+		Username:       "root",
+	})
+
+	templateRepo := newMockTemplateRepositoryForBenchmark()
+	templateRepo.Save(ctx, &domaintemplate.Template{
+		ID:            "sysbench-oltp-read-write",
+		Name:          "Sysbench OLTP",
+		Tool:          "sysbench",
+		DatabaseTypes: []string{"mysql"},
+	})
+
+	uc := NewBenchmarkUseCase(newMockRunRepository(), adapterReg, NewConnectionUseCase(connRepo, nil), NewTemplateUseCase(templateRepo, ""))
+
+	task := &execution.BenchmarkTask{
+		ID: "task", Name: "Task", ConnectionID: "prod-conn", TemplateID: "sysbench-oltp-read-write",
+		Parameters: map[string]interface{}{"threads": 1, "time": 60},
+		Options:    execution.TaskOptions{SkipPrepare: true, SkipCleanup: true},
+		CreatedAt:  time.Now(),
+	}
+	checks, err := uc.PreCheckBenchmark(ctx, task)
+	if err != nil {
+		t.Fatalf("PreCheckBenchmark() failed: %v", err)
+	}
+	blocked := false
+	for _, check := range checks {
+		if check.Name == "observer connection check" {
+			blocked = errors.Is(check.Err, ErrConnectionObserver)
+		}
+	}
+	if !blocked {
+		t.Error("run-only task on an observer connection was not blocked")
+	}
+}
+
+// TestBenchmarkUseCase_GetRunLogs tests filtering logs kept by the in-memory run repository.
+func TestBenchmarkUseCase_GetRunLogs(t *testing.T) {
+	ctx := context.Background()
+	uc := NewBenchmarkUseCase(NewMemoryRunRepository(), adapter.NewAdapterRegistry(), nil, nil)
+
+	for _, entry := range []LogEntry{
+		{Stream: "stdout", Content: "[ 1s ] tps: 100"},
+		{Stream: "stderr", Content: "FATAL: connection lost"},
+		{Stream: "stdout", Content: "[ 2s ] tps: 120"},
+		{Stream: "info", Content: "done"},
+	} {
+		uc.saveLogEntry(ctx, "run-1", entry)
+	}
+
+	tests := []struct {
+		name   string
+		filter LogFilter
+		want   []int64
+	}{
+		{"all", LogFilter{}, []int64{1, 2, 3, 4}},
+		{"stream", LogFilter{Streams: []string{"stdout"}}, []int64{1, 3}},
+		{"search", LogFilter{Search: "Fatal"}, []int64{2}},
+		{"tail", LogFilter{Tail: 2}, []int64{3, 4}},
+		{"after ID", LogFilter{AfterID: 3}, []int64{4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := uc.GetRunLogs(ctx, "run-1", tt.filter)
+			if err != nil {
+				t.Fatalf("GetRunLogs() failed: %v", err)
+			}
+			var ids []int64
+			for _, entry := range entries {
+				ids = append(ids, entry.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.want) {
+				t.Errorf("IDs = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+// mockSnapshotter returns a fixed snapshot or error.
+type mockSnapshotter struct {
+	snapshot *dbconfig.Snapshot
+	err      error
+}
+
+func (m *mockSnapshotter) Capture(ctx context.Context, conn connection.Connection) (*dbconfig.Snapshot, error) {
+	return m.snapshot, m.err
+}
+
+func TestCaptureConfigSnapshot(t *testing.T) {
+	ctx := context.Background()
+	runRepo := NewMemoryRunRepository()
+	uc := NewBenchmarkUseCase(runRepo, adapter.NewAdapterRegistry(), nil, nil)
+	conn := &connection.MySQLConnection{}
+
+	run := &execution.Run{ID: "run-1", State: execution.StatePrepared}
+	runRepo.Save(ctx, run)
+
+	// A failed capture does not fail the run and is logged
+	uc.SetConfigSnapshotter(&mockSnapshotter{err: errors.New("access denied")})
+	uc.captureConfigSnapshot(ctx, run, conn)
+	if run.ConfigSnapshot != nil {
+		t.Errorf("ConfigSnapshot = %+v, want nil", run.ConfigSnapshot)
+	}
+	if entries, _ := uc.GetRunLogs(ctx, run.ID, LogFilter{Search: "access denied"}); len(entries) != 1 {
+		t.Errorf("got %d log entries about the failed capture, want 1", len(entries))
+	}
+
+	snapshot := &dbconfig.Snapshot{DatabaseType: "mysql", Settings: map[string]string{"max_connections": "151"}}
+	uc.SetConfigSnapshotter(&mockSnapshotter{snapshot: snapshot})
+	uc.captureConfigSnapshot(ctx, run, conn)
+
+	stored, _ := runRepo.FindByID(ctx, run.ID)
+	if stored.ConfigSnapshot != snapshot {
+		t.Errorf("stored ConfigSnapshot = %+v, want %+v", stored.ConfigSnapshot, snapshot)
+	}
+
+	run.Result = &execution.BenchmarkResult{RunID: run.ID}
+	if record := recordFromRun(run); record.ConfigSnapshot != snapshot {
+		t.Errorf("history record ConfigSnapshot = %+v, want the run's snapshot", record.ConfigSnapshot)
+	}
+}
+
+// mockReplicaMonitor reports one poll of every replica at a fixed lag once
+// monitoring ends.
+type mockReplicaMonitor struct {
+	lag float64
+}
+
+func (m *mockReplicaMonitor) Monitor(ctx context.Context, conn connection.Replicated, interval time.Duration) *replication.Stats {
+	stats := replication.NewStats(conn.ReplicaAddresses(), interval)
+	<-ctx.Done()
+	for _, addr := range conn.ReplicaAddresses() {
+		stats.Add(replication.Status{Replica: addr, Running: true, Lag: m.lag})
+	}
+	return stats
+}
+
+func TestStartReplicaMonitor(t *testing.T) {
+	ctx := context.Background()
+	runRepo := NewMemoryRunRepository()
+	uc := NewBenchmarkUseCase(runRepo, adapter.NewAdapterRegistry(), nil, nil)
+	uc.SetReplicaMonitor(&mockReplicaMonitor{lag: 2.5})
+	options := execution.TaskOptions{MonitorReplicas: true}
+
+	run := &execution.Run{ID: "run-1", State: execution.StateRunning}
+	runRepo.Save(ctx, run)
+
+	// Connections without replicas are not monitored, which is logged
+	uc.startReplicaMonitor(ctx, run, &connection.MySQLConnection{}, options)()
+	if run.Replication != nil {
+		t.Errorf("Replication = %+v without replicas, want nil", run.Replication)
+	}
+	if entries, _ := uc.GetRunLogs(ctx, run.ID, LogFilter{Search: "no replicas"}); len(entries) != 1 {
+		t.Errorf("got %d log entries about the skipped monitoring, want 1", len(entries))
+	}
+
+	conn := &connection.MySQLConnection{Host: "db-1", Port: 3306, Replicas: []string{"db-2", "db-3"}}
+	uc.startReplicaMonitor(ctx, run, conn, execution.TaskOptions{})()
+	if run.Replication != nil {
+		t.Errorf("Replication = %+v without MonitorReplicas, want nil", run.Replication)
+	}
+
+	uc.startReplicaMonitor(ctx, run, conn, options)()
+	stored, _ := runRepo.FindByID(ctx, run.ID)
+	if stored.Replication == nil || len(stored.Replication.Replicas) != 2 || stored.Replication.MaxLag() != 2.5 {
+		t.Fatalf("stored Replication = %+v, want 2 replicas with 2.5s lag", stored.Replication)
+	}
+
+	run.Result = &execution.BenchmarkResult{RunID: run.ID}
+	if record := recordFromRun(run); record.Replication != run.Replication {
+		t.Errorf("history record Replication = %+v, want the run's statistics", record.Replication)
+	}
+}
+
+// mockServerLogs returns a fixed position and log, or an error.
+type mockServerLogs struct {
+	content string
+	err     error
+	reads   int
+}
+
+func (m *mockServerLogs) Position(ctx context.Context, conn connection.Connection) (*dbconfig.LogPosition, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &dbconfig.LogPosition{Path: "/var/lib/mysql/db-1.err", Offset: 4096, Via: "SSH", At: time.Now()}, nil
+}
+
+func (m *mockServerLogs) Read(ctx context.Context, conn connection.Connection, pos *dbconfig.LogPosition) (string, error) {
+	m.reads++
+	return m.content, nil
+}
+
+func TestStartServerLogCollection(t *testing.T) {
+	ctx := context.Background()
+	runRepo := NewMemoryRunRepository()
+	uc := NewBenchmarkUseCase(runRepo, adapter.NewAdapterRegistry(), nil, nil)
+	uc.SetArtifactDir(t.TempDir())
+	conn := &connection.MySQLConnection{}
+	options := execution.TaskOptions{CollectServerLog: true}
+
+	run := &execution.Run{ID: "run-1", State: execution.StateRunning}
+	runRepo.Save(ctx, run)
+
+	// A log that cannot be located does not fail the run and is logged
+	uc.SetServerLogCollector(&mockServerLogs{err: errors.New("needs an SSH tunnel")})
+	uc.startServerLogCollection(ctx, run, conn, options)()
+	if entries, _ := uc.GetRunLogs(ctx, run.ID, LogFilter{Search: "needs an SSH tunnel"}); len(entries) != 1 {
+		t.Errorf("got %d log entries about the failed collection, want 1", len(entries))
+	}
+
+	logs := &mockServerLogs{content: "2026-03-01T02:00:05Z [ERROR] Disk is full\n"}
+	uc.SetServerLogCollector(logs)
+	uc.startServerLogCollection(ctx, run, conn, execution.TaskOptions{})()
+	if logs.reads != 0 {
+		t.Errorf("log read %d times without CollectServerLog", logs.reads)
+	}
+
+	collect := uc.startServerLogCollection(ctx, run, conn, options)
+	collect()
+	collect() // Only the first call reads the log
+	if logs.reads != 1 {
+		t.Errorf("log read %d times, want once", logs.reads)
+	}
+	files, err := ListRunArtifacts(uc.artifactDir, run.ID)
+	if err != nil || len(files) != 1 || files[0].Path != ArtifactServerLog {
+		t.Fatalf("artifacts = %+v, %v, want %s", files, err, ArtifactServerLog)
+	}
+	data, _ := os.ReadFile(filepath.Join(uc.artifactDir, run.ID, ArtifactServerLog))
+	if !strings.HasPrefix(string(data), "# /var/lib/mysql/db-1.err via SSH, ") || !strings.HasSuffix(string(data), logs.content) {
+		t.Errorf("%s = %q", ArtifactServerLog, data)
+	}
+}
+
+func TestStartErrorCount(t *testing.T) {
+	ctx := context.Background()
+	runRepo := NewMemoryRunRepository()
+	uc := NewBenchmarkUseCase(runRepo, adapter.NewAdapterRegistry(), nil, nil)
+
+	run := &execution.Run{ID: "run-1", State: execution.StateRunning}
+	runRepo.Save(ctx, run)
+
+	// Nothing is counted outside of the run phase
+	uc.saveRemoteLog(ctx, run.ID, "stderr", "ERROR:  deadlock detected")
+
+	stop := uc.startErrorCount(ctx, run)
+	lines := uc.errorCounter(run.ID).lines()
+	fmt.Fprint(lines, "FATAL: mysql_drv_query() returned error 1213 (Deadlock found when trying to get lock)\n"+
+		"[ 10s ] thds: 4 tps: 342.03 qps: 6846.39 err/s: 0.20\n"+
+		"FATAL: mysql_drv_query() returned error 1205 (Lock wait timeout exceeded)")
+	lines.Flush()
+	uc.saveRemoteLog(ctx, run.ID, "stderr", "[agent-1] ERROR:  deadlock detected")
+	uc.saveRemoteLog(ctx, run.ID, "stdout", "deadlock")
+	stop()
+
+	want := dberror.Counts{dberror.Deadlock: 2, dberror.LockWaitTimeout: 1}
+	stored, _ := runRepo.FindByID(ctx, run.ID)
+	if !maps.Equal(run.ErrorClasses, want) || !maps.Equal(stored.ErrorClasses, want) {
+		t.Errorf("ErrorClasses = %v, stored %v; want %v", run.ErrorClasses, stored.ErrorClasses, want)
+	}
+	if entries, _ := uc.GetRunLogs(ctx, run.ID, LogFilter{Search: "deadlock 2, lock_wait_timeout 1"}); len(entries) != 1 {
+		t.Errorf("got %d log entries with the error counts, want 1", len(entries))
+	}
+	if uc.errorCounter(run.ID) != nil {
+		t.Error("errors are still counted after the run phase")
+	}
+
+	run.Result = &execution.BenchmarkResult{RunID: run.ID}
+	if record := recordFromRun(run); record.ErrorClasses[dberror.Deadlock] != 2 {
+		t.Errorf("history record ErrorClasses = %v, want the run's counts", record.ErrorClasses)
+	}
+}
+
+func TestRunParameters(t *testing.T) {
+	tmpl := &domaintemplate.Template{
+		Parameters: map[string]domaintemplate.Parameter{
+			"tables":     {Type: domaintemplate.ParameterTypeInteger, Default: 10},
+			"table_size": {Type: domaintemplate.ParameterTypeInteger, Default: 100000},
+			"rand_type":  {Type: domaintemplate.ParameterTypeString},
+		},
+	}
+
+	got := runParameters(tmpl, map[string]interface{}{"tables": 32, "threads": 8, "_original_time": 60})
+	want := map[string]string{"tables": "32", "table_size": "100000", "threads": "8"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("runParameters() = %v, want %v", got, want)
+	}
+}
+
+func TestTaskSchemaPhases(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  map[string]interface{}
+		options execution.TaskOptions
+		want    schemaPhases
+	}{
+		{"full run", map[string]interface{}{"time": 60}, execution.TaskOptions{}, schemaPhases{prepares: true, runs: true, cleans: true}},
+		{"run phase", map[string]interface{}{"time": 60}, execution.TaskOptions{SkipPrepare: true, SkipCleanup: true}, schemaPhases{runs: true}},
+		{"prepare only", map[string]interface{}{"time": 0, "_original_time": 60}, execution.TaskOptions{SkipCleanup: true}, schemaPhases{prepares: true}},
+		{"cleanup only", map[string]interface{}{"time": 0}, execution.TaskOptions{SkipPrepare: true}, schemaPhases{cleans: true}},
+	}
+	for _, tt := range tests {
+		if got := taskSchemaPhases(tt.params, tt.options); got != tt.want {
+			t.Errorf("%s: taskSchemaPhases() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
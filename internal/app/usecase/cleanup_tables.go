@@ -0,0 +1,108 @@
+// Package usecase provides the listing of the tables the cleanup phase drops.
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+// cleanupTableQueries list the sysbench tables of the benchmark database;
+// the MySQL query takes the database name as its argument.
+var cleanupTableQueries = map[connection.DatabaseType]string{
+	connection.DatabaseTypeMySQL:      "SELECT table_name FROM information_schema.tables WHERE table_schema = ? AND table_name LIKE 'sbtest%'",
+	connection.DatabaseTypePostgreSQL: "SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_name LIKE 'sbtest%'",
+}
+
+// sysbenchTablePattern matches the tables sysbench creates, sbtest1 to sbtestN.
+var sysbenchTablePattern = regexp.MustCompile(`^sbtest(\d+)$`)
+
+// CleanupTables returns the benchmark database of a task and the existing
+// tables its cleanup phase drops, queried from that database, so they can be
+// confirmed before the cleanup starts. Only sysbench on MySQL and PostgreSQL
+// can be listed.
+func (uc *BenchmarkUseCase) CleanupTables(ctx context.Context, task *execution.BenchmarkTask) (string, []string, error) {
+	conn, err := uc.connUseCase.GetConnectionByID(ctx, task.ConnectionID)
+	if err != nil {
+		return "", nil, fmt.Errorf("get connection: %w", err)
+	}
+	dbName := benchmarkDatabase(conn, task.Parameters)
+
+	tmpl, err := uc.templateUseCase.GetTemplate(ctx, task.TemplateID)
+	if err != nil {
+		return dbName, nil, fmt.Errorf("get template: %w", err)
+	}
+	if tmpl.Tool != "sysbench" {
+		return dbName, nil, fmt.Errorf("listing the tables of %s is not supported", tmpl.Tool)
+	}
+
+	query, ok := cleanupTableQueries[conn.GetType()]
+	if !ok {
+		return dbName, nil, fmt.Errorf("listing tables is not supported for %s", conn.GetType())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, estimateTimeout)
+	defer cancel()
+
+	dsn, err := calibrationDSN(conn, dbName)
+	if err != nil {
+		return dbName, nil, err
+	}
+	db, err := sql.Open(calibrations[conn.GetType()].driver, dsn)
+	if err != nil {
+		return dbName, nil, fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	var args []interface{}
+	if conn.GetType() == connection.DatabaseTypeMySQL {
+		args = append(args, dbName)
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return dbName, nil, fmt.Errorf("list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var existing []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return dbName, nil, fmt.Errorf("list tables: %w", err)
+		}
+		existing = append(existing, name)
+	}
+	if err := rows.Err(); err != nil {
+		return dbName, nil, fmt.Errorf("list tables: %w", err)
+	}
+
+	return dbName, sysbenchCleanupTables(existing, paramInt(task.Parameters, "tables", 1)), nil
+}
+
+// sysbenchCleanupTables returns the tables of existing that sysbench cleanup
+// drops for the given table count, sbtest1 to sbtest<tables>, in order.
+func sysbenchCleanupTables(existing []string, tables int) []string {
+	numbers := make(map[int]string)
+	for _, name := range existing {
+		m := sysbenchTablePattern.FindStringSubmatch(name)
+		if m == nil {
+			continue
+		}
+		if n, err := strconv.Atoi(m[1]); err == nil && n >= 1 && n <= tables {
+			numbers[n] = name
+		}
+	}
+
+	var dropped []string
+	for _, n := range slices.Sorted(maps.Keys(numbers)) {
+		dropped = append(dropped, numbers[n])
+	}
+	return dropped
+}
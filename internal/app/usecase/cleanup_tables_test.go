@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestSysbenchCleanupTables tests that only the sysbench tables of the task's
+// table count are listed, in numeric order.
+func TestSysbenchCleanupTables(t *testing.T) {
+	existing := []string{"sbtest10", "sbtest2", "sbtest1", "sbtest_history", "sbtest11", "sbtest", "sbtest0"}
+
+	got := sysbenchCleanupTables(existing, 10)
+	want := []string{"sbtest1", "sbtest2", "sbtest10"}
+	if !slices.Equal(got, want) {
+		t.Errorf("sysbenchCleanupTables() = %v, want %v", got, want)
+	}
+
+	if got := sysbenchCleanupTables(existing, 0); len(got) != 0 {
+		t.Errorf("sysbenchCleanupTables() with no tables = %v, want none", got)
+	}
+}
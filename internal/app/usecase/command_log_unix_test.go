@@ -0,0 +1,87 @@
+//go:build !windows
+
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+// TestExecuteCommand_MasksSecrets tests that the password of a command shows
+// in neither the logs nor the process record, and that its credential file
+// is readable only by the user.
+func TestExecuteCommand_MasksSecrets(t *testing.T) {
+	var logs bytes.Buffer
+	defer slog.SetDefault(slog.Default())
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+
+	repo := newMemoryProcessRepository()
+	runRepo := NewMemoryRunRepository()
+	uc := NewBenchmarkUseCase(runRepo, nil, nil, nil)
+	uc.SetProcessRepository(repo)
+
+	// Record the process while it runs
+	var recorded []RunProcess
+	saved := make(chan struct{})
+	go func() {
+		defer close(saved)
+		for range 200 {
+			if procs, _ := repo.FindAll(context.Background()); len(procs) > 0 {
+				recorded = procs
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	workDir := t.TempDir()
+	cmd := &adapter.Command{
+		CmdLine: `sh -c "sleep 0.5" -p s3cr3t\ pass -dbap 0ther`,
+		WorkDir: workDir,
+		Env:     []string{"MYSQL_PWD=s3cr3t pass"},
+		Files:   map[string]string{adapter.CredentialFilePrefix + "test.xml": "<Password>s3cr3t pass</Password>"},
+		Secrets: []string{"s3cr3t pass", "0ther"},
+	}
+	if err := uc.executeCommand(context.Background(), &execution.Run{ID: "run-1", WorkDir: workDir}, cmd); err != nil {
+		t.Fatalf("executeCommand() failed: %v", err)
+	}
+	<-saved
+	if len(recorded) != 1 {
+		t.Fatalf("process records = %+v, want the running command", recorded)
+	}
+
+	for _, secret := range []string{"s3cr3t", "0ther"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("logs contain secret %q:\n%s", secret, logs.String())
+		}
+		for _, proc := range recorded {
+			if strings.Contains(proc.Command, secret) {
+				t.Errorf("process record %q contains secret %q", proc.Command, secret)
+			}
+		}
+	}
+	if !strings.Contains(logs.String(), adapter.MaskedSecret) {
+		t.Errorf("logs do not show the masked command:\n%s", logs.String())
+	}
+
+	info, err := os.Stat(filepath.Join(workDir, adapter.CredentialFilePrefix+"test.xml"))
+	if err != nil {
+		t.Fatalf("credential file not written: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("credential file mode = %v, want 0600", perm)
+	}
+	removeCredentialFiles(workDir)
+	if _, err := os.Stat(filepath.Join(workDir, adapter.CredentialFilePrefix+"test.xml")); !os.IsNotExist(err) {
+		t.Errorf("credential file not removed: %v", err)
+	}
+}
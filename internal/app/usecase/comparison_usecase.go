@@ -10,6 +10,7 @@ import (
 
 	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/comparison"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
 )
 
@@ -17,6 +18,10 @@ import (
 type ComparisonUseCase struct {
 	historyRepo repository.HistoryRepository
 	runRepo     RunRepository
+	exportDir   string // Directory for exported reports and imported benchmark outputs
+
+	exportConfig func(ctx context.Context) (*config.ExportConfig, error) // Optional; configured export directory
+	exportUC     *ExportUseCase                                          // Optional; exports the records of report bundles
 }
 
 // NewComparisonUseCase creates a new comparison use case.
@@ -24,30 +29,54 @@ func NewComparisonUseCase(historyRepo repository.HistoryRepository, runRepo RunR
 	return &ComparisonUseCase{
 		historyRepo: historyRepo,
 		runRepo:     runRepo,
+		exportDir:   "./exports",
+	}
+}
+
+// SetExportDir sets the directory for exported reports and imported benchmark outputs.
+func (uc *ComparisonUseCase) SetExportDir(dir string) {
+	if dir != "" {
+		uc.exportDir = dir
 	}
 }
 
+// SetExportConfig sets the source of the configured export directory, which
+// replaces the directory of SetExportDir when set.
+func (uc *ComparisonUseCase) SetExportConfig(exportConfig func(ctx context.Context) (*config.ExportConfig, error)) {
+	uc.exportConfig = exportConfig
+}
+
+// ExportDir returns the directory for exported reports.
+func (uc *ComparisonUseCase) ExportDir() string {
+	dir, _ := exportSettings(context.Background(), uc.exportConfig, uc.exportDir)
+	return dir
+}
+
 // GetAllRecords retrieves all history records for comparison selection.
 func (uc *ComparisonUseCase) GetAllRecords(ctx context.Context) ([]*history.Record, error) {
 	return uc.historyRepo.GetAll(ctx)
 }
 
-// GetRecordRefs returns summary references of all history records.
+// GetRecordRefs returns summary references of the completed history records.
+// Failed and cancelled runs have no results to compare and are left out.
 func (uc *ComparisonUseCase) GetRecordRefs(ctx context.Context) ([]*comparison.RecordRef, error) {
 	records, err := uc.historyRepo.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	refs := make([]*comparison.RecordRef, len(records))
-	for i, record := range records {
+	refs := make([]*comparison.RecordRef, 0, len(records))
+	for _, record := range records {
+		if !record.IsCompleted() {
+			continue
+		}
 		durationSec := record.Duration.Seconds()
 		qps := 0.0
 		if durationSec > 0 && record.TotalQueries > 0 {
 			qps = float64(record.TotalQueries) / durationSec
 		}
 
-		refs[i] = &comparison.RecordRef{
+		ref := &comparison.RecordRef{
 			ID:             record.ID,
 			TemplateName:   record.TemplateName,
 			DatabaseType:   record.DatabaseType,
@@ -68,7 +97,14 @@ func (uc *ComparisonUseCase) GetRecordRefs(ctx context.Context) ([]*comparison.R
 			TotalQueries:   record.TotalQueries,
 			Reconnects:     record.Reconnects,
 			IgnoredErrors:  record.IgnoredErrors,
+			Tags:           record.Tags,
+			Parameters:     record.Parameters,
+			ConfigSnapshot: record.ConfigSnapshot,
+		}
+		if !record.IsValid() {
+			ref.FailedChecks = record.Validity.Failed()
 		}
+		refs = append(refs, ref)
 	}
 
 	return refs, nil
@@ -193,9 +229,17 @@ func (uc *ComparisonUseCase) GenerateComprehensiveReport(
 		return nil, fmt.Errorf("fetch records: %w", err)
 	}
 
-	if len(records) < 2 {
-		return nil, fmt.Errorf("need at least 2 records for comparison, got %d", len(records))
+	// Runs that failed their sanity checks are not eligible for comparison
+	var valid []*history.Record
+	for _, record := range records {
+		if record.IsValid() {
+			valid = append(valid, record)
+		}
 	}
+	if len(valid) < 2 {
+		return nil, fmt.Errorf("need at least 2 valid records for comparison, got %d of %d", len(valid), len(records))
+	}
+	records = valid
 
 	slog.Info("Comparison: Records loaded", "count", len(records))
 
@@ -387,8 +431,14 @@ func (uc *ComparisonUseCase) GenerateSimplifiedReport(
 		}
 	}
 
-	if len(refs) < 2 {
-		return nil, fmt.Errorf("need at least 2 records for comparison, got %d", len(refs))
+	valid := 0
+	for _, ref := range refs {
+		if ref.IsValid() {
+			valid++
+		}
+	}
+	if valid < 2 {
+		return nil, fmt.Errorf("need at least 2 valid records for comparison, got %d of %d", valid, len(refs))
 	}
 
 	slog.Info("Comparison: Record refs loaded", "count", len(refs))
@@ -407,7 +457,8 @@ func (uc *ComparisonUseCase) GenerateSimplifiedReport(
 }
 
 // ExportSimplifiedReport exports a simplified report to file.
-// Supported formats: "markdown", "txt"
+// Supported formats: "markdown", "txt", "html" (with the TPS and p95 latency charts),
+// "xlsx" (a summary sheet with the charts and a sheet per group)
 func (uc *ComparisonUseCase) ExportSimplifiedReport(
 	ctx context.Context,
 	report *comparison.SimplifiedReport,
@@ -418,18 +469,25 @@ func (uc *ComparisonUseCase) ExportSimplifiedReport(
 		return fmt.Errorf("report is nil")
 	}
 
-	var content string
+	var content []byte
 	switch format {
 	case "markdown", "md":
-		content = report.FormatMarkdown()
+		content = []byte(report.FormatMarkdown())
 	case "txt":
-		content = report.FormatTXT()
+		content = []byte(report.FormatTXT())
+	case "html":
+		content = []byte(report.FormatHTML())
+	case "xlsx":
+		var err error
+		if content, err = report.FormatXLSX(); err != nil {
+			return fmt.Errorf("format xlsx: %w", err)
+		}
 	default:
-		return fmt.Errorf("unsupported format: %s (supported: markdown, txt)", format)
+		return fmt.Errorf("unsupported format: %s (supported: markdown, txt, html, xlsx)", format)
 	}
 
 	// Write to file
-	err := os.WriteFile(filepath, []byte(content), 0644)
+	err := os.WriteFile(filepath, content, 0644)
 	if err != nil {
 		return fmt.Errorf("write file: %w", err)
 	}
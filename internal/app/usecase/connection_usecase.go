@@ -4,19 +4,29 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/access"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
 	"github.com/whhaicheng/DB-BenchMind/internal/infra/keyring"
 )
 
+// ErrSnapshotUnavailable is returned when no configuration snapshotter is set.
+var ErrSnapshotUnavailable = errors.New("configuration snapshots are not available")
+
 // ConnectionUseCase provides connection management business operations.
 // Implements: REQ-CONN-001 ~ REQ-CONN-010
 type ConnectionUseCase struct {
-	repo    ConnectionRepository
-	keyring keyring.Provider
+	repo        ConnectionRepository
+	keyring     keyring.Provider
+	snapshotter ConfigSnapshotter // nil disables CaptureConfigSnapshot
+	access      AccessChecker     // nil allows every operation
 }
 
 // NewConnectionUseCase creates a new connection use case.
@@ -27,11 +37,42 @@ func NewConnectionUseCase(repo ConnectionRepository, keyring keyring.Provider) *
 	}
 }
 
+// SetConfigSnapshotter sets the snapshotter with which CaptureConfigSnapshot
+// reads the configuration of a database outside of a run.
+func (uc *ConnectionUseCase) SetConfigSnapshotter(snapshotter ConfigSnapshotter) {
+	uc.snapshotter = snapshotter
+}
+
+// SetAccessControl sets the checker with which creating, changing and
+// deleting connections is restricted to roles that may manage connections.
+func (uc *ConnectionUseCase) SetAccessControl(checker AccessChecker) {
+	uc.access = checker
+}
+
 // GetKeyring returns the keyring provider (used by UI to load SSH passwords).
 func (uc *ConnectionUseCase) GetKeyring() keyring.Provider {
 	return uc.keyring
 }
 
+// KeyringLocked reports whether the keyring needs a master password, and whether
+// that master password has been created before (unlock) or must be chosen (create).
+func (uc *ConnectionUseCase) KeyringLocked() (locked, masterPasswordSet bool) {
+	lockable, ok := uc.keyring.(keyring.Lockable)
+	if !ok {
+		return false, false
+	}
+	return lockable.Locked(), lockable.MasterPasswordSet()
+}
+
+// UnlockKeyring supplies the keyring master password.
+func (uc *ConnectionUseCase) UnlockKeyring(ctx context.Context, masterPassword string) error {
+	lockable, ok := uc.keyring.(keyring.Lockable)
+	if !ok {
+		return nil
+	}
+	return lockable.Unlock(ctx, masterPassword)
+}
+
 // =============================================================================
 // Connection Operations
 // Implements: REQ-CONN-001, REQ-CONN-008
@@ -44,6 +85,10 @@ func (uc *ConnectionUseCase) GetKeyring() keyring.Provider {
 // - Repository save fails
 // - Keyring save fails
 func (uc *ConnectionUseCase) CreateConnection(ctx context.Context, conn connection.Connection) error {
+	if err := requirePermission(ctx, uc.access, access.PermissionManageConnections); err != nil {
+		return err
+	}
+
 	// Validate connection
 	if err := conn.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
@@ -106,12 +151,22 @@ func (uc *ConnectionUseCase) CreateConnection(ctx context.Context, conn connecti
 }
 
 // UpdateConnection updates an existing connection (REQ-CONN-008).
+// The connection keeps its ID and creation time; secrets left empty keep their stored values.
 // Returns an error if:
 // - Connection not found
 // - Validation fails
 // - New name already exists (excluding current connection)
 // - Repository update fails
 func (uc *ConnectionUseCase) UpdateConnection(ctx context.Context, conn connection.Connection) error {
+	if err := requirePermission(ctx, uc.access, access.PermissionManageConnections); err != nil {
+		return err
+	}
+	return uc.updateConnection(ctx, conn)
+}
+
+// updateConnection updates a connection without checking permissions, for
+// recording what a run did to the database, such as the prepared data set.
+func (uc *ConnectionUseCase) updateConnection(ctx context.Context, conn connection.Connection) error {
 	// Validate connection
 	if err := conn.Validate(); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
@@ -134,41 +189,125 @@ func (uc *ConnectionUseCase) UpdateConnection(ctx context.Context, conn connecti
 		}
 	}
 
-	// Update password in keyring if changed
-	if pwd := getPassword(conn); pwd != "" {
-		if err := uc.keyring.Set(ctx, conn.GetID(), pwd); err != nil {
-			return fmt.Errorf("update password in keyring: %w", err)
+	// Update secrets in keyring; empty secrets keep the stored ones.
+	// Previous values are restored if the database update fails.
+	secrets := map[string]string{
+		conn.GetID():            getPassword(conn),
+		conn.GetID() + ":ssh":   getSSHPassword(conn),
+		conn.GetID() + ":winrm": getWinRMPassword(conn),
+	}
+	previous := make(map[string]*string)
+	for key, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		if old, err := uc.keyring.Get(ctx, key); err == nil {
+			previous[key] = &old
+		} else {
+			previous[key] = nil
+		}
+		if err := uc.keyring.Set(ctx, key, secret); err != nil {
+			uc.restoreSecrets(ctx, previous)
+			return fmt.Errorf("update secret %s in keyring: %w", key, err)
 		}
 	}
 
-	// Update SSH password in keyring if changed
-	if sshPwd := getSSHPassword(conn); sshPwd != "" {
-		sshKey := conn.GetID() + ":ssh"
-		if err := uc.keyring.Set(ctx, sshKey, sshPwd); err != nil {
-			return fmt.Errorf("update SSH password in keyring: %w", err)
+	// Update connection in place, preserving ID and creation time
+	if err := uc.repo.Update(ctx, conn); err != nil {
+		uc.restoreSecrets(ctx, previous)
+		return fmt.Errorf("update connection: %w", err)
+	}
+
+	return nil
+}
+
+// restoreSecrets restores keyring entries to their values before a failed update.
+// A nil value means the entry did not exist.
+func (uc *ConnectionUseCase) restoreSecrets(ctx context.Context, previous map[string]*string) {
+	for key, old := range previous {
+		var err error
+		if old == nil {
+			err = uc.keyring.Delete(ctx, key)
+		} else {
+			err = uc.keyring.Set(ctx, key, *old)
+		}
+		if err != nil {
+			slog.Warn("Connection: Failed to restore secret", "key", key, "error", err)
 		}
 	}
+}
 
-	// Update WinRM password in keyring if changed
-	if winrmPwd := getWinRMPassword(conn); winrmPwd != "" {
-		winrmKey := conn.GetID() + ":winrm"
-		if err := uc.keyring.Set(ctx, winrmKey, winrmPwd); err != nil {
-			return fmt.Errorf("update WinRM password in keyring: %w", err)
+// CloneConnection creates a copy of a connection under a new name.
+// All fields and stored secrets are copied; the copy gets a new ID.
+func (uc *ConnectionUseCase) CloneConnection(ctx context.Context, id, newName string) (connection.Connection, error) {
+	original, err := uc.GetConnectionByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("connection not found: %w", err)
+	}
+
+	base := connection.BaseConnection{
+		ID:        uuid.New().String(),
+		Name:      newName,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Protected: original.IsProtected(),
+		Observer:  original.IsObserver(),
+		Group:     original.GetGroup(),
+		Favorite:  original.IsFavorite(),
+	}
+
+	var clone connection.Connection
+	switch c := original.(type) {
+	case *connection.MySQLConnection:
+		cp := *c
+		cp.BaseConnection = base
+		cp.SSH = cloneSSHConfig(c.SSH)
+		clone = &cp
+	case *connection.PostgreSQLConnection:
+		cp := *c
+		cp.BaseConnection = base
+		cp.SSH = cloneSSHConfig(c.SSH)
+		clone = &cp
+	case *connection.OracleConnection:
+		cp := *c
+		cp.BaseConnection = base
+		cp.SSH = cloneSSHConfig(c.SSH)
+		clone = &cp
+	case *connection.SQLServerConnection:
+		cp := *c
+		cp.BaseConnection = base
+		if c.WinRM != nil {
+			winrm := *c.WinRM
+			cp.WinRM = &winrm
 		}
+		clone = &cp
+	default:
+		return nil, fmt.Errorf("unsupported connection type: %T", original)
 	}
 
-	// Save updated connection
-	if err := uc.repo.Save(ctx, conn); err != nil {
-		return fmt.Errorf("update connection: %w", err)
+	if err := uc.CreateConnection(ctx, clone); err != nil {
+		return nil, err
 	}
+	return clone, nil
+}
 
-	return nil
+// cloneSSHConfig returns a copy of an SSH tunnel configuration.
+func cloneSSHConfig(ssh *connection.SSHTunnelConfig) *connection.SSHTunnelConfig {
+	if ssh == nil {
+		return nil
+	}
+	cp := *ssh
+	return &cp
 }
 
 // DeleteConnection deletes a connection (REQ-CONN-009).
 // Returns an error if connection not found.
 // Also removes password from keyring.
 func (uc *ConnectionUseCase) DeleteConnection(ctx context.Context, id string) error {
+	if err := requirePermission(ctx, uc.access, access.PermissionManageConnections); err != nil {
+		return err
+	}
+
 	// Check if connection exists
 	_, err := uc.repo.FindByID(ctx, id)
 	if err != nil {
@@ -265,6 +404,83 @@ func (uc *ConnectionUseCase) TestConnection(ctx context.Context, id string) (*co
 	return result, nil
 }
 
+// CaptureConfigSnapshot reads the current configuration of the database behind
+// a connection without running a benchmark, so observe-only connections can be
+// inspected too.
+func (uc *ConnectionUseCase) CaptureConfigSnapshot(ctx context.Context, id string) (*dbconfig.Snapshot, error) {
+	if uc.snapshotter == nil {
+		return nil, ErrSnapshotUnavailable
+	}
+	conn, err := uc.GetConnectionByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+
+	snapshot, err := uc.snapshotter.Capture(ctx, conn)
+	if err != nil {
+		return nil, fmt.Errorf("capture configuration: %w", err)
+	}
+	return snapshot, nil
+}
+
+// DefaultTestConcurrency is the default number of connections tested at once by TestAllConnections.
+const DefaultTestConcurrency = 4
+
+// ConnectionTestOutcome is the outcome of testing one connection in a bulk test.
+type ConnectionTestOutcome struct {
+	ConnectionID string
+	Name         string
+	Type         connection.DatabaseType
+	Result       *connection.TestResult // nil if the test could not run
+	Err          error
+}
+
+// Succeeded reports whether the connection test succeeded.
+func (o *ConnectionTestOutcome) Succeeded() bool {
+	return o.Err == nil && o.Result != nil && o.Result.Success
+}
+
+// TestAllConnections tests every saved connection concurrently, with at most
+// concurrency tests running at once. Outcomes are returned in ListConnections order.
+func (uc *ConnectionUseCase) TestAllConnections(ctx context.Context, concurrency int) ([]ConnectionTestOutcome, error) {
+	conns, err := uc.ListConnections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list connections: %w", err)
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultTestConcurrency
+	}
+
+	outcomes := make([]ConnectionTestOutcome, len(conns))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < len(conns); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				conn := conns[i]
+				outcome := ConnectionTestOutcome{
+					ConnectionID: conn.GetID(),
+					Name:         conn.GetName(),
+					Type:         conn.GetType(),
+				}
+				outcome.Result, outcome.Err = uc.TestConnection(ctx, conn.GetID())
+				slog.Info("Connection: Tested", "name", outcome.Name, "success", outcome.Succeeded())
+				outcomes[i] = outcome
+			}
+		}()
+	}
+
+	for i := range conns {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return outcomes, nil
+}
+
 // =============================================================================
 // Password Management
 // Implements: REQ-CONN-006
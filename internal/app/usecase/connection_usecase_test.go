@@ -4,9 +4,11 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
 	"github.com/whhaicheng/DB-BenchMind/internal/infra/keyring"
 )
 
@@ -29,6 +31,15 @@ func (m *MockConnectionRepository) Save(ctx context.Context, conn connection.Con
 	return nil
 }
 
+func (m *MockConnectionRepository) Update(ctx context.Context, conn connection.Connection) error {
+	old, ok := m.connections[conn.GetID()]
+	if !ok {
+		return &MockNotFoundError{ID: conn.GetID()}
+	}
+	delete(m.existingNames, old.GetName())
+	return m.Save(ctx, conn)
+}
+
 func (m *MockConnectionRepository) FindByID(ctx context.Context, id string) (connection.Connection, error) {
 	conn, ok := m.connections[id]
 	if !ok {
@@ -314,6 +325,185 @@ func TestConnectionUseCase_GetConnectionByID(t *testing.T) {
 	}
 }
 
+// TestConnectionUseCase_UpdateConnection tests updating a connection in place.
+func TestConnectionUseCase_UpdateConnection(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockConnectionRepository()
+	keyring := NewMockKeyring()
+	uc := NewConnectionUseCase(repo, keyring)
+
+	_ = repo.Save(ctx, &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "edit-me", Name: "Before"},
+		Host:           "localhost",
+		Port:           3306,
+		Username:       "root",
+	})
+	_ = keyring.Set(ctx, "edit-me", "old-secret")
+	_ = keyring.Set(ctx, "edit-me:ssh", "old-ssh")
+
+	// Rename and change the password; SSH password is left empty
+	updated := &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "edit-me", Name: "After"},
+		Host:           "db.example.com",
+		Port:           3306,
+		Username:       "root",
+		Password:       "new-secret",
+	}
+	if err := uc.UpdateConnection(ctx, updated); err != nil {
+		t.Fatalf("UpdateConnection() error = %v", err)
+	}
+
+	if len(repo.connections) != 1 || repo.connections["edit-me"].GetName() != "After" {
+		t.Errorf("connection not updated in place: %v", repo.connections)
+	}
+	if exists, _ := repo.ExistsByName(ctx, "Before", ""); exists {
+		t.Error("old name still registered")
+	}
+	if pw, _ := keyring.Get(ctx, "edit-me"); pw != "new-secret" {
+		t.Errorf("password = %q, want new-secret", pw)
+	}
+	if pw, _ := keyring.Get(ctx, "edit-me:ssh"); pw != "old-ssh" {
+		t.Errorf("SSH password = %q, want old-ssh", pw)
+	}
+}
+
+// TestConnectionUseCase_UpdateConnection_NotFound tests that a failed update leaves secrets unchanged.
+func TestConnectionUseCase_UpdateConnection_NotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockConnectionRepository()
+	keyring := NewMockKeyring()
+	uc := NewConnectionUseCase(repo, keyring)
+
+	err := uc.UpdateConnection(ctx, &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "missing", Name: "Missing"},
+		Host:           "localhost",
+		Port:           3306,
+		Username:       "root",
+		Password:       "secret",
+	})
+	if err == nil {
+		t.Fatal("UpdateConnection() should fail for non-existent connection")
+	}
+	if _, err := keyring.Get(ctx, "missing"); err == nil {
+		t.Error("password should not be stored for a failed update")
+	}
+}
+
+// TestConnectionUseCase_CloneConnection tests cloning a connection with its secrets.
+func TestConnectionUseCase_CloneConnection(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockConnectionRepository()
+	keyring := NewMockKeyring()
+	uc := NewConnectionUseCase(repo, keyring)
+
+	_ = repo.Save(ctx, &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "source", Name: "Source"},
+		Host:           "localhost",
+		Port:           3306,
+		Username:       "root",
+		SSH:            &connection.SSHTunnelConfig{Enabled: true, Host: "bastion", Port: 22, Username: "ops"},
+	})
+	_ = keyring.Set(ctx, "source", "secret")
+	_ = keyring.Set(ctx, "source:ssh", "ssh-secret")
+
+	clone, err := uc.CloneConnection(ctx, "source", "Source Copy")
+	if err != nil {
+		t.Fatalf("CloneConnection() error = %v", err)
+	}
+	if clone.GetID() == "source" || clone.GetName() != "Source Copy" {
+		t.Errorf("clone id=%s name=%s, want new id and name Source Copy", clone.GetID(), clone.GetName())
+	}
+	mysqlClone := clone.(*connection.MySQLConnection)
+	if mysqlClone.Host != "localhost" || mysqlClone.SSH == nil || mysqlClone.SSH.Host != "bastion" {
+		t.Errorf("clone fields not copied: %+v", mysqlClone)
+	}
+	if mysqlClone.SSH == repo.connections["source"].(*connection.MySQLConnection).SSH {
+		t.Error("clone shares SSH config with source")
+	}
+	if pw, _ := keyring.Get(ctx, clone.GetID()); pw != "secret" {
+		t.Errorf("clone password = %q, want secret", pw)
+	}
+	if pw, _ := keyring.Get(ctx, clone.GetID()+":ssh"); pw != "ssh-secret" {
+		t.Errorf("clone SSH password = %q, want ssh-secret", pw)
+	}
+
+	// Cloning under an existing name fails
+	if _, err := uc.CloneConnection(ctx, "source", "Source"); err == nil {
+		t.Error("CloneConnection() should fail for duplicate name")
+	}
+}
+
+// TestConnectionUseCase_CaptureConfigSnapshot tests capturing the configuration
+// of an observer connection outside of a run.
+func TestConnectionUseCase_CaptureConfigSnapshot(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockConnectionRepository()
+	uc := NewConnectionUseCase(repo, NewMockKeyring())
+
+	_ = repo.Save(ctx, &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "prod", Name: "Production", Observer: true},
+		Host:           "localhost",
+		Port:           3306,
+		Username:       "root",
+	})
+
+	if _, err := uc.CaptureConfigSnapshot(ctx, "prod"); !errors.Is(err, ErrSnapshotUnavailable) {
+		t.Errorf("CaptureConfigSnapshot() without snapshotter error = %v, want ErrSnapshotUnavailable", err)
+	}
+
+	want := &dbconfig.Snapshot{DatabaseType: "mysql", Settings: map[string]string{"max_connections": "500"}}
+	uc.SetConfigSnapshotter(&mockSnapshotter{snapshot: want})
+	got, err := uc.CaptureConfigSnapshot(ctx, "prod")
+	if err != nil {
+		t.Fatalf("CaptureConfigSnapshot() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("CaptureConfigSnapshot() = %+v, want %+v", got, want)
+	}
+
+	if _, err := uc.CaptureConfigSnapshot(ctx, "missing"); err == nil {
+		t.Error("CaptureConfigSnapshot() should fail for an unknown connection")
+	}
+}
+
+// TestConnectionUseCase_TestAllConnections tests that every connection is tested once.
+func TestConnectionUseCase_TestAllConnections(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockConnectionRepository()
+	uc := NewConnectionUseCase(repo, NewMockKeyring())
+
+	// Port 1 on localhost refuses connections, so every test fails fast
+	for _, id := range []string{"a", "b", "c"} {
+		_ = repo.Save(ctx, &connection.MySQLConnection{
+			BaseConnection: connection.BaseConnection{ID: id, Name: "conn-" + id},
+			Host:           "127.0.0.1",
+			Port:           1,
+			Username:       "root",
+		})
+	}
+
+	outcomes, err := uc.TestAllConnections(ctx, 2)
+	if err != nil {
+		t.Fatalf("TestAllConnections() error = %v", err)
+	}
+	if len(outcomes) != 3 {
+		t.Fatalf("TestAllConnections() returned %d outcomes, want 3", len(outcomes))
+	}
+	seen := make(map[string]bool)
+	for i, outcome := range outcomes {
+		seen[outcome.ConnectionID] = true
+		if outcome.Name != "conn-"+outcome.ConnectionID {
+			t.Errorf("outcome[%d] name = %s, want conn-%s", i, outcome.Name, outcome.ConnectionID)
+		}
+		if outcome.Succeeded() {
+			t.Errorf("outcome[%d] succeeded, want failure", i)
+		}
+	}
+	if len(seen) != 3 {
+		t.Errorf("tested %d distinct connections, want 3", len(seen))
+	}
+}
+
 // TestConnectionUseCase_DeleteConnection tests deleting a connection.
 func TestConnectionUseCase_DeleteConnection(t *testing.T) {
 	ctx := context.Background()
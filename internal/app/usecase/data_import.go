@@ -19,7 +19,7 @@ import (
 // It reads all benchmark_*.txt files, parses them, and stores in database.
 func (uc *ComparisonUseCase) ImportSysbenchOutputs(ctx context.Context) (*ImportResult, error) {
 	// Find all benchmark output files
-	exportsDir := "./exports"
+	exportsDir := uc.ExportDir()
 	files, err := filepath.Glob(filepath.Join(exportsDir, "benchmark_*.txt"))
 	if err != nil {
 		return nil, fmt.Errorf("find benchmark files: %w", err)
@@ -0,0 +1,136 @@
+// Package usecase provides the reuse detection of prepared benchmark data sets.
+// Implements: REQ-EXEC-001
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+// datasetFingerprint returns the fingerprint of the data set a task's prepare
+// phase loads, or nil if the tool's data sets are not fingerprinted.
+func datasetFingerprint(adapt adapter.BenchmarkAdapter, config *adapter.Config) *connection.DatasetFingerprint {
+	sysbench, ok := adapt.(*adapter.SysbenchAdapter)
+	if !ok {
+		return nil
+	}
+	return &connection.DatasetFingerprint{
+		Tool:      string(adapter.AdapterTypeSysbench),
+		Script:    filepath.Base(sysbench.ScriptName(config.Template)),
+		Database:  benchmarkDatabase(config.Connection, config.Parameters),
+		Tables:    paramInt(config.Parameters, "tables", 1),
+		TableSize: paramInt(config.Parameters, "table_size", 10000),
+	}
+}
+
+// datasetMismatch describes how the data set prepared on the connection differs
+// from the one the task expects. It is empty if they match, or if no data set
+// is recorded so there is nothing to compare against.
+func datasetMismatch(adapt adapter.BenchmarkAdapter, config *adapter.Config) string {
+	requested := datasetFingerprint(adapt, config)
+	prepared := config.Connection.GetDataset()
+	if requested == nil || prepared == nil {
+		return ""
+	}
+	return strings.Join(prepared.Diff(requested), "; ")
+}
+
+// DatasetMismatch checks a task that skips the prepare phase against the data
+// set prepared on its connection. It returns a description of the differences,
+// or an empty string if the data set matches or none is recorded. Results of a
+// run on a mismatching data set may be invalid unless it is re-prepared.
+func (uc *BenchmarkUseCase) DatasetMismatch(ctx context.Context, task *execution.BenchmarkTask) (string, error) {
+	conn, err := uc.connUseCase.GetConnectionByID(ctx, task.ConnectionID)
+	if err != nil {
+		return "", fmt.Errorf("get connection: %w", err)
+	}
+
+	tmpl, err := uc.templateUseCase.GetTemplate(ctx, task.TemplateID)
+	if err != nil {
+		return "", fmt.Errorf("get template: %w", err)
+	}
+
+	adapt := uc.adapterReg.GetByTool(tmpl.Tool)
+	if adapt == nil {
+		return "", fmt.Errorf("adapter not found for tool: %s", tmpl.Tool)
+	}
+
+	return datasetMismatch(adapt, &adapter.Config{
+		Connection: conn,
+		Template:   tmpl,
+		Parameters: task.Parameters,
+		Options:    task.Options,
+	}), nil
+}
+
+// reuseDataset decides whether a full run skips its prepare phase. Besides an
+// explicit SkipPrepare, the prepare is skipped when the recorded data set
+// matches the task and its tables still exist. A run that skips the prepare on
+// a mismatching data set gets a warning in its log.
+func (uc *BenchmarkUseCase) reuseDataset(ctx context.Context, run *execution.Run, adapt adapter.BenchmarkAdapter, config *adapter.Config) bool {
+	if config.Options.SkipPrepare {
+		if mismatch := datasetMismatch(adapt, config); mismatch != "" {
+			slog.Warn("Benchmark: Prepared data set does not match the task", "run_id", run.ID, "mismatch", mismatch)
+			uc.saveLogEntry(ctx, run.ID, LogEntry{
+				Timestamp: time.Now().Format(time.RFC3339),
+				Stream:    "stderr",
+				Content:   fmt.Sprintf("Warning: the prepared data set does not match this run (%s); results may be invalid unless it is re-prepared", mismatch),
+			})
+		}
+		return true
+	}
+
+	requested := datasetFingerprint(adapt, config)
+	prepared := config.Connection.GetDataset()
+	if requested == nil || prepared == nil || !prepared.Matches(requested) {
+		return false
+	}
+	if !uc.checkTablesExist(ctx, config.Connection, config.Parameters) {
+		slog.Info("Benchmark: Recorded data set has no tables, preparing again", "run_id", run.ID)
+		return false
+	}
+
+	slog.Info("Benchmark: Reusing prepared data set", "run_id", run.ID, "prepared_at", prepared.PreparedAt)
+	uc.saveLogEntry(ctx, run.ID, LogEntry{
+		Timestamp: time.Now().Format(time.RFC3339),
+		Stream:    "info",
+		Content: fmt.Sprintf("Info: %d tables of %d rows prepared in %s at %s match this run, skipping prepare",
+			prepared.Tables, prepared.TableSize, prepared.Database, prepared.PreparedAt.Format(time.DateTime)),
+	})
+	return true
+}
+
+// recordDataset records the data set on the connection after a prepare loaded
+// it, or removes it after a cleanup dropped its database's tables.
+// A failed update is logged and does not fail the run.
+func (uc *BenchmarkUseCase) recordDataset(ctx context.Context, adapt adapter.BenchmarkAdapter, config *adapter.Config, prepared bool) {
+	dataset := datasetFingerprint(adapt, config)
+	if dataset == nil {
+		return
+	}
+
+	conn := config.Connection
+	if prepared {
+		dataset.PreparedAt = time.Now()
+		conn.SetDataset(dataset)
+	} else {
+		if current := conn.GetDataset(); current == nil || current.Database != dataset.Database {
+			return
+		}
+		conn.SetDataset(nil)
+	}
+
+	if err := uc.connUseCase.updateConnection(ctx, conn); err != nil {
+		slog.Warn("Benchmark: Failed to record data set", "conn_id", conn.GetID(), "prepared", prepared, "error", err)
+		return
+	}
+	slog.Info("Benchmark: Data set recorded", "conn_id", conn.GetID(), "prepared", prepared)
+}
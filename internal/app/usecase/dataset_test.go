@@ -0,0 +1,110 @@
+// Package usecase provides unit tests for the reuse detection of prepared data sets.
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	domaintemplate "github.com/whhaicheng/DB-BenchMind/internal/domain/template"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+func newDatasetTestUseCase(t *testing.T) (*BenchmarkUseCase, *mockConnectionRepository) {
+	t.Helper()
+	ctx := context.Background()
+
+	connRepo := newMockConnectionRepository()
+	connRepo.Save(ctx, &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "mysql-1", Name: "MySQL"},
+		Host:           "localhost",
+		Port:           3306,
+		Username:       "root",
+	})
+
+	templateRepo := newMockTemplateRepositoryForBenchmark()
+	templateRepo.Save(ctx, &domaintemplate.Template{ID: "sysbench-oltp-read-write", Name: "Sysbench OLTP", Tool: "sysbench"})
+
+	adapterReg := adapter.NewAdapterRegistry()
+	adapterReg.Register(adapter.NewSysbenchAdapter())
+
+	uc := NewBenchmarkUseCase(newMockRunRepository(), adapterReg,
+		NewConnectionUseCase(connRepo, nil), NewTemplateUseCase(templateRepo, ""))
+	return uc, connRepo
+}
+
+func TestBenchmarkUseCase_RecordDataset(t *testing.T) {
+	uc, connRepo := newDatasetTestUseCase(t)
+	ctx := context.Background()
+	sysbench := adapter.NewSysbenchAdapter()
+
+	conn, _ := uc.connUseCase.GetConnectionByID(ctx, "mysql-1")
+	tmpl, _ := uc.templateUseCase.GetTemplate(ctx, "sysbench-oltp-read-write")
+	config := &adapter.Config{
+		Connection: conn,
+		Template:   tmpl,
+		Parameters: map[string]interface{}{"db_name": "sbtest", "tables": 16, "table_size": 100000},
+	}
+
+	uc.recordDataset(ctx, sysbench, config, true)
+	dataset := connRepo.connections["mysql-1"].GetDataset()
+	if dataset == nil {
+		t.Fatal("Dataset = nil after prepare")
+	}
+	want := connection.DatasetFingerprint{Tool: "sysbench", Script: "oltp_read_write.lua", Database: "sbtest", Tables: 16, TableSize: 100000}
+	if !dataset.Matches(&want) || dataset.PreparedAt.IsZero() {
+		t.Errorf("Dataset = %+v, want %+v", dataset, want)
+	}
+
+	// Other tools leave the data set alone
+	uc.recordDataset(ctx, adapter.NewSwingbenchAdapter(), config, false)
+	if connRepo.connections["mysql-1"].GetDataset() == nil {
+		t.Error("Dataset removed by a swingbench cleanup")
+	}
+
+	// A cleanup of another database leaves the data set alone
+	other := &adapter.Config{Connection: conn, Template: tmpl, Parameters: map[string]interface{}{"db_name": "other"}}
+	uc.recordDataset(ctx, sysbench, other, false)
+	if connRepo.connections["mysql-1"].GetDataset() == nil {
+		t.Error("Dataset removed by a cleanup of another database")
+	}
+
+	uc.recordDataset(ctx, sysbench, config, false)
+	if connRepo.connections["mysql-1"].GetDataset() != nil {
+		t.Error("Dataset kept after a cleanup")
+	}
+}
+
+func TestBenchmarkUseCase_DatasetMismatch(t *testing.T) {
+	uc, connRepo := newDatasetTestUseCase(t)
+	ctx := context.Background()
+
+	task := &execution.BenchmarkTask{
+		ConnectionID: "mysql-1",
+		TemplateID:   "sysbench-oltp-read-write",
+		Parameters:   map[string]interface{}{"tables": 16, "table_size": 100000},
+		Options:      execution.TaskOptions{SkipPrepare: true},
+	}
+
+	// Nothing recorded, nothing to compare against
+	if mismatch, err := uc.DatasetMismatch(ctx, task); err != nil || mismatch != "" {
+		t.Errorf("DatasetMismatch() = %q, %v before any prepare, want no mismatch", mismatch, err)
+	}
+
+	connRepo.connections["mysql-1"].SetDataset(&connection.DatasetFingerprint{
+		Tool: "sysbench", Script: "oltp_read_write.lua", Database: "sbtest", Tables: 16, TableSize: 100000,
+	})
+	if mismatch, err := uc.DatasetMismatch(ctx, task); err != nil || mismatch != "" {
+		t.Errorf("DatasetMismatch() = %q, %v for the prepared data set, want no mismatch", mismatch, err)
+	}
+
+	task.Parameters["tables"] = 32
+	mismatch, err := uc.DatasetMismatch(ctx, task)
+	if err != nil {
+		t.Fatalf("DatasetMismatch() error = %v", err)
+	}
+	if mismatch != "tables: 16 prepared, 32 requested" {
+		t.Errorf("DatasetMismatch() = %q, want the table count difference", mismatch)
+	}
+}
@@ -0,0 +1,351 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+// DemoTag marks the history records of demo runs.
+const DemoTag = "demo"
+
+// demoParameter is the run parameter that is "true" for demo runs.
+const demoParameter = "demo"
+
+// Defaults of demo runs.
+const (
+	DemoConnectionName = "demo-mysql"
+	DemoTemplateName   = "Sysbench OLTP Read Write"
+)
+
+// Model of demo runs: throughput over threads follows the Universal
+// Scalability Law, the queries of a transaction those of oltp_read_write.
+const (
+	demoTPSPerThread = 420.0  // TPS of one thread
+	demoContention   = 0.03   // Serialized share of the work
+	demoCoherency    = 0.0004 // Cost of keeping threads coherent
+	demoNoise        = 0.04   // Relative standard deviation of the samples
+	demoStallChance  = 0.03   // Share of samples during a checkpoint stall
+	demoStallFactor  = 0.45   // Throughput during a stall
+	demoErrorRate    = 0.02   // Error rate (%) at 64 threads, growing with threads
+
+	demoReadQueries  = 14 // Queries per transaction
+	demoWriteQueries = 4
+	demoOtherQueries = 2
+)
+
+// demoSeedThreads are the thread counts of the runs SeedHistory creates.
+var demoSeedThreads = []int{1, 4, 8, 16, 32, 64}
+
+// DemoOptions configure a demo run. Zero values select the defaults.
+type DemoOptions struct {
+	ConnectionName string
+	TemplateName   string
+	DatabaseType   string
+	Threads        int
+	Duration       time.Duration // Measured window
+	Warmup         time.Duration // Before the measured window, not in the results
+	Interval       time.Duration // Between samples; default 1s
+	Metadata       execution.RunMetadata
+
+	speedup float64 // Throughput factor of a tuned database; 0 = 1
+}
+
+// withDefaults returns the options with defaults for the zero values.
+func (o DemoOptions) withDefaults() DemoOptions {
+	if o.ConnectionName == "" {
+		o.ConnectionName = DemoConnectionName
+	}
+	if o.TemplateName == "" {
+		o.TemplateName = DemoTemplateName
+	}
+	if o.DatabaseType == "" {
+		o.DatabaseType = string(connection.DatabaseTypeMySQL)
+	}
+	if o.Interval <= 0 {
+		o.Interval = time.Second
+	}
+	if o.speedup == 0 {
+		o.speedup = 1
+	}
+	return o
+}
+
+// DemoUseCase simulates benchmark runs with realistic synthetic samples and
+// results, without a database or benchmark tools, so that the monitor,
+// history, exports and comparisons can be tried offline.
+type DemoUseCase struct {
+	settingsUC *SettingsUseCase
+	historyUC  *HistoryUseCase
+}
+
+// NewDemoUseCase creates a new demo use case that saves runs to historyUC.
+// Demo mode is enabled by the demo_mode setting of settingsUC.
+func NewDemoUseCase(settingsUC *SettingsUseCase, historyUC *HistoryUseCase) *DemoUseCase {
+	return &DemoUseCase{
+		settingsUC: settingsUC,
+		historyUC:  historyUC,
+	}
+}
+
+// Enabled reports whether demo mode is enabled.
+func (uc *DemoUseCase) Enabled(ctx context.Context) bool {
+	advCfg, err := uc.settingsUC.GetAdvancedConfig(ctx)
+	if err != nil {
+		slog.Warn("Demo: Failed to read settings", "error", err)
+		return false
+	}
+	return advCfg.DemoMode
+}
+
+// Run simulates a run in real time, passing each sample to onSample, and
+// returns the completed run. Stopping ctx abandons the run.
+func (uc *DemoUseCase) Run(ctx context.Context, opts DemoOptions, onSample func(execution.MetricSample)) (*execution.Run, error) {
+	opts = opts.withDefaults()
+	if opts.Threads < 1 || opts.Duration <= 0 || opts.Warmup < 0 {
+		return nil, fmt.Errorf("invalid demo run: %d threads, %s, %s warmup", opts.Threads, opts.Duration, opts.Warmup)
+	}
+	slog.Info("Demo: Run started", "connection", opts.ConnectionName, "threads", opts.Threads, "duration", opts.Duration, "warmup", opts.Warmup)
+
+	model := newDemoModel(opts, rand.Uint64())
+	start := time.Now()
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	var samples []execution.MetricSample
+	for elapsed := opts.Interval; elapsed <= opts.Warmup+opts.Duration; elapsed += opts.Interval {
+		var at time.Time
+		select {
+		case <-ctx.Done():
+			slog.Info("Demo: Run stopped", "connection", opts.ConnectionName)
+			return nil, ctx.Err()
+		case at = <-ticker.C:
+		}
+		sample := model.sample(at, elapsed)
+		if sample.Phase == "run" {
+			samples = append(samples, sample)
+		}
+		if onSample != nil {
+			onSample(sample)
+		}
+	}
+	return demoRun(opts, start, time.Now(), samples), nil
+}
+
+// IsDemoRun reports whether run was simulated by a DemoUseCase.
+func IsDemoRun(run *execution.Run) bool {
+	return run.Parameters[demoParameter] == "true"
+}
+
+// SaveRun saves a demo run to history, tagged DemoTag.
+func (uc *DemoUseCase) SaveRun(ctx context.Context, run *execution.Run) error {
+	if err := uc.historyUC.SaveRunToHistory(ctx, run); err != nil {
+		return err
+	}
+	return uc.historyUC.UpdateAnnotations(ctx, run.ID, []string{DemoTag}, "")
+}
+
+// SeedHistory saves two series of completed demo runs over demoSeedThreads
+// to history, a baseline and one after tuning, tagged DemoTag and
+// "demo:baseline" or "demo:tuned", for trying the history and comparisons.
+// Returns the number of records saved.
+func (uc *DemoUseCase) SeedHistory(ctx context.Context) (int, error) {
+	series := []struct {
+		tag     string
+		speedup float64
+		ago     time.Duration
+	}{
+		{"demo:baseline", 1, 48 * time.Hour},
+		{"demo:tuned", 1.18, 24 * time.Hour},
+	}
+
+	saved := 0
+	for _, s := range series {
+		start := time.Now().Add(-s.ago)
+		for _, threads := range demoSeedThreads {
+			opts := DemoOptions{
+				Threads:  threads,
+				Duration: 60 * time.Second,
+				Warmup:   10 * time.Second,
+				Metadata: execution.RunMetadata{Purpose: "Demo thread scaling", Environment: DemoTag},
+				speedup:  s.speedup,
+			}.withDefaults()
+			run := simulateDemoRun(opts, start)
+			if err := uc.historyUC.SaveRunToHistory(ctx, run); err != nil {
+				return saved, fmt.Errorf("save demo run: %w", err)
+			}
+			if err := uc.historyUC.UpdateAnnotations(ctx, run.ID, []string{DemoTag, s.tag}, ""); err != nil {
+				return saved, fmt.Errorf("tag demo run: %w", err)
+			}
+			saved++
+			start = start.Add(opts.Warmup + opts.Duration + time.Minute)
+		}
+	}
+	slog.Info("Demo: History seeded", "records", saved)
+	return saved, nil
+}
+
+// RemoveHistory deletes the history records tagged DemoTag and returns how
+// many were deleted.
+func (uc *DemoUseCase) RemoveHistory(ctx context.Context) (int, error) {
+	records, err := uc.historyUC.ListRecords(ctx, &repository.ListOptions{Tags: []string{DemoTag}})
+	if err != nil {
+		return 0, fmt.Errorf("list demo records: %w", err)
+	}
+	var errs []error
+	removed := 0
+	for _, record := range records {
+		if err := uc.historyUC.DeleteRecord(ctx, record.ID); err != nil {
+			errs = append(errs, fmt.Errorf("delete %s: %w", record.ID, err))
+			continue
+		}
+		removed++
+	}
+	slog.Info("Demo: History removed", "records", removed)
+	return removed, errors.Join(errs...)
+}
+
+// simulateDemoRun returns a demo run that started at start, without waiting.
+func simulateDemoRun(opts DemoOptions, start time.Time) *execution.Run {
+	model := newDemoModel(opts, uint64(start.UnixNano())+uint64(opts.Threads))
+	var samples []execution.MetricSample
+	for elapsed := opts.Interval; elapsed <= opts.Warmup+opts.Duration; elapsed += opts.Interval {
+		if sample := model.sample(start.Add(elapsed), elapsed); sample.Phase == "run" {
+			samples = append(samples, sample)
+		}
+	}
+	return demoRun(opts, start, start.Add(opts.Warmup+opts.Duration), samples)
+}
+
+// demoModel generates the samples of a demo run.
+type demoModel struct {
+	rng     *rand.Rand
+	opts    DemoOptions
+	tps     float64 // Steady-state TPS
+	errRate float64 // Error rate (%)
+}
+
+// newDemoModel creates the model of a run with opts, seeded with seed.
+func newDemoModel(opts DemoOptions, seed uint64) *demoModel {
+	n := float64(opts.Threads)
+	return &demoModel{
+		rng:     rand.New(rand.NewPCG(seed, 0)),
+		opts:    opts,
+		tps:     opts.speedup * demoTPSPerThread * n / (1 + demoContention*(n-1) + demoCoherency*n*(n-1)),
+		errRate: demoErrorRate * n / 64,
+	}
+}
+
+// sample returns the sample at elapsed time since the start of the run:
+// throughput ramps up while the cache warms up, varies by demoNoise and
+// drops during occasional stalls; latency follows by Little's law.
+func (m *demoModel) sample(at time.Time, elapsed time.Duration) execution.MetricSample {
+	phase := "run"
+	factor := 1 + demoNoise*m.rng.NormFloat64()
+	if elapsed <= m.opts.Warmup {
+		phase = "warmup"
+		factor *= 0.4 + 0.6*elapsed.Seconds()/m.opts.Warmup.Seconds()
+	}
+	if m.rng.Float64() < demoStallChance {
+		factor *= demoStallFactor
+	}
+	tps := m.tps * max(factor, 0.05)
+	latency := float64(m.opts.Threads) / tps * 1000
+	p95 := latency * (1.6 + 0.3*m.rng.Float64())
+	p99 := p95 * (1.3 + 0.3*m.rng.Float64())
+	errRate := m.errRate * (0.5 + m.rng.Float64())
+	qps := tps * (demoReadQueries + demoWriteQueries + demoOtherQueries)
+
+	return execution.MetricSample{
+		Timestamp:  at,
+		Phase:      phase,
+		TPS:        tps,
+		QPS:        qps,
+		LatencyAvg: latency,
+		LatencyP95: p95,
+		LatencyP99: p99,
+		ErrorRate:  errRate,
+		RawLine: fmt.Sprintf("[ %ds ] thds: %d tps: %.2f qps: %.2f (r/w/o: %.2f/%.2f/%.2f) lat (ms,95%%): %.2f err/s: %.2f reconn/s: 0.00",
+			int(elapsed.Seconds()), m.opts.Threads, tps, qps, tps*demoReadQueries, tps*demoWriteQueries, tps*demoOtherQueries, p95, tps*errRate/100),
+	}
+}
+
+// demoRun returns the completed run with the results of the measured samples.
+func demoRun(opts DemoOptions, start, end time.Time, samples []execution.MetricSample) *execution.Run {
+	measured := start.Add(opts.Warmup)
+	duration := end.Sub(measured)
+	run := &execution.Run{
+		ID:             uuid.New().String(),
+		State:          execution.StateCompleted,
+		CreatedAt:      start,
+		StartedAt:      &measured,
+		CompletedAt:    &end,
+		Duration:       &duration,
+		SampleInterval: opts.Interval,
+		Parameters: map[string]string{
+			"threads":     strconv.Itoa(opts.Threads),
+			"time":        strconv.Itoa(int(opts.Duration.Seconds())),
+			"warmup":      strconv.Itoa(int(opts.Warmup.Seconds())),
+			demoParameter: "true",
+		},
+		Metadata: opts.Metadata,
+	}
+
+	result := &execution.BenchmarkResult{
+		RunID:          run.ID,
+		Duration:       duration,
+		ConnectionName: opts.ConnectionName,
+		TemplateName:   opts.TemplateName,
+		DatabaseType:   opts.DatabaseType,
+		Threads:        opts.Threads,
+		StartTime:      measured,
+		SampleInterval: opts.Interval,
+		TimeSeries:     samples,
+		LatencyMin:     math.Inf(1),
+	}
+	for _, s := range samples {
+		result.TPSCalculated += s.TPS
+		result.LatencyAvg += s.LatencyAvg
+		result.LatencyP95 += s.LatencyP95
+		result.LatencyP99 += s.LatencyP99
+		result.ErrorRate += s.ErrorRate
+		result.LatencyMin = min(result.LatencyMin, s.LatencyAvg*0.25)
+		result.LatencyMax = max(result.LatencyMax, s.LatencyP99*1.8)
+	}
+	if n := float64(len(samples)); n > 0 {
+		result.TPSCalculated /= n
+		result.LatencyAvg /= n
+		result.LatencyP95 /= n
+		result.LatencyP99 /= n
+		result.ErrorRate /= n
+	} else {
+		result.LatencyMin = 0
+	}
+
+	tx := int64(result.TPSCalculated * duration.Seconds())
+	result.TotalTransactions = tx
+	result.TotalEvents = tx
+	result.ReadQueries = tx * demoReadQueries
+	result.WriteQueries = tx * demoWriteQueries
+	result.OtherQueries = tx * demoOtherQueries
+	result.TotalQueries = result.ReadQueries + result.WriteQueries + result.OtherQueries
+	result.ErrorCount = int64(float64(tx) * result.ErrorRate / 100)
+	result.IgnoredErrors = result.ErrorCount
+	result.TotalTime = duration.Seconds()
+	result.LatencySum = float64(tx) * result.LatencyAvg
+	result.EventsAvg = float64(tx) / float64(opts.Threads)
+	result.EventsStddev = result.EventsAvg * 0.01
+	result.ExecTimeAvg = result.LatencySum / 1000 / float64(opts.Threads)
+	result.ExecTimeStddev = result.ExecTimeAvg * 0.01
+	run.Result = result
+	return run
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+func TestDemoUseCase_Run(t *testing.T) {
+	ctx := context.Background()
+	historyRepo := newMockHistoryRepository()
+	uc := NewDemoUseCase(setupSettingsTest(t), NewHistoryUseCase(historyRepo))
+
+	var phases []string
+	run, err := uc.Run(ctx, DemoOptions{Threads: 8, Duration: 20 * time.Millisecond, Warmup: 10 * time.Millisecond, Interval: time.Millisecond}, func(s execution.MetricSample) {
+		phases = append(phases, s.Phase)
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(phases) != 30 || phases[0] != "warmup" || phases[29] != "run" {
+		t.Fatalf("phases = %v, want 10 warmup and 20 run samples", phases)
+	}
+	if run.State != execution.StateCompleted || len(run.Result.TimeSeries) != 20 {
+		t.Fatalf("run = %s with %d samples, want completed with 20", run.State, len(run.Result.TimeSeries))
+	}
+	result := run.Result
+	if result.ConnectionName != DemoConnectionName || result.TPSCalculated <= 0 || result.LatencyP95 <= result.LatencyAvg {
+		t.Errorf("result = %+v, want the demo connection, TPS and p95 above average latency", result)
+	}
+
+	if err := uc.SaveRun(ctx, run); err != nil {
+		t.Fatalf("SaveRun() error = %v", err)
+	}
+	if record := historyRepo.records[run.ID]; record == nil || !record.HasTag(DemoTag) {
+		t.Errorf("saved record = %+v, want it tagged %q", record, DemoTag)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := uc.Run(cancelled, DemoOptions{Threads: 1, Duration: time.Hour}, nil); err == nil {
+		t.Error("Run() with a stopped context error = nil, want an error")
+	}
+}
+
+func TestDemoUseCase_SeedAndRemoveHistory(t *testing.T) {
+	ctx := context.Background()
+	historyRepo := newMockHistoryRepository()
+	historyUC := NewHistoryUseCase(historyRepo)
+	uc := NewDemoUseCase(setupSettingsTest(t), historyUC)
+
+	n, err := uc.SeedHistory(ctx)
+	if err != nil {
+		t.Fatalf("SeedHistory() error = %v", err)
+	}
+	if n != 2*len(demoSeedThreads) || len(historyRepo.records) != n {
+		t.Fatalf("SeedHistory() = %d with %d records, want %d", n, len(historyRepo.records), 2*len(demoSeedThreads))
+	}
+
+	// Throughput scales sublinearly and tuning helps
+	tps := make(map[string]map[int]float64)
+	for _, record := range historyRepo.records {
+		for _, tag := range []string{"demo:baseline", "demo:tuned"} {
+			if record.HasTag(tag) {
+				if tps[tag] == nil {
+					tps[tag] = make(map[int]float64)
+				}
+				tps[tag][record.Threads] = record.TPSCalculated
+			}
+		}
+	}
+	baseline := tps["demo:baseline"]
+	if !(baseline[1] < baseline[16] && baseline[16] < 16*baseline[1]) {
+		t.Errorf("baseline TPS = %v, want sublinear scaling", baseline)
+	}
+	if tps["demo:tuned"][32] <= baseline[32] {
+		t.Errorf("tuned TPS at 32 threads = %.0f, want above baseline %.0f", tps["demo:tuned"][32], baseline[32])
+	}
+
+	if err := historyRepo.Save(ctx, simulateDemoRunRecord(t)); err != nil {
+		t.Fatal(err)
+	}
+	removed, err := uc.RemoveHistory(ctx)
+	if err != nil {
+		t.Fatalf("RemoveHistory() error = %v", err)
+	}
+	if removed != n {
+		t.Errorf("RemoveHistory() = %d, want %d", removed, n)
+	}
+	if left, _ := historyUC.CountRecords(ctx, &repository.ListOptions{}); left != 1 {
+		t.Errorf("%d records left, want the untagged one", left)
+	}
+}
+
+// simulateDemoRunRecord returns the history record of a demo run that was
+// not tagged, like one saved from a real run.
+func simulateDemoRunRecord(t *testing.T) *history.Record {
+	t.Helper()
+	run := simulateDemoRun(DemoOptions{Threads: 2, Duration: 5 * time.Second}.withDefaults(), time.Now())
+	return recordFromRun(run)
+}
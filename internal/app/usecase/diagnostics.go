@@ -0,0 +1,356 @@
+// Package usecase provides diagnostics bundles: the recent logs and crash
+// reports, the settings, the recent runs and a description of the system in
+// one ZIP file to attach to bug reports.
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/tool"
+)
+
+// Limits of diagnostics bundles.
+const (
+	diagnosticsLogAge     = 7 * 24 * time.Hour // Age of the newest log files included
+	diagnosticsLogTail    = 1 << 20            // Bytes at the end of each log file included
+	diagnosticsRecentRuns = 20                 // Newest history records included
+)
+
+// Entries of diagnostics bundles.
+const (
+	diagnosticsSystemEntry = "system.json"
+	diagnosticsConfigEntry = "config.json" // Settings without password hashes and webhook URL paths
+	diagnosticsRunsEntry   = "runs.json"   // Recent runs without secrets
+	diagnosticsLogsDir     = "logs"
+)
+
+// crashReportPrefix starts the names of crash reports in the log directory.
+const crashReportPrefix = "crash-"
+
+// DiagnosticsSystem describes the system in a diagnostics bundle.
+type DiagnosticsSystem struct {
+	CreatedAt     time.Time                          `json:"created_at"`
+	AppVersion    string                             `json:"app_version,omitempty"`
+	OS            string                             `json:"os"`
+	Arch          string                             `json:"arch"`
+	GoVersion     string                             `json:"go_version"`
+	CPUs          int                                `json:"cpus"`
+	DataDir       string                             `json:"data_dir"`
+	SchemaVersion int                                `json:"schema_version,omitempty"`
+	Tools         map[config.ToolType]*tool.ToolInfo `json:"tools"`
+	LogFiles      []string                           `json:"log_files"`
+	CrashReports  []string                           `json:"crash_reports,omitempty"`
+	Runs          int                                `json:"runs"`
+}
+
+// DiagnosticsRun is a run in a diagnostics bundle: the state of a history
+// record without its results, with secret parameters masked.
+type DiagnosticsRun struct {
+	ID             string            `json:"id"`
+	StartTime      time.Time         `json:"start_time"`
+	Duration       time.Duration     `json:"duration"`
+	ConnectionName string            `json:"connection_name"`
+	TemplateName   string            `json:"template_name"`
+	DatabaseType   string            `json:"database_type"`
+	Threads        int               `json:"threads"`
+	Agents         []string          `json:"agents,omitempty"`
+	State          string            `json:"state"`
+	ErrorMessage   string            `json:"error_message,omitempty"`
+	Parameters     map[string]string `json:"parameters,omitempty"`
+}
+
+// DiagnosticsUseCase collects diagnostics bundles.
+type DiagnosticsUseCase struct {
+	dataDir    string
+	logDir     string
+	exportDir  string // Default directory of bundles
+	settingsUC *SettingsUseCase
+	db         *sql.DB         // For the schema version; nil leaves it out
+	historyUC  *HistoryUseCase // For the recent runs; nil leaves them out
+	appVersion string
+}
+
+// NewDiagnosticsUseCase creates a new diagnostics use case for the data
+// directory dataDir with the log files in logDir. Bundles are written to the
+// configured export directory, or exportDir if none is configured.
+func NewDiagnosticsUseCase(dataDir, logDir, exportDir string, settingsUC *SettingsUseCase) *DiagnosticsUseCase {
+	return &DiagnosticsUseCase{
+		dataDir:    dataDir,
+		logDir:     logDir,
+		exportDir:  exportDir,
+		settingsUC: settingsUC,
+	}
+}
+
+// SetDatabase sets the database whose schema version is reported.
+func (uc *DiagnosticsUseCase) SetDatabase(db *sql.DB) {
+	uc.db = db
+}
+
+// SetHistoryUseCase sets the history whose newest runs are included.
+func (uc *DiagnosticsUseCase) SetHistoryUseCase(historyUC *HistoryUseCase) {
+	uc.historyUC = historyUC
+}
+
+// SetAppVersion sets the application version reported.
+func (uc *DiagnosticsUseCase) SetAppVersion(version string) {
+	uc.appVersion = version
+}
+
+// LogDir returns the directory of the log files.
+func (uc *DiagnosticsUseCase) LogDir() string {
+	return uc.logDir
+}
+
+// BundlePath returns a path for a new diagnostics bundle in the export
+// directory.
+func (uc *DiagnosticsUseCase) BundlePath(ctx context.Context) string {
+	dir, _ := exportSettings(ctx, uc.settingsUC.GetExportConfig, uc.exportDir)
+	return filepath.Join(dir, fmt.Sprintf("db-benchmind-diagnostics-%s.zip", time.Now().Format("20060102-150405")))
+}
+
+// Collect writes a diagnostics bundle to dest: the last 1 MB of the log
+// files and the crash reports written in the last 7 days, the settings with
+// password hashes removed and webhook URLs cut to their host, the 20 newest
+// runs with secrets masked, and the system with the schema version and the
+// detected benchmark tools.
+func (uc *DiagnosticsUseCase) Collect(ctx context.Context, dest string) (*DiagnosticsSystem, error) {
+	system := &DiagnosticsSystem{
+		CreatedAt:  time.Now(),
+		AppVersion: uc.appVersion,
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		GoVersion:  runtime.Version(),
+		CPUs:       runtime.NumCPU(),
+		DataDir:    uc.dataDir,
+		Tools:      uc.settingsUC.DetectTools(ctx),
+	}
+	if uc.db != nil {
+		version, err := database.SchemaVersion(ctx, uc.db)
+		if err != nil {
+			return nil, fmt.Errorf("get schema version: %w", err)
+		}
+		system.SchemaVersion = version
+	}
+
+	// Compressed log files are older parts; the tails of the others suffice
+	logFiles, err := listFiles(uc.logDir)
+	if err != nil {
+		return nil, fmt.Errorf("list log files: %w", err)
+	}
+	for _, rel := range logFiles {
+		info, err := os.Stat(filepath.Join(uc.logDir, rel))
+		if err != nil || !strings.HasSuffix(rel, ".log") || time.Since(info.ModTime()) > diagnosticsLogAge {
+			continue
+		}
+		if strings.HasPrefix(filepath.Base(rel), crashReportPrefix) {
+			system.CrashReports = append(system.CrashReports, rel)
+		} else {
+			system.LogFiles = append(system.LogFiles, rel)
+		}
+	}
+
+	runs := []DiagnosticsRun{}
+	if uc.historyUC != nil {
+		records, err := uc.historyUC.ListRecords(ctx, &repository.ListOptions{Limit: diagnosticsRecentRuns, OrderBy: "start_time DESC"})
+		if err != nil {
+			return nil, fmt.Errorf("list history records: %w", err)
+		}
+		for _, record := range records {
+			run := DiagnosticsRun{
+				ID:             record.ID,
+				StartTime:      record.StartTime,
+				Duration:       record.Duration,
+				ConnectionName: record.ConnectionName,
+				TemplateName:   record.TemplateName,
+				DatabaseType:   record.DatabaseType,
+				Threads:        record.Threads,
+				Agents:         record.Agents,
+				State:          record.State,
+				ErrorMessage:   redactSecrets(record.ErrorMessage),
+			}
+			if run.State == "" {
+				run.State = "completed"
+			}
+			for name, value := range record.Parameters {
+				if run.Parameters == nil {
+					run.Parameters = make(map[string]string, len(record.Parameters))
+				}
+				if isSecretName(name) {
+					value = maskedSecret
+				}
+				run.Parameters[name] = redactSecrets(value)
+			}
+			runs = append(runs, run)
+		}
+		system.Runs = len(runs)
+	}
+
+	cfg, err := uc.settingsUC.GetConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get config: %w", err)
+	}
+	configData, err := json.MarshalIndent(redactConfig(*cfg), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal config: %w", err)
+	}
+	systemData, err := json.MarshalIndent(system, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal system: %w", err)
+	}
+	type entry struct {
+		name string
+		data []byte
+	}
+	entries := []entry{{diagnosticsSystemEntry, systemData}, {diagnosticsConfigEntry, configData}}
+	if uc.historyUC != nil {
+		runsData, err := json.MarshalIndent(runs, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal runs: %w", err)
+		}
+		entries = append(entries, entry{diagnosticsRunsEntry, runsData})
+	}
+
+	file, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("create bundle file: %w", err)
+	}
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	for _, entry := range entries {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: entry.name, Method: zip.Deflate, Modified: system.CreatedAt})
+		if err != nil {
+			return nil, fmt.Errorf("write %s: %w", entry.name, err)
+		}
+		if _, err := w.Write(entry.data); err != nil {
+			return nil, fmt.Errorf("write %s: %w", entry.name, err)
+		}
+	}
+	for _, rel := range append(append([]string{}, system.LogFiles...), system.CrashReports...) {
+		if err := writeZipTail(zw, path.Join(diagnosticsLogsDir, filepath.ToSlash(rel)), filepath.Join(uc.logDir, rel), diagnosticsLogTail); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("write bundle file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("write bundle file: %w", err)
+	}
+
+	slog.Info("Diagnostics: Bundle collected", "file", dest, "log_files", len(system.LogFiles), "crash_reports", len(system.CrashReports), "runs", system.Runs)
+	return system, nil
+}
+
+// writeZipTail adds the last max bytes of the file at src to the ZIP file as
+// name, starting at a line.
+func writeZipTail(zw *zip.Writer, name, src string, max int64) error {
+	file, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", src, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", src, err)
+	}
+	if info.Size() <= max {
+		return writeZipFile(zw, name, src)
+	}
+	data := make([]byte, max)
+	if _, err := file.ReadAt(data, info.Size()-max); err != nil && err != io.EOF {
+		return fmt.Errorf("read %s: %w", src, err)
+	}
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		data = data[i+1:]
+	}
+
+	hdr := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: info.ModTime()}
+	w, err := zw.CreateHeader(hdr)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+// secretPatterns match secrets in free text: NAME=VALUE and NAME: VALUE
+// pairs whose name denotes a secret, passwords in URLs and in MySQL DSNs
+// (user:password@tcp(host:port)/db).
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)((?:pass(?:word)?|pwd|secret|token)\w*\s*[=:]\s*)("[^"]*"|'[^']*'|[^\s,;]+)`),
+	regexp.MustCompile(`(://[^:/@\s]+:)[^@\s]+(@)`),
+	regexp.MustCompile(`((?:^|[\s"'(=])[^:/@\s"'(=]+:)[^@\s]+(@(?:tcp|unix)\()`),
+}
+
+// redactSecrets masks the secrets matched by secretPatterns in s.
+func redactSecrets(s string) string {
+	s = secretPatterns[0].ReplaceAllString(s, "${1}"+maskedSecret)
+	s = secretPatterns[1].ReplaceAllString(s, "${1}"+maskedSecret+"${2}")
+	return secretPatterns[2].ReplaceAllString(s, "${1}"+maskedSecret+"${2}")
+}
+
+// WriteCrashReport writes the panic value and the stack of a crash to a new
+// crash report in logDir, which diagnostics bundles include, and returns its
+// path.
+func WriteCrashReport(logDir, version string, value any, stack []byte) (string, error) {
+	now := time.Now()
+	report := filepath.Join(logDir, fmt.Sprintf("%s%s.log", crashReportPrefix, now.Format("20060102-150405")))
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "DB-BenchMind %s crashed at %s\n", version, now.Format(time.RFC3339))
+	fmt.Fprintf(&b, "OS: %s/%s, Go: %s\n\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
+	fmt.Fprintf(&b, "panic: %v\n\n%s", value, stack)
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(report, b.Bytes(), 0644); err != nil {
+		return "", err
+	}
+	return report, nil
+}
+
+// redactConfig returns cfg without the app lock password hashes and with
+// the webhook URLs cut to their scheme and host, as their paths and queries
+// often hold tokens.
+func redactConfig(cfg config.Config) config.Config {
+	// Empty hashes still show which roles have a password
+	if cfg.AppLock.AdminPassword != nil {
+		cfg.AppLock.AdminPassword = &config.PasswordHash{}
+	}
+	if cfg.AppLock.OperatorPassword != nil {
+		cfg.AppLock.OperatorPassword = &config.PasswordHash{}
+	}
+	webhooks := make([]config.WebhookConfig, len(cfg.Webhooks))
+	for i, webhook := range cfg.Webhooks {
+		if u, err := url.Parse(webhook.URL); err == nil {
+			webhook.URL = (&url.URL{Scheme: u.Scheme, Host: u.Host}).String() + "/..."
+		} else {
+			webhook.URL = "..."
+		}
+		webhooks[i] = webhook
+	}
+	cfg.Webhooks = webhooks
+	return cfg
+}
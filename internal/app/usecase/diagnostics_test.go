@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+func TestDiagnosticsUseCase_Collect(t *testing.T) {
+	ctx := context.Background()
+	settingsUC := setupSettingsTest(t)
+	cfg, err := settingsUC.GetConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Webhooks = []config.WebhookConfig{{Name: "chat", Enabled: true, URL: "https://hooks.example.com/services/SECRET", Format: "generic", Events: []string{config.WebhookEventFailed}}}
+	cfg.AppLock.AdminPassword = &config.PasswordHash{KDF: "argon2id", Hash: []byte("secret")}
+	if err := settingsUC.UpdateConfig(ctx, cfg); err != nil {
+		t.Fatalf("UpdateConfig() error = %v", err)
+	}
+
+	logDir := t.TempDir()
+	old := filepath.Join(logDir, "db-benchmind-2026-01-01.log")
+	for _, path := range []string{filepath.Join(logDir, "db-benchmind-2026-01-28.log"), filepath.Join(logDir, "db-benchmind-2026-01-27.log.gz"), old} {
+		if err := os.WriteFile(path, []byte("log\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	oldTime := time.Now().Add(-30 * 24 * time.Hour)
+	if err := os.Chtimes(old, oldTime, oldTime); err != nil {
+		t.Fatal(err)
+	}
+	crash, err := WriteCrashReport(logDir, "1.0.0", "boom", []byte("goroutine 1 [running]:\n"))
+	if err != nil {
+		t.Fatalf("WriteCrashReport() error = %v", err)
+	}
+
+	historyRepo := newMockHistoryRepository()
+	historyRepo.Save(ctx, &history.Record{
+		ID:             "run-1",
+		StartTime:      time.Now(),
+		ConnectionName: "prod",
+		State:          "failed",
+		ErrorMessage:   "connect postgres://bench:hunter2@db:5432/sbtest: password=hunter2 rejected",
+		Parameters:     map[string]string{"pgsql-password": "hunter2", "threads": "8"},
+	})
+
+	uc := NewDiagnosticsUseCase("/data", logDir, "/data/exports", settingsUC)
+	uc.SetHistoryUseCase(NewHistoryUseCase(historyRepo))
+	uc.SetAppVersion("1.0.0")
+	dest := filepath.Join(t.TempDir(), "diagnostics.zip")
+	system, err := uc.Collect(ctx, dest)
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+	if want := []string{"db-benchmind-2026-01-28.log"}; !slices.Equal(system.LogFiles, want) {
+		t.Errorf("LogFiles = %v, want %v", system.LogFiles, want)
+	}
+	if want := []string{filepath.Base(crash)}; !slices.Equal(system.CrashReports, want) {
+		t.Errorf("CrashReports = %v, want %v", system.CrashReports, want)
+	}
+	if system.Runs != 1 || system.AppVersion != "1.0.0" {
+		t.Errorf("Runs = %d, AppVersion = %q, want 1, 1.0.0", system.Runs, system.AppVersion)
+	}
+
+	zr, err := zip.OpenReader(dest)
+	if err != nil {
+		t.Fatalf("zip.OpenReader() error = %v", err)
+	}
+	defer zr.Close()
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	if want := []string{"system.json", "config.json", "runs.json", "logs/db-benchmind-2026-01-28.log", "logs/" + filepath.Base(crash)}; !slices.Equal(names, want) {
+		t.Fatalf("entries = %v, want %v", names, want)
+	}
+
+	runs, err := zr.File[2].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer runs.Close()
+	data, err := io.ReadAll(runs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "hunter2") || !strings.Contains(string(data), `"threads": "8"`) {
+		t.Errorf("runs.json = %s, want secrets masked and other parameters kept", data)
+	}
+
+	r, err := zr.File[1].Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	var bundled config.Config
+	if err := json.NewDecoder(r).Decode(&bundled); err != nil {
+		t.Fatalf("decode config.json: %v", err)
+	}
+	if got := bundled.Webhooks[0].URL; strings.Contains(got, "SECRET") {
+		t.Errorf("webhook URL = %q, want it without the path", got)
+	}
+	if hash := bundled.AppLock.AdminPassword; hash == nil || len(hash.Hash) != 0 {
+		t.Errorf("admin password = %+v, want an empty hash", hash)
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"dial tcp: connection refused", "dial tcp: connection refused"},
+		{"password=hunter2 host=db", "password=***** host=db"},
+		{`PGPASSWORD: "a b" failed`, `PGPASSWORD: ***** failed`},
+		{"mysql://root:hunter2@db:3306/x", "mysql://root:*****@db:3306/x"},
+		{"open root:hunter2@tcp(db:3306)/x failed", "open root:*****@tcp(db:3306)/x failed"},
+		{"10:58:37 user@tcp(db:3306)", "10:58:37 user@tcp(db:3306)"},
+	}
+	for _, tt := range tests {
+		if got := redactSecrets(tt.in); got != tt.want {
+			t.Errorf("redactSecrets(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+
+package usecase
+
+import "syscall"
+
+// availableDiskSpace returns the number of bytes available to the user on the
+// file system containing path.
+func availableDiskSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dberror"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+// errorCounter counts the database errors in the tool output of a run phase by class.
+// It is safe for concurrent use.
+type errorCounter struct {
+	mu     sync.Mutex
+	counts dberror.Counts
+}
+
+// countLine classifies a line of tool output and counts it if it reports an
+// error. A nil counter counts nothing.
+func (c *errorCounter) countLine(line string) {
+	if c == nil {
+		return
+	}
+	class := dberror.Classify(line)
+	if class == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(dberror.Counts)
+	}
+	c.counts[class]++
+}
+
+// lines returns a writer that counts each line written to it. Each output
+// stream needs its own, so that lines of different streams are not mixed.
+func (c *errorCounter) lines() *lineWriter {
+	return newLineWriter(c.countLine)
+}
+
+// result returns a copy of the counts, nil if no error was seen.
+func (c *errorCounter) result() dberror.Counts {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return maps.Clone(c.counts)
+}
+
+// startErrorCount starts counting the database errors in the tool output of
+// the run phase of run. The returned function stops counting and stores the
+// counts with the run.
+func (uc *BenchmarkUseCase) startErrorCount(ctx context.Context, run *execution.Run) func() {
+	counter := &errorCounter{}
+	uc.errorCountersMu.Lock()
+	if uc.errorCounters == nil {
+		uc.errorCounters = make(map[string]*errorCounter)
+	}
+	uc.errorCounters[run.ID] = counter
+	uc.errorCountersMu.Unlock()
+
+	return func() {
+		uc.errorCountersMu.Lock()
+		delete(uc.errorCounters, run.ID)
+		uc.errorCountersMu.Unlock()
+
+		counts := counter.result()
+		run.ErrorClasses = counts
+		if len(counts) == 0 {
+			return
+		}
+		uc.saveLogEntry(ctx, run.ID, LogEntry{
+			Timestamp: time.Now().Format(time.RFC3339),
+			Stream:    "info",
+			Content:   "Errors by class: " + formatErrorCounts(counts),
+		})
+
+		// Save onto the stored run, whose state the phases have advanced
+		current, err := uc.runRepo.FindByID(context.WithoutCancel(ctx), run.ID)
+		if err != nil {
+			slog.Warn("Benchmark: Failed to load run for error counts", "run_id", run.ID, "error", err)
+			return
+		}
+		current.ErrorClasses = counts
+		if err := uc.runRepo.Save(context.WithoutCancel(ctx), current); err != nil {
+			slog.Warn("Benchmark: Failed to save error counts", "run_id", run.ID, "error", err)
+		}
+	}
+}
+
+// errorCounter returns the error counter of the run phase of a run, or nil
+// if its errors are not being counted.
+func (uc *BenchmarkUseCase) errorCounter(runID string) *errorCounter {
+	uc.errorCountersMu.Lock()
+	defer uc.errorCountersMu.Unlock()
+	return uc.errorCounters[runID]
+}
+
+// formatErrorCounts formats error counts as "deadlock 3, lock_wait_timeout 1".
+func formatErrorCounts(counts dberror.Counts) string {
+	parts := make([]string, 0, len(counts))
+	for _, class := range counts.Sorted() {
+		parts = append(parts, fmt.Sprintf("%s %d", class, counts[class]))
+	}
+	return strings.Join(parts, ", ")
+}
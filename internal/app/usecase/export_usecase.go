@@ -3,12 +3,16 @@ package usecase
 
 import (
 	"context"
+	"encoding/csv"
 	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
 )
 
@@ -18,11 +22,14 @@ type ExportFormat string
 const (
 	FormatTXT      ExportFormat = "txt"
 	FormatMarkdown ExportFormat = "markdown"
+	FormatCSV      ExportFormat = "csv" // One table of all records, see ExportCSV
 )
 
 // ExportUseCase provides export business logic.
 type ExportUseCase struct {
-	exportDir string // Default export directory
+	exportDir    string                                                  // Default export directory
+	artifactDir  string                                                  // Directory in which kept run artifacts are stored
+	exportConfig func(ctx context.Context) (*config.ExportConfig, error) // Optional; configured directory and file names
 }
 
 // NewExportUseCase creates a new export use case.
@@ -35,16 +42,60 @@ func NewExportUseCase(exportDir string) *ExportUseCase {
 	}
 }
 
+// SetArtifactDir sets the directory in which kept run artifacts are stored.
+// Kept artifacts are copied next to the exported records.
+func (uc *ExportUseCase) SetArtifactDir(dir string) {
+	uc.artifactDir = dir
+}
+
+// SetExportConfig sets the source of the configured export directory and file
+// name template. The settings are re-read before every export so changes take
+// effect without a restart; the default directory is used when none is configured.
+func (uc *ExportUseCase) SetExportConfig(exportConfig func(ctx context.Context) (*config.ExportConfig, error)) {
+	uc.exportConfig = exportConfig
+}
+
+// ExportDir returns the directory the next export is written to.
+func (uc *ExportUseCase) ExportDir(ctx context.Context) string {
+	dir, _ := uc.settings(ctx)
+	return dir
+}
+
+// settings returns the export directory and file name template in effect.
+func (uc *ExportUseCase) settings(ctx context.Context) (dir, fileName string) {
+	return exportSettings(ctx, uc.exportConfig, uc.exportDir)
+}
+
+// exportSettings returns the configured export directory and file name
+// template, falling back to defaultDir when none is configured.
+func exportSettings(ctx context.Context, exportConfig func(ctx context.Context) (*config.ExportConfig, error), defaultDir string) (dir, fileName string) {
+	dir, fileName = defaultDir, config.DefaultExportFileName
+	if exportConfig == nil {
+		return dir, fileName
+	}
+	cfg, err := exportConfig(ctx)
+	if err != nil {
+		slog.Warn("Export: Failed to load export settings", "error", err)
+		return dir, fileName
+	}
+	if cfg.Dir != "" {
+		dir = cfg.Dir
+	}
+	return dir, cfg.FileNameTemplate()
+}
+
 // ExportRecord exports a single history record to the specified format.
 func (uc *ExportUseCase) ExportRecord(ctx context.Context, record *history.Record, format ExportFormat) (string, error) {
+	exportDir, fileName := uc.settings(ctx)
+
 	// Ensure export directory exists
-	if err := os.MkdirAll(uc.exportDir, 0755); err != nil {
+	if err := os.MkdirAll(exportDir, 0755); err != nil {
 		return "", fmt.Errorf("create export directory: %w", err)
 	}
 
 	// Generate filename
-	filename := uc.generateFilename(record, format)
-	filepath := filepath.Join(uc.exportDir, filename)
+	filename := uc.generateFilename(fileName, record, format)
+	filepath := filepath.Join(exportDir, filename)
 
 	// Export based on format
 	switch format {
@@ -60,28 +111,48 @@ func (uc *ExportUseCase) ExportRecord(ctx context.Context, record *history.Recor
 		return "", fmt.Errorf("unsupported format: %s", format)
 	}
 
+	if err := uc.exportArtifacts(record, filepath); err != nil {
+		return "", err
+	}
+
 	return filepath, nil
 }
 
 // ExportAllRecords exports all history records to the specified format.
 // Returns the count of successfully exported records and the directory path.
 func (uc *ExportUseCase) ExportAllRecords(ctx context.Context, records []*history.Record, format ExportFormat) (int, string, error) {
+	exportDir, _ := uc.settings(ctx)
+	count, err := uc.ExportRecordsTo(ctx, records, format, exportDir)
+	return count, exportDir, err
+}
+
+// ExportRecordsTo exports history records to the specified format in dir
+// instead of the export directory, e.g. to bundle them with a report.
+// Returns the count of successfully exported records.
+func (uc *ExportUseCase) ExportRecordsTo(ctx context.Context, records []*history.Record, format ExportFormat, dir string) (int, error) {
 	if len(records) == 0 {
-		return 0, "", fmt.Errorf("no records to export")
+		return 0, fmt.Errorf("no records to export")
 	}
 
+	_, fileName := uc.settings(ctx)
+
 	// Ensure export directory exists
-	if err := os.MkdirAll(uc.exportDir, 0755); err != nil {
-		return 0, "", fmt.Errorf("create export directory: %w", err)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, fmt.Errorf("create export directory: %w", err)
 	}
 
 	successCount := 0
 	failedRecords := []string{}
+	used := make(map[string]int) // Records whose file names coincide are numbered
 
 	for i, record := range records {
 		// Generate filename for this record
-		filename := uc.generateFilename(record, format)
-		filepath := filepath.Join(uc.exportDir, filename)
+		filename := uc.generateFilename(fileName, record, format)
+		if used[filename]++; used[filename] > 1 {
+			ext := filepath.Ext(filename)
+			filename = fmt.Sprintf("%s_%d%s", strings.TrimSuffix(filename, ext), used[filename], ext)
+		}
+		filepath := filepath.Join(dir, filename)
 
 		// Export based on format
 		var err error
@@ -93,6 +164,9 @@ func (uc *ExportUseCase) ExportAllRecords(ctx context.Context, records []*histor
 		default:
 			err = fmt.Errorf("unsupported format: %s", format)
 		}
+		if err == nil {
+			err = uc.exportArtifacts(record, filepath)
+		}
 
 		if err != nil {
 			slog.Error("Failed to export record", "index", i, "id", record.ID, "error", err)
@@ -103,25 +177,54 @@ func (uc *ExportUseCase) ExportAllRecords(ctx context.Context, records []*histor
 	}
 
 	if len(failedRecords) > 0 {
-		return successCount, uc.exportDir, fmt.Errorf("failed to export %d records: %v", len(failedRecords), failedRecords)
+		return successCount, fmt.Errorf("failed to export %d records: %v", len(failedRecords), failedRecords)
 	}
 
-	return successCount, uc.exportDir, nil
+	return successCount, nil
+}
+
+// exportArtifacts copies the artifacts kept for a record's run into a
+// directory named after the exported file, e.g. benchmark_x_20060102_150405_artifacts.
+func (uc *ExportUseCase) exportArtifacts(record *history.Record, exportPath string) error {
+	if uc.artifactDir == "" {
+		return nil
+	}
+	dest := strings.TrimSuffix(exportPath, filepath.Ext(exportPath)) + "_artifacts"
+	copied, err := copyRunArtifacts(uc.artifactDir, record.ID, dest)
+	if err != nil {
+		return fmt.Errorf("export artifacts: %w", err)
+	}
+	if copied {
+		slog.Info("Export: Run artifacts copied", "id", record.ID, "dir", dest)
+	}
+	return nil
 }
 
-// generateFilename generates a filename for the exported record.
-func (uc *ExportUseCase) generateFilename(record *history.Record, format ExportFormat) string {
-	// Format: benchmark_{template_name}_{timestamp}.{ext}
-	templateName := strings.ReplaceAll(record.TemplateName, " ", "_")
-	templateName = strings.ReplaceAll(templateName, "/", "_")
-	timestamp := record.StartTime.Format("20060102_150405")
+// generateFilename generates a filename for the exported record from the
+// file name template, see config.ExportFileNamePlaceholders.
+func (uc *ExportUseCase) generateFilename(fileName string, record *history.Record, format ExportFormat) string {
+	name := strings.NewReplacer(
+		"{connection}", fileNamePart(record.ConnectionName),
+		"{template}", fileNamePart(record.TemplateName),
+		"{database}", fileNamePart(record.DatabaseType),
+		"{environment}", fileNamePart(record.Environment),
+		"{threads}", fmt.Sprint(record.Threads),
+		"{date}", record.StartTime.Format("20060102"),
+		"{time}", record.StartTime.Format("150405"),
+		"{id}", fileNamePart(record.ID),
+	).Replace(fileName)
 
 	ext := string(format)
 	if format == FormatMarkdown {
 		ext = "md"
 	}
 
-	return fmt.Sprintf("benchmark_%s_%s.%s", templateName, timestamp, ext)
+	return name + "." + ext
+}
+
+// fileNamePart makes a value usable in a file name.
+func fileNamePart(value string) string {
+	return strings.NewReplacer(" ", "_", "/", "_", "\\", "_", ":", "_").Replace(value)
 }
 
 // exportToTXT exports record to plain text format (exact sysbench format).
@@ -201,6 +304,43 @@ func (uc *ExportUseCase) exportToTXT(record *history.Record, filepath string) er
 	builder.WriteString(fmt.Sprintf("    execution time (avg/stddev):   %.4f/%.2f\n", record.ExecTimeAvg, record.ExecTimeStddev))
 	builder.WriteString("\n")
 
+	// SLA targets
+	if outcome := record.Assertions; outcome != nil {
+		builder.WriteString(fmt.Sprintf("SLA: %s\n", outcome))
+		for _, result := range outcome.Results {
+			builder.WriteString(fmt.Sprintf("    %s\n", result))
+		}
+		builder.WriteString("\n")
+	}
+
+	// TPS drops and error spikes, to correlate with server logs
+	if anomalies := history.DetectAnomalies(record.TimeSeries, history.AnomalyOptions{}); len(anomalies) > 0 {
+		builder.WriteString(fmt.Sprintf("Anomalies (%d):\n", len(anomalies)))
+		for _, a := range anomalies {
+			builder.WriteString(fmt.Sprintf("    %s\n", a))
+		}
+		builder.WriteString("\n")
+	}
+
+	// Run metadata
+	if record.Purpose != "" {
+		builder.WriteString(fmt.Sprintf("Purpose: %s\n", record.Purpose))
+	}
+	if record.Ticket != "" {
+		builder.WriteString(fmt.Sprintf("Ticket: %s\n", record.Ticket))
+	}
+	if record.Environment != "" {
+		builder.WriteString(fmt.Sprintf("Environment: %s\n", record.Environment))
+	}
+
+	// User annotations
+	if len(record.Tags) > 0 {
+		builder.WriteString(fmt.Sprintf("Tags: %s\n", strings.Join(record.Tags, ", ")))
+	}
+	if record.Notes != "" {
+		builder.WriteString(fmt.Sprintf("Notes:\n%s\n", record.Notes))
+	}
+
 	// Write to file
 	if err := os.WriteFile(filepath, []byte(builder.String()), 0644); err != nil {
 		return fmt.Errorf("write file: %w", err)
@@ -225,8 +365,41 @@ func (uc *ExportUseCase) exportToMarkdown(record *history.Record, filepath strin
 	builder.WriteString(fmt.Sprintf("| Threads | %d |\n", record.Threads))
 	builder.WriteString(fmt.Sprintf("| Start Time | %s |\n", record.StartTime.Format("2006-01-02 15:04:05")))
 	builder.WriteString(fmt.Sprintf("| Duration | %s |\n", record.Duration))
+	if record.Purpose != "" {
+		builder.WriteString(fmt.Sprintf("| Purpose | %s |\n", record.Purpose))
+	}
+	if record.Ticket != "" {
+		builder.WriteString(fmt.Sprintf("| Ticket | %s |\n", record.Ticket))
+	}
+	if record.Environment != "" {
+		builder.WriteString(fmt.Sprintf("| Environment | %s |\n", record.Environment))
+	}
+	if len(record.Tags) > 0 {
+		builder.WriteString(fmt.Sprintf("| Tags | %s |\n", strings.Join(record.Tags, ", ")))
+	}
 	builder.WriteString("\n")
 
+	if record.Notes != "" {
+		builder.WriteString("## Notes\n\n")
+		builder.WriteString(record.Notes + "\n\n")
+	}
+
+	if outcome := record.Assertions; outcome != nil {
+		builder.WriteString(fmt.Sprintf("## SLA Targets: %s\n\n", outcome))
+		for _, result := range outcome.Results {
+			builder.WriteString(fmt.Sprintf("- %s\n", result))
+		}
+		builder.WriteString("\n")
+	}
+
+	if anomalies := history.DetectAnomalies(record.TimeSeries, history.AnomalyOptions{}); len(anomalies) > 0 {
+		builder.WriteString(fmt.Sprintf("## Anomalies (%d)\n\n", len(anomalies)))
+		for _, a := range anomalies {
+			builder.WriteString(fmt.Sprintf("- %s\n", a))
+		}
+		builder.WriteString("\n")
+	}
+
 	// Build core metrics
 	builder.WriteString("## Core Metrics\n\n")
 	builder.WriteString("| Metric | Value |\n")
@@ -352,3 +525,86 @@ func (uc *ExportUseCase) exportToMarkdown(record *history.Record, filepath strin
 
 	return nil
 }
+
+// csvHeader is the header row of CSV exports.
+var csvHeader = []string{
+	"id", "start_time", "state", "connection", "template", "database_type", "threads",
+	"duration_seconds", "tps", "latency_avg_ms", "latency_p95_ms", "latency_p99_ms", "latency_max_ms",
+	"total_transactions", "total_queries", "ignored_errors", "reconnects",
+	"valid", "sla", "tags", "purpose", "ticket", "environment",
+}
+
+// ExportCSV exports records to one CSV file in the export directory, one row
+// per record, e.g. for spreadsheets or periodic report extraction.
+// Returns the path of the file.
+func (uc *ExportUseCase) ExportCSV(ctx context.Context, records []*history.Record) (string, error) {
+	if len(records) == 0 {
+		return "", fmt.Errorf("no records to export")
+	}
+
+	exportDir, _ := uc.settings(ctx)
+	if err := os.MkdirAll(exportDir, 0755); err != nil {
+		return "", fmt.Errorf("create export directory: %w", err)
+	}
+	path := filepath.Join(exportDir, "history_"+time.Now().Format("20060102_150405")+".csv")
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("create file: %w", err)
+	}
+	defer file.Close()
+
+	w := csv.NewWriter(file)
+	w.Write(csvHeader)
+	for _, record := range records {
+		w.Write(csvRow(record))
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+
+	slog.Info("Export: Records exported to CSV", "count", len(records), "path", path)
+	return path, nil
+}
+
+// csvRow returns the CSV columns of a record, see csvHeader.
+func csvRow(record *history.Record) []string {
+	state := record.State
+	if state == "" {
+		state = "completed"
+	}
+	sla := ""
+	if record.Assertions != nil {
+		sla = record.Assertions.String()
+	}
+	float := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	return []string{
+		record.ID,
+		record.StartTime.Format(time.RFC3339),
+		state,
+		record.ConnectionName,
+		record.TemplateName,
+		record.DatabaseType,
+		strconv.Itoa(record.Threads),
+		float(record.Duration.Seconds()),
+		float(record.TPSCalculated),
+		float(record.LatencyAvg),
+		float(record.LatencyP95),
+		float(record.LatencyP99),
+		float(record.LatencyMax),
+		strconv.FormatInt(record.TotalTransactions, 10),
+		strconv.FormatInt(record.TotalQueries, 10),
+		strconv.FormatInt(record.IgnoredErrors, 10),
+		strconv.FormatInt(record.Reconnects, 10),
+		strconv.FormatBool(record.IsValid()),
+		sla,
+		strings.Join(record.Tags, ";"),
+		record.Purpose,
+		record.Ticket,
+		record.Environment,
+	}
+}
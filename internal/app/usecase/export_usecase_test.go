@@ -0,0 +1,110 @@
+// Package usecase provides unit tests for history record exports.
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// TestExport_ConfiguredDirAndFileName tests that exports follow the export
+// settings, and that records with the same file name do not overwrite each other.
+func TestExport_ConfiguredDirAndFileName(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2026, 3, 1, 2, 30, 0, 0, time.UTC)
+	records := []*history.Record{
+		{ID: "run-1", ConnectionName: "prod db", TemplateName: "OLTP Read/Write", StartTime: start},
+		{ID: "run-2", ConnectionName: "prod db", TemplateName: "OLTP Read/Write", StartTime: start.Add(time.Hour)},
+	}
+
+	exportUC := NewExportUseCase(t.TempDir())
+	path, err := exportUC.ExportRecord(ctx, records[0], FormatMarkdown)
+	if err != nil {
+		t.Fatalf("ExportRecord() failed: %v", err)
+	}
+	if got := filepath.Base(path); got != "benchmark_OLTP_Read_Write_20260301_023000.md" {
+		t.Errorf("default file name = %s", got)
+	}
+
+	dir := filepath.Join(t.TempDir(), "configured")
+	exportUC.SetExportConfig(func(context.Context) (*config.ExportConfig, error) {
+		return &config.ExportConfig{Dir: dir, FileName: "{connection}_{template}_{date}"}, nil
+	})
+	count, exportDir, err := exportUC.ExportAllRecords(ctx, records, FormatTXT)
+	if err != nil || count != 2 || exportDir != dir {
+		t.Fatalf("ExportAllRecords() = %d, %s, %v, want 2 records in %s", count, exportDir, err, dir)
+	}
+	for _, name := range []string{"prod_db_OLTP_Read_Write_20260301.txt", "prod_db_OLTP_Read_Write_20260301_2.txt"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("exported file %s: %v", name, err)
+		}
+	}
+}
+
+// TestExport_Anomalies tests that the TPS drops of a run are listed with
+// their timestamps in the TXT and Markdown exports.
+func TestExport_Anomalies(t *testing.T) {
+	start := time.Date(2026, 3, 1, 2, 30, 0, 0, time.UTC)
+	record := &history.Record{ID: "run-1", TemplateName: "OLTP", StartTime: start}
+	for i, tps := range []float64{1000, 1000, 1000, 1000, 1000, 1000, 100, 1000} {
+		record.TimeSeries = append(record.TimeSeries, history.MetricSample{Timestamp: start.Add(time.Duration(i) * time.Second), Phase: "run", TPS: tps})
+	}
+
+	exportUC := NewExportUseCase(t.TempDir())
+	for format, want := range map[ExportFormat]string{
+		FormatTXT:      "Anomalies (1):\n    02:30:06-02:30:06 (+6s) tps_drop",
+		FormatMarkdown: "## Anomalies (1)\n\n- 02:30:06-02:30:06 (+6s) tps_drop",
+	} {
+		path, err := exportUC.ExportRecord(context.Background(), record, format)
+		if err != nil {
+			t.Fatalf("ExportRecord(%s) failed: %v", format, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s export does not list the anomaly %q:\n%s", format, want, data)
+		}
+	}
+}
+
+// TestExportCSV tests that all records are exported to one CSV table.
+func TestExportCSV(t *testing.T) {
+	records := []*history.Record{
+		{ID: "run-1", ConnectionName: "prod, mysql", TemplateName: "OLTP", Threads: 8, TPSCalculated: 1234.5, Tags: []string{"a", "b"}},
+		{ID: "run-2", ConnectionName: "prod-mysql", State: "failed"},
+	}
+
+	exportUC := NewExportUseCase(t.TempDir())
+	path, err := exportUC.ExportCSV(context.Background(), records)
+	if err != nil {
+		t.Fatalf("ExportCSV() failed: %v", err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("reading the exported CSV: %v", err)
+	}
+
+	if len(rows) != 3 || len(rows[1]) != len(csvHeader) {
+		t.Fatalf("exported %d rows of %d columns, want a header and 2 records of %d columns", len(rows), len(rows[1]), len(csvHeader))
+	}
+	if got := rows[1]; got[3] != "prod, mysql" || got[6] != "8" || got[8] != "1234.50" || got[19] != "a;b" {
+		t.Errorf("row = %v", got)
+	}
+	if got := rows[2][2]; got != "failed" {
+		t.Errorf("state = %s, want failed", got)
+	}
+}
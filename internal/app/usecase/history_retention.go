@@ -0,0 +1,189 @@
+// Package usecase provides history retention, archive and purge logic.
+package usecase
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// defaultPurgeInterval is used when the retention config has no purge interval.
+const defaultPurgeInterval = 24 * time.Hour
+
+// PurgeOptions selects the history records to purge.
+type PurgeOptions struct {
+	OlderThan  time.Duration // Purge records that started longer ago than this (0 = no age limit)
+	KeepLatest int           // Purge all but the newest N records (0 = no count limit)
+	ArchiveDir string        // Archive purged records here first ("" = delete without archiving)
+	DryRun     bool          // Only report what would be purged
+}
+
+// PurgeResult reports the outcome of a purge.
+type PurgeResult struct {
+	Records     []*history.Record // Records selected for purging, oldest first
+	Purged      int               // Records actually deleted
+	ArchivePath string            // Archive file, if any
+}
+
+// HistoryArchive is the content of an archive file.
+type HistoryArchive struct {
+	ArchivedAt time.Time         `json:"archived_at"`
+	Records    []*history.Record `json:"records"`
+}
+
+// PurgeOptionsFromConfig converts a retention config into purge options.
+func PurgeOptionsFromConfig(cfg *config.HistoryConfig) *PurgeOptions {
+	opts := &PurgeOptions{
+		OlderThan:  time.Duration(cfg.MaxAgeDays) * 24 * time.Hour,
+		KeepLatest: cfg.MaxRecords,
+	}
+	if cfg.ArchiveBeforePurge {
+		opts.ArchiveDir = cfg.ArchiveDir
+	}
+	return opts
+}
+
+// PurgeRecords deletes history records selected by opts, archiving them first if requested.
+func (uc *HistoryUseCase) PurgeRecords(ctx context.Context, opts *PurgeOptions) (*PurgeResult, error) {
+	records, err := uc.selectPurgeCandidates(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PurgeResult{Records: records}
+	if len(records) == 0 || opts.DryRun {
+		return result, nil
+	}
+
+	if opts.ArchiveDir != "" {
+		path, err := uc.ArchiveRecords(ctx, records, opts.ArchiveDir)
+		if err != nil {
+			// Never delete what could not be archived
+			return result, err
+		}
+		result.ArchivePath = path
+	}
+
+	for _, record := range records {
+		if err := uc.historyRepo.Delete(ctx, record.ID); err != nil {
+			return result, fmt.Errorf("delete record %s: %w", record.ID, err)
+		}
+		uc.removeRunData(ctx, record.ID)
+		result.Purged++
+	}
+
+	slog.Info("History: Records purged",
+		"count", result.Purged,
+		"older_than", opts.OlderThan,
+		"keep_latest", opts.KeepLatest,
+		"archive", result.ArchivePath)
+
+	return result, nil
+}
+
+// selectPurgeCandidates returns the records matched by the age or count limit, oldest first.
+func (uc *HistoryUseCase) selectPurgeCandidates(ctx context.Context, opts *PurgeOptions) ([]*history.Record, error) {
+	candidates := make(map[string]*history.Record)
+
+	if opts.OlderThan > 0 {
+		cutoff := time.Now().Add(-opts.OlderThan)
+		old, err := uc.historyRepo.List(ctx, &repository.ListOptions{StartTimeBefore: &cutoff})
+		if err != nil {
+			return nil, fmt.Errorf("list old records: %w", err)
+		}
+		for _, r := range old {
+			candidates[r.ID] = r
+		}
+	}
+
+	if opts.KeepLatest > 0 {
+		// Newest first, so everything after the first KeepLatest is excess
+		excess, err := uc.historyRepo.List(ctx, &repository.ListOptions{Offset: opts.KeepLatest})
+		if err != nil {
+			return nil, fmt.Errorf("list excess records: %w", err)
+		}
+		for _, r := range excess {
+			candidates[r.ID] = r
+		}
+	}
+
+	records := make([]*history.Record, 0, len(candidates))
+	for _, r := range candidates {
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].StartTime.Before(records[j].StartTime)
+	})
+	return records, nil
+}
+
+// ArchiveRecords writes records, including their time series, to a gzip compressed
+// JSON file in dir. Returns the path of the archive file.
+func (uc *HistoryUseCase) ArchiveRecords(ctx context.Context, records []*history.Record, dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("create archive directory: %w", err)
+	}
+
+	now := time.Now()
+	path := filepath.Join(dir, fmt.Sprintf("history-archive-%s.json.gz", now.Format("20060102-150405")))
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", fmt.Errorf("create archive file: %w", err)
+	}
+
+	zw := gzip.NewWriter(file)
+	encodeErr := json.NewEncoder(zw).Encode(&HistoryArchive{ArchivedAt: now, Records: records})
+	closeErr := zw.Close()
+	fileErr := file.Close()
+
+	for _, err := range []error{encodeErr, closeErr, fileErr} {
+		if err != nil {
+			os.Remove(path)
+			return "", fmt.Errorf("write archive: %w", err)
+		}
+	}
+
+	slog.Info("History: Records archived", "count", len(records), "path", path)
+	return path, nil
+}
+
+// StartRetentionJob runs the retention policy now and then periodically until ctx is done.
+// The policy is re-read before every run so settings changes take effect without a restart.
+func (uc *HistoryUseCase) StartRetentionJob(ctx context.Context, policy func(ctx context.Context) (*config.HistoryConfig, error)) {
+	go func() {
+		for {
+			interval := defaultPurgeInterval
+
+			cfg, err := policy(ctx)
+			if err != nil {
+				slog.Warn("History: Failed to load retention policy", "error", err)
+			} else {
+				if cfg.PurgeIntervalHours > 0 {
+					interval = time.Duration(cfg.PurgeIntervalHours) * time.Hour
+				}
+				if cfg.Enabled() {
+					if _, err := uc.PurgeRecords(ctx, PurgeOptionsFromConfig(cfg)); err != nil {
+						slog.Error("History: Retention purge failed", "error", err)
+					}
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+		}
+	}()
+}
@@ -0,0 +1,204 @@
+// Package usecase provides unit tests for history retention.
+package usecase
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// mockHistoryRepository is an in-memory history repository supporting the
+// list options used by retention.
+type mockHistoryRepository struct {
+	records map[string]*history.Record
+}
+
+func newMockHistoryRepository() *mockHistoryRepository {
+	return &mockHistoryRepository{records: make(map[string]*history.Record)}
+}
+
+func (m *mockHistoryRepository) Save(ctx context.Context, record *history.Record) error {
+	m.records[record.ID] = record
+	return nil
+}
+
+func (m *mockHistoryRepository) GetByID(ctx context.Context, id string) (*history.Record, error) {
+	record, ok := m.records[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return record, nil
+}
+
+func (m *mockHistoryRepository) GetAll(ctx context.Context) ([]*history.Record, error) {
+	return m.List(ctx, &repository.ListOptions{})
+}
+
+func (m *mockHistoryRepository) Delete(ctx context.Context, id string) error {
+	delete(m.records, id)
+	return nil
+}
+
+func (m *mockHistoryRepository) List(ctx context.Context, opts *repository.ListOptions) ([]*history.Record, error) {
+	var result []*history.Record
+	for _, r := range m.records {
+		if opts.StartTimeBefore != nil && !r.StartTime.Before(*opts.StartTimeBefore) {
+			continue
+		}
+		if !r.HasAllTags(opts.Tags) || (opts.ValidOnly && !r.IsValid()) {
+			continue
+		}
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartTime.After(result[j].StartTime)
+	})
+	if opts.Offset >= len(result) {
+		return nil, nil
+	}
+	return result[opts.Offset:], nil
+}
+
+func (m *mockHistoryRepository) Count(ctx context.Context, opts *repository.ListOptions) (int, error) {
+	records, err := m.List(ctx, opts)
+	return len(records), err
+}
+
+func (m *mockHistoryRepository) UpdateAnnotations(ctx context.Context, id string, tags []string, notes string) error {
+	if record, ok := m.records[id]; ok {
+		record.Tags = tags
+		record.Notes = notes
+	}
+	return nil
+}
+
+func (m *mockHistoryRepository) UpdateValidity(ctx context.Context, id string, validity *history.Validity) error {
+	if record, ok := m.records[id]; ok {
+		record.Validity = validity
+	}
+	return nil
+}
+
+func (m *mockHistoryRepository) CompactRecords(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+// newRetentionTestRepo creates a repository with one record per day, run-0 being the newest.
+func newRetentionTestRepo(count int) *mockHistoryRepository {
+	repo := newMockHistoryRepository()
+	now := time.Now()
+	for i := 0; i < count; i++ {
+		id := fmt.Sprintf("run-%d", i)
+		repo.records[id] = &history.Record{
+			ID:        id,
+			StartTime: now.Add(-time.Duration(i)*24*time.Hour - time.Hour),
+			TimeSeries: []history.MetricSample{
+				{Timestamp: now, TPS: 100, RawLine: "[ 1s ] thds: 8 tps: 100.00"},
+			},
+		}
+	}
+	return repo
+}
+
+// TestHistoryUseCase_PurgeRecords tests selecting records by age and count.
+func TestHistoryUseCase_PurgeRecords(t *testing.T) {
+	ctx := context.Background()
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name       string
+		opts       PurgeOptions
+		wantPurged int
+	}{
+		{"older than", PurgeOptions{OlderThan: 5 * day}, 5},
+		{"keep latest", PurgeOptions{KeepLatest: 3}, 7},
+		{"age or count", PurgeOptions{OlderThan: 8 * day, KeepLatest: 6}, 4},
+		{"no limits", PurgeOptions{}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRetentionTestRepo(10)
+			uc := NewHistoryUseCase(repo)
+
+			result, err := uc.PurgeRecords(ctx, &tt.opts)
+			if err != nil {
+				t.Fatalf("PurgeRecords() failed: %v", err)
+			}
+			if result.Purged != tt.wantPurged {
+				t.Errorf("Purged = %d, want %d", result.Purged, tt.wantPurged)
+			}
+			if len(repo.records) != 10-tt.wantPurged {
+				t.Errorf("remaining records = %d, want %d", len(repo.records), 10-tt.wantPurged)
+			}
+			if _, ok := repo.records["run-0"]; !ok {
+				t.Error("newest record was purged")
+			}
+		})
+	}
+}
+
+// TestHistoryUseCase_PurgeRecords_DryRun tests that a dry run deletes nothing.
+func TestHistoryUseCase_PurgeRecords_DryRun(t *testing.T) {
+	repo := newRetentionTestRepo(10)
+	uc := NewHistoryUseCase(repo)
+
+	result, err := uc.PurgeRecords(context.Background(), &PurgeOptions{KeepLatest: 4, DryRun: true})
+	if err != nil {
+		t.Fatalf("PurgeRecords() failed: %v", err)
+	}
+	if len(result.Records) != 6 || result.Purged != 0 {
+		t.Errorf("dry run selected %d and purged %d, want 6 and 0", len(result.Records), result.Purged)
+	}
+	if len(repo.records) != 10 {
+		t.Errorf("remaining records = %d, want 10", len(repo.records))
+	}
+}
+
+// TestHistoryUseCase_PurgeRecords_Archive tests that purged records are archived with their time series.
+func TestHistoryUseCase_PurgeRecords_Archive(t *testing.T) {
+	repo := newRetentionTestRepo(5)
+	uc := NewHistoryUseCase(repo)
+	dir := t.TempDir()
+
+	result, err := uc.PurgeRecords(context.Background(), &PurgeOptions{KeepLatest: 2, ArchiveDir: dir})
+	if err != nil {
+		t.Fatalf("PurgeRecords() failed: %v", err)
+	}
+	if result.ArchivePath == "" {
+		t.Fatal("ArchivePath is empty")
+	}
+
+	file, err := os.Open(result.ArchivePath)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("archive is not gzip: %v", err)
+	}
+
+	var archive HistoryArchive
+	if err := json.NewDecoder(zr).Decode(&archive); err != nil {
+		t.Fatalf("decode archive: %v", err)
+	}
+	if len(archive.Records) != 3 {
+		t.Fatalf("archived %d records, want 3", len(archive.Records))
+	}
+	// Oldest first
+	if archive.Records[0].ID != "run-4" {
+		t.Errorf("first archived record = %s, want run-4", archive.Records[0].ID)
+	}
+	if len(archive.Records[0].TimeSeries) != 1 {
+		t.Errorf("archived time series length = %d, want 1", len(archive.Records[0].TimeSeries))
+	}
+}
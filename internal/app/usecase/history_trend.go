@@ -0,0 +1,42 @@
+// Package usecase provides trend analysis of recurring benchmark runs.
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// ListTrends returns the trends of the configurations that were run at least
+// history.MinTrendRuns times, most recently run first.
+func (uc *HistoryUseCase) ListTrends(ctx context.Context) ([]*history.Trend, error) {
+	records, err := uc.historyRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list history records: %w", err)
+	}
+	return history.GroupTrends(records, history.DefaultTrendWindow), nil
+}
+
+// TrendOf returns the trend of the configuration of record, including record
+// itself whether or not it has been saved to history yet.
+func (uc *HistoryUseCase) TrendOf(ctx context.Context, record *history.Record) (*history.Trend, error) {
+	records, err := uc.historyRepo.List(ctx, &repository.ListOptions{
+		ConnectionName: record.ConnectionName,
+		TemplateName:   record.TemplateName,
+		Threads:        record.Threads,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list history records: %w", err)
+	}
+
+	fingerprint := record.Fingerprint()
+	runs := []*history.Record{record}
+	for _, r := range records {
+		if r.ID != record.ID && r.Fingerprint() == fingerprint {
+			runs = append(runs, r)
+		}
+	}
+	return history.NewTrend(runs, history.DefaultTrendWindow), nil
+}
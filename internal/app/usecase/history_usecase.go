@@ -5,6 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
@@ -20,6 +25,10 @@ const (
 // HistoryUseCase provides history record business logic.
 type HistoryUseCase struct {
 	historyRepo repository.HistoryRepository
+	artifactDir string           // Directory in which kept run artifacts are stored
+	logRepo     RunLogRepository // Optional run log storage
+
+	sanityChecks func(ctx context.Context) ([]history.SanityCheck, error) // Optional sanity check source
 }
 
 // NewHistoryUseCase creates a new history use case.
@@ -29,12 +38,109 @@ func NewHistoryUseCase(historyRepo repository.HistoryRepository) *HistoryUseCase
 	}
 }
 
+// SetArtifactDir sets the directory in which kept run artifacts are stored.
+// Artifacts are removed together with their history record.
+func (uc *HistoryUseCase) SetArtifactDir(dir string) {
+	uc.artifactDir = dir
+}
+
+// SetLogRepository sets the repository holding the run logs.
+// Logs are removed together with their history record.
+func (uc *HistoryUseCase) SetLogRepository(repo RunLogRepository) {
+	uc.logRepo = repo
+}
+
 // SaveRunToHistory saves a completed benchmark run to history.
 func (uc *HistoryUseCase) SaveRunToHistory(ctx context.Context, run *execution.Run) error {
 	if run.Result == nil {
 		return nil // No result to save
 	}
 
+	record := recordFromRun(run)
+
+	// Sample time series if too large
+	record.TimeSeries = uc.sampleTimeSeries(record.TimeSeries, MaxTimeSeriesSize)
+
+	err := uc.historyRepo.Save(ctx, record)
+	if err != nil {
+		return err
+	}
+
+	// Verify save by reading back
+	saved, err := uc.historyRepo.GetByID(ctx, record.ID)
+	if err != nil {
+		return fmt.Errorf("saved but cannot verify: %w", err)
+	}
+	if saved == nil {
+		return fmt.Errorf("saved but GetByID returns nil")
+	}
+
+	// A run failing its sanity checks is still saved, only marked invalid
+	if _, err := uc.evaluateRecordChecks(ctx, saved); err != nil {
+		slog.Warn("History: Failed to evaluate sanity checks", "id", record.ID, "error", err)
+	}
+
+	return nil
+}
+
+// PreviewRecord returns the history record a completed run would be saved
+// as, for showing its details before it is saved. Returns nil without a result.
+func (uc *HistoryUseCase) PreviewRecord(run *execution.Run) *history.Record {
+	if run.Result == nil {
+		return nil
+	}
+	return recordFromRun(run)
+}
+
+// SaveStoppedRun saves a failed, cancelled or timed out run to history with
+// its state, so it can be investigated later. A run stopped before it
+// produced results is saved with the names of the event and no metrics.
+// Stopped runs are not evaluated by the sanity checks.
+func (uc *HistoryUseCase) SaveStoppedRun(ctx context.Context, event RunEvent) error {
+	run := event.Run
+	if !run.State.IsTerminal() || run.State == execution.StateCompleted {
+		return fmt.Errorf("run %s is %s, not stopped", run.ID, run.State)
+	}
+
+	var record *history.Record
+	if run.Result != nil {
+		record = recordFromRun(run)
+		record.TimeSeries = uc.sampleTimeSeries(record.TimeSeries, MaxTimeSeriesSize)
+	} else {
+		threads, _ := strconv.Atoi(run.Parameters["threads"])
+		record = &history.Record{
+			ID:             run.ID,
+			CreatedAt:      time.Now(),
+			ConnectionName: event.ConnectionName,
+			TemplateName:   event.TemplateName,
+			DatabaseType:   event.DatabaseType,
+			Threads:        threads,
+			StartTime:      run.CreatedAt,
+			SampleInterval: run.SampleInterval,
+			Parameters:     run.Parameters,
+			ConfigSnapshot: run.ConfigSnapshot,
+			Replication:    run.Replication,
+			ErrorClasses:   run.ErrorClasses,
+			Purpose:        run.Metadata.Purpose,
+			Ticket:         run.Metadata.Ticket,
+			Environment:    run.Metadata.Environment,
+		}
+		if run.StartedAt != nil {
+			record.StartTime = *run.StartedAt
+		}
+		if run.Duration != nil {
+			record.Duration = *run.Duration
+		}
+	}
+	record.State = string(run.State)
+	record.ErrorMessage = run.ErrorMessage
+
+	return uc.historyRepo.Save(ctx, record)
+}
+
+// recordFromRun converts the result of a run into a history record.
+// The run must have a result.
+func recordFromRun(run *execution.Run) *history.Record {
 	// Convert execution.MetricSample to history.MetricSample
 	timeSeries := make([]history.MetricSample, len(run.Result.TimeSeries))
 	for i, sample := range run.Result.TimeSeries {
@@ -51,11 +157,8 @@ func (uc *HistoryUseCase) SaveRunToHistory(ctx context.Context, run *execution.R
 		}
 	}
 
-	// Sample time series if too large
-	timeSeries = uc.sampleTimeSeries(timeSeries, MaxTimeSeriesSize)
-
 	// Create history record from run result
-	record := &history.Record{
+	return &history.Record{
 		ID:        run.ID,
 		CreatedAt: time.Now(),
 
@@ -64,13 +167,16 @@ func (uc *HistoryUseCase) SaveRunToHistory(ctx context.Context, run *execution.R
 		TemplateName:   run.Result.TemplateName,
 		DatabaseType:   run.Result.DatabaseType,
 		Threads:        run.Result.Threads,
+		Agents:         run.Result.Agents,
 
 		// Timing
-		StartTime: run.Result.StartTime,
-		Duration:  run.Result.Duration,
+		StartTime:      run.Result.StartTime,
+		Duration:       run.Result.Duration,
+		SampleInterval: run.Result.SampleInterval,
 
 		// Core metrics
 		TPSCalculated: run.Result.TPSCalculated,
+		TpmC:          run.Result.TpmC,
 
 		// Latency (ms)
 		LatencyAvg: run.Result.LatencyAvg,
@@ -89,6 +195,7 @@ func (uc *HistoryUseCase) SaveRunToHistory(ctx context.Context, run *execution.R
 
 		// Errors and Reconnects
 		IgnoredErrors: run.Result.IgnoredErrors,
+		ErrorClasses:  run.ErrorClasses,
 		Reconnects:    run.Result.Reconnects,
 
 		// General Statistics
@@ -103,23 +210,18 @@ func (uc *HistoryUseCase) SaveRunToHistory(ctx context.Context, run *execution.R
 
 		// Time Series Data
 		TimeSeries: timeSeries,
-	}
 
-	err := uc.historyRepo.Save(ctx, record)
-	if err != nil {
-		return err
-	}
+		Parameters:     run.Parameters,
+		ConfigSnapshot: run.ConfigSnapshot,
+		Replication:    run.Replication,
 
-	// Verify save by reading back
-	saved, err := uc.historyRepo.GetByID(ctx, record.ID)
-	if err != nil {
-		return fmt.Errorf("saved but cannot verify: %w", err)
-	}
-	if saved == nil {
-		return fmt.Errorf("saved but GetByID returns nil")
-	}
+		// Metadata
+		Purpose:     run.Metadata.Purpose,
+		Ticket:      run.Metadata.Ticket,
+		Environment: run.Metadata.Environment,
 
-	return nil
+		Assertions: run.AssertionOutcome,
+	}
 }
 
 // sampleTimeSeries samples time series data if it exceeds maxSize.
@@ -165,10 +267,101 @@ func (uc *HistoryUseCase) GetRecordByID(ctx context.Context, id string) (*histor
 
 // DeleteRecord deletes a history record by ID.
 func (uc *HistoryUseCase) DeleteRecord(ctx context.Context, id string) error {
-	return uc.historyRepo.Delete(ctx, id)
+	if err := uc.historyRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	uc.removeRunData(ctx, id)
+	return nil
+}
+
+// GetRunLogs retrieves the log entries of a history record's run matching filter, oldest first.
+func (uc *HistoryUseCase) GetRunLogs(ctx context.Context, id string, filter LogFilter) ([]LogEntry, error) {
+	if uc.logRepo == nil {
+		return []LogEntry{}, nil
+	}
+	return uc.logRepo.FindLogEntries(ctx, id, filter)
+}
+
+// ListArtifacts returns the artifacts kept for the run of a history record.
+// Returns nil if no artifacts were kept.
+func (uc *HistoryUseCase) ListArtifacts(id string) ([]ArtifactFile, error) {
+	if uc.artifactDir == "" {
+		return nil, nil
+	}
+	return ListRunArtifacts(uc.artifactDir, id)
+}
+
+// GetRunOutput returns the raw tool output of a history record's run: its
+// output log including rotated parts if captured, otherwise its stdout log entries.
+func (uc *HistoryUseCase) GetRunOutput(ctx context.Context, id string) (string, error) {
+	if uc.artifactDir != "" {
+		dir, err := RunArtifactDir(uc.artifactDir, id)
+		if err != nil {
+			return "", err
+		}
+		output, err := readRotatedLog(filepath.Join(dir, ArtifactOutputLog))
+		if err == nil {
+			return output, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", fmt.Errorf("read output log: %w", err)
+		}
+	}
+
+	entries, err := uc.GetRunLogs(ctx, id, LogFilter{Streams: []string{"stdout"}})
+	if err != nil {
+		return "", err
+	}
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		lines[i] = entry.Content
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// ArtifactDir returns the directory holding the artifacts of a history record's run.
+func (uc *HistoryUseCase) ArtifactDir(id string) string {
+	if uc.artifactDir == "" {
+		return ""
+	}
+	dir, _ := RunArtifactDir(uc.artifactDir, id)
+	return dir
+}
+
+// removeRunData deletes the logs and kept artifacts of a deleted record's run.
+func (uc *HistoryUseCase) removeRunData(ctx context.Context, id string) {
+	if uc.logRepo != nil {
+		if err := uc.logRepo.DeleteLogEntries(ctx, id); err != nil {
+			slog.Warn("History: Failed to remove run logs", "id", id, "error", err)
+		}
+	}
+	if uc.artifactDir != "" {
+		if err := removeRunArtifacts(uc.artifactDir, id); err != nil {
+			slog.Warn("History: Failed to remove run artifacts", "id", id, "error", err)
+		}
+	}
 }
 
 // ListRecords retrieves history records with options.
 func (uc *HistoryUseCase) ListRecords(ctx context.Context, opts *repository.ListOptions) ([]*history.Record, error) {
 	return uc.historyRepo.List(ctx, opts)
 }
+
+// CountRecords returns the number of history records matching the filters in opts.
+func (uc *HistoryUseCase) CountRecords(ctx context.Context, opts *repository.ListOptions) (int, error) {
+	return uc.historyRepo.Count(ctx, opts)
+}
+
+// UpdateAnnotations sets the tags and notes of a history record.
+// Tags are trimmed and de-duplicated before saving.
+func (uc *HistoryUseCase) UpdateAnnotations(ctx context.Context, id string, tags []string, notes string) error {
+	tags = history.NormalizeTags(tags)
+	notes = strings.TrimSpace(notes)
+
+	if err := uc.historyRepo.UpdateAnnotations(ctx, id, tags, notes); err != nil {
+		return fmt.Errorf("update annotations: %w", err)
+	}
+
+	slog.Info("History: Annotations updated", "id", id, "tags", tags)
+	return nil
+}
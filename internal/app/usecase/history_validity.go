@@ -0,0 +1,109 @@
+// Package usecase provides history sanity check logic.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// SetSanityChecks sets the source of the sanity checks evaluated on every run
+// saved to history. The checks are re-read before every evaluation so settings
+// changes take effect without a restart.
+func (uc *HistoryUseCase) SetSanityChecks(checks func(ctx context.Context) ([]history.SanityCheck, error)) {
+	uc.sanityChecks = checks
+}
+
+// EvaluateRecord evaluates the sanity checks on a history record and saves the
+// outcome. Runs of a repetition series are checked against the other runs of
+// the series. Without any configured checks the record is left unchecked, which
+// counts as valid.
+func (uc *HistoryUseCase) EvaluateRecord(ctx context.Context, id string) (*history.Validity, error) {
+	record, err := uc.historyRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get history record: %w", err)
+	}
+	return uc.evaluateRecordChecks(ctx, record)
+}
+
+// EvaluateAllRecords re-evaluates the sanity checks on every history record,
+// e.g. after the checks changed. It returns the number of invalid records.
+func (uc *HistoryUseCase) EvaluateAllRecords(ctx context.Context) (int, error) {
+	checks, err := uc.loadSanityChecks(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	records, err := uc.historyRepo.GetAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("list history records: %w", err)
+	}
+
+	invalid := 0
+	for _, record := range records {
+		validity, err := uc.evaluateRecord(ctx, record, checks)
+		if err != nil {
+			return invalid, err
+		}
+		if validity != nil && !validity.Valid {
+			invalid++
+		}
+	}
+
+	slog.Info("History: Sanity checks evaluated", "records", len(records), "invalid", invalid, "checks", len(checks))
+	return invalid, nil
+}
+
+// evaluateRecordChecks loads the configured checks and evaluates them on record.
+func (uc *HistoryUseCase) evaluateRecordChecks(ctx context.Context, record *history.Record) (*history.Validity, error) {
+	checks, err := uc.loadSanityChecks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return uc.evaluateRecord(ctx, record, checks)
+}
+
+// loadSanityChecks returns the configured sanity checks, if any.
+func (uc *HistoryUseCase) loadSanityChecks(ctx context.Context) ([]history.SanityCheck, error) {
+	if uc.sanityChecks == nil {
+		return nil, nil
+	}
+	checks, err := uc.sanityChecks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load sanity checks: %w", err)
+	}
+	return checks, nil
+}
+
+// evaluateRecord evaluates checks on record and saves the outcome.
+// Runs that did not complete have no results and are not checked.
+func (uc *HistoryUseCase) evaluateRecord(ctx context.Context, record *history.Record, checks []history.SanityCheck) (*history.Validity, error) {
+	var validity *history.Validity
+	if len(checks) > 0 && record.IsCompleted() {
+		var repeats []*history.Record
+		if tag := record.RepeatTag(); tag != "" {
+			var err error
+			repeats, err = uc.historyRepo.List(ctx, &repository.ListOptions{Tags: []string{tag}})
+			if err != nil {
+				return nil, fmt.Errorf("list repetitions: %w", err)
+			}
+		}
+		validity = history.EvaluateChecks(record, checks, repeats)
+	}
+
+	if validity == nil && record.Validity == nil {
+		return nil, nil
+	}
+	if err := uc.historyRepo.UpdateValidity(ctx, record.ID, validity); err != nil {
+		return nil, fmt.Errorf("update validity: %w", err)
+	}
+	record.Validity = validity
+
+	if validity != nil && !validity.Valid {
+		slog.Info("History: Run failed sanity checks", "id", record.ID, "failed", validity.Failed())
+	}
+	return validity, nil
+}
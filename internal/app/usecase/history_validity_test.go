@@ -0,0 +1,112 @@
+// Package usecase provides unit tests for history sanity checks and stopped runs.
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// TestHistoryUseCase_EvaluateRecord tests marking runs invalid and checking repetitions against each other.
+func TestHistoryUseCase_EvaluateRecord(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockHistoryRepository()
+	uc := NewHistoryUseCase(repo)
+
+	// Without checks records stay unchecked
+	repo.records["single"] = &history.Record{ID: "single", Duration: 10 * time.Second, TPSCalculated: 1000}
+	if v, err := uc.EvaluateRecord(ctx, "single"); err != nil || v != nil {
+		t.Fatalf("EvaluateRecord(no checks) = %v, %v, want nil", v, err)
+	}
+
+	checks := []history.SanityCheck{
+		{Name: "long enough", Kind: history.CheckMinDuration, Threshold: 60},
+		{Name: "stable", Kind: history.CheckMaxCV, Threshold: 5},
+	}
+	uc.SetSanityChecks(func(ctx context.Context) ([]history.SanityCheck, error) { return checks, nil })
+
+	for i, tps := range []float64{1000, 1010, 700} {
+		id := []string{"rep-1", "rep-2", "rep-3"}[i]
+		repo.records[id] = &history.Record{ID: id, Duration: time.Minute, TPSCalculated: tps, Tags: []string{"repeat:agg-1"}}
+	}
+
+	invalid, err := uc.EvaluateAllRecords(ctx)
+	if err != nil {
+		t.Fatalf("EvaluateAllRecords() failed: %v", err)
+	}
+	if invalid != 4 {
+		t.Errorf("EvaluateAllRecords() = %d invalid, want 4", invalid)
+	}
+
+	single := repo.records["single"]
+	if failed := single.Validity.Failed(); len(failed) != 1 || failed[0] != "long enough" {
+		t.Errorf("single failed checks = %v, want [long enough]", failed)
+	}
+	if r := single.Validity.Results[1]; !r.Passed || r.Details != "not repeated" {
+		t.Errorf("single CV result = %+v, want passed, not repeated", r)
+	}
+	if rep := repo.records["rep-1"]; rep.IsValid() || rep.Validity.Results[1].Details != "3 runs" {
+		t.Errorf("rep-1 validity = %+v, want CV over 3 runs failed", rep.Validity)
+	}
+
+	valid, _ := repo.List(ctx, &repository.ListOptions{ValidOnly: true})
+	if len(valid) != 0 {
+		t.Errorf("List(ValidOnly) returned %d records, want 0", len(valid))
+	}
+
+	// Removing the checks clears the outcome
+	checks = nil
+	if _, err := uc.EvaluateAllRecords(ctx); err != nil {
+		t.Fatalf("EvaluateAllRecords() failed: %v", err)
+	}
+	if !repo.records["rep-1"].IsValid() || repo.records["rep-1"].Validity != nil {
+		t.Errorf("rep-1 validity = %+v, want unchecked", repo.records["rep-1"].Validity)
+	}
+}
+
+// TestHistoryUseCase_SaveStoppedRun tests saving failed runs with their state, unchecked.
+func TestHistoryUseCase_SaveStoppedRun(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockHistoryRepository()
+	uc := NewHistoryUseCase(repo)
+	uc.SetSanityChecks(func(ctx context.Context) ([]history.SanityCheck, error) {
+		return []history.SanityCheck{{Name: "long enough", Kind: history.CheckMinDuration, Threshold: 60}}, nil
+	})
+
+	started := time.Now().Add(-time.Minute)
+	run := &execution.Run{
+		ID:           "failed-1",
+		State:        execution.StateFailed,
+		StartedAt:    &started,
+		ErrorMessage: "sysbench exited with status 1",
+		Parameters:   map[string]string{"threads": "16"},
+		Metadata:     execution.RunMetadata{Purpose: "Index change", Ticket: "CHG-1042", Environment: "prod-replica"},
+	}
+	event := RunEvent{Run: run, ConnectionName: "prod", TemplateName: "oltp_read_write", DatabaseType: "mysql"}
+	if err := uc.SaveStoppedRun(ctx, event); err != nil {
+		t.Fatalf("SaveStoppedRun() failed: %v", err)
+	}
+
+	record := repo.records["failed-1"]
+	if record == nil || record.IsCompleted() || record.State != "failed" || record.ErrorMessage != run.ErrorMessage {
+		t.Fatalf("saved record = %+v, want failed with its error", record)
+	}
+	if record.ConnectionName != "prod" || record.Threads != 16 || !record.StartTime.Equal(started) {
+		t.Errorf("saved record = %+v, want names, threads and start time of the run", record)
+	}
+	if record.Purpose != "Index change" || record.Ticket != "CHG-1042" || record.Environment != "prod-replica" {
+		t.Errorf("saved metadata = %q, %q, %q, want the metadata of the run", record.Purpose, record.Ticket, record.Environment)
+	}
+	if _, err := uc.EvaluateAllRecords(ctx); err != nil || record.Validity != nil {
+		t.Errorf("stopped run validity = %+v, %v, want unchecked", record.Validity, err)
+	}
+
+	run.State = execution.StateCompleted
+	if err := uc.SaveStoppedRun(ctx, event); err == nil {
+		t.Error("SaveStoppedRun(completed) succeeded, want error")
+	}
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// RedactLogAttr is a slog.HandlerOptions.ReplaceAttr that keeps secrets out
+// of the logs: values of attributes named like a secret or a DSN (e.g.
+// "password", "mysql_pwd", "dsn") are masked, and passwords in other text,
+// lists of text and errors (e.g. "password=..." or "user:pass@tcp(...)" in a
+// connection error) are redacted. Flags such as "has_password" are kept.
+func RedactLogAttr(groups []string, a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindString:
+		if isSecretLogKey(a.Key) {
+			if a.Value.String() != "" {
+				a.Value = slog.StringValue(maskedSecret)
+			}
+			return a
+		}
+		if redacted := redactSecrets(a.Value.String()); redacted != a.Value.String() {
+			a.Value = slog.StringValue(redacted)
+		}
+	case slog.KindAny:
+		switch v := a.Value.Any().(type) {
+		case error:
+			if redacted := redactSecrets(v.Error()); redacted != v.Error() {
+				a.Value = slog.StringValue(redacted)
+			}
+		case []string: // e.g. arguments or environment variables
+			redacted := make([]string, len(v))
+			for i, s := range v {
+				redacted[i] = redactSecrets(s)
+			}
+			a.Value = slog.AnyValue(redacted)
+		default:
+			if isSecretLogKey(a.Key) {
+				a.Value = slog.StringValue(maskedSecret)
+			}
+		}
+	}
+	return a
+}
+
+// isSecretLogKey reports whether a log attribute key names a secret or a
+// DSN, which holds the password.
+func isSecretLogKey(key string) bool {
+	return isSecretName(key) || strings.Contains(strings.ToUpper(key), "DSN")
+}
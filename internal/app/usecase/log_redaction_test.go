@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRedactLogAttr(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: RedactLogAttr}))
+
+	logger.Info("Oracle: Generated DSN",
+		"dsn", "oracle://soe:hunter2@db:1521/ORCL",
+		"password", "hunter3",
+		"env", []string{"MYSQL_PWD=x"},
+		"mysql_pwd", "hunter4",
+		"password_set", true,
+		"error", errors.New("open root:hunter5@tcp(db:3306)/sbtest: connection refused"),
+		"line", "pq: password=hunter6 host=db",
+		"host", "db")
+	logger.WithGroup("ssh").Info("Tunnel", "secret", "hunter7")
+
+	out := buf.String()
+	for _, secret := range []string{"hunter2", "hunter3", "hunter4", "hunter5", "hunter6", "hunter7"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log contains %q:\n%s", secret, out)
+		}
+	}
+	for _, kept := range []string{`"password_set":true`, `"host":"db"`, `"env":["MYSQL_PWD=*****"]`, "connection refused", "Generated DSN"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("log lacks %s:\n%s", kept, out)
+		}
+	}
+}
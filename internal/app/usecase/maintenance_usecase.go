@@ -0,0 +1,90 @@
+// Package usecase provides database maintenance business logic.
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database"
+)
+
+// MaintenanceResult holds the outcome of a database compaction.
+type MaintenanceResult struct {
+	RecordsCompacted int   // History records whose stored data was recompressed
+	SizeBefore       int64 // Database size before compaction (bytes)
+	SizeAfter        int64 // Database size after compaction (bytes)
+}
+
+// Reclaimed returns the space reclaimed in bytes.
+func (r *MaintenanceResult) Reclaimed() int64 {
+	if r.SizeAfter >= r.SizeBefore {
+		return 0
+	}
+	return r.SizeBefore - r.SizeAfter
+}
+
+// MaintenanceUseCase provides database maintenance business logic.
+type MaintenanceUseCase struct {
+	db          *sql.DB
+	dbPath      string
+	historyRepo repository.HistoryRepository
+}
+
+// NewMaintenanceUseCase creates a new maintenance use case.
+func NewMaintenanceUseCase(db *sql.DB, dbPath string, historyRepo repository.HistoryRepository) *MaintenanceUseCase {
+	return &MaintenanceUseCase{
+		db:          db,
+		dbPath:      dbPath,
+		historyRepo: historyRepo,
+	}
+}
+
+// CompactDatabase recompresses stored history records and then VACUUMs the
+// database so the freed pages are returned to the file system.
+func (uc *MaintenanceUseCase) CompactDatabase(ctx context.Context) (*MaintenanceResult, error) {
+	before, err := database.DatabaseSize(uc.dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	compacted, err := uc.historyRepo.CompactRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("compact history records: %w", err)
+	}
+
+	vacuum, err := database.Vacuum(ctx, uc.db, uc.dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MaintenanceResult{
+		RecordsCompacted: compacted,
+		SizeBefore:       before,
+		SizeAfter:        vacuum.SizeAfter,
+	}
+
+	slog.Info("Maintenance: Database compacted",
+		"records_compacted", compacted,
+		"size_before", result.SizeBefore,
+		"size_after", result.SizeAfter,
+		"reclaimed", result.Reclaimed())
+
+	return result, nil
+}
+
+// FormatBytes formats a byte count for display (e.g. "1.5 MB").
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
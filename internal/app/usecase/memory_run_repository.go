@@ -95,10 +95,35 @@ func (r *MemoryRunRepository) GetMetricSamples(ctx context.Context, runID string
 func (r *MemoryRunRepository) SaveLogEntry(ctx context.Context, runID string, entry LogEntry) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	entry.ID = int64(len(r.logs[runID]) + 1)
 	r.logs[runID] = append(r.logs[runID], entry)
 	return nil
 }
 
+// FindLogEntries returns the log entries of a run matching filter, oldest first.
+func (r *MemoryRunRepository) FindLogEntries(ctx context.Context, runID string, filter LogFilter) ([]LogEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entries := []LogEntry{}
+	for _, entry := range r.logs[runID] {
+		if filter.Match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	if filter.Tail > 0 && len(entries) > filter.Tail {
+		entries = entries[len(entries)-filter.Tail:]
+	}
+	return entries, nil
+}
+
+// DeleteLogEntries deletes all log entries of a run.
+func (r *MemoryRunRepository) DeleteLogEntries(ctx context.Context, runID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.logs, runID)
+	return nil
+}
+
 // Delete deletes a run by its ID.
 func (r *MemoryRunRepository) Delete(ctx context.Context, id string) error {
 	r.mu.Lock()
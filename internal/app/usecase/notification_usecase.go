@@ -0,0 +1,359 @@
+// Package usecase provides run notification business logic.
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/keyring"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/notify"
+)
+
+// NotificationPasswordKey is the keyring key of the SMTP password.
+const NotificationPasswordKey = "notification:smtp"
+
+// NotificationUseCase sends email and webhook notifications about benchmark runs.
+type NotificationUseCase struct {
+	settingsUC *SettingsUseCase
+	keyring    keyring.Provider
+	mailer     notify.Mailer
+	poster     notify.WebhookPoster // Optional; without it webhooks are not fired
+	exportUC   *ExportUseCase       // Optional; exports the report attached to notifications
+	historyUC  *HistoryUseCase      // Optional; without it trend alerts are not checked
+}
+
+// NewNotificationUseCase creates a new notification use case.
+func NewNotificationUseCase(settingsUC *SettingsUseCase, keyring keyring.Provider, mailer notify.Mailer) *NotificationUseCase {
+	return &NotificationUseCase{
+		settingsUC: settingsUC,
+		keyring:    keyring,
+		mailer:     mailer,
+	}
+}
+
+// SetExportUseCase sets the export use case used to produce report attachments.
+func (uc *NotificationUseCase) SetExportUseCase(exportUC *ExportUseCase) {
+	uc.exportUC = exportUC
+}
+
+// SetHistoryUseCase sets the history the trend of completed runs is checked against.
+func (uc *NotificationUseCase) SetHistoryUseCase(historyUC *HistoryUseCase) {
+	uc.historyUC = historyUC
+}
+
+// SetWebhookPoster sets the poster used to fire webhooks.
+func (uc *NotificationUseCase) SetWebhookPoster(poster notify.WebhookPoster) {
+	uc.poster = poster
+}
+
+// SetPassword stores the SMTP password in the keyring.
+// An empty password removes the stored one.
+func (uc *NotificationUseCase) SetPassword(ctx context.Context, password string) error {
+	if password == "" {
+		if err := uc.keyring.Delete(ctx, NotificationPasswordKey); err != nil && !keyring.IsNotFound(err) {
+			return fmt.Errorf("delete smtp password: %w", err)
+		}
+		return nil
+	}
+	if err := uc.keyring.Set(ctx, NotificationPasswordKey, password); err != nil {
+		return fmt.Errorf("save smtp password: %w", err)
+	}
+	return nil
+}
+
+// HasPassword checks if an SMTP password is stored.
+func (uc *NotificationUseCase) HasPassword(ctx context.Context) bool {
+	_, err := uc.keyring.Get(ctx, NotificationPasswordKey)
+	return err == nil
+}
+
+// NotifyRunStarted fires the webhooks subscribed to started runs.
+func (uc *NotificationUseCase) NotifyRunStarted(ctx context.Context, event RunEvent) error {
+	return uc.fireWebhooks(ctx, config.WebhookEventStarted, event)
+}
+
+// NotifyRunFinished fires the webhooks subscribed to the outcome of a finished
+// run and sends an email if the notification settings ask for it.
+func (uc *NotificationUseCase) NotifyRunFinished(ctx context.Context, event RunEvent) error {
+	var lifecycle string
+	switch event.Run.State {
+	case execution.StateCompleted:
+		lifecycle = config.WebhookEventCompleted
+	case execution.StateFailed, execution.StateTimeout:
+		lifecycle = config.WebhookEventFailed
+	case execution.StateCancelled, execution.StateForceStopped:
+		lifecycle = config.WebhookEventStopped
+	default:
+		return nil
+	}
+
+	err := errors.Join(uc.fireWebhooks(ctx, lifecycle, event), uc.sendRunEmail(ctx, lifecycle, event))
+	if lifecycle == config.WebhookEventCompleted {
+		err = errors.Join(err, uc.checkTrend(ctx, event))
+	}
+	return err
+}
+
+// checkTrend fires the trend_alert webhooks if a completed run deviates from
+// the earlier runs with the same configuration by more than the configured
+// threshold. The run need not be saved to history yet.
+func (uc *NotificationUseCase) checkTrend(ctx context.Context, event RunEvent) error {
+	if uc.historyUC == nil || uc.poster == nil || event.Run.Result == nil {
+		return nil
+	}
+	cfg, err := uc.settingsUC.GetHistoryConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get history config: %w", err)
+	}
+	if cfg.TrendAlertPercent <= 0 {
+		return nil
+	}
+
+	trend, err := uc.historyUC.TrendOf(ctx, recordFromRun(event.Run))
+	if err != nil {
+		return fmt.Errorf("get trend: %w", err)
+	}
+	dev, ok := trend.LatestDeviation()
+	if !ok || dev.RecordID != event.Run.ID || !dev.Exceeds(cfg.TrendAlertPercent) {
+		return nil
+	}
+	slog.Warn("Notification: Run deviates from its trend", "run_id", event.Run.ID, "fingerprint", trend.Fingerprint,
+		"tps_percent", dev.TPSPercent, "latency_p95_percent", dev.LatencyP95Percent, "threshold", cfg.TrendAlertPercent)
+
+	payload := webhookEvent(config.WebhookEventTrendAlert, event)
+	payload.Trend = &notify.WebhookTrend{
+		Fingerprint:       trend.Fingerprint,
+		Baseline:          dev.Baseline,
+		TPSPercent:        dev.TPSPercent,
+		LatencyP95Percent: dev.LatencyP95Percent,
+		Threshold:         cfg.TrendAlertPercent,
+	}
+	return uc.postWebhooks(ctx, payload)
+}
+
+// sendRunEmail emails the summary of a finished run. Stopped runs are never emailed.
+func (uc *NotificationUseCase) sendRunEmail(ctx context.Context, lifecycle string, event RunEvent) error {
+	cfg, err := uc.settingsUC.GetNotificationConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get notification config: %w", err)
+	}
+	if !cfg.Enabled {
+		return nil
+	}
+	switch lifecycle {
+	case config.WebhookEventCompleted:
+		if !cfg.OnSuccess {
+			return nil
+		}
+	case config.WebhookEventFailed:
+		if !cfg.OnFailure {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	run := event.Run
+	msg := &notify.Message{
+		From:    cfg.From,
+		To:      cfg.To,
+		Subject: fmt.Sprintf("[DB-BenchMind] Benchmark %s: %s on %s", lifecycle, event.TemplateName, event.ConnectionName),
+		Body:    runSummary(event),
+	}
+
+	if cfg.AttachReport && run.Result != nil && uc.exportUC != nil {
+		if path, err := uc.exportUC.ExportRecord(ctx, recordFromRun(run), FormatMarkdown); err != nil {
+			slog.Warn("Notification: Failed to export report", "run_id", run.ID, "error", err)
+		} else if data, err := os.ReadFile(path); err != nil {
+			slog.Warn("Notification: Failed to read report", "path", path, "error", err)
+		} else {
+			msg.Body += fmt.Sprintf("\nThe full report is attached and saved at:\n%s\n", path)
+			msg.Attachments = append(msg.Attachments, notify.Attachment{
+				Name:        filepath.Base(path),
+				ContentType: "text/markdown",
+				Data:        data,
+			})
+		}
+	}
+
+	if err := uc.send(ctx, cfg, msg); err != nil {
+		return err
+	}
+	slog.Info("Notification: Run notification sent", "run_id", run.ID, "state", run.State, "recipients", len(cfg.To))
+	return nil
+}
+
+// SendTestEmail sends a test message with the given settings, which need not be saved yet.
+func (uc *NotificationUseCase) SendTestEmail(ctx context.Context, cfg config.NotificationConfig) error {
+	cfg.Enabled = true
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	return uc.send(ctx, &cfg, &notify.Message{
+		From:    cfg.From,
+		To:      cfg.To,
+		Subject: "[DB-BenchMind] Test notification",
+		Body:    "This is a test message from DB-BenchMind. Email notifications are set up correctly.\n",
+	})
+}
+
+// fireWebhooks posts event to the enabled webhooks subscribed to lifecycle.
+func (uc *NotificationUseCase) fireWebhooks(ctx context.Context, lifecycle string, event RunEvent) error {
+	return uc.postWebhooks(ctx, webhookEvent(lifecycle, event))
+}
+
+// postWebhooks posts payload to the enabled webhooks subscribed to its event.
+func (uc *NotificationUseCase) postWebhooks(ctx context.Context, payload *notify.WebhookEvent) error {
+	if uc.poster == nil {
+		return nil
+	}
+	webhooks, err := uc.settingsUC.GetWebhooks(ctx)
+	if err != nil {
+		return fmt.Errorf("get webhooks: %w", err)
+	}
+
+	var errs []error
+	for i := range webhooks {
+		wh := &webhooks[i]
+		if !wh.Fires(payload.Event) {
+			continue
+		}
+		if err := uc.postWebhook(ctx, wh, payload); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		slog.Info("Notification: Webhook fired", "webhook", wh.Name, "event", payload.Event, "run_id", payload.RunID)
+	}
+	return errors.Join(errs...)
+}
+
+// TestWebhook posts a sample completed run event to a webhook, which need not be saved yet.
+func (uc *NotificationUseCase) TestWebhook(ctx context.Context, wh config.WebhookConfig) error {
+	if err := wh.Validate(); err != nil {
+		return err
+	}
+	if uc.poster == nil {
+		return fmt.Errorf("webhooks are not available")
+	}
+
+	return uc.postWebhook(ctx, &wh, &notify.WebhookEvent{
+		Event:      config.WebhookEventCompleted,
+		RunID:      "test",
+		State:      string(execution.StateCompleted),
+		Template:   "DB-BenchMind test notification",
+		Connection: "test",
+		Timestamp:  time.Now(),
+	})
+}
+
+// postWebhook renders the payload of a webhook and posts it.
+func (uc *NotificationUseCase) postWebhook(ctx context.Context, wh *config.WebhookConfig, event *notify.WebhookEvent) error {
+	payload, err := notify.BuildWebhookPayload(wh.Format, wh.Template, event)
+	if err != nil {
+		return fmt.Errorf("webhook %s: %w", wh.Name, err)
+	}
+	if err := uc.poster.Post(ctx, wh.URL, payload); err != nil {
+		return fmt.Errorf("webhook %s: %w", wh.Name, err)
+	}
+	return nil
+}
+
+// webhookEvent converts a run event into the webhook payload data.
+func webhookEvent(lifecycle string, event RunEvent) *notify.WebhookEvent {
+	run := event.Run
+	e := &notify.WebhookEvent{
+		Event:      lifecycle,
+		RunID:      run.ID,
+		State:      string(run.State),
+		Template:   event.TemplateName,
+		Connection: event.ConnectionName,
+		Timestamp:  time.Now(),
+		Error:      run.ErrorMessage,
+	}
+	if run.Duration != nil {
+		e.Duration = run.Duration.Round(time.Second).String()
+	}
+	if r := run.Result; r != nil {
+		e.Metrics = &notify.WebhookMetrics{
+			TPS:        r.TPSCalculated,
+			QPS:        resultQPS(r),
+			LatencyAvg: r.LatencyAvg,
+			LatencyP95: r.LatencyP95,
+			LatencyP99: r.LatencyP99,
+			Errors:     r.ErrorCount,
+			Reconnects: r.Reconnects,
+		}
+	}
+	return e
+}
+
+// resultQPS returns the average queries per second of a result.
+func resultQPS(r *execution.BenchmarkResult) float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.TotalQueries) / r.Duration.Seconds()
+}
+
+// send delivers msg through the configured SMTP server.
+func (uc *NotificationUseCase) send(ctx context.Context, cfg *config.NotificationConfig, msg *notify.Message) error {
+	smtpCfg := notify.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Security: cfg.Security,
+		Username: cfg.Username,
+	}
+	if cfg.Username != "" {
+		password, err := uc.keyring.Get(ctx, NotificationPasswordKey)
+		if err != nil {
+			return fmt.Errorf("get smtp password: %w", err)
+		}
+		smtpCfg.Password = password
+	}
+
+	if err := uc.mailer.Send(ctx, smtpCfg, msg); err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	return nil
+}
+
+// runSummary renders the plain text body of a run notification.
+func runSummary(event RunEvent) string {
+	run := event.Run
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Template:   %s\n", event.TemplateName)
+	fmt.Fprintf(&b, "Connection: %s\n", event.ConnectionName)
+	fmt.Fprintf(&b, "Run ID:     %s\n", run.ID)
+	fmt.Fprintf(&b, "State:      %s\n", run.State)
+	if run.StartedAt != nil {
+		fmt.Fprintf(&b, "Started:    %s\n", run.StartedAt.Format(time.DateTime))
+	}
+	if run.Duration != nil {
+		fmt.Fprintf(&b, "Duration:   %s\n", run.Duration.Round(time.Second))
+	}
+	if run.ErrorMessage != "" {
+		fmt.Fprintf(&b, "Error:      %s\n", run.ErrorMessage)
+	}
+
+	if r := run.Result; r != nil {
+		b.WriteString("\nSummary metrics\n")
+		fmt.Fprintf(&b, "  TPS:           %.2f\n", r.TPSCalculated)
+		fmt.Fprintf(&b, "  QPS:           %.2f\n", resultQPS(r))
+		fmt.Fprintf(&b, "  Latency avg:   %.2f ms\n", r.LatencyAvg)
+		fmt.Fprintf(&b, "  Latency p95:   %.2f ms\n", r.LatencyP95)
+		fmt.Fprintf(&b, "  Latency p99:   %.2f ms\n", r.LatencyP99)
+		fmt.Fprintf(&b, "  Errors:        %d\n", r.ErrorCount)
+		fmt.Fprintf(&b, "  Reconnects:    %d\n", r.Reconnects)
+	}
+
+	return b.String()
+}
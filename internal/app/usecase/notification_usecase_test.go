@@ -0,0 +1,264 @@
+// Package usecase provides unit tests for email notifications.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/notify"
+)
+
+// mockMailer records the messages it is asked to send.
+type mockMailer struct {
+	configs  []notify.SMTPConfig
+	messages []*notify.Message
+}
+
+func (m *mockMailer) Send(ctx context.Context, cfg notify.SMTPConfig, msg *notify.Message) error {
+	m.configs = append(m.configs, cfg)
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// TestNotificationUseCase_NotifyRunFinished tests which runs are notified and what is sent.
+func TestNotificationUseCase_NotifyRunFinished(t *testing.T) {
+	ctx := context.Background()
+	settingsUC := NewSettingsUseCase(newMockSettingsRepository(filepath.Join(t.TempDir(), "config.json")), nil)
+	notifyCfg := config.NotificationConfig{
+		Enabled:      true,
+		SMTPHost:     "smtp.example.com",
+		SMTPPort:     587,
+		Security:     config.SMTPSecurityStartTLS,
+		Username:     "bench",
+		From:         "bench@example.com",
+		To:           []string{"dba@example.com"},
+		OnSuccess:    true,
+		OnFailure:    true,
+		AttachReport: true,
+	}
+	if err := settingsUC.UpdateNotificationConfig(ctx, notifyCfg); err != nil {
+		t.Fatalf("UpdateNotificationConfig() failed: %v", err)
+	}
+
+	mailer := &mockMailer{}
+	uc := NewNotificationUseCase(settingsUC, NewMockKeyring(), mailer)
+	uc.SetExportUseCase(NewExportUseCase(t.TempDir()))
+	if err := uc.SetPassword(ctx, "secret"); err != nil {
+		t.Fatalf("SetPassword() failed: %v", err)
+	}
+
+	duration := 5 * time.Minute
+	completed := RunEvent{
+		Run: &execution.Run{
+			ID:       "run-1",
+			State:    execution.StateCompleted,
+			Duration: &duration,
+			Result: &execution.BenchmarkResult{
+				RunID:         "run-1",
+				TPSCalculated: 1234.5,
+				LatencyP95:    12.5,
+				TemplateName:  "OLTP Read Write",
+				StartTime:     time.Now(),
+			},
+		},
+		ConnectionName: "mysql-prod",
+		TemplateName:   "OLTP Read Write",
+	}
+	if err := uc.NotifyRunFinished(ctx, completed); err != nil {
+		t.Fatalf("NotifyRunFinished(completed) failed: %v", err)
+	}
+	if len(mailer.messages) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(mailer.messages))
+	}
+	msg := mailer.messages[0]
+	if msg.Subject != "[DB-BenchMind] Benchmark completed: OLTP Read Write on mysql-prod" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if !strings.Contains(msg.Body, "TPS:           1234.50") || !strings.Contains(msg.Body, "Latency p95:   12.50 ms") {
+		t.Errorf("Body does not contain the summary metrics:\n%s", msg.Body)
+	}
+	if len(msg.Attachments) != 1 || !strings.HasSuffix(msg.Attachments[0].Name, ".md") {
+		t.Errorf("Attachments = %v, want the markdown report", msg.Attachments)
+	}
+	if mailer.configs[0].Password != "secret" {
+		t.Errorf("Password = %q, want the keyring password", mailer.configs[0].Password)
+	}
+
+	// Cancelled runs are never notified
+	cancelled := RunEvent{Run: &execution.Run{ID: "run-2", State: execution.StateCancelled}}
+	if err := uc.NotifyRunFinished(ctx, cancelled); err != nil {
+		t.Fatalf("NotifyRunFinished(cancelled) failed: %v", err)
+	}
+
+	// Failures are not notified once turned off
+	notifyCfg.OnFailure = false
+	if err := settingsUC.UpdateNotificationConfig(ctx, notifyCfg); err != nil {
+		t.Fatalf("UpdateNotificationConfig() failed: %v", err)
+	}
+	failed := RunEvent{Run: &execution.Run{ID: "run-3", State: execution.StateFailed, ErrorMessage: "connection refused"}}
+	if err := uc.NotifyRunFinished(ctx, failed); err != nil {
+		t.Fatalf("NotifyRunFinished(failed) failed: %v", err)
+	}
+
+	if len(mailer.messages) != 1 {
+		t.Errorf("sent %d messages, want 1", len(mailer.messages))
+	}
+}
+
+// mockWebhookPoster records the payloads it is asked to post.
+type mockWebhookPoster struct {
+	urls     []string
+	payloads []string
+}
+
+func (m *mockWebhookPoster) Post(ctx context.Context, url string, payload []byte) error {
+	m.urls = append(m.urls, url)
+	m.payloads = append(m.payloads, string(payload))
+	return nil
+}
+
+// TestNotificationUseCase_Webhooks tests that webhooks fire on their subscribed lifecycle events.
+func TestNotificationUseCase_Webhooks(t *testing.T) {
+	ctx := context.Background()
+	settingsUC := NewSettingsUseCase(newMockSettingsRepository(filepath.Join(t.TempDir(), "config.json")), nil)
+	webhooks := []config.WebhookConfig{
+		{
+			Name:    "slack",
+			Enabled: true,
+			URL:     "https://hooks.slack.com/services/T000/B000/XXXX",
+			Format:  config.WebhookFormatSlack,
+			Events:  []string{config.WebhookEventCompleted, config.WebhookEventFailed},
+		},
+		{
+			Name:     "ci",
+			Enabled:  true,
+			URL:      "https://ci.example.com/hook",
+			Format:   config.WebhookFormatGeneric,
+			Template: `{"run":"{{.RunID}}","event":"{{.Event}}"}`,
+			Events:   config.WebhookEvents,
+		},
+		{
+			Name:    "disabled",
+			URL:     "https://example.com/hook",
+			Format:  config.WebhookFormatTeams,
+			Events:  config.WebhookEvents,
+			Enabled: false,
+		},
+	}
+	if err := settingsUC.UpdateWebhooks(ctx, webhooks); err != nil {
+		t.Fatalf("UpdateWebhooks() failed: %v", err)
+	}
+
+	poster := &mockWebhookPoster{}
+	uc := NewNotificationUseCase(settingsUC, NewMockKeyring(), &mockMailer{})
+	uc.SetWebhookPoster(poster)
+
+	started := RunEvent{Run: &execution.Run{ID: "run-1", State: execution.StatePending}, TemplateName: "OLTP", ConnectionName: "mysql"}
+	if err := uc.NotifyRunStarted(ctx, started); err != nil {
+		t.Fatalf("NotifyRunStarted() failed: %v", err)
+	}
+	stopped := RunEvent{Run: &execution.Run{ID: "run-1", State: execution.StateCancelled}, TemplateName: "OLTP", ConnectionName: "mysql"}
+	if err := uc.NotifyRunFinished(ctx, stopped); err != nil {
+		t.Fatalf("NotifyRunFinished(stopped) failed: %v", err)
+	}
+	failed := RunEvent{Run: &execution.Run{ID: "run-2", State: execution.StateTimeout}, TemplateName: "OLTP", ConnectionName: "mysql"}
+	if err := uc.NotifyRunFinished(ctx, failed); err != nil {
+		t.Fatalf("NotifyRunFinished(failed) failed: %v", err)
+	}
+
+	want := []string{
+		`{"run":"run-1","event":"started"}`,
+		`{"run":"run-1","event":"stopped"}`,
+		"slack",
+		`{"run":"run-2","event":"failed"}`,
+	}
+	if len(poster.payloads) != len(want) {
+		t.Fatalf("posted %d payloads, want %d: %v", len(poster.payloads), len(want), poster.payloads)
+	}
+	for i, payload := range poster.payloads {
+		if want[i] == "slack" {
+			if poster.urls[i] != webhooks[0].URL || !strings.Contains(payload, "Benchmark failed: OLTP on mysql") {
+				t.Errorf("payloads[%d] = %s, want the Slack message", i, payload)
+			}
+			continue
+		}
+		if payload != want[i] {
+			t.Errorf("payloads[%d] = %s, want %s", i, payload, want[i])
+		}
+	}
+}
+
+// TestNotificationUseCase_TrendAlert tests that a completed run deviating from
+// the earlier runs of its configuration fires the trend_alert webhooks.
+func TestNotificationUseCase_TrendAlert(t *testing.T) {
+	ctx := context.Background()
+	settingsUC := NewSettingsUseCase(newMockSettingsRepository(filepath.Join(t.TempDir(), "config.json")), nil)
+	webhooks := []config.WebhookConfig{{
+		Name:    "trends",
+		Enabled: true,
+		URL:     "https://ci.example.com/hook",
+		Format:  config.WebhookFormatGeneric,
+		Events:  []string{config.WebhookEventTrendAlert},
+	}}
+	if err := settingsUC.UpdateWebhooks(ctx, webhooks); err != nil {
+		t.Fatalf("UpdateWebhooks() failed: %v", err)
+	}
+	historyCfg, _ := settingsUC.GetHistoryConfig(ctx)
+	historyCfg.TrendAlertPercent = 10
+	if err := settingsUC.UpdateHistoryConfig(ctx, *historyCfg); err != nil {
+		t.Fatalf("UpdateHistoryConfig() failed: %v", err)
+	}
+
+	repo := newMockHistoryRepository()
+	params := map[string]string{"time": "300"}
+	start := time.Now().Add(-72 * time.Hour)
+	for i := 0; i < 3; i++ {
+		_ = repo.Save(ctx, &history.Record{
+			ID: fmt.Sprintf("nightly-%d", i), ConnectionName: "mysql", TemplateName: "OLTP", Threads: 8,
+			Parameters: params, StartTime: start.Add(time.Duration(i) * 24 * time.Hour), TPSCalculated: 1000, LatencyP95: 20,
+		})
+	}
+
+	poster := &mockWebhookPoster{}
+	uc := NewNotificationUseCase(settingsUC, NewMockKeyring(), &mockMailer{})
+	uc.SetWebhookPoster(poster)
+	uc.SetHistoryUseCase(NewHistoryUseCase(repo))
+
+	run := func(id string, tps float64) RunEvent {
+		return RunEvent{Run: &execution.Run{
+			ID:         id,
+			State:      execution.StateCompleted,
+			Parameters: params,
+			Result: &execution.BenchmarkResult{
+				ConnectionName: "mysql", TemplateName: "OLTP", Threads: 8,
+				StartTime: time.Now(), TPSCalculated: tps, LatencyP95: 20,
+			},
+		}, TemplateName: "OLTP", ConnectionName: "mysql"}
+	}
+
+	if err := uc.NotifyRunFinished(ctx, run("steady", 950)); err != nil {
+		t.Fatalf("NotifyRunFinished(steady) failed: %v", err)
+	}
+	if len(poster.payloads) != 0 {
+		t.Fatalf("a run 5%% below its trend posted %v, want nothing", poster.payloads)
+	}
+
+	if err := uc.NotifyRunFinished(ctx, run("slow", 800)); err != nil {
+		t.Fatalf("NotifyRunFinished(slow) failed: %v", err)
+	}
+	if len(poster.payloads) != 1 {
+		t.Fatalf("posted %d payloads, want 1 trend alert", len(poster.payloads))
+	}
+	for _, want := range []string{`"event":"trend_alert"`, `"run_id":"slow"`, `"tps_percent":-20`, `"baseline":3`} {
+		if !strings.Contains(poster.payloads[0], want) {
+			t.Errorf("payload = %s, want %s", poster.payloads[0], want)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+// Package usecase provides capture of the tool output of runs to rotating log files.
+package usecase
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+const (
+	// OutputLogMaxSize is the size at which the output log of a run is rotated (10MB).
+	OutputLogMaxSize = 10 * 1024 * 1024
+
+	// OutputLogBackups is the number of rotated output logs kept per run,
+	// output.log.1 being the most recent. Older output is dropped.
+	OutputLogBackups = 5
+)
+
+// rotatingLog is a log file that is rotated when it reaches maxSize.
+// It is safe for concurrent use; each Write goes to one file.
+type rotatingLog struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	backups int
+	f       *os.File
+	size    int64
+}
+
+// openRotatingLog opens path for appending, creating its directory.
+func openRotatingLog(path string, maxSize int64, backups int) (*rotatingLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	l := &rotatingLog{path: path, maxSize: maxSize, backups: backups}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *rotatingLog) open() error {
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.f = f
+	l.size = info.Size()
+	return nil
+}
+
+// Write appends p, rotating the file first if p would exceed its maximum size.
+func (l *rotatingLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.f == nil {
+		return 0, os.ErrClosed
+	}
+	if l.size > 0 && l.size+int64(len(p)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := l.f.Write(p)
+	l.size += int64(n)
+	return n, err
+}
+
+// rotate renames the file to path.1, shifting older backups and dropping the oldest.
+func (l *rotatingLog) rotate() error {
+	if err := l.f.Close(); err != nil {
+		return err
+	}
+	l.f = nil
+	os.Remove(fmt.Sprintf("%s.%d", l.path, l.backups))
+	for i := l.backups - 1; i >= 1; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1))
+	}
+	if l.backups > 0 {
+		if err := os.Rename(l.path, l.path+".1"); err != nil {
+			return err
+		}
+	} else if err := os.Remove(l.path); err != nil {
+		return err
+	}
+	return l.open()
+}
+
+// Close closes the file.
+func (l *rotatingLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
+
+// readRotatedLog returns the content of the log at path including its
+// rotated backups, oldest first.
+func readRotatedLog(path string) (string, error) {
+	var b strings.Builder
+	for i := OutputLogBackups; i >= 1; i-- {
+		data, err := os.ReadFile(fmt.Sprintf("%s.%d", path, i))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		b.Write(data)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	b.Write(data)
+	return b.String(), nil
+}
+
+// openOutputLog starts capturing the tool output of a run to the output.log
+// in its artifact directory. Does nothing without an artifact directory.
+func (uc *BenchmarkUseCase) openOutputLog(run *execution.Run) {
+	if uc.artifactDir == "" {
+		return
+	}
+	dir, err := RunArtifactDir(uc.artifactDir, run.ID)
+	if err != nil {
+		return
+	}
+	l, err := openRotatingLog(filepath.Join(dir, ArtifactOutputLog), OutputLogMaxSize, OutputLogBackups)
+	if err != nil {
+		slog.Warn("Benchmark: Failed to open output log", "run_id", run.ID, "error", err)
+		return
+	}
+
+	uc.outputLogsMu.Lock()
+	if uc.outputLogs == nil {
+		uc.outputLogs = make(map[string]*rotatingLog)
+	}
+	uc.outputLogs[run.ID] = l
+	uc.outputLogsMu.Unlock()
+}
+
+// closeOutputLog stops capturing the tool output of a run.
+func (uc *BenchmarkUseCase) closeOutputLog(runID string) {
+	uc.outputLogsMu.Lock()
+	l := uc.outputLogs[runID]
+	delete(uc.outputLogs, runID)
+	uc.outputLogsMu.Unlock()
+
+	if l != nil {
+		l.Close()
+	}
+}
+
+// outputLog returns the output log of a run, or nil if its output is not captured.
+func (uc *BenchmarkUseCase) outputLog(runID string) io.Writer {
+	uc.outputLogsMu.Lock()
+	defer uc.outputLogsMu.Unlock()
+	if l := uc.outputLogs[runID]; l != nil {
+		return l
+	}
+	return nil
+}
+
+// outputLogSection starts a section of tool output in the output log of a
+// run and returns the writer for it. Returns nil if the output is not captured.
+func (uc *BenchmarkUseCase) outputLogSection(runID, title string) io.Writer {
+	l := uc.outputLog(runID)
+	if l == nil {
+		return nil
+	}
+	fmt.Fprintf(l, "==== %s %s ====\n", time.Now().Format(time.RFC3339), title)
+	return l
+}
+
+// teeOutput returns stdout copying everything read to w, or stdout itself if w is nil.
+func teeOutput(stdout io.ReadCloser, w io.Writer) io.ReadCloser {
+	if w == nil {
+		return stdout
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.TeeReader(stdout, w), stdout}
+}
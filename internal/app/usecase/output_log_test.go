@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+// TestRotatingLog tests that the log is rotated at its maximum size and that
+// the oldest backups are dropped.
+func TestRotatingLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run-1", ArtifactOutputLog)
+	l, err := openRotatingLog(path, 10, 2)
+	if err != nil {
+		t.Fatalf("openRotatingLog() failed: %v", err)
+	}
+	for _, line := range []string{"line 1\n", "line 2\n", "line 3\n", "line 4\n"} {
+		if _, err := l.Write([]byte(line)); err != nil {
+			t.Fatalf("Write() failed: %v", err)
+		}
+	}
+	l.Close()
+
+	for file, want := range map[string]string{path: "line 4\n", path + ".1": "line 3\n", path + ".2": "line 2\n"} {
+		if data, err := os.ReadFile(file); err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v, want %q", filepath.Base(file), data, err, want)
+		}
+	}
+	if out, err := readRotatedLog(path); err != nil || out != "line 2\nline 3\nline 4\n" {
+		t.Errorf("readRotatedLog() = %q, %v, want the kept lines oldest first", out, err)
+	}
+}
+
+// TestBenchmarkUseCase_OutputLog tests that tool output is captured for runs
+// that do not keep their artifacts too, and can be read from history.
+func TestBenchmarkUseCase_OutputLog(t *testing.T) {
+	root := t.TempDir()
+	uc := &BenchmarkUseCase{}
+	uc.SetArtifactDir(root)
+
+	run := &execution.Run{ID: "run-1", WorkDir: filepath.Join(t.TempDir(), "work")}
+	uc.openOutputLog(run)
+	uc.saveArtifactLog(run, "sysbench", "Creating table 'sbtest1'...")
+	stdout := teeOutput(io.NopCloser(strings.NewReader("[ 1s ] tps: 100\n")), uc.outputLogSection(run.ID, "run"))
+	if _, err := stdout.Read(make([]byte, 64)); err != nil {
+		t.Fatalf("Read() failed: %v", err)
+	}
+	uc.closeOutputLog(run.ID)
+
+	if uc.outputLog(run.ID) != nil {
+		t.Error("output log still open after close")
+	}
+	historyUC := NewHistoryUseCase(newMockHistoryRepository())
+	historyUC.SetArtifactDir(root)
+	out, err := historyUC.GetRunOutput(context.Background(), run.ID)
+	if err != nil || !strings.Contains(out, "sysbench ====\nCreating table") || !strings.Contains(out, "run ====\n[ 1s ] tps: 100") {
+		t.Errorf("GetRunOutput() = %q, %v, want both sections", out, err)
+	}
+}
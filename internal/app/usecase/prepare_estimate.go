@@ -0,0 +1,413 @@
+// Package usecase provides the dataset size and load time estimate of the prepare phase.
+// Implements: REQ-EXEC-001
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+// Dataset size model.
+const (
+	// sysbenchRowBytes is the size of a sysbench OLTP row on disk: 188 bytes of
+	// columns (id, k, c CHAR(120), pad CHAR(60)) plus row and index overhead.
+	sysbenchRowBytes = 250
+	// tpccWarehouseRows and tpccWarehouseBytes are the rows and size on disk of
+	// one TPC-C warehouse, including its share of the item table.
+	tpccWarehouseRows  = 500_000
+	tpccWarehouseBytes = 100 << 20
+)
+
+// Calibration insert limits; the calibration stops at whichever comes first.
+const (
+	calibrationBatchRows = 100
+	calibrationMaxRows   = 20_000
+	calibrationTime      = 2 * time.Second
+)
+
+// estimateTimeout bounds the calibration and the free space queries.
+const estimateTimeout = 30 * time.Second
+
+// PrepareEstimate is the estimated size and load time of the data the prepare phase loads.
+type PrepareEstimate struct {
+	Rows       int64         // Rows loaded
+	DataBytes  int64         // Size on disk, including indexes
+	Parallel   int           // Tables or warehouses loaded at the same time
+	LoadRate   float64       // Rows per second of the calibration insert, 0 if not measured
+	LoadTime   time.Duration // Estimated load time, 0 if not measured
+	FreeBytes  int64         // Free disk space on the database server, -1 if unknown
+	FreeSource string        // How the free space was queried: SSH, WinRM or SQL
+	Notes      []string      // Parts that could not be estimated
+}
+
+// ExceedsFreeSpace returns true if the data does not fit the known free disk space.
+func (e *PrepareEstimate) ExceedsFreeSpace() bool {
+	return e.FreeBytes >= 0 && e.DataBytes > e.FreeBytes
+}
+
+// Format renders the estimate as text for display.
+func (e *PrepareEstimate) Format() string {
+	var b strings.Builder
+
+	if e.Rows > 0 {
+		fmt.Fprintf(&b, "Data volume:  %d rows, about %s\n", e.Rows, formatBytes(e.DataBytes))
+	}
+	if e.LoadTime > 0 {
+		fmt.Fprintf(&b, "Load time:    about %s (%.0f rows/s measured, %d in parallel)\n",
+			e.LoadTime.Round(time.Second), e.LoadRate, e.Parallel)
+	}
+	if e.FreeBytes >= 0 {
+		fmt.Fprintf(&b, "Server disk:  %s free (%s)\n", formatBytes(e.FreeBytes), e.FreeSource)
+	}
+	if e.ExceedsFreeSpace() {
+		fmt.Fprintf(&b, "\nWarning: the data needs %s but the server has only %s free.\n",
+			formatBytes(e.DataBytes), formatBytes(e.FreeBytes))
+	}
+	for _, note := range e.Notes {
+		fmt.Fprintf(&b, "Note: %s\n", note)
+	}
+
+	return b.String()
+}
+
+// EstimatePrepare estimates the data volume of a task's prepare phase and how
+// long it takes to load, from a short calibration insert into a temporary
+// table. The free disk space of the database server is queried over SSH,
+// WinRM or SQL, depending on the database and connection.
+// Parts that cannot be estimated are listed in Notes instead of failing.
+func (uc *BenchmarkUseCase) EstimatePrepare(ctx context.Context, task *execution.BenchmarkTask) (*PrepareEstimate, error) {
+	conn, err := uc.connUseCase.GetConnectionByID(ctx, task.ConnectionID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+
+	tmpl, err := uc.templateUseCase.GetTemplate(ctx, task.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("get template: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, estimateTimeout)
+	defer cancel()
+
+	estimate := &PrepareEstimate{FreeBytes: -1}
+	rows, bytes, parallel, ok := estimateDataset(tmpl.Tool, task.Parameters)
+	if !ok {
+		estimate.Notes = append(estimate.Notes, fmt.Sprintf("no dataset model for %s", tmpl.Tool))
+	} else {
+		estimate.Rows, estimate.DataBytes, estimate.Parallel = rows, bytes, parallel
+
+		rate, err := calibrateInsert(ctx, conn, benchmarkDatabase(conn, task.Parameters))
+		if err != nil {
+			estimate.Notes = append(estimate.Notes, fmt.Sprintf("load rate not measured: %v", err))
+		} else if rate > 0 {
+			estimate.LoadRate = rate
+			estimate.LoadTime = time.Duration(float64(rows) / (rate * float64(parallel)) * float64(time.Second))
+		}
+	}
+
+	free, source, err := serverFreeSpace(ctx, conn)
+	if err != nil {
+		estimate.Notes = append(estimate.Notes, fmt.Sprintf("server disk space not queried: %v", err))
+	} else {
+		estimate.FreeBytes, estimate.FreeSource = free, source
+	}
+
+	return estimate, nil
+}
+
+// estimateDataset returns the rows, size on disk and load parallelism of the
+// dataset a tool's prepare phase loads; ok is false for tools without a model.
+func estimateDataset(tool string, params map[string]interface{}) (rows, bytes int64, parallel int, ok bool) {
+	threads := paramInt(params, "threads", 1)
+
+	switch tool {
+	case "sysbench":
+		tables := paramInt(params, "tables", 1)
+		rows = int64(tables) * int64(paramInt(params, "table_size", 10000))
+		// sysbench prepares one table per thread
+		return rows, rows * sysbenchRowBytes, max(1, min(threads, tables)), true
+	case "hammerdb":
+		warehouses := paramInt(params, "warehouses", 1)
+		return int64(warehouses) * tpccWarehouseRows, int64(warehouses) * tpccWarehouseBytes, max(1, min(threads, warehouses)), true
+	default:
+		return 0, 0, 0, false
+	}
+}
+
+// paramInt returns an integer task parameter, or defaultValue if it is not set.
+func paramInt(params map[string]interface{}, key string, defaultValue int) int {
+	switch v := params[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+// calibration describes the calibration insert of one database type.
+type calibration struct {
+	driver string
+	create string // Creates the temporary table dbbenchmind_calibration
+	insert string // INSERT prefix; one "(k, c, pad)" tuple per row is appended
+}
+
+var calibrations = map[connection.DatabaseType]calibration{
+	connection.DatabaseTypeMySQL: {
+		driver: "mysql",
+		create: "CREATE TEMPORARY TABLE dbbenchmind_calibration (id INT NOT NULL AUTO_INCREMENT PRIMARY KEY, k INT NOT NULL, c CHAR(120) NOT NULL, pad CHAR(60) NOT NULL, KEY (k))",
+		insert: "INSERT INTO dbbenchmind_calibration (k, c, pad) VALUES ",
+	},
+	connection.DatabaseTypePostgreSQL: {
+		driver: "postgres",
+		create: "CREATE TEMPORARY TABLE dbbenchmind_calibration (id SERIAL PRIMARY KEY, k INTEGER NOT NULL, c CHAR(120) NOT NULL, pad CHAR(60) NOT NULL)",
+		insert: "INSERT INTO dbbenchmind_calibration (k, c, pad) VALUES ",
+	},
+	connection.DatabaseTypeSQLServer: {
+		driver: "sqlserver",
+		create: "CREATE TABLE #dbbenchmind_calibration (id INT IDENTITY PRIMARY KEY, k INT NOT NULL, c CHAR(120) NOT NULL, pad CHAR(60) NOT NULL)",
+		insert: "INSERT INTO #dbbenchmind_calibration (k, c, pad) VALUES ",
+	},
+}
+
+// calibrateInsert inserts sysbench-like rows into a temporary table for a
+// short time and returns the rows inserted per second. The table is dropped
+// with the session.
+func calibrateInsert(ctx context.Context, conn connection.Connection, dbName string) (float64, error) {
+	cal, ok := calibrations[conn.GetType()]
+	if !ok {
+		return 0, fmt.Errorf("not supported for %s", conn.GetType())
+	}
+
+	dsn, err := calibrationDSN(conn, dbName)
+	if err != nil {
+		return 0, err
+	}
+
+	db, err := sql.Open(cal.driver, dsn)
+	if err != nil {
+		return 0, fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	// Temporary tables belong to one session
+	session, err := db.Conn(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("connect: %w", err)
+	}
+	defer session.Close()
+
+	if _, err := session.ExecContext(ctx, cal.create); err != nil {
+		return 0, fmt.Errorf("create calibration table: %w", err)
+	}
+
+	tuples := make([]string, calibrationBatchRows)
+	for i := range tuples {
+		tuples[i] = fmt.Sprintf("(%d, '%s', '%s')", i, strings.Repeat("0", 119), strings.Repeat("0", 59))
+	}
+	insert := cal.insert + strings.Join(tuples, ", ")
+
+	start := time.Now()
+	rows := 0
+	for rows < calibrationMaxRows && time.Since(start) < calibrationTime {
+		if _, err := session.ExecContext(ctx, insert); err != nil {
+			return 0, fmt.Errorf("calibration insert: %w", err)
+		}
+		rows += calibrationBatchRows
+	}
+
+	return float64(rows) / time.Since(start).Seconds(), nil
+}
+
+// calibrationDSN returns the DSN the calibration insert connects with.
+func calibrationDSN(conn connection.Connection, dbName string) (string, error) {
+	switch c := conn.(type) {
+	case *connection.MySQLConnection:
+		tlsParams, err := c.DSNTLSParams()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", c.Username, c.Password, c.Host, c.Port, dbName) + tlsParams, nil
+	case *connection.PostgreSQLConnection:
+		return fmt.Sprintf("host=%s port=%d dbname=%s user=%s password=%s sslmode=%s",
+			c.Host, c.Port, dbName, c.Username, c.Password, c.SSLMode) + c.OptionsDSN(), nil
+	case *connection.SQLServerConnection:
+		return c.GetDSNWithPassword(), nil
+	default:
+		return "", fmt.Errorf("not supported for %s", conn.GetType())
+	}
+}
+
+// serverFreeSpace returns the free disk space of the volume holding the data
+// of the database server, and how it was queried:
+//   - MySQL and PostgreSQL: df on the data directory over SSH, when the SSH
+//     tunnel ends on the database server itself
+//   - SQL Server: sys.dm_os_volume_stats, or the drive of the default data
+//     path over WinRM if that view cannot be read
+func serverFreeSpace(ctx context.Context, conn connection.Connection) (int64, string, error) {
+	switch c := conn.(type) {
+	case *connection.MySQLConnection:
+		if c.SSH == nil || !c.SSH.Enabled || !isLoopback(c.Host) {
+			return 0, "", fmt.Errorf("needs an SSH tunnel to the database server")
+		}
+		tlsParams, err := c.DSNTLSParams()
+		if err != nil {
+			return 0, "", err
+		}
+		dsn := func(host string, port int) string {
+			return fmt.Sprintf("%s:%s@tcp(%s:%d)/", c.Username, c.Password, host, port) + tlsParams
+		}
+		return sshFreeSpace(ctx, c.SSH, c.Host, c.Port, "mysql", dsn, "SELECT @@datadir")
+	case *connection.PostgreSQLConnection:
+		if c.SSH == nil || !c.SSH.Enabled || !isLoopback(c.Host) {
+			return 0, "", fmt.Errorf("needs an SSH tunnel to the database server")
+		}
+		dsn := func(host string, port int) string {
+			return fmt.Sprintf("host=%s port=%d dbname=postgres user=%s password=%s sslmode=%s",
+				host, port, c.Username, c.Password, c.SSLMode) + c.OptionsDSN()
+		}
+		return sshFreeSpace(ctx, c.SSH, c.Host, c.Port, "postgres", dsn, "SHOW data_directory")
+	case *connection.SQLServerConnection:
+		return sqlServerFreeSpace(ctx, c)
+	default:
+		return 0, "", fmt.Errorf("not supported for %s", conn.GetType())
+	}
+}
+
+// isLoopback returns true if host is the local host, i.e. the database runs
+// on the SSH server.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// sshFreeSpace reads the data directory with query, connecting through the
+// SSH tunnel, and runs df on it over SSH. dsn builds the DSN for a host and port.
+func sshFreeSpace(ctx context.Context, ssh *connection.SSHTunnelConfig, host string, port int, driver string, dsn func(string, int) string, query string) (int64, string, error) {
+	tunnel, err := connection.NewSSHTunnel(ctx, ssh, host, port)
+	if err != nil {
+		return 0, "", err
+	}
+	defer tunnel.Close()
+
+	db, err := sql.Open(driver, dsn("127.0.0.1", tunnel.GetLocalPort()))
+	if err != nil {
+		return 0, "", fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	var dataDir string
+	if err := db.QueryRowContext(ctx, query).Scan(&dataDir); err != nil {
+		return 0, "", fmt.Errorf("read data directory: %w", err)
+	}
+
+	output, err := ssh.Run(ctx, "df -Pk "+shellQuote(dataDir))
+	if err != nil {
+		return 0, "", err
+	}
+	free, err := parseDFAvailable(output)
+	if err != nil {
+		return 0, "", err
+	}
+	return free, "SSH", nil
+}
+
+// parseDFAvailable returns the available bytes of "df -Pk" output.
+func parseDFAvailable(output string) (int64, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) < 2 {
+		return 0, fmt.Errorf("unexpected df output: %q", output)
+	}
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(fields) < 4 {
+		return 0, fmt.Errorf("unexpected df output: %q", output)
+	}
+	kb, err := strconv.ParseInt(fields[3], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse df output: %w", err)
+	}
+	return kb * 1024, nil
+}
+
+// sqlServerFreeSpace queries the free space of the volumes of the connection's
+// database, falling back to the drive of the default data path over WinRM.
+func sqlServerFreeSpace(ctx context.Context, c *connection.SQLServerConnection) (int64, string, error) {
+	db, err := sql.Open("sqlserver", c.GetDSNWithPassword())
+	if err != nil {
+		return 0, "", fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	var free sql.NullInt64
+	err = db.QueryRowContext(ctx, `SELECT MIN(vs.available_bytes)
+		FROM sys.master_files mf
+		CROSS APPLY sys.dm_os_volume_stats(mf.database_id, mf.file_id) vs
+		WHERE mf.database_id = DB_ID()`).Scan(&free)
+	if err == nil && free.Valid {
+		return free.Int64, "SQL", nil
+	}
+	if c.WinRM == nil {
+		return 0, "", fmt.Errorf("read volume stats: %v", err)
+	}
+
+	var dataPath string
+	if err := db.QueryRowContext(ctx, "SELECT CAST(SERVERPROPERTY('InstanceDefaultDataPath') AS NVARCHAR(260))").Scan(&dataPath); err != nil {
+		return 0, "", fmt.Errorf("read data path: %w", err)
+	}
+	if len(dataPath) < 2 || dataPath[1] != ':' {
+		return 0, "", fmt.Errorf("unexpected data path %q", dataPath)
+	}
+
+	client, err := connection.NewWinRMClient(ctx, c.WinRM)
+	if err != nil {
+		return 0, "", err
+	}
+	defer client.Close()
+
+	var stdout, stderr strings.Builder
+	cmdLine := fmt.Sprintf(`powershell -NoProfile -Command "(Get-PSDrive %c).Free"`, dataPath[0])
+	if code, err := client.Run(ctx, cmdLine, nil, &stdout, &stderr); err != nil || code != 0 {
+		return 0, "", fmt.Errorf("query drive %c: exit code %d: %v %s", dataPath[0], code, err, strings.TrimSpace(stderr.String()))
+	}
+	bytes, err := strconv.ParseInt(strings.TrimSpace(stdout.String()), 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("parse free space: %w", err)
+	}
+	return bytes, "WinRM", nil
+}
+
+// formatBytes formats a byte count with a binary unit, e.g. "2.3 GB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// shellQuote quotes a string for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
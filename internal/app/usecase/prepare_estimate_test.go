@@ -0,0 +1,75 @@
+// Package usecase provides unit tests for the prepare estimate.
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEstimateDataset(t *testing.T) {
+	rows, bytes, parallel, ok := estimateDataset("sysbench", map[string]interface{}{"tables": 10, "table_size": 1000000, "threads": 4})
+	if !ok || rows != 10_000_000 || bytes != 10_000_000*sysbenchRowBytes || parallel != 4 {
+		t.Errorf("sysbench estimate = %d rows, %d bytes, %d parallel, %v", rows, bytes, parallel, ok)
+	}
+
+	rows, bytes, parallel, ok = estimateDataset("hammerdb", map[string]interface{}{"warehouses": 2, "threads": 16})
+	if !ok || rows != 2*tpccWarehouseRows || bytes != 2*tpccWarehouseBytes || parallel != 2 {
+		t.Errorf("hammerdb estimate = %d rows, %d bytes, %d parallel, %v", rows, bytes, parallel, ok)
+	}
+
+	if _, _, _, ok := estimateDataset("swingbench", nil); ok {
+		t.Error("swingbench estimate ok = true, want false")
+	}
+}
+
+func TestParseDFAvailable(t *testing.T) {
+	output := "Filesystem     1024-blocks      Used Available Capacity Mounted on\n" +
+		"/dev/nvme0n1p2   479597248 201863624 253281508      45% /var/lib/mysql\n"
+	free, err := parseDFAvailable(output)
+	if err != nil {
+		t.Fatalf("parseDFAvailable() failed: %v", err)
+	}
+	if free != 253281508*1024 {
+		t.Errorf("parseDFAvailable() = %d", free)
+	}
+
+	if _, err := parseDFAvailable("df: /missing: No such file or directory"); err == nil {
+		t.Error("parseDFAvailable() of an error message succeeded")
+	}
+}
+
+func TestPrepareEstimate_Format(t *testing.T) {
+	e := &PrepareEstimate{
+		Rows:       10_000_000,
+		DataBytes:  10_000_000 * sysbenchRowBytes,
+		Parallel:   4,
+		LoadRate:   25000,
+		LoadTime:   100 * time.Second,
+		FreeBytes:  1 << 30,
+		FreeSource: "SSH",
+	}
+	if !e.ExceedsFreeSpace() {
+		t.Error("ExceedsFreeSpace() = false, want true")
+	}
+	text := e.Format()
+	for _, want := range []string{"10000000 rows, about 2.3 GB", "about 1m40s", "1.0 GB free (SSH)", "Warning: the data needs 2.3 GB"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Format() does not contain %q:\n%s", want, text)
+		}
+	}
+
+	// Unknown free space never warns
+	e.FreeBytes = -1
+	if e.ExceedsFreeSpace() || strings.Contains(e.Format(), "Warning") {
+		t.Error("estimate with unknown free space warns")
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	for host, want := range map[string]bool{"localhost": true, "127.0.0.1": true, "::1": true, "10.0.0.5": false, "db.example.com": false} {
+		if got := isLoopback(host); got != want {
+			t.Errorf("isLoopback(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
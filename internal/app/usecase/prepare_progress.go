@@ -0,0 +1,81 @@
+// Package usecase provides progress reporting of the prepare phase.
+// Implements: REQ-EXEC-005
+package usecase
+
+import (
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/sysbench"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+// PrepareStatus is the progress of a running sysbench prepare phase.
+type PrepareStatus struct {
+	Fraction  float64       // Estimated share of the prepare that is done, in [0, 1)
+	Loaded    int           // Tables whose rows are inserted
+	Tables    int           // Tables the prepare creates
+	Elapsed   time.Duration // Time since the prepare started
+	Remaining time.Duration // Estimated time left, 0 while it cannot be estimated
+}
+
+// prepareTracker follows the output of one prepare command.
+type prepareTracker struct {
+	progress  *sysbench.PrepareProgress
+	startedAt time.Time
+}
+
+// GetPrepareStatus returns the progress of a run's prepare phase, parsed from
+// the sysbench output as it arrives. Returns false if the run has no sysbench
+// prepare in progress.
+func (uc *BenchmarkUseCase) GetPrepareStatus(runID string) (*PrepareStatus, bool) {
+	uc.prepareMu.Lock()
+	defer uc.prepareMu.Unlock()
+
+	tracker, ok := uc.prepareProgress[runID]
+	if !ok {
+		return nil, false
+	}
+
+	elapsed := time.Since(tracker.startedAt)
+	status := &PrepareStatus{
+		Fraction: tracker.progress.Fraction(),
+		Loaded:   tracker.progress.Loaded(),
+		Tables:   tracker.progress.Tables(),
+		Elapsed:  elapsed,
+	}
+	if remaining, ok := tracker.progress.Remaining(elapsed); ok {
+		status.Remaining = remaining
+	}
+	return status, true
+}
+
+// startPrepareProgress starts following the prepare output of a run.
+// Only sysbench reports its progress per table.
+func (uc *BenchmarkUseCase) startPrepareProgress(runID string, adapt adapter.BenchmarkAdapter, config *adapter.Config) {
+	if adapt.Type() != adapter.AdapterTypeSysbench {
+		return
+	}
+
+	uc.prepareMu.Lock()
+	defer uc.prepareMu.Unlock()
+	uc.prepareProgress[runID] = &prepareTracker{
+		progress:  sysbench.NewPrepareProgress(paramInt(config.Parameters, "tables", 1)),
+		startedAt: time.Now(),
+	}
+}
+
+// stopPrepareProgress stops following the prepare output of a run.
+func (uc *BenchmarkUseCase) stopPrepareProgress(runID string) {
+	uc.prepareMu.Lock()
+	defer uc.prepareMu.Unlock()
+	delete(uc.prepareProgress, runID)
+}
+
+// trackPrepareLine updates the prepare progress of a run from a line of output.
+func (uc *BenchmarkUseCase) trackPrepareLine(runID, line string) {
+	uc.prepareMu.Lock()
+	defer uc.prepareMu.Unlock()
+	if tracker, ok := uc.prepareProgress[runID]; ok {
+		tracker.progress.ParseLine(line)
+	}
+}
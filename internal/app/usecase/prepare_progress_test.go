@@ -0,0 +1,41 @@
+// Package usecase provides unit tests for the prepare progress reporting.
+package usecase
+
+import (
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+func TestBenchmarkUseCase_PrepareStatus(t *testing.T) {
+	uc := NewBenchmarkUseCase(newMockRunRepository(), adapter.NewAdapterRegistry(), nil, nil)
+	config := &adapter.Config{Parameters: map[string]interface{}{"tables": 4}}
+
+	// Only sysbench prepares are followed
+	uc.startPrepareProgress("run-1", adapter.NewSwingbenchAdapter(), config)
+	if _, ok := uc.GetPrepareStatus("run-1"); ok {
+		t.Fatal("GetPrepareStatus() ok for a swingbench prepare")
+	}
+
+	uc.startPrepareProgress("run-1", adapter.NewSysbenchAdapter(), config)
+	uc.trackPrepareLine("run-1", "Creating table 'sbtest1'...")
+	uc.trackPrepareLine("run-1", "Inserting 10000 records into 'sbtest1'")
+	uc.trackPrepareLine("run-1", "Creating a secondary index on 'sbtest1'...")
+	uc.trackPrepareLine("run-2", "Creating a secondary index on 'sbtest2'...")
+
+	status, ok := uc.GetPrepareStatus("run-1")
+	if !ok {
+		t.Fatal("GetPrepareStatus() not ok during a sysbench prepare")
+	}
+	if status.Tables != 4 || status.Loaded != 1 {
+		t.Errorf("Loaded/Tables = %d/%d, want 1/4", status.Loaded, status.Tables)
+	}
+	if status.Fraction <= 0.2 || status.Fraction >= 0.25 {
+		t.Errorf("Fraction = %v, want 0.225", status.Fraction)
+	}
+
+	uc.stopPrepareProgress("run-1")
+	if _, ok := uc.GetPrepareStatus("run-1"); ok {
+		t.Error("GetPrepareStatus() ok after the prepare stopped")
+	}
+}
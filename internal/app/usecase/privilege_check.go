@@ -0,0 +1,252 @@
+// Package usecase provides the privilege pre-check of benchmark runs.
+// Implements: REQ-EXEC-001
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+// Privileges on the benchmark schema, in the order they are reported.
+var benchmarkPrivileges = []string{"CREATE", "DROP", "INSERT", "SELECT", "UPDATE"}
+
+// requiredPrivileges returns the privileges the phases of a task need:
+// CREATE, DROP and INSERT to prepare, SELECT and UPDATE to run, DROP to clean up.
+func requiredPrivileges(phases schemaPhases) []string {
+	needed := make(map[string]bool)
+	if phases.prepares {
+		needed["CREATE"], needed["DROP"], needed["INSERT"] = true, true, true
+	}
+	if phases.runs {
+		needed["SELECT"], needed["UPDATE"] = true, true
+	}
+	if phases.cleans {
+		needed["DROP"] = true
+	}
+
+	var required []string
+	for _, privilege := range benchmarkPrivileges {
+		if needed[privilege] {
+			required = append(required, privilege)
+		}
+	}
+	return required
+}
+
+// checkPrivileges checks that the connection user holds the required privileges
+// and names the missing ones. Privileges that cannot be read are not reported;
+// the tool reports them when it runs.
+//
+// Sysbench creates its tables in dbName on MySQL and PostgreSQL. HammerDB creates
+// its own database (SQL Server) or schema user (Oracle), which needs server-level
+// privileges instead; it owns what it creates.
+func (uc *BenchmarkUseCase) checkPrivileges(ctx context.Context, adapt adapter.BenchmarkAdapter, conn connection.Connection, dbName string, required []string) error {
+	var missing []string
+	var err error
+
+	switch c := conn.(type) {
+	case *connection.MySQLConnection:
+		if adapt.Type() != adapter.AdapterTypeSysbench {
+			return nil
+		}
+		missing, err = mysqlMissingPrivileges(ctx, c, dbName, required)
+	case *connection.PostgreSQLConnection:
+		if adapt.Type() != adapter.AdapterTypeSysbench {
+			return nil
+		}
+		missing, err = postgresMissingPrivileges(ctx, c, dbName, required)
+	case *connection.SQLServerConnection:
+		missing, err = serverMissingPrivileges(ctx, "sqlserver", c.GetDSNWithPassword(), required, map[string]serverPrivilege{
+			"CREATE": {"CREATE ANY DATABASE", "SELECT HAS_PERMS_BY_NAME(NULL, NULL, 'CREATE ANY DATABASE')"},
+			"DROP":   {"ALTER ANY DATABASE", "SELECT HAS_PERMS_BY_NAME(NULL, NULL, 'ALTER ANY DATABASE')"},
+		})
+	case *connection.OracleConnection:
+		missing, err = serverMissingPrivileges(ctx, "oracle", c.GetDSNWithPassword(), required, map[string]serverPrivilege{
+			"CREATE": {"CREATE USER", "SELECT COUNT(*) FROM session_privs WHERE privilege = 'CREATE USER'"},
+			"DROP":   {"DROP USER", "SELECT COUNT(*) FROM session_privs WHERE privilege = 'DROP USER'"},
+		})
+	default:
+		return nil
+	}
+
+	if err != nil {
+		slog.Warn("Benchmark: Failed to read privileges", "connection", conn.GetName(), "error", err)
+		return nil
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing privileges: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// mysqlMissingPrivileges reads the grants of the MySQL user and returns the
+// required privileges it lacks on dbName.
+func mysqlMissingPrivileges(ctx context.Context, conn *connection.MySQLConnection, dbName string, required []string) ([]string, error) {
+	// The database may not exist yet, so connect without one
+	tlsParams, err := conn.DSNTLSParams()
+	if err != nil {
+		return nil, err
+	}
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/", conn.Username, conn.Password, conn.Host, conn.Port) + tlsParams
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	rows, err := db.QueryContext(ctx, "SHOW GRANTS")
+	if err != nil {
+		return nil, fmt.Errorf("show grants: %w", err)
+	}
+	defer rows.Close()
+
+	var grants []string
+	for rows.Next() {
+		var grant string
+		if err := rows.Scan(&grant); err != nil {
+			return nil, fmt.Errorf("read grants: %w", err)
+		}
+		grants = append(grants, grant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read grants: %w", err)
+	}
+
+	var missing []string
+	for _, privilege := range required {
+		if !mysqlGrantsAllow(grants, dbName, privilege) {
+			missing = append(missing, fmt.Sprintf("%s ON `%s`.*", privilege, dbName))
+		}
+	}
+	return missing, nil
+}
+
+// mysqlGrantsAllow reports whether SHOW GRANTS output grants privilege on dbName.
+// Privileges granted through roles or wildcard database names are not resolved
+// and are assumed to be granted.
+func mysqlGrantsAllow(grants []string, dbName, privilege string) bool {
+	for _, grant := range grants {
+		privileges, rest, ok := strings.Cut(strings.TrimPrefix(grant, "GRANT "), " ON ")
+		if !ok {
+			return true // Role grant, e.g. GRANT `app`@`%` TO `bench`@`%`
+		}
+
+		target, _, _ := strings.Cut(rest, " TO ")
+		target = strings.TrimSpace(target)
+		if target != "*.*" && target != "`"+dbName+"`.*" && target != dbName+".*" && !strings.Contains(target, "%") {
+			continue
+		}
+
+		for _, granted := range strings.Split(privileges, ",") {
+			granted = strings.TrimSpace(granted)
+			if granted == privilege || granted == "ALL PRIVILEGES" || granted == "ALL" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// postgresMissingPrivileges returns the required privileges the PostgreSQL user
+// lacks in the public schema of dbName, where sysbench creates its tables.
+// Table privileges are only checked once the tables exist; the user owns the
+// tables it creates.
+func postgresMissingPrivileges(ctx context.Context, conn *connection.PostgreSQLConnection, dbName string, required []string) ([]string, error) {
+	dsn := fmt.Sprintf("host=%s port=%d dbname=%s user=%s password=%s sslmode=%s",
+		conn.Host,
+		conn.Port,
+		dbName,
+		conn.Username,
+		conn.Password,
+		conn.SSLMode) + conn.OptionsDSN()
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	// A missing database fails here; the prepare phase creates it
+	var tableExists bool
+	if err := db.QueryRowContext(ctx, "SELECT to_regclass('public.sbtest1') IS NOT NULL").Scan(&tableExists); err != nil {
+		return nil, fmt.Errorf("look up tables: %w", err)
+	}
+
+	var missing []string
+	for _, privilege := range required {
+		var query string
+		switch {
+		case privilege == "CREATE":
+			query = "SELECT has_schema_privilege('public', 'CREATE')"
+		case !tableExists:
+			continue
+		case privilege == "DROP":
+			query = "SELECT pg_has_role(tableowner, 'MEMBER') FROM pg_tables WHERE schemaname = 'public' AND tablename = 'sbtest1'"
+		default:
+			query = fmt.Sprintf("SELECT has_table_privilege('public.sbtest1', '%s')", privilege)
+		}
+
+		var granted bool
+		if err := db.QueryRowContext(ctx, query).Scan(&granted); err != nil {
+			return nil, fmt.Errorf("check %s: %w", privilege, err)
+		}
+		if !granted {
+			missing = append(missing, postgresPrivilegeName(privilege))
+		}
+	}
+	return missing, nil
+}
+
+// postgresPrivilegeName names a missing PostgreSQL privilege with what it is needed on.
+func postgresPrivilegeName(privilege string) string {
+	switch privilege {
+	case "CREATE":
+		return "CREATE ON SCHEMA public"
+	case "DROP":
+		return "DROP (ownership of public.sbtest1)"
+	default:
+		return privilege + " ON public.sbtest1"
+	}
+}
+
+// serverPrivilege is a server-level privilege and the query that returns
+// whether the user holds it (non-zero) or not (0).
+type serverPrivilege struct {
+	name  string
+	query string
+}
+
+// serverMissingPrivileges returns the server-level privileges the user lacks
+// for the required benchmark privileges. Required privileges without a
+// server-level counterpart are not checked.
+func serverMissingPrivileges(ctx context.Context, driver, dsn string, required []string, privileges map[string]serverPrivilege) ([]string, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	var missing []string
+	for _, privilege := range required {
+		server, ok := privileges[privilege]
+		if !ok {
+			continue
+		}
+
+		var granted sql.NullInt64
+		if err := db.QueryRowContext(ctx, server.query).Scan(&granted); err != nil {
+			return nil, fmt.Errorf("check %s: %w", server.name, err)
+		}
+		if granted.Int64 == 0 {
+			missing = append(missing, server.name)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,46 @@
+// Package usecase provides unit tests for the privilege pre-check.
+package usecase
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRequiredPrivileges(t *testing.T) {
+	tests := []struct {
+		phases schemaPhases
+		want   string
+	}{
+		{schemaPhases{prepares: true, runs: true, cleans: true}, "CREATE,DROP,INSERT,SELECT,UPDATE"},
+		{schemaPhases{runs: true}, "SELECT,UPDATE"},
+		{schemaPhases{cleans: true}, "DROP"},
+		{schemaPhases{}, ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(requiredPrivileges(tt.phases), ","); got != tt.want {
+			t.Errorf("requiredPrivileges(%+v) = %s, want %s", tt.phases, got, tt.want)
+		}
+	}
+}
+
+func TestMySQLGrantsAllow(t *testing.T) {
+	tests := []struct {
+		name      string
+		grants    []string
+		privilege string
+		want      bool
+	}{
+		{"global all", []string{"GRANT ALL PRIVILEGES ON *.* TO `root`@`localhost` WITH GRANT OPTION"}, "DROP", true},
+		{"database grant", []string{"GRANT USAGE ON *.* TO `bench`@`%`", "GRANT SELECT, INSERT, CREATE ON `sbtest`.* TO `bench`@`%`"}, "CREATE", true},
+		{"not granted", []string{"GRANT USAGE ON *.* TO `bench`@`%`", "GRANT SELECT, INSERT, CREATE ON `sbtest`.* TO `bench`@`%`"}, "DROP", false},
+		{"other database", []string{"GRANT ALL PRIVILEGES ON `app`.* TO `bench`@`%`"}, "SELECT", false},
+		{"create view only", []string{"GRANT SELECT, CREATE VIEW ON `sbtest`.* TO `bench`@`%`"}, "CREATE", false},
+		{"wildcard database", []string{"GRANT ALL PRIVILEGES ON `sb%`.* TO `bench`@`%`"}, "CREATE", true},
+		{"role", []string{"GRANT USAGE ON *.* TO `bench`@`%`", "GRANT `dba`@`%` TO `bench`@`%`"}, "UPDATE", true},
+	}
+	for _, tt := range tests {
+		if got := mysqlGrantsAllow(tt.grants, "sbtest", tt.privilege); got != tt.want {
+			t.Errorf("%s: mysqlGrantsAllow(%s) = %v, want %v", tt.name, tt.privilege, got, tt.want)
+		}
+	}
+}
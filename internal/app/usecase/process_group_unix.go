@@ -0,0 +1,48 @@
+//go:build !windows
+
+package usecase
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// setProcessGroup makes cmd start in its own process group, so that it can be
+// stopped together with any child processes it spawns. Cancelling the command's
+// context kills the whole group, so cmd must be created with exec.CommandContext.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return signalProcessGroup(cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
+
+// signalProcessGroup sends sig to every process in the process group led by pid.
+func signalProcessGroup(pid int, sig syscall.Signal) error {
+	if err := syscall.Kill(-pid, sig); err != nil {
+		if errors.Is(err, syscall.ESRCH) {
+			return os.ErrProcessDone
+		}
+		return err
+	}
+	return nil
+}
+
+// processGroupExists reports whether the process group pgid still has processes
+// that this user may signal.
+func processGroupExists(pgid int) bool {
+	return syscall.Kill(-pgid, 0) == nil
+}
+
+// currentBootID returns an identifier of the current system boot, or "" if the
+// platform does not provide one. Process IDs are only meaningful within one boot.
+func currentBootID() string {
+	data, err := os.ReadFile("/proc/sys/kernel/random/boot_id")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
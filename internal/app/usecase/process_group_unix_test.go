@@ -0,0 +1,150 @@
+//go:build !windows
+
+package usecase
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// memoryProcessRepository is an in-memory ProcessRepository for testing.
+type memoryProcessRepository struct {
+	mu    sync.Mutex
+	procs map[int]RunProcess
+}
+
+func newMemoryProcessRepository() *memoryProcessRepository {
+	return &memoryProcessRepository{procs: make(map[int]RunProcess)}
+}
+
+func (r *memoryProcessRepository) Save(ctx context.Context, proc RunProcess) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.procs[proc.PID] = proc
+	return nil
+}
+
+func (r *memoryProcessRepository) Delete(ctx context.Context, pid int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.procs, pid)
+	return nil
+}
+
+func (r *memoryProcessRepository) FindAll(ctx context.Context) ([]RunProcess, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var procs []RunProcess
+	for _, proc := range r.procs {
+		procs = append(procs, proc)
+	}
+	return procs, nil
+}
+
+// TestSignalProcessGroup_KillsChildren tests that a child spawned by the command is stopped too.
+func TestSignalProcessGroup_KillsChildren(t *testing.T) {
+	cmd := exec.CommandContext(context.Background(), "sh", "-c", "sleep 60 & wait")
+	setProcessGroup(cmd)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("StdoutPipe() failed: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+
+	if err := signalProcessGroup(cmd.Process.Pid, syscall.SIGTERM); err != nil {
+		t.Fatalf("signalProcessGroup() failed: %v", err)
+	}
+
+	// The pipe reaches EOF only once the sleep child, which shares it, has exited too
+	eof := make(chan struct{})
+	go func() {
+		io.Copy(io.Discard, stdout)
+		close(eof)
+	}()
+	select {
+	case <-eof:
+	case <-time.After(10 * time.Second):
+		signalProcessGroup(cmd.Process.Pid, syscall.SIGKILL)
+		t.Fatal("child process survived the process group signal")
+	}
+	cmd.Wait()
+}
+
+// TestBenchmarkUseCase_ReapOrphanedProcesses tests cleanup of recorded processes after a crash.
+func TestBenchmarkUseCase_ReapOrphanedProcesses(t *testing.T) {
+	ctx := context.Background()
+
+	orphan := exec.CommandContext(ctx, "sleep", "60")
+	setProcessGroup(orphan)
+	if err := orphan.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- orphan.Wait() }()
+
+	repo := newMemoryProcessRepository()
+	repo.Save(ctx, RunProcess{PID: orphan.Process.Pid, RunID: "run-1", Command: "sleep 60", BootID: currentBootID(), StartedAt: time.Now()})
+	// A record from another boot must never be signalled
+	repo.Save(ctx, RunProcess{PID: os.Getpid(), RunID: "run-2", Command: "old", BootID: "earlier-boot", StartedAt: time.Now()})
+
+	uc := NewBenchmarkUseCase(NewMemoryRunRepository(), nil, nil, nil)
+	uc.SetProcessRepository(repo)
+
+	killed, err := uc.ReapOrphanedProcesses(ctx)
+	if err != nil {
+		t.Fatalf("ReapOrphanedProcesses() failed: %v", err)
+	}
+	if killed != 1 {
+		t.Errorf("killed = %d, want 1", killed)
+	}
+
+	select {
+	case <-exited:
+	case <-time.After(10 * time.Second):
+		orphan.Process.Kill()
+		t.Fatal("orphaned process was not killed")
+	}
+
+	if procs, _ := repo.FindAll(ctx); len(procs) != 0 {
+		t.Errorf("process records after reaping = %+v, want none", procs)
+	}
+}
+
+// TestBenchmarkUseCase_TrackProcess tests that started processes are recorded until they finish.
+func TestBenchmarkUseCase_TrackProcess(t *testing.T) {
+	repo := newMemoryProcessRepository()
+	uc := NewBenchmarkUseCase(NewMemoryRunRepository(), nil, nil, nil)
+	uc.SetProcessRepository(repo)
+
+	cmd := exec.CommandContext(context.Background(), "true")
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	uc.trackProcess("run-1", cmd, nil)
+
+	procs, _ := repo.FindAll(context.Background())
+	if len(procs) != 1 || procs[0].PID != cmd.Process.Pid || procs[0].RunID != "run-1" {
+		t.Errorf("process records = %+v, want PID %d of run-1", procs, cmd.Process.Pid)
+	}
+
+	cmd.Wait()
+	uc.untrackProcess("run-1", cmd)
+
+	if procs, _ := repo.FindAll(context.Background()); len(procs) != 0 {
+		t.Errorf("process records after untrack = %+v, want none", procs)
+	}
+	uc.runningProcessesMu.RLock()
+	defer uc.runningProcessesMu.RUnlock()
+	if _, ok := uc.runningProcesses["run-1"]; ok {
+		t.Error("process still registered as running after untrack")
+	}
+}
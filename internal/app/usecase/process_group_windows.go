@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// stillActive is the exit code GetExitCodeProcess reports for a running process.
+const stillActive = 259
+
+// setProcessGroup makes cmd start in its own process group. Cancelling the
+// command's context kills the process together with its child processes, so cmd
+// must be created with exec.CommandContext.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+	cmd.Cancel = func() error {
+		return signalProcessGroup(cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
+
+// signalProcessGroup stops the process pid and its child processes with taskkill.
+// Windows has no signals: SIGKILL terminates the process tree forcefully, while any
+// other signal first asks it to close and falls back to terminating it, since
+// console tools such as sysbench cannot be asked to close.
+func signalProcessGroup(pid int, sig syscall.Signal) error {
+	if !processGroupExists(pid) {
+		return os.ErrProcessDone
+	}
+	if sig != syscall.SIGKILL {
+		if err := taskkill(pid, false); err == nil {
+			return nil
+		}
+	}
+	return taskkill(pid, true)
+}
+
+// taskkill runs taskkill on the process tree of pid.
+func taskkill(pid int, force bool) error {
+	args := []string{"/T", "/PID", strconv.Itoa(pid)}
+	if force {
+		args = append([]string{"/F"}, args...)
+	}
+	output, err := exec.Command("taskkill", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("taskkill: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// processGroupExists reports whether the process pid is still running.
+func processGroupExists(pid int) bool {
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer windows.CloseHandle(handle)
+
+	var exitCode uint32
+	if err := windows.GetExitCodeProcess(handle, &exitCode); err != nil {
+		return false
+	}
+	return exitCode == stillActive
+}
+
+// currentBootID returns the system boot time to the minute, as Windows has no boot
+// identifier. A boot close to a minute boundary may yield a different ID, in which
+// case orphan cleanup skips the record rather than risk signalling a reused PID.
+func currentBootID() string {
+	boot := time.Now().Add(-windows.DurationSinceBoot()).Truncate(time.Minute)
+	return boot.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,204 @@
+// Package usecase provides A/B race business logic.
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// ErrRaceSameConnection is returned when both sides of a race use the same connection.
+var ErrRaceSameConnection = errors.New("a race needs two different connections")
+
+// Race sides.
+const (
+	RaceSideA = "A"
+	RaceSideB = "B"
+)
+
+// RaceTarget is a connection a race runs its task against.
+type RaceTarget struct {
+	ConnectionID   string
+	ConnectionName string
+	DatabaseType   string
+}
+
+// RaceStartedFunc is called when the run of a side of a race has started.
+type RaceStartedFunc func(side string, run *execution.Run)
+
+// RaceUseCase runs the same task against two connections at the same time
+// ("A/B race") and saves the pair as a race. Both runs are saved to history
+// tagged "race:<race-id>".
+type RaceUseCase struct {
+	runner    BenchmarkRunner
+	historyUC *HistoryUseCase
+	raceRepo  repository.RaceRepository
+}
+
+// NewRaceUseCase creates a new race use case.
+func NewRaceUseCase(runner BenchmarkRunner, historyUC *HistoryUseCase, raceRepo repository.RaceRepository) *RaceUseCase {
+	return &RaceUseCase{
+		runner:    runner,
+		historyUC: historyUC,
+		raceRepo:  raceRepo,
+	}
+}
+
+// RunRace runs task against the connections a and b at the same time and
+// returns the race once both runs have ended. The connection of task is
+// ignored and the task runs once, whatever its Repeat.
+//
+// If the run of b fails to start, the run of a is stopped and nothing is
+// saved. Once both have started the race is saved even if a run fails or ctx
+// is cancelled, which stops both runs.
+func (uc *RaceUseCase) RunRace(ctx context.Context, task *execution.BenchmarkTask, templateName string, a, b RaceTarget, onStarted RaceStartedFunc) (*history.Race, error) {
+	if a.ConnectionID == b.ConnectionID {
+		return nil, ErrRaceSameConnection
+	}
+	if err := task.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPreCheckFailed, err)
+	}
+
+	threads, _ := task.Parameters["threads"].(int)
+	race := &history.Race{
+		ID:           uuid.New().String(),
+		Name:         task.Name,
+		TemplateName: templateName,
+		Threads:      threads,
+		CreatedAt:    time.Now(),
+	}
+	slog.Info("Race: Started", "task", task.Name, "race_id", race.ID, "a", a.ConnectionName, "b", b.ConnectionName)
+
+	targets := []RaceTarget{a, b}
+	sides := []string{RaceSideA, RaceSideB}
+	started := make([]*execution.Run, len(targets))
+	for i, target := range targets {
+		run, err := uc.runner.StartBenchmark(ctx, raceTask(task, target, sides[i]))
+		if err != nil {
+			for _, other := range started[:i] {
+				if stopErr := uc.runner.StopBenchmark(context.WithoutCancel(ctx), other.ID, false); stopErr != nil {
+					slog.Warn("Race: Failed to stop run", "run_id", other.ID, "error", stopErr)
+				}
+			}
+			return nil, fmt.Errorf("start run on %s: %w", target.ConnectionName, err)
+		}
+		started[i] = run
+	}
+	for i, run := range started {
+		slog.Info("Race: Run started", "race_id", race.ID, "side", sides[i], "connection", targets[i].ConnectionName, "run_id", run.ID)
+		if onStarted != nil {
+			onStarted(sides[i], run)
+		}
+	}
+
+	// Both runs are waited for, so stopping one does not leave the other running
+	finals := make([]*execution.Run, len(started))
+	var wg sync.WaitGroup
+	for i, run := range started {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			finals[i] = waitForRun(ctx, uc.runner, run.ID)
+		}()
+	}
+	wg.Wait()
+
+	saveCtx := context.WithoutCancel(ctx)
+	notes := fmt.Sprintf("Race %s vs %s", a.ConnectionName, b.ConnectionName)
+	race.A = uc.saveSide(saveCtx, race, notes, targets[0], started[0], finals[0])
+	race.B = uc.saveSide(saveCtx, race, notes, targets[1], started[1], finals[1])
+
+	if err := uc.raceRepo.SaveRace(saveCtx, race); err != nil {
+		return race, fmt.Errorf("save race: %w", err)
+	}
+
+	slog.Info("Race: Finished", "race_id", race.ID, "completed", race.Completed(), "winner", race.Winner(),
+		"tps_a", race.A.TPS, "tps_b", race.B.TPS)
+	if ctx.Err() != nil {
+		return race, fmt.Errorf("race stopped: %w", ctx.Err())
+	}
+	return race, nil
+}
+
+// saveSide saves the run of a side of the race to history, tagged with the
+// race, and returns the side. A run that did not complete is saved with its state.
+func (uc *RaceUseCase) saveSide(ctx context.Context, race *history.Race, notes string, target RaceTarget, started, final *execution.Run) history.RaceSide {
+	side := history.RaceSide{
+		ConnectionName: target.ConnectionName,
+		DatabaseType:   target.DatabaseType,
+		RunID:          started.ID,
+	}
+	if final == nil {
+		side.State = string(execution.StateFailed)
+		side.ErrorMessage = "run status unavailable"
+		return side
+	}
+
+	var err error
+	if final.State == execution.StateCompleted && final.Result != nil {
+		err = uc.historyUC.SaveRunToHistory(ctx, final)
+	} else {
+		if final.State == execution.StateCompleted {
+			final.State = execution.StateFailed // Completed without results
+		}
+		err = uc.historyUC.SaveStoppedRun(ctx, RunEvent{
+			Run:            final,
+			ConnectionName: target.ConnectionName,
+			TemplateName:   race.TemplateName,
+			DatabaseType:   target.DatabaseType,
+		})
+	}
+	if err != nil {
+		slog.Error("Race: Failed to save run to history", "run_id", final.ID, "error", err)
+		side.State = string(final.State)
+		side.ErrorMessage = final.ErrorMessage
+		return side
+	}
+
+	if err := uc.historyUC.UpdateAnnotations(ctx, final.ID, []string{race.Tag()}, notes); err != nil {
+		slog.Warn("Race: Failed to tag history record", "run_id", final.ID, "error", err)
+	}
+
+	record, err := uc.historyUC.GetRecordByID(ctx, final.ID)
+	if err != nil {
+		slog.Error("Race: Failed to get history record", "run_id", final.ID, "error", err)
+		return side
+	}
+	recorded := history.NewRaceSide(record)
+	// The connection of the race is authoritative over the name in the results
+	recorded.ConnectionName = target.ConnectionName
+	recorded.DatabaseType = target.DatabaseType
+	return recorded
+}
+
+// raceTask returns the task of one side of a race.
+func raceTask(task *execution.BenchmarkTask, target RaceTarget, side string) *execution.BenchmarkTask {
+	t := *task
+	t.ID = uuid.New().String()
+	t.Name = fmt.Sprintf("%s [%s]", task.Name, side)
+	t.ConnectionID = target.ConnectionID
+	t.Parameters = maps.Clone(task.Parameters)
+	t.CreatedAt = time.Now()
+	t.Options.Repeat = 0
+	return &t
+}
+
+// ListRaces returns the races, newest first.
+func (uc *RaceUseCase) ListRaces(ctx context.Context) ([]*history.Race, error) {
+	return uc.raceRepo.ListRaces(ctx)
+}
+
+// GetRace returns a race.
+func (uc *RaceUseCase) GetRace(ctx context.Context, id string) (*history.Race, error) {
+	return uc.raceRepo.GetRace(ctx, id)
+}
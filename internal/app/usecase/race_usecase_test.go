@@ -0,0 +1,147 @@
+// Package usecase provides unit tests for A/B races.
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// mockRaceRepository is an in-memory RaceRepository.
+type mockRaceRepository struct {
+	races map[string]*history.Race
+}
+
+func (m *mockRaceRepository) SaveRace(ctx context.Context, race *history.Race) error {
+	m.races[race.ID] = race
+	return nil
+}
+
+func (m *mockRaceRepository) GetRace(ctx context.Context, id string) (*history.Race, error) {
+	race, ok := m.races[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return race, nil
+}
+
+func (m *mockRaceRepository) ListRaces(ctx context.Context) ([]*history.Race, error) {
+	var races []*history.Race
+	for _, race := range m.races {
+		races = append(races, race)
+	}
+	return races, nil
+}
+
+func (m *mockRaceRepository) DeleteRace(ctx context.Context, id string) error {
+	delete(m.races, id)
+	return nil
+}
+
+// newTestRaceUseCase creates a race use case with in-memory repositories.
+func newTestRaceUseCase(t *testing.T, runner BenchmarkRunner) (*RaceUseCase, *mockHistoryRepository, *mockRaceRepository) {
+	t.Helper()
+	runPollInterval = time.Millisecond
+	t.Cleanup(func() { runPollInterval = time.Second })
+
+	historyRepo := newMockHistoryRepository()
+	raceRepo := &mockRaceRepository{races: make(map[string]*history.Race)}
+	return NewRaceUseCase(runner, NewHistoryUseCase(historyRepo), raceRepo), historyRepo, raceRepo
+}
+
+var (
+	racePrimary = RaceTarget{ConnectionID: "conn-1", ConnectionName: "mysql-primary", DatabaseType: "mysql"}
+	raceReplica = RaceTarget{ConnectionID: "conn-2", ConnectionName: "mysql-replica", DatabaseType: "mysql"}
+)
+
+// TestRaceUseCase_RunRace tests running a task on two connections and saving the pair.
+func TestRaceUseCase_RunRace(t *testing.T) {
+	ctx := context.Background()
+	runner := newMockBenchmarkRunner()
+	runner.tps = []float64{1000, 1250}
+	uc, historyRepo, raceRepo := newTestRaceUseCase(t, runner)
+
+	started := make(map[string]string)
+	race, err := uc.RunRace(ctx, repeatedTask("oltp", 3), "oltp_read_write", racePrimary, raceReplica, func(side string, run *execution.Run) {
+		started[side] = run.ID
+	})
+	if err != nil {
+		t.Fatalf("RunRace() failed: %v", err)
+	}
+	if len(runner.tasks) != 2 || runner.tasks[0].ConnectionID != "conn-1" || runner.tasks[1].ConnectionID != "conn-2" {
+		t.Fatalf("started %d tasks, want one on each connection", len(runner.tasks))
+	}
+	if runner.tasks[0].Options.Repeat != 0 {
+		t.Errorf("race task Repeat = %d, want a single run", runner.tasks[0].Options.Repeat)
+	}
+	if started[RaceSideA] != race.A.RunID || started[RaceSideB] != race.B.RunID {
+		t.Errorf("started = %v, want the runs of the race", started)
+	}
+	if race.A.ConnectionName != "mysql-primary" || race.B.TPS != 1250 || race.Winner() != RaceSideB {
+		t.Errorf("race = %+v, want B winning with 1250 TPS", race)
+	}
+	if _, err := raceRepo.GetRace(ctx, race.ID); err != nil {
+		t.Errorf("race not saved: %v", err)
+	}
+
+	for _, id := range []string{race.A.RunID, race.B.RunID} {
+		record, err := historyRepo.GetByID(ctx, id)
+		if err != nil {
+			t.Fatalf("history record %s not saved: %v", id, err)
+		}
+		if !slices.Contains(record.Tags, race.Tag()) {
+			t.Errorf("record %s tags = %v, want %s", id, record.Tags, race.Tag())
+		}
+	}
+}
+
+// TestRaceUseCase_FailedSide tests that a failed run is saved with its state.
+func TestRaceUseCase_FailedSide(t *testing.T) {
+	ctx := context.Background()
+	runner := newMockBenchmarkRunner()
+	uc, historyRepo, _ := newTestRaceUseCase(t, runner)
+
+	task := repeatedTask("broken", 1)
+	race, err := uc.RunRace(ctx, task, "broken", racePrimary, raceReplica, nil)
+	if err != nil {
+		t.Fatalf("RunRace() failed: %v", err)
+	}
+	if race.Completed() || race.A.State != string(execution.StateFailed) || race.A.ErrorMessage == "" {
+		t.Errorf("race A = %+v, want failed with its error", race.A)
+	}
+	if record, err := historyRepo.GetByID(ctx, race.B.RunID); err != nil || record.ConnectionName != "mysql-replica" {
+		t.Errorf("failed run B record = %+v, %v; want saved with its connection", record, err)
+	}
+
+	if _, err := uc.RunRace(ctx, task, "broken", racePrimary, racePrimary, nil); !errors.Is(err, ErrRaceSameConnection) {
+		t.Errorf("RunRace(same connection) error = %v, want ErrRaceSameConnection", err)
+	}
+}
+
+// TestRaceUseCase_Stop tests that cancelling a race stops both runs.
+func TestRaceUseCase_Stop(t *testing.T) {
+	runner := newMockBenchmarkRunner()
+	runner.hang = true
+	uc, _, raceRepo := newTestRaceUseCase(t, runner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	race, err := uc.RunRace(ctx, repeatedTask("oltp", 1), "oltp", racePrimary, raceReplica, func(side string, run *execution.Run) {
+		if side == RaceSideB {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunRace() error = %v, want context.Canceled", err)
+	}
+	if len(runner.stopped) != 2 {
+		t.Errorf("stopped runs = %v, want both", runner.stopped)
+	}
+	if race.A.State != string(execution.StateCancelled) || len(raceRepo.races) != 1 {
+		t.Errorf("race A = %+v with %d saved, want cancelled and saved", race.A, len(raceRepo.races))
+	}
+}
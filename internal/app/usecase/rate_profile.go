@@ -0,0 +1,160 @@
+// Package usecase provides rate profiles for the run phase.
+// Implements: REQ-EXEC-002
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	domaintemplate "github.com/whhaicheng/DB-BenchMind/internal/domain/template"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+// reportSecondPattern matches the elapsed second of a sysbench report line ("[ 28s ] thds: ...").
+var reportSecondPattern = regexp.MustCompile(`\[\s*(\d+)s\s*\]`)
+
+// executeRateProfile executes the run phase as one sysbench run per step of
+// the profile, each with the rate of its step, and merges their results.
+// Report lines of later steps are shifted by the step offset so the run
+// phase reads as one continuous run.
+func (uc *BenchmarkUseCase) executeRateProfile(
+	ctx context.Context,
+	run *execution.Run,
+	adapt adapter.BenchmarkAdapter,
+	config *adapter.Config,
+	profile *execution.RateProfile,
+	conn connection.Connection,
+	tmpl *domaintemplate.Template,
+) error {
+	if adapt.Type() != adapter.AdapterTypeSysbench {
+		return fmt.Errorf("rate profiles are only supported for sysbench, not %s", adapt.Type())
+	}
+
+	steps := profile.Steps(paramInt(config.Parameters, "time", 0))
+	if len(steps) == 0 {
+		return fmt.Errorf("rate profile needs a positive run time")
+	}
+
+	var results []*execution.BenchmarkResult
+	for i, step := range steps {
+		if uc.isStopped(ctx, run.ID) {
+			slog.Info("Benchmark: Rate profile stopped", "run_id", run.ID, "step", i+1)
+			break
+		}
+
+		slog.Info("Benchmark: Rate profile step", "run_id", run.ID,
+			"step", i+1, "steps", len(steps), "rate", step.Rate, "duration", step.Duration)
+		uc.saveLogEntry(ctx, run.ID, LogEntry{
+			Timestamp: time.Now().Format(time.RFC3339),
+			Stream:    "info",
+			Content:   fmt.Sprintf("Rate profile step %d/%d: %d TPS for %ds", i+1, len(steps), step.Rate, step.Duration),
+		})
+
+		stepConfig := withRunTime(config, step.Duration)
+		stepConfig.Parameters["rate"] = step.Rate
+		stepConfig.Parameters["_time_offset"] = step.Offset
+
+		// Each step's command is bounded by its own duration
+		run.Result = nil
+		timeout := time.Duration(step.Duration*2+60) * time.Second
+		if err := uc.executeRun(ctx, run, adapt, stepConfig, timeout, conn, tmpl); err != nil {
+			if uc.isStopped(ctx, run.ID) {
+				break
+			}
+			return fmt.Errorf("rate profile step %d/%d: %w", i+1, len(steps), err)
+		}
+		if run.Result != nil {
+			results = append(results, run.Result)
+		}
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	result := mergeStepResults(results)
+	if samples, err := uc.runRepo.GetMetricSamples(ctx, run.ID); err == nil {
+		result.TimeSeries = samples
+	}
+	run.Result = result
+	if err := uc.runRepo.Save(ctx, run); err != nil {
+		slog.Error("Benchmark: Failed to save rate profile result", "run_id", run.ID, "error", err)
+	}
+	return nil
+}
+
+// mergeStepResults combines the results of consecutive runs into the result
+// of one run covering all of them. Counters add up, rates are recomputed
+// over the total time, and percentiles take the worst step since the
+// per-step latency distributions are not available.
+func mergeStepResults(results []*execution.BenchmarkResult) *execution.BenchmarkResult {
+	merged := *results[0]
+	merged.TimeSeries = nil
+	if len(results) == 1 {
+		return &merged
+	}
+
+	var latencyWeighted, eventsVar, execVar float64
+	latencyWeighted = results[0].LatencyAvg * float64(results[0].TotalEvents)
+	eventsVar = results[0].EventsStddev * results[0].EventsStddev
+	execVar = results[0].ExecTimeStddev * results[0].ExecTimeStddev
+
+	for _, r := range results[1:] {
+		merged.TotalTransactions += r.TotalTransactions
+		merged.TotalQueries += r.TotalQueries
+		merged.ReadQueries += r.ReadQueries
+		merged.WriteQueries += r.WriteQueries
+		merged.OtherQueries += r.OtherQueries
+		merged.IgnoredErrors += r.IgnoredErrors
+		merged.Reconnects += r.Reconnects
+		merged.ErrorCount += r.ErrorCount
+		merged.TotalTime += r.TotalTime
+		merged.TotalEvents += r.TotalEvents
+		merged.Duration += r.Duration
+		merged.LatencySum += r.LatencySum
+		merged.EventsAvg += r.EventsAvg
+		merged.ExecTimeAvg += r.ExecTimeAvg
+
+		if r.LatencyMin < merged.LatencyMin {
+			merged.LatencyMin = r.LatencyMin
+		}
+		merged.LatencyMax = math.Max(merged.LatencyMax, r.LatencyMax)
+		merged.LatencyP95 = math.Max(merged.LatencyP95, r.LatencyP95)
+		merged.LatencyP99 = math.Max(merged.LatencyP99, r.LatencyP99)
+
+		latencyWeighted += r.LatencyAvg * float64(r.TotalEvents)
+		eventsVar += r.EventsStddev * r.EventsStddev
+		execVar += r.ExecTimeStddev * r.ExecTimeStddev
+	}
+
+	if merged.TotalTime > 0 {
+		merged.TPSCalculated = float64(merged.TotalTransactions) / merged.TotalTime
+	}
+	if merged.TotalEvents > 0 {
+		merged.LatencyAvg = latencyWeighted / float64(merged.TotalEvents)
+	}
+	merged.EventsStddev = math.Sqrt(eventsVar)
+	merged.ExecTimeStddev = math.Sqrt(execVar)
+	return &merged
+}
+
+// shiftReportSecond adds offset seconds to the elapsed second of a sysbench
+// report line. Other lines are returned unchanged.
+func shiftReportSecond(line string, offset int) string {
+	loc := reportSecondPattern.FindStringSubmatchIndex(line)
+	if loc == nil {
+		return line
+	}
+	second, err := strconv.Atoi(line[loc[2]:loc[3]])
+	if err != nil {
+		return line
+	}
+	return line[:loc[0]] + fmt.Sprintf("[ %ds ]", second+offset) + line[loc[1]:]
+}
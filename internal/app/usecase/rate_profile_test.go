@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+// TestMergeStepResults tests combining the results of rate profile steps.
+func TestMergeStepResults(t *testing.T) {
+	steps := []*execution.BenchmarkResult{
+		{
+			RunID: "run-1", TotalTransactions: 1000, TotalQueries: 20000, TotalTime: 10, TotalEvents: 1000,
+			LatencyAvg: 2, LatencyMin: 1, LatencyMax: 10, LatencyP95: 4, EventsStddev: 3, ExecTimeStddev: 4,
+		},
+		{
+			RunID: "run-1", TotalTransactions: 3000, TotalQueries: 60000, TotalTime: 10, TotalEvents: 3000,
+			LatencyAvg: 4, LatencyMin: 0.5, LatencyMax: 8, LatencyP95: 6, EventsStddev: 4, ExecTimeStddev: 3,
+		},
+	}
+
+	got := mergeStepResults(steps)
+
+	if got.RunID != "run-1" {
+		t.Errorf("RunID = %q, want run-1", got.RunID)
+	}
+	if got.TotalTransactions != 4000 || got.TotalQueries != 80000 || got.TotalEvents != 4000 {
+		t.Errorf("counters = %d/%d/%d, want 4000/80000/4000", got.TotalTransactions, got.TotalQueries, got.TotalEvents)
+	}
+	if got.TPSCalculated != 200 {
+		t.Errorf("TPSCalculated = %v, want 200", got.TPSCalculated)
+	}
+	if got.LatencyAvg != 3.5 {
+		t.Errorf("LatencyAvg = %v, want 3.5 (weighted by events)", got.LatencyAvg)
+	}
+	if got.LatencyMin != 0.5 || got.LatencyMax != 10 || got.LatencyP95 != 6 {
+		t.Errorf("latency min/max/p95 = %v/%v/%v, want 0.5/10/6", got.LatencyMin, got.LatencyMax, got.LatencyP95)
+	}
+	if math.Abs(got.EventsStddev-5) > 1e-9 || math.Abs(got.ExecTimeStddev-5) > 1e-9 {
+		t.Errorf("stddevs = %v/%v, want 5/5", got.EventsStddev, got.ExecTimeStddev)
+	}
+	if steps[0].TotalTransactions != 1000 {
+		t.Error("mergeStepResults() modified the first step result")
+	}
+}
+
+// TestShiftReportSecond tests shifting report lines of later rate profile steps.
+func TestShiftReportSecond(t *testing.T) {
+	tests := []struct {
+		line   string
+		offset int
+		want   string
+	}{
+		{"[ 10s ] thds: 8 tps: 100.00", 30, "[ 40s ] thds: 8 tps: 100.00"},
+		{"[ 5s ] thds: 8 tps: 100.00", 0, "[ 5s ] thds: 8 tps: 100.00"},
+		{"Threads started!", 30, "Threads started!"},
+	}
+	for _, tt := range tests {
+		if got := shiftReportSecond(tt.line, tt.offset); got != tt.want {
+			t.Errorf("shiftReportSecond(%q, %d) = %q, want %q", tt.line, tt.offset, got, tt.want)
+		}
+	}
+}
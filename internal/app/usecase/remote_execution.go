@@ -0,0 +1,454 @@
+// Package usecase provides remote benchmark execution.
+// This file runs benchmark tools on SQL Server hosts through WinRM, or on
+// load-generator agents.
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf16"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/agent"
+)
+
+var (
+	// ErrRemoteExecutionUnsupported is returned when remote execution is requested
+	// for a connection that has no usable WinRM configuration.
+	ErrRemoteExecutionUnsupported = errors.New("remote execution requires a SQL Server connection with WinRM enabled")
+
+	// ErrAgentNotFound is returned when a task names an agent that is not configured.
+	ErrAgentNotFound = errors.New("agent not found")
+
+	// errRemoteCredentialFiles is returned for a command with credential
+	// files on a WinRM host; agents create them in the work directory.
+	errRemoteCredentialFiles = errors.New("credential files are not supported over WinRM")
+)
+
+// =============================================================================
+// Remote Target Resolution
+// =============================================================================
+
+// resolveWinRMTarget returns the WinRM configuration used to run the benchmark
+// tool on the database host itself.
+func resolveWinRMTarget(conn connection.Connection) (*connection.WinRMConfig, error) {
+	sqlConn, ok := conn.(*connection.SQLServerConnection)
+	if !ok {
+		return nil, fmt.Errorf("%w: connection type is %s", ErrRemoteExecutionUnsupported, conn.GetType())
+	}
+
+	cfg := sqlConn.GetWinRMConfig()
+	if cfg == nil || !cfg.Enabled {
+		return nil, ErrRemoteExecutionUnsupported
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("winrm config: %w", err)
+	}
+	return cfg, nil
+}
+
+// =============================================================================
+// Remote Hosts
+// =============================================================================
+
+// AgentLookup returns the settings of a load-generator agent by name.
+type AgentLookup func(ctx context.Context, name string) (*config.AgentConfig, error)
+
+// remoteHost is a host the benchmark tool runs on instead of the local
+// machine: the SQL Server host via WinRM, or a load-generator agent.
+type remoteHost interface {
+	// Name identifies the host in logs and messages.
+	Name() string
+	// Via names the transport, e.g. WinRM.
+	Via() string
+	// Run runs cmd on the host, streaming its output to stdout and stderr.
+	// Cancelling ctx terminates the command. Returns the remote exit code.
+	Run(ctx context.Context, cmd *adapter.Command, stdout, stderr io.Writer) (int, error)
+	// LookPath resolves an executable in PATH of the host.
+	LookPath(ctx context.Context, name string) (string, error)
+}
+
+// winrmHost runs commands on a SQL Server host through cmd.exe over WinRM.
+type winrmHost struct {
+	cfg *connection.WinRMConfig
+}
+
+func (h *winrmHost) Name() string { return h.cfg.Host }
+
+func (h *winrmHost) Via() string { return "WinRM" }
+
+func (h *winrmHost) Run(ctx context.Context, cmd *adapter.Command, stdout, stderr io.Writer) (int, error) {
+	if len(cmd.Files) > 0 {
+		return -1, errRemoteCredentialFiles
+	}
+	client, err := connection.NewWinRMClient(ctx, h.cfg)
+	if err != nil {
+		return -1, err
+	}
+	defer client.Close()
+	cmdLine, stdin, err := buildRemoteCommandLine(cmd)
+	if err != nil {
+		return -1, err
+	}
+	return client.Run(ctx, cmdLine, stdin, stdout, stderr)
+}
+
+func (h *winrmHost) LookPath(ctx context.Context, name string) (string, error) {
+	client, err := connection.NewWinRMClient(ctx, h.cfg)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+	return client.LookPath(ctx, name)
+}
+
+// agentHost runs commands on a load-generator agent. Every command uses its
+// own connection, authenticated with the controller key at keyPath.
+type agentHost struct {
+	cfg     config.AgentConfig
+	keyPath string
+}
+
+func (h *agentHost) Name() string { return fmt.Sprintf("%s (%s)", h.cfg.Name, h.cfg.Address) }
+
+func (h *agentHost) Via() string { return "agent" }
+
+func (h *agentHost) dial(ctx context.Context) (*agent.Client, error) {
+	key, err := agent.LoadOrCreateKey(h.keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("agent key: %w", err)
+	}
+	return agent.Dial(ctx, h.cfg.Address, h.cfg.HostKey, key)
+}
+
+func (h *agentHost) Run(ctx context.Context, cmd *adapter.Command, stdout, stderr io.Writer) (int, error) {
+	// The agent runs the tool directly, without a shell
+	args, err := parseCommandLine(cmd.CmdLine)
+	if err != nil {
+		return -1, err
+	}
+	client, err := h.dial(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer client.Close()
+	// The agent creates the credential files in the work directory of the command
+	return client.Run(ctx, args, cmd.Env, cmd.Files, commandStdin(cmd), stdout, stderr)
+}
+
+func (h *agentHost) LookPath(ctx context.Context, name string) (string, error) {
+	client, err := h.dial(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+	return client.LookPath(ctx, name)
+}
+
+// SetAgents sets how agents named in the task options are found, and the
+// controller key the agents must list in their authorized keys file.
+func (uc *BenchmarkUseCase) SetAgents(lookup AgentLookup, keyPath string) {
+	uc.agentLookup = lookup
+	uc.agentKeyPath = keyPath
+}
+
+// resolveRemoteHost returns the host a task's tool runs on, or nil if it runs
+// locally. Several agents are returned as an agentGroup.
+func (uc *BenchmarkUseCase) resolveRemoteHost(ctx context.Context, conn connection.Connection, options execution.TaskOptions) (remoteHost, error) {
+	switch {
+	case options.RemoteWinRM:
+		cfg, err := resolveWinRMTarget(conn)
+		if err != nil {
+			return nil, err
+		}
+		return &winrmHost{cfg: cfg}, nil
+	case len(options.LoadGenerators()) > 0:
+		if uc.agentLookup == nil {
+			return nil, fmt.Errorf("%w: agents are not configured", ErrAgentNotFound)
+		}
+		var group agentGroup
+		for _, name := range options.LoadGenerators() {
+			cfg, err := uc.agentLookup(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			group = append(group, &agentHost{cfg: *cfg, keyPath: uc.agentKeyPath})
+		}
+		if len(group) == 1 {
+			return group[0], nil
+		}
+		return group, nil
+	}
+	return nil, nil
+}
+
+// setRemoteTarget records the remote host of a run.
+func (uc *BenchmarkUseCase) setRemoteTarget(runID string, host remoteHost) {
+	uc.remoteMu.Lock()
+	defer uc.remoteMu.Unlock()
+	uc.remoteTargets[runID] = host
+}
+
+// clearRemoteTarget removes the remote host of a run.
+func (uc *BenchmarkUseCase) clearRemoteTarget(runID string) {
+	uc.remoteMu.Lock()
+	defer uc.remoteMu.Unlock()
+	delete(uc.remoteTargets, runID)
+}
+
+// remoteTarget returns the remote host of a run, or nil if it runs locally.
+func (uc *BenchmarkUseCase) remoteTarget(runID string) remoteHost {
+	uc.remoteMu.RLock()
+	defer uc.remoteMu.RUnlock()
+	return uc.remoteTargets[runID]
+}
+
+// cancelRemoteCommand cancels a running remote command.
+// Returns false if the run has no remote command in flight.
+func (uc *BenchmarkUseCase) cancelRemoteCommand(runID string) bool {
+	uc.remoteMu.Lock()
+	cancel := uc.remoteCancels[runID]
+	delete(uc.remoteCancels, runID)
+	uc.remoteMu.Unlock()
+
+	if cancel == nil {
+		return false
+	}
+	cancel()
+	return true
+}
+
+// =============================================================================
+// Remote Command Execution
+// =============================================================================
+
+// checkRemoteTool verifies that the adapter's tool is installed on the remote host.
+func (uc *BenchmarkUseCase) checkRemoteTool(ctx context.Context, host remoteHost, adapt adapter.BenchmarkAdapter) error {
+	checkCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	binary := remoteToolBinary(adapt)
+	path, err := host.LookPath(checkCtx, binary)
+	if err != nil {
+		return fmt.Errorf("tool %s not installed on %s: %w", binary, host.Name(), err)
+	}
+
+	slog.Info("Benchmark: Remote tool found", "tool", binary, "host", host.Name(), "via", host.Via(), "path", path)
+	return nil
+}
+
+// executeRemoteCommand runs a command on the remote host and saves its output
+// to the run logs as it arrives.
+func (uc *BenchmarkUseCase) executeRemoteCommand(ctx context.Context, run *execution.Run, host remoteHost, cmd *adapter.Command) error {
+	slog.Info("Benchmark: === EXECUTING REMOTE COMMAND ===",
+		"run_id", run.ID,
+		"host", host.Name(),
+		"via", host.Via(),
+		"env_count", len(cmd.Env))
+
+	var output bytes.Buffer
+	var outputMu sync.Mutex
+	logLine := func(stream string) func(string) {
+		return func(line string) {
+			outputMu.Lock()
+			output.WriteString(line + "\n")
+			outputMu.Unlock()
+			uc.saveRemoteLog(ctx, run.ID, stream, line)
+		}
+	}
+	stdout := newLineWriter(logLine("stdout"))
+	stderr := newLineWriter(logLine("stderr"))
+
+	code, err := host.Run(ctx, cmd, stdout, stderr)
+	stdout.Flush()
+	stderr.Flush()
+	uc.saveArtifactLog(run, "remote command", output.String())
+
+	if err == nil && code != 0 {
+		err = fmt.Errorf("remote exit code %d", code)
+	}
+	if err != nil {
+		slog.Error("Benchmark: Remote command failed", "run_id", run.ID, "host", host.Name(), "error", err)
+		return fmt.Errorf("command failed with exit status %v: %w", err, fmt.Errorf("output:\n%s", output.String()))
+	}
+	return nil
+}
+
+// startRemoteCommand starts a command on the remote host without waiting for it.
+// stdout is returned as a stream for realtime collection, stderr goes to the run
+// logs, and the command's final error is sent to done.
+func (uc *BenchmarkUseCase) startRemoteCommand(
+	ctx context.Context,
+	run *execution.Run,
+	host remoteHost,
+	cmd *adapter.Command,
+	done chan<- error,
+) (io.ReadCloser, error) {
+	cmdCtx, cancel := context.WithCancel(ctx)
+	uc.remoteMu.Lock()
+	uc.remoteCancels[run.ID] = cancel
+	uc.remoteMu.Unlock()
+
+	slog.Info("Benchmark: Starting remote command", "run_id", run.ID, "host", host.Name(), "via", host.Via())
+
+	pr, pw := io.Pipe()
+	stderr := newLineWriter(func(line string) {
+		uc.saveRemoteLog(ctx, run.ID, "stderr", line)
+	})
+
+	go func() {
+		defer func() {
+			uc.remoteMu.Lock()
+			delete(uc.remoteCancels, run.ID)
+			uc.remoteMu.Unlock()
+			cancel()
+		}()
+
+		code, err := host.Run(cmdCtx, cmd, pw, stderr)
+		stderr.Flush()
+		if err == nil && code != 0 {
+			err = fmt.Errorf("remote exit code %d", code)
+		}
+		pw.CloseWithError(err)
+		done <- err
+	}()
+
+	return pr, nil
+}
+
+// saveRemoteLog saves a single line of remote output. Errors on the stderr of
+// a run phase are counted by class.
+func (uc *BenchmarkUseCase) saveRemoteLog(ctx context.Context, runID, stream, line string) {
+	if stream == "stderr" {
+		uc.errorCounter(runID).countLine(line)
+	}
+	uc.saveLogEntry(ctx, runID, LogEntry{
+		Timestamp: time.Now().Format(time.RFC3339),
+		Stream:    stream,
+		Content:   line,
+	})
+}
+
+// buildRemoteCommandLine converts an adapter command into a remote command
+// line and its stdin. A command with environment variables (passwords such as
+// MYSQL_PWD) runs in a PowerShell wrapper that reads their values from the
+// first lines of stdin and sets them on the tool process only, so that no
+// secret appears in a command line or in the WinRM and event logs; the rest of
+// stdin is passed on to the tool.
+func buildRemoteCommandLine(cmd *adapter.Command) (string, io.Reader, error) {
+	if len(cmd.Env) == 0 {
+		return cmd.CmdLine, commandStdin(cmd), nil
+	}
+
+	var script, values strings.Builder
+	script.WriteString("$in = [Console]::In\n")
+	script.WriteString("$psi = New-Object Diagnostics.ProcessStartInfo 'cmd.exe', " + powerShellQuote("/c "+cmd.CmdLine) + "\n")
+	script.WriteString("$psi.UseShellExecute = $false; $psi.RedirectStandardInput = $true\n")
+	for _, env := range cmd.Env {
+		name, value, _ := strings.Cut(env, "=")
+		if strings.ContainsAny(value, "\r\n") {
+			return "", nil, fmt.Errorf("environment variable %s contains a line break", name)
+		}
+		fmt.Fprintf(&script, "$psi.EnvironmentVariables[%s] = $in.ReadLine()\n", powerShellQuote(name))
+		values.WriteString(value + "\n")
+	}
+	script.WriteString("$p = [Diagnostics.Process]::Start($psi)\n")
+	script.WriteString("$p.StandardInput.Write($in.ReadToEnd()); $p.StandardInput.Close()\n")
+	script.WriteString("$p.WaitForExit(); exit $p.ExitCode")
+
+	return powerShellCommand(script.String()), strings.NewReader(values.String() + cmd.Stdin), nil
+}
+
+// powerShellCommand returns the cmd.exe command line that runs a PowerShell
+// script, encoded so that it needs no quoting.
+func powerShellCommand(script string) string {
+	units := utf16.Encode([]rune(script))
+	b := make([]byte, 2*len(units))
+	for i, u := range units {
+		b[2*i], b[2*i+1] = byte(u), byte(u>>8)
+	}
+	return "powershell -NoProfile -NonInteractive -EncodedCommand " + base64.StdEncoding.EncodeToString(b)
+}
+
+// powerShellQuote quotes a string for PowerShell.
+func powerShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// commandStdin returns a reader for the command's stdin, or nil if it has none.
+func commandStdin(cmd *adapter.Command) io.Reader {
+	if cmd.Stdin == "" {
+		return nil
+	}
+	return strings.NewReader(cmd.Stdin)
+}
+
+// remoteToolBinary returns the executable name of an adapter's tool.
+func remoteToolBinary(adapt adapter.BenchmarkAdapter) string {
+	switch adapt.(type) {
+	case *adapter.SysbenchAdapter:
+		return adapter.DefaultSysbenchPath
+	case *adapter.HammerDBAdapter:
+		return adapter.DefaultHammerDBPath
+	default:
+		return string(adapt.Type())
+	}
+}
+
+// lineWriter is an io.Writer that calls emit for every complete output line.
+type lineWriter struct {
+	mu   sync.Mutex
+	buf  []byte
+	emit func(string)
+}
+
+// newLineWriter creates a new line writer.
+func newLineWriter(emit func(string)) *lineWriter {
+	return &lineWriter{emit: emit}
+}
+
+// Write implements io.Writer.
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+		w.emitLine(w.buf[:idx])
+		w.buf = w.buf[idx+1:]
+	}
+	return len(p), nil
+}
+
+// Flush emits any buffered partial line.
+func (w *lineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) > 0 {
+		w.emitLine(w.buf)
+		w.buf = nil
+	}
+}
+
+func (w *lineWriter) emitLine(b []byte) {
+	line := strings.TrimRight(string(b), "\r")
+	if line != "" {
+		w.emit(line)
+	}
+}
@@ -0,0 +1,224 @@
+// Package usecase provides unit tests for remote execution helpers.
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/agent"
+)
+
+func TestResolveWinRMTarget(t *testing.T) {
+	winrmCfg := &connection.WinRMConfig{Enabled: true, Host: "sql01", Port: 5985}
+
+	tests := []struct {
+		name    string
+		conn    connection.Connection
+		wantErr bool
+	}{
+		{
+			name:    "sql server with winrm",
+			conn:    &connection.SQLServerConnection{Host: "sql01", WinRM: winrmCfg},
+			wantErr: false,
+		},
+		{
+			name:    "sql server without winrm",
+			conn:    &connection.SQLServerConnection{Host: "sql01"},
+			wantErr: true,
+		},
+		{
+			name:    "sql server with winrm disabled",
+			conn:    &connection.SQLServerConnection{Host: "sql01", WinRM: &connection.WinRMConfig{Host: "sql01", Port: 5985}},
+			wantErr: true,
+		},
+		{
+			name:    "mysql connection",
+			conn:    &connection.MySQLConnection{Host: "db01"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := resolveWinRMTarget(tt.conn)
+			if tt.wantErr {
+				if !errors.Is(err, ErrRemoteExecutionUnsupported) {
+					t.Errorf("resolveWinRMTarget() error = %v, want ErrRemoteExecutionUnsupported", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveWinRMTarget() unexpected error: %v", err)
+			}
+			if cfg != winrmCfg {
+				t.Errorf("resolveWinRMTarget() returned unexpected config")
+			}
+		})
+	}
+}
+
+func TestBuildRemoteCommandLine(t *testing.T) {
+	cmd := &adapter.Command{CmdLine: "hammerdbcli", Stdin: "script"}
+	cmdLine, stdin, err := buildRemoteCommandLine(cmd)
+	if err != nil || cmdLine != "hammerdbcli" {
+		t.Errorf("buildRemoteCommandLine() = %q, %v, want the command line unchanged", cmdLine, err)
+	}
+	if b, _ := io.ReadAll(stdin); string(b) != "script" {
+		t.Errorf("stdin = %q, want script", b)
+	}
+}
+
+// TestBuildRemoteCommandLine_Env tests that environment variables are sent on
+// stdin, not in the command line.
+func TestBuildRemoteCommandLine_Env(t *testing.T) {
+	cmd := &adapter.Command{
+		CmdLine: "sysbench oltp_read_write run",
+		Env:     []string{"MYSQL_PWD=s3cr3t pass", "B=two"},
+		Stdin:   "input",
+	}
+
+	cmdLine, stdin, err := buildRemoteCommandLine(cmd)
+	if err != nil {
+		t.Fatalf("buildRemoteCommandLine() error = %v", err)
+	}
+	encoded, ok := strings.CutPrefix(cmdLine, "powershell -NoProfile -NonInteractive -EncodedCommand ")
+	if !ok {
+		t.Fatalf("command line = %s, want an encoded PowerShell command", cmdLine)
+	}
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = uint16(b[2*i]) | uint16(b[2*i+1])<<8
+	}
+	script := string(utf16.Decode(units))
+	if strings.Contains(cmdLine, "s3cr3t") || strings.Contains(script, "s3cr3t") {
+		t.Errorf("the password is in the command line: %s", script)
+	}
+	for _, want := range []string{"'/c sysbench oltp_read_write run'", "$psi.EnvironmentVariables['MYSQL_PWD'] = $in.ReadLine()"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script does not contain %q:\n%s", want, script)
+		}
+	}
+	if in, _ := io.ReadAll(stdin); string(in) != "s3cr3t pass\ntwo\ninput" {
+		t.Errorf("stdin = %q, want the values and then the command's stdin", in)
+	}
+
+	cmd.Env = []string{"MYSQL_PWD=a\nb"}
+	if _, _, err := buildRemoteCommandLine(cmd); err == nil {
+		t.Error("buildRemoteCommandLine() error = nil for a value with a line break")
+	}
+}
+
+func TestLineWriter(t *testing.T) {
+	var lines []string
+	w := newLineWriter(func(line string) {
+		lines = append(lines, line)
+	})
+
+	w.Write([]byte("first\r\nsec"))
+	w.Write([]byte("ond\n\nthird"))
+	w.Flush()
+
+	want := []string{"first", "second", "third"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %v, want %v", lines, want)
+	}
+}
+
+func TestResolveRemoteHost(t *testing.T) {
+	ctx := context.Background()
+	uc := &BenchmarkUseCase{}
+	conn := &connection.MySQLConnection{Host: "db01"}
+
+	host, err := uc.resolveRemoteHost(ctx, conn, execution.TaskOptions{})
+	if err != nil || host != nil {
+		t.Fatalf("resolveRemoteHost() for a local run = %v, %v", host, err)
+	}
+	if _, err := uc.resolveRemoteHost(ctx, conn, execution.TaskOptions{Agent: "loadgen-1"}); !errors.Is(err, ErrAgentNotFound) {
+		t.Errorf("resolveRemoteHost() without agents error = %v, want ErrAgentNotFound", err)
+	}
+
+	uc.SetAgents(func(ctx context.Context, name string) (*config.AgentConfig, error) {
+		if name != "loadgen-1" {
+			return nil, ErrAgentNotFound
+		}
+		return &config.AgentConfig{Name: name, Address: "10.0.0.20:7422", HostKey: "SHA256:x"}, nil
+	}, "agent_key")
+	host, err = uc.resolveRemoteHost(ctx, conn, execution.TaskOptions{Agent: "loadgen-1"})
+	if err != nil {
+		t.Fatalf("resolveRemoteHost() error = %v", err)
+	}
+	if host.Name() != "loadgen-1 (10.0.0.20:7422)" || host.Via() != "agent" {
+		t.Errorf("resolveRemoteHost() = %s via %s", host.Name(), host.Via())
+	}
+}
+
+// startLocalAgent starts an agent on localhost that authorizes the returned host's key.
+func startLocalAgent(t *testing.T, name string) *agentHost {
+	t.Helper()
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "agent_key")
+	key, err := agent.LoadOrCreateKey(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorizedKeys := filepath.Join(dir, "authorized_keys")
+	if err := os.WriteFile(authorizedKeys, []byte(agent.AuthorizedKey(key)+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	server, err := agent.NewServer(agent.ServerConfig{HostKeyPath: filepath.Join(dir, "host_key"), AuthorizedKeysPath: authorizedKeys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go server.Serve(ctx, l)
+
+	return &agentHost{
+		cfg:     config.AgentConfig{Name: name, Address: l.Addr().String(), HostKey: server.Fingerprint()},
+		keyPath: keyPath,
+	}
+}
+
+// TestAgentHost_Run runs an adapter command on a local agent.
+func TestAgentHost_Run(t *testing.T) {
+	ctx := context.Background()
+	host := startLocalAgent(t, "local")
+	if _, err := host.LookPath(ctx, "sh"); err != nil {
+		t.Fatalf("LookPath(sh) error = %v", err)
+	}
+
+	cmd := &adapter.Command{
+		CmdLine: `sh -c 'echo "[ 1s ] thds: $THREADS"; echo warning >&2'`,
+		Env:     []string{"THREADS=8"},
+	}
+	var stdout, stderr bytes.Buffer
+	code, err := host.Run(ctx, cmd, &stdout, &stderr)
+	if err != nil || code != 0 {
+		t.Fatalf("Run() = %d, %v", code, err)
+	}
+	if stdout.String() != "[ 1s ] thds: 8\n" || stderr.String() != "warning\n" {
+		t.Errorf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+}
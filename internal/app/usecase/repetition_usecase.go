@@ -0,0 +1,174 @@
+// Package usecase provides repeated benchmark business logic.
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// RepetitionUseCase runs a task N times and aggregates the results.
+// The runs are saved to history tagged "repeat:<aggregate-id>"; runs flagged
+// as outliers are also tagged "outlier".
+type RepetitionUseCase struct {
+	runner        BenchmarkRunner
+	historyUC     *HistoryUseCase
+	aggregateRepo repository.AggregateRepository
+}
+
+// NewRepetitionUseCase creates a new repetition use case.
+func NewRepetitionUseCase(runner BenchmarkRunner, historyUC *HistoryUseCase, aggregateRepo repository.AggregateRepository) *RepetitionUseCase {
+	return &RepetitionUseCase{
+		runner:        runner,
+		historyUC:     historyUC,
+		aggregateRepo: aggregateRepo,
+	}
+}
+
+// RepetitionStartedFunc is called when a repetition's run has started.
+type RepetitionStartedFunc func(repetition, total int, run *execution.Run)
+
+// RunRepeated runs the task task.Options.Repeat times, one run after another,
+// and returns the aggregate of the completed runs. Data is prepared before the
+// first run and cleaned up after the last one only.
+//
+// The series stops at the first run that does not complete, or when ctx is
+// cancelled. The aggregate of the runs completed so far is still saved and
+// returned together with an error describing why the series was cut short.
+func (uc *RepetitionUseCase) RunRepeated(ctx context.Context, task *execution.BenchmarkTask, onStarted RepetitionStartedFunc) (*history.Aggregate, error) {
+	if err := task.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrPreCheckFailed, err)
+	}
+
+	total := task.Options.Repetitions()
+	aggregateID := uuid.New().String()
+	tag := history.AggregateTagPrefix + aggregateID
+	slog.Info("Repetition: Series started", "task", task.Name, "aggregate_id", aggregateID, "repeat", total)
+
+	var records []*history.Record
+	var seriesErr error
+	for rep := 1; rep <= total; rep++ {
+		if ctx.Err() != nil {
+			seriesErr = fmt.Errorf("stopped after %d of %d repetitions: %w", rep-1, total, ctx.Err())
+			break
+		}
+
+		record, err := uc.runRepetition(ctx, task, rep, total, tag, onStarted)
+		if err != nil {
+			seriesErr = fmt.Errorf("repetition %d of %d: %w", rep, total, err)
+			break
+		}
+		records = append(records, record)
+	}
+
+	if len(records) == 0 {
+		slog.Error("Repetition: No run completed", "task", task.Name, "aggregate_id", aggregateID, "error", seriesErr)
+		return nil, seriesErr
+	}
+
+	agg := history.NewAggregate(records, task.Options.OutlierK())
+	agg.ID = aggregateID
+	agg.Name = task.Name
+	agg.CreatedAt = time.Now()
+	agg.Repeat = total
+
+	saveCtx := context.WithoutCancel(ctx)
+	for _, r := range records {
+		if !agg.IsOutlier(r.ID) {
+			continue
+		}
+		if err := uc.historyUC.UpdateAnnotations(saveCtx, r.ID, []string{tag, history.OutlierTag}, r.Notes); err != nil {
+			slog.Warn("Repetition: Failed to tag outlier", "run_id", r.ID, "error", err)
+		}
+	}
+
+	// Each run was checked when saved, before the series was complete
+	for _, r := range records {
+		if _, err := uc.historyUC.EvaluateRecord(saveCtx, r.ID); err != nil {
+			slog.Warn("Repetition: Failed to evaluate sanity checks", "run_id", r.ID, "error", err)
+		}
+	}
+
+	if err := uc.aggregateRepo.SaveAggregate(saveCtx, agg); err != nil {
+		return agg, fmt.Errorf("save aggregate: %w", err)
+	}
+
+	slog.Info("Repetition: Series finished", "task", task.Name, "aggregate_id", agg.ID,
+		"completed", len(agg.RunIDs), "repeat", total, "outliers", len(agg.Outliers),
+		"tps_mean", agg.TPS.Mean, "tps_cv", agg.TPS.CV)
+	return agg, seriesErr
+}
+
+// runRepetition runs one repetition and saves it to history, tagged with the aggregate.
+func (uc *RepetitionUseCase) runRepetition(ctx context.Context, task *execution.BenchmarkTask, rep, total int, tag string, onStarted RepetitionStartedFunc) (*history.Record, error) {
+	started, err := uc.runner.StartBenchmark(ctx, repetitionTask(task, rep, total))
+	if err != nil {
+		return nil, fmt.Errorf("start run: %w", err)
+	}
+	slog.Info("Repetition: Run started", "task", task.Name, "repetition", rep, "repeat", total, "run_id", started.ID)
+	if onStarted != nil {
+		onStarted(rep, total, started)
+	}
+
+	final := waitForRun(ctx, uc.runner, started.ID)
+	if final == nil {
+		return nil, fmt.Errorf("run %s: status unavailable", started.ID)
+	}
+	if final.State != execution.StateCompleted || final.Result == nil {
+		if final.ErrorMessage != "" {
+			return nil, fmt.Errorf("run %s %s: %s", final.ID, final.State, final.ErrorMessage)
+		}
+		return nil, fmt.Errorf("run %s %s", final.ID, final.State)
+	}
+
+	historyCtx := context.WithoutCancel(ctx)
+	if err := uc.historyUC.SaveRunToHistory(historyCtx, final); err != nil {
+		return nil, fmt.Errorf("save run to history: %w", err)
+	}
+	notes := fmt.Sprintf("Repetition %d of %d", rep, total)
+	if err := uc.historyUC.UpdateAnnotations(historyCtx, final.ID, []string{tag}, notes); err != nil {
+		slog.Warn("Repetition: Failed to tag history record", "run_id", final.ID, "error", err)
+	}
+
+	record, err := uc.historyUC.GetRecordByID(historyCtx, final.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get history record: %w", err)
+	}
+	return record, nil
+}
+
+// repetitionTask returns the task of one repetition. Only the first repetition
+// prepares data and only the last one cleans it up, unless the task skips them anyway.
+func repetitionTask(task *execution.BenchmarkTask, rep, total int) *execution.BenchmarkTask {
+	t := *task
+	t.ID = uuid.New().String()
+	t.Name = fmt.Sprintf("%s #%d", task.Name, rep)
+	t.Parameters = maps.Clone(task.Parameters)
+	t.CreatedAt = time.Now()
+	t.Options.Repeat = 0
+	if rep > 1 {
+		t.Options.SkipPrepare = true
+	}
+	if rep < total {
+		t.Options.SkipCleanup = true
+	}
+	return &t
+}
+
+// ListAggregates returns the aggregates of repeated tasks, newest first.
+func (uc *RepetitionUseCase) ListAggregates(ctx context.Context) ([]*history.Aggregate, error) {
+	return uc.aggregateRepo.ListAggregates(ctx)
+}
+
+// GetAggregate returns the aggregate of a repeated task.
+func (uc *RepetitionUseCase) GetAggregate(ctx context.Context, id string) (*history.Aggregate, error) {
+	return uc.aggregateRepo.GetAggregate(ctx, id)
+}
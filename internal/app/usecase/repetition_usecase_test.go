@@ -0,0 +1,127 @@
+// Package usecase provides unit tests for repeated benchmarks.
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// mockAggregateRepository is an in-memory AggregateRepository.
+type mockAggregateRepository struct {
+	aggregates map[string]*history.Aggregate
+}
+
+func (m *mockAggregateRepository) SaveAggregate(ctx context.Context, a *history.Aggregate) error {
+	m.aggregates[a.ID] = a
+	return nil
+}
+
+func (m *mockAggregateRepository) GetAggregate(ctx context.Context, id string) (*history.Aggregate, error) {
+	a, ok := m.aggregates[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return a, nil
+}
+
+func (m *mockAggregateRepository) ListAggregates(ctx context.Context) ([]*history.Aggregate, error) {
+	var aggregates []*history.Aggregate
+	for _, a := range m.aggregates {
+		aggregates = append(aggregates, a)
+	}
+	return aggregates, nil
+}
+
+func (m *mockAggregateRepository) DeleteAggregate(ctx context.Context, id string) error {
+	delete(m.aggregates, id)
+	return nil
+}
+
+// newTestRepetitionUseCase creates a repetition use case with in-memory repositories.
+func newTestRepetitionUseCase(t *testing.T, runner BenchmarkRunner) (*RepetitionUseCase, *mockHistoryRepository, *mockAggregateRepository) {
+	t.Helper()
+	runPollInterval = time.Millisecond
+	t.Cleanup(func() { runPollInterval = time.Second })
+
+	historyRepo := newMockHistoryRepository()
+	aggregateRepo := &mockAggregateRepository{aggregates: make(map[string]*history.Aggregate)}
+	return NewRepetitionUseCase(runner, NewHistoryUseCase(historyRepo), aggregateRepo), historyRepo, aggregateRepo
+}
+
+// repeatedTask returns a task repeated n times.
+func repeatedTask(template string, n int) *execution.BenchmarkTask {
+	return &execution.BenchmarkTask{
+		ID:           "task-1",
+		Name:         "mysql Benchmark",
+		ConnectionID: "conn-1",
+		TemplateID:   template,
+		Parameters:   map[string]interface{}{"threads": 8},
+		Options:      execution.TaskOptions{Repeat: n},
+	}
+}
+
+// TestRepetitionUseCase_RunRepeated tests repeating a task, flagging outliers and saving the aggregate.
+func TestRepetitionUseCase_RunRepeated(t *testing.T) {
+	ctx := context.Background()
+	runner := newMockBenchmarkRunner()
+	runner.tps = []float64{1000, 1010, 990, 1005, 995, 1000, 400}
+	uc, historyRepo, aggregateRepo := newTestRepetitionUseCase(t, runner)
+
+	var started []int
+	agg, err := uc.RunRepeated(ctx, repeatedTask("oltp", 7), func(rep, total int, run *execution.Run) {
+		started = append(started, rep)
+	})
+	if err != nil {
+		t.Fatalf("RunRepeated() failed: %v", err)
+	}
+	if len(started) != 7 || len(agg.RunIDs) != 7 || agg.Repeat != 7 {
+		t.Fatalf("aggregate = %d of %d runs, started %v, want all 7", len(agg.RunIDs), agg.Repeat, started)
+	}
+	if agg.TPS.Mean != 1000 || len(agg.Outliers) != 1 || !agg.IsOutlier("run-7") {
+		t.Errorf("aggregate TPS = %+v, outliers %v, want mean 1000 and run-7 flagged", agg.TPS, agg.Outliers)
+	}
+	if _, err := aggregateRepo.GetAggregate(ctx, agg.ID); err != nil {
+		t.Errorf("aggregate not saved: %v", err)
+	}
+
+	for i, task := range runner.tasks {
+		first, last := i == 0, i == len(runner.tasks)-1
+		if task.Options.SkipPrepare == first || task.Options.SkipCleanup == last || task.Options.Repeat != 0 {
+			t.Errorf("task %d options = %+v, want prepare only first and cleanup only last", i+1, task.Options)
+		}
+	}
+
+	outlier, _ := historyRepo.GetByID(ctx, "run-7")
+	if !slices.Contains(outlier.Tags, agg.Tag()) || !slices.Contains(outlier.Tags, history.OutlierTag) {
+		t.Errorf("outlier tags = %v, want %s and %s", outlier.Tags, agg.Tag(), history.OutlierTag)
+	}
+	normal, _ := historyRepo.GetByID(ctx, "run-1")
+	if len(normal.Tags) != 1 || normal.Tags[0] != agg.Tag() || normal.Notes != "Repetition 1 of 7" {
+		t.Errorf("run-1 tags = %v, notes %q", normal.Tags, normal.Notes)
+	}
+}
+
+// TestRepetitionUseCase_FailedRun tests that a failed run stops the series.
+func TestRepetitionUseCase_FailedRun(t *testing.T) {
+	ctx := context.Background()
+	runner := newMockBenchmarkRunner()
+	uc, _, aggregateRepo := newTestRepetitionUseCase(t, runner)
+
+	agg, err := uc.RunRepeated(ctx, repeatedTask("broken", 3), nil)
+	if err == nil || agg != nil {
+		t.Errorf("RunRepeated(failing) = %v, %v, want an error and no aggregate", agg, err)
+	}
+	if len(runner.tasks) != 1 || len(aggregateRepo.aggregates) != 0 {
+		t.Errorf("started %d runs and saved %d aggregates, want 1 and 0", len(runner.tasks), len(aggregateRepo.aggregates))
+	}
+
+	if _, err := uc.RunRepeated(ctx, repeatedTask("oltp", execution.MaxRepeat+1), nil); !errors.Is(err, ErrPreCheckFailed) {
+		t.Errorf("RunRepeated(too many) error = %v, want ErrPreCheckFailed", err)
+	}
+}
@@ -0,0 +1,128 @@
+// Package usecase provides comparison report bundles: a report and the
+// exports of its records in one ZIP file.
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/comparison"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// Entries of report bundles.
+const (
+	bundleReportMarkdown = "comparison_report.md"
+	bundleReportHTML     = "comparison_report.html"
+	bundleRecordsDir     = "records" // Markdown exports of the records, with their kept run artifacts
+)
+
+// SetExportUseCase sets the export use case that exports the records of
+// report bundles.
+func (uc *ComparisonUseCase) SetExportUseCase(exportUC *ExportUseCase) {
+	uc.exportUC = exportUC
+}
+
+// ExportReportBundle exports a simplified report as one ZIP file that can be
+// shared as a complete evidence package: the report in Markdown and HTML and
+// the Markdown export of every selected record, including those excluded by
+// their sanity checks, with the run artifacts kept for them.
+func (uc *ComparisonUseCase) ExportReportBundle(ctx context.Context, report *comparison.SimplifiedReport, dest string) error {
+	if report == nil {
+		return fmt.Errorf("report is nil")
+	}
+	if uc.exportUC == nil {
+		return fmt.Errorf("record exports are not available")
+	}
+
+	var records []*history.Record
+	for _, ref := range append(append([]*comparison.RecordRef{}, report.Records...), report.Excluded...) {
+		record, err := uc.historyRepo.GetByID(ctx, ref.ID)
+		if err != nil {
+			return fmt.Errorf("get history record %s: %w", ref.ID, err)
+		}
+		records = append(records, record)
+	}
+
+	staging, err := os.MkdirTemp("", "db-benchmind-bundle-")
+	if err != nil {
+		return fmt.Errorf("create staging directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	if err := os.WriteFile(filepath.Join(staging, bundleReportMarkdown), []byte(report.FormatMarkdown()), 0644); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(staging, bundleReportHTML), []byte(report.FormatHTML()), 0644); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	if len(records) > 0 {
+		if _, err := uc.exportUC.ExportRecordsTo(ctx, records, FormatMarkdown, filepath.Join(staging, bundleRecordsDir)); err != nil {
+			return fmt.Errorf("export records: %w", err)
+		}
+	}
+
+	if err := writeZip(dest, staging); err != nil {
+		return err
+	}
+	slog.Info("Comparison: Report bundle exported", "filepath", dest, "report_id", report.ReportID, "records", len(records))
+	return nil
+}
+
+// writeZip writes the files below dir to a ZIP file at dest.
+func writeZip(dest, dir string) error {
+	files, err := listFiles(dir)
+	if err != nil {
+		return fmt.Errorf("list bundle files: %w", err)
+	}
+
+	file, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("create bundle file: %w", err)
+	}
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	for _, rel := range files {
+		if err := writeZipFile(zw, path.Clean(filepath.ToSlash(rel)), filepath.Join(dir, rel)); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("write bundle file: %w", err)
+	}
+	return file.Close()
+}
+
+// writeZipFile adds the file at src to the ZIP file as name.
+func writeZipFile(zw *zip.Writer, name, src string) error {
+	file, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", src, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", src, err)
+	}
+	hdr, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	hdr.Name, hdr.Method = name, zip.Deflate
+	w, err := zw.CreateHeader(hdr)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	if _, err := io.Copy(w, file); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
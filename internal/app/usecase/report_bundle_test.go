@@ -0,0 +1,65 @@
+// Package usecase provides unit tests for comparison report bundles.
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/comparison"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// TestExportReportBundle tests that a bundle holds the report and the exports
+// of its records with their kept artifacts.
+func TestExportReportBundle(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockHistoryRepository()
+	start := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+	for i, threads := range []int{8, 16} {
+		repo.Save(ctx, &history.Record{
+			ID: []string{"run-1", "run-2"}[i], ConnectionName: "prod", TemplateName: "OLTP", Threads: threads,
+			StartTime: start.Add(time.Duration(i) * time.Hour), TPSCalculated: float64(1000 * threads), LatencyP95: 10,
+		})
+	}
+	artifacts := t.TempDir()
+	writeArtifact(t, artifacts, "run-1", ArtifactOutputLog, "tps: 8000")
+
+	exportUC := NewExportUseCase(t.TempDir())
+	exportUC.SetArtifactDir(artifacts)
+	comparisonUC := NewComparisonUseCase(repo, nil)
+	comparisonUC.SetExportUseCase(exportUC)
+
+	report, err := comparisonUC.GenerateSimplifiedReport(ctx, []string{"run-1", "run-2"}, comparison.GroupByThreads)
+	if err != nil {
+		t.Fatalf("GenerateSimplifiedReport() failed: %v", err)
+	}
+	dest := filepath.Join(t.TempDir(), "bundle.zip")
+	if err := comparisonUC.ExportReportBundle(ctx, report, dest); err != nil {
+		t.Fatalf("ExportReportBundle() failed: %v", err)
+	}
+
+	zr, err := zip.OpenReader(dest)
+	if err != nil {
+		t.Fatalf("opening the bundle: %v", err)
+	}
+	defer zr.Close()
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	for _, want := range []string{
+		"comparison_report.md",
+		"comparison_report.html",
+		"records/benchmark_OLTP_20260301_020000.md",
+		"records/benchmark_OLTP_20260301_030000.md",
+		"records/benchmark_OLTP_20260301_020000_artifacts/" + ArtifactOutputLog,
+	} {
+		if !slices.Contains(names, want) {
+			t.Errorf("bundle entries = %v, missing %s", names, want)
+		}
+	}
+}
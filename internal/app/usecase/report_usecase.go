@@ -156,23 +156,38 @@ func (uc *ReportUseCase) buildGenerateContext(ctx context.Context, run *executio
 		genCtx.ErrorRate = run.Result.ErrorRate
 	}
 
-	// Get time series samples
-	genCtx.Samples = make([]report.MetricSample, len(run.Result.TimeSeries))
-	for i, s := range run.Result.TimeSeries {
-		genCtx.Samples[i] = report.MetricSample{
+	// Get time series samples of the measured window (warmup samples are excluded)
+	genCtx.Samples = make([]report.MetricSample, 0, len(run.Result.TimeSeries))
+	for _, s := range run.Result.TimeSeries {
+		if s.Phase == "warmup" {
+			continue
+		}
+		genCtx.Samples = append(genCtx.Samples, report.MetricSample{
 			Timestamp:  s.Timestamp,
 			TPS:        s.TPS,
 			LatencyAvg: s.LatencyAvg,
 			LatencyP95: s.LatencyP95,
 			LatencyP99: s.LatencyP99,
 			ErrorRate:  s.ErrorRate,
-		}
+		})
 	}
 
 	// Get logs if requested
 	if config.IncludeLogs {
-		// TODO: Implement log retrieval from run_logs table
 		genCtx.Logs = []report.LogEntry{}
+		if logRepo, ok := uc.runRepo.(RunLogRepository); ok {
+			entries, err := logRepo.FindLogEntries(ctx, run.ID, LogFilter{})
+			if err != nil {
+				return nil, fmt.Errorf("get logs: %w", err)
+			}
+			for _, e := range entries {
+				genCtx.Logs = append(genCtx.Logs, report.LogEntry{
+					Timestamp: e.Timestamp,
+					Stream:    e.Stream,
+					Content:   e.Content,
+				})
+			}
+		}
 	}
 
 	return genCtx, nil
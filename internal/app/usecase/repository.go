@@ -5,10 +5,15 @@ package usecase
 
 import (
 	"context"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/assertion"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/suite"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/template"
 )
 
@@ -25,6 +30,10 @@ type ConnectionRepository interface {
 	// Returns an error if the operation fails.
 	Save(ctx context.Context, conn connection.Connection) error
 
+	// Update updates an existing connection, preserving its ID and creation time.
+	// Returns an error if the connection is not found or operation fails.
+	Update(ctx context.Context, conn connection.Connection) error
+
 	// FindByID finds a connection by its ID.
 	// Returns the connection if found, or an error if not found or operation fails.
 	FindByID(ctx context.Context, id string) (connection.Connection, error)
@@ -123,11 +132,136 @@ type FindOptions struct {
 // LogEntry represents a log entry for a run.
 // Implements: REQ-EXEC-005
 type LogEntry struct {
+	ID        int64  // Sequence number within the run, assigned by the repository
 	Timestamp string // ISO 8601 format
-	Stream    string // "stdout" or "stderr"
+	Stream    string // "stdout", "stderr", "info" or "error"
 	Content   string // Log content
 }
 
+// LogFilter selects the log entries of a run.
+type LogFilter struct {
+	Streams []string // Only these streams (empty = all)
+	Search  string   // Content contains this text, case-insensitive (empty = any)
+	AfterID int64    // Only entries with a greater ID, for following a live log
+	Tail    int      // Only the last N matching entries (0 = all)
+}
+
+// Match reports whether an entry passes the stream, search and AfterID conditions.
+// Tail is applied by the caller over the matching entries.
+func (f LogFilter) Match(entry LogEntry) bool {
+	if entry.ID <= f.AfterID {
+		return false
+	}
+	if len(f.Streams) > 0 && !slices.Contains(f.Streams, entry.Stream) {
+		return false
+	}
+	if f.Search != "" && !strings.Contains(strings.ToLower(entry.Content), strings.ToLower(f.Search)) {
+		return false
+	}
+	return true
+}
+
+// =============================================================================
+// Run Log Repository Interface
+// Implements: REQ-EXEC-005
+// =============================================================================
+
+// RunLogRepository defines the interface for run log persistence.
+type RunLogRepository interface {
+	// SaveLogEntry saves a log entry for a run.
+	SaveLogEntry(ctx context.Context, runID string, entry LogEntry) error
+
+	// FindLogEntries returns the log entries of a run matching filter, oldest first.
+	FindLogEntries(ctx context.Context, runID string, filter LogFilter) ([]LogEntry, error)
+
+	// DeleteLogEntries deletes all log entries of a run.
+	DeleteLogEntries(ctx context.Context, runID string) error
+}
+
+// =============================================================================
+// Process Repository Interface
+// Implements: REQ-EXEC-006
+// =============================================================================
+
+// ProcessRepository records the benchmark tool processes started for runs,
+// so that processes left behind by a crash can be cleaned up on the next startup.
+type ProcessRepository interface {
+	// Save records a started process.
+	Save(ctx context.Context, proc RunProcess) error
+
+	// Delete removes the record of a process by its PID.
+	Delete(ctx context.Context, pid int) error
+
+	// FindAll returns all recorded processes.
+	FindAll(ctx context.Context) ([]RunProcess, error)
+}
+
+// RunProcess represents a benchmark tool process started for a run.
+type RunProcess struct {
+	PID       int       // Process ID, also the process group ID
+	RunID     string    // Run the process belongs to
+	Command   string    // Command line, for logging
+	BootID    string    // System boot the process was started in, empty if unknown
+	StartedAt time.Time // When the process was started
+
+	// Run phase processes write their output to OutputFile instead of a pipe,
+	// so they survive a crash of the application and can be re-attached to
+	OutputFile string       // Tool output file, empty if the process cannot be re-attached
+	Recovery   *RunRecovery // The run the process belongs to, set with OutputFile
+}
+
+// RunRecovery describes the run of a run phase process well enough to finish
+// the run, or save it to history, after the application crashed.
+type RunRecovery struct {
+	Tool           string            `json:"tool"`
+	ConnectionName string            `json:"connection_name"`
+	TemplateName   string            `json:"template_name"`
+	DatabaseType   string            `json:"database_type"`
+	Threads        int               `json:"threads"`
+	StartedAt      time.Time         `json:"started_at"` // Start of the run phase
+	SampleInterval time.Duration     `json:"sample_interval,omitempty"`
+	Parameters     map[string]string `json:"parameters,omitempty"`
+	WorkDir        string            `json:"work_dir"`
+	TPCC           bool              `json:"tpcc,omitempty"` // The results include tpmC
+
+	// Purpose, ticket and environment, saved with the recovered run
+	Metadata execution.RunMetadata `json:"metadata"`
+
+	// SLA targets, evaluated when the recovered run completes
+	Assertions assertion.Targets `json:"assertions"`
+}
+
+// Recoverable reports whether the process can be re-attached to after a crash.
+func (p RunProcess) Recoverable() bool {
+	return p.OutputFile != "" && p.Recovery != nil
+}
+
+// =============================================================================
+// Suite Repository Interface
+// =============================================================================
+
+// SuiteRepository defines the interface for benchmark suite persistence operations.
+type SuiteRepository interface {
+	// SaveSuite saves a suite; an existing suite with the same ID is replaced.
+	SaveSuite(ctx context.Context, s *suite.Suite) error
+
+	// FindSuiteByID finds a suite by its ID.
+	// Returns ErrSuiteNotFound if the suite does not exist.
+	FindSuiteByID(ctx context.Context, id string) (*suite.Suite, error)
+
+	// FindAllSuites returns all suites, ordered by name.
+	FindAllSuites(ctx context.Context) ([]*suite.Suite, error)
+
+	// DeleteSuite deletes a suite and its run records.
+	DeleteSuite(ctx context.Context, id string) error
+
+	// SaveSuiteRun saves a suite run; an existing run with the same ID is replaced.
+	SaveSuiteRun(ctx context.Context, run *suite.Run) error
+
+	// FindSuiteRuns returns the runs of a suite, newest first.
+	FindSuiteRuns(ctx context.Context, suiteID string) ([]*suite.Run, error)
+}
+
 // =============================================================================
 // Settings Repository Interface
 // Implements: Phase 7 - Settings Management
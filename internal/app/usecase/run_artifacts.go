@@ -0,0 +1,181 @@
+// Package usecase provides retention of run artifacts.
+package usecase
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+// ArtifactOutputLog is the file in a kept work directory that collects the tool output.
+const ArtifactOutputLog = "output.log"
+
+// ArtifactServerLog is the file of a run's artifacts that holds the database
+// error log written during the run.
+const ArtifactServerLog = "server.log"
+
+// ArtifactFile is a file kept from the work directory of a run.
+type ArtifactFile struct {
+	Path string // Path relative to the run's artifact directory
+	Size int64  // Size in bytes
+}
+
+// RunArtifactDir returns the directory under root in which the artifacts of a run are kept.
+func RunArtifactDir(root, runID string) (string, error) {
+	if runID == "" || runID != filepath.Base(runID) || runID == "." || runID == ".." {
+		return "", fmt.Errorf("invalid run ID: %q", runID)
+	}
+	return filepath.Join(root, runID), nil
+}
+
+// ListRunArtifacts returns the files kept for a run, sorted by path.
+// Returns nil if no artifacts were kept.
+func ListRunArtifacts(root, runID string) ([]ArtifactFile, error) {
+	dir, err := RunArtifactDir(root, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []ArtifactFile
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, ArtifactFile{Path: rel, Size: info.Size()})
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("list artifacts: %w", err)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// copyRunArtifacts copies the artifacts kept for a run into dest.
+// Returns false if no artifacts were kept.
+func copyRunArtifacts(root, runID, dest string) (bool, error) {
+	files, err := ListRunArtifacts(root, runID)
+	if err != nil || len(files) == 0 {
+		return false, err
+	}
+	src, _ := RunArtifactDir(root, runID)
+
+	for _, file := range files {
+		if err := copyFile(filepath.Join(src, file.Path), filepath.Join(dest, file.Path)); err != nil {
+			return false, fmt.Errorf("copy artifact %s: %w", file.Path, err)
+		}
+	}
+	return true, nil
+}
+
+// removeRunArtifacts deletes the artifacts kept for a run, if any.
+func removeRunArtifacts(root, runID string) error {
+	dir, err := RunArtifactDir(root, runID)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+// writeServerLog writes the database error log read for a run to the
+// ArtifactServerLog in dir, headed by where it was read from and its window.
+func writeServerLog(dir string, pos *dbconfig.LogPosition, end time.Time, content string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	header := fmt.Sprintf("# %s via %s, %s - %s\n", pos.Path, pos.Via,
+		pos.At.Format(time.RFC3339), end.Format(time.RFC3339))
+	return os.WriteFile(filepath.Join(dir, ArtifactServerLog), []byte(header+content), 0644)
+}
+
+// workDir returns the work directory of a run. Runs that keep their artifacts
+// work under the artifact directory; all others use a temporary directory.
+func (uc *BenchmarkUseCase) workDir(runID string, options execution.TaskOptions) string {
+	if options.KeepArtifacts && uc.artifactDir != "" {
+		if dir, err := RunArtifactDir(uc.artifactDir, runID); err == nil {
+			return dir
+		}
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("db-benchmind-%s", runID))
+}
+
+// keepsArtifacts returns true if the work directory of a run is kept after the run.
+func (uc *BenchmarkUseCase) keepsArtifacts(run *execution.Run) bool {
+	return uc.artifactDir != "" && filepath.Dir(run.WorkDir) == filepath.Clean(uc.artifactDir)
+}
+
+// saveArtifactLog appends tool output to the output log of a run, or, if its
+// output is not captured, to the output log of a run that keeps its artifacts.
+// Command lines are not written since some tools take the password as an argument.
+func (uc *BenchmarkUseCase) saveArtifactLog(run *execution.Run, title, output string) {
+	if outputLog := uc.outputLogSection(run.ID, title); outputLog != nil {
+		io.WriteString(outputLog, output+"\n")
+		return
+	}
+	if !uc.keepsArtifacts(run) {
+		return
+	}
+	if err := appendArtifactLog(run.WorkDir, title, output); err != nil {
+		slog.Warn("Benchmark: Failed to write artifact log", "run_id", run.ID, "error", err)
+	}
+}
+
+// appendArtifactLog appends a block of tool output to the output log of a kept work directory.
+func appendArtifactLog(workDir, title, output string) error {
+	f, err := os.OpenFile(filepath.Join(workDir, ArtifactOutputLog), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintf(f, "==== %s %s ====\n%s\n", time.Now().Format(time.RFC3339), title, output); err != nil {
+		return err
+	}
+	return nil
+}
+
+// copyFile copies a regular file, creating the parent directories of dst.
+func copyFile(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
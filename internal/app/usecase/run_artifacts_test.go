@@ -0,0 +1,183 @@
+// Package usecase provides unit tests for run artifact retention.
+package usecase
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// writeArtifact creates a kept artifact file for a run.
+func writeArtifact(t *testing.T, root, runID, path, content string) {
+	t.Helper()
+	full := filepath.Join(root, runID, path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestListRunArtifacts tests listing kept artifacts and rejecting invalid run IDs.
+func TestListRunArtifacts(t *testing.T) {
+	root := t.TempDir()
+	writeArtifact(t, root, "run-1", ArtifactOutputLog, "tps: 100")
+	writeArtifact(t, root, "run-1", "conf/hammerdb.tcl", "puts ok")
+
+	files, err := ListRunArtifacts(root, "run-1")
+	if err != nil {
+		t.Fatalf("ListRunArtifacts() failed: %v", err)
+	}
+	want := []ArtifactFile{
+		{Path: filepath.Join("conf", "hammerdb.tcl"), Size: 7},
+		{Path: ArtifactOutputLog, Size: 8},
+	}
+	if len(files) != len(want) {
+		t.Fatalf("files = %v, want %v", files, want)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("files[%d] = %v, want %v", i, files[i], want[i])
+		}
+	}
+
+	if files, err := ListRunArtifacts(root, "missing"); err != nil || files != nil {
+		t.Errorf("ListRunArtifacts(missing) = %v, %v, want nil, nil", files, err)
+	}
+	for _, id := range []string{"", "..", "../run-1", "a/b"} {
+		if _, err := ListRunArtifacts(root, id); err == nil {
+			t.Errorf("ListRunArtifacts(%q) succeeded, want error", id)
+		}
+	}
+}
+
+// TestBenchmarkUseCase_WorkDir tests that only runs keeping artifacts work under the artifact directory.
+func TestBenchmarkUseCase_WorkDir(t *testing.T) {
+	root := t.TempDir()
+	uc := &BenchmarkUseCase{}
+	uc.SetArtifactDir(root)
+
+	kept := &execution.Run{ID: "run-1"}
+	kept.WorkDir = uc.workDir(kept.ID, execution.TaskOptions{KeepArtifacts: true})
+	if kept.WorkDir != filepath.Join(root, "run-1") || !uc.keepsArtifacts(kept) {
+		t.Errorf("kept work dir = %s, want it kept under %s", kept.WorkDir, root)
+	}
+
+	temp := &execution.Run{ID: "run-2"}
+	temp.WorkDir = uc.workDir(temp.ID, execution.TaskOptions{})
+	if !strings.HasPrefix(temp.WorkDir, os.TempDir()) || uc.keepsArtifacts(temp) {
+		t.Errorf("temporary work dir = %s, want it under %s and not kept", temp.WorkDir, os.TempDir())
+	}
+
+	if err := os.MkdirAll(kept.WorkDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	uc.saveArtifactLog(kept, "run", "tps: 100")
+	uc.saveArtifactLog(temp, "run", "tps: 100")
+	data, err := os.ReadFile(filepath.Join(kept.WorkDir, ArtifactOutputLog))
+	if err != nil || !strings.Contains(string(data), "tps: 100") {
+		t.Errorf("output log = %q, %v, want the tool output", data, err)
+	}
+	if _, err := os.Stat(temp.WorkDir); !os.IsNotExist(err) {
+		t.Errorf("temporary work dir was written, want it untouched")
+	}
+}
+
+// TestArtifacts_ExportAndDelete tests that exports copy artifacts and deleting a record removes them.
+func TestArtifacts_ExportAndDelete(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	writeArtifact(t, root, "run-1", ArtifactOutputLog, "tps: 100")
+
+	record := &history.Record{ID: "run-1", TemplateName: "OLTP", StartTime: time.Now()}
+
+	exportUC := NewExportUseCase(t.TempDir())
+	exportUC.SetArtifactDir(root)
+	path, err := exportUC.ExportRecord(ctx, record, FormatTXT)
+	if err != nil {
+		t.Fatalf("ExportRecord() failed: %v", err)
+	}
+	copied := filepath.Join(strings.TrimSuffix(path, ".txt")+"_artifacts", ArtifactOutputLog)
+	if data, err := os.ReadFile(copied); err != nil || string(data) != "tps: 100" {
+		t.Errorf("exported artifact = %q, %v, want the kept output log", data, err)
+	}
+
+	repo := newMockHistoryRepository()
+	repo.Save(ctx, record)
+	historyUC := NewHistoryUseCase(repo)
+	historyUC.SetArtifactDir(root)
+	if err := historyUC.DeleteRecord(ctx, record.ID); err != nil {
+		t.Fatalf("DeleteRecord() failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "run-1")); !os.IsNotExist(err) {
+		t.Errorf("artifacts still exist after deleting the record")
+	}
+}
+
+// memLogRepository is an in-memory RunLogRepository.
+type memLogRepository struct {
+	entries map[string][]LogEntry
+}
+
+func (r *memLogRepository) SaveLogEntry(ctx context.Context, runID string, entry LogEntry) error {
+	entry.ID = int64(len(r.entries[runID]) + 1)
+	r.entries[runID] = append(r.entries[runID], entry)
+	return nil
+}
+
+func (r *memLogRepository) FindLogEntries(ctx context.Context, runID string, filter LogFilter) ([]LogEntry, error) {
+	var found []LogEntry
+	for _, entry := range r.entries[runID] {
+		if filter.Match(entry) {
+			found = append(found, entry)
+		}
+	}
+	return found, nil
+}
+
+func (r *memLogRepository) DeleteLogEntries(ctx context.Context, runID string) error {
+	delete(r.entries, runID)
+	return nil
+}
+
+// TestHistoryUseCase_GetRunOutput tests that the kept output log is preferred
+// over the stdout log entries of a run.
+func TestHistoryUseCase_GetRunOutput(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	writeArtifact(t, root, "run-1", ArtifactOutputLog, "==== run ====\ntps: 100")
+
+	logs := &memLogRepository{entries: make(map[string][]LogEntry)}
+	logs.SaveLogEntry(ctx, "run-2", LogEntry{Stream: "stdout", Content: "tps: 200"})
+	logs.SaveLogEntry(ctx, "run-2", LogEntry{Stream: "info", Content: "Run phase started"})
+	logs.SaveLogEntry(ctx, "run-2", LogEntry{Stream: "stdout", Content: "tps: 210"})
+
+	uc := NewHistoryUseCase(newMockHistoryRepository())
+	uc.SetArtifactDir(root)
+	uc.SetLogRepository(logs)
+
+	if out, err := uc.GetRunOutput(ctx, "run-1"); err != nil || out != "==== run ====\ntps: 100" {
+		t.Errorf("GetRunOutput(kept) = %q, %v, want the output log", out, err)
+	}
+	if out, err := uc.GetRunOutput(ctx, "run-2"); err != nil || out != "tps: 200\ntps: 210" {
+		t.Errorf("GetRunOutput(not kept) = %q, %v, want the stdout entries", out, err)
+	}
+	if _, err := uc.GetRunOutput(ctx, "../run-1"); err == nil {
+		t.Error("GetRunOutput(invalid ID) succeeded, want error")
+	}
+
+	if record := uc.PreviewRecord(&execution.Run{ID: "run-3"}); record != nil {
+		t.Errorf("PreviewRecord() without result = %+v, want nil", record)
+	}
+	run := &execution.Run{ID: "run-3", Result: &execution.BenchmarkResult{TPSCalculated: 150}}
+	if record := uc.PreviewRecord(run); record == nil || record.ID != "run-3" || record.TPSCalculated != 150 {
+		t.Errorf("PreviewRecord() = %+v, want the record of run-3", record)
+	}
+}
@@ -0,0 +1,369 @@
+// Package usecase provides recovery of benchmark runs left running by a crash.
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	domaintemplate "github.com/whhaicheng/DB-BenchMind/internal/domain/template"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+// RunOutputFile is the file in the work directory of a run to which a local
+// run phase process writes its output.
+const RunOutputFile = "run-output.log"
+
+// followPollInterval is how often new output and the exit of a re-attached
+// process are checked for.
+const followPollInterval = 200 * time.Millisecond
+
+// OrphanedRun is a run phase process left behind when the application exited
+// during a run, as returned by OrphanedRuns.
+type OrphanedRun struct {
+	RunProcess
+	Running bool // The process group still runs; otherwise only its output is left
+}
+
+// RunEvent returns the lifecycle event of run with the names of the orphaned run,
+// e.g. for saving it to history.
+func (o OrphanedRun) RunEvent(run *execution.Run) RunEvent {
+	return RunEvent{
+		Run:            run,
+		ConnectionName: o.Recovery.ConnectionName,
+		TemplateName:   o.Recovery.TemplateName,
+		DatabaseType:   o.Recovery.DatabaseType,
+	}
+}
+
+// newRun returns the run the orphaned process belongs to, in the running state.
+func (o OrphanedRun) newRun() *execution.Run {
+	startedAt := o.Recovery.StartedAt
+	return &execution.Run{
+		ID:             o.RunID,
+		State:          execution.StateRunning,
+		CreatedAt:      o.StartedAt,
+		StartedAt:      &startedAt,
+		WorkDir:        o.Recovery.WorkDir,
+		SampleInterval: o.Recovery.SampleInterval,
+		Parameters:     o.Recovery.Parameters,
+		Metadata:       o.Recovery.Metadata,
+		Assertions:     o.Recovery.Assertions,
+	}
+}
+
+// runRecovery returns what is needed to finish run after a crash.
+func runRecovery(run *execution.Run, conn connection.Connection, tmpl *domaintemplate.Template, config *adapter.Config) *RunRecovery {
+	threads, _ := config.Parameters["threads"].(int)
+	return &RunRecovery{
+		Tool:           tmpl.Tool,
+		ConnectionName: conn.GetName(),
+		TemplateName:   tmpl.Name,
+		DatabaseType:   string(conn.GetType()),
+		Threads:        threads,
+		StartedAt:      *run.StartedAt,
+		SampleInterval: run.SampleInterval,
+		Parameters:     run.Parameters,
+		WorkDir:        run.WorkDir,
+		TPCC:           adapter.IsSysbenchTPCC(tmpl),
+		Metadata:       run.Metadata,
+		Assertions:     run.Assertions,
+	}
+}
+
+// startCommandToFile starts the local process of cmd with its stdout and stderr
+// written to outputFile instead of pipes, so that the tool keeps running, and
+// its output is kept, if the application exits.
+func startCommandToFile(ctx context.Context, cmd *adapter.Command, outputFile string) (*exec.Cmd, error) {
+	execCmd, err := newCommand(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(outputFile)
+	if err != nil {
+		return nil, fmt.Errorf("create output file: %w", err)
+	}
+	defer f.Close() // The process has its own descriptor
+	execCmd.Stdout = f
+	execCmd.Stderr = f
+
+	if err := execCmd.Start(); err != nil {
+		return nil, fmt.Errorf("start command: %w", err)
+	}
+	return execCmd, nil
+}
+
+// followReader reads a file that another process is writing. At the end of the
+// file it waits for more output until done is closed, so it reaches EOF only
+// once the writer has exited.
+type followReader struct {
+	f    *os.File
+	done <-chan struct{}
+}
+
+// followFile opens path for reading with a followReader.
+func followFile(path string, done <-chan struct{}) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &followReader{f: f, done: done}, nil
+}
+
+func (r *followReader) Read(p []byte) (int, error) {
+	for {
+		n, err := r.f.Read(p)
+		if n > 0 || err != io.EOF {
+			return n, err
+		}
+		select {
+		case <-r.done:
+			// Output written just before the writer exited
+			return r.f.Read(p)
+		case <-time.After(followPollInterval):
+		}
+	}
+}
+
+func (r *followReader) Close() error {
+	return r.f.Close()
+}
+
+// watchProcessGroup returns a channel that is closed once the process group
+// pgid has exited or ctx is done.
+func watchProcessGroup(ctx context.Context, pgid int) <-chan struct{} {
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(followPollInterval)
+		defer ticker.Stop()
+		for processGroupExists(pgid) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return exited
+}
+
+// OrphanedRuns returns the run phase processes of this boot that were left
+// behind when the application exited during a run, e.g. because it crashed.
+// Each can be re-attached to with ReattachRun, even if its process has exited
+// since, or be terminated with TerminateOrphanedRun. Call it on startup, after
+// ReapOrphanedProcesses has cleaned up the other records.
+func (uc *BenchmarkUseCase) OrphanedRuns(ctx context.Context) ([]OrphanedRun, error) {
+	if uc.processRepo == nil {
+		return nil, nil
+	}
+
+	procs, err := uc.processRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list recorded processes: %w", err)
+	}
+
+	bootID := currentBootID()
+	var orphans []OrphanedRun
+	for _, proc := range procs {
+		if !proc.Recoverable() || proc.BootID != bootID {
+			continue
+		}
+		// Runs known to this instance are not orphaned
+		if _, err := uc.runRepo.FindByID(ctx, proc.RunID); err == nil {
+			continue
+		}
+		orphans = append(orphans, OrphanedRun{RunProcess: proc, Running: processGroupExists(proc.PID)})
+	}
+	return orphans, nil
+}
+
+// ReattachRun adopts an orphaned run: the run is registered in the running
+// state and its output file is followed for realtime samples until the
+// process group exits. The run then completes with the final results from its
+// output, or fails if the output has none. It can be monitored like a run
+// started with StartBenchmark and stopped with StopBenchmark.
+func (uc *BenchmarkUseCase) ReattachRun(ctx context.Context, orphan OrphanedRun) (*execution.Run, error) {
+	if !orphan.Recoverable() {
+		return nil, fmt.Errorf("run %s cannot be re-attached", orphan.RunID)
+	}
+	adapt := uc.adapterReg.GetByTool(orphan.Recovery.Tool)
+	if adapt == nil {
+		return nil, fmt.Errorf("no adapter for tool %s", orphan.Recovery.Tool)
+	}
+
+	output, err := followFile(orphan.OutputFile, watchProcessGroup(context.Background(), orphan.PID))
+	if err != nil {
+		return nil, fmt.Errorf("open output of run %s: %w", orphan.RunID, err)
+	}
+
+	run := orphan.newRun()
+	if err := uc.runRepo.Save(ctx, run); err != nil {
+		output.Close()
+		return nil, fmt.Errorf("save run: %w", err)
+	}
+	slog.Info("Benchmark: Re-attached to orphaned run", "run_id", run.ID, "pid", orphan.PID, "running", orphan.Running)
+	uc.saveLogEntry(ctx, run.ID, LogEntry{
+		Timestamp: time.Now().Format(time.RFC3339),
+		Stream:    "info",
+		Content:   fmt.Sprintf("Re-attached to process %d after the application restarted", orphan.PID),
+	})
+
+	go uc.finishReattachedRun(context.Background(), run, orphan, adapt, output)
+	return run, nil
+}
+
+// finishReattachedRun collects the samples of a re-attached run from its output
+// and completes the run once the output ends.
+func (uc *BenchmarkUseCase) finishReattachedRun(ctx context.Context, run *execution.Run, orphan OrphanedRun, adapt adapter.BenchmarkAdapter, output io.ReadCloser) {
+	defer output.Close()
+
+	uc.openOutputLog(run)
+	defer uc.closeOutputLog(run.ID)
+	sampleCh, errCh, outputBuf := adapt.StartRealtimeCollection(ctx, teeOutput(output, uc.outputLogSection(run.ID, "run")))
+	for sampleCh != nil || errCh != nil {
+		select {
+		case sample, ok := <-sampleCh:
+			if !ok {
+				sampleCh = nil
+				continue
+			}
+			uc.recordSample(ctx, run.ID, sample, "run")
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+				continue
+			}
+			uc.saveLogEntry(ctx, run.ID, LogEntry{
+				Timestamp: time.Now().Format(time.RFC3339),
+				Stream:    "stderr",
+				Content:   err.Error(),
+			})
+		}
+	}
+
+	// The run may have been stopped with StopBenchmark in the meantime
+	if current, err := uc.runRepo.FindByID(ctx, run.ID); err == nil {
+		run = current
+	}
+	finalResult, err := outputBuf.FinalResults(ctx, adapt)
+	switch {
+	case run.State.IsTerminal():
+	case err != nil || !hasFinalResults(finalResult):
+		run.State = execution.StateFailed
+		run.ErrorMessage = "the re-attached process exited without final results"
+	default:
+		result := newBenchmarkResult(run, finalResult)
+		result.ConnectionName = orphan.Recovery.ConnectionName
+		result.TemplateName = orphan.Recovery.TemplateName
+		result.DatabaseType = orphan.Recovery.DatabaseType
+		result.Threads = orphan.Recovery.Threads
+		if orphan.Recovery.TPCC {
+			result.TpmC = adapter.TPCCTpmC(result.TPSCalculated)
+		}
+		if samples, err := uc.runRepo.GetMetricSamples(ctx, run.ID); err == nil {
+			result.TimeSeries = samples
+		}
+		run.Result = result
+		run.State = execution.StateCompleted
+		uc.evaluateAssertions(run)
+	}
+	now := time.Now()
+	run.CompletedAt = &now
+	run.CalculateDuration()
+	if err := uc.runRepo.Save(ctx, run); err != nil {
+		slog.Error("Benchmark: Failed to save re-attached run", "run_id", run.ID, "error", err)
+	}
+	slog.Info("Benchmark: Re-attached run finished", "run_id", run.ID, "state", run.State)
+
+	uc.forgetOrphanedRun(ctx, orphan)
+
+	uc.realtimeCallbackMu.RLock()
+	callback := uc.finishedCallback
+	uc.realtimeCallbackMu.RUnlock()
+	if callback != nil {
+		callback(orphan.RunEvent(run))
+	}
+}
+
+// hasFinalResults reports whether the tool output had a final summary.
+func hasFinalResults(r *adapter.FinalResult) bool {
+	return r.TotalTransactions > 0 || r.TransactionsPerSec > 0 || r.TotalEvents > 0
+}
+
+// TerminateOrphanedRun kills the process group of an orphaned run if it still
+// runs, and removes its record and work directory. It returns the run, force
+// stopped, for saving to history; the run is not registered.
+func (uc *BenchmarkUseCase) TerminateOrphanedRun(ctx context.Context, orphan OrphanedRun) (*execution.Run, error) {
+	if processGroupExists(orphan.PID) {
+		slog.Warn("Benchmark: Terminating orphaned run", "run_id", orphan.RunID, "pid", orphan.PID, "cmd", orphan.Command)
+		if err := signalProcessGroup(orphan.PID, syscall.SIGKILL); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			return nil, fmt.Errorf("kill process group %d: %w", orphan.PID, err)
+		}
+	}
+
+	run := orphan.newRun()
+	run.State = execution.StateForceStopped
+	run.ErrorMessage = "terminated after the application restarted"
+	now := time.Now()
+	run.CompletedAt = &now
+	run.CalculateDuration()
+
+	uc.forgetOrphanedRun(ctx, orphan)
+	return run, nil
+}
+
+// forgetOrphanedRun removes the process record of a finished orphaned run and
+// its work directory, or the credential files in it if the run keeps its artifacts.
+func (uc *BenchmarkUseCase) forgetOrphanedRun(ctx context.Context, orphan OrphanedRun) {
+	if err := uc.processRepo.Delete(ctx, orphan.PID); err != nil {
+		slog.Warn("Benchmark: Failed to remove process record", "run_id", orphan.RunID, "pid", orphan.PID, "error", err)
+	}
+	run := orphan.newRun()
+	switch {
+	case run.WorkDir == "":
+	case uc.keepsArtifacts(run):
+		removeCredentialFiles(run.WorkDir)
+	default:
+		os.RemoveAll(run.WorkDir)
+	}
+}
+
+// stopReattachedRun stops the process group recorded for a re-attached run:
+// with SIGTERM, followed by SIGKILL if force. Returns false if the run has no
+// recorded process.
+func (uc *BenchmarkUseCase) stopReattachedRun(ctx context.Context, runID string, force bool) bool {
+	if uc.processRepo == nil {
+		return false
+	}
+	procs, err := uc.processRepo.FindAll(ctx)
+	if err != nil {
+		slog.Warn("Benchmark: Failed to list recorded processes", "run_id", runID, "error", err)
+		return false
+	}
+	for _, proc := range procs {
+		if proc.RunID != runID {
+			continue
+		}
+		if err := signalProcessGroup(proc.PID, syscall.SIGTERM); err != nil {
+			slog.Error("Benchmark: Failed to send SIGTERM", "run_id", runID, "pid", proc.PID, "error", err)
+		}
+		if force {
+			time.Sleep(2 * time.Second)
+			if err := signalProcessGroup(proc.PID, syscall.SIGKILL); err != nil && !errors.Is(err, os.ErrProcessDone) {
+				slog.Error("Benchmark: Failed to send SIGKILL", "run_id", runID, "pid", proc.PID, "error", err)
+			}
+		}
+		return true
+	}
+	return false
+}
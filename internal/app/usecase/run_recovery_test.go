@@ -0,0 +1,166 @@
+//go:build !windows
+
+package usecase
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+// TestFollowFile tests that output written after the end of the file is read
+// until the writer is done.
+func TestFollowFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), RunOutputFile)
+	if err := os.WriteFile(path, []byte("first\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	r, err := followFile(path, done)
+	if err != nil {
+		t.Fatalf("followFile() failed: %v", err)
+	}
+	defer r.Close()
+
+	go func() {
+		time.Sleep(2 * followPollInterval)
+		f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+		f.WriteString("second\n")
+		f.Close()
+		close(done)
+	}()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll() failed: %v", err)
+	}
+	if string(data) != "first\nsecond\n" {
+		t.Errorf("followed output = %q, want both lines", data)
+	}
+}
+
+// TestBenchmarkUseCase_ReattachRun tests that a run left running is finished
+// from the output file of its process.
+func TestBenchmarkUseCase_ReattachRun(t *testing.T) {
+	ctx := context.Background()
+	workDir := t.TempDir()
+	outputFile := filepath.Join(workDir, RunOutputFile)
+
+	script := `sleep 1
+echo "[ 1s ] thds: 4 tps: 100.00 qps: 2000.00 (r/w/o: 1400.00/400.00/200.00) lat (ms,95%): 10.00 err/s: 0.00 reconn/s: 0.00"
+echo "[ 2s ] thds: 4 tps: 120.00 qps: 2400.00 (r/w/o: 1680.00/480.00/240.00) lat (ms,95%): 12.00 err/s: 0.00 reconn/s: 0.00"
+echo "    transactions:                        220    (110.00 per sec.)"`
+	tool, err := startCommandToFile(ctx, &adapter.Command{CmdLine: "sh -c '" + script + "'"}, outputFile)
+	if err != nil {
+		t.Fatalf("startCommandToFile() failed: %v", err)
+	}
+	go tool.Wait()
+
+	repo := newMemoryProcessRepository()
+	repo.Save(ctx, RunProcess{
+		PID: tool.Process.Pid, RunID: "run-1", Command: "sh", BootID: currentBootID(), StartedAt: time.Now(),
+		OutputFile: outputFile,
+		Recovery: &RunRecovery{
+			Tool: "sysbench", ConnectionName: "db1", TemplateName: "oltp", Threads: 4,
+			StartedAt: time.Now(), SampleInterval: time.Second, WorkDir: workDir,
+		},
+	})
+
+	adapterReg := adapter.NewAdapterRegistry()
+	adapterReg.Register(adapter.NewSysbenchAdapter())
+	uc := NewBenchmarkUseCase(NewMemoryRunRepository(), adapterReg, nil, nil)
+	uc.SetProcessRepository(repo)
+
+	// The recoverable record survives the reaping on startup
+	if killed, err := uc.ReapOrphanedProcesses(ctx); err != nil || killed != 0 {
+		t.Fatalf("ReapOrphanedProcesses() = %d, %v, want 0, nil", killed, err)
+	}
+	orphans, err := uc.OrphanedRuns(ctx)
+	if err != nil || len(orphans) != 1 {
+		t.Fatalf("OrphanedRuns() = %+v, %v, want one run", orphans, err)
+	}
+	if !orphans[0].Running {
+		t.Error("orphaned run not reported as running")
+	}
+
+	finished := make(chan RunEvent, 1)
+	uc.SetRunFinishedCallback(func(event RunEvent) { finished <- event })
+
+	run, err := uc.ReattachRun(ctx, orphans[0])
+	if err != nil {
+		t.Fatalf("ReattachRun() failed: %v", err)
+	}
+	if run.State != execution.StateRunning {
+		t.Errorf("re-attached run state = %s, want running", run.State)
+	}
+
+	select {
+	case event := <-finished:
+		if event.Run.State != execution.StateCompleted || event.Run.Result == nil {
+			t.Fatalf("finished run = %+v, want completed with result", event.Run)
+		}
+		if event.TemplateName != "oltp" || event.Run.Result.Threads != 4 || event.Run.Result.TotalTransactions != 220 {
+			t.Errorf("finished event = %+v, result = %+v", event, event.Run.Result)
+		}
+		if len(event.Run.Result.TimeSeries) != 2 {
+			t.Errorf("time series has %d samples, want 2", len(event.Run.Result.TimeSeries))
+		}
+	case <-time.After(20 * time.Second):
+		t.Fatal("re-attached run did not finish")
+	}
+
+	if procs, _ := repo.FindAll(ctx); len(procs) != 0 {
+		t.Errorf("process records after finishing = %+v, want none", procs)
+	}
+}
+
+// TestBenchmarkUseCase_TerminateOrphanedRun tests that terminating an orphaned
+// run kills its process group.
+func TestBenchmarkUseCase_TerminateOrphanedRun(t *testing.T) {
+	ctx := context.Background()
+
+	tool := exec.CommandContext(ctx, "sleep", "60")
+	setProcessGroup(tool)
+	if err := tool.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- tool.Wait() }()
+
+	repo := newMemoryProcessRepository()
+	uc := NewBenchmarkUseCase(NewMemoryRunRepository(), nil, nil, nil)
+	uc.SetProcessRepository(repo)
+
+	orphan := OrphanedRun{RunProcess: RunProcess{
+		PID: tool.Process.Pid, RunID: "run-1", BootID: currentBootID(), StartedAt: time.Now(),
+		OutputFile: filepath.Join(t.TempDir(), RunOutputFile),
+		Recovery:   &RunRecovery{Tool: "sysbench", StartedAt: time.Now()},
+	}, Running: true}
+	repo.Save(ctx, orphan.RunProcess)
+
+	run, err := uc.TerminateOrphanedRun(ctx, orphan)
+	if err != nil {
+		t.Fatalf("TerminateOrphanedRun() failed: %v", err)
+	}
+	if run.State != execution.StateForceStopped {
+		t.Errorf("terminated run state = %s, want force_stopped", run.State)
+	}
+
+	select {
+	case <-exited:
+	case <-time.After(10 * time.Second):
+		tool.Process.Kill()
+		t.Fatal("orphaned process was not killed")
+	}
+	if procs, _ := repo.FindAll(ctx); len(procs) != 0 {
+		t.Errorf("process records after terminating = %+v, want none", procs)
+	}
+}
@@ -4,16 +4,30 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
 	"github.com/whhaicheng/DB-BenchMind/internal/infra/tool"
 )
 
+// ErrTaskPresetNotFound is returned when no Task Configuration preset has the requested name.
+var ErrTaskPresetNotFound = errors.New("task preset not found")
+
 // SettingsUseCase provides settings management business operations.
 type SettingsUseCase struct {
 	settingsRepo SettingsRepository
 	detector     *tool.Detector
+	adapterReg   *adapter.AdapterRegistry // adapters that run the configured tool paths
+	installer    *tool.Installer
+	toolsDir     string // directory portable tools are installed into
 }
 
 // NewSettingsUseCase creates a new settings use case.
@@ -24,9 +38,15 @@ func NewSettingsUseCase(
 	return &SettingsUseCase{
 		settingsRepo: settingsRepo,
 		detector:     detector,
+		installer:    tool.NewInstaller(),
 	}
 }
 
+// SetToolsDir sets the directory portable tools are installed into.
+func (uc *SettingsUseCase) SetToolsDir(dir string) {
+	uc.toolsDir = dir
+}
+
 // GetConfig retrieves the current configuration.
 func (uc *SettingsUseCase) GetConfig(ctx context.Context) (*config.Config, error) {
 	return uc.settingsRepo.GetConfig(ctx)
@@ -47,9 +67,102 @@ func (uc *SettingsUseCase) GetToolConfig(ctx context.Context, toolType config.To
 	return uc.settingsRepo.GetToolConfig(ctx, toolType)
 }
 
-// SetToolPath sets the path for a specific tool.
+// SetAdapterRegistry sets the adapters that run the configured tool paths.
+func (uc *SettingsUseCase) SetAdapterRegistry(reg *adapter.AdapterRegistry) {
+	uc.adapterReg = reg
+}
+
+// ApplyToolPaths makes the adapters run the tool paths of the saved settings.
+func (uc *SettingsUseCase) ApplyToolPaths(ctx context.Context) error {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+	uc.applyToolPaths(cfg)
+	return nil
+}
+
+// applyToolPaths passes the tool paths of cfg to the adapters.
+func (uc *SettingsUseCase) applyToolPaths(cfg *config.Config) {
+	if uc.adapterReg == nil {
+		return
+	}
+	uc.adapterReg.SetToolPaths(adapter.ToolPaths{
+		Sysbench:   cfg.Tools[config.ToolTypeSysbench].Path,
+		Swingbench: cfg.Tools[config.ToolTypeSwingbench].Path,
+		HammerDB:   cfg.Tools[config.ToolTypeHammerDB].Path,
+		Psql:       cfg.Tools[config.ToolTypePsql].Path,
+		MySQL:      cfg.Tools[config.ToolTypeMySQL].Path,
+	})
+}
+
+// SetToolPath sets the path for a specific tool, see SetToolPaths.
 func (uc *SettingsUseCase) SetToolPath(ctx context.Context, toolType config.ToolType, path string) error {
-	return uc.settingsRepo.SetToolPath(ctx, toolType, path)
+	return uc.SetToolPaths(ctx, map[config.ToolType]string{toolType: path})
+}
+
+// SetToolPaths sets the paths of several tools. An empty path looks the tool
+// up in PATH. Each path must be an executable of at least the minimum
+// supported version; nothing is saved unless every path is valid. The
+// detected versions are saved with the paths and the adapters switch to the
+// new executables.
+func (uc *SettingsUseCase) SetToolPaths(ctx context.Context, paths map[config.ToolType]string) error {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+
+	var errs []error
+	for _, toolType := range slices.Sorted(maps.Keys(paths)) {
+		toolCfg, ok := cfg.Tools[toolType]
+		if !ok {
+			toolCfg = config.ToolConfig{Type: toolType}
+		}
+		toolCfg.Path = paths[toolType]
+		toolCfg.Version = ""
+		if err := toolCfg.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", toolType, err))
+			continue
+		}
+		if toolCfg.Path != "" {
+			version, err := uc.toolVersion(ctx, toolType, toolCfg.Path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", toolType, err))
+				continue
+			}
+			toolCfg.Version = version
+		}
+		if err := cfg.SetToolConfig(toolCfg); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", toolType, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	if err := uc.settingsRepo.SaveConfig(ctx, cfg); err != nil {
+		return fmt.Errorf("save config: %w", err)
+	}
+	uc.applyToolPaths(cfg)
+	return nil
+}
+
+// toolVersion returns the version of the tool at path, checked against the
+// minimum supported version. Tools without a minimum version may have no
+// detectable version.
+func (uc *SettingsUseCase) toolVersion(ctx context.Context, toolType config.ToolType, path string) (string, error) {
+	detector := uc.detector
+	if detector == nil {
+		detector = tool.NewDetector()
+	}
+	version, err := detector.GetToolVersionAt(ctx, toolType, path)
+	if err != nil && toolType.MinVersion() != "" {
+		return "", fmt.Errorf("check version: %w", err)
+	}
+	if err := toolType.CheckVersion(version); err != nil {
+		return "", err
+	}
+	return version, nil
 }
 
 // SetToolEnabled enables or disables a tool.
@@ -90,6 +203,36 @@ func (uc *SettingsUseCase) DetectTool(ctx context.Context, toolType config.ToolT
 	return info, nil
 }
 
+// ToolInstallPlan returns how a tool is installed on this system.
+func (uc *SettingsUseCase) ToolInstallPlan(toolType config.ToolType) *tool.InstallPlan {
+	return tool.PlanInstall(toolType, tool.CurrentPlatform())
+}
+
+// InstallTool installs a tool with a download plan into the tools
+// directory and saves its path. SetToolPath checks that the installed tool
+// runs and is a supported version; an installation that fails the check is
+// removed again. Other plans are carried out by the user.
+func (uc *SettingsUseCase) InstallTool(ctx context.Context, plan *tool.InstallPlan) (*config.ToolConfig, error) {
+	if plan.Method != tool.InstallDownload {
+		return nil, fmt.Errorf("%s is installed with the package manager or manually, not by download", plan.Type)
+	}
+	if uc.toolsDir == "" {
+		return nil, errors.New("tools directory not set")
+	}
+
+	path, err := uc.installer.Download(ctx, plan, uc.toolsDir)
+	if err != nil {
+		return nil, fmt.Errorf("install %s: %w", plan.Type, err)
+	}
+	if err := uc.SetToolPath(ctx, plan.Type, path); err != nil {
+		if rel, relErr := filepath.Rel(uc.toolsDir, path); relErr == nil {
+			os.RemoveAll(filepath.Join(uc.toolsDir, strings.Split(filepath.ToSlash(rel), "/")[0]))
+		}
+		return nil, fmt.Errorf("verify %s: %w", plan.Type, err)
+	}
+	return uc.settingsRepo.GetToolConfig(ctx, plan.Type)
+}
+
 // DetectAndSaveTools detects all tools and saves their information to config.
 func (uc *SettingsUseCase) DetectAndSaveTools(ctx context.Context) (map[config.ToolType]*tool.ToolInfo, error) {
 	// Detect all tools
@@ -127,13 +270,33 @@ func (uc *SettingsUseCase) DetectAndSaveTools(ctx context.Context) (map[config.T
 	if err := uc.settingsRepo.SaveConfig(ctx, cfg); err != nil {
 		return nil, fmt.Errorf("save config: %w", err)
 	}
+	uc.applyToolPaths(cfg)
 
 	return toolInfos, nil
 }
 
-// ResetSettings resets all settings to defaults.
+// ResetSettings resets all settings to defaults. The app lock is kept, so a
+// reset cannot remove it.
 func (uc *SettingsUseCase) ResetSettings(ctx context.Context) error {
-	return uc.settingsRepo.ResetToDefaults(ctx)
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+	if err := uc.settingsRepo.ResetToDefaults(ctx); err != nil {
+		return err
+	}
+
+	if cfg.AppLock.Enabled() {
+		defaults, err := uc.settingsRepo.GetConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("get config: %w", err)
+		}
+		defaults.AppLock = cfg.AppLock
+		if err := uc.settingsRepo.SaveConfig(ctx, defaults); err != nil {
+			return fmt.Errorf("save config: %w", err)
+		}
+	}
+	return uc.ApplyToolPaths(ctx)
 }
 
 // GetDatabaseConfig retrieves database configuration.
@@ -232,6 +395,267 @@ func (uc *SettingsUseCase) UpdateAdvancedConfig(ctx context.Context, advCfg conf
 	return uc.settingsRepo.SaveConfig(ctx, cfg)
 }
 
+// GetHistoryConfig retrieves history retention configuration.
+func (uc *SettingsUseCase) GetHistoryConfig(ctx context.Context) (*config.HistoryConfig, error) {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &cfg.History, nil
+}
+
+// UpdateHistoryConfig updates history retention configuration.
+func (uc *SettingsUseCase) UpdateHistoryConfig(ctx context.Context, historyCfg config.HistoryConfig) error {
+	if err := historyCfg.Validate(); err != nil {
+		return fmt.Errorf("validate history config: %w", err)
+	}
+
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+
+	cfg.History = historyCfg
+	return uc.settingsRepo.SaveConfig(ctx, cfg)
+}
+
+// GetExportConfig retrieves export configuration.
+func (uc *SettingsUseCase) GetExportConfig(ctx context.Context) (*config.ExportConfig, error) {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &cfg.Export, nil
+}
+
+// UpdateExportConfig updates export configuration.
+func (uc *SettingsUseCase) UpdateExportConfig(ctx context.Context, exportCfg config.ExportConfig) error {
+	if err := exportCfg.Validate(); err != nil {
+		return fmt.Errorf("validate export config: %w", err)
+	}
+
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+
+	cfg.Export = exportCfg
+	return uc.settingsRepo.SaveConfig(ctx, cfg)
+}
+
+// GetNotificationConfig retrieves email notification configuration.
+func (uc *SettingsUseCase) GetNotificationConfig(ctx context.Context) (*config.NotificationConfig, error) {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &cfg.Notifications, nil
+}
+
+// UpdateNotificationConfig updates email notification configuration.
+func (uc *SettingsUseCase) UpdateNotificationConfig(ctx context.Context, notifyCfg config.NotificationConfig) error {
+	if err := notifyCfg.Validate(); err != nil {
+		return fmt.Errorf("validate notification config: %w", err)
+	}
+
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+
+	cfg.Notifications = notifyCfg
+	return uc.settingsRepo.SaveConfig(ctx, cfg)
+}
+
+// GetWebhooks retrieves the run lifecycle webhooks.
+func (uc *SettingsUseCase) GetWebhooks(ctx context.Context) ([]config.WebhookConfig, error) {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Webhooks, nil
+}
+
+// UpdateWebhooks replaces the run lifecycle webhooks.
+func (uc *SettingsUseCase) UpdateWebhooks(ctx context.Context, webhooks []config.WebhookConfig) error {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+
+	cfg.Webhooks = webhooks
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("validate webhooks: %w", err)
+	}
+	return uc.settingsRepo.SaveConfig(ctx, cfg)
+}
+
+// GetAgents retrieves the load-generator agents.
+func (uc *SettingsUseCase) GetAgents(ctx context.Context) ([]config.AgentConfig, error) {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.Agents, nil
+}
+
+// GetAgent retrieves a load-generator agent by name.
+// Returns ErrAgentNotFound if no agent has that name.
+func (uc *SettingsUseCase) GetAgent(ctx context.Context, name string) (*config.AgentConfig, error) {
+	agents, err := uc.GetAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range agents {
+		if agents[i].Name == name {
+			return &agents[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrAgentNotFound, name)
+}
+
+// UpdateAgents replaces the load-generator agents.
+func (uc *SettingsUseCase) UpdateAgents(ctx context.Context, agents []config.AgentConfig) error {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+
+	cfg.Agents = agents
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("validate agents: %w", err)
+	}
+	return uc.settingsRepo.SaveConfig(ctx, cfg)
+}
+
+// GetTaskDefaults retrieves the task parameters last used with a connection,
+// or the default parameters if none were saved for it.
+func (uc *SettingsUseCase) GetTaskDefaults(ctx context.Context, connID string) (config.TaskDefaults, error) {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return config.TaskDefaults{}, err
+	}
+	if defaults, ok := cfg.TaskDefaults[connID]; ok {
+		return defaults, nil
+	}
+	return config.DefaultTaskDefaults(), nil
+}
+
+// SaveTaskDefaults remembers the task parameters used with a connection.
+func (uc *SettingsUseCase) SaveTaskDefaults(ctx context.Context, connID string, defaults config.TaskDefaults) error {
+	if err := defaults.Validate(); err != nil {
+		return fmt.Errorf("validate task defaults: %w", err)
+	}
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+
+	if cfg.TaskDefaults == nil {
+		cfg.TaskDefaults = make(map[string]config.TaskDefaults)
+	}
+	cfg.TaskDefaults[connID] = defaults
+	return uc.settingsRepo.SaveConfig(ctx, cfg)
+}
+
+// ResetTaskDefaults forgets the task parameters saved for a connection,
+// so it starts from the default parameters again.
+func (uc *SettingsUseCase) ResetTaskDefaults(ctx context.Context, connID string) error {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+	if _, ok := cfg.TaskDefaults[connID]; !ok {
+		return nil
+	}
+
+	delete(cfg.TaskDefaults, connID)
+	return uc.settingsRepo.SaveConfig(ctx, cfg)
+}
+
+// GetTaskPresets retrieves the named Task Configurations.
+func (uc *SettingsUseCase) GetTaskPresets(ctx context.Context) ([]config.TaskPreset, error) {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.TaskPresets, nil
+}
+
+// GetTaskPreset retrieves a Task Configuration by name.
+// Returns ErrTaskPresetNotFound if no preset has that name.
+func (uc *SettingsUseCase) GetTaskPreset(ctx context.Context, name string) (*config.TaskPreset, error) {
+	presets, err := uc.GetTaskPresets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range presets {
+		if presets[i].Name == name {
+			return &presets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrTaskPresetNotFound, name)
+}
+
+// SaveTaskPreset saves a Task Configuration, replacing the preset of the same name.
+func (uc *SettingsUseCase) SaveTaskPreset(ctx context.Context, preset config.TaskPreset) error {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+
+	i := slices.IndexFunc(cfg.TaskPresets, func(p config.TaskPreset) bool { return p.Name == preset.Name })
+	if i >= 0 {
+		cfg.TaskPresets[i] = preset
+	} else {
+		cfg.TaskPresets = append(cfg.TaskPresets, preset)
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("validate task preset: %w", err)
+	}
+	return uc.settingsRepo.SaveConfig(ctx, cfg)
+}
+
+// DeleteTaskPreset deletes a Task Configuration by name.
+// Returns ErrTaskPresetNotFound if no preset has that name.
+func (uc *SettingsUseCase) DeleteTaskPreset(ctx context.Context, name string) error {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+
+	i := slices.IndexFunc(cfg.TaskPresets, func(p config.TaskPreset) bool { return p.Name == name })
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrTaskPresetNotFound, name)
+	}
+	cfg.TaskPresets = slices.Delete(cfg.TaskPresets, i, i+1)
+	return uc.settingsRepo.SaveConfig(ctx, cfg)
+}
+
+// GetSanityChecks retrieves the sanity checks evaluated on every run.
+func (uc *SettingsUseCase) GetSanityChecks(ctx context.Context) ([]history.SanityCheck, error) {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.SanityChecks, nil
+}
+
+// UpdateSanityChecks replaces the sanity checks evaluated on every run.
+// Existing history records are not re-evaluated.
+func (uc *SettingsUseCase) UpdateSanityChecks(ctx context.Context, checks []history.SanityCheck) error {
+	cfg, err := uc.settingsRepo.GetConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("get config: %w", err)
+	}
+
+	cfg.SanityChecks = checks
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("validate sanity checks: %w", err)
+	}
+	return uc.settingsRepo.SaveConfig(ctx, cfg)
+}
+
 // IsToolEnabled checks if a tool is enabled.
 func (uc *SettingsUseCase) IsToolEnabled(ctx context.Context, toolType config.ToolType) (bool, error) {
 	return uc.settingsRepo.IsToolEnabled(ctx, toolType)
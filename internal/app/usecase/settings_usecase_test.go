@@ -2,13 +2,19 @@
 package usecase
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
 	"github.com/whhaicheng/DB-BenchMind/internal/infra/tool"
 )
 
@@ -237,7 +243,7 @@ func TestSettingsUseCase_SetToolPath(t *testing.T) {
 
 	// Create a temp executable
 	tmpFile := t.TempDir() + "/sysbench"
-	if err := os.WriteFile(tmpFile, []byte("#!/bin/sh\necho test"), 0755); err != nil {
+	if err := os.WriteFile(tmpFile, []byte("#!/bin/sh\necho 'sysbench 1.0.20'"), 0755); err != nil {
 		t.Fatalf("Failed to create test file: %v", err)
 	}
 
@@ -256,6 +262,102 @@ func TestSettingsUseCase_SetToolPath(t *testing.T) {
 	}
 }
 
+// TestSettingsUseCase_SetToolPaths tests version validation and the adapter paths.
+func TestSettingsUseCase_SetToolPaths(t *testing.T) {
+	ctx := context.Background()
+	uc := setupSettingsTest(t)
+	reg := adapter.NewAdapterRegistry()
+	reg.Register(adapter.NewSysbenchAdapter())
+	uc.SetAdapterRegistry(reg)
+
+	dir := t.TempDir()
+	writeTool := func(name, output string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("#!/bin/sh\necho '"+output+"'"), 0755); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+		return path
+	}
+	sysbench := writeTool("sysbench", "sysbench 1.0.20")
+	oldMySQL := writeTool("mysql-5.5", "mysql  Ver 14.14 Distrib 5.5.62, for linux-glibc2.12 (x86_64)")
+
+	// One old tool rejects the whole save
+	err := uc.SetToolPaths(ctx, map[config.ToolType]string{
+		config.ToolTypeSysbench: sysbench,
+		config.ToolTypeMySQL:    oldMySQL,
+	})
+	if !errors.Is(err, config.ErrToolVersionTooOld) {
+		t.Fatalf("SetToolPaths() error = %v, want ErrToolVersionTooOld", err)
+	}
+	if path, _ := uc.GetToolPath(ctx, config.ToolTypeSysbench); path != "" {
+		t.Errorf("sysbench path saved despite the error: %s", path)
+	}
+
+	if err := uc.SetToolPaths(ctx, map[config.ToolType]string{config.ToolTypeSysbench: sysbench}); err != nil {
+		t.Fatalf("SetToolPaths() failed: %v", err)
+	}
+	toolCfg, err := uc.GetToolConfig(ctx, config.ToolTypeSysbench)
+	if err != nil {
+		t.Fatalf("GetToolConfig() failed: %v", err)
+	}
+	if toolCfg.Version != "1.0.20" {
+		t.Errorf("Version = %q, want 1.0.20", toolCfg.Version)
+	}
+	if got := reg.Get(adapter.AdapterTypeSysbench).(*adapter.SysbenchAdapter).SysbenchPath; got != sysbench {
+		t.Errorf("adapter SysbenchPath = %s, want %s", got, sysbench)
+	}
+}
+
+// TestSettingsUseCase_InstallTool tests installing a downloaded build.
+func TestSettingsUseCase_InstallTool(t *testing.T) {
+	ctx := context.Background()
+	uc := setupSettingsTest(t)
+	toolsDir := filepath.Join(t.TempDir(), "tools")
+	uc.SetToolsDir(toolsDir)
+
+	archives := map[string]string{
+		"/hammerdb.tar.gz": "#!/bin/sh\necho 'HammerDB CLI v4.6'\n",
+		"/old.tar.gz":      "#!/bin/sh\necho 'HammerDB CLI v3.3'\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		script, ok := archives[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		gz := gzip.NewWriter(w)
+		tw := tar.NewWriter(gz)
+		tw.WriteHeader(&tar.Header{Name: "HammerDB/hammerdbcli", Mode: 0755, Size: int64(len(script)), Typeflag: tar.TypeReg})
+		tw.Write([]byte(script))
+		tw.Close()
+		gz.Close()
+	}))
+	defer server.Close()
+
+	plan := tool.DownloadPlan(config.ToolTypeHammerDB, server.URL+"/hammerdb.tar.gz", "")
+	plan.Executable = "hammerdbcli"
+	toolCfg, err := uc.InstallTool(ctx, plan)
+	if err != nil {
+		t.Fatalf("InstallTool() failed: %v", err)
+	}
+	if want := filepath.Join(toolsDir, "hammerdb", "HammerDB", "hammerdbcli"); toolCfg.Path != want || toolCfg.Version != "4.6" {
+		t.Errorf("InstallTool() = %s %s, want %s 4.6", toolCfg.Path, toolCfg.Version, want)
+	}
+
+	// Too old builds are removed again
+	plan.URL = server.URL + "/old.tar.gz"
+	if _, err := uc.InstallTool(ctx, plan); !errors.Is(err, config.ErrToolVersionTooOld) {
+		t.Fatalf("InstallTool() error = %v, want ErrToolVersionTooOld", err)
+	}
+	if _, err := os.Stat(filepath.Join(toolsDir, "old")); !os.IsNotExist(err) {
+		t.Error("rejected installation was not removed")
+	}
+
+	if _, err := uc.InstallTool(ctx, tool.PlanInstall(config.ToolTypeSwingbench, tool.CurrentPlatform())); err == nil {
+		t.Error("InstallTool() should refuse plans that are not downloads")
+	}
+}
+
 // TestSettingsUseCase_SetToolEnabled tests enabling/disabling tools.
 func TestSettingsUseCase_SetToolEnabled(t *testing.T) {
 	ctx := context.Background()
@@ -284,8 +386,8 @@ func TestSettingsUseCase_DetectTools(t *testing.T) {
 
 	toolInfos := uc.DetectTools(ctx)
 
-	if len(toolInfos) != 3 {
-		t.Errorf("DetectTools() returned %d results, want 3", len(toolInfos))
+	if len(toolInfos) != len(config.ToolTypes()) {
+		t.Errorf("DetectTools() returned %d results, want %d", len(toolInfos), len(config.ToolTypes()))
 	}
 
 	for toolType, info := range toolInfos {
@@ -332,8 +434,8 @@ func TestSettingsUseCase_DetectAndSaveTools(t *testing.T) {
 		t.Fatalf("DetectAndSaveTools() failed: %v", err)
 	}
 
-	if len(toolInfos) != 3 {
-		t.Errorf("DetectAndSaveTools() returned %d results, want 3", len(toolInfos))
+	if len(toolInfos) != len(config.ToolTypes()) {
+		t.Errorf("DetectAndSaveTools() returned %d results, want %d", len(toolInfos), len(config.ToolTypes()))
 	}
 
 	// Verify config was updated
@@ -356,6 +458,7 @@ func TestSettingsUseCase_ResetSettings(t *testing.T) {
 	// Save custom config
 	cfg := config.DefaultConfig()
 	cfg.UI.Theme = "dark"
+	cfg.AppLock.AdminPassword = &config.PasswordHash{KDF: "argon2id", Salt: []byte("salt"), Hash: []byte("hash")}
 	if err := uc.UpdateConfig(ctx, cfg); err != nil {
 		t.Fatalf("UpdateConfig() failed: %v", err)
 	}
@@ -374,6 +477,9 @@ func TestSettingsUseCase_ResetSettings(t *testing.T) {
 	if loaded.UI.Theme != "auto" {
 		t.Errorf("Theme after reset = %s, want auto", loaded.UI.Theme)
 	}
+	if !loaded.AppLock.Enabled() {
+		t.Error("app lock removed by reset")
+	}
 }
 
 // TestSettingsUseCase_GetDatabaseConfig tests getting database config.
@@ -571,6 +677,108 @@ func TestSettingsUseCase_UpdateAdvancedConfig(t *testing.T) {
 	}
 }
 
+// TestSettingsUseCase_UpdateAgents tests saving and looking up agents.
+func TestSettingsUseCase_UpdateAgents(t *testing.T) {
+	ctx := context.Background()
+	uc := setupSettingsTest(t)
+
+	agents := []config.AgentConfig{{Name: "loadgen-1", Address: "10.0.0.20:7422", HostKey: "SHA256:abc"}}
+	if err := uc.UpdateAgents(ctx, agents); err != nil {
+		t.Fatalf("UpdateAgents() failed: %v", err)
+	}
+	got, err := uc.GetAgent(ctx, "loadgen-1")
+	if err != nil {
+		t.Fatalf("GetAgent() failed: %v", err)
+	}
+	if got.Address != "10.0.0.20:7422" {
+		t.Errorf("Address = %s, want 10.0.0.20:7422", got.Address)
+	}
+	if _, err := uc.GetAgent(ctx, "loadgen-2"); !errors.Is(err, ErrAgentNotFound) {
+		t.Errorf("GetAgent() error = %v, want ErrAgentNotFound", err)
+	}
+
+	if err := uc.UpdateAgents(ctx, []config.AgentConfig{{Name: "bad", Address: "10.0.0.21"}}); err == nil {
+		t.Error("UpdateAgents() accepted an agent without port and host key")
+	}
+}
+
+// TestSettingsUseCase_TaskDefaults tests remembering and resetting the task
+// parameters of a connection.
+func TestSettingsUseCase_TaskDefaults(t *testing.T) {
+	ctx := context.Background()
+	uc := setupSettingsTest(t)
+
+	got, err := uc.GetTaskDefaults(ctx, "conn-1")
+	if err != nil {
+		t.Fatalf("GetTaskDefaults() failed: %v", err)
+	}
+	if got != config.DefaultTaskDefaults() {
+		t.Errorf("GetTaskDefaults() = %+v, want defaults", got)
+	}
+
+	saved := config.TaskDefaults{Threads: 16, Duration: 300, DBName: "bench"}
+	if err := uc.SaveTaskDefaults(ctx, "conn-1", saved); err != nil {
+		t.Fatalf("SaveTaskDefaults() failed: %v", err)
+	}
+	if got, _ := uc.GetTaskDefaults(ctx, "conn-1"); got != saved {
+		t.Errorf("GetTaskDefaults() = %+v, want %+v", got, saved)
+	}
+	if got, _ := uc.GetTaskDefaults(ctx, "conn-2"); got != config.DefaultTaskDefaults() {
+		t.Errorf("GetTaskDefaults() of another connection = %+v, want defaults", got)
+	}
+
+	if err := uc.SaveTaskDefaults(ctx, "conn-1", config.TaskDefaults{Threads: 0, Duration: 60}); err == nil {
+		t.Error("SaveTaskDefaults() accepted 0 threads")
+	}
+
+	if err := uc.ResetTaskDefaults(ctx, "conn-1"); err != nil {
+		t.Fatalf("ResetTaskDefaults() failed: %v", err)
+	}
+	if got, _ := uc.GetTaskDefaults(ctx, "conn-1"); got != config.DefaultTaskDefaults() {
+		t.Errorf("GetTaskDefaults() after reset = %+v, want defaults", got)
+	}
+}
+
+// TestSettingsUseCase_TaskPresets tests saving, replacing and deleting task presets.
+func TestSettingsUseCase_TaskPresets(t *testing.T) {
+	ctx := context.Background()
+	uc := setupSettingsTest(t)
+
+	preset := config.TaskPreset{Name: "nightly", ConnectionID: "conn-1", TemplateID: "oltp", Threads: 8, Duration: 600, DBName: "sbtest"}
+	if err := uc.SaveTaskPreset(ctx, preset); err != nil {
+		t.Fatalf("SaveTaskPreset() failed: %v", err)
+	}
+	preset.Threads = 32
+	if err := uc.SaveTaskPreset(ctx, preset); err != nil {
+		t.Fatalf("SaveTaskPreset() replacing failed: %v", err)
+	}
+	presets, err := uc.GetTaskPresets(ctx)
+	if err != nil || len(presets) != 1 {
+		t.Fatalf("GetTaskPresets() = %+v, %v, want one preset", presets, err)
+	}
+	got, err := uc.GetTaskPreset(ctx, "nightly")
+	if err != nil {
+		t.Fatalf("GetTaskPreset() failed: %v", err)
+	}
+	if got.Threads != 32 {
+		t.Errorf("Threads = %d, want 32", got.Threads)
+	}
+
+	if err := uc.SaveTaskPreset(ctx, config.TaskPreset{Name: "no connection", Threads: 1, Duration: 60}); err == nil {
+		t.Error("SaveTaskPreset() accepted a preset without connection")
+	}
+
+	if err := uc.DeleteTaskPreset(ctx, "nightly"); err != nil {
+		t.Fatalf("DeleteTaskPreset() failed: %v", err)
+	}
+	if _, err := uc.GetTaskPreset(ctx, "nightly"); !errors.Is(err, ErrTaskPresetNotFound) {
+		t.Errorf("GetTaskPreset() after delete error = %v, want ErrTaskPresetNotFound", err)
+	}
+	if err := uc.DeleteTaskPreset(ctx, "nightly"); !errors.Is(err, ErrTaskPresetNotFound) {
+		t.Errorf("DeleteTaskPreset() error = %v, want ErrTaskPresetNotFound", err)
+	}
+}
+
 // TestSettingsUseCase_GetEnabledTools tests getting enabled tools list.
 func TestSettingsUseCase_GetEnabledTools(t *testing.T) {
 	ctx := context.Background()
@@ -598,7 +806,7 @@ func TestSettingsUseCase_VerifyTool(t *testing.T) {
 
 	// Create a temp executable
 	tmpFile := t.TempDir() + "/test-tool"
-	if err := os.WriteFile(tmpFile, []byte("#!/bin/sh\necho test"), 0755); err != nil {
+	if err := os.WriteFile(tmpFile, []byte("#!/bin/sh\necho 'sysbench 1.0.20'"), 0755); err != nil {
 		t.Fatalf("Failed to create test file: %v", err)
 	}
 
@@ -0,0 +1,128 @@
+// Package usecase provides the installation of the swingbench SOE schema.
+// Implements: REQ-EXEC-001
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+// oewizard defaults of the SOE schema installation, as used by the swingbench adapter.
+const (
+	defaultSOEScale   = 1
+	defaultSOEThreads = 32
+)
+
+// SOESchemaInstall describes an installation of the swingbench Order Entry (SOE) schema.
+type SOESchemaInstall struct {
+	ConnectionID string // Oracle connection; its user becomes the schema owner
+	TemplateID   string // Swingbench template whose prepare phase runs oewizard
+	Scale        int    // oewizard scale, defaultSOEScale if 0
+	Threads      int    // Data generation threads, defaultSOEThreads if 0
+	DBAUsername  string // DBA user that creates the schema owner
+	DBAPassword  string // Password of the DBA user
+}
+
+// SOESchemaTask returns the prepare-only task that installs the SOE schema with
+// oewizard. Start it with StartBenchmark: the oewizard output is saved to the
+// run log as it arrives, and the schema is recorded on the connection once the
+// prepare succeeds, so later swingbench runs can check that it exists.
+func (uc *BenchmarkUseCase) SOESchemaTask(ctx context.Context, install SOESchemaInstall) (*execution.BenchmarkTask, error) {
+	conn, err := uc.connUseCase.GetConnectionByID(ctx, install.ConnectionID)
+	if err != nil {
+		return nil, fmt.Errorf("get connection: %w", err)
+	}
+	if conn.GetType() != connection.DatabaseTypeOracle {
+		return nil, fmt.Errorf("the SOE schema can only be installed on Oracle, got %s", conn.GetType())
+	}
+
+	tmpl, err := uc.templateUseCase.GetTemplate(ctx, install.TemplateID)
+	if err != nil {
+		return nil, fmt.Errorf("get template: %w", err)
+	}
+	if tmpl.Tool != string(adapter.AdapterTypeSwingbench) {
+		return nil, fmt.Errorf("template %s does not use swingbench", tmpl.Name)
+	}
+
+	if install.DBAUsername == "" {
+		return nil, fmt.Errorf("DBA username is required to create the schema owner")
+	}
+	scale := install.Scale
+	if scale <= 0 {
+		scale = defaultSOEScale
+	}
+	threads := install.Threads
+	if threads <= 0 {
+		threads = defaultSOEThreads
+	}
+
+	// time=0 with _original_time selects prepare-only mode
+	return &execution.BenchmarkTask{
+		ID:           uuid.New().String(),
+		Name:         fmt.Sprintf("Install SOE schema (scale %d)", scale),
+		ConnectionID: install.ConnectionID,
+		TemplateID:   install.TemplateID,
+		Parameters: map[string]interface{}{
+			"scale":          scale,
+			"threads":        threads,
+			"dba_username":   install.DBAUsername,
+			"dba_password":   install.DBAPassword,
+			"time":           0,
+			"_original_time": 0,
+		},
+		Options: execution.TaskOptions{
+			SkipCleanup: true,
+		},
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// checkSOESchema checks that the SOE schema is installed on an Oracle connection.
+func checkSOESchema(conn connection.Connection) error {
+	oracleConn, ok := conn.(*connection.OracleConnection)
+	if !ok {
+		return fmt.Errorf("swingbench only supports Oracle database, got %s", conn.GetType())
+	}
+	if oracleConn.SOESchema == nil {
+		return fmt.Errorf("no SOE schema is recorded for connection %s", oracleConn.Name)
+	}
+	return nil
+}
+
+// recordSOESchema records the SOE schema on the Oracle connection after a
+// swingbench prepare created it, or removes it after a cleanup dropped it.
+// A failed update is logged and does not fail the run.
+func (uc *BenchmarkUseCase) recordSOESchema(ctx context.Context, adapt adapter.BenchmarkAdapter, conn connection.Connection, params map[string]interface{}, installed bool) {
+	oracleConn, ok := conn.(*connection.OracleConnection)
+	if !ok || adapt.Type() != adapter.AdapterTypeSwingbench {
+		return
+	}
+
+	if installed {
+		scale := defaultSOEScale
+		if s, ok := params["scale"].(int); ok && s > 0 {
+			scale = s
+		}
+		oracleConn.SOESchema = &connection.SOESchema{
+			Username:    oracleConn.Username,
+			Scale:       scale,
+			InstalledAt: time.Now(),
+		}
+	} else {
+		oracleConn.SOESchema = nil
+	}
+
+	if err := uc.connUseCase.updateConnection(ctx, oracleConn); err != nil {
+		slog.Warn("Benchmark: Failed to record SOE schema", "conn_id", oracleConn.ID, "installed", installed, "error", err)
+		return
+	}
+	slog.Info("Benchmark: SOE schema recorded", "conn_id", oracleConn.ID, "installed", installed)
+}
@@ -0,0 +1,106 @@
+// Package usecase provides unit tests for the SOE schema installation.
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	domaintemplate "github.com/whhaicheng/DB-BenchMind/internal/domain/template"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+)
+
+func newSOETestUseCase(t *testing.T) (*BenchmarkUseCase, *mockConnectionRepository) {
+	t.Helper()
+	ctx := context.Background()
+
+	connRepo := newMockConnectionRepository()
+	connRepo.Save(ctx, &connection.OracleConnection{
+		BaseConnection: connection.BaseConnection{ID: "oracle-1", Name: "Oracle"},
+		Host:           "localhost",
+		Port:           1521,
+		SID:            "ORCL",
+		Username:       "soe",
+	})
+	connRepo.Save(ctx, &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "mysql-1", Name: "MySQL"},
+		Host:           "localhost",
+		Port:           3306,
+		Username:       "root",
+	})
+
+	templateRepo := newMockTemplateRepositoryForBenchmark()
+	templateRepo.Save(ctx, &domaintemplate.Template{ID: "swingbench-soe", Name: "Swingbench SOE", Tool: "swingbench"})
+	templateRepo.Save(ctx, &domaintemplate.Template{ID: "sysbench-oltp", Name: "Sysbench OLTP", Tool: "sysbench"})
+
+	uc := NewBenchmarkUseCase(newMockRunRepository(), adapter.NewAdapterRegistry(),
+		NewConnectionUseCase(connRepo, nil), NewTemplateUseCase(templateRepo, ""))
+	return uc, connRepo
+}
+
+func TestBenchmarkUseCase_SOESchemaTask(t *testing.T) {
+	uc, _ := newSOETestUseCase(t)
+	ctx := context.Background()
+
+	task, err := uc.SOESchemaTask(ctx, SOESchemaInstall{
+		ConnectionID: "oracle-1",
+		TemplateID:   "swingbench-soe",
+		Scale:        4,
+		DBAUsername:  "system",
+		DBAPassword:  "secret",
+	})
+	if err != nil {
+		t.Fatalf("SOESchemaTask() error = %v", err)
+	}
+	if err := task.Validate(); err != nil {
+		t.Errorf("task.Validate() error = %v", err)
+	}
+	if phases := taskSchemaPhases(task.Parameters, task.Options); phases != (schemaPhases{prepares: true}) {
+		t.Errorf("task phases = %+v, want prepare only", phases)
+	}
+	if task.Parameters["scale"] != 4 || task.Parameters["threads"] != defaultSOEThreads {
+		t.Errorf("scale, threads = %v, %v, want 4, %d", task.Parameters["scale"], task.Parameters["threads"], defaultSOEThreads)
+	}
+
+	invalid := []SOESchemaInstall{
+		{ConnectionID: "mysql-1", TemplateID: "swingbench-soe", DBAUsername: "system"},
+		{ConnectionID: "oracle-1", TemplateID: "sysbench-oltp", DBAUsername: "system"},
+		{ConnectionID: "oracle-1", TemplateID: "swingbench-soe"},
+	}
+	for _, install := range invalid {
+		if _, err := uc.SOESchemaTask(ctx, install); err == nil {
+			t.Errorf("SOESchemaTask(%+v) error = nil, want error", install)
+		}
+	}
+}
+
+func TestBenchmarkUseCase_RecordSOESchema(t *testing.T) {
+	uc, connRepo := newSOETestUseCase(t)
+	ctx := context.Background()
+	swingbench := adapter.NewSwingbenchAdapter()
+
+	conn, _ := uc.connUseCase.GetConnectionByID(ctx, "oracle-1")
+	if err := checkSOESchema(conn); err == nil {
+		t.Error("checkSOESchema() error = nil before install")
+	}
+
+	uc.recordSOESchema(ctx, swingbench, conn, map[string]interface{}{"scale": 4}, true)
+	stored := connRepo.connections["oracle-1"].(*connection.OracleConnection)
+	if stored.SOESchema == nil || stored.SOESchema.Scale != 4 || stored.SOESchema.Username != "soe" {
+		t.Fatalf("SOESchema = %+v, want scale 4 owned by soe", stored.SOESchema)
+	}
+	if err := checkSOESchema(stored); err != nil {
+		t.Errorf("checkSOESchema() error = %v after install", err)
+	}
+
+	// Other tools leave the schema alone
+	uc.recordSOESchema(ctx, adapter.NewSysbenchAdapter(), conn, nil, false)
+	if connRepo.connections["oracle-1"].(*connection.OracleConnection).SOESchema == nil {
+		t.Error("SOESchema removed by a sysbench cleanup")
+	}
+
+	uc.recordSOESchema(ctx, swingbench, conn, nil, false)
+	if connRepo.connections["oracle-1"].(*connection.OracleConnection).SOESchema != nil {
+		t.Error("SOESchema kept after a swingbench cleanup")
+	}
+}
@@ -0,0 +1,364 @@
+// Package usecase provides benchmark suite business logic.
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/comparison"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/suite"
+)
+
+var (
+	// ErrSuiteNotFound is returned when a suite is not found.
+	ErrSuiteNotFound = errors.New("suite not found")
+
+	// ErrSuiteExists is returned when a suite with the same name already exists.
+	ErrSuiteExists = errors.New("suite with this name already exists")
+)
+
+// SuiteUseCase manages benchmark suites and runs them step by step.
+// The records of a suite run are tagged "suite:<suite-run-id>" in history and
+// compared in a report exported when the suite run ends.
+type SuiteUseCase struct {
+	suiteRepo    SuiteRepository
+	runner       BenchmarkRunner
+	historyUC    *HistoryUseCase
+	comparisonUC *ComparisonUseCase // Optional; generates the final comparison report
+
+	runningMu sync.Mutex
+	running   map[string]context.CancelFunc // Cancels the running suite runs, by suite ID
+}
+
+// NewSuiteUseCase creates a new suite use case.
+func NewSuiteUseCase(suiteRepo SuiteRepository, runner BenchmarkRunner, historyUC *HistoryUseCase) *SuiteUseCase {
+	return &SuiteUseCase{
+		suiteRepo: suiteRepo,
+		runner:    runner,
+		historyUC: historyUC,
+		running:   make(map[string]context.CancelFunc),
+	}
+}
+
+// SetComparisonUseCase sets the comparison use case that generates the report of a suite run.
+func (uc *SuiteUseCase) SetComparisonUseCase(comparisonUC *ComparisonUseCase) {
+	uc.comparisonUC = comparisonUC
+}
+
+// =============================================================================
+// Suite Management
+// =============================================================================
+
+// SaveSuite creates or updates a suite. A suite without an ID is created.
+func (uc *SuiteUseCase) SaveSuite(ctx context.Context, s *suite.Suite) error {
+	s.Name = strings.TrimSpace(s.Name)
+	if s.GroupBy == "" {
+		s.GroupBy = suite.DefaultGroupBy
+	}
+	if err := s.Validate(); err != nil {
+		return fmt.Errorf("validate suite: %w", err)
+	}
+
+	suites, err := uc.suiteRepo.FindAllSuites(ctx)
+	if err != nil {
+		return fmt.Errorf("list suites: %w", err)
+	}
+	for _, existing := range suites {
+		if existing.Name == s.Name && existing.ID != s.ID {
+			return fmt.Errorf("%w: %s", ErrSuiteExists, s.Name)
+		}
+	}
+
+	now := time.Now()
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+		s.CreatedAt = now
+	}
+	s.UpdatedAt = now
+
+	if err := uc.suiteRepo.SaveSuite(ctx, s); err != nil {
+		return fmt.Errorf("save suite: %w", err)
+	}
+	slog.Info("Suite: Saved", "id", s.ID, "name", s.Name, "steps", len(s.Steps))
+	return nil
+}
+
+// GetSuite retrieves a suite by ID.
+func (uc *SuiteUseCase) GetSuite(ctx context.Context, id string) (*suite.Suite, error) {
+	return uc.suiteRepo.FindSuiteByID(ctx, id)
+}
+
+// ListSuites returns all suites, ordered by name.
+func (uc *SuiteUseCase) ListSuites(ctx context.Context) ([]*suite.Suite, error) {
+	return uc.suiteRepo.FindAllSuites(ctx)
+}
+
+// DeleteSuite deletes a suite and its run records. History records are kept.
+func (uc *SuiteUseCase) DeleteSuite(ctx context.Context, id string) error {
+	if uc.IsRunning(id) {
+		return fmt.Errorf("%w: suite is running", ErrInvalidState)
+	}
+	return uc.suiteRepo.DeleteSuite(ctx, id)
+}
+
+// ListSuiteRuns returns the runs of a suite, newest first.
+func (uc *SuiteUseCase) ListSuiteRuns(ctx context.Context, suiteID string) ([]*suite.Run, error) {
+	return uc.suiteRepo.FindSuiteRuns(ctx, suiteID)
+}
+
+// =============================================================================
+// Suite Execution
+// =============================================================================
+
+// StartSuite starts running a suite in the background and returns its run.
+// The run record is updated as the suite progresses; StopSuite stops it.
+func (uc *SuiteUseCase) StartSuite(ctx context.Context, suiteID string) (*suite.Run, error) {
+	s, run, runCtx, err := uc.beginSuiteRun(ctx, suiteID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := *run
+	go uc.executeSuite(runCtx, s, run)
+	return &snapshot, nil
+}
+
+// RunSuite runs a suite and waits until it ends. Cancelling ctx stops the suite.
+func (uc *SuiteUseCase) RunSuite(ctx context.Context, suiteID string) (*suite.Run, error) {
+	s, run, runCtx, err := uc.beginSuiteRun(ctx, suiteID)
+	if err != nil {
+		return nil, err
+	}
+	stop := context.AfterFunc(ctx, func() { uc.StopSuite(s.ID) })
+	defer stop()
+
+	uc.executeSuite(runCtx, s, run)
+	return run, nil
+}
+
+// StopSuite stops a running suite. The current benchmark run is stopped and
+// the remaining steps are skipped.
+func (uc *SuiteUseCase) StopSuite(suiteID string) error {
+	uc.runningMu.Lock()
+	cancel, ok := uc.running[suiteID]
+	uc.runningMu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: suite is not running", ErrInvalidState)
+	}
+	cancel()
+	return nil
+}
+
+// IsRunning checks if a suite is running.
+func (uc *SuiteUseCase) IsRunning(suiteID string) bool {
+	uc.runningMu.Lock()
+	defer uc.runningMu.Unlock()
+	_, ok := uc.running[suiteID]
+	return ok
+}
+
+// beginSuiteRun validates a suite, saves a new run record for it and marks it running.
+func (uc *SuiteUseCase) beginSuiteRun(ctx context.Context, suiteID string) (*suite.Suite, *suite.Run, context.Context, error) {
+	s, err := uc.suiteRepo.FindSuiteByID(ctx, suiteID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("get suite: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, nil, nil, fmt.Errorf("validate suite: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	uc.runningMu.Lock()
+	if _, ok := uc.running[s.ID]; ok {
+		uc.runningMu.Unlock()
+		cancel()
+		return nil, nil, nil, fmt.Errorf("%w: suite %s is already running", ErrInvalidState, s.Name)
+	}
+	uc.running[s.ID] = cancel
+	uc.runningMu.Unlock()
+
+	run := &suite.Run{
+		ID:        uuid.New().String(),
+		SuiteID:   s.ID,
+		SuiteName: s.Name,
+		State:     suite.StateRunning,
+		StartedAt: time.Now(),
+		TotalRuns: s.TotalRuns(),
+	}
+	if err := uc.suiteRepo.SaveSuiteRun(ctx, run); err != nil {
+		uc.finishRunning(s.ID)
+		return nil, nil, nil, fmt.Errorf("save suite run: %w", err)
+	}
+
+	slog.Info("Suite: Run started", "suite", s.Name, "suite_run_id", run.ID, "runs", run.TotalRuns)
+	return s, run, runCtx, nil
+}
+
+// finishRunning removes a suite from the running suites.
+func (uc *SuiteUseCase) finishRunning(suiteID string) {
+	uc.runningMu.Lock()
+	defer uc.runningMu.Unlock()
+	if cancel, ok := uc.running[suiteID]; ok {
+		cancel()
+		delete(uc.running, suiteID)
+	}
+}
+
+// executeSuite runs the steps of a suite in order and generates the comparison report.
+func (uc *SuiteUseCase) executeSuite(ctx context.Context, s *suite.Suite, run *suite.Run) {
+	defer uc.finishRunning(s.ID)
+
+	// Run records are saved even after the suite is stopped
+	saveCtx := context.WithoutCancel(ctx)
+
+	remaining := run.TotalRuns
+	for i := range s.Steps {
+		step := &s.Steps[i]
+		for rep := 1; rep <= step.Repetitions && ctx.Err() == nil; rep++ {
+			uc.executeStep(ctx, s, run, i, rep)
+			uc.saveSuiteRun(saveCtx, run)
+
+			remaining--
+			if remaining > 0 && step.CooldownSeconds > 0 && ctx.Err() == nil {
+				slog.Info("Suite: Cooling down", "suite", s.Name, "seconds", step.CooldownSeconds)
+				select {
+				case <-ctx.Done():
+				case <-time.After(time.Duration(step.CooldownSeconds) * time.Second):
+				}
+			}
+		}
+	}
+
+	completed := run.CompletedRunIDs()
+	switch {
+	case ctx.Err() != nil:
+		run.State = suite.StateCancelled
+		run.ErrorMessage = fmt.Sprintf("stopped after %d of %d runs", len(run.Runs), run.TotalRuns)
+	case len(completed) < run.TotalRuns:
+		run.State = suite.StateFailed
+		run.ErrorMessage = fmt.Sprintf("%d of %d runs did not complete", run.TotalRuns-len(completed), run.TotalRuns)
+	default:
+		run.State = suite.StateCompleted
+	}
+
+	if len(completed) >= 2 && uc.comparisonUC != nil {
+		path, err := uc.exportSuiteReport(saveCtx, s, run, completed)
+		if err != nil {
+			slog.Warn("Suite: Failed to export comparison report", "suite", s.Name, "error", err)
+		} else {
+			run.ReportPath = path
+		}
+	}
+
+	now := time.Now()
+	run.CompletedAt = &now
+	uc.saveSuiteRun(saveCtx, run)
+	slog.Info("Suite: Run finished", "suite", s.Name, "suite_run_id", run.ID, "state", run.State,
+		"completed", len(completed), "runs", run.TotalRuns, "report", run.ReportPath)
+}
+
+// executeStep runs one repetition of a step and waits until it ends.
+// Completed runs are saved to history, tagged with the suite run.
+func (uc *SuiteUseCase) executeStep(ctx context.Context, s *suite.Suite, run *suite.Run, index, rep int) {
+	step := &s.Steps[index]
+	ref := suite.RunRef{Step: index, Repetition: rep}
+
+	task := &execution.BenchmarkTask{
+		ID:           uuid.New().String(),
+		Name:         fmt.Sprintf("%s: %s #%d", s.Name, step.DisplayName(index), rep),
+		ConnectionID: step.ConnectionID,
+		TemplateID:   step.TemplateID,
+		Parameters:   stepParameters(step.Parameters),
+		Options:      step.Options,
+		Tags:         []string{run.Tag()},
+		Assertions:   step.Assertions,
+		CreatedAt:    time.Now(),
+	}
+	started, err := uc.runner.StartBenchmark(ctx, task)
+	if err != nil {
+		slog.Error("Suite: Failed to start run", "suite", s.Name, "step", index+1, "repetition", rep, "error", err)
+		ref.State = execution.StateFailed
+		ref.Error = err.Error()
+		run.Runs = append(run.Runs, ref)
+		return
+	}
+	ref.RunID = started.ID
+	ref.State = started.State
+	run.Runs = append(run.Runs, ref)
+	uc.saveSuiteRun(context.WithoutCancel(ctx), run)
+	slog.Info("Suite: Step started", "suite", s.Name, "step", index+1, "repetition", rep, "run_id", started.ID)
+
+	final := waitForRun(ctx, uc.runner, started.ID)
+	last := &run.Runs[len(run.Runs)-1]
+	if final == nil {
+		last.State = execution.StateFailed
+		last.Error = "run status unavailable"
+		return
+	}
+	last.State = final.State
+	last.Error = final.ErrorMessage
+	if outcome := final.AssertionOutcome; outcome != nil {
+		last.FailedAssertions = outcome.Failed()
+	}
+
+	if final.State == execution.StateCompleted && final.Result != nil && uc.historyUC != nil {
+		historyCtx := context.WithoutCancel(ctx)
+		if err := uc.historyUC.SaveRunToHistory(historyCtx, final); err != nil {
+			slog.Error("Suite: Failed to save run to history", "run_id", final.ID, "error", err)
+			return
+		}
+		notes := fmt.Sprintf("Suite %s, %s, repetition %d of %d", s.Name, step.DisplayName(index), rep, step.Repetitions)
+		if err := uc.historyUC.UpdateAnnotations(historyCtx, final.ID, []string{run.Tag()}, notes); err != nil {
+			slog.Warn("Suite: Failed to tag history record", "run_id", final.ID, "error", err)
+		}
+	}
+}
+
+// stepParameters copies the parameters of a step for a task. Suites are stored
+// as JSON, which decodes numbers as float64, while adapters expect integers.
+func stepParameters(params map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(params))
+	for name, value := range params {
+		if f, ok := value.(float64); ok && f == math.Trunc(f) {
+			value = int(f)
+		}
+		result[name] = value
+	}
+	return result
+}
+
+// exportSuiteReport compares the completed runs of a suite run and exports the report as Markdown.
+func (uc *SuiteUseCase) exportSuiteReport(ctx context.Context, s *suite.Suite, run *suite.Run, recordIDs []string) (string, error) {
+	report, err := uc.comparisonUC.GenerateSimplifiedReport(ctx, recordIDs, comparison.GroupByField(s.GroupBy))
+	if err != nil {
+		return "", err
+	}
+
+	dir := uc.comparisonUC.ExportDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("create export directory: %w", err)
+	}
+	name := strings.NewReplacer(" ", "_", "/", "_").Replace(s.Name)
+	path := filepath.Join(dir, fmt.Sprintf("suite_%s_%s.md", name, run.StartedAt.Format("20060102_150405")))
+	if err := uc.comparisonUC.ExportSimplifiedReport(ctx, report, "markdown", path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// saveSuiteRun saves the progress of a suite run.
+func (uc *SuiteUseCase) saveSuiteRun(ctx context.Context, run *suite.Run) {
+	if err := uc.suiteRepo.SaveSuiteRun(ctx, run); err != nil {
+		slog.Error("Suite: Failed to save suite run", "suite_run_id", run.ID, "error", err)
+	}
+}
@@ -0,0 +1,283 @@
+// Package usecase provides unit tests for benchmark suites.
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/suite"
+)
+
+// mockSuiteRepository is an in-memory SuiteRepository.
+type mockSuiteRepository struct {
+	mu     sync.Mutex
+	suites map[string]suite.Suite
+	runs   map[string]suite.Run
+}
+
+func newMockSuiteRepository() *mockSuiteRepository {
+	return &mockSuiteRepository{suites: make(map[string]suite.Suite), runs: make(map[string]suite.Run)}
+}
+
+func (m *mockSuiteRepository) SaveSuite(ctx context.Context, s *suite.Suite) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.suites[s.ID] = *s
+	return nil
+}
+
+func (m *mockSuiteRepository) FindSuiteByID(ctx context.Context, id string) (*suite.Suite, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s, ok := m.suites[id]
+	if !ok {
+		return nil, ErrSuiteNotFound
+	}
+	return &s, nil
+}
+
+func (m *mockSuiteRepository) FindAllSuites(ctx context.Context) ([]*suite.Suite, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var suites []*suite.Suite
+	for _, s := range m.suites {
+		suites = append(suites, &s)
+	}
+	return suites, nil
+}
+
+func (m *mockSuiteRepository) DeleteSuite(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.suites, id)
+	return nil
+}
+
+func (m *mockSuiteRepository) SaveSuiteRun(ctx context.Context, run *suite.Run) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	saved := *run
+	saved.Runs = append([]suite.RunRef(nil), run.Runs...)
+	m.runs[run.ID] = saved
+	return nil
+}
+
+func (m *mockSuiteRepository) FindSuiteRuns(ctx context.Context, suiteID string) ([]*suite.Run, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var runs []*suite.Run
+	for _, run := range m.runs {
+		if run.SuiteID == suiteID {
+			runs = append(runs, &run)
+		}
+	}
+	return runs, nil
+}
+
+// mockBenchmarkRunner completes runs immediately, failing those of the "broken" template.
+type mockBenchmarkRunner struct {
+	mu      sync.Mutex
+	tasks   []*execution.BenchmarkTask
+	runs    map[string]*execution.Run
+	stopped []string
+	hang    bool      // Runs never finish until stopped
+	tps     []float64 // TPS of the n-th run, instead of 100 per thread
+}
+
+func newMockBenchmarkRunner() *mockBenchmarkRunner {
+	return &mockBenchmarkRunner{runs: make(map[string]*execution.Run)}
+}
+
+func (m *mockBenchmarkRunner) StartBenchmark(ctx context.Context, task *execution.BenchmarkTask) (*execution.Run, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tasks = append(m.tasks, task)
+	run := &execution.Run{ID: fmt.Sprintf("run-%d", len(m.tasks)), TaskID: task.ID, State: execution.StateRunning}
+	m.runs[run.ID] = run
+	if m.hang {
+		return run, nil
+	}
+
+	if task.TemplateID == "broken" {
+		run.State = execution.StateFailed
+		run.ErrorMessage = "table sbtest1 doesn't exist"
+		return run, nil
+	}
+	threads, _ := task.Parameters["threads"].(int)
+	tps := float64(100 * threads)
+	if n := len(m.tasks); n <= len(m.tps) {
+		tps = m.tps[n-1]
+	}
+	run.State = execution.StateCompleted
+	run.Result = &execution.BenchmarkResult{
+		RunID:          run.ID,
+		TemplateName:   task.TemplateID,
+		ConnectionName: "mysql",
+		Threads:        threads,
+		TPSCalculated:  tps,
+		Duration:       time.Minute,
+		StartTime:      time.Now(),
+	}
+	return run, nil
+}
+
+// started returns the number of started runs.
+func (m *mockBenchmarkRunner) started() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.tasks)
+}
+
+func (m *mockBenchmarkRunner) GetBenchmarkStatus(ctx context.Context, runID string) (*execution.Run, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	run, ok := m.runs[runID]
+	if !ok {
+		return nil, ErrBenchmarkNotFound
+	}
+	copied := *run
+	return &copied, nil
+}
+
+func (m *mockBenchmarkRunner) StopBenchmark(ctx context.Context, runID string, force bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stopped = append(m.stopped, runID)
+	m.runs[runID].State = execution.StateCancelled
+	return nil
+}
+
+// newTestSuiteUseCase creates a suite use case with in-memory repositories.
+func newTestSuiteUseCase(t *testing.T, runner BenchmarkRunner) (*SuiteUseCase, *mockHistoryRepository) {
+	t.Helper()
+	runPollInterval = time.Millisecond
+	t.Cleanup(func() { runPollInterval = time.Second })
+
+	historyRepo := newMockHistoryRepository()
+	comparisonUC := NewComparisonUseCase(historyRepo, nil)
+	comparisonUC.SetExportDir(t.TempDir())
+
+	uc := NewSuiteUseCase(newMockSuiteRepository(), runner, NewHistoryUseCase(historyRepo))
+	uc.SetComparisonUseCase(comparisonUC)
+	return uc, historyRepo
+}
+
+// TestSuiteUseCase_RunSuite tests running steps in order, tagging history and exporting the report.
+func TestSuiteUseCase_RunSuite(t *testing.T) {
+	ctx := context.Background()
+	runner := newMockBenchmarkRunner()
+	uc, historyRepo := newTestSuiteUseCase(t, runner)
+
+	s := &suite.Suite{
+		Name: "thread scaling",
+		Steps: []suite.Step{
+			{ConnectionID: "conn-1", TemplateID: "oltp", Parameters: map[string]interface{}{"threads": 8}, Repetitions: 2},
+			{ConnectionID: "conn-1", TemplateID: "oltp", Parameters: map[string]interface{}{"threads": float64(16)}, Repetitions: 1},
+		},
+	}
+	if err := uc.SaveSuite(ctx, s); err != nil {
+		t.Fatalf("SaveSuite() failed: %v", err)
+	}
+	if err := uc.SaveSuite(ctx, &suite.Suite{Name: "thread scaling", Steps: s.Steps}); !errors.Is(err, ErrSuiteExists) {
+		t.Errorf("SaveSuite(duplicate name) error = %v, want ErrSuiteExists", err)
+	}
+
+	run, err := uc.RunSuite(ctx, s.ID)
+	if err != nil {
+		t.Fatalf("RunSuite() failed: %v", err)
+	}
+	if run.State != suite.StateCompleted || len(run.Runs) != 3 {
+		t.Fatalf("suite run = %s with %d runs, want completed with 3", run.State, len(run.Runs))
+	}
+	if threads := runner.tasks[2].Parameters["threads"]; threads != 16 {
+		t.Errorf("third task threads = %v, want 16", threads)
+	}
+
+	for _, ref := range run.Runs {
+		record, err := historyRepo.GetByID(ctx, ref.RunID)
+		if err != nil {
+			t.Fatalf("history record %s not saved: %v", ref.RunID, err)
+		}
+		if len(record.Tags) != 1 || record.Tags[0] != run.Tag() {
+			t.Errorf("record %s tags = %v, want [%s]", ref.RunID, record.Tags, run.Tag())
+		}
+	}
+	if _, err := os.Stat(run.ReportPath); err != nil {
+		t.Errorf("comparison report %q not exported: %v", run.ReportPath, err)
+	}
+	if uc.IsRunning(s.ID) {
+		t.Errorf("suite still running after RunSuite returned")
+	}
+
+	runs, err := uc.ListSuiteRuns(ctx, s.ID)
+	if err != nil || len(runs) != 1 || runs[0].State != suite.StateCompleted {
+		t.Errorf("ListSuiteRuns() = %v, %v, want the completed run", runs, err)
+	}
+}
+
+// TestSuiteUseCase_FailedAndStoppedRuns tests that failed runs do not stop a suite and that stopping skips the rest.
+func TestSuiteUseCase_FailedAndStoppedRuns(t *testing.T) {
+	ctx := context.Background()
+
+	runner := newMockBenchmarkRunner()
+	uc, _ := newTestSuiteUseCase(t, runner)
+	s := &suite.Suite{
+		Name: "mixed",
+		Steps: []suite.Step{
+			{ConnectionID: "conn-1", TemplateID: "broken", Repetitions: 1},
+			{ConnectionID: "conn-1", TemplateID: "oltp", Repetitions: 1},
+		},
+	}
+	if err := uc.SaveSuite(ctx, s); err != nil {
+		t.Fatalf("SaveSuite() failed: %v", err)
+	}
+	run, err := uc.RunSuite(ctx, s.ID)
+	if err != nil {
+		t.Fatalf("RunSuite() failed: %v", err)
+	}
+	if run.State != suite.StateFailed || len(run.Runs) != 2 || run.Runs[1].State != execution.StateCompleted {
+		t.Errorf("suite run = %s with runs %v, want failed with the second run completed", run.State, run.Runs)
+	}
+	if run.ReportPath != "" {
+		t.Errorf("ReportPath = %q, want no report for a single completed run", run.ReportPath)
+	}
+
+	hanging := newMockBenchmarkRunner()
+	hanging.hang = true
+	uc, _ = newTestSuiteUseCase(t, hanging)
+	s.ID = ""
+	if err := uc.SaveSuite(ctx, s); err != nil {
+		t.Fatalf("SaveSuite() failed: %v", err)
+	}
+	if _, err := uc.StartSuite(ctx, s.ID); err != nil {
+		t.Fatalf("StartSuite() failed: %v", err)
+	}
+	if _, err := uc.StartSuite(ctx, s.ID); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("StartSuite(running) error = %v, want ErrInvalidState", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for hanging.started() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if err := uc.StopSuite(s.ID); err != nil {
+		t.Fatalf("StopSuite() failed: %v", err)
+	}
+
+	for uc.IsRunning(s.ID) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	runs, _ := uc.ListSuiteRuns(ctx, s.ID)
+	if len(runs) != 1 || runs[0].State != suite.StateCancelled || len(runs[0].Runs) != 1 {
+		t.Fatalf("suite runs = %v, want one cancelled run with one benchmark run", runs)
+	}
+	if len(hanging.stopped) != 1 {
+		t.Errorf("stopped %v, want the running benchmark stopped", hanging.stopped)
+	}
+}
@@ -7,11 +7,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/google/uuid"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/sqlmix"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/template"
 )
 
@@ -32,15 +34,26 @@ var (
 // TemplateUseCase provides template management business operations.
 // Implements: REQ-TMPL-001 ~ REQ-TMPL-007
 type TemplateUseCase struct {
-	repo        TemplateRepository
-	builtinPath string // Path to builtin templates directory
+	repo      TemplateRepository
+	builtinFS fs.FS // Builtin template files (*.json); nil if none
 }
 
-// NewTemplateUseCase creates a new template use case.
+// NewTemplateUseCase creates a new template use case that loads builtin templates
+// from a directory. An empty builtinPath disables builtin templates.
 func NewTemplateUseCase(repo TemplateRepository, builtinPath string) *TemplateUseCase {
+	var builtinFS fs.FS
+	if builtinPath != "" {
+		builtinFS = os.DirFS(builtinPath)
+	}
+	return NewTemplateUseCaseFS(repo, builtinFS)
+}
+
+// NewTemplateUseCaseFS creates a new template use case that loads builtin templates
+// from a file system, such as the templates embedded in the binary.
+func NewTemplateUseCaseFS(repo TemplateRepository, builtinFS fs.FS) *TemplateUseCase {
 	return &TemplateUseCase{
-		repo:        repo,
-		builtinPath: builtinPath,
+		repo:      repo,
+		builtinFS: builtinFS,
 	}
 }
 
@@ -108,6 +121,9 @@ func (uc *TemplateUseCase) ImportTemplate(ctx context.Context, filePath string)
 	if err := tmpl.Validate(); err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrTemplateInvalid, err)
 	}
+	if err := validateToolData(tmpl); err != nil {
+		return nil, err
+	}
 
 	// Generate ID if not set
 	if tmpl.ID == "" {
@@ -241,19 +257,19 @@ func (uc *TemplateUseCase) ExportTemplate(ctx context.Context, id, filePath stri
 // This should be called during application initialization.
 // Implements: REQ-TMPL-007
 func (uc *TemplateUseCase) LoadBuiltinTemplates(ctx context.Context) error {
-	if uc.builtinPath == "" {
+	if uc.builtinFS == nil {
 		return nil // No builtin templates to load
 	}
 
 	// Read all JSON files from builtin templates directory
-	files, err := filepath.Glob(filepath.Join(uc.builtinPath, "*.json"))
+	files, err := fs.Glob(uc.builtinFS, "*.json")
 	if err != nil {
 		return fmt.Errorf("find builtin templates: %w", err)
 	}
 
 	var templates []*template.Template
 	for _, file := range files {
-		data, err := os.ReadFile(file)
+		data, err := fs.ReadFile(uc.builtinFS, file)
 		if err != nil {
 			return fmt.Errorf("read template file %s: %w", file, err)
 		}
@@ -266,6 +282,9 @@ func (uc *TemplateUseCase) LoadBuiltinTemplates(ctx context.Context) error {
 		if err := tmpl.Validate(); err != nil {
 			return fmt.Errorf("validate template %s: %w", tmpl.ID, err)
 		}
+		if err := validateToolData(tmpl); err != nil {
+			return fmt.Errorf("validate template %s: %w", tmpl.ID, err)
+		}
 
 		templates = append(templates, tmpl)
 	}
@@ -278,10 +297,38 @@ func (uc *TemplateUseCase) LoadBuiltinTemplates(ctx context.Context) error {
 	return nil
 }
 
+// LoadTemplateDir imports the templates of the *.json files in dir, such as
+// the SQL mix templates of users. A missing directory has no templates.
+// Files that cannot be imported are skipped and reported in the error.
+func (uc *TemplateUseCase) LoadTemplateDir(ctx context.Context, dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("find templates: %w", err)
+	}
+	var errs []error
+	for _, file := range files {
+		if _, err := uc.ImportTemplate(ctx, file); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(file), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // =============================================================================
 // Helper Functions
 // =============================================================================
 
+// validateToolData checks the tool-specific custom data of a template, such
+// as the statements of a SQL mix.
+func validateToolData(tmpl *template.Template) error {
+	if tmpl.Tool == sqlmix.Tool {
+		if _, err := sqlmix.FromTemplate(tmpl); err != nil {
+			return fmt.Errorf("%w: %v", ErrTemplateInvalid, err)
+		}
+	}
+	return nil
+}
+
 // generateTemplateID generates a new unique template ID.
 func generateTemplateID() string {
 	return fmt.Sprintf("custom-%s", uuid.New().String())
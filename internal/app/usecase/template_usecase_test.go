@@ -3,10 +3,13 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/whhaicheng/DB-BenchMind/contracts"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/template"
 )
 
@@ -249,6 +252,60 @@ func TestTemplateUseCase_ImportTemplate_InvalidJSON(t *testing.T) {
 	}
 }
 
+// TestTemplateUseCase_LoadTemplateDir tests importing the templates of a
+// directory, including the checks of SQL mixes.
+func TestTemplateUseCase_LoadTemplateDir(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockTemplateRepository()
+	uc := NewTemplateUseCase(repo, "")
+
+	if err := uc.LoadTemplateDir(ctx, filepath.Join(t.TempDir(), "missing")); err != nil {
+		t.Errorf("LoadTemplateDir() of a missing directory error = %v", err)
+	}
+
+	dir := t.TempDir()
+	mix := func(sql string) map[string]interface{} {
+		return map[string]interface{}{
+			"sql_mix": map[string]interface{}{"statements": []interface{}{map[string]interface{}{"sql": sql}}},
+		}
+	}
+	for id, custom := range map[string]map[string]interface{}{
+		"kv-mix":  mix("SELECT 1"),
+		"bad-mix": mix("SELECT {id}"),
+	} {
+		tmpl := &template.Template{
+			ID:              id,
+			Name:            id,
+			Tool:            "sqlmix",
+			DatabaseTypes:   []string{"mysql"},
+			CommandTemplate: template.CommandTemplate{Run: "sqlmix run"},
+			OutputParser:    template.OutputParser{Type: template.ParserTypeRegex},
+			CustomData:      custom,
+		}
+		data, err := tmpl.ToJSON()
+		if err != nil {
+			t.Fatalf("Failed to serialize template: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, id+".json"), data, 0644); err != nil {
+			t.Fatalf("Failed to write test file: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a template"), 0644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	err := uc.LoadTemplateDir(ctx, dir)
+	if !errors.Is(err, ErrTemplateInvalid) || !strings.Contains(err.Error(), "bad-mix.json") {
+		t.Errorf("LoadTemplateDir() error = %v, want invalid bad-mix.json", err)
+	}
+	if _, err := uc.GetTemplate(ctx, "kv-mix"); err != nil {
+		t.Errorf("valid template not imported: %v", err)
+	}
+	if _, err := uc.GetTemplate(ctx, "bad-mix"); err == nil {
+		t.Error("template with an invalid SQL mix was imported")
+	}
+}
+
 // TestTemplateUseCase_ValidateTemplateForDatabase tests database compatibility validation.
 func TestTemplateUseCase_ValidateTemplateForDatabase(t *testing.T) {
 	ctx := context.Background()
@@ -523,3 +580,22 @@ func intPtr(i int) *int {
 
 // Need to define this constant for the test
 const parameterTypeEnum template.ParameterType = template.ParameterTypeEnum
+
+// TestTemplateUseCase_LoadBuiltinTemplates_Embedded tests loading the templates embedded in the binary.
+func TestTemplateUseCase_LoadBuiltinTemplates_Embedded(t *testing.T) {
+	ctx := context.Background()
+	repo := newMockTemplateRepository()
+	uc := NewTemplateUseCaseFS(repo, contracts.BuiltinTemplates())
+
+	if err := uc.LoadBuiltinTemplates(ctx); err != nil {
+		t.Fatalf("LoadBuiltinTemplates() failed: %v", err)
+	}
+
+	builtin, err := uc.ListBuiltinTemplates(ctx)
+	if err != nil {
+		t.Fatalf("ListBuiltinTemplates() failed: %v", err)
+	}
+	if len(builtin) == 0 {
+		t.Error("no builtin templates loaded from embedded files")
+	}
+}
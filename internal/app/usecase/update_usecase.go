@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/update"
+)
+
+// ErrNoUpdateAsset is returned when a release has no binary for the current
+// platform.
+var ErrNoUpdateAsset = errors.New("release has no binary for this platform")
+
+// UpdateInfo is the result of an update check.
+type UpdateInfo struct {
+	Current   string          // Version running
+	Release   *update.Release // Latest release
+	Available bool            // Whether Release is newer than Current
+	Asset     *update.Asset   // Binary of Release for this platform; nil if none
+	CheckedAt time.Time
+}
+
+// UpdateUseCase checks for new versions of DB-BenchMind and downloads them.
+type UpdateUseCase struct {
+	client      *update.Client
+	downloadDir string
+	settingsUC  *SettingsUseCase
+	appVersion  string
+
+	mu   sync.Mutex
+	last *UpdateInfo
+}
+
+// NewUpdateUseCase creates a new update use case that queries client and
+// downloads into downloadDir. Automatic checks follow the check_updates
+// setting of settingsUC.
+func NewUpdateUseCase(client *update.Client, downloadDir string, settingsUC *SettingsUseCase) *UpdateUseCase {
+	return &UpdateUseCase{
+		client:      client,
+		downloadDir: downloadDir,
+		settingsUC:  settingsUC,
+	}
+}
+
+// SetAppVersion sets the version running, which releases are compared with.
+func (uc *UpdateUseCase) SetAppVersion(version string) {
+	uc.appVersion = version
+}
+
+// DownloadDir returns the directory new versions are downloaded into.
+func (uc *UpdateUseCase) DownloadDir() string {
+	return uc.downloadDir
+}
+
+// Enabled reports whether automatic update checks are enabled.
+func (uc *UpdateUseCase) Enabled(ctx context.Context) bool {
+	advCfg, err := uc.settingsUC.GetAdvancedConfig(ctx)
+	if err != nil {
+		slog.Warn("Update: Failed to read settings", "error", err)
+		return false
+	}
+	return advCfg.CheckUpdates
+}
+
+// Check queries the latest release and compares it with the version running.
+func (uc *UpdateUseCase) Check(ctx context.Context) (*UpdateInfo, error) {
+	release, err := uc.client.Latest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("check for updates: %w", err)
+	}
+
+	info := &UpdateInfo{
+		Current:   uc.appVersion,
+		Release:   release,
+		Available: update.Newer(release.Version, uc.appVersion),
+		Asset:     release.Asset(runtime.GOOS, runtime.GOARCH),
+		CheckedAt: time.Now(),
+	}
+	slog.Info("Update: Checked", "current", info.Current, "latest", release.Version, "available", info.Available)
+
+	uc.mu.Lock()
+	uc.last = info
+	uc.mu.Unlock()
+	return info, nil
+}
+
+// CheckIfEnabled checks for updates if automatic checks are enabled, and
+// returns nil otherwise.
+func (uc *UpdateUseCase) CheckIfEnabled(ctx context.Context) (*UpdateInfo, error) {
+	if !uc.Enabled(ctx) {
+		return nil, nil
+	}
+	return uc.Check(ctx)
+}
+
+// Last returns the result of the last check, or nil if none succeeded.
+func (uc *UpdateUseCase) Last() *UpdateInfo {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	return uc.last
+}
+
+// Download downloads the binary of the release in info for this platform.
+// Returns its path and whether its checksum was verified.
+func (uc *UpdateUseCase) Download(ctx context.Context, info *UpdateInfo) (string, bool, error) {
+	if info.Asset == nil {
+		return "", false, fmt.Errorf("%w (%s/%s)", ErrNoUpdateAsset, runtime.GOOS, runtime.GOARCH)
+	}
+	return uc.client.Download(ctx, info.Release, info.Asset, uc.downloadDir)
+}
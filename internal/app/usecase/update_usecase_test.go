@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/update"
+)
+
+func TestUpdateUseCase_Check(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(update.Release{Version: "v1.1.0", Notes: "- Fixes"})
+	}))
+	defer server.Close()
+
+	settingsUC := setupSettingsTest(t)
+	uc := NewUpdateUseCase(update.NewClient(server.URL, "owner/repo"), t.TempDir(), settingsUC)
+	uc.SetAppVersion("1.0.0")
+
+	info, err := uc.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if !info.Available || info.Current != "1.0.0" || info.Release.Version != "v1.1.0" {
+		t.Errorf("Check() = %+v, want v1.1.0 available over 1.0.0", info)
+	}
+	if uc.Last() != info {
+		t.Error("Last() does not return the last check")
+	}
+	// The release has no binaries
+	if _, _, err := uc.Download(ctx, info); !errors.Is(err, ErrNoUpdateAsset) {
+		t.Errorf("Download() error = %v, want ErrNoUpdateAsset", err)
+	}
+
+	advCfg, err := settingsUC.GetAdvancedConfig(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	advCfg.CheckUpdates = false
+	if err := settingsUC.UpdateAdvancedConfig(ctx, *advCfg); err != nil {
+		t.Fatalf("UpdateAdvancedConfig() error = %v", err)
+	}
+	if info, err := uc.CheckIfEnabled(ctx); info != nil || err != nil {
+		t.Errorf("CheckIfEnabled() = %+v, %v; want nil when disabled", info, err)
+	}
+}
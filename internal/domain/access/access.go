@@ -0,0 +1,63 @@
+// Package access provides the roles of the app lock and the permissions
+// each role is granted.
+package access
+
+import "fmt"
+
+// Role is the role a session is unlocked with.
+type Role string
+
+const (
+	// RoleAdmin may do everything, including managing connections and the app lock.
+	RoleAdmin Role = "admin"
+
+	// RoleOperator may only run benchmarks and view history.
+	RoleOperator Role = "operator"
+	// RoleViewer may only view history. It is granted to API requests, e.g.
+	// by the groups of an OIDC token; the app lock has no viewer password.
+	RoleViewer Role = "viewer"
+)
+
+// Permission is an operation restricted by role.
+type Permission string
+
+const (
+	PermissionManageConnections Permission = "manage connections"
+	PermissionCleanup           Permission = "run cleanup"
+	PermissionRunBenchmarks     Permission = "run benchmarks"
+	PermissionViewHistory       Permission = "view history"
+	PermissionManageAppLock     Permission = "manage the app lock"
+)
+
+// operatorPermissions are the permissions of the operator role.
+var operatorPermissions = map[Permission]bool{
+	PermissionRunBenchmarks: true,
+	PermissionViewHistory:   true,
+}
+
+// viewerPermissions are the permissions of the viewer role.
+var viewerPermissions = map[Permission]bool{
+	PermissionViewHistory: true,
+}
+
+// Validate checks that the role is known.
+func (r Role) Validate() error {
+	switch r {
+	case RoleAdmin, RoleOperator, RoleViewer:
+		return nil
+	}
+	return fmt.Errorf("unknown role: %q", r)
+}
+
+// Can reports whether the role is granted permission.
+func (r Role) Can(permission Permission) bool {
+	switch r {
+	case RoleAdmin:
+		return true
+	case RoleOperator:
+		return operatorPermissions[permission]
+	case RoleViewer:
+		return viewerPermissions[permission]
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package access
+
+import "testing"
+
+func TestRole_Can(t *testing.T) {
+	tests := []struct {
+		role       Role
+		permission Permission
+		want       bool
+	}{
+		{RoleAdmin, PermissionManageConnections, true},
+		{RoleAdmin, PermissionCleanup, true},
+		{RoleAdmin, PermissionManageAppLock, true},
+		{RoleOperator, PermissionRunBenchmarks, true},
+		{RoleOperator, PermissionViewHistory, true},
+		{RoleOperator, PermissionManageConnections, false},
+		{RoleOperator, PermissionCleanup, false},
+		{RoleOperator, PermissionManageAppLock, false},
+		{RoleViewer, PermissionViewHistory, true},
+		{RoleViewer, PermissionRunBenchmarks, false},
+		{RoleViewer, PermissionCleanup, false},
+		{"", PermissionRunBenchmarks, false},
+	}
+	for _, tt := range tests {
+		if got := tt.role.Can(tt.permission); got != tt.want {
+			t.Errorf("%q.Can(%q) = %v, want %v", tt.role, tt.permission, got, tt.want)
+		}
+	}
+}
+
+func TestRole_Validate(t *testing.T) {
+	for _, role := range []Role{RoleAdmin, RoleOperator, RoleViewer} {
+		if err := role.Validate(); err != nil {
+			t.Errorf("%q.Validate() error = %v", role, err)
+		}
+	}
+	if err := Role("guest").Validate(); err == nil {
+		t.Error("Validate() should fail for an unknown role")
+	}
+}
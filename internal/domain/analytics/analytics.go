@@ -0,0 +1,142 @@
+// Package analytics provides the analytical query benchmark: a TPC-H-like
+// data set loaded at a scale factor, and runs of its 22 decision-support
+// queries whose latencies are recorded per query and compared across runs.
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+// DefaultScaleFactor is the scale factor of a data set when none is given:
+// about 100MB, 600,000 lineitem rows.
+const DefaultScaleFactor = 0.1
+
+// Run is a run of the analytical queries: every query stream runs the
+// selected queries once, the streams at the same time.
+type Run struct {
+	ID             string             `json:"id"`   // UUID
+	Name           string             `json:"name"` // Label (optional)
+	ConnectionName string             `json:"connection_name"`
+	DatabaseType   string             `json:"database_type"`
+	Database       string             `json:"database"`
+	ScaleFactor    float64            `json:"scale_factor"` // Of the data set queried
+	Concurrency    int                `json:"concurrency"`  // Query streams; 1 runs the queries sequentially
+	Queries        []int              `json:"queries"`      // Query numbers each stream runs
+	State          execution.RunState `json:"state"`
+	ErrorMessage   string             `json:"error_message,omitempty"`
+	StartedAt      time.Time          `json:"started_at"`
+	Duration       time.Duration      `json:"duration"`
+	Results        []QueryResult      `json:"results"` // In completion order
+}
+
+// QueryResult is one execution of a query by a stream.
+type QueryResult struct {
+	Query   int           `json:"query"`  // Query number, 1 to 22
+	Stream  int           `json:"stream"` // Query stream, from 1
+	Latency time.Duration `json:"latency"`
+	Rows    int64         `json:"rows"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// QueryStats are the latencies of the successful executions of a query in a run.
+type QueryStats struct {
+	Query      int
+	Executions int // Successful executions
+	Errors     int
+	Min        time.Duration
+	Avg        time.Duration
+	Max        time.Duration
+}
+
+// String formats the stats for logs and the CLI.
+func (s QueryStats) String() string {
+	if s.Executions == 0 {
+		return fmt.Sprintf("Q%d: %d errors", s.Query, s.Errors)
+	}
+	return fmt.Sprintf("Q%d: avg %s, min %s, max %s (%d runs, %d errors)",
+		s.Query, formatLatency(s.Avg), formatLatency(s.Min), formatLatency(s.Max), s.Executions, s.Errors)
+}
+
+// QueryStats returns the stats of each query of the run, by query number.
+func (r *Run) QueryStats() []QueryStats {
+	byQuery := make(map[int]*QueryStats)
+	total := make(map[int]time.Duration)
+	for _, result := range r.Results {
+		stats, ok := byQuery[result.Query]
+		if !ok {
+			stats = &QueryStats{Query: result.Query}
+			byQuery[result.Query] = stats
+		}
+		if result.Error != "" {
+			stats.Errors++
+			continue
+		}
+		if stats.Executions == 0 || result.Latency < stats.Min {
+			stats.Min = result.Latency
+		}
+		stats.Max = max(stats.Max, result.Latency)
+		stats.Executions++
+		total[result.Query] += result.Latency
+	}
+
+	all := make([]QueryStats, 0, len(byQuery))
+	for query, stats := range byQuery {
+		if stats.Executions > 0 {
+			stats.Avg = total[query] / time.Duration(stats.Executions)
+		}
+		all = append(all, *stats)
+	}
+	slices.SortFunc(all, func(a, b QueryStats) int { return a.Query - b.Query })
+	return all
+}
+
+// Errors returns the number of failed query executions.
+func (r *Run) Errors() int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Error != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// GeoMean returns the geometric mean of the average latencies of the queries
+// that succeeded at least once, as the TPC-H power test does, so that the long
+// queries do not hide changes of the short ones. It is 0 if none succeeded.
+func (r *Run) GeoMean() time.Duration {
+	var sum float64
+	n := 0
+	for _, stats := range r.QueryStats() {
+		if stats.Executions == 0 || stats.Avg <= 0 {
+			continue
+		}
+		sum += math.Log(float64(stats.Avg))
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return time.Duration(math.Exp(sum / float64(n)))
+}
+
+// QueriesPerHour returns the successful query executions per hour of the run.
+func (r *Run) QueriesPerHour() float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(len(r.Results)-r.Errors()) / r.Duration.Hours()
+}
+
+// formatLatency formats a query latency in milliseconds or seconds.
+func formatLatency(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+	}
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
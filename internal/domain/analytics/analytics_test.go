@@ -0,0 +1,188 @@
+// Package analytics provides unit tests for the analytical query benchmark.
+package analytics
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestQueries tests the query set and the scale factor of Q11.
+func TestQueries(t *testing.T) {
+	queries := Queries(0.1)
+	if len(queries) != QueryCount {
+		t.Fatalf("Queries() returned %d queries, want %d", len(queries), QueryCount)
+	}
+	for i, q := range queries {
+		if q.Number != i+1 || q.Name == "" || q.SQL == "" {
+			t.Errorf("query %d = %+v, want number %d with a name and SQL", i, q.Number, i+1)
+		}
+		if strings.Contains(q.SQL, "{") {
+			t.Errorf("Q%d has an unreplaced placeholder: %s", q.Number, q.SQL)
+		}
+	}
+	if !strings.Contains(queries[10].SQL, "* 0.001\n") {
+		t.Errorf("Q11 should use the fraction 0.0001/SF = 0.001, got: %s", queries[10].SQL)
+	}
+}
+
+// TestParseQueryList tests query list parsing.
+func TestParseQueryList(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []int
+		wantErr bool
+	}{
+		{"", AllQueries(), false},
+		{"1,3,5-7", []int{1, 3, 5, 6, 7}, false},
+		{"Q6, q14", []int{6, 14}, false},
+		{"0", nil, true},
+		{"23", nil, true},
+		{"7-5", nil, true},
+		{"x", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseQueryList(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseQueryList(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseQueryList(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestGenerator tests the row counts, the keys and the determinism of the data set.
+func TestGenerator(t *testing.T) {
+	const sf = 0.001
+	generate := func() map[string][][]any {
+		rows := make(map[string][][]any)
+		err := NewGenerator(sf, 42).Generate(func(table string, row []any) error {
+			rows[table] = append(rows[table], row)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Generate() failed: %v", err)
+		}
+		return rows
+	}
+	rows := generate()
+
+	columns := make(map[string]int)
+	for _, table := range Tables {
+		columns[table.Name] = len(table.Columns)
+	}
+	for table, want := range RowCounts(sf) {
+		if got := len(rows[table]); got != want {
+			t.Errorf("%s has %d rows, want %d", table, got, want)
+		}
+	}
+	for table, tableRows := range rows {
+		for _, row := range tableRows {
+			if len(row) != columns[table] {
+				t.Fatalf("%s row has %d values, want %d: %v", table, len(row), columns[table], row)
+			}
+		}
+	}
+	if n := len(rows["lineitem"]); n < len(rows["orders"]) || n > 7*len(rows["orders"]) {
+		t.Errorf("lineitem has %d rows for %d orders, want 1 to 7 per order", n, len(rows["orders"]))
+	}
+
+	// Part/supplier pairs are unique, as the primary key of partsupp requires
+	pairs := make(map[[2]int]bool)
+	for _, row := range rows["partsupp"] {
+		pair := [2]int{row[0].(int), row[1].(int)}
+		if pairs[pair] {
+			t.Fatalf("duplicate partsupp key %v", pair)
+		}
+		pairs[pair] = true
+	}
+	// Lines are supplied by a supplier of their part
+	for _, row := range rows["lineitem"] {
+		if !pairs[[2]int{row[1].(int), row[2].(int)}] {
+			t.Fatalf("lineitem %v references no partsupp row", row[:4])
+		}
+	}
+
+	if !reflect.DeepEqual(rows, generate()) {
+		t.Error("Generate() with the same seed returned different rows")
+	}
+}
+
+// TestRun_QueryStats tests the per-query stats and the summary of a run.
+func TestRun_QueryStats(t *testing.T) {
+	run := &Run{
+		Duration: time.Minute,
+		Results: []QueryResult{
+			{Query: 6, Stream: 1, Latency: 100 * time.Millisecond},
+			{Query: 1, Stream: 1, Latency: 4 * time.Second},
+			{Query: 6, Stream: 2, Latency: 300 * time.Millisecond},
+			{Query: 1, Stream: 2, Error: "timeout"},
+			{Query: 9, Stream: 1, Error: "timeout"},
+		},
+	}
+
+	stats := run.QueryStats()
+	want := []QueryStats{
+		{Query: 1, Executions: 1, Errors: 1, Min: 4 * time.Second, Avg: 4 * time.Second, Max: 4 * time.Second},
+		{Query: 6, Executions: 2, Min: 100 * time.Millisecond, Avg: 200 * time.Millisecond, Max: 300 * time.Millisecond},
+		{Query: 9, Errors: 1},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("QueryStats() = %+v, want %+v", stats, want)
+	}
+
+	// sqrt(4s * 200ms) ≈ 894ms
+	if got := run.GeoMean().Round(time.Millisecond); got != 894*time.Millisecond {
+		t.Errorf("GeoMean() = %v, want 894ms", got)
+	}
+	if got := run.QueriesPerHour(); got != 180 {
+		t.Errorf("QueriesPerHour() = %v, want 180", got)
+	}
+	if got := run.Errors(); got != 2 {
+		t.Errorf("Errors() = %d, want 2", got)
+	}
+}
+
+// TestCompare tests the per-query comparison of runs and its report sections.
+func TestCompare(t *testing.T) {
+	if _, err := Compare(nil); err != ErrNoRuns {
+		t.Errorf("Compare(nil) error = %v, want ErrNoRuns", err)
+	}
+
+	base := &Run{ID: "run-1", Name: "before", Concurrency: 1, Results: []QueryResult{
+		{Query: 1, Latency: 2 * time.Second},
+		{Query: 6, Latency: 200 * time.Millisecond},
+	}}
+	after := &Run{ID: "run-2", Name: "after", Concurrency: 1, Results: []QueryResult{
+		{Query: 1, Latency: time.Second},
+		{Query: 6, Error: "timeout"},
+		{Query: 9, Latency: 5 * time.Second},
+	}}
+
+	c, err := Compare([]*Run{base, after})
+	if err != nil {
+		t.Fatalf("Compare() failed: %v", err)
+	}
+	if len(c.Queries) != 3 || c.Queries[0].Query != 1 || c.Queries[2].Query != 9 {
+		t.Fatalf("Compare() queries = %+v, want Q1, Q6 and Q9", c.Queries)
+	}
+	if change, ok := c.Queries[0].Change(1); !ok || change != -50 {
+		t.Errorf("Q1 change = %v, %v, want -50%%", change, ok)
+	}
+	if _, ok := c.Queries[1].Change(1); ok {
+		t.Error("Q6 failed in R2 and should have no change")
+	}
+
+	md := c.FormatMarkdown()
+	for _, want := range []string{"## 3) Per-Query Latency", "| Q1 Pricing Summary Report | 2.00s | 1.00s | -50.0% |", "| Q6 Forecasting Revenue Change | 200.0ms | error | - |", "| Q9 Product Type Profit Measure | - | 5.00s | - |"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("FormatMarkdown() should contain %q, got:\n%s", want, md)
+		}
+	}
+	if txt := c.FormatTXT(); !strings.Contains(txt, "-50.0%") {
+		t.Errorf("FormatTXT() should contain the change of Q1, got:\n%s", txt)
+	}
+}
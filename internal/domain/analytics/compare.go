@@ -0,0 +1,178 @@
+// Package analytics provides the per-query comparison of analytical runs.
+package analytics
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrNoRuns is returned when a comparison has no runs.
+var ErrNoRuns = errors.New("no analytical runs to compare")
+
+// Comparison compares the per-query latencies of analytical runs against
+// the first run, the baseline.
+type Comparison struct {
+	Runs    []*Run
+	Queries []QueryComparison // By query number; queries of any run
+}
+
+// QueryComparison is the average latency of a query in each run of a comparison.
+type QueryComparison struct {
+	Query int
+	Name  string
+	Stats []QueryStats // One per run; Executions is 0 if the query did not run or failed
+}
+
+// Compare compares runs, the first being the baseline.
+func Compare(runs []*Run) (*Comparison, error) {
+	if len(runs) == 0 {
+		return nil, ErrNoRuns
+	}
+
+	byQuery := make(map[int][]QueryStats)
+	for i, run := range runs {
+		for _, stats := range run.QueryStats() {
+			if _, ok := byQuery[stats.Query]; !ok {
+				byQuery[stats.Query] = make([]QueryStats, len(runs))
+			}
+			byQuery[stats.Query][i] = stats
+		}
+	}
+
+	c := &Comparison{Runs: runs}
+	for query, stats := range byQuery {
+		for i := range stats {
+			stats[i].Query = query
+		}
+		c.Queries = append(c.Queries, QueryComparison{Query: query, Name: queryName(query), Stats: stats})
+	}
+	slices.SortFunc(c.Queries, func(a, b QueryComparison) int { return a.Query - b.Query })
+	return c, nil
+}
+
+// Change returns the change in percent of the average latency of the query in
+// run i versus the baseline; false if either has no successful execution.
+func (q QueryComparison) Change(i int) (float64, bool) {
+	base, other := q.Stats[0], q.Stats[i]
+	if base.Executions == 0 || other.Executions == 0 || base.Avg <= 0 {
+		return 0, false
+	}
+	return (float64(other.Avg) - float64(base.Avg)) / float64(base.Avg) * 100, true
+}
+
+// FormatMarkdown formats the comparison as the sections of a Markdown report:
+// the runs, their summary and the per-query latencies.
+func (c *Comparison) FormatMarkdown() string {
+	var b strings.Builder
+	b.WriteString("# Analytical Query Benchmark Report\n\n")
+
+	b.WriteString("## 1) Runs\n\n")
+	b.WriteString("| Run | Name | Connection | Database | Scale Factor | Streams | Started | State |\n")
+	b.WriteString("|-----|------|------------|----------|-------------:|--------:|---------|-------|\n")
+	for i, run := range c.Runs {
+		fmt.Fprintf(&b, "| R%d | %s | %s | %s | %g | %d | %s | %s |\n", i+1, run.Name, run.ConnectionName,
+			run.DatabaseType, run.ScaleFactor, run.Concurrency, run.StartedAt.Format("2006-01-02 15:04:05"), run.State)
+	}
+
+	b.WriteString("\n## 2) Summary\n\n")
+	b.WriteString("| Run | Duration | Geometric Mean | Queries/Hour | Errors |\n")
+	b.WriteString("|-----|---------:|---------------:|-------------:|-------:|\n")
+	for i, run := range c.Runs {
+		fmt.Fprintf(&b, "| R%d | %s | %s | %.1f | %d |\n", i+1, run.Duration.Round(time.Millisecond),
+			formatLatency(run.GeoMean()), run.QueriesPerHour(), run.Errors())
+	}
+
+	b.WriteString("\n## 3) Per-Query Latency (average)\n\n")
+	b.WriteString("| Query |")
+	for i := range c.Runs {
+		fmt.Fprintf(&b, " R%d |", i+1)
+		if i > 0 {
+			fmt.Fprintf(&b, " R%d vs R1 |", i+1)
+		}
+	}
+	b.WriteString("\n|-------|")
+	for i := range c.Runs {
+		b.WriteString("----:|")
+		if i > 0 {
+			b.WriteString("---------:|")
+		}
+	}
+	b.WriteString("\n")
+	for _, q := range c.Queries {
+		fmt.Fprintf(&b, "| Q%d %s |", q.Query, q.Name)
+		for i, stats := range q.Stats {
+			fmt.Fprintf(&b, " %s |", formatStats(stats))
+			if i > 0 {
+				fmt.Fprintf(&b, " %s |", formatChange(q, i))
+			}
+		}
+		b.WriteString("\n")
+	}
+	if len(c.Runs) > 1 {
+		b.WriteString("\nA positive change is a slower query than in R1.\n")
+	}
+	return b.String()
+}
+
+// FormatTXT formats the comparison as plain text for the terminal.
+func (c *Comparison) FormatTXT() string {
+	var b strings.Builder
+	for i, run := range c.Runs {
+		fmt.Fprintf(&b, "R%d  %s  %s (%s, SF %g, %d streams)  %s  %s\n", i+1, run.ID, run.ConnectionName,
+			run.DatabaseType, run.ScaleFactor, run.Concurrency, run.StartedAt.Format("2006-01-02 15:04:05"), run.State)
+		fmt.Fprintf(&b, "    duration %s, geometric mean %s, %.1f queries/hour, %d errors\n",
+			run.Duration.Round(time.Millisecond), formatLatency(run.GeoMean()), run.QueriesPerHour(), run.Errors())
+	}
+
+	b.WriteString("\nQuery")
+	for i := range c.Runs {
+		fmt.Fprintf(&b, "%12s", fmt.Sprintf("R%d", i+1))
+		if i > 0 {
+			fmt.Fprintf(&b, "%10s", "change")
+		}
+	}
+	b.WriteString("\n")
+	for _, q := range c.Queries {
+		fmt.Fprintf(&b, "Q%-4d", q.Query)
+		for i, stats := range q.Stats {
+			fmt.Fprintf(&b, "%12s", formatStats(stats))
+			if i > 0 {
+				fmt.Fprintf(&b, "%10s", formatChange(q, i))
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// formatStats formats the average latency of a query, or why it has none.
+func formatStats(stats QueryStats) string {
+	switch {
+	case stats.Executions > 0:
+		return formatLatency(stats.Avg)
+	case stats.Errors > 0:
+		return "error"
+	default:
+		return "-"
+	}
+}
+
+// formatChange formats the change of a query in run i versus the baseline.
+func formatChange(q QueryComparison, i int) string {
+	change, ok := q.Change(i)
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", change)
+}
+
+// queryName returns the name of a query number, or "" if it is unknown.
+func queryName(query int) string {
+	if query < 1 || query > QueryCount {
+		return ""
+	}
+	return queryNames[query-1]
+}
@@ -0,0 +1,407 @@
+// Package analytics provides the schema and the generator of the data set.
+package analytics
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+// Table is a table of the data set.
+type Table struct {
+	Name    string
+	Columns []string
+	DDL     string // CREATE TABLE statement, the same for MySQL and PostgreSQL
+}
+
+// Tables are the tables of the data set, in the order they are loaded.
+var Tables = []Table{
+	{
+		Name:    "region",
+		Columns: []string{"r_regionkey", "r_name", "r_comment"},
+		DDL: `CREATE TABLE region (r_regionkey INTEGER NOT NULL, r_name CHAR(25) NOT NULL, r_comment VARCHAR(152),
+	PRIMARY KEY (r_regionkey))`,
+	},
+	{
+		Name:    "nation",
+		Columns: []string{"n_nationkey", "n_name", "n_regionkey", "n_comment"},
+		DDL: `CREATE TABLE nation (n_nationkey INTEGER NOT NULL, n_name CHAR(25) NOT NULL, n_regionkey INTEGER NOT NULL,
+	n_comment VARCHAR(152), PRIMARY KEY (n_nationkey))`,
+	},
+	{
+		Name:    "part",
+		Columns: []string{"p_partkey", "p_name", "p_mfgr", "p_brand", "p_type", "p_size", "p_container", "p_retailprice", "p_comment"},
+		DDL: `CREATE TABLE part (p_partkey INTEGER NOT NULL, p_name VARCHAR(55) NOT NULL, p_mfgr CHAR(25) NOT NULL,
+	p_brand CHAR(10) NOT NULL, p_type VARCHAR(25) NOT NULL, p_size INTEGER NOT NULL, p_container CHAR(10) NOT NULL,
+	p_retailprice DECIMAL(15,2) NOT NULL, p_comment VARCHAR(23) NOT NULL, PRIMARY KEY (p_partkey))`,
+	},
+	{
+		Name:    "supplier",
+		Columns: []string{"s_suppkey", "s_name", "s_address", "s_nationkey", "s_phone", "s_acctbal", "s_comment"},
+		DDL: `CREATE TABLE supplier (s_suppkey INTEGER NOT NULL, s_name CHAR(25) NOT NULL, s_address VARCHAR(40) NOT NULL,
+	s_nationkey INTEGER NOT NULL, s_phone CHAR(15) NOT NULL, s_acctbal DECIMAL(15,2) NOT NULL,
+	s_comment VARCHAR(101) NOT NULL, PRIMARY KEY (s_suppkey))`,
+	},
+	{
+		Name:    "partsupp",
+		Columns: []string{"ps_partkey", "ps_suppkey", "ps_availqty", "ps_supplycost", "ps_comment"},
+		DDL: `CREATE TABLE partsupp (ps_partkey INTEGER NOT NULL, ps_suppkey INTEGER NOT NULL, ps_availqty INTEGER NOT NULL,
+	ps_supplycost DECIMAL(15,2) NOT NULL, ps_comment VARCHAR(199) NOT NULL, PRIMARY KEY (ps_partkey, ps_suppkey))`,
+	},
+	{
+		Name:    "customer",
+		Columns: []string{"c_custkey", "c_name", "c_address", "c_nationkey", "c_phone", "c_acctbal", "c_mktsegment", "c_comment"},
+		DDL: `CREATE TABLE customer (c_custkey INTEGER NOT NULL, c_name VARCHAR(25) NOT NULL, c_address VARCHAR(40) NOT NULL,
+	c_nationkey INTEGER NOT NULL, c_phone CHAR(15) NOT NULL, c_acctbal DECIMAL(15,2) NOT NULL,
+	c_mktsegment CHAR(10) NOT NULL, c_comment VARCHAR(117) NOT NULL, PRIMARY KEY (c_custkey))`,
+	},
+	{
+		Name:    "orders",
+		Columns: []string{"o_orderkey", "o_custkey", "o_orderstatus", "o_totalprice", "o_orderdate", "o_orderpriority", "o_clerk", "o_shippriority", "o_comment"},
+		DDL: `CREATE TABLE orders (o_orderkey INTEGER NOT NULL, o_custkey INTEGER NOT NULL, o_orderstatus CHAR(1) NOT NULL,
+	o_totalprice DECIMAL(15,2) NOT NULL, o_orderdate DATE NOT NULL, o_orderpriority CHAR(15) NOT NULL,
+	o_clerk CHAR(15) NOT NULL, o_shippriority INTEGER NOT NULL, o_comment VARCHAR(79) NOT NULL, PRIMARY KEY (o_orderkey))`,
+	},
+	{
+		Name: "lineitem",
+		Columns: []string{"l_orderkey", "l_partkey", "l_suppkey", "l_linenumber", "l_quantity", "l_extendedprice", "l_discount", "l_tax",
+			"l_returnflag", "l_linestatus", "l_shipdate", "l_commitdate", "l_receiptdate", "l_shipinstruct", "l_shipmode", "l_comment"},
+		DDL: `CREATE TABLE lineitem (l_orderkey INTEGER NOT NULL, l_partkey INTEGER NOT NULL, l_suppkey INTEGER NOT NULL,
+	l_linenumber INTEGER NOT NULL, l_quantity DECIMAL(15,2) NOT NULL, l_extendedprice DECIMAL(15,2) NOT NULL,
+	l_discount DECIMAL(15,2) NOT NULL, l_tax DECIMAL(15,2) NOT NULL, l_returnflag CHAR(1) NOT NULL,
+	l_linestatus CHAR(1) NOT NULL, l_shipdate DATE NOT NULL, l_commitdate DATE NOT NULL, l_receiptdate DATE NOT NULL,
+	l_shipinstruct CHAR(25) NOT NULL, l_shipmode CHAR(10) NOT NULL, l_comment VARCHAR(44) NOT NULL,
+	PRIMARY KEY (l_orderkey, l_linenumber))`,
+	},
+}
+
+// Indexes are the secondary indexes, created after the data is loaded, on the
+// join columns the queries use besides the primary keys.
+var Indexes = []string{
+	"CREATE INDEX idx_nation_regionkey ON nation (n_regionkey)",
+	"CREATE INDEX idx_supplier_nationkey ON supplier (s_nationkey)",
+	"CREATE INDEX idx_partsupp_suppkey ON partsupp (ps_suppkey)",
+	"CREATE INDEX idx_customer_nationkey ON customer (c_nationkey)",
+	"CREATE INDEX idx_orders_custkey ON orders (o_custkey)",
+	"CREATE INDEX idx_orders_orderdate ON orders (o_orderdate)",
+	"CREATE INDEX idx_lineitem_part_supp ON lineitem (l_partkey, l_suppkey)",
+	"CREATE INDEX idx_lineitem_shipdate ON lineitem (l_shipdate)",
+}
+
+// Rows at scale factor 1 of the tables whose size scales.
+const (
+	suppliersPerSF   = 10_000
+	partsPerSF       = 200_000
+	customersPerSF   = 150_000
+	ordersPerSF      = 1_500_000
+	clerksPerSF      = 1_000
+	suppliersPerPart = 4
+)
+
+// Dates of the data set: orders are placed from startDate to endDate minus
+// 151 days, and lines received until currentDate are returned or accepted.
+var (
+	startDate   = time.Date(1992, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate     = time.Date(1998, 12, 31, 0, 0, 0, 0, time.UTC)
+	currentDate = time.Date(1995, 6, 17, 0, 0, 0, 0, time.UTC)
+)
+
+// Value domains of TPC-H.
+var (
+	regions = []string{"AFRICA", "AMERICA", "ASIA", "EUROPE", "MIDDLE EAST"}
+	nations = []struct {
+		name   string
+		region int
+	}{
+		{"ALGERIA", 0}, {"ARGENTINA", 1}, {"BRAZIL", 1}, {"CANADA", 1}, {"EGYPT", 4},
+		{"ETHIOPIA", 0}, {"FRANCE", 3}, {"GERMANY", 3}, {"INDIA", 2}, {"INDONESIA", 2},
+		{"IRAN", 4}, {"IRAQ", 4}, {"JAPAN", 2}, {"JORDAN", 4}, {"KENYA", 0},
+		{"MOROCCO", 0}, {"MOZAMBIQUE", 0}, {"PERU", 1}, {"CHINA", 2}, {"ROMANIA", 3},
+		{"SAUDI ARABIA", 4}, {"VIETNAM", 2}, {"RUSSIA", 3}, {"UNITED KINGDOM", 3}, {"UNITED STATES", 1},
+	}
+	colors = strings.Fields(`almond antique aquamarine azure beige bisque black blanched blue blush brown burlywood
+		burnished chartreuse chiffon chocolate coral cornflower cornsilk cream cyan dark deep dim dodger drab firebrick
+		floral forest frosted gainsboro ghost goldenrod green grey honeydew hot indian ivory khaki lace lavender lawn
+		lemon light lime linen magenta maroon medium metallic midnight mint misty moccasin navajo navy olive orange
+		orchid pale papaya peach peru pink plum powder puff purple red rose rosy royal saddle salmon sandy seashell
+		sienna sky slate smoke snow spring steel tan thistle tomato turquoise violet wheat white yellow`)
+	typeSizes      = []string{"STANDARD", "SMALL", "MEDIUM", "LARGE", "ECONOMY", "PROMO"}
+	typeFinishes   = []string{"ANODIZED", "BURNISHED", "PLATED", "POLISHED", "BRUSHED"}
+	typeMaterials  = []string{"TIN", "NICKEL", "BRASS", "STEEL", "COPPER"}
+	containerSizes = []string{"SM", "LG", "MED", "JUMBO", "WRAP"}
+	containerTypes = []string{"CASE", "BOX", "BAG", "JAR", "PKG", "PACK", "CAN", "DRUM"}
+	segments       = []string{"AUTOMOBILE", "BUILDING", "FURNITURE", "MACHINERY", "HOUSEHOLD"}
+	priorities     = []string{"1-URGENT", "2-HIGH", "3-MEDIUM", "4-NOT SPECIFIED", "5-LOW"}
+	instructions   = []string{"DELIVER IN PERSON", "COLLECT COD", "NONE", "TAKE BACK RETURN"}
+	shipModes      = []string{"REG AIR", "AIR", "RAIL", "SHIP", "TRUCK", "MAIL", "FOB"}
+	words          = strings.Fields(`furiously quickly carefully blithely slyly fluffily final regular express
+		ironic pending bold even silent unusual special idle ruthless deposits accounts packages requests foxes
+		ideas theodolites pinto beans instructions dependencies excuses platelets asymptotes courts dolphins
+		sleep wake are haggle nag use boost affix detect integrate cajole print among across against along`)
+)
+
+// RowCounts returns the rows of each table at scaleFactor, except lineitem,
+// which has 1 to 7 rows per order, about 4 on average. Every table that
+// scales has at least 4 rows, the suppliers of a part.
+func RowCounts(scaleFactor float64) map[string]int {
+	scaled := func(perSF int) int { return max(suppliersPerPart, int(float64(perSF)*scaleFactor)) }
+	return map[string]int{
+		"region":   len(regions),
+		"nation":   len(nations),
+		"part":     scaled(partsPerSF),
+		"supplier": scaled(suppliersPerSF),
+		"partsupp": scaled(partsPerSF) * suppliersPerPart,
+		"customer": scaled(customersPerSF),
+		"orders":   scaled(ordersPerSF),
+	}
+}
+
+// Generator generates the rows of a data set. The same scale factor and
+// seed always generate the same rows.
+type Generator struct {
+	rng       *rand.Rand
+	parts     int
+	suppliers int
+	customers int
+	orders    int
+	clerks    int
+}
+
+// NewGenerator returns a generator of the data set at scaleFactor.
+func NewGenerator(scaleFactor float64, seed uint64) *Generator {
+	counts := RowCounts(scaleFactor)
+	return &Generator{
+		rng:       rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)),
+		parts:     counts["part"],
+		suppliers: counts["supplier"],
+		customers: counts["customer"],
+		orders:    counts["orders"],
+		clerks:    max(1, int(clerksPerSF*scaleFactor)),
+	}
+}
+
+// Generate calls emit with every row of the data set, in the column order of
+// Tables. The tables come in the order of Tables, except that the lines of
+// each order follow the order. Generation stops at the first error of emit.
+func (g *Generator) Generate(emit func(table string, row []any) error) error {
+	for i, name := range regions {
+		if err := emit("region", []any{i, name, g.comment(152)}); err != nil {
+			return err
+		}
+	}
+	for i, n := range nations {
+		if err := emit("nation", []any{i, n.name, n.region, g.comment(152)}); err != nil {
+			return err
+		}
+	}
+	for key := 1; key <= g.parts; key++ {
+		if err := emit("part", g.part(key)); err != nil {
+			return err
+		}
+	}
+	for key := 1; key <= g.suppliers; key++ {
+		if err := emit("supplier", g.supplier(key)); err != nil {
+			return err
+		}
+	}
+	for key := 1; key <= g.parts; key++ {
+		for i := range suppliersPerPart {
+			row := []any{key, g.partSupplier(key, i), g.between(1, 9999), g.money(100, 100000), g.comment(199)}
+			if err := emit("partsupp", row); err != nil {
+				return err
+			}
+		}
+	}
+	for key := 1; key <= g.customers; key++ {
+		if err := emit("customer", g.customer(key)); err != nil {
+			return err
+		}
+	}
+	for key := 1; key <= g.orders; key++ {
+		order, lines := g.order(key)
+		if err := emit("orders", order); err != nil {
+			return err
+		}
+		for _, line := range lines {
+			if err := emit("lineitem", line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// part returns the part row of key.
+func (g *Generator) part(key int) []any {
+	name := make([]string, 5)
+	for i, j := range g.rng.Perm(len(colors))[:5] {
+		name[i] = colors[j]
+	}
+	mfgr := g.between(1, 5)
+	return []any{
+		key,
+		strings.Join(name, " "),
+		fmt.Sprintf("Manufacturer#%d", mfgr),
+		fmt.Sprintf("Brand#%d%d", mfgr, g.between(1, 5)),
+		g.pick(typeSizes) + " " + g.pick(typeFinishes) + " " + g.pick(typeMaterials),
+		g.between(1, 50),
+		g.pick(containerSizes) + " " + g.pick(containerTypes),
+		retailPrice(key),
+		g.comment(23),
+	}
+}
+
+// supplier returns the supplier row of key. A few suppliers have customer
+// complaints in their comment, which Q16 excludes.
+func (g *Generator) supplier(key int) []any {
+	nation := g.between(0, len(nations)-1)
+	comment := g.comment(101)
+	if g.rng.IntN(2000) < 5 {
+		comment = truncate("Customer "+g.comment(40)+" Complaints "+comment, 101)
+	}
+	return []any{
+		key,
+		fmt.Sprintf("Supplier#%09d", key),
+		g.comment(40),
+		nation,
+		g.phone(nation),
+		g.money(-99999, 999999),
+		comment,
+	}
+}
+
+// customer returns the customer row of key.
+func (g *Generator) customer(key int) []any {
+	nation := g.between(0, len(nations)-1)
+	return []any{
+		key,
+		fmt.Sprintf("Customer#%09d", key),
+		g.comment(40),
+		nation,
+		g.phone(nation),
+		g.money(-99999, 999999),
+		g.pick(segments),
+		g.comment(117),
+	}
+}
+
+// order returns the order row of key and its lines. As in TPC-H, a third of
+// the customers (keys divisible by 3) place no orders, and the order status
+// follows from the status of its lines.
+func (g *Generator) order(key int) ([]any, [][]any) {
+	customer := g.between(1, g.customers)
+	for g.customers >= 3 && customer%3 == 0 {
+		customer = g.between(1, g.customers)
+	}
+	orderDate := startDate.AddDate(0, 0, g.rng.IntN(int(endDate.Sub(startDate).Hours()/24)-151+1))
+
+	var lines [][]any
+	var total float64
+	shipped := 0
+	count := g.between(1, 7)
+	for number := 1; number <= count; number++ {
+		part := g.between(1, g.parts)
+		quantity := g.between(1, 50)
+		price := round2(float64(quantity) * retailPrice(part))
+		discount := float64(g.between(0, 10)) / 100
+		tax := float64(g.between(0, 8)) / 100
+		shipDate := orderDate.AddDate(0, 0, g.between(1, 121))
+		commitDate := orderDate.AddDate(0, 0, g.between(30, 90))
+		receiptDate := shipDate.AddDate(0, 0, g.between(1, 30))
+
+		returnFlag := "N"
+		if !receiptDate.After(currentDate) {
+			returnFlag = g.pick([]string{"R", "A"})
+		}
+		lineStatus := "O"
+		if !shipDate.After(currentDate) {
+			lineStatus = "F"
+			shipped++
+		}
+		total += price * (1 + tax) * (1 - discount)
+
+		lines = append(lines, []any{
+			key, part, g.partSupplier(part, g.rng.IntN(suppliersPerPart)), number,
+			quantity, price, discount, tax, returnFlag, lineStatus,
+			formatDate(shipDate), formatDate(commitDate), formatDate(receiptDate),
+			g.pick(instructions), g.pick(shipModes), g.comment(44),
+		})
+	}
+
+	status := "P"
+	switch shipped {
+	case 0:
+		status = "O"
+	case count:
+		status = "F"
+	}
+	comment := g.comment(79)
+	if g.rng.IntN(100) == 0 {
+		comment = truncate(g.comment(20)+" special "+g.comment(20)+" requests "+comment, 79)
+	}
+	order := []any{
+		key, customer, status, round2(total), formatDate(orderDate), g.pick(priorities),
+		fmt.Sprintf("Clerk#%09d", g.between(1, g.clerks)), 0, comment,
+	}
+	return order, lines
+}
+
+// partSupplier returns the i-th of the suppliers of part. The suppliers of a
+// part are a quarter of all suppliers apart, so they are distinct.
+func (g *Generator) partSupplier(part, i int) int {
+	return (part+i*(g.suppliers/suppliersPerPart))%g.suppliers + 1
+}
+
+// retailPrice returns the retail price of a part, which TPC-H derives from its key.
+func retailPrice(key int) float64 {
+	return float64(90000+(key/10)%20001+100*(key%1000)) / 100
+}
+
+// phone returns a phone number whose country code is derived from nation.
+func (g *Generator) phone(nation int) string {
+	return fmt.Sprintf("%02d-%03d-%03d-%04d", nation+10, g.between(100, 999), g.between(100, 999), g.between(1000, 9999))
+}
+
+// comment returns random text of at most n characters.
+func (g *Generator) comment(n int) string {
+	var b strings.Builder
+	for b.Len() < n/2 {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(g.pick(words))
+	}
+	return truncate(b.String(), n)
+}
+
+// money returns a random amount from minCents to maxCents, in units.
+func (g *Generator) money(minCents, maxCents int) float64 {
+	return float64(g.between(minCents, maxCents)) / 100
+}
+
+// between returns a random integer from lo to hi, inclusive.
+func (g *Generator) between(lo, hi int) int {
+	return lo + g.rng.IntN(hi-lo+1)
+}
+
+// pick returns a random element of values.
+func (g *Generator) pick(values []string) string {
+	return values[g.rng.IntN(len(values))]
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+func formatDate(t time.Time) string {
+	return t.Format(time.DateOnly)
+}
@@ -0,0 +1,186 @@
+// Package assertion provides the SLA targets of a benchmark run, evaluated
+// when the run completes, e.g. to gate a CI pipeline on its results.
+package assertion
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Kind identifies what an assertion measures.
+type Kind string
+
+const (
+	// KindMinTPS fails runs whose TPS is below the limit.
+	KindMinTPS Kind = "min_tps"
+	// KindMaxLatencyP95 fails runs whose p95 latency exceeds the limit in milliseconds.
+	KindMaxLatencyP95 Kind = "max_latency_p95"
+	// KindMaxErrorRate fails runs whose errors exceed the limit in percent of transactions.
+	KindMaxErrorRate Kind = "max_error_rate"
+)
+
+// Targets are the SLA targets a run must meet to pass. Unset targets are not
+// asserted, so a zero limit (e.g. no errors at all) can still be set.
+type Targets struct {
+	MinTPS        *float64 `json:"min_tps,omitempty"`                // TPS ≥ limit
+	MaxLatencyP95 *float64 `json:"max_latency_p95_ms,omitempty"`     // p95 latency ≤ limit (ms)
+	MaxErrorRate  *float64 `json:"max_error_rate_percent,omitempty"` // Errors ≤ limit (% of transactions)
+}
+
+// IsZero reports whether no target is set.
+func (t Targets) IsZero() bool {
+	return t.MinTPS == nil && t.MaxLatencyP95 == nil && t.MaxErrorRate == nil
+}
+
+// Validate validates the targets.
+func (t Targets) Validate() error {
+	for _, target := range t.targets() {
+		if *target.limit < 0 {
+			return fmt.Errorf("assertion %s: limit cannot be negative", target.kind)
+		}
+	}
+	return nil
+}
+
+// Override returns t with the targets set in o replacing its own, e.g. the
+// targets of a template overridden by those of a task.
+func (t Targets) Override(o Targets) Targets {
+	if o.MinTPS != nil {
+		t.MinTPS = o.MinTPS
+	}
+	if o.MaxLatencyP95 != nil {
+		t.MaxLatencyP95 = o.MaxLatencyP95
+	}
+	if o.MaxErrorRate != nil {
+		t.MaxErrorRate = o.MaxErrorRate
+	}
+	return t
+}
+
+// String describes the targets, e.g. "TPS ≥ 1000, p95 ≤ 20 ms".
+func (t Targets) String() string {
+	var parts []string
+	for _, target := range t.targets() {
+		parts = append(parts, describe(target.kind, *target.limit))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// target is one set target of Targets.
+type target struct {
+	kind  Kind
+	limit *float64
+}
+
+// targets returns the set targets in a fixed order.
+func (t Targets) targets() []target {
+	var set []target
+	for _, target := range []target{
+		{KindMinTPS, t.MinTPS},
+		{KindMaxLatencyP95, t.MaxLatencyP95},
+		{KindMaxErrorRate, t.MaxErrorRate},
+	} {
+		if target.limit != nil {
+			set = append(set, target)
+		}
+	}
+	return set
+}
+
+// Metrics are the results of a run the targets are evaluated on.
+type Metrics struct {
+	TPS        float64
+	LatencyP95 float64 // ms
+	ErrorRate  float64 // % of transactions
+}
+
+// Result is the outcome of one target on a run.
+type Result struct {
+	Kind   Kind    `json:"kind"`
+	Value  float64 `json:"value"`
+	Limit  float64 `json:"limit"`
+	Passed bool    `json:"passed"`
+}
+
+// String describes the result, e.g. "TPS 950.00 (≥ 1000): FAIL".
+func (r Result) String() string {
+	status := "PASS"
+	if !r.Passed {
+		status = "FAIL"
+	}
+	name, op, unit := r.Kind.parts()
+	return fmt.Sprintf("%s %.2f%s (%s %g%s): %s", name, r.Value, unit, op, r.Limit, unit, status)
+}
+
+// Outcome is the outcome of the targets of a run.
+type Outcome struct {
+	Passed      bool      `json:"passed"`
+	EvaluatedAt time.Time `json:"evaluated_at"`
+	Results     []Result  `json:"results"`
+}
+
+// Failed returns the kinds of the failed targets.
+func (o *Outcome) Failed() []string {
+	var kinds []string
+	for _, r := range o.Results {
+		if !r.Passed {
+			kinds = append(kinds, string(r.Kind))
+		}
+	}
+	return kinds
+}
+
+// String returns the verdict, "PASS", or "FAIL" with the failed targets.
+func (o *Outcome) String() string {
+	if o.Passed {
+		return "PASS"
+	}
+	return "FAIL (" + strings.Join(o.Failed(), ", ") + ")"
+}
+
+// Evaluate evaluates the targets on the metrics of a completed run.
+// It returns nil if no target is set.
+func Evaluate(t Targets, m Metrics) *Outcome {
+	if t.IsZero() {
+		return nil
+	}
+	o := &Outcome{Passed: true, EvaluatedAt: time.Now()}
+	for _, target := range t.targets() {
+		r := Result{Kind: target.kind, Limit: *target.limit}
+		switch target.kind {
+		case KindMinTPS:
+			r.Value = m.TPS
+			r.Passed = r.Value >= r.Limit
+		case KindMaxLatencyP95:
+			r.Value = m.LatencyP95
+			r.Passed = r.Value <= r.Limit
+		case KindMaxErrorRate:
+			r.Value = m.ErrorRate
+			r.Passed = r.Value <= r.Limit
+		}
+		if !r.Passed {
+			o.Passed = false
+		}
+		o.Results = append(o.Results, r)
+	}
+	return o
+}
+
+// parts returns the metric name, comparison and unit of the kind.
+func (k Kind) parts() (name, op, unit string) {
+	switch k {
+	case KindMinTPS:
+		return "TPS", "≥", ""
+	case KindMaxLatencyP95:
+		return "p95", "≤", " ms"
+	default:
+		return "error rate", "≤", "%"
+	}
+}
+
+// describe describes a target, e.g. "p95 ≤ 20 ms".
+func describe(k Kind, limit float64) string {
+	name, op, unit := k.parts()
+	return fmt.Sprintf("%s %s %g%s", name, op, limit, unit)
+}
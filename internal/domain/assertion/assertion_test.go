@@ -0,0 +1,53 @@
+package assertion
+
+import (
+	"slices"
+	"testing"
+)
+
+func limit(v float64) *float64 {
+	return &v
+}
+
+// TestEvaluate tests passing and failing targets, including a zero limit.
+func TestEvaluate(t *testing.T) {
+	targets := Targets{MinTPS: limit(1000), MaxLatencyP95: limit(20), MaxErrorRate: limit(0)}
+
+	pass := Evaluate(targets, Metrics{TPS: 1000, LatencyP95: 20})
+	if !pass.Passed || len(pass.Results) != 3 || len(pass.Failed()) != 0 {
+		t.Errorf("Evaluate(at the limits) = %+v, want all 3 targets passed", pass)
+	}
+
+	fail := Evaluate(targets, Metrics{TPS: 950, LatencyP95: 18, ErrorRate: 0.1})
+	if fail.Passed || !slices.Equal(fail.Failed(), []string{"min_tps", "max_error_rate"}) {
+		t.Errorf("Evaluate() failed = %v, want min_tps and max_error_rate", fail.Failed())
+	}
+	if got, want := fail.Results[0].String(), "TPS 950.00 (≥ 1000): FAIL"; got != want {
+		t.Errorf("Result.String() = %q, want %q", got, want)
+	}
+	if got, want := fail.String(), "FAIL (min_tps, max_error_rate)"; got != want {
+		t.Errorf("Outcome.String() = %q, want %q", got, want)
+	}
+
+	if o := Evaluate(Targets{}, Metrics{TPS: 1}); o != nil {
+		t.Errorf("Evaluate(no targets) = %+v, want nil", o)
+	}
+}
+
+// TestTargets_Override tests that task targets replace template targets one by one.
+func TestTargets_Override(t *testing.T) {
+	template := Targets{MinTPS: limit(1000), MaxLatencyP95: limit(20)}
+	task := Targets{MinTPS: limit(1500), MaxErrorRate: limit(1)}
+
+	got := template.Override(task)
+	if got.String() != "TPS ≥ 1500, p95 ≤ 20 ms, error rate ≤ 1%" {
+		t.Errorf("Override() = %s", got)
+	}
+	if *template.MinTPS != 1000 {
+		t.Error("Override() changed the overridden targets")
+	}
+
+	if err := (Targets{MaxLatencyP95: limit(-1)}).Validate(); err == nil {
+		t.Error("Validate() accepted a negative limit")
+	}
+}
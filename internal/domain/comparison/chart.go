@@ -0,0 +1,200 @@
+// Package comparison provides the charts of the simplified report.
+// This file computes the TPS and p95 latency vs thread count series and
+// renders them as SVG for exported reports.
+package comparison
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"slices"
+	"strings"
+)
+
+// ChartMetric selects the metric plotted by a chart.
+type ChartMetric string
+
+const (
+	ChartTPS        ChartMetric = "tps"         // Mean TPS
+	ChartLatencyP95 ChartMetric = "latency_p95" // Mean p95 latency (ms)
+)
+
+// Title returns the chart title of the metric.
+func (m ChartMetric) Title() string {
+	if m == ChartLatencyP95 {
+		return "P95 latency (ms) vs threads"
+	}
+	return "TPS vs threads"
+}
+
+// ChartPoint is the statistics of the runs of a series at one thread count.
+type ChartPoint struct {
+	Threads    int
+	N          int
+	TPS        GroupMetricStats
+	LatencyP95 GroupMetricStats
+}
+
+// Stats returns the statistics of the given metric.
+func (p ChartPoint) Stats(metric ChartMetric) GroupMetricStats {
+	if metric == ChartLatencyP95 {
+		return p.LatencyP95
+	}
+	return p.TPS
+}
+
+// ChartSeries is one line of a chart, sorted by thread count.
+type ChartSeries struct {
+	Label  string
+	Points []ChartPoint
+}
+
+// ChartSeries returns the series of the report's charts. Grouped by threads,
+// all runs form one series; otherwise each group (e.g. tag=before) is a
+// series of its runs by thread count, so groups can be compared as curves.
+// Grouped by version, each version is a series over its thread groups.
+func (r *SimplifiedReport) ChartSeries() []ChartSeries {
+	if r.GroupBy == GroupByThreads || r.GroupBy == "" {
+		return []ChartSeries{chartSeries("runs", r.ConfigGroups)}
+	}
+	if r.GroupBy == GroupByVersion {
+		versions, records := groupVersions(r.ConfigGroups)
+		series := make([]ChartSeries, 0, len(versions))
+		for _, version := range versions {
+			series = append(series, chartSeries(fmt.Sprintf("%s=%s", groupFieldName(GroupByVersion), version), groupByThreads(records[version])))
+		}
+		return series
+	}
+	series := make([]ChartSeries, 0, len(r.ConfigGroups))
+	for _, group := range r.ConfigGroups {
+		series = append(series, chartSeries(group.Label, groupByThreads(group.Records)))
+	}
+	return series
+}
+
+// chartSeries converts thread groups into a series.
+func chartSeries(label string, groups []*ThreadGroup) ChartSeries {
+	s := ChartSeries{Label: label}
+	for _, group := range groups {
+		s.Points = append(s.Points, ChartPoint{
+			Threads:    group.Threads,
+			N:          group.Statistics.N,
+			TPS:        group.Statistics.TPS,
+			LatencyP95: group.Statistics.LatencyP95,
+		})
+	}
+	slices.SortFunc(s.Points, func(a, b ChartPoint) int { return a.Threads - b.Threads })
+	return s
+}
+
+// ChartScale returns the thread counts on the x axis, in order, and the top
+// of the y axis: the largest mean + stddev with 10% headroom (at least 1).
+// Thread counts are placed at equal distances, since they usually double.
+func ChartScale(series []ChartSeries, metric ChartMetric) ([]int, float64) {
+	var threads []int
+	top := 0.0
+	for _, s := range series {
+		for _, p := range s.Points {
+			if !slices.Contains(threads, p.Threads) {
+				threads = append(threads, p.Threads)
+			}
+			stats := p.Stats(metric)
+			top = math.Max(top, stats.Mean+stats.StdDev)
+		}
+	}
+	slices.Sort(threads)
+	if top <= 0 {
+		return threads, 1
+	}
+	return threads, top * 1.1
+}
+
+// ChartColors are the colors of the series, reused when there are more series.
+var ChartColors = []string{"#2196f3", "#ff9800", "#4caf50", "#e91e63", "#9c27b0", "#00bcd4", "#795548", "#607d8b"}
+
+// RenderChartSVG renders a line chart of metric vs thread count with error
+// bars of ± one standard deviation.
+func RenderChartSVG(series []ChartSeries, metric ChartMetric, width, height int) string {
+	const left, right, top, bottom = 70, 20, 40, 50
+	plotW, plotH := float64(width-left-right), float64(height-top-bottom)
+	threads, maxValue := ChartScale(series, metric)
+
+	x := func(t int) float64 {
+		i := slices.Index(threads, t)
+		if len(threads) == 1 {
+			return left + plotW/2
+		}
+		return left + plotW*float64(i)/float64(len(threads)-1)
+	}
+	y := func(v float64) float64 {
+		return top + plotH*(1-math.Max(v, 0)/maxValue)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n", width, height, width, height)
+	fmt.Fprintf(&sb, `<text x="%d" y="20" font-size="14" font-weight="bold">%s</text>`+"\n", left, html.EscapeString(metric.Title()))
+
+	// Axes with five value ticks and one tick per thread count
+	fmt.Fprintf(&sb, `<line x1="%d" y1="%d" x2="%d" y2="%.1f" stroke="#888"/>`+"\n", left, top, left, top+plotH)
+	fmt.Fprintf(&sb, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#888"/>`+"\n", left, top+plotH, left+plotW, top+plotH)
+	for i := 0; i <= 4; i++ {
+		v := maxValue * float64(i) / 4
+		fmt.Fprintf(&sb, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#eee"/>`+"\n", left, y(v), left+plotW, y(v))
+		fmt.Fprintf(&sb, `<text x="%d" y="%.1f" text-anchor="end">%.0f</text>`+"\n", left-6, y(v)+4, v)
+	}
+	for _, t := range threads {
+		fmt.Fprintf(&sb, `<text x="%.1f" y="%.1f" text-anchor="middle">%d</text>`+"\n", x(t), top+plotH+18, t)
+	}
+	fmt.Fprintf(&sb, `<text x="%.1f" y="%d" text-anchor="middle">threads</text>`+"\n", left+plotW/2, height-8)
+
+	for i, s := range series {
+		color := ChartColors[i%len(ChartColors)]
+		var points []string
+		for _, p := range s.Points {
+			stats := p.Stats(metric)
+			px, py := x(p.Threads), y(stats.Mean)
+			points = append(points, fmt.Sprintf("%.1f,%.1f", px, py))
+			if stats.StdDev > 0 {
+				lo, hi := y(stats.Mean-stats.StdDev), y(stats.Mean+stats.StdDev)
+				fmt.Fprintf(&sb, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"/>`+"\n", px, lo, px, hi, color)
+				fmt.Fprintf(&sb, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"/>`+"\n", px-4, lo, px+4, lo, color)
+				fmt.Fprintf(&sb, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s"/>`+"\n", px-4, hi, px+4, hi, color)
+			}
+			fmt.Fprintf(&sb, `<circle cx="%.1f" cy="%.1f" r="3" fill="%s"><title>%d threads: %.2f ± %.2f (n=%d)</title></circle>`+"\n",
+				px, py, color, p.Threads, stats.Mean, stats.StdDev, p.N)
+		}
+		fmt.Fprintf(&sb, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n", strings.Join(points, " "), color)
+
+		// Legend in the top right corner
+		ly := top + 16*i
+		fmt.Fprintf(&sb, `<rect x="%.1f" y="%d" width="10" height="10" fill="%s"/>`+"\n", left+plotW-150, ly, color)
+		fmt.Fprintf(&sb, `<text x="%.1f" y="%d">%s</text>`+"\n", left+plotW-135, ly+9, html.EscapeString(s.Label))
+	}
+
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// FormatHTML formats the report as an HTML page: the TPS and p95 latency
+// charts followed by the Markdown report. The charts are inline SVG, so the
+// file has no external dependencies and can be printed to PDF by a browser.
+func (r *SimplifiedReport) FormatHTML() string {
+	series := r.ChartSeries()
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&sb, "<title>Performance Comparison Report %s</title>\n", html.EscapeString(r.ReportID))
+	sb.WriteString("<style>body{font-family:sans-serif;margin:24px}pre{background:#f7f7f7;padding:12px;overflow-x:auto}.chart{display:inline-block;margin:0 16px 16px 0}</style>\n")
+	sb.WriteString("</head>\n<body>\n")
+	fmt.Fprintf(&sb, "<h1>Performance Comparison Report</h1>\n<p>Generated %s, grouped by %s, %d record(s). Error bars show ± one standard deviation.</p>\n",
+		r.GeneratedAt.Format("2006-01-02 15:04:05"), html.EscapeString(string(r.GroupBy)), r.SelectedRecords)
+	if versions := r.VersionsTitle(); versions != "" {
+		fmt.Fprintf(&sb, "<h2>%s</h2>\n", html.EscapeString(versions))
+	}
+	for _, metric := range []ChartMetric{ChartTPS, ChartLatencyP95} {
+		fmt.Fprintf(&sb, "<div class=\"chart\">\n%s</div>\n", RenderChartSVG(series, metric, 560, 340))
+	}
+	fmt.Fprintf(&sb, "<pre>%s</pre>\n", html.EscapeString(r.FormatMarkdown()))
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
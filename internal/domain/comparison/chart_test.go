@@ -0,0 +1,58 @@
+// Package comparison provides unit tests for the report charts.
+package comparison
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSimplifiedReport_ChartSeries(t *testing.T) {
+	records := []*RecordRef{
+		{ID: "1", Threads: 16, TPS: 180, LatencyP95: 12, Tags: []string{"before"}},
+		{ID: "2", Threads: 8, TPS: 100, LatencyP95: 10, Tags: []string{"before"}},
+		{ID: "3", Threads: 8, TPS: 110, LatencyP95: 9, Tags: []string{"after"}},
+		{ID: "4", Threads: 8, TPS: 120, LatencyP95: 11, Tags: []string{"after"}},
+	}
+
+	series := GenerateSimplifiedReport(records, GroupByThreads).ChartSeries()
+	if len(series) != 1 || len(series[0].Points) != 2 {
+		t.Fatalf("grouped by threads: got %+v, want one series with 2 points", series)
+	}
+	if p := series[0].Points[0]; p.Threads != 8 || p.N != 3 || p.TPS.Mean != 110 || p.TPS.StdDev != 10 {
+		t.Errorf("8 threads point = %+v", p)
+	}
+
+	series = GenerateSimplifiedReport(records, GroupByTag).ChartSeries()
+	if len(series) != 2 || series[0].Label != "tag=after" || series[1].Label != "tag=before" {
+		t.Fatalf("grouped by tag: got %+v", series)
+	}
+	if got := series[1].Points; len(got) != 2 || got[0].Threads != 8 || got[1].Threads != 16 {
+		t.Errorf("tag=before points = %+v, want 8 and 16 threads", got)
+	}
+
+	threads, top := ChartScale(series, ChartTPS)
+	if len(threads) != 2 || threads[0] != 8 || threads[1] != 16 {
+		t.Errorf("ChartScale() threads = %v", threads)
+	}
+	if top < 180 {
+		t.Errorf("ChartScale() top = %v, want at least the largest TPS", top)
+	}
+}
+
+func TestSimplifiedReport_FormatHTML(t *testing.T) {
+	records := []*RecordRef{
+		{ID: "1", Threads: 8, TPS: 100, LatencyP95: 10},
+		{ID: "2", Threads: 8, TPS: 120, LatencyP95: 12},
+		{ID: "3", Threads: 16, TPS: 190, LatencyP95: 14},
+	}
+	page := GenerateSimplifiedReport(records, GroupByThreads).FormatHTML()
+
+	if strings.Count(page, "<svg") != 2 {
+		t.Errorf("FormatHTML() has %d charts, want 2", strings.Count(page, "<svg"))
+	}
+	for _, want := range []string{"TPS vs threads", "P95 latency (ms) vs threads", "8 threads: 110.00 ± 14.14 (n=2)", "threads=16"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("FormatHTML() does not contain %q", want)
+		}
+	}
+}
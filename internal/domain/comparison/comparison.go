@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
 )
 
@@ -24,6 +25,13 @@ const (
 	GroupByTemplate GroupByField = "template"
 	// GroupByDate groups results by date.
 	GroupByDate GroupByField = "date"
+	// GroupByTag groups results by history record tag.
+	// A record with several tags appears in each of its tag groups.
+	GroupByTag GroupByField = "tag"
+	// GroupByVersion groups results by the database version captured at run
+	// time and the thread count, e.g. "MySQL 8.0.36 threads=8" vs
+	// "MySQL 8.4.2 threads=8" for upgrade validation.
+	GroupByVersion GroupByField = "version"
 )
 
 // RecordRef is a reference to a history record with summary info.
@@ -48,6 +56,34 @@ type RecordRef struct {
 	TotalQueries   int64         `json:"total_queries,omitempty"`
 	Reconnects     int64         `json:"reconnects,omitempty"`
 	IgnoredErrors  int64         `json:"ignored_errors,omitempty"`
+	Tags           []string      `json:"tags,omitempty"`
+	FailedChecks   []string      `json:"failed_checks,omitempty"` // Sanity checks the run failed
+
+	Parameters     map[string]string  `json:"parameters,omitempty"` // Template parameters the run used
+	ConfigSnapshot *dbconfig.Snapshot `json:"-"`                    // Database configuration the run was measured against
+}
+
+// IsValid reports whether the run passed its sanity checks. Invalid runs are
+// left out of comparisons.
+func (r *RecordRef) IsValid() bool {
+	return len(r.FailedChecks) == 0
+}
+
+// HasAllTags reports whether the record carries every one of the given tags.
+func (r *RecordRef) HasAllTags(tags []string) bool {
+	for _, tag := range tags {
+		found := false
+		for _, t := range r.Tags {
+			if t == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
 
 // MetricStats contains statistical information about metrics.
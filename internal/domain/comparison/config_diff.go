@@ -0,0 +1,141 @@
+// Package comparison provides database configuration diffs between groups.
+// This file explains performance deltas by the configuration the runs were measured against.
+package comparison
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
+)
+
+// maxConfigChanges limits the changes listed per group in formatted reports.
+const maxConfigChanges = 30
+
+// ConfigDiff holds the database configuration differences of a group from the baseline group.
+type ConfigDiff struct {
+	BaseLabel   string             // Label of the baseline group
+	TargetLabel string             // Label of the compared group
+	Base        *dbconfig.Snapshot // Snapshot of the baseline group
+	Target      *dbconfig.Snapshot // Snapshot of the compared group
+	Changes     []dbconfig.Change
+}
+
+// groupSnapshot returns the configuration snapshot of the first run of the
+// group that has one.
+func groupSnapshot(group *ThreadGroup) *dbconfig.Snapshot {
+	for _, record := range group.Records {
+		if record.ConfigSnapshot != nil {
+			return record.ConfigSnapshot
+		}
+	}
+	return nil
+}
+
+// CompareConfigs diffs the configuration snapshot of every group against the
+// first group. Groups without a snapshot are skipped.
+func CompareConfigs(groups []*ThreadGroup) []ConfigDiff {
+	if len(groups) < 2 {
+		return nil
+	}
+	base := groupSnapshot(groups[0])
+	if base == nil {
+		return nil
+	}
+
+	var diffs []ConfigDiff
+	for _, group := range groups[1:] {
+		target := groupSnapshot(group)
+		if target == nil {
+			continue
+		}
+		diffs = append(diffs, ConfigDiff{
+			BaseLabel:   groups[0].Label,
+			TargetLabel: group.Label,
+			Base:        base,
+			Target:      target,
+			Changes:     dbconfig.Diff(base, target),
+		})
+	}
+	return diffs
+}
+
+// formatConfigMarkdown writes the configuration section of the markdown report.
+func (r *SimplifiedReport) formatConfigMarkdown(builder *strings.Builder) {
+	builder.WriteString("### 1.2 Database Configuration\n\n")
+
+	captured := 0
+	for _, group := range r.ConfigGroups {
+		if groupSnapshot(group) != nil {
+			captured++
+		}
+	}
+	if captured == 0 {
+		builder.WriteString("No configuration snapshots were captured for the compared runs.\n\n")
+		return
+	}
+
+	builder.WriteString("| Group | Server |\n")
+	builder.WriteString("|-------|--------|\n")
+	for _, group := range r.ConfigGroups {
+		summary := "not captured"
+		if snapshot := groupSnapshot(group); snapshot != nil {
+			summary = snapshot.Summary()
+		}
+		builder.WriteString(fmt.Sprintf("| %s | %s |\n", group.Label, summary))
+	}
+	builder.WriteString("\n")
+
+	for _, diff := range r.ConfigDiffs {
+		if len(diff.Changes) == 0 {
+			builder.WriteString(fmt.Sprintf("%s has the same configuration as %s.\n\n", diff.TargetLabel, diff.BaseLabel))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("**%s vs %s:** %d setting(s) differ\n\n", diff.TargetLabel, diff.BaseLabel, len(diff.Changes)))
+		builder.WriteString(fmt.Sprintf("| Setting | %s | %s |\n", diff.BaseLabel, diff.TargetLabel))
+		builder.WriteString("|---------|------|------|\n")
+		for i, change := range diff.Changes {
+			if i == maxConfigChanges {
+				builder.WriteString(fmt.Sprintf("| ... %d more | | |\n", len(diff.Changes)-maxConfigChanges))
+				break
+			}
+			builder.WriteString(fmt.Sprintf("| %s | %s | %s |\n", change.Name, formatSetting(change.Before), formatSetting(change.After)))
+		}
+		builder.WriteString("\n")
+	}
+}
+
+// formatConfigTXT writes the configuration differences of the text report.
+func (r *SimplifiedReport) formatConfigTXT(builder *strings.Builder) {
+	if len(r.ConfigDiffs) == 0 {
+		return
+	}
+
+	builder.WriteString("Configuration Differences:\n")
+	for _, diff := range r.ConfigDiffs {
+		if len(diff.Changes) == 0 {
+			builder.WriteString(fmt.Sprintf("  %s vs %s: none\n", diff.TargetLabel, diff.BaseLabel))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("  %s vs %s:\n", diff.TargetLabel, diff.BaseLabel))
+		for i, change := range diff.Changes {
+			if i == maxConfigChanges {
+				builder.WriteString(fmt.Sprintf("    ... %d more\n", len(diff.Changes)-maxConfigChanges))
+				break
+			}
+			builder.WriteString(fmt.Sprintf("    %s: %s -> %s\n", change.Name, formatSetting(change.Before), formatSetting(change.After)))
+		}
+	}
+	builder.WriteString("\n")
+}
+
+// formatSetting formats a setting value for display, marking absent ones.
+func formatSetting(value string) string {
+	if value == "" {
+		return "-"
+	}
+	if len(value) > 60 {
+		return value[:57] + "..."
+	}
+	return value
+}
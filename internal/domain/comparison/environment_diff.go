@@ -0,0 +1,147 @@
+// Package comparison provides the side-by-side environment diff of two runs.
+// This file lists what differed between two runs besides their results.
+package comparison
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Environment diff sections.
+const (
+	SectionRun       = "Run"       // Connection, template, database type and threads
+	SectionParameter = "Parameter" // Template parameters
+	SectionDatabase  = "Database"  // Database settings and server properties
+)
+
+// EnvironmentRow is one key of the environment diff with the values of both runs.
+// An empty value means the key is not set for that run.
+type EnvironmentRow struct {
+	Section string
+	Name    string
+	Before  string
+	After   string
+	Changed bool
+}
+
+// EnvironmentDiff compares the environment of two runs side by side: how they
+// were run, the template parameters and the database configuration snapshots.
+type EnvironmentDiff struct {
+	Before  *RecordRef
+	After   *RecordRef
+	Rows    []EnvironmentRow // Sorted by section, then name
+	Changed int              // Number of changed rows
+	Notes   []string         // Parts that could not be compared
+}
+
+// DiffEnvironment compares the environment of two runs. The database section
+// is only compared when both runs have a configuration snapshot.
+func DiffEnvironment(before, after *RecordRef) *EnvironmentDiff {
+	d := &EnvironmentDiff{Before: before, After: after}
+
+	d.addSection(SectionRun, runValues(before), runValues(after))
+
+	if before.Parameters == nil || after.Parameters == nil {
+		d.Notes = append(d.Notes, missingNote("template parameters", before, after, func(r *RecordRef) bool { return r.Parameters != nil }))
+	} else {
+		d.addSection(SectionParameter, before.Parameters, after.Parameters)
+	}
+
+	if before.ConfigSnapshot == nil || after.ConfigSnapshot == nil {
+		d.Notes = append(d.Notes, missingNote("database configuration", before, after, func(r *RecordRef) bool { return r.ConfigSnapshot != nil }))
+	} else {
+		d.addSection(SectionDatabase, before.ConfigSnapshot.Values(), after.ConfigSnapshot.Values())
+	}
+
+	return d
+}
+
+// ChangedRows returns the rows whose values differ.
+func (d *EnvironmentDiff) ChangedRows() []EnvironmentRow {
+	var rows []EnvironmentRow
+	for _, row := range d.Rows {
+		if row.Changed {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// addSection adds one row per key of either map.
+func (d *EnvironmentDiff) addSection(section string, before, after map[string]string) {
+	names := make([]string, 0, len(before)+len(after))
+	for name := range before {
+		names = append(names, name)
+	}
+	for name := range after {
+		if _, ok := before[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		row := EnvironmentRow{
+			Section: section,
+			Name:    name,
+			Before:  before[name],
+			After:   after[name],
+		}
+		row.Changed = row.Before != row.After
+		if row.Changed {
+			d.Changed++
+		}
+		d.Rows = append(d.Rows, row)
+	}
+}
+
+// runValues returns how a run was run, by name.
+func runValues(r *RecordRef) map[string]string {
+	return map[string]string{
+		"connection":    r.ConnectionName,
+		"database_type": r.DatabaseType,
+		"template":      r.TemplateName,
+		"threads":       strconv.Itoa(r.Threads),
+		"duration":      r.Duration.String(),
+	}
+}
+
+// missingNote describes which run lacks the compared part.
+func missingNote(what string, before, after *RecordRef, has func(*RecordRef) bool) string {
+	var missing []string
+	for _, r := range []*RecordRef{before, after} {
+		if !has(r) {
+			missing = append(missing, r.ID)
+		}
+	}
+	return fmt.Sprintf("No %s recorded for %s; not compared", what, strings.Join(missing, ", "))
+}
+
+// FormatTXT formats the changed rows of the diff as text.
+func (d *EnvironmentDiff) FormatTXT() string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("Before: %s (%s)\n", d.Before.ID, d.Before.StartTime.Format("2006-01-02 15:04:05")))
+	builder.WriteString(fmt.Sprintf("After:  %s (%s)\n\n", d.After.ID, d.After.StartTime.Format("2006-01-02 15:04:05")))
+
+	if d.Changed == 0 {
+		builder.WriteString("No differences.\n")
+	} else {
+		builder.WriteString(fmt.Sprintf("%d difference(s):\n", d.Changed))
+		section := ""
+		for _, row := range d.ChangedRows() {
+			if row.Section != section {
+				section = row.Section
+				builder.WriteString(fmt.Sprintf("\n[%s]\n", section))
+			}
+			builder.WriteString(fmt.Sprintf("  %s: %s -> %s\n", row.Name, formatSetting(row.Before), formatSetting(row.After)))
+		}
+	}
+
+	for _, note := range d.Notes {
+		builder.WriteString(fmt.Sprintf("\nNote: %s\n", note))
+	}
+	return builder.String()
+}
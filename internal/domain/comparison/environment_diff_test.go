@@ -0,0 +1,82 @@
+// Package comparison provides unit tests for the environment diff.
+package comparison
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
+)
+
+func TestDiffEnvironment(t *testing.T) {
+	before := &RecordRef{
+		ID:             "before",
+		ConnectionName: "prod",
+		DatabaseType:   "MySQL",
+		TemplateName:   "oltp-rw",
+		Threads:        8,
+		Parameters:     map[string]string{"tables": "10", "table_size": "100000"},
+		ConfigSnapshot: &dbconfig.Snapshot{
+			Settings: map[string]string{"innodb_buffer_pool_size": "134217728", "max_connections": "151", "gtid_executed": "a:1"},
+		},
+	}
+	after := &RecordRef{
+		ID:             "after",
+		ConnectionName: "prod",
+		DatabaseType:   "MySQL",
+		TemplateName:   "oltp-rw",
+		Threads:        16,
+		Parameters:     map[string]string{"tables": "10", "table_size": "100000", "rand-type": "uniform"},
+		ConfigSnapshot: &dbconfig.Snapshot{
+			Settings: map[string]string{"innodb_buffer_pool_size": "8589934592", "max_connections": "151", "gtid_executed": "a:2"},
+		},
+	}
+
+	d := DiffEnvironment(before, after)
+
+	want := []EnvironmentRow{
+		{Section: SectionRun, Name: "threads", Before: "8", After: "16", Changed: true},
+		{Section: SectionParameter, Name: "rand-type", After: "uniform", Changed: true},
+		{Section: SectionDatabase, Name: "innodb_buffer_pool_size", Before: "134217728", After: "8589934592", Changed: true},
+	}
+	changed := d.ChangedRows()
+	if d.Changed != len(want) || len(changed) != len(want) {
+		t.Fatalf("changed rows = %+v, want %+v", changed, want)
+	}
+	for i := range want {
+		if changed[i] != want[i] {
+			t.Errorf("changed row %d = %+v, want %+v", i, changed[i], want[i])
+		}
+	}
+
+	// Unchanged keys are listed side by side; volatile settings are not
+	var names []string
+	for _, row := range d.Rows {
+		if row.Section == SectionDatabase {
+			names = append(names, row.Name)
+		}
+	}
+	if strings.Join(names, ",") != "innodb_buffer_pool_size,max_connections" {
+		t.Errorf("database rows = %v", names)
+	}
+
+	txt := d.FormatTXT()
+	for _, want := range []string{"3 difference(s)", "[Parameter]\n  rand-type: - -> uniform", "threads: 8 -> 16"} {
+		if !strings.Contains(txt, want) {
+			t.Errorf("FormatTXT() does not contain %q:\n%s", want, txt)
+		}
+	}
+}
+
+func TestDiffEnvironment_MissingSnapshot(t *testing.T) {
+	before := &RecordRef{ID: "old", Threads: 8}
+	after := &RecordRef{ID: "new", Threads: 8, Parameters: map[string]string{"tables": "10"}, ConfigSnapshot: &dbconfig.Snapshot{}}
+
+	d := DiffEnvironment(before, after)
+	if d.Changed != 0 {
+		t.Errorf("Changed = %d, want 0", d.Changed)
+	}
+	if len(d.Notes) != 2 || !strings.Contains(d.Notes[1], "No database configuration recorded for old") {
+		t.Errorf("Notes = %v", d.Notes)
+	}
+}
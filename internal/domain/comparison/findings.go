@@ -0,0 +1,251 @@
+// Package comparison provides the findings engine of the simplified report.
+// It walks thread groups in ascending thread order and detects where scaling
+// stops paying off, each finding carrying the numbers that support it.
+package comparison
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Thresholds of the findings engine.
+const (
+	// KneeEfficiency is the cumulative scaling efficiency below which the scaling knee is reported.
+	KneeEfficiency = 0.70
+	// SaturationGain is the minimum TPS gain of a step; below it throughput has saturated.
+	SaturationGain = 0.05
+	// LatencyCliffRatio is the p95 growth of one step that counts as a latency cliff,
+	// provided latency also grows faster than the thread count.
+	LatencyCliffRatio = 2.0
+	// UnstableCV is the coefficient of variation of TPS above which a group is unstable.
+	UnstableCV = 0.10
+)
+
+// FindingKind identifies what a finding is about.
+type FindingKind string
+
+const (
+	FindingScalingKnee    FindingKind = "scaling_knee"
+	FindingSaturation     FindingKind = "saturation"
+	FindingLatencyCliff   FindingKind = "latency_cliff"
+	FindingErrorOnset     FindingKind = "error_onset"
+	FindingUnstable       FindingKind = "unstable"
+	FindingRecommendation FindingKind = "recommendation"
+)
+
+// Finding is a claim about the results and the evidence behind it.
+type Finding struct {
+	Kind     FindingKind
+	Group    string // Label of the group the claim refers to
+	Threads  int    // Thread count the claim refers to (0 if none)
+	Claim    string // e.g. "Throughput stops growing at 64 threads"
+	Evidence string // e.g. "TPS 32→64 threads: 5120.00 → 5180.00 (+1.2%)"
+}
+
+// ScalingStep describes scaling between two adjacent thread counts.
+type ScalingStep struct {
+	FromThreads int
+	ToThreads   int
+	TPSGain     float64 // Relative TPS change, e.g. 0.85 = +85%
+	Efficiency  float64 // TPS ratio / thread ratio; 1 = linear scaling
+	P95Ratio    float64 // p95 latency ratio, 0 if unknown
+}
+
+// analyzeScaling fills the threads-based findings: per-step efficiency,
+// scaling knee, saturation, latency cliff and error onset. groups must be
+// sorted by ascending thread count.
+func analyzeScaling(findings *SimplifiedReportFindings, groups []*ThreadGroup) {
+	scaled := make([]*ThreadGroup, 0, len(groups))
+	for _, g := range groups {
+		if g.Threads > 0 && g.Statistics.TPS.Mean > 0 {
+			scaled = append(scaled, g)
+		}
+	}
+
+	for i := 1; i < len(scaled); i++ {
+		prev, cur := scaled[i-1], scaled[i]
+		threadRatio := float64(cur.Threads) / float64(prev.Threads)
+		tpsRatio := cur.Statistics.TPS.Mean / prev.Statistics.TPS.Mean
+		step := ScalingStep{
+			FromThreads: prev.Threads,
+			ToThreads:   cur.Threads,
+			TPSGain:     tpsRatio - 1,
+			Efficiency:  tpsRatio / threadRatio,
+		}
+		if prev.Statistics.LatencyP95.Mean > 0 {
+			step.P95Ratio = cur.Statistics.LatencyP95.Mean / prev.Statistics.LatencyP95.Mean
+		}
+		findings.ScalingSteps = append(findings.ScalingSteps, step)
+
+		if findings.Saturation == 0 && step.TPSGain < SaturationGain {
+			findings.Saturation = cur.Threads
+			claim := fmt.Sprintf("Throughput stops growing at %d threads", cur.Threads)
+			if step.TPSGain < -SaturationGain {
+				claim = fmt.Sprintf("Throughput drops at %d threads", cur.Threads)
+			}
+			findings.Insights = append(findings.Insights, Finding{
+				Kind:    FindingSaturation,
+				Group:   cur.Label,
+				Threads: cur.Threads,
+				Claim:   claim,
+				Evidence: fmt.Sprintf("TPS %d→%d threads: %.2f → %.2f (%+.1f%%) for %.1fx the threads",
+					prev.Threads, cur.Threads, prev.Statistics.TPS.Mean, cur.Statistics.TPS.Mean, step.TPSGain*100, threadRatio),
+			})
+		}
+
+		if findings.LatencyCliff == 0 && step.P95Ratio >= LatencyCliffRatio && step.P95Ratio > threadRatio {
+			findings.LatencyCliff = cur.Threads
+			findings.Insights = append(findings.Insights, Finding{
+				Kind:    FindingLatencyCliff,
+				Group:   cur.Label,
+				Threads: cur.Threads,
+				Claim:   fmt.Sprintf("Latency cliff at %d threads", cur.Threads),
+				Evidence: fmt.Sprintf("p95 %d→%d threads: %.2fms → %.2fms (%.1fx) while threads grew %.1fx and TPS %+.1f%%",
+					prev.Threads, cur.Threads, prev.Statistics.LatencyP95.Mean, cur.Statistics.LatencyP95.Mean,
+					step.P95Ratio, threadRatio, step.TPSGain*100),
+			})
+		}
+	}
+
+	// The knee is where cumulative efficiency against the smallest thread count drops
+	if len(scaled) > 1 {
+		base := scaled[0]
+		for _, g := range scaled[1:] {
+			speedup := g.Statistics.TPS.Mean / base.Statistics.TPS.Mean
+			efficiency := speedup / (float64(g.Threads) / float64(base.Threads))
+			if efficiency < KneeEfficiency {
+				findings.ScalingKnee = g.Threads
+				findings.Insights = append(findings.Insights, Finding{
+					Kind:    FindingScalingKnee,
+					Group:   g.Label,
+					Threads: g.Threads,
+					Claim:   fmt.Sprintf("Scaling knee at %d threads", g.Threads),
+					Evidence: fmt.Sprintf("%.2fx speedup over %d threads for %.1fx the threads = %.0f%% efficiency (< %.0f%%)",
+						speedup, base.Threads, float64(g.Threads)/float64(base.Threads), efficiency*100, KneeEfficiency*100),
+				})
+				break
+			}
+		}
+	}
+
+	for i, g := range groups {
+		if g.Threads == 0 || (g.Statistics.Errors == 0 && g.Statistics.Reconnects == 0) {
+			continue
+		}
+		findings.ErrorOnset = g.Threads
+		evidence := fmt.Sprintf("%d errors and %d reconnects over %d run(s) at %d threads",
+			g.Statistics.Errors, g.Statistics.Reconnects, g.Statistics.N, g.Threads)
+		if i > 0 {
+			evidence += fmt.Sprintf("; none at %d threads", groups[i-1].Threads)
+		} else {
+			evidence += "; already at the smallest tested thread count"
+		}
+		findings.Insights = append(findings.Insights, Finding{
+			Kind:     FindingErrorOnset,
+			Group:    g.Label,
+			Threads:  g.Threads,
+			Claim:    fmt.Sprintf("Errors start at %d threads", g.Threads),
+			Evidence: evidence,
+		})
+		break
+	}
+
+	sort.SliceStable(findings.Insights, func(i, j int) bool {
+		return findings.Insights[i].Threads < findings.Insights[j].Threads
+	})
+}
+
+// analyzeStability reports groups whose TPS varies more than UnstableCV across runs.
+func analyzeStability(findings *SimplifiedReportFindings, groups []*ThreadGroup) {
+	for _, g := range groups {
+		tps := g.Statistics.TPS
+		if g.Statistics.N < 2 || tps.Mean == 0 {
+			continue
+		}
+		if cv := tps.StdDev / tps.Mean; cv > UnstableCV {
+			findings.Insights = append(findings.Insights, Finding{
+				Kind:    FindingUnstable,
+				Group:   g.Label,
+				Threads: g.Threads,
+				Claim:   fmt.Sprintf("%s is unstable", g.Label),
+				Evidence: fmt.Sprintf("TPS CV %.1f%% over %d runs (%.2f ± %.2f, range %.2f .. %.2f)",
+					cv*100, g.Statistics.N, tps.Mean, tps.StdDev, tps.Min, tps.Max),
+			})
+		}
+	}
+}
+
+// recommend turns the findings into recommendations. When grouping by
+// threads it suggests the largest thread count below every detected limit:
+// each threads-based finding names the first thread count that no longer pays off.
+func recommend(findings *SimplifiedReportFindings, groups []*ThreadGroup, groupBy GroupByField) {
+	add := func(group string, threads int, claim, evidence string) {
+		findings.Recommendations = append(findings.Recommendations, Finding{
+			Kind: FindingRecommendation, Group: group, Threads: threads, Claim: claim, Evidence: evidence,
+		})
+	}
+
+	if groupBy == GroupByThreads && len(findings.ScalingSteps) > 0 {
+		limit := 0
+		var reasons []string
+		for _, f := range findings.Insights {
+			if f.Kind == FindingUnstable || f.Threads == 0 {
+				continue
+			}
+			if limit == 0 || f.Threads < limit {
+				limit = f.Threads
+			}
+			reasons = append(reasons, f.Claim)
+		}
+
+		g := largestGroupBelow(groups, limit)
+		switch {
+		case limit == 0:
+			last := findings.ScalingSteps[len(findings.ScalingSteps)-1]
+			add(fmt.Sprintf("threads=%d", last.ToThreads), last.ToThreads,
+				fmt.Sprintf("Throughput still scales at %d threads; test higher thread counts", last.ToThreads),
+				fmt.Sprintf("last step %d→%d threads: TPS %+.1f%%, %.0f%% efficiency", last.FromThreads, last.ToThreads, last.TPSGain*100, last.Efficiency*100))
+		case g == nil:
+			add("", limit,
+				fmt.Sprintf("Test below %d threads", limit),
+				fmt.Sprintf("no smaller thread count was tested; %s", strings.Join(reasons, "; ")))
+		default:
+			add(g.Label, g.Threads,
+				fmt.Sprintf("Run at about %d threads", g.Threads),
+				fmt.Sprintf("TPS %.2f at p95 %.2fms (%.0f%% of the best TPS %.2f); beyond: %s",
+					g.Statistics.TPS.Mean, g.Statistics.LatencyP95.Mean,
+					percentOf(g.Statistics.TPS.Mean, findings.BestTPSValue), findings.BestTPSValue, strings.Join(reasons, "; ")))
+		}
+	} else if findings.BestTPSGroup != "" {
+		add(findings.BestTPSGroup, findings.BestTPSThreads,
+			fmt.Sprintf("Prefer %s", findings.BestTPSGroup),
+			fmt.Sprintf("highest mean TPS %.2f", findings.BestTPSValue))
+	}
+
+	for _, f := range findings.Insights {
+		if f.Kind == FindingUnstable {
+			add(f.Group, f.Threads, fmt.Sprintf("Repeat %s with more runs before drawing conclusions", f.Group), f.Evidence)
+		}
+	}
+}
+
+// largestGroupBelow returns the group with the most threads below limit (any if limit is 0).
+func largestGroupBelow(groups []*ThreadGroup, limit int) *ThreadGroup {
+	var best *ThreadGroup
+	for _, g := range groups {
+		if g.Threads > 0 && (limit == 0 || g.Threads < limit) && (best == nil || g.Threads > best.Threads) {
+			best = g
+		}
+	}
+	return best
+}
+
+// percentOf returns v as a percentage of total.
+func percentOf(v, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(v / total * 100)
+}
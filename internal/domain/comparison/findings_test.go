@@ -0,0 +1,113 @@
+// Package comparison provides unit tests for the findings engine.
+package comparison
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// scalingPoint is the result of one run at a thread count.
+type scalingPoint struct {
+	threads int
+	tps     float64
+	p95     float64
+	errors  int64
+}
+
+// scalingRecords returns one record per point.
+func scalingRecords(points []scalingPoint) []*RecordRef {
+	var records []*RecordRef
+	for i, p := range points {
+		records = append(records, &RecordRef{
+			ID:            fmt.Sprintf("%d", i),
+			DatabaseType:  "MySQL",
+			Threads:       p.threads,
+			TPS:           p.tps,
+			LatencyP95:    p.p95,
+			IgnoredErrors: p.errors,
+		})
+	}
+	return records
+}
+
+func TestGenerateSimplifiedReport_Findings(t *testing.T) {
+	records := scalingRecords([]scalingPoint{
+		{1, 1000, 2.0, 0},
+		{2, 1900, 2.1, 0},
+		{4, 3600, 2.2, 0},
+		{8, 5000, 3.0, 0},
+		{16, 5100, 6.5, 0},
+		{32, 4500, 14.0, 12},
+	})
+
+	report := GenerateSimplifiedReport(records, GroupByThreads)
+	f := report.Findings
+
+	if f.ScalingKnee != 8 || f.Saturation != 16 || f.LatencyCliff != 16 || f.ErrorOnset != 32 {
+		t.Errorf("knee=%d saturation=%d cliff=%d errors=%d, want 8, 16, 16, 32",
+			f.ScalingKnee, f.Saturation, f.LatencyCliff, f.ErrorOnset)
+	}
+	if len(f.ScalingSteps) != 5 {
+		t.Fatalf("got %d scaling steps, want 5", len(f.ScalingSteps))
+	}
+	if step := f.ScalingSteps[0]; step.FromThreads != 1 || step.ToThreads != 2 || math.Abs(step.Efficiency-0.95) > 1e-9 {
+		t.Errorf("first step = %+v, want 1→2 at 95%% efficiency", step)
+	}
+
+	kinds := make(map[FindingKind]bool)
+	for i, insight := range f.Insights {
+		kinds[insight.Kind] = true
+		if insight.Evidence == "" {
+			t.Errorf("finding %q has no evidence", insight.Claim)
+		}
+		if i > 0 && insight.Threads < f.Insights[i-1].Threads {
+			t.Errorf("findings not in thread order: %v", f.Insights)
+		}
+	}
+	for _, kind := range []FindingKind{FindingScalingKnee, FindingSaturation, FindingLatencyCliff, FindingErrorOnset} {
+		if !kinds[kind] {
+			t.Errorf("missing %s finding in %v", kind, f.Insights)
+		}
+	}
+
+	if len(f.Recommendations) == 0 || f.Recommendations[0].Threads != 4 {
+		t.Fatalf("Recommendations = %v, want 4 threads first", f.Recommendations)
+	}
+	if !strings.Contains(f.Recommendations[0].Evidence, "Scaling knee at 8 threads") {
+		t.Errorf("recommendation evidence %q does not cite the knee", f.Recommendations[0].Evidence)
+	}
+
+	md := report.FormatMarkdown()
+	for _, want := range []string{"Latency cliff at 16 threads", "Errors start at 32 threads", "| 1 → 2 | +90.0% | 95% | 1.05x |", "Run at about 4 threads"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("FormatMarkdown() does not contain %q", want)
+		}
+	}
+}
+
+func TestGenerateSimplifiedReport_StillScaling(t *testing.T) {
+	records := scalingRecords([]scalingPoint{
+		{4, 1000, 2.0, 0},
+		{8, 1900, 2.1, 0},
+		{16, 3700, 2.3, 0},
+	})
+	records = append(records, &RecordRef{ID: "unstable", Threads: 16, TPS: 2500, LatencyP95: 2.3})
+
+	f := GenerateSimplifiedReport(records, GroupByThreads).Findings
+
+	if f.ScalingKnee != 0 || f.Saturation != 0 || f.LatencyCliff != 0 || f.ErrorOnset != 0 {
+		t.Errorf("unexpected limits: knee=%d saturation=%d cliff=%d errors=%d",
+			f.ScalingKnee, f.Saturation, f.LatencyCliff, f.ErrorOnset)
+	}
+	if len(f.Recommendations) != 2 {
+		t.Fatalf("Recommendations = %v, want scale further and repeat the unstable group", f.Recommendations)
+	}
+	if !strings.Contains(f.Recommendations[0].Claim, "test higher thread counts") {
+		t.Errorf("first recommendation = %q", f.Recommendations[0].Claim)
+	}
+	if f.Recommendations[1].Group != "threads=16" {
+		t.Errorf("second recommendation group = %q, want threads=16", f.Recommendations[1].Group)
+	}
+}
@@ -0,0 +1,249 @@
+// Package comparison provides statistical significance testing.
+// This file implements Welch's t-test for comparing two groups of runs.
+package comparison
+
+import (
+	"fmt"
+	"math"
+)
+
+// DefaultSignificanceLevel is the alpha used for significance verdicts (95% confidence).
+const DefaultSignificanceLevel = 0.05
+
+// SignificanceResult holds the outcome of a two-sample test for one metric.
+type SignificanceResult struct {
+	Metric      string  // Metric name (e.g. "TPS")
+	BaseLabel   string  // Label of the baseline group (e.g. "threads=8")
+	TargetLabel string  // Label of the compared group (e.g. "threads=16")
+	NBase       int     // Runs in baseline group
+	NTarget     int     // Runs in compared group
+	MeanBase    float64 // Baseline mean
+	MeanTarget  float64 // Compared group mean
+	Diff        float64 // MeanTarget - MeanBase
+	CILower     float64 // Lower bound of the confidence interval of Diff
+	CIUpper     float64 // Upper bound of the confidence interval of Diff
+	TStat       float64 // Welch t statistic
+	DF          float64 // Welch-Satterthwaite degrees of freedom
+	PValue      float64 // Two-sided p-value
+	Alpha       float64 // Significance level used
+	Testable    bool    // False if either group has fewer than 2 runs
+	Significant bool    // True if PValue < Alpha
+}
+
+// Verdict returns a human readable significance verdict.
+func (r SignificanceResult) Verdict() string {
+	if !r.Testable {
+		return "not testable (need N>=2 in both groups)"
+	}
+	if r.Significant {
+		return "difference IS statistically significant"
+	}
+	return "difference is NOT statistically significant"
+}
+
+// WelchTTest performs a two-sided Welch's t-test between base and target samples.
+// The confidence interval of the mean difference uses the same alpha.
+func WelchTTest(base, target []float64, alpha float64) SignificanceResult {
+	baseStats := calculateGroupMetricStats(base)
+	targetStats := calculateGroupMetricStats(target)
+
+	result := SignificanceResult{
+		NBase:      len(base),
+		NTarget:    len(target),
+		MeanBase:   baseStats.Mean,
+		MeanTarget: targetStats.Mean,
+		Diff:       targetStats.Mean - baseStats.Mean,
+		Alpha:      alpha,
+		PValue:     1,
+	}
+	result.CILower, result.CIUpper = result.Diff, result.Diff
+
+	if len(base) < 2 || len(target) < 2 {
+		return result
+	}
+	result.Testable = true
+
+	varBase := baseStats.StdDev * baseStats.StdDev / float64(len(base))
+	varTarget := targetStats.StdDev * targetStats.StdDev / float64(len(target))
+	se := math.Sqrt(varBase + varTarget)
+
+	if se == 0 {
+		// No variance in either group: any difference is exact
+		if result.Diff != 0 {
+			result.TStat = math.Inf(sign(result.Diff))
+			result.PValue = 0
+			result.Significant = true
+		}
+		result.DF = float64(len(base) + len(target) - 2)
+		return result
+	}
+
+	result.TStat = result.Diff / se
+	result.DF = (varBase + varTarget) * (varBase + varTarget) /
+		(varBase*varBase/float64(len(base)-1) + varTarget*varTarget/float64(len(target)-1))
+	result.PValue = 2 * (1 - studentTCDF(math.Abs(result.TStat), result.DF))
+	result.Significant = result.PValue < alpha
+
+	margin := studentTQuantile(1-alpha/2, result.DF) * se
+	result.CILower = result.Diff - margin
+	result.CIUpper = result.Diff + margin
+
+	return result
+}
+
+// CompareGroups runs significance tests for TPS, QPS and latency
+// between each group and the one before it.
+func CompareGroups(groups []*ThreadGroup, alpha float64) []SignificanceResult {
+	var results []SignificanceResult
+
+	for i := 1; i < len(groups); i++ {
+		base, target := groups[i-1], groups[i]
+		metrics := []struct {
+			name  string
+			value func(*RecordRef) float64
+		}{
+			{"TPS", func(r *RecordRef) float64 { return r.TPS }},
+			{"QPS", func(r *RecordRef) float64 { return r.QPS }},
+			{"Lat avg ms", func(r *RecordRef) float64 { return r.LatencyAvg }},
+			{"Lat p95 ms", func(r *RecordRef) float64 { return r.LatencyP95 }},
+		}
+
+		for _, m := range metrics {
+			res := WelchTTest(recordValues(base.Records, m.value), recordValues(target.Records, m.value), alpha)
+			res.Metric = m.name
+			res.BaseLabel = base.Label
+			res.TargetLabel = target.Label
+			results = append(results, res)
+		}
+	}
+
+	return results
+}
+
+// recordValues extracts one metric from a list of records.
+func recordValues(records []*RecordRef, value func(*RecordRef) float64) []float64 {
+	values := make([]float64, len(records))
+	for i, r := range records {
+		values[i] = value(r)
+	}
+	return values
+}
+
+// formatPValue formats a p-value for reports.
+func formatPValue(p float64) string {
+	if p < 0.001 {
+		return "<0.001"
+	}
+	return fmt.Sprintf("%.3f", p)
+}
+
+func sign(v float64) int {
+	if v < 0 {
+		return -1
+	}
+	return 1
+}
+
+// =============================================================================
+// Student's t distribution
+// =============================================================================
+
+// studentTCDF returns P(T <= t) for Student's t distribution with df degrees of freedom.
+func studentTCDF(t, df float64) float64 {
+	x := df / (df + t*t)
+	tail := 0.5 * regularizedIncompleteBeta(df/2, 0.5, x)
+	if t >= 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// studentTQuantile returns t such that P(T <= t) = p, found by bisection.
+func studentTQuantile(p, df float64) float64 {
+	lo, hi := -1000.0, 1000.0
+	for i := 0; i < 200; i++ {
+		mid := (lo + hi) / 2
+		if studentTCDF(mid, df) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// regularizedIncompleteBeta computes I_x(a, b) using a continued fraction.
+func regularizedIncompleteBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lgA, _ := math.Lgamma(a)
+	lgB, _ := math.Lgamma(b)
+	lgAB, _ := math.Lgamma(a + b)
+	front := math.Exp(lgAB - lgA - lgB + a*math.Log(x) + b*math.Log(1-x))
+
+	// Use the symmetry relation for faster convergence
+	if x > (a+1)/(a+b+2) {
+		return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+	}
+	return front * betaContinuedFraction(a, b, x) / a
+}
+
+// betaContinuedFraction evaluates the continued fraction for the incomplete beta function
+// (modified Lentz's method).
+func betaContinuedFraction(a, b, x float64) float64 {
+	const (
+		maxIter = 300
+		eps     = 1e-14
+		tiny    = 1e-300
+	)
+
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= maxIter; m++ {
+		fm := float64(m)
+
+		// Even step
+		num := fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		// Odd step
+		num = -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1))
+		d = 1 + num*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + num/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+
+		if math.Abs(delta-1) < eps {
+			break
+		}
+	}
+
+	return h
+}
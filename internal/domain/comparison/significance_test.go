@@ -0,0 +1,89 @@
+// Package comparison provides unit tests for significance testing.
+package comparison
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWelchTTest_KnownValues(t *testing.T) {
+	// Reference example with unequal variances (t ≈ 2.46, df ≈ 24.9, p ≈ 0.021)
+	a := []float64{27.5, 21.0, 19.0, 23.6, 17.0, 17.9, 16.9, 20.1, 21.9, 22.6, 23.1, 19.6, 19.0, 21.7, 21.4}
+	b := []float64{27.1, 22.0, 20.8, 23.4, 23.4, 23.5, 25.8, 22.0, 24.8, 20.2, 21.9, 22.1, 22.9, 20.5, 24.4}
+
+	res := WelchTTest(a, b, DefaultSignificanceLevel)
+
+	if !res.Testable {
+		t.Fatal("expected result to be testable")
+	}
+	if math.Abs(res.TStat-2.455) > 0.01 {
+		t.Errorf("TStat = %.4f, want ~2.455", res.TStat)
+	}
+	if math.Abs(res.DF-24.9) > 0.1 {
+		t.Errorf("DF = %.4f, want ~24.9", res.DF)
+	}
+	if math.Abs(res.PValue-0.021) > 0.002 {
+		t.Errorf("PValue = %.4f, want ~0.021", res.PValue)
+	}
+	if !res.Significant {
+		t.Error("expected difference to be significant at alpha=0.05")
+	}
+	if res.CILower > res.Diff || res.CIUpper < res.Diff || res.CILower <= 0 {
+		t.Errorf("CI = [%.4f, %.4f] does not bracket Diff=%.4f above zero", res.CILower, res.CIUpper, res.Diff)
+	}
+}
+
+func TestWelchTTest_NotSignificant(t *testing.T) {
+	a := []float64{100, 110, 90, 105, 95}
+	b := []float64{102, 108, 92, 104, 97}
+
+	res := WelchTTest(a, b, DefaultSignificanceLevel)
+	if res.Significant {
+		t.Errorf("expected no significant difference, p=%.4f", res.PValue)
+	}
+	if res.Verdict() != "difference is NOT statistically significant" {
+		t.Errorf("Verdict() = %q", res.Verdict())
+	}
+}
+
+func TestWelchTTest_InsufficientData(t *testing.T) {
+	res := WelchTTest([]float64{100}, []float64{200, 210}, DefaultSignificanceLevel)
+	if res.Testable || res.Significant {
+		t.Errorf("expected untestable result, got %+v", res)
+	}
+}
+
+func TestStudentTQuantile(t *testing.T) {
+	tests := []struct {
+		p, df, want float64
+	}{
+		{0.975, 10, 2.228},
+		{0.975, 30, 2.042},
+		{0.95, 5, 2.015},
+	}
+	for _, tt := range tests {
+		got := studentTQuantile(tt.p, tt.df)
+		if math.Abs(got-tt.want) > 0.001 {
+			t.Errorf("studentTQuantile(%v, %v) = %.4f, want %.3f", tt.p, tt.df, got, tt.want)
+		}
+	}
+}
+
+func TestCompareGroups(t *testing.T) {
+	groups := []*ThreadGroup{
+		{Label: "threads=8", Threads: 8, Records: []*RecordRef{{TPS: 100}, {TPS: 102}, {TPS: 98}}},
+		{Label: "threads=16", Threads: 16, Records: []*RecordRef{{TPS: 180}, {TPS: 185}, {TPS: 178}}},
+	}
+
+	results := CompareGroups(groups, DefaultSignificanceLevel)
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results (one per metric), got %d", len(results))
+	}
+	tps := results[0]
+	if tps.Metric != "TPS" || tps.BaseLabel != "threads=8" || tps.TargetLabel != "threads=16" {
+		t.Errorf("unexpected labels: %+v", tps)
+	}
+	if !tps.Significant {
+		t.Errorf("expected TPS difference to be significant, p=%.4f", tps.PValue)
+	}
+}
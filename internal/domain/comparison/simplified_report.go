@@ -6,6 +6,7 @@ package comparison
 import (
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -13,11 +14,19 @@ import (
 
 // SimplifiedReportFindings contains findings for simplified report.
 type SimplifiedReportFindings struct {
+	BestTPSGroup       string // Label of the group with the best mean TPS
+	BestLatencyGroup   string // Label of the group with the best mean p95 latency
 	BestTPSThreads     int
 	BestTPSValue       float64
 	BestLatencyThreads int
 	BestLatencyValue   float64
-	ScalingKnee        int
+	ScalingKnee        int           // First thread count with cumulative efficiency below KneeEfficiency
+	Saturation         int           // First thread count where TPS grows less than SaturationGain
+	LatencyCliff       int           // First thread count where p95 grows by LatencyCliffRatio or more
+	ErrorOnset         int           // Smallest thread count with errors or reconnects
+	ScalingSteps       []ScalingStep // Scaling between adjacent thread counts
+	Insights           []Finding     // Detected findings with their evidence
+	Recommendations    []Finding     // Recommendations with their evidence
 	Recommendation     string
 }
 
@@ -28,14 +37,21 @@ type SimplifiedReport struct {
 	SelectedRecords int
 	GroupBy         GroupByField
 	Records         []*RecordRef
+	Excluded        []*RecordRef // Selected runs left out for failing their sanity checks
 	ConfigGroups    []*ThreadGroup
+	ConfigDiffs     []ConfigDiff // Database configuration of each group vs the first group
 	SanityChecks    []SanityCheckResult
+	Significance    []SignificanceResult
 	Findings        *SimplifiedReportFindings
 	Notes           string
 }
 
-// ThreadGroup groups records by thread count for analysis.
+// ThreadGroup groups records for analysis.
+// Despite the name it is used for every GroupByField; Threads is the
+// common thread count of the group (0 if the records differ).
 type ThreadGroup struct {
+	Label      string // e.g. "threads=8", "database=MySQL", "tag=before"
+	Key        string // Grouping value, e.g. "8", "MySQL", "before"
 	Threads    int
 	Records    []*RecordRef
 	Statistics ThreadGroupStats
@@ -79,22 +95,227 @@ func GenerateSimplifiedReport(records []*RecordRef, groupBy GroupByField) *Simpl
 		ReportID:        fmt.Sprintf("report-%s", time.Now().Format("20060102_150405")),
 		SelectedRecords: len(records),
 		GroupBy:         groupBy,
-		Records:         records,
 		Notes:           "Simplified report (no Template Variant, no time series)",
 	}
 
-	// Group by threads
-	report.ConfigGroups = groupByThreads(records)
+	// Only runs that passed their sanity checks are compared
+	for _, record := range records {
+		if record.IsValid() {
+			report.Records = append(report.Records, record)
+		} else {
+			report.Excluded = append(report.Excluded, record)
+		}
+	}
+
+	// Group records by the requested field
+	report.ConfigGroups = groupRecords(report.Records, groupBy)
+
+	// Diff the database configuration the groups were measured against
+	report.ConfigDiffs = CompareConfigs(report.ConfigGroups)
 
 	// Perform sanity checks
 	report.SanityChecks = performSimplifiedChecks(report.ConfigGroups)
+	report.SanityChecks = append(report.SanityChecks, validityCheck(report.Excluded))
+	if groupBy == GroupByVersion {
+		report.SanityChecks = append(report.SanityChecks, versionScopeCheck(report.ConfigGroups))
+	}
+
+	// Test whether differences between adjacent groups are significant
+	if groupBy == GroupByVersion {
+		report.Significance = compareVersions(report.ConfigGroups, DefaultSignificanceLevel)
+	} else {
+		report.Significance = CompareGroups(report.ConfigGroups, DefaultSignificanceLevel)
+	}
 
 	// Generate findings
-	report.Findings = generateSimplifiedFindings(report.ConfigGroups)
+	report.Findings = generateSimplifiedFindings(report.ConfigGroups, groupBy)
 
 	return report
 }
 
+// groupRecords groups records by the given field.
+// Unknown fields fall back to grouping by threads.
+func groupRecords(records []*RecordRef, groupBy GroupByField) []*ThreadGroup {
+	switch groupBy {
+	case GroupByDatabaseType:
+		return groupByKey(records, groupBy, func(r *RecordRef) []string {
+			return []string{r.DatabaseType}
+		})
+	case GroupByTemplate:
+		return groupByKey(records, groupBy, func(r *RecordRef) []string {
+			return []string{r.TemplateName}
+		})
+	case GroupByDate:
+		return groupByKey(records, groupBy, func(r *RecordRef) []string {
+			return []string{r.StartTime.Format("2006-01-02")}
+		})
+	case GroupByTag:
+		return groupByKey(records, groupBy, func(r *RecordRef) []string {
+			if len(r.Tags) == 0 {
+				return []string{"untagged"}
+			}
+			return r.Tags
+		})
+	case GroupByVersion:
+		// Each version is measured per thread count, so that a group never averages different loads
+		groups := groupByKey(records, groupBy, func(r *RecordRef) []string {
+			return []string{fmt.Sprintf("%s threads=%d", versionKey(r), r.Threads)}
+		})
+		sort.SliceStable(groups, func(i, j int) bool {
+			if groups[i].Threads != groups[j].Threads {
+				return groups[i].Threads < groups[j].Threads
+			}
+			return naturalLess(versionKey(groups[i].Records[0]), versionKey(groups[j].Records[0]))
+		})
+		return groups
+	default:
+		return groupByThreads(records)
+	}
+}
+
+// groupByKey groups records by string keys returned by keysOf.
+// Groups are sorted by key.
+func groupByKey(records []*RecordRef, groupBy GroupByField, keysOf func(*RecordRef) []string) []*ThreadGroup {
+	groups := make(map[string]*ThreadGroup)
+
+	for _, record := range records {
+		for _, key := range keysOf(record) {
+			if key == "" {
+				key = "unknown"
+			}
+			if groups[key] == nil {
+				groups[key] = &ThreadGroup{
+					Label:   fmt.Sprintf("%s=%s", groupFieldName(groupBy), key),
+					Key:     key,
+					Threads: record.Threads,
+					Records: []*RecordRef{},
+				}
+			}
+			group := groups[key]
+			if group.Threads != record.Threads {
+				group.Threads = 0
+			}
+			group.Records = append(group.Records, record)
+		}
+	}
+
+	var groupList []*ThreadGroup
+	for _, group := range groups {
+		group.Statistics = calculateThreadStats(group.Records)
+		groupList = append(groupList, group)
+	}
+
+	sort.Slice(groupList, func(i, j int) bool {
+		return groupList[i].Key < groupList[j].Key
+	})
+
+	return groupList
+}
+
+// groupFieldName returns the short name used in group labels.
+func groupFieldName(groupBy GroupByField) string {
+	switch groupBy {
+	case GroupByDatabaseType:
+		return "database"
+	case GroupByTemplate:
+		return "template"
+	case GroupByDate:
+		return "date"
+	case GroupByTag:
+		return "tag"
+	case GroupByVersion:
+		return "version"
+	default:
+		return "threads"
+	}
+}
+
+// versionKey returns the database type and the version captured at run time
+// of a record, e.g. "MySQL 8.0.36". Records without a configuration snapshot
+// have an unknown version.
+func versionKey(r *RecordRef) string {
+	version := "unknown"
+	if r.ConfigSnapshot != nil && r.ConfigSnapshot.Version != "" {
+		version = r.ConfigSnapshot.Version
+	}
+	return strings.TrimSpace(r.DatabaseType + " " + version)
+}
+
+// naturalLess orders strings with their digit runs compared as numbers, so
+// that "8.4.2" comes before "8.10.0".
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		da, db := leadingDigits(a), leadingDigits(b)
+		if da != "" && db != "" {
+			na, nb := strings.TrimLeft(da, "0"), strings.TrimLeft(db, "0")
+			if len(na) != len(nb) {
+				return len(na) < len(nb)
+			}
+			if na != nb {
+				return na < nb
+			}
+			a, b = a[len(da):], b[len(db):]
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+// leadingDigits returns the digits s starts with.
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
+
+// groupVersions returns the versions of version groups in version order,
+// each with the records of all its thread counts.
+func groupVersions(groups []*ThreadGroup) ([]string, map[string][]*RecordRef) {
+	var versions []string
+	records := make(map[string][]*RecordRef)
+	for _, group := range groups {
+		for _, record := range group.Records {
+			version := versionKey(record)
+			if records[version] == nil {
+				versions = append(versions, version)
+			}
+			records[version] = append(records[version], record)
+		}
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return naturalLess(versions[i], versions[j])
+	})
+	return versions, records
+}
+
+// VersionsTitle names the compared versions of a report grouped by version,
+// e.g. "MySQL 8.0.36 vs 8.4.2"; the database type is repeated only where it
+// changes. It is empty for other groupings.
+func (r *SimplifiedReport) VersionsTitle() string {
+	if r.GroupBy != GroupByVersion {
+		return ""
+	}
+	versions, records := groupVersions(r.ConfigGroups)
+	var parts []string
+	prevType := ""
+	for _, version := range versions {
+		part := version
+		dbType := records[version][0].DatabaseType
+		if dbType != "" && dbType == prevType {
+			part = strings.TrimPrefix(part, dbType+" ")
+		}
+		prevType = dbType
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " vs ")
+}
+
 // groupByThreads groups records by thread count.
 func groupByThreads(records []*RecordRef) []*ThreadGroup {
 	groups := make(map[int]*ThreadGroup)
@@ -103,6 +324,8 @@ func groupByThreads(records []*RecordRef) []*ThreadGroup {
 		threads := record.Threads
 		if groups[threads] == nil {
 			groups[threads] = &ThreadGroup{
+				Label:   fmt.Sprintf("threads=%d", threads),
+				Key:     fmt.Sprintf("%d", threads),
 				Threads: threads,
 				Records: []*RecordRef{},
 			}
@@ -213,8 +436,8 @@ func performSimplifiedChecks(groups []*ThreadGroup) []SanityCheckResult {
 			total := record.ReadQueries + record.WriteQueries + record.OtherQueries
 			if total != record.TotalQueries {
 				sqlPassed = false
-				sqlDetails += fmt.Sprintf("Group %d: total=%d vs calc=%d",
-					group.Threads, record.TotalQueries, total)
+				sqlDetails += fmt.Sprintf("Group %s: total=%d vs calc=%d",
+					group.Label, record.TotalQueries, total)
 			}
 		}
 	}
@@ -233,8 +456,8 @@ func performSimplifiedChecks(groups []*ThreadGroup) []SanityCheckResult {
 		diff := math.Abs(expectedQPS - actualQPS)
 		if expectedQPS > 0 && (diff/expectedQPS) > 0.05 { // 5% tolerance
 			qpsPassed = false
-			qpsDetails += fmt.Sprintf("Group %d: expected=%.2f, actual=%.2f",
-				group.Threads, expectedQPS, actualQPS)
+			qpsDetails += fmt.Sprintf("Group %s: expected=%.2f, actual=%.2f",
+				group.Label, expectedQPS, actualQPS)
 		}
 	}
 	checks = append(checks, SanityCheckResult{
@@ -250,8 +473,8 @@ func performSimplifiedChecks(groups []*ThreadGroup) []SanityCheckResult {
 		if group.Statistics.LatencyAvg.Min > group.Statistics.LatencyAvg.Mean ||
 			group.Statistics.LatencyAvg.Mean > group.Statistics.LatencyP95.Mean {
 			latencyPassed = false
-			latencyDetails += fmt.Sprintf("Group %d: min=%.2f, avg=%.2f, p95=%.2f",
-				group.Threads, group.Statistics.LatencyAvg.Min,
+			latencyDetails += fmt.Sprintf("Group %s: min=%.2f, avg=%.2f, p95=%.2f",
+				group.Label, group.Statistics.LatencyAvg.Min,
 				group.Statistics.LatencyAvg.Mean, group.Statistics.LatencyP95.Mean)
 		}
 	}
@@ -267,8 +490,8 @@ func performSimplifiedChecks(groups []*ThreadGroup) []SanityCheckResult {
 	for _, group := range groups {
 		if group.Statistics.Errors > 0 || group.Statistics.Reconnects > 0 {
 			errorsPassed = false
-			errorsDetails += fmt.Sprintf("Group %d: errors=%d, reconnects=%d",
-				group.Threads, group.Statistics.Errors, group.Statistics.Reconnects)
+			errorsDetails += fmt.Sprintf("Group %s: errors=%d, reconnects=%d",
+				group.Label, group.Statistics.Errors, group.Statistics.Reconnects)
 		}
 	}
 	checks = append(checks, SanityCheckResult{
@@ -280,8 +503,73 @@ func performSimplifiedChecks(groups []*ThreadGroup) []SanityCheckResult {
 	return checks
 }
 
+// validityCheck reports the selected runs excluded for failing their sanity checks.
+func validityCheck(excluded []*RecordRef) SanityCheckResult {
+	var details []string
+	for _, record := range excluded {
+		details = append(details, fmt.Sprintf("%s failed %s", record.ID, strings.Join(record.FailedChecks, ", ")))
+	}
+	return SanityCheckResult{
+		Name:    "Runs passed their sanity checks",
+		Passed:  len(excluded) == 0,
+		Details: strings.Join(details, "; "),
+	}
+}
+
+// versionScopeCheck reports whether the versions were measured with the same
+// template and thread counts, so that only the version differs between groups.
+func versionScopeCheck(groups []*ThreadGroup) SanityCheckResult {
+	var details []string
+	templates := make(map[string]bool)
+	var baseThreads []int
+	versions, records := groupVersions(groups)
+	for i, version := range versions {
+		var threads []int
+		for _, record := range records[version] {
+			templates[record.TemplateName] = true
+			if !slices.Contains(threads, record.Threads) {
+				threads = append(threads, record.Threads)
+			}
+		}
+		slices.Sort(threads)
+		if i == 0 {
+			baseThreads = threads
+		} else if !slices.Equal(threads, baseThreads) {
+			details = append(details, fmt.Sprintf("%s threads %v vs %s threads %v", version, threads, versions[0], baseThreads))
+		}
+	}
+	if len(templates) > 1 {
+		names := make([]string, 0, len(templates))
+		for name := range templates {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		details = append([]string{"templates " + strings.Join(names, ", ")}, details...)
+	}
+	return SanityCheckResult{
+		Name:    "Versions compared with the same template and threads",
+		Passed:  len(details) == 0,
+		Details: strings.Join(details, "; "),
+	}
+}
+
+// compareVersions tests whether the differences between adjacent versions
+// measured with the same thread count are significant.
+func compareVersions(groups []*ThreadGroup, alpha float64) []SignificanceResult {
+	var results []SignificanceResult
+	for start := 0; start < len(groups); {
+		end := start + 1
+		for end < len(groups) && groups[end].Threads == groups[start].Threads {
+			end++
+		}
+		results = append(results, CompareGroups(groups[start:end], alpha)...)
+		start = end
+	}
+	return results
+}
+
 // generateSimplifiedFindings generates findings from grouped data.
-func generateSimplifiedFindings(groups []*ThreadGroup) *SimplifiedReportFindings {
+func generateSimplifiedFindings(groups []*ThreadGroup, groupBy GroupByField) *SimplifiedReportFindings {
 	findings := &SimplifiedReportFindings{}
 
 	// Find best TPS
@@ -292,6 +580,7 @@ func generateSimplifiedFindings(groups []*ThreadGroup) *SimplifiedReportFindings
 		}
 	}
 	if bestTPSGroup != nil {
+		findings.BestTPSGroup = bestTPSGroup.Label
 		findings.BestTPSThreads = bestTPSGroup.Threads
 		findings.BestTPSValue = bestTPSGroup.Statistics.TPS.Mean
 	}
@@ -304,30 +593,22 @@ func generateSimplifiedFindings(groups []*ThreadGroup) *SimplifiedReportFindings
 		}
 	}
 	if bestLatencyGroup != nil {
+		findings.BestLatencyGroup = bestLatencyGroup.Label
 		findings.BestLatencyThreads = bestLatencyGroup.Threads
 		findings.BestLatencyValue = bestLatencyGroup.Statistics.LatencyP95.Mean
 	}
 
-	// Identify scaling knee
-	if len(groups) > 1 {
-		// Find where efficiency drops below 70%
-		for i := 1; i < len(groups); i++ {
-			group := groups[i]
-
-			speedup := group.Statistics.TPS.Mean / groups[0].Statistics.TPS.Mean
-			efficiency := speedup / float64(group.Threads)
-
-			if efficiency < 0.70 {
-				findings.ScalingKnee = group.Threads
-				break
-			}
-		}
+	// Scaling knee, saturation, latency cliff and error onset (only meaningful when grouping by threads)
+	if groupBy == GroupByThreads {
+		analyzeScaling(findings, groups)
 	}
+	analyzeStability(findings, groups)
+	recommend(findings, groups, groupBy)
 
 	// Generate recommendation
 	if bestTPSGroup != nil {
-		findings.Recommendation = fmt.Sprintf("threads=%d (TPS=%.2f, p95=%.2fms)",
-			bestTPSGroup.Threads,
+		findings.Recommendation = fmt.Sprintf("%s (TPS=%.2f, p95=%.2fms)",
+			bestTPSGroup.Label,
 			bestTPSGroup.Statistics.TPS.Mean,
 			bestTPSGroup.Statistics.LatencyP95.Mean)
 	}
@@ -348,6 +629,9 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 	builder.WriteString(fmt.Sprintf("* **Generated at:** %s\n", r.GeneratedAt.Format("2006-01-02 15:04:05")))
 	builder.WriteString(fmt.Sprintf("* **Report ID:** %s\n", r.ReportID))
 	builder.WriteString(fmt.Sprintf("* **Group by:** %s\n", r.GroupBy))
+	if versions := r.VersionsTitle(); versions != "" {
+		builder.WriteString(fmt.Sprintf("* **Versions:** %s\n", versions))
+	}
 	builder.WriteString(fmt.Sprintf("* **Config Groups:** %d\n", len(r.ConfigGroups)))
 	builder.WriteString("\n---\n\n")
 
@@ -362,6 +646,8 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 	builder.WriteString(fmt.Sprintf("| Config Groups | %d |\n", len(r.ConfigGroups)))
 	builder.WriteString("\n")
 
+	r.formatConfigMarkdown(&builder)
+
 	builder.WriteString("### 1.3 Measurement Policy\n\n")
 	builder.WriteString("* **Report interval:** 1s\n")
 	builder.WriteString("* **Test duration:** Varies by run\n")
@@ -372,8 +658,8 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 
 	// Section 2: Experiment Matrix
 	builder.WriteString("## 2) Experiment Matrix\n\n")
-	builder.WriteString("| Config ID | threads | Database | Template | Runs (N) | Tags |\n")
-	builder.WriteString("|---------:|-------:|---------|----------|--------:|------|\n")
+	builder.WriteString("| Config ID | Group | threads | Database | Template | Runs (N) | Tags |\n")
+	builder.WriteString("|---------:|-------|-------:|---------|----------|--------:|------|\n")
 	for i, group := range r.ConfigGroups {
 		cid := fmt.Sprintf("C%d", i+1)
 		database := group.Records[0].DatabaseType
@@ -381,19 +667,19 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 		n := group.Statistics.N
 
 		var tags []string
-		if r.Findings != nil && group.Threads == r.Findings.BestTPSThreads {
+		if r.Findings != nil && group.Label == r.Findings.BestTPSGroup {
 			tags = append(tags, "best-tps")
 		}
-		if r.Findings != nil && group.Threads == r.Findings.BestLatencyThreads {
+		if r.Findings != nil && group.Label == r.Findings.BestLatencyGroup {
 			tags = append(tags, "best-latency")
 		}
-		if group.Threads == 1 {
+		if r.isBaselineGroup(i, group) {
 			tags = append(tags, "baseline")
 		}
 		tagStr := strings.Join(tags, " ")
 
-		builder.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %d | %s |\n",
-			cid, group.Threads, database, template, n, tagStr))
+		builder.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %d | %s |\n",
+			cid, group.Label, formatGroupThreads(group), database, template, n, tagStr))
 	}
 	builder.WriteString("\n")
 
@@ -403,15 +689,15 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 	builder.WriteString("> Latency unit: milliseconds\n\n")
 
 	builder.WriteString("### 3.1 Throughput & Latency Summary\n\n")
-	builder.WriteString("| threads | N | TPS (mean ± sd) | TPS (min..max) | QPS (mean ± sd) | QPS (min..max) | Lat avg ms (mean ± sd) | Lat p95 ms (mean ± sd) | Lat max ms (max-of-max) |\n")
-	builder.WriteString("|-------:|:-:|---------------:|--------------:|---------------:|--------------:|----------------------:|----------------------:|-----------------------:|\n")
+	builder.WriteString("| Group | N | TPS (mean ± sd) | TPS (min..max) | QPS (mean ± sd) | QPS (min..max) | Lat avg ms (mean ± sd) | Lat p95 ms (mean ± sd) | Lat max ms (max-of-max) |\n")
+	builder.WriteString("|-------|:-:|---------------:|--------------:|---------------:|--------------:|----------------------:|----------------------:|-----------------------:|\n")
 
 	for _, group := range r.ConfigGroups {
 		// Calculate max latency (max-of-max across all runs in this group)
 		maxLat := group.Statistics.LatencyMax.Max
 
-		builder.WriteString(fmt.Sprintf("| %d | %d | %s | %s | %s | %s | %s | %s | %.2f |\n",
-			group.Threads,
+		builder.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s | %s | %s | %s | %.2f |\n",
+			group.Label,
 			group.Statistics.N,
 			formatGroupMetric(group.Statistics.TPS),
 			formatGroupMetricRange(group.Statistics.TPS),
@@ -425,15 +711,15 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 	builder.WriteString("\n")
 
 	builder.WriteString("### 3.2 Reliability\n\n")
-	builder.WriteString("| threads | N | Total Errors | Total Reconnects | Any non-zero? |\n")
-	builder.WriteString("|-------:|:-:|------------:|---------------:|:-------------|\n")
+	builder.WriteString("| Group | N | Total Errors | Total Reconnects | Any non-zero? |\n")
+	builder.WriteString("|-------|:-:|------------:|---------------:|:-------------|\n")
 	for _, group := range r.ConfigGroups {
 		anyNonZero := "NO"
 		if group.Statistics.Errors > 0 || group.Statistics.Reconnects > 0 {
 			anyNonZero = "YES"
 		}
-		builder.WriteString(fmt.Sprintf("| %d | %d | %d | %d | %s |\n",
-			group.Threads, group.Statistics.N,
+		builder.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %s |\n",
+			group.Label, group.Statistics.N,
 			group.Statistics.Errors, group.Statistics.Reconnects, anyNonZero))
 	}
 	builder.WriteString("\n")
@@ -444,8 +730,8 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 		totalQ := record.ReadQueries + record.WriteQueries + record.OtherQueries
 		if totalQ > 0 {
 			builder.WriteString("### 3.3 Actual Query Mix (from SQL statistics)\n\n")
-			builder.WriteString("| threads | Read % | Write % | Other % | Queries / Transaction |\n")
-			builder.WriteString("|-------:|------:|-------:|-------:|--------------------:|\n")
+			builder.WriteString("| Group | Read % | Write % | Other % | Queries / Transaction |\n")
+			builder.WriteString("|-------|------:|-------:|-------:|--------------------:|\n")
 			for _, group := range r.ConfigGroups {
 				if len(group.Records) > 0 {
 					r := group.Records[0]
@@ -458,8 +744,8 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 						if r.TPS > 0 {
 							qpt = float64(r.TotalQueries) / r.TPS
 						}
-						builder.WriteString(fmt.Sprintf("| %d | %.1f | %.1f | %.1f | %.2f |\n",
-							group.Threads, rp, wp, op, qpt))
+						builder.WriteString(fmt.Sprintf("| %s | %.1f | %.1f | %.1f | %.2f |\n",
+							group.Label, rp, wp, op, qpt))
 					}
 				}
 			}
@@ -467,8 +753,28 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 		}
 	}
 
+	// Statistical significance between adjacent groups
+	if len(r.Significance) > 0 {
+		builder.WriteString(fmt.Sprintf("### 3.4 Statistical Significance (Welch's t-test, α=%.2f)\n\n", DefaultSignificanceLevel))
+		builder.WriteString("> Each group is compared with the previous one. Δ = compared mean − baseline mean; CI is the 95% confidence interval of Δ.\n\n")
+		builder.WriteString("| Comparison | Metric | Δ mean | 95% CI of Δ | t | df | p-value | Verdict |\n")
+		builder.WriteString("|-----------|--------|------:|------------:|--:|---:|-------:|---------|\n")
+		for _, sig := range r.Significance {
+			comparison := fmt.Sprintf("%s vs %s", sig.TargetLabel, sig.BaseLabel)
+			if !sig.Testable {
+				builder.WriteString(fmt.Sprintf("| %s | %s | %.2f | N/A | N/A | N/A | N/A | %s |\n",
+					comparison, sig.Metric, sig.Diff, sig.Verdict()))
+				continue
+			}
+			builder.WriteString(fmt.Sprintf("| %s | %s | %.2f | %.2f .. %.2f | %.2f | %.1f | %s | %s |\n",
+				comparison, sig.Metric, sig.Diff, sig.CILower, sig.CIUpper,
+				sig.TStat, sig.DF, formatPValue(sig.PValue), sig.Verdict()))
+		}
+		builder.WriteString("\n")
+	}
+
 	// Section 5: Scaling & Efficiency
-	if len(r.ConfigGroups) > 0 && r.ConfigGroups[0].Threads == 1 {
+	if r.GroupBy == GroupByThreads && len(r.ConfigGroups) > 0 && r.ConfigGroups[0].Threads == 1 {
 		builder.WriteString("## 5) Scaling & Efficiency (Threads Analysis)\n\n")
 		baselineTPS := r.ConfigGroups[0].Statistics.TPS.Mean
 		builder.WriteString(fmt.Sprintf("**Baseline:** threads=1 (TPS=%.2f)\n\n", baselineTPS))
@@ -504,7 +810,7 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 	// Section 6: Visuals
 	builder.WriteString("## 6) Visuals (ASCII Charts)\n\n")
 
-	builder.WriteString("### 6.1 TPS vs Threads\n")
+	builder.WriteString(fmt.Sprintf("### 6.1 TPS by %s\n", groupFieldName(r.GroupBy)))
 	builder.WriteString("```text\n")
 	maxTPS := 0.0
 	for _, g := range r.ConfigGroups {
@@ -524,12 +830,12 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 		}
 		bar := strings.Repeat("█", barLength)
 		spaces := strings.Repeat(" ", barWidth-barLength)
-		builder.WriteString(fmt.Sprintf("threads=%d  |%s%s %.2f\n",
-			g.Threads, bar, spaces, tps))
+		builder.WriteString(fmt.Sprintf("%-*s |%s%s %.2f\n",
+			r.labelWidth(), g.Label, bar, spaces, tps))
 	}
 	builder.WriteString("```\n\n")
 
-	builder.WriteString("### 6.2 p95 Latency vs Threads\n")
+	builder.WriteString(fmt.Sprintf("### 6.2 p95 Latency by %s\n", groupFieldName(r.GroupBy)))
 	builder.WriteString("```text\n")
 	maxP95 := 0.0
 	for _, g := range r.ConfigGroups {
@@ -549,8 +855,8 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 		}
 		bar := strings.Repeat("█", barLength)
 		spaces := strings.Repeat(" ", barWidth-barLength)
-		builder.WriteString(fmt.Sprintf("threads=%d  |%s%s %.2fms\n",
-			g.Threads, bar, spaces, p95))
+		builder.WriteString(fmt.Sprintf("%-*s |%s%s %.2fms\n",
+			r.labelWidth(), g.Label, bar, spaces, p95))
 	}
 	builder.WriteString("```\n\n")
 
@@ -586,23 +892,31 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 	}
 	builder.WriteString("\n")
 
+	if len(r.Excluded) > 0 {
+		builder.WriteString(fmt.Sprintf("%d of %d selected runs failed their sanity checks and are not compared:\n\n", len(r.Excluded), r.SelectedRecords))
+		for _, record := range r.Excluded {
+			builder.WriteString(fmt.Sprintf("- `%s` (%s, %d threads): %s\n",
+				record.ID, record.ConnectionName, record.Threads, strings.Join(record.FailedChecks, ", ")))
+		}
+		builder.WriteString("\n")
+	}
+
 	// Section 8: Findings & Recommendations
 	builder.WriteString("## 8) Findings & Recommendations\n\n")
 
 	builder.WriteString("### 8.1 Key Findings\n\n")
 	if r.Findings != nil {
-		builder.WriteString(fmt.Sprintf("* **Best throughput point:** threads=%d (TPS=%.2f, p95=%.2fms)\n",
-			r.Findings.BestTPSThreads, r.Findings.BestTPSValue,
-			getLatencyForThreads(r.ConfigGroups, r.Findings.BestTPSThreads)))
+		builder.WriteString(fmt.Sprintf("* **Best throughput point:** %s (TPS=%.2f, p95=%.2fms)\n",
+			r.Findings.BestTPSGroup, r.Findings.BestTPSValue,
+			getLatencyForGroup(r.ConfigGroups, r.Findings.BestTPSGroup)))
 
-		if r.Findings.BestLatencyThreads > 0 {
-			builder.WriteString(fmt.Sprintf("* **Best latency point:** threads=%d (p95=%.2fms)\n",
-				r.Findings.BestLatencyThreads, r.Findings.BestLatencyValue))
+		if r.Findings.BestLatencyGroup != "" {
+			builder.WriteString(fmt.Sprintf("* **Best latency point:** %s (p95=%.2fms)\n",
+				r.Findings.BestLatencyGroup, r.Findings.BestLatencyValue))
 		}
 
-		if r.Findings.ScalingKnee > 0 {
-			builder.WriteString(fmt.Sprintf("* **Scaling knee:** threads=~%d (efficiency drops significantly)\n",
-				r.Findings.ScalingKnee))
+		for _, f := range r.Findings.Insights {
+			builder.WriteString(fmt.Sprintf("* **%s.** Evidence: %s\n", f.Claim, f.Evidence))
 		}
 
 		// Check stability
@@ -623,13 +937,34 @@ func (r *SimplifiedReport) FormatMarkdown() string {
 		}
 	}
 
+	if r.Findings != nil && len(r.Findings.ScalingSteps) > 0 {
+		builder.WriteString("\n**Scaling efficiency per step** (100% = linear):\n\n")
+		builder.WriteString("| Step | TPS Change | Efficiency | p95 Change |\n")
+		builder.WriteString("|------|------------|------------|------------|\n")
+		for _, step := range r.Findings.ScalingSteps {
+			p95 := "-"
+			if step.P95Ratio > 0 {
+				p95 = fmt.Sprintf("%.2fx", step.P95Ratio)
+			}
+			builder.WriteString(fmt.Sprintf("| %d → %d | %+.1f%% | %.0f%% | %s |\n",
+				step.FromThreads, step.ToThreads, step.TPSGain*100, step.Efficiency*100, p95))
+		}
+	}
+
 	builder.WriteString("\n### 8.2 Recommendation\n\n")
 	if r.Findings != nil {
-		builder.WriteString(fmt.Sprintf("**Suggested:** threads=%d\n\n", r.Findings.BestTPSThreads))
+		builder.WriteString(fmt.Sprintf("**Suggested:** %s\n\n", r.Findings.BestTPSGroup))
+
+		for _, rec := range r.Findings.Recommendations {
+			builder.WriteString(fmt.Sprintf("* **%s.** Evidence: %s\n", rec.Claim, rec.Evidence))
+		}
+		if len(r.Findings.Recommendations) > 0 {
+			builder.WriteString("\n")
+		}
 
 		// Trade-off statement
 		bestGroup := getGroupByThreads(r.ConfigGroups, r.Findings.BestTPSThreads)
-		if bestGroup != nil && len(r.ConfigGroups) > 0 && r.ConfigGroups[0].Threads == 1 {
+		if r.GroupBy == GroupByThreads && bestGroup != nil && len(r.ConfigGroups) > 0 && r.ConfigGroups[0].Threads == 1 {
 			speedup := bestGroup.Statistics.TPS.Mean / r.ConfigGroups[0].Statistics.TPS.Mean
 			efficiency := speedup / float64(bestGroup.Threads)
 			builder.WriteString(fmt.Sprintf("**Trade-off:** %.2fx speedup with %.2f%% scaling efficiency at %.2fms p95 latency\n\n",
@@ -652,6 +987,44 @@ func getLatencyForThreads(groups []*ThreadGroup, threads int) float64 {
 	return 0
 }
 
+// getLatencyForGroup returns p95 latency for the group with the given label.
+func getLatencyForGroup(groups []*ThreadGroup, label string) float64 {
+	for _, g := range groups {
+		if g.Label == label {
+			return g.Statistics.LatencyP95.Mean
+		}
+	}
+	return 0
+}
+
+// isBaselineGroup reports whether a group is the comparison baseline:
+// threads=1 when grouping by threads, otherwise the first group.
+func (r *SimplifiedReport) isBaselineGroup(index int, group *ThreadGroup) bool {
+	if r.GroupBy == GroupByThreads {
+		return group.Threads == 1
+	}
+	return index == 0
+}
+
+// labelWidth returns the width of the longest group label (for chart alignment).
+func (r *SimplifiedReport) labelWidth() int {
+	width := 0
+	for _, g := range r.ConfigGroups {
+		if len(g.Label) > width {
+			width = len(g.Label)
+		}
+	}
+	return width
+}
+
+// formatGroupThreads formats the thread count of a group ("mixed" if records differ).
+func formatGroupThreads(group *ThreadGroup) string {
+	if group.Threads == 0 {
+		return "mixed"
+	}
+	return fmt.Sprintf("%d", group.Threads)
+}
+
 // getGroupByThreads returns the thread group with the given thread count.
 func getGroupByThreads(groups []*ThreadGroup, threads int) *ThreadGroup {
 	for _, g := range groups {
@@ -725,16 +1098,25 @@ func (r *SimplifiedReport) FormatTXT() string {
 
 	builder.WriteString(fmt.Sprintf("Generated: %s\n", r.GeneratedAt.Format("2006-01-02 15:04:05")))
 	builder.WriteString(fmt.Sprintf("Report ID: %s\n", r.ReportID))
-	builder.WriteString(fmt.Sprintf("Records: %d\n\n", r.SelectedRecords))
+	builder.WriteString(fmt.Sprintf("Records: %d\n", r.SelectedRecords))
+	if versions := r.VersionsTitle(); versions != "" {
+		builder.WriteString(fmt.Sprintf("Versions: %s\n", versions))
+	}
+	for _, record := range r.Excluded {
+		builder.WriteString(fmt.Sprintf("  Excluded %s: failed %s\n", record.ID, strings.Join(record.FailedChecks, ", ")))
+	}
+	builder.WriteString("\n")
 
 	// Config groups
 	builder.WriteString("Configuration Groups:\n")
 	for _, group := range r.ConfigGroups {
-		builder.WriteString(fmt.Sprintf("  threads=%d: %d run(s), TPS=%.2f\n",
-			group.Threads, group.Statistics.N, group.Statistics.TPS.Mean))
+		builder.WriteString(fmt.Sprintf("  %s: %d run(s), TPS=%.2f\n",
+			group.Label, group.Statistics.N, group.Statistics.TPS.Mean))
 	}
 	builder.WriteString("\n")
 
+	r.formatConfigTXT(&builder)
+
 	// Sanity checks
 	builder.WriteString("Sanity Checks:\n")
 	passed := 0
@@ -747,16 +1129,38 @@ func (r *SimplifiedReport) FormatTXT() string {
 	}
 	builder.WriteString(fmt.Sprintf("\nTotal: %d/%d passed\n\n", passed, len(r.SanityChecks)))
 
+	// Statistical significance
+	if len(r.Significance) > 0 {
+		builder.WriteString(fmt.Sprintf("Statistical Significance (Welch's t-test, alpha=%.2f):\n", DefaultSignificanceLevel))
+		for _, sig := range r.Significance {
+			if !sig.Testable {
+				builder.WriteString(fmt.Sprintf("  %s vs %s %s: delta=%.2f, %s\n",
+					sig.TargetLabel, sig.BaseLabel, sig.Metric, sig.Diff, sig.Verdict()))
+				continue
+			}
+			builder.WriteString(fmt.Sprintf("  %s vs %s %s: delta=%.2f (95%% CI %.2f .. %.2f), p=%s, %s\n",
+				sig.TargetLabel, sig.BaseLabel, sig.Metric, sig.Diff,
+				sig.CILower, sig.CIUpper, formatPValue(sig.PValue), sig.Verdict()))
+		}
+		builder.WriteString("\n")
+	}
+
 	// Findings
 	if r.Findings != nil {
 		builder.WriteString("Findings:\n")
-		builder.WriteString(fmt.Sprintf("  Best TPS: threads=%d (TPS=%.2f)\n",
-			r.Findings.BestTPSThreads, r.Findings.BestTPSValue))
-		if r.Findings.BestLatencyThreads > 0 {
-			builder.WriteString(fmt.Sprintf("  Best Latency: threads=%d (p95=%.2fms)\n",
-				r.Findings.BestLatencyThreads, r.Findings.BestLatencyValue))
+		builder.WriteString(fmt.Sprintf("  Best TPS: %s (TPS=%.2f)\n",
+			r.Findings.BestTPSGroup, r.Findings.BestTPSValue))
+		if r.Findings.BestLatencyGroup != "" {
+			builder.WriteString(fmt.Sprintf("  Best Latency: %s (p95=%.2fms)\n",
+				r.Findings.BestLatencyGroup, r.Findings.BestLatencyValue))
+		}
+		for _, f := range r.Findings.Insights {
+			builder.WriteString(fmt.Sprintf("  %s (%s)\n", f.Claim, f.Evidence))
 		}
 		builder.WriteString(fmt.Sprintf("  Recommendation: %s\n", r.Findings.Recommendation))
+		for _, rec := range r.Findings.Recommendations {
+			builder.WriteString(fmt.Sprintf("  - %s (%s)\n", rec.Claim, rec.Evidence))
+		}
 	}
 
 	return builder.String()
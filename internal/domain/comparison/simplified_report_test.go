@@ -0,0 +1,187 @@
+// Package comparison provides unit tests for simplified report grouping.
+package comparison
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
+)
+
+func TestGenerateSimplifiedReport_GroupBy(t *testing.T) {
+	records := []*RecordRef{
+		{ID: "1", DatabaseType: "MySQL", TemplateName: "oltp-rw", Threads: 8, TPS: 100, Tags: []string{"before"}},
+		{ID: "2", DatabaseType: "MySQL", TemplateName: "oltp-ro", Threads: 8, TPS: 110, Tags: []string{"after"}},
+		{ID: "3", DatabaseType: "PostgreSQL", TemplateName: "oltp-rw", Threads: 16, TPS: 120},
+	}
+
+	tests := []struct {
+		name       string
+		groupBy    GroupByField
+		wantLabels []string
+	}{
+		{"threads", GroupByThreads, []string{"threads=8", "threads=16"}},
+		{"database type", GroupByDatabaseType, []string{"database=MySQL", "database=PostgreSQL"}},
+		{"template", GroupByTemplate, []string{"template=oltp-ro", "template=oltp-rw"}},
+		{"tag", GroupByTag, []string{"tag=after", "tag=before", "tag=untagged"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := GenerateSimplifiedReport(records, tt.groupBy)
+			if len(report.ConfigGroups) != len(tt.wantLabels) {
+				t.Fatalf("got %d groups, want %d", len(report.ConfigGroups), len(tt.wantLabels))
+			}
+			for i, want := range tt.wantLabels {
+				if got := report.ConfigGroups[i].Label; got != want {
+					t.Errorf("group %d label = %q, want %q", i, got, want)
+				}
+			}
+			if md := report.FormatMarkdown(); !strings.Contains(md, tt.wantLabels[0]) {
+				t.Errorf("FormatMarkdown() does not mention group %q", tt.wantLabels[0])
+			}
+		})
+	}
+}
+
+func TestGenerateSimplifiedReport_MixedThreads(t *testing.T) {
+	records := []*RecordRef{
+		{ID: "1", DatabaseType: "MySQL", Threads: 8, TPS: 100},
+		{ID: "2", DatabaseType: "MySQL", Threads: 16, TPS: 150},
+	}
+
+	report := GenerateSimplifiedReport(records, GroupByDatabaseType)
+	if got := report.ConfigGroups[0].Threads; got != 0 {
+		t.Errorf("Threads = %d, want 0 for mixed thread counts", got)
+	}
+	if report.Findings.BestTPSGroup != "database=MySQL" {
+		t.Errorf("BestTPSGroup = %q, want %q", report.Findings.BestTPSGroup, "database=MySQL")
+	}
+}
+
+func TestGenerateSimplifiedReport_ExcludesInvalidRuns(t *testing.T) {
+	records := []*RecordRef{
+		{ID: "1", DatabaseType: "MySQL", Threads: 8, TPS: 100},
+		{ID: "2", DatabaseType: "MySQL", Threads: 8, TPS: 104},
+		{ID: "3", DatabaseType: "MySQL", ConnectionName: "mysql-local", Threads: 8, TPS: 20, FailedChecks: []string{"min-duration"}},
+	}
+
+	report := GenerateSimplifiedReport(records, GroupByThreads)
+	if report.SelectedRecords != 3 || len(report.Records) != 2 || len(report.Excluded) != 1 {
+		t.Fatalf("selected %d, compared %d, excluded %d, want 3, 2, 1",
+			report.SelectedRecords, len(report.Records), len(report.Excluded))
+	}
+	if n := report.ConfigGroups[0].Statistics.N; n != 2 {
+		t.Errorf("group N = %d, want 2", n)
+	}
+
+	check := report.SanityChecks[len(report.SanityChecks)-1]
+	if check.Passed || !strings.Contains(check.Details, "3 failed min-duration") {
+		t.Errorf("validity check = %+v, want failed naming run 3", check)
+	}
+	if md := report.FormatMarkdown(); !strings.Contains(md, "- `3` (mysql-local, 8 threads): min-duration") {
+		t.Errorf("FormatMarkdown() does not list the excluded run")
+	}
+}
+
+func TestGenerateSimplifiedReport_ConfigDiffs(t *testing.T) {
+	before := &dbconfig.Snapshot{
+		DatabaseType: "mysql",
+		Version:      "8.0.36",
+		Settings:     map[string]string{"innodb_buffer_pool_size": "134217728", "max_connections": "151"},
+	}
+	after := &dbconfig.Snapshot{
+		DatabaseType: "mysql",
+		Version:      "8.0.36",
+		Settings:     map[string]string{"innodb_buffer_pool_size": "8589934592", "max_connections": "151"},
+	}
+	records := []*RecordRef{
+		{ID: "1", Threads: 8, TPS: 100, Tags: []string{"baseline"}, ConfigSnapshot: before},
+		{ID: "2", Threads: 8, TPS: 150, Tags: []string{"tuned"}, ConfigSnapshot: after},
+		{ID: "3", Threads: 8, TPS: 90, Tags: []string{"unknown"}},
+	}
+
+	report := GenerateSimplifiedReport(records, GroupByTag)
+	if len(report.ConfigDiffs) != 1 {
+		t.Fatalf("got %d config diffs, want 1 (groups without snapshot skipped)", len(report.ConfigDiffs))
+	}
+	diff := report.ConfigDiffs[0]
+	if diff.BaseLabel != "tag=baseline" || diff.TargetLabel != "tag=tuned" || len(diff.Changes) != 1 ||
+		diff.Changes[0].Name != "innodb_buffer_pool_size" {
+		t.Errorf("config diff = %+v", diff)
+	}
+
+	md := report.FormatMarkdown()
+	for _, want := range []string{"### 1.2 Database Configuration", "| tag=unknown | not captured |", "| innodb_buffer_pool_size | 134217728 | 8589934592 |"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("FormatMarkdown() does not contain %q", want)
+		}
+	}
+	if txt := report.FormatTXT(); !strings.Contains(txt, "innodb_buffer_pool_size: 134217728 -> 8589934592") {
+		t.Errorf("FormatTXT() does not list the changed setting")
+	}
+}
+
+func TestGenerateSimplifiedReport_GroupByVersion(t *testing.T) {
+	snapshot := func(version string) *dbconfig.Snapshot {
+		return &dbconfig.Snapshot{DatabaseType: "mysql", Version: version}
+	}
+	records := []*RecordRef{
+		{ID: "1", DatabaseType: "MySQL", TemplateName: "oltp-rw", Threads: 8, TPS: 100, ConfigSnapshot: snapshot("8.10.0")},
+		{ID: "2", DatabaseType: "MySQL", TemplateName: "oltp-rw", Threads: 8, TPS: 110, ConfigSnapshot: snapshot("8.0.36")},
+		{ID: "3", DatabaseType: "MySQL", TemplateName: "oltp-rw", Threads: 8, TPS: 120, ConfigSnapshot: snapshot("8.4.2")},
+	}
+
+	// Each version is measured at 8 and 16 threads
+	for _, r := range slices.Clone(records) {
+		records = append(records, &RecordRef{ID: r.ID + "-16", DatabaseType: r.DatabaseType, TemplateName: r.TemplateName, Threads: 16, TPS: r.TPS * 2, ConfigSnapshot: r.ConfigSnapshot})
+	}
+
+	report := GenerateSimplifiedReport(records, GroupByVersion)
+	var labels []string
+	threads := make(map[string]int)
+	for _, group := range report.ConfigGroups {
+		labels = append(labels, group.Label)
+		threads[group.Label] = group.Threads
+		if len(group.Records) != 1 || group.Threads != group.Records[0].Threads {
+			t.Errorf("group %s has %d records, threads %d", group.Label, len(group.Records), group.Threads)
+		}
+	}
+	if want := "version=MySQL 8.0.36 threads=8,version=MySQL 8.4.2 threads=8,version=MySQL 8.10.0 threads=8," +
+		"version=MySQL 8.0.36 threads=16,version=MySQL 8.4.2 threads=16,version=MySQL 8.10.0 threads=16"; strings.Join(labels, ",") != want {
+		t.Errorf("groups = %v, want %s", labels, want)
+	}
+	for _, res := range report.Significance {
+		if threads[res.BaseLabel] != threads[res.TargetLabel] {
+			t.Errorf("significance compares %s with %s, want the same threads", res.BaseLabel, res.TargetLabel)
+		}
+	}
+	if want := 4 * 4; len(report.Significance) != want {
+		t.Errorf("significance results = %d, want %d", len(report.Significance), want)
+	}
+	if series := report.ChartSeries(); len(series) != 3 || series[0].Label != "version=MySQL 8.0.36" || len(series[0].Points) != 2 {
+		t.Errorf("ChartSeries() = %+v, want a curve over 8 and 16 threads per version", series)
+	}
+	if got := report.VersionsTitle(); got != "MySQL 8.0.36 vs 8.4.2 vs 8.10.0" {
+		t.Errorf("VersionsTitle() = %q", got)
+	}
+	if md := report.FormatMarkdown(); !strings.Contains(md, "* **Versions:** MySQL 8.0.36 vs 8.4.2 vs 8.10.0") {
+		t.Errorf("FormatMarkdown() does not name the versions")
+	}
+	check := report.SanityChecks[len(report.SanityChecks)-1]
+	if !check.Passed {
+		t.Errorf("version scope check = %+v, want passed", check)
+	}
+
+	// A version measured with other threads or without a snapshot is flagged
+	records = append(records, &RecordRef{ID: "4", DatabaseType: "MySQL", TemplateName: "oltp-ro", Threads: 16, TPS: 90})
+	report = GenerateSimplifiedReport(records, GroupByVersion)
+	if last := report.ConfigGroups[len(report.ConfigGroups)-1]; last.Key != "MySQL unknown threads=16" {
+		t.Errorf("last group = %q, want MySQL unknown threads=16", last.Key)
+	}
+	check = report.SanityChecks[len(report.SanityChecks)-1]
+	if check.Passed || !strings.Contains(check.Details, "templates oltp-ro, oltp-rw") || !strings.Contains(check.Details, "threads [16] vs") {
+		t.Errorf("version scope check = %+v, want failed", check)
+	}
+}
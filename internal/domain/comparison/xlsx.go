@@ -0,0 +1,400 @@
+// Package comparison provides the Excel export of the simplified report.
+// This file writes the report as an XLSX workbook: a summary sheet with the
+// TPS and p95 latency charts, and one sheet with the runs of each group.
+package comparison
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// FormatXLSX formats the report as an XLSX workbook.
+// The Summary sheet holds the statistics of each group, the chart data (the
+// series of ChartSeries) and charts of TPS and p95 latency vs threads
+// with ± stddev error bars. Each group has a sheet with its runs.
+func (r *SimplifiedReport) FormatXLSX() ([]byte, error) {
+	wb := &xlsxWorkbook{}
+	summary := wb.addSheet("Summary")
+	summary.widths = []float64{24, 10, 6, 12, 12, 12, 12, 12, 14, 14, 14, 14, 10, 12}
+
+	summary.addRow(xlsxBold("Performance Comparison Report " + r.ReportID))
+	summary.addRow(xlsxText(fmt.Sprintf("Generated %s, grouped by %s, %d record(s)",
+		r.GeneratedAt.Format("2006-01-02 15:04:05"), r.GroupBy, r.SelectedRecords)))
+	if versions := r.VersionsTitle(); versions != "" {
+		summary.addRow(xlsxText(versions))
+	}
+	summary.addRow()
+	summary.addRow(xlsxBoldRow("Group", "Threads", "Runs", "TPS Mean", "TPS StdDev", "TPS Min", "TPS Max",
+		"QPS Mean", "Avg Latency (ms)", "P95 Latency (ms)", "P95 StdDev", "Max Latency (ms)", "Errors", "Reconnects")...)
+	for _, group := range r.ConfigGroups {
+		s := group.Statistics
+		summary.addRow(xlsxText(group.Label), xlsxNumber(float64(group.Threads)), xlsxNumber(float64(s.N)),
+			xlsxDecimal(s.TPS.Mean), xlsxDecimal(s.TPS.StdDev), xlsxDecimal(s.TPS.Min), xlsxDecimal(s.TPS.Max),
+			xlsxDecimal(s.QPS.Mean), xlsxDecimal(s.LatencyAvg.Mean), xlsxDecimal(s.LatencyP95.Mean),
+			xlsxDecimal(s.LatencyP95.StdDev), xlsxDecimal(s.LatencyMax.Mean),
+			xlsxNumber(float64(s.Errors)), xlsxNumber(float64(s.Reconnects)))
+	}
+
+	// Chart data: the points of each series in consecutive rows
+	summary.addRow()
+	summary.addRow(xlsxBold("Chart Data"))
+	summary.addRow(xlsxBoldRow("Series", "Threads", "Runs", "TPS Mean", "TPS StdDev", "P95 Latency (ms)", "P95 StdDev")...)
+	series := r.ChartSeries()
+	tps := xlsxChart{title: ChartTPS.Title(), yTitle: "TPS", logX: true}
+	p95 := xlsxChart{title: ChartLatencyP95.Title(), yTitle: "P95 latency (ms)", logX: true}
+	for _, s := range series {
+		first := len(summary.rows) + 1
+		for _, p := range s.Points {
+			if p.Threads <= 0 {
+				tps.logX, p95.logX = false, false
+			}
+			summary.addRow(xlsxText(s.Label), xlsxNumber(float64(p.Threads)), xlsxNumber(float64(p.N)),
+				xlsxDecimal(p.TPS.Mean), xlsxDecimal(p.TPS.StdDev), xlsxDecimal(p.LatencyP95.Mean), xlsxDecimal(p.LatencyP95.StdDev))
+		}
+		if len(s.Points) == 0 {
+			continue
+		}
+		last := len(summary.rows)
+		ref := func(col int) string { return summary.rangeRef(col, first, last) }
+		tps.series = append(tps.series, xlsxChartSeries{name: s.Label, x: ref(1), y: ref(3), err: ref(4)})
+		p95.series = append(p95.series, xlsxChartSeries{name: s.Label, x: ref(1), y: ref(5), err: ref(6)})
+	}
+	tps.from, tps.to = [2]int{15, 0}, [2]int{24, 20}
+	p95.from, p95.to = [2]int{15, 21}, [2]int{24, 41}
+	summary.charts = []xlsxChart{tps, p95}
+
+	for _, group := range r.ConfigGroups {
+		sheet := wb.addSheet(group.Label)
+		sheet.widths = []float64{38, 20, 20, 12, 16, 8, 12, 12, 14, 14, 14, 14, 14, 12, 8, 12, 24}
+		sheet.addRow(xlsxBoldRow("Run ID", "Start Time", "Template", "Database", "Connection", "Threads",
+			"TPS", "QPS", "Avg Latency (ms)", "Min Latency (ms)", "P95 Latency (ms)", "P99 Latency (ms)",
+			"Max Latency (ms)", "Duration (s)", "Errors", "Reconnects", "Tags")...)
+		for _, rec := range group.Records {
+			sheet.addRow(xlsxText(rec.ID), xlsxText(rec.StartTime.Format("2006-01-02 15:04:05")),
+				xlsxText(rec.TemplateName), xlsxText(rec.DatabaseType), xlsxText(rec.ConnectionName),
+				xlsxNumber(float64(rec.Threads)), xlsxDecimal(rec.TPS), xlsxDecimal(rec.QPS),
+				xlsxDecimal(rec.LatencyAvg), xlsxDecimal(rec.LatencyMin), xlsxDecimal(rec.LatencyP95),
+				xlsxDecimal(rec.LatencyP99), xlsxDecimal(rec.LatencyMax), xlsxDecimal(rec.Duration.Seconds()),
+				xlsxNumber(float64(rec.IgnoredErrors)), xlsxNumber(float64(rec.Reconnects)),
+				xlsxText(strings.Join(rec.Tags, ", ")))
+		}
+	}
+
+	return wb.bytes()
+}
+
+// xlsxCell is a cell of a worksheet.
+type xlsxCell struct {
+	text   string
+	number float64
+	isNum  bool
+	style  int // Index into the cellXfs of xlsxStyles
+}
+
+// Cell styles defined in xlsxStyles; 0 is the default style.
+const (
+	xlsxStyleBold    = 1
+	xlsxStyleDecimal = 2 // Built-in number format 2, "0.00"
+)
+
+func xlsxText(s string) xlsxCell    { return xlsxCell{text: s} }
+func xlsxBold(s string) xlsxCell    { return xlsxCell{text: s, style: xlsxStyleBold} }
+func xlsxNumber(v float64) xlsxCell { return xlsxCell{number: v, isNum: true} }
+func xlsxDecimal(v float64) xlsxCell {
+	return xlsxCell{number: v, isNum: true, style: xlsxStyleDecimal}
+}
+
+// xlsxBoldRow returns a header row.
+func xlsxBoldRow(titles ...string) []xlsxCell {
+	cells := make([]xlsxCell, len(titles))
+	for i, title := range titles {
+		cells[i] = xlsxBold(title)
+	}
+	return cells
+}
+
+// xlsxChartSeries is a scatter series; x, y and err are cell ranges.
+type xlsxChartSeries struct {
+	name, x, y, err string
+}
+
+// xlsxChart is a scatter chart with lines, markers and ± err error bars,
+// anchored from the (column, row) from to to, zero based.
+type xlsxChart struct {
+	title, yTitle string
+	logX          bool // Threads on a base 2 log scale, as they usually double
+	series        []xlsxChartSeries
+	from, to      [2]int
+}
+
+// xlsxSheet is a worksheet of an xlsxWorkbook.
+type xlsxSheet struct {
+	name   string
+	widths []float64 // Column widths in characters
+	rows   [][]xlsxCell
+	charts []xlsxChart
+}
+
+// addRow appends a row; no cells add an empty row.
+func (s *xlsxSheet) addRow(cells ...xlsxCell) {
+	s.rows = append(s.rows, cells)
+}
+
+// rangeRef returns the absolute reference of rows first to last (one based)
+// of a zero based column, e.g. 'Summary'!$B$4:$B$7.
+func (s *xlsxSheet) rangeRef(col, first, last int) string {
+	name := "'" + strings.ReplaceAll(s.name, "'", "''") + "'"
+	c := xlsxColumn(col)
+	return fmt.Sprintf("%s!$%s$%d:$%s$%d", name, c, first, c, last)
+}
+
+// xlsxWorkbook is a minimal XLSX (Office Open XML) writer: inline strings,
+// three cell styles and scatter charts, which is all the report needs.
+type xlsxWorkbook struct {
+	sheets []*xlsxSheet
+}
+
+// addSheet adds a sheet, making name valid and unique as Excel requires:
+// at most 31 characters and none of []:*?/\.
+func (wb *xlsxWorkbook) addSheet(name string) *xlsxSheet {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	if name == "" {
+		name = "Sheet"
+	}
+	base := []rune(name)
+	for i := 2; ; i++ {
+		if len([]rune(name)) > 31 {
+			name = string([]rune(name)[:31])
+		}
+		if !wb.hasSheet(name) {
+			break
+		}
+		suffix := fmt.Sprintf(" (%d)", i)
+		name = string(base[:min(len(base), 31-len(suffix))]) + suffix
+	}
+	sheet := &xlsxSheet{name: name}
+	wb.sheets = append(wb.sheets, sheet)
+	return sheet
+}
+
+// hasSheet reports whether a sheet has the name; Excel compares case-insensitively.
+func (wb *xlsxWorkbook) hasSheet(name string) bool {
+	for _, s := range wb.sheets {
+		if strings.EqualFold(s.name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// bytes writes the workbook as a ZIP package.
+func (wb *xlsxWorkbook) bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	write := func(name, content string) error {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write([]byte(xml.Header + content))
+		return err
+	}
+
+	var types, sheets, rels strings.Builder
+	types.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+
+	charts := 0
+	for i, sheet := range wb.sheets {
+		n := i + 1
+		fmt.Fprintf(&types, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&sheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xlsxEscape(sheet.name), n, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+
+		if err := write(fmt.Sprintf("xl/worksheets/sheet%d.xml", n), sheet.xml()); err != nil {
+			return nil, fmt.Errorf("write sheet %s: %w", sheet.name, err)
+		}
+		if len(sheet.charts) == 0 {
+			continue
+		}
+
+		// The charts of a sheet are anchored in a drawing named after the sheet
+		fmt.Fprintf(&types, `<Override PartName="/xl/drawings/drawing%d.xml" ContentType="application/vnd.openxmlformats-officedocument.drawing+xml"/>`, n)
+		var anchors, drawingRels strings.Builder
+		for j, chart := range sheet.charts {
+			charts++
+			fmt.Fprintf(&types, `<Override PartName="/xl/charts/chart%d.xml" ContentType="application/vnd.openxmlformats-officedocument.drawingml.chart+xml"/>`, charts)
+			fmt.Fprintf(&drawingRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart" Target="../charts/chart%d.xml"/>`, j+1, charts)
+			anchors.WriteString(chart.anchorXML(j + 1))
+			if err := write(fmt.Sprintf("xl/charts/chart%d.xml", charts), chart.xml()); err != nil {
+				return nil, fmt.Errorf("write chart: %w", err)
+			}
+		}
+		drawingParts := []struct{ name, content string }{
+			{fmt.Sprintf("xl/worksheets/_rels/sheet%d.xml.rels", n), `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+				fmt.Sprintf(`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/drawing" Target="../drawings/drawing%d.xml"/>`, n) +
+				`</Relationships>`},
+			{fmt.Sprintf("xl/drawings/drawing%d.xml", n), `<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">` +
+				anchors.String() + `</xdr:wsDr>`},
+			{fmt.Sprintf("xl/drawings/_rels/drawing%d.xml.rels", n), `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+				drawingRels.String() + `</Relationships>`},
+		}
+		for _, part := range drawingParts {
+			if err := write(part.name, part.content); err != nil {
+				return nil, fmt.Errorf("write drawing: %w", err)
+			}
+		}
+	}
+	types.WriteString(`</Types>`)
+	rels.WriteString(`<Relationship Id="rIdStyles" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`)
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", types.String()},
+		{"_rels/.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets>` + sheets.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			rels.String() + `</Relationships>`},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		if err := write(part.name, part.content); err != nil {
+			return nil, fmt.Errorf("write %s: %w", part.name, err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("close workbook: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// xlsxStyles defines the cell styles: default, bold and two decimals.
+const xlsxStyles = `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="2" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>` +
+	`</styleSheet>`
+
+// xml returns the worksheet part.
+func (s *xlsxSheet) xml() string {
+	var sb strings.Builder
+	sb.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	if len(s.widths) > 0 {
+		sb.WriteString("<cols>")
+		for i, w := range s.widths {
+			fmt.Fprintf(&sb, `<col min="%d" max="%d" width="%g" customWidth="1"/>`, i+1, i+1, w)
+		}
+		sb.WriteString("</cols>")
+	}
+	sb.WriteString("<sheetData>")
+	for i, row := range s.rows {
+		fmt.Fprintf(&sb, `<row r="%d">`, i+1)
+		for j, cell := range row {
+			ref := fmt.Sprintf("%s%d", xlsxColumn(j), i+1)
+			switch {
+			case cell.isNum && (math.IsNaN(cell.number) || math.IsInf(cell.number, 0)):
+				fmt.Fprintf(&sb, `<c r="%s" s="%d"/>`, ref, cell.style)
+			case cell.isNum:
+				fmt.Fprintf(&sb, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.style, strconv.FormatFloat(cell.number, 'f', -1, 64))
+			default:
+				fmt.Fprintf(&sb, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, cell.style, xlsxEscape(cell.text))
+			}
+		}
+		sb.WriteString("</row>")
+	}
+	sb.WriteString("</sheetData>")
+	if len(s.charts) > 0 {
+		sb.WriteString(`<drawing r:id="rId1"/>`)
+	}
+	sb.WriteString("</worksheet>")
+	return sb.String()
+}
+
+// anchorXML returns the drawing anchor of the chart with relationship rId<id>.
+func (c xlsxChart) anchorXML(id int) string {
+	return fmt.Sprintf(`<xdr:twoCellAnchor>`+
+		`<xdr:from><xdr:col>%d</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>%d</xdr:row><xdr:rowOff>0</xdr:rowOff></xdr:from>`+
+		`<xdr:to><xdr:col>%d</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>%d</xdr:row><xdr:rowOff>0</xdr:rowOff></xdr:to>`+
+		`<xdr:graphicFrame macro=""><xdr:nvGraphicFramePr><xdr:cNvPr id="%d" name="Chart %d"/><xdr:cNvGraphicFramePr/></xdr:nvGraphicFramePr>`+
+		`<xdr:xfrm><a:off x="0" y="0"/><a:ext cx="0" cy="0"/></xdr:xfrm>`+
+		`<a:graphic><a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/chart">`+
+		`<c:chart xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:id="rId%d"/>`+
+		`</a:graphicData></a:graphic></xdr:graphicFrame><xdr:clientData/></xdr:twoCellAnchor>`,
+		c.from[0], c.from[1], c.to[0], c.to[1], id+1, id, id)
+}
+
+// xml returns the chart part.
+func (c xlsxChart) xml() string {
+	var sb strings.Builder
+	sb.WriteString(`<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+	fmt.Fprintf(&sb, `<c:chart>%s<c:autoTitleDeleted val="0"/><c:plotArea><c:layout/>`, xlsxChartTitle(c.title))
+	sb.WriteString(`<c:scatterChart><c:scatterStyle val="lineMarker"/><c:varyColors val="0"/>`)
+	for i, s := range c.series {
+		color := strings.TrimPrefix(ChartColors[i%len(ChartColors)], "#")
+		fmt.Fprintf(&sb, `<c:ser><c:idx val="%d"/><c:order val="%d"/><c:tx><c:v>%s</c:v></c:tx>`, i, i, xlsxEscape(s.name))
+		fmt.Fprintf(&sb, `<c:spPr><a:ln w="25400"><a:solidFill><a:srgbClr val="%s"/></a:solidFill></a:ln></c:spPr>`, color)
+		fmt.Fprintf(&sb, `<c:marker><c:symbol val="circle"/><c:size val="6"/><c:spPr><a:solidFill><a:srgbClr val="%s"/></a:solidFill></c:spPr></c:marker>`, color)
+		fmt.Fprintf(&sb, `<c:errBars><c:errDir val="y"/><c:errBarType val="both"/><c:errValType val="cust"/><c:noEndCap val="0"/>`+
+			`<c:plus><c:numRef><c:f>%s</c:f></c:numRef></c:plus><c:minus><c:numRef><c:f>%s</c:f></c:numRef></c:minus></c:errBars>`,
+			xlsxEscape(s.err), xlsxEscape(s.err))
+		fmt.Fprintf(&sb, `<c:xVal><c:numRef><c:f>%s</c:f></c:numRef></c:xVal><c:yVal><c:numRef><c:f>%s</c:f></c:numRef></c:yVal>`,
+			xlsxEscape(s.x), xlsxEscape(s.y))
+		sb.WriteString(`<c:smooth val="0"/></c:ser>`)
+	}
+	sb.WriteString(`<c:axId val="1"/><c:axId val="2"/></c:scatterChart>`)
+
+	logBase := ""
+	if c.logX {
+		logBase = `<c:logBase val="2"/>`
+	}
+	fmt.Fprintf(&sb, `<c:valAx><c:axId val="1"/><c:scaling>%s<c:orientation val="minMax"/></c:scaling><c:delete val="0"/><c:axPos val="b"/>%s`+
+		`<c:numFmt formatCode="General" sourceLinked="0"/><c:tickLblPos val="nextTo"/><c:crossAx val="2"/><c:crosses val="autoZero"/><c:crossBetween val="midCat"/></c:valAx>`,
+		logBase, xlsxChartTitle("threads"))
+	fmt.Fprintf(&sb, `<c:valAx><c:axId val="2"/><c:scaling><c:orientation val="minMax"/></c:scaling><c:delete val="0"/><c:axPos val="l"/><c:majorGridlines/>%s`+
+		`<c:numFmt formatCode="General" sourceLinked="0"/><c:tickLblPos val="nextTo"/><c:crossAx val="1"/><c:crosses val="autoZero"/><c:crossBetween val="midCat"/></c:valAx>`,
+		xlsxChartTitle(c.yTitle))
+	sb.WriteString(`</c:plotArea><c:legend><c:legendPos val="b"/><c:overlay val="0"/></c:legend><c:plotVisOnly val="1"/></c:chart></c:chartSpace>`)
+	return sb.String()
+}
+
+// xlsxChartTitle returns a chart or axis title element.
+func xlsxChartTitle(text string) string {
+	return `<c:title><c:tx><c:rich><a:bodyPr/><a:p><a:r><a:t>` + xlsxEscape(text) +
+		`</a:t></a:r></a:p></c:rich></c:tx><c:overlay val="0"/></c:title>`
+}
+
+// xlsxColumn returns the letters of a zero based column index: A, B, ..., AA.
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// xlsxEscape escapes text for XML content and attributes.
+func xlsxEscape(s string) string {
+	var sb strings.Builder
+	xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
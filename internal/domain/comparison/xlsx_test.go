@@ -0,0 +1,89 @@
+// Package comparison provides unit tests for the Excel export.
+package comparison
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestSimplifiedReport_FormatXLSX(t *testing.T) {
+	records := []*RecordRef{
+		{ID: "1", Threads: 8, TPS: 100, LatencyP95: 10, Tags: []string{"a&b"}},
+		{ID: "2", Threads: 8, TPS: 120, LatencyP95: 12},
+		{ID: "3", Threads: 16, TPS: 190, LatencyP95: 14},
+	}
+	data, err := GenerateSimplifiedReport(records, GroupByThreads).FormatXLSX()
+	if err != nil {
+		t.Fatalf("FormatXLSX() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("workbook is not a ZIP package: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(content)
+
+		// Every part must be well-formed XML
+		dec := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s is not well-formed: %v", f.Name, err)
+			}
+		}
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "xl/workbook.xml", "xl/worksheets/sheet3.xml", "xl/charts/chart2.xml", "xl/drawings/drawing1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("workbook has no part %s", name)
+		}
+	}
+	for _, want := range []string{`name="Summary"`, `name="threads=8"`, `name="threads=16"`} {
+		if !strings.Contains(parts["xl/workbook.xml"], want) {
+			t.Errorf("workbook.xml does not contain %s", want)
+		}
+	}
+	if !strings.Contains(parts["xl/charts/chart1.xml"], "Summary&#39;!$E$") {
+		t.Errorf("TPS chart has no error bars from the chart data")
+	}
+	if !strings.Contains(parts["xl/worksheets/sheet2.xml"], "a&amp;b") {
+		t.Errorf("group sheet does not contain the escaped tags")
+	}
+}
+
+func TestXLSXWorkbook_AddSheet(t *testing.T) {
+	wb := &xlsxWorkbook{}
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"template=oltp/read:write", "template=oltp_read_write"},
+		{"TEMPLATE=oltp_read_write", "TEMPLATE=oltp_read_write (2)"},
+		{strings.Repeat("x", 40), strings.Repeat("x", 31)},
+		{strings.Repeat("x", 35), strings.Repeat("x", 27) + " (2)"},
+	}
+	for _, tt := range tests {
+		if got := wb.addSheet(tt.name).name; got != tt.want {
+			t.Errorf("addSheet(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumn(i); got != want {
+			t.Errorf("xlsxColumn(%d) = %s, want %s", i, got, want)
+		}
+	}
+}
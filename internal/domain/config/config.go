@@ -3,10 +3,21 @@
 package config
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/assertion"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
 )
 
 var (
@@ -18,6 +29,9 @@ var (
 
 	// ErrInvalidToolPath is returned when a tool path is invalid.
 	ErrInvalidToolPath = errors.New("invalid tool path")
+
+	// ErrToolVersionTooOld is returned when a tool is older than its minimum supported version.
+	ErrToolVersionTooOld = errors.New("tool version too old")
 )
 
 // ToolType represents a benchmark tool type.
@@ -27,8 +41,26 @@ const (
 	ToolTypeSysbench   ToolType = "sysbench"
 	ToolTypeSwingbench ToolType = "swingbench"
 	ToolTypeHammerDB   ToolType = "hammerdb"
+
+	// Database clients, used to create the benchmark database
+	ToolTypePsql  ToolType = "psql"
+	ToolTypeMySQL ToolType = "mysql"
 )
 
+// minToolVersions are the oldest supported versions of the tools.
+// Swingbench has no version flag, so its version is not checked.
+var minToolVersions = map[ToolType]string{
+	ToolTypeSysbench: "1.0",
+	ToolTypeHammerDB: "4.0",
+	ToolTypePsql:     "9.6",
+	ToolTypeMySQL:    "5.7",
+}
+
+// ToolTypes returns all tool types, benchmark tools first.
+func ToolTypes() []ToolType {
+	return []ToolType{ToolTypeSysbench, ToolTypeSwingbench, ToolTypeHammerDB, ToolTypePsql, ToolTypeMySQL}
+}
+
 // String returns the string representation of the tool type.
 func (t ToolType) String() string {
 	return string(t)
@@ -37,13 +69,63 @@ func (t ToolType) String() string {
 // Validate checks if the tool type is valid.
 func (t ToolType) Validate() error {
 	switch t {
-	case ToolTypeSysbench, ToolTypeSwingbench, ToolTypeHammerDB:
+	case ToolTypeSysbench, ToolTypeSwingbench, ToolTypeHammerDB, ToolTypePsql, ToolTypeMySQL:
 		return nil
 	default:
 		return fmt.Errorf("%w: unknown tool type: %s", ErrInvalidConfiguration, t)
 	}
 }
 
+// MinVersion returns the oldest supported version of the tool, or "" if
+// any version is accepted.
+func (t ToolType) MinVersion() string {
+	return minToolVersions[t]
+}
+
+// CheckVersion returns ErrToolVersionTooOld if version is older than the
+// minimum supported version of the tool.
+func (t ToolType) CheckVersion(version string) error {
+	minVersion := t.MinVersion()
+	if minVersion == "" {
+		return nil
+	}
+	if version == "" {
+		return fmt.Errorf("%w: cannot determine the %s version (need %s or later)", ErrToolVersionTooOld, t, minVersion)
+	}
+	if CompareVersions(version, minVersion) < 0 {
+		return fmt.Errorf("%w: %s %s is installed, %s or later is required", ErrToolVersionTooOld, t, version, minVersion)
+	}
+	return nil
+}
+
+// CompareVersions compares two dotted versions such as "1.0.20" numerically
+// and returns -1, 0 or +1. Missing components count as 0 and anything after
+// the leading digits of a component ("20-MariaDB") is ignored.
+func CompareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		if c := cmp.Compare(versionComponent(as, i), versionComponent(bs, i)); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// versionComponent returns the numeric value of component i of a version.
+func versionComponent(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	n := 0
+	for _, r := range parts[i] {
+		if r < '0' || r > '9' {
+			break
+		}
+		n = n*10 + int(r-'0')
+	}
+	return n
+}
+
 // ToolConfig represents configuration for a benchmark tool.
 type ToolConfig struct {
 	// Type is the tool type.
@@ -190,14 +272,34 @@ func (c *ReportConfig) Validate() error {
 	return nil
 }
 
+// UI themes; auto follows the system.
+const (
+	ThemeAuto  = "auto"
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+)
+
+// Limits of UIConfig.FontScale.
+const (
+	MinFontScale = 0.8
+	MaxFontScale = 2.0
+)
+
 // UIConfig represents UI configuration.
 type UIConfig struct {
 	// Theme is the UI theme (light, dark, auto).
 	Theme string `json:"theme"`
 
-	// Language is the UI language.
+	// Language is the UI language (en, zh); empty means English.
 	Language string `json:"language"`
 
+	// FontScale scales the base text size; 0 means 1.0.
+	FontScale float64 `json:"font_scale,omitempty"`
+
+	// MonospaceFont is a TTF/OTF font file used for the realtime log output
+	// and other monospace text; empty means the built-in font.
+	MonospaceFont string `json:"monospace_font,omitempty"`
+
 	// AutoSave indicates if changes should be auto-saved.
 	AutoSave bool `json:"auto_save"`
 
@@ -208,15 +310,32 @@ type UIConfig struct {
 // Validate validates the UI configuration.
 func (c *UIConfig) Validate() error {
 	validThemes := map[string]bool{
-		"light": true,
-		"dark":  true,
-		"auto":  true,
+		ThemeLight: true,
+		ThemeDark:  true,
+		ThemeAuto:  true,
 	}
 
 	if !validThemes[c.Theme] {
 		return fmt.Errorf("%w: invalid theme: %s", ErrInvalidConfiguration, c.Theme)
 	}
 
+	if !slices.Contains([]string{"", "en", "zh"}, c.Language) {
+		return fmt.Errorf("%w: invalid language: %s", ErrInvalidConfiguration, c.Language)
+	}
+
+	if c.FontScale != 0 && (c.FontScale < MinFontScale || c.FontScale > MaxFontScale) {
+		return fmt.Errorf("%w: font_scale must be between %.1f and %.1f", ErrInvalidConfiguration, MinFontScale, MaxFontScale)
+	}
+
+	if c.MonospaceFont != "" {
+		if ext := strings.ToLower(filepath.Ext(c.MonospaceFont)); ext != ".ttf" && ext != ".otf" {
+			return fmt.Errorf("%w: monospace_font must be a .ttf or .otf file", ErrInvalidConfiguration)
+		}
+		if _, err := os.Stat(c.MonospaceFont); err != nil {
+			return fmt.Errorf("%w: monospace_font: %v", ErrInvalidConfiguration, err)
+		}
+	}
+
 	if c.RefreshInterval < 1 || c.RefreshInterval > 60 {
 		return fmt.Errorf("%w: refresh_interval must be between 1 and 60 seconds", ErrInvalidConfiguration)
 	}
@@ -224,20 +343,526 @@ func (c *UIConfig) Validate() error {
 	return nil
 }
 
+// Scale returns the font scale, 1.0 if unset.
+func (c *UIConfig) Scale() float64 {
+	if c.FontScale == 0 {
+		return 1.0
+	}
+	return c.FontScale
+}
+
+// HistoryConfig represents history saving and retention configuration.
+type HistoryConfig struct {
+	// ManualSave turns off saving finished runs to history automatically;
+	// completed runs are then saved from the completion dialog only.
+	ManualSave bool `json:"manual_save,omitempty"`
+
+	// MaxAgeDays is the maximum age of history records in days (0 = keep forever).
+	MaxAgeDays int `json:"max_age_days"`
+
+	// MaxRecords is the maximum number of history records to keep (0 = unlimited).
+	MaxRecords int `json:"max_records"`
+
+	// ArchiveBeforePurge exports purged records to compressed JSON before deleting them.
+	ArchiveBeforePurge bool `json:"archive_before_purge"`
+
+	// ArchiveDir is the directory for archive files.
+	ArchiveDir string `json:"archive_dir"`
+
+	// PurgeIntervalHours is how often the background purge job runs.
+	PurgeIntervalHours int `json:"purge_interval_hours"`
+
+	// TrendAlertPercent fires the trend_alert webhooks when the TPS or p95
+	// latency of a completed run deviates from the mean of the earlier runs
+	// with the same configuration by more than this percentage (0 = off).
+	TrendAlertPercent float64 `json:"trend_alert_percent,omitempty"`
+}
+
+// Validate validates the history configuration.
+func (c *HistoryConfig) Validate() error {
+	if c.MaxAgeDays < 0 {
+		return fmt.Errorf("%w: max_age_days cannot be negative", ErrInvalidConfiguration)
+	}
+
+	if c.MaxRecords < 0 {
+		return fmt.Errorf("%w: max_records cannot be negative", ErrInvalidConfiguration)
+	}
+
+	if c.ArchiveBeforePurge && c.ArchiveDir == "" {
+		return fmt.Errorf("%w: archive_dir is required when archiving is enabled", ErrInvalidConfiguration)
+	}
+
+	if c.PurgeIntervalHours < 0 || c.PurgeIntervalHours > 24*30 {
+		return fmt.Errorf("%w: purge_interval_hours must be between 0 and 720", ErrInvalidConfiguration)
+	}
+
+	if c.TrendAlertPercent < 0 {
+		return fmt.Errorf("%w: trend_alert_percent cannot be negative", ErrInvalidConfiguration)
+	}
+
+	return nil
+}
+
+// AutoSave reports whether finished runs are saved to history automatically.
+func (c *HistoryConfig) AutoSave() bool {
+	return !c.ManualSave
+}
+
+// Enabled reports whether any retention limit is configured.
+func (c *HistoryConfig) Enabled() bool {
+	return c.MaxAgeDays > 0 || c.MaxRecords > 0
+}
+
+// DefaultExportFileName is the file name template of exports without one
+// configured, e.g. benchmark_oltp_read_write_20060102_150405.
+const DefaultExportFileName = "benchmark_{template}_{date}_{time}"
+
+// ExportFileNamePlaceholders are the placeholders of export file name templates.
+var ExportFileNamePlaceholders = []string{
+	"{connection}",  // Connection name
+	"{template}",    // Template name
+	"{database}",    // Database type
+	"{environment}", // Run environment, e.g. staging
+	"{threads}",     // Thread count
+	"{date}",        // Start date, 20060102
+	"{time}",        // Start time, 150405
+	"{id}",          // History record ID
+}
+
+// ExportConfig represents the export configuration of history records and reports.
+type ExportConfig struct {
+	// Dir is the directory exports are written to (empty = exports in the
+	// application home directory).
+	Dir string `json:"dir,omitempty"`
+
+	// FileName is the file name template of exported records, without the
+	// extension (empty = DefaultExportFileName).
+	FileName string `json:"file_name,omitempty"`
+}
+
+// Validate validates the export configuration.
+func (c *ExportConfig) Validate() error {
+	if c.Dir != "" && !filepath.IsAbs(c.Dir) {
+		return fmt.Errorf("%w: dir must be an absolute path", ErrInvalidConfiguration)
+	}
+
+	if strings.ContainsAny(c.FileName, `/\`) {
+		return fmt.Errorf("%w: file_name cannot contain path separators", ErrInvalidConfiguration)
+	}
+	rest := c.FileName
+	for _, placeholder := range ExportFileNamePlaceholders {
+		rest = strings.ReplaceAll(rest, placeholder, "")
+	}
+	if strings.ContainsAny(rest, "{}") {
+		return fmt.Errorf("%w: file_name has an unknown placeholder, use %s",
+			ErrInvalidConfiguration, strings.Join(ExportFileNamePlaceholders, " "))
+	}
+
+	return nil
+}
+
+// FileNameTemplate returns the file name template in effect.
+func (c *ExportConfig) FileNameTemplate() string {
+	if c.FileName == "" {
+		return DefaultExportFileName
+	}
+	return c.FileName
+}
+
+// SMTP connection security modes.
+const (
+	SMTPSecurityStartTLS = "starttls" // Plain connection upgraded with STARTTLS (usually port 587)
+	SMTPSecurityTLS      = "tls"      // Implicit TLS (usually port 465)
+	SMTPSecurityNone     = "none"     // No encryption (local relays only)
+)
+
+// NotificationConfig represents email notification configuration.
+// The SMTP password is kept in the keyring, not in the configuration file.
+type NotificationConfig struct {
+	// Enabled turns email notifications on.
+	Enabled bool `json:"enabled"`
+
+	// SMTPHost is the SMTP server host.
+	SMTPHost string `json:"smtp_host"`
+
+	// SMTPPort is the SMTP server port.
+	SMTPPort int `json:"smtp_port"`
+
+	// Security is the connection security: starttls, tls or none.
+	Security string `json:"security"`
+
+	// Username is the SMTP user name (empty = no authentication).
+	Username string `json:"username"`
+
+	// From is the sender address.
+	From string `json:"from"`
+
+	// To lists the recipient addresses.
+	To []string `json:"to"`
+
+	// OnSuccess sends a notification when a run completes.
+	OnSuccess bool `json:"on_success"`
+
+	// OnFailure sends a notification when a run fails or times out.
+	OnFailure bool `json:"on_failure"`
+
+	// AttachReport exports the run report and attaches it to the notification.
+	AttachReport bool `json:"attach_report"`
+}
+
+// Validate validates the notification configuration.
+// The server settings are only checked when notifications are enabled.
+func (c *NotificationConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.SMTPHost == "" {
+		return fmt.Errorf("%w: smtp_host is required", ErrInvalidConfiguration)
+	}
+
+	if c.SMTPPort < 1 || c.SMTPPort > 65535 {
+		return fmt.Errorf("%w: smtp_port must be between 1 and 65535", ErrInvalidConfiguration)
+	}
+
+	switch c.Security {
+	case SMTPSecurityStartTLS, SMTPSecurityTLS, SMTPSecurityNone:
+	default:
+		return fmt.Errorf("%w: invalid security mode: %s", ErrInvalidConfiguration, c.Security)
+	}
+
+	if _, err := mail.ParseAddress(c.From); err != nil {
+		return fmt.Errorf("%w: invalid from address %q", ErrInvalidConfiguration, c.From)
+	}
+
+	if len(c.To) == 0 {
+		return fmt.Errorf("%w: at least one recipient is required", ErrInvalidConfiguration)
+	}
+	for _, to := range c.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return fmt.Errorf("%w: invalid recipient address %q", ErrInvalidConfiguration, to)
+		}
+	}
+
+	return nil
+}
+
+// Webhook payload formats.
+const (
+	WebhookFormatGeneric = "generic" // JSON run summary, or the rendered template
+	WebhookFormatSlack   = "slack"   // Slack incoming webhook message
+	WebhookFormatTeams   = "teams"   // Microsoft Teams incoming webhook card
+)
+
+// Run lifecycle events that can fire webhooks.
+const (
+	WebhookEventStarted   = "started"
+	WebhookEventCompleted = "completed"
+	WebhookEventFailed    = "failed"  // Failed or timed out
+	WebhookEventStopped   = "stopped" // Cancelled or force stopped
+
+	// A completed run deviates from the trend of its configuration
+	// by more than HistoryConfig.TrendAlertPercent
+	WebhookEventTrendAlert = "trend_alert"
+)
+
+// WebhookEvents lists all events in order.
+var WebhookEvents = []string{WebhookEventStarted, WebhookEventCompleted, WebhookEventFailed, WebhookEventStopped, WebhookEventTrendAlert}
+
+// WebhookConfig represents a webhook fired on run lifecycle events.
+type WebhookConfig struct {
+	// Name identifies the webhook.
+	Name string `json:"name"`
+
+	// Enabled turns the webhook on.
+	Enabled bool `json:"enabled"`
+
+	// URL is the HTTP(S) endpoint the payload is posted to.
+	URL string `json:"url"`
+
+	// Format is the payload format: generic, slack or teams.
+	Format string `json:"format"`
+
+	// Template is an optional Go text/template. For generic webhooks it renders
+	// the whole request body; for Slack and Teams it renders the message text.
+	Template string `json:"template,omitempty"`
+
+	// Events lists the run lifecycle events that fire the webhook.
+	Events []string `json:"events"`
+}
+
+// Validate validates the webhook configuration.
+func (c *WebhookConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("%w: webhook name is required", ErrInvalidConfiguration)
+	}
+
+	u, err := url.Parse(c.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: webhook %s: url must be an http(s) URL", ErrInvalidConfiguration, c.Name)
+	}
+
+	switch c.Format {
+	case WebhookFormatGeneric, WebhookFormatSlack, WebhookFormatTeams:
+	default:
+		return fmt.Errorf("%w: webhook %s: invalid format: %s", ErrInvalidConfiguration, c.Name, c.Format)
+	}
+
+	if c.Template != "" {
+		if _, err := template.New(c.Name).Parse(c.Template); err != nil {
+			return fmt.Errorf("%w: webhook %s: invalid template: %v", ErrInvalidConfiguration, c.Name, err)
+		}
+	}
+
+	if len(c.Events) == 0 {
+		return fmt.Errorf("%w: webhook %s: at least one event is required", ErrInvalidConfiguration, c.Name)
+	}
+	for _, event := range c.Events {
+		if !slices.Contains(WebhookEvents, event) {
+			return fmt.Errorf("%w: webhook %s: invalid event: %s", ErrInvalidConfiguration, c.Name, event)
+		}
+	}
+
+	return nil
+}
+
+// Fires checks if the webhook is enabled and fires on event.
+func (c *WebhookConfig) Fires(event string) bool {
+	return c.Enabled && slices.Contains(c.Events, event)
+}
+
+// DefaultAgentPort is the port load-generator agents listen on by default.
+const DefaultAgentPort = 7422
+
+// AgentConfig represents a load-generator agent that runs benchmark tools
+// on another machine, e.g. one next to the database.
+type AgentConfig struct {
+	// Name identifies the agent.
+	Name string `json:"name"`
+
+	// Address is the host:port the agent listens on.
+	Address string `json:"address"`
+
+	// HostKey is the SHA256 fingerprint of the agent's host key, as printed
+	// by the agent on startup. Connections to other keys are refused.
+	HostKey string `json:"host_key"`
+}
+
+// Validate validates the agent configuration.
+func (c *AgentConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("%w: agent name is required", ErrInvalidConfiguration)
+	}
+	host, port, err := net.SplitHostPort(c.Address)
+	if err != nil || host == "" || port == "" {
+		return fmt.Errorf("%w: agent %s: address must be host:port", ErrInvalidConfiguration, c.Name)
+	}
+	if !strings.HasPrefix(c.HostKey, "SHA256:") {
+		return fmt.Errorf("%w: agent %s: host key must be a SHA256:... fingerprint", ErrInvalidConfiguration, c.Name)
+	}
+	return nil
+}
+
+// Default task parameters, used for connections without saved defaults.
+const (
+	DefaultTaskThreads  = 1
+	DefaultTaskDuration = 60
+	DefaultTaskDBName   = "sbtest"
+)
+
+// TaskDefaults are the task parameters last used with a connection, pre-filled
+// on the Task page when the connection is selected.
+type TaskDefaults struct {
+	// Threads is the number of client threads.
+	Threads int `json:"threads"`
+
+	// Duration is the run duration in seconds.
+	Duration int `json:"duration"`
+
+	// DBName is the benchmark database name.
+	DBName string `json:"db_name"`
+}
+
+// DefaultTaskDefaults returns the task parameters of connections without saved defaults.
+func DefaultTaskDefaults() TaskDefaults {
+	return TaskDefaults{Threads: DefaultTaskThreads, Duration: DefaultTaskDuration, DBName: DefaultTaskDBName}
+}
+
+// Validate validates the task defaults.
+func (c *TaskDefaults) Validate() error {
+	if c.Threads < 1 {
+		return fmt.Errorf("%w: threads must be at least 1", ErrInvalidConfiguration)
+	}
+	if c.Duration < 1 {
+		return fmt.Errorf("%w: duration must be at least 1 second", ErrInvalidConfiguration)
+	}
+	return nil
+}
+
+// PasswordHash is a salted password hash. Like the keyring key file, it holds
+// the KDF parameters so they can change between releases.
+type PasswordHash struct {
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+	Hash    []byte `json:"hash"`
+}
+
+// AppLockConfig holds the passwords that unlock the app as the admin or the
+// operator role. The app is not locked while no admin password is set.
+type AppLockConfig struct {
+	AdminPassword    *PasswordHash `json:"admin_password,omitempty"`
+	OperatorPassword *PasswordHash `json:"operator_password,omitempty"`
+}
+
+// Enabled reports whether the app is locked at startup.
+func (c *AppLockConfig) Enabled() bool {
+	return c.AdminPassword != nil
+}
+
+// Validate validates the app lock configuration.
+func (c *AppLockConfig) Validate() error {
+	if c.OperatorPassword != nil && c.AdminPassword == nil {
+		return fmt.Errorf("%w: an operator password needs an admin password", ErrInvalidConfiguration)
+	}
+	return nil
+}
+
+// TaskPreset is a named Task Configuration, so recurring test setups can be
+// selected on the Task page instead of re-entering their values.
+type TaskPreset struct {
+	// Name identifies the preset.
+	Name string `json:"name"`
+
+	// ConnectionID is the connection the task runs against.
+	ConnectionID string `json:"connection_id"`
+
+	// TemplateID is the template of the task; empty means none.
+	TemplateID string `json:"template_id,omitempty"`
+
+	// Threads is the number of client threads.
+	Threads int `json:"threads"`
+
+	// Duration is the run duration in seconds.
+	Duration int `json:"duration"`
+
+	// Warmup is the warmup in seconds before the measured window.
+	Warmup int `json:"warmup,omitempty"`
+
+	// DBName is the benchmark database name.
+	DBName string `json:"db_name"`
+
+	// RateProfile varies the target rate of the run; nil means a fixed rate.
+	RateProfile *execution.RateProfile `json:"rate_profile,omitempty"`
+
+	// SampleInterval is the sample interval in seconds; 0 means adaptive.
+	SampleInterval int `json:"sample_interval,omitempty"`
+
+	// Repeat is the number of times the run phase is repeated; 0 means once.
+	Repeat int `json:"repeat,omitempty"`
+
+	// OutlierSigma flags repeated runs outside mean ± k·σ; 0 means the default.
+	OutlierSigma float64 `json:"outlier_sigma,omitempty"`
+
+	// Remote runs the tool on the database host via WinRM.
+	Remote bool `json:"remote,omitempty"`
+
+	// Agents are the load-generator agents the task runs on; empty means this machine.
+	Agents []string `json:"agents,omitempty"`
+
+	// KeepArtifacts keeps the work directory of each run.
+	KeepArtifacts bool `json:"keep_artifacts,omitempty"`
+
+	// MonitorReplicas polls the replica lag of the connection's replicas during each run.
+	MonitorReplicas bool `json:"monitor_replicas,omitempty"`
+
+	// CollectServerLog saves the database error log written during each run to its artifacts.
+	CollectServerLog bool `json:"collect_server_log,omitempty"`
+
+	// Assertions are the SLA targets of each run, overriding the template's.
+	Assertions assertion.Targets `json:"assertions,omitzero"`
+}
+
+// Validate validates the task preset.
+func (c *TaskPreset) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return fmt.Errorf("%w: preset name is required", ErrInvalidConfiguration)
+	}
+	if c.ConnectionID == "" {
+		return fmt.Errorf("%w: preset %s: connection is required", ErrInvalidConfiguration, c.Name)
+	}
+	if c.Threads < 1 || c.Duration < 1 || c.Warmup < 0 {
+		return fmt.Errorf("%w: preset %s: threads and duration must be at least 1, warmup at least 0", ErrInvalidConfiguration, c.Name)
+	}
+	if c.Repeat < 0 || c.Repeat > execution.MaxRepeat {
+		return fmt.Errorf("%w: preset %s: repeat must be between 0 and %d", ErrInvalidConfiguration, c.Name, execution.MaxRepeat)
+	}
+	if c.RateProfile != nil {
+		if err := c.RateProfile.Validate(); err != nil {
+			return fmt.Errorf("%w: preset %s: %v", ErrInvalidConfiguration, c.Name, err)
+		}
+	}
+	if err := c.Assertions.Validate(); err != nil {
+		return fmt.Errorf("%w: preset %s: %v", ErrInvalidConfiguration, c.Name, err)
+	}
+	return nil
+}
+
+// Log formats of AdvancedConfig.LogFormat.
+const (
+	LogFormatText = "text" // key=value lines (default)
+	LogFormatJSON = "json" // One JSON object per line
+)
+
+// LogModules are the modules that can log at their own level, named after
+// the packages they cover (internal/transport/ui, internal/app/usecase and
+// internal/infra/adapter).
+var LogModules = []string{"ui", "usecase", "adapter"}
+
+// validLogLevels are the logging levels of the settings.
+var validLogLevels = map[string]bool{
+	"debug": true,
+	"info":  true,
+	"warn":  true,
+	"error": true,
+}
+
 // AdvancedConfig represents advanced configuration.
 type AdvancedConfig struct {
 	// LogLevel is the logging level (debug, info, warn, error).
 	LogLevel string `json:"log_level"`
 
-	// MaxLogFiles is the maximum number of log files to keep.
+	// LogFormat is the format of the log output (text or json; empty is text).
+	LogFormat string `json:"log_format,omitempty"`
+
+	// ModuleLogLevels maps modules of LogModules to their own logging level,
+	// overriding LogLevel.
+	ModuleLogLevels map[string]string `json:"module_log_levels,omitempty"`
+
+	// MaxLogFiles is the maximum number of log files to keep per program
+	// (GUI and CLI), including the current one. 0 keeps all.
 	MaxLogFiles int `json:"max_log_files"`
 
+	// MaxLogSizeMB is the size in MB at which the current log file is rotated
+	// and compressed. 0 rotates daily only.
+	MaxLogSizeMB int `json:"max_log_size_mb"`
+
+	// MaxLogAgeDays is the age in days after which rotated log files are
+	// deleted. 0 keeps them.
+	MaxLogAgeDays int `json:"max_log_age_days"`
+
 	// EnableTelemetry enables anonymous usage telemetry.
 	EnableTelemetry bool `json:"enable_telemetry"`
 
 	// CheckUpdates enables automatic update checks.
 	CheckUpdates bool `json:"check_updates"`
 
+	// DemoMode simulates runs with synthetic samples instead of running
+	// benchmark tools, so the GUI can be tried without a database.
+	DemoMode bool `json:"demo_mode"`
+
 	// WorkDir is the working directory for benchmark execution.
 	WorkDir string `json:"work_dir"`
 
@@ -247,29 +872,53 @@ type AdvancedConfig struct {
 
 // Validate validates the advanced configuration.
 func (c *AdvancedConfig) Validate() error {
-	validLevels := map[string]bool{
-		"debug": true,
-		"info":  true,
-		"warn":  true,
-		"error": true,
+	if err := c.ValidateLogging(); err != nil {
+		return err
+	}
+
+	if c.WorkDir != "" {
+		if !filepath.IsAbs(c.WorkDir) {
+			return fmt.Errorf("%w: work_dir must be an absolute path", ErrInvalidConfiguration)
+		}
+	}
+
+	if c.Timeout < 1 || c.Timeout > 1440 {
+		return fmt.Errorf("%w: timeout must be between 1 and 1440 minutes", ErrInvalidConfiguration)
 	}
 
-	if !validLevels[c.LogLevel] {
+	return nil
+}
+
+// ValidateLogging validates the log level, format, module levels and log
+// file limits.
+func (c *AdvancedConfig) ValidateLogging() error {
+	if !validLogLevels[c.LogLevel] {
 		return fmt.Errorf("%w: invalid log level: %s", ErrInvalidConfiguration, c.LogLevel)
 	}
 
+	if c.LogFormat != "" && c.LogFormat != LogFormatText && c.LogFormat != LogFormatJSON {
+		return fmt.Errorf("%w: invalid log format: %s", ErrInvalidConfiguration, c.LogFormat)
+	}
+
+	for module, level := range c.ModuleLogLevels {
+		if !slices.Contains(LogModules, module) {
+			return fmt.Errorf("%w: unknown log module: %s", ErrInvalidConfiguration, module)
+		}
+		if !validLogLevels[level] {
+			return fmt.Errorf("%w: invalid log level of module %s: %s", ErrInvalidConfiguration, module, level)
+		}
+	}
+
 	if c.MaxLogFiles < 0 || c.MaxLogFiles > 100 {
 		return fmt.Errorf("%w: max_log_files must be between 0 and 100", ErrInvalidConfiguration)
 	}
 
-	if c.WorkDir != "" {
-		if !filepath.IsAbs(c.WorkDir) {
-			return fmt.Errorf("%w: work_dir must be an absolute path", ErrInvalidConfiguration)
-		}
+	if c.MaxLogSizeMB < 0 || c.MaxLogSizeMB > 1024 {
+		return fmt.Errorf("%w: max_log_size_mb must be between 0 and 1024", ErrInvalidConfiguration)
 	}
 
-	if c.Timeout < 1 || c.Timeout > 1440 {
-		return fmt.Errorf("%w: timeout must be between 1 and 1440 minutes", ErrInvalidConfiguration)
+	if c.MaxLogAgeDays < 0 || c.MaxLogAgeDays > 3650 {
+		return fmt.Errorf("%w: max_log_age_days must be between 0 and 3650", ErrInvalidConfiguration)
 	}
 
 	return nil
@@ -294,6 +943,33 @@ type Config struct {
 
 	// Advanced is the advanced configuration.
 	Advanced AdvancedConfig `json:"advanced"`
+
+	// History is the history retention configuration.
+	History HistoryConfig `json:"history"`
+
+	// Export is the export configuration.
+	Export ExportConfig `json:"export"`
+
+	// Notifications is the email notification configuration.
+	Notifications NotificationConfig `json:"notifications"`
+
+	// Webhooks are fired on run lifecycle events.
+	Webhooks []WebhookConfig `json:"webhooks"`
+
+	// SanityChecks are evaluated on every run saved to history.
+	SanityChecks []history.SanityCheck `json:"sanity_checks"`
+
+	// Agents are the load-generator agents runs can be dispatched to.
+	Agents []AgentConfig `json:"agents,omitempty"`
+
+	// TaskDefaults maps connection IDs to the task parameters last used with them.
+	TaskDefaults map[string]TaskDefaults `json:"task_defaults,omitempty"`
+
+	// TaskPresets are the named Task Configurations of the Task page.
+	TaskPresets []TaskPreset `json:"task_presets,omitempty"`
+
+	// AppLock is the app lock and the passwords of its roles.
+	AppLock AppLockConfig `json:"app_lock"`
 }
 
 // Validate validates the complete configuration.
@@ -328,6 +1004,72 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("advanced: %w", err)
 	}
 
+	if err := c.History.Validate(); err != nil {
+		return fmt.Errorf("history: %w", err)
+	}
+
+	if err := c.Export.Validate(); err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+
+	if err := c.Notifications.Validate(); err != nil {
+		return fmt.Errorf("notifications: %w", err)
+	}
+
+	names := make(map[string]bool)
+	for i := range c.Webhooks {
+		if err := c.Webhooks[i].Validate(); err != nil {
+			return fmt.Errorf("webhooks: %w", err)
+		}
+		if names[c.Webhooks[i].Name] {
+			return fmt.Errorf("%w: webhooks: duplicate webhook name: %s", ErrInvalidConfiguration, c.Webhooks[i].Name)
+		}
+		names[c.Webhooks[i].Name] = true
+	}
+
+	agents := make(map[string]bool)
+	for i := range c.Agents {
+		if err := c.Agents[i].Validate(); err != nil {
+			return fmt.Errorf("agents: %w", err)
+		}
+		if agents[c.Agents[i].Name] {
+			return fmt.Errorf("%w: agents: duplicate agent name: %s", ErrInvalidConfiguration, c.Agents[i].Name)
+		}
+		agents[c.Agents[i].Name] = true
+	}
+
+	checks := make(map[string]bool)
+	for i := range c.SanityChecks {
+		if err := c.SanityChecks[i].Validate(); err != nil {
+			return fmt.Errorf("%w: sanity checks: %v", ErrInvalidConfiguration, err)
+		}
+		if checks[c.SanityChecks[i].Name] {
+			return fmt.Errorf("%w: sanity checks: duplicate check name: %s", ErrInvalidConfiguration, c.SanityChecks[i].Name)
+		}
+		checks[c.SanityChecks[i].Name] = true
+	}
+
+	for connID, defaults := range c.TaskDefaults {
+		if err := defaults.Validate(); err != nil {
+			return fmt.Errorf("task defaults %s: %w", connID, err)
+		}
+	}
+
+	presets := make(map[string]bool)
+	for i := range c.TaskPresets {
+		if err := c.TaskPresets[i].Validate(); err != nil {
+			return fmt.Errorf("task presets: %w", err)
+		}
+		if presets[c.TaskPresets[i].Name] {
+			return fmt.Errorf("%w: task presets: duplicate preset name: %s", ErrInvalidConfiguration, c.TaskPresets[i].Name)
+		}
+		presets[c.TaskPresets[i].Name] = true
+	}
+
+	if err := c.AppLock.Validate(); err != nil {
+		return fmt.Errorf("app lock: %w", err)
+	}
+
 	return nil
 }
 
@@ -338,6 +1080,7 @@ func DefaultConfig() *Config {
 	defaultDBPath := filepath.Join(userHomeDir, ".db-benchmind", "benchmarks.db")
 	defaultWorkDir := filepath.Join(os.TempDir(), "db-benchmind")
 	defaultOutputDir := filepath.Join(userHomeDir, ".db-benchmind", "reports")
+	defaultArchiveDir := filepath.Join(userHomeDir, ".db-benchmind", "archive")
 
 	return &Config{
 		Version: 1,
@@ -373,7 +1116,7 @@ func DefaultConfig() *Config {
 			OutputDir:     defaultOutputDir,
 		},
 		UI: UIConfig{
-			Theme:           "auto",
+			Theme:           ThemeAuto,
 			Language:        "en",
 			AutoSave:        true,
 			RefreshInterval: 5,
@@ -381,11 +1124,28 @@ func DefaultConfig() *Config {
 		Advanced: AdvancedConfig{
 			LogLevel:        "info",
 			MaxLogFiles:     10,
+			MaxLogSizeMB:    10,
+			MaxLogAgeDays:   30,
 			EnableTelemetry: false,
 			CheckUpdates:    true,
 			WorkDir:         defaultWorkDir,
 			Timeout:         60, // 1 hour
 		},
+		History: HistoryConfig{
+			MaxAgeDays:         0, // Keep forever
+			MaxRecords:         0, // Unlimited
+			ArchiveBeforePurge: true,
+			ArchiveDir:         defaultArchiveDir,
+			PurgeIntervalHours: 24,
+		},
+		Notifications: NotificationConfig{
+			Enabled:      false,
+			SMTPPort:     587,
+			Security:     SMTPSecurityStartTLS,
+			OnSuccess:    true,
+			OnFailure:    true,
+			AttachReport: true,
+		},
 	}
 }
 
@@ -2,9 +2,13 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
 )
 
 // TestToolType_Validate tests tool type validation.
@@ -17,6 +21,8 @@ func TestToolType_Validate(t *testing.T) {
 		{"valid sysbench", ToolTypeSysbench, false},
 		{"valid swingbench", ToolTypeSwingbench, false},
 		{"valid hammerdb", ToolTypeHammerDB, false},
+		{"valid psql", ToolTypePsql, false},
+		{"valid mysql client", ToolTypeMySQL, false},
 		{"invalid tool", ToolType("invalid"), true},
 	}
 
@@ -29,6 +35,59 @@ func TestToolType_Validate(t *testing.T) {
 	}
 }
 
+// TestToolType_CheckVersion tests the minimum supported tool versions.
+func TestToolType_CheckVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		tool    ToolType
+		version string
+		wantErr bool
+	}{
+		{"sysbench 1.0.20", ToolTypeSysbench, "1.0.20", false},
+		{"sysbench 0.5", ToolTypeSysbench, "0.5", true},
+		{"hammerdb 4.6", ToolTypeHammerDB, "4.6", false},
+		{"hammerdb 3.3", ToolTypeHammerDB, "3.3", true},
+		{"psql 16.2", ToolTypePsql, "16.2", false},
+		{"psql 9.4", ToolTypePsql, "9.4.26", true},
+		{"mysql 8.0.36", ToolTypeMySQL, "8.0.36", false},
+		{"mariadb client", ToolTypeMySQL, "10.6.12-MariaDB", false},
+		{"unknown version", ToolTypeSysbench, "", true},
+		{"swingbench is not checked", ToolTypeSwingbench, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tool.CheckVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckVersion(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrToolVersionTooOld) {
+				t.Errorf("CheckVersion(%q) error = %v, want ErrToolVersionTooOld", tt.version, err)
+			}
+		})
+	}
+}
+
+// TestCompareVersions tests numeric version comparison.
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.20", "1.0", 1},
+		{"1.0", "1.0.0", 0},
+		{"9.6", "10", -1},
+		{"10.6.12-MariaDB", "10.6.12", 0},
+		{"4.10", "4.9", 1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
 // TestToolConfig_Validate tests tool configuration validation.
 func TestToolConfig_Validate(t *testing.T) {
 	tests := []struct {
@@ -244,6 +303,15 @@ func TestUIConfig_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "chinese",
+			config: UIConfig{
+				Theme:           "auto",
+				Language:        "zh",
+				RefreshInterval: 5,
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid theme",
 			config: UIConfig{
@@ -251,6 +319,51 @@ func TestUIConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "invalid language",
+			config: UIConfig{
+				Theme:           "auto",
+				Language:        "fr",
+				RefreshInterval: 5,
+			},
+			wantErr: true,
+		},
+		{
+			name: "font scale",
+			config: UIConfig{
+				Theme:           "dark",
+				FontScale:       1.25,
+				RefreshInterval: 5,
+			},
+			wantErr: false,
+		},
+		{
+			name: "font scale too large",
+			config: UIConfig{
+				Theme:           "dark",
+				FontScale:       3,
+				RefreshInterval: 5,
+			},
+			wantErr: true,
+		},
+		{
+			name: "monospace font not a font file",
+			config: UIConfig{
+				Theme:           "light",
+				MonospaceFont:   "/etc/hostname",
+				RefreshInterval: 5,
+			},
+			wantErr: true,
+		},
+		{
+			name: "monospace font missing",
+			config: UIConfig{
+				Theme:           "light",
+				MonospaceFont:   "/nonexistent/mono.ttf",
+				RefreshInterval: 5,
+			},
+			wantErr: true,
+		},
 		{
 			name: "refresh_interval too small",
 			config: UIConfig{
@@ -310,6 +423,24 @@ func TestAdvancedConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative max_log_size_mb",
+			config: AdvancedConfig{
+				LogLevel:     "info",
+				MaxLogSizeMB: -1,
+				Timeout:      60,
+			},
+			wantErr: true,
+		},
+		{
+			name: "max_log_age_days too large",
+			config: AdvancedConfig{
+				LogLevel:      "info",
+				MaxLogAgeDays: 5000,
+				Timeout:       60,
+			},
+			wantErr: true,
+		},
 		{
 			name: "timeout too small",
 			config: AdvancedConfig{
@@ -318,6 +449,43 @@ func TestAdvancedConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "json format with module levels",
+			config: AdvancedConfig{
+				LogLevel:        "info",
+				LogFormat:       LogFormatJSON,
+				ModuleLogLevels: map[string]string{"adapter": "debug", "ui": "warn"},
+				Timeout:         60,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid log format",
+			config: AdvancedConfig{
+				LogLevel:  "info",
+				LogFormat: "xml",
+				Timeout:   60,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown log module",
+			config: AdvancedConfig{
+				LogLevel:        "info",
+				ModuleLogLevels: map[string]string{"grpc": "debug"},
+				Timeout:         60,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid module log level",
+			config: AdvancedConfig{
+				LogLevel:        "info",
+				ModuleLogLevels: map[string]string{"usecase": "trace"},
+				Timeout:         60,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -329,6 +497,283 @@ func TestAdvancedConfig_Validate(t *testing.T) {
 	}
 }
 
+// TestHistoryConfig_Validate tests history retention configuration validation.
+func TestHistoryConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  HistoryConfig
+		wantErr bool
+	}{
+		{
+			name:    "keep forever",
+			config:  HistoryConfig{},
+			wantErr: false,
+		},
+		{
+			name: "valid limits with archive",
+			config: HistoryConfig{
+				MaxAgeDays:         90,
+				MaxRecords:         1000,
+				ArchiveBeforePurge: true,
+				ArchiveDir:         "/tmp/archive",
+				PurgeIntervalHours: 24,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "negative max age",
+			config:  HistoryConfig{MaxAgeDays: -1},
+			wantErr: true,
+		},
+		{
+			name:    "archive without directory",
+			config:  HistoryConfig{MaxAgeDays: 90, ArchiveBeforePurge: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("HistoryConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestExportConfig_Validate tests export configuration validation.
+func TestExportConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ExportConfig
+		wantErr bool
+	}{
+		{
+			name:    "defaults",
+			config:  ExportConfig{},
+			wantErr: false,
+		},
+		{
+			name:    "directory and file name",
+			config:  ExportConfig{Dir: "/data/exports", FileName: "{connection}_{template}_{date}"},
+			wantErr: false,
+		},
+		{
+			name:    "relative directory",
+			config:  ExportConfig{Dir: "exports"},
+			wantErr: true,
+		},
+		{
+			name:    "path separator in file name",
+			config:  ExportConfig{FileName: "{date}/{template}"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown placeholder",
+			config:  ExportConfig{FileName: "{host}_{date}"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ExportConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestNotificationConfig_Validate tests notification configuration validation.
+func TestNotificationConfig_Validate(t *testing.T) {
+	valid := NotificationConfig{
+		Enabled:  true,
+		SMTPHost: "smtp.example.com",
+		SMTPPort: 587,
+		Security: SMTPSecurityStartTLS,
+		From:     "DB-BenchMind <bench@example.com>",
+		To:       []string{"dba@example.com"},
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *NotificationConfig)
+		wantErr bool
+	}{
+		{"valid", func(c *NotificationConfig) {}, false},
+		{"disabled skips checks", func(c *NotificationConfig) { c.Enabled = false; c.SMTPHost = "" }, false},
+		{"missing host", func(c *NotificationConfig) { c.SMTPHost = "" }, true},
+		{"invalid port", func(c *NotificationConfig) { c.SMTPPort = 0 }, true},
+		{"invalid security", func(c *NotificationConfig) { c.Security = "ssl" }, true},
+		{"invalid from", func(c *NotificationConfig) { c.From = "not an address" }, true},
+		{"no recipients", func(c *NotificationConfig) { c.To = nil }, true},
+		{"invalid recipient", func(c *NotificationConfig) { c.To = []string{"dba@example.com", "oops"} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("NotificationConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestWebhookConfig_Validate tests webhook configuration validation.
+func TestWebhookConfig_Validate(t *testing.T) {
+	valid := WebhookConfig{
+		Name:    "team-channel",
+		Enabled: true,
+		URL:     "https://hooks.slack.com/services/T000/B000/XXXX",
+		Format:  WebhookFormatSlack,
+		Events:  []string{WebhookEventCompleted, WebhookEventFailed},
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *WebhookConfig)
+		wantErr bool
+	}{
+		{"valid", func(c *WebhookConfig) {}, false},
+		{"valid template", func(c *WebhookConfig) {
+			c.Template = "{{.Template}} {{.Event}}{{with .Metrics}}: {{printf \"%.1f\" .TPS}} TPS{{end}}"
+		}, false},
+		{"missing name", func(c *WebhookConfig) { c.Name = "" }, true},
+		{"missing url", func(c *WebhookConfig) { c.URL = "" }, true},
+		{"non-http url", func(c *WebhookConfig) { c.URL = "ftp://example.com/hook" }, true},
+		{"invalid format", func(c *WebhookConfig) { c.Format = "discord" }, true},
+		{"invalid template", func(c *WebhookConfig) { c.Template = "{{.Template" }, true},
+		{"no events", func(c *WebhookConfig) { c.Events = nil }, true},
+		{"invalid event", func(c *WebhookConfig) { c.Events = []string{"paused"} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("WebhookConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if !valid.Fires(WebhookEventFailed) || valid.Fires(WebhookEventStarted) {
+		t.Errorf("Fires() does not match the configured events")
+	}
+	disabled := valid
+	disabled.Enabled = false
+	if disabled.Fires(WebhookEventFailed) {
+		t.Errorf("Fires() = true for a disabled webhook")
+	}
+}
+
+// TestAgentConfig_Validate tests load-generator agent validation.
+func TestAgentConfig_Validate(t *testing.T) {
+	valid := AgentConfig{
+		Name:    "loadgen-1",
+		Address: "10.0.0.20:7422",
+		HostKey: "SHA256:2sVfvLQx3Vbq3jfhEd3GSH3Zt7u0Yy0m3qHnSxQ3u9A",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *AgentConfig)
+		wantErr bool
+	}{
+		{"valid", func(c *AgentConfig) {}, false},
+		{"ipv6 address", func(c *AgentConfig) { c.Address = "[fd00::20]:7422" }, false},
+		{"missing name", func(c *AgentConfig) { c.Name = "" }, true},
+		{"missing port", func(c *AgentConfig) { c.Address = "10.0.0.20" }, true},
+		{"missing host", func(c *AgentConfig) { c.Address = ":7422" }, true},
+		{"missing host key", func(c *AgentConfig) { c.HostKey = "" }, true},
+		{"md5 host key", func(c *AgentConfig) { c.HostKey = "MD5:aa:bb" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("AgentConfig.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	cfg := DefaultConfig()
+	cfg.Agents = []AgentConfig{valid, valid}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Config.Validate() accepted duplicate agent names")
+	}
+}
+
+// TestTaskDefaults_Validate tests task defaults validation.
+func TestTaskDefaults_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults TaskDefaults
+		wantErr  bool
+	}{
+		{"defaults", DefaultTaskDefaults(), false},
+		{"empty db name", TaskDefaults{Threads: 8, Duration: 300}, false},
+		{"zero threads", TaskDefaults{Threads: 0, Duration: 60}, true},
+		{"zero duration", TaskDefaults{Threads: 1, Duration: 0}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.defaults.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("TaskDefaults.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	cfg := DefaultConfig()
+	cfg.TaskDefaults = map[string]TaskDefaults{"conn-1": {Threads: -1, Duration: 60}}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Config.Validate() accepted invalid task defaults")
+	}
+}
+
+// TestTaskPreset_Validate tests task preset validation.
+func TestTaskPreset_Validate(t *testing.T) {
+	valid := TaskPreset{Name: "nightly", ConnectionID: "conn-1", Threads: 8, Duration: 600, DBName: "sbtest"}
+
+	tests := []struct {
+		name    string
+		modify  func(c *TaskPreset)
+		wantErr bool
+	}{
+		{"valid", func(c *TaskPreset) {}, false},
+		{"staircase", func(c *TaskPreset) {
+			c.RateProfile = &execution.RateProfile{Kind: execution.RateProfileStaircase, StartRate: 100, StepRate: 100, StepTime: 60}
+		}, false},
+		{"missing name", func(c *TaskPreset) { c.Name = " " }, true},
+		{"missing connection", func(c *TaskPreset) { c.ConnectionID = "" }, true},
+		{"zero threads", func(c *TaskPreset) { c.Threads = 0 }, true},
+		{"negative warmup", func(c *TaskPreset) { c.Warmup = -1 }, true},
+		{"repeat too large", func(c *TaskPreset) { c.Repeat = execution.MaxRepeat + 1 }, true},
+		{"invalid rate profile", func(c *TaskPreset) { c.RateProfile = &execution.RateProfile{Kind: "sine"} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("TaskPreset.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	cfg := DefaultConfig()
+	cfg.TaskPresets = []TaskPreset{valid, valid}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Config.Validate() accepted duplicate preset names")
+	}
+}
+
 // TestConfig_Validate tests complete configuration validation.
 func TestConfig_Validate(t *testing.T) {
 	tests := []struct {
@@ -378,6 +823,27 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "duplicate sanity check name",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.SanityChecks = []history.SanityCheck{
+					{Name: "errors", Kind: history.CheckMaxErrorRate, Threshold: 1},
+					{Name: "errors", Kind: history.CheckMinDuration, Threshold: 60},
+				}
+				return c
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "unknown sanity check kind",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.SanityChecks = []history.SanityCheck{{Name: "qps", Kind: "min_qps", Threshold: 1}}
+				return c
+			}(),
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -501,3 +967,28 @@ func TestConfig_IsToolEnabled(t *testing.T) {
 		t.Error("Swingbench should be disabled by default")
 	}
 }
+
+func TestAppLockConfig_Validate(t *testing.T) {
+	hash := &PasswordHash{KDF: "argon2id", Salt: []byte("salt"), Hash: []byte("hash")}
+	tests := []struct {
+		name    string
+		lock    AppLockConfig
+		enabled bool
+		wantErr bool
+	}{
+		{"disabled", AppLockConfig{}, false, false},
+		{"admin only", AppLockConfig{AdminPassword: hash}, true, false},
+		{"admin and operator", AppLockConfig{AdminPassword: hash, OperatorPassword: hash}, true, false},
+		{"operator without admin", AppLockConfig{OperatorPassword: hash}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.lock.Enabled(); got != tt.enabled {
+				t.Errorf("Enabled() = %v, want %v", got, tt.enabled)
+			}
+			if err := tt.lock.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
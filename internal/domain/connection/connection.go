@@ -4,6 +4,8 @@ package connection
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -53,6 +55,38 @@ type Connection interface {
 
 	// ToJSON serializes the connection to JSON (without password).
 	ToJSON() ([]byte, error)
+
+	// GetDataset returns the benchmark data set last prepared on the connection.
+	GetDataset() *DatasetFingerprint
+
+	// SetDataset records the prepared benchmark data set, nil after a cleanup.
+	SetDataset(dataset *DatasetFingerprint)
+
+	// IsProtected reports whether the prepare and cleanup phases are blocked
+	// on the connection, e.g. because it points at a production schema.
+	IsProtected() bool
+
+	// SetProtected sets whether the prepare and cleanup phases are blocked.
+	SetProtected(protected bool)
+
+	// IsObserver reports whether the connection is observe-only, with every
+	// benchmark phase blocked.
+	IsObserver() bool
+
+	// SetObserver sets whether the connection is observe-only.
+	SetObserver(observer bool)
+
+	// GetGroup returns the user-defined group of the connection, "" if ungrouped.
+	GetGroup() string
+
+	// SetGroup sets the group of the connection, "" to ungroup it.
+	SetGroup(group string)
+
+	// IsFavorite reports whether the connection is starred as a favorite.
+	IsFavorite() bool
+
+	// SetFavorite sets whether the connection is a favorite.
+	SetFavorite(favorite bool)
 }
 
 // TestResult represents the result of a connection test.
@@ -114,6 +148,21 @@ type BaseConnection struct {
 	Name      string    `json:"name"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Benchmark data set last prepared on the connection, nil if none is recorded
+	Dataset *DatasetFingerprint `json:"dataset,omitempty"`
+
+	// Protected blocks the prepare and cleanup phases, which create and drop tables
+	Protected bool `json:"protected,omitempty"`
+
+	// Observer blocks every benchmark phase; tests and configuration snapshots remain available
+	Observer bool `json:"observer,omitempty"`
+
+	// Group is a user-defined folder such as "Prod EU" for organizing the connection list
+	Group string `json:"group,omitempty"`
+
+	// Favorite lists the connection first
+	Favorite bool `json:"favorite,omitempty"`
 }
 
 // GetID returns the connection ID.
@@ -131,3 +180,87 @@ func (b *BaseConnection) SetName(name string) {
 	b.Name = name
 	b.UpdatedAt = time.Now()
 }
+
+// GetDataset returns the benchmark data set last prepared on the connection.
+func (b *BaseConnection) GetDataset() *DatasetFingerprint {
+	return b.Dataset
+}
+
+// SetDataset records the prepared benchmark data set, nil after a cleanup.
+func (b *BaseConnection) SetDataset(dataset *DatasetFingerprint) {
+	b.Dataset = dataset
+}
+
+// IsProtected reports whether the prepare and cleanup phases are blocked.
+func (b *BaseConnection) IsProtected() bool {
+	return b.Protected
+}
+
+// SetProtected sets whether the prepare and cleanup phases are blocked.
+func (b *BaseConnection) SetProtected(protected bool) {
+	b.Protected = protected
+}
+
+// IsObserver reports whether the connection is observe-only.
+func (b *BaseConnection) IsObserver() bool {
+	return b.Observer
+}
+
+// SetObserver sets whether the connection is observe-only.
+func (b *BaseConnection) SetObserver(observer bool) {
+	b.Observer = observer
+}
+
+// GetGroup returns the user-defined group of the connection.
+func (b *BaseConnection) GetGroup() string {
+	return b.Group
+}
+
+// SetGroup sets the group of the connection, trimming surrounding spaces.
+func (b *BaseConnection) SetGroup(group string) {
+	b.Group = strings.TrimSpace(group)
+}
+
+// IsFavorite reports whether the connection is a favorite.
+func (b *BaseConnection) IsFavorite() bool {
+	return b.Favorite
+}
+
+// SetFavorite sets whether the connection is a favorite.
+func (b *BaseConnection) SetFavorite(favorite bool) {
+	b.Favorite = favorite
+}
+
+// DatasetFingerprint identifies a prepared benchmark data set, so that a run can
+// tell whether the tables it expects are the ones that were loaded.
+type DatasetFingerprint struct {
+	Tool       string    `json:"tool"`        // Tool that prepared the data, e.g. sysbench
+	Script     string    `json:"script"`      // Workload script, e.g. oltp_read_write.lua
+	Database   string    `json:"database"`    // Database the tables were created in
+	Tables     int       `json:"tables"`      // Number of tables
+	TableSize  int       `json:"table_size"`  // Rows per table
+	PreparedAt time.Time `json:"prepared_at"` // When the prepare completed
+}
+
+// Diff returns the differences between the fingerprint and another data set,
+// e.g. "tables: 16 prepared, 32 requested". PreparedAt is not compared.
+// An empty result means both describe the same data set.
+func (f *DatasetFingerprint) Diff(requested *DatasetFingerprint) []string {
+	var diffs []string
+	add := func(field string, prepared, want interface{}) {
+		if prepared != want {
+			diffs = append(diffs, fmt.Sprintf("%s: %v prepared, %v requested", field, prepared, want))
+		}
+	}
+	add("tool", f.Tool, requested.Tool)
+	add("script", f.Script, requested.Script)
+	add("database", f.Database, requested.Database)
+	add("tables", f.Tables, requested.Tables)
+	add("table_size", f.TableSize, requested.TableSize)
+	return diffs
+}
+
+// Matches returns true if the fingerprint describes the requested data set.
+func (f *DatasetFingerprint) Matches(requested *DatasetFingerprint) bool {
+	return len(f.Diff(requested)) == 0
+}
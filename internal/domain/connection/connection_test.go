@@ -0,0 +1,39 @@
+// Package connection provides unit tests for the common connection types.
+package connection
+
+import (
+	"testing"
+)
+
+// TestDatasetFingerprint_Diff tests the comparison of prepared data sets.
+func TestDatasetFingerprint_Diff(t *testing.T) {
+	prepared := &DatasetFingerprint{
+		Tool:      "sysbench",
+		Script:    "oltp_read_write.lua",
+		Database:  "sbtest",
+		Tables:    16,
+		TableSize: 100000,
+	}
+
+	tests := []struct {
+		name      string
+		requested DatasetFingerprint
+		wantDiffs int
+	}{
+		{"same data set", *prepared, 0},
+		{"more tables", DatasetFingerprint{Tool: "sysbench", Script: "oltp_read_write.lua", Database: "sbtest", Tables: 32, TableSize: 100000}, 1},
+		{"other database and size", DatasetFingerprint{Tool: "sysbench", Script: "oltp_read_write.lua", Database: "bench", Tables: 16, TableSize: 1000}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs := prepared.Diff(&tt.requested)
+			if len(diffs) != tt.wantDiffs {
+				t.Errorf("Diff() = %v, want %d differences", diffs, tt.wantDiffs)
+			}
+			if got := prepared.Matches(&tt.requested); got != (tt.wantDiffs == 0) {
+				t.Errorf("Matches() = %v, want %v", got, tt.wantDiffs == 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+// Package connection provides connection string previews and checks.
+package connection
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// MaskedPassword replaces the password in connection strings shown to users.
+const MaskedPassword = "****"
+
+// urlUnsafeChars are characters that break the userinfo of URL connection
+// strings (go-ora EZConnect URLs, go-mssqldb) unless percent-encoded.
+const urlUnsafeChars = "\"#%/<>?[\\]^`{|}"
+
+// DSNWarning reports characters of a connection field that change or break
+// the connection string built from it, since they are not escaped.
+type DSNWarning struct {
+	Field  string // "username" or "password"
+	Chars  string // Offending characters, in order of first occurrence; whitespace as a space
+	Format string // Connection string format, e.g. "lib/pq key/value"
+}
+
+// String returns a description of the warning.
+func (w DSNWarning) String() string {
+	return fmt.Sprintf("%s contains %s, which must be escaped in %s connection strings", w.Field, quoteChars(w.Chars), w.Format)
+}
+
+// MaskedDSN returns the connection string used to connect, as generated by
+// GetDSNWithPassword plus the TLS parameter of MySQL, with the password
+// masked for display.
+func MaskedDSN(conn Connection) string {
+	switch c := conn.(type) {
+	case *MySQLConnection:
+		masked := *c
+		masked.Password = maskPassword(c.Password)
+		if tls, _ := c.tlsValue(); tls != "" {
+			return masked.GetDSNWithPassword() + "?tls=" + tls
+		}
+		return masked.GetDSNWithPassword()
+	case *PostgreSQLConnection:
+		masked := *c
+		masked.Password = maskPassword(c.Password)
+		return masked.GetDSNWithPassword()
+	case *OracleConnection:
+		masked := *c
+		masked.Password = maskPassword(c.Password)
+		return masked.GetDSNWithPassword()
+	case *SQLServerConnection:
+		masked := *c
+		masked.Password = maskPassword(c.Password)
+		if c.WinRM != nil {
+			winrm := *c.WinRM
+			winrm.Password = maskPassword(c.WinRM.Password)
+			masked.WinRM = &winrm
+		}
+		return masked.GetDSNWithPassword()
+	default:
+		return conn.GetDSN()
+	}
+}
+
+// maskPassword masks a password, keeping an empty one empty so that a
+// missing password shows in the preview.
+func maskPassword(password string) string {
+	if password == "" {
+		return ""
+	}
+	return MaskedPassword
+}
+
+// CheckDSN returns warnings for the username and password characters that the
+// connection string of the connection does not escape: whitespace, quotes
+// and backslashes in lib/pq key/value strings, URL delimiters in the Oracle
+// and SQL Server URLs (except the backslash of SQL Server DOMAIN\user
+// usernames), and colons in MySQL usernames.
+func CheckDSN(conn Connection) []DSNWarning {
+	var format, username, password string
+	var userUnsafe, passUnsafe func(rune) bool
+	switch c := conn.(type) {
+	case *MySQLConnection:
+		format, username, password = "MySQL", c.Username, c.Password
+		userUnsafe = func(r rune) bool { return r == ':' }
+		passUnsafe = func(rune) bool { return false }
+	case *PostgreSQLConnection:
+		format, username, password = "lib/pq key/value", c.Username, c.Password
+		userUnsafe = func(r rune) bool { return unicode.IsSpace(r) || r == '\'' || r == '\\' }
+		passUnsafe = userUnsafe
+	case *OracleConnection:
+		format, username, password = "Oracle EZConnect URL", c.Username, c.Password
+		passUnsafe = func(r rune) bool { return unicode.IsSpace(r) || strings.ContainsRune(urlUnsafeChars, r) }
+		userUnsafe = func(r rune) bool { return r == ':' || passUnsafe(r) }
+	case *SQLServerConnection:
+		format = "SQL Server URL"
+		username, password = c.login()
+		passUnsafe = func(r rune) bool { return unicode.IsSpace(r) || strings.ContainsRune(urlUnsafeChars, r) }
+		userUnsafe = func(r rune) bool { return r != '\\' && (r == ':' || passUnsafe(r)) } // DOMAIN\user is escaped
+	default:
+		return nil
+	}
+
+	var warnings []DSNWarning
+	if chars := unsafeChars(username, userUnsafe); chars != "" {
+		warnings = append(warnings, DSNWarning{Field: "username", Chars: chars, Format: format})
+	}
+	if chars := unsafeChars(password, passUnsafe); chars != "" {
+		warnings = append(warnings, DSNWarning{Field: "password", Chars: chars, Format: format})
+	}
+	return warnings
+}
+
+// unsafeChars returns the distinct characters of s for which unsafe is true,
+// with whitespace reported as a space.
+func unsafeChars(s string, unsafe func(rune) bool) string {
+	var chars []rune
+	for _, r := range s {
+		if !unsafe(r) {
+			continue
+		}
+		if unicode.IsSpace(r) {
+			r = ' '
+		}
+		if !strings.ContainsRune(string(chars), r) {
+			chars = append(chars, r)
+		}
+	}
+	return string(chars)
+}
+
+// quoteChars lists characters for messages, e.g. `'a', 'b'` or `space`.
+func quoteChars(chars string) string {
+	parts := make([]string, 0, len(chars))
+	for _, r := range chars {
+		if r == ' ' {
+			parts = append(parts, "space")
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%q", r))
+	}
+	return strings.Join(parts, ", ")
+}
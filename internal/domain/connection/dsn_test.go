@@ -0,0 +1,89 @@
+// Package connection provides unit tests for connection string previews and checks.
+package connection
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestMaskedDSN tests masking the password of the connection string.
+func TestMaskedDSN(t *testing.T) {
+	conn := &PostgreSQLConnection{Host: "db", Port: 5432, Database: "app", Username: "bench", Password: "s3cret", SSLMode: "require"}
+
+	got := MaskedDSN(conn)
+	want := "host=db port=5432 dbname=app user=bench password=**** sslmode=require"
+	if got != want {
+		t.Errorf("MaskedDSN() = %q, want %q", got, want)
+	}
+	if conn.Password != "s3cret" {
+		t.Errorf("MaskedDSN() changed the password to %q", conn.Password)
+	}
+
+	conn.Password = ""
+	if got := MaskedDSN(conn); !strings.Contains(got, "password= ") {
+		t.Errorf("MaskedDSN() = %q, want an empty password", got)
+	}
+
+	mysqlConn := &MySQLConnection{Host: "db", Port: 3306, Username: "bench", Password: "s3cret", SSLMode: MySQLSSLRequired}
+	if got, want := MaskedDSN(mysqlConn), "bench:****@tcp(db:3306)/?tls=skip-verify"; got != want {
+		t.Errorf("MaskedDSN() = %q, want %q", got, want)
+	}
+}
+
+// TestCheckDSN tests the warnings for characters the connection strings do not escape.
+func TestCheckDSN(t *testing.T) {
+	tests := []struct {
+		name string
+		conn Connection
+		want []DSNWarning
+	}{
+		{
+			name: "mysql password",
+			conn: &MySQLConnection{Username: "bench", Password: "p@ss:w/rd"},
+		},
+		{
+			name: "mysql username",
+			conn: &MySQLConnection{Username: "be:nch", Password: "x"},
+			want: []DSNWarning{{Field: "username", Chars: ":", Format: "MySQL"}},
+		},
+		{
+			name: "postgresql password",
+			conn: &PostgreSQLConnection{Username: "bench", Password: "it's a\\secret\tx"},
+			want: []DSNWarning{{Field: "password", Chars: "' \\", Format: "lib/pq key/value"}},
+		},
+		{
+			name: "oracle",
+			conn: &OracleConnection{Username: "soe:1", Password: "p/w#1?"},
+			want: []DSNWarning{
+				{Field: "username", Chars: ":", Format: "Oracle EZConnect URL"},
+				{Field: "password", Chars: "/#?", Format: "Oracle EZConnect URL"},
+			},
+		},
+		{
+			name: "sqlserver safe",
+			conn: &SQLServerConnection{Username: "sa", Password: "P@ss:w0rd!"},
+		},
+		{
+			name: "sqlserver domain user",
+			conn: &SQLServerConnection{Username: `CORP\bench`, Password: "x", Authentication: SQLServerAuthWindows},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckDSN(tt.conn); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckDSN() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDSNWarning_String tests the description of a warning.
+func TestDSNWarning_String(t *testing.T) {
+	w := DSNWarning{Field: "password", Chars: " '", Format: "lib/pq key/value"}
+	want := `password contains space, '\'', which must be escaped in lib/pq key/value connection strings`
+	if got := w.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,195 @@
+// Package connection provides database connection domain models.
+package connection
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// readOnlyFields are fields of the stored connection that are assigned or
+// recorded by the application rather than configured.
+var readOnlyFields = map[string]bool{
+	"id":         true,
+	"created_at": true,
+	"updated_at": true,
+	"dataset":    true,
+	"soe_schema": true,
+}
+
+// SetField sets a configuration field of a connection by its stored (JSON)
+// name, converting value to the field's type. Fields of nested settings are
+// addressed with a dot, e.g. "ssh.host" or "winrm.use_https"; unset nested
+// settings are created. Entries of option maps are addressed the same way,
+// e.g. "options.connect_timeout", and removed by an empty value. Passwords
+// are not settable by name.
+func SetField(conn Connection, name, value string) error {
+	v := reflect.ValueOf(conn)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("connection must be a non-nil pointer")
+	}
+
+	path := strings.Split(name, ".")
+	if readOnlyFields[path[0]] {
+		return fmt.Errorf("field %s cannot be set", name)
+	}
+
+	current := v.Elem()
+	for i, part := range path {
+		field, ok := fieldByJSONName(current, part)
+		if !ok {
+			return fmt.Errorf("unknown field %s for %s connection (settable: %s)",
+				name, conn.GetType(), strings.Join(Fields(conn), ", "))
+		}
+
+		if i == len(path)-2 && field.Kind() == reflect.Map && field.Type().Key().Kind() == reflect.String &&
+			field.Type().Elem().Kind() == reflect.String {
+			// Set an entry of an option map such as options
+			setMapEntry(field, path[i+1], value)
+			return nil
+		}
+		if i < len(path)-1 {
+			// Descend into nested settings such as ssh or winrm
+			if field.Kind() != reflect.Pointer || field.Type().Elem().Kind() != reflect.Struct {
+				return fmt.Errorf("field %s has no sub-fields", strings.Join(path[:i+1], "."))
+			}
+			if field.IsNil() {
+				field.Set(reflect.New(field.Type().Elem()))
+			}
+			current = field.Elem()
+			continue
+		}
+
+		return setScalar(field, name, value)
+	}
+	return nil
+}
+
+// Fields returns the names of the settable fields of a connection, sorted.
+func Fields(conn Connection) []string {
+	v := reflect.ValueOf(conn)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return nil
+	}
+	var names []string
+	collectFields(v.Elem().Type(), "", &names)
+	sort.Strings(names)
+	return names
+}
+
+// collectFields appends the settable field names of struct type t.
+func collectFields(t reflect.Type, prefix string, names *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			collectFields(f.Type, prefix, names)
+			continue
+		}
+		name := jsonName(f)
+		if name == "" || (prefix == "" && readOnlyFields[name]) {
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.String, reflect.Int, reflect.Bool:
+			*names = append(*names, prefix+name)
+		case reflect.Slice:
+			if f.Type.Elem().Kind() == reflect.String {
+				*names = append(*names, prefix+name)
+			}
+		case reflect.Pointer:
+			if f.Type.Elem().Kind() == reflect.Struct {
+				collectFields(f.Type.Elem(), prefix+name+".", names)
+			}
+		case reflect.Map:
+			if f.Type.Key().Kind() == reflect.String && f.Type.Elem().Kind() == reflect.String {
+				*names = append(*names, prefix+name+".<name>")
+			}
+		}
+	}
+}
+
+// fieldByJSONName finds the field of struct value v stored as name,
+// including fields of embedded structs.
+func fieldByJSONName(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if field, ok := fieldByJSONName(v.Field(i), name); ok {
+				return field, true
+			}
+			continue
+		}
+		if jsonName(f) == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// jsonName returns the stored name of a struct field, or "" if it is not stored.
+func jsonName(f reflect.StructField) string {
+	if !f.IsExported() {
+		return ""
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// setMapEntry sets the entry key of a string map field to value, removing
+// it if value is empty. An unset map is created.
+func setMapEntry(field reflect.Value, key, value string) {
+	if value == "" {
+		if !field.IsNil() {
+			field.SetMapIndex(reflect.ValueOf(key), reflect.Value{})
+		}
+		return
+	}
+	if field.IsNil() {
+		field.Set(reflect.MakeMap(field.Type()))
+	}
+	field.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(value))
+}
+
+// setScalar parses value into a string, int, bool or string list field.
+// Lists are separated by commas, e.g. "replica-1,replica-2:3307".
+func setScalar(field reflect.Value, name, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("field %s: %q is not an integer", name, value)
+		}
+		field.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("field %s: %q is not a boolean (true/false)", name, value)
+		}
+		field.SetBool(b)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("field %s cannot be set", name)
+		}
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("field %s cannot be set", name)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Package connection provides unit tests for setting connection fields by name.
+package connection
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestSetField tests setting fields of a connection by their stored names.
+func TestSetField(t *testing.T) {
+	conn := &PostgreSQLConnection{BaseConnection: BaseConnection{ID: "pg-1", Name: "pg"}, Host: "old", Port: 5432}
+
+	fields := map[string]string{
+		"host":         "db.example.com",
+		"port":         "5433",
+		"ssl_mode":     "disable",
+		"name":         "pg-prod",
+		"ssh.enabled":  "true",
+		"ssh.host":     "bastion",
+		"ssh.key_path": "/home/me/.ssh/id_rsa",
+		"replicas":     "replica-1, replica-2:5433",
+	}
+	for name, value := range fields {
+		if err := SetField(conn, name, value); err != nil {
+			t.Fatalf("SetField(%s) error = %v", name, err)
+		}
+	}
+
+	if conn.Host != "db.example.com" || conn.Port != 5433 || conn.SSLMode != "disable" || conn.Name != "pg-prod" {
+		t.Errorf("fields not set: %+v", conn)
+	}
+	if !slices.Equal(conn.Replicas, []string{"replica-1", "replica-2:5433"}) {
+		t.Errorf("Replicas = %v, want both replicas", conn.Replicas)
+	}
+	if conn.SSH == nil || !conn.SSH.Enabled || conn.SSH.Host != "bastion" || conn.SSH.KeyPath != "/home/me/.ssh/id_rsa" {
+		t.Errorf("ssh fields not set: %+v", conn.SSH)
+	}
+}
+
+// TestSetField_Options tests setting and removing entries of option maps.
+func TestSetField_Options(t *testing.T) {
+	conn := &PostgreSQLConnection{}
+	if err := SetField(conn, "options.connect_timeout", "10"); err != nil {
+		t.Fatalf("SetField() error = %v", err)
+	}
+	if conn.Options["connect_timeout"] != "10" {
+		t.Errorf("Options = %v, want connect_timeout=10", conn.Options)
+	}
+	if err := SetField(conn, "options.connect_timeout", ""); err != nil {
+		t.Fatalf("SetField() error = %v", err)
+	}
+	if len(conn.Options) != 0 {
+		t.Errorf("Options = %v, want empty after removing", conn.Options)
+	}
+	if !slices.Contains(Fields(conn), "options.<name>") {
+		t.Errorf("Fields() = %v, missing options.<name>", Fields(conn))
+	}
+}
+
+// TestSetField_Errors tests rejecting unknown, read-only and malformed fields.
+func TestSetField_Errors(t *testing.T) {
+	conn := &SQLServerConnection{BaseConnection: BaseConnection{ID: "ss-1"}}
+
+	tests := map[string]string{
+		"unknown field":     "ssl_mode",
+		"read-only field":   "id",
+		"password":          "password",
+		"not an integer":    "port",
+		"not a boolean":     "trust_server_certificate",
+		"no sub-fields":     "host.name",
+		"unknown sub-field": "winrm.password",
+	}
+	values := map[string]string{"port": "abc", "trust_server_certificate": "maybe"}
+	for name, field := range tests {
+		if err := SetField(conn, field, values[field]); err == nil {
+			t.Errorf("%s: SetField(%s) error = nil", name, field)
+		}
+	}
+	if conn.ID != "ss-1" {
+		t.Errorf("ID changed to %q", conn.ID)
+	}
+}
+
+// TestFields tests listing the settable fields of a connection.
+func TestFields(t *testing.T) {
+	got := Fields(&SQLServerConnection{})
+	for _, want := range []string{"name", "host", "port", "trust_server_certificate", "winrm.host", "winrm.use_https"} {
+		if !slices.Contains(got, want) {
+			t.Errorf("Fields() = %v, missing %s", got, want)
+		}
+	}
+	for _, unwanted := range []string{"id", "created_at", "dataset", "password", "winrm.password"} {
+		if slices.Contains(got, unwanted) {
+			t.Errorf("Fields() = %v, contains %s", got, unwanted)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Package connection provides the groups, favorites and search of the connection list.
+package connection
+
+import (
+	"sort"
+	"strings"
+)
+
+// Groups returns the distinct user-defined groups of conns, sorted by name.
+func Groups(conns []Connection) []string {
+	seen := make(map[string]bool)
+	var groups []string
+	for _, conn := range conns {
+		if group := conn.GetGroup(); group != "" && !seen[group] {
+			seen[group] = true
+			groups = append(groups, group)
+		}
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// Matches reports whether conn matches a search query: every word of query
+// occurs, ignoring case, in its name, group, type or redacted address (host,
+// port and database). An empty query matches every connection.
+func Matches(conn Connection, query string) bool {
+	text := strings.ToLower(strings.Join([]string{conn.Redact(), conn.GetGroup(), string(conn.GetType())}, " "))
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// SortFavoritesFirst sorts conns with favorites first, keeping the order of
+// the others.
+func SortFavoritesFirst(conns []Connection) {
+	sort.SliceStable(conns, func(i, j int) bool {
+		return conns[i].IsFavorite() && !conns[j].IsFavorite()
+	})
+}
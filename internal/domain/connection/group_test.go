@@ -0,0 +1,63 @@
+// Implements: REQ-CONN-001
+package connection
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestGroups tests collecting the distinct groups of connections.
+func TestGroups(t *testing.T) {
+	conns := []Connection{
+		&MySQLConnection{BaseConnection: BaseConnection{Name: "a", Group: "Prod EU"}},
+		&MySQLConnection{BaseConnection: BaseConnection{Name: "b"}},
+		&PostgreSQLConnection{BaseConnection: BaseConnection{Name: "c", Group: "Perf Lab"}},
+		&OracleConnection{BaseConnection: BaseConnection{Name: "d", Group: "Prod EU"}},
+	}
+	if got, want := Groups(conns), []string{"Perf Lab", "Prod EU"}; !slices.Equal(got, want) {
+		t.Errorf("Groups() = %v, want %v", got, want)
+	}
+}
+
+// TestMatches tests searching connections.
+func TestMatches(t *testing.T) {
+	conn := &MySQLConnection{
+		BaseConnection: BaseConnection{Name: "orders-primary", Group: "Prod EU"},
+		Host:           "10.0.0.5", Port: 3306, Database: "sbtest",
+	}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"ORDERS", true},
+		{"prod eu", true},
+		{"mysql 10.0.0.5", true},
+		{"sbtest", true},
+		{"orders postgresql", false},
+		{"staging", false},
+	}
+	for _, tt := range tests {
+		if got := Matches(conn, tt.query); got != tt.want {
+			t.Errorf("Matches(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+// TestSortFavoritesFirst tests listing favorites first.
+func TestSortFavoritesFirst(t *testing.T) {
+	conns := []Connection{
+		&MySQLConnection{BaseConnection: BaseConnection{Name: "a"}},
+		&MySQLConnection{BaseConnection: BaseConnection{Name: "b", Favorite: true}},
+		&MySQLConnection{BaseConnection: BaseConnection{Name: "c"}},
+		&MySQLConnection{BaseConnection: BaseConnection{Name: "d", Favorite: true}},
+	}
+	SortFavoritesFirst(conns)
+	var names []string
+	for _, conn := range conns {
+		names = append(names, conn.GetName())
+	}
+	if want := []string{"b", "d", "a", "c"}; !slices.Equal(names, want) {
+		t.Errorf("SortFavoritesFirst() = %v, want %v", names, want)
+	}
+}
@@ -26,7 +26,13 @@ type MySQLConnection struct {
 	Password string `json:"-"`        // Password (not serialized, stored in keyring)
 
 	// SSL configuration
-	SSLMode string `json:"ssl_mode"` // SSL mode: disabled/preferred/required
+	SSLMode string `json:"ssl_mode"`           // SSL mode: disabled/preferred/required/verify-ca/verify-identity, empty to detect
+	SSLCA   string `json:"ssl_ca,omitempty"`   // CA certificate file (PEM)
+	SSLCert string `json:"ssl_cert,omitempty"` // Client certificate file (PEM)
+	SSLKey  string `json:"ssl_key,omitempty"`  // Client private key file (PEM)
+
+	// Replicas of this primary, "host" or "host:port", monitored during benchmarks
+	Replicas []string `json:"replicas,omitempty"`
 
 	// SSH tunnel configuration
 	SSH *SSHTunnelConfig `json:"ssh,omitempty"` // SSH tunnel configuration
@@ -99,8 +105,9 @@ func (c *MySQLConnection) Validate() error {
 		errs = append(errs, err)
 	}
 
-	// Note: SSL mode validation removed - we auto-detect the best mode
-	// c.SSLMode field is kept for backward compatibility but not validated
+	// Validate SSL mode and certificate files (an empty mode is detected by Test)
+	errs = append(errs, c.validateSSL()...)
+	errs = append(errs, validateReplicas(c.Replicas)...)
 
 	if len(errs) > 0 {
 		return &MultiValidationError{Errors: errs}
@@ -113,7 +120,8 @@ func (c *MySQLConnection) Validate() error {
 //
 // If SSH tunnel is enabled, it establishes the tunnel first.
 //
-// It attempts multiple SSL configurations in order:
+// With an SSL mode set, it connects with that mode and the certificate files.
+// Otherwise it attempts multiple SSL configurations in order:
 // 1. disabled (no SSL - fastest)
 // 2. preferred (auto-detect, fallback to no SSL)
 // 3. required (force SSL)
@@ -147,6 +155,33 @@ func (c *MySQLConnection) Test(ctx context.Context) (*TestResult, error) {
 		slog.Info("MySQL: Using SSH tunnel", "local_port", targetPort)
 	}
 
+	// Connect with the configured SSL mode only
+	if mode := c.EffectiveSSLMode(); mode != "" {
+		params, err := c.DSNTLSParams()
+		if err != nil {
+			return &TestResult{
+				Success:   false,
+				LatencyMs: time.Since(start).Milliseconds(),
+				Error:     err.Error(),
+			}, nil
+		}
+		slog.Info("MySQL: Testing connection",
+			"host", targetHost,
+			"port", targetPort,
+			"ssh_tunnel", tunnel != nil,
+			"ssl_mode", mode,
+			"username", c.Username)
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s%s", c.Username, c.Password, targetHost, targetPort, c.Database, params)
+		result, err := c.testConnection(ctx, dsn, start)
+		if err != nil {
+			return nil, fmt.Errorf("test cancelled: %w", err)
+		}
+		if !result.Success {
+			result.Error = fmt.Sprintf("ssl_mode=%s: %s", mode, result.Error)
+		}
+		return result, nil
+	}
+
 	// SSL modes to try in order (most common first)
 	sslModes := []string{"disabled", "preferred", "required"}
 
@@ -0,0 +1,168 @@
+// Package connection provides the TLS settings of MySQL connections.
+package connection
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL SSL modes, as the --ssl-mode option of the MySQL client utilities.
+const (
+	MySQLSSLDisabled       = "disabled"
+	MySQLSSLPreferred      = "preferred"
+	MySQLSSLRequired       = "required"
+	MySQLSSLVerifyCA       = "verify-ca"
+	MySQLSSLVerifyIdentity = "verify-identity"
+)
+
+// MySQLSSLModes are the SSL modes of MySQL connections, from least to most secure.
+var MySQLSSLModes = []string{MySQLSSLDisabled, MySQLSSLPreferred, MySQLSSLRequired, MySQLSSLVerifyCA, MySQLSSLVerifyIdentity}
+
+// legacyMySQLSSLMode was saved by earlier versions of the connection dialog
+// whatever the server supported, so it is read as an unset mode.
+const legacyMySQLSSLMode = "disable"
+
+// EffectiveSSLMode returns the configured SSL mode, or "" if none is set and
+// the mode is detected when testing the connection.
+func (c *MySQLConnection) EffectiveSSLMode() string {
+	if c.SSLMode == legacyMySQLSSLMode {
+		return ""
+	}
+	return c.SSLMode
+}
+
+// validateSSL validates the SSL mode and certificate files.
+func (c *MySQLConnection) validateSSL() []error {
+	var errs []error
+	mode := c.EffectiveSSLMode()
+	valid := mode == ""
+	for _, m := range MySQLSSLModes {
+		valid = valid || mode == m
+	}
+	if !valid {
+		errs = append(errs, &ValidationError{
+			Field:   "ssl_mode",
+			Message: "ssl_mode must be one of: disabled, preferred, required, verify-ca, verify-identity",
+			Value:   c.SSLMode,
+		})
+	}
+	if mode == MySQLSSLVerifyCA && c.SSLCA == "" {
+		errs = append(errs, &ValidationError{Field: "ssl_ca", Message: "ssl_ca is required for ssl_mode verify-ca", Value: c.SSLCA})
+	}
+	if (c.SSLCert == "") != (c.SSLKey == "") {
+		errs = append(errs, &ValidationError{
+			Field:   "ssl_cert",
+			Message: "ssl_cert and ssl_key must be set together",
+			Value:   c.SSLCert,
+		})
+	}
+	return errs
+}
+
+// tlsValue returns the tls parameter of the driver DSN for the SSL mode, ""
+// if none is set. custom is set if the value names a TLS configuration that
+// has to be registered with the driver first, for the certificate files or
+// CA-only verification.
+func (c *MySQLConnection) tlsValue() (value string, custom bool) {
+	mode := c.EffectiveSSLMode()
+	hasCert := c.SSLCert != ""
+	switch {
+	case mode == "":
+		return "", false
+	case mode == MySQLSSLDisabled:
+		return "false", false
+	case mode == MySQLSSLPreferred:
+		return "preferred", false // Certificate files need SSL; they apply from required up
+	case mode == MySQLSSLRequired && !hasCert:
+		return "skip-verify", false
+	case mode == MySQLSSLVerifyIdentity && !hasCert && c.SSLCA == "":
+		return "true", false // System CAs
+	}
+	sum := sha256.Sum256([]byte(mode + "\x00" + c.SSLCA + "\x00" + c.SSLCert + "\x00" + c.SSLKey + "\x00" + c.Host))
+	return fmt.Sprintf("db-benchmind-%x", sum[:6]), true
+}
+
+// DSNTLSParams returns the query string selecting the TLS settings of the
+// connection for the driver DSN, e.g. "?tls=skip-verify", or "" if no SSL
+// mode is set. Settings with certificate files are registered with the
+// driver, so the files must be readable.
+func (c *MySQLConnection) DSNTLSParams() (string, error) {
+	value, custom := c.tlsValue()
+	if value == "" {
+		return "", nil
+	}
+	if custom {
+		cfg, err := c.tlsConfig()
+		if err != nil {
+			return "", err
+		}
+		if err := mysql.RegisterTLSConfig(value, cfg); err != nil {
+			return "", fmt.Errorf("register TLS config: %w", err)
+		}
+	}
+	return "?tls=" + value, nil
+}
+
+// tlsConfig builds the TLS configuration of the SSL mode and certificate files.
+func (c *MySQLConnection) tlsConfig() (*tls.Config, error) {
+	cfg := &tls.Config{ServerName: c.Host}
+
+	if c.SSLCA != "" {
+		pem, err := os.ReadFile(c.SSLCA)
+		if err != nil {
+			return nil, fmt.Errorf("read SSL CA file: %w", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("SSL CA file %s contains no PEM certificates", c.SSLCA)
+		}
+	}
+	if c.SSLCert != "" {
+		cert, err := tls.LoadX509KeyPair(c.SSLCert, c.SSLKey)
+		if err != nil {
+			return nil, fmt.Errorf("load SSL client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	switch c.EffectiveSSLMode() {
+	case MySQLSSLRequired:
+		cfg.InsecureSkipVerify = true
+	case MySQLSSLVerifyCA:
+		// Verify the chain against the CA but not the host name
+		roots := cfg.RootCAs
+		cfg.InsecureSkipVerify = true
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyChain(rawCerts, roots)
+		}
+	}
+	return cfg, nil
+}
+
+// verifyChain verifies the certificate chain sent by a server against roots,
+// without checking the host name.
+func verifyChain(rawCerts [][]byte, roots *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return errors.New("server sent no certificate")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("parse server certificate: %w", err)
+		}
+		certs[i] = cert
+	}
+	opts := x509.VerifyOptions{Roots: roots, Intermediates: x509.NewCertPool()}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
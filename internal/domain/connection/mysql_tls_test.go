@@ -0,0 +1,148 @@
+// Package connection provides unit tests for the TLS settings of MySQL connections.
+package connection
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMySQLConnection_ValidateSSL tests validating the SSL mode and certificate files.
+func TestMySQLConnection_ValidateSSL(t *testing.T) {
+	tests := []struct {
+		name    string
+		conn    *MySQLConnection
+		wantErr string
+	}{
+		{name: "unset", conn: &MySQLConnection{}},
+		{name: "legacy disable", conn: &MySQLConnection{SSLMode: "disable"}},
+		{name: "verify-identity", conn: &MySQLConnection{SSLMode: MySQLSSLVerifyIdentity}},
+		{name: "client certificate", conn: &MySQLConnection{SSLMode: MySQLSSLRequired, SSLCert: "client.pem", SSLKey: "client-key.pem"}},
+		{name: "invalid mode", conn: &MySQLConnection{SSLMode: "on"}, wantErr: "ssl_mode must be one of"},
+		{name: "verify-ca without CA", conn: &MySQLConnection{SSLMode: MySQLSSLVerifyCA}, wantErr: "ssl_ca is required"},
+		{name: "certificate without key", conn: &MySQLConnection{SSLMode: MySQLSSLRequired, SSLCert: "client.pem"}, wantErr: "set together"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.conn.validateSSL()
+			if tt.wantErr == "" {
+				if len(errs) != 0 {
+					t.Errorf("validateSSL() = %v, want no errors", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("validateSSL() = %v, want one error containing %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestMySQLConnection_DSNTLSParams tests mapping the SSL modes to the driver DSN.
+func TestMySQLConnection_DSNTLSParams(t *testing.T) {
+	tests := map[string]string{
+		"":                     "",
+		"disable":              "",
+		MySQLSSLDisabled:       "?tls=false",
+		MySQLSSLPreferred:      "?tls=preferred",
+		MySQLSSLRequired:       "?tls=skip-verify",
+		MySQLSSLVerifyIdentity: "?tls=true",
+	}
+	for mode, want := range tests {
+		conn := &MySQLConnection{Host: "db", SSLMode: mode}
+		if got, err := conn.DSNTLSParams(); err != nil || got != want {
+			t.Errorf("DSNTLSParams(%q) = %q, %v, want %q", mode, got, err, want)
+		}
+	}
+
+	// Certificate files are registered as a custom TLS configuration
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir)
+	conn := &MySQLConnection{Host: "db", SSLMode: MySQLSSLVerifyCA, SSLCA: certFile, SSLCert: certFile, SSLKey: keyFile}
+	got, err := conn.DSNTLSParams()
+	if err != nil {
+		t.Fatalf("DSNTLSParams() error = %v", err)
+	}
+	if !strings.HasPrefix(got, "?tls=db-benchmind-") {
+		t.Errorf("DSNTLSParams() = %q, want a custom configuration", got)
+	}
+
+	conn.SSLCA = filepath.Join(dir, "missing.pem")
+	if _, err := conn.DSNTLSParams(); err == nil {
+		t.Error("DSNTLSParams() error = nil for a missing CA file")
+	}
+}
+
+// TestVerifyChain tests verifying server certificates without the host name.
+func TestVerifyChain(t *testing.T) {
+	certFile, _ := writeTestCertificate(t, t.TempDir())
+	data, err := os.ReadFile(certFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode(data)
+	roots := x509.NewCertPool()
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots.AddCert(cert)
+
+	if err := verifyChain([][]byte{block.Bytes}, roots); err != nil {
+		t.Errorf("verifyChain() error = %v", err)
+	}
+	if err := verifyChain([][]byte{block.Bytes}, x509.NewCertPool()); err == nil {
+		t.Error("verifyChain() error = nil for an unknown CA")
+	}
+	if err := verifyChain(nil, roots); err == nil {
+		t.Error("verifyChain() error = nil without certificates")
+	}
+}
+
+// writeTestCertificate writes a self-signed CA certificate for another host
+// name and its key to dir, and returns their paths.
+func writeTestCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "mysql-test-ca"},
+		DNSNames:              []string{"other.example.com"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
@@ -0,0 +1,101 @@
+// Package connection provides the connect strings and TCPS settings of Oracle connections.
+package connection
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Oracle Net protocols.
+const (
+	OracleProtocolTCP  = "tcp"
+	OracleProtocolTCPS = "tcps" // TLS, required by Oracle Autonomous Database
+)
+
+// IsTCPS reports whether the connection uses TCPS.
+func (c *OracleConnection) IsTCPS() bool {
+	return c.Protocol == OracleProtocolTCPS
+}
+
+// buildURL builds the go-ora URL of the connection for host and port. The
+// password is left out if withPassword is false.
+func (c *OracleConnection) buildURL(host string, port int, withPassword bool) string {
+	userinfo := c.Username
+	if withPassword {
+		userinfo += ":" + c.Password
+	}
+	dsn := fmt.Sprintf("oracle://%s@%s:%d/%s", userinfo, host, port, c.ServiceName)
+
+	var params []string
+	if c.ServiceName == "" && c.SID != "" {
+		params = append(params, "SID="+url.QueryEscape(c.SID))
+	}
+	if c.IsTCPS() {
+		params = append(params, "SSL=true")
+		if c.TrustServerCertificate {
+			params = append(params, "SSL+VERIFY=false")
+		}
+	}
+	if c.WalletDir != "" {
+		params = append(params, "WALLET="+url.QueryEscape(c.WalletDir))
+	}
+	if len(params) > 0 {
+		dsn += "?" + strings.Join(params, "&")
+	}
+	return dsn
+}
+
+// Descriptor returns the Oracle Net connect descriptor of the connection, for
+// clients that cannot express a SID or TCPS in Easy Connect strings, e.g.
+// (DESCRIPTION=(ADDRESS=(PROTOCOL=TCPS)(HOST=h)(PORT=1522))(CONNECT_DATA=(SERVICE_NAME=s))).
+func (c *OracleConnection) Descriptor() string {
+	protocol := "TCP"
+	if c.IsTCPS() {
+		protocol = "TCPS"
+	}
+	connectData := "SERVICE_NAME=" + c.ServiceName
+	if c.ServiceName == "" {
+		connectData = "SID=" + c.SID
+	}
+	descriptor := fmt.Sprintf("(DESCRIPTION=(ADDRESS=(PROTOCOL=%s)(HOST=%s)(PORT=%d))(CONNECT_DATA=(%s))",
+		protocol, c.Host, c.Port, connectData)
+	if c.WalletDir != "" {
+		descriptor += fmt.Sprintf("(SECURITY=(MY_WALLET_DIRECTORY=%s))", c.WalletDir)
+	}
+	return descriptor + ")"
+}
+
+// validateTCPS validates the protocol, wallet and certificate settings.
+func (c *OracleConnection) validateTCPS() []error {
+	var errs []error
+	switch c.Protocol {
+	case "", OracleProtocolTCP, OracleProtocolTCPS:
+	default:
+		errs = append(errs, &ValidationError{Field: "protocol", Message: "protocol must be one of: tcp, tcps", Value: c.Protocol})
+	}
+	if c.WalletDir != "" && c.ServiceName == "" {
+		// go-ora looks up wallet entries by service name
+		errs = append(errs, &ValidationError{Field: "wallet_dir", Message: "wallet_dir requires a service_name", Value: c.WalletDir})
+	}
+	if c.TrustServerCertificate && !c.IsTCPS() {
+		errs = append(errs, &ValidationError{
+			Field:   "trust_server_certificate",
+			Message: "trust_server_certificate requires protocol tcps",
+			Value:   c.TrustServerCertificate,
+		})
+	}
+	return errs
+}
+
+// identifierHint returns a hint for listener errors about an unknown SID or
+// service name, which are easily mixed up, "" for other errors.
+func (c *OracleConnection) identifierHint(errMsg string) string {
+	switch {
+	case c.ServiceName == "" && strings.Contains(errMsg, "ORA-12505"):
+		return fmt.Sprintf(" (the listener does not know SID %q; if it is a service name, connect by service name)", c.SID)
+	case c.ServiceName != "" && strings.Contains(errMsg, "ORA-12514"):
+		return fmt.Sprintf(" (the listener does not know service %q; if it is a SID, connect by SID)", c.ServiceName)
+	}
+	return ""
+}
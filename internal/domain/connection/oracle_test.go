@@ -2,6 +2,7 @@
 package connection
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -93,3 +94,82 @@ func TestOracleConnection_Validate(t *testing.T) {
 		})
 	}
 }
+
+// TestOracleConnection_GetDSNWithPassword tests the go-ora URLs of service names, SIDs and TCPS.
+func TestOracleConnection_GetDSNWithPassword(t *testing.T) {
+	tests := []struct {
+		name string
+		conn *OracleConnection
+		want string
+	}{
+		{
+			name: "service name",
+			conn: &OracleConnection{Host: "db", Port: 1521, ServiceName: "ORCLPDB1", Username: "soe", Password: "pw"},
+			want: "oracle://soe:pw@db:1521/ORCLPDB1",
+		},
+		{
+			name: "SID",
+			conn: &OracleConnection{Host: "db", Port: 1521, SID: "XE", Username: "soe", Password: "pw"},
+			want: "oracle://soe:pw@db:1521/?SID=XE",
+		},
+		{
+			name: "TCPS with wallet",
+			conn: &OracleConnection{Host: "adb.example.com", Port: 1522, ServiceName: "bench_high", Username: "admin", Password: "pw",
+				Protocol: OracleProtocolTCPS, WalletDir: "/opt/wallet", TrustServerCertificate: true},
+			want: "oracle://admin:pw@adb.example.com:1522/bench_high?SSL=true&SSL+VERIFY=false&WALLET=%2Fopt%2Fwallet",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.conn.GetDSNWithPassword(); got != tt.want {
+				t.Errorf("GetDSNWithPassword() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestOracleConnection_Descriptor tests the connect descriptors for external tools.
+func TestOracleConnection_Descriptor(t *testing.T) {
+	conn := &OracleConnection{Host: "adb.example.com", Port: 1522, ServiceName: "bench_high", Protocol: OracleProtocolTCPS, WalletDir: "/opt/wallet"}
+	want := "(DESCRIPTION=(ADDRESS=(PROTOCOL=TCPS)(HOST=adb.example.com)(PORT=1522))(CONNECT_DATA=(SERVICE_NAME=bench_high))" +
+		"(SECURITY=(MY_WALLET_DIRECTORY=/opt/wallet)))"
+	if got := conn.Descriptor(); got != want {
+		t.Errorf("Descriptor() = %q, want %q", got, want)
+	}
+
+	conn = &OracleConnection{Host: "db", Port: 1521, SID: "XE"}
+	want = "(DESCRIPTION=(ADDRESS=(PROTOCOL=TCP)(HOST=db)(PORT=1521))(CONNECT_DATA=(SID=XE)))"
+	if got := conn.Descriptor(); got != want {
+		t.Errorf("Descriptor() = %q, want %q", got, want)
+	}
+}
+
+// TestOracleConnection_ValidateTCPS tests validating the TCPS settings.
+func TestOracleConnection_ValidateTCPS(t *testing.T) {
+	tests := []struct {
+		name    string
+		conn    *OracleConnection
+		wantErr string
+	}{
+		{name: "TCPS with wallet", conn: &OracleConnection{ServiceName: "svc", Protocol: OracleProtocolTCPS, WalletDir: "/opt/wallet"}},
+		{name: "invalid protocol", conn: &OracleConnection{ServiceName: "svc", Protocol: "ipc"}, wantErr: "protocol must be one of"},
+		{name: "wallet with SID", conn: &OracleConnection{SID: "XE", WalletDir: "/opt/wallet"}, wantErr: "requires a service_name"},
+		{name: "trust without TCPS", conn: &OracleConnection{ServiceName: "svc", TrustServerCertificate: true}, wantErr: "requires protocol tcps"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.conn.validateTCPS()
+			if tt.wantErr == "" {
+				if len(errs) != 0 {
+					t.Errorf("validateTCPS() = %v, want no errors", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("validateTCPS() = %v, want one error containing %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package connection
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Replicated is implemented by connections to a primary whose replicas are
+// listed with the connection, so that their replication lag can be
+// monitored during a benchmark.
+type Replicated interface {
+	Connection
+	// ReplicaAddresses returns the configured replicas, "host" or "host:port".
+	ReplicaAddresses() []string
+	// ReplicaConnection returns the connection to the replica at addr: the
+	// same credentials, TLS and SSH settings with the host and port replaced.
+	ReplicaConnection(addr string) (Connection, error)
+}
+
+// ParseReplicas splits a comma, space or newline separated list of replica
+// addresses, dropping empty entries.
+func ParseReplicas(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	})
+}
+
+// SplitReplicaAddress splits a replica address into its host and port. An
+// address without a port uses defaultPort; IPv6 hosts with a port are
+// written in brackets, e.g. "[fd00::2]:3307".
+func SplitReplicaAddress(addr string, defaultPort int) (string, int, error) {
+	host, portText, err := net.SplitHostPort(addr)
+	if err != nil {
+		// No port
+		return strings.Trim(addr, "[]"), defaultPort, nil
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil || ValidatePort(port) != nil {
+		return "", 0, fmt.Errorf("replica %s: port must be between 1 and 65535", addr)
+	}
+	if host == "" {
+		return "", 0, fmt.Errorf("replica %s: host is required", addr)
+	}
+	return host, port, nil
+}
+
+// validateReplicas validates the replica addresses of a connection.
+func validateReplicas(replicas []string) []error {
+	var errs []error
+	for _, addr := range replicas {
+		if _, _, err := SplitReplicaAddress(addr, 1); err != nil {
+			errs = append(errs, &ValidationError{Field: "replicas", Message: err.Error()})
+		}
+	}
+	return errs
+}
+
+// ReplicaAddresses returns the configured replicas.
+func (c *MySQLConnection) ReplicaAddresses() []string {
+	return c.Replicas
+}
+
+// ReplicaConnection returns the connection to the replica at addr.
+func (c *MySQLConnection) ReplicaConnection(addr string) (Connection, error) {
+	host, port, err := SplitReplicaAddress(addr, c.Port)
+	if err != nil {
+		return nil, err
+	}
+	replica := *c
+	replica.Host, replica.Port, replica.Replicas = host, port, nil
+	return &replica, nil
+}
+
+// ReplicaAddresses returns the configured replicas.
+func (c *PostgreSQLConnection) ReplicaAddresses() []string {
+	return c.Replicas
+}
+
+// ReplicaConnection returns the connection to the replica at addr.
+func (c *PostgreSQLConnection) ReplicaConnection(addr string) (Connection, error) {
+	host, port, err := SplitReplicaAddress(addr, c.Port)
+	if err != nil {
+		return nil, err
+	}
+	replica := *c
+	replica.Host, replica.Port, replica.Replicas = host, port, nil
+	return &replica, nil
+}
@@ -0,0 +1,46 @@
+package connection
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestReplicaConnection tests deriving replica connections from a primary.
+func TestReplicaConnection(t *testing.T) {
+	primary := &MySQLConnection{
+		BaseConnection: BaseConnection{ID: "mysql-1", Name: "primary"},
+		Host:           "db-1", Port: 3306, Username: "bench", Password: "secret",
+		Replicas: ParseReplicas("db-2, db-3:3307\n[fd00::4]:3308"),
+	}
+	if !slices.Equal(primary.ReplicaAddresses(), []string{"db-2", "db-3:3307", "[fd00::4]:3308"}) {
+		t.Fatalf("ReplicaAddresses() = %v", primary.ReplicaAddresses())
+	}
+
+	tests := map[string]struct {
+		host string
+		port int
+	}{
+		"db-2":           {"db-2", 3306},
+		"db-3:3307":      {"db-3", 3307},
+		"[fd00::4]:3308": {"fd00::4", 3308},
+	}
+	for addr, want := range tests {
+		conn, err := primary.ReplicaConnection(addr)
+		if err != nil {
+			t.Fatalf("ReplicaConnection(%s) error = %v", addr, err)
+		}
+		replica := conn.(*MySQLConnection)
+		if replica.Host != want.host || replica.Port != want.port || replica.Password != "secret" || replica.Replicas != nil {
+			t.Errorf("ReplicaConnection(%s) = %s:%d, replicas %v; want %s:%d with the primary's credentials",
+				addr, replica.Host, replica.Port, replica.Replicas, want.host, want.port)
+		}
+	}
+	if primary.Host != "db-1" {
+		t.Errorf("primary host changed to %s", primary.Host)
+	}
+
+	primary.Replicas = append(primary.Replicas, "db-5:99999")
+	if err := primary.Validate(); err == nil {
+		t.Error("Validate() with an invalid replica port error = nil")
+	}
+}
@@ -0,0 +1,113 @@
+// Implements: REQ-CONN-002
+package connection
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestSQLServerConnection_GetDSNWithPassword tests the connection URL of the advanced options.
+func TestSQLServerConnection_GetDSNWithPassword(t *testing.T) {
+	tests := []struct {
+		name string
+		conn *SQLServerConnection
+		want string
+	}{
+		{
+			name: "default",
+			conn: &SQLServerConnection{Host: "db", Port: 1433, Database: "app", Username: "sa", Password: "pw", TrustServerCertificate: true},
+			want: "sqlserver://sa:pw@db:1433?database=app&trustservercertificate=true",
+		},
+		{
+			name: "instance in host",
+			conn: &SQLServerConnection{Host: `db\SQLEXPRESS`, Port: 1433, Username: "sa", Password: "pw"},
+			want: "sqlserver://sa:pw@db/SQLEXPRESS?database=&trustservercertificate=false",
+		},
+		{
+			name: "instance on a fixed port",
+			conn: &SQLServerConnection{Host: "db", Port: 50123, Instance: "BENCH", Username: "sa", Password: "pw"},
+			want: "sqlserver://sa:pw@db:50123/BENCH?database=&trustservercertificate=false",
+		},
+		{
+			name: "strict encryption and read-only intent",
+			conn: &SQLServerConnection{Host: "db", Port: 1433, Database: "app", Username: "sa", Password: "pw",
+				Encrypt: SQLServerEncryptStrict, ReadOnlyIntent: true},
+			want: "sqlserver://sa:pw@db:1433?database=app&encrypt=strict&trustservercertificate=false&ApplicationIntent=ReadOnly",
+		},
+		{
+			name: "windows authentication with the WinRM account",
+			conn: &SQLServerConnection{Host: "db", Port: 1433, Authentication: SQLServerAuthWindows,
+				WinRM: &WinRMConfig{Enabled: true, Username: `CORP\bench`, Password: "winpw"}},
+			want: "sqlserver://CORP%5Cbench:winpw@db:1433?database=&trustservercertificate=false",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.conn.GetDSNWithPassword(); got != tt.want {
+				t.Errorf("GetDSNWithPassword() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSQLServerConnection_ValidateAdvanced tests validating the advanced options.
+func TestSQLServerConnection_ValidateAdvanced(t *testing.T) {
+	base := func() *SQLServerConnection {
+		return &SQLServerConnection{BaseConnection: BaseConnection{Name: "mssql"}, Host: "db", Port: 1433, Database: "app", Username: "sa"}
+	}
+	tests := []struct {
+		name    string
+		modify  func(c *SQLServerConnection)
+		wantErr string
+	}{
+		{name: "defaults", modify: func(*SQLServerConnection) {}},
+		{name: "mandatory encryption", modify: func(c *SQLServerConnection) { c.Encrypt = SQLServerEncryptMandatory }},
+		{name: "invalid encryption", modify: func(c *SQLServerConnection) { c.Encrypt = "yes" }, wantErr: "encrypt must be one of"},
+		{
+			name:    "strict with trusted certificate",
+			modify:  func(c *SQLServerConnection) { c.Encrypt, c.TrustServerCertificate = SQLServerEncryptStrict, true },
+			wantErr: "cannot be used with encrypt strict",
+		},
+		{
+			name:    "read-only intent without database",
+			modify:  func(c *SQLServerConnection) { c.ReadOnlyIntent, c.Database = true, "" },
+			wantErr: "database is required",
+		},
+		{
+			name:    "instance twice",
+			modify:  func(c *SQLServerConnection) { c.Host, c.Instance = `db\A`, "B" },
+			wantErr: "instance is set both",
+		},
+		{
+			name:    "windows without domain",
+			modify:  func(c *SQLServerConnection) { c.Authentication = SQLServerAuthWindows },
+			wantErr: `DOMAIN\user`,
+		},
+		{
+			name: "windows with the WinRM account",
+			modify: func(c *SQLServerConnection) {
+				c.Authentication, c.Username = SQLServerAuthWindows, ""
+				c.WinRM = &WinRMConfig{Username: `CORP\bench`}
+			},
+		},
+		{name: "invalid authentication", modify: func(c *SQLServerConnection) { c.Authentication = "kerberos" }, wantErr: "authentication must be one of"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := base()
+			tt.modify(conn)
+			err := conn.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"log/slog"
 	"net"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -304,3 +306,44 @@ func (t *SSHTunnel) IsClosed() bool {
 	defer t.mu.Unlock()
 	return t.closed
 }
+
+// Run executes a command line on the SSH server and returns its standard output.
+// Cancelling ctx closes the session.
+func (c *SSHTunnelConfig) Run(ctx context.Context, cmdLine string) (string, error) {
+	sshConfig, err := c.buildSSHConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to create SSH config: %w", err)
+	}
+
+	sshAddr := net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+	client, err := ssh.Dial("tcp", sshAddr, sshConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to SSH server %s: %w", sshAddr, err)
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("failed to open SSH session: %w", err)
+	}
+	defer session.Close()
+
+	var stdout, stderr strings.Builder
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Run(cmdLine)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return "", fmt.Errorf("%s: %w: %s", cmdLine, err, strings.TrimSpace(stderr.String()))
+		}
+		return stdout.String(), nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
@@ -4,7 +4,9 @@ package connection
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/masterzen/winrm"
@@ -152,6 +154,37 @@ func (c *WinRMClient) Test(ctx context.Context) (*TestResult, error) {
 	}, nil
 }
 
+// Run executes a command line on the remote host through cmd.exe.
+// stdout and stderr are streamed to the given writers while the command runs;
+// stdin may be nil. Cancelling ctx terminates the remote command.
+// Returns the remote exit code.
+func (c *WinRMClient) Run(ctx context.Context, cmdLine string, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	slog.Info("WinRM: Running remote command",
+		"op", "winrm_run",
+		"host", c.config.Host)
+
+	if stdin != nil {
+		return c.client.RunWithContextWithInput(ctx, cmdLine, stdout, stderr, stdin)
+	}
+	return c.client.RunWithContext(ctx, cmdLine, stdout, stderr)
+}
+
+// LookPath resolves an executable on the remote host using where.exe.
+// Returns the first matching path or an error if the tool is not installed.
+func (c *WinRMClient) LookPath(ctx context.Context, name string) (string, error) {
+	var stdout, stderr strings.Builder
+	code, err := c.Run(ctx, "where "+name, nil, &stdout, &stderr)
+	if err != nil {
+		return "", fmt.Errorf("where %s: %w", name, err)
+	}
+	if code != 0 {
+		return "", fmt.Errorf("%s not found on %s", name, c.config.Host)
+	}
+
+	path := strings.TrimSpace(strings.SplitN(stdout.String(), "\n", 2)[0])
+	return path, nil
+}
+
 // Close closes the WinRM client.
 func (c *WinRMClient) Close() error {
 	// WinRM client doesn't have explicit close method
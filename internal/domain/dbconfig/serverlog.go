@@ -0,0 +1,12 @@
+package dbconfig
+
+import "time"
+
+// LogPosition is the end of the error log of a database server when a run
+// phase starts; what the server writes to the log after it covers the run.
+type LogPosition struct {
+	Path   string    `json:"path"`   // Path of the log file on the database server
+	Offset int64     `json:"offset"` // Size of the log file in bytes
+	Via    string    `json:"via"`    // How the log is read: SSH or WinRM
+	At     time.Time `json:"at"`     // When the position was taken
+}
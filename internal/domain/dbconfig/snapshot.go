@@ -0,0 +1,133 @@
+// Package dbconfig provides the target database configuration snapshot
+// captured before the run phase and stored with the history record.
+package dbconfig
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Snapshot is the configuration of the target database and its server at run time.
+type Snapshot struct {
+	CapturedAt   time.Time         `json:"captured_at"`
+	DatabaseType string            `json:"database_type"`
+	Version      string            `json:"version,omitempty"`
+	Settings     map[string]string `json:"settings,omitempty"` // e.g. SHOW GLOBAL VARIABLES, pg_settings
+	Server       ServerInfo        `json:"server"`
+	Errors       []string          `json:"errors,omitempty"` // Parts that could not be captured
+}
+
+// ServerInfo describes the database server host, as far as the database reports it.
+type ServerInfo struct {
+	Hostname string `json:"hostname,omitempty"`
+	OS       string `json:"os,omitempty"`
+	CPUs     int    `json:"cpus,omitempty"`
+	MemoryMB int64  `json:"memory_mb,omitempty"`
+}
+
+// volatileSettings change between runs without any configuration change and
+// are left out of diffs.
+var volatileSettings = map[string]bool{
+	"gtid_executed": true, // MySQL
+	"gtid_purged":   true, // MySQL
+}
+
+// Change is a setting that differs between two snapshots. Before or After is
+// empty when the setting exists on one side only.
+type Change struct {
+	Name   string `json:"name"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Diff returns the settings and server properties that differ between two
+// snapshots, sorted by name. Server properties are prefixed with "server.".
+func Diff(before, after *Snapshot) []Change {
+	if before == nil || after == nil {
+		return nil
+	}
+	return DiffValues(before.Values(), after.Values())
+}
+
+// DiffValues returns the names whose values differ between two maps, sorted by name.
+func DiffValues(before, after map[string]string) []Change {
+	var changes []Change
+	for name, value := range before {
+		if after[name] != value {
+			changes = append(changes, Change{Name: name, Before: value, After: after[name]})
+		}
+	}
+	for name, value := range after {
+		if _, ok := before[name]; !ok {
+			changes = append(changes, Change{Name: name, After: value})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// Values returns the settings and the known server properties by name,
+// without the volatile settings.
+func (s *Snapshot) Values() map[string]string {
+	values := make(map[string]string, len(s.Settings)+5)
+	for name, value := range s.Settings {
+		if !volatileSettings[name] {
+			values[name] = value
+		}
+	}
+	if s.Version != "" {
+		values["server.version"] = s.Version
+	}
+	if s.Server.Hostname != "" {
+		values["server.hostname"] = s.Server.Hostname
+	}
+	if s.Server.OS != "" {
+		values["server.os"] = s.Server.OS
+	}
+	if s.Server.CPUs > 0 {
+		values["server.cpus"] = strconv.Itoa(s.Server.CPUs)
+	}
+	if s.Server.MemoryMB > 0 {
+		values["server.memory_mb"] = strconv.FormatInt(s.Server.MemoryMB, 10)
+	}
+	return values
+}
+
+// Summary describes the snapshot in one line, e.g.
+// "8.0.36 on db1 (Linux, 16 CPUs, 64.0 GB), 612 settings".
+func (s *Snapshot) Summary() string {
+	version := s.Version
+	if version == "" {
+		version = s.DatabaseType
+	}
+
+	var host []string
+	if s.Server.OS != "" {
+		host = append(host, s.Server.OS)
+	}
+	if s.Server.CPUs > 0 {
+		host = append(host, fmt.Sprintf("%d CPUs", s.Server.CPUs))
+	}
+	if s.Server.MemoryMB > 0 {
+		host = append(host, fmt.Sprintf("%.1f GB", float64(s.Server.MemoryMB)/1024))
+	}
+
+	summary := version
+	if s.Server.Hostname != "" {
+		summary += " on " + s.Server.Hostname
+	}
+	if len(host) > 0 {
+		summary += " ("
+		for i, part := range host {
+			if i > 0 {
+				summary += ", "
+			}
+			summary += part
+		}
+		summary += ")"
+	}
+	return fmt.Sprintf("%s, %d settings", summary, len(s.Settings))
+}
@@ -0,0 +1,64 @@
+// Package dbconfig provides unit tests for configuration snapshots.
+package dbconfig
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	before := &Snapshot{
+		DatabaseType: "mysql",
+		Version:      "8.0.36",
+		Settings: map[string]string{
+			"innodb_buffer_pool_size": "134217728",
+			"max_connections":         "151",
+			"gtid_executed":           "abc:1-10",
+			"sql_mode":                "STRICT_TRANS_TABLES",
+		},
+		Server: ServerInfo{Hostname: "db1", CPUs: 8},
+	}
+	after := &Snapshot{
+		DatabaseType: "mysql",
+		Version:      "8.0.36",
+		Settings: map[string]string{
+			"innodb_buffer_pool_size": "8589934592",
+			"max_connections":         "151",
+			"gtid_executed":           "abc:1-99",
+			"binlog_format":           "ROW",
+		},
+		Server: ServerInfo{Hostname: "db1", CPUs: 16},
+	}
+
+	want := []Change{
+		{Name: "binlog_format", After: "ROW"},
+		{Name: "innodb_buffer_pool_size", Before: "134217728", After: "8589934592"},
+		{Name: "server.cpus", Before: "8", After: "16"},
+		{Name: "sql_mode", Before: "STRICT_TRANS_TABLES"},
+	}
+	if got := Diff(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+
+	if got := Diff(before, before); len(got) != 0 {
+		t.Errorf("Diff(same) = %+v, want none", got)
+	}
+	if got := Diff(nil, after); got != nil {
+		t.Errorf("Diff(nil) = %+v, want nil", got)
+	}
+}
+
+func TestSnapshot_Summary(t *testing.T) {
+	s := &Snapshot{
+		DatabaseType: "mysql",
+		Version:      "8.0.36",
+		Settings:     map[string]string{"max_connections": "151"},
+		Server:       ServerInfo{Hostname: "db1", OS: "Linux", CPUs: 16, MemoryMB: 65536},
+	}
+	if got, want := s.Summary(), "8.0.36 on db1 (Linux, 16 CPUs, 64.0 GB), 1 settings"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+	if got, want := (&Snapshot{DatabaseType: "postgresql"}).Summary(), "postgresql, 0 settings"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,115 @@
+// Package dberror classifies the database errors reported in the output of
+// benchmark tools, so that a run reports how many deadlocks, lock wait
+// timeouts, connection resets and constraint violations it hit rather than a
+// single error count.
+package dberror
+
+import "strings"
+
+// Class is a class of database error.
+type Class string
+
+const (
+	// Deadlock is a transaction rolled back to break a deadlock
+	// (MySQL 1213, PostgreSQL 40P01, Oracle ORA-00060, SQL Server 1205).
+	Deadlock Class = "deadlock"
+	// LockWaitTimeout is a statement that gave up waiting for a lock
+	// (MySQL 1205, PostgreSQL 55P03, Oracle ORA-00054, ORA-30006).
+	LockWaitTimeout Class = "lock_wait_timeout"
+	// ConnectionReset is a connection lost to the server
+	// (MySQL 2006, 2013, PostgreSQL 57P01, Oracle ORA-03113, ORA-03114).
+	ConnectionReset Class = "connection_reset"
+	// ConstraintViolation is a unique, foreign key or check constraint
+	// violation (MySQL 1062, 1451, 1452, PostgreSQL 23xxx, Oracle ORA-00001, ORA-02291).
+	ConstraintViolation Class = "constraint_violation"
+)
+
+// Classes lists the error classes in display order.
+var Classes = []Class{Deadlock, LockWaitTimeout, ConnectionReset, ConstraintViolation}
+
+// patterns are the lower case fragments of an output line that identify each
+// class. They are tried in the order of Classes, so a lock wait timeout that
+// mentions a deadlock counts as a deadlock.
+var patterns = map[Class][]string{
+	Deadlock: {
+		"deadlock", "error 1213", "errno = 1213", "40p01", "ora-00060",
+	},
+	LockWaitTimeout: {
+		"lock wait timeout", "lock timeout", "error 1205", "errno = 1205", "55p03",
+		"ora-00054", "ora-30006", "lock request time out",
+	},
+	ConnectionReset: {
+		"connection reset", "lost connection", "server has gone away", "broken pipe",
+		"server closed the connection unexpectedly", "terminating connection",
+		"error 2006", "error 2013", "errno = 2006", "errno = 2013", "57p01",
+		"ora-03113", "ora-03114", "ora-03135",
+	},
+	ConstraintViolation: {
+		"duplicate entry", "duplicate key", "unique constraint", "foreign key constraint",
+		"violates check constraint", "violates not-null constraint", "integrity constraint", "error 1062", "errno = 1062",
+		"ora-00001", "ora-02291", "ora-02292",
+	},
+}
+
+// ignoredLines are fragments of lines that repeat an error already reported
+// on the line before, such as sysbench's "FATAL: `thread_run' function
+// failed: ... SQL error, errno = 1213" after the failed query.
+var ignoredLines = []string{"thread_run' function failed"}
+
+// Classify returns the class of the error reported on a line of tool output,
+// or "" if the line reports no error of a known class.
+func Classify(line string) Class {
+	lower := strings.ToLower(line)
+	for _, fragment := range ignoredLines {
+		if strings.Contains(lower, fragment) {
+			return ""
+		}
+	}
+	for _, class := range Classes {
+		for _, fragment := range patterns[class] {
+			if strings.Contains(lower, fragment) {
+				return class
+			}
+		}
+	}
+	return ""
+}
+
+// Counts is the number of errors of each class seen in a run.
+type Counts map[Class]int64
+
+// Total returns the number of errors of all classes.
+func (c Counts) Total() int64 {
+	var n int64
+	for _, count := range c {
+		n += count
+	}
+	return n
+}
+
+// Merge returns the sum of c and other, leaving both unchanged. Returns nil
+// if both are empty.
+func (c Counts) Merge(other Counts) Counts {
+	if len(c) == 0 && len(other) == 0 {
+		return nil
+	}
+	merged := make(Counts, len(c)+len(other))
+	for class, n := range c {
+		merged[class] += n
+	}
+	for class, n := range other {
+		merged[class] += n
+	}
+	return merged
+}
+
+// Sorted returns the classes with errors in the order of Classes.
+func (c Counts) Sorted() []Class {
+	var classes []Class
+	for _, class := range Classes {
+		if c[class] > 0 {
+			classes = append(classes, class)
+		}
+	}
+	return classes
+}
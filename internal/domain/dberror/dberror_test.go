@@ -0,0 +1,55 @@
+package dberror
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestClassify tests classifying the error lines of sysbench, PostgreSQL and Oracle tools.
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		line string
+		want Class
+	}{
+		{"FATAL: mysql_drv_query() returned error 1213 (Deadlock found when trying to get lock; try restarting transaction) for query 'UPDATE sbtest1 SET k=k+1 WHERE id=5'", Deadlock},
+		{"FATAL: `thread_run' function failed: ./oltp_common.lua:488: SQL error, errno = 1213, state = '40001': Deadlock found", ""},
+		{"ERROR:  deadlock detected", Deadlock},
+		{"FATAL: mysql_drv_query() returned error 1205 (Lock wait timeout exceeded; try restarting transaction)", LockWaitTimeout},
+		{"ERROR:  canceling statement due to lock timeout", LockWaitTimeout},
+		{"FATAL: mysql_drv_query() returned error 2013 (Lost connection to MySQL server during query)", ConnectionReset},
+		{"read tcp 10.0.0.1:51234->10.0.0.2:3306: read: connection reset by peer", ConnectionReset},
+		{"FATAL: PQexec() failed: server closed the connection unexpectedly", ConnectionReset},
+		{"FATAL: mysql_drv_query() returned error 1062 (Duplicate entry '5' for key 'PRIMARY')", ConstraintViolation},
+		{`ERROR:  duplicate key value violates unique constraint "sbtest1_pkey"`, ConstraintViolation},
+		{"ORA-00001: unique constraint (SOE.ORDERS_PK) violated", ConstraintViolation},
+		{"[ 10s ] thds: 4 tps: 342.03 qps: 6846.39 (r/w/o: 4792.91/1369.02/684.46) lat (ms,95%): 13.46 err/s: 0.00", ""},
+		{"    ignored errors:                      12     (0.20 per sec.)", ""},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.line); got != tt.want {
+			t.Errorf("Classify(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+// TestCounts tests totalling, merging and ordering error counts.
+func TestCounts(t *testing.T) {
+	a := Counts{ConstraintViolation: 2, Deadlock: 3}
+	b := Counts{Deadlock: 1, LockWaitTimeout: 4}
+
+	merged := a.Merge(b)
+	if merged[Deadlock] != 4 || merged.Total() != 10 {
+		t.Errorf("Merge() = %v, want 4 deadlocks and 10 errors", merged)
+	}
+	if a[Deadlock] != 3 || len(a) != 2 {
+		t.Errorf("Merge() changed its receiver to %v", a)
+	}
+	if got := Counts(nil).Merge(nil); got != nil {
+		t.Errorf("Merge() of no counts = %v, want nil", got)
+	}
+
+	want := []Class{Deadlock, LockWaitTimeout, ConstraintViolation}
+	if got := merged.Sorted(); !slices.Equal(got, want) {
+		t.Errorf("Sorted() = %v, want %v", got, want)
+	}
+}
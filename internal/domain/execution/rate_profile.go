@@ -0,0 +1,96 @@
+// Package execution provides benchmark execution domain models.
+// Implements: REQ-EXEC-001 ~ REQ-EXEC-010
+package execution
+
+import (
+	"fmt"
+)
+
+// RateProfileKind is the shape of a rate profile.
+type RateProfileKind string
+
+const (
+	RateProfileStaircase RateProfileKind = "staircase" // Add StepRate every StepTime seconds
+	RateProfileRamp      RateProfileKind = "ramp"      // Go linearly from StartRate to TargetRate
+)
+
+// RateProfile varies the transaction rate limit (sysbench --rate) during the
+// run phase instead of holding it fixed. The run phase is split into steps of
+// StepTime seconds, each run with its own rate.
+type RateProfile struct {
+	Kind       RateProfileKind `json:"kind"`
+	StartRate  int             `json:"start_rate"`  // Transactions per second of the first step
+	StepRate   int             `json:"step_rate"`   // Transactions per second added per step (staircase)
+	TargetRate int             `json:"target_rate"` // Transactions per second of the last step (ramp)
+	StepTime   int             `json:"step_time"`   // Seconds per step
+}
+
+// RateStep is one step of a rate profile.
+type RateStep struct {
+	Offset   int // Seconds since the start of the run phase
+	Duration int // Seconds
+	Rate     int // Transactions per second
+}
+
+// Validate validates the profile.
+func (p *RateProfile) Validate() error {
+	switch p.Kind {
+	case RateProfileStaircase:
+		if p.StepRate <= 0 {
+			return fmt.Errorf("staircase step_rate must be positive")
+		}
+	case RateProfileRamp:
+		if p.TargetRate <= 0 {
+			return fmt.Errorf("ramp target_rate must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown rate profile kind: %q", p.Kind)
+	}
+	if p.StartRate <= 0 {
+		return fmt.Errorf("rate profile start_rate must be positive")
+	}
+	if p.StepTime <= 0 {
+		return fmt.Errorf("rate profile step_time must be positive")
+	}
+	return nil
+}
+
+// Steps splits a run phase of runTime seconds into the steps of the profile.
+// The last step is shortened to end with the run phase. A ramp reaches
+// TargetRate in its last step.
+func (p *RateProfile) Steps(runTime int) []RateStep {
+	if p.StepTime <= 0 || runTime <= 0 {
+		return nil
+	}
+
+	count := (runTime + p.StepTime - 1) / p.StepTime
+	steps := make([]RateStep, 0, count)
+	for i := 0; i < count; i++ {
+		step := RateStep{
+			Offset:   i * p.StepTime,
+			Duration: min(p.StepTime, runTime-i*p.StepTime),
+			Rate:     p.StartRate,
+		}
+		switch p.Kind {
+		case RateProfileStaircase:
+			step.Rate = p.StartRate + i*p.StepRate
+		case RateProfileRamp:
+			if count > 1 {
+				step.Rate = p.StartRate + (p.TargetRate-p.StartRate)*i/(count-1)
+			}
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// RateAt returns the rate of the step that second of the run phase falls in,
+// or 0 if it is outside the run phase.
+func (p *RateProfile) RateAt(second, runTime int) int {
+	for _, step := range p.Steps(runTime) {
+		if second >= step.Offset && second < step.Offset+step.Duration {
+			return step.Rate
+		}
+	}
+	return 0
+}
@@ -0,0 +1,96 @@
+package execution
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestRateProfile_Steps tests splitting the run phase into rate steps.
+func TestRateProfile_Steps(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile RateProfile
+		runTime int
+		want    []RateStep
+	}{
+		{
+			name:    "staircase",
+			profile: RateProfile{Kind: RateProfileStaircase, StartRate: 100, StepRate: 50, StepTime: 30},
+			runTime: 90,
+			want: []RateStep{
+				{Offset: 0, Duration: 30, Rate: 100},
+				{Offset: 30, Duration: 30, Rate: 150},
+				{Offset: 60, Duration: 30, Rate: 200},
+			},
+		},
+		{
+			name:    "staircase with short last step",
+			profile: RateProfile{Kind: RateProfileStaircase, StartRate: 100, StepRate: 50, StepTime: 40},
+			runTime: 90,
+			want: []RateStep{
+				{Offset: 0, Duration: 40, Rate: 100},
+				{Offset: 40, Duration: 40, Rate: 150},
+				{Offset: 80, Duration: 10, Rate: 200},
+			},
+		},
+		{
+			name:    "ramp",
+			profile: RateProfile{Kind: RateProfileRamp, StartRate: 100, TargetRate: 400, StepTime: 10},
+			runTime: 40,
+			want: []RateStep{
+				{Offset: 0, Duration: 10, Rate: 100},
+				{Offset: 10, Duration: 10, Rate: 200},
+				{Offset: 20, Duration: 10, Rate: 300},
+				{Offset: 30, Duration: 10, Rate: 400},
+			},
+		},
+		{
+			name:    "ramp down",
+			profile: RateProfile{Kind: RateProfileRamp, StartRate: 300, TargetRate: 100, StepTime: 10},
+			runTime: 30,
+			want: []RateStep{
+				{Offset: 0, Duration: 10, Rate: 300},
+				{Offset: 10, Duration: 10, Rate: 200},
+				{Offset: 20, Duration: 10, Rate: 100},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.profile.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got := tt.profile.Steps(tt.runTime); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Steps(%d) = %+v, want %+v", tt.runTime, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRateProfile_RateAt tests looking up the rate of a second of the run phase.
+func TestRateProfile_RateAt(t *testing.T) {
+	profile := RateProfile{Kind: RateProfileStaircase, StartRate: 100, StepRate: 50, StepTime: 30}
+
+	for second, want := range map[int]int{0: 100, 29: 100, 30: 150, 89: 200, 90: 0, -1: 0} {
+		if got := profile.RateAt(second, 90); got != want {
+			t.Errorf("RateAt(%d) = %d, want %d", second, got, want)
+		}
+	}
+}
+
+// TestRateProfile_Validate tests rejecting incomplete profiles.
+func TestRateProfile_Validate(t *testing.T) {
+	invalid := []RateProfile{
+		{Kind: "sine", StartRate: 100, StepTime: 10},
+		{Kind: RateProfileStaircase, StartRate: 100, StepTime: 10},
+		{Kind: RateProfileRamp, StartRate: 100, StepTime: 10},
+		{Kind: RateProfileRamp, StartRate: 0, TargetRate: 100, StepTime: 10},
+		{Kind: RateProfileStaircase, StartRate: 100, StepRate: 10},
+	}
+	for _, profile := range invalid {
+		if err := profile.Validate(); err == nil {
+			t.Errorf("Validate(%+v) error = nil, want error", profile)
+		}
+	}
+}
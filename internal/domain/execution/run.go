@@ -5,7 +5,13 @@ package execution
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/assertion"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dberror"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/replication"
 )
 
 // Run represents a single execution of a benchmark task.
@@ -31,6 +37,30 @@ type Run struct {
 
 	// Work directory for storing logs and artifacts
 	WorkDir string `json:"work_dir,omitempty"`
+
+	// Report/sample interval actually used by the run
+	SampleInterval time.Duration `json:"sample_interval,omitempty"`
+
+	// Template parameters the run used: template defaults with the task's overrides
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// Target database configuration captured before the run phase
+	ConfigSnapshot *dbconfig.Snapshot `json:"config_snapshot,omitempty"`
+
+	// Replica lag and errors polled during the run phase (nil = not monitored)
+	Replication *replication.Stats `json:"replication,omitempty"`
+
+	// Database errors in the tool output of the run phase by class (nil = none seen)
+	ErrorClasses dberror.Counts `json:"error_classes,omitempty"`
+
+	// Why and where the run was made, copied from its task
+	Metadata RunMetadata `json:"metadata"`
+
+	// SLA targets of the run: the template's, overridden by the task's
+	Assertions assertion.Targets `json:"assertions"`
+
+	// Outcome of the SLA targets, evaluated when the run completes (nil = no targets)
+	AssertionOutcome *assertion.Outcome `json:"assertion_outcome,omitempty"`
 }
 
 // BenchmarkResult represents the parsed result of a benchmark execution.
@@ -49,6 +79,7 @@ type BenchmarkResult struct {
 	LatencySum    float64 `json:"latency_sum_ms"`     // Sum of all latencies (ms)
 	ErrorCount    int64   `json:"error_count"`        // Total errors
 	ErrorRate     float64 `json:"error_rate_percent"` // Error rate (%)
+	TpmC          float64 `json:"tpmc,omitempty"`     // New-order transactions per minute (TPC-C workloads)
 
 	// Statistics
 	Duration          time.Duration `json:"duration"`                // Run duration
@@ -73,11 +104,13 @@ type BenchmarkResult struct {
 	ExecTimeStddev float64 `json:"exec_time_stddev,omitempty"` // Execution time stddev
 
 	// Connection and Template Info (for History)
-	ConnectionName string    `json:"connection_name,omitempty"` // Connection name
-	TemplateName   string    `json:"template_name,omitempty"`   // Template name
-	DatabaseType   string    `json:"database_type,omitempty"`   // Database type
-	Threads        int       `json:"threads,omitempty"`         // Thread count
-	StartTime      time.Time `json:"start_time,omitempty"`      // Benchmark start time
+	ConnectionName string        `json:"connection_name,omitempty"` // Connection name
+	TemplateName   string        `json:"template_name,omitempty"`   // Template name
+	DatabaseType   string        `json:"database_type,omitempty"`   // Database type
+	Threads        int           `json:"threads,omitempty"`         // Thread count
+	Agents         []string      `json:"agents,omitempty"`          // Load-generator agents whose samples were aggregated
+	StartTime      time.Time     `json:"start_time,omitempty"`      // Benchmark start time
+	SampleInterval time.Duration `json:"sample_interval,omitempty"` // Report/sample interval
 
 	// Time series data
 	TimeSeries []MetricSample `json:"time_series,omitempty"` // Time series metrics
@@ -123,6 +156,31 @@ func (r *Run) CalculateDuration() {
 	}
 }
 
+// EvaluateAssertions evaluates the SLA targets of the run on its result.
+// Runs without a result or without targets get no outcome.
+func (r *Run) EvaluateAssertions() {
+	if r.Result == nil {
+		return
+	}
+	r.AssertionOutcome = assertion.Evaluate(r.Assertions, assertion.Metrics{
+		TPS:        r.Result.TPSCalculated,
+		LatencyP95: r.Result.LatencyP95,
+		ErrorRate:  r.Result.ErrorPercent(),
+	})
+}
+
+// ErrorPercent returns the errors of the run in percent of its transactions:
+// the error rate reported by the tool, or else the ignored errors.
+func (r *BenchmarkResult) ErrorPercent() float64 {
+	if r.ErrorRate > 0 || r.IgnoredErrors == 0 {
+		return r.ErrorRate
+	}
+	if r.TotalTransactions == 0 {
+		return 100
+	}
+	return float64(r.IgnoredErrors) / float64(r.TotalTransactions) * 100
+}
+
 // ToJSON serializes the run to JSON.
 func (r *Run) ToJSON() ([]byte, error) {
 	return json.Marshal(r)
@@ -152,9 +210,19 @@ type BenchmarkTask struct {
 	Parameters   map[string]interface{} `json:"parameters"`    // Parameter overrides
 	Options      TaskOptions            `json:"options"`       // Execution options
 	Tags         []string               `json:"tags"`          // Tags
+	Metadata     RunMetadata            `json:"metadata"`      // Purpose, ticket and environment
+	Assertions   assertion.Targets      `json:"assertions"`    // SLA targets, overriding the template's
 	CreatedAt    time.Time              `json:"created_at"`
 }
 
+// RunMetadata describes why and where a benchmark was run, so its results
+// can still be interpreted months later. It is saved with every history record.
+type RunMetadata struct {
+	Purpose     string `json:"purpose,omitempty"`     // Why the benchmark was run, e.g. "index change on orders"
+	Ticket      string `json:"ticket,omitempty"`      // Change ticket or PR link
+	Environment string `json:"environment,omitempty"` // Environment name, e.g. "staging", "prod-replica"
+}
+
 // Validate validates the task configuration.
 func (t *BenchmarkTask) Validate() error {
 	if t.ID == "" {
@@ -169,17 +237,115 @@ func (t *BenchmarkTask) Validate() error {
 	if t.TemplateID == "" {
 		return fmt.Errorf("template_id is required")
 	}
+	if t.Options.Repeat < 0 || t.Options.Repeat > MaxRepeat {
+		return fmt.Errorf("repeat must be between 0 and %d", MaxRepeat)
+	}
+	if t.Options.OutlierSigma < 0 {
+		return fmt.Errorf("outlier_sigma must not be negative")
+	}
+	if err := t.Assertions.Validate(); err != nil {
+		return err
+	}
+	if t.Options.RateProfile != nil {
+		if err := t.Options.RateProfile.Validate(); err != nil {
+			return err
+		}
+	}
+	if t.Options.RemoteWinRM && t.Options.Agent != "" {
+		return fmt.Errorf("remote_winrm and agent cannot be used together")
+	}
+	if len(t.Options.Agents) > 0 {
+		if t.Options.RemoteWinRM || t.Options.Agent != "" {
+			return fmt.Errorf("agents cannot be used together with remote_winrm or agent")
+		}
+		for i, name := range t.Options.Agents {
+			if name == "" {
+				return fmt.Errorf("agent name is required")
+			}
+			if slices.Contains(t.Options.Agents[:i], name) {
+				return fmt.Errorf("agent %s is listed twice", name)
+			}
+		}
+	}
 	return nil
 }
 
+// MaxRepeat is the largest number of repetitions of a task.
+const MaxRepeat = 100
+
+// DefaultOutlierSigma is the k used when a repeated task does not set OutlierSigma.
+const DefaultOutlierSigma = 2.0
+
 // TaskOptions represents execution options for a task.
 // Implements: spec.md 3.4.1
 type TaskOptions struct {
-	SkipPrepare    bool          `json:"skip_prepare"`    // Skip data preparation
-	SkipCleanup    bool          `json:"skip_cleanup"`    // Skip data cleanup
-	WarmupTime     int           `json:"warmup_time"`     // Warmup duration (seconds)
-	SampleInterval time.Duration `json:"sample_interval"` // Sample interval (default 1s)
-	DryRun         bool          `json:"dry_run"`         // Show commands only, don't execute (REQ-EXEC-010)
-	PrepareTimeout time.Duration `json:"prepare_timeout"` // Prepare phase timeout (default 30m)
-	RunTimeout     time.Duration `json:"run_timeout"`     // Run phase timeout (default 24h)
+	SkipPrepare      bool          `json:"skip_prepare"`           // Skip data preparation
+	SkipCleanup      bool          `json:"skip_cleanup"`           // Skip data cleanup
+	WarmupTime       int           `json:"warmup_time"`            // Warmup duration (seconds)
+	SampleInterval   time.Duration `json:"sample_interval"`        // Sample interval (0 = adaptive, see AdaptiveSampleInterval)
+	DryRun           bool          `json:"dry_run"`                // Show commands only, don't execute (REQ-EXEC-010)
+	PrepareTimeout   time.Duration `json:"prepare_timeout"`        // Prepare phase timeout (default 30m)
+	RunTimeout       time.Duration `json:"run_timeout"`            // Run phase timeout (default 24h)
+	RemoteWinRM      bool          `json:"remote_winrm"`           // Run the tool on the SQL Server host via WinRM
+	Agent            string        `json:"agent,omitempty"`        // Run the tool on this load-generator agent (settings agent name)
+	Agents           []string      `json:"agents,omitempty"`       // Run the workload on all of these agents at once, as one aggregated run
+	KeepArtifacts    bool          `json:"keep_artifacts"`         // Keep the work directory under data/runs/<run-id>
+	Repeat           int           `json:"repeat"`                 // Run the workload N times and aggregate (0 or 1 = once)
+	OutlierSigma     float64       `json:"outlier_sigma"`          // Runs outside mean ± k·σ TPS are outliers (0 = DefaultOutlierSigma)
+	RateProfile      *RateProfile  `json:"rate_profile,omitempty"` // Varies the rate limit during the run phase (nil = fixed rate)
+	MonitorReplicas  bool          `json:"monitor_replicas"`       // Poll the replica lag of the connection's replicas during the run phase
+	CollectServerLog bool          `json:"collect_server_log"`     // Save the database error log written during the run to the run artifacts
+}
+
+// Remote reports whether the tool runs on another host, via WinRM or an agent.
+func (o TaskOptions) Remote() bool {
+	return o.RemoteWinRM || len(o.LoadGenerators()) > 0
+}
+
+// LoadGenerators returns the agents the workload runs on, or nil if it runs
+// on this machine or via WinRM.
+func (o TaskOptions) LoadGenerators() []string {
+	if o.Agent != "" {
+		return []string{o.Agent}
+	}
+	return o.Agents
+}
+
+// Repetitions returns the number of times the workload is run.
+func (o TaskOptions) Repetitions() int {
+	if o.Repeat < 1 {
+		return 1
+	}
+	return o.Repeat
+}
+
+// OutlierK returns the k of the k·σ outlier rule.
+func (o TaskOptions) OutlierK() float64 {
+	if o.OutlierSigma <= 0 {
+		return DefaultOutlierSigma
+	}
+	return o.OutlierSigma
+}
+
+// AdaptiveSampleInterval returns the report/sample interval for a planned run duration.
+// Short runs keep 1s resolution; long runs use coarser intervals so that
+// multi-hour benchmarks do not flood storage and the UI.
+func AdaptiveSampleInterval(runTime time.Duration) time.Duration {
+	switch {
+	case runTime < 10*time.Minute:
+		return time.Second
+	case runTime < time.Hour:
+		return 5 * time.Second
+	default:
+		return 30 * time.Second
+	}
+}
+
+// ResolveSampleInterval returns the sample interval to use for a run of the given
+// planned duration. An explicit SampleInterval overrides the adaptive default.
+func (o TaskOptions) ResolveSampleInterval(runTime time.Duration) time.Duration {
+	if o.SampleInterval > 0 {
+		return o.SampleInterval
+	}
+	return AdaptiveSampleInterval(runTime)
 }
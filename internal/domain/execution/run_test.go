@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/assertion"
 )
 
 // TestRun_SetState tests state setting with validation.
@@ -26,6 +28,7 @@ func TestRun_SetState(t *testing.T) {
 	}{
 		{"valid: pending -> preparing", StatePending, StatePreparing, false},
 		{"valid: preparing -> prepared", StatePreparing, StatePrepared, false},
+		{"valid: pending -> failed (pre-check)", StatePending, StateFailed, false},
 		{"valid: running -> completed", StateRunning, StateCompleted, false},
 		{"invalid: pending -> completed", StatePending, StateCompleted, true},
 		{"invalid: completed -> running", StateCompleted, StateRunning, true},
@@ -179,6 +182,39 @@ func TestBenchmarkTask_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "winrm and agent",
+			task: BenchmarkTask{
+				ID:           uuid.New().String(),
+				Name:         "Test Task",
+				ConnectionID: uuid.New().String(),
+				TemplateID:   "sysbench-oltp-read-write",
+				Options:      TaskOptions{RemoteWinRM: true, Agent: "loadgen-1"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "agent group",
+			task: BenchmarkTask{
+				ID:           uuid.New().String(),
+				Name:         "Test Task",
+				ConnectionID: uuid.New().String(),
+				TemplateID:   "sysbench-oltp-read-write",
+				Options:      TaskOptions{Agents: []string{"loadgen-1", "loadgen-2"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "agent group with duplicate agent",
+			task: BenchmarkTask{
+				ID:           uuid.New().String(),
+				Name:         "Test Task",
+				ConnectionID: uuid.New().String(),
+				TemplateID:   "sysbench-oltp-read-write",
+				Options:      TaskOptions{Agents: []string{"loadgen-1", "loadgen-1"}},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -249,6 +285,30 @@ func TestBenchmarkResult(t *testing.T) {
 	}
 }
 
+// TestRun_EvaluateAssertions tests SLA targets on the result, including
+// errors derived from ignored errors.
+func TestRun_EvaluateAssertions(t *testing.T) {
+	minTPS, maxErrors := 1000.0, 1.0
+	run := &Run{
+		Assertions: assertion.Targets{MinTPS: &minTPS, MaxErrorRate: &maxErrors},
+		Result:     &BenchmarkResult{TPSCalculated: 1200, TotalTransactions: 1000, IgnoredErrors: 20},
+	}
+
+	run.EvaluateAssertions()
+	if run.AssertionOutcome == nil || run.AssertionOutcome.Passed {
+		t.Fatalf("AssertionOutcome = %+v, want failed", run.AssertionOutcome)
+	}
+	if failed := run.AssertionOutcome.Failed(); len(failed) != 1 || failed[0] != "max_error_rate" {
+		t.Errorf("Failed() = %v, want [max_error_rate]", failed)
+	}
+
+	run.Assertions = assertion.Targets{}
+	run.EvaluateAssertions()
+	if run.AssertionOutcome != nil {
+		t.Errorf("AssertionOutcome = %+v, want nil without targets", run.AssertionOutcome)
+	}
+}
+
 // TestTaskOptions tests task options structure.
 func TestTaskOptions(t *testing.T) {
 	options := TaskOptions{
@@ -268,3 +328,40 @@ func TestTaskOptions(t *testing.T) {
 		t.Errorf("SkipCleanup = %v, want %v", options.SkipCleanup, true)
 	}
 }
+
+// TestAdaptiveSampleInterval tests interval selection by planned duration.
+func TestAdaptiveSampleInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		runTime time.Duration
+		want    time.Duration
+	}{
+		{"one minute", time.Minute, time.Second},
+		{"just under ten minutes", 10*time.Minute - time.Second, time.Second},
+		{"ten minutes", 10 * time.Minute, 5 * time.Second},
+		{"just under one hour", time.Hour - time.Second, 5 * time.Second},
+		{"one hour", time.Hour, 30 * time.Second},
+		{"eight hours", 8 * time.Hour, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AdaptiveSampleInterval(tt.runTime); got != tt.want {
+				t.Errorf("AdaptiveSampleInterval(%v) = %v, want %v", tt.runTime, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestTaskOptions_ResolveSampleInterval tests that an explicit interval overrides the adaptive one.
+func TestTaskOptions_ResolveSampleInterval(t *testing.T) {
+	auto := TaskOptions{}
+	if got := auto.ResolveSampleInterval(2 * time.Hour); got != 30*time.Second {
+		t.Errorf("ResolveSampleInterval() = %v, want %v", got, 30*time.Second)
+	}
+
+	override := TaskOptions{SampleInterval: 2 * time.Second}
+	if got := override.ResolveSampleInterval(2 * time.Hour); got != 2*time.Second {
+		t.Errorf("ResolveSampleInterval() = %v, want %v", got, 2*time.Second)
+	}
+}
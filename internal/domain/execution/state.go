@@ -43,9 +43,9 @@ func (s RunState) IsTerminal() bool {
 func (s RunState) CanTransitionTo(target RunState) bool {
 	// Define valid state transitions
 	transitions := map[RunState][]RunState{
-		StatePending:   {StatePreparing, StateCancelled},
+		StatePending:   {StatePreparing, StateFailed, StateCancelled},
 		StatePreparing: {StatePrepared, StateFailed, StateCancelled, StateTimeout},
-		StatePrepared:  {StateWarmingUp, StateCancelled},
+		StatePrepared:  {StateWarmingUp, StateFailed, StateCancelled},
 		StateWarmingUp: {StateRunning, StateFailed, StateCancelled, StateTimeout},
 		StateRunning:   {StateCompleted, StateFailed, StateCancelled, StateTimeout, StateForceStopped},
 	}
@@ -0,0 +1,176 @@
+// Package heatmap provides the latency heatmap of the samples of a run: time
+// on the x axis, latency buckets on a log scale on the y axis, and as the
+// color of each cell how many of the per-second p95 and p99 latencies of its
+// time window fall into its bucket. Periodic stalls such as checkpoints or
+// vacuum show as recurring cells far above the band of the other samples,
+// which averages over the run hide.
+package heatmap
+
+import (
+	"fmt"
+	"html"
+	"image/color"
+	"math"
+	"strings"
+	"time"
+)
+
+// DefaultRows is the number of latency buckets of a heatmap.
+const DefaultRows = 20
+
+// Sample is the latencies reported for one interval of a run.
+type Sample struct {
+	Offset    time.Duration // Since the first sample
+	Latencies []float64     // ms, e.g. the p95 and p99 of the interval; values <= 0 are ignored
+}
+
+// Heatmap is the count of sample latencies in each cell of time windows and
+// latency buckets.
+type Heatmap struct {
+	Starts []time.Duration // Offset of the first sample of each column
+	Bounds []float64       // Bucket bounds in ms, ascending: row i is [Bounds[i], Bounds[i+1])
+	Counts [][]int         // Counts[column][row]
+	Max    int             // Largest count of a cell
+}
+
+// New builds the heatmap of samples, in order of time, with at most
+// maxColumns columns of consecutive samples and rows latency buckets
+// between the lowest and the highest latency. It returns nil without
+// latencies.
+func New(samples []Sample, maxColumns, rows int) *Heatmap {
+	lo, hi := math.Inf(1), 0.0
+	for _, s := range samples {
+		for _, v := range s.Latencies {
+			if v > 0 {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+	}
+	if hi == 0 || maxColumns < 1 || rows < 1 {
+		return nil
+	}
+	if lo == hi {
+		lo, hi = lo/2, hi*2
+	}
+
+	h := &Heatmap{Bounds: make([]float64, rows+1)}
+	for i := range h.Bounds {
+		h.Bounds[i] = lo * math.Pow(hi/lo, float64(i)/float64(rows))
+	}
+	perColumn := (len(samples) + maxColumns - 1) / maxColumns
+	for i, s := range samples {
+		if i%perColumn == 0 {
+			h.Starts = append(h.Starts, s.Offset)
+			h.Counts = append(h.Counts, make([]int, rows))
+		}
+		column := h.Counts[len(h.Counts)-1]
+		for _, v := range s.Latencies {
+			if v <= 0 {
+				continue
+			}
+			row := h.Row(v)
+			column[row]++
+			h.Max = max(h.Max, column[row])
+		}
+	}
+	return h
+}
+
+// Rows returns the number of latency buckets.
+func (h *Heatmap) Rows() int {
+	return len(h.Bounds) - 1
+}
+
+// Row returns the bucket of a latency in ms, clamped to the buckets.
+func (h *Heatmap) Row(latency float64) int {
+	lo, hi := h.Bounds[0], h.Bounds[len(h.Bounds)-1]
+	row := int(math.Log(latency/lo) / math.Log(hi/lo) * float64(h.Rows()))
+	return min(max(row, 0), h.Rows()-1)
+}
+
+// Density returns the count of a cell relative to the largest count, 0 to 1.
+func (h *Heatmap) Density(column, row int) float64 {
+	if h.Max == 0 {
+		return 0
+	}
+	return float64(h.Counts[column][row]) / float64(h.Max)
+}
+
+// Color returns the color of a density: transparent for empty cells, then
+// from light yellow through orange to dark red.
+func Color(density float64) color.NRGBA {
+	if density <= 0 {
+		return color.NRGBA{}
+	}
+	density = math.Min(density, 1)
+	stops := []color.NRGBA{
+		{R: 0xff, G: 0xf3, B: 0xb0, A: 0xff},
+		{R: 0xfd, G: 0x8d, B: 0x3c, A: 0xff},
+		{R: 0xb1, G: 0x00, B: 0x26, A: 0xff},
+	}
+	pos := density * float64(len(stops)-1)
+	i := min(int(pos), len(stops)-2)
+	f := pos - float64(i)
+	mix := func(a, b uint8) uint8 { return uint8(math.Round(float64(a) + f*(float64(b)-float64(a)))) }
+	a, b := stops[i], stops[i+1]
+	return color.NRGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: 0xff}
+}
+
+// FormatLatency formats a bucket bound in ms for an axis label.
+func FormatLatency(ms float64) string {
+	switch {
+	case ms >= 1000:
+		return fmt.Sprintf("%.1fs", ms/1000)
+	case ms >= 10:
+		return fmt.Sprintf("%.0fms", ms)
+	default:
+		return fmt.Sprintf("%.2fms", ms)
+	}
+}
+
+// RenderSVG renders the heatmap as an SVG image with latency labels on the
+// y axis, time labels on the x axis and a tooltip on each cell.
+func (h *Heatmap) RenderSVG(title string, width, height int) string {
+	const left, right, top, bottom = 70, 20, 40, 40
+	plotW, plotH := float64(width-left-right), float64(height-top-bottom)
+	cellW, cellH := plotW/float64(len(h.Counts)), plotH/float64(h.Rows())
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n", width, height, width, height)
+	fmt.Fprintf(&sb, `<text x="%d" y="20" font-size="14" font-weight="bold">%s</text>`+"\n", left, html.EscapeString(title))
+	fmt.Fprintf(&sb, `<rect x="%d" y="%d" width="%.1f" height="%.1f" fill="#fafafa" stroke="#888"/>`+"\n", left, top, plotW, plotH)
+
+	for c, counts := range h.Counts {
+		for row, n := range counts {
+			if n == 0 {
+				continue
+			}
+			col := Color(h.Density(c, row))
+			fmt.Fprintf(&sb, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="#%02x%02x%02x"><title>%s: %s-%s, %d sample(s)</title></rect>`+"\n",
+				left+cellW*float64(c), top+plotH-cellH*float64(row+1), cellW, cellH, col.R, col.G, col.B,
+				formatOffset(h.Starts[c]), FormatLatency(h.Bounds[row]), FormatLatency(h.Bounds[row+1]), n)
+		}
+	}
+
+	// Latency labels at five bucket bounds, time labels at five columns
+	for i := 0; i <= 4; i++ {
+		row := h.Rows() * i / 4
+		y := top + plotH - cellH*float64(row)
+		fmt.Fprintf(&sb, `<text x="%d" y="%.1f" text-anchor="end">%s</text>`+"\n", left-6, y+4, FormatLatency(h.Bounds[row]))
+	}
+	for i, last := 0, -1; i <= 4; i++ {
+		c := (len(h.Counts) - 1) * i / 4
+		if c == last {
+			continue
+		}
+		last = c
+		fmt.Fprintf(&sb, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`+"\n", left+cellW*(float64(c)+0.5), top+plotH+18, formatOffset(h.Starts[c]))
+	}
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// formatOffset formats a time offset for an axis label, e.g. 1m5s.
+func formatOffset(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
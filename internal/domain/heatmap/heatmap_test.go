@@ -0,0 +1,111 @@
+// Package heatmap provides unit tests for the latency heatmap.
+package heatmap
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// stallSamples returns a minute of samples at 5-10ms with a 200ms stall
+// every 20 seconds.
+func stallSamples() []Sample {
+	var samples []Sample
+	for i := range 60 {
+		p95, p99 := 5.0, 10.0
+		if i%20 == 19 {
+			p95, p99 = 150, 200
+		}
+		samples = append(samples, Sample{Offset: time.Duration(i) * time.Second, Latencies: []float64{p95, p99}})
+	}
+	return samples
+}
+
+func TestNew(t *testing.T) {
+	h := New(stallSamples(), 120, DefaultRows)
+	if h == nil {
+		t.Fatal("New() = nil")
+	}
+	if len(h.Counts) != 60 || h.Rows() != DefaultRows {
+		t.Fatalf("New() has %d columns and %d rows, want 60 and %d", len(h.Counts), h.Rows(), DefaultRows)
+	}
+	if h.Bounds[0] != 5 || h.Bounds[DefaultRows] != 200 {
+		t.Errorf("Bounds = %v, want 5 to 200", h.Bounds)
+	}
+
+	// The stalls are in the top row, the other samples far below
+	top := DefaultRows - 1
+	for c, counts := range h.Counts {
+		stall := c%20 == 19
+		if (counts[top] == 1) != stall {
+			t.Errorf("column %d top row = %d, stall %v", c, counts[top], stall)
+		}
+	}
+	if h.Counts[0][0] != 1 || h.Counts[0][h.Row(10)] != 1 {
+		t.Errorf("column 0 = %v, want p95 and p99 in their buckets", h.Counts[0])
+	}
+	if h.Max != 1 || h.Density(0, 0) != 1 || h.Density(0, top) != 0 {
+		t.Errorf("Max = %d, densities %v/%v", h.Max, h.Density(0, 0), h.Density(0, top))
+	}
+}
+
+func TestNew_Columns(t *testing.T) {
+	h := New(stallSamples(), 25, DefaultRows)
+	// 3 samples per column
+	if len(h.Counts) != 20 || h.Starts[1] != 3*time.Second {
+		t.Fatalf("New() has %d columns starting at %v, want 20 of 3s", len(h.Counts), h.Starts)
+	}
+	if h.Max != 3 || h.Counts[0][0] != 3 {
+		t.Errorf("Max = %d, column 0 = %v, want 3 samples in bucket 0", h.Max, h.Counts[0])
+	}
+}
+
+func TestNew_Empty(t *testing.T) {
+	if h := New(nil, 120, DefaultRows); h != nil {
+		t.Errorf("New() without samples = %+v, want nil", h)
+	}
+	if h := New([]Sample{{Latencies: []float64{0, -1}}}, 120, DefaultRows); h != nil {
+		t.Errorf("New() without latencies = %+v, want nil", h)
+	}
+
+	// A single latency spans a bucket range around it
+	h := New([]Sample{{Latencies: []float64{8}}}, 120, DefaultRows)
+	if h == nil || h.Bounds[0] != 4 || h.Bounds[DefaultRows] != 16 || h.Counts[0][h.Row(8)] != 1 {
+		t.Errorf("New() of one latency = %+v", h)
+	}
+}
+
+func TestColor(t *testing.T) {
+	if c := Color(0); c.A != 0 {
+		t.Errorf("Color(0) = %v, want transparent", c)
+	}
+	low, high := Color(0.1), Color(1)
+	if low.A != 0xff || high.A != 0xff {
+		t.Errorf("Color() alpha = %d/%d, want opaque", low.A, high.A)
+	}
+	if high.G >= low.G {
+		t.Errorf("Color(1) = %v is not darker than Color(0.1) = %v", high, low)
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	svg := New(stallSamples(), 120, DefaultRows).RenderSVG("Latency <p95/p99>", 800, 300)
+	for _, want := range []string{"<svg", "Latency &lt;p95/p99&gt;", "19s: ", "200ms", "5.00ms", "</svg>"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("RenderSVG() does not contain %q", want)
+		}
+	}
+	// One cell per occupied bucket: 57 columns with 2 buckets, 3 stalls with 2
+	if n := strings.Count(svg, "<title>"); n != 120 {
+		t.Errorf("RenderSVG() has %d cells, want 120", n)
+	}
+}
+
+func TestFormatLatency(t *testing.T) {
+	tests := map[float64]string{0.5: "0.50ms", 12.4: "12ms", 1500: "1.5s"}
+	for ms, want := range tests {
+		if got := FormatLatency(ms); got != want {
+			t.Errorf("FormatLatency(%v) = %q, want %q", ms, got, want)
+		}
+	}
+}
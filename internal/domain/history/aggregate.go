@@ -0,0 +1,161 @@
+package history
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// AggregateTagPrefix prefixes the history tag that groups the runs of a
+// repeated task ("repeat:<aggregate-id>").
+const AggregateTagPrefix = "repeat:"
+
+// OutlierTag is added to the history records of runs flagged as outliers.
+const OutlierTag = "outlier"
+
+// Aggregate summarizes the runs of a task that was repeated N times.
+// It is stored alongside the history records of the individual runs.
+type Aggregate struct {
+	ID             string    `json:"id"`   // UUID, also used in the history tag
+	Name           string    `json:"name"` // Task name
+	ConnectionName string    `json:"connection_name"`
+	TemplateName   string    `json:"template_name"`
+	DatabaseType   string    `json:"database_type"`
+	Threads        int       `json:"threads"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	Repeat       int      `json:"repeat"`             // Planned repetitions
+	RunIDs       []string `json:"run_ids"`            // Completed runs (= history record IDs), in order
+	Outliers     []string `json:"outliers,omitempty"` // Runs whose TPS is outside mean ± k·σ
+	OutlierSigma float64  `json:"outlier_sigma"`      // k
+
+	// Statistics over the runs that are not outliers
+	TPS        MetricStats `json:"tps"`
+	QPS        MetricStats `json:"qps"`
+	LatencyAvg MetricStats `json:"latency_avg_ms"`
+	LatencyP95 MetricStats `json:"latency_p95_ms"`
+	LatencyP99 MetricStats `json:"latency_p99_ms"`
+}
+
+// MetricStats holds summary statistics of one metric across runs.
+type MetricStats struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stddev"` // Sample standard deviation
+	CV     float64 `json:"cv"`     // Coefficient of variation (StdDev / Mean)
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+}
+
+// Tag returns the history tag that groups the runs of the aggregate.
+func (a *Aggregate) Tag() string {
+	return AggregateTagPrefix + a.ID
+}
+
+// IsOutlier reports whether the run was flagged as an outlier.
+func (a *Aggregate) IsOutlier(runID string) bool {
+	return slices.Contains(a.Outliers, runID)
+}
+
+// NewAggregate computes the aggregate of the records of a repeated task.
+// Runs whose TPS lies more than k standard deviations from the mean of all
+// runs are flagged as outliers and left out of the statistics.
+func NewAggregate(records []*Record, k float64) *Aggregate {
+	a := &Aggregate{OutlierSigma: k}
+	if len(records) == 0 {
+		return a
+	}
+
+	first := records[0]
+	a.ConnectionName = first.ConnectionName
+	a.TemplateName = first.TemplateName
+	a.DatabaseType = first.DatabaseType
+	a.Threads = first.Threads
+
+	tps := make([]float64, len(records))
+	for i, r := range records {
+		a.RunIDs = append(a.RunIDs, r.ID)
+		tps[i] = r.TPSCalculated
+	}
+
+	outliers := FindOutliers(tps, k)
+	var kept []*Record
+	for i, r := range records {
+		if slices.Contains(outliers, i) {
+			a.Outliers = append(a.Outliers, r.ID)
+			continue
+		}
+		kept = append(kept, r)
+	}
+
+	a.TPS = statsOf(kept, func(r *Record) float64 { return r.TPSCalculated })
+	a.QPS = statsOf(kept, recordQPS)
+	a.LatencyAvg = statsOf(kept, func(r *Record) float64 { return r.LatencyAvg })
+	a.LatencyP95 = statsOf(kept, func(r *Record) float64 { return r.LatencyP95 })
+	a.LatencyP99 = statsOf(kept, func(r *Record) float64 { return r.LatencyP99 })
+	return a
+}
+
+// FindOutliers returns the indexes of the values more than k sample standard
+// deviations away from the mean. Fewer than three values have no outliers.
+func FindOutliers(values []float64, k float64) []int {
+	if len(values) < 3 {
+		return nil
+	}
+	stats := ComputeStats(values)
+	if stats.StdDev == 0 {
+		return nil
+	}
+
+	var outliers []int
+	for i, v := range values {
+		if math.Abs(v-stats.Mean) > k*stats.StdDev {
+			outliers = append(outliers, i)
+		}
+	}
+	return outliers
+}
+
+// ComputeStats returns the summary statistics of values.
+func ComputeStats(values []float64) MetricStats {
+	if len(values) == 0 {
+		return MetricStats{}
+	}
+
+	stats := MetricStats{Min: values[0], Max: values[0]}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+		stats.Min = math.Min(stats.Min, v)
+		stats.Max = math.Max(stats.Max, v)
+	}
+	stats.Mean = sum / float64(len(values))
+
+	if len(values) > 1 {
+		squares := 0.0
+		for _, v := range values {
+			squares += (v - stats.Mean) * (v - stats.Mean)
+		}
+		stats.StdDev = math.Sqrt(squares / float64(len(values)-1))
+	}
+	if stats.Mean != 0 {
+		stats.CV = stats.StdDev / stats.Mean
+	}
+	return stats
+}
+
+// statsOf computes the statistics of one metric of records.
+func statsOf(records []*Record, metric func(*Record) float64) MetricStats {
+	values := make([]float64, len(records))
+	for i, r := range records {
+		values[i] = metric(r)
+	}
+	return ComputeStats(values)
+}
+
+// recordQPS returns the average queries per second of a record.
+func recordQPS(r *Record) float64 {
+	if r.Duration <= 0 {
+		return 0
+	}
+	return float64(r.TotalQueries) / r.Duration.Seconds()
+}
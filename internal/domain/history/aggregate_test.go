@@ -0,0 +1,82 @@
+package history
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestComputeStats tests mean, sample standard deviation and CV.
+func TestComputeStats(t *testing.T) {
+	stats := ComputeStats([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+
+	if stats.Mean != 5 || stats.Min != 2 || stats.Max != 9 {
+		t.Errorf("ComputeStats() = %+v, want mean 5, min 2, max 9", stats)
+	}
+	if want := math.Sqrt(32.0 / 7); math.Abs(stats.StdDev-want) > 1e-9 {
+		t.Errorf("StdDev = %v, want %v", stats.StdDev, want)
+	}
+	if math.Abs(stats.CV-stats.StdDev/5) > 1e-9 {
+		t.Errorf("CV = %v, want StdDev/Mean", stats.CV)
+	}
+
+	if single := ComputeStats([]float64{42}); single.StdDev != 0 || single.CV != 0 || single.Mean != 42 {
+		t.Errorf("ComputeStats(one value) = %+v", single)
+	}
+	if empty := ComputeStats(nil); empty != (MetricStats{}) {
+		t.Errorf("ComputeStats(nil) = %+v, want zero", empty)
+	}
+}
+
+// TestFindOutliers tests the k·σ outlier rule.
+func TestFindOutliers(t *testing.T) {
+	values := []float64{1000, 1010, 990, 1005, 995, 1000, 400}
+
+	if got := FindOutliers(values, 2); len(got) != 1 || got[0] != 6 {
+		t.Errorf("FindOutliers(k=2) = %v, want [6]", got)
+	}
+	if got := FindOutliers(values, 3); len(got) != 0 {
+		t.Errorf("FindOutliers(k=3) = %v, want none", got)
+	}
+	if got := FindOutliers([]float64{100, 500}, 0.1); len(got) != 0 {
+		t.Errorf("FindOutliers(two values) = %v, want none", got)
+	}
+	if got := FindOutliers([]float64{7, 7, 7}, 1); len(got) != 0 {
+		t.Errorf("FindOutliers(equal values) = %v, want none", got)
+	}
+}
+
+// TestNewAggregate tests that outliers are flagged and excluded from the statistics.
+func TestNewAggregate(t *testing.T) {
+	var records []*Record
+	for i, tps := range []float64{1000, 1010, 990, 1005, 995, 1000, 400} {
+		records = append(records, &Record{
+			ID:             fmt.Sprintf("run-%d", i),
+			ConnectionName: "mysql-prod",
+			TemplateName:   "oltp_read_write",
+			Threads:        16,
+			TPSCalculated:  tps,
+			TotalQueries:   int64(tps * 20 * 60),
+			Duration:       time.Minute,
+		})
+	}
+
+	a := NewAggregate(records, 2)
+
+	if len(a.RunIDs) != 7 || a.ConnectionName != "mysql-prod" || a.Threads != 16 {
+		t.Errorf("NewAggregate() = %+v", a)
+	}
+	if !a.IsOutlier("run-6") || len(a.Outliers) != 1 {
+		t.Errorf("Outliers = %v, want [run-6]", a.Outliers)
+	}
+	if a.TPS.Mean != 1000 || a.TPS.Min != 990 {
+		t.Errorf("TPS = %+v, want mean 1000 without the outlier", a.TPS)
+	}
+	if math.Abs(a.QPS.Mean-20000) > 1e-6 {
+		t.Errorf("QPS mean = %v, want 20000", a.QPS.Mean)
+	}
+	if a.Tag() != AggregateTagPrefix+a.ID {
+		t.Errorf("Tag() = %q", a.Tag())
+	}
+}
@@ -0,0 +1,186 @@
+package history
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// AnomalyKind identifies what an anomaly of a run is.
+type AnomalyKind string
+
+const (
+	// AnomalyTPSDrop is a stretch of samples whose TPS fell more than
+	// TPSDropPercent below the rolling baseline.
+	AnomalyTPSDrop AnomalyKind = "tps_drop"
+	// AnomalyErrorSpike is a stretch of samples whose error rate rose more
+	// than ErrorRateIncrease percentage points above the rolling baseline.
+	AnomalyErrorSpike AnomalyKind = "error_spike"
+)
+
+// Defaults of AnomalyOptions.
+const (
+	DefaultAnomalyWindow            = 10
+	DefaultAnomalyTPSDropPercent    = 30.0
+	DefaultAnomalyErrorRateIncrease = 1.0
+)
+
+// minAnomalyBaseline is the number of samples the baseline needs before
+// samples are compared with it.
+const minAnomalyBaseline = 5
+
+// AnomalyOptions are the thresholds of anomaly detection. Zero values take
+// the defaults.
+type AnomalyOptions struct {
+	Window            int     // Samples in the rolling baseline
+	TPSDropPercent    float64 // Drop of TPS below the baseline, in percent
+	ErrorRateIncrease float64 // Rise of the error rate above the baseline, in percentage points
+}
+
+// withDefaults returns the options with zero values replaced by the defaults.
+func (o AnomalyOptions) withDefaults() AnomalyOptions {
+	if o.Window <= 0 {
+		o.Window = DefaultAnomalyWindow
+	}
+	if o.TPSDropPercent <= 0 {
+		o.TPSDropPercent = DefaultAnomalyTPSDropPercent
+	}
+	if o.ErrorRateIncrease <= 0 {
+		o.ErrorRateIncrease = DefaultAnomalyErrorRateIncrease
+	}
+	return o
+}
+
+// Anomaly is a stretch of consecutive samples of a run that deviate from the
+// rolling baseline of the samples before them.
+type Anomaly struct {
+	Kind     AnomalyKind   `json:"kind"`
+	Start    time.Time     `json:"start"`  // Timestamp of the first sample
+	End      time.Time     `json:"end"`    // Timestamp of the last sample
+	Offset   time.Duration `json:"offset"` // Of the first sample since the first sample of the run
+	Samples  int           `json:"samples"`
+	Baseline float64       `json:"baseline"` // Baseline TPS or error rate (%)
+	Value    float64       `json:"value"`    // Lowest TPS or highest error rate (%) of the stretch
+}
+
+// EndOffset returns the offset of the last sample since the first sample of the run.
+func (a Anomaly) EndOffset() time.Duration {
+	return a.Offset + a.End.Sub(a.Start)
+}
+
+// String describes the anomaly, e.g.
+// "15:04:05-15:04:12 (+1m5s) tps_drop: 420.00 TPS vs baseline 1200.00 (-65.0%)".
+func (a Anomaly) String() string {
+	when := fmt.Sprintf("%s-%s (+%s)", a.Start.Format("15:04:05"), a.End.Format("15:04:05"), a.Offset.Round(time.Second))
+	if a.Kind == AnomalyErrorSpike {
+		return fmt.Sprintf("%s %s: %.2f%% errors vs baseline %.2f%%", when, a.Kind, a.Value, a.Baseline)
+	}
+	change := 0.0
+	if a.Baseline > 0 {
+		change = (a.Value - a.Baseline) / a.Baseline * 100
+	}
+	return fmt.Sprintf("%s %s: %.2f TPS vs baseline %.2f (%+.1f%%)", when, a.Kind, a.Value, a.Baseline, change)
+}
+
+// AnomalyDetector finds anomalies in the samples of a run as they are added.
+// Each metric has a rolling baseline of its last Window normal samples;
+// anomalous samples are left out of it, so that a drop does not lower its own
+// baseline. A deviation that lasts Window samples is taken as the new level:
+// its anomaly ends and the baseline restarts from its samples.
+type AnomalyDetector struct {
+	opts      AnomalyOptions
+	first     time.Time
+	started   bool
+	tps       anomalyTracker
+	errorRate anomalyTracker
+	anomalies []Anomaly
+}
+
+// anomalyTracker keeps the baseline and the open anomaly of one metric.
+type anomalyTracker struct {
+	baseline []float64
+	pending  []float64 // Values of the open anomaly
+	open     int       // Index of the open anomaly in the detector's anomalies, -1 if none
+}
+
+// NewAnomalyDetector creates a detector with the given options.
+func NewAnomalyDetector(opts AnomalyOptions) *AnomalyDetector {
+	return &AnomalyDetector{
+		opts:      opts.withDefaults(),
+		tps:       anomalyTracker{open: -1},
+		errorRate: anomalyTracker{open: -1},
+	}
+}
+
+// Add adds the next sample of the run phase.
+func (d *AnomalyDetector) Add(sample MetricSample) {
+	if !d.started {
+		d.first, d.started = sample.Timestamp, true
+	}
+	drop := 1 - d.opts.TPSDropPercent/100
+	d.add(&d.tps, AnomalyTPSDrop, sample, sample.TPS, func(v, base float64) bool { return base > 0 && v < base*drop })
+	d.add(&d.errorRate, AnomalyErrorSpike, sample, sample.ErrorRate, func(v, base float64) bool { return v > base+d.opts.ErrorRateIncrease })
+}
+
+// add adds the value of one metric, opening, extending or closing its anomaly.
+func (d *AnomalyDetector) add(t *anomalyTracker, kind AnomalyKind, sample MetricSample, v float64, deviates func(v, base float64) bool) {
+	if len(t.baseline) < minAnomalyBaseline || !deviates(v, mean(t.baseline)) {
+		t.pending, t.open = nil, -1
+		t.baseline = append(t.baseline, v)
+		if len(t.baseline) > d.opts.Window {
+			t.baseline = t.baseline[len(t.baseline)-d.opts.Window:]
+		}
+		return
+	}
+
+	if t.open < 0 {
+		d.anomalies = append(d.anomalies, Anomaly{
+			Kind:     kind,
+			Start:    sample.Timestamp,
+			Offset:   sample.Timestamp.Sub(d.first),
+			Baseline: mean(t.baseline),
+			Value:    v,
+		})
+		t.open = len(d.anomalies) - 1
+	}
+	a := &d.anomalies[t.open]
+	a.End = sample.Timestamp
+	a.Samples++
+	if (kind == AnomalyTPSDrop && v < a.Value) || (kind == AnomalyErrorSpike && v > a.Value) {
+		a.Value = v
+	}
+	t.pending = append(t.pending, v)
+
+	// A lasting deviation is the new level
+	if len(t.pending) >= d.opts.Window {
+		t.baseline, t.pending, t.open = t.pending, nil, -1
+	}
+}
+
+// Anomalies returns the anomalies found so far, in order of their start.
+// The last anomaly of each kind may still be growing.
+func (d *AnomalyDetector) Anomalies() []Anomaly {
+	return slices.Clone(d.anomalies)
+}
+
+// DetectAnomalies returns the anomalies of the run phase of samples; samples
+// without a phase, from records saved before phases were tracked, count as
+// the run phase.
+func DetectAnomalies(samples []MetricSample, opts AnomalyOptions) []Anomaly {
+	d := NewAnomalyDetector(opts)
+	for _, sample := range samples {
+		if sample.Phase == "run" || sample.Phase == "" {
+			d.Add(sample)
+		}
+	}
+	return d.Anomalies()
+}
+
+// mean returns the mean of values.
+func mean(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,96 @@
+package history
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// newAnomalySamples returns one run-phase sample per second with the given
+// TPS and no errors.
+func newAnomalySamples(tps ...float64) []MetricSample {
+	start := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+	samples := make([]MetricSample, len(tps))
+	for i, v := range tps {
+		samples[i] = MetricSample{Timestamp: start.Add(time.Duration(i) * time.Second), Phase: "run", TPS: v}
+	}
+	return samples
+}
+
+// TestDetectAnomalies_TPSDrop tests that a stall is one anomaly measured
+// against the samples before it.
+func TestDetectAnomalies_TPSDrop(t *testing.T) {
+	samples := newAnomalySamples(1000, 1010, 990, 1000, 1000, 1000, 300, 200, 250, 1000, 990, 1000)
+	anomalies := DetectAnomalies(samples, AnomalyOptions{})
+	if len(anomalies) != 1 {
+		t.Fatalf("DetectAnomalies() = %v, want one drop", anomalies)
+	}
+	a := anomalies[0]
+	if a.Kind != AnomalyTPSDrop || a.Offset != 6*time.Second || a.EndOffset() != 8*time.Second || a.Samples != 3 {
+		t.Errorf("anomaly = %+v, want a drop from 6s to 8s", a)
+	}
+	if a.Baseline != 1000 || a.Value != 200 {
+		t.Errorf("anomaly baseline/value = %v/%v, want 1000/200", a.Baseline, a.Value)
+	}
+	if s := a.String(); !strings.Contains(s, "02:00:06-02:00:08 (+6s) tps_drop") || !strings.Contains(s, "(-80.0%)") {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+// TestDetectAnomalies_Threshold tests that drops within the threshold and
+// samples before the baseline is formed are not anomalies.
+func TestDetectAnomalies_Threshold(t *testing.T) {
+	samples := newAnomalySamples(100, 10, 1000, 1000, 1000, 1000, 750, 1000)
+	if anomalies := DetectAnomalies(samples, AnomalyOptions{TPSDropPercent: 50}); len(anomalies) != 0 {
+		t.Errorf("DetectAnomalies() = %v, want none", anomalies)
+	}
+}
+
+// TestDetectAnomalies_NewLevel tests that a lasting drop becomes the baseline.
+func TestDetectAnomalies_NewLevel(t *testing.T) {
+	tps := []float64{1000, 1000, 1000, 1000, 1000}
+	for range 8 {
+		tps = append(tps, 500)
+	}
+	tps = append(tps, 200)
+	anomalies := DetectAnomalies(newAnomalySamples(tps...), AnomalyOptions{Window: 5})
+	if len(anomalies) != 2 {
+		t.Fatalf("DetectAnomalies() = %v, want two drops", anomalies)
+	}
+	if anomalies[0].Samples != 5 || anomalies[1].Baseline != 500 || anomalies[1].Value != 200 {
+		t.Errorf("DetectAnomalies() = %+v", anomalies)
+	}
+}
+
+// TestDetectAnomalies_ErrorSpike tests error spikes and that samples of other
+// phases are skipped.
+func TestDetectAnomalies_ErrorSpike(t *testing.T) {
+	samples := newAnomalySamples(0, 1000, 1000, 1000, 1000, 1000, 1000, 1000)
+	samples[0].Phase = "warmup"
+	samples[0].ErrorRate = 50
+	samples[6].ErrorRate = 0.5
+	samples[7].ErrorRate = 4
+	anomalies := DetectAnomalies(samples, AnomalyOptions{})
+	if len(anomalies) != 1 {
+		t.Fatalf("DetectAnomalies() = %v, want one spike", anomalies)
+	}
+	if a := anomalies[0]; a.Kind != AnomalyErrorSpike || a.Offset != 6*time.Second || a.Value != 4 {
+		t.Errorf("anomaly = %+v, want a 4%% spike at 6s", a)
+	}
+}
+
+// TestAnomalyDetector tests that anomalies are reported while they grow.
+func TestAnomalyDetector(t *testing.T) {
+	d := NewAnomalyDetector(AnomalyOptions{})
+	for _, sample := range newAnomalySamples(1000, 1000, 1000, 1000, 1000, 100) {
+		d.Add(sample)
+	}
+	anomalies := d.Anomalies()
+	if len(anomalies) != 1 || anomalies[0].Samples != 1 {
+		t.Fatalf("Anomalies() = %v, want one open drop", anomalies)
+	}
+	d.Add(MetricSample{Timestamp: anomalies[0].Start.Add(time.Second), TPS: 50})
+	if anomalies := d.Anomalies(); len(anomalies) != 1 || anomalies[0].Samples != 2 || anomalies[0].Value != 50 {
+		t.Errorf("Anomalies() = %v, want the drop extended", anomalies)
+	}
+}
@@ -4,7 +4,15 @@ package history
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/assertion"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dberror"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/replication"
 )
 
 // MetricSample represents a single metric sample (time series data).
@@ -22,7 +30,7 @@ type MetricSample struct {
 }
 
 // Record represents a saved benchmark run history record.
-// Only successful runs are saved to history.
+// Failed and cancelled runs are saved with their State and have no results.
 type Record struct {
 	// Basic information
 	ID        string    `json:"id"`         // Run ID (UUID)
@@ -34,12 +42,52 @@ type Record struct {
 	DatabaseType   string `json:"database_type"`   // Database type (MySQL/PostgreSQL)
 	Threads        int    `json:"threads"`         // Thread count
 
+	// Load-generator agents that ran the workload together; their samples
+	// and results are aggregated into this record (empty = one host)
+	Agents []string `json:"agents,omitempty"`
+
+	// User labels (e.g. "before-tuning", "innodb_buffer_pool=32G"),
+	// usable for filtering and grouping comparisons
+	Tags []string `json:"tags,omitempty"`
+
+	// Free-text annotation
+	Notes string `json:"notes,omitempty"`
+
+	// Why and where the run was made, entered with its task
+	Purpose     string `json:"purpose,omitempty"`     // e.g. "index change on orders"
+	Ticket      string `json:"ticket,omitempty"`      // Change ticket or PR link
+	Environment string `json:"environment,omitempty"` // e.g. "staging", "prod-replica"
+
+	// Final state of the run when it did not complete (e.g. "failed",
+	// "cancelled"); empty for completed runs
+	State        string `json:"state,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"` // Why the run failed
+
+	// Outcome of the user-defined sanity checks (nil = not checked, counts as valid)
+	Validity *Validity `json:"validity,omitempty"`
+
+	// Outcome of the SLA targets of the run (nil = no targets)
+	Assertions *assertion.Outcome `json:"assertions,omitempty"`
+
+	// Template parameters the run used: template defaults with the task's overrides
+	Parameters map[string]string `json:"parameters,omitempty"`
+
+	// Target database configuration captured before the run phase
+	ConfigSnapshot *dbconfig.Snapshot `json:"config_snapshot,omitempty"`
+
+	// Replica lag and errors polled during the run phase (nil = not monitored)
+	Replication *replication.Stats `json:"replication,omitempty"`
+
 	// Timing
 	StartTime time.Time     `json:"start_time"` // Benchmark start time
 	Duration  time.Duration `json:"duration"`   // Run duration
 
+	// Report/sample interval used for the time series
+	SampleInterval time.Duration `json:"sample_interval,omitempty"`
+
 	// Core metrics
 	TPSCalculated float64 `json:"tps_calculated"` // Calculated TPS
+	TpmC          float64 `json:"tpmc,omitempty"` // New-order transactions per minute (TPC-C workloads)
 
 	// Latency (ms)
 	LatencyAvg float64 `json:"latency_avg_ms"` // Average latency (ms)
@@ -59,8 +107,9 @@ type Record struct {
 	TotalTransactions int64 `json:"total_transactions"` // Total transactions
 
 	// Errors and Reconnects
-	IgnoredErrors int64 `json:"ignored_errors"` // Ignored errors
-	Reconnects    int64 `json:"reconnects"`     // Reconnects
+	IgnoredErrors int64          `json:"ignored_errors"`          // Ignored errors
+	ErrorClasses  dberror.Counts `json:"error_classes,omitempty"` // Errors in the tool output by class
+	Reconnects    int64          `json:"reconnects"`              // Reconnects
 
 	// General Statistics
 	TotalTime   float64 `json:"total_time_seconds"` // Total time in seconds
@@ -87,3 +136,69 @@ func (r *Record) GetTimeSeriesSize() int {
 	}
 	return len(data)
 }
+
+// IsCompleted reports whether the run completed and has results.
+func (r *Record) IsCompleted() bool {
+	return r.State == ""
+}
+
+// HasTag reports whether the record carries the given tag.
+func (r *Record) HasTag(tag string) bool {
+	for _, t := range r.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllTags reports whether the record carries every one of the given tags.
+func (r *Record) HasAllTags(tags []string) bool {
+	for _, tag := range tags {
+		if !r.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseTags splits a comma separated tag list (e.g. "baseline, innodb_buffer_pool=32G").
+func ParseTags(s string) []string {
+	return NormalizeTags(strings.Split(s, ","))
+}
+
+// NormalizeTags trims whitespace and removes empty and duplicate tags, keeping the original order.
+func NormalizeTags(tags []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		result = append(result, tag)
+	}
+	return result
+}
+
+// ParseAge parses a record age such as "90d", "2w" or "36h".
+// Days and weeks are accepted in addition to time.ParseDuration units.
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil || count <= 0 {
+				return 0, fmt.Errorf("invalid age: %q", s)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid age: %q", s)
+	}
+	return d, nil
+}
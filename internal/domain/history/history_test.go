@@ -0,0 +1,60 @@
+// Package history provides unit tests for history record helpers.
+package history
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTags(t *testing.T) {
+	got := ParseTags(" baseline, innodb_buffer_pool=32G,,baseline ,after ")
+	want := []string{"baseline", "innodb_buffer_pool=32G", "after"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseTags() = %v, want %v", got, want)
+	}
+
+	if got := ParseTags("  "); got != nil {
+		t.Errorf("ParseTags(blank) = %v, want nil", got)
+	}
+}
+
+func TestRecord_HasAllTags(t *testing.T) {
+	record := &Record{Tags: []string{"baseline", "innodb_buffer_pool=32G"}}
+
+	if !record.HasAllTags([]string{"innodb_buffer_pool=32G"}) {
+		t.Error("HasAllTags() = false, want true for a present tag")
+	}
+	if !record.HasAllTags(nil) {
+		t.Error("HasAllTags(nil) = false, want true")
+	}
+	if record.HasAllTags([]string{"baseline", "after"}) {
+		t.Error("HasAllTags() = true, want false when a tag is missing")
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"90d", 90 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"36h", 36 * time.Hour, false},
+		{"0d", 0, true},
+		{"d", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAge(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAge(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package history
+
+import "time"
+
+// RaceTagPrefix prefixes the history tag that pairs the two runs of a race
+// ("race:<race-id>").
+const RaceTagPrefix = "race:"
+
+// raceTieThreshold is the relative TPS difference below which a race is a tie.
+const raceTieThreshold = 0.01
+
+// Race is a paired comparison: the same task run against two connections at
+// the same time ("A/B race"), e.g. MySQL vs PostgreSQL or primary vs replica.
+// It is stored alongside the history records of the two runs.
+type Race struct {
+	ID           string    `json:"id"`   // UUID, also used in the history tag
+	Name         string    `json:"name"` // Task name
+	TemplateName string    `json:"template_name"`
+	Threads      int       `json:"threads"`
+	CreatedAt    time.Time `json:"created_at"`
+	A            RaceSide  `json:"a"`
+	B            RaceSide  `json:"b"`
+}
+
+// RaceSide is the run of one connection in a race.
+type RaceSide struct {
+	ConnectionName string  `json:"connection_name"`
+	DatabaseType   string  `json:"database_type"`
+	RunID          string  `json:"run_id"`          // History record ID; empty if the run did not start
+	State          string  `json:"state,omitempty"` // Empty when completed, like Record.State
+	ErrorMessage   string  `json:"error_message,omitempty"`
+	TPS            float64 `json:"tps"`
+	QPS            float64 `json:"qps"`
+	LatencyAvg     float64 `json:"latency_avg_ms"`
+	LatencyP95     float64 `json:"latency_p95_ms"`
+	ErrorRate      float64 `json:"error_rate"` // Percent of transactions
+}
+
+// NewRaceSide returns the side of a race that ran as record.
+func NewRaceSide(record *Record) RaceSide {
+	return RaceSide{
+		ConnectionName: record.ConnectionName,
+		DatabaseType:   record.DatabaseType,
+		RunID:          record.ID,
+		State:          record.State,
+		ErrorMessage:   record.ErrorMessage,
+		TPS:            record.TPSCalculated,
+		QPS:            recordQPS(record),
+		LatencyAvg:     record.LatencyAvg,
+		LatencyP95:     record.LatencyP95,
+		ErrorRate:      errorRate(record),
+	}
+}
+
+// Completed reports whether the run of the side completed.
+func (s RaceSide) Completed() bool {
+	return s.RunID != "" && s.State == ""
+}
+
+// Tag returns the history tag that pairs the runs of the race.
+func (r *Race) Tag() string {
+	return RaceTagPrefix + r.ID
+}
+
+// Completed reports whether both runs completed.
+func (r *Race) Completed() bool {
+	return r.A.Completed() && r.B.Completed()
+}
+
+// Winner returns the side with the higher TPS: "A" or "B". It returns ""
+// when either run did not complete or their TPS differs by less than 1%.
+func (r *Race) Winner() string {
+	if !r.Completed() {
+		return ""
+	}
+	switch delta := r.TPSDelta() / 100; {
+	case delta >= raceTieThreshold:
+		return "B"
+	case delta <= -raceTieThreshold:
+		return "A"
+	}
+	return ""
+}
+
+// TPSDelta returns the TPS of B relative to A, in percent.
+func (r *Race) TPSDelta() float64 {
+	return percentDelta(r.A.TPS, r.B.TPS)
+}
+
+// LatencyP95Delta returns the p95 latency of B relative to A, in percent.
+func (r *Race) LatencyP95Delta() float64 {
+	return percentDelta(r.A.LatencyP95, r.B.LatencyP95)
+}
+
+// percentDelta returns the change from a to b in percent, or 0 if a is 0.
+func percentDelta(a, b float64) float64 {
+	if a == 0 {
+		return 0
+	}
+	return (b - a) / a * 100
+}
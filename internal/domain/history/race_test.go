@@ -0,0 +1,39 @@
+package history
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestRace_Winner tests the winner, the deltas and ties of a race.
+func TestRace_Winner(t *testing.T) {
+	a := NewRaceSide(&Record{ID: "run-a", ConnectionName: "mysql-primary", TPSCalculated: 1000, LatencyP95: 20,
+		TotalTransactions: 1000, IgnoredErrors: 10, TotalQueries: 20000, Duration: 10 * time.Second})
+	b := NewRaceSide(&Record{ID: "run-b", ConnectionName: "mysql-replica", TPSCalculated: 1200, LatencyP95: 15})
+	race := &Race{ID: "race-1", A: a, B: b}
+
+	if a.QPS != 2000 || a.ErrorRate != 1 {
+		t.Errorf("NewRaceSide() = %+v, want 2000 QPS and 1%% errors", a)
+	}
+	if race.Winner() != "B" {
+		t.Errorf("Winner() = %q, want B", race.Winner())
+	}
+	if math.Abs(race.TPSDelta()-20) > 1e-9 || math.Abs(race.LatencyP95Delta()+25) > 1e-9 {
+		t.Errorf("deltas = %.2f%% TPS, %.2f%% p95; want +20%%, -25%%", race.TPSDelta(), race.LatencyP95Delta())
+	}
+	if race.Tag() != "race:race-1" {
+		t.Errorf("Tag() = %q", race.Tag())
+	}
+
+	race.B.TPS = 1005
+	if race.Winner() != "" {
+		t.Errorf("Winner() with 0.5%% difference = %q, want a tie", race.Winner())
+	}
+
+	race.B.TPS = 100
+	race.B.State = "failed"
+	if race.Completed() || race.Winner() != "" {
+		t.Errorf("race with a failed side: Completed() = %v, Winner() = %q; want false, no winner", race.Completed(), race.Winner())
+	}
+}
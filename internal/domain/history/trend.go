@@ -0,0 +1,280 @@
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultTrendWindow is the number of runs in the rolling mean of a trend.
+const DefaultTrendWindow = 5
+
+// MinTrendRuns is the number of runs a configuration needs to form a trend:
+// the latest run and at least two earlier runs to compare it with.
+const MinTrendRuns = 3
+
+// changePointSegment is the number of runs compared on each side of a
+// candidate change point.
+const changePointSegment = 3
+
+// changePointScore is how many standard errors the means of the runs before
+// and after a change point must differ by.
+const changePointScore = 3.0
+
+// minChangePercent is the smallest level shift, relative to the level before
+// it, reported as a change point; it keeps very stable series from reporting
+// insignificant shifts.
+const minChangePercent = 5.0
+
+// Fingerprint identifies the configuration of a run: connection, template,
+// database type, threads, load generators and template parameters. Runs with
+// the same fingerprint are recurring runs of one benchmark, whose results
+// can be followed over time.
+func (r *Record) Fingerprint() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%d\x00", r.ConnectionName, r.TemplateName, r.DatabaseType, r.Threads)
+	agents := slices.Clone(r.Agents)
+	slices.Sort(agents)
+	fmt.Fprintf(h, "%s\x00", strings.Join(agents, ","))
+	names := make([]string, 0, len(r.Parameters))
+	for name := range r.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "%s=%s\x00", name, r.Parameters[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+// TrendPoint is one run of a trend.
+type TrendPoint struct {
+	RecordID   string    `json:"record_id"`
+	StartTime  time.Time `json:"start_time"`
+	TPS        float64   `json:"tps"`
+	LatencyP95 float64   `json:"latency_p95_ms"`
+
+	// Rolling means over the window of runs ending with this one
+	TPSMean        float64 `json:"tps_mean"`
+	LatencyP95Mean float64 `json:"latency_p95_mean_ms"`
+
+	// The level of TPS or p95 latency shifts from this run on
+	ChangePoint bool `json:"change_point,omitempty"`
+}
+
+// Trend follows the TPS and p95 latency of the recurring runs of one
+// configuration over calendar time.
+type Trend struct {
+	Fingerprint    string            `json:"fingerprint"`
+	ConnectionName string            `json:"connection_name"`
+	TemplateName   string            `json:"template_name"`
+	DatabaseType   string            `json:"database_type"`
+	Threads        int               `json:"threads"`
+	Parameters     map[string]string `json:"parameters,omitempty"`
+	Window         int               `json:"window"` // Runs in the rolling mean
+	Points         []TrendPoint      `json:"points"` // Oldest first
+}
+
+// TrendDeviation is how far the latest run of a trend lies from the mean of
+// the runs before it, in percent of that mean.
+type TrendDeviation struct {
+	RecordID          string  `json:"record_id"`
+	Baseline          int     `json:"baseline"` // Earlier runs averaged
+	TPSPercent        float64 `json:"tps_percent"`
+	LatencyP95Percent float64 `json:"latency_p95_percent"`
+}
+
+// Exceeds reports whether TPS or p95 latency deviates by more than
+// threshold percent, in either direction.
+func (d TrendDeviation) Exceeds(threshold float64) bool {
+	return math.Abs(d.TPSPercent) > threshold || math.Abs(d.LatencyP95Percent) > threshold
+}
+
+// NewTrend computes the trend of the records of one configuration with a
+// rolling mean over window runs. Runs that did not complete or failed their
+// sanity checks are left out.
+func NewTrend(records []*Record, window int) *Trend {
+	if window < 1 {
+		window = DefaultTrendWindow
+	}
+	t := &Trend{Window: window}
+
+	var runs []*Record
+	for _, r := range records {
+		if r.IsCompleted() && r.IsValid() {
+			runs = append(runs, r)
+		}
+	}
+	if len(runs) == 0 {
+		return t
+	}
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].StartTime.Before(runs[j].StartTime) })
+
+	latest := runs[len(runs)-1]
+	t.Fingerprint = latest.Fingerprint()
+	t.ConnectionName = latest.ConnectionName
+	t.TemplateName = latest.TemplateName
+	t.DatabaseType = latest.DatabaseType
+	t.Threads = latest.Threads
+	t.Parameters = latest.Parameters
+
+	tps := make([]float64, len(runs))
+	p95 := make([]float64, len(runs))
+	for i, r := range runs {
+		tps[i] = r.TPSCalculated
+		p95[i] = r.LatencyP95
+	}
+	tpsMean := rollingMean(tps, window)
+	p95Mean := rollingMean(p95, window)
+	changes := append(ChangePoints(tps), ChangePoints(p95)...)
+
+	t.Points = make([]TrendPoint, len(runs))
+	for i, r := range runs {
+		t.Points[i] = TrendPoint{
+			RecordID:       r.ID,
+			StartTime:      r.StartTime,
+			TPS:            tps[i],
+			LatencyP95:     p95[i],
+			TPSMean:        tpsMean[i],
+			LatencyP95Mean: p95Mean[i],
+			ChangePoint:    slices.Contains(changes, i),
+		}
+	}
+	return t
+}
+
+// GroupTrends groups records by fingerprint and returns the trends of the
+// configurations with at least MinTrendRuns runs, most recently run first.
+func GroupTrends(records []*Record, window int) []*Trend {
+	groups := make(map[string][]*Record)
+	for _, r := range records {
+		fp := r.Fingerprint()
+		groups[fp] = append(groups[fp], r)
+	}
+
+	var trends []*Trend
+	for _, group := range groups {
+		if t := NewTrend(group, window); len(t.Points) >= MinTrendRuns {
+			trends = append(trends, t)
+		}
+	}
+	sort.Slice(trends, func(i, j int) bool {
+		return trends[i].Latest().StartTime.After(trends[j].Latest().StartTime)
+	})
+	return trends
+}
+
+// Label returns a one-line description of the configuration of the trend.
+func (t *Trend) Label() string {
+	return fmt.Sprintf("%s · %s · %d threads", t.ConnectionName, t.TemplateName, t.Threads)
+}
+
+// Latest returns the most recent run of the trend. The trend must have runs.
+func (t *Trend) Latest() TrendPoint {
+	return t.Points[len(t.Points)-1]
+}
+
+// ChangePointTimes returns the start times of the runs a level shift begins with.
+func (t *Trend) ChangePointTimes() []time.Time {
+	var times []time.Time
+	for _, p := range t.Points {
+		if p.ChangePoint {
+			times = append(times, p.StartTime)
+		}
+	}
+	return times
+}
+
+// LatestDeviation compares the latest run with the mean of the up to Window
+// runs before it. It reports false if the trend has fewer than MinTrendRuns runs.
+func (t *Trend) LatestDeviation() (TrendDeviation, bool) {
+	if len(t.Points) < MinTrendRuns {
+		return TrendDeviation{}, false
+	}
+	latest := t.Latest()
+	before := t.Points[max(0, len(t.Points)-1-t.Window) : len(t.Points)-1]
+
+	var tps, p95 float64
+	for _, p := range before {
+		tps += p.TPS
+		p95 += p.LatencyP95
+	}
+	tps /= float64(len(before))
+	p95 /= float64(len(before))
+
+	return TrendDeviation{
+		RecordID:          latest.RecordID,
+		Baseline:          len(before),
+		TPSPercent:        percentChange(tps, latest.TPS),
+		LatencyP95Percent: percentChange(p95, latest.LatencyP95),
+	}, true
+}
+
+// ChangePoints returns the indexes of the values a level shift begins at:
+// the mean of the values from there on differs from the mean of the values
+// before it by several standard errors and by at least a few percent. Each
+// side needs a few values, so shifts at the very start or end are not found.
+func ChangePoints(values []float64) []int {
+	seg := changePointSegment
+	scores := make(map[int]float64)
+	for i := seg; i+seg <= len(values); i++ {
+		before := ComputeStats(values[i-seg : i])
+		after := ComputeStats(values[i : i+seg])
+		shift := math.Abs(after.Mean - before.Mean)
+		if shift == 0 || math.Abs(percentChange(before.Mean, after.Mean)) < minChangePercent {
+			continue
+		}
+		stdErr := math.Sqrt((before.StdDev*before.StdDev + after.StdDev*after.StdDev) / float64(seg))
+		score := math.Inf(1)
+		if stdErr > 0 {
+			score = shift / stdErr
+		}
+		if score >= changePointScore {
+			scores[i] = score
+		}
+	}
+
+	// A shift scores at several neighbouring runs; keep the best one
+	var points []int
+	for i, score := range scores {
+		best := true
+		for j := i - seg + 1; j < i+seg; j++ {
+			if other, ok := scores[j]; ok && j != i && (other > score || (other == score && j < i)) {
+				best = false
+				break
+			}
+		}
+		if best {
+			points = append(points, i)
+		}
+	}
+	sort.Ints(points)
+	return points
+}
+
+// rollingMean returns the mean of each value and the up to window-1 values before it.
+func rollingMean(values []float64, window int) []float64 {
+	means := make([]float64, len(values))
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+		means[i] = sum / float64(min(i+1, window))
+	}
+	return means
+}
+
+// percentChange returns the change from base to v in percent of base.
+func percentChange(base, v float64) float64 {
+	if base == 0 {
+		return 0
+	}
+	return (v - base) / base * 100
+}
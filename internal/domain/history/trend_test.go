@@ -0,0 +1,139 @@
+package history
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newTrendRecords returns one daily run per TPS value of the same configuration.
+func newTrendRecords(tps ...float64) []*Record {
+	start := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+	records := make([]*Record, len(tps))
+	for i, v := range tps {
+		records[i] = &Record{
+			ID:             fmt.Sprintf("run-%d", i),
+			ConnectionName: "prod-replica",
+			TemplateName:   "Sysbench OLTP Read-Write",
+			DatabaseType:   "mysql",
+			Threads:        16,
+			Parameters:     map[string]string{"time": "300", "tables": "10"},
+			StartTime:      start.AddDate(0, 0, i),
+			TPSCalculated:  v,
+			LatencyP95:     20,
+		}
+	}
+	return records
+}
+
+// TestRecord_Fingerprint tests that only the run configuration identifies a run.
+func TestRecord_Fingerprint(t *testing.T) {
+	records := newTrendRecords(1000, 2000)
+	records[1].Parameters = map[string]string{"tables": "10", "time": "300"}
+	records[1].Notes = "after tuning"
+	if records[0].Fingerprint() != records[1].Fingerprint() {
+		t.Error("Fingerprint() differs for runs of the same configuration")
+	}
+
+	records[1].Parameters["time"] = "600"
+	if records[0].Fingerprint() == records[1].Fingerprint() {
+		t.Error("Fingerprint() is the same for runs with different parameters")
+	}
+	records[1].Parameters["time"] = "300"
+	records[1].Threads = 32
+	if records[0].Fingerprint() == records[1].Fingerprint() {
+		t.Error("Fingerprint() is the same for runs with different threads")
+	}
+}
+
+// TestNewTrend tests ordering, rolling means and skipped runs.
+func TestNewTrend(t *testing.T) {
+	records := newTrendRecords(100, 200, 300, 400)
+	slices.Reverse(records)
+	failed := newTrendRecords(5)[0]
+	failed.ID, failed.State = "failed", "failed"
+	records = append(records, failed)
+
+	trend := NewTrend(records, 2)
+	if len(trend.Points) != 4 || trend.Points[0].RecordID != "run-0" || trend.Latest().RecordID != "run-3" {
+		t.Fatalf("NewTrend() points = %+v, want the 4 completed runs oldest first", trend.Points)
+	}
+	wantMeans := []float64{100, 150, 250, 350}
+	for i, p := range trend.Points {
+		if p.TPSMean != wantMeans[i] {
+			t.Errorf("point %d TPSMean = %v, want %v", i, p.TPSMean, wantMeans[i])
+		}
+	}
+	if trend.Window != 2 || trend.Threads != 16 || trend.ConnectionName != "prod-replica" {
+		t.Errorf("NewTrend() = %+v, want the configuration of the runs", trend)
+	}
+}
+
+// TestChangePoints tests that one level shift is found once, and noise is not.
+func TestChangePoints(t *testing.T) {
+	steady := []float64{1000, 1010, 995, 1005, 990, 1000, 1008, 997}
+	if got := ChangePoints(steady); len(got) != 0 {
+		t.Errorf("ChangePoints(steady) = %v, want none", got)
+	}
+
+	shifted := []float64{1000, 1010, 995, 1005, 800, 810, 790, 805}
+	if got := ChangePoints(shifted); !slices.Equal(got, []int{4}) {
+		t.Errorf("ChangePoints(shifted) = %v, want [4]", got)
+	}
+
+	flat := []float64{500, 500, 500, 502, 502, 502}
+	if got := ChangePoints(flat); len(got) != 0 {
+		t.Errorf("ChangePoints(flat, shift below %v%%) = %v, want none", minChangePercent, got)
+	}
+
+	trend := NewTrend(newTrendRecords(shifted...), DefaultTrendWindow)
+	if times := trend.ChangePointTimes(); len(times) != 1 || !times[0].Equal(trend.Points[4].StartTime) {
+		t.Errorf("ChangePointTimes() = %v, want the start of run 4", times)
+	}
+}
+
+// TestTrend_LatestDeviation tests the deviation of the latest run from the window before it.
+func TestTrend_LatestDeviation(t *testing.T) {
+	trend := NewTrend(newTrendRecords(500, 1000, 1000, 1000, 800), 3)
+	dev, ok := trend.LatestDeviation()
+	if !ok || dev.RecordID != "run-4" || dev.Baseline != 3 {
+		t.Fatalf("LatestDeviation() = %+v, %v, want run-4 against 3 runs", dev, ok)
+	}
+	if math.Abs(dev.TPSPercent+20) > 1e-9 || dev.LatencyP95Percent != 0 {
+		t.Errorf("LatestDeviation() = %+v, want TPS -20%% and p95 unchanged", dev)
+	}
+	if !dev.Exceeds(15) || dev.Exceeds(25) {
+		t.Errorf("Exceeds() wrong for a 20%% deviation")
+	}
+
+	if _, ok := NewTrend(newTrendRecords(1000, 800), 3).LatestDeviation(); ok {
+		t.Error("LatestDeviation() with 2 runs reported a deviation")
+	}
+}
+
+// TestGroupTrends tests grouping by configuration and the minimum run count.
+func TestGroupTrends(t *testing.T) {
+	records := newTrendRecords(1000, 1010, 990)
+	other := newTrendRecords(2000, 2010, 1990, 2005)
+	for _, r := range other {
+		r.ID += "-32"
+		r.Threads = 32
+		r.StartTime = r.StartTime.AddDate(0, 1, 0)
+	}
+	lone := newTrendRecords(3000, 3000)
+	for _, r := range lone {
+		r.ID += "-64"
+		r.Threads = 64
+	}
+	records = append(append(records, other...), lone...)
+
+	trends := GroupTrends(records, DefaultTrendWindow)
+	if len(trends) != 2 {
+		t.Fatalf("GroupTrends() = %d trends, want 2", len(trends))
+	}
+	if trends[0].Threads != 32 || len(trends[0].Points) != 4 || trends[1].Threads != 16 {
+		t.Errorf("GroupTrends() = %s, %s, want the most recently run configuration first", trends[0].Label(), trends[1].Label())
+	}
+}
@@ -0,0 +1,204 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CheckKind identifies what a sanity check measures.
+type CheckKind string
+
+const (
+	// CheckMaxErrorRate fails runs whose ignored errors exceed Threshold percent of transactions.
+	CheckMaxErrorRate CheckKind = "max_error_rate"
+	// CheckMinDuration fails runs shorter than Threshold seconds.
+	CheckMinDuration CheckKind = "min_duration"
+	// CheckMaxReconnects fails runs with more than Threshold reconnects.
+	CheckMaxReconnects CheckKind = "max_reconnects"
+	// CheckMaxCV fails repeated runs whose TPS coefficient of variation across
+	// the repetitions exceeds Threshold percent.
+	CheckMaxCV CheckKind = "max_cv"
+	// CheckMaxReplicaLag fails runs whose replicas fell more than Threshold
+	// seconds behind, or stopped replicating, during the run phase. Runs
+	// without replica monitoring pass it.
+	CheckMaxReplicaLag CheckKind = "max_replica_lag"
+)
+
+// CheckKinds lists the supported sanity check kinds.
+var CheckKinds = []CheckKind{CheckMaxErrorRate, CheckMinDuration, CheckMaxReconnects, CheckMaxCV, CheckMaxReplicaLag}
+
+// Unit returns the unit of the check threshold.
+func (k CheckKind) Unit() string {
+	switch k {
+	case CheckMinDuration, CheckMaxReplicaLag:
+		return "s"
+	case CheckMaxReconnects:
+		return ""
+	default:
+		return "%"
+	}
+}
+
+// SanityCheck is a user-defined threshold evaluated on every run saved to history.
+// Runs that fail any applicable check are marked invalid and left out of comparisons.
+type SanityCheck struct {
+	Name      string    `json:"name"`
+	Kind      CheckKind `json:"kind"`
+	Threshold float64   `json:"threshold"`
+	// Template restricts the check to runs of this template name; empty applies it to all runs.
+	Template string `json:"template,omitempty"`
+}
+
+// Validate validates the sanity check.
+func (c *SanityCheck) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return fmt.Errorf("check name is required")
+	}
+	valid := false
+	for _, kind := range CheckKinds {
+		if c.Kind == kind {
+			valid = true
+		}
+	}
+	if !valid {
+		return fmt.Errorf("check %s: unknown kind: %s", c.Name, c.Kind)
+	}
+	if c.Threshold < 0 {
+		return fmt.Errorf("check %s: threshold cannot be negative", c.Name)
+	}
+	return nil
+}
+
+// AppliesTo reports whether the check applies to the record.
+func (c *SanityCheck) AppliesTo(r *Record) bool {
+	return c.Template == "" || c.Template == r.TemplateName
+}
+
+// String describes the check, e.g. "max_error_rate ≤ 1%".
+func (c *SanityCheck) String() string {
+	op := "≤"
+	if c.Kind == CheckMinDuration {
+		op = "≥"
+	}
+	s := fmt.Sprintf("%s %s %g%s", c.Kind, op, c.Threshold, c.Kind.Unit())
+	if c.Template != "" {
+		s += " (" + c.Template + ")"
+	}
+	return s
+}
+
+// CheckResult is the outcome of one sanity check on a run.
+type CheckResult struct {
+	Name      string    `json:"name"`
+	Kind      CheckKind `json:"kind"`
+	Value     float64   `json:"value"`
+	Threshold float64   `json:"threshold"`
+	Passed    bool      `json:"passed"`
+	Details   string    `json:"details,omitempty"`
+}
+
+// Validity is the outcome of the sanity checks of a run.
+type Validity struct {
+	Valid     bool          `json:"valid"`
+	CheckedAt time.Time     `json:"checked_at"`
+	Results   []CheckResult `json:"results,omitempty"`
+}
+
+// Failed returns the names of the failed checks.
+func (v *Validity) Failed() []string {
+	var names []string
+	for _, r := range v.Results {
+		if !r.Passed {
+			names = append(names, r.Name)
+		}
+	}
+	return names
+}
+
+// IsValid reports whether the run passed its sanity checks.
+// Runs that were never checked are valid.
+func (r *Record) IsValid() bool {
+	return r.Validity == nil || r.Validity.Valid
+}
+
+// EvaluateChecks evaluates the applicable checks on a record. repeats are the
+// other runs of the same repetition series, used by CheckMaxCV; a run that was
+// not repeated passes it.
+func EvaluateChecks(r *Record, checks []SanityCheck, repeats []*Record) *Validity {
+	v := &Validity{Valid: true, CheckedAt: time.Now()}
+	for i := range checks {
+		c := &checks[i]
+		if !c.AppliesTo(r) {
+			continue
+		}
+
+		result := CheckResult{Name: c.Name, Kind: c.Kind, Threshold: c.Threshold}
+		switch c.Kind {
+		case CheckMaxErrorRate:
+			result.Value = errorRate(r)
+			result.Passed = result.Value <= c.Threshold
+		case CheckMinDuration:
+			result.Value = r.Duration.Seconds()
+			result.Passed = result.Value >= c.Threshold
+		case CheckMaxReconnects:
+			result.Value = float64(r.Reconnects)
+			result.Passed = result.Value <= c.Threshold
+		case CheckMaxCV:
+			tps := []float64{r.TPSCalculated}
+			for _, peer := range repeats {
+				if peer.ID != r.ID {
+					tps = append(tps, peer.TPSCalculated)
+				}
+			}
+			if len(tps) < 2 {
+				result.Passed = true
+				result.Details = "not repeated"
+				break
+			}
+			result.Value = ComputeStats(tps).CV * 100
+			result.Passed = result.Value <= c.Threshold
+			result.Details = fmt.Sprintf("%d runs", len(tps))
+		case CheckMaxReplicaLag:
+			if r.Replication == nil {
+				result.Passed = true
+				result.Details = "replicas not monitored"
+				break
+			}
+			result.Value = r.Replication.MaxLag()
+			result.Passed = result.Value <= c.Threshold && r.Replication.Failures() == 0
+			if failures := r.Replication.Failures(); failures > 0 {
+				result.Details = fmt.Sprintf("replication stopped or unreachable in %d polls", failures)
+			}
+		}
+		if !result.Passed {
+			v.Valid = false
+			if result.Details == "" {
+				result.Details = fmt.Sprintf("%.2f%s, limit %s", result.Value, c.Kind.Unit(), c.String())
+			}
+		}
+		v.Results = append(v.Results, result)
+	}
+	return v
+}
+
+// errorRate returns the ignored errors as a percentage of transactions.
+func errorRate(r *Record) float64 {
+	if r.IgnoredErrors == 0 {
+		return 0
+	}
+	if r.TotalTransactions == 0 {
+		return 100
+	}
+	return float64(r.IgnoredErrors) / float64(r.TotalTransactions) * 100
+}
+
+// RepeatTag returns the repetition series tag of the record, if any.
+func (r *Record) RepeatTag() string {
+	for _, tag := range r.Tags {
+		if strings.HasPrefix(tag, AggregateTagPrefix) {
+			return tag
+		}
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/replication"
+)
+
+// TestEvaluateChecks tests each check kind and template scoping.
+func TestEvaluateChecks(t *testing.T) {
+	record := &Record{
+		ID:                "run-1",
+		TemplateName:      "oltp_read_write",
+		Duration:          30 * time.Second,
+		TPSCalculated:     1000,
+		TotalTransactions: 30000,
+		IgnoredErrors:     600,
+		Reconnects:        1,
+	}
+
+	tests := []struct {
+		name      string
+		check     SanityCheck
+		wantValid bool
+	}{
+		{"error rate within", SanityCheck{Name: "errors", Kind: CheckMaxErrorRate, Threshold: 2}, true},
+		{"error rate exceeded", SanityCheck{Name: "errors", Kind: CheckMaxErrorRate, Threshold: 1}, false},
+		{"duration too short", SanityCheck{Name: "duration", Kind: CheckMinDuration, Threshold: 60}, false},
+		{"duration long enough", SanityCheck{Name: "duration", Kind: CheckMinDuration, Threshold: 30}, true},
+		{"reconnects exceeded", SanityCheck{Name: "reconnects", Kind: CheckMaxReconnects, Threshold: 0}, false},
+		{"other template", SanityCheck{Name: "reconnects", Kind: CheckMaxReconnects, Threshold: 0, Template: "tpcc"}, true},
+		{"not repeated", SanityCheck{Name: "cv", Kind: CheckMaxCV, Threshold: 1}, true},
+		{"replicas not monitored", SanityCheck{Name: "lag", Kind: CheckMaxReplicaLag, Threshold: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := EvaluateChecks(record, []SanityCheck{tt.check}, nil)
+			if v.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (results %+v)", v.Valid, tt.wantValid, v.Results)
+			}
+			if !tt.wantValid && (len(v.Failed()) != 1 || v.Failed()[0] != tt.check.Name) {
+				t.Errorf("Failed() = %v, want [%s]", v.Failed(), tt.check.Name)
+			}
+		})
+	}
+
+	repeats := []*Record{record, {ID: "run-2", TPSCalculated: 700}}
+	v := EvaluateChecks(record, []SanityCheck{{Name: "cv", Kind: CheckMaxCV, Threshold: 10}}, repeats)
+	if v.Valid || v.Results[0].Value < 20 {
+		t.Errorf("repeated CV check = %+v, want failed with CV above 20%%", v.Results)
+	}
+
+	record.Replication = replication.NewStats([]string{"db-2"}, time.Second)
+	record.Replication.Add(replication.Status{Replica: "db-2", Running: true, Lag: 4})
+	lag := []SanityCheck{{Name: "lag", Kind: CheckMaxReplicaLag, Threshold: 5}}
+	if v := EvaluateChecks(record, lag, nil); !v.Valid || v.Results[0].Value != 4 {
+		t.Errorf("replica lag check = %+v, want passed with 4s", v.Results)
+	}
+	record.Replication.Add(replication.Status{Replica: "db-2", Error: "replication not running"})
+	if v := EvaluateChecks(record, lag, nil); v.Valid {
+		t.Errorf("replica lag check with a stopped replica = %+v, want failed", v.Results)
+	}
+
+	if (&Record{}).IsValid() != true {
+		t.Errorf("unchecked record is not valid")
+	}
+}
+
+// TestSanityCheck_Validate tests sanity check validation.
+func TestSanityCheck_Validate(t *testing.T) {
+	if err := (&SanityCheck{Name: "x", Kind: CheckMaxCV, Threshold: 5}).Validate(); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+	for _, c := range []SanityCheck{
+		{Kind: CheckMaxCV},
+		{Name: "x", Kind: "max_tps"},
+		{Name: "x", Kind: CheckMinDuration, Threshold: -1},
+	} {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want error", c)
+		}
+	}
+}
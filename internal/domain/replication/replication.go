@@ -0,0 +1,97 @@
+// Package replication provides the replica lag and error statistics polled
+// from the replicas of the target database during the run phase.
+package replication
+
+import (
+	"slices"
+	"time"
+)
+
+// maxErrors bounds the distinct replication errors kept per replica.
+const maxErrors = 10
+
+// Status is one poll of a replica.
+type Status struct {
+	Replica string  // Replica address, host or host:port
+	Running bool    // Whether the replica is applying changes
+	Lag     float64 // Replication lag in seconds; only meaningful when Running
+	Error   string  // Replication error reported by the replica, or why it could not be polled
+}
+
+// Stats is the replication state of the replicas over a run.
+type Stats struct {
+	StartedAt time.Time      `json:"started_at"`
+	Interval  time.Duration  `json:"interval"` // Poll interval
+	Replicas  []ReplicaStats `json:"replicas"`
+}
+
+// ReplicaStats is the replication state of one replica over a run.
+type ReplicaStats struct {
+	Replica  string   `json:"replica"`
+	Samples  int      `json:"samples"`            // Polls that returned a lag
+	Failures int      `json:"failures,omitempty"` // Polls with the replica stopped, erroring or unreachable
+	MaxLag   float64  `json:"max_lag_seconds"`    // Highest lag seen
+	AvgLag   float64  `json:"avg_lag_seconds"`    // Mean lag over the samples
+	LastLag  float64  `json:"last_lag_seconds"`   // Lag at the last sample
+	Errors   []string `json:"errors,omitempty"`   // Distinct errors, oldest first
+}
+
+// NewStats returns empty statistics of the replicas, polled every interval.
+func NewStats(replicas []string, interval time.Duration) *Stats {
+	s := &Stats{StartedAt: time.Now(), Interval: interval}
+	for _, replica := range replicas {
+		s.Replicas = append(s.Replicas, ReplicaStats{Replica: replica})
+	}
+	return s
+}
+
+// Add records a poll of a replica. Polls of replicas the statistics were
+// not created with are ignored.
+func (s *Stats) Add(status Status) {
+	i := slices.IndexFunc(s.Replicas, func(r ReplicaStats) bool { return r.Replica == status.Replica })
+	if i < 0 {
+		return
+	}
+	r := &s.Replicas[i]
+
+	if status.Error != "" && !slices.Contains(r.Errors, status.Error) && len(r.Errors) < maxErrors {
+		r.Errors = append(r.Errors, status.Error)
+	}
+	if !status.Running {
+		r.Failures++
+		return
+	}
+	r.AvgLag = (r.AvgLag*float64(r.Samples) + status.Lag) / float64(r.Samples+1)
+	r.Samples++
+	r.MaxLag = max(r.MaxLag, status.Lag)
+	r.LastLag = status.Lag
+}
+
+// MaxLag returns the highest lag of any replica, in seconds.
+func (s *Stats) MaxLag() float64 {
+	var lag float64
+	for _, r := range s.Replicas {
+		lag = max(lag, r.MaxLag)
+	}
+	return lag
+}
+
+// Failures returns the polls of all replicas that found a replica stopped,
+// erroring or unreachable.
+func (s *Stats) Failures() int {
+	var n int
+	for _, r := range s.Replicas {
+		n += r.Failures
+	}
+	return n
+}
+
+// Healthy reports whether every replica was polled and none failed.
+func (s *Stats) Healthy() bool {
+	for _, r := range s.Replicas {
+		if r.Failures > 0 || r.Samples == 0 {
+			return false
+		}
+	}
+	return true
+}
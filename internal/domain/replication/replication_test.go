@@ -0,0 +1,38 @@
+package replication
+
+import (
+	"testing"
+	"time"
+)
+
+// TestStats_Add tests the lag and failure statistics of polled replicas.
+func TestStats_Add(t *testing.T) {
+	s := NewStats([]string{"replica-1", "replica-2:3307"}, time.Second)
+	for _, status := range []Status{
+		{Replica: "replica-1", Running: true, Lag: 0},
+		{Replica: "replica-1", Running: true, Lag: 4},
+		{Replica: "replica-1", Running: true, Lag: 2},
+		{Replica: "replica-2:3307", Running: true, Lag: 1},
+		{Replica: "replica-2:3307", Error: "Error 1062: Duplicate entry"},
+		{Replica: "replica-2:3307", Error: "Error 1062: Duplicate entry"},
+		{Replica: "unknown", Running: true, Lag: 100},
+	} {
+		s.Add(status)
+	}
+
+	r := s.Replicas[0]
+	if r.Samples != 3 || r.MaxLag != 4 || r.AvgLag != 2 || r.LastLag != 2 {
+		t.Errorf("replica-1 = %+v, want 3 samples, max 4s, avg 2s, last 2s", r)
+	}
+	r = s.Replicas[1]
+	if r.Samples != 1 || r.Failures != 2 || len(r.Errors) != 1 {
+		t.Errorf("replica-2 = %+v, want 1 sample, 2 failures, 1 distinct error", r)
+	}
+
+	if s.MaxLag() != 4 || s.Failures() != 2 || s.Healthy() {
+		t.Errorf("MaxLag() = %g, Failures() = %d, Healthy() = %v; want 4, 2, false", s.MaxLag(), s.Failures(), s.Healthy())
+	}
+	if healthy := NewStats([]string{"replica-1"}, time.Second); healthy.Healthy() {
+		t.Error("Healthy() of a replica never polled = true, want false")
+	}
+}
@@ -0,0 +1,334 @@
+// Package sqlmix provides the user-defined SQL workloads of the SQL mix runner:
+// statements picked by weight whose parameters are drawn from generators.
+//
+// A template of the "sqlmix" tool defines its mix in custom_data.sql_mix:
+//
+//	{
+//	  "prepare":    [{"sql": "INSERT INTO kv (id, v) VALUES ({id}, {v})", "repeat": 10000,
+//	                  "params": {"id": {"type": "sequence"}, "v": {"type": "string", "length": 32}}}],
+//	  "statements": [{"name": "get", "weight": 9, "sql": "SELECT v FROM kv WHERE id = {id}",
+//	                  "params": {"id": {"type": "int", "min": 1, "max": 10000}}}],
+//	  "cleanup":    [{"sql": "DROP TABLE kv"}]
+//	}
+package sqlmix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/template"
+)
+
+// Tool is the tool of the templates of SQL mixes.
+const Tool = "sqlmix"
+
+// CustomDataKey is the key of the mix in the custom data of a template.
+const CustomDataKey = "sql_mix"
+
+// ErrNoMix is returned for a template without a mix.
+var ErrNoMix = errors.New("template has no SQL mix (custom_data." + CustomDataKey + ")")
+
+// Mix is a SQL workload: statements that create and load its tables, the
+// weighted statements of the run phase and statements that drop the tables.
+type Mix struct {
+	Prepare    []Statement `json:"prepare,omitempty"`
+	Statements []Statement `json:"statements"`
+	Cleanup    []Statement `json:"cleanup,omitempty"`
+
+	// Count failed run-phase statements and continue, instead of ending the run
+	IgnoreErrors bool `json:"ignore_errors,omitempty"`
+}
+
+// Statement is a SQL statement of a mix. {name} in its SQL is bound to a
+// value of the generator of parameter name on every execution.
+type Statement struct {
+	Name   string               `json:"name,omitempty"`
+	SQL    string               `json:"sql"`
+	Weight int                  `json:"weight,omitempty"` // Run phase: relative frequency, default 1
+	Repeat int                  `json:"repeat,omitempty"` // Prepare and cleanup: executions, default 1
+	Params map[string]Generator `json:"params,omitempty"`
+}
+
+// GeneratorType is the kind of values a generator draws.
+type GeneratorType string
+
+const (
+	// GeneratorInt draws integers uniformly from [Min, Max].
+	GeneratorInt GeneratorType = "int"
+	// GeneratorFloat draws floats uniformly from [Min, Max).
+	GeneratorFloat GeneratorType = "float"
+	// GeneratorSequence counts up from Start (default 1), across all threads.
+	GeneratorSequence GeneratorType = "sequence"
+	// GeneratorString draws alphanumeric strings of Length characters.
+	GeneratorString GeneratorType = "string"
+	// GeneratorChoice draws one of Values.
+	GeneratorChoice GeneratorType = "choice"
+	// GeneratorNow is the current time.
+	GeneratorNow GeneratorType = "now"
+)
+
+// maxStringLength is the longest string a generator draws.
+const maxStringLength = 65535
+
+// Generator draws the values of a statement parameter.
+type Generator struct {
+	Type   GeneratorType `json:"type"`
+	Min    float64       `json:"min,omitempty"`
+	Max    float64       `json:"max,omitempty"`
+	Start  int64         `json:"start,omitempty"`
+	Length int           `json:"length,omitempty"`
+	Values []any         `json:"values,omitempty"`
+}
+
+// Kind is the kind of a statement, as counted in the run results.
+type Kind int
+
+const (
+	KindOther Kind = iota
+	KindRead
+	KindWrite
+)
+
+// paramPattern matches the parameter references of statement SQL.
+var paramPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// FromTemplate returns the validated mix of a template.
+func FromTemplate(tmpl *template.Template) (*Mix, error) {
+	raw, ok := tmpl.CustomData[CustomDataKey]
+	if !ok {
+		return nil, ErrNoMix
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("encode SQL mix: %w", err)
+	}
+	var mix Mix
+	if err := json.Unmarshal(data, &mix); err != nil {
+		return nil, fmt.Errorf("invalid SQL mix: %w", err)
+	}
+	if err := mix.Validate(); err != nil {
+		return nil, err
+	}
+	return &mix, nil
+}
+
+// Validate checks the statements and generators of the mix.
+func (m *Mix) Validate() error {
+	if len(m.Statements) == 0 {
+		return errors.New("SQL mix has no statements")
+	}
+	for i, s := range m.Statements {
+		if s.Weight < 0 {
+			return fmt.Errorf("statement %s: negative weight %d", s.label(i), s.Weight)
+		}
+	}
+	for _, group := range []struct {
+		name       string
+		statements []Statement
+	}{{"prepare", m.Prepare}, {"run", m.Statements}, {"cleanup", m.Cleanup}} {
+		for i, s := range group.statements {
+			if err := s.validate(); err != nil {
+				return fmt.Errorf("%s statement %s: %w", group.name, s.label(i), err)
+			}
+		}
+	}
+	return nil
+}
+
+// validate checks that the SQL references exactly the parameters of the statement.
+func (s Statement) validate() error {
+	if strings.TrimSpace(s.SQL) == "" {
+		return errors.New("SQL is empty")
+	}
+	if s.Repeat < 0 {
+		return fmt.Errorf("negative repeat %d", s.Repeat)
+	}
+	used := make(map[string]bool)
+	for _, m := range paramPattern.FindAllStringSubmatch(s.SQL, -1) {
+		if _, ok := s.Params[m[1]]; !ok {
+			return fmt.Errorf("{%s} has no generator in params", m[1])
+		}
+		used[m[1]] = true
+	}
+	for name, g := range s.Params {
+		if !used[name] {
+			return fmt.Errorf("parameter %s is not used in the SQL", name)
+		}
+		if err := g.validate(); err != nil {
+			return fmt.Errorf("parameter %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// validate checks the settings of the generator type.
+func (g Generator) validate() error {
+	switch g.Type {
+	case GeneratorInt, GeneratorFloat:
+		if g.Min > g.Max {
+			return fmt.Errorf("min %g is greater than max %g", g.Min, g.Max)
+		}
+	case GeneratorString:
+		if g.Length < 1 || g.Length > maxStringLength {
+			return fmt.Errorf("length %d must be between 1 and %d", g.Length, maxStringLength)
+		}
+	case GeneratorChoice:
+		if len(g.Values) == 0 {
+			return errors.New("choice has no values")
+		}
+	case GeneratorSequence, GeneratorNow:
+	default:
+		return fmt.Errorf("unknown generator type %q (want int, float, sequence, string, choice or now)", g.Type)
+	}
+	return nil
+}
+
+// label names the i-th statement of a group in errors and output.
+func (s Statement) label(i int) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return "#" + strconv.Itoa(i+1)
+}
+
+// weight returns the weight of the statement in the run phase.
+func (s Statement) weight() int {
+	return max(s.Weight, 1)
+}
+
+// Executions returns how often the statement runs in the prepare or cleanup phase.
+func (s Statement) Executions() int {
+	return max(s.Repeat, 1)
+}
+
+// Kind returns whether the statement reads, writes or does something else,
+// from its first keyword.
+func (s Statement) Kind() Kind {
+	fields := strings.Fields(strings.TrimLeft(strings.TrimSpace(s.SQL), "("))
+	if len(fields) == 0 {
+		return KindOther
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "SELECT", "WITH", "SHOW", "VALUES":
+		return KindRead
+	case "INSERT", "UPDATE", "DELETE", "REPLACE", "MERGE", "UPSERT":
+		return KindWrite
+	default:
+		return KindOther
+	}
+}
+
+// Bound is a statement whose parameter references are replaced with the
+// placeholders of a database driver.
+type Bound struct {
+	Statement
+	Label string // Name, or the position of the statement
+	Query string // SQL with driver placeholders
+
+	params []string                 // Parameter of each placeholder
+	seqs   map[string]*atomic.Int64 // Next value of each sequence parameter
+}
+
+// Bind binds the i-th statement of a group. placeholder returns the driver
+// placeholder of the n-th argument, counting from 1.
+func (s Statement) Bind(i int, placeholder func(n int) string) *Bound {
+	b := &Bound{Statement: s, Label: s.label(i), seqs: make(map[string]*atomic.Int64)}
+	b.Query = paramPattern.ReplaceAllStringFunc(s.SQL, func(ref string) string {
+		b.params = append(b.params, ref[1:len(ref)-1])
+		return placeholder(len(b.params))
+	})
+	for name, g := range s.Params {
+		if g.Type == GeneratorSequence {
+			b.seqs[name] = new(atomic.Int64)
+			b.seqs[name].Store(cmpOr(g.Start, 1))
+		}
+	}
+	return b
+}
+
+// Args draws the arguments of one execution. A parameter referenced more
+// than once has the same value at every reference.
+func (b *Bound) Args(r *rand.Rand) []any {
+	if len(b.params) == 0 {
+		return nil
+	}
+	values := make(map[string]any, len(b.Params))
+	args := make([]any, len(b.params))
+	for i, name := range b.params {
+		v, ok := values[name]
+		if !ok {
+			v = b.draw(r, name)
+			values[name] = v
+		}
+		args[i] = v
+	}
+	return args
+}
+
+// alphanumeric are the characters of generated strings.
+const alphanumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// draw draws a value of parameter name.
+func (b *Bound) draw(r *rand.Rand, name string) any {
+	g := b.Params[name]
+	switch g.Type {
+	case GeneratorInt:
+		lo, hi := int64(g.Min), int64(g.Max)
+		return lo + r.Int64N(hi-lo+1)
+	case GeneratorFloat:
+		return g.Min + r.Float64()*(g.Max-g.Min)
+	case GeneratorSequence:
+		return b.seqs[name].Add(1) - 1
+	case GeneratorString:
+		s := make([]byte, g.Length)
+		for i := range s {
+			s[i] = alphanumeric[r.IntN(len(alphanumeric))]
+		}
+		return string(s)
+	case GeneratorChoice:
+		return g.Values[r.IntN(len(g.Values))]
+	case GeneratorNow:
+		return time.Now()
+	default:
+		return nil
+	}
+}
+
+// Picker picks the statements of the run phase by weight.
+type Picker struct {
+	cumulative []int
+}
+
+// NewPicker creates a picker of statements, of which there must be at least one.
+func NewPicker(statements []Statement) *Picker {
+	p := &Picker{cumulative: make([]int, len(statements))}
+	total := 0
+	for i, s := range statements {
+		total += s.weight()
+		p.cumulative[i] = total
+	}
+	return p
+}
+
+// Pick returns the index of a statement, each picked with the probability of its weight.
+func (p *Picker) Pick(r *rand.Rand) int {
+	n := r.IntN(p.cumulative[len(p.cumulative)-1])
+	i, _ := slices.BinarySearch(p.cumulative, n+1)
+	return i
+}
+
+// cmpOr returns v, or fallback if v is 0.
+func cmpOr(v, fallback int64) int64 {
+	if v == 0 {
+		return fallback
+	}
+	return v
+}
@@ -0,0 +1,169 @@
+// Package sqlmix provides unit tests for SQL mix workloads.
+package sqlmix
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/template"
+)
+
+// TestFromTemplate tests reading the mix from the custom data of a template.
+func TestFromTemplate(t *testing.T) {
+	tmpl := &template.Template{CustomData: map[string]interface{}{
+		CustomDataKey: map[string]interface{}{
+			"statements": []interface{}{
+				map[string]interface{}{
+					"name":   "get",
+					"weight": 3,
+					"sql":    "SELECT v FROM kv WHERE id = {id}",
+					"params": map[string]interface{}{"id": map[string]interface{}{"type": "int", "min": 1, "max": 10}},
+				},
+			},
+			"ignore_errors": true,
+		},
+	}}
+	mix, err := FromTemplate(tmpl)
+	if err != nil {
+		t.Fatalf("FromTemplate() error = %v", err)
+	}
+	if len(mix.Statements) != 1 || mix.Statements[0].Weight != 3 || !mix.IgnoreErrors {
+		t.Errorf("FromTemplate() = %+v", mix)
+	}
+	if g := mix.Statements[0].Params["id"]; g.Type != GeneratorInt || g.Max != 10 {
+		t.Errorf("generator = %+v, want int up to 10", g)
+	}
+
+	if _, err := FromTemplate(&template.Template{}); err != ErrNoMix {
+		t.Errorf("FromTemplate() without a mix error = %v, want ErrNoMix", err)
+	}
+}
+
+// TestMixValidate tests the checks of statements and generators.
+func TestMixValidate(t *testing.T) {
+	intParam := map[string]Generator{"id": {Type: GeneratorInt, Min: 1, Max: 10}}
+	tests := []struct {
+		name    string
+		mix     Mix
+		wantErr string
+	}{
+		{"valid", Mix{Statements: []Statement{{SQL: "SELECT * FROM t WHERE id = {id}", Params: intParam}}}, ""},
+		{"no statements", Mix{}, "no statements"},
+		{"empty SQL", Mix{Statements: []Statement{{SQL: " "}}}, "SQL is empty"},
+		{"negative weight", Mix{Statements: []Statement{{SQL: "SELECT 1", Weight: -1}}}, "negative weight"},
+		{"missing param", Mix{Statements: []Statement{{SQL: "SELECT {x}"}}}, "{x} has no generator"},
+		{"unused param", Mix{Statements: []Statement{{SQL: "SELECT 1", Params: intParam}}}, "id is not used"},
+		{"bad range", Mix{Statements: []Statement{{SQL: "SELECT {id}", Params: map[string]Generator{"id": {Type: GeneratorInt, Min: 5, Max: 1}}}}}, "greater than max"},
+		{"bad length", Mix{Statements: []Statement{{SQL: "SELECT {s}", Params: map[string]Generator{"s": {Type: GeneratorString}}}}}, "length 0"},
+		{"no choices", Mix{Statements: []Statement{{SQL: "SELECT {c}", Params: map[string]Generator{"c": {Type: GeneratorChoice}}}}}, "no values"},
+		{"unknown type", Mix{Statements: []Statement{{SQL: "SELECT {u}", Params: map[string]Generator{"u": {Type: "uuid"}}}}}, "unknown generator type"},
+		{"bad prepare", Mix{
+			Statements: []Statement{{SQL: "SELECT 1"}},
+			Prepare:    []Statement{{Name: "load", SQL: "INSERT INTO t VALUES ({id})"}},
+		}, "prepare statement load"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mix.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestBind tests placeholders and the arguments drawn for them.
+func TestBind(t *testing.T) {
+	s := Statement{
+		SQL: "UPDATE t SET v = {v}, n = {n} WHERE id = {id} OR parent = {id}",
+		Params: map[string]Generator{
+			"v":  {Type: GeneratorString, Length: 8},
+			"n":  {Type: GeneratorChoice, Values: []any{"a", "b"}},
+			"id": {Type: GeneratorSequence, Start: 100},
+		},
+	}
+	b := s.Bind(1, func(n int) string { return fmt.Sprintf("$%d", n) })
+	if b.Query != "UPDATE t SET v = $1, n = $2 WHERE id = $3 OR parent = $4" {
+		t.Errorf("Query = %q", b.Query)
+	}
+	if b.Label != "#2" {
+		t.Errorf("Label = %q, want #2", b.Label)
+	}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for want := int64(100); want < 103; want++ {
+		args := b.Args(r)
+		if len(args) != 4 {
+			t.Fatalf("Args() = %v, want 4 arguments", args)
+		}
+		if v := args[0].(string); len(v) != 8 {
+			t.Errorf("string argument %q, want 8 characters", v)
+		}
+		if n := args[1]; n != "a" && n != "b" {
+			t.Errorf("choice argument %v, want a or b", n)
+		}
+		if args[2] != want || args[3] != want {
+			t.Errorf("sequence arguments %v, %v, want %d twice", args[2], args[3], want)
+		}
+	}
+}
+
+// TestGeneratorRange tests that integers are drawn from the closed range.
+func TestGeneratorRange(t *testing.T) {
+	b := Statement{SQL: "SELECT {id}", Params: map[string]Generator{"id": {Type: GeneratorInt, Min: 1, Max: 3}}}.
+		Bind(0, func(int) string { return "?" })
+	r := rand.New(rand.NewPCG(3, 4))
+	seen := make(map[int64]bool)
+	for range 1000 {
+		v := b.Args(r)[0].(int64)
+		if v < 1 || v > 3 {
+			t.Fatalf("drew %d, want 1..3", v)
+		}
+		seen[v] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("drew %v, want all of 1..3", seen)
+	}
+}
+
+// TestPicker tests that statements are picked in proportion to their weight.
+func TestPicker(t *testing.T) {
+	p := NewPicker([]Statement{{Weight: 8}, {}, {Weight: 1}})
+	r := rand.New(rand.NewPCG(5, 6))
+	counts := make([]int, 3)
+	for range 10000 {
+		counts[p.Pick(r)]++
+	}
+	// Weights 8:1:1
+	if counts[0] < 7500 || counts[0] > 8500 || counts[1] < 700 || counts[2] < 700 {
+		t.Errorf("picked %v, want about 8000/1000/1000", counts)
+	}
+}
+
+// TestStatementKind tests classifying statements by their first keyword.
+func TestStatementKind(t *testing.T) {
+	tests := map[string]Kind{
+		"SELECT 1":                    KindRead,
+		"  with x as (select 1) ...":  KindRead,
+		"(SELECT 1) UNION (SELECT 2)": KindRead,
+		"insert into t values (1)":    KindWrite,
+		"UPDATE t SET v = 1":          KindWrite,
+		"DELETE FROM t":               KindWrite,
+		"CREATE TABLE t (id INT)":     KindOther,
+		"BEGIN":                       KindOther,
+		"SELECT\n  v\nFROM t":         KindRead,
+	}
+	for sql, want := range tests {
+		if got := (Statement{SQL: sql}).Kind(); got != want {
+			t.Errorf("Kind(%q) = %d, want %d", sql, got, want)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Package suite provides the benchmark suite domain model.
+// A suite is an ordered list of benchmark steps run as a unit.
+package suite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/assertion"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+// DefaultGroupBy is the comparison grouping used when a suite does not set one.
+const DefaultGroupBy = "threads"
+
+// TagPrefix prefixes the history tag that groups the records of a suite run
+// ("suite:<suite-run-id>").
+const TagPrefix = "suite:"
+
+// Suite is an ordered list of benchmark steps that is run as a unit.
+type Suite struct {
+	ID          string    `json:"id"`          // UUID
+	Name        string    `json:"name"`        // Unique name
+	Description string    `json:"description"` // Free text
+	Steps       []Step    `json:"steps"`       // Executed in order
+	GroupBy     string    `json:"group_by"`    // Comparison grouping of the final report (default threads)
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Step is one benchmark configuration of a suite, run one or more times.
+type Step struct {
+	Name            string                 `json:"name"`                // Display name (optional)
+	ConnectionID    string                 `json:"connection_id"`       // Connection ID
+	TemplateID      string                 `json:"template_id"`         // Template ID
+	Parameters      map[string]interface{} `json:"parameters"`          // Parameter overrides
+	Options         execution.TaskOptions  `json:"options"`             // Execution options
+	Repetitions     int                    `json:"repetitions"`         // Number of runs (at least 1)
+	CooldownSeconds int                    `json:"cooldown_seconds"`    // Pause after each run
+	Assertions      assertion.Targets      `json:"assertions,omitzero"` // SLA targets, overriding the template's
+}
+
+// Validate validates the suite definition.
+func (s *Suite) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("suite name is required")
+	}
+	if len(s.Steps) == 0 {
+		return fmt.Errorf("suite %s has no steps", s.Name)
+	}
+	for i := range s.Steps {
+		if err := s.Steps[i].Validate(); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// TotalRuns returns the number of benchmark runs of the suite.
+func (s *Suite) TotalRuns() int {
+	total := 0
+	for _, step := range s.Steps {
+		total += step.Repetitions
+	}
+	return total
+}
+
+// Validate validates the step configuration.
+func (s *Step) Validate() error {
+	if s.ConnectionID == "" {
+		return fmt.Errorf("connection_id is required")
+	}
+	if s.TemplateID == "" {
+		return fmt.Errorf("template_id is required")
+	}
+	if s.Repetitions < 1 || s.Repetitions > 1000 {
+		return fmt.Errorf("repetitions must be between 1 and 1000")
+	}
+	if s.CooldownSeconds < 0 {
+		return fmt.Errorf("cooldown_seconds must not be negative")
+	}
+	if s.Options.DryRun {
+		return fmt.Errorf("dry run steps cannot be run in a suite")
+	}
+	if s.Options.Repetitions() > 1 {
+		return fmt.Errorf("steps are repeated with repetitions, not options.repeat")
+	}
+	if err := s.Assertions.Validate(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DisplayName returns the step name, or a name built from its template.
+func (s *Step) DisplayName(index int) string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return fmt.Sprintf("Step %d (%s)", index+1, s.TemplateID)
+}
+
+// RunState represents the state of a suite run.
+type RunState string
+
+const (
+	StateRunning   RunState = "running"   // Steps are executing
+	StateCompleted RunState = "completed" // All runs completed
+	StateFailed    RunState = "failed"    // At least one run did not complete
+	StateCancelled RunState = "cancelled" // Stopped by the user
+)
+
+// IsTerminal checks if the suite run has finished.
+func (s RunState) IsTerminal() bool {
+	return s == StateCompleted || s == StateFailed || s == StateCancelled
+}
+
+// Run is one execution of a suite.
+type Run struct {
+	ID           string     `json:"id"`       // UUID, also used in the history tag
+	SuiteID      string     `json:"suite_id"` // Suite that was run
+	SuiteName    string     `json:"suite_name"`
+	State        RunState   `json:"state"`
+	StartedAt    time.Time  `json:"started_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	TotalRuns    int        `json:"total_runs"`              // Planned benchmark runs
+	Runs         []RunRef   `json:"runs"`                    // Benchmark runs started so far, in order
+	ReportPath   string     `json:"report_path,omitempty"`   // Exported comparison report
+	ErrorMessage string     `json:"error_message,omitempty"` // Summary of failures
+}
+
+// RunRef references a benchmark run of a suite run.
+type RunRef struct {
+	Step       int                `json:"step"`       // Step index (0-based)
+	Repetition int                `json:"repetition"` // Repetition (1-based)
+	RunID      string             `json:"run_id"`     // Benchmark run ID (= history record ID)
+	State      execution.RunState `json:"state"`
+	Error      string             `json:"error,omitempty"`
+
+	// SLA targets the completed run missed
+	FailedAssertions []string `json:"failed_assertions,omitempty"`
+}
+
+// Tag returns the history tag that groups the records of the suite run.
+func (r *Run) Tag() string {
+	return TagPrefix + r.ID
+}
+
+// FailedAssertionRunIDs returns the IDs of the completed benchmark runs that
+// missed their SLA targets.
+func (r *Run) FailedAssertionRunIDs() []string {
+	var ids []string
+	for _, ref := range r.Runs {
+		if len(ref.FailedAssertions) > 0 {
+			ids = append(ids, ref.RunID)
+		}
+	}
+	return ids
+}
+
+// CompletedRunIDs returns the IDs of the benchmark runs that completed.
+func (r *Run) CompletedRunIDs() []string {
+	var ids []string
+	for _, ref := range r.Runs {
+		if ref.State == execution.StateCompleted {
+			ids = append(ids, ref.RunID)
+		}
+	}
+	return ids
+}
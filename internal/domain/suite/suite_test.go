@@ -0,0 +1,74 @@
+// Package suite provides unit tests for the benchmark suite domain model.
+package suite
+
+import (
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+// TestSuite_Validate tests suite and step validation.
+func TestSuite_Validate(t *testing.T) {
+	valid := func() *Suite {
+		return &Suite{
+			Name: "nightly",
+			Steps: []Step{
+				{ConnectionID: "conn-1", TemplateID: "sysbench-oltp-read-write", Repetitions: 3, CooldownSeconds: 60},
+				{ConnectionID: "conn-1", TemplateID: "sysbench-oltp-read-only", Repetitions: 1},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(s *Suite)
+		wantErr bool
+	}{
+		{"valid", func(s *Suite) {}, false},
+		{"missing name", func(s *Suite) { s.Name = "" }, true},
+		{"no steps", func(s *Suite) { s.Steps = nil }, true},
+		{"missing connection", func(s *Suite) { s.Steps[1].ConnectionID = "" }, true},
+		{"missing template", func(s *Suite) { s.Steps[0].TemplateID = "" }, true},
+		{"no repetitions", func(s *Suite) { s.Steps[0].Repetitions = 0 }, true},
+		{"negative cooldown", func(s *Suite) { s.Steps[0].CooldownSeconds = -1 }, true},
+		{"dry run", func(s *Suite) { s.Steps[0].Options.DryRun = true }, true},
+		{"task repeat", func(s *Suite) { s.Steps[0].Options.Repeat = 3 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := valid()
+			tt.modify(s)
+			if err := s.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Suite.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got := valid().TotalRuns(); got != 4 {
+		t.Errorf("TotalRuns() = %d, want 4", got)
+	}
+}
+
+// TestRun_CompletedRunIDs tests selecting the completed runs of a suite run.
+func TestRun_CompletedRunIDs(t *testing.T) {
+	run := &Run{
+		ID: "suite-run-1",
+		Runs: []RunRef{
+			{RunID: "run-1", State: execution.StateCompleted},
+			{RunID: "run-2", State: execution.StateFailed},
+			{RunID: "run-3", State: execution.StateCompleted, FailedAssertions: []string{"min_tps"}},
+		},
+	}
+
+	ids := run.CompletedRunIDs()
+	if len(ids) != 2 || ids[0] != "run-1" || ids[1] != "run-3" {
+		t.Errorf("CompletedRunIDs() = %v, want [run-1 run-3]", ids)
+	}
+	if failed := run.FailedAssertionRunIDs(); len(failed) != 1 || failed[0] != "run-3" {
+		t.Errorf("FailedAssertionRunIDs() = %v, want [run-3]", failed)
+	}
+	if run.Tag() != "suite:suite-run-1" {
+		t.Errorf("Tag() = %q", run.Tag())
+	}
+}
@@ -0,0 +1,107 @@
+// Package sysbench provides sysbench log parsing functionality.
+// This file tracks the progress of the prepare phase from its output.
+package sysbench
+
+import (
+	"regexp"
+	"time"
+)
+
+// Prepare output lines, one set per table:
+//
+//	Creating table 'sbtest1'...
+//	Inserting 10000 records into 'sbtest1'
+//	Creating a secondary index on 'sbtest1'...
+var (
+	createTablePattern = regexp.MustCompile(`Creating table '(\w+)'`)
+	insertPattern      = regexp.MustCompile(`Inserting \d+ records into '(\w+)'`)
+	indexPattern       = regexp.MustCompile(`Creating a secondary index on '(\w+)'`)
+)
+
+// Share of a table's load that is done once it reaches a stage. Inserting
+// the rows takes most of the time, so a table only counts as mostly loaded
+// once sysbench moves on to its secondary index.
+const (
+	stageCreatedWeight   = 0.05
+	stageInsertingWeight = 0.1
+	stageIndexingWeight  = 0.9
+)
+
+// PrepareProgress tracks the progress of a sysbench prepare from its output.
+// It is not safe for concurrent use.
+type PrepareProgress struct {
+	tables int                // Tables the prepare creates
+	stages map[string]float64 // Done share of each table seen so far
+	loaded int                // Tables whose rows are inserted
+}
+
+// NewPrepareProgress creates a tracker for a prepare that creates tables tables.
+func NewPrepareProgress(tables int) *PrepareProgress {
+	if tables < 1 {
+		tables = 1
+	}
+	return &PrepareProgress{
+		tables: tables,
+		stages: make(map[string]float64),
+	}
+}
+
+// ParseLine updates the progress from a line of prepare output.
+// Returns true if the line was a progress line.
+func (p *PrepareProgress) ParseLine(line string) bool {
+	if m := indexPattern.FindStringSubmatch(line); m != nil {
+		if p.stages[m[1]] < stageIndexingWeight {
+			p.loaded++
+		}
+		p.advance(m[1], stageIndexingWeight)
+		return true
+	}
+	if m := insertPattern.FindStringSubmatch(line); m != nil {
+		p.advance(m[1], stageInsertingWeight)
+		return true
+	}
+	if m := createTablePattern.FindStringSubmatch(line); m != nil {
+		p.advance(m[1], stageCreatedWeight)
+		return true
+	}
+	return false
+}
+
+// advance moves a table to a stage; tables never move back.
+func (p *PrepareProgress) advance(table string, weight float64) {
+	if weight > p.stages[table] {
+		p.stages[table] = weight
+	}
+}
+
+// Tables returns the number of tables the prepare creates.
+func (p *PrepareProgress) Tables() int {
+	return p.tables
+}
+
+// Loaded returns the number of tables whose rows are inserted.
+func (p *PrepareProgress) Loaded() int {
+	return min(p.loaded, p.tables)
+}
+
+// Fraction returns the estimated share of the prepare that is done, in [0, 1).
+// The prepare only counts as complete once its command exits.
+func (p *PrepareProgress) Fraction() float64 {
+	var done float64
+	for _, weight := range p.stages {
+		done += weight
+	}
+	return min(done/float64(p.tables), 0.99)
+}
+
+// Remaining estimates the time left from the time elapsed so far. It returns
+// false until a table is loaded, since the create and insert lines of the
+// first tables arrive before any loading time can be measured.
+func (p *PrepareProgress) Remaining(elapsed time.Duration) (time.Duration, bool) {
+	fraction := p.Fraction()
+	if p.loaded == 0 || fraction <= 0 {
+		return 0, false
+	}
+	remaining := time.Duration(float64(elapsed) * (1 - fraction) / fraction)
+	return remaining.Round(time.Second), true
+}
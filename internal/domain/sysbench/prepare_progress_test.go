@@ -0,0 +1,59 @@
+package sysbench
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPrepareProgress(t *testing.T) {
+	p := NewPrepareProgress(2)
+
+	if _, ok := p.Remaining(time.Minute); ok {
+		t.Error("Remaining() ok before any output")
+	}
+
+	lines := []struct {
+		line         string
+		wantProgress bool
+		wantLoaded   int
+		wantFraction float64
+	}{
+		{"sysbench 1.0.20 (using system LuaJIT 2.1.0-beta3)", false, 0, 0},
+		{"Creating table 'sbtest1'...", true, 0, 0.025},
+		{"Inserting 10000 records into 'sbtest1'", true, 0, 0.05},
+		{"Creating a secondary index on 'sbtest1'...", true, 1, 0.45},
+		{"Creating table 'sbtest2'...", true, 1, 0.475},
+		{"Inserting 10000 records into 'sbtest2'", true, 1, 0.5},
+		{"Creating a secondary index on 'sbtest2'...", true, 2, 0.9},
+		{"Creating a secondary index on 'sbtest2'...", true, 2, 0.9},
+	}
+	for _, tt := range lines {
+		if got := p.ParseLine(tt.line); got != tt.wantProgress {
+			t.Errorf("ParseLine(%q) = %v, want %v", tt.line, got, tt.wantProgress)
+		}
+		if got := p.Loaded(); got != tt.wantLoaded {
+			t.Errorf("after %q Loaded() = %d, want %d", tt.line, got, tt.wantLoaded)
+		}
+		if got := p.Fraction(); got < tt.wantFraction-1e-9 || got > tt.wantFraction+1e-9 {
+			t.Errorf("after %q Fraction() = %v, want %v", tt.line, got, tt.wantFraction)
+		}
+	}
+
+	remaining, ok := p.Remaining(90 * time.Second)
+	if !ok || remaining != 10*time.Second {
+		t.Errorf("Remaining(90s) = %v, %v, want 10s, true", remaining, ok)
+	}
+}
+
+func TestPrepareProgress_MoreTablesThanExpected(t *testing.T) {
+	p := NewPrepareProgress(1)
+	for _, table := range []string{"sbtest1", "sbtest2"} {
+		p.ParseLine("Creating a secondary index on '" + table + "'...")
+	}
+	if got := p.Fraction(); got != 0.99 {
+		t.Errorf("Fraction() = %v, want 0.99 until the prepare exits", got)
+	}
+	if got := p.Loaded(); got != 1 {
+		t.Errorf("Loaded() = %d, want 1", got)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/assertion"
 )
 
 var (
@@ -37,6 +39,9 @@ type Template struct {
 	CommandTemplate CommandTemplate        `json:"command_template"`
 	OutputParser    OutputParser           `json:"output_parser"`
 	CustomData      map[string]interface{} `json:"custom_data,omitempty"`
+
+	// SLA targets of every run of the template; tasks may override them
+	Assertions assertion.Targets `json:"assertions,omitzero"`
 }
 
 // Parameter defines a configurable parameter for a template.
@@ -127,6 +132,10 @@ func (t *Template) Validate() error {
 		return fmt.Errorf("output parser: %w", err)
 	}
 
+	if err := t.Assertions.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrTemplateInvalid, err)
+	}
+
 	return nil
 }
 
@@ -5,7 +5,9 @@ package adapter
 import (
 	"context"
 	"io"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
@@ -25,8 +27,29 @@ const (
 	AdapterTypeHammerDB AdapterType = "hammerdb"
 	// AdapterTypeTPCC is for tpcc tool.
 	AdapterTypeTPCC AdapterType = "tpcc"
+	// AdapterTypeSQLMix is for the built-in SQL mix runner.
+	AdapterTypeSQLMix AdapterType = "sqlmix"
 )
 
+// Default executables of the tools, looked up in PATH.
+const (
+	DefaultSysbenchPath = "sysbench"
+	DefaultHammerDBPath = "hammerdbcli"
+	DefaultPsqlPath     = "psql"
+	DefaultMySQLPath    = "mysql"
+)
+
+// ToolPaths are the configured executables of the benchmark tools and the
+// database clients. Empty paths keep the adapter defaults.
+type ToolPaths struct {
+	Sysbench string
+	// Swingbench is the charbench executable; oewizard is expected next to it.
+	Swingbench string
+	HammerDB   string
+	Psql       string
+	MySQL      string
+}
+
 // Config represents the configuration for running a benchmark.
 // Implements: REQ-EXEC-001, REQ-EXEC-002
 type Config struct {
@@ -50,6 +73,45 @@ type Command struct {
 	WorkDir string `json:"work_dir"`
 	// Environment variables
 	Env []string `json:"env,omitempty"`
+	// Standard input fed to the process (e.g. HammerDB CLI scripts)
+	Stdin string `json:"stdin,omitempty"`
+	// Credential files by name, created in WorkDir readable only by the
+	// user before the process starts (e.g. a Swingbench config holding the
+	// password). Only local runs can use them.
+	Files map[string]string `json:"-"`
+	// Secrets that CmdLine, Env or Stdin contain and logs must not
+	Secrets []string `json:"-"`
+}
+
+// MaskedSecret replaces secrets in logged commands.
+const MaskedSecret = "*****"
+
+// CredentialFilePrefix starts the names of the credential files of commands,
+// so that they can be removed from work directories that are kept.
+const CredentialFilePrefix = ".credentials-"
+
+// Mask returns s with every secret of the command replaced by MaskedSecret,
+// as is and as escaped on the command line. A nil command masks nothing.
+func (c *Command) Mask(s string) string {
+	if c == nil {
+		return s
+	}
+	for _, secret := range c.Secrets {
+		if secret == "" {
+			continue
+		}
+		s = strings.ReplaceAll(s, commandArg(secret), MaskedSecret)
+		s = strings.ReplaceAll(s, secret, MaskedSecret)
+	}
+	return s
+}
+
+// MaskedCmdLine returns the command line with its secrets masked, for logs.
+func (c *Command) MaskedCmdLine() string {
+	if c == nil {
+		return ""
+	}
+	return c.Mask(c.CmdLine)
 }
 
 // Result represents the parsed result of a benchmark execution.
@@ -155,10 +217,10 @@ type BenchmarkAdapter interface {
 
 	// StartRealtimeCollection starts realtime metric collection from the running process.
 	// Returns a channel that will receive samples until the context is cancelled,
-	// an error channel, and a buffer containing the complete stdout for final result parsing.
+	// an error channel, and a buffer with the end of stdout for final result parsing.
 	// Note: This only reads stdout. stderr should be captured separately by the caller.
 	// Implements: REQ-EXEC-004 (realtime monitoring)
-	StartRealtimeCollection(ctx context.Context, stdout io.Reader) (<-chan Sample, <-chan error, *strings.Builder)
+	StartRealtimeCollection(ctx context.Context, stdout io.Reader) (<-chan Sample, <-chan error, *OutputBuffer)
 
 	// ValidateConfig validates the configuration for this adapter.
 	// Returns an error if the configuration is invalid.
@@ -174,9 +236,47 @@ type BenchmarkAdapter interface {
 	SupportsDatabase(dbType connection.DatabaseType) bool
 }
 
+// toolPathAdapter is implemented by adapters whose executables can be configured.
+type toolPathAdapter interface {
+	// withToolPaths returns a copy of the adapter that runs the configured executables.
+	withToolPaths(paths ToolPaths) BenchmarkAdapter
+}
+
+// localPath returns the configured executable path for local runs. Remote
+// runs look the tool up in PATH of the remote host, so they use name.
+func localPath(config *Config, path, name string) string {
+	if config != nil && config.Options.Remote() {
+		return name
+	}
+	return path
+}
+
+// connectionSecrets returns the secrets of the connection of a benchmark
+// configuration and the parameters named like passwords (e.g. dba_password).
+func connectionSecrets(config *Config) []string {
+	var secrets []string
+	switch c := config.Connection.(type) {
+	case *connection.MySQLConnection:
+		secrets = append(secrets, c.Password)
+	case *connection.PostgreSQLConnection:
+		secrets = append(secrets, c.Password)
+	case *connection.OracleConnection:
+		secrets = append(secrets, c.Password)
+	case *connection.SQLServerConnection:
+		secrets = append(secrets, c.Password)
+	}
+	for key, value := range config.Parameters {
+		if s, ok := value.(string); ok && strings.Contains(strings.ToLower(key), "password") {
+			secrets = append(secrets, s)
+		}
+	}
+	return slices.DeleteFunc(secrets, func(s string) bool { return s == "" })
+}
+
 // AdapterRegistry manages benchmark adapters.
 // Implements: Adapter lookup and registration
 type AdapterRegistry struct {
+	mu       sync.RWMutex
 	adapters map[AdapterType]BenchmarkAdapter
 }
 
@@ -189,18 +289,36 @@ func NewAdapterRegistry() *AdapterRegistry {
 
 // Register registers a benchmark adapter.
 func (r *AdapterRegistry) Register(adapter BenchmarkAdapter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.adapters[adapter.Type()] = adapter
 }
 
+// SetToolPaths replaces the registered adapters with copies that run the
+// configured executables. Runs that already hold an adapter keep using it.
+func (r *AdapterRegistry) SetToolPaths(paths ToolPaths) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for typ, adapter := range r.adapters {
+		if a, ok := adapter.(toolPathAdapter); ok {
+			r.adapters[typ] = a.withToolPaths(paths)
+		}
+	}
+}
+
 // Get returns an adapter by type.
 // Returns nil if the adapter is not registered.
 func (r *AdapterRegistry) Get(adapterType AdapterType) BenchmarkAdapter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	return r.adapters[adapterType]
 }
 
 // GetByTool returns an adapter by tool name (from template).
 // Returns nil if the adapter is not found.
 func (r *AdapterRegistry) GetByTool(tool string) BenchmarkAdapter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	// Map tool names to adapter types
 	switch tool {
 	case "sysbench":
@@ -211,6 +329,8 @@ func (r *AdapterRegistry) GetByTool(tool string) BenchmarkAdapter {
 		return r.adapters[AdapterTypeHammerDB]
 	case "tpcc":
 		return r.adapters[AdapterTypeTPCC]
+	case "sqlmix":
+		return r.adapters[AdapterTypeSQLMix]
 	default:
 		return nil
 	}
@@ -218,6 +338,8 @@ func (r *AdapterRegistry) GetByTool(tool string) BenchmarkAdapter {
 
 // List returns all registered adapter types.
 func (r *AdapterRegistry) List() []AdapterType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	var types []AdapterType
 	for typ := range r.adapters {
 		types = append(types, typ)
@@ -37,13 +37,12 @@ func (m *mockBenchmarkAdapter) ParseRunOutput(ctx context.Context, stdout string
 	return &Result{TPS: 1000.0}, nil
 }
 
-func (m *mockBenchmarkAdapter) StartRealtimeCollection(ctx context.Context, stdout io.Reader) (<-chan Sample, <-chan error, *strings.Builder) {
+func (m *mockBenchmarkAdapter) StartRealtimeCollection(ctx context.Context, stdout io.Reader) (<-chan Sample, <-chan error, *OutputBuffer) {
 	sampleCh := make(chan Sample, 1)
 	errCh := make(chan error, 1)
-	var buf strings.Builder
 	close(sampleCh)
 	close(errCh)
-	return sampleCh, errCh, &buf
+	return sampleCh, errCh, NewOutputBuffer(0)
 }
 
 func (m *mockBenchmarkAdapter) ValidateConfig(ctx context.Context, config *Config) error {
@@ -116,9 +115,11 @@ func TestAdapterRegistry_GetByTool(t *testing.T) {
 	sysbench := &mockBenchmarkAdapter{adapterType: AdapterTypeSysbench}
 	swingbench := &mockBenchmarkAdapter{adapterType: AdapterTypeSwingbench}
 	hammerdb := &mockBenchmarkAdapter{adapterType: AdapterTypeHammerDB}
+	sqlMix := &mockBenchmarkAdapter{adapterType: AdapterTypeSQLMix}
 	registry.Register(sysbench)
 	registry.Register(swingbench)
 	registry.Register(hammerdb)
+	registry.Register(sqlMix)
 
 	tests := []struct {
 		name    string
@@ -128,6 +129,7 @@ func TestAdapterRegistry_GetByTool(t *testing.T) {
 		{"get sysbench", "sysbench", false},
 		{"get swingbench", "swingbench", false},
 		{"get hammerdb", "hammerdb", false},
+		{"get sqlmix", "sqlmix", false},
 		{"get tpcc (not registered)", "tpcc", true},
 		{"get unknown tool", "unknown", true},
 	}
@@ -178,6 +180,62 @@ func TestAdapterRegistry_List(t *testing.T) {
 	}
 }
 
+// TestAdapterRegistry_SetToolPaths tests running configured executables.
+func TestAdapterRegistry_SetToolPaths(t *testing.T) {
+	ctx := context.Background()
+	registry := NewAdapterRegistry()
+	original := NewSysbenchAdapter()
+	registry.Register(original)
+	registry.Register(NewSwingbenchAdapter())
+	registry.Register(&mockBenchmarkAdapter{adapterType: AdapterTypeTPCC})
+
+	registry.SetToolPaths(ToolPaths{
+		Sysbench:   "/opt/sysbench/bin/sysbench",
+		Swingbench: "/opt/swingbench/bin/charbench",
+		MySQL:      "/opt/mysql/bin/mysql",
+	})
+
+	sysbench := registry.Get(AdapterTypeSysbench).(*SysbenchAdapter)
+	if sysbench.SysbenchPath != "/opt/sysbench/bin/sysbench" || sysbench.MySQLPath != "/opt/mysql/bin/mysql" {
+		t.Errorf("sysbench paths = %q, %q", sysbench.SysbenchPath, sysbench.MySQLPath)
+	}
+	if sysbench.PsqlPath != DefaultPsqlPath {
+		t.Errorf("PsqlPath = %q, want the default", sysbench.PsqlPath)
+	}
+	if original.SysbenchPath != DefaultSysbenchPath {
+		t.Errorf("registered adapter was modified: %q", original.SysbenchPath)
+	}
+	swingbench := registry.Get(AdapterTypeSwingbench).(*SwingbenchAdapter)
+	if swingbench.OewizardPath != "/opt/swingbench/bin/oewizard" {
+		t.Errorf("OewizardPath = %q", swingbench.OewizardPath)
+	}
+	if registry.Get(AdapterTypeTPCC) == nil {
+		t.Error("adapter without tool paths was dropped")
+	}
+
+	config := &Config{
+		Connection: &connection.MySQLConnection{Host: "localhost", Port: 3306, Database: "sbtest", Username: "root"},
+		Parameters: map[string]interface{}{},
+	}
+	cmd, err := sysbench.BuildCreateDatabaseCommand(ctx, config)
+	if err != nil {
+		t.Fatalf("BuildCreateDatabaseCommand() failed: %v", err)
+	}
+	if !strings.HasPrefix(cmd.CmdLine, "/opt/mysql/bin/mysql ") {
+		t.Errorf("CmdLine = %s, want the configured mysql client", cmd.CmdLine)
+	}
+
+	// Remote hosts look the tool up in their own PATH
+	config.Options = execution.TaskOptions{RemoteWinRM: true}
+	cmd, err = sysbench.BuildPrepareCommand(ctx, config)
+	if err != nil {
+		t.Fatalf("BuildPrepareCommand() failed: %v", err)
+	}
+	if !strings.HasPrefix(cmd.CmdLine, DefaultSysbenchPath+" ") {
+		t.Errorf("remote CmdLine = %s, want the default sysbench", cmd.CmdLine)
+	}
+}
+
 // TestBenchmarkAdapter_BuildPrepareCommand tests command building.
 func TestBenchmarkAdapter_BuildPrepareCommand(t *testing.T) {
 	ctx := context.Background()
@@ -310,6 +368,7 @@ func TestAdapterType(t *testing.T) {
 		{AdapterTypeSwingbench, "swingbench"},
 		{AdapterTypeHammerDB, "hammerdb"},
 		{AdapterTypeTPCC, "tpcc"},
+		{AdapterTypeSQLMix, "sqlmix"},
 	}
 
 	for _, tt := range tests {
@@ -4,6 +4,7 @@ package adapter
 
 import (
 	"bufio"
+	"cmp"
 	"context"
 	"fmt"
 	"io"
@@ -25,10 +26,15 @@ type HammerDBAdapter struct {
 // NewHammerDBAdapter creates a new hammerdb adapter.
 func NewHammerDBAdapter() *HammerDBAdapter {
 	return &HammerDBAdapter{
-		HammerDBPath: "hammerdbcli", // Default to CLI
+		HammerDBPath: DefaultHammerDBPath, // Default to CLI
 	}
 }
 
+// withToolPaths returns a copy of the adapter that runs the configured executables.
+func (a *HammerDBAdapter) withToolPaths(paths ToolPaths) BenchmarkAdapter {
+	return &HammerDBAdapter{HammerDBPath: cmp.Or(paths.HammerDB, DefaultHammerDBPath)}
+}
+
 // Type returns the adapter type.
 func (a *HammerDBAdapter) Type() AdapterType {
 	return AdapterTypeHammerDB
@@ -41,11 +47,13 @@ func (a *HammerDBAdapter) BuildPrepareCommand(ctx context.Context, config *Confi
 	// Build prepare script
 	script := a.buildScript(ctx, conn, config, "prepare")
 
-	cmdLine := fmt.Sprintf("echo '%s' | %s", script, a.HammerDBPath)
-
+	// The script is fed to hammerdbcli on stdin so it also works on
+	// Windows hosts where shell pipes are not available (WinRM execution).
 	return &Command{
-		CmdLine: cmdLine,
+		CmdLine: localPath(config, a.HammerDBPath, DefaultHammerDBPath),
 		WorkDir: config.WorkDir,
+		Stdin:   script,
+		Secrets: connectionSecrets(config),
 	}, nil
 }
 
@@ -56,11 +64,13 @@ func (a *HammerDBAdapter) BuildRunCommand(ctx context.Context, config *Config) (
 	// Build run script
 	script := a.buildScript(ctx, conn, config, "run")
 
-	cmdLine := fmt.Sprintf("echo '%s' | %s", script, a.HammerDBPath)
-
+	// The script is fed to hammerdbcli on stdin so it also works on
+	// Windows hosts where shell pipes are not available (WinRM execution).
 	return &Command{
-		CmdLine: cmdLine,
+		CmdLine: localPath(config, a.HammerDBPath, DefaultHammerDBPath),
 		WorkDir: config.WorkDir,
+		Stdin:   script,
+		Secrets: connectionSecrets(config),
 	}, nil
 }
 
@@ -71,62 +81,177 @@ func (a *HammerDBAdapter) BuildCleanupCommand(ctx context.Context, config *Confi
 	// Build cleanup script
 	script := a.buildScript(ctx, conn, config, "cleanup")
 
-	cmdLine := fmt.Sprintf("echo '%s' | %s", script, a.HammerDBPath)
-
+	// The script is fed to hammerdbcli on stdin so it also works on
+	// Windows hosts where shell pipes are not available (WinRM execution).
 	return &Command{
-		CmdLine: cmdLine,
+		CmdLine: localPath(config, a.HammerDBPath, DefaultHammerDBPath),
 		WorkDir: config.WorkDir,
+		Stdin:   script,
+		Secrets: connectionSecrets(config),
 	}, nil
 }
 
-// buildScript builds a TCL script for HammerDB.
+// HammerDB TPROC-C defaults used when the template or connection leaves them out.
+const (
+	hammerDBDefaultDatabase = "tpcc"                          // Schema created by buildschema
+	hammerDBDefaultUser     = "tpcc"                          // Schema owner for Oracle and PostgreSQL
+	hammerDBDefaultODBC     = "ODBC Driver 18 for SQL Server" // SQL Server ODBC driver
+)
+
+// hammerDBSetting is one "diset <dict> <key> <value>" line of a script.
+type hammerDBSetting struct {
+	dict  string // "connection" or "tpcc"
+	key   string
+	value string
+}
+
+// buildScript builds the hammerdbcli TCL script of a phase:
+// buildschema for prepare, a timed TPROC-C run for run and deleteschema for cleanup.
 func (a *HammerDBAdapter) buildScript(ctx context.Context, conn connection.Connection, config *Config, phase string) string {
 	var script strings.Builder
 
-	// Database type and connection
-	dbType := a.getDBType(conn)
-	connectionStr := a.buildConnectionString(conn)
-
-	script.WriteString(fmt.Sprintf("dbtype %s\n", dbType))
-	script.WriteString(fmt.Sprintf("disconn %s\n", connectionStr))
-	script.WriteString(fmt.Sprintf("vu %d\n", a.getIntParam(config.Parameters, "virtual_users", 1)))
-	script.WriteString(fmt.Sprintf("vucount %d\n", a.getIntParam(config.Parameters, "vu_count", 1)))
-	script.WriteString(fmt.Sprintf("vuverbose %s\n", a.getBoolParam(config.Parameters, "vu_verbose", "false")))
-	script.WriteString(fmt.Sprintf("iterations %d\n", a.getIntParam(config.Parameters, "iterations", 1)))
-	script.WriteString(fmt.Sprintf("tcname %s\n", a.getStringParam(config.Parameters, "testcase", "TPC-C")))
-	script.WriteString(fmt.Sprintf("tcstatus %s\n", a.getStringParam(config.Parameters, "tcstatus", "")))
-	script.WriteString(fmt.Sprintf("rampup %d\n", a.getIntParam(config.Parameters, "rampup", 0)))
-	script.WriteString(fmt.Sprintf("duration %d\n", a.getIntParam(config.Parameters, "duration", 1)))
-	script.WriteString(fmt.Sprintf("alliterations %s\n", a.getBoolParam(config.Parameters, "all_iterations", "true")))
-	script.WriteString(fmt.Sprintf("times %s\n", a.getBoolParam(config.Parameters, "times", "true")))
-	script.WriteString(fmt.Sprintf("background %s\n", a.getBoolParam(config.Parameters, "background", "false")))
-	script.WriteString(fmt.Sprintf("nozip %s\n", a.getBoolParam(config.Parameters, "no_zip", "false")))
-	script.WriteString(fmt.Sprintf("suppress_output %s\n", a.getBoolParam(config.Parameters, "suppress_output", "false")))
-	script.WriteString(fmt.Sprintf("hwscale %s\n", a.getBoolParam(config.Parameters, "hwscale", "false")))
-	script.WriteString(fmt.Sprintf("hwmem %s\n", a.getBoolParam(config.Parameters, "hwmem", "false")))
-	script.WriteString(fmt.Sprintf("clearlog %s\n", a.getBoolParam(config.Parameters, "clear_log", "true")))
-	script.WriteString(fmt.Sprintf("logtotemp %s\n", a.getBoolParam(config.Parameters, "log_to_temp", "false")))
-
-	// Phase-specific commands
+	script.WriteString(fmt.Sprintf("dbset db %s\n", a.getDBType(conn)))
+	script.WriteString("dbset bm TPC-C\n")
+	for _, setting := range a.buildSettings(conn, config.Parameters) {
+		script.WriteString(fmt.Sprintf("diset %s %s %s\n", setting.dict, setting.key, tclWord(setting.value)))
+	}
+
 	switch phase {
 	case "prepare":
-		script.WriteString("loadscript\n")
-		script.WriteString("create virtualmachine\n")
-		script.WriteString("vucreate\n")
-		script.WriteString("vurun\n")
+		script.WriteString("buildschema\n")
 	case "run":
 		script.WriteString("loadscript\n")
-		script.WriteString("create virtualmachine\n")
+		script.WriteString(fmt.Sprintf("vuset vu %d\n", a.getIntParam(config.Parameters, "threads", 1)))
 		script.WriteString("vucreate\n")
 		script.WriteString("vurun\n")
 		script.WriteString("vudestroy\n")
 	case "cleanup":
-		script.WriteString("delete virtualmachine\n")
+		script.WriteString("deleteschema\n")
 	}
+	script.WriteString("quit\n")
 
 	return script.String()
 }
 
+// buildSettings returns the connection and TPROC-C settings of the target database.
+// The run time and ramp up are in minutes, as HammerDB expects them.
+func (a *HammerDBAdapter) buildSettings(conn connection.Connection, params map[string]interface{}) []hammerDBSetting {
+	threads := a.getIntParam(params, "threads", 1)
+	warehouses := a.getIntParam(params, "warehouses", 1)
+	// buildschema uses one virtual user per warehouse at most
+	buildUsers := min(threads, warehouses)
+
+	var settings []hammerDBSetting
+	conf := func(key, value string) {
+		settings = append(settings, hammerDBSetting{dict: "connection", key: key, value: value})
+	}
+	tpcc := func(key, value string) {
+		settings = append(settings, hammerDBSetting{dict: "tpcc", key: key, value: value})
+	}
+
+	// Workload keys are prefixed per database, except for Oracle
+	prefix := ""
+	switch c := conn.(type) {
+	case *connection.SQLServerConnection:
+		prefix = "mssqls_"
+		host, instance := c.HostAndInstance()
+		server := host
+		if instance != "" {
+			server = host + `\` + instance
+		}
+		authentication := "sql"
+		if c.Authentication == connection.SQLServerAuthWindows {
+			authentication = "windows"
+		}
+		// The linux_ keys are used when hammerdbcli runs on Linux
+		conf("mssqls_server", server)
+		conf("mssqls_linux_server", server)
+		// Named instances on the default port are resolved by SQL Server Browser
+		conf("mssqls_tcp", strconv.FormatBool(instance == "" || (c.Port != 0 && c.Port != 1433)))
+		conf("mssqls_port", strconv.Itoa(c.Port))
+		conf("mssqls_authentication", authentication)
+		conf("mssqls_linux_authent", authentication)
+		odbc := a.getStringParam(params, "odbc_driver", hammerDBDefaultODBC)
+		conf("mssqls_odbc_driver", odbc)
+		conf("mssqls_linux_odbc", odbc)
+		conf("mssqls_uid", c.Username)
+		conf("mssqls_pass", c.Password)
+		conf("mssqls_encrypt_connection", strconv.FormatBool(c.Encrypt != connection.SQLServerEncryptDisable &&
+			c.Encrypt != connection.SQLServerEncryptOptional))
+		conf("mssqls_trust_server_cert", strconv.FormatBool(c.TrustServerCertificate))
+		tpcc("mssqls_dbase", orDefault(c.Database, hammerDBDefaultDatabase))
+	case *connection.MySQLConnection:
+		prefix = "mysql_"
+		conf("mysql_host", c.Host)
+		conf("mysql_port", strconv.Itoa(c.Port))
+		tpcc("mysql_user", c.Username)
+		tpcc("mysql_pass", c.Password)
+		tpcc("mysql_dbase", orDefault(c.Database, hammerDBDefaultDatabase))
+	case *connection.PostgreSQLConnection:
+		prefix = "pg_"
+		conf("pg_host", c.Host)
+		conf("pg_port", strconv.Itoa(c.Port))
+		conf("pg_sslmode", orDefault(c.SSLMode, "prefer"))
+		tpcc("pg_superuser", c.Username)
+		tpcc("pg_superuserpass", c.Password)
+		tpcc("pg_user", a.getStringParam(params, "schema_user", hammerDBDefaultUser))
+		tpcc("pg_pass", c.Password)
+		tpcc("pg_dbase", orDefault(c.Database, hammerDBDefaultDatabase))
+	case *connection.OracleConnection:
+		// Easy Connect only names services; SIDs and TCPS need a descriptor
+		instance := c.Descriptor()
+		if c.ServiceName != "" && !c.IsTCPS() {
+			instance = fmt.Sprintf("//%s:%d/%s", c.Host, c.Port, c.ServiceName)
+		}
+		conf("system_user", c.Username)
+		conf("system_password", c.Password)
+		conf("instance", instance)
+		tpcc("tpcc_user", a.getStringParam(params, "schema_user", hammerDBDefaultUser))
+		tpcc("tpcc_pass", c.Password)
+	}
+
+	tpcc(prefix+"count_ware", strconv.Itoa(warehouses))
+	tpcc(prefix+"num_vu", strconv.Itoa(buildUsers))
+	if prefix == "" {
+		tpcc("ora_driver", "timed")
+	} else {
+		tpcc(prefix+"driver", "timed")
+	}
+	tpcc(prefix+"rampup", strconv.Itoa(a.getIntParam(params, "rampup_time", 2)))
+	tpcc(prefix+"duration", strconv.Itoa(a.getIntParam(params, "time", 5)))
+
+	return settings
+}
+
+// tclWord quotes a value as a single TCL word.
+func tclWord(value string) string {
+	if value == "" {
+		return "{}"
+	}
+	if !strings.ContainsAny(value, " \t\n\"\\$[]{};") {
+		return value
+	}
+	if !strings.ContainsAny(value, "{}\\") {
+		return "{" + value + "}"
+	}
+	var quoted strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune(" \t\n\"\\$[]{};", r) {
+			quoted.WriteByte('\\')
+		}
+		quoted.WriteRune(r)
+	}
+	return quoted.String()
+}
+
+// orDefault returns value, or defaultValue when value is empty.
+func orDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
 // ParseRunOutput parses the output from a hammerdb run.
 func (a *HammerDBAdapter) ParseRunOutput(ctx context.Context, stdout string, stderr string) (*Result, error) {
 	result := &Result{
@@ -142,8 +267,10 @@ func (a *HammerDBAdapter) ParseRunOutput(ctx context.Context, stdout string, std
 		}
 
 		// Parse TPM (Transactions Per Minute) or NOPM (New Orders Per Minute)
-		// Format: "TEST RESULT : System achieved 12345 NOPM from 1 Virtual Users"
-		if strings.Contains(line, "NOPM") || strings.Contains(line, "TPM") {
+		// Format: "Vuser 1:TEST RESULT : System achieved 40165 NOPM from 93407 SQL Server TPM"
+		if _, tpm, ok := parseTestResult(line); ok {
+			result.TPS = tpm / 60 // Convert to TPS
+		} else if strings.Contains(line, "NOPM") || strings.Contains(line, "TPM") {
 			re := regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(?:NOPM|TPM)`)
 			matches := re.FindStringSubmatch(line)
 			if len(matches) > 1 {
@@ -215,10 +342,10 @@ func (a *HammerDBAdapter) ParseRunOutput(ctx context.Context, stdout string, std
 }
 
 // StartRealtimeCollection starts realtime metric collection from hammerdb output.
-func (a *HammerDBAdapter) StartRealtimeCollection(ctx context.Context, stdout io.Reader) (<-chan Sample, <-chan error, *strings.Builder) {
+func (a *HammerDBAdapter) StartRealtimeCollection(ctx context.Context, stdout io.Reader) (<-chan Sample, <-chan error, *OutputBuffer) {
 	sampleChan := make(chan Sample, 10)
 	errChan := make(chan error, 1)
-	var stdoutBuf strings.Builder
+	stdoutBuf := NewSummaryOutputBuffer(SummaryOutputTail, &hammerdbSummaryParser{})
 
 	go func() {
 		defer close(sampleChan)
@@ -232,9 +359,8 @@ func (a *HammerDBAdapter) StartRealtimeCollection(ctx context.Context, stdout io
 			line := scanner.Text()
 			line = strings.TrimSpace(line)
 
-			// Save to stdout buffer
-			stdoutBuf.WriteString(line)
-			stdoutBuf.WriteString("\n")
+			// Parse the final result as it arrives
+			stdoutBuf.AddLine(line)
 
 			// Parse realtime TPM/NOPM
 			if strings.Contains(line, "NOPM") || strings.Contains(line, "TPM") {
@@ -283,14 +409,56 @@ func (a *HammerDBAdapter) StartRealtimeCollection(ctx context.Context, stdout io
 		}
 	}()
 
-	return sampleChan, errChan, &stdoutBuf
+	return sampleChan, errChan, stdoutBuf
+}
+
+// testResultPattern matches the result line of a timed TPROC-C run, e.g.
+// "Vuser 1:TEST RESULT : System achieved 40165 NOPM from 93407 SQL Server TPM".
+var testResultPattern = regexp.MustCompile(`TEST RESULT\s*:\s*System achieved\s+(\d+)\s+NOPM\s+from\s+(\d+)\s+.*TPM`)
+
+// parseTestResult returns the NOPM and TPM of a TPROC-C result line.
+func parseTestResult(line string) (nopm, tpm float64, ok bool) {
+	matches := testResultPattern.FindStringSubmatch(line)
+	if matches == nil {
+		return 0, 0, false
+	}
+	nopm, _ = strconv.ParseFloat(matches[1], 64)
+	tpm, _ = strconv.ParseFloat(matches[2], 64)
+	return nopm, tpm, true
 }
 
 // ParseFinalResults parses final results from hammerdb output.
-// TODO: Implement hammerdb-specific parsing
+// HammerDB reports database transactions per minute, which are converted to
+// transactions per second.
 func (a *HammerDBAdapter) ParseFinalResults(ctx context.Context, stdout string) (*FinalResult, error) {
-	// Stub implementation for now
-	return &FinalResult{}, fmt.Errorf("parse final results not implemented for hammerdb")
+	parser := &hammerdbSummaryParser{}
+	for _, line := range strings.Split(stdout, "\n") {
+		parser.ParseLine(line)
+	}
+	return parser.Result()
+}
+
+// hammerdbSummaryParser keeps the first TEST RESULT line of hammerdb output.
+type hammerdbSummaryParser struct {
+	result *FinalResult
+}
+
+// ParseLine implements SummaryParser.
+func (p *hammerdbSummaryParser) ParseLine(line string) {
+	if p.result != nil {
+		return
+	}
+	if _, tpm, ok := parseTestResult(line); ok {
+		p.result = &FinalResult{TransactionsPerSec: tpm / 60}
+	}
+}
+
+// Result implements SummaryParser.
+func (p *hammerdbSummaryParser) Result() (*FinalResult, error) {
+	if p.result == nil {
+		return nil, fmt.Errorf("no TEST RESULT line in hammerdb output")
+	}
+	return p.result, nil
 }
 
 // ValidateConfig validates the configuration for hammerdb.
@@ -329,38 +497,19 @@ func (a *HammerDBAdapter) SupportsDatabase(dbType connection.DatabaseType) bool
 	}
 }
 
-// getDBType returns the HammerDB database type string.
+// getDBType returns the hammerdbcli database name used by "dbset db".
 func (a *HammerDBAdapter) getDBType(conn connection.Connection) string {
 	switch conn.GetType() {
 	case connection.DatabaseTypeMySQL:
-		return "MySQL"
+		return "mysql"
 	case connection.DatabaseTypeOracle:
-		return "Oracle"
+		return "ora"
 	case connection.DatabaseTypeSQLServer:
-		return "MSSQLServer"
+		return "mssqls"
 	case connection.DatabaseTypePostgreSQL:
-		return "Postgres"
+		return "pg"
 	default:
-		return "Unknown"
-	}
-}
-
-// buildConnectionString builds a HammerDB connection string.
-func (a *HammerDBAdapter) buildConnectionString(conn connection.Connection) string {
-	switch c := conn.(type) {
-	case *connection.MySQLConnection:
-		return fmt.Sprintf("%s@%s:%d/%s", c.Username, c.Host, c.Port, c.Database)
-	case *connection.OracleConnection:
-		if c.ServiceName != "" {
-			return fmt.Sprintf("%s@//%s:%d/%s", c.Username, c.Host, c.Port, c.ServiceName)
-		}
-		return fmt.Sprintf("%s@%s:%d:%s", c.Username, c.Host, c.Port, c.SID)
-	case *connection.SQLServerConnection:
-		return fmt.Sprintf("%s@%s:%d/%s", c.Username, c.Host, c.Port, c.Database)
-	case *connection.PostgreSQLConnection:
-		return fmt.Sprintf("%s@%s:%d/%s", c.Username, c.Host, c.Port, c.Database)
-	default:
-		return ""
+		return "unknown"
 	}
 }
 
@@ -383,24 +532,9 @@ func (a *HammerDBAdapter) getIntParam(params map[string]interface{}, key string,
 
 func (a *HammerDBAdapter) getStringParam(params map[string]interface{}, key, defaultValue string) string {
 	if val, ok := params[key]; ok {
-		if s, ok := val.(string); ok {
+		if s, ok := val.(string); ok && s != "" {
 			return s
 		}
 	}
 	return defaultValue
 }
-
-func (a *HammerDBAdapter) getBoolParam(params map[string]interface{}, key, defaultValue string) string {
-	if val, ok := params[key]; ok {
-		switch v := val.(type) {
-		case bool:
-			if v {
-				return "true"
-			}
-			return "false"
-		case string:
-			return v
-		}
-	}
-	return defaultValue
-}
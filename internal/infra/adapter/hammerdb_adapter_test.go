@@ -0,0 +1,134 @@
+// Package adapter provides unit tests for HammerDB adapter.
+package adapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+)
+
+func newSQLServerConfig() *Config {
+	return &Config{
+		Connection: &connection.SQLServerConnection{
+			BaseConnection:         connection.BaseConnection{ID: "mssql-1", Name: "Test SQL Server"},
+			Host:                   "mssql.local",
+			Port:                   1433,
+			Username:               "sa",
+			Password:               "p@ss word",
+			TrustServerCertificate: true,
+		},
+		Parameters: map[string]interface{}{
+			"threads":     16,
+			"time":        10,
+			"warehouses":  4,
+			"rampup_time": 1,
+		},
+		WorkDir: "/tmp/test",
+	}
+}
+
+// TestHammerDBAdapter_SQLServerScripts tests the TPROC-C scripts for SQL Server.
+func TestHammerDBAdapter_SQLServerScripts(t *testing.T) {
+	ctx := context.Background()
+	adapter := NewHammerDBAdapter()
+	config := newSQLServerConfig()
+
+	require.NoError(t, adapter.ValidateConfig(ctx, config))
+
+	prepare, err := adapter.BuildPrepareCommand(ctx, config)
+	require.NoError(t, err)
+	assert.Equal(t, "hammerdbcli", prepare.CmdLine)
+	for _, line := range []string{
+		"dbset db mssqls\n",
+		"dbset bm TPC-C\n",
+		"diset connection mssqls_server mssql.local\n",
+		"diset connection mssqls_port 1433\n",
+		"diset connection mssqls_odbc_driver {ODBC Driver 18 for SQL Server}\n",
+		"diset connection mssqls_uid sa\n",
+		"diset connection mssqls_pass {p@ss word}\n",
+		"diset connection mssqls_trust_server_cert true\n",
+		"diset tpcc mssqls_dbase tpcc\n",
+		"diset tpcc mssqls_count_ware 4\n",
+		"diset tpcc mssqls_num_vu 4\n",
+		"buildschema\nquit\n",
+	} {
+		assert.Contains(t, prepare.Stdin, line)
+	}
+
+	run, err := adapter.BuildRunCommand(ctx, config)
+	require.NoError(t, err)
+	for _, line := range []string{
+		"diset tpcc mssqls_driver timed\n",
+		"diset tpcc mssqls_rampup 1\n",
+		"diset tpcc mssqls_duration 10\n",
+		"loadscript\nvuset vu 16\nvucreate\nvurun\nvudestroy\nquit\n",
+	} {
+		assert.Contains(t, run.Stdin, line)
+	}
+
+	cleanup, err := adapter.BuildCleanupCommand(ctx, config)
+	require.NoError(t, err)
+	assert.Contains(t, cleanup.Stdin, "deleteschema\nquit\n")
+}
+
+// TestHammerDBAdapter_SQLServerAdvancedOptions tests the named instance,
+// encryption and authentication settings of SQL Server.
+func TestHammerDBAdapter_SQLServerAdvancedOptions(t *testing.T) {
+	ctx := context.Background()
+	adapter := NewHammerDBAdapter()
+	config := newSQLServerConfig()
+	conn := config.Connection.(*connection.SQLServerConnection)
+	conn.Host = `mssql.local\BENCH`
+	conn.Encrypt = connection.SQLServerEncryptOptional
+	conn.Authentication = connection.SQLServerAuthWindows
+
+	prepare, err := adapter.BuildPrepareCommand(ctx, config)
+	require.NoError(t, err)
+	for _, line := range []string{
+		"diset connection mssqls_server mssql.local\\\\BENCH\n",
+		"diset connection mssqls_tcp false\n",
+		"diset connection mssqls_authentication windows\n",
+		"diset connection mssqls_linux_authent windows\n",
+		"diset connection mssqls_encrypt_connection false\n",
+	} {
+		assert.Contains(t, prepare.Stdin, line)
+	}
+}
+
+// TestHammerDBAdapter_ParseFinalResults tests parsing the TPROC-C result line.
+func TestHammerDBAdapter_ParseFinalResults(t *testing.T) {
+	ctx := context.Background()
+	adapter := NewHammerDBAdapter()
+	stdout := "Vuser 1:Rampup 1 minutes complete ...\n" +
+		"Vuser 1:TEST RESULT : System achieved 40165 NOPM from 93420 SQL Server TPM\n"
+
+	result, err := adapter.ParseFinalResults(ctx, stdout)
+	require.NoError(t, err)
+	assert.InDelta(t, 1557.0, result.TransactionsPerSec, 0.001)
+
+	runResult, err := adapter.ParseRunOutput(ctx, stdout, "")
+	require.NoError(t, err)
+	assert.InDelta(t, 1557.0, runResult.TPS, 0.001)
+
+	_, err = adapter.ParseFinalResults(ctx, "Error: no ODBC driver\n")
+	assert.Error(t, err)
+}
+
+// TestTCLWord tests quoting values as TCL words.
+func TestTCLWord(t *testing.T) {
+	tests := map[string]string{
+		"":        "{}",
+		"tpcc":    "tpcc",
+		"a b":     "{a b}",
+		"p$ss":    "{p$ss}",
+		"a{b":     `a\{b`,
+		`back\sl`: `back\\sl`,
+	}
+	for value, want := range tests {
+		assert.Equal(t, want, tclWord(value), "tclWord(%q)", value)
+	}
+}
@@ -0,0 +1,117 @@
+package adapter
+
+import "math"
+
+// MergeSamples combines the samples that several load generators reported
+// for the same interval into the sample of one logical run.
+// Throughput and threads are summed. Latencies and the error rate are averaged
+// weighted by each generator's TPS, which is exact for the average latency and
+// an approximation for the percentiles. RawLine is left empty.
+func MergeSamples(samples []Sample) Sample {
+	var merged Sample
+	if len(samples) == 0 {
+		return merged
+	}
+
+	weights := make([]float64, len(samples))
+	avg := make([]float64, len(samples))
+	p95 := make([]float64, len(samples))
+	p99 := make([]float64, len(samples))
+	errRate := make([]float64, len(samples))
+	for i, s := range samples {
+		if s.Timestamp.After(merged.Timestamp) {
+			merged.Timestamp = s.Timestamp
+		}
+		merged.TPS += s.TPS
+		merged.QPS += s.QPS
+		merged.ThreadCount += s.ThreadCount
+
+		weights[i] = s.TPS
+		avg[i] = s.LatencyAvg
+		p95[i] = s.LatencyP95
+		p99[i] = s.LatencyP99
+		errRate[i] = s.ErrorRate
+	}
+	merged.LatencyAvg = weightedMean(avg, weights)
+	merged.LatencyP95 = weightedMean(p95, weights)
+	merged.LatencyP99 = weightedMean(p99, weights)
+	merged.ErrorRate = weightedMean(errRate, weights)
+	return merged
+}
+
+// MergeFinalResults combines the final results of several load generators
+// that ran the same workload at the same time.
+// Counts and rates are summed, the minimum and maximum latency are taken over
+// all generators and the run time is the longest one. The average latency is
+// recomputed from the latency sum; percentiles are averaged weighted by each
+// generator's transactions (an approximation). Threads fairness statistics
+// are averaged. Returns nil for no results.
+func MergeFinalResults(results []*FinalResult) *FinalResult {
+	if len(results) == 0 {
+		return nil
+	}
+
+	merged := &FinalResult{LatencyMin: math.Inf(1)}
+	n := float64(len(results))
+	weights := make([]float64, len(results))
+	avg := make([]float64, len(results))
+	p95 := make([]float64, len(results))
+	p99 := make([]float64, len(results))
+	for i, r := range results {
+		merged.TotalTransactions += r.TotalTransactions
+		merged.TransactionsPerSec += r.TransactionsPerSec
+		merged.TotalQueries += r.TotalQueries
+		merged.QueriesPerSec += r.QueriesPerSec
+		merged.ReadQueries += r.ReadQueries
+		merged.WriteQueries += r.WriteQueries
+		merged.OtherQueries += r.OtherQueries
+		merged.IgnoredErrors += r.IgnoredErrors
+		merged.Reconnects += r.Reconnects
+		merged.LatencySum += r.LatencySum
+		merged.TotalEvents += r.TotalEvents
+
+		merged.LatencyMin = math.Min(merged.LatencyMin, r.LatencyMin)
+		merged.LatencyMax = math.Max(merged.LatencyMax, r.LatencyMax)
+		merged.TotalTime = math.Max(merged.TotalTime, r.TotalTime)
+
+		merged.EventsAvg += r.EventsAvg / n
+		merged.EventsStddev += r.EventsStddev / n
+		merged.ExecTimeAvg += r.ExecTimeAvg / n
+		merged.ExecTimeStddev += r.ExecTimeStddev / n
+
+		weights[i] = float64(r.TotalTransactions)
+		avg[i] = r.LatencyAvg
+		p95[i] = r.LatencyP95
+		p99[i] = r.LatencyP99
+	}
+
+	if merged.LatencySum > 0 && merged.TotalEvents > 0 {
+		merged.LatencyAvg = merged.LatencySum / float64(merged.TotalEvents)
+	} else {
+		merged.LatencyAvg = weightedMean(avg, weights)
+	}
+	merged.LatencyP95 = weightedMean(p95, weights)
+	merged.LatencyP99 = weightedMean(p99, weights)
+	return merged
+}
+
+// weightedMean returns the mean of values weighted by weights, or the plain
+// mean if the weights sum to zero.
+func weightedMean(values, weights []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum, total float64
+	for i, v := range values {
+		sum += v * weights[i]
+		total += weights[i]
+	}
+	if total > 0 {
+		return sum / total
+	}
+	sum = 0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,78 @@
+package adapter
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// TestMergeSamples tests that throughput is summed and latencies are TPS-weighted.
+func TestMergeSamples(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	merged := MergeSamples([]Sample{
+		{Timestamp: t0, TPS: 300, QPS: 6000, LatencyAvg: 10, LatencyP95: 20, LatencyP99: 30, ThreadCount: 8},
+		{Timestamp: t0.Add(200 * time.Millisecond), TPS: 100, QPS: 2000, LatencyAvg: 30, LatencyP95: 40, LatencyP99: 50, ErrorRate: 4, ThreadCount: 8},
+	})
+
+	if merged.TPS != 400 || merged.QPS != 8000 || merged.ThreadCount != 16 {
+		t.Errorf("TPS = %v, QPS = %v, threads = %d, want 400, 8000, 16", merged.TPS, merged.QPS, merged.ThreadCount)
+	}
+	if !merged.Timestamp.Equal(t0.Add(200 * time.Millisecond)) {
+		t.Errorf("Timestamp = %v, want the latest sample", merged.Timestamp)
+	}
+	if !approxEqual(merged.LatencyAvg, 15) || !approxEqual(merged.LatencyP95, 25) || !approxEqual(merged.LatencyP99, 35) {
+		t.Errorf("latency avg/p95/p99 = %v/%v/%v, want 15/25/35", merged.LatencyAvg, merged.LatencyP95, merged.LatencyP99)
+	}
+	if !approxEqual(merged.ErrorRate, 1) {
+		t.Errorf("ErrorRate = %v, want 1", merged.ErrorRate)
+	}
+
+	// Idle generators are averaged equally
+	idle := MergeSamples([]Sample{{LatencyP95: 10}, {LatencyP95: 20}})
+	if !approxEqual(idle.LatencyP95, 15) {
+		t.Errorf("idle LatencyP95 = %v, want 15", idle.LatencyP95)
+	}
+}
+
+// TestMergeFinalResults tests combining the final results of two generators.
+func TestMergeFinalResults(t *testing.T) {
+	if MergeFinalResults(nil) != nil {
+		t.Error("MergeFinalResults(nil) != nil")
+	}
+
+	merged := MergeFinalResults([]*FinalResult{
+		{
+			TotalTransactions: 3000, TransactionsPerSec: 50, TotalQueries: 60000, QueriesPerSec: 1000,
+			LatencyMin: 2, LatencyMax: 80, LatencyP95: 20, LatencyP99: 40, LatencySum: 30000,
+			TotalTime: 60.01, TotalEvents: 3000, Reconnects: 1,
+		},
+		{
+			TotalTransactions: 1000, TransactionsPerSec: 16.5, TotalQueries: 20000, QueriesPerSec: 330,
+			LatencyMin: 1, LatencyMax: 120, LatencyP95: 60, LatencyP99: 80, LatencySum: 30000,
+			TotalTime: 60.02, TotalEvents: 1000, IgnoredErrors: 2,
+		},
+	})
+
+	if merged.TotalTransactions != 4000 || merged.TotalQueries != 80000 || merged.TotalEvents != 4000 {
+		t.Errorf("totals = %d/%d/%d", merged.TotalTransactions, merged.TotalQueries, merged.TotalEvents)
+	}
+	if !approxEqual(merged.TransactionsPerSec, 66.5) || !approxEqual(merged.QueriesPerSec, 1330) {
+		t.Errorf("TPS = %v, QPS = %v", merged.TransactionsPerSec, merged.QueriesPerSec)
+	}
+	if merged.LatencyMin != 1 || merged.LatencyMax != 120 || merged.TotalTime != 60.02 {
+		t.Errorf("min/max/time = %v/%v/%v", merged.LatencyMin, merged.LatencyMax, merged.TotalTime)
+	}
+	if !approxEqual(merged.LatencyAvg, 15) {
+		t.Errorf("LatencyAvg = %v, want 15 (latency sum / events)", merged.LatencyAvg)
+	}
+	if !approxEqual(merged.LatencyP95, 30) || !approxEqual(merged.LatencyP99, 50) {
+		t.Errorf("p95/p99 = %v/%v, want 30/50", merged.LatencyP95, merged.LatencyP99)
+	}
+	if merged.Reconnects != 1 || merged.IgnoredErrors != 2 {
+		t.Errorf("reconnects/errors = %d/%d", merged.Reconnects, merged.IgnoredErrors)
+	}
+}
@@ -0,0 +1,110 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"sync"
+)
+
+// DefaultOutputTail is the amount of tool output an OutputBuffer keeps.
+// Tools print their final summary at the end, far within this limit.
+const DefaultOutputTail = 1 << 20
+
+// SummaryOutputTail is the amount of tool output kept by buffers that parse
+// the final results while the output arrives, for diagnostics only.
+const SummaryOutputTail = 64 << 10
+
+// SummaryParser parses the final results of a tool from its output line by
+// line, as the output arrives, so the output need not be kept for parsing.
+type SummaryParser interface {
+	// ParseLine parses the next line of output.
+	ParseLine(line string)
+
+	// Result returns the final results parsed so far.
+	Result() (*FinalResult, error)
+}
+
+// OutputBuffer collects the output of a running tool for its final results.
+// Adapters with a SummaryParser parse the results while the output arrives;
+// for the others the buffer keeps the last lines of the output, at most limit
+// bytes, for ParseFinalResults. Either way very long runs do not hold their
+// whole output in memory; the full output is captured to the run's output log
+// by the caller.
+// It is safe for concurrent use.
+type OutputBuffer struct {
+	mu        sync.Mutex
+	buf       []byte
+	limit     int
+	truncated bool
+	parser    SummaryParser
+}
+
+// NewOutputBuffer creates a buffer keeping the last limit bytes of output.
+// A limit of 0 or less keeps DefaultOutputTail.
+func NewOutputBuffer(limit int) *OutputBuffer {
+	if limit <= 0 {
+		limit = DefaultOutputTail
+	}
+	return &OutputBuffer{limit: limit}
+}
+
+// NewSummaryOutputBuffer creates a buffer that passes each line added with
+// AddLine to parser, and keeps the last limit bytes of output.
+func NewSummaryOutputBuffer(limit int, parser SummaryParser) *OutputBuffer {
+	b := NewOutputBuffer(limit)
+	b.parser = parser
+	return b
+}
+
+// AddLine appends a line of output and passes it to the summary parser.
+func (b *OutputBuffer) AddLine(line string) {
+	b.WriteString(line + "\n")
+	if b.parser != nil {
+		b.mu.Lock()
+		b.parser.ParseLine(line)
+		b.mu.Unlock()
+	}
+}
+
+// FinalResults returns the final results parsed while the output was
+// collected or, without a summary parser, parses the kept output with adapt.
+func (b *OutputBuffer) FinalResults(ctx context.Context, adapt BenchmarkAdapter) (*FinalResult, error) {
+	if b.parser == nil {
+		return adapt.ParseFinalResults(ctx, b.String())
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.parser.Result()
+}
+
+// WriteString appends s, dropping the oldest whole lines beyond the limit.
+func (b *OutputBuffer) WriteString(s string) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.buf = append(b.buf, s...)
+	// Trim only once twice the limit is reached, so appends stay cheap
+	if len(b.buf) > 2*b.limit {
+		tail := b.buf[len(b.buf)-b.limit:]
+		if i := bytes.IndexByte(tail, '\n'); i >= 0 {
+			tail = tail[i+1:]
+		}
+		b.buf = append(make([]byte, 0, 2*b.limit), tail...)
+		b.truncated = true
+	}
+	return len(s), nil
+}
+
+// String returns the kept output.
+func (b *OutputBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.buf)
+}
+
+// Truncated reports whether the beginning of the output was dropped.
+func (b *OutputBuffer) Truncated() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.truncated
+}
@@ -0,0 +1,32 @@
+package adapter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestOutputBuffer tests that only the last whole lines within the limit are kept.
+func TestOutputBuffer(t *testing.T) {
+	b := NewOutputBuffer(64)
+	for i := 1; i <= 100; i++ {
+		b.WriteString(fmt.Sprintf("line %d\n", i))
+	}
+
+	out := b.String()
+	if !b.Truncated() {
+		t.Error("Truncated() = false after exceeding the limit")
+	}
+	if len(out) > 2*64 || !strings.HasSuffix(out, "line 100\n") {
+		t.Errorf("kept output = %q, want the last lines within twice the limit", out)
+	}
+	if !strings.HasPrefix(out, "line ") {
+		t.Errorf("kept output starts with a partial line: %q", out)
+	}
+
+	small := NewOutputBuffer(0)
+	small.WriteString("transactions: 220 (110.00 per sec.)\n")
+	if small.Truncated() || small.String() != "transactions: 220 (110.00 per sec.)\n" {
+		t.Errorf("String() = %q, want the whole output", small.String())
+	}
+}
@@ -0,0 +1,239 @@
+// Package adapter provides the SQL mix adapter, which runs user-defined
+// statements without an external tool.
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/sqlmix"
+)
+
+// SQLMixWorkerCommand is the hidden command of the application binary that
+// runs a phase of a SQL mix from the spec file given as its argument.
+const SQLMixWorkerCommand = "sqlmix-worker"
+
+// sqlMixSpecFile is the credential file of the spec of a SQL mix phase.
+const sqlMixSpecFile = CredentialFilePrefix + "sqlmix.json"
+
+// SQLMixAdapter implements BenchmarkAdapter for the statements of the
+// sql_mix custom data of templates. The phases run in a child process of
+// the application binary, so that runs are stopped and re-attached like
+// those of the external tools, and report in the format of sysbench.
+type SQLMixAdapter struct {
+	// Executable is the application binary that runs the worker command
+	Executable string
+}
+
+// NewSQLMixAdapter creates a SQL mix adapter running the current binary.
+func NewSQLMixAdapter() *SQLMixAdapter {
+	exe, err := os.Executable()
+	if err != nil {
+		exe = "db-benchmind"
+	}
+	return &SQLMixAdapter{Executable: exe}
+}
+
+// Type returns the adapter type.
+func (a *SQLMixAdapter) Type() AdapterType {
+	return AdapterTypeSQLMix
+}
+
+// BuildPrepareCommand builds the command that runs the prepare statements.
+func (a *SQLMixAdapter) BuildPrepareCommand(ctx context.Context, config *Config) (*Command, error) {
+	return a.buildCommand(config, SQLMixPhasePrepare)
+}
+
+// BuildRunCommand builds the command that runs the weighted statements.
+func (a *SQLMixAdapter) BuildRunCommand(ctx context.Context, config *Config) (*Command, error) {
+	return a.buildCommand(config, SQLMixPhaseRun)
+}
+
+// BuildCleanupCommand builds the command that runs the cleanup statements.
+func (a *SQLMixAdapter) BuildCleanupCommand(ctx context.Context, config *Config) (*Command, error) {
+	return a.buildCommand(config, SQLMixPhaseCleanup)
+}
+
+// buildCommand builds the worker command of a phase. The spec, which holds
+// the password, is passed in a credential file.
+func (a *SQLMixAdapter) buildCommand(config *Config, phase string) (*Command, error) {
+	if config.Connection == nil || config.Template == nil {
+		return nil, fmt.Errorf("connection and template are required")
+	}
+	mix, err := sqlmix.FromTemplate(config.Template)
+	if err != nil {
+		return nil, err
+	}
+	connJSON, err := json.Marshal(config.Connection)
+	if err != nil {
+		return nil, fmt.Errorf("encode connection: %w", err)
+	}
+
+	spec := SQLMixSpec{
+		Phase:          phase,
+		DatabaseType:   config.Connection.GetType(),
+		Connection:     connJSON,
+		Password:       connectionPassword(config.Connection),
+		Database:       sqlMixDatabase(config),
+		Mix:            mix,
+		Threads:        sqlMixIntParam(config, "threads", 1),
+		Time:           sqlMixIntParam(config, "time", 0),
+		Rate:           sqlMixIntParam(config, "rate", 0),
+		ReportInterval: reportIntervalSeconds(config.Options.SampleInterval),
+
+		MaxOpenConns:    sqlMixIntParam(config, "max_open_conns", 0),
+		MaxIdleConns:    sqlMixIntParam(config, "max_idle_conns", 0),
+		ConnMaxLifetime: sqlMixIntParam(config, "conn_max_lifetime", 0),
+		Prepared:        sqlMixBoolParam(config, "prepared_statements"),
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("encode SQL mix spec: %w", err)
+	}
+
+	cmd := &Command{
+		CmdLine: strings.Join([]string{commandArg(a.Executable), SQLMixWorkerCommand, sqlMixSpecFile}, " "),
+		WorkDir: config.WorkDir,
+		Files:   map[string]string{sqlMixSpecFile: string(data)},
+		Secrets: connectionSecrets(config),
+	}
+
+	slog.Info("SQLMixAdapter: Built command", "phase", phase, "cmd", cmd.MaskedCmdLine())
+
+	return cmd, nil
+}
+
+// ParseRunOutput parses the output of a run, which has the sysbench format.
+func (a *SQLMixAdapter) ParseRunOutput(ctx context.Context, stdout string, stderr string) (*Result, error) {
+	return (&SysbenchAdapter{}).ParseRunOutput(ctx, stdout, stderr)
+}
+
+// StartRealtimeCollection parses the per-interval reports of a run, which
+// have the sysbench format.
+func (a *SQLMixAdapter) StartRealtimeCollection(ctx context.Context, stdout io.Reader) (<-chan Sample, <-chan error, *OutputBuffer) {
+	return (&SysbenchAdapter{}).StartRealtimeCollection(ctx, stdout)
+}
+
+// ParseFinalResults parses the summary of a run, which has the sysbench format.
+func (a *SQLMixAdapter) ParseFinalResults(ctx context.Context, stdout string) (*FinalResult, error) {
+	return (&SysbenchAdapter{}).ParseFinalResults(ctx, stdout)
+}
+
+// ValidateConfig validates the connection, the mix of the template and the
+// parameters of the run.
+func (a *SQLMixAdapter) ValidateConfig(ctx context.Context, config *Config) error {
+	if config.Connection == nil {
+		return fmt.Errorf("connection is required")
+	}
+	if !a.SupportsDatabase(config.Connection.GetType()) {
+		return fmt.Errorf("database type %s not supported by the SQL mix runner", config.Connection.GetType())
+	}
+	if config.Template == nil {
+		return fmt.Errorf("template is required")
+	}
+	if _, err := sqlmix.FromTemplate(config.Template); err != nil {
+		return err
+	}
+	if config.Options.Remote() {
+		return fmt.Errorf("the SQL mix runner cannot run on a remote host or agent")
+	}
+
+	if threads := sqlMixIntParam(config, "threads", 1); threads < 1 || threads > 1024 {
+		return fmt.Errorf("threads must be between 1 and 1024, got %d", threads)
+	}
+	if runTime := sqlMixIntParam(config, "time", 0); runTime < 0 || runTime > 86400 {
+		return fmt.Errorf("time must be between 0 and 86400 seconds, got %d", runTime)
+	}
+	if rate := sqlMixIntParam(config, "rate", 0); rate < 0 {
+		return fmt.Errorf("rate must not be negative, got %d", rate)
+	}
+	if maxOpen := sqlMixIntParam(config, "max_open_conns", 0); maxOpen < 0 || maxOpen > 10000 {
+		return fmt.Errorf("max_open_conns must be between 0 and 10000, got %d", maxOpen)
+	}
+	if maxIdle := sqlMixIntParam(config, "max_idle_conns", 0); maxIdle < -1 || maxIdle > 10000 {
+		return fmt.Errorf("max_idle_conns must be between -1 and 10000, got %d", maxIdle)
+	}
+	if lifetime := sqlMixIntParam(config, "conn_max_lifetime", 0); lifetime < 0 {
+		return fmt.Errorf("conn_max_lifetime must not be negative, got %d", lifetime)
+	}
+	return nil
+}
+
+// SupportsDatabase checks if this adapter supports the given database type.
+func (a *SQLMixAdapter) SupportsDatabase(dbType connection.DatabaseType) bool {
+	switch dbType {
+	case connection.DatabaseTypeMySQL, connection.DatabaseTypePostgreSQL,
+		connection.DatabaseTypeOracle, connection.DatabaseTypeSQLServer:
+		return true
+	default:
+		return false
+	}
+}
+
+// connectionPassword returns the password of a connection.
+func connectionPassword(conn connection.Connection) string {
+	switch c := conn.(type) {
+	case *connection.MySQLConnection:
+		return c.Password
+	case *connection.PostgreSQLConnection:
+		return c.Password
+	case *connection.OracleConnection:
+		return c.Password
+	case *connection.SQLServerConnection:
+		return c.Password
+	default:
+		return ""
+	}
+}
+
+// sqlMixDatabase returns the database the statements run in: that of the
+// connection, or the db_name parameter. Oracle connections use the schema
+// of their user.
+func sqlMixDatabase(config *Config) string {
+	switch c := config.Connection.(type) {
+	case *connection.MySQLConnection:
+		if c.Database != "" {
+			return c.Database
+		}
+	case *connection.PostgreSQLConnection:
+		if c.Database != "" {
+			return c.Database
+		}
+	case *connection.SQLServerConnection:
+		if c.Database != "" {
+			return c.Database
+		}
+	default:
+		return ""
+	}
+	db, _ := config.Parameters["db_name"].(string)
+	return db
+}
+
+// sqlMixIntParam returns an integer parameter of the task, or else the
+// default of the template, or else defaultValue.
+func sqlMixIntParam(config *Config, name string, defaultValue int) int {
+	if value, ok := tpccIntParam(config, name); ok {
+		return value
+	}
+	return defaultValue
+}
+
+// sqlMixBoolParam returns a boolean parameter of the task, or else the
+// default of the template, or else false.
+func sqlMixBoolParam(config *Config, name string) bool {
+	if value, ok := config.Parameters[name].(bool); ok {
+		return value
+	}
+	if config.Template == nil {
+		return false
+	}
+	value, _ := config.Template.Parameters[name].Default.(bool)
+	return value
+}
@@ -0,0 +1,310 @@
+// Package adapter provides unit tests for the SQL mix adapter.
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/sqlmix"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/template"
+)
+
+// testSQLMix is a key-value mix for the tests.
+func testSQLMix() *sqlmix.Mix {
+	return &sqlmix.Mix{
+		Prepare: []sqlmix.Statement{
+			{SQL: "CREATE TABLE kv (id INTEGER PRIMARY KEY, v TEXT)"},
+			{Name: "load", SQL: "INSERT INTO kv (id, v) VALUES ({id}, {v})", Repeat: 100, Params: map[string]sqlmix.Generator{
+				"id": {Type: sqlmix.GeneratorSequence},
+				"v":  {Type: sqlmix.GeneratorString, Length: 16},
+			}},
+		},
+		Statements: []sqlmix.Statement{
+			{Name: "get", Weight: 4, SQL: "SELECT v FROM kv WHERE id = {id}", Params: map[string]sqlmix.Generator{
+				"id": {Type: sqlmix.GeneratorInt, Min: 1, Max: 100},
+			}},
+			{Name: "put", SQL: "UPDATE kv SET v = {v} WHERE id = {id}", Params: map[string]sqlmix.Generator{
+				"id": {Type: sqlmix.GeneratorInt, Min: 1, Max: 100},
+				"v":  {Type: sqlmix.GeneratorString, Length: 16},
+			}},
+		},
+		Cleanup: []sqlmix.Statement{{SQL: "DROP TABLE kv"}},
+	}
+}
+
+// testSQLMixTemplate returns a template of the mix, with its custom data as read from JSON.
+func testSQLMixTemplate(t *testing.T, mix *sqlmix.Mix) *template.Template {
+	data, err := json.Marshal(mix)
+	require.NoError(t, err)
+	var custom interface{}
+	require.NoError(t, json.Unmarshal(data, &custom))
+	return &template.Template{
+		ID:         "sqlmix-test",
+		Tool:       "sqlmix",
+		CustomData: map[string]interface{}{sqlmix.CustomDataKey: custom},
+	}
+}
+
+// TestSQLMixAdapter_BuildRunCommand tests that the spec and the password are
+// passed in a credential file.
+func TestSQLMixAdapter_BuildRunCommand(t *testing.T) {
+	a := &SQLMixAdapter{Executable: "/opt/db bench/db-benchmind"}
+	conn := &connection.PostgreSQLConnection{Host: "db", Port: 5432, Username: "bench", Password: "s3cret"}
+	config := &Config{
+		Connection: conn,
+		Template:   testSQLMixTemplate(t, testSQLMix()),
+		Parameters: map[string]interface{}{
+			"threads": 8, "time": 60, "rate": 500, "db_name": "mixdb",
+			"max_open_conns": 4, "max_idle_conns": -1, "conn_max_lifetime": 30, "prepared_statements": true,
+		},
+		Options: execution.TaskOptions{SampleInterval: 5 * time.Second},
+		WorkDir: t.TempDir(),
+	}
+
+	cmd, err := a.BuildRunCommand(context.Background(), config)
+	require.NoError(t, err)
+	assert.Equal(t, `/opt/db\ bench/db-benchmind sqlmix-worker .credentials-sqlmix.json`, cmd.CmdLine)
+	assert.NotContains(t, cmd.CmdLine, "s3cret")
+	assert.Equal(t, []string{"s3cret"}, cmd.Secrets)
+	require.Contains(t, cmd.Files, sqlMixSpecFile)
+
+	var spec SQLMixSpec
+	require.NoError(t, json.Unmarshal([]byte(cmd.Files[sqlMixSpecFile]), &spec))
+	assert.Equal(t, SQLMixPhaseRun, spec.Phase)
+	assert.Equal(t, connection.DatabaseTypePostgreSQL, spec.DatabaseType)
+	assert.Equal(t, "s3cret", spec.Password)
+	assert.Equal(t, "mixdb", spec.Database)
+	assert.Equal(t, 8, spec.Threads)
+	assert.Equal(t, 60, spec.Time)
+	assert.Equal(t, 500, spec.Rate)
+	assert.Equal(t, 5, spec.ReportInterval)
+	assert.Equal(t, 4, spec.MaxOpenConns)
+	assert.Equal(t, -1, spec.MaxIdleConns)
+	assert.Equal(t, 30, spec.ConnMaxLifetime)
+	assert.True(t, spec.Prepared)
+	assert.Len(t, spec.Mix.Statements, 2)
+
+	driver, dsn, err := spec.dsn()
+	require.NoError(t, err)
+	assert.Equal(t, "postgres", driver)
+	assert.Contains(t, dsn, "host=db port=5432 dbname=mixdb user=bench password=s3cret")
+}
+
+// TestSQLMixAdapter_ValidateConfig tests the checks of the mix and the parameters.
+func TestSQLMixAdapter_ValidateConfig(t *testing.T) {
+	a := &SQLMixAdapter{}
+	valid := testSQLMixTemplate(t, testSQLMix())
+	mysql := &connection.MySQLConnection{Host: "db", Port: 3306}
+
+	tests := []struct {
+		name    string
+		config  *Config
+		wantErr string
+	}{
+		{"valid", &Config{Connection: mysql, Template: valid, Parameters: map[string]interface{}{"threads": 4, "time": 60}}, ""},
+		{"no connection", &Config{Template: valid}, "connection is required"},
+		{"unsupported database", &Config{Connection: &unsupportedConnection{}, Template: valid}, "not supported"},
+		{"no mix", &Config{Connection: mysql, Template: &template.Template{}}, "no SQL mix"},
+		{"invalid mix", &Config{Connection: mysql, Template: testSQLMixTemplate(t, &sqlmix.Mix{})}, "no statements"},
+		{"remote", &Config{Connection: mysql, Template: valid, Options: execution.TaskOptions{Agent: "a1"}}, "remote host or agent"},
+		{"too many threads", &Config{Connection: mysql, Template: valid, Parameters: map[string]interface{}{"threads": 2000}}, "threads must be between"},
+		{"negative rate", &Config{Connection: mysql, Template: valid, Parameters: map[string]interface{}{"rate": -1}}, "rate must not be negative"},
+		{"no idle connections", &Config{Connection: mysql, Template: valid, Parameters: map[string]interface{}{"max_idle_conns": -1}}, ""},
+		{"negative max idle", &Config{Connection: mysql, Template: valid, Parameters: map[string]interface{}{"max_idle_conns": -2}}, "max_idle_conns must be between"},
+		{"negative lifetime", &Config{Connection: mysql, Template: valid, Parameters: map[string]interface{}{"conn_max_lifetime": -1}}, "conn_max_lifetime must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := a.ValidateConfig(context.Background(), tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// unsupportedConnection is a connection of a type the SQL mix runner does not support.
+type unsupportedConnection struct {
+	connection.MySQLConnection
+}
+
+func (c *unsupportedConnection) GetType() connection.DatabaseType { return "db2" }
+
+// TestRunSQLMix tests the phases of a mix on SQLite and that the output is
+// read by the sysbench parsers.
+func TestRunSQLMix(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", "file:"+t.TempDir()+"/mix.db")
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1) // SQLite allows one writer
+	placeholder := sqlMixPlaceholder(connection.DatabaseTypeMySQL)
+
+	spec := &SQLMixSpec{Mix: testSQLMix(), Threads: 4, Time: 2, ReportInterval: 1}
+
+	var out bytes.Buffer
+	spec.Phase = SQLMixPhasePrepare
+	require.NoError(t, runSQLMix(ctx, db, placeholder, spec, &out))
+	assert.Contains(t, out.String(), "Executing statement load (100 times)")
+	var rows int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM kv").Scan(&rows))
+	assert.Equal(t, 100, rows)
+
+	out.Reset()
+	spec.Phase = SQLMixPhaseRun
+	require.NoError(t, runSQLMix(ctx, db, placeholder, spec, &out))
+	output := out.String()
+	assert.Contains(t, output, "Statements:")
+
+	a := &SQLMixAdapter{}
+	samples, _, buf := a.StartRealtimeCollection(ctx, strings.NewReader(output))
+	var n int
+	for sample := range samples {
+		assert.Equal(t, 4, sample.ThreadCount)
+		n++
+	}
+	assert.GreaterOrEqual(t, n, 1, "per-second reports")
+
+	result, err := buf.FinalResults(ctx, a)
+	require.NoError(t, err)
+	assert.Greater(t, result.TotalTransactions, int64(0))
+	assert.Equal(t, result.TotalTransactions, result.ReadQueries+result.WriteQueries)
+	assert.Greater(t, result.ReadQueries, result.WriteQueries, "get has 4 times the weight of put")
+	assert.InDelta(t, 2.0, result.TotalTime, 0.5)
+	assert.Greater(t, result.LatencyP95, 0.0)
+	assert.LessOrEqual(t, result.LatencyMin, result.LatencyP95)
+	assert.LessOrEqual(t, result.LatencyP95, result.LatencyMax)
+	assert.Greater(t, result.EventsAvg, 0.0)
+
+	out.Reset()
+	spec.Phase = SQLMixPhaseCleanup
+	require.NoError(t, runSQLMix(ctx, db, placeholder, spec, &out))
+	assert.Error(t, db.QueryRow("SELECT COUNT(*) FROM kv").Scan(&rows), "table dropped")
+}
+
+// TestRunSQLMix_Pool tests a run with prepared statements on fewer
+// connections than threads.
+func TestRunSQLMix_Pool(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", "file:"+t.TempDir()+"/mix.db")
+	require.NoError(t, err)
+	defer db.Close()
+	placeholder := sqlMixPlaceholder(connection.DatabaseTypeMySQL)
+
+	spec := &SQLMixSpec{Mix: testSQLMix(), Threads: 4, Time: 1, ReportInterval: 1,
+		MaxOpenConns: 1, MaxIdleConns: -1, ConnMaxLifetime: 60, Prepared: true}
+	spec.configurePool(db)
+	assert.Equal(t, 1, db.Stats().MaxOpenConnections)
+
+	var out bytes.Buffer
+	spec.Phase = SQLMixPhasePrepare
+	require.NoError(t, runSQLMix(ctx, db, placeholder, spec, &out))
+
+	out.Reset()
+	spec.Phase = SQLMixPhaseRun
+	require.NoError(t, runSQLMix(ctx, db, placeholder, spec, &out))
+	output := out.String()
+	assert.Contains(t, output, "Using prepared statements")
+	assert.Contains(t, output, "Connection pool:")
+	assert.Regexp(t, `max open connections:\s+1\n`, output)
+	assert.Regexp(t, `connection lifetime:\s+60s\n`, output)
+	assert.Regexp(t, `prepared statements:\s+on\n`, output)
+
+	result, err := (&SQLMixAdapter{}).ParseFinalResults(ctx, output)
+	require.NoError(t, err)
+	assert.Greater(t, result.TotalTransactions, int64(0))
+
+	// Statements of tables that do not exist fail to prepare
+	spec.Mix = &sqlmix.Mix{Statements: []sqlmix.Statement{{Name: "missing", SQL: "SELECT * FROM no_such_table"}}}
+	err = runSQLMix(ctx, db, placeholder, spec, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "prepare statement missing")
+}
+
+// TestSQLMixParams tests that unset parameters take the defaults of the template.
+func TestSQLMixParams(t *testing.T) {
+	tmpl := &template.Template{Parameters: map[string]template.Parameter{
+		"max_open_conns":      {Type: template.ParameterTypeInteger, Default: 16},
+		"prepared_statements": {Type: template.ParameterTypeBoolean, Default: true},
+	}}
+	config := &Config{Template: tmpl, Parameters: map[string]interface{}{}}
+	assert.Equal(t, 16, sqlMixIntParam(config, "max_open_conns", 0))
+	assert.Equal(t, 0, sqlMixIntParam(config, "conn_max_lifetime", 0))
+	assert.True(t, sqlMixBoolParam(config, "prepared_statements"))
+
+	config.Parameters["prepared_statements"] = false
+	assert.False(t, sqlMixBoolParam(config, "prepared_statements"))
+	assert.False(t, sqlMixBoolParam(&Config{}, "prepared_statements"))
+}
+
+// TestRunSQLMix_Errors tests that a failed statement ends the run, unless the
+// mix ignores errors.
+func TestRunSQLMix_Errors(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", "file:"+t.TempDir()+"/mix.db")
+	require.NoError(t, err)
+	defer db.Close()
+	placeholder := sqlMixPlaceholder(connection.DatabaseTypeMySQL)
+
+	mix := &sqlmix.Mix{Statements: []sqlmix.Statement{{Name: "missing", SQL: "SELECT * FROM no_such_table"}}}
+	spec := &SQLMixSpec{Phase: SQLMixPhaseRun, Mix: mix, Threads: 2, Time: 1, ReportInterval: 1}
+
+	var out bytes.Buffer
+	err = runSQLMix(ctx, db, placeholder, spec, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "statement missing")
+	assert.Contains(t, out.String(), "FATAL:")
+	assert.NotContains(t, out.String(), "SQL statistics:")
+
+	out.Reset()
+	mix.IgnoreErrors = true
+	require.NoError(t, runSQLMix(ctx, db, placeholder, spec, &out))
+	result, err := (&SQLMixAdapter{}).ParseFinalResults(ctx, out.String())
+	require.NoError(t, err)
+	assert.Zero(t, result.TotalTransactions)
+	assert.Greater(t, result.IgnoredErrors, int64(0))
+}
+
+// TestSQLMixPlaceholder tests the bind parameters of the drivers.
+func TestSQLMixPlaceholder(t *testing.T) {
+	tests := map[connection.DatabaseType]string{
+		connection.DatabaseTypeMySQL:      "?",
+		connection.DatabaseTypePostgreSQL: "$2",
+		connection.DatabaseTypeSQLServer:  "@p2",
+		connection.DatabaseTypeOracle:     ":2",
+	}
+	for dbType, want := range tests {
+		assert.Equal(t, want, sqlMixPlaceholder(dbType)(2), dbType)
+	}
+}
+
+// TestLatencyHistogram tests the percentiles of the histogram.
+func TestLatencyHistogram(t *testing.T) {
+	var h latencyHistogram
+	assert.Zero(t, h.percentile(95))
+	for i := 1; i <= 100; i++ {
+		h.record(time.Duration(i) * time.Millisecond)
+	}
+	assert.InDelta(t, 1.0, h.min(), 1e-9)
+	assert.InDelta(t, 100.0, h.max(), 1e-9)
+	assert.InDelta(t, 50.5, h.avg(), 1e-9)
+	// Bucket bounds are within 2% of the latencies
+	assert.InDelta(t, 95.0, h.percentile(95), 95*0.02, fmt.Sprint(h.percentile(95)))
+	assert.InDelta(t, 99.0, h.percentile(99), 99*0.02)
+	assert.Equal(t, 100.0, h.percentile(100))
+}
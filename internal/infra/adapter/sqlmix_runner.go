@@ -0,0 +1,655 @@
+package adapter
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/sqlmix"
+)
+
+// Phases of the SQL mix worker.
+const (
+	SQLMixPhasePrepare = "prepare"
+	SQLMixPhaseRun     = "run"
+	SQLMixPhaseCleanup = "cleanup"
+)
+
+// SQLMixSpec is what the SQL mix worker runs: a phase of a mix on a connection.
+type SQLMixSpec struct {
+	Phase          string                  `json:"phase"`
+	DatabaseType   connection.DatabaseType `json:"database_type"`
+	Connection     json.RawMessage         `json:"connection"` // Without the password
+	Password       string                  `json:"password,omitempty"`
+	Database       string                  `json:"database,omitempty"` // Overrides that of the connection
+	Mix            *sqlmix.Mix             `json:"mix"`
+	Threads        int                     `json:"threads"`
+	Time           int                     `json:"time"`           // Run phase: seconds, 0 until stopped
+	Rate           int                     `json:"rate,omitempty"` // Run phase: statements per second, 0 unlimited
+	ReportInterval int                     `json:"report_interval"`
+
+	// Connection pool, see configurePool
+	MaxOpenConns    int  `json:"max_open_conns,omitempty"`    // 0: one per thread
+	MaxIdleConns    int  `json:"max_idle_conns,omitempty"`    // 0: as many as open, negative: none
+	ConnMaxLifetime int  `json:"conn_max_lifetime,omitempty"` // Seconds, 0 unlimited
+	Prepared        bool `json:"prepared,omitempty"`          // Run phase: prepare each statement once
+}
+
+// ReadSQLMixSpec reads the spec file of the SQL mix worker.
+func ReadSQLMixSpec(path string) (*SQLMixSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read SQL mix spec: %w", err)
+	}
+	var spec SQLMixSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parse SQL mix spec: %w", err)
+	}
+	if spec.Mix == nil {
+		return nil, sqlmix.ErrNoMix
+	}
+	return &spec, nil
+}
+
+// RunSQLMix connects to the database of spec and runs its phase, writing
+// progress, per-interval reports and the summary of a run to w in the
+// format of sysbench. A run stops when its time is up or ctx is done.
+func RunSQLMix(ctx context.Context, spec *SQLMixSpec, w io.Writer) error {
+	driver, dsn, err := spec.dsn()
+	if err != nil {
+		return err
+	}
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+
+	spec.configurePool(db)
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	return runSQLMix(ctx, db, sqlMixPlaceholder(spec.DatabaseType), spec, w)
+}
+
+// configurePool sets the connection pool of db: by default one connection
+// per thread, all kept idle and reused for the whole run.
+func (s *SQLMixSpec) configurePool(db *sql.DB) {
+	maxOpen := s.MaxOpenConns
+	if maxOpen <= 0 {
+		maxOpen = max(s.Threads, 1)
+	}
+	maxIdle := s.MaxIdleConns
+	switch {
+	case maxIdle == 0:
+		maxIdle = maxOpen
+	case maxIdle < 0:
+		maxIdle = 0 // Close every connection after use
+	}
+	db.SetMaxOpenConns(maxOpen)
+	db.SetMaxIdleConns(maxIdle)
+	db.SetConnMaxLifetime(time.Duration(s.ConnMaxLifetime) * time.Second)
+}
+
+// dsn returns the driver and DSN of the connection of the spec.
+func (s *SQLMixSpec) dsn() (string, string, error) {
+	switch s.DatabaseType {
+	case connection.DatabaseTypeMySQL:
+		var c connection.MySQLConnection
+		if err := json.Unmarshal(s.Connection, &c); err != nil {
+			return "", "", fmt.Errorf("parse connection: %w", err)
+		}
+		c.SetPassword(s.Password)
+		c.Database = s.Database
+		tlsParams, err := c.DSNTLSParams()
+		if err != nil {
+			return "", "", err
+		}
+		return "mysql", c.GetDSNWithPassword() + tlsParams, nil
+	case connection.DatabaseTypePostgreSQL:
+		var c connection.PostgreSQLConnection
+		if err := json.Unmarshal(s.Connection, &c); err != nil {
+			return "", "", fmt.Errorf("parse connection: %w", err)
+		}
+		c.SetPassword(s.Password)
+		if s.Database != "" {
+			c.Database = s.Database
+		}
+		return "postgres", c.GetDSNWithPassword(), nil
+	case connection.DatabaseTypeSQLServer:
+		var c connection.SQLServerConnection
+		if err := json.Unmarshal(s.Connection, &c); err != nil {
+			return "", "", fmt.Errorf("parse connection: %w", err)
+		}
+		c.SetPassword(s.Password)
+		if s.Database != "" {
+			c.Database = s.Database
+		}
+		return "sqlserver", c.GetDSNWithPassword(), nil
+	case connection.DatabaseTypeOracle:
+		var c connection.OracleConnection
+		if err := json.Unmarshal(s.Connection, &c); err != nil {
+			return "", "", fmt.Errorf("parse connection: %w", err)
+		}
+		c.SetPassword(s.Password)
+		return "oracle", c.GetDSNWithPassword(), nil
+	default:
+		return "", "", fmt.Errorf("database type %s not supported by the SQL mix runner", s.DatabaseType)
+	}
+}
+
+// sqlMixPlaceholder returns the bind parameter n (from 1) of the driver of dbType.
+func sqlMixPlaceholder(dbType connection.DatabaseType) func(n int) string {
+	switch dbType {
+	case connection.DatabaseTypePostgreSQL:
+		return func(n int) string { return fmt.Sprintf("$%d", n) }
+	case connection.DatabaseTypeSQLServer:
+		return func(n int) string { return fmt.Sprintf("@p%d", n) }
+	case connection.DatabaseTypeOracle:
+		return func(n int) string { return fmt.Sprintf(":%d", n) }
+	default:
+		return func(int) string { return "?" }
+	}
+}
+
+// runSQLMix runs the phase of spec on db.
+func runSQLMix(ctx context.Context, db *sql.DB, placeholder func(n int) string, spec *SQLMixSpec, w io.Writer) error {
+	switch spec.Phase {
+	case SQLMixPhasePrepare:
+		return runSQLMixStatements(ctx, db, placeholder, spec.Mix.Prepare, max(spec.Threads, 1), w)
+	case SQLMixPhaseCleanup:
+		return runSQLMixStatements(ctx, db, placeholder, spec.Mix.Cleanup, max(spec.Threads, 1), w)
+	case SQLMixPhaseRun:
+		return newSQLMixRun(db, placeholder, spec).run(ctx, w)
+	default:
+		return fmt.Errorf("unknown SQL mix phase %q", spec.Phase)
+	}
+}
+
+// newRand returns a random source for one goroutine.
+func newRand() *rand.Rand {
+	return rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+}
+
+// runSQLMixStatements runs prepare or cleanup statements in order. The
+// executions of a repeated statement are spread over the threads.
+func runSQLMixStatements(ctx context.Context, db *sql.DB, placeholder func(n int) string, statements []sqlmix.Statement, threads int, w io.Writer) error {
+	if len(statements) == 0 {
+		fmt.Fprintln(w, "No statements to execute")
+		return nil
+	}
+	for i, s := range statements {
+		b := s.Bind(i, placeholder)
+		n := int64(s.Executions())
+		fmt.Fprintf(w, "Executing statement %s (%d times)...\n", b.Label, n)
+		start := time.Now()
+
+		stmtCtx, cancel := context.WithCancel(ctx)
+		var (
+			next     atomic.Int64
+			wg       sync.WaitGroup
+			errOnce  sync.Once
+			firstErr error
+		)
+		for range min(int64(threads), n) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r := newRand()
+				for next.Add(1) <= n && stmtCtx.Err() == nil {
+					if _, err := db.ExecContext(stmtCtx, b.Query, b.Args(r)...); err != nil {
+						errOnce.Do(func() { firstErr = err; cancel() })
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		cancel()
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if firstErr != nil {
+			fmt.Fprintf(w, "FATAL: statement '%s' failed: %v\n", b.Label, firstErr)
+			return fmt.Errorf("statement %s: %w", b.Label, firstErr)
+		}
+		fmt.Fprintf(w, "Executed statement %s in %s\n", b.Label, time.Since(start).Round(time.Millisecond))
+	}
+	return nil
+}
+
+// sqlMixStatementStats counts the executions of a statement of a run.
+type sqlMixStatementStats struct {
+	errors    atomic.Int64
+	latencies latencyHistogram
+}
+
+// sqlMixRun is the run phase of a mix: threads that execute statements
+// picked by weight, counted by kind and timed.
+type sqlMixRun struct {
+	db      *sql.DB
+	spec    *SQLMixSpec
+	bound   []*sqlmix.Bound
+	kinds   []sqlmix.Kind
+	stmts   []*sql.Stmt // Prepared statements, if the spec prepares them
+	picker  *sqlmix.Picker
+	threads int
+
+	reads, writes, others atomic.Int64
+	errors                atomic.Int64
+	latencies             latencyHistogram
+	interval              atomic.Pointer[latencyHistogram] // Latencies since the last report
+	statements            []sqlMixStatementStats
+	threadEvents          []int64 // Written by each thread, read once they are done
+
+	errOnce  sync.Once
+	firstErr error
+}
+
+// newSQLMixRun prepares the run phase of spec on db.
+func newSQLMixRun(db *sql.DB, placeholder func(n int) string, spec *SQLMixSpec) *sqlMixRun {
+	r := &sqlMixRun{
+		db:         db,
+		spec:       spec,
+		picker:     sqlmix.NewPicker(spec.Mix.Statements),
+		threads:    max(spec.Threads, 1),
+		statements: make([]sqlMixStatementStats, len(spec.Mix.Statements)),
+	}
+	for i, s := range spec.Mix.Statements {
+		r.bound = append(r.bound, s.Bind(i, placeholder))
+		r.kinds = append(r.kinds, s.Kind())
+	}
+	r.threadEvents = make([]int64, r.threads)
+	r.interval.Store(new(latencyHistogram))
+	return r
+}
+
+// run runs the threads until the time is up or ctx is done, reporting every
+// interval, then writes the summary. A failed statement ends the run with an
+// error, unless the mix ignores errors.
+func (r *sqlMixRun) run(ctx context.Context, w io.Writer) error {
+	if r.spec.Time > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(r.spec.Time)*time.Second)
+		defer cancel()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if r.spec.Prepared {
+		if err := r.prepare(ctx); err != nil {
+			return err
+		}
+		defer r.closeStmts()
+	}
+
+	fmt.Fprintf(w, "Running the SQL mix with %d threads (%d statements)\n", r.threads, len(r.bound))
+	if r.spec.Rate > 0 {
+		fmt.Fprintf(w, "Target rate: %d per second\n", r.spec.Rate)
+	}
+	if r.spec.Prepared {
+		fmt.Fprintln(w, "Using prepared statements")
+	}
+	fmt.Fprintln(w, "\nThreads started!")
+	fmt.Fprintln(w)
+
+	start := time.Now()
+	var slots atomic.Int64
+	var wg sync.WaitGroup
+	threadTimes := make([]time.Duration, r.threads)
+	for t := range r.threads {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			threadStart := time.Now()
+			r.worker(ctx, cancel, t, start, &slots)
+			threadTimes[t] = time.Since(threadStart)
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	r.report(w, start, done)
+	elapsed := time.Since(start)
+
+	if r.firstErr != nil {
+		return r.firstErr
+	}
+	r.writeSummary(w, elapsed, threadTimes)
+	return nil
+}
+
+// prepare prepares the statements of the run. database/sql prepares them
+// again on each connection of the pool the first time they run there.
+func (r *sqlMixRun) prepare(ctx context.Context) error {
+	r.stmts = make([]*sql.Stmt, len(r.bound))
+	for i, b := range r.bound {
+		stmt, err := r.db.PrepareContext(ctx, b.Query)
+		if err != nil {
+			r.closeStmts()
+			return fmt.Errorf("prepare statement %s: %w", b.Label, err)
+		}
+		r.stmts[i] = stmt
+	}
+	return nil
+}
+
+// closeStmts closes the prepared statements.
+func (r *sqlMixRun) closeStmts() {
+	for _, stmt := range r.stmts {
+		if stmt != nil {
+			stmt.Close()
+		}
+	}
+}
+
+// worker executes statements until ctx is done. With a rate, the threads
+// take turns at evenly spaced times from start.
+func (r *sqlMixRun) worker(ctx context.Context, cancel context.CancelFunc, thread int, start time.Time, slots *atomic.Int64) {
+	rnd := newRand()
+	var spacing time.Duration
+	if r.spec.Rate > 0 {
+		spacing = time.Second / time.Duration(r.spec.Rate)
+	}
+	for ctx.Err() == nil {
+		if spacing > 0 {
+			at := start.Add(time.Duration(slots.Add(1)-1) * spacing)
+			if d := time.Until(at); d > 0 {
+				select {
+				case <-time.After(d):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+
+		i := r.picker.Pick(rnd)
+		b := r.bound[i]
+		began := time.Now()
+		err := r.execute(ctx, i, b.Args(rnd))
+		latency := time.Since(began)
+		if err != nil {
+			if ctx.Err() != nil {
+				return // Interrupted at the end of the run
+			}
+			r.statements[i].errors.Add(1)
+			r.errors.Add(1)
+			if !r.spec.Mix.IgnoreErrors {
+				r.errOnce.Do(func() {
+					r.firstErr = fmt.Errorf("statement %s: %w", b.Label, err)
+					cancel()
+				})
+				return
+			}
+			continue
+		}
+
+		switch r.kinds[i] {
+		case sqlmix.KindRead:
+			r.reads.Add(1)
+		case sqlmix.KindWrite:
+			r.writes.Add(1)
+		default:
+			r.others.Add(1)
+		}
+		r.latencies.record(latency)
+		r.interval.Load().record(latency)
+		r.statements[i].latencies.record(latency)
+		r.threadEvents[thread]++
+	}
+}
+
+// execute executes statement i, reading all rows of queries.
+func (r *sqlMixRun) execute(ctx context.Context, i int, args []any) error {
+	var stmt *sql.Stmt
+	if r.stmts != nil {
+		stmt = r.stmts[i]
+	}
+	query := r.bound[i].Query
+	if r.kinds[i] != sqlmix.KindRead {
+		var err error
+		if stmt != nil {
+			_, err = stmt.ExecContext(ctx, args...)
+		} else {
+			_, err = r.db.ExecContext(ctx, query, args...)
+		}
+		return err
+	}
+	var rows *sql.Rows
+	var err error
+	if stmt != nil {
+		rows, err = stmt.QueryContext(ctx, args...)
+	} else {
+		rows, err = r.db.QueryContext(ctx, query, args...)
+	}
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+// events returns the statements executed without errors.
+func (r *sqlMixRun) events() int64 {
+	return r.reads.Load() + r.writes.Load() + r.others.Load()
+}
+
+// report writes a sysbench report line every interval until done is closed.
+func (r *sqlMixRun) report(w io.Writer, start time.Time, done <-chan struct{}) {
+	interval := time.Duration(max(r.spec.ReportInterval, 1)) * time.Second
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := time.Now()
+	var lastReads, lastWrites, lastOthers, lastErrors int64
+	for {
+		select {
+		case <-done:
+			if r.firstErr != nil {
+				fmt.Fprintf(w, "FATAL: %v\n", r.firstErr)
+			}
+			return
+		case now := <-ticker.C:
+			hist := r.interval.Swap(new(latencyHistogram))
+			secs := now.Sub(last).Seconds()
+			last = now
+			reads, writes, others, errs := r.reads.Load(), r.writes.Load(), r.others.Load(), r.errors.Load()
+			rps, wps, ops := float64(reads-lastReads)/secs, float64(writes-lastWrites)/secs, float64(others-lastOthers)/secs
+			eps := float64(errs-lastErrors) / secs
+			lastReads, lastWrites, lastOthers, lastErrors = reads, writes, others, errs
+
+			fmt.Fprintf(w, "[ %ds ] thds: %d tps: %.2f qps: %.2f (r/w/o: %.2f/%.2f/%.2f) lat (ms,95%%): %.2f err/s: %.2f reconn/s: 0.00\n",
+				int(now.Sub(start).Round(time.Second)/time.Second), r.threads,
+				rps+wps+ops, rps+wps+ops, rps, wps, ops, hist.percentile(95), eps)
+		}
+	}
+}
+
+// writeSummary writes the summary of the run in the format of sysbench,
+// followed by the statistics of each statement.
+func (r *sqlMixRun) writeSummary(w io.Writer, elapsed time.Duration, threadTimes []time.Duration) {
+	secs := elapsed.Seconds()
+	events := r.events()
+	perSec := func(n int64) float64 { return float64(n) / secs }
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "SQL statistics:")
+	fmt.Fprintln(w, "    queries performed:")
+	fmt.Fprintf(w, "        read:                            %d\n", r.reads.Load())
+	fmt.Fprintf(w, "        write:                           %d\n", r.writes.Load())
+	fmt.Fprintf(w, "        other:                           %d\n", r.others.Load())
+	fmt.Fprintf(w, "        total:                           %d\n", events)
+	fmt.Fprintf(w, "    transactions:                        %d  (%.2f per sec.)\n", events, perSec(events))
+	fmt.Fprintf(w, "    queries:                             %d  (%.2f per sec.)\n", events, perSec(events))
+	fmt.Fprintf(w, "    ignored errors:                      %d  (%.2f per sec.)\n", r.errors.Load(), perSec(r.errors.Load()))
+	fmt.Fprintf(w, "    reconnects:                          0  (0.00 per sec.)\n")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "General statistics:")
+	fmt.Fprintf(w, "    total time:                          %.4fs\n", secs)
+	fmt.Fprintf(w, "    total number of events:              %d\n", events)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Latency (ms):")
+	fmt.Fprintf(w, "         min:                                    %.2f\n", r.latencies.min())
+	fmt.Fprintf(w, "         avg:                                    %.2f\n", r.latencies.avg())
+	fmt.Fprintf(w, "         max:                                    %.2f\n", r.latencies.max())
+	fmt.Fprintf(w, "         95th percentile:                        %.2f\n", r.latencies.percentile(95))
+	fmt.Fprintf(w, "         99th percentile:                        %.2f\n", r.latencies.percentile(99))
+	fmt.Fprintf(w, "         sum:                                    %.2f\n", r.latencies.sum())
+	fmt.Fprintln(w)
+
+	eventCounts := make([]float64, len(r.threadEvents))
+	for i, n := range r.threadEvents {
+		eventCounts[i] = float64(n)
+	}
+	times := make([]float64, len(threadTimes))
+	for i, d := range threadTimes {
+		times[i] = d.Seconds()
+	}
+	eventsAvg, eventsStddev := meanStddev(eventCounts)
+	timeAvg, timeStddev := meanStddev(times)
+	fmt.Fprintln(w, "Threads fairness:")
+	fmt.Fprintf(w, "    events (avg/stddev):           %.4f/%.2f\n", eventsAvg, eventsStddev)
+	fmt.Fprintf(w, "    execution time (avg/stddev):   %.4f/%.2f\n", timeAvg, timeStddev)
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "Statements:")
+	fmt.Fprintf(w, "    %-24s %10s %8s %10s %10s\n", "name", "count", "errors", "avg (ms)", "95% (ms)")
+	for i, b := range r.bound {
+		stats := &r.statements[i]
+		fmt.Fprintf(w, "    %-24s %10d %8d %10.2f %10.2f\n",
+			b.Label, stats.latencies.count.Load(), stats.errors.Load(), stats.latencies.avg(), stats.latencies.percentile(95))
+	}
+	fmt.Fprintln(w)
+
+	// The settings and the waits for connections, to compare pool sizes
+	pool := r.db.Stats()
+	lifetime := "unlimited"
+	if r.spec.ConnMaxLifetime > 0 {
+		lifetime = fmt.Sprintf("%ds", r.spec.ConnMaxLifetime)
+	}
+	prepared := "off"
+	if r.spec.Prepared {
+		prepared = "on"
+	}
+	fmt.Fprintln(w, "Connection pool:")
+	fmt.Fprintf(w, "    max open connections:                %d\n", pool.MaxOpenConnections)
+	fmt.Fprintf(w, "    connection lifetime:                 %s\n", lifetime)
+	fmt.Fprintf(w, "    prepared statements:                 %s\n", prepared)
+	fmt.Fprintf(w, "    open connections:                    %d\n", pool.OpenConnections)
+	fmt.Fprintf(w, "    waits for a connection:              %d  (%.2f ms avg)\n", pool.WaitCount, avgWaitMs(pool))
+	fmt.Fprintf(w, "    closed as idle:                      %d\n", pool.MaxIdleClosed+pool.MaxIdleTimeClosed)
+	fmt.Fprintf(w, "    closed at lifetime:                  %d\n", pool.MaxLifetimeClosed)
+}
+
+// avgWaitMs returns the average wait for a connection of the pool in milliseconds.
+func avgWaitMs(stats sql.DBStats) float64 {
+	if stats.WaitCount == 0 {
+		return 0
+	}
+	return nsToMs(stats.WaitDuration.Nanoseconds() / stats.WaitCount)
+}
+
+// meanStddev returns the mean and the population standard deviation of values.
+func meanStddev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)))
+}
+
+// Buckets of latency histograms: logarithmic from latencyHistogramMin to
+// latencyHistogramMax milliseconds, each about 2% wider than the previous.
+const (
+	latencyHistogramBuckets = 1024
+	latencyHistogramMin     = 0.001
+	latencyHistogramMax     = 100000.0
+)
+
+// latencyBucketGrowth is the ratio of the bounds of consecutive buckets.
+var latencyBucketGrowth = math.Pow(latencyHistogramMax/latencyHistogramMin, 1.0/latencyHistogramBuckets)
+
+// latencyHistogram counts latencies in logarithmic buckets, like sysbench
+// does for its percentiles. It is safe for concurrent use.
+type latencyHistogram struct {
+	buckets [latencyHistogramBuckets]atomic.Int64
+	count   atomic.Int64
+	sumNs   atomic.Int64
+	minNs   atomic.Int64 // 0 until the first latency
+	maxNs   atomic.Int64
+}
+
+// record counts a latency.
+func (h *latencyHistogram) record(d time.Duration) {
+	ms := float64(d) / float64(time.Millisecond)
+	i := 0
+	if ms > latencyHistogramMin {
+		i = min(int(math.Log(ms/latencyHistogramMin)/math.Log(latencyBucketGrowth)), latencyHistogramBuckets-1)
+	}
+	h.buckets[i].Add(1)
+	h.count.Add(1)
+	h.sumNs.Add(int64(d))
+
+	ns := max(int64(d), 1)
+	for cur := h.minNs.Load(); (cur == 0 || ns < cur) && !h.minNs.CompareAndSwap(cur, ns); cur = h.minNs.Load() {
+	}
+	for cur := h.maxNs.Load(); ns > cur && !h.maxNs.CompareAndSwap(cur, ns); cur = h.maxNs.Load() {
+	}
+}
+
+// percentile returns the upper bound in milliseconds of the bucket holding
+// the p-th percentile, or 0 without latencies.
+func (h *latencyHistogram) percentile(p float64) float64 {
+	count := h.count.Load()
+	if count == 0 {
+		return 0
+	}
+	rank := int64(math.Ceil(float64(count) * p / 100))
+	var seen int64
+	for i := range h.buckets {
+		seen += h.buckets[i].Load()
+		if seen >= rank {
+			return min(latencyHistogramMin*math.Pow(latencyBucketGrowth, float64(i+1)), h.max())
+		}
+	}
+	return h.max()
+}
+
+func (h *latencyHistogram) min() float64 { return nsToMs(h.minNs.Load()) }
+func (h *latencyHistogram) max() float64 { return nsToMs(h.maxNs.Load()) }
+func (h *latencyHistogram) sum() float64 { return nsToMs(h.sumNs.Load()) }
+
+// avg returns the mean latency in milliseconds, or 0 without latencies.
+func (h *latencyHistogram) avg() float64 {
+	if n := h.count.Load(); n > 0 {
+		return h.sum() / float64(n)
+	}
+	return 0
+}
+
+// nsToMs converts nanoseconds to milliseconds.
+func nsToMs(ns int64) float64 {
+	return float64(ns) / float64(time.Millisecond)
+}
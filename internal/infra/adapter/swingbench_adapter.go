@@ -5,9 +5,15 @@ package adapter
 import (
 	"bufio"
 	"context"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -32,6 +38,17 @@ func NewSwingbenchAdapter() *SwingbenchAdapter {
 	}
 }
 
+// withToolPaths returns a copy of the adapter that runs the configured
+// charbench and the oewizard next to it.
+func (a *SwingbenchAdapter) withToolPaths(paths ToolPaths) BenchmarkAdapter {
+	c := NewSwingbenchAdapter()
+	if paths.Swingbench != "" {
+		c.SwingbenchPath = paths.Swingbench
+		c.OewizardPath = filepath.Join(filepath.Dir(paths.Swingbench), "oewizard")
+	}
+	return c
+}
+
 // Type returns the adapter type.
 func (a *SwingbenchAdapter) Type() AdapterType {
 	return AdapterTypeSwingbench
@@ -57,7 +74,6 @@ func (a *SwingbenchAdapter) BuildPrepareCommand(ctx context.Context, config *Con
 
 	// Build oewizard command
 	cmdArgs := []string{
-		"cd", "/opt/benchtools/swingbench/bin", "&&",
 		a.OewizardPath,
 		"-cl", // Character mode (non-interactive)
 		"-create",
@@ -66,11 +82,6 @@ func (a *SwingbenchAdapter) BuildPrepareCommand(ctx context.Context, config *Con
 		"-u", oracleConn.Username,
 	}
 
-	// Add password if provided
-	if oracleConn.Password != "" {
-		cmdArgs = append(cmdArgs, "-p", oracleConn.Password)
-	}
-
 	// Add scale parameter (data size)
 	if scale, ok := config.Parameters["scale"].(int); ok {
 		cmdArgs = append(cmdArgs, "-scale", strconv.Itoa(scale))
@@ -86,18 +97,19 @@ func (a *SwingbenchAdapter) BuildPrepareCommand(ctx context.Context, config *Con
 	}
 
 	// Add DBA credentials for schema creation
-	if dbaUser, ok := config.Parameters["dba_username"].(string); ok && dbaUser != "" {
-		cmdArgs = append(cmdArgs, "-dba", dbaUser)
-		if dbaPass, ok := config.Parameters["dba_password"].(string); ok && dbaPass != "" {
-			cmdArgs = append(cmdArgs, "-dbap", dbaPass)
-		}
+	credentialArgs, files, err := a.oewizardCredentials(config, oracleConn.Password)
+	if err != nil {
+		return nil, err
 	}
+	cmdArgs = append(cmdArgs, credentialArgs...)
 
 	cmdLine := strings.Join(cmdArgs, " ")
 
 	return &Command{
 		CmdLine: cmdLine,
 		WorkDir: config.WorkDir,
+		Files:   files,
+		Secrets: connectionSecrets(config),
 	}, nil
 }
 
@@ -121,17 +133,29 @@ func (a *SwingbenchAdapter) BuildRunCommand(ctx context.Context, config *Config)
 
 	// Build charbench command
 	cmdArgs := []string{
-		"cd", "/opt/benchtools/swingbench/bin", "&&",
 		a.SwingbenchPath,
 	}
 
 	// Add config file (required for charbench)
-	if configFile, ok := config.Parameters["config_file"].(string); ok && configFile != "" {
-		cmdArgs = append(cmdArgs, "-c", configFile)
-	} else {
+	configFile, ok := config.Parameters["config_file"].(string)
+	if !ok || configFile == "" {
 		return nil, fmt.Errorf("config_file parameter is required for charbench")
 	}
 
+	// charbench reads the password from a copy of the config file, so that
+	// it does not show on the command line; -p is only used if the config
+	// file cannot be read on this machine
+	var files map[string]string
+	passwordArg := oracleConn.Password != ""
+	if passwordArg {
+		if content, ok := configWithPassword(configFile, oracleConn.Password); ok {
+			files = map[string]string{swingbenchCredentialFile: content}
+			configFile = credentialFilePath(config, swingbenchCredentialFile)
+			passwordArg = false
+		}
+	}
+	cmdArgs = append(cmdArgs, "-c", configFile)
+
 	// Add connection string
 	cmdArgs = append(cmdArgs, "-cs", connectionStr)
 
@@ -140,9 +164,9 @@ func (a *SwingbenchAdapter) BuildRunCommand(ctx context.Context, config *Config)
 		cmdArgs = append(cmdArgs, "-u", oracleConn.Username)
 	}
 
-	// Add password if provided
-	if oracleConn.Password != "" {
-		cmdArgs = append(cmdArgs, "-p", oracleConn.Password)
+	// Add password if the config file cannot hold it
+	if passwordArg {
+		cmdArgs = append(cmdArgs, "-p", commandArg(oracleConn.Password))
 	}
 
 	// Add user count (concurrent users)
@@ -163,9 +187,120 @@ func (a *SwingbenchAdapter) BuildRunCommand(ctx context.Context, config *Config)
 	return &Command{
 		CmdLine: cmdLine,
 		WorkDir: config.WorkDir,
+		Files:   files,
+		Secrets: connectionSecrets(config),
 	}, nil
 }
 
+// swingbenchCredentialFile is the copy of the charbench config file that
+// holds the password of a local run.
+const swingbenchCredentialFile = CredentialFilePrefix + "swingconfig.xml"
+
+// swingbenchPassword matches the password of the connection of a charbench config file.
+var swingbenchPassword = regexp.MustCompile(`(?s)<Password>.*?</Password>`)
+
+// configWithPassword returns the content of a charbench config file with the
+// password of its connection replaced, or false if the file cannot be read
+// or has no <Password> element.
+func configWithPassword(configFile, password string) (string, bool) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return "", false
+	}
+	loc := swingbenchPassword.FindIndex(data)
+	if loc == nil {
+		return "", false
+	}
+	var b strings.Builder
+	b.Write(data[:loc[0]])
+	b.WriteString("<Password>")
+	xml.EscapeText(&b, []byte(password))
+	b.WriteString("</Password>")
+	b.Write(data[loc[1]:])
+	return b.String(), true
+}
+
+// oewizardCredentialFile is the copy of the oewizard wizard config that
+// holds the passwords of a prepare or cleanup.
+const oewizardCredentialFile = CredentialFilePrefix + "oewizard.xml"
+
+// oewizardDefaultParameters matches the end of the default parameters of a wizard config.
+var oewizardDefaultParameters = regexp.MustCompile(`</DefaultParameters>`)
+
+// oewizardCredentials returns the arguments that pass the user, the DBA and
+// their passwords to oewizard. oewizard reads the passwords from a copy of
+// its wizard config, returned as a credential file, so that they do not show
+// on the command line.
+func (a *SwingbenchAdapter) oewizardCredentials(config *Config, password string) ([]string, map[string]string, error) {
+	var args []string
+	passwords := make(map[string]string)
+	if password != "" {
+		passwords["password"] = password
+	}
+	if dbaUser, ok := config.Parameters["dba_username"].(string); ok && dbaUser != "" {
+		args = append(args, "-dba", dbaUser)
+		if dbaPass, ok := config.Parameters["dba_password"].(string); ok && dbaPass != "" {
+			passwords["dbapassword"] = dbaPass
+		}
+	}
+	if len(passwords) == 0 {
+		return args, nil, nil
+	}
+
+	wizardConfig := a.wizardConfigPath(config)
+	content, err := wizardConfigWithPasswords(wizardConfig, passwords)
+	if err != nil {
+		return nil, nil, fmt.Errorf("oewizard wizard config %s (set the wizard_config parameter): %w", wizardConfig, err)
+	}
+	args = append(args, "-cf", credentialFilePath(config, oewizardCredentialFile))
+	return args, map[string]string{oewizardCredentialFile: content}, nil
+}
+
+// wizardConfigPath returns the wizard config of oewizard: the wizard_config
+// parameter, or wizardconfigs/oewizard.xml of the Swingbench installation.
+func (a *SwingbenchAdapter) wizardConfigPath(config *Config) string {
+	if path, ok := config.Parameters["wizard_config"].(string); ok && path != "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(filepath.Dir(a.OewizardPath)), "wizardconfigs", "oewizard.xml")
+}
+
+// wizardConfigWithPasswords returns the content of an oewizard wizard config
+// with the default parameters named by the keys of passwords set.
+func wizardConfigWithPasswords(wizardConfig string, passwords map[string]string) (string, error) {
+	data, err := os.ReadFile(wizardConfig)
+	if err != nil {
+		return "", err
+	}
+	content := string(data)
+	if oewizardDefaultParameters.FindStringIndex(content) == nil {
+		return "", errors.New("no <DefaultParameters> element")
+	}
+	for _, key := range slices.Sorted(maps.Keys(passwords)) {
+		var value strings.Builder
+		xml.EscapeText(&value, []byte(passwords[key]))
+		parameter := fmt.Sprintf(`<Parameter Key="%s" Value="%s"/>`, key, value.String())
+		existing := regexp.MustCompile(`<Parameter\s[^>]*Key="` + regexp.QuoteMeta(key) + `"[^>]*/>`)
+		if loc := existing.FindStringIndex(content); loc != nil {
+			content = content[:loc[0]] + parameter + content[loc[1]:]
+			continue
+		}
+		loc := oewizardDefaultParameters.FindStringIndex(content)
+		content = content[:loc[0]] + parameter + "\n" + content[loc[0]:]
+	}
+	return content, nil
+}
+
+// credentialFilePath returns the path a command opens a credential file by:
+// in the work directory of a local run, or relative to the work directory an
+// agent creates for the command.
+func credentialFilePath(config *Config, name string) string {
+	if config.Options.Remote() {
+		return name
+	}
+	return filepath.Join(config.WorkDir, name)
+}
+
 // BuildCleanupCommand builds the command for cleanup phase.
 // Uses oewizard to drop the schema.
 func (a *SwingbenchAdapter) BuildCleanupCommand(ctx context.Context, config *Config) (*Command, error) {
@@ -186,7 +321,6 @@ func (a *SwingbenchAdapter) BuildCleanupCommand(ctx context.Context, config *Con
 
 	// Build oewizard drop command
 	cmdArgs := []string{
-		"cd", "/opt/benchtools/swingbench/bin", "&&",
 		a.OewizardPath,
 		"-cl", // Character mode (non-interactive)
 		"-drop",
@@ -194,24 +328,20 @@ func (a *SwingbenchAdapter) BuildCleanupCommand(ctx context.Context, config *Con
 		"-u", oracleConn.Username,
 	}
 
-	// Add password if provided
-	if oracleConn.Password != "" {
-		cmdArgs = append(cmdArgs, "-p", oracleConn.Password)
-	}
-
 	// Add DBA credentials for schema drop
-	if dbaUser, ok := config.Parameters["dba_username"].(string); ok && dbaUser != "" {
-		cmdArgs = append(cmdArgs, "-dba", dbaUser)
-		if dbaPass, ok := config.Parameters["dba_password"].(string); ok && dbaPass != "" {
-			cmdArgs = append(cmdArgs, "-dbap", dbaPass)
-		}
+	credentialArgs, files, err := a.oewizardCredentials(config, oracleConn.Password)
+	if err != nil {
+		return nil, err
 	}
+	cmdArgs = append(cmdArgs, credentialArgs...)
 
 	cmdLine := strings.Join(cmdArgs, " ")
 
 	return &Command{
 		CmdLine: cmdLine,
 		WorkDir: config.WorkDir,
+		Files:   files,
+		Secrets: connectionSecrets(config),
 	}, nil
 }
 
@@ -355,10 +485,10 @@ func (a *SwingbenchAdapter) ParseRunOutput(ctx context.Context, stdout string, s
 }
 
 // StartRealtimeCollection starts realtime metric collection from swingbench output.
-func (a *SwingbenchAdapter) StartRealtimeCollection(ctx context.Context, stdout io.Reader, stderr io.Reader) (<-chan Sample, <-chan error, *strings.Builder) {
+func (a *SwingbenchAdapter) StartRealtimeCollection(ctx context.Context, stdout io.Reader) (<-chan Sample, <-chan error, *OutputBuffer) {
 	sampleChan := make(chan Sample, 10)
 	errChan := make(chan error, 1)
-	var stdoutBuf strings.Builder
+	stdoutBuf := NewOutputBuffer(DefaultOutputTail)
 
 	go func() {
 		defer close(sampleChan)
@@ -421,7 +551,7 @@ func (a *SwingbenchAdapter) StartRealtimeCollection(ctx context.Context, stdout
 		}
 	}()
 
-	return sampleChan, errChan, &stdoutBuf
+	return sampleChan, errChan, stdoutBuf
 }
 
 // ParseFinalResults parses final results from swingbench output.
@@ -464,7 +594,10 @@ func (a *SwingbenchAdapter) buildConnectionString(conn *connection.OracleConnect
 	// Swingbench format: jdbc:oracle:thin:@//host:port/service_name or jdbc:oracle:thin:@host:port:sid
 	var connectionStr string
 
-	if conn.ServiceName != "" {
+	if conn.IsTCPS() {
+		// Easy Connect has no wallet directory; the descriptor carries it
+		connectionStr = "jdbc:oracle:thin:@" + conn.Descriptor()
+	} else if conn.ServiceName != "" {
 		connectionStr = fmt.Sprintf("jdbc:oracle:thin:@//%s:%d/%s",
 			conn.Host, conn.Port, conn.ServiceName)
 	} else if conn.SID != "" {
@@ -488,9 +621,12 @@ func (a *SwingbenchAdapter) buildConnectionString(conn *connection.OracleConnect
 }
 
 // buildCharbenchConnectionString builds a charbench/oewizard connection string for Oracle.
-// Format: //host:port/service_name or host:port:sid (Easy Connect format)
+// Format: //host:port/service_name or host:port:sid (Easy Connect format), or
+// a connect descriptor for TCPS.
 func (a *SwingbenchAdapter) buildCharbenchConnectionString(conn *connection.OracleConnection) string {
-	if conn.ServiceName != "" {
+	if conn.IsTCPS() {
+		return commandArg(conn.Descriptor()) // The wallet directory may contain spaces
+	} else if conn.ServiceName != "" {
 		return fmt.Sprintf("//%s:%d/%s", conn.Host, conn.Port, conn.ServiceName)
 	} else if conn.SID != "" {
 		return fmt.Sprintf("%s:%d:%s", conn.Host, conn.Port, conn.SID)
@@ -3,6 +3,8 @@ package adapter
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -40,6 +42,7 @@ func TestSwingbenchAdapter_BuildPrepareCommand(t *testing.T) {
 			"threads":       32,
 			"dba_username":  "sys as sysdba",
 			"dba_password":  "testpass",
+			"wizard_config": writeWizardConfig(t),
 		},
 		WorkDir: "/tmp/test",
 	}
@@ -93,6 +96,30 @@ func TestSwingbenchAdapter_BuildRunCommand(t *testing.T) {
 				assert.Contains(t, cmd.CmdLine, "-rt 10:00")
 			},
 		},
+		{
+			name: "SOE benchmark over TCPS with a wallet",
+			conn: &connection.OracleConnection{
+				BaseConnection: connection.BaseConnection{
+					ID:   "test-conn-tcps",
+					Name: "Test Autonomous",
+				},
+				Host:        "adb.example.com",
+				Port:        1522,
+				ServiceName: "bench_high",
+				Username:    "admin",
+				Password:    "testpass",
+				Protocol:    connection.OracleProtocolTCPS,
+				WalletDir:   "/opt/my wallet",
+			},
+			params: map[string]interface{}{
+				"config_file": "/opt/benchtools/swingbench/configs/SOE_CPU_Bound.xml",
+			},
+			validate: func(t *testing.T, cmd *Command, err error) {
+				require.NoError(t, err)
+				assert.Contains(t, cmd.CmdLine, "-cs (DESCRIPTION=(ADDRESS=(PROTOCOL=TCPS)(HOST=adb.example.com)(PORT=1522))"+
+					"(CONNECT_DATA=(SERVICE_NAME=bench_high))(SECURITY=(MY_WALLET_DIRECTORY=/opt/my\\ wallet))) ")
+			},
+		},
 		{
 			name: "SOE benchmark with SID instead of service name",
 			conn: &connection.OracleConnection{
@@ -198,8 +225,9 @@ func TestSwingbenchAdapter_BuildCleanupCommand(t *testing.T) {
 	config := &Config{
 		Connection: conn,
 		Parameters: map[string]interface{}{
-			"dba_username": "sys as sysdba",
-			"dba_password": "testpass",
+			"dba_username":  "sys as sysdba",
+			"dba_password":  "testpass",
+			"wizard_config": writeWizardConfig(t),
 		},
 		WorkDir: "/tmp/test",
 	}
@@ -416,3 +444,103 @@ func TestSwingbenchAdapter_buildConnectionString(t *testing.T) {
 		})
 	}
 }
+
+// TestSwingbenchAdapter_BuildRunCommand_CredentialFile tests that a local run
+// passes the password in a copy of the config file rather than with -p.
+func TestSwingbenchAdapter_BuildRunCommand_CredentialFile(t *testing.T) {
+	ctx := context.Background()
+	configFile := filepath.Join(t.TempDir(), "SOE_Server_Side_V2.xml")
+	require.NoError(t, os.WriteFile(configFile, []byte(
+		"<SwingBenchConfiguration>\n<Connection>\n<UserName>soe</UserName>\n<Password>soe</Password>\n</Connection>\n</SwingBenchConfiguration>\n"), 0644))
+
+	conn := &connection.OracleConnection{
+		Host:        "localhost",
+		Port:        1521,
+		ServiceName: "ORCL",
+		Username:    "soe",
+		Password:    "p<a&ss word",
+	}
+	config := &Config{
+		Connection: conn,
+		Parameters: map[string]interface{}{"config_file": configFile, "dba_password": "sys pass"},
+		WorkDir:    "/tmp/run-1",
+	}
+
+	cmd, err := NewSwingbenchAdapter().BuildRunCommand(ctx, config)
+	require.NoError(t, err)
+	assert.NotContains(t, cmd.CmdLine, "-p ")
+	assert.Contains(t, cmd.CmdLine, "-c /tmp/run-1/"+swingbenchCredentialFile)
+	assert.Contains(t, cmd.Files[swingbenchCredentialFile], "<Password>p&lt;a&amp;ss word</Password>")
+	assert.ElementsMatch(t, []string{"p<a&ss word", "sys pass"}, cmd.Secrets)
+
+	// Agents create the file in the work directory of the command
+	config.Options.Agent = "lg-1"
+	cmd, err = NewSwingbenchAdapter().BuildRunCommand(ctx, config)
+	require.NoError(t, err)
+	assert.NotContains(t, cmd.CmdLine, "-p ")
+	assert.Contains(t, cmd.CmdLine, "-c "+swingbenchCredentialFile+" ")
+	assert.Contains(t, cmd.Files[swingbenchCredentialFile], "<Password>p&lt;a&amp;ss word</Password>")
+}
+
+// TestSwingbenchAdapter_OewizardCredentialFile tests that prepare and cleanup
+// pass the passwords to oewizard in a copy of its wizard config.
+func TestSwingbenchAdapter_OewizardCredentialFile(t *testing.T) {
+	ctx := context.Background()
+	wizardConfig := writeWizardConfig(t)
+	conn := &connection.OracleConnection{
+		Host:        "localhost",
+		Port:        1521,
+		ServiceName: "ORCL",
+		Username:    "soe",
+		Password:    `p<a&ss "word`,
+	}
+	config := &Config{
+		Connection: conn,
+		Parameters: map[string]interface{}{"dba_username": "sys", "dba_password": "sys pass", "wizard_config": wizardConfig},
+		WorkDir:    "/tmp/run-1",
+	}
+
+	for name, build := range map[string]func(context.Context, *Config) (*Command, error){
+		"prepare": NewSwingbenchAdapter().BuildPrepareCommand,
+		"cleanup": NewSwingbenchAdapter().BuildCleanupCommand,
+	} {
+		cmd, err := build(ctx, config)
+		require.NoError(t, err, name)
+		assert.NotContains(t, cmd.CmdLine, "-p ", name)
+		assert.NotContains(t, cmd.CmdLine, "-dbap", name)
+		assert.NotContains(t, cmd.CmdLine, "word", name)
+		assert.NotContains(t, cmd.CmdLine, "sys pass", name)
+		assert.Contains(t, cmd.CmdLine, "-dba sys -cf /tmp/run-1/"+oewizardCredentialFile, name)
+		content := cmd.Files[oewizardCredentialFile]
+		assert.Contains(t, content, `<Parameter Key="password" Value="p&lt;a&amp;ss &#34;word"/>`, name)
+		assert.Contains(t, content, `<Parameter Key="dbapassword" Value="sys pass"/>`, name)
+		assert.Contains(t, content, `<Parameter Key="tablespace" Value="SOE"/>`, name)
+		assert.NotContains(t, content, `Value="manager"`, name)
+	}
+
+	// Agents create the file in the work directory of the command
+	config.Options.Agent = "lg-1"
+	cmd, err := NewSwingbenchAdapter().BuildCleanupCommand(ctx, config)
+	require.NoError(t, err)
+	assert.Contains(t, cmd.CmdLine, "-cf "+oewizardCredentialFile)
+
+	// Without its wizard config oewizard cannot get the passwords
+	config.Parameters["wizard_config"] = filepath.Join(t.TempDir(), "missing.xml")
+	_, err = NewSwingbenchAdapter().BuildPrepareCommand(ctx, config)
+	assert.ErrorContains(t, err, "wizard_config")
+}
+
+// writeWizardConfig writes an oewizard wizard config and returns its path.
+func writeWizardConfig(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "oewizard.xml")
+	require.NoError(t, os.WriteFile(path, []byte(`<WizardConfig Mode="InterActive" Name="Oracle Entry Install Wizard">
+   <DefaultParameters>
+      <Parameter Key="dbapassword" Value="manager"/>
+      <Parameter Key="tablespace" Value="SOE"/>
+      <Parameter Key="username" Value="SOE"/>
+   </DefaultParameters>
+</WizardConfig>
+`), 0644))
+	return path
+}
@@ -4,6 +4,7 @@ package adapter
 
 import (
 	"bufio"
+	"cmp"
 	"context"
 	"fmt"
 	"io"
@@ -24,15 +25,34 @@ import (
 type SysbenchAdapter struct {
 	// Path to sysbench executable (optional, if empty uses PATH)
 	SysbenchPath string
+	// Paths to the mysql and psql clients that create the benchmark database
+	MySQLPath string
+	PsqlPath  string
+
+	// Version of the sysbench at SysbenchPath, detected on first use
+	version *sysbenchVersion
 }
 
 // NewSysbenchAdapter creates a new sysbench adapter.
 func NewSysbenchAdapter() *SysbenchAdapter {
 	return &SysbenchAdapter{
-		SysbenchPath: "sysbench", // Default to PATH
+		SysbenchPath: DefaultSysbenchPath, // Default to PATH
+		MySQLPath:    DefaultMySQLPath,
+		PsqlPath:     DefaultPsqlPath,
+		version:      newSysbenchVersion(DefaultSysbenchPath),
 	}
 }
 
+// withToolPaths returns a copy of the adapter that runs the configured executables.
+func (a *SysbenchAdapter) withToolPaths(paths ToolPaths) BenchmarkAdapter {
+	c := *NewSysbenchAdapter()
+	c.SysbenchPath = cmp.Or(paths.Sysbench, c.SysbenchPath)
+	c.MySQLPath = cmp.Or(paths.MySQL, c.MySQLPath)
+	c.PsqlPath = cmp.Or(paths.Psql, c.PsqlPath)
+	c.version = newSysbenchVersion(c.SysbenchPath)
+	return &c
+}
+
 // BuildCreateDatabaseCommand builds a command to create the database if it doesn't exist.
 // This should be called before BuildPrepareCommand to ensure the database exists.
 func (a *SysbenchAdapter) BuildCreateDatabaseCommand(ctx context.Context, config *Config) (*Command, error) {
@@ -78,23 +98,25 @@ func (a *SysbenchAdapter) BuildCreateDatabaseCommand(ctx context.Context, config
 		slog.Info("SysbenchAdapter: Building create database command",
 			"host", c.Host, "port", c.Port, "user", c.Username,
 			"has_password", c.Password != "", "db", dbName)
-		cmdLine = fmt.Sprintf("mysql -h %s -P %d -u %s -e \"%s\"",
-			c.Host, c.Port, c.Username, createSQL)
+		cmdLine = fmt.Sprintf("%s -h %s -P %d -u %s -e \"%s\"",
+			localPath(config, a.MySQLPath, DefaultMySQLPath), c.Host, c.Port, c.Username, createSQL)
 
 	case *connection.PostgreSQLConnection:
 		// PostgreSQL: psql -h host -p port -U user -c "CREATE DATABASE \"db\";"
-		cmdLine = fmt.Sprintf("psql -h %s -p %d -U %s -c \"%s\"",
-			c.Host, c.Port, c.Username, createSQL)
+		cmdLine = fmt.Sprintf("%s -h %s -p %d -U %s -c \"%s\"",
+			localPath(config, a.PsqlPath, DefaultPsqlPath), c.Host, c.Port, c.Username, createSQL)
 		// Password is set via PGPASSWORD environment variable
 		if c.Password != "" {
 			env = append(env, fmt.Sprintf("PGPASSWORD=%s", c.Password))
 		}
+		env = append(env, c.OptionsEnv()...)
 	}
 
 	return &Command{
 		CmdLine: cmdLine,
 		WorkDir: config.WorkDir,
 		Env:     env,
+		Secrets: connectionSecrets(config),
 	}, nil
 }
 
@@ -112,38 +134,44 @@ func (a *SysbenchAdapter) BuildPrepareCommand(ctx context.Context, config *Confi
 	dbDriver := a.getDBType(conn)
 
 	// Determine sysbench script name from template ID or default
-	scriptName := a.getScriptName(config.Template)
+	compat := a.compat(ctx, config)
+	scriptName := compat.script(a.scriptPath(config))
 
 	// Build prepare command
 	cmdArgs := []string{
-		a.SysbenchPath,
+		localPath(config, a.SysbenchPath, DefaultSysbenchPath),
 		scriptName,
 		fmt.Sprintf("--db-driver=%s", dbDriver),
 	}
 
 	// Add connection-specific arguments
-	cmdArgs = append(cmdArgs, a.buildConnectionArgs(conn, config)...)
+	cmdArgs = append(cmdArgs, a.buildConnectionArgs(conn, config, compat)...)
 
 	// Add template parameters
-	if tables, ok := config.Parameters["tables"].(int); ok {
-		cmdArgs = append(cmdArgs, fmt.Sprintf("--tables=%d", tables))
-	}
-	if tableSize, ok := config.Parameters["table_size"].(int); ok {
-		cmdArgs = append(cmdArgs, fmt.Sprintf("--table-size=%d", tableSize))
+	if IsSysbenchTPCC(config.Template) {
+		cmdArgs = append(cmdArgs, tpccArgs(config, "prepare")...)
+	} else {
+		if tables, ok := config.Parameters["tables"].(int); ok {
+			cmdArgs = append(cmdArgs, fmt.Sprintf("--tables=%d", tables))
+		}
+		if tableSize, ok := config.Parameters["table_size"].(int); ok {
+			cmdArgs = append(cmdArgs, fmt.Sprintf("--table-size=%d", tableSize))
+		}
 	}
 
 	cmdArgs = append(cmdArgs, "prepare")
 
-	cmdLine := strings.Join(cmdArgs, " ")
+	cmd := &Command{
+		CmdLine: strings.Join(cmdArgs, " "),
+		WorkDir: config.WorkDir,
+		Env:     a.commandEnv(config),
+		Secrets: connectionSecrets(config),
+	}
 
 	slog.Info("SysbenchAdapter: Built prepare command",
-		"cmd", cmdLine)
+		"cmd", cmd.MaskedCmdLine())
 
-	return &Command{
-		CmdLine: cmdLine,
-		WorkDir: config.WorkDir,
-		Env:     a.buildEnvVars(conn),
-	}, nil
+	return cmd, nil
 }
 
 // BuildRunCommand builds the command for the main benchmark run.
@@ -154,20 +182,23 @@ func (a *SysbenchAdapter) BuildRunCommand(ctx context.Context, config *Config) (
 	dbDriver := a.getDBType(conn)
 
 	// Determine sysbench script name from template ID or default
-	scriptName := a.getScriptName(config.Template)
+	compat := a.compat(ctx, config)
+	scriptName := compat.script(a.scriptPath(config))
 
 	// Build run command
 	cmdArgs := []string{
-		a.SysbenchPath,
+		localPath(config, a.SysbenchPath, DefaultSysbenchPath),
 		scriptName,
 		fmt.Sprintf("--db-driver=%s", dbDriver),
 	}
 
 	// Add connection-specific arguments
-	cmdArgs = append(cmdArgs, a.buildConnectionArgs(conn, config)...)
+	cmdArgs = append(cmdArgs, a.buildConnectionArgs(conn, config, compat)...)
 
 	// Add template parameters
-	if tables, ok := config.Parameters["tables"].(int); ok {
+	if IsSysbenchTPCC(config.Template) {
+		cmdArgs = append(cmdArgs, tpccArgs(config, "run")...)
+	} else if tables, ok := config.Parameters["tables"].(int); ok {
 		cmdArgs = append(cmdArgs, fmt.Sprintf("--tables=%d", tables))
 	}
 	if threads, ok := config.Parameters["threads"].(int); ok {
@@ -181,20 +212,21 @@ func (a *SysbenchAdapter) BuildRunCommand(ctx context.Context, config *Config) (
 	}
 
 	// Add report interval for realtime monitoring
-	cmdArgs = append(cmdArgs, "--report-interval=1")
+	cmdArgs = append(cmdArgs, fmt.Sprintf("--report-interval=%d", reportIntervalSeconds(config.Options.SampleInterval)))
 
 	cmdArgs = append(cmdArgs, "run")
 
-	cmdLine := strings.Join(cmdArgs, " ")
+	cmd := &Command{
+		CmdLine: strings.Join(cmdArgs, " "),
+		WorkDir: config.WorkDir,
+		Env:     a.commandEnv(config),
+		Secrets: connectionSecrets(config),
+	}
 
 	slog.Info("SysbenchAdapter: Built run command",
-		"cmd", cmdLine)
+		"cmd", cmd.MaskedCmdLine())
 
-	return &Command{
-		CmdLine: cmdLine,
-		WorkDir: config.WorkDir,
-		Env:     a.buildEnvVars(conn),
-	}, nil
+	return cmd, nil
 }
 
 // BuildCleanupCommand builds the command for cleanup phase.
@@ -205,32 +237,36 @@ func (a *SysbenchAdapter) BuildCleanupCommand(ctx context.Context, config *Confi
 	dbDriver := a.getDBType(conn)
 
 	// Build script path or name
-	scriptName := a.getScriptName(config.Template)
+	compat := a.compat(ctx, config)
+	scriptName := compat.script(a.scriptPath(config))
 
 	cmdArgs := []string{
-		a.SysbenchPath,
+		localPath(config, a.SysbenchPath, DefaultSysbenchPath),
 		scriptName,
 		fmt.Sprintf("--db-driver=%s", dbDriver),
 	}
 
-	cmdArgs = append(cmdArgs, a.buildConnectionArgs(conn, config)...)
+	cmdArgs = append(cmdArgs, a.buildConnectionArgs(conn, config, compat)...)
 
-	if tables, ok := config.Parameters["tables"].(int); ok {
+	if IsSysbenchTPCC(config.Template) {
+		cmdArgs = append(cmdArgs, tpccArgs(config, "cleanup")...)
+	} else if tables, ok := config.Parameters["tables"].(int); ok {
 		cmdArgs = append(cmdArgs, fmt.Sprintf("--tables=%d", tables))
 	}
 
 	cmdArgs = append(cmdArgs, "cleanup")
 
-	cmdLine := strings.Join(cmdArgs, " ")
+	cmd := &Command{
+		CmdLine: strings.Join(cmdArgs, " "),
+		WorkDir: config.WorkDir,
+		Env:     a.commandEnv(config),
+		Secrets: connectionSecrets(config),
+	}
 
 	slog.Info("SysbenchAdapter: Built cleanup command",
-		"cmd", cmdLine)
+		"cmd", cmd.MaskedCmdLine())
 
-	return &Command{
-		CmdLine: cmdLine,
-		WorkDir: config.WorkDir,
-		Env:     a.buildEnvVars(conn),
-	}, nil
+	return cmd, nil
 }
 
 // ParseRunOutput parses the output from a benchmark run.
@@ -328,10 +364,10 @@ func (a *SysbenchAdapter) ParseRunOutput(ctx context.Context, stdout string, std
 // [ 10s ] thds: 4 tps: 342.03 qps: 6846.39 (r/w/o: 4792.91/1369.02/684.46) lat (ms,95%): 13.46 err/s: 0.00 reconn/s: 0.00
 //
 // Also returns a buffer containing the complete stdout for final result parsing.
-func (a *SysbenchAdapter) StartRealtimeCollection(ctx context.Context, stdout io.Reader) (<-chan Sample, <-chan error, *strings.Builder) {
+func (a *SysbenchAdapter) StartRealtimeCollection(ctx context.Context, stdout io.Reader) (<-chan Sample, <-chan error, *OutputBuffer) {
 	sampleCh := make(chan Sample, 10)
 	errCh := make(chan error, 1)
-	var stdoutBuf strings.Builder
+	stdoutBuf := NewSummaryOutputBuffer(SummaryOutputTail, newSysbenchSummaryParser())
 
 	go func() {
 		defer close(sampleCh)
@@ -341,9 +377,8 @@ func (a *SysbenchAdapter) StartRealtimeCollection(ctx context.Context, stdout io
 		for scanner.Scan() {
 			line := scanner.Text()
 
-			// Save to buffer for final result parsing
-			stdoutBuf.WriteString(line)
-			stdoutBuf.WriteString("\n")
+			// Parse the final results as the summary arrives
+			stdoutBuf.AddLine(line)
 
 			// Parse intermediate results - check for time marker first
 			if !regexp.MustCompile(`\[\s*\d+s\s*\]`).MatchString(line) {
@@ -417,127 +452,149 @@ func (a *SysbenchAdapter) StartRealtimeCollection(ctx context.Context, stdout io
 		}
 	}()
 
-	return sampleCh, errCh, &stdoutBuf
+	return sampleCh, errCh, stdoutBuf
 }
 
 // ParseFinalResults parses the final benchmark results from sysbench output.
 // Implements: REQ-EXEC-005 (result collection)
 func (a *SysbenchAdapter) ParseFinalResults(ctx context.Context, stdout string) (*FinalResult, error) {
-	result := &FinalResult{}
-
-	lines := strings.Split(stdout, "\n")
-
-	// Parse SQL statistics
-	for i, line := range lines {
-		// Total transactions: 20466  (340.98 per sec.)
-		if matches := regexp.MustCompile(`transactions:\s*(\d+)\s*\(\s*(\d+\.?\d*)\s*per sec\.\)`).FindStringSubmatch(line); len(matches) > 2 {
-			result.TotalTransactions, _ = strconv.ParseInt(matches[1], 10, 64)
-			result.TransactionsPerSec, _ = strconv.ParseFloat(matches[2], 64)
-		}
-
-		// queries: 409320 (6819.55 per sec.)
-		if matches := regexp.MustCompile(`queries:\s*(\d+)\s*\(\s*(\d+\.?\d*)\s*per sec\.\)`).FindStringSubmatch(line); len(matches) > 2 {
-			result.TotalQueries, _ = strconv.ParseInt(matches[1], 10, 64)
-			result.QueriesPerSec, _ = strconv.ParseFloat(matches[2], 64)
-		}
-
-		// read:    286524
-		if matches := regexp.MustCompile(`read:\s*(\d+)`).FindStringSubmatch(line); len(matches) > 1 {
-			result.ReadQueries, _ = strconv.ParseInt(matches[1], 10, 64)
-		}
-
-		// write:   81864
-		if matches := regexp.MustCompile(`write:\s*(\d+)`).FindStringSubmatch(line); len(matches) > 1 {
-			result.WriteQueries, _ = strconv.ParseInt(matches[1], 10, 64)
-		}
-
-		// other:   40932
-		if matches := regexp.MustCompile(`other:\s*(\d+)`).FindStringSubmatch(line); len(matches) > 1 {
-			result.OtherQueries, _ = strconv.ParseInt(matches[1], 10, 64)
-		}
+	parser := newSysbenchSummaryParser()
+	for _, line := range strings.Split(stdout, "\n") {
+		parser.ParseLine(line)
+	}
+	return parser.Result()
+}
 
-		// ignored errors:  0      (0.00 per sec.)
-		if matches := regexp.MustCompile(`ignored errors:\s*(\d+)`).FindStringSubmatch(line); len(matches) > 1 {
-			result.IgnoredErrors, _ = strconv.ParseInt(matches[1], 10, 64)
-		}
+// Patterns of the sysbench summary printed when a run ends.
+var (
+	sysbenchTransactionsPattern = regexp.MustCompile(`transactions:\s*(\d+)\s*\(\s*(\d+\.?\d*)\s*per sec\.\)`)
+	sysbenchQueriesPattern      = regexp.MustCompile(`queries:\s*(\d+)\s*\(\s*(\d+\.?\d*)\s*per sec\.\)`)
+	sysbenchCountPattern        = regexp.MustCompile(`^(read|write|other|ignored errors|reconnects|total number of events):\s*(\d+)`)
+	sysbenchTotalTimePattern    = regexp.MustCompile(`total time:\s*(\d+\.?\d*)s`)
+	sysbenchLatencyPattern      = regexp.MustCompile(`^(min|avg|max|sum|95th percentile|99th percentile):\s*(\d+\.?\d*)`)
+	sysbenchFairnessPattern     = regexp.MustCompile(`^(events|execution time)\s*\(avg/stddev\):\s*(\d+\.?\d*)/(\d+\.?\d*)`)
+)
 
-		// reconnects:  0      (0.00 per sec.)
-		if matches := regexp.MustCompile(`reconnects:\s*(\d+)`).FindStringSubmatch(line); len(matches) > 1 {
-			result.Reconnects, _ = strconv.ParseInt(matches[1], 10, 64)
-		}
+// sysbenchSection is the section of the sysbench summary a line belongs to.
+type sysbenchSection int
 
-		// General statistics: total time:                          60.0202s
-		if strings.Contains(line, "total time:") {
-			if matches := regexp.MustCompile(`total time:\s*(\d+\.?\d*)s`).FindStringSubmatch(line); len(matches) > 1 {
-				result.TotalTime, _ = strconv.ParseFloat(matches[1], 64)
-			}
-		}
+const (
+	sysbenchSectionNone sysbenchSection = iota
+	sysbenchSectionSQL
+	sysbenchSectionGeneral
+	sysbenchSectionLatency
+	sysbenchSectionFairness
+)
 
-		// total number of events:              20466
-		if strings.Contains(line, "total number of events:") {
-			if matches := regexp.MustCompile(`total number of events:\s*(\d+)`).FindStringSubmatch(line); len(matches) > 1 {
-				result.TotalEvents, _ = strconv.ParseInt(matches[1], 10, 64)
-			}
-		}
+// sysbenchSectionHeaders maps the summary section headers to their sections.
+var sysbenchSectionHeaders = map[string]sysbenchSection{
+	"SQL statistics:":     sysbenchSectionSQL,
+	"General statistics:": sysbenchSectionGeneral,
+	"Latency (ms):":       sysbenchSectionLatency,
+	"Threads fairness:":   sysbenchSectionFairness,
+}
 
-		// Latency (ms): section
-		if strings.TrimSpace(line) == "Latency (ms):" {
-			// Parse next few lines for min, avg, max, 95th percentile
-			for j := i + 1; j < len(lines) && j < i+10; j++ {
-				latencyLine := strings.TrimSpace(lines[j])
-				if latencyLine == "" {
-					continue
-				}
-
-				// min:                                    8.42
-				if matches := regexp.MustCompile(`min:\s*(\d+\.?\d*)`).FindStringSubmatch(latencyLine); len(matches) > 1 {
-					result.LatencyMin, _ = strconv.ParseFloat(matches[1], 64)
-				}
-
-				// avg:                                   11.73
-				if matches := regexp.MustCompile(`avg:\s*(\d+\.?\d*)`).FindStringSubmatch(latencyLine); len(matches) > 1 {
-					result.LatencyAvg, _ = strconv.ParseFloat(matches[1], 64)
-				}
-
-				// max:                                   31.18
-				if matches := regexp.MustCompile(`max:\s*(\d+\.?\d*)`).FindStringSubmatch(latencyLine); len(matches) > 1 {
-					result.LatencyMax, _ = strconv.ParseFloat(matches[1], 64)
-				}
-
-				// 95th percentile:                       13.70
-				if matches := regexp.MustCompile(`95th percentile:\s*(\d+\.?\d*)`).FindStringSubmatch(latencyLine); len(matches) > 1 {
-					result.LatencyP95, _ = strconv.ParseFloat(matches[1], 64)
-				}
-
-				// 99th percentile (if present)
-				if matches := regexp.MustCompile(`99th percentile:\s*(\d+\.?\d*)`).FindStringSubmatch(latencyLine); len(matches) > 1 {
-					result.LatencyP99, _ = strconv.ParseFloat(matches[1], 64)
-				}
-
-				// sum:                               239982.82
-				if matches := regexp.MustCompile(`sum:\s*(\d+\.?\d*)`).FindStringSubmatch(latencyLine); len(matches) > 1 {
-					result.LatencySum, _ = strconv.ParseFloat(matches[1], 64)
-				}
-			}
-		}
+// sysbenchSummaryParser parses the sysbench summary line by line. It tracks
+// the section of the summary, so the per-second reports before it are only
+// matched against the distinctive transactions and queries lines.
+type sysbenchSummaryParser struct {
+	section sysbenchSection
+	result  FinalResult
+}
 
-		// Threads fairness: events (avg/stddev):           5116.5000/4.15
-		if strings.Contains(line, "events (avg/stddev):") {
-			if matches := regexp.MustCompile(`events\s*\(avg/stddev\):\s*(\d+\.?\d*)/(\d+\.?\d*)`).FindStringSubmatch(line); len(matches) > 2 {
-				result.EventsAvg, _ = strconv.ParseFloat(matches[1], 64)
-				result.EventsStddev, _ = strconv.ParseFloat(matches[2], 64)
-			}
-		}
+func newSysbenchSummaryParser() *sysbenchSummaryParser {
+	return &sysbenchSummaryParser{}
+}
 
-		// execution time (avg/stddev):   59.9957/0.00
-		if strings.Contains(line, "execution time (avg/stddev):") {
-			if matches := regexp.MustCompile(`execution time\s*\(avg/stddev\):\s*(\d+\.?\d*)/(\d+\.?\d*)`).FindStringSubmatch(line); len(matches) > 2 {
-				result.ExecTimeAvg, _ = strconv.ParseFloat(matches[1], 64)
-				result.ExecTimeStddev, _ = strconv.ParseFloat(matches[2], 64)
-			}
+// ParseLine implements SummaryParser.
+func (p *sysbenchSummaryParser) ParseLine(line string) {
+	line = strings.TrimSpace(line)
+	if section, ok := sysbenchSectionHeaders[line]; ok {
+		p.section = section
+		return
+	}
+	r := &p.result
+
+	// transactions:                        20466  (340.98 per sec.)
+	if matches := sysbenchTransactionsPattern.FindStringSubmatch(line); matches != nil {
+		r.TotalTransactions, _ = strconv.ParseInt(matches[1], 10, 64)
+		r.TransactionsPerSec, _ = strconv.ParseFloat(matches[2], 64)
+		return
+	}
+	// queries:                             409320 (6819.55 per sec.)
+	if matches := sysbenchQueriesPattern.FindStringSubmatch(line); matches != nil {
+		r.TotalQueries, _ = strconv.ParseInt(matches[1], 10, 64)
+		r.QueriesPerSec, _ = strconv.ParseFloat(matches[2], 64)
+		return
+	}
+	// total time:                          60.0202s
+	if matches := sysbenchTotalTimePattern.FindStringSubmatch(line); matches != nil {
+		r.TotalTime, _ = strconv.ParseFloat(matches[1], 64)
+		return
+	}
+
+	switch p.section {
+	case sysbenchSectionSQL, sysbenchSectionGeneral, sysbenchSectionNone:
+		// read: 286524, ignored errors: 0 (0.00 per sec.), total number of events: 20466
+		matches := sysbenchCountPattern.FindStringSubmatch(line)
+		if matches == nil {
+			return
+		}
+		n, _ := strconv.ParseInt(matches[2], 10, 64)
+		switch matches[1] {
+		case "read":
+			r.ReadQueries = n
+		case "write":
+			r.WriteQueries = n
+		case "other":
+			r.OtherQueries = n
+		case "ignored errors":
+			r.IgnoredErrors = n
+		case "reconnects":
+			r.Reconnects = n
+		case "total number of events":
+			r.TotalEvents = n
+		}
+	case sysbenchSectionLatency:
+		// min: 8.42, 95th percentile: 13.70, sum: 239982.82
+		matches := sysbenchLatencyPattern.FindStringSubmatch(line)
+		if matches == nil {
+			return
+		}
+		v, _ := strconv.ParseFloat(matches[2], 64)
+		switch matches[1] {
+		case "min":
+			r.LatencyMin = v
+		case "avg":
+			r.LatencyAvg = v
+		case "max":
+			r.LatencyMax = v
+		case "sum":
+			r.LatencySum = v
+		case "95th percentile":
+			r.LatencyP95 = v
+		case "99th percentile":
+			r.LatencyP99 = v
+		}
+	case sysbenchSectionFairness:
+		// events (avg/stddev): 5116.5000/4.15, execution time (avg/stddev): 59.9957/0.00
+		matches := sysbenchFairnessPattern.FindStringSubmatch(line)
+		if matches == nil {
+			return
+		}
+		avg, _ := strconv.ParseFloat(matches[2], 64)
+		stddev, _ := strconv.ParseFloat(matches[3], 64)
+		if matches[1] == "events" {
+			r.EventsAvg, r.EventsStddev = avg, stddev
+		} else {
+			r.ExecTimeAvg, r.ExecTimeStddev = avg, stddev
 		}
 	}
+}
 
+// Result implements SummaryParser.
+func (p *sysbenchSummaryParser) Result() (*FinalResult, error) {
+	result := p.result
 	slog.Info("SysbenchAdapter: Parsed final results",
 		"total_transactions", result.TotalTransactions,
 		"tps", result.TransactionsPerSec,
@@ -545,7 +602,7 @@ func (a *SysbenchAdapter) ParseFinalResults(ctx context.Context, stdout string)
 		"latency_avg", result.LatencyAvg,
 		"latency_p95", result.LatencyP95)
 
-	return result, nil
+	return &result, nil
 }
 
 // ValidateConfig validates the configuration for sysbench.
@@ -566,6 +623,16 @@ func (a *SysbenchAdapter) ValidateConfig(ctx context.Context, config *Config) er
 		return fmt.Errorf("template is required")
 	}
 
+	// The templates use the options of sysbench 1.0
+	if err := a.compat(ctx, config).require(featureSysbench1); err != nil {
+		return err
+	}
+	if IsSysbenchTPCC(config.Template) {
+		if err := validateTPCC(config); err != nil {
+			return err
+		}
+	}
+
 	// Detect execution phase from options
 	// Prepare-only mode: SkipCleanup=true, time=0
 	// Cleanup-only mode: SkipPrepare=true, time=0
@@ -627,15 +694,19 @@ func (a *SysbenchAdapter) SupportsDatabase(dbType connection.DatabaseType) bool
 // Helper Methods
 // =============================================================================
 
-// getScriptName determines the sysbench script name from template.
-func (a *SysbenchAdapter) getScriptName(template *domaintemplate.Template) string {
-	// Sysbench Lua scripts are typically located in /usr/share/sysbench/
-	// Return full path for reliability
-	const sysbenchScriptPath = "/usr/share/sysbench"
+// sysbenchScriptPath is where the Lua scripts of sysbench are typically installed.
+const sysbenchScriptPath = "/usr/share/sysbench"
 
+// ScriptName determines the sysbench script path from template.
+// Return full path for reliability; sysbenchCompat reduces it to the name
+// for versions that find their bundled scripts themselves.
+func (a *SysbenchAdapter) ScriptName(template *domaintemplate.Template) string {
 	if template == nil {
 		return filepath.Join(sysbenchScriptPath, "oltp_read_write.lua") // Default fallback
 	}
+	if IsSysbenchTPCC(template) {
+		return filepath.Join(tpccScriptDir(&Config{Template: template}), "tpcc.lua")
+	}
 
 	// Extract script name from template ID
 	// Template IDs are like: "sysbench-oltp-read-write", "sysbench-oltp-read-only", etc.
@@ -685,7 +756,7 @@ func (a *SysbenchAdapter) buildConnectionString(conn connection.Connection, dbTy
 }
 
 // buildConnectionArgs builds connection-specific command line arguments.
-func (a *SysbenchAdapter) buildConnectionArgs(conn connection.Connection, config *Config) []string {
+func (a *SysbenchAdapter) buildConnectionArgs(conn connection.Connection, config *Config, compat sysbenchCompat) []string {
 	var args []string
 
 	switch c := conn.(type) {
@@ -708,9 +779,7 @@ func (a *SysbenchAdapter) buildConnectionArgs(conn connection.Connection, config
 			// Password is set via environment variable for security
 			fmt.Sprintf("--mysql-db=%s", dbName),
 		)
-		if c.SSLMode != "" && c.SSLMode != "disabled" {
-			args = append(args, "--mysql-ssl=ON")
-		}
+		args = append(args, mysqlSSLArgs(c, compat)...)
 
 	case *connection.PostgreSQLConnection:
 		// Get database name from connection or parameters
@@ -739,6 +808,63 @@ func (a *SysbenchAdapter) buildConnectionArgs(conn connection.Connection, config
 	return args
 }
 
+// mysqlSSLArgs returns the sysbench SSL arguments of a MySQL connection.
+// sysbench 1.1 takes the SSL mode itself. sysbench 1.0 only switches SSL on
+// or off, so the verifying modes pass the CA file without enforcing the
+// verification; the connection test of the application verifies the server.
+func mysqlSSLArgs(c *connection.MySQLConnection, compat sysbenchCompat) []string {
+	switch mode := c.EffectiveSSLMode(); mode {
+	case "":
+		if c.SSLMode != "" {
+			// Legacy "disable", which earlier versions ran with SSL
+			return []string{compat.mysqlSSLArg(connection.MySQLSSLRequired)}
+		}
+		return nil // Not configured: the sysbench default
+	case connection.MySQLSSLDisabled:
+		return []string{compat.mysqlSSLArg(mode)}
+	}
+
+	args := []string{compat.mysqlSSLArg(c.EffectiveSSLMode())}
+	if c.SSLCA != "" {
+		args = append(args, "--mysql-ssl-ca="+commandArg(c.SSLCA))
+	}
+	if c.SSLCert != "" {
+		args = append(args, "--mysql-ssl-cert="+commandArg(c.SSLCert), "--mysql-ssl-key="+commandArg(c.SSLKey))
+	}
+	return args
+}
+
+// commandArg escapes the characters of value that split or quote words of
+// command lines, e.g. spaces in file paths and backslashes of Windows paths.
+func commandArg(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune(" \t\\'\"`", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// scriptPath returns the script of the sysbench commands of config: that of
+// its template, in the script_dir of the task for sysbench-tpcc.
+func (a *SysbenchAdapter) scriptPath(config *Config) string {
+	if IsSysbenchTPCC(config.Template) {
+		return filepath.Join(tpccScriptDir(config), "tpcc.lua")
+	}
+	return a.ScriptName(config.Template)
+}
+
+// commandEnv returns the environment of the sysbench commands of config.
+func (a *SysbenchAdapter) commandEnv(config *Config) []string {
+	env := a.buildEnvVars(config.Connection)
+	if IsSysbenchTPCC(config.Template) {
+		env = append(env, tpccEnv(config)...)
+	}
+	return env
+}
+
 // buildEnvVars builds environment variables for the command.
 func (a *SysbenchAdapter) buildEnvVars(conn connection.Connection) []string {
 	var env []string
@@ -753,6 +879,9 @@ func (a *SysbenchAdapter) buildEnvVars(conn connection.Connection) []string {
 		if c.Password != "" {
 			env = append(env, fmt.Sprintf("PGPASSWORD=%s", c.Password))
 		}
+		// sysbench has no --pgsql-* options for the additional options,
+		// its libpq reads them from the environment
+		env = append(env, c.OptionsEnv()...)
 	}
 
 	return env
@@ -797,3 +926,13 @@ func (a *SysbenchAdapter) ParseIntermediateOutput(line string) *Sample {
 
 	return sample
 }
+
+// reportIntervalSeconds converts a sample interval to sysbench's --report-interval
+// value in whole seconds (minimum 1).
+func reportIntervalSeconds(interval time.Duration) int {
+	seconds := int(interval / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
@@ -3,10 +3,13 @@ package adapter
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/template"
 )
 
@@ -140,6 +143,131 @@ func TestSysbenchAdapter_BuildRunCommand(t *testing.T) {
 	}
 }
 
+// TestSysbenchAdapter_MySQLSSL tests passing the SSL mode and certificate
+// files of MySQL connections to sysbench.
+func TestSysbenchAdapter_MySQLSSL(t *testing.T) {
+	tests := []struct {
+		name string
+		conn *connection.MySQLConnection
+		want []string
+	}{
+		{name: "unset", conn: &connection.MySQLConnection{}},
+		{name: "legacy disable", conn: &connection.MySQLConnection{SSLMode: "disable"}, want: []string{"--mysql-ssl=on"}},
+		{name: "disabled", conn: &connection.MySQLConnection{SSLMode: connection.MySQLSSLDisabled}, want: []string{"--mysql-ssl=off"}},
+		{name: "required", conn: &connection.MySQLConnection{SSLMode: connection.MySQLSSLRequired}, want: []string{"--mysql-ssl=on"}},
+		{
+			name: "verify-ca with client certificate",
+			conn: &connection.MySQLConnection{
+				SSLMode: connection.MySQLSSLVerifyCA,
+				SSLCA:   "/etc/mysql/ca.pem",
+				SSLCert: "/etc/mysql/client cert.pem",
+				SSLKey:  `C:\certs\client-key.pem`,
+			},
+			want: []string{"--mysql-ssl=on", "--mysql-ssl-ca=/etc/mysql/ca.pem",
+				`--mysql-ssl-cert=/etc/mysql/client\ cert.pem`, `--mysql-ssl-key=C:\\certs\\client-key.pem`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mysqlSSLArgs(tt.conn, sysbenchCompat{}); !slices.Equal(got, tt.want) {
+				t.Errorf("mysqlSSLArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSysbenchCompat tests translating the options of the templates to the
+// installed sysbench version.
+func TestSysbenchCompat(t *testing.T) {
+	ctx := context.Background()
+	conn := &connection.MySQLConnection{
+		Host: "db-1", Port: 3306, Username: "bench",
+		SSLMode: connection.MySQLSSLVerifyCA, SSLCA: "/etc/mysql/ca.pem",
+	}
+	config := &Config{
+		Connection: conn,
+		Template:   &template.Template{ID: "sysbench-oltp-read-only"},
+		Parameters: map[string]interface{}{"threads": 8, "time": 60, "tables": 10},
+	}
+	withVersion := func(version string) *SysbenchAdapter {
+		a := NewSysbenchAdapter()
+		a.version = &sysbenchVersion{detect: func(context.Context) (string, error) { return version, nil }}
+		return a
+	}
+
+	tests := []struct {
+		version    string
+		wantScript string
+		wantSSL    string
+	}{
+		{"", "/usr/share/sysbench/oltp_read_only.lua", "--mysql-ssl=on"},
+		{"1.0.20", " oltp_read_only ", "--mysql-ssl=on"},
+		{"1.1.0-df89d34", " oltp_read_only ", "--mysql-ssl=VERIFY_CA"},
+	}
+	for _, tt := range tests {
+		cmd, err := withVersion(tt.version).BuildRunCommand(ctx, config)
+		if err != nil {
+			t.Fatalf("BuildRunCommand(%q) failed: %v", tt.version, err)
+		}
+		if !strings.Contains(cmd.CmdLine, tt.wantScript) || !strings.Contains(cmd.CmdLine, tt.wantSSL+" ") {
+			t.Errorf("sysbench %q: CmdLine = %s, want script %q and %s", tt.version, cmd.CmdLine, tt.wantScript, tt.wantSSL)
+		}
+	}
+
+	// Remote hosts may run another version, so they get the options of 1.0
+	config.Options.Agent = "lg-1"
+	if cmd, _ := withVersion("1.1.0").BuildRunCommand(ctx, config); !strings.Contains(cmd.CmdLine, "--mysql-ssl=on") {
+		t.Errorf("remote CmdLine = %s, want the options of sysbench 1.0", cmd.CmdLine)
+	}
+	config.Options.Agent = ""
+
+	err := withVersion("0.5").ValidateConfig(ctx, config)
+	if err == nil || !strings.Contains(err.Error(), "sysbench 0.5 is installed") {
+		t.Errorf("ValidateConfig() with sysbench 0.5 error = %v, want the installed and needed versions", err)
+	}
+	if err := withVersion("1.0.20").ValidateConfig(ctx, config); err != nil {
+		t.Errorf("ValidateConfig() with sysbench 1.0.20 error = %v", err)
+	}
+}
+
+// TestSysbenchAdapter_BuildRunCommand_SampleInterval tests that the sample interval
+// is passed to sysbench as --report-interval.
+func TestSysbenchAdapter_BuildRunCommand_SampleInterval(t *testing.T) {
+	ctx := context.Background()
+	adapter := NewSysbenchAdapter()
+
+	conn := &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{
+			ID:   "test-conn",
+			Name: "Test MySQL",
+		},
+		Host:     "localhost",
+		Port:     3306,
+		Database: "testdb",
+		Username: "root",
+	}
+
+	config := &Config{
+		Connection: conn,
+		Parameters: map[string]interface{}{
+			"threads": 8,
+			"time":    7200,
+		},
+		Options: execution.TaskOptions{SampleInterval: 30 * time.Second},
+		WorkDir: "/tmp/work",
+	}
+
+	cmd, err := adapter.BuildRunCommand(ctx, config)
+	if err != nil {
+		t.Fatalf("BuildRunCommand() failed: %v", err)
+	}
+
+	if !strings.Contains(cmd.CmdLine, "--report-interval=30") {
+		t.Errorf("CmdLine should contain '--report-interval=30', got: %s", cmd.CmdLine)
+	}
+}
+
 // TestSysbenchAdapter_BuildCleanupCommand tests cleanup command building.
 func TestSysbenchAdapter_BuildCleanupCommand(t *testing.T) {
 	ctx := context.Background()
@@ -432,6 +560,18 @@ func TestSysbenchAdapter_PostgreSQL(t *testing.T) {
 	if !strings.Contains(cmd.CmdLine, "--pgsql-port=5432") {
 		t.Errorf("CmdLine should contain '--pgsql-port=5432', got: %s", cmd.CmdLine)
 	}
+
+	// Additional options are passed as libpq environment variables
+	conn.Options = map[string]string{"connect_timeout": "10", "application_name": "bench"}
+	cmd, err = adapter.BuildRunCommand(ctx, config)
+	if err != nil {
+		t.Fatalf("BuildRunCommand() failed: %v", err)
+	}
+	for _, want := range []string{"PGPASSWORD=password", "PGAPPNAME=bench", "PGCONNECT_TIMEOUT=10"} {
+		if !slices.Contains(cmd.Env, want) {
+			t.Errorf("Env = %v, missing %s", cmd.Env, want)
+		}
+	}
 }
 
 // TestSysbenchAdapter_ParseIntermediateOutput tests intermediate output parsing.
@@ -449,3 +589,71 @@ func TestSysbenchAdapter_ParseIntermediateOutput(t *testing.T) {
 		t.Errorf("LatencyAvg = %v, want 6.45", sample.LatencyAvg)
 	}
 }
+
+// sysbenchRunOutput is the output of a short sysbench run.
+const sysbenchRunOutput = `Running the test with following options:
+Number of threads: 8
+Report intermediate results every 1 second(s)
+
+[ 1s ] thds: 8 tps: 340.00 qps: 6800.00 (r/w/o: 4760.00/1360.00/680.00) lat (ms,95%): 13.70 err/s: 0.00 reconn/s: 0.00
+[ 2s ] thds: 8 tps: 342.00 qps: 6840.00 (r/w/o: 4788.00/1368.00/684.00) lat (ms,95%): 13.46 err/s: 0.00 reconn/s: 0.00
+SQL statistics:
+    queries performed:
+        read:                            286524
+        write:                           81864
+        other:                           40932
+        total:                           409320
+    transactions:                        20466  (340.98 per sec.)
+    queries:                             409320 (6819.55 per sec.)
+    ignored errors:                      3      (0.05 per sec.)
+    reconnects:                          0      (0.00 per sec.)
+
+General statistics:
+    total time:                          60.0202s
+    total number of events:              20466
+
+Latency (ms):
+         min:                                    8.42
+         avg:                                   11.73
+         max:                                   31.18
+         95th percentile:                       13.70
+         sum:                               239982.82
+
+Threads fairness:
+    events (avg/stddev):           2558.2500/4.15
+    execution time (avg/stddev):   59.9957/0.00
+`
+
+// TestSysbenchAdapter_FinalResults tests that the summary is parsed while the
+// output is collected, the same as by ParseFinalResults.
+func TestSysbenchAdapter_FinalResults(t *testing.T) {
+	ctx := context.Background()
+	adapter := NewSysbenchAdapter()
+
+	samples, errs, output := adapter.StartRealtimeCollection(ctx, strings.NewReader(sysbenchRunOutput))
+	for range samples {
+	}
+	for range errs {
+	}
+	streamed, err := output.FinalResults(ctx, adapter)
+	if err != nil {
+		t.Fatalf("FinalResults() error = %v", err)
+	}
+
+	want := FinalResult{
+		TotalTransactions: 20466, TransactionsPerSec: 340.98,
+		TotalQueries: 409320, QueriesPerSec: 6819.55,
+		ReadQueries: 286524, WriteQueries: 81864, OtherQueries: 40932,
+		IgnoredErrors: 3, TotalTime: 60.0202, TotalEvents: 20466,
+		LatencyMin: 8.42, LatencyAvg: 11.73, LatencyMax: 31.18, LatencyP95: 13.70, LatencySum: 239982.82,
+		EventsAvg: 2558.25, EventsStddev: 4.15, ExecTimeAvg: 59.9957,
+	}
+	if *streamed != want {
+		t.Errorf("FinalResults() = %+v, want %+v", *streamed, want)
+	}
+
+	parsed, err := adapter.ParseFinalResults(ctx, sysbenchRunOutput)
+	if err != nil || *parsed != want {
+		t.Errorf("ParseFinalResults() = %+v, %v, want %+v", parsed, err, want)
+	}
+}
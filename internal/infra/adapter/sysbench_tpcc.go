@@ -0,0 +1,106 @@
+package adapter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	domaintemplate "github.com/whhaicheng/DB-BenchMind/internal/domain/template"
+)
+
+// DefaultSysbenchTPCCDir is where the Percona sysbench-tpcc scripts
+// (https://github.com/Percona-Lab/sysbench-tpcc) are expected, unless the
+// script_dir parameter of the template names another directory.
+const DefaultSysbenchTPCCDir = "/opt/benchtools/sysbench-tpcc"
+
+// sysbenchTPCCPrefix starts the IDs of the templates that run sysbench-tpcc.
+const sysbenchTPCCPrefix = "sysbench-tpcc"
+
+// tpccNewOrderShare is the share of new-order transactions in the mix of
+// tpcc.lua, which picks new-order for 10 of its 23 transaction types.
+const tpccNewOrderShare = 10.0 / 23
+
+// IsSysbenchTPCC reports whether a template runs the TPC-C workload of the
+// sysbench-tpcc scripts rather than a bundled sysbench script.
+func IsSysbenchTPCC(tmpl *domaintemplate.Template) bool {
+	return tmpl != nil && strings.HasPrefix(tmpl.ID, sysbenchTPCCPrefix)
+}
+
+// TPCCTpmC returns the tpmC equivalent of the transaction rate of a
+// sysbench-tpcc run: its new-order transactions per minute. sysbench reports
+// all transactions together, so the rate is split by the mix of tpcc.lua.
+func TPCCTpmC(tps float64) float64 {
+	return tps * 60 * tpccNewOrderShare
+}
+
+// tpccScriptDir returns the directory of the sysbench-tpcc scripts: the
+// script_dir parameter of the task, or else of the template.
+func tpccScriptDir(config *Config) string {
+	if dir, ok := config.Parameters["script_dir"].(string); ok && dir != "" {
+		return dir
+	}
+	if config.Template != nil {
+		if dir, ok := config.Template.Parameters["script_dir"].Default.(string); ok && dir != "" {
+			return dir
+		}
+	}
+	return DefaultSysbenchTPCCDir
+}
+
+// tpccEnv returns the environment sysbench needs to run tpcc.lua: the
+// script directory in LUA_PATH, where it finds the tpcc_*.lua modules.
+func tpccEnv(config *Config) []string {
+	return []string{fmt.Sprintf("LUA_PATH=%s;;", filepath.Join(tpccScriptDir(config), "?.lua"))}
+}
+
+// tpccIntParam returns an integer parameter of the task, or else the default
+// of the template, which is a float64 when read from JSON.
+func tpccIntParam(config *Config, name string) (int, bool) {
+	if value, ok := config.Parameters[name].(int); ok {
+		return value, true
+	}
+	if config.Template == nil {
+		return 0, false
+	}
+	switch value := config.Template.Parameters[name].Default.(type) {
+	case int:
+		return value, true
+	case float64:
+		return int(value), true
+	}
+	return 0, false
+}
+
+// tpccArgs returns the workload options of tpcc.lua for a phase: the number
+// of table sets and of warehouses in each, and the loader threads of prepare.
+// Unlike the bundled scripts, tpcc.lua has no --table-size.
+func tpccArgs(config *Config, phase string) []string {
+	var args []string
+	if tables, ok := tpccIntParam(config, "tables"); ok {
+		args = append(args, fmt.Sprintf("--tables=%d", tables))
+	}
+	if phase == "cleanup" {
+		return args
+	}
+	if warehouses, ok := tpccIntParam(config, "warehouses"); ok {
+		args = append(args, fmt.Sprintf("--scale=%d", warehouses))
+	}
+	if threads, ok := config.Parameters["threads"].(int); ok && phase == "prepare" {
+		args = append(args, fmt.Sprintf("--threads=%d", threads))
+	}
+	return args
+}
+
+// validateTPCC checks that the sysbench-tpcc scripts are installed. The
+// scripts of remote hosts cannot be checked.
+func validateTPCC(config *Config) error {
+	if config.Options.Remote() {
+		return nil
+	}
+	dir := tpccScriptDir(config)
+	if _, err := os.Stat(filepath.Join(dir, "tpcc.lua")); err != nil {
+		return fmt.Errorf("sysbench-tpcc scripts not found in %s: clone https://github.com/Percona-Lab/sysbench-tpcc there or set script_dir", dir)
+	}
+	return nil
+}
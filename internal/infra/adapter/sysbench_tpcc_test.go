@@ -0,0 +1,150 @@
+package adapter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/template"
+)
+
+// newTPCCConfig returns the config of a sysbench-tpcc task whose template
+// keeps the scripts in scriptDir.
+func newTPCCConfig(scriptDir string) *Config {
+	return &Config{
+		Connection: &connection.MySQLConnection{
+			BaseConnection: connection.BaseConnection{ID: "test", Name: "Test"},
+			Host:           "localhost",
+			Port:           3306,
+			Database:       "tpcc",
+			Username:       "root",
+		},
+		Template: &template.Template{
+			ID:            "sysbench-tpcc",
+			Name:          "Sysbench TPC-C",
+			Tool:          "sysbench",
+			DatabaseTypes: []string{"mysql", "postgresql"},
+			Parameters: map[string]template.Parameter{
+				"tables":     {Type: template.ParameterTypeInteger, Label: "Tables", Default: float64(1)},
+				"warehouses": {Type: template.ParameterTypeInteger, Label: "Warehouses", Default: float64(10)},
+				"script_dir": {Type: template.ParameterTypeString, Label: "Scripts", Default: scriptDir},
+			},
+			CommandTemplate: template.CommandTemplate{Run: "run"},
+			OutputParser:    template.OutputParser{Type: template.ParserTypeRegex},
+		},
+		Parameters: map[string]interface{}{
+			"threads":    4,
+			"time":       60,
+			"tables":     2,
+			"table_size": 10000,
+		},
+		WorkDir: "/tmp/work",
+	}
+}
+
+// TestSysbenchAdapter_TPCCCommands tests the commands of sysbench-tpcc templates.
+func TestSysbenchAdapter_TPCCCommands(t *testing.T) {
+	ctx := context.Background()
+	adapter := NewSysbenchAdapter()
+	config := newTPCCConfig("/opt/tpcc")
+
+	prepare, err := adapter.BuildPrepareCommand(ctx, config)
+	if err != nil {
+		t.Fatalf("BuildPrepareCommand() failed: %v", err)
+	}
+	run, err := adapter.BuildRunCommand(ctx, config)
+	if err != nil {
+		t.Fatalf("BuildRunCommand() failed: %v", err)
+	}
+	cleanup, err := adapter.BuildCleanupCommand(ctx, config)
+	if err != nil {
+		t.Fatalf("BuildCleanupCommand() failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		cmd     *Command
+		want    []string
+		notWant []string
+	}{
+		{"prepare", prepare, []string{"/opt/tpcc/tpcc.lua", "--tables=2", "--scale=10", "--threads=4", "prepare"}, []string{"--table-size"}},
+		{"run", run, []string{"/opt/tpcc/tpcc.lua", "--tables=2", "--scale=10", "--threads=4", "--time=60", "run"}, []string{"--table-size"}},
+		{"cleanup", cleanup, []string{"/opt/tpcc/tpcc.lua", "--tables=2", "cleanup"}, []string{"--scale", "--threads"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, want := range tt.want {
+				if !strings.Contains(tt.cmd.CmdLine, want) {
+					t.Errorf("CmdLine should contain %q, got: %s", want, tt.cmd.CmdLine)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(tt.cmd.CmdLine, notWant) {
+					t.Errorf("CmdLine should not contain %q, got: %s", notWant, tt.cmd.CmdLine)
+				}
+			}
+			if !slices.Contains(tt.cmd.Env, "LUA_PATH=/opt/tpcc/?.lua;;") {
+				t.Errorf("Env should set LUA_PATH to the script directory, got: %v", tt.cmd.Env)
+			}
+		})
+	}
+
+	// The task's script_dir overrides the template's
+	config.Parameters["script_dir"] = "/srv/tpcc"
+	if got := adapter.scriptPath(config); got != "/srv/tpcc/tpcc.lua" {
+		t.Errorf("scriptPath() = %q, want /srv/tpcc/tpcc.lua", got)
+	}
+}
+
+// TestSysbenchAdapter_TPCCDefaults tests that the template defaults apply when
+// the task leaves out the workload parameters.
+func TestSysbenchAdapter_TPCCDefaults(t *testing.T) {
+	config := newTPCCConfig("/opt/tpcc")
+	config.Parameters = map[string]interface{}{"threads": 8}
+
+	if got, want := tpccArgs(config, "prepare"), []string{"--tables=1", "--scale=10", "--threads=8"}; !slices.Equal(got, want) {
+		t.Errorf("tpccArgs(prepare) = %v, want %v", got, want)
+	}
+	if got, want := tpccArgs(config, "run"), []string{"--tables=1", "--scale=10"}; !slices.Equal(got, want) {
+		t.Errorf("tpccArgs(run) = %v, want %v", got, want)
+	}
+
+	config.Template.Parameters = nil
+	if got := tpccScriptDir(config); got != DefaultSysbenchTPCCDir {
+		t.Errorf("tpccScriptDir() = %q, want %q", got, DefaultSysbenchTPCCDir)
+	}
+}
+
+// TestSysbenchAdapter_ValidateTPCC tests that the sysbench-tpcc scripts must be installed.
+func TestSysbenchAdapter_ValidateTPCC(t *testing.T) {
+	ctx := context.Background()
+	adapter := NewSysbenchAdapter()
+	dir := t.TempDir()
+
+	err := adapter.ValidateConfig(ctx, newTPCCConfig(dir))
+	if err == nil || !strings.Contains(err.Error(), "sysbench-tpcc") {
+		t.Fatalf("ValidateConfig() without tpcc.lua = %v, want a sysbench-tpcc error", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "tpcc.lua"), []byte("-- tpcc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := adapter.ValidateConfig(ctx, newTPCCConfig(dir)); err != nil {
+		t.Errorf("ValidateConfig() with tpcc.lua failed: %v", err)
+	}
+}
+
+// TestTPCCTpmC tests the tpmC equivalent of the transaction rate.
+func TestTPCCTpmC(t *testing.T) {
+	// 23 transactions per second, 10 of 23 of them new-order
+	if got := TPCCTpmC(23); got != 600 {
+		t.Errorf("TPCCTpmC(23) = %v, want 600", got)
+	}
+	if !IsSysbenchTPCC(&template.Template{ID: "sysbench-tpcc"}) || IsSysbenchTPCC(&template.Template{ID: "sysbench-mysql-test"}) || IsSysbenchTPCC(nil) {
+		t.Error("IsSysbenchTPCC() should match only the sysbench-tpcc templates")
+	}
+}
@@ -0,0 +1,126 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/tool"
+)
+
+// sysbenchFeature is an option or behavior of sysbench that not every
+// version supports.
+type sysbenchFeature struct {
+	name       string // What the feature is, for error messages
+	minVersion string // Oldest version that supports it
+}
+
+var (
+	// featureSysbench1 covers the oltp_* scripts and the --threads, --time,
+	// --tables and --rate options. sysbench 0.x names them --test=oltp.lua,
+	// --num-threads, --max-time, --oltp-tables-count and --tx-rate.
+	featureSysbench1 = sysbenchFeature{name: "the oltp_* workloads and the --threads/--time/--tables options", minVersion: "1.0"}
+
+	// featureBundledScripts runs the scripts installed with sysbench by name,
+	// wherever the installation put them.
+	featureBundledScripts = sysbenchFeature{name: "running bundled scripts by name", minVersion: "1.0"}
+
+	// featureMySQLSSLModes passes the SSL mode of the connection to
+	// --mysql-ssl; sysbench 1.0 only accepts on and off.
+	featureMySQLSSLModes = sysbenchFeature{name: "--mysql-ssl with an SSL mode", minVersion: "1.1"}
+)
+
+// sysbenchVersion detects the installed sysbench version once.
+type sysbenchVersion struct {
+	detect  func(ctx context.Context) (string, error)
+	once    sync.Once
+	version string
+}
+
+// newSysbenchVersion creates a detector of the version of the sysbench at path.
+func newSysbenchVersion(path string) *sysbenchVersion {
+	return &sysbenchVersion{detect: func(ctx context.Context) (string, error) {
+		return tool.NewDetector().GetToolVersionAt(ctx, config.ToolTypeSysbench, path)
+	}}
+}
+
+// get returns the installed version, or "" if it cannot be detected.
+func (v *sysbenchVersion) get(ctx context.Context) string {
+	if v == nil {
+		return ""
+	}
+	v.once.Do(func() {
+		version, err := v.detect(ctx)
+		if err != nil {
+			slog.Warn("SysbenchAdapter: Cannot detect the sysbench version, using the options of 1.0", "error", err)
+			return
+		}
+		slog.Info("SysbenchAdapter: Detected sysbench version", "version", version)
+		v.version = version
+	})
+	return v.version
+}
+
+// sysbenchCompat translates the options of the templates to the installed
+// sysbench version. An unknown version, e.g. that of remote hosts, gets the
+// options of sysbench 1.0, which every supported version accepts.
+type sysbenchCompat struct {
+	version string
+}
+
+// compat returns the compatibility layer for the sysbench a run uses.
+// Remote runs use the sysbench of the remote host, whose version is not known.
+func (a *SysbenchAdapter) compat(ctx context.Context, config *Config) sysbenchCompat {
+	if config != nil && config.Options.Remote() {
+		return sysbenchCompat{}
+	}
+	return sysbenchCompat{version: a.version.get(ctx)}
+}
+
+// supports reports whether the installed version supports feature.
+func (c sysbenchCompat) supports(feature sysbenchFeature) bool {
+	version := c.version
+	if version == "" {
+		version = config.ToolTypeSysbench.MinVersion()
+	}
+	return config.CompareVersions(version, feature.minVersion) >= 0
+}
+
+// require returns an error naming the installed and the needed version if
+// feature is not supported.
+func (c sysbenchCompat) require(feature sysbenchFeature) error {
+	if c.supports(feature) {
+		return nil
+	}
+	return fmt.Errorf("sysbench %s is installed, but %s needs sysbench %s or later", c.version, feature.name, feature.minVersion)
+}
+
+// script returns the script argument of a sysbench command. sysbench 1.0
+// finds its bundled scripts by name, without the .lua extension, wherever
+// they are installed (e.g. under /opt/homebrew/share/sysbench), so a path to
+// a bundled script is reduced to the name unless the version is unknown.
+func (c sysbenchCompat) script(path string) string {
+	if c.version == "" || !c.supports(featureBundledScripts) || filepath.Dir(path) != filepath.Clean(sysbenchScriptPath) {
+		return path
+	}
+	return strings.TrimSuffix(filepath.Base(path), ".lua")
+}
+
+// mysqlSSLArg returns the --mysql-ssl argument for an SSL mode of a MySQL
+// connection: the mode itself for versions that take one, e.g.
+// "--mysql-ssl=VERIFY_CA" for "verify-ca", otherwise on, or off if disabled.
+func (c sysbenchCompat) mysqlSSLArg(mode string) string {
+	switch {
+	case c.supports(featureMySQLSSLModes):
+		return "--mysql-ssl=" + strings.ToUpper(strings.ReplaceAll(mode, "-", "_"))
+	case mode == connection.MySQLSSLDisabled:
+		return "--mysql-ssl=off"
+	default:
+		return "--mysql-ssl=on"
+	}
+}
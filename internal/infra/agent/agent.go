@@ -0,0 +1,88 @@
+// Package agent runs benchmark tools on load-generator hosts.
+// An agent is a small SSH server (db-benchmind agent --listen) that executes
+// the prepare/run/cleanup commands it receives and streams their output back,
+// so the load is generated next to the database instead of on the GUI machine.
+// Controllers authenticate with an ed25519 key listed in the agent's authorized
+// keys file; agents are identified by the SHA256 fingerprint of their host key.
+package agent
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	// ErrHostKeyMismatch is returned when an agent presents a host key other
+	// than the configured one.
+	ErrHostKeyMismatch = errors.New("agent host key does not match")
+)
+
+// User is the SSH user name controllers log in with.
+const User = "db-benchmind"
+
+// Request operations.
+const (
+	OpRun      = "run"      // Run Args with Env and Files; stdin, stdout and stderr are streamed
+	OpLookPath = "lookpath" // Print the path of the executable Args[0]
+)
+
+// Request is the payload of an exec request on an agent session.
+type Request struct {
+	Op   string   `json:"op"`
+	Args []string `json:"args"`
+	Env  []string `json:"env,omitempty"`
+	// Files are credential files by name, created in the work directory of
+	// the command readable only by the agent user (e.g. a Swingbench config
+	// holding the password).
+	Files map[string]string `json:"files,omitempty"`
+}
+
+// LoadOrCreateKey loads the ed25519 private key at path, creating it with
+// mode 0600 if it does not exist.
+func LoadOrCreateKey(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		signer, err := ssh.ParsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse key %s: %w", path, err)
+		}
+		return signer, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read key: %w", err)
+	}
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+	block, err := ssh.MarshalPrivateKey(priv, "db-benchmind")
+	if err != nil {
+		return nil, fmt.Errorf("marshal key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("create key directory: %w", err)
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+		return nil, fmt.Errorf("write key: %w", err)
+	}
+	return ssh.NewSignerFromKey(priv)
+}
+
+// AuthorizedKey returns the public key of signer as an authorized_keys line.
+func AuthorizedKey(signer ssh.Signer) string {
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey()))) + " db-benchmind"
+}
+
+// Fingerprint returns the SHA256 fingerprint of a key, e.g. SHA256:2sVf...
+func Fingerprint(key ssh.PublicKey) string {
+	return ssh.FingerprintSHA256(key)
+}
@@ -0,0 +1,172 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// startAgent starts an agent that authorizes the returned controller key.
+func startAgent(t *testing.T) (*Server, string, ssh.Signer) {
+	t.Helper()
+	dir := t.TempDir()
+	controllerKey, err := LoadOrCreateKey(filepath.Join(dir, "controller_key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorizedKeys := filepath.Join(dir, "authorized_keys")
+	if err := os.WriteFile(authorizedKeys, []byte(AuthorizedKey(controllerKey)+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	server, err := NewServer(ServerConfig{
+		HostKeyPath:        filepath.Join(dir, "host_key"),
+		AuthorizedKeysPath: authorizedKeys,
+		WorkDir:            dir,
+	})
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(ctx, l) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Serve() error = %v", err)
+		}
+	})
+	return server, l.Addr().String(), controllerKey
+}
+
+// TestLoadOrCreateKey tests that a created key is loaded again.
+func TestLoadOrCreateKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys", "id_ed25519")
+	created, err := LoadOrCreateKey(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateKey() error = %v", err)
+	}
+	loaded, err := LoadOrCreateKey(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateKey() error = %v", err)
+	}
+	if Fingerprint(created.PublicKey()) != Fingerprint(loaded.PublicKey()) {
+		t.Error("loaded key differs from the created key")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("key mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+// TestClient_Run tests streaming output, environment, stdin and exit codes.
+func TestClient_Run(t *testing.T) {
+	server, addr, key := startAgent(t)
+	ctx := context.Background()
+
+	client, err := Dial(ctx, addr, server.Fingerprint(), key)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer client.Close()
+
+	var stdout, stderr strings.Builder
+	script := `read line; echo "$line $BENCH_VAR"; echo oops >&2; exit 3`
+	code, err := client.Run(ctx, []string{"sh", "-c", script}, []string{"BENCH_VAR=42"}, nil, strings.NewReader("hello\n"), &stdout, &stderr)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if code != 3 {
+		t.Errorf("exit code = %d, want 3", code)
+	}
+	if stdout.String() != "hello 42\n" || stderr.String() != "oops\n" {
+		t.Errorf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+
+	path, err := client.LookPath(ctx, "sh")
+	if err != nil || !strings.HasSuffix(path, "/sh") {
+		t.Errorf("LookPath(sh) = %q, %v", path, err)
+	}
+	if _, err := client.LookPath(ctx, "no-such-benchmark-tool"); err == nil {
+		t.Error("LookPath() found a missing tool")
+	}
+}
+
+// TestClient_RunFiles tests that files are created in the work directory,
+// readable only by the agent user, and that other paths are refused.
+func TestClient_RunFiles(t *testing.T) {
+	server, addr, key := startAgent(t)
+	ctx := context.Background()
+	client, err := Dial(ctx, addr, server.Fingerprint(), key)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer client.Close()
+
+	var stdout, stderr strings.Builder
+	files := map[string]string{".credentials-swingconfig.xml": "<Password>s3cr3t</Password>"}
+	code, err := client.Run(ctx, []string{"sh", "-c", "cat .credentials-swingconfig.xml; stat -c %a .credentials-swingconfig.xml"}, nil, files, nil, &stdout, &stderr)
+	if err != nil || code != 0 {
+		t.Fatalf("Run() = %d, %v, stderr = %q", code, err, stderr.String())
+	}
+	if stdout.String() != "<Password>s3cr3t</Password>600\n" {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+
+	code, err = client.Run(ctx, []string{"true"}, nil, map[string]string{"../escape": "x"}, nil, &strings.Builder{}, &strings.Builder{})
+	if err != nil || code == 0 {
+		t.Errorf("Run() with a file outside the work directory = %d, %v, want a failure", code, err)
+	}
+}
+
+// TestClient_RunCancel tests that cancelling the context kills the remote command.
+func TestClient_RunCancel(t *testing.T) {
+	server, addr, key := startAgent(t)
+	client, err := Dial(context.Background(), addr, server.Fingerprint(), key)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.Run(ctx, []string{"sleep", "30"}, nil, nil, nil, &strings.Builder{}, &strings.Builder{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Run() error = %v, want DeadlineExceeded", err)
+	}
+	if time.Since(start) > 10*time.Second {
+		t.Error("Run() did not return after cancellation")
+	}
+}
+
+// TestDial_Rejected tests host key pinning and controller authorization.
+func TestDial_Rejected(t *testing.T) {
+	server, addr, key := startAgent(t)
+	ctx := context.Background()
+
+	if _, err := Dial(ctx, addr, "SHA256:not-the-agent", key); !errors.Is(err, ErrHostKeyMismatch) {
+		t.Errorf("Dial() with another host key error = %v, want ErrHostKeyMismatch", err)
+	}
+
+	stranger, err := LoadOrCreateKey(filepath.Join(t.TempDir(), "stranger"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Dial(ctx, addr, server.Fingerprint(), stranger); err == nil {
+		t.Error("Dial() with an unauthorized key succeeded")
+	}
+}
@@ -0,0 +1,124 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// dialTimeout bounds connecting to an agent when ctx has no deadline.
+const dialTimeout = 30 * time.Second
+
+// Client is a connection to an agent.
+type Client struct {
+	address string
+	client  *ssh.Client
+}
+
+// Dial connects to the agent at address, authenticating with signer. The
+// agent's host key must have the fingerprint hostKey.
+func Dial(ctx context.Context, address, hostKey string, signer ssh.Signer) (*Client, error) {
+	sshConfig := &ssh.ClientConfig{
+		User: User,
+		Auth: []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if got := Fingerprint(key); got != hostKey {
+				return fmt.Errorf("%w: %s presented %s, expected %s", ErrHostKeyMismatch, address, got, hostKey)
+			}
+			return nil
+		},
+		Timeout: dialTimeout,
+	}
+
+	dialer := net.Dialer{Timeout: dialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("connect to agent %s: %w", address, err)
+	}
+	conn, chans, reqs, err := ssh.NewClientConn(netConn, address, sshConfig)
+	if err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("agent %s: %w", address, err)
+	}
+
+	slog.Info("Agent: Connected", "op", "agent_connect", "address", address)
+	return &Client{address: address, client: ssh.NewClient(conn, chans, reqs)}, nil
+}
+
+// Run runs args on the agent with the extra environment variables env, after
+// creating files in its work directory. stdout and stderr are streamed to the given writers while the command runs;
+// stdin may be nil. Cancelling ctx kills the command on the agent.
+// Returns the remote exit code.
+func (c *Client) Run(ctx context.Context, args, env []string, files map[string]string, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	return c.exec(ctx, Request{Op: OpRun, Args: args, Env: env, Files: files}, stdin, stdout, stderr)
+}
+
+// LookPath resolves an executable in PATH of the agent.
+// Returns an error if the tool is not installed.
+func (c *Client) LookPath(ctx context.Context, name string) (string, error) {
+	var stdout, stderr strings.Builder
+	code, err := c.exec(ctx, Request{Op: OpLookPath, Args: []string{name}}, nil, &stdout, &stderr)
+	if err != nil {
+		return "", err
+	}
+	if code != 0 {
+		return "", fmt.Errorf("%s not found on agent %s: %s", name, c.address, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// exec sends a request on a new session and waits for its exit status.
+func (c *Client) exec(ctx context.Context, req Request, stdin io.Reader, stdout, stderr io.Writer) (int, error) {
+	session, err := c.client.NewSession()
+	if err != nil {
+		return -1, fmt.Errorf("open session: %w", err)
+	}
+	defer session.Close()
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return -1, err
+	}
+	session.Stdin = stdin
+	session.Stdout = stdout
+	session.Stderr = stderr
+	if err := session.Start(string(payload)); err != nil {
+		return -1, fmt.Errorf("start %s on agent: %w", req.Op, err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Wait()
+	}()
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		session.Signal(ssh.SIGKILL)
+		session.Close()
+		<-done
+		return -1, ctx.Err()
+	}
+
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitStatus(), nil
+	}
+	if err != nil {
+		return -1, err
+	}
+	return 0, nil
+}
+
+// Close closes the connection to the agent.
+func (c *Client) Close() error {
+	return c.client.Close()
+}
@@ -0,0 +1,257 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// ServerConfig configures an agent.
+type ServerConfig struct {
+	// HostKeyPath is the agent's private host key, created if missing.
+	HostKeyPath string
+	// AuthorizedKeysPath lists the controller keys allowed to connect, in
+	// OpenSSH authorized_keys format. It is re-read on every login.
+	AuthorizedKeysPath string
+	// WorkDir is the directory the per-command work directories are created in.
+	WorkDir string
+}
+
+// Server is a load-generator agent.
+type Server struct {
+	cfg     ServerConfig
+	hostKey ssh.Signer
+	ssh     *ssh.ServerConfig
+}
+
+// NewServer creates an agent, loading or creating its host key.
+func NewServer(cfg ServerConfig) (*Server, error) {
+	if cfg.AuthorizedKeysPath == "" {
+		return nil, errors.New("authorized keys file is required")
+	}
+	if cfg.WorkDir == "" {
+		cfg.WorkDir = os.TempDir()
+	}
+	hostKey, err := LoadOrCreateKey(cfg.HostKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("host key: %w", err)
+	}
+
+	s := &Server{cfg: cfg, hostKey: hostKey}
+	s.ssh = &ssh.ServerConfig{PublicKeyCallback: s.authorize}
+	s.ssh.AddHostKey(hostKey)
+	return s, nil
+}
+
+// Fingerprint returns the fingerprint of the agent's host key, which is
+// configured on the controller to identify the agent.
+func (s *Server) Fingerprint() string {
+	return Fingerprint(s.hostKey.PublicKey())
+}
+
+// authorize accepts controller keys listed in the authorized keys file.
+func (s *Server) authorize(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	data, err := os.ReadFile(s.cfg.AuthorizedKeysPath)
+	if err != nil {
+		return nil, fmt.Errorf("read authorized keys: %w", err)
+	}
+	for len(data) > 0 {
+		authorized, _, _, rest, err := ssh.ParseAuthorizedKey(data)
+		if err != nil {
+			break
+		}
+		if bytes.Equal(authorized.Marshal(), key.Marshal()) {
+			return nil, nil
+		}
+		data = rest
+	}
+	slog.Warn("Agent: Rejected key", "remote", meta.RemoteAddr(), "fingerprint", Fingerprint(key))
+	return nil, fmt.Errorf("key %s is not authorized", Fingerprint(key))
+}
+
+// Serve accepts controller connections on l until ctx is cancelled.
+func (s *Server) Serve(ctx context.Context, l net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("accept: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.handleConn(ctx, conn)
+		}()
+	}
+}
+
+// handleConn serves the sessions of one controller connection.
+func (s *Server) handleConn(ctx context.Context, netConn net.Conn) {
+	conn, chans, reqs, err := ssh.NewServerConn(netConn, s.ssh)
+	if err != nil {
+		slog.Warn("Agent: Handshake failed", "remote", netConn.RemoteAddr(), "error", err)
+		netConn.Close()
+		return
+	}
+	defer conn.Close()
+	slog.Info("Agent: Controller connected", "remote", conn.RemoteAddr())
+	go ssh.DiscardRequests(reqs)
+
+	// Closing the connection on shutdown ends the channel loop below
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-connCtx.Done()
+		conn.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for newChan := range chans {
+		if newChan.ChannelType() != "session" {
+			newChan.Reject(ssh.UnknownChannelType, "only session channels are supported")
+			continue
+		}
+		ch, chReqs, err := newChan.Accept()
+		if err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.handleSession(connCtx, ch, chReqs)
+		}()
+	}
+}
+
+// handleSession runs the command of an exec request. A signal request, or
+// the controller closing the session, kills the command.
+func (s *Server) handleSession(ctx context.Context, ch ssh.Channel, reqs <-chan *ssh.Request) {
+	defer ch.Close()
+
+	var req *Request
+	for r := range reqs {
+		if r.Type != "exec" {
+			r.Reply(false, nil)
+			continue
+		}
+		var payload struct{ Command string }
+		var parsed Request
+		if err := ssh.Unmarshal(r.Payload, &payload); err != nil || json.Unmarshal([]byte(payload.Command), &parsed) != nil || len(parsed.Args) == 0 {
+			r.Reply(false, nil)
+			continue
+		}
+		r.Reply(true, nil)
+		req = &parsed
+		break
+	}
+	if req == nil {
+		return
+	}
+
+	cmdCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		for r := range reqs {
+			if r.Type == "signal" {
+				cancel()
+			}
+			r.Reply(false, nil)
+		}
+		// The controller went away
+		cancel()
+	}()
+
+	var code int
+	switch req.Op {
+	case OpRun:
+		code = s.run(cmdCtx, req, ch)
+	case OpLookPath:
+		path, err := exec.LookPath(req.Args[0])
+		if err != nil {
+			fmt.Fprintln(ch.Stderr(), err)
+			code = 1
+		} else {
+			fmt.Fprintln(ch, path)
+		}
+	default:
+		fmt.Fprintf(ch.Stderr(), "unknown operation: %s\n", req.Op)
+		code = 2
+	}
+
+	ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{uint32(code)}))
+}
+
+// run executes a command in a fresh work directory and returns its exit code.
+func (s *Server) run(ctx context.Context, req *Request, ch ssh.Channel) int {
+	workDir, err := os.MkdirTemp(s.cfg.WorkDir, "db-benchmind-")
+	if err != nil {
+		fmt.Fprintf(ch.Stderr(), "create work directory: %v\n", err)
+		return 1
+	}
+	defer os.RemoveAll(workDir)
+	for name, content := range req.Files {
+		// Files are only created in the work directory itself
+		if name != filepath.Base(name) || name == "." || name == ".." {
+			fmt.Fprintf(ch.Stderr(), "invalid file name: %q\n", name)
+			return 1
+		}
+		if err := os.WriteFile(filepath.Join(workDir, name), []byte(content), 0600); err != nil {
+			fmt.Fprintf(ch.Stderr(), "write %s: %v\n", name, err)
+			return 1
+		}
+	}
+
+	slog.Info("Agent: Running command", "binary", req.Args[0], "arguments", len(req.Args)-1, "env_count", len(req.Env), "file_count", len(req.Files))
+	cmd := exec.CommandContext(ctx, req.Args[0], req.Args[1:]...)
+	cmd.Dir = workDir
+	cmd.Env = append(os.Environ(), req.Env...)
+	cmd.Stdin = ch
+	cmd.Stdout = ch
+	cmd.Stderr = ch.Stderr()
+
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitErr) && exitErr.ExitCode() >= 0:
+		slog.Info("Agent: Command failed", "binary", req.Args[0], "exit_code", exitErr.ExitCode())
+		return exitErr.ExitCode()
+	default:
+		// Not started, or killed after a signal
+		slog.Info("Agent: Command failed", "binary", req.Args[0], "error", err)
+		io.WriteString(ch.Stderr(), err.Error()+"\n")
+		return 1
+	}
+}
+
+// ListenAndServe listens on addr and serves controllers until ctx is cancelled.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	slog.Info("Agent: Listening", "address", l.Addr(), "host_key", s.Fingerprint())
+	return s.Serve(ctx, l)
+}
@@ -0,0 +1,132 @@
+// Package appdir resolves the DB-BenchMind home directory.
+// The home directory holds the database, logs, settings, keyring files and exports,
+// so the binaries can run from any working directory.
+package appdir
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// EnvHome is the environment variable that overrides the home directory.
+const EnvHome = "DB_BENCHMIND_HOME"
+
+// appName is the directory name used under the OS config directory.
+const appName = "db-benchmind"
+
+// Source describes where the home directory came from.
+type Source string
+
+const (
+	SourceFlag    Source = "flag"    // --data-dir flag
+	SourceEnv     Source = "env"     // DB_BENCHMIND_HOME
+	SourceLegacy  Source = "legacy"  // Existing ./data directory in the working directory
+	SourceDefault Source = "default" // OS config directory (XDG_CONFIG_HOME, %AppData%, ...)
+)
+
+// Dirs is the resolved directory layout.
+type Dirs struct {
+	Home   string // Root directory (absolute)
+	Source Source // Where Home came from
+}
+
+// Resolve determines the home directory. In order of precedence:
+// the flag value, $DB_BENCHMIND_HOME, an existing ./data/db-benchmind.db in the
+// working directory (the layout used by earlier versions), and finally the
+// OS-specific user config directory.
+func Resolve(flagValue string) (Dirs, error) {
+	if flagValue != "" {
+		return newDirs(flagValue, SourceFlag)
+	}
+	if env := os.Getenv(EnvHome); env != "" {
+		return newDirs(env, SourceEnv)
+	}
+	if _, err := os.Stat(filepath.Join("data", "db-benchmind.db")); err == nil {
+		return newDirs(".", SourceLegacy)
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return Dirs{}, fmt.Errorf("determine user config directory: %w (set --data-dir or %s)", err, EnvHome)
+	}
+	return newDirs(filepath.Join(configDir, appName), SourceDefault)
+}
+
+func newDirs(home string, source Source) (Dirs, error) {
+	abs, err := filepath.Abs(home)
+	if err != nil {
+		return Dirs{}, fmt.Errorf("resolve home directory %s: %w", home, err)
+	}
+	return Dirs{Home: abs, Source: source}, nil
+}
+
+// DataDir returns the directory for the database, logs, settings and keyring files.
+func (d Dirs) DataDir() string {
+	return filepath.Join(d.Home, "data")
+}
+
+// DBPath returns the path of the SQLite database.
+func (d Dirs) DBPath() string {
+	return filepath.Join(d.DataDir(), "db-benchmind.db")
+}
+
+// LogDir returns the log directory.
+func (d Dirs) LogDir() string {
+	return filepath.Join(d.DataDir(), "logs")
+}
+
+// RunsDir returns the directory in which run artifacts are kept.
+func (d Dirs) RunsDir() string {
+	return filepath.Join(d.DataDir(), "runs")
+}
+
+// ToolsDir returns the directory portable benchmark tools are installed into.
+func (d Dirs) ToolsDir() string {
+	return filepath.Join(d.DataDir(), "tools")
+}
+
+// TemplatesDir returns the directory of the templates of users, such as SQL mixes.
+func (d Dirs) TemplatesDir() string {
+	return filepath.Join(d.DataDir(), "templates")
+}
+
+// UpdateDir returns the directory new versions are downloaded into.
+func (d Dirs) UpdateDir() string {
+	return filepath.Join(d.DataDir(), "updates")
+}
+
+// AgentKeyPath returns the key the controller authenticates to agents with.
+func (d Dirs) AgentKeyPath() string {
+	return filepath.Join(d.DataDir(), "agent_ed25519")
+}
+
+// AgentHostKeyPath returns the host key of this installation in agent mode.
+func (d Dirs) AgentHostKeyPath() string {
+	return filepath.Join(d.DataDir(), "agent_host_ed25519")
+}
+
+// AgentAuthorizedKeysPath returns the controller keys accepted in agent mode.
+func (d Dirs) AgentAuthorizedKeysPath() string {
+	return filepath.Join(d.DataDir(), "agent_authorized_keys")
+}
+
+// ConfigPath returns the path of the settings file.
+func (d Dirs) ConfigPath() string {
+	return filepath.Join(d.DataDir(), "config.json")
+}
+
+// ExportDir returns the export directory.
+func (d Dirs) ExportDir() string {
+	return filepath.Join(d.Home, "exports")
+}
+
+// Ensure creates the data, log and export directories.
+func (d Dirs) Ensure() error {
+	for _, dir := range []string{d.DataDir(), d.LogDir(), d.ExportDir()} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("create directory %s: %w", dir, err)
+		}
+	}
+	return nil
+}
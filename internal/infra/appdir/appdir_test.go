@@ -0,0 +1,71 @@
+// Package appdir provides unit tests for home directory resolution.
+package appdir
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestResolve tests the precedence of flag, environment, legacy layout and default.
+func TestResolve(t *testing.T) {
+	wd := t.TempDir()
+	t.Chdir(wd)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(wd, "xdg"))
+	t.Setenv("HOME", wd)
+	t.Setenv(EnvHome, "")
+
+	dirs, err := Resolve("")
+	if err != nil {
+		t.Fatalf("Resolve() failed: %v", err)
+	}
+	if dirs.Source != SourceDefault {
+		t.Errorf("Source = %s, want %s", dirs.Source, SourceDefault)
+	}
+
+	// An existing ./data database keeps the old layout
+	if err := os.MkdirAll("data", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("data", "db-benchmind.db"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	dirs, err = Resolve("")
+	if err != nil {
+		t.Fatalf("Resolve() failed: %v", err)
+	}
+	if dirs.Source != SourceLegacy || dirs.DBPath() != filepath.Join(wd, "data", "db-benchmind.db") {
+		t.Errorf("Resolve() = %s %s, want legacy layout in %s", dirs.Source, dirs.DBPath(), wd)
+	}
+
+	envHome := filepath.Join(wd, "env-home")
+	t.Setenv(EnvHome, envHome)
+	dirs, err = Resolve("")
+	if err != nil {
+		t.Fatalf("Resolve() failed: %v", err)
+	}
+	if dirs.Source != SourceEnv || dirs.Home != envHome {
+		t.Errorf("Resolve() = %s %s, want env %s", dirs.Source, dirs.Home, envHome)
+	}
+
+	dirs, err = Resolve("flag-home")
+	if err != nil {
+		t.Fatalf("Resolve() failed: %v", err)
+	}
+	if dirs.Source != SourceFlag || dirs.Home != filepath.Join(wd, "flag-home") {
+		t.Errorf("Resolve() = %s %s, want flag %s", dirs.Source, dirs.Home, filepath.Join(wd, "flag-home"))
+	}
+}
+
+// TestDirs_Ensure tests that the directory layout is created.
+func TestDirs_Ensure(t *testing.T) {
+	dirs := Dirs{Home: t.TempDir()}
+	if err := dirs.Ensure(); err != nil {
+		t.Fatalf("Ensure() failed: %v", err)
+	}
+	for _, dir := range []string{dirs.DataDir(), dirs.LogDir(), dirs.ExportDir()} {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			t.Errorf("%s was not created", dir)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+)
+
+// VacuumResult 数据库整理结果
+type VacuumResult struct {
+	SizeBefore int64 // 整理前文件大小（主库 + WAL，字节）
+	SizeAfter  int64 // 整理后文件大小（主库 + WAL，字节）
+}
+
+// Reclaimed 返回回收的空间（字节），不会为负数
+func (r *VacuumResult) Reclaimed() int64 {
+	if r.SizeAfter >= r.SizeBefore {
+		return 0
+	}
+	return r.SizeBefore - r.SizeAfter
+}
+
+// Vacuum 回收 SQLite 数据库空闲页
+// ctx: 上下文（支持取消）
+// db: 由 InitializeSQLite 打开的数据库连接
+// dbPath: 数据库文件路径（用于统计文件大小）
+func Vacuum(ctx context.Context, db *sql.DB, dbPath string) (*VacuumResult, error) {
+	// 1. 先把 WAL 合并回主库，保证统计的是真实占用
+	if _, err := db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return nil, fmt.Errorf("checkpoint wal: %w", err)
+	}
+
+	before, err := DatabaseSize(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. 重建数据库文件
+	if _, err := db.ExecContext(ctx, "VACUUM"); err != nil {
+		return nil, fmt.Errorf("vacuum: %w", err)
+	}
+
+	// 3. VACUUM 在 WAL 模式下会写入 WAL，再次截断
+	if _, err := db.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return nil, fmt.Errorf("checkpoint wal: %w", err)
+	}
+
+	after, err := DatabaseSize(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VacuumResult{SizeBefore: before, SizeAfter: after}, nil
+}
+
+// DatabaseSize 返回数据库主文件与 WAL 文件的总大小（字节）
+func DatabaseSize(dbPath string) (int64, error) {
+	var total int64
+	for _, path := range []string{dbPath, dbPath + "-wal"} {
+		info, err := os.Stat(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return 0, fmt.Errorf("stat %s: %w", path, err)
+		}
+		total += info.Size()
+	}
+	return total, nil
+}
+
+// Snapshot 将数据库的一致性快照写入 dest（VACUUM INTO），不阻塞其他读取
+// ctx: 上下文（支持取消）
+// db: 由 InitializeSQLite 打开的数据库连接
+// dest: 快照文件路径（必须不存在）
+func Snapshot(ctx context.Context, db *sql.DB, dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("snapshot %s: file already exists", dest)
+	}
+	if _, err := db.ExecContext(ctx, "VACUUM INTO ?", dest); err != nil {
+		return fmt.Errorf("snapshot database: %w", err)
+	}
+	return nil
+}
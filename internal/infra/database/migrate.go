@@ -0,0 +1,161 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// baselineVersion 是 schema.sql 对应的版本，迁移从其后一个版本开始
+const baselineVersion = 1
+
+// migrationFilePattern 迁移文件名：<版本>_<描述>.sql，如 0002_add_index.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.sql$`)
+
+// Migration 一个版本化的 Schema 变更
+type Migration struct {
+	Version int    // 版本号（大于 1，唯一）
+	Name    string // 描述（文件名中版本号之后的部分）
+	SQL     string // 变更语句
+}
+
+// loadMigrations 读取迁移文件，按版本升序返回
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "migrations/*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("list migrations: %w", err)
+	}
+
+	migrations := make([]Migration, 0, len(files))
+	seen := make(map[int]string)
+	for _, file := range files {
+		name := path.Base(file)
+		m := migrationFilePattern.FindStringSubmatch(name)
+		if m == nil {
+			return nil, fmt.Errorf("invalid migration file name %q (want <version>_<name>.sql)", name)
+		}
+		version, _ := strconv.Atoi(m[1])
+		if version <= baselineVersion {
+			return nil, fmt.Errorf("migration %s: version must be greater than %d", name, baselineVersion)
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s have the same version %d", other, name, version)
+		}
+		seen[version] = name
+
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", name, err)
+		}
+		migrations = append(migrations, Migration{Version: version, Name: m[2], SQL: string(data)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrate 按版本顺序执行尚未执行的迁移，每个迁移在单独的事务中执行并记录到 schema_migrations
+// ctx: 上下文（支持取消）
+// db: 已执行 schema.sql 的数据库连接
+// 返回: 本次执行的迁移或错误
+//
+// 数据库版本高于本程序已知的最高版本（由更新的版本升级过）时返回错误，避免旧版本写坏数据
+func Migrate(ctx context.Context, db *sql.DB) ([]Migration, error) {
+	return migrate(ctx, db, migrationsFS)
+}
+
+// migrate 执行 fsys 中的迁移
+func migrate(ctx context.Context, db *sql.DB, fsys fs.FS) ([]Migration, error) {
+	migrations, err := loadMigrations(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := SchemaVersion(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	latest := baselineVersion
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].Version
+	}
+	if current > latest {
+		return nil, fmt.Errorf("database schema version %d is newer than this release supports (%d); upgrade DB-BenchMind", current, latest)
+	}
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, m := range migrations {
+		if applied[m.Version] {
+			continue
+		}
+		if err := applyMigration(ctx, db, m); err != nil {
+			return done, err
+		}
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+// applyMigration 在一个事务中执行迁移并记录版本
+func applyMigration(ctx context.Context, db *sql.DB, m Migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("migration %04d_%s: begin: %w", m.Version, m.Name, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)",
+		m.Version, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return fmt.Errorf("migration %04d_%s: record version: %w", m.Version, m.Name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("migration %04d_%s: commit: %w", m.Version, m.Name, err)
+	}
+	return nil
+}
+
+// appliedVersions 返回已执行的版本
+func appliedVersions(ctx context.Context, db *sql.DB) (map[int]bool, error) {
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("query schema migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("scan schema migration: %w", err)
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// SchemaVersion 返回数据库当前的 Schema 版本（已执行的最高版本）
+func SchemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var version sql.NullInt64
+	if err := db.QueryRowContext(ctx, "SELECT MAX(version) FROM schema_migrations").Scan(&version); err != nil {
+		return 0, fmt.Errorf("query schema version: %w", err)
+	}
+	return int(version.Int64), nil
+}
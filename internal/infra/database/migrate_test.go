@@ -0,0 +1,142 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	_ "modernc.org/sqlite" // 纯 Go SQLite 驱动
+)
+
+// Test 1: 测试初始化时执行内置迁移
+func TestInitializeSQLite_Migrations(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitializeSQLite(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("InitializeSQLite failed: %v", err)
+	}
+	defer db.Close()
+
+	migrations, err := loadMigrations(migrationsFS)
+	if err != nil {
+		t.Fatalf("loadMigrations failed: %v", err)
+	}
+	version, err := SchemaVersion(ctx, db)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if want := migrations[len(migrations)-1].Version; version != want {
+		t.Errorf("Expected schema version %d, got %d", want, version)
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='index' AND name='idx_metric_samples_run_timestamp'").Scan(&count); err != nil {
+		t.Fatalf("Failed to query index: %v", err)
+	}
+	if count != 1 {
+		t.Error("Expected index idx_metric_samples_run_timestamp to exist")
+	}
+}
+
+// Test 2: 测试迁移按版本顺序执行且只执行一次
+func TestMigrate_AppliesPendingInOrder(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitializeSQLite(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("InitializeSQLite failed: %v", err)
+	}
+	defer db.Close()
+
+	fsys := fstest.MapFS{
+		"migrations/0101_add_notes.sql":  {Data: []byte("ALTER TABLE suites ADD COLUMN notes TEXT;")},
+		"migrations/0100_add_table.sql":  {Data: []byte("CREATE TABLE extra (id INTEGER PRIMARY KEY);")},
+		"migrations/0102_fill_extra.sql": {Data: []byte("INSERT INTO extra (id) VALUES (1); INSERT INTO extra (id) VALUES (2);")},
+	}
+
+	applied, err := migrate(ctx, db, fsys)
+	if err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	if len(applied) != 3 || applied[0].Version != 100 || applied[0].Name != "add_table" || applied[2].Version != 102 {
+		t.Errorf("Expected versions 100, 101, 102 in order, got %+v", applied)
+	}
+
+	applied, err = migrate(ctx, db, fsys)
+	if err != nil {
+		t.Fatalf("second migrate failed: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("Expected no migrations on second run, got %d", len(applied))
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM extra").Scan(&count); err != nil {
+		t.Fatalf("Failed to query extra: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 rows in extra, got %d", count)
+	}
+}
+
+// Test 3: 测试失败的迁移回滚且不记录版本
+func TestMigrate_FailureRollsBack(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitializeSQLite(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("InitializeSQLite failed: %v", err)
+	}
+	defer db.Close()
+
+	fsys := fstest.MapFS{
+		"migrations/0100_broken.sql": {Data: []byte("CREATE TABLE half (id INTEGER); INSERT INTO missing VALUES (1);")},
+	}
+	if _, err := migrate(ctx, db, fsys); err == nil {
+		t.Fatal("Expected broken migration to fail")
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM schema_migrations WHERE version = 100").Scan(&count); err != nil {
+		t.Fatalf("Failed to query schema_migrations: %v", err)
+	}
+	if count != 0 {
+		t.Error("Expected failed migration not to be recorded")
+	}
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = 'half'").Scan(&count); err != nil {
+		t.Fatalf("Failed to query sqlite_master: %v", err)
+	}
+	if count != 0 {
+		t.Error("Expected failed migration to be rolled back")
+	}
+}
+
+// Test 4: 测试数据库版本高于程序支持的版本时拒绝启动
+func TestMigrate_NewerDatabase(t *testing.T) {
+	ctx := context.Background()
+	db, err := InitializeSQLite(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("InitializeSQLite failed: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec("INSERT INTO schema_migrations (version, applied_at) VALUES (9999, datetime('now'))"); err != nil {
+		t.Fatalf("Failed to record version: %v", err)
+	}
+	if _, err := Migrate(ctx, db); err == nil {
+		t.Error("Expected Migrate to reject a newer database")
+	}
+}
+
+// Test 5: 测试非法的迁移文件
+func TestLoadMigrations_Invalid(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"bad name":  {"migrations/add_index.sql": {}},
+		"baseline":  {"migrations/0001_again.sql": {}},
+		"duplicate": {"migrations/0002_a.sql": {}, "migrations/0002_b.sql": {}},
+	}
+	for name, fsys := range tests {
+		if _, err := loadMigrations(fsys); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+-- 按运行读取采样并按时间排序（GetMetricSamples）时使用复合索引
+CREATE INDEX IF NOT EXISTS idx_metric_samples_run_timestamp ON metric_samples(run_id, timestamp);
@@ -0,0 +1,3 @@
+-- 运行阶段的进程把输出写入文件并记录所属运行，GUI 崩溃重启后可以重新接管（re-attach）或终止仍在运行的基准测试
+ALTER TABLE run_processes ADD COLUMN output_file TEXT NOT NULL DEFAULT '';  -- 工具输出文件，空 = 不可重新接管
+ALTER TABLE run_processes ADD COLUMN recovery_json TEXT NOT NULL DEFAULT '';  -- 所属运行的信息（usecase.RunRecovery）
@@ -0,0 +1,4 @@
+-- 运行元数据（目的、变更单/PR 链接、环境名）单独成列，record_json 压缩后无法在历史记录中搜索
+ALTER TABLE history_records ADD COLUMN purpose TEXT NOT NULL DEFAULT '';  -- 运行目的
+ALTER TABLE history_records ADD COLUMN ticket TEXT NOT NULL DEFAULT '';  -- 变更单或 PR 链接
+ALTER TABLE history_records ADD COLUMN environment TEXT NOT NULL DEFAULT '';  -- 环境名，如 staging、prod-replica
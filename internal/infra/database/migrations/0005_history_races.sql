@@ -0,0 +1,12 @@
+-- A/B 对比运行表（同一任务同时在两个连接上运行的配对结果；两次运行的历史记录带有 race:<id> 标签）
+CREATE TABLE IF NOT EXISTS history_races (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,  -- 任务名称
+    template_name TEXT NOT NULL,
+    connection_a TEXT NOT NULL,  -- A 侧连接名称
+    connection_b TEXT NOT NULL,  -- B 侧连接名称
+    created_at TEXT NOT NULL,  -- ISO 8601 format
+    race_json TEXT NOT NULL  -- 完整对比 JSON（两侧的运行 ID、状态和指标）
+);
+
+CREATE INDEX IF NOT EXISTS idx_history_races_created_at ON history_races(created_at DESC);
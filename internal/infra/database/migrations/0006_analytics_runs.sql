@@ -0,0 +1,12 @@
+-- 分析查询基准测试运行表（TPC-H 风格数据集上 22 个查询的逐查询延迟）
+CREATE TABLE IF NOT EXISTS analytics_runs (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,  -- 运行标签（可为空）
+    connection_name TEXT NOT NULL,
+    database_type TEXT NOT NULL,
+    scale_factor REAL NOT NULL,  -- 数据集的规模因子
+    started_at TEXT NOT NULL,  -- ISO 8601 format
+    run_json TEXT NOT NULL  -- 完整运行 JSON（并发流数、状态和每次查询的延迟）
+);
+
+CREATE INDEX IF NOT EXISTS idx_analytics_runs_started_at ON analytics_runs(started_at DESC);
@@ -0,0 +1,111 @@
+// Package repository provides SQLite repository implementations.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// ErrAggregateNotFound is returned when an aggregate is not found.
+var ErrAggregateNotFound = errors.New("aggregate not found")
+
+// SQLiteAggregateRepository implements the AggregateRepository interface using SQLite.
+type SQLiteAggregateRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteAggregateRepository creates a new SQLite aggregate repository.
+func NewSQLiteAggregateRepository(db *sql.DB) *SQLiteAggregateRepository {
+	return &SQLiteAggregateRepository{db: db}
+}
+
+// SaveAggregate saves an aggregate; an existing aggregate with the same ID is replaced.
+func (r *SQLiteAggregateRepository) SaveAggregate(ctx context.Context, a *history.Aggregate) error {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("marshal aggregate: %w", err)
+	}
+
+	query := `
+		INSERT OR REPLACE INTO history_aggregates (
+			id, name, connection_name, template_name, created_at, aggregate_json
+		) VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = execRetry(ctx, r.db, query,
+		a.ID,
+		a.Name,
+		a.ConnectionName,
+		a.TemplateName,
+		a.CreatedAt.Format(time.RFC3339),
+		string(data),
+	)
+	if err != nil {
+		return fmt.Errorf("save aggregate: %w", err)
+	}
+
+	return nil
+}
+
+// GetAggregate retrieves an aggregate by ID.
+func (r *SQLiteAggregateRepository) GetAggregate(ctx context.Context, id string) (*history.Aggregate, error) {
+	var data string
+	err := r.db.QueryRowContext(ctx, `SELECT aggregate_json FROM history_aggregates WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAggregateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query aggregate: %w", err)
+	}
+
+	var a history.Aggregate
+	if err := json.Unmarshal([]byte(data), &a); err != nil {
+		return nil, fmt.Errorf("unmarshal aggregate %s: %w", id, err)
+	}
+	return &a, nil
+}
+
+// ListAggregates retrieves all aggregates, newest first.
+func (r *SQLiteAggregateRepository) ListAggregates(ctx context.Context) ([]*history.Aggregate, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, aggregate_json FROM history_aggregates ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("query aggregates: %w", err)
+	}
+	defer rows.Close()
+
+	var aggregates []*history.Aggregate
+	for rows.Next() {
+		var id, data string
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, fmt.Errorf("scan aggregate: %w", err)
+		}
+		var a history.Aggregate
+		if err := json.Unmarshal([]byte(data), &a); err != nil {
+			return nil, fmt.Errorf("unmarshal aggregate %s: %w", id, err)
+		}
+		aggregates = append(aggregates, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate aggregates: %w", err)
+	}
+
+	return aggregates, nil
+}
+
+// DeleteAggregate deletes an aggregate. The history records of its runs are kept.
+func (r *SQLiteAggregateRepository) DeleteAggregate(ctx context.Context, id string) error {
+	result, err := execRetry(ctx, r.db, `DELETE FROM history_aggregates WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete aggregate: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrAggregateNotFound
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package repository provides unit tests for aggregate repository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// setupAggregateTestDB creates an in-memory SQLite database for aggregate testing.
+func setupAggregateTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS history_aggregates (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			connection_name TEXT NOT NULL,
+			template_name TEXT NOT NULL,
+			created_at TEXT NOT NULL,
+			aggregate_json TEXT NOT NULL
+		);
+	`)
+	if err != nil {
+		db.Close()
+		t.Fatalf("create tables: %v", err)
+	}
+
+	return db
+}
+
+// TestSQLiteAggregateRepository_SaveGetListDelete tests the aggregate lifecycle.
+func TestSQLiteAggregateRepository_SaveGetListDelete(t *testing.T) {
+	ctx := context.Background()
+	db := setupAggregateTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteAggregateRepository(db)
+	now := time.Now().Truncate(time.Second)
+
+	older := &history.Aggregate{
+		ID:             "agg-1",
+		Name:           "mysql-prod Benchmark",
+		ConnectionName: "mysql-prod",
+		TemplateName:   "oltp_read_write",
+		CreatedAt:      now.Add(-time.Hour),
+		Repeat:         5,
+		RunIDs:         []string{"run-1", "run-2", "run-3", "run-4", "run-5"},
+		Outliers:       []string{"run-5"},
+		OutlierSigma:   2,
+		TPS:            history.MetricStats{Mean: 1000, StdDev: 10, CV: 0.01, Min: 990, Max: 1010},
+	}
+	newer := &history.Aggregate{ID: "agg-2", Name: "pg Benchmark", CreatedAt: now, Repeat: 3}
+	for _, a := range []*history.Aggregate{older, newer} {
+		if err := repo.SaveAggregate(ctx, a); err != nil {
+			t.Fatalf("SaveAggregate() failed: %v", err)
+		}
+	}
+
+	got, err := repo.GetAggregate(ctx, "agg-1")
+	if err != nil {
+		t.Fatalf("GetAggregate() failed: %v", err)
+	}
+	if len(got.RunIDs) != 5 || !got.IsOutlier("run-5") || got.TPS != older.TPS || !got.CreatedAt.Equal(older.CreatedAt) {
+		t.Errorf("GetAggregate() = %+v, want %+v", got, older)
+	}
+
+	all, err := repo.ListAggregates(ctx)
+	if err != nil {
+		t.Fatalf("ListAggregates() failed: %v", err)
+	}
+	if len(all) != 2 || all[0].ID != "agg-2" {
+		t.Errorf("ListAggregates() = %d aggregates, want 2 newest first", len(all))
+	}
+
+	if err := repo.DeleteAggregate(ctx, "agg-1"); err != nil {
+		t.Fatalf("DeleteAggregate() failed: %v", err)
+	}
+	if _, err := repo.GetAggregate(ctx, "agg-1"); !errors.Is(err, ErrAggregateNotFound) {
+		t.Errorf("GetAggregate(deleted) error = %v, want ErrAggregateNotFound", err)
+	}
+	if err := repo.DeleteAggregate(ctx, "agg-1"); !errors.Is(err, ErrAggregateNotFound) {
+		t.Errorf("DeleteAggregate(deleted) error = %v, want ErrAggregateNotFound", err)
+	}
+}
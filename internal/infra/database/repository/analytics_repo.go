@@ -0,0 +1,112 @@
+// Package repository provides SQLite repository implementations.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/analytics"
+)
+
+// ErrAnalyticsRunNotFound is returned when an analytical run is not found.
+var ErrAnalyticsRunNotFound = errors.New("analytical run not found")
+
+// SQLiteAnalyticsRepository implements the AnalyticsRepository interface using SQLite.
+type SQLiteAnalyticsRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteAnalyticsRepository creates a new SQLite analytics repository.
+func NewSQLiteAnalyticsRepository(db *sql.DB) *SQLiteAnalyticsRepository {
+	return &SQLiteAnalyticsRepository{db: db}
+}
+
+// SaveAnalyticsRun saves a run; an existing run with the same ID is replaced.
+func (r *SQLiteAnalyticsRepository) SaveAnalyticsRun(ctx context.Context, run *analytics.Run) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("marshal analytical run: %w", err)
+	}
+
+	query := `
+		INSERT OR REPLACE INTO analytics_runs (
+			id, name, connection_name, database_type, scale_factor, started_at, run_json
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = execRetry(ctx, r.db, query,
+		run.ID,
+		run.Name,
+		run.ConnectionName,
+		run.DatabaseType,
+		run.ScaleFactor,
+		run.StartedAt.Format(time.RFC3339),
+		string(data),
+	)
+	if err != nil {
+		return fmt.Errorf("save analytical run: %w", err)
+	}
+
+	return nil
+}
+
+// GetAnalyticsRun retrieves a run by ID.
+func (r *SQLiteAnalyticsRepository) GetAnalyticsRun(ctx context.Context, id string) (*analytics.Run, error) {
+	var data string
+	err := r.db.QueryRowContext(ctx, `SELECT run_json FROM analytics_runs WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAnalyticsRunNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query analytical run: %w", err)
+	}
+
+	var run analytics.Run
+	if err := json.Unmarshal([]byte(data), &run); err != nil {
+		return nil, fmt.Errorf("unmarshal analytical run %s: %w", id, err)
+	}
+	return &run, nil
+}
+
+// ListAnalyticsRuns retrieves all runs, newest first.
+func (r *SQLiteAnalyticsRepository) ListAnalyticsRuns(ctx context.Context) ([]*analytics.Run, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, run_json FROM analytics_runs ORDER BY started_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("query analytical runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*analytics.Run
+	for rows.Next() {
+		var id, data string
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, fmt.Errorf("scan analytical run: %w", err)
+		}
+		var run analytics.Run
+		if err := json.Unmarshal([]byte(data), &run); err != nil {
+			return nil, fmt.Errorf("unmarshal analytical run %s: %w", id, err)
+		}
+		runs = append(runs, &run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate analytical runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// DeleteAnalyticsRun deletes a run.
+func (r *SQLiteAnalyticsRepository) DeleteAnalyticsRun(ctx context.Context, id string) error {
+	result, err := execRetry(ctx, r.db, `DELETE FROM analytics_runs WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete analytical run: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrAnalyticsRunNotFound
+	}
+	return nil
+}
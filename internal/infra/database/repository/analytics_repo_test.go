@@ -0,0 +1,103 @@
+// Package repository provides unit tests for analytics repository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/analytics"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+// setupAnalyticsTestDB creates an in-memory SQLite database for analytics testing.
+func setupAnalyticsTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS analytics_runs (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			connection_name TEXT NOT NULL,
+			database_type TEXT NOT NULL,
+			scale_factor REAL NOT NULL,
+			started_at TEXT NOT NULL,
+			run_json TEXT NOT NULL
+		);
+	`)
+	if err != nil {
+		db.Close()
+		t.Fatalf("create tables: %v", err)
+	}
+
+	return db
+}
+
+// TestSQLiteAnalyticsRepository_SaveGetListDelete tests the analytical run lifecycle.
+func TestSQLiteAnalyticsRepository_SaveGetListDelete(t *testing.T) {
+	ctx := context.Background()
+	db := setupAnalyticsTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteAnalyticsRepository(db)
+	now := time.Now().Truncate(time.Second)
+
+	older := &analytics.Run{
+		ID:             "run-1",
+		Name:           "before",
+		ConnectionName: "pg-warehouse",
+		DatabaseType:   "postgresql",
+		ScaleFactor:    1,
+		Concurrency:    2,
+		Queries:        []int{1, 6},
+		State:          execution.StateCompleted,
+		StartedAt:      now.Add(-time.Hour),
+		Duration:       time.Minute,
+		Results: []analytics.QueryResult{
+			{Query: 1, Stream: 1, Latency: 3 * time.Second, Rows: 4},
+			{Query: 6, Stream: 2, Error: "canceling statement due to statement timeout"},
+		},
+	}
+	newer := &analytics.Run{ID: "run-2", ConnectionName: "mysql-dw", StartedAt: now}
+	for _, run := range []*analytics.Run{older, newer} {
+		if err := repo.SaveAnalyticsRun(ctx, run); err != nil {
+			t.Fatalf("SaveAnalyticsRun() failed: %v", err)
+		}
+	}
+
+	got, err := repo.GetAnalyticsRun(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("GetAnalyticsRun() failed: %v", err)
+	}
+	if !reflect.DeepEqual(got.Results, older.Results) || got.Concurrency != 2 || !got.StartedAt.Equal(older.StartedAt) {
+		t.Errorf("GetAnalyticsRun() = %+v, want %+v", got, older)
+	}
+
+	all, err := repo.ListAnalyticsRuns(ctx)
+	if err != nil {
+		t.Fatalf("ListAnalyticsRuns() failed: %v", err)
+	}
+	if len(all) != 2 || all[0].ID != "run-2" {
+		t.Errorf("ListAnalyticsRuns() = %d runs, want 2 newest first", len(all))
+	}
+
+	if err := repo.DeleteAnalyticsRun(ctx, "run-1"); err != nil {
+		t.Fatalf("DeleteAnalyticsRun() failed: %v", err)
+	}
+	if _, err := repo.GetAnalyticsRun(ctx, "run-1"); !errors.Is(err, ErrAnalyticsRunNotFound) {
+		t.Errorf("GetAnalyticsRun(deleted) error = %v, want ErrAnalyticsRunNotFound", err)
+	}
+	if err := repo.DeleteAnalyticsRun(ctx, "run-1"); !errors.Is(err, ErrAnalyticsRunNotFound) {
+		t.Errorf("DeleteAnalyticsRun(deleted) error = %v, want ErrAnalyticsRunNotFound", err)
+	}
+}
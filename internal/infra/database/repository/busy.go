@@ -0,0 +1,70 @@
+// Package repository provides SQLite repository implementations.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Writes that find the database locked by another connection or process
+// (e.g. the CLI while the GUI runs a benchmark) are retried with a doubling
+// backoff after SQLite's own busy_timeout has expired.
+const (
+	busyRetries = 4
+	busyBackoff = 50 * time.Millisecond
+)
+
+// isBusy reports whether err is SQLITE_BUSY or SQLITE_LOCKED.
+func isBusy(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	code := sqliteErr.Code() & 0xff // Primary result code
+	return code == sqlite3.SQLITE_BUSY || code == sqlite3.SQLITE_LOCKED
+}
+
+// retryBusy runs op until it succeeds, fails with another error, or the
+// retries are used up.
+func retryBusy(ctx context.Context, op func() error) error {
+	backoff := busyBackoff
+	for attempt := 0; ; attempt++ {
+		err := op()
+		if err == nil || !isBusy(err) || attempt == busyRetries {
+			return err
+		}
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// execRetry executes a statement, retrying while the database is busy.
+func execRetry(ctx context.Context, db execer, query string, args ...interface{}) (sql.Result, error) {
+	var result sql.Result
+	err := retryBusy(ctx, func() error {
+		var err error
+		result, err = db.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+// beginRetry begins a transaction, retrying while the database is busy.
+func beginRetry(ctx context.Context, db *sql.DB) (*sql.Tx, error) {
+	var tx *sql.Tx
+	err := retryBusy(ctx, func() error {
+		var err error
+		tx, err = db.BeginTx(ctx, nil)
+		return err
+	})
+	return tx, err
+}
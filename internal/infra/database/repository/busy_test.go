@@ -0,0 +1,63 @@
+// Package repository provides unit tests for retrying busy writes.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// TestRetryBusy tests retrying a write while another connection holds the write lock.
+func TestRetryBusy(t *testing.T) {
+	ctx := context.Background()
+	dsn := "file:" + filepath.Join(t.TempDir(), "busy.db")
+
+	// Two pools without busy_timeout, like two processes sharing the file
+	holder, err := sql.Open("sqlite", dsn+"?_txlock=immediate")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer holder.Close()
+	writer, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	defer writer.Close()
+
+	if _, err := holder.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY)`); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	tx, err := holder.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	_, err = writer.ExecContext(ctx, `INSERT INTO items (id) VALUES (1)`)
+	if !isBusy(err) {
+		t.Fatalf("insert while locked error = %v, want SQLITE_BUSY", err)
+	}
+
+	// The lock is released while the write is being retried
+	go func() {
+		time.Sleep(2 * busyBackoff)
+		tx.Commit()
+	}()
+	if _, err := execRetry(ctx, writer, `INSERT INTO items (id) VALUES (1)`); err != nil {
+		t.Fatalf("execRetry() error = %v", err)
+	}
+
+	// Other errors are not retried
+	attempts := 0
+	retryBusy(ctx, func() error {
+		attempts++
+		_, err := writer.ExecContext(ctx, `INSERT INTO items (id) VALUES (1)`)
+		return err
+	})
+	if attempts != 1 {
+		t.Errorf("constraint error attempts = %d, want 1", attempts)
+	}
+}
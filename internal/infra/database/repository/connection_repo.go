@@ -54,7 +54,7 @@ func (r *SQLiteConnectionRepository) Save(ctx context.Context, conn connection.C
 			updated_at = excluded.updated_at
 	`
 
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = execRetry(ctx, r.db, query,
 		conn.GetID(),
 		conn.GetName(),
 		string(conn.GetType()),
@@ -70,6 +70,66 @@ func (r *SQLiteConnectionRepository) Save(ctx context.Context, conn connection.C
 	return nil
 }
 
+// Update updates an existing connection in a single transaction.
+// The ID and creation time are preserved, so rows referencing the connection
+// (such as tasks) are kept.
+// Implements: usecase.ConnectionRepository.Update
+func (r *SQLiteConnectionRepository) Update(ctx context.Context, conn connection.Connection) error {
+	tx, err := beginRetry(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var createdAt, name string
+	err = tx.QueryRowContext(ctx,
+		"SELECT created_at, name FROM connections WHERE id = ?", conn.GetID()).
+		Scan(&createdAt, &name)
+	if err == sql.ErrNoRows {
+		return &ConnectionNotFoundError{ID: conn.GetID()}
+	}
+	if err != nil {
+		return fmt.Errorf("query connection: %w", err)
+	}
+
+	configJSON, err := r.serializeConnection(conn)
+	if err != nil {
+		return fmt.Errorf("marshal connection: %w", err)
+	}
+	configJSON, err = withCreatedAt(configJSON, createdAt)
+	if err != nil {
+		return fmt.Errorf("marshal connection: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE connections
+		SET name = ?, db_type = ?, config_json = ?, updated_at = ?
+		WHERE id = ?
+	`,
+		conn.GetName(),
+		string(conn.GetType()),
+		configJSON,
+		time.Now().Format(time.RFC3339),
+		conn.GetID(),
+	)
+	if err != nil {
+		return fmt.Errorf("update connection: %w", err)
+	}
+
+	// History records name their connection
+	if name != conn.GetName() {
+		if err := renameHistoryConnection(ctx, tx, name, conn.GetName()); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // FindByID finds a connection by its ID.
 // Implements: usecase.ConnectionRepository.FindByID
 func (r *SQLiteConnectionRepository) FindByID(ctx context.Context, id string) (connection.Connection, error) {
@@ -125,7 +185,7 @@ func (r *SQLiteConnectionRepository) FindAll(ctx context.Context) ([]connection.
 // Delete deletes a connection by its ID.
 // Implements: usecase.ConnectionRepository.Delete
 func (r *SQLiteConnectionRepository) Delete(ctx context.Context, id string) error {
-	result, err := r.db.ExecContext(ctx, "DELETE FROM connections WHERE id = ?", id)
+	result, err := execRetry(ctx, r.db, "DELETE FROM connections WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("delete connection: %w", err)
 	}
@@ -172,6 +232,30 @@ func (r *SQLiteConnectionRepository) serializeConnection(conn connection.Connect
 		"created_at": time.Now().Format(time.RFC3339),
 		"updated_at": time.Now().Format(time.RFC3339),
 	}
+	if conn.IsProtected() {
+		data["protected"] = true
+	}
+	if conn.IsObserver() {
+		data["observer"] = true
+	}
+	if group := conn.GetGroup(); group != "" {
+		data["group"] = group
+	}
+	if conn.IsFavorite() {
+		data["favorite"] = true
+	}
+
+	// Serialize the prepared benchmark data set
+	if ds := conn.GetDataset(); ds != nil {
+		data["dataset"] = map[string]interface{}{
+			"tool":        ds.Tool,
+			"script":      ds.Script,
+			"database":    ds.Database,
+			"tables":      ds.Tables,
+			"table_size":  ds.TableSize,
+			"prepared_at": ds.PreparedAt.Format(time.RFC3339),
+		}
+	}
 
 	// Add type-specific fields
 	switch c := conn.(type) {
@@ -181,6 +265,14 @@ func (r *SQLiteConnectionRepository) serializeConnection(conn connection.Connect
 		data["database"] = c.Database
 		data["username"] = c.Username
 		data["ssl_mode"] = c.SSLMode
+		for key, path := range map[string]string{"ssl_ca": c.SSLCA, "ssl_cert": c.SSLCert, "ssl_key": c.SSLKey} {
+			if path != "" {
+				data[key] = path
+			}
+		}
+		if len(c.Replicas) > 0 {
+			data["replicas"] = c.Replicas
+		}
 		// Serialize SSH configuration if enabled
 		if c.SSH != nil {
 			data["ssh"] = map[string]interface{}{
@@ -204,6 +296,14 @@ func (r *SQLiteConnectionRepository) serializeConnection(conn connection.Connect
 		data["service_name"] = c.ServiceName
 		data["sid"] = c.SID
 		data["username"] = c.Username
+		for key, value := range map[string]string{"protocol": c.Protocol, "wallet_dir": c.WalletDir} {
+			if value != "" {
+				data[key] = value
+			}
+		}
+		if c.TrustServerCertificate {
+			data["trust_server_certificate"] = true
+		}
 		// Serialize SSH configuration if enabled
 		if c.SSH != nil {
 			data["ssh"] = map[string]interface{}{
@@ -221,12 +321,28 @@ func (r *SQLiteConnectionRepository) serializeConnection(conn connection.Connect
 				"ssh_port", c.SSH.Port,
 				"ssh_user", c.SSH.Username)
 		}
+		// Serialize the installed swingbench schema
+		if c.SOESchema != nil {
+			data["soe_schema"] = map[string]interface{}{
+				"username":     c.SOESchema.Username,
+				"scale":        c.SOESchema.Scale,
+				"installed_at": c.SOESchema.InstalledAt.Format(time.RFC3339),
+			}
+		}
 	case *connection.SQLServerConnection:
 		data["host"] = c.Host
 		data["port"] = c.Port
 		data["database"] = c.Database
 		data["username"] = c.Username
 		data["trust_server_certificate"] = c.TrustServerCertificate
+		for key, value := range map[string]string{"instance": c.Instance, "encrypt": c.Encrypt, "authentication": c.Authentication} {
+			if value != "" {
+				data[key] = value
+			}
+		}
+		if c.ReadOnlyIntent {
+			data["read_only_intent"] = true
+		}
 		// Serialize WinRM configuration if enabled
 		if c.WinRM != nil {
 			data["winrm"] = map[string]interface{}{
@@ -250,6 +366,12 @@ func (r *SQLiteConnectionRepository) serializeConnection(conn connection.Connect
 		data["database"] = c.Database
 		data["username"] = c.Username
 		data["ssl_mode"] = c.SSLMode
+		if len(c.Options) > 0 {
+			data["options"] = c.Options
+		}
+		if len(c.Replicas) > 0 {
+			data["replicas"] = c.Replicas
+		}
 		// Serialize SSH configuration if enabled
 		if c.SSH != nil {
 			data["ssh"] = map[string]interface{}{
@@ -279,6 +401,21 @@ func (r *SQLiteConnectionRepository) serializeConnection(conn connection.Connect
 	return string(bytes), nil
 }
 
+// withCreatedAt replaces the created_at field of serialized connection config.
+func withCreatedAt(configJSON, createdAt string) (string, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(configJSON), &data); err != nil {
+		return "", err
+	}
+	data["created_at"] = createdAt
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
 // deserializeConnection deserializes a connection from JSON.
 func (r *SQLiteConnectionRepository) deserializeConnection(id, name string, connType connection.DatabaseType, configJSON string) (connection.Connection, error) {
 	var data map[string]interface{}
@@ -295,6 +432,23 @@ func (r *SQLiteConnectionRepository) deserializeConnection(id, name string, conn
 		Name:      name,
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
+		Protected: getBool(data, "protected"),
+		Observer:  getBool(data, "observer"),
+		Group:     getString(data, "group"),
+		Favorite:  getBool(data, "favorite"),
+	}
+
+	// Load the prepared benchmark data set if present
+	if dsData, ok := data["dataset"].(map[string]interface{}); ok {
+		preparedAt, _ := time.Parse(time.RFC3339, getString(dsData, "prepared_at"))
+		base.Dataset = &connection.DatasetFingerprint{
+			Tool:       getString(dsData, "tool"),
+			Script:     getString(dsData, "script"),
+			Database:   getString(dsData, "database"),
+			Tables:     getInt(dsData, "tables"),
+			TableSize:  getInt(dsData, "table_size"),
+			PreparedAt: preparedAt,
+		}
 	}
 
 	switch connType {
@@ -306,6 +460,10 @@ func (r *SQLiteConnectionRepository) deserializeConnection(id, name string, conn
 			Database:       getString(data, "database"),
 			Username:       getString(data, "username"),
 			SSLMode:        getString(data, "ssl_mode"),
+			SSLCA:          getString(data, "ssl_ca"),
+			SSLCert:        getString(data, "ssl_cert"),
+			SSLKey:         getString(data, "ssl_key"),
+			Replicas:       getStrings(data, "replicas"),
 		}
 		// Load SSH configuration if present
 		if sshData, ok := data["ssh"].(map[string]interface{}); ok {
@@ -339,7 +497,10 @@ func (r *SQLiteConnectionRepository) deserializeConnection(id, name string, conn
 			ServiceName:    getString(data, "service_name"),
 			SID:            getString(data, "sid"),
 			Username:       getString(data, "username"),
+			Protocol:       getString(data, "protocol"),
+			WalletDir:      getString(data, "wallet_dir"),
 		}
+		conn.TrustServerCertificate = getBool(data, "trust_server_certificate")
 		// Load SSH configuration if present
 		if sshData, ok := data["ssh"].(map[string]interface{}); ok {
 			conn.SSH = &connection.SSHTunnelConfig{
@@ -357,6 +518,15 @@ func (r *SQLiteConnectionRepository) deserializeConnection(id, name string, conn
 				"ssh_port", conn.SSH.Port,
 				"ssh_user", conn.SSH.Username)
 		}
+		// Load the installed swingbench schema if present
+		if soeData, ok := data["soe_schema"].(map[string]interface{}); ok {
+			installedAt, _ := time.Parse(time.RFC3339, getString(soeData, "installed_at"))
+			conn.SOESchema = &connection.SOESchema{
+				Username:    getString(soeData, "username"),
+				Scale:       getInt(soeData, "scale"),
+				InstalledAt: installedAt,
+			}
+		}
 		if conn.Port == 0 {
 			slog.Info("Repository: Oracle port is 0, using default 1521", "conn_id", id, "raw_port", rawPort)
 			conn.Port = 1521
@@ -379,6 +549,10 @@ func (r *SQLiteConnectionRepository) deserializeConnection(id, name string, conn
 			Database:               getString(data, "database"),
 			Username:               getString(data, "username"),
 			TrustServerCertificate: getBool(data, "trust_server_certificate"),
+			Instance:               getString(data, "instance"),
+			Encrypt:                getString(data, "encrypt"),
+			ReadOnlyIntent:         getBool(data, "read_only_intent"),
+			Authentication:         getString(data, "authentication"),
 		}
 		// Load WinRM configuration if present
 		if winrmData, ok := data["winrm"].(map[string]interface{}); ok {
@@ -410,6 +584,8 @@ func (r *SQLiteConnectionRepository) deserializeConnection(id, name string, conn
 			Database:       getString(data, "database"),
 			Username:       getString(data, "username"),
 			SSLMode:        getString(data, "ssl_mode"),
+			Options:        getStringMap(data, "options"),
+			Replicas:       getStrings(data, "replicas"),
 		}
 		// Load SSH configuration if present
 		if sshData, ok := data["ssh"].(map[string]interface{}); ok {
@@ -484,3 +660,29 @@ func getBool(data map[string]interface{}, key string) bool {
 	}
 	return false
 }
+
+func getStringMap(data map[string]interface{}, key string) map[string]string {
+	m, ok := data[key].(map[string]interface{})
+	if !ok || len(m) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for k := range m {
+		result[k] = getString(m, k)
+	}
+	return result
+}
+
+func getStrings(data map[string]interface{}, key string) []string {
+	items, ok := data[key].([]interface{})
+	if !ok || len(items) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if str, ok := item.(string); ok {
+			result = append(result, str)
+		}
+	}
+	return result
+}
@@ -5,12 +5,16 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"maps"
+	"slices"
 	"testing"
 	"time"
 
 	_ "modernc.org/sqlite"
 
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
 )
 
 // TestSQLiteConnectionRepository_SaveAndFind tests Save and FindByID operations.
@@ -367,6 +371,434 @@ func TestSQLiteConnectionRepository_Update(t *testing.T) {
 	}
 }
 
+// TestSQLiteConnectionRepository_Update_InPlace tests that Update preserves the creation time.
+func TestSQLiteConnectionRepository_Update_InPlace(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSQLiteConnectionRepository(db)
+	ctx := context.Background()
+
+	conn := &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "in-place", Name: "Original"},
+		Host:           "localhost",
+		Port:           3306,
+		Username:       "root",
+	}
+	if err := repo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	createdAt := "2020-01-02T03:04:05Z"
+	if _, err := db.Exec("UPDATE connections SET created_at = ? WHERE id = ?", createdAt, "in-place"); err != nil {
+		t.Fatalf("set created_at: %v", err)
+	}
+
+	conn.SetName("Renamed")
+	conn.Host = "db.example.com"
+	if err := repo.Update(ctx, conn); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, "in-place")
+	if err != nil {
+		t.Fatalf("FindByID() failed: %v", err)
+	}
+	mysqlConn := found.(*connection.MySQLConnection)
+	if mysqlConn.Name != "Renamed" || mysqlConn.Host != "db.example.com" {
+		t.Errorf("Update() not applied: name=%s host=%s", mysqlConn.Name, mysqlConn.Host)
+	}
+	if got := mysqlConn.CreatedAt.UTC().Format(time.RFC3339); got != createdAt {
+		t.Errorf("CreatedAt = %s, want %s", got, createdAt)
+	}
+
+	var column string
+	if err := db.QueryRow("SELECT created_at FROM connections WHERE id = ?", "in-place").Scan(&column); err != nil {
+		t.Fatalf("query created_at: %v", err)
+	}
+	if column != createdAt {
+		t.Errorf("created_at column = %s, want %s", column, createdAt)
+	}
+}
+
+// TestSQLiteConnectionRepository_Update_RenamesHistory tests that the history
+// records of a renamed connection are found under its new name.
+func TestSQLiteConnectionRepository_Update_RenamesHistory(t *testing.T) {
+	db := setupTestDB(t)
+	db.SetMaxOpenConns(1)
+	repo := NewSQLiteConnectionRepository(db)
+	historyRepo := NewSQLiteHistoryRepository(db)
+	ctx := context.Background()
+
+	conn := &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "renamed", Name: "mysql-local"},
+		Host:           "localhost",
+		Port:           3306,
+		Username:       "root",
+	}
+	if err := repo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+	other := newTestHistoryRecord("run-other")
+	other.ConnectionName = "mysql-other"
+	for _, record := range []*history.Record{newTestHistoryRecord("run-1"), newTestHistoryRecord("run-2"), other} {
+		if err := historyRepo.Save(ctx, record); err != nil {
+			t.Fatalf("Save(%s) failed: %v", record.ID, err)
+		}
+	}
+	// Compacted records are renamed too
+	if _, err := historyRepo.CompactRecords(ctx); err != nil {
+		t.Fatalf("CompactRecords() failed: %v", err)
+	}
+
+	conn.SetName("mysql-prod")
+	if err := repo.Update(ctx, conn); err != nil {
+		t.Fatalf("Update() failed: %v", err)
+	}
+
+	records, err := historyRepo.List(ctx, &repository.ListOptions{ConnectionName: "mysql-prod"})
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("List(mysql-prod) = %d records, want 2", len(records))
+	}
+	for _, record := range records {
+		if record.ConnectionName != "mysql-prod" {
+			t.Errorf("record %s ConnectionName = %q, want mysql-prod", record.ID, record.ConnectionName)
+		}
+	}
+	if n, _ := historyRepo.Count(ctx, &repository.ListOptions{ConnectionName: "mysql-local"}); n != 0 {
+		t.Errorf("Count(mysql-local) = %d, want 0", n)
+	}
+	if found, err := historyRepo.GetByID(ctx, "run-other"); err != nil || found.ConnectionName != "mysql-other" {
+		t.Errorf("GetByID(run-other) = %+v, %v, want it left under mysql-other", found, err)
+	}
+}
+
+// TestSQLiteConnectionRepository_Update_NotFound tests updating a non-existent connection.
+func TestSQLiteConnectionRepository_Update_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSQLiteConnectionRepository(db)
+
+	err := repo.Update(context.Background(), &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "missing", Name: "Missing"},
+	})
+	if !isConnectionNotFound(err) {
+		t.Errorf("Update() error = %v, want ConnectionNotFoundError", err)
+	}
+}
+
+// TestSQLiteConnectionRepository_SOESchema tests that the installed swingbench schema is kept.
+func TestSQLiteConnectionRepository_SOESchema(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSQLiteConnectionRepository(db)
+	ctx := context.Background()
+
+	installedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	conn := &connection.OracleConnection{
+		BaseConnection: connection.BaseConnection{ID: "oracle-soe", Name: "Oracle SOE"},
+		Host:           "localhost",
+		Port:           1521,
+		SID:            "ORCL",
+		Username:       "soe",
+		SOESchema:      &connection.SOESchema{Username: "soe", Scale: 10, InstalledAt: installedAt},
+	}
+	if err := repo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, conn.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	schema := found.(*connection.OracleConnection).SOESchema
+	if schema == nil {
+		t.Fatal("FindByID() SOESchema = nil, want installed schema")
+	}
+	if schema.Username != "soe" || schema.Scale != 10 || !schema.InstalledAt.Equal(installedAt) {
+		t.Errorf("FindByID() SOESchema = %+v", schema)
+	}
+}
+
+// TestSQLiteConnectionRepository_Dataset tests that the prepared data set fingerprint is kept.
+func TestSQLiteConnectionRepository_Dataset(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSQLiteConnectionRepository(db)
+	ctx := context.Background()
+
+	preparedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	want := connection.DatasetFingerprint{
+		Tool:       "sysbench",
+		Script:     "oltp_read_write.lua",
+		Database:   "sbtest",
+		Tables:     16,
+		TableSize:  100000,
+		PreparedAt: preparedAt,
+	}
+	conn := &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "mysql-dataset", Name: "MySQL Dataset", Dataset: &want},
+		Host:           "localhost",
+		Port:           3306,
+		Username:       "root",
+	}
+	if err := repo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, conn.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	dataset := found.GetDataset()
+	if dataset == nil {
+		t.Fatal("FindByID() Dataset = nil, want prepared data set")
+	}
+	if !dataset.Matches(&want) || !dataset.PreparedAt.Equal(preparedAt) {
+		t.Errorf("FindByID() Dataset = %+v, want %+v", dataset, want)
+	}
+}
+
+// TestSQLiteConnectionRepository_Protected tests that the protected flag is kept.
+func TestSQLiteConnectionRepository_Protected(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSQLiteConnectionRepository(db)
+	ctx := context.Background()
+
+	conn := &connection.PostgreSQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "pg-protected", Name: "Production", Protected: true},
+		Host:           "localhost",
+		Port:           5432,
+		Database:       "app",
+		Username:       "postgres",
+	}
+	if err := repo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	found, err := repo.FindByID(ctx, conn.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if !found.IsProtected() {
+		t.Error("FindByID() IsProtected() = false, want true")
+	}
+
+	conn.SetProtected(false)
+	if err := repo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if found, _ := repo.FindByID(ctx, conn.ID); found.IsProtected() {
+		t.Error("FindByID() IsProtected() = true after clearing the flag")
+	}
+}
+
+func TestSQLiteConnectionRepository_Observer(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSQLiteConnectionRepository(db)
+	ctx := context.Background()
+
+	conn := &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "mysql-observer", Name: "Production", Observer: true},
+		Host:           "localhost",
+		Port:           3306,
+		Database:       "app",
+		Username:       "root",
+	}
+	if err := repo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	found, err := repo.FindByID(ctx, conn.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if !found.IsObserver() || found.IsProtected() {
+		t.Errorf("FindByID() IsObserver() = %v, IsProtected() = %v, want true, false", found.IsObserver(), found.IsProtected())
+	}
+}
+
+// TestSQLiteConnectionRepository_GroupAndFavorite tests that the group and
+// favorite star of a connection are kept.
+func TestSQLiteConnectionRepository_GroupAndFavorite(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSQLiteConnectionRepository(db)
+	ctx := context.Background()
+
+	conn := &connection.PostgreSQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "pg-grouped", Name: "eu-orders", Group: "Prod EU", Favorite: true},
+		Host:           "localhost",
+		Port:           5432,
+		Database:       "orders",
+		Username:       "bench",
+	}
+	if err := repo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	found, err := repo.FindByID(ctx, conn.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.GetGroup() != "Prod EU" || !found.IsFavorite() {
+		t.Errorf("FindByID() GetGroup() = %q, IsFavorite() = %v, want %q, true", found.GetGroup(), found.IsFavorite(), "Prod EU")
+	}
+
+	conn.SetGroup("")
+	conn.SetFavorite(false)
+	if err := repo.Update(ctx, conn); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repo.FindByID(ctx, conn.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.GetGroup() != "" || found.IsFavorite() {
+		t.Errorf("FindByID() GetGroup() = %q, IsFavorite() = %v after clearing them", found.GetGroup(), found.IsFavorite())
+	}
+}
+
+// TestSQLiteConnectionRepository_PostgreSQLOptions tests that the additional
+// PostgreSQL options are kept.
+func TestSQLiteConnectionRepository_PostgreSQLOptions(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSQLiteConnectionRepository(db)
+	ctx := context.Background()
+
+	options := map[string]string{"connect_timeout": "10", "application_name": "db-benchmind"}
+	conn := &connection.PostgreSQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "pg-options", Name: "Replica"},
+		Host:           "localhost",
+		Port:           5432,
+		Database:       "app",
+		Username:       "postgres",
+		Options:        options,
+	}
+	if err := repo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	found, err := repo.FindByID(ctx, conn.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if got := found.(*connection.PostgreSQLConnection).Options; !maps.Equal(got, options) {
+		t.Errorf("FindByID() Options = %v, want %v", got, options)
+	}
+}
+
+// TestSQLiteConnectionRepository_MySQLSSL tests that the MySQL SSL mode and
+// certificate files are kept.
+func TestSQLiteConnectionRepository_MySQLSSL(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSQLiteConnectionRepository(db)
+	ctx := context.Background()
+
+	conn := &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "mysql-ssl", Name: "Cloud MySQL"},
+		Host:           "db.example.com",
+		Port:           3306,
+		Username:       "bench",
+		SSLMode:        connection.MySQLSSLVerifyCA,
+		SSLCA:          "/etc/mysql/ca.pem",
+		SSLCert:        "/etc/mysql/client-cert.pem",
+		SSLKey:         "/etc/mysql/client-key.pem",
+	}
+	if err := repo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	found, err := repo.FindByID(ctx, conn.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	got := found.(*connection.MySQLConnection)
+	if got.SSLMode != conn.SSLMode || got.SSLCA != conn.SSLCA || got.SSLCert != conn.SSLCert || got.SSLKey != conn.SSLKey {
+		t.Errorf("FindByID() SSL = %q %q %q %q, want %q %q %q %q", got.SSLMode, got.SSLCA, got.SSLCert, got.SSLKey,
+			conn.SSLMode, conn.SSLCA, conn.SSLCert, conn.SSLKey)
+	}
+}
+
+// TestSQLiteConnectionRepository_Replicas tests that the replicas of a
+// primary are kept.
+func TestSQLiteConnectionRepository_Replicas(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSQLiteConnectionRepository(db)
+	ctx := context.Background()
+
+	replicas := []string{"db-2", "db-3:3307"}
+	conn := &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "mysql-primary", Name: "Primary"},
+		Host:           "db-1",
+		Port:           3306,
+		Username:       "bench",
+		Replicas:       replicas,
+	}
+	if err := repo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	found, err := repo.FindByID(ctx, conn.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if got := found.(*connection.MySQLConnection).Replicas; !slices.Equal(got, replicas) {
+		t.Errorf("FindByID() Replicas = %v, want %v", got, replicas)
+	}
+}
+
+// TestSQLiteConnectionRepository_SQLServerOptions tests that the advanced
+// SQL Server options are kept.
+func TestSQLiteConnectionRepository_SQLServerOptions(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSQLiteConnectionRepository(db)
+	ctx := context.Background()
+
+	conn := &connection.SQLServerConnection{
+		BaseConnection: connection.BaseConnection{ID: "mssql-ag", Name: "AG Secondary"},
+		Host:           "ag-listener",
+		Port:           1433,
+		Database:       "app",
+		Username:       `CORP\bench`,
+		Instance:       "BENCH",
+		Encrypt:        connection.SQLServerEncryptMandatory,
+		ReadOnlyIntent: true,
+		Authentication: connection.SQLServerAuthWindows,
+	}
+	if err := repo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	found, err := repo.FindByID(ctx, conn.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	got := found.(*connection.SQLServerConnection)
+	if got.Instance != conn.Instance || got.Encrypt != conn.Encrypt || !got.ReadOnlyIntent || got.Authentication != conn.Authentication {
+		t.Errorf("FindByID() = %+v, want the options of %+v", got, conn)
+	}
+}
+
+// TestSQLiteConnectionRepository_OracleTCPS tests that the Oracle TCPS
+// settings are kept.
+func TestSQLiteConnectionRepository_OracleTCPS(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewSQLiteConnectionRepository(db)
+	ctx := context.Background()
+
+	conn := &connection.OracleConnection{
+		BaseConnection:         connection.BaseConnection{ID: "adb", Name: "Autonomous"},
+		Host:                   "adb.example.com",
+		Port:                   1522,
+		ServiceName:            "bench_high",
+		Username:               "admin",
+		Protocol:               connection.OracleProtocolTCPS,
+		WalletDir:              "/opt/wallet",
+		TrustServerCertificate: true,
+	}
+	if err := repo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	found, err := repo.FindByID(ctx, conn.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	got := found.(*connection.OracleConnection)
+	if got.Protocol != conn.Protocol || got.WalletDir != conn.WalletDir || !got.TrustServerCertificate {
+		t.Errorf("FindByID() = %+v, want the TCPS settings of %+v", got, conn)
+	}
+}
+
 // setupTestDB creates an in-memory SQLite database for testing.
 func setupTestDB(t *testing.T) *sql.DB {
 	t.Helper()
@@ -390,6 +822,10 @@ func setupTestDB(t *testing.T) *sql.DB {
 		CREATE INDEX IF NOT EXISTS idx_connections_db_type ON connections(db_type);
 		CREATE INDEX IF NOT EXISTS idx_connections_created_at ON connections(created_at);
 	`)
+	if err == nil {
+		// Renaming a connection renames its history records
+		_, err = db.Exec(historyTestSchema)
+	}
 	if err != nil {
 		db.Close()
 		t.Fatalf("create tables: %v", err)
@@ -2,11 +2,15 @@
 package repository
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
@@ -15,12 +19,13 @@ import (
 
 var (
 	// ErrHistoryRecordNotFound is returned when a history record is not found.
-	ErrHistoryRecordNotFound = errors.New("history record not found")
+	ErrHistoryRecordNotFound = repository.ErrRecordNotFound
 )
 
 // SQLiteHistoryRepository implements the HistoryRepository interface using SQLite.
 type SQLiteHistoryRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	readDB *sql.DB // Read pool for queries; nil uses db
 }
 
 // NewSQLiteHistoryRepository creates a new SQLite history repository.
@@ -28,6 +33,20 @@ func NewSQLiteHistoryRepository(db *sql.DB) *SQLiteHistoryRepository {
 	return &SQLiteHistoryRepository{db: db}
 }
 
+// SetReadDB sets a read pool (database.OpenSQLiteReadPool) for queries,
+// so the History page does not wait behind benchmark writes.
+func (r *SQLiteHistoryRepository) SetReadDB(db *sql.DB) {
+	r.readDB = db
+}
+
+// reader returns the database queries run on.
+func (r *SQLiteHistoryRepository) reader() *sql.DB {
+	if r.readDB != nil {
+		return r.readDB
+	}
+	return r.db
+}
+
 // Save saves a history record to the database.
 // If the record already exists (by ID), it will be updated.
 func (r *SQLiteHistoryRepository) Save(ctx context.Context, record *history.Record) error {
@@ -37,9 +56,16 @@ func (r *SQLiteHistoryRepository) Save(ctx context.Context, record *history.Reco
 		return fmt.Errorf("marshal record: %w", err)
 	}
 
+	// The record and its index rows are saved together or not at all
+	tx, err := beginRetry(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	// Check if record already exists
 	var existingID string
-	err = r.db.QueryRowContext(ctx, "SELECT id FROM history_records WHERE id = ?", record.ID).Scan(&existingID)
+	err = tx.QueryRowContext(ctx, "SELECT id FROM history_records WHERE id = ?", record.ID).Scan(&existingID)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return fmt.Errorf("check existing record: %w", err)
 	}
@@ -53,11 +79,12 @@ func (r *SQLiteHistoryRepository) Save(ctx context.Context, record *history.Reco
 	query := `
 		INSERT INTO history_records (
 			id, created_at, connection_name, template_name, database_type,
-			threads, start_time, duration_seconds, tps, record_json
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			threads, start_time, duration_seconds, tps, record_json,
+			purpose, ticket, environment
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := execRetry(ctx, tx, query,
 		record.ID,
 		record.CreatedAt.Format(time.RFC3339),
 		record.ConnectionName,
@@ -68,6 +95,9 @@ func (r *SQLiteHistoryRepository) Save(ctx context.Context, record *history.Reco
 		record.Duration.Seconds(),
 		record.TPSCalculated,
 		string(recordJSON),
+		record.Purpose,
+		record.Ticket,
+		record.Environment,
 	)
 	if err != nil {
 		return fmt.Errorf("insert history record: %w", err)
@@ -78,6 +108,16 @@ func (r *SQLiteHistoryRepository) Save(ctx context.Context, record *history.Reco
 		return fmt.Errorf("expected 1 row affected, got %d", rowsAffected)
 	}
 
+	if err := replaceRecordTags(ctx, tx, record.ID, record.Tags); err != nil {
+		return err
+	}
+	if err := replaceRecordValidity(ctx, tx, record.ID, record.Validity); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit history record: %w", err)
+	}
 	return nil
 }
 
@@ -87,12 +127,12 @@ func (r *SQLiteHistoryRepository) GetByID(ctx context.Context, id string) (*hist
 	          threads, start_time, duration_seconds, tps, record_json
 	          FROM history_records WHERE id = ?`
 
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.reader().QueryRowContext(ctx, query, id)
 
 	var record history.Record
 	var createdAtStr, startTimeStr string
 	var durationSeconds, tps float64
-	var recordJSON string
+	var recordJSON []byte
 
 	err := row.Scan(
 		&record.ID,
@@ -130,8 +170,8 @@ func (r *SQLiteHistoryRepository) GetByID(ctx context.Context, id string) (*hist
 	record.TPSCalculated = tps
 
 	// Unmarshal the full record JSON to get all fields
-	if err := json.Unmarshal([]byte(recordJSON), &record); err != nil {
-		return nil, fmt.Errorf("unmarshal record JSON: %w", err)
+	if err := unmarshalRecordJSON(recordJSON, &record); err != nil {
+		return nil, err
 	}
 
 	return &record, nil
@@ -143,7 +183,7 @@ func (r *SQLiteHistoryRepository) GetAll(ctx context.Context) ([]*history.Record
 	          threads, start_time, duration_seconds, tps, record_json
 	          FROM history_records ORDER BY start_time DESC`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.reader().QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("query history records: %w", err)
 	}
@@ -154,7 +194,7 @@ func (r *SQLiteHistoryRepository) GetAll(ctx context.Context) ([]*history.Record
 		var record history.Record
 		var createdAtStr, startTimeStr string
 		var durationSeconds, tps float64
-		var recordJSON string
+		var recordJSON []byte
 
 		err := rows.Scan(
 			&record.ID,
@@ -188,8 +228,8 @@ func (r *SQLiteHistoryRepository) GetAll(ctx context.Context) ([]*history.Record
 		record.Duration = time.Duration(durationSeconds) * time.Second
 
 		// Unmarshal the full record JSON to get all fields
-		if err := json.Unmarshal([]byte(recordJSON), &record); err != nil {
-			return nil, fmt.Errorf("unmarshal record JSON: %w", err)
+		if err := unmarshalRecordJSON(recordJSON, &record); err != nil {
+			return nil, err
 		}
 
 		// ⭐ 关键修复：在Unmarshal之后设置TPS，确保使用数据库列中的值
@@ -209,7 +249,7 @@ func (r *SQLiteHistoryRepository) GetAll(ctx context.Context) ([]*history.Record
 func (r *SQLiteHistoryRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM history_records WHERE id = ?`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := execRetry(ctx, r.db, query, id)
 	if err != nil {
 		return fmt.Errorf("delete history record: %w", err)
 	}
@@ -223,42 +263,242 @@ func (r *SQLiteHistoryRepository) Delete(ctx context.Context, id string) error {
 		return ErrHistoryRecordNotFound
 	}
 
+	if err := replaceRecordTags(ctx, r.db, id, nil); err != nil {
+		return err
+	}
+	if err := replaceRecordValidity(ctx, r.db, id, nil); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-// List retrieves history records with pagination and filtering options.
-func (r *SQLiteHistoryRepository) List(ctx context.Context, opts *repository.ListOptions) ([]*history.Record, error) {
+// Count returns the number of history records matching the filters in opts.
+// Limit, Offset and OrderBy are ignored.
+func (r *SQLiteHistoryRepository) Count(ctx context.Context, opts *repository.ListOptions) (int, error) {
 	if opts == nil {
 		opts = &repository.ListOptions{}
 	}
 
-	// Build query with filters
-	query := `SELECT id, created_at, connection_name, template_name, database_type,
-	          threads, start_time, duration_seconds, tps, record_json
-	          FROM history_records WHERE 1=1`
+	where, args := buildHistoryFilter(opts)
+	var count int
+	if err := r.reader().QueryRowContext(ctx, "SELECT COUNT(*) FROM history_records"+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count history records: %w", err)
+	}
+	return count, nil
+}
+
+// buildHistoryFilter builds the WHERE clause for the filters in opts.
+func buildHistoryFilter(opts *repository.ListOptions) (string, []interface{}) {
+	where := " WHERE 1=1"
 	args := []interface{}{}
 
-	// Add filters
 	if opts.ConnectionName != "" {
-		query += " AND connection_name = ?"
+		where += " AND connection_name = ?"
 		args = append(args, opts.ConnectionName)
 	}
 	if opts.TemplateName != "" {
-		query += " AND template_name = ?"
+		where += " AND template_name = ?"
 		args = append(args, opts.TemplateName)
 	}
 	if opts.DatabaseType != "" {
-		query += " AND database_type = ?"
+		where += " AND database_type = ?"
 		args = append(args, opts.DatabaseType)
 	}
+	if opts.Threads > 0 {
+		where += " AND threads = ?"
+		args = append(args, opts.Threads)
+	}
+	if opts.Search != "" {
+		where += " AND (connection_name LIKE ? ESCAPE '\\' OR template_name LIKE ? ESCAPE '\\'" +
+			" OR purpose LIKE ? ESCAPE '\\' OR ticket LIKE ? ESCAPE '\\' OR environment LIKE ? ESCAPE '\\')"
+		pattern := "%" + escapeLike(opts.Search) + "%"
+		args = append(args, pattern, pattern, pattern, pattern, pattern)
+	}
 	if opts.StartTimeAfter != nil {
-		query += " AND start_time >= ?"
+		where += " AND start_time >= ?"
 		args = append(args, opts.StartTimeAfter.Format(time.RFC3339))
 	}
 	if opts.StartTimeBefore != nil {
-		query += " AND start_time <= ?"
+		where += " AND start_time <= ?"
 		args = append(args, opts.StartTimeBefore.Format(time.RFC3339))
 	}
+	for _, tag := range opts.Tags {
+		where += " AND id IN (SELECT record_id FROM history_record_tags WHERE tag = ?)"
+		args = append(args, tag)
+	}
+	if opts.ValidOnly {
+		where += " AND id NOT IN (SELECT record_id FROM history_record_validity WHERE valid = 0)"
+	}
+
+	return where, args
+}
+
+// escapeLike escapes LIKE wildcards so the search text matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(s)
+}
+
+// UpdateAnnotations replaces the tags and notes of a history record.
+func (r *SQLiteHistoryRepository) UpdateAnnotations(ctx context.Context, id string, tags []string, notes string) error {
+	return r.updateRecord(ctx, id, func(tx *sql.Tx, record *history.Record) error {
+		record.Tags = tags
+		record.Notes = notes
+		return replaceRecordTags(ctx, tx, id, tags)
+	})
+}
+
+// UpdateValidity replaces the sanity check outcome of a history record.
+func (r *SQLiteHistoryRepository) UpdateValidity(ctx context.Context, id string, validity *history.Validity) error {
+	return r.updateRecord(ctx, id, func(tx *sql.Tx, record *history.Record) error {
+		record.Validity = validity
+		return replaceRecordValidity(ctx, tx, id, validity)
+	})
+}
+
+// updateRecord applies update to a stored history record in a transaction.
+// update may also maintain the index tables through tx.
+func (r *SQLiteHistoryRepository) updateRecord(ctx context.Context, id string, update func(tx *sql.Tx, record *history.Record) error) error {
+	tx, err := beginRetry(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var recordJSON []byte
+	err = tx.QueryRowContext(ctx, "SELECT record_json FROM history_records WHERE id = ?", id).Scan(&recordJSON)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrHistoryRecordNotFound
+		}
+		return fmt.Errorf("query history record: %w", err)
+	}
+
+	var record history.Record
+	if err := unmarshalRecordJSON(recordJSON, &record); err != nil {
+		return err
+	}
+	if err := update(tx, &record); err != nil {
+		return err
+	}
+
+	stored, err := encodeRecordJSON(&record, recordJSON)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE history_records SET record_json = ? WHERE id = ?", stored, id); err != nil {
+		return fmt.Errorf("update history record: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// encodeRecordJSON encodes an updated record for storage, keeping it
+// compressed if its previous JSON was compacted.
+func encodeRecordJSON(record *history.Record, previous []byte) (interface{}, error) {
+	updated, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("marshal record: %w", err)
+	}
+	if !isGzipped(previous) {
+		return string(updated), nil
+	}
+	compressed, err := compressRecordJSON(updated)
+	if err != nil {
+		return nil, fmt.Errorf("compress record: %w", err)
+	}
+	return compressed, nil
+}
+
+// renameHistoryConnection moves the history records of a connection to its
+// new name in tx, so that they are found under the name after a rename.
+func renameHistoryConnection(ctx context.Context, tx *sql.Tx, oldName, newName string) error {
+	rows, err := tx.QueryContext(ctx, "SELECT id, record_json FROM history_records WHERE connection_name = ?", oldName)
+	if err != nil {
+		return fmt.Errorf("query history records: %w", err)
+	}
+	stored := make(map[string][]byte)
+	for rows.Next() {
+		var id string
+		var recordJSON []byte
+		if err := rows.Scan(&id, &recordJSON); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan history record: %w", err)
+		}
+		stored[id] = recordJSON
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("query history records: %w", err)
+	}
+
+	for id, recordJSON := range stored {
+		var record history.Record
+		if err := unmarshalRecordJSON(recordJSON, &record); err != nil {
+			return fmt.Errorf("history record %s: %w", id, err)
+		}
+		record.ConnectionName = newName
+		updated, err := encodeRecordJSON(&record, recordJSON)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE history_records SET connection_name = ?, record_json = ? WHERE id = ?", newName, updated, id); err != nil {
+			return fmt.Errorf("update history record: %w", err)
+		}
+	}
+	return nil
+}
+
+// execer is implemented by both *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// replaceRecordTags replaces the tag index rows of a history record.
+func replaceRecordTags(ctx context.Context, db execer, id string, tags []string) error {
+	if _, err := db.ExecContext(ctx, "DELETE FROM history_record_tags WHERE record_id = ?", id); err != nil {
+		return fmt.Errorf("delete history tags: %w", err)
+	}
+	for _, tag := range tags {
+		_, err := db.ExecContext(ctx, "INSERT OR IGNORE INTO history_record_tags (record_id, tag) VALUES (?, ?)", id, tag)
+		if err != nil {
+			return fmt.Errorf("insert history tag: %w", err)
+		}
+	}
+	return nil
+}
+
+// replaceRecordValidity replaces the validity index row of a history record.
+// A nil validity removes it: unchecked records are valid.
+func replaceRecordValidity(ctx context.Context, db execer, id string, validity *history.Validity) error {
+	if validity == nil {
+		if _, err := db.ExecContext(ctx, "DELETE FROM history_record_validity WHERE record_id = ?", id); err != nil {
+			return fmt.Errorf("delete history validity: %w", err)
+		}
+		return nil
+	}
+	_, err := db.ExecContext(ctx, "INSERT OR REPLACE INTO history_record_validity (record_id, valid, checked_at) VALUES (?, ?, ?)",
+		id, validity.Valid, validity.CheckedAt.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("save history validity: %w", err)
+	}
+	return nil
+}
+
+// List retrieves history records with pagination and filtering options.
+func (r *SQLiteHistoryRepository) List(ctx context.Context, opts *repository.ListOptions) ([]*history.Record, error) {
+	if opts == nil {
+		opts = &repository.ListOptions{}
+	}
+
+	// Build query with filters
+	where, args := buildHistoryFilter(opts)
+	query := `SELECT id, created_at, connection_name, template_name, database_type,
+	          threads, start_time, duration_seconds, tps, record_json
+	          FROM history_records` + where
 
 	// Add ordering
 	orderClause := "start_time DESC"
@@ -273,11 +513,14 @@ func (r *SQLiteHistoryRepository) List(ctx context.Context, opts *repository.Lis
 		args = append(args, opts.Limit)
 	}
 	if opts.Offset > 0 {
+		if opts.Limit <= 0 {
+			query += " LIMIT -1" // SQLite requires LIMIT before OFFSET
+		}
 		query += " OFFSET ?"
 		args = append(args, opts.Offset)
 	}
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.reader().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("query history records: %w", err)
 	}
@@ -288,7 +531,7 @@ func (r *SQLiteHistoryRepository) List(ctx context.Context, opts *repository.Lis
 		var record history.Record
 		var createdAtStr, startTimeStr string
 		var durationSeconds, tps float64
-		var recordJSON string
+		var recordJSON []byte
 
 		err := rows.Scan(
 			&record.ID,
@@ -322,8 +565,8 @@ func (r *SQLiteHistoryRepository) List(ctx context.Context, opts *repository.Lis
 		record.Duration = time.Duration(durationSeconds) * time.Second
 
 		// Unmarshal the full record JSON to get all fields
-		if err := json.Unmarshal([]byte(recordJSON), &record); err != nil {
-			return nil, fmt.Errorf("unmarshal record JSON: %w", err)
+		if err := unmarshalRecordJSON(recordJSON, &record); err != nil {
+			return nil, err
 		}
 
 		// ⭐ 关键修复：在Unmarshal之后设置TPS，确保使用数据库列中的值
@@ -338,3 +581,97 @@ func (r *SQLiteHistoryRepository) List(ctx context.Context, opts *repository.Lis
 
 	return records, nil
 }
+
+// CompactRecords recompresses the stored record JSON of every history record
+// that is not yet gzip compressed. Returns the number of records compacted.
+func (r *SQLiteHistoryRepository) CompactRecords(ctx context.Context) (int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, record_json FROM history_records`)
+	if err != nil {
+		return 0, fmt.Errorf("query history records: %w", err)
+	}
+
+	// Collect first: the pool has a single connection, so updates cannot run
+	// while the result set is still open.
+	pending := make(map[string][]byte)
+	for rows.Next() {
+		var id string
+		var recordJSON []byte
+		if err := rows.Scan(&id, &recordJSON); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan history record: %w", err)
+		}
+		if isGzipped(recordJSON) {
+			continue
+		}
+		pending[id] = recordJSON
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return 0, fmt.Errorf("iterate history records: %w", err)
+	}
+	rows.Close()
+
+	compacted := 0
+	for id, recordJSON := range pending {
+		compressed, err := compressRecordJSON(recordJSON)
+		if err != nil {
+			return compacted, fmt.Errorf("compress record %s: %w", id, err)
+		}
+		if len(compressed) >= len(recordJSON) {
+			continue
+		}
+
+		_, err = execRetry(ctx, r.db, `UPDATE history_records SET record_json = ? WHERE id = ?`, compressed, id)
+		if err != nil {
+			return compacted, fmt.Errorf("update history record %s: %w", id, err)
+		}
+		compacted++
+	}
+
+	return compacted, nil
+}
+
+// =============================================================================
+// Record JSON Encoding
+// =============================================================================
+
+// isGzipped reports whether data starts with the gzip magic number.
+func isGzipped(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+// compressRecordJSON gzips record JSON with the best compression level.
+func compressRecordJSON(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalRecordJSON decodes stored record JSON, which is either plain
+// JSON text or gzip compressed by CompactRecords.
+func unmarshalRecordJSON(data []byte, record *history.Record) error {
+	if isGzipped(data) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("decompress record JSON: %w", err)
+		}
+		defer zr.Close()
+		if data, err = io.ReadAll(zr); err != nil {
+			return fmt.Errorf("decompress record JSON: %w", err)
+		}
+	}
+
+	if err := json.Unmarshal(data, record); err != nil {
+		return fmt.Errorf("unmarshal record JSON: %w", err)
+	}
+	return nil
+}
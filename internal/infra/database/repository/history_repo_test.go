@@ -0,0 +1,419 @@
+// Package repository provides unit tests for history repository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// historyTestSchema creates the history tables.
+const historyTestSchema = `
+	CREATE TABLE IF NOT EXISTS history_records (
+		id TEXT PRIMARY KEY,
+		created_at TEXT NOT NULL,
+		connection_name TEXT NOT NULL,
+		template_name TEXT NOT NULL,
+		database_type TEXT NOT NULL,
+		threads INTEGER NOT NULL,
+		start_time TEXT NOT NULL,
+		duration_seconds REAL NOT NULL,
+		tps REAL NOT NULL,
+		record_json TEXT NOT NULL,
+		purpose TEXT NOT NULL DEFAULT '',
+		ticket TEXT NOT NULL DEFAULT '',
+		environment TEXT NOT NULL DEFAULT ''
+	);
+	CREATE TABLE IF NOT EXISTS history_record_tags (
+		record_id TEXT NOT NULL,
+		tag TEXT NOT NULL,
+		PRIMARY KEY (record_id, tag)
+	);
+	CREATE TABLE IF NOT EXISTS history_record_validity (
+		record_id TEXT PRIMARY KEY,
+		valid INTEGER NOT NULL,
+		checked_at TEXT NOT NULL
+	);
+`
+
+// setupHistoryTestDB creates an in-memory SQLite database for history testing.
+func setupHistoryTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(historyTestSchema)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// newTestHistoryRecord creates a history record with a raw-output time series.
+func newTestHistoryRecord(id string) *history.Record {
+	now := time.Now().UTC().Truncate(time.Second)
+	record := &history.Record{
+		ID:             id,
+		CreatedAt:      now,
+		ConnectionName: "mysql-local",
+		TemplateName:   "oltp_read_write",
+		DatabaseType:   string(connection.DatabaseTypeMySQL),
+		Threads:        8,
+		StartTime:      now,
+		Duration:       time.Minute,
+		TPSCalculated:  1234.5,
+	}
+	for i := 0; i < 60; i++ {
+		record.TimeSeries = append(record.TimeSeries, history.MetricSample{
+			Timestamp: now.Add(time.Duration(i) * time.Second),
+			Phase:     "run",
+			TPS:       1234.5,
+			RawLine:   "[ 1s ] thds: 8 tps: 1234.50 qps: 24690.00 (r/w/o: 17283.00/4938.00/2469.00) lat (ms,95%): 9.22 err/s: 0.00 reconn/s: 0.00",
+		})
+	}
+	return record
+}
+
+// TestHistoryRepository_CompactRecords tests that compacted records shrink and still load.
+func TestHistoryRepository_CompactRecords(t *testing.T) {
+	ctx := context.Background()
+	db := setupHistoryTestDB(t)
+	repo := NewSQLiteHistoryRepository(db)
+
+	for _, id := range []string{"run-1", "run-2"} {
+		if err := repo.Save(ctx, newTestHistoryRecord(id)); err != nil {
+			t.Fatalf("Save(%s) failed: %v", id, err)
+		}
+	}
+
+	var sizeBefore int
+	if err := db.QueryRow("SELECT SUM(LENGTH(record_json)) FROM history_records").Scan(&sizeBefore); err != nil {
+		t.Fatalf("query size: %v", err)
+	}
+
+	compacted, err := repo.CompactRecords(ctx)
+	if err != nil {
+		t.Fatalf("CompactRecords() failed: %v", err)
+	}
+	if compacted != 2 {
+		t.Errorf("CompactRecords() = %d, want 2", compacted)
+	}
+
+	var sizeAfter int
+	if err := db.QueryRow("SELECT SUM(LENGTH(record_json)) FROM history_records").Scan(&sizeAfter); err != nil {
+		t.Fatalf("query size: %v", err)
+	}
+	if sizeAfter >= sizeBefore {
+		t.Errorf("stored size after compaction = %d, want < %d", sizeAfter, sizeBefore)
+	}
+
+	// Already compacted records are skipped
+	compacted, err = repo.CompactRecords(ctx)
+	if err != nil {
+		t.Fatalf("second CompactRecords() failed: %v", err)
+	}
+	if compacted != 0 {
+		t.Errorf("second CompactRecords() = %d, want 0", compacted)
+	}
+
+	record, err := repo.GetByID(ctx, "run-1")
+	if err != nil {
+		t.Fatalf("GetByID() failed: %v", err)
+	}
+	if len(record.TimeSeries) != 60 {
+		t.Fatalf("TimeSeries length = %d, want 60", len(record.TimeSeries))
+	}
+	if !strings.HasPrefix(record.TimeSeries[0].RawLine, "[ 1s ]") {
+		t.Errorf("RawLine = %q, want original raw output", record.TimeSeries[0].RawLine)
+	}
+
+	records, err := repo.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("GetAll() failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("GetAll() returned %d records, want 2", len(records))
+	}
+}
+
+// TestHistoryRepository_UpdateAnnotations tests editing tags and notes and filtering by tag.
+func TestHistoryRepository_UpdateAnnotations(t *testing.T) {
+	ctx := context.Background()
+	db := setupHistoryTestDB(t)
+	repo := NewSQLiteHistoryRepository(db)
+
+	tagged := newTestHistoryRecord("run-1")
+	tagged.Tags = []string{"baseline"}
+	for _, record := range []*history.Record{tagged, newTestHistoryRecord("run-2")} {
+		if err := repo.Save(ctx, record); err != nil {
+			t.Fatalf("Save(%s) failed: %v", record.ID, err)
+		}
+	}
+
+	// Compacted records stay readable after editing
+	if _, err := repo.CompactRecords(ctx); err != nil {
+		t.Fatalf("CompactRecords() failed: %v", err)
+	}
+
+	err := repo.UpdateAnnotations(ctx, "run-2", []string{"innodb_buffer_pool=32G", "baseline"}, "after tuning")
+	if err != nil {
+		t.Fatalf("UpdateAnnotations() failed: %v", err)
+	}
+
+	record, err := repo.GetByID(ctx, "run-2")
+	if err != nil {
+		t.Fatalf("GetByID() failed: %v", err)
+	}
+	if record.Notes != "after tuning" {
+		t.Errorf("Notes = %q, want %q", record.Notes, "after tuning")
+	}
+	if !record.HasAllTags([]string{"innodb_buffer_pool=32G", "baseline"}) {
+		t.Errorf("Tags = %v, want innodb_buffer_pool=32G and baseline", record.Tags)
+	}
+
+	tests := []struct {
+		tags []string
+		want int
+	}{
+		{nil, 2},
+		{[]string{"baseline"}, 2},
+		{[]string{"innodb_buffer_pool=32G"}, 1},
+		{[]string{"baseline", "innodb_buffer_pool=32G"}, 1},
+		{[]string{"missing"}, 0},
+	}
+	for _, tt := range tests {
+		records, err := repo.List(ctx, &repository.ListOptions{Tags: tt.tags})
+		if err != nil {
+			t.Fatalf("List(%v) failed: %v", tt.tags, err)
+		}
+		if len(records) != tt.want {
+			t.Errorf("List(%v) returned %d records, want %d", tt.tags, len(records), tt.want)
+		}
+	}
+
+	if err := repo.UpdateAnnotations(ctx, "missing", nil, ""); err != ErrHistoryRecordNotFound {
+		t.Errorf("UpdateAnnotations(missing) error = %v, want ErrHistoryRecordNotFound", err)
+	}
+
+	// Deleting a record removes its tags
+	if err := repo.Delete(ctx, "run-2"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM history_record_tags WHERE record_id = 'run-2'").Scan(&count); err != nil {
+		t.Fatalf("count tags: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("tags left after delete = %d, want 0", count)
+	}
+}
+
+// TestHistoryRepository_UpdateValidity tests saving sanity check outcomes and listing valid runs only.
+func TestHistoryRepository_UpdateValidity(t *testing.T) {
+	ctx := context.Background()
+	db := setupHistoryTestDB(t)
+	repo := NewSQLiteHistoryRepository(db)
+
+	checked := newTestHistoryRecord("run-1")
+	checked.Validity = &history.Validity{Valid: true, CheckedAt: time.Now()}
+	for _, record := range []*history.Record{checked, newTestHistoryRecord("run-2"), newTestHistoryRecord("run-3")} {
+		if err := repo.Save(ctx, record); err != nil {
+			t.Fatalf("Save(%s) failed: %v", record.ID, err)
+		}
+	}
+
+	invalid := &history.Validity{
+		CheckedAt: time.Now(),
+		Results:   []history.CheckResult{{Name: "errors", Kind: history.CheckMaxErrorRate, Value: 5, Threshold: 1}},
+	}
+	if err := repo.UpdateValidity(ctx, "run-2", invalid); err != nil {
+		t.Fatalf("UpdateValidity() failed: %v", err)
+	}
+
+	record, err := repo.GetByID(ctx, "run-2")
+	if err != nil {
+		t.Fatalf("GetByID() failed: %v", err)
+	}
+	if record.IsValid() || len(record.Validity.Failed()) != 1 {
+		t.Errorf("Validity = %+v, want invalid with one failed check", record.Validity)
+	}
+
+	// Unchecked records count as valid
+	count, err := repo.Count(ctx, &repository.ListOptions{ValidOnly: true})
+	if err != nil {
+		t.Fatalf("Count() failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Count(ValidOnly) = %d, want 2", count)
+	}
+
+	// Clearing the validity makes the record valid again
+	if err := repo.UpdateValidity(ctx, "run-2", nil); err != nil {
+		t.Fatalf("UpdateValidity(nil) failed: %v", err)
+	}
+	records, err := repo.List(ctx, &repository.ListOptions{ValidOnly: true})
+	if err != nil {
+		t.Fatalf("List() failed: %v", err)
+	}
+	if len(records) != 3 {
+		t.Errorf("List(ValidOnly) returned %d records, want 3", len(records))
+	}
+
+	if err := repo.UpdateValidity(ctx, "missing", invalid); err != ErrHistoryRecordNotFound {
+		t.Errorf("UpdateValidity(missing) error = %v, want ErrHistoryRecordNotFound", err)
+	}
+
+	if err := repo.Delete(ctx, "run-1"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if err := db.QueryRow("SELECT COUNT(*) FROM history_record_validity").Scan(&count); err != nil {
+		t.Fatalf("count validity: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("validity rows left after delete = %d, want 0", count)
+	}
+}
+
+// TestHistoryRepository_SaveRollback tests that a record whose tags fail to
+// save is not saved at all.
+func TestHistoryRepository_SaveRollback(t *testing.T) {
+	ctx := context.Background()
+	db := setupHistoryTestDB(t)
+	repo := NewSQLiteHistoryRepository(db)
+
+	_, err := db.Exec(`CREATE TRIGGER reject_tag BEFORE INSERT ON history_record_tags
+		WHEN NEW.tag = 'rejected' BEGIN SELECT RAISE(ABORT, 'tag rejected'); END`)
+	if err != nil {
+		t.Fatalf("create trigger: %v", err)
+	}
+
+	record := newTestHistoryRecord("run-1")
+	record.Tags = []string{"baseline", "rejected"}
+	record.Validity = &history.Validity{Valid: true, CheckedAt: time.Now()}
+	if err := repo.Save(ctx, record); err == nil {
+		t.Fatal("Save() error = nil, want the tag insert error")
+	}
+
+	if _, err := repo.GetByID(ctx, "run-1"); err != ErrHistoryRecordNotFound {
+		t.Errorf("GetByID() error = %v, want ErrHistoryRecordNotFound", err)
+	}
+	for _, table := range []string{"history_record_tags", "history_record_validity"} {
+		var count int
+		if err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count); err != nil {
+			t.Fatalf("count %s: %v", table, err)
+		}
+		if count != 0 {
+			t.Errorf("%s rows left = %d, want 0", table, count)
+		}
+	}
+
+	// The record saves once its tags do
+	record.Tags = []string{"baseline"}
+	if err := repo.Save(ctx, record); err != nil {
+		t.Errorf("Save() after the rollback failed: %v", err)
+	}
+}
+
+// TestHistoryRepository_ListFilters tests filtering and pagination.
+func TestHistoryRepository_ListFilters(t *testing.T) {
+	ctx := context.Background()
+	db := setupHistoryTestDB(t)
+	repo := NewSQLiteHistoryRepository(db)
+
+	base := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		record := newTestHistoryRecord(fmt.Sprintf("run-%d", i))
+		record.StartTime = base.AddDate(0, 0, i)
+		record.Threads = 8 * (i%2 + 1)
+		if i == 4 {
+			record.ConnectionName = "pg_100%"
+			record.DatabaseType = string(connection.DatabaseTypePostgreSQL)
+		}
+		if i < 2 {
+			record.Purpose = "Index change on orders"
+			record.Ticket = "https://git.example.com/app/pull/4312"
+			record.Environment = "prod-replica"
+		}
+		if err := repo.Save(ctx, record); err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+	}
+
+	after := base.AddDate(0, 0, 1)
+	before := base.AddDate(0, 0, 3)
+	tests := []struct {
+		name string
+		opts repository.ListOptions
+		want int
+	}{
+		{"all", repository.ListOptions{}, 5},
+		{"threads", repository.ListOptions{Threads: 16}, 2},
+		{"database type", repository.ListOptions{DatabaseType: string(connection.DatabaseTypePostgreSQL)}, 1},
+		{"database type mysql", repository.ListOptions{DatabaseType: string(connection.DatabaseTypeMySQL)}, 4},
+		{"search literal percent", repository.ListOptions{Search: "100%"}, 1},
+		{"search template", repository.ListOptions{Search: "read_write"}, 5},
+		{"search purpose", repository.ListOptions{Search: "index change"}, 2},
+		{"search ticket", repository.ListOptions{Search: "pull/4312"}, 2},
+		{"search environment", repository.ListOptions{Search: "prod-replica"}, 2},
+		{"date range", repository.ListOptions{StartTimeAfter: &after, StartTimeBefore: &before}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := repo.Count(ctx, &tt.opts)
+			if err != nil {
+				t.Fatalf("Count() failed: %v", err)
+			}
+			if count != tt.want {
+				t.Errorf("Count() = %d, want %d", count, tt.want)
+			}
+			records, err := repo.List(ctx, &tt.opts)
+			if err != nil {
+				t.Fatalf("List() failed: %v", err)
+			}
+			if len(records) != tt.want {
+				t.Errorf("List() returned %d records, want %d", len(records), tt.want)
+			}
+		})
+	}
+
+	// Second page of two, newest first
+	page, err := repo.List(ctx, &repository.ListOptions{Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("List() page failed: %v", err)
+	}
+	if len(page) != 2 || page[0].ID != "run-2" || page[1].ID != "run-1" {
+		t.Errorf("second page = %v, want [run-2 run-1]", recordIDs(page))
+	}
+
+	// Offset without limit
+	rest, err := repo.List(ctx, &repository.ListOptions{Offset: 3})
+	if err != nil {
+		t.Fatalf("List() offset failed: %v", err)
+	}
+	if len(rest) != 2 {
+		t.Errorf("List(Offset: 3) returned %d records, want 2", len(rest))
+	}
+}
+
+func recordIDs(records []*history.Record) []string {
+	ids := make([]string, len(records))
+	for i, r := range records {
+		ids[i] = r.ID
+	}
+	return ids
+}
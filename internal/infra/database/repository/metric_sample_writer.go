@@ -0,0 +1,210 @@
+// Package repository provides SQLite repository implementations.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+)
+
+const (
+	// DefaultSampleFlushInterval is how often batched metric samples are written.
+	DefaultSampleFlushInterval = time.Second
+	// DefaultSampleBatchSize is the number of buffered samples that triggers an early write.
+	DefaultSampleBatchSize = 256
+)
+
+// errSampleWriterClosed is returned when a sample is added after Close.
+var errSampleWriterClosed = errors.New("metric sample writer closed")
+
+// insertMetricSampleQuery inserts one metric sample.
+const insertMetricSampleQuery = `
+	INSERT INTO metric_samples (
+		run_id, timestamp, phase, tps, qps, latency_avg, latency_p95, latency_p99, error_rate
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+// pendingSample is a metric sample waiting to be written.
+type pendingSample struct {
+	runID  string
+	sample execution.MetricSample
+}
+
+// metricSampleWriter buffers metric samples on a channel and writes them in
+// one transaction per flush, instead of one write per sample.
+// Implements: REQ-STORAGE-004
+type metricSampleWriter struct {
+	db        *sql.DB
+	samples   chan pendingSample
+	flushReq  chan chan error
+	mu        sync.RWMutex // Held for reading while adding, for writing while closing
+	closed    bool
+	stop      chan struct{}
+	done      chan struct{}
+	interval  time.Duration
+	batchSize int
+}
+
+// newMetricSampleWriter starts a writer that flushes every interval, or
+// earlier once batchSize samples are buffered.
+func newMetricSampleWriter(db *sql.DB, interval time.Duration, batchSize int) *metricSampleWriter {
+	if interval <= 0 {
+		interval = DefaultSampleFlushInterval
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultSampleBatchSize
+	}
+	w := &metricSampleWriter{
+		db:        db,
+		samples:   make(chan pendingSample, batchSize*4),
+		flushReq:  make(chan chan error),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		interval:  interval,
+		batchSize: batchSize,
+	}
+	go w.loop()
+	return w
+}
+
+// Add queues a sample. It blocks while the buffer is full, which slows the
+// sample producer down to the rate the database can take.
+func (w *metricSampleWriter) Add(ctx context.Context, runID string, sample execution.MetricSample) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if w.closed {
+		return errSampleWriterClosed
+	}
+	select {
+	case w.samples <- pendingSample{runID: runID, sample: sample}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("save metric sample: %w", ctx.Err())
+	}
+}
+
+// Flush writes all queued samples and waits for the write to finish.
+func (w *metricSampleWriter) Flush(ctx context.Context) error {
+	reply := make(chan error, 1)
+	select {
+	case w.flushReq <- reply:
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-reply:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close writes the queued samples and stops the writer.
+func (w *metricSampleWriter) Close() {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.stop)
+	}
+	w.mu.Unlock()
+	<-w.done
+}
+
+// loop collects samples and writes them until the writer is closed.
+func (w *metricSampleWriter) loop() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	batch := make([]pendingSample, 0, w.batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		err := w.write(batch)
+		if err != nil {
+			slog.Error("Repository: Failed to write metric samples", "count", len(batch), "error", err)
+		}
+		batch = batch[:0]
+		return err
+	}
+	drain := func() {
+		for {
+			select {
+			case s := <-w.samples:
+				batch = append(batch, s)
+			default:
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case s := <-w.samples:
+			batch = append(batch, s)
+			if len(batch) >= w.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case reply := <-w.flushReq:
+			drain()
+			reply <- flush()
+		case <-w.stop:
+			drain()
+			flush()
+			return
+		}
+	}
+}
+
+// write inserts a batch of samples in one transaction.
+func (w *metricSampleWriter) write(batch []pendingSample) error {
+	ctx := context.Background()
+	tx, err := beginRetry(ctx, w.db)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, insertMetricSampleQuery)
+	if err != nil {
+		return fmt.Errorf("prepare metric sample insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, p := range batch {
+		if _, err := stmt.ExecContext(ctx, metricSampleArgs(p.runID, p.sample)...); err != nil {
+			return fmt.Errorf("save metric sample: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit metric samples: %w", err)
+	}
+	return nil
+}
+
+// metricSampleArgs returns the insert arguments of a sample.
+func metricSampleArgs(runID string, sample execution.MetricSample) []interface{} {
+	return []interface{}{
+		runID,
+		sample.Timestamp.Format(time.RFC3339),
+		sample.Phase,
+		sample.TPS,
+		sample.QPS,
+		sample.LatencyAvg,
+		sample.LatencyP95,
+		sample.LatencyP99,
+		sample.ErrorRate,
+	}
+}
@@ -0,0 +1,102 @@
+// Package repository provides SQLite repository implementations.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+)
+
+// SQLiteProcessRepository implements the ProcessRepository interface using SQLite.
+// Implements: REQ-EXEC-006
+type SQLiteProcessRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteProcessRepository creates a new SQLite process repository.
+func NewSQLiteProcessRepository(db *sql.DB) *SQLiteProcessRepository {
+	return &SQLiteProcessRepository{db: db}
+}
+
+// Save records a started process.
+// A record with the same PID is replaced.
+func (r *SQLiteProcessRepository) Save(ctx context.Context, proc usecase.RunProcess) error {
+	recoveryJSON := ""
+	if proc.Recovery != nil {
+		data, err := json.Marshal(proc.Recovery)
+		if err != nil {
+			return fmt.Errorf("marshal run recovery: %w", err)
+		}
+		recoveryJSON = string(data)
+	}
+
+	query := `
+		INSERT OR REPLACE INTO run_processes (pid, run_id, command, boot_id, started_at, output_file, recovery_json)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := execRetry(ctx, r.db, query,
+		proc.PID,
+		proc.RunID,
+		proc.Command,
+		proc.BootID,
+		proc.StartedAt.Format(time.RFC3339),
+		proc.OutputFile,
+		recoveryJSON,
+	)
+	if err != nil {
+		return fmt.Errorf("save process: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the record of a process by its PID.
+// Deleting a PID that is not recorded is not an error.
+func (r *SQLiteProcessRepository) Delete(ctx context.Context, pid int) error {
+	if _, err := execRetry(ctx, r.db, `DELETE FROM run_processes WHERE pid = ?`, pid); err != nil {
+		return fmt.Errorf("delete process: %w", err)
+	}
+	return nil
+}
+
+// FindAll returns all recorded processes, oldest first.
+func (r *SQLiteProcessRepository) FindAll(ctx context.Context) ([]usecase.RunProcess, error) {
+	query := `
+		SELECT pid, run_id, command, boot_id, started_at, output_file, recovery_json
+		FROM run_processes
+		ORDER BY started_at ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query processes: %w", err)
+	}
+	defer rows.Close()
+
+	var procs []usecase.RunProcess
+	for rows.Next() {
+		var proc usecase.RunProcess
+		var startedAt, recoveryJSON string
+		if err := rows.Scan(&proc.PID, &proc.RunID, &proc.Command, &proc.BootID, &startedAt, &proc.OutputFile, &recoveryJSON); err != nil {
+			return nil, fmt.Errorf("scan process: %w", err)
+		}
+		proc.StartedAt, _ = time.Parse(time.RFC3339, startedAt)
+		if recoveryJSON != "" {
+			proc.Recovery = &usecase.RunRecovery{}
+			if err := json.Unmarshal([]byte(recoveryJSON), proc.Recovery); err != nil {
+				return nil, fmt.Errorf("unmarshal run recovery of process %d: %w", proc.PID, err)
+			}
+		}
+		procs = append(procs, proc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate processes: %w", err)
+	}
+
+	return procs, nil
+}
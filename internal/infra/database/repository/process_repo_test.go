@@ -0,0 +1,101 @@
+// Package repository provides unit tests for process repository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+)
+
+// setupProcessTestDB creates an in-memory SQLite database for process testing.
+func setupProcessTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS run_processes (
+			pid INTEGER PRIMARY KEY,
+			run_id TEXT NOT NULL,
+			command TEXT NOT NULL,
+			boot_id TEXT NOT NULL,
+			started_at TEXT NOT NULL,
+			output_file TEXT NOT NULL DEFAULT '',
+			recovery_json TEXT NOT NULL DEFAULT ''
+		);
+	`)
+	if err != nil {
+		db.Close()
+		t.Fatalf("create tables: %v", err)
+	}
+
+	return db
+}
+
+// TestSQLiteProcessRepository_SaveFindDelete tests the process record lifecycle.
+func TestSQLiteProcessRepository_SaveFindDelete(t *testing.T) {
+	ctx := context.Background()
+	db := setupProcessTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteProcessRepository(db)
+	startedAt := time.Now().Truncate(time.Second)
+
+	procs := []usecase.RunProcess{
+		{PID: 100, RunID: "run-1", Command: "sysbench run", BootID: "boot-a", StartedAt: startedAt},
+		{PID: 200, RunID: "run-2", Command: "hammerdbcli", StartedAt: startedAt.Add(time.Second),
+			OutputFile: "/tmp/run-2/run-output.log",
+			Recovery:   &usecase.RunRecovery{Tool: "sysbench", TemplateName: "oltp_read_write", Threads: 8, Parameters: map[string]string{"time": "60"}}},
+	}
+	for _, proc := range procs {
+		if err := repo.Save(ctx, proc); err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+	}
+
+	// Saving the same PID again replaces the record
+	procs[0].RunID = "run-3"
+	if err := repo.Save(ctx, procs[0]); err != nil {
+		t.Fatalf("Save() failed: %v", err)
+	}
+
+	found, err := repo.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() failed: %v", err)
+	}
+	if len(found) != 2 {
+		t.Fatalf("FindAll() returned %d processes, want 2", len(found))
+	}
+	if found[0].PID != 100 || found[0].RunID != "run-3" || found[0].BootID != "boot-a" {
+		t.Errorf("found[0] = %+v, want PID 100 of run-3 with boot ID boot-a", found[0])
+	}
+	if !found[0].StartedAt.Equal(startedAt) {
+		t.Errorf("StartedAt = %v, want %v", found[0].StartedAt, startedAt)
+	}
+
+	if err := repo.Delete(ctx, 100); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if err := repo.Delete(ctx, 12345); err != nil {
+		t.Errorf("Delete() of unknown PID failed: %v", err)
+	}
+
+	found, err = repo.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() failed: %v", err)
+	}
+	if len(found) != 1 || found[0].PID != 200 {
+		t.Fatalf("FindAll() after Delete = %+v, want only PID 200", found)
+	}
+	if !found[0].Recoverable() || found[0].Recovery.Threads != 8 || found[0].Recovery.Parameters["time"] != "60" {
+		t.Errorf("recovery of PID 200 = %+v, %+v, want the saved run", found[0].OutputFile, found[0].Recovery)
+	}
+}
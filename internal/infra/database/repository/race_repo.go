@@ -0,0 +1,112 @@
+// Package repository provides SQLite repository implementations.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// ErrRaceNotFound is returned when a race is not found.
+var ErrRaceNotFound = errors.New("race not found")
+
+// SQLiteRaceRepository implements the RaceRepository interface using SQLite.
+type SQLiteRaceRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteRaceRepository creates a new SQLite race repository.
+func NewSQLiteRaceRepository(db *sql.DB) *SQLiteRaceRepository {
+	return &SQLiteRaceRepository{db: db}
+}
+
+// SaveRace saves a race; an existing race with the same ID is replaced.
+func (r *SQLiteRaceRepository) SaveRace(ctx context.Context, race *history.Race) error {
+	data, err := json.Marshal(race)
+	if err != nil {
+		return fmt.Errorf("marshal race: %w", err)
+	}
+
+	query := `
+		INSERT OR REPLACE INTO history_races (
+			id, name, template_name, connection_a, connection_b, created_at, race_json
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = execRetry(ctx, r.db, query,
+		race.ID,
+		race.Name,
+		race.TemplateName,
+		race.A.ConnectionName,
+		race.B.ConnectionName,
+		race.CreatedAt.Format(time.RFC3339),
+		string(data),
+	)
+	if err != nil {
+		return fmt.Errorf("save race: %w", err)
+	}
+
+	return nil
+}
+
+// GetRace retrieves a race by ID.
+func (r *SQLiteRaceRepository) GetRace(ctx context.Context, id string) (*history.Race, error) {
+	var data string
+	err := r.db.QueryRowContext(ctx, `SELECT race_json FROM history_races WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRaceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query race: %w", err)
+	}
+
+	var race history.Race
+	if err := json.Unmarshal([]byte(data), &race); err != nil {
+		return nil, fmt.Errorf("unmarshal race %s: %w", id, err)
+	}
+	return &race, nil
+}
+
+// ListRaces retrieves all races, newest first.
+func (r *SQLiteRaceRepository) ListRaces(ctx context.Context) ([]*history.Race, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, race_json FROM history_races ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("query races: %w", err)
+	}
+	defer rows.Close()
+
+	var races []*history.Race
+	for rows.Next() {
+		var id, data string
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, fmt.Errorf("scan race: %w", err)
+		}
+		var race history.Race
+		if err := json.Unmarshal([]byte(data), &race); err != nil {
+			return nil, fmt.Errorf("unmarshal race %s: %w", id, err)
+		}
+		races = append(races, &race)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate races: %w", err)
+	}
+
+	return races, nil
+}
+
+// DeleteRace deletes a race. The history records of its runs are kept.
+func (r *SQLiteRaceRepository) DeleteRace(ctx context.Context, id string) error {
+	result, err := execRetry(ctx, r.db, `DELETE FROM history_races WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete race: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return ErrRaceNotFound
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// Package repository provides unit tests for race repository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// setupRaceTestDB creates an in-memory SQLite database for race testing.
+func setupRaceTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS history_races (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			template_name TEXT NOT NULL,
+			connection_a TEXT NOT NULL,
+			connection_b TEXT NOT NULL,
+			created_at TEXT NOT NULL,
+			race_json TEXT NOT NULL
+		);
+	`)
+	if err != nil {
+		db.Close()
+		t.Fatalf("create tables: %v", err)
+	}
+
+	return db
+}
+
+// TestSQLiteRaceRepository_SaveGetListDelete tests the race lifecycle.
+func TestSQLiteRaceRepository_SaveGetListDelete(t *testing.T) {
+	ctx := context.Background()
+	db := setupRaceTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteRaceRepository(db)
+	now := time.Now().Truncate(time.Second)
+
+	older := &history.Race{
+		ID:           "race-1",
+		Name:         "mysql-primary Benchmark",
+		TemplateName: "oltp_read_write",
+		Threads:      16,
+		CreatedAt:    now.Add(-time.Hour),
+		A:            history.RaceSide{ConnectionName: "mysql-primary", RunID: "run-a", TPS: 1000, LatencyP95: 20},
+		B:            history.RaceSide{ConnectionName: "mysql-replica", RunID: "run-b", State: "failed", ErrorMessage: "connection refused"},
+	}
+	newer := &history.Race{ID: "race-2", Name: "pg Benchmark", CreatedAt: now}
+	for _, race := range []*history.Race{older, newer} {
+		if err := repo.SaveRace(ctx, race); err != nil {
+			t.Fatalf("SaveRace() failed: %v", err)
+		}
+	}
+
+	got, err := repo.GetRace(ctx, "race-1")
+	if err != nil {
+		t.Fatalf("GetRace() failed: %v", err)
+	}
+	if got.A != older.A || got.B != older.B || !got.CreatedAt.Equal(older.CreatedAt) {
+		t.Errorf("GetRace() = %+v, want %+v", got, older)
+	}
+
+	all, err := repo.ListRaces(ctx)
+	if err != nil {
+		t.Fatalf("ListRaces() failed: %v", err)
+	}
+	if len(all) != 2 || all[0].ID != "race-2" {
+		t.Errorf("ListRaces() = %d races, want 2 newest first", len(all))
+	}
+
+	if err := repo.DeleteRace(ctx, "race-1"); err != nil {
+		t.Fatalf("DeleteRace() failed: %v", err)
+	}
+	if _, err := repo.GetRace(ctx, "race-1"); !errors.Is(err, ErrRaceNotFound) {
+		t.Errorf("GetRace(deleted) error = %v, want ErrRaceNotFound", err)
+	}
+	if err := repo.DeleteRace(ctx, "race-1"); !errors.Is(err, ErrRaceNotFound) {
+		t.Errorf("DeleteRace(deleted) error = %v, want ErrRaceNotFound", err)
+	}
+}
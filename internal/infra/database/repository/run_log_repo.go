@@ -0,0 +1,120 @@
+// Package repository provides SQLite repository implementations.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+)
+
+// SQLiteRunLogRepository implements the RunLogRepository interface using SQLite.
+// Entries are kept in run_log_entries, which does not reference the runs table,
+// so logs persist even when runs are kept in memory.
+// Implements: REQ-EXEC-005
+type SQLiteRunLogRepository struct {
+	db     *sql.DB
+	readDB *sql.DB // Read pool for queries; nil uses db
+}
+
+// NewSQLiteRunLogRepository creates a new SQLite run log repository.
+func NewSQLiteRunLogRepository(db *sql.DB) *SQLiteRunLogRepository {
+	return &SQLiteRunLogRepository{db: db}
+}
+
+// SetReadDB sets a read pool (database.OpenSQLiteReadPool) for queries,
+// so following a log does not wait behind the writes of the running benchmark.
+func (r *SQLiteRunLogRepository) SetReadDB(db *sql.DB) {
+	r.readDB = db
+}
+
+// SaveLogEntry saves a log entry for a run.
+func (r *SQLiteRunLogRepository) SaveLogEntry(ctx context.Context, runID string, entry usecase.LogEntry) error {
+	return insertLogEntry(ctx, r.db, "run_log_entries", runID, entry)
+}
+
+// FindLogEntries returns the log entries of a run matching filter, oldest first.
+func (r *SQLiteRunLogRepository) FindLogEntries(ctx context.Context, runID string, filter usecase.LogFilter) ([]usecase.LogEntry, error) {
+	db := r.db
+	if r.readDB != nil {
+		db = r.readDB
+	}
+	return findLogEntries(ctx, db, "run_log_entries", runID, filter)
+}
+
+// DeleteLogEntries deletes all log entries of a run.
+func (r *SQLiteRunLogRepository) DeleteLogEntries(ctx context.Context, runID string) error {
+	return deleteLogEntries(ctx, r.db, "run_log_entries", runID)
+}
+
+// insertLogEntry inserts a log entry into a log table.
+func insertLogEntry(ctx context.Context, db *sql.DB, table, runID string, entry usecase.LogEntry) error {
+	query := `INSERT INTO ` + table + ` (run_id, timestamp, stream, content) VALUES (?, ?, ?, ?)`
+
+	if _, err := execRetry(ctx, db, query, runID, entry.Timestamp, entry.Stream, entry.Content); err != nil {
+		return fmt.Errorf("save log entry: %w", err)
+	}
+	return nil
+}
+
+// findLogEntries queries a log table. The row ID serves as the entry ID.
+func findLogEntries(ctx context.Context, db *sql.DB, table, runID string, filter usecase.LogFilter) ([]usecase.LogEntry, error) {
+	where := []string{"run_id = ?"}
+	args := []interface{}{runID}
+
+	if filter.AfterID > 0 {
+		where = append(where, "id > ?")
+		args = append(args, filter.AfterID)
+	}
+	if len(filter.Streams) > 0 {
+		where = append(where, "stream IN (?"+strings.Repeat(", ?", len(filter.Streams)-1)+")")
+		for _, stream := range filter.Streams {
+			args = append(args, stream)
+		}
+	}
+	if filter.Search != "" {
+		// lower() folds ASCII only, so non-ASCII searches are case-sensitive
+		where = append(where, "instr(lower(content), lower(?)) > 0")
+		args = append(args, filter.Search)
+	}
+
+	query := `SELECT id, timestamp, stream, content FROM ` + table + ` WHERE ` + strings.Join(where, " AND ")
+	if filter.Tail > 0 {
+		// Take the last N entries, then restore chronological order
+		query = `SELECT * FROM (` + query + ` ORDER BY id DESC LIMIT ?) ORDER BY id ASC`
+		args = append(args, filter.Tail)
+	} else {
+		query += ` ORDER BY id ASC`
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query log entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []usecase.LogEntry{}
+	for rows.Next() {
+		var entry usecase.LogEntry
+		if err := rows.Scan(&entry.ID, &entry.Timestamp, &entry.Stream, &entry.Content); err != nil {
+			return nil, fmt.Errorf("scan log entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate log entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// deleteLogEntries deletes all entries of a run from a log table.
+func deleteLogEntries(ctx context.Context, db *sql.DB, table, runID string) error {
+	if _, err := execRetry(ctx, db, `DELETE FROM `+table+` WHERE run_id = ?`, runID); err != nil {
+		return fmt.Errorf("delete log entries: %w", err)
+	}
+	return nil
+}
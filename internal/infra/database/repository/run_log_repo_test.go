@@ -0,0 +1,107 @@
+// Package repository provides unit tests for run log repository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+)
+
+// setupRunLogTestDB creates an in-memory SQLite database for run log testing.
+func setupRunLogTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS run_log_entries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			run_id TEXT NOT NULL,
+			timestamp TEXT NOT NULL,
+			stream TEXT NOT NULL,
+			content TEXT NOT NULL
+		);
+	`)
+	if err != nil {
+		db.Close()
+		t.Fatalf("create tables: %v", err)
+	}
+
+	return db
+}
+
+// TestSQLiteRunLogRepository_FindLogEntries tests filtering, tail and follow queries.
+func TestSQLiteRunLogRepository_FindLogEntries(t *testing.T) {
+	db := setupRunLogTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteRunLogRepository(db)
+	ctx := context.Background()
+
+	streams := []string{"stdout", "stderr", "info", "error"}
+	for i := 0; i < 8; i++ {
+		entry := usecase.LogEntry{
+			Timestamp: time.Now().Format(time.RFC3339),
+			Stream:    streams[i%len(streams)],
+			Content:   fmt.Sprintf("line %d", i),
+		}
+		if i == 5 {
+			entry.Content = "FATAL: Table sbtest1 doesn't exist"
+		}
+		if err := repo.SaveLogEntry(ctx, "run-1", entry); err != nil {
+			t.Fatalf("SaveLogEntry() failed: %v", err)
+		}
+	}
+	if err := repo.SaveLogEntry(ctx, "run-2", usecase.LogEntry{Stream: "stdout", Content: "other run"}); err != nil {
+		t.Fatalf("SaveLogEntry() failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter usecase.LogFilter
+		want   []string
+	}{
+		{"all", usecase.LogFilter{}, []string{"line 0", "line 1", "line 2", "line 3", "line 4", "FATAL: Table sbtest1 doesn't exist", "line 6", "line 7"}},
+		{"streams", usecase.LogFilter{Streams: []string{"stderr", "error"}}, []string{"line 1", "line 3", "FATAL: Table sbtest1 doesn't exist", "line 7"}},
+		{"search is case-insensitive", usecase.LogFilter{Search: "fatal"}, []string{"FATAL: Table sbtest1 doesn't exist"}},
+		{"tail keeps order", usecase.LogFilter{Tail: 3}, []string{"FATAL: Table sbtest1 doesn't exist", "line 6", "line 7"}},
+		{"tail of stream", usecase.LogFilter{Streams: []string{"stdout"}, Tail: 1}, []string{"line 4"}},
+		{"after ID", usecase.LogFilter{AfterID: 6}, []string{"line 6", "line 7"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := repo.FindLogEntries(ctx, "run-1", tt.filter)
+			if err != nil {
+				t.Fatalf("FindLogEntries() failed: %v", err)
+			}
+			if len(entries) != len(tt.want) {
+				t.Fatalf("got %d entries, want %d: %v", len(entries), len(tt.want), entries)
+			}
+			for i, entry := range entries {
+				if entry.Content != tt.want[i] {
+					t.Errorf("entries[%d] = %q, want %q", i, entry.Content, tt.want[i])
+				}
+			}
+		})
+	}
+
+	if err := repo.DeleteLogEntries(ctx, "run-1"); err != nil {
+		t.Fatalf("DeleteLogEntries() failed: %v", err)
+	}
+	if entries, _ := repo.FindLogEntries(ctx, "run-1", usecase.LogFilter{}); len(entries) != 0 {
+		t.Errorf("got %d entries after delete, want 0", len(entries))
+	}
+	if entries, _ := repo.FindLogEntries(ctx, "run-2", usecase.LogFilter{}); len(entries) != 1 {
+		t.Errorf("got %d entries of other run, want 1", len(entries))
+	}
+}
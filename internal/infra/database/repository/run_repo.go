@@ -22,6 +22,8 @@ var (
 // Implements: REQ-STORAGE-001, REQ-STORAGE-004, REQ-STORAGE-005
 type SQLiteRunRepository struct {
 	db *sql.DB
+	// Writes metric samples in batches; nil writes each sample directly
+	sampleWriter *metricSampleWriter
 }
 
 // NewSQLiteRunRepository creates a new SQLite run repository.
@@ -81,7 +83,7 @@ func (r *SQLiteRunRepository) Save(ctx context.Context, run *execution.Run) erro
 			error_message = excluded.error_message
 	`
 
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = execRetry(ctx, r.db, query,
 		run.ID,
 		run.TaskID,
 		string(run.State),
@@ -267,7 +269,7 @@ func (r *SQLiteRunRepository) UpdateState(ctx context.Context, id string, state
 
 	// Update in database
 	query := `UPDATE runs SET state = ? WHERE id = ?`
-	result, err := r.db.ExecContext(ctx, query, string(state), id)
+	result, err := execRetry(ctx, r.db, query, string(state), id)
 	if err != nil {
 		return fmt.Errorf("update state: %w", err)
 	}
@@ -284,26 +286,44 @@ func (r *SQLiteRunRepository) UpdateState(ctx context.Context, id string, state
 	return nil
 }
 
+// EnableSampleBatching buffers metric samples and writes them in one
+// transaction every interval, or once batchSize samples are buffered.
+// Zero values use DefaultSampleFlushInterval and DefaultSampleBatchSize.
+// Call Close to write the remaining samples.
+func (r *SQLiteRunRepository) EnableSampleBatching(interval time.Duration, batchSize int) {
+	if r.sampleWriter == nil {
+		r.sampleWriter = newMetricSampleWriter(r.db, interval, batchSize)
+	}
+}
+
+// FlushMetricSamples writes the buffered metric samples, if batching is enabled.
+func (r *SQLiteRunRepository) FlushMetricSamples(ctx context.Context) error {
+	if r.sampleWriter == nil {
+		return nil
+	}
+	return r.sampleWriter.Flush(ctx)
+}
+
+// Close writes the buffered metric samples and stops batching.
+// It does not close the database.
+func (r *SQLiteRunRepository) Close() error {
+	if r.sampleWriter != nil {
+		r.sampleWriter.Close()
+	}
+	return nil
+}
+
 // SaveMetricSample saves a metric sample for a run.
+// With batching enabled the sample is queued and written on the next flush.
 func (r *SQLiteRunRepository) SaveMetricSample(ctx context.Context, runID string, sample execution.MetricSample) error {
-	query := `
-		INSERT INTO metric_samples (
-			run_id, timestamp, phase, tps, qps, latency_avg, latency_p95, latency_p99, error_rate
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
+	if r.sampleWriter != nil {
+		// After Close the sample is written directly
+		if err := r.sampleWriter.Add(ctx, runID, sample); !errors.Is(err, errSampleWriterClosed) {
+			return err
+		}
+	}
 
-	_, err := r.db.ExecContext(ctx, query,
-		runID,
-		sample.Timestamp.Format(time.RFC3339),
-		sample.Phase,
-		sample.TPS,
-		sample.QPS,
-		sample.LatencyAvg,
-		sample.LatencyP95,
-		sample.LatencyP99,
-		sample.ErrorRate,
-	)
-	if err != nil {
+	if _, err := execRetry(ctx, r.db, insertMetricSampleQuery, metricSampleArgs(runID, sample)...); err != nil {
 		return fmt.Errorf("save metric sample: %w", err)
 	}
 
@@ -311,7 +331,12 @@ func (r *SQLiteRunRepository) SaveMetricSample(ctx context.Context, runID string
 }
 
 // GetMetricSamples retrieves all metric samples for a run.
+// Buffered samples are written first, so the result includes them.
 func (r *SQLiteRunRepository) GetMetricSamples(ctx context.Context, runID string) ([]execution.MetricSample, error) {
+	if err := r.FlushMetricSamples(ctx); err != nil {
+		return nil, fmt.Errorf("flush metric samples: %w", err)
+	}
+
 	query := `
 		SELECT timestamp, phase, tps, qps, latency_avg, latency_p95, latency_p99, error_rate
 		FROM metric_samples
@@ -362,28 +387,28 @@ func (r *SQLiteRunRepository) GetMetricSamples(ctx context.Context, runID string
 
 // SaveLogEntry saves a log entry for a run.
 func (r *SQLiteRunRepository) SaveLogEntry(ctx context.Context, runID string, entry usecase.LogEntry) error {
-	query := `
-		INSERT INTO run_logs (run_id, timestamp, stream, content)
-		VALUES (?, ?, ?, ?)
-	`
+	return insertLogEntry(ctx, r.db, "run_logs", runID, entry)
+}
 
-	_, err := r.db.ExecContext(ctx, query,
-		runID,
-		entry.Timestamp,
-		entry.Stream,
-		entry.Content,
-	)
-	if err != nil {
-		return fmt.Errorf("save log entry: %w", err)
-	}
+// FindLogEntries returns the log entries of a run matching filter, oldest first.
+func (r *SQLiteRunRepository) FindLogEntries(ctx context.Context, runID string, filter usecase.LogFilter) ([]usecase.LogEntry, error) {
+	return findLogEntries(ctx, r.db, "run_logs", runID, filter)
+}
 
-	return nil
+// DeleteLogEntries deletes all log entries of a run.
+func (r *SQLiteRunRepository) DeleteLogEntries(ctx context.Context, runID string) error {
+	return deleteLogEntries(ctx, r.db, "run_logs", runID)
 }
 
 // Delete deletes a run by its ID.
 func (r *SQLiteRunRepository) Delete(ctx context.Context, id string) error {
+	// Buffered samples of the run must not be written after it is deleted
+	if err := r.FlushMetricSamples(ctx); err != nil {
+		return fmt.Errorf("flush metric samples: %w", err)
+	}
+
 	query := `DELETE FROM runs WHERE id = ?`
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := execRetry(ctx, r.db, query, id)
 	if err != nil {
 		return fmt.Errorf("delete run: %w", err)
 	}
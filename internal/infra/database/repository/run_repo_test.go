@@ -393,3 +393,73 @@ func TestSQLiteRunRepository_Delete_NotFound(t *testing.T) {
 		t.Errorf("Expected ErrRunNotFound, got: %v", err)
 	}
 }
+
+// TestSQLiteRunRepository_SampleBatching tests buffering metric samples and writing them in batches.
+func TestSQLiteRunRepository_SampleBatching(t *testing.T) {
+	ctx := context.Background()
+	db := setupRunTestDB(t)
+	defer db.Close()
+	// The in-memory database exists on one connection only
+	db.SetMaxOpenConns(1)
+
+	repo := NewSQLiteRunRepository(db)
+	repo.EnableSampleBatching(time.Hour, 3)
+	defer repo.Close()
+
+	runID := uuid.New().String()
+	countSamples := func() int {
+		t.Helper()
+		var count int
+		if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM metric_samples WHERE run_id = ?", runID).Scan(&count); err != nil {
+			t.Fatalf("Query metric samples failed: %v", err)
+		}
+		return count
+	}
+	save := func(tps float64) {
+		t.Helper()
+		if err := repo.SaveMetricSample(ctx, runID, execution.MetricSample{Timestamp: time.Now(), Phase: "run", TPS: tps}); err != nil {
+			t.Fatalf("SaveMetricSample() failed: %v", err)
+		}
+	}
+
+	save(100)
+	save(200)
+	if err := repo.FlushMetricSamples(ctx); err != nil {
+		t.Fatalf("FlushMetricSamples() failed: %v", err)
+	}
+	if got := countSamples(); got != 2 {
+		t.Errorf("after FlushMetricSamples() count = %d, want 2", got)
+	}
+
+	// A full batch is written without waiting for the interval
+	for i := 0; i < 3; i++ {
+		save(300)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for countSamples() != 5 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := countSamples(); got != 5 {
+		t.Errorf("after a full batch count = %d, want 5", got)
+	}
+
+	// Reads include samples still in the buffer
+	save(400)
+	samples, err := repo.GetMetricSamples(ctx, runID)
+	if err != nil {
+		t.Fatalf("GetMetricSamples() failed: %v", err)
+	}
+	if len(samples) != 6 {
+		t.Errorf("GetMetricSamples() returned %d samples, want 6", len(samples))
+	}
+
+	// Close writes the rest; later samples are written directly
+	save(500)
+	if err := repo.Close(); err != nil {
+		t.Fatalf("Close() failed: %v", err)
+	}
+	save(600)
+	if got := countSamples(); got != 8 {
+		t.Errorf("after Close() count = %d, want 8", got)
+	}
+}
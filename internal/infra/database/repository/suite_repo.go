@@ -0,0 +1,231 @@
+// Package repository provides SQLite repository implementations.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/suite"
+)
+
+// SQLiteSuiteRepository implements the SuiteRepository interface using SQLite.
+type SQLiteSuiteRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteSuiteRepository creates a new SQLite suite repository.
+func NewSQLiteSuiteRepository(db *sql.DB) *SQLiteSuiteRepository {
+	return &SQLiteSuiteRepository{db: db}
+}
+
+// SaveSuite saves a suite; an existing suite with the same ID is replaced.
+func (r *SQLiteSuiteRepository) SaveSuite(ctx context.Context, s *suite.Suite) error {
+	steps, err := json.Marshal(s.Steps)
+	if err != nil {
+		return fmt.Errorf("marshal steps: %w", err)
+	}
+
+	query := `
+		INSERT INTO suites (id, name, description, steps, group_by, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			name = excluded.name,
+			description = excluded.description,
+			steps = excluded.steps,
+			group_by = excluded.group_by,
+			updated_at = excluded.updated_at
+	`
+
+	_, err = execRetry(ctx, r.db, query,
+		s.ID,
+		s.Name,
+		s.Description,
+		string(steps),
+		s.GroupBy,
+		s.CreatedAt.Format(time.RFC3339),
+		s.UpdatedAt.Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("save suite: %w", err)
+	}
+
+	return nil
+}
+
+// FindSuiteByID finds a suite by its ID.
+func (r *SQLiteSuiteRepository) FindSuiteByID(ctx context.Context, id string) (*suite.Suite, error) {
+	query := `
+		SELECT id, name, description, steps, group_by, created_at, updated_at
+		FROM suites
+		WHERE id = ?
+	`
+
+	s, err := scanSuite(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, usecase.ErrSuiteNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// FindAllSuites returns all suites, ordered by name.
+func (r *SQLiteSuiteRepository) FindAllSuites(ctx context.Context) ([]*suite.Suite, error) {
+	query := `
+		SELECT id, name, description, steps, group_by, created_at, updated_at
+		FROM suites
+		ORDER BY name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query suites: %w", err)
+	}
+	defer rows.Close()
+
+	var suites []*suite.Suite
+	for rows.Next() {
+		s, err := scanSuite(rows)
+		if err != nil {
+			return nil, err
+		}
+		suites = append(suites, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate suites: %w", err)
+	}
+
+	return suites, nil
+}
+
+// DeleteSuite deletes a suite and its run records.
+func (r *SQLiteSuiteRepository) DeleteSuite(ctx context.Context, id string) error {
+	tx, err := beginRetry(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM suite_runs WHERE suite_id = ?`, id); err != nil {
+		return fmt.Errorf("delete suite runs: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, `DELETE FROM suites WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("delete suite: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return usecase.ErrSuiteNotFound
+	}
+
+	return tx.Commit()
+}
+
+// SaveSuiteRun saves a suite run; an existing run with the same ID is replaced.
+func (r *SQLiteSuiteRepository) SaveSuiteRun(ctx context.Context, run *suite.Run) error {
+	runs, err := json.Marshal(run.Runs)
+	if err != nil {
+		return fmt.Errorf("marshal runs: %w", err)
+	}
+
+	var completedAt interface{}
+	if run.CompletedAt != nil {
+		completedAt = run.CompletedAt.Format(time.RFC3339)
+	}
+
+	query := `
+		INSERT OR REPLACE INTO suite_runs (
+			id, suite_id, suite_name, state, started_at, completed_at,
+			total_runs, runs, report_path, error_message
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err = execRetry(ctx, r.db, query,
+		run.ID,
+		run.SuiteID,
+		run.SuiteName,
+		string(run.State),
+		run.StartedAt.Format(time.RFC3339),
+		completedAt,
+		run.TotalRuns,
+		string(runs),
+		run.ReportPath,
+		run.ErrorMessage,
+	)
+	if err != nil {
+		return fmt.Errorf("save suite run: %w", err)
+	}
+
+	return nil
+}
+
+// FindSuiteRuns returns the runs of a suite, newest first.
+func (r *SQLiteSuiteRepository) FindSuiteRuns(ctx context.Context, suiteID string) ([]*suite.Run, error) {
+	query := `
+		SELECT id, suite_id, suite_name, state, started_at, completed_at,
+			total_runs, runs, report_path, error_message
+		FROM suite_runs
+		WHERE suite_id = ?
+		ORDER BY started_at DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, suiteID)
+	if err != nil {
+		return nil, fmt.Errorf("query suite runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*suite.Run
+	for rows.Next() {
+		var run suite.Run
+		var state, startedAt, refs string
+		var completedAt sql.NullString
+		if err := rows.Scan(&run.ID, &run.SuiteID, &run.SuiteName, &state, &startedAt, &completedAt,
+			&run.TotalRuns, &refs, &run.ReportPath, &run.ErrorMessage); err != nil {
+			return nil, fmt.Errorf("scan suite run: %w", err)
+		}
+		run.State = suite.RunState(state)
+		run.StartedAt, _ = time.Parse(time.RFC3339, startedAt)
+		if completedAt.Valid {
+			t, _ := time.Parse(time.RFC3339, completedAt.String)
+			run.CompletedAt = &t
+		}
+		if err := json.Unmarshal([]byte(refs), &run.Runs); err != nil {
+			return nil, fmt.Errorf("unmarshal runs of suite run %s: %w", run.ID, err)
+		}
+		runs = append(runs, &run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate suite runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanSuite scans a suite row.
+func scanSuite(row rowScanner) (*suite.Suite, error) {
+	var s suite.Suite
+	var steps, createdAt, updatedAt string
+	if err := row.Scan(&s.ID, &s.Name, &s.Description, &steps, &s.GroupBy, &createdAt, &updatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scan suite: %w", err)
+	}
+	if err := json.Unmarshal([]byte(steps), &s.Steps); err != nil {
+		return nil, fmt.Errorf("unmarshal steps of suite %s: %w", s.Name, err)
+	}
+	s.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	s.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return &s, nil
+}
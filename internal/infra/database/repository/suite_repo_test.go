@@ -0,0 +1,183 @@
+// Package repository provides unit tests for suite repository.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/suite"
+)
+
+// setupSuiteTestDB creates an in-memory SQLite database for suite testing.
+func setupSuiteTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS suites (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL UNIQUE,
+			description TEXT NOT NULL DEFAULT '',
+			steps TEXT NOT NULL,
+			group_by TEXT NOT NULL DEFAULT 'threads',
+			created_at TEXT NOT NULL,
+			updated_at TEXT NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS suite_runs (
+			id TEXT PRIMARY KEY,
+			suite_id TEXT NOT NULL,
+			suite_name TEXT NOT NULL,
+			state TEXT NOT NULL,
+			started_at TEXT NOT NULL,
+			completed_at TEXT,
+			total_runs INTEGER NOT NULL,
+			runs TEXT NOT NULL,
+			report_path TEXT NOT NULL DEFAULT '',
+			error_message TEXT NOT NULL DEFAULT '',
+			FOREIGN KEY (suite_id) REFERENCES suites(id) ON DELETE CASCADE
+		);
+	`)
+	if err != nil {
+		db.Close()
+		t.Fatalf("create tables: %v", err)
+	}
+
+	return db
+}
+
+// TestSQLiteSuiteRepository_SaveFindDelete tests the suite lifecycle.
+func TestSQLiteSuiteRepository_SaveFindDelete(t *testing.T) {
+	ctx := context.Background()
+	db := setupSuiteTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteSuiteRepository(db)
+	now := time.Now().Truncate(time.Second)
+
+	scaling := &suite.Suite{
+		ID:      "suite-1",
+		Name:    "thread scaling",
+		GroupBy: "threads",
+		Steps: []suite.Step{
+			{ConnectionID: "conn-1", TemplateID: "oltp", Parameters: map[string]interface{}{"threads": 8}, Repetitions: 3, CooldownSeconds: 60},
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	smoke := &suite.Suite{ID: "suite-2", Name: "a smoke test", Steps: scaling.Steps, CreatedAt: now, UpdatedAt: now}
+	for _, s := range []*suite.Suite{scaling, smoke} {
+		if err := repo.SaveSuite(ctx, s); err != nil {
+			t.Fatalf("SaveSuite() failed: %v", err)
+		}
+	}
+
+	// Saving again updates the suite in place
+	scaling.Description = "8 threads, three times"
+	if err := repo.SaveSuite(ctx, scaling); err != nil {
+		t.Fatalf("SaveSuite(update) failed: %v", err)
+	}
+
+	got, err := repo.FindSuiteByID(ctx, "suite-1")
+	if err != nil {
+		t.Fatalf("FindSuiteByID() failed: %v", err)
+	}
+	if got.Description != scaling.Description || len(got.Steps) != 1 || got.Steps[0].Repetitions != 3 || got.Steps[0].CooldownSeconds != 60 {
+		t.Errorf("FindSuiteByID() = %+v, want %+v", got, scaling)
+	}
+	if threads := got.Steps[0].Parameters["threads"]; threads != float64(8) {
+		t.Errorf("step threads = %v, want 8", threads)
+	}
+	if !got.CreatedAt.Equal(now) {
+		t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, now)
+	}
+
+	all, err := repo.FindAllSuites(ctx)
+	if err != nil {
+		t.Fatalf("FindAllSuites() failed: %v", err)
+	}
+	if len(all) != 2 || all[0].Name != "a smoke test" {
+		t.Errorf("FindAllSuites() = %d suites, want 2 ordered by name", len(all))
+	}
+
+	if err := repo.DeleteSuite(ctx, "suite-2"); err != nil {
+		t.Fatalf("DeleteSuite() failed: %v", err)
+	}
+	if _, err := repo.FindSuiteByID(ctx, "suite-2"); !errors.Is(err, usecase.ErrSuiteNotFound) {
+		t.Errorf("FindSuiteByID(deleted) error = %v, want ErrSuiteNotFound", err)
+	}
+	if err := repo.DeleteSuite(ctx, "suite-2"); !errors.Is(err, usecase.ErrSuiteNotFound) {
+		t.Errorf("DeleteSuite(deleted) error = %v, want ErrSuiteNotFound", err)
+	}
+}
+
+// TestSQLiteSuiteRepository_SuiteRuns tests saving and listing suite runs.
+func TestSQLiteSuiteRepository_SuiteRuns(t *testing.T) {
+	ctx := context.Background()
+	db := setupSuiteTestDB(t)
+	defer db.Close()
+
+	repo := NewSQLiteSuiteRepository(db)
+	now := time.Now().Truncate(time.Second)
+	s := &suite.Suite{ID: "suite-1", Name: "scaling", CreatedAt: now, UpdatedAt: now}
+	if err := repo.SaveSuite(ctx, s); err != nil {
+		t.Fatalf("SaveSuite() failed: %v", err)
+	}
+
+	older := &suite.Run{ID: "sr-1", SuiteID: s.ID, SuiteName: s.Name, State: suite.StateRunning, StartedAt: now.Add(-time.Hour), TotalRuns: 2}
+	newer := &suite.Run{ID: "sr-2", SuiteID: s.ID, SuiteName: s.Name, State: suite.StateRunning, StartedAt: now, TotalRuns: 2}
+	for _, run := range []*suite.Run{older, newer} {
+		if err := repo.SaveSuiteRun(ctx, run); err != nil {
+			t.Fatalf("SaveSuiteRun() failed: %v", err)
+		}
+	}
+
+	// Saving again records the progress of the run
+	older.State = suite.StateCompleted
+	older.CompletedAt = &now
+	older.ReportPath = "/tmp/suite_scaling.md"
+	older.Runs = []suite.RunRef{
+		{Step: 0, Repetition: 1, RunID: "run-1", State: execution.StateCompleted},
+		{Step: 0, Repetition: 2, RunID: "run-2", State: execution.StateCompleted},
+	}
+	if err := repo.SaveSuiteRun(ctx, older); err != nil {
+		t.Fatalf("SaveSuiteRun(update) failed: %v", err)
+	}
+
+	runs, err := repo.FindSuiteRuns(ctx, s.ID)
+	if err != nil {
+		t.Fatalf("FindSuiteRuns() failed: %v", err)
+	}
+	if len(runs) != 2 || runs[0].ID != "sr-2" {
+		t.Fatalf("FindSuiteRuns() = %d runs, want 2 newest first", len(runs))
+	}
+	got := runs[1]
+	if got.State != suite.StateCompleted || got.CompletedAt == nil || got.ReportPath != older.ReportPath {
+		t.Errorf("suite run = %+v, want %+v", got, older)
+	}
+	if ids := got.CompletedRunIDs(); len(ids) != 2 || ids[1] != "run-2" {
+		t.Errorf("CompletedRunIDs() = %v, want [run-1 run-2]", ids)
+	}
+	if runs[0].CompletedAt != nil {
+		t.Errorf("running suite run has CompletedAt %v", runs[0].CompletedAt)
+	}
+
+	// Deleting the suite removes its runs
+	if err := repo.DeleteSuite(ctx, s.ID); err != nil {
+		t.Fatalf("DeleteSuite() failed: %v", err)
+	}
+	if runs, _ := repo.FindSuiteRuns(ctx, s.ID); len(runs) != 0 {
+		t.Errorf("FindSuiteRuns(deleted suite) = %d runs, want 0", len(runs))
+	}
+}
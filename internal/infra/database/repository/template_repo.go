@@ -55,7 +55,7 @@ func (r *TemplateRepository) Save(ctx context.Context, tmpl *template.Template)
 		return fmt.Errorf("failed to marshal database types: %w", err)
 	}
 
-	_, err = r.db.ExecContext(ctx, query,
+	_, err = execRetry(ctx, r.db, query,
 		tmpl.ID,
 		tmpl.Name,
 		tmpl.Description,
@@ -226,7 +226,7 @@ func (r *TemplateRepository) Delete(ctx context.Context, id string) error {
 	}
 
 	// Delete the template
-	result, err := r.db.ExecContext(ctx, "DELETE FROM templates WHERE id = ?", id)
+	result, err := execRetry(ctx, r.db, "DELETE FROM templates WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("failed to delete template: %w", err)
 	}
@@ -270,7 +270,7 @@ func (r *TemplateRepository) LoadBuiltinTemplates(ctx context.Context, templates
 				is_builtin = excluded.is_builtin
 		`
 
-		_, err = r.db.ExecContext(ctx, query,
+		_, err = execRetry(ctx, r.db, query,
 			tmpl.ID,
 			tmpl.Name,
 			tmpl.Description,
@@ -1,6 +1,9 @@
 -- DB-BenchMind Database Schema
 -- SQLite Database (modernc.org/sqlite - no CGO)
 -- WAL mode enabled for better concurrency
+--
+-- Baseline schema (version 1), executed at every startup. Do not change
+-- existing tables here: add a versioned file under migrations/ instead.
 
 -- Enable WAL mode and optimize for performance
 PRAGMA journal_mode = WAL;
@@ -164,6 +167,110 @@ CREATE INDEX IF NOT EXISTS idx_history_records_database_type ON history_records(
 CREATE INDEX IF NOT EXISTS idx_history_records_start_time ON history_records(start_time DESC);
 CREATE INDEX IF NOT EXISTS idx_history_records_tps ON history_records(tps DESC);
 
+-- =============================================================================
+-- Table 6.6: history_record_tags
+-- 历史记录标签表（用于按标签过滤，标签同时保存在 record_json 中）
+-- =============================================================================
+CREATE TABLE IF NOT EXISTS history_record_tags (
+    record_id TEXT NOT NULL,  -- history_records.id
+    tag TEXT NOT NULL,  -- User-defined tag (e.g. "innodb_buffer_pool=32G")
+    PRIMARY KEY (record_id, tag)
+);
+
+-- Index for history_record_tags
+CREATE INDEX IF NOT EXISTS idx_history_record_tags_tag ON history_record_tags(tag);
+
+-- =============================================================================
+-- Table 6.7: run_processes
+-- 运行进程表（记录基准测试工具进程，崩溃重启后用于清理遗留进程）
+-- =============================================================================
+CREATE TABLE IF NOT EXISTS run_processes (
+    pid INTEGER PRIMARY KEY,  -- 进程 ID（同时是进程组 ID）
+    run_id TEXT NOT NULL,
+    command TEXT NOT NULL,  -- 启动的命令（仅用于日志）
+    boot_id TEXT NOT NULL,  -- 进程启动时的系统启动 ID，未知时为空
+    started_at TEXT NOT NULL  -- ISO 8601 format
+);
+
+-- =============================================================================
+-- Table 6.8: run_log_entries
+-- 运行日志持久化表（GUI 的运行记录只保存在内存中，因此不引用 runs 表；
+-- run_id 同时是历史记录 ID，删除历史记录时一并删除）
+-- =============================================================================
+CREATE TABLE IF NOT EXISTS run_log_entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    run_id TEXT NOT NULL,
+    timestamp TEXT NOT NULL,  -- ISO 8601 format
+    stream TEXT NOT NULL,  -- 'stdout', 'stderr', 'info' or 'error'
+    content TEXT NOT NULL
+);
+
+-- Index for run_log_entries
+CREATE INDEX IF NOT EXISTS idx_run_log_entries_run_id ON run_log_entries(run_id, id);
+
+-- =============================================================================
+-- Table 6.9: suites
+-- 测试套件表（按顺序执行的多个测试步骤；模板保存在内存中，因此步骤以 JSON 保存且不引用 templates 表）
+-- =============================================================================
+CREATE TABLE IF NOT EXISTS suites (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    steps TEXT NOT NULL,  -- JSON 数组：连接、模板、参数、重复次数、冷却时间
+    group_by TEXT NOT NULL DEFAULT 'threads',  -- 最终对比报告的分组字段
+    created_at TEXT NOT NULL,  -- ISO 8601 format
+    updated_at TEXT NOT NULL
+);
+
+-- =============================================================================
+-- Table 6.10: suite_runs
+-- 测试套件运行记录表（各次运行的 ID 即历史记录 ID）
+-- =============================================================================
+CREATE TABLE IF NOT EXISTS suite_runs (
+    id TEXT PRIMARY KEY,
+    suite_id TEXT NOT NULL,
+    suite_name TEXT NOT NULL,
+    state TEXT NOT NULL,  -- 'running', 'completed', 'failed', 'cancelled'
+    started_at TEXT NOT NULL,  -- ISO 8601 format
+    completed_at TEXT,
+    total_runs INTEGER NOT NULL,  -- 计划的运行次数
+    runs TEXT NOT NULL,  -- JSON 数组：已启动的运行（步骤、重复序号、运行 ID、状态）
+    report_path TEXT NOT NULL DEFAULT '',  -- 导出的对比报告路径
+    error_message TEXT NOT NULL DEFAULT '',
+    FOREIGN KEY (suite_id) REFERENCES suites(id) ON DELETE CASCADE
+);
+
+-- Index for suite_runs
+CREATE INDEX IF NOT EXISTS idx_suite_runs_suite_id ON suite_runs(suite_id, started_at DESC);
+
+-- =============================================================================
+-- Table 6.11: history_aggregates
+-- 重复运行汇总表（同一任务运行 N 次的均值/标准差/变异系数和离群运行；
+-- 各次运行的历史记录带有 repeat:<id> 标签）
+-- =============================================================================
+CREATE TABLE IF NOT EXISTS history_aggregates (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,  -- 任务名称
+    connection_name TEXT NOT NULL,
+    template_name TEXT NOT NULL,
+    created_at TEXT NOT NULL,  -- ISO 8601 format
+    aggregate_json TEXT NOT NULL  -- 完整汇总 JSON（运行 ID、离群运行、各指标统计）
+);
+
+-- Index for history_aggregates
+CREATE INDEX IF NOT EXISTS idx_history_aggregates_created_at ON history_aggregates(created_at DESC);
+
+-- =============================================================================
+-- Table 6.12: history_record_validity
+-- 历史记录有效性表（用户定义的合理性检查结果，用于过滤不参与对比的无效运行；
+-- 各项检查结果同时保存在 record_json 中，未检查的记录视为有效）
+-- =============================================================================
+CREATE TABLE IF NOT EXISTS history_record_validity (
+    record_id TEXT PRIMARY KEY,  -- history_records.id
+    valid INTEGER NOT NULL,  -- 1 = 通过全部检查，0 = 至少一项检查未通过
+    checked_at TEXT NOT NULL  -- ISO 8601 format
+);
+
 -- =============================================================================
 -- Table 7: reports
 -- 报告导出记录表
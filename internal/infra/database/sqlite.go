@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"embed"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"time"
 
 	_ "modernc.org/sqlite"
 )
@@ -14,10 +16,36 @@ import (
 //go:embed schema.sql
 var schemaFS embed.FS
 
+const (
+	// BusyTimeout 等待其他连接（或进程，如 GUI 运行时的 CLI）释放锁的时间
+	BusyTimeout = 5 * time.Second
+	// DefaultReadPoolSize 只读连接池默认连接数
+	DefaultReadPoolSize = 4
+)
+
+// sqliteDSN 构造连接串；_pragma 对连接池中每个新连接生效
+// readOnly: 只读连接（query_only），不修改日志模式
+func sqliteDSN(dbPath string, readOnly bool) string {
+	q := url.Values{}
+	q.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", BusyTimeout.Milliseconds()))
+	q.Add("_pragma", "foreign_keys(1)")
+	q.Add("_pragma", "synchronous(NORMAL)")
+	if readOnly {
+		q.Add("_pragma", "query_only(1)")
+	} else {
+		q.Add("_pragma", "journal_mode(WAL)")
+		// 写事务开始时即获取写锁，锁冲突在 BEGIN 时等待，而不是在提交时失败
+		q.Set("_txlock", "immediate")
+	}
+	return "file:" + dbPath + "?" + q.Encode()
+}
+
 // InitializeSQLite 初始化 SQLite 数据库
 // ctx: 上下文（支持取消）
 // dbPath: 数据库文件路径（如 "./data/db-benchmind.db"）
-// 返回: 数据库连接对象（单连接池）或错误
+// 返回: 数据库连接对象（单连接池，所有写入经由该连接串行执行）或错误
+//
+// 并发读取（如 GUI 页面在基准测试写入时查询历史记录）使用 OpenSQLiteReadPool
 func InitializeSQLite(ctx context.Context, dbPath string) (*sql.DB, error) {
 	// 1. 创建目录
 	dbDir := filepath.Dir(dbPath)
@@ -25,14 +53,13 @@ func InitializeSQLite(ctx context.Context, dbPath string) (*sql.DB, error) {
 		return nil, fmt.Errorf("create db directory: %w", err)
 	}
 
-	// 2. 连接数据库（启用 WAL 和外键）
-	dsn := fmt.Sprintf("file:%s?_journal_mode=WAL&_foreign_keys=on&_cache_size=64000&_synchronous=normal", dbPath)
-	db, err := sql.Open("sqlite", dsn)
+	// 2. 连接数据库（启用 WAL、外键和 busy_timeout）
+	db, err := sql.Open("sqlite", sqliteDSN(dbPath, false))
 	if err != nil {
 		return nil, fmt.Errorf("open sqlite: %w", err)
 	}
 
-	// 3. 配置单连接池
+	// 3. 配置单连接池（唯一的写连接）
 	db.SetMaxOpenConns(1)
 	db.SetMaxIdleConns(1)
 
@@ -49,7 +76,13 @@ func InitializeSQLite(ctx context.Context, dbPath string) (*sql.DB, error) {
 		return nil, fmt.Errorf("execute schema: %w", err)
 	}
 
-	// 5. 验证连接
+	// 5. 执行版本化迁移
+	if _, err := Migrate(ctx, db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrate schema: %w", err)
+	}
+
+	// 6. 验证连接
 	if err := db.PingContext(ctx); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("ping database: %w", err)
@@ -57,3 +90,29 @@ func InitializeSQLite(ctx context.Context, dbPath string) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// OpenSQLiteReadPool 打开只读连接池
+// ctx: 上下文（支持取消）
+// dbPath: 已由 InitializeSQLite 初始化的数据库文件路径
+// size: 最大连接数（<= 0 时使用 DefaultReadPoolSize）
+// 返回: 只读连接池或错误
+//
+// WAL 模式下读取不阻塞写入，也不等待写连接上排队的写入
+func OpenSQLiteReadPool(ctx context.Context, dbPath string, size int) (*sql.DB, error) {
+	if size <= 0 {
+		size = DefaultReadPoolSize
+	}
+
+	db, err := sql.Open("sqlite", sqliteDSN(dbPath, true))
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite read pool: %w", err)
+	}
+	db.SetMaxOpenConns(size)
+	db.SetMaxIdleConns(size)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+	return db, nil
+}
@@ -152,3 +152,71 @@ func TestInitializeSQLite_ReopenExisting(t *testing.T) {
 		t.Errorf("Expected 7 templates after reopen, got %d", count)
 	}
 }
+
+// Test 7: 测试 busy_timeout 已设置
+func TestInitializeSQLite_BusyTimeout(t *testing.T) {
+	db, err := InitializeSQLite(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("InitializeSQLite failed: %v", err)
+	}
+	defer db.Close()
+
+	var timeout int64
+	if err := db.QueryRow("PRAGMA busy_timeout").Scan(&timeout); err != nil {
+		t.Fatalf("Failed to query busy_timeout: %v", err)
+	}
+	if timeout != BusyTimeout.Milliseconds() {
+		t.Errorf("Expected busy_timeout=%d, got %d", BusyTimeout.Milliseconds(), timeout)
+	}
+}
+
+// Test 8: 测试只读连接池读取写连接的数据并拒绝写入
+func TestOpenSQLiteReadPool(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	db, err := InitializeSQLite(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("InitializeSQLite failed: %v", err)
+	}
+	defer db.Close()
+
+	readDB, err := OpenSQLiteReadPool(ctx, dbPath, 0)
+	if err != nil {
+		t.Fatalf("OpenSQLiteReadPool failed: %v", err)
+	}
+	defer readDB.Close()
+
+	if got := readDB.Stats().MaxOpenConnections; got != DefaultReadPoolSize {
+		t.Errorf("Expected %d max connections, got %d", DefaultReadPoolSize, got)
+	}
+
+	// 写连接的事务未提交时，读连接不等待
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx failed: %v", err)
+	}
+	if _, err := tx.Exec("DELETE FROM templates"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	var count int
+	if err := readDB.QueryRow("SELECT COUNT(*) FROM templates").Scan(&count); err != nil {
+		t.Fatalf("Failed to read during write: %v", err)
+	}
+	if count != 7 {
+		t.Errorf("Expected 7 templates before commit, got %d", count)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if err := readDB.QueryRow("SELECT COUNT(*) FROM templates").Scan(&count); err != nil {
+		t.Fatalf("Failed to read after commit: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected 0 templates after commit, got %d", count)
+	}
+
+	if _, err := readDB.Exec("DELETE FROM templates"); err == nil {
+		t.Error("Expected write on read pool to fail")
+	}
+}
@@ -0,0 +1,259 @@
+// Package dbsnapshot captures the configuration of a target database and its
+// server, e.g. SHOW GLOBAL VARIABLES on MySQL or pg_settings on PostgreSQL,
+// and monitors the replication lag of its replicas during a run.
+package dbsnapshot
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
+)
+
+// DefaultTimeout bounds a capture so that a slow server cannot hold up the run.
+const DefaultTimeout = 30 * time.Second
+
+// Capturer captures database configuration snapshots.
+type Capturer struct {
+	timeout time.Duration
+}
+
+// NewCapturer creates a new capturer with DefaultTimeout.
+func NewCapturer() *Capturer {
+	return &Capturer{timeout: DefaultTimeout}
+}
+
+// probe describes how the configuration of one database type is read.
+type probe struct {
+	driver string
+	// settings returns one row per setting: name, value.
+	settings string
+	// server fills the version and server information of the snapshot.
+	server func(ctx context.Context, db *sql.DB, s *dbconfig.Snapshot)
+}
+
+var probes = map[connection.DatabaseType]probe{
+	connection.DatabaseTypeMySQL: {
+		driver:   "mysql",
+		settings: "SHOW GLOBAL VARIABLES",
+		server:   mysqlServer,
+	},
+	connection.DatabaseTypePostgreSQL: {
+		driver:   "postgres",
+		settings: "SELECT name, setting || COALESCE(' ' || unit, '') FROM pg_settings",
+		server:   postgresServer,
+	},
+	connection.DatabaseTypeOracle: {
+		driver:   "oracle",
+		settings: "SELECT name, value FROM v$parameter",
+		server:   oracleServer,
+	},
+	connection.DatabaseTypeSQLServer: {
+		driver:   "sqlserver",
+		settings: "SELECT name, CAST(value_in_use AS NVARCHAR(256)) FROM sys.configurations",
+		server:   sqlServerServer,
+	},
+}
+
+// Capture reads the configuration of the database behind conn.
+// Failing to read the settings fails the capture; server information that
+// cannot be read is recorded in the snapshot's Errors instead.
+func (c *Capturer) Capture(ctx context.Context, conn connection.Connection) (*dbconfig.Snapshot, error) {
+	p, ok := probes[conn.GetType()]
+	if !ok {
+		return nil, fmt.Errorf("unsupported database type: %s", conn.GetType())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	dsn, closeTunnel, err := dataSourceName(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	defer closeTunnel()
+
+	db, err := sql.Open(p.driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+
+	settings, err := readSettings(ctx, db, p.settings)
+	if err != nil {
+		return nil, fmt.Errorf("read settings: %w", err)
+	}
+
+	snapshot := &dbconfig.Snapshot{
+		CapturedAt:   time.Now(),
+		DatabaseType: string(conn.GetType()),
+		Settings:     settings,
+	}
+	p.server(ctx, db, snapshot)
+
+	slog.Info("Snapshot: Captured database configuration",
+		"connection", conn.GetName(), "settings", len(settings), "errors", len(snapshot.Errors))
+	return snapshot, nil
+}
+
+// dataSourceName returns the DSN of conn, through an SSH tunnel if configured.
+// The returned function closes the tunnel.
+func dataSourceName(ctx context.Context, conn connection.Connection) (string, func(), error) {
+	noop := func() {}
+
+	switch c := conn.(type) {
+	case *connection.MySQLConnection:
+		copied := *c
+		copied.Database = "" // The settings are global; do not depend on the benchmark database
+		closeTunnel, err := tunnel(ctx, c.SSH, &copied.Host, &copied.Port)
+		if err != nil {
+			return "", noop, err
+		}
+		tlsParams, err := c.DSNTLSParams() // The TLS host name is that of the server, not of the tunnel
+		if err != nil {
+			closeTunnel()
+			return "", noop, err
+		}
+		if tlsParams == "" {
+			tlsParams = "?tls=preferred"
+		}
+		return copied.GetDSNWithPassword() + tlsParams, closeTunnel, nil
+	case *connection.PostgreSQLConnection:
+		copied := *c
+		closeTunnel, err := tunnel(ctx, c.SSH, &copied.Host, &copied.Port)
+		if err != nil {
+			return "", noop, err
+		}
+		return copied.GetDSNWithPassword(), closeTunnel, nil
+	case *connection.OracleConnection:
+		copied := *c
+		closeTunnel, err := tunnel(ctx, c.SSH, &copied.Host, &copied.Port)
+		if err != nil {
+			return "", noop, err
+		}
+		return copied.GetDSNWithPassword(), closeTunnel, nil
+	case *connection.SQLServerConnection:
+		return c.GetDSNWithPassword(), noop, nil
+	default:
+		return "", noop, fmt.Errorf("unsupported connection type: %T", conn)
+	}
+}
+
+// tunnel opens the SSH tunnel described by config, if enabled, and points
+// host and port at its local end.
+func tunnel(ctx context.Context, config *connection.SSHTunnelConfig, host *string, port *int) (func(), error) {
+	if config == nil || !config.Enabled {
+		return func() {}, nil
+	}
+
+	t, err := connection.NewSSHTunnel(ctx, config, *host, *port)
+	if err != nil {
+		return func() {}, fmt.Errorf("SSH tunnel: %w", err)
+	}
+	*host = "127.0.0.1"
+	*port = t.GetLocalPort()
+	return func() { t.Close() }, nil
+}
+
+// readSettings reads name/value rows into a map. NULL values are stored as "".
+func readSettings(ctx context.Context, db *sql.DB, query string) (map[string]string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	settings := make(map[string]string)
+	for rows.Next() {
+		var name, value sql.NullString
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, err
+		}
+		if name.Valid {
+			settings[strings.TrimSpace(name.String)] = value.String
+		}
+	}
+	return settings, rows.Err()
+}
+
+// queryRow scans a single row, recording a failure in the snapshot's Errors.
+func queryRow(ctx context.Context, db *sql.DB, s *dbconfig.Snapshot, what, query string, dest ...interface{}) bool {
+	if err := db.QueryRowContext(ctx, query).Scan(dest...); err != nil {
+		s.Errors = append(s.Errors, fmt.Sprintf("%s: %v", what, err))
+		return false
+	}
+	return true
+}
+
+// =============================================================================
+// Server Information
+// =============================================================================
+
+// mysqlServer reads the server information from the global variables.
+// MySQL does not report the CPU count or memory size of its host.
+func mysqlServer(ctx context.Context, db *sql.DB, s *dbconfig.Snapshot) {
+	s.Version = s.Settings["version"]
+	s.Server.Hostname = s.Settings["hostname"]
+	s.Server.OS = strings.TrimSpace(s.Settings["version_compile_os"] + " " + s.Settings["version_compile_machine"])
+}
+
+// postgresServer reads the version and the platform from version().
+// PostgreSQL does not report the CPU count or memory size of its host.
+func postgresServer(ctx context.Context, db *sql.DB, s *dbconfig.Snapshot) {
+	s.Version = s.Settings["server_version"]
+
+	// e.g. "PostgreSQL 16.2 on x86_64-pc-linux-gnu, compiled by gcc ..."
+	var version string
+	if queryRow(ctx, db, s, "version", "SELECT version()", &version) {
+		if _, platform, ok := strings.Cut(version, " on "); ok {
+			platform, _, _ = strings.Cut(platform, ",")
+			s.Server.OS = platform
+		}
+	}
+
+	var addr sql.NullString
+	if queryRow(ctx, db, s, "server address", "SELECT host(inet_server_addr())", &addr) {
+		s.Server.Hostname = addr.String
+	}
+}
+
+// oracleServer reads the instance, platform and v$osstat host statistics.
+func oracleServer(ctx context.Context, db *sql.DB, s *dbconfig.Snapshot) {
+	queryRow(ctx, db, s, "instance", "SELECT version, host_name FROM v$instance", &s.Version, &s.Server.Hostname)
+	queryRow(ctx, db, s, "platform", "SELECT platform_name FROM v$database", &s.Server.OS)
+
+	stats, err := readSettings(ctx, db, "SELECT stat_name, TO_CHAR(value) FROM v$osstat WHERE stat_name IN ('NUM_CPUS', 'PHYSICAL_MEMORY_BYTES')")
+	if err != nil {
+		s.Errors = append(s.Errors, fmt.Sprintf("os statistics: %v", err))
+		return
+	}
+	s.Server.CPUs, _ = strconv.Atoi(stats["NUM_CPUS"])
+	if bytes, err := strconv.ParseInt(stats["PHYSICAL_MEMORY_BYTES"], 10, 64); err == nil {
+		s.Server.MemoryMB = bytes / (1024 * 1024)
+	}
+}
+
+// sqlServerServer reads the server properties and sys.dm_os_sys_info.
+func sqlServerServer(ctx context.Context, db *sql.DB, s *dbconfig.Snapshot) {
+	queryRow(ctx, db, s, "server properties",
+		"SELECT CAST(SERVERPROPERTY('ProductVersion') AS NVARCHAR(128)), CAST(SERVERPROPERTY('MachineName') AS NVARCHAR(128))",
+		&s.Version, &s.Server.Hostname)
+	queryRow(ctx, db, s, "system info",
+		"SELECT cpu_count, physical_memory_kb / 1024 FROM sys.dm_os_sys_info",
+		&s.Server.CPUs, &s.Server.MemoryMB)
+	// sys.dm_os_host_info exists from SQL Server 2017
+	queryRow(ctx, db, s, "host info",
+		"SELECT host_platform + ' ' + host_release FROM sys.dm_os_host_info",
+		&s.Server.OS)
+}
@@ -0,0 +1,62 @@
+// Package dbsnapshot provides unit tests for configuration capture.
+package dbsnapshot
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
+	_ "modernc.org/sqlite"
+)
+
+func TestReadSettings(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE variables (name TEXT, value TEXT);
+		INSERT INTO variables VALUES ('max_connections', '151'), ('version', '8.0.36'),
+		('hostname', 'db1'), ('version_compile_os', 'Linux'), ('version_compile_machine', 'x86_64'),
+		('empty', NULL)`)
+	if err != nil {
+		t.Fatalf("create variables: %v", err)
+	}
+
+	settings, err := readSettings(ctx, db, "SELECT name, value FROM variables")
+	if err != nil {
+		t.Fatalf("readSettings() failed: %v", err)
+	}
+	if len(settings) != 6 || settings["max_connections"] != "151" || settings["empty"] != "" {
+		t.Errorf("readSettings() = %v", settings)
+	}
+
+	s := &dbconfig.Snapshot{Settings: settings}
+	mysqlServer(ctx, db, s)
+	if s.Version != "8.0.36" || s.Server.Hostname != "db1" || s.Server.OS != "Linux x86_64" {
+		t.Errorf("mysqlServer() = %q, %+v", s.Version, s.Server)
+	}
+
+	// Failing server queries are recorded, not returned
+	if queryRow(ctx, db, s, "missing", "SELECT x FROM missing", new(string)) {
+		t.Error("queryRow() on a missing table succeeded")
+	}
+	if len(s.Errors) != 1 {
+		t.Errorf("Errors = %v, want one entry", s.Errors)
+	}
+}
+
+func TestProbes(t *testing.T) {
+	for _, dbType := range []connection.DatabaseType{
+		connection.DatabaseTypeMySQL, connection.DatabaseTypePostgreSQL,
+		connection.DatabaseTypeOracle, connection.DatabaseTypeSQLServer,
+	} {
+		if _, ok := probes[dbType]; !ok {
+			t.Errorf("no probe for %s", dbType)
+		}
+	}
+}
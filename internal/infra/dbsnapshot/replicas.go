@@ -0,0 +1,340 @@
+package dbsnapshot
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/replication"
+)
+
+// DefaultPollTimeout bounds one poll of the replicas.
+const DefaultPollTimeout = 5 * time.Second
+
+// ReplicaMonitor polls the replication lag and errors of the replicas of a
+// primary: SHOW REPLICA STATUS on each MySQL replica, pg_stat_replication on
+// a PostgreSQL primary.
+type ReplicaMonitor struct {
+	timeout time.Duration
+}
+
+// NewReplicaMonitor creates a new replica monitor with DefaultPollTimeout.
+func NewReplicaMonitor() *ReplicaMonitor {
+	return &ReplicaMonitor{timeout: DefaultPollTimeout}
+}
+
+// replicaPoller reads the replication state of the replicas of one primary.
+type replicaPoller interface {
+	poll(ctx context.Context) []replication.Status
+	close()
+}
+
+// Monitor polls the replicas of conn every interval until ctx is done and
+// returns their statistics. Replicas that cannot be polled are recorded as
+// failures rather than ending the monitoring.
+func (m *ReplicaMonitor) Monitor(ctx context.Context, conn connection.Replicated, interval time.Duration) *replication.Stats {
+	stats := replication.NewStats(conn.ReplicaAddresses(), interval)
+
+	var poller replicaPoller
+	switch c := conn.(type) {
+	case *connection.MySQLConnection:
+		poller = openMySQLReplicas(ctx, c)
+	case *connection.PostgreSQLConnection:
+		poller = openPostgreSQLPrimary(ctx, c)
+	default:
+		for _, addr := range conn.ReplicaAddresses() {
+			stats.Add(replication.Status{Replica: addr, Error: fmt.Sprintf("unsupported database type: %s", conn.GetType())})
+		}
+		return stats
+	}
+	defer poller.close()
+
+	m.pollUntilDone(ctx, poller, stats, interval)
+	slog.Info("Replicas: Monitoring finished", "connection", conn.GetName(),
+		"replicas", len(stats.Replicas), "max_lag_seconds", stats.MaxLag(), "failures", stats.Failures())
+	return stats
+}
+
+// pollUntilDone adds a poll of the replicas to stats every interval until ctx is done.
+func (m *ReplicaMonitor) pollUntilDone(ctx context.Context, poller replicaPoller, stats *replication.Stats, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		pollCtx, cancel := context.WithTimeout(ctx, m.timeout)
+		statuses := poller.poll(pollCtx)
+		cancel()
+		// A poll cut short by the end of the run is not a replica failure
+		if ctx.Err() != nil {
+			return
+		}
+		for _, status := range statuses {
+			stats.Add(status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// =============================================================================
+// MySQL
+// =============================================================================
+
+// mysqlReplica is the connection to one MySQL replica.
+type mysqlReplica struct {
+	addr        string
+	db          *sql.DB
+	closeTunnel func()
+	err         error // Why the replica could not be opened
+}
+
+// mysqlReplicas polls SHOW REPLICA STATUS on each replica.
+type mysqlReplicas []mysqlReplica
+
+// openMySQLReplicas opens the connections to the replicas of conn.
+func openMySQLReplicas(ctx context.Context, conn *connection.MySQLConnection) mysqlReplicas {
+	var replicas mysqlReplicas
+	for _, addr := range conn.ReplicaAddresses() {
+		r := mysqlReplica{addr: addr, closeTunnel: func() {}}
+		replica, err := conn.ReplicaConnection(addr)
+		if err == nil {
+			var dsn string
+			dsn, r.closeTunnel, err = dataSourceName(ctx, replica)
+			if err == nil {
+				r.db, err = sql.Open("mysql", dsn)
+			}
+		}
+		if err != nil {
+			r.err = err
+		} else {
+			r.db.SetMaxOpenConns(1)
+		}
+		replicas = append(replicas, r)
+	}
+	return replicas
+}
+
+func (rs mysqlReplicas) poll(ctx context.Context) []replication.Status {
+	statuses := make([]replication.Status, 0, len(rs))
+	for _, r := range rs {
+		if r.err != nil {
+			statuses = append(statuses, replication.Status{Replica: r.addr, Error: r.err.Error()})
+			continue
+		}
+		// SHOW REPLICA STATUS is MySQL 8.0.22+; older servers only know SHOW SLAVE STATUS
+		rows, err := readRows(ctx, r.db, "SHOW REPLICA STATUS")
+		if err != nil {
+			rows, err = readRows(ctx, r.db, "SHOW SLAVE STATUS")
+		}
+		if err != nil {
+			statuses = append(statuses, replication.Status{Replica: r.addr, Error: err.Error()})
+			continue
+		}
+		statuses = append(statuses, mysqlReplicaStatus(r.addr, rows))
+	}
+	return statuses
+}
+
+func (rs mysqlReplicas) close() {
+	for _, r := range rs {
+		if r.db != nil {
+			r.db.Close()
+		}
+		r.closeTunnel()
+	}
+}
+
+// mysqlReplicaStatus returns the status of a replica from its SHOW REPLICA
+// STATUS rows, one per replication channel. The replica runs if every
+// channel runs; its lag is that of the most behind channel.
+func mysqlReplicaStatus(addr string, rows []map[string]string) replication.Status {
+	status := replication.Status{Replica: addr, Running: len(rows) > 0}
+	if len(rows) == 0 {
+		status.Error = "not a replica: SHOW REPLICA STATUS returned no rows"
+		return status
+	}
+	for _, row := range rows {
+		io := column(row, "Replica_IO_Running", "Slave_IO_Running")
+		sqlThread := column(row, "Replica_SQL_Running", "Slave_SQL_Running")
+		lag, err := strconv.ParseFloat(column(row, "Seconds_Behind_Source", "Seconds_Behind_Master"), 64)
+		if io != "Yes" || sqlThread != "Yes" || err != nil {
+			status.Running = false
+		}
+		status.Lag = max(status.Lag, lag)
+
+		if msg := column(row, "Last_SQL_Error"); msg != "" {
+			status.Error = msg
+		} else if msg := column(row, "Last_IO_Error"); msg != "" && status.Error == "" {
+			status.Error = msg
+		}
+		if !status.Running && status.Error == "" {
+			status.Error = fmt.Sprintf("replication not running (IO: %s, SQL: %s)", io, sqlThread)
+		}
+	}
+	return status
+}
+
+// column returns the first of the named columns present in row.
+func column(row map[string]string, names ...string) string {
+	for _, name := range names {
+		if value, ok := row[name]; ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// =============================================================================
+// PostgreSQL
+// =============================================================================
+
+// postgresStandbysQuery lists the standbys streaming from the primary.
+// replay_lag is NULL while the primary is idle, which counts as caught up.
+const postgresStandbysQuery = `SELECT application_name, COALESCE(host(client_addr), ''),
+	COALESCE(client_hostname, ''), state, COALESCE(EXTRACT(EPOCH FROM replay_lag), 0)
+	FROM pg_stat_replication`
+
+// postgresStandby is a row of pg_stat_replication.
+type postgresStandby struct {
+	applicationName string
+	clientAddr      string
+	clientHostname  string
+	state           string
+	lag             float64
+}
+
+// postgresPrimary polls pg_stat_replication on the primary.
+type postgresPrimary struct {
+	replicas    []string
+	db          *sql.DB
+	closeTunnel func()
+	err         error
+}
+
+// openPostgreSQLPrimary opens the connection to the primary of conn.
+func openPostgreSQLPrimary(ctx context.Context, conn *connection.PostgreSQLConnection) *postgresPrimary {
+	p := &postgresPrimary{replicas: conn.ReplicaAddresses(), closeTunnel: func() {}}
+	dsn, closeTunnel, err := dataSourceName(ctx, conn)
+	if err != nil {
+		p.err = err
+		return p
+	}
+	p.closeTunnel = closeTunnel
+	if p.db, p.err = sql.Open("postgres", dsn); p.err == nil {
+		p.db.SetMaxOpenConns(1)
+	}
+	return p
+}
+
+func (p *postgresPrimary) poll(ctx context.Context) []replication.Status {
+	standbys, err := p.standbys(ctx)
+	if err != nil {
+		statuses := make([]replication.Status, 0, len(p.replicas))
+		for _, addr := range p.replicas {
+			statuses = append(statuses, replication.Status{Replica: addr, Error: err.Error()})
+		}
+		return statuses
+	}
+	return postgresReplicaStatuses(ctx, p.replicas, standbys)
+}
+
+// standbys reads the standbys of the primary.
+func (p *postgresPrimary) standbys(ctx context.Context) ([]postgresStandby, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	rows, err := p.db.QueryContext(ctx, postgresStandbysQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var standbys []postgresStandby
+	for rows.Next() {
+		var s postgresStandby
+		if err := rows.Scan(&s.applicationName, &s.clientAddr, &s.clientHostname, &s.state, &s.lag); err != nil {
+			return nil, err
+		}
+		standbys = append(standbys, s)
+	}
+	return standbys, rows.Err()
+}
+
+func (p *postgresPrimary) close() {
+	if p.db != nil {
+		p.db.Close()
+	}
+	p.closeTunnel()
+}
+
+// postgresReplicaStatuses matches the replicas to the standbys of the
+// primary by application name, client host name or resolved address.
+func postgresReplicaStatuses(ctx context.Context, replicas []string, standbys []postgresStandby) []replication.Status {
+	statuses := make([]replication.Status, 0, len(replicas))
+	for _, addr := range replicas {
+		host, _, _ := connection.SplitReplicaAddress(addr, 0)
+		names := []string{host}
+		if ips, err := net.DefaultResolver.LookupHost(ctx, host); err == nil {
+			names = append(names, ips...)
+		}
+
+		status := replication.Status{Replica: addr, Error: "not streaming from the primary (not in pg_stat_replication)"}
+		for _, s := range standbys {
+			if !slices.Contains(names, s.applicationName) && !slices.Contains(names, s.clientHostname) &&
+				!slices.Contains(names, s.clientAddr) {
+				continue
+			}
+			status.Running = s.state == "streaming"
+			status.Lag = s.lag
+			status.Error = ""
+			if !status.Running {
+				status.Error = "standby state: " + s.state
+			}
+			break
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// readRows reads every row of query into a map by column name. NULL values
+// are read as "".
+func readRows(ctx context.Context, db *sql.DB, query string) ([]map[string]string, error) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var result []map[string]string
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		row := make(map[string]string, len(columns))
+		for i, name := range columns {
+			row[strings.TrimSpace(name)] = values[i].String
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,74 @@
+// Package dbsnapshot provides unit tests for replica monitoring.
+package dbsnapshot
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestMySQLReplicaStatus(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer db.Close()
+
+	// Two channels as SHOW REPLICA STATUS reports them, one with an error
+	_, err = db.Exec(`CREATE TABLE replica_status (Channel_Name TEXT, Replica_IO_Running TEXT,
+		Replica_SQL_Running TEXT, Seconds_Behind_Source TEXT, Last_IO_Error TEXT, Last_SQL_Error TEXT);
+		INSERT INTO replica_status VALUES ('', 'Yes', 'Yes', '3', '', ''),
+		('analytics', 'Yes', 'No', NULL, '', 'Error 1062: Duplicate entry')`)
+	if err != nil {
+		t.Fatalf("create replica status: %v", err)
+	}
+
+	rows, err := readRows(ctx, db, "SELECT * FROM replica_status")
+	if err != nil {
+		t.Fatalf("readRows() failed: %v", err)
+	}
+	if len(rows) != 2 || rows[0]["Seconds_Behind_Source"] != "3" || rows[1]["Seconds_Behind_Source"] != "" {
+		t.Fatalf("readRows() = %v", rows)
+	}
+
+	status := mysqlReplicaStatus("db-2", rows[:1])
+	if !status.Running || status.Lag != 3 || status.Error != "" {
+		t.Errorf("mysqlReplicaStatus(running) = %+v, want running 3s behind", status)
+	}
+	status = mysqlReplicaStatus("db-2", rows)
+	if status.Running || status.Error != "Error 1062: Duplicate entry" {
+		t.Errorf("mysqlReplicaStatus(stopped channel) = %+v, want stopped with the SQL error", status)
+	}
+
+	// Servers before MySQL 8.0.22 name the columns after the master
+	old := map[string]string{"Slave_IO_Running": "Yes", "Slave_SQL_Running": "Yes", "Seconds_Behind_Master": "7"}
+	if status := mysqlReplicaStatus("db-3", []map[string]string{old}); !status.Running || status.Lag != 7 {
+		t.Errorf("mysqlReplicaStatus(SHOW SLAVE STATUS) = %+v, want running 7s behind", status)
+	}
+	if status := mysqlReplicaStatus("db-4", nil); status.Running || status.Error == "" {
+		t.Errorf("mysqlReplicaStatus(no rows) = %+v, want not a replica", status)
+	}
+}
+
+func TestPostgresReplicaStatuses(t *testing.T) {
+	standbys := []postgresStandby{
+		{applicationName: "walreceiver", clientAddr: "10.0.0.2", state: "streaming", lag: 1.5},
+		{applicationName: "10.0.0.3", clientAddr: "10.0.1.3", state: "catchup"},
+	}
+	statuses := postgresReplicaStatuses(context.Background(), []string{"10.0.0.2", "10.0.0.3:5433", "10.0.0.4"}, standbys)
+	if len(statuses) != 3 {
+		t.Fatalf("postgresReplicaStatuses() = %d statuses, want 3", len(statuses))
+	}
+	if s := statuses[0]; !s.Running || s.Lag != 1.5 || s.Replica != "10.0.0.2" {
+		t.Errorf("streaming standby = %+v, want running 1.5s behind", s)
+	}
+	if s := statuses[1]; s.Running || s.Error != "standby state: catchup" {
+		t.Errorf("catching up standby = %+v, want not running", s)
+	}
+	if s := statuses[2]; s.Running || s.Error == "" {
+		t.Errorf("missing standby = %+v, want not streaming", s)
+	}
+}
@@ -0,0 +1,260 @@
+package dbsnapshot
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
+)
+
+// DefaultServerLogMaxBytes bounds the log read after a run; of a larger
+// window only the end is kept.
+const DefaultServerLogMaxBytes = 1024 * 1024
+
+// ServerLogCollector reads the error log of a database server with the
+// credentials of its connection: over the SSH tunnel for MySQL, PostgreSQL and
+// Oracle when the tunnel ends on the database server, over WinRM for SQL
+// Server. The database reports where its log is.
+type ServerLogCollector struct {
+	timeout  time.Duration
+	maxBytes int64
+}
+
+// NewServerLogCollector creates a new collector with DefaultTimeout and
+// DefaultServerLogMaxBytes.
+func NewServerLogCollector() *ServerLogCollector {
+	return &ServerLogCollector{timeout: DefaultTimeout, maxBytes: DefaultServerLogMaxBytes}
+}
+
+// Position locates the error log of the server of conn and returns its end.
+func (c *ServerLogCollector) Position(ctx context.Context, conn connection.Connection) (*dbconfig.LogPosition, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	via, err := serverLogVia(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	logPath, err := locateServerLog(ctx, conn)
+	if err != nil {
+		return nil, fmt.Errorf("locate error log: %w", err)
+	}
+	output, err := runOnServer(ctx, conn, logSizeCommand(via, logPath))
+	if err != nil {
+		return nil, fmt.Errorf("read size of %s: %w", logPath, err)
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse size of %s: %q", logPath, strings.TrimSpace(output))
+	}
+	return &dbconfig.LogPosition{Path: logPath, Offset: size, Via: via, At: time.Now()}, nil
+}
+
+// Read returns what the server wrote to its error log after pos. A log that
+// shrank since was rotated and is read from its start.
+func (c *ServerLogCollector) Read(ctx context.Context, conn connection.Connection, pos *dbconfig.LogPosition) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	output, err := runOnServer(ctx, conn, logReadCommand(pos, c.maxBytes))
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", pos.Path, err)
+	}
+	slog.Info("ServerLog: Read error log", "connection", conn.GetName(), "path", pos.Path, "via", pos.Via, "bytes", len(output))
+	return output, nil
+}
+
+// serverLogVia returns how the error log of the server of conn is read.
+func serverLogVia(conn connection.Connection) (string, error) {
+	var via string
+	switch c := conn.(type) {
+	case *connection.MySQLConnection:
+		via = sshVia(c.SSH, c.Host)
+	case *connection.PostgreSQLConnection:
+		via = sshVia(c.SSH, c.Host)
+	case *connection.OracleConnection:
+		via = sshVia(c.SSH, c.Host)
+	case *connection.SQLServerConnection:
+		if cfg := c.GetWinRMConfig(); cfg != nil && cfg.Enabled {
+			via = "WinRM"
+		}
+	default:
+		return "", fmt.Errorf("unsupported database type: %s", conn.GetType())
+	}
+	if via == "" {
+		return "", fmt.Errorf("needs an SSH tunnel ending on the database server, or WinRM for SQL Server")
+	}
+	return via, nil
+}
+
+// sshVia returns "SSH" if the tunnel of a connection ends on its database
+// server, i.e. the database host is local to the SSH server.
+func sshVia(ssh *connection.SSHTunnelConfig, host string) string {
+	if ssh == nil || !ssh.Enabled {
+		return ""
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return ""
+	}
+	return "SSH"
+}
+
+// locateServerLog asks the database for the path of its error log.
+func locateServerLog(ctx context.Context, conn connection.Connection) (string, error) {
+	p, ok := probes[conn.GetType()]
+	if !ok {
+		return "", fmt.Errorf("unsupported database type: %s", conn.GetType())
+	}
+	dsn, closeTunnel, err := dataSourceName(ctx, conn)
+	if err != nil {
+		return "", err
+	}
+	defer closeTunnel()
+
+	db, err := sql.Open(p.driver, dsn)
+	if err != nil {
+		return "", fmt.Errorf("open database: %w", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	switch conn.GetType() {
+	case connection.DatabaseTypeMySQL:
+		var logError, dataDir string
+		if err := db.QueryRowContext(ctx, "SELECT @@log_error, @@datadir").Scan(&logError, &dataDir); err != nil {
+			return "", err
+		}
+		if logError == "" || logError == "stderr" {
+			return "", fmt.Errorf("log_error is %q; the error log is not written to a file", logError)
+		}
+		return serverPath(dataDir, logError), nil
+	case connection.DatabaseTypePostgreSQL:
+		var logFile sql.NullString
+		var dataDir string
+		if err := db.QueryRowContext(ctx, "SELECT pg_current_logfile(), current_setting('data_directory')").Scan(&logFile, &dataDir); err != nil {
+			return "", err
+		}
+		if !logFile.Valid || logFile.String == "" {
+			return "", fmt.Errorf("no current log file; logging_collector is off")
+		}
+		return serverPath(dataDir, logFile.String), nil
+	case connection.DatabaseTypeOracle:
+		var traceDir, instance string
+		if err := db.QueryRowContext(ctx, "SELECT value FROM v$diag_info WHERE name = 'Diag Trace'").Scan(&traceDir); err != nil {
+			return "", err
+		}
+		if err := db.QueryRowContext(ctx, "SELECT instance_name FROM v$instance").Scan(&instance); err != nil {
+			return "", err
+		}
+		return path.Join(traceDir, "alert_"+instance+".log"), nil
+	default:
+		var logFile sql.NullString
+		if err := db.QueryRowContext(ctx, "SELECT CAST(SERVERPROPERTY('ErrorLogFileName') AS NVARCHAR(260))").Scan(&logFile); err != nil {
+			return "", err
+		}
+		if !logFile.Valid || logFile.String == "" {
+			return "", fmt.Errorf("the server does not report its error log file")
+		}
+		return logFile.String, nil
+	}
+}
+
+// serverPath resolves a log file setting relative to the data directory, e.g.
+// MySQL's "./host.err".
+func serverPath(dataDir, file string) string {
+	if path.IsAbs(file) {
+		return file
+	}
+	return path.Join(dataDir, file)
+}
+
+// runOnServer runs a command on the database server of conn over SSH or
+// WinRM and returns its standard output.
+func runOnServer(ctx context.Context, conn connection.Connection, cmdLine string) (string, error) {
+	switch c := conn.(type) {
+	case *connection.MySQLConnection:
+		return c.SSH.Run(ctx, cmdLine)
+	case *connection.PostgreSQLConnection:
+		return c.SSH.Run(ctx, cmdLine)
+	case *connection.OracleConnection:
+		return c.SSH.Run(ctx, cmdLine)
+	case *connection.SQLServerConnection:
+		client, err := connection.NewWinRMClient(ctx, c.GetWinRMConfig())
+		if err != nil {
+			return "", err
+		}
+		defer client.Close()
+		var stdout, stderr strings.Builder
+		code, err := client.Run(ctx, cmdLine, nil, &stdout, &stderr)
+		if err != nil {
+			return "", err
+		}
+		if code != 0 {
+			return "", fmt.Errorf("exit code %d: %s", code, strings.TrimSpace(stderr.String()))
+		}
+		return stdout.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported connection type: %T", conn)
+	}
+}
+
+// logSizeCommand returns the command that prints the size of a log file.
+func logSizeCommand(via, logPath string) string {
+	if via == "WinRM" {
+		return powerShellCommand(fmt.Sprintf("(Get-Item -LiteralPath %s).Length", powerShellQuote(logPath)))
+	}
+	return "wc -c < " + shellQuote(logPath)
+}
+
+// logReadCommand returns the command that prints the log written after pos,
+// at most its last maxBytes.
+func logReadCommand(pos *dbconfig.LogPosition, maxBytes int64) string {
+	if pos.Via == "WinRM" {
+		// The SQL Server error log is UTF-16 with a byte order mark, and open for writing
+		script := fmt.Sprintf(`$f = [IO.File]::Open(%s, 'Open', 'Read', 'ReadWrite')
+try {
+  $bom = New-Object byte[] 2; $n = $f.Read($bom, 0, 2)
+  $enc = if ($n -eq 2 -and $bom[0] -eq 0xFF -and $bom[1] -eq 0xFE) { [Text.Encoding]::Unicode } else { [Text.Encoding]::UTF8 }
+  $start = [long]%d; if ($f.Length -lt $start) { $start = 0 }
+  $start = [Math]::Max($start, $f.Length - %d); if ($start %% 2 -and $enc -eq [Text.Encoding]::Unicode) { $start++ }
+  [void]$f.Seek($start, 'Begin')
+  $buf = New-Object byte[] ($f.Length - $start); $read = $f.Read($buf, 0, $buf.Length)
+  [Console]::Out.Write($enc.GetString($buf, 0, $read).TrimStart([char]0xFEFF))
+} finally { $f.Close() }`, powerShellQuote(pos.Path), pos.Offset, maxBytes)
+		return powerShellCommand(script)
+	}
+	return fmt.Sprintf("f=%s; start=%d; [ \"$(wc -c < \"$f\")\" -lt \"$start\" ] && start=0; tail -c +$((start + 1)) \"$f\" | tail -c %d",
+		shellQuote(pos.Path), pos.Offset, maxBytes)
+}
+
+// powerShellCommand returns the cmd.exe command line that runs a PowerShell
+// script, encoded so that it needs no quoting.
+func powerShellCommand(script string) string {
+	units := utf16.Encode([]rune(script))
+	b := make([]byte, 2*len(units))
+	for i, u := range units {
+		b[2*i], b[2*i+1] = byte(u), byte(u>>8)
+	}
+	return "powershell -NoProfile -NonInteractive -EncodedCommand " + base64.StdEncoding.EncodeToString(b)
+}
+
+// powerShellQuote quotes a string for PowerShell.
+func powerShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// shellQuote quotes a string for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
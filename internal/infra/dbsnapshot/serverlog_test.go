@@ -0,0 +1,111 @@
+// Package dbsnapshot provides unit tests for server log collection.
+package dbsnapshot
+
+import (
+	"encoding/base64"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
+)
+
+func TestServerLogVia(t *testing.T) {
+	ssh := &connection.SSHTunnelConfig{Enabled: true}
+	tests := []struct {
+		name string
+		conn connection.Connection
+		want string
+	}{
+		{"tunnel to the server", &connection.MySQLConnection{Host: "127.0.0.1", SSH: ssh}, "SSH"},
+		{"tunnel to localhost", &connection.PostgreSQLConnection{Host: "localhost", SSH: ssh}, "SSH"},
+		{"tunnel to another host", &connection.OracleConnection{Host: "db.internal", SSH: ssh}, ""},
+		{"no tunnel", &connection.MySQLConnection{Host: "127.0.0.1"}, ""},
+		{"winrm", &connection.SQLServerConnection{WinRM: &connection.WinRMConfig{Enabled: true}}, "WinRM"},
+		{"no winrm", &connection.SQLServerConnection{}, ""},
+	}
+	for _, tt := range tests {
+		got, err := serverLogVia(tt.conn)
+		if got != tt.want || (err != nil) != (tt.want == "") {
+			t.Errorf("%s: serverLogVia() = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestServerPath(t *testing.T) {
+	if got := serverPath("/var/lib/mysql/", "./db1.err"); got != "/var/lib/mysql/db1.err" {
+		t.Errorf("relative path = %s", got)
+	}
+	if got := serverPath("/var/lib/postgresql/16/main", "/var/log/postgresql/pg.log"); got != "/var/log/postgresql/pg.log" {
+		t.Errorf("absolute path = %s", got)
+	}
+}
+
+// TestLogCommands runs the SSH commands in a local shell.
+func TestLogCommands(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no POSIX shell")
+	}
+	run := func(cmdLine string) string {
+		out, err := exec.Command("sh", "-c", cmdLine).Output()
+		if err != nil {
+			t.Fatalf("%s: %v", cmdLine, err)
+		}
+		return string(out)
+	}
+
+	logPath := filepath.Join(t.TempDir(), "it's.log")
+	if err := os.WriteFile(logPath, []byte("before the run\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	size := strings.TrimSpace(run(logSizeCommand("SSH", logPath)))
+	if size != "15" {
+		t.Fatalf("size = %q, want 15", size)
+	}
+
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("[ERROR] during the run\n")
+	f.Close()
+	pos := &dbconfig.LogPosition{Path: logPath, Offset: 15, Via: "SSH"}
+	if got := run(logReadCommand(pos, 1024)); got != "[ERROR] during the run\n" {
+		t.Errorf("read = %q, want the lines written after the position", got)
+	}
+	if got := run(logReadCommand(pos, 4)); got != "run\n" {
+		t.Errorf("read of 4 bytes = %q, want the end", got)
+	}
+
+	// A rotated log is read from its start
+	os.WriteFile(logPath, []byte("new\n"), 0644)
+	if got := run(logReadCommand(pos, 1024)); got != "new\n" {
+		t.Errorf("read of a rotated log = %q", got)
+	}
+}
+
+func TestPowerShellCommand(t *testing.T) {
+	cmdLine := logSizeCommand("WinRM", `C:\Program Files\MSSQL\Log\ERRORLOG`)
+	encoded, ok := strings.CutPrefix(cmdLine, "powershell -NoProfile -NonInteractive -EncodedCommand ")
+	if !ok {
+		t.Fatalf("command line = %s", cmdLine)
+	}
+	b, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(b)%2 != 0 {
+		t.Fatalf("decode: %v", err)
+	}
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = uint16(b[2*i]) | uint16(b[2*i+1])<<8
+	}
+	if got := string(utf16.Decode(units)); got != `(Get-Item -LiteralPath 'C:\Program Files\MSSQL\Log\ERRORLOG').Length` {
+		t.Errorf("script = %s", got)
+	}
+	if got := powerShellQuote("it's"); got != "'it''s'" {
+		t.Errorf("powerShellQuote() = %s", got)
+	}
+}
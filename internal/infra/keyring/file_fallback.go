@@ -15,6 +15,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // FileFallback provides encrypted file-based password storage.
@@ -26,8 +27,11 @@ type FileFallback struct {
 }
 
 // NewFileFallback creates a new file-based keyring fallback.
-// The masterPassword is used to derive the encryption key.
-// If masterPassword is empty, a default password is used (less secure).
+// The masterPassword is used to derive the encryption key with Argon2id.
+// The first time a master password is used in dataDir, it is recorded in the
+// key file and passwords saved without a master password are re-encrypted.
+// Returns ErrWrongMasterPassword if it does not match the recorded one.
+// If masterPassword is empty, a built-in default password is used (legacy, less secure).
 func NewFileFallback(dataDir, masterPassword string) (*FileFallback, error) {
 	if dataDir == "" {
 		return nil, errors.New("data directory is required")
@@ -38,20 +42,25 @@ func NewFileFallback(dataDir, masterPassword string) (*FileFallback, error) {
 		return nil, fmt.Errorf("create data directory: %w", err)
 	}
 
-	// Use default password if not provided (less secure but functional)
+	f := &FileFallback{
+		keyFile: filepath.Join(dataDir, ".key"),
+		dataDir: dataDir,
+		secret:  legacyKey(),
+	}
+
 	if masterPassword == "" {
-		masterPassword = "db-benchmind-default-key" // Less secure
+		return f, nil
 	}
 
-	// Derive encryption key from master password
-	// In production, you might want to use a more secure approach
-	secret := deriveKey(masterPassword, "db-benchmind-salt")
+	if err := f.unlock(masterPassword); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
 
-	return &FileFallback{
-		keyFile: filepath.Join(dataDir, ".key"),
-		dataDir: dataDir,
-		secret:  secret,
-	}, nil
+// legacyKey returns the key used when no master password is given.
+func legacyKey() []byte {
+	return deriveKey("db-benchmind-default-key", "db-benchmind-salt")
 }
 
 // deriveKey derives a 32-byte encryption key from a password using repeated SHA256.
@@ -132,6 +141,24 @@ func (f *FileFallback) Available(ctx context.Context) bool {
 	return true
 }
 
+// Keys returns the keys of all stored passwords.
+func (f *FileFallback) Keys() ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(f.dataDir, "*.enc"))
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, file := range files {
+		key, err := hex.DecodeString(strings.TrimSuffix(filepath.Base(file), ".enc"))
+		if err != nil {
+			continue // Not a password file
+		}
+		keys = append(keys, string(key))
+	}
+	return keys, nil
+}
+
 // getPasswordPath returns the file path for a password key.
 func (f *FileFallback) getPasswordPath(key string) string {
 	// Use hex encoding to safely use the key as a filename
@@ -205,3 +205,35 @@ func TestFileFallback_DifferentPasswords(t *testing.T) {
 		}
 	}
 }
+
+// TestFileFallback_Keys tests listing stored keys.
+func TestFileFallback_Keys(t *testing.T) {
+	provider, err := NewFileFallback(t.TempDir(), "test-password")
+	if err != nil {
+		t.Fatalf("NewFileFallback() failed: %v", err)
+	}
+	ctx := context.Background()
+
+	for _, key := range []string{"conn-1", "conn/2"} {
+		if err := provider.Set(ctx, key, "secret"); err != nil {
+			t.Fatalf("Set(%s) failed: %v", key, err)
+		}
+	}
+
+	keys, err := provider.Keys()
+	if err != nil {
+		t.Fatalf("Keys() failed: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("Keys() = %v, want 2 keys", keys)
+	}
+	for _, key := range []string{"conn-1", "conn/2"} {
+		found := false
+		for _, k := range keys {
+			found = found || k == key
+		}
+		if !found {
+			t.Errorf("Keys() = %v, missing %s", keys, key)
+		}
+	}
+}
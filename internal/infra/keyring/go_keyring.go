@@ -6,58 +6,104 @@ package keyring
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 )
 
 // GoKeyring is a stub when go-keyring is not available.
+// Passwords are only stored in the file fallback after Unlock.
 type GoKeyring struct {
+	fallbackDir string
+
+	mu       sync.RWMutex
 	fallback *FileFallback
 }
 
 // NewGoKeyring creates a new stub keyring that only uses fallback.
 func NewGoKeyring(fallbackDir string) *GoKeyring {
-	k := &GoKeyring{}
-
-	if fallbackDir != "" {
-		fallback, err := NewFileFallback(fallbackDir, "")
-		if err == nil {
-			k.fallback = fallback
-		}
-	}
-
-	return k
+	return &GoKeyring{fallbackDir: fallbackDir}
 }
 
 // Set stores a password using fallback only.
 func (k *GoKeyring) Set(ctx context.Context, key, password string) error {
-	if k.fallback == nil {
+	if k.fallbackDir == "" {
 		return fmt.Errorf("keyring not available and no fallback configured")
 	}
-	return k.fallback.Set(ctx, key, password)
+	fallback := k.GetFallback()
+	if fallback == nil {
+		return ErrLocked
+	}
+	return fallback.Set(ctx, key, password)
 }
 
 // Get retrieves a password using fallback only.
 func (k *GoKeyring) Get(ctx context.Context, key string) (string, error) {
-	if k.fallback == nil {
+	fallback := k.GetFallback()
+	if fallback == nil {
+		if k.Locked() {
+			return "", ErrLocked
+		}
 		return "", &ErrNotFound{Key: key}
 	}
-	return k.fallback.Get(ctx, key)
+	return fallback.Get(ctx, key)
 }
 
 // Delete removes a password using fallback only.
 func (k *GoKeyring) Delete(ctx context.Context, key string) error {
-	if k.fallback == nil {
+	fallback := k.GetFallback()
+	if fallback == nil {
 		return &ErrNotFound{Key: key}
 	}
-	return k.fallback.Delete(ctx, key)
+	return fallback.Delete(ctx, key)
 }
 
-// Available always returns true (fallback is used).
+// Available returns true if a fallback is configured (it may still be locked).
 func (k *GoKeyring) Available(ctx context.Context) bool {
-	return k.fallback != nil && k.fallback.Available(ctx)
+	return k.fallbackDir != ""
+}
+
+// SystemAvailable always returns false (no system keyring in this build).
+func (k *GoKeyring) SystemAvailable() bool {
+	return false
+}
+
+// Locked returns true until the fallback is unlocked with a master password.
+func (k *GoKeyring) Locked() bool {
+	return k.fallbackDir != "" && k.GetFallback() == nil
+}
+
+// MasterPasswordSet returns true if a master password has been created for the fallback.
+func (k *GoKeyring) MasterPasswordSet() bool {
+	return k.fallbackDir != "" && MasterPasswordSet(k.fallbackDir)
+}
+
+// Unlock opens the file fallback with the master password, creating it if none is set yet.
+func (k *GoKeyring) Unlock(ctx context.Context, masterPassword string) error {
+	if k.fallbackDir == "" {
+		return errors.New("no fallback configured")
+	}
+	if masterPassword == "" {
+		return errors.New("master password is required")
+	}
+	fallback, err := NewFileFallback(k.fallbackDir, masterPassword)
+	if err != nil {
+		return err
+	}
+	k.mu.Lock()
+	k.fallback = fallback
+	k.mu.Unlock()
+	return nil
+}
+
+// MigrateFallback does nothing (no system keyring in this build).
+func (k *GoKeyring) MigrateFallback(ctx context.Context) (int, error) {
+	return 0, nil
 }
 
-// GetFallback returns the fallback provider.
+// GetFallback returns the fallback provider (nil while locked).
 func (k *GoKeyring) GetFallback() *FileFallback {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
 	return k.fallback
 }
@@ -0,0 +1,169 @@
+// Package keyring provides master-password protection for the file fallback.
+// Implements: REQ-CONN-007 (fallback when keyring is unavailable)
+package keyring
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+var (
+	// ErrWrongMasterPassword is returned when the master password does not match the key file.
+	ErrWrongMasterPassword = errors.New("wrong master password")
+
+	// ErrLocked is returned when the file fallback is needed but has not been unlocked.
+	ErrLocked = errors.New("keyring is locked: master password required")
+)
+
+// pendingSuffix marks password files re-encrypted with a new master password.
+const pendingSuffix = ".pending"
+
+// verifierText is encrypted into the key file to check the master password.
+const verifierText = "db-benchmind-master-password"
+
+// Argon2id parameters (RFC 9106 second recommended option).
+const (
+	argonTime    = 3
+	argonMemory  = 64 * 1024 // KiB
+	argonThreads = 4
+	argonKeyLen  = 32
+)
+
+// keyFileData is the content of the key file. It holds the KDF parameters and
+// a verifier, never the master password or the derived key.
+type keyFileData struct {
+	Version  int    `json:"version"`
+	KDF      string `json:"kdf"`
+	Salt     []byte `json:"salt"`
+	Time     uint32 `json:"time"`
+	Memory   uint32 `json:"memory"`
+	Threads  uint8  `json:"threads"`
+	Verifier []byte `json:"verifier"` // verifierText encrypted with the derived key
+}
+
+// MasterPasswordSet reports whether a master password has been set for the fallback in dataDir.
+func MasterPasswordSet(dataDir string) bool {
+	_, err := os.Stat(filepath.Join(dataDir, ".key"))
+	return err == nil
+}
+
+// unlock derives the key from the master password, creating the key file on first use.
+func (f *FileFallback) unlock(masterPassword string) error {
+	data, err := os.ReadFile(f.keyFile)
+	if os.IsNotExist(err) {
+		return f.setMasterPassword(masterPassword)
+	}
+	if err != nil {
+		return fmt.Errorf("read key file: %w", err)
+	}
+
+	var kf keyFileData
+	if err := json.Unmarshal(data, &kf); err != nil {
+		return fmt.Errorf("parse key file: %w", err)
+	}
+	if kf.KDF != "argon2id" {
+		return fmt.Errorf("unsupported key derivation: %s", kf.KDF)
+	}
+
+	f.secret = argon2.IDKey([]byte(masterPassword), kf.Salt, kf.Time, kf.Memory, kf.Threads, argonKeyLen)
+	verifier, err := f.decrypt(kf.Verifier)
+	if err != nil || subtle.ConstantTimeCompare([]byte(verifier), []byte(verifierText)) != 1 {
+		f.secret = nil
+		return ErrWrongMasterPassword
+	}
+	return f.completePending()
+}
+
+// completePending replaces password files with their re-encrypted versions.
+func (f *FileFallback) completePending() error {
+	pending, err := filepath.Glob(filepath.Join(f.dataDir, "*.enc"+pendingSuffix))
+	if err != nil {
+		return err
+	}
+	for _, file := range pending {
+		if err := os.Rename(file, strings.TrimSuffix(file, pendingSuffix)); err != nil {
+			return fmt.Errorf("replace password file: %w", err)
+		}
+	}
+	return nil
+}
+
+// removePending discards re-encrypted password files of an aborted migration.
+func (f *FileFallback) removePending() {
+	pending, _ := filepath.Glob(filepath.Join(f.dataDir, "*.enc"+pendingSuffix))
+	for _, file := range pending {
+		os.Remove(file)
+	}
+}
+
+// setMasterPassword creates the key file and re-encrypts passwords saved with the legacy key.
+func (f *FileFallback) setMasterPassword(masterPassword string) error {
+	keys, err := f.Keys()
+	if err != nil {
+		return fmt.Errorf("list stored passwords: %w", err)
+	}
+
+	// Read existing passwords with the legacy key
+	existing := make(map[string]string, len(keys))
+	for _, key := range keys {
+		encrypted, err := os.ReadFile(f.getPasswordPath(key))
+		if err != nil {
+			return fmt.Errorf("read password file: %w", err)
+		}
+		password, err := f.decrypt(encrypted)
+		if err != nil {
+			return fmt.Errorf("decrypt stored password %s: %w", key, err)
+		}
+		existing[key] = password
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("generate salt: %w", err)
+	}
+	f.secret = argon2.IDKey([]byte(masterPassword), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+
+	verifier, err := f.encrypt(verifierText)
+	if err != nil {
+		return fmt.Errorf("encrypt verifier: %w", err)
+	}
+	data, err := json.MarshalIndent(&keyFileData{
+		Version:  1,
+		KDF:      "argon2id",
+		Salt:     salt,
+		Time:     argonTime,
+		Memory:   argonMemory,
+		Threads:  argonThreads,
+		Verifier: verifier,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal key file: %w", err)
+	}
+
+	// Write the re-encrypted passwords beside the originals, then the key file,
+	// then replace the originals. If interrupted after the key file is written,
+	// unlock completes the replacement.
+	for key, password := range existing {
+		encrypted, err := f.encrypt(password)
+		if err == nil {
+			err = os.WriteFile(f.getPasswordPath(key)+pendingSuffix, encrypted, 0600)
+		}
+		if err != nil {
+			f.removePending()
+			return fmt.Errorf("re-encrypt password %s: %w", key, err)
+		}
+	}
+	if err := os.WriteFile(f.keyFile, data, 0600); err != nil {
+		f.removePending()
+		return fmt.Errorf("write key file: %w", err)
+	}
+	return f.completePending()
+}
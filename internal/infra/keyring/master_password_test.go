@@ -0,0 +1,113 @@
+// Implements: Keyring tests (master password for file fallback)
+package keyring
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFileFallback_MasterPassword tests creating and verifying a master password.
+func TestFileFallback_MasterPassword(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	if MasterPasswordSet(dir) {
+		t.Fatal("MasterPasswordSet() = true for empty directory")
+	}
+
+	provider, err := NewFileFallback(dir, "correct horse")
+	if err != nil {
+		t.Fatalf("NewFileFallback() failed: %v", err)
+	}
+	if !MasterPasswordSet(dir) {
+		t.Fatal("MasterPasswordSet() = false after first use")
+	}
+	if err := provider.Set(ctx, "conn-1", "secret"); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+
+	if _, err := NewFileFallback(dir, "wrong"); !errors.Is(err, ErrWrongMasterPassword) {
+		t.Errorf("NewFileFallback(wrong) error = %v, want ErrWrongMasterPassword", err)
+	}
+
+	// The default key can no longer read the password
+	legacy, err := NewFileFallback(dir, "")
+	if err != nil {
+		t.Fatalf("NewFileFallback(\"\") failed: %v", err)
+	}
+	if _, err := legacy.Get(ctx, "conn-1"); err == nil {
+		t.Error("password readable without master password")
+	}
+
+	reopened, err := NewFileFallback(dir, "correct horse")
+	if err != nil {
+		t.Fatalf("NewFileFallback() reopen failed: %v", err)
+	}
+	if password, err := reopened.Get(ctx, "conn-1"); err != nil || password != "secret" {
+		t.Errorf("Get() = %q, %v, want secret", password, err)
+	}
+}
+
+// TestFileFallback_MasterPassword_ReencryptsLegacy tests that passwords saved
+// without a master password are re-encrypted when one is set.
+func TestFileFallback_MasterPassword_ReencryptsLegacy(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	legacy, err := NewFileFallback(dir, "")
+	if err != nil {
+		t.Fatalf("NewFileFallback() failed: %v", err)
+	}
+	if err := legacy.Set(ctx, "conn-1", "secret"); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+
+	provider, err := NewFileFallback(dir, "correct horse")
+	if err != nil {
+		t.Fatalf("NewFileFallback() failed: %v", err)
+	}
+	if password, err := provider.Get(ctx, "conn-1"); err != nil || password != "secret" {
+		t.Errorf("Get() = %q, %v, want secret", password, err)
+	}
+	if _, err := legacy.Get(ctx, "conn-1"); err == nil {
+		t.Error("re-encrypted password still readable with the default key")
+	}
+	if pending, _ := filepath.Glob(filepath.Join(dir, "*"+pendingSuffix)); len(pending) != 0 {
+		t.Errorf("pending files left behind: %v", pending)
+	}
+}
+
+// TestFileFallback_MasterPassword_CompletesInterruptedMigration tests recovery of
+// re-encrypted files that were not yet moved into place.
+func TestFileFallback_MasterPassword_CompletesInterruptedMigration(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	provider, err := NewFileFallback(dir, "correct horse")
+	if err != nil {
+		t.Fatalf("NewFileFallback() failed: %v", err)
+	}
+	if err := provider.Set(ctx, "conn-1", "secret"); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+
+	// Simulate a crash between writing the key file and replacing the originals
+	path := provider.getPasswordPath("conn-1")
+	if err := os.Rename(path, path+pendingSuffix); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("legacy-ciphertext"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewFileFallback(dir, "correct horse")
+	if err != nil {
+		t.Fatalf("NewFileFallback() failed: %v", err)
+	}
+	if password, err := reopened.Get(ctx, "conn-1"); err != nil || password != "secret" {
+		t.Errorf("Get() = %q, %v, want secret", password, err)
+	}
+}
@@ -0,0 +1,286 @@
+//go:build !nopkgs
+
+// Package keyring provides system keyring integration with file fallback.
+// Implements: REQ-CONN-006, REQ-CONN-007
+package keyring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	gokeyring "github.com/zalando/go-keyring"
+)
+
+// ServiceName is the service under which passwords are stored in the system keyring.
+const ServiceName = "db-benchmind"
+
+// probeKey is written and removed to check that the system keyring works.
+const probeKey = "db-benchmind-availability-probe"
+
+// GoKeyring stores passwords in the system keyring (Secret Service on Linux,
+// Keychain on macOS, Credential Manager on Windows). When the system keyring is
+// unavailable (e.g. a headless Linux host without a Secret Service daemon),
+// the encrypted file fallback is used instead. The file fallback only stores
+// passwords after it has been unlocked with a master password (see Unlock).
+//
+// Passwords previously saved by the file fallback are moved into the system
+// keyring when they are read, or all at once with MigrateFallback.
+type GoKeyring struct {
+	fallbackDir string
+
+	mu       sync.RWMutex
+	fallback *FileFallback // nil until unlocked if a master password is set
+	unlocked bool          // fallback is protected by a master password
+
+	once      sync.Once
+	available bool // Result of the system keyring probe
+}
+
+// NewGoKeyring creates a new system keyring provider.
+// fallbackDir is the directory of the encrypted file fallback ("" = no fallback).
+func NewGoKeyring(fallbackDir string) *GoKeyring {
+	k := &GoKeyring{fallbackDir: fallbackDir}
+
+	// Without a master password the fallback can still read (and migrate)
+	// passwords saved by earlier versions
+	if fallbackDir != "" && !MasterPasswordSet(fallbackDir) {
+		fallback, err := NewFileFallback(fallbackDir, "")
+		if err != nil {
+			slog.Warn("Keyring: File fallback unavailable", "dir", fallbackDir, "error", err)
+		} else {
+			k.fallback = fallback
+		}
+	}
+
+	return k
+}
+
+// Set stores a password in the system keyring, or in the fallback if the system keyring is unavailable.
+func (k *GoKeyring) Set(ctx context.Context, key, password string) error {
+	if k.systemAvailable() {
+		err := gokeyring.Set(ServiceName, key, password)
+		if err == nil {
+			k.removeFallbackCopy(ctx, key)
+			return nil
+		}
+		if k.fallbackDir == "" {
+			return fmt.Errorf("system keyring set: %w", err)
+		}
+		slog.Warn("Keyring: System keyring write failed, using file fallback", "key", key, "error", err)
+	}
+
+	if k.fallbackDir == "" {
+		return fmt.Errorf("keyring not available and no fallback configured")
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if !k.unlocked {
+		return ErrLocked
+	}
+	return k.fallback.Set(ctx, key, password)
+}
+
+// Get retrieves a password. Passwords found only in the fallback are moved to the system keyring.
+func (k *GoKeyring) Get(ctx context.Context, key string) (string, error) {
+	if k.systemAvailable() {
+		password, err := gokeyring.Get(ServiceName, key)
+		if err == nil {
+			return password, nil
+		}
+		if !errors.Is(err, gokeyring.ErrNotFound) {
+			slog.Warn("Keyring: System keyring read failed", "key", key, "error", err)
+		}
+	}
+
+	fallback := k.getFallback()
+	if fallback == nil {
+		if k.Locked() {
+			return "", ErrLocked
+		}
+		return "", &ErrNotFound{Key: key}
+	}
+	password, err := fallback.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	if k.systemAvailable() {
+		if err := k.migrate(ctx, key, password); err != nil {
+			slog.Warn("Keyring: Failed to migrate password to system keyring", "key", key, "error", err)
+		}
+	}
+	return password, nil
+}
+
+// Delete removes a password from both the system keyring and the fallback.
+// Returns ErrNotFound only if neither contained the key.
+func (k *GoKeyring) Delete(ctx context.Context, key string) error {
+	found := false
+
+	if k.systemAvailable() {
+		err := gokeyring.Delete(ServiceName, key)
+		switch {
+		case err == nil:
+			found = true
+		case !errors.Is(err, gokeyring.ErrNotFound):
+			return fmt.Errorf("system keyring delete: %w", err)
+		}
+	}
+
+	if fallback := k.getFallback(); fallback != nil {
+		err := fallback.Delete(ctx, key)
+		switch {
+		case err == nil:
+			found = true
+		case !IsNotFound(err):
+			return err
+		}
+	}
+
+	if !found {
+		return &ErrNotFound{Key: key}
+	}
+	return nil
+}
+
+// Available returns true if the system keyring or the fallback can store passwords.
+// A locked fallback counts as available: it becomes usable after Unlock.
+func (k *GoKeyring) Available(ctx context.Context) bool {
+	return k.systemAvailable() || k.fallbackDir != ""
+}
+
+// SystemAvailable returns true if the system keyring is used.
+func (k *GoKeyring) SystemAvailable() bool {
+	return k.systemAvailable()
+}
+
+// Locked returns true if a master password is needed: either the system keyring
+// is unavailable and passwords must be stored in the file fallback, or the file
+// fallback holds master-password protected passwords that can be migrated.
+func (k *GoKeyring) Locked() bool {
+	if k.fallbackDir == "" {
+		return false
+	}
+	k.mu.RLock()
+	unlocked := k.unlocked
+	k.mu.RUnlock()
+	if unlocked {
+		return false
+	}
+	return !k.systemAvailable() || MasterPasswordSet(k.fallbackDir)
+}
+
+// MasterPasswordSet returns true if a master password has been created for the fallback.
+func (k *GoKeyring) MasterPasswordSet() bool {
+	return k.fallbackDir != "" && MasterPasswordSet(k.fallbackDir)
+}
+
+// Unlock opens the file fallback with the master password, creating it if none
+// is set yet. Passwords saved without a master password are re-encrypted, and
+// moved to the system keyring if it is available.
+func (k *GoKeyring) Unlock(ctx context.Context, masterPassword string) error {
+	if k.fallbackDir == "" {
+		return errors.New("no fallback configured")
+	}
+	if masterPassword == "" {
+		return errors.New("master password is required")
+	}
+
+	fallback, err := NewFileFallback(k.fallbackDir, masterPassword)
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	k.fallback = fallback
+	k.unlocked = true
+	k.mu.Unlock()
+	slog.Info("Keyring: File fallback unlocked")
+
+	if _, err := k.MigrateFallback(ctx); err != nil {
+		slog.Warn("Keyring: Failed to migrate stored passwords to system keyring", "error", err)
+	}
+	return nil
+}
+
+// GetFallback returns the fallback provider (nil while locked).
+func (k *GoKeyring) GetFallback() *FileFallback {
+	return k.getFallback()
+}
+
+// MigrateFallback moves all passwords stored by the file fallback into the system keyring.
+// Returns the number of passwords migrated. Does nothing if the system keyring is
+// unavailable or the fallback is locked.
+func (k *GoKeyring) MigrateFallback(ctx context.Context) (int, error) {
+	fallback := k.getFallback()
+	if fallback == nil || !k.systemAvailable() {
+		return 0, nil
+	}
+
+	keys, err := fallback.Keys()
+	if err != nil {
+		return 0, fmt.Errorf("list fallback passwords: %w", err)
+	}
+
+	migrated := 0
+	for _, key := range keys {
+		password, err := fallback.Get(ctx, key)
+		if err != nil {
+			return migrated, fmt.Errorf("read fallback password %s: %w", key, err)
+		}
+		if err := k.migrate(ctx, key, password); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+
+	if migrated > 0 {
+		slog.Info("Keyring: Migrated passwords to system keyring", "count", migrated)
+	}
+	return migrated, nil
+}
+
+// getFallback returns the current fallback, if any.
+func (k *GoKeyring) getFallback() *FileFallback {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.fallback
+}
+
+// migrate stores a fallback password in the system keyring and removes the file copy.
+func (k *GoKeyring) migrate(ctx context.Context, key, password string) error {
+	if err := gokeyring.Set(ServiceName, key, password); err != nil {
+		return fmt.Errorf("system keyring set %s: %w", key, err)
+	}
+	k.removeFallbackCopy(ctx, key)
+	return nil
+}
+
+// removeFallbackCopy deletes a password from the fallback once it is in the system keyring.
+func (k *GoKeyring) removeFallbackCopy(ctx context.Context, key string) {
+	fallback := k.getFallback()
+	if fallback == nil {
+		return
+	}
+	if err := fallback.Delete(ctx, key); err != nil && !IsNotFound(err) {
+		slog.Warn("Keyring: Failed to remove fallback password", "key", key, "error", err)
+	}
+}
+
+// systemAvailable probes the system keyring once.
+func (k *GoKeyring) systemAvailable() bool {
+	k.once.Do(func() {
+		if err := gokeyring.Set(ServiceName, probeKey, "probe"); err != nil {
+			slog.Info("Keyring: System keyring unavailable, using file fallback", "error", err)
+			return
+		}
+		gokeyring.Delete(ServiceName, probeKey)
+		k.available = true
+		slog.Info("Keyring: Using system keyring")
+	})
+	return k.available
+}
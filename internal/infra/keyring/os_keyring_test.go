@@ -0,0 +1,90 @@
+//go:build !nopkgs
+
+// Implements: Keyring tests (system keyring with fallback migration)
+package keyring
+
+import (
+	"context"
+	"testing"
+
+	gokeyring "github.com/zalando/go-keyring"
+)
+
+// TestGoKeyring_MigratesFallback tests that fallback passwords move to the system keyring.
+func TestGoKeyring_MigratesFallback(t *testing.T) {
+	gokeyring.MockInit()
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// Passwords saved by an earlier version in the file fallback
+	fallback, err := NewFileFallback(dir, "")
+	if err != nil {
+		t.Fatalf("NewFileFallback() failed: %v", err)
+	}
+	for _, key := range []string{"conn-1", "conn-2"} {
+		if err := fallback.Set(ctx, key, "secret-"+key); err != nil {
+			t.Fatalf("fallback Set(%s) failed: %v", key, err)
+		}
+	}
+
+	k := NewGoKeyring(dir)
+	if !k.SystemAvailable() {
+		t.Fatal("SystemAvailable() = false with mock keyring")
+	}
+
+	// Read migrates a single password
+	password, err := k.Get(ctx, "conn-1")
+	if err != nil || password != "secret-conn-1" {
+		t.Fatalf("Get(conn-1) = %q, %v", password, err)
+	}
+	if _, err := fallback.Get(ctx, "conn-1"); !IsNotFound(err) {
+		t.Errorf("fallback still has conn-1 after migration: %v", err)
+	}
+
+	migrated, err := k.MigrateFallback(ctx)
+	if err != nil {
+		t.Fatalf("MigrateFallback() failed: %v", err)
+	}
+	if migrated != 1 {
+		t.Errorf("MigrateFallback() = %d, want 1", migrated)
+	}
+	if stored, err := gokeyring.Get(ServiceName, "conn-2"); err != nil || stored != "secret-conn-2" {
+		t.Errorf("system keyring conn-2 = %q, %v", stored, err)
+	}
+
+	if err := k.Delete(ctx, "conn-2"); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if err := k.Delete(ctx, "conn-2"); !IsNotFound(err) {
+		t.Errorf("second Delete() error = %v, want ErrNotFound", err)
+	}
+}
+
+// TestGoKeyring_FallbackWhenUnavailable tests that the file fallback is used without a system keyring.
+func TestGoKeyring_FallbackWhenUnavailable(t *testing.T) {
+	gokeyring.MockInitWithError(gokeyring.ErrUnsupportedPlatform)
+	ctx := context.Background()
+
+	k := NewGoKeyring(t.TempDir())
+	if k.SystemAvailable() {
+		t.Fatal("SystemAvailable() = true with failing keyring")
+	}
+	if !k.Locked() {
+		t.Fatal("Locked() = false, want master password required")
+	}
+	if err := k.Set(ctx, "conn-1", "secret"); err != ErrLocked {
+		t.Fatalf("Set() while locked error = %v, want ErrLocked", err)
+	}
+	if err := k.Unlock(ctx, "master-password"); err != nil {
+		t.Fatalf("Unlock() failed: %v", err)
+	}
+	if err := k.Set(ctx, "conn-1", "secret"); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+	if password, err := k.Get(ctx, "conn-1"); err != nil || password != "secret" {
+		t.Errorf("Get() = %q, %v", password, err)
+	}
+	if _, err := k.GetFallback().Get(ctx, "conn-1"); err != nil {
+		t.Errorf("password not stored in fallback: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+// Package keyring provides password hashing for the app lock.
+package keyring
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+)
+
+// HashPassword returns the Argon2id hash of password with a random salt.
+func HashPassword(password string) (*config.PasswordHash, error) {
+	if password == "" {
+		return nil, errors.New("password is required")
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+	return &config.PasswordHash{
+		KDF:     "argon2id",
+		Salt:    salt,
+		Time:    argonTime,
+		Memory:  argonMemory,
+		Threads: argonThreads,
+		Hash:    argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, argonKeyLen),
+	}, nil
+}
+
+// VerifyPassword reports whether password matches hash. Hashes of an unknown
+// key derivation never match.
+func VerifyPassword(hash *config.PasswordHash, password string) bool {
+	if hash == nil || hash.KDF != "argon2id" {
+		return false
+	}
+	derived := argon2.IDKey([]byte(password), hash.Salt, hash.Time, hash.Memory, hash.Threads, uint32(len(hash.Hash)))
+	return subtle.ConstantTimeCompare(derived, hash.Hash) == 1
+}
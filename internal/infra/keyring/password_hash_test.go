@@ -0,0 +1,31 @@
+package keyring
+
+import "testing"
+
+func TestHashPassword(t *testing.T) {
+	hash, err := HashPassword("1234")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	if !VerifyPassword(hash, "1234") {
+		t.Error("VerifyPassword() = false for the hashed password")
+	}
+	if VerifyPassword(hash, "4321") {
+		t.Error("VerifyPassword() = true for another password")
+	}
+
+	again, err := HashPassword("1234")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	if string(again.Salt) == string(hash.Salt) {
+		t.Error("HashPassword() reused the salt")
+	}
+
+	if _, err := HashPassword(""); err == nil {
+		t.Error("HashPassword() should fail for an empty password")
+	}
+	if VerifyPassword(nil, "1234") {
+		t.Error("VerifyPassword() = true without a hash")
+	}
+}
@@ -27,6 +27,20 @@ type Provider interface {
 	Available(ctx context.Context) bool
 }
 
+// Lockable is implemented by providers that need a master password before
+// they can store passwords (see GoKeyring).
+type Lockable interface {
+	// Locked returns true if a master password must be supplied with Unlock.
+	Locked() bool
+
+	// MasterPasswordSet returns true if a master password was created before;
+	// otherwise Unlock creates it.
+	MasterPasswordSet() bool
+
+	// Unlock supplies the master password.
+	Unlock(ctx context.Context, masterPassword string) error
+}
+
 // ErrNotFound is returned when a key is not found in the keyring.
 type ErrNotFound struct {
 	Key string
@@ -0,0 +1,74 @@
+// Package keyring provides password-based sealing of secrets that leave the keyring.
+package keyring
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// ErrWrongPassword is returned when sealed data cannot be opened with the given password.
+var ErrWrongPassword = errors.New("wrong password")
+
+// sealedData is the envelope of data sealed with a password. Like the key
+// file, it holds the KDF parameters so they can change between releases.
+type sealedData struct {
+	Version int    `json:"version"`
+	KDF     string `json:"kdf"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"`
+	Threads uint8  `json:"threads"`
+	Data    []byte `json:"data"` // Nonce followed by the AES-GCM ciphertext
+}
+
+// Seal encrypts data with a key derived from password (Argon2id, AES-GCM),
+// independent of the keyring the secrets came from. Used to carry saved
+// passwords in backups.
+func Seal(password string, data []byte) ([]byte, error) {
+	if password == "" {
+		return nil, errors.New("password is required")
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+	f := &FileFallback{secret: argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, argonKeyLen)}
+	encrypted, err := f.encrypt(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("encrypt: %w", err)
+	}
+
+	return json.Marshal(&sealedData{
+		Version: 1,
+		KDF:     "argon2id",
+		Salt:    salt,
+		Time:    argonTime,
+		Memory:  argonMemory,
+		Threads: argonThreads,
+		Data:    encrypted,
+	})
+}
+
+// Unseal decrypts data sealed by Seal. Returns ErrWrongPassword if password
+// does not match.
+func Unseal(password string, sealed []byte) ([]byte, error) {
+	var sd sealedData
+	if err := json.Unmarshal(sealed, &sd); err != nil {
+		return nil, fmt.Errorf("parse sealed data: %w", err)
+	}
+	if sd.KDF != "argon2id" {
+		return nil, fmt.Errorf("unsupported key derivation: %s", sd.KDF)
+	}
+
+	f := &FileFallback{secret: argon2.IDKey([]byte(password), sd.Salt, sd.Time, sd.Memory, sd.Threads, argonKeyLen)}
+	data, err := f.decrypt(sd.Data)
+	if err != nil {
+		return nil, ErrWrongPassword
+	}
+	return []byte(data), nil
+}
@@ -0,0 +1,25 @@
+package keyring
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestSeal tests sealing data with a password.
+func TestSeal(t *testing.T) {
+	sealed, err := Seal("backup password", []byte("secret"))
+	if err != nil {
+		t.Fatalf("Seal() failed: %v", err)
+	}
+
+	data, err := Unseal("backup password", sealed)
+	if err != nil || string(data) != "secret" {
+		t.Errorf("Unseal() = %q, %v, want %q", data, err, "secret")
+	}
+	if _, err := Unseal("wrong", sealed); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("Unseal(wrong) error = %v, want ErrWrongPassword", err)
+	}
+	if _, err := Seal("", []byte("secret")); err == nil {
+		t.Error("Seal(\"\") error = nil, want error")
+	}
+}
@@ -0,0 +1,299 @@
+// Package logfile writes application logs to daily files that are rotated by
+// size, compressed with gzip and pruned by count and age.
+//
+// The files of a program are <name>-YYYY-MM-DD.log for the current day and,
+// once rotated, <name>-YYYY-MM-DD.log.gz and <name>-YYYY-MM-DD.N.log.gz for
+// the parts of a day rotated by size.
+package logfile
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dayLayout is the date in the file names.
+const dayLayout = "2006-01-02"
+
+// Limits bound the log files of a program.
+type Limits struct {
+	MaxSize  int64         // Size in bytes at which the current file is rotated; 0 rotates daily only
+	MaxFiles int           // Files kept, including the current one; 0 keeps all
+	MaxAge   time.Duration // Age after which rotated files are deleted; 0 keeps them
+}
+
+// Writer is an io.Writer to the current log file of a program. It is safe
+// for concurrent use.
+type Writer struct {
+	dir  string
+	name string
+	now  func() time.Time
+
+	mu     sync.Mutex
+	limits Limits
+	file   *os.File
+	day    string // Day of file
+	size   int64  // Bytes in file
+
+	tidyMu sync.Mutex     // Serializes compressing and pruning
+	tidyWG sync.WaitGroup // Running tidy goroutines, waited for by Close
+}
+
+// Open opens the log file of the current day for the program name in dir,
+// creating dir if needed. Log files left uncompressed from earlier days are
+// compressed and the files beyond limits are deleted in the background.
+func Open(dir, name string, limits Limits) (*Writer, error) {
+	return open(dir, name, limits, time.Now)
+}
+
+// open is Open with the clock now.
+func open(dir, name string, limits Limits, now func() time.Time) (*Writer, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create log directory: %w", err)
+	}
+	w := &Writer{dir: dir, name: name, now: now, limits: limits}
+	if err := w.openFile(now().Format(dayLayout)); err != nil {
+		return nil, err
+	}
+	w.startTidy()
+	return w, nil
+}
+
+// Path returns the path of the current log file.
+func (w *Writer) Path() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.path(w.day)
+}
+
+// SetLimits changes the limits. The files beyond the new limits are deleted
+// in the background.
+func (w *Writer) SetLimits(limits Limits) {
+	w.mu.Lock()
+	w.limits = limits
+	w.mu.Unlock()
+	w.startTidy()
+}
+
+// Write writes p to the current log file, first rotating it when the day
+// has changed or p would make it exceed the maximum size.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	day := w.now().Format(dayLayout)
+	if day != w.day || (w.limits.MaxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.limits.MaxSize) {
+		if err := w.rotate(day); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the current log file and waits for the background
+// compression to finish.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	var err error
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+	w.mu.Unlock()
+	w.tidyWG.Wait()
+	return err
+}
+
+// path returns the path of the current file of day.
+func (w *Writer) path(day string) string {
+	return filepath.Join(w.dir, fmt.Sprintf("%s-%s.log", w.name, day))
+}
+
+// openFile opens the file of day for appending.
+func (w *Writer) openFile(day string) error {
+	file, err := os.OpenFile(w.path(day), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("stat log file: %w", err)
+	}
+	w.file, w.day, w.size = file, day, info.Size()
+	return nil
+}
+
+// rotate closes the current file and opens the file of day. A file of the
+// same day is renamed to the next free part number first. The closed file
+// is compressed in the background.
+func (w *Writer) rotate(day string) error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("close log file: %w", err)
+	}
+	w.file = nil
+	if day == w.day {
+		current := w.path(day)
+		for part := 1; ; part++ {
+			rotated := filepath.Join(w.dir, fmt.Sprintf("%s-%s.%d.log", w.name, day, part))
+			if !exists(rotated) && !exists(rotated+".gz") {
+				if err := os.Rename(current, rotated); err != nil {
+					return fmt.Errorf("rotate log file: %w", err)
+				}
+				break
+			}
+		}
+	}
+	if err := w.openFile(day); err != nil {
+		return err
+	}
+	w.startTidy()
+	return nil
+}
+
+// startTidy compresses and prunes the log files in the background.
+func (w *Writer) startTidy() {
+	w.tidyWG.Add(1)
+	go func() {
+		defer w.tidyWG.Done()
+		w.tidy()
+	}()
+}
+
+// tidy compresses the log files other than the current one and deletes the
+// files beyond the limits, oldest first.
+func (w *Writer) tidy() {
+	w.tidyMu.Lock()
+	defer w.tidyMu.Unlock()
+	w.mu.Lock()
+	current, limits := w.path(w.day), w.limits
+	w.mu.Unlock()
+
+	files, err := w.files()
+	if err != nil {
+		slog.Warn("Logfile: Failed to list log files", "dir", w.dir, "error", err)
+		return
+	}
+	for i, file := range files {
+		if file.path == current || strings.HasSuffix(file.path, ".gz") {
+			continue
+		}
+		if err := compress(file.path); err != nil {
+			slog.Warn("Logfile: Failed to compress log file", "file", file.path, "error", err)
+			continue
+		}
+		files[i].path += ".gz"
+	}
+
+	// Newest first; the current file is always kept
+	slices.SortFunc(files, func(a, b logFile) int { return b.modTime.Compare(a.modTime) })
+	kept := 1
+	for _, file := range files {
+		if file.path == current {
+			continue
+		}
+		tooOld := limits.MaxAge > 0 && w.now().Sub(file.modTime) > limits.MaxAge
+		tooMany := limits.MaxFiles > 0 && kept >= limits.MaxFiles
+		if !tooOld && !tooMany {
+			kept++
+			continue
+		}
+		if err := os.Remove(file.path); err != nil {
+			slog.Warn("Logfile: Failed to delete log file", "file", file.path, "error", err)
+			continue
+		}
+		slog.Debug("Logfile: Deleted log file", "file", file.path, "too_old", tooOld)
+	}
+}
+
+// logFile is a log file of the program.
+type logFile struct {
+	path    string
+	modTime time.Time
+}
+
+// files returns the log files of the program, compressed or not. Files of
+// other programs whose name starts with the same prefix are left out by
+// requiring the date right after it.
+func (w *Writer) files() ([]logFile, error) {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []logFile
+	for _, entry := range entries {
+		rest, ok := strings.CutPrefix(entry.Name(), w.name+"-")
+		if !ok || entry.IsDir() || len(rest) < len(dayLayout) || !(strings.HasSuffix(rest, ".log") || strings.HasSuffix(rest, ".log.gz")) {
+			continue
+		}
+		if _, err := time.Parse(dayLayout, rest[:len(dayLayout)]); err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, logFile{path: filepath.Join(w.dir, entry.Name()), modTime: info.ModTime()})
+	}
+	return files, nil
+}
+
+// compress replaces the file at path with path.gz, keeping its modification
+// time so that it ages from when it was last written.
+func compress(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".gz.tmp"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	zw.Name = filepath.Base(path)
+	zw.ModTime = info.ModTime()
+	_, err = io.Copy(zw, src)
+	if cerr := zw.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chtimes(tmp, info.ModTime(), info.ModTime())
+	}
+	if err == nil {
+		err = os.Rename(tmp, path+".gz")
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	src.Close()
+	return os.Remove(path)
+}
+
+// exists reports whether a file exists at path.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,121 @@
+package logfile
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// listDir returns the names of the files in dir.
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+// write writes s to w, failing the test on errors.
+func write(t *testing.T, w *Writer, s string) {
+	t.Helper()
+	if _, err := io.WriteString(w, s); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+}
+
+func TestWriter_RotatesBySizeAndDay(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 28, 10, 0, 0, 0, time.UTC)
+	w, err := open(dir, "app", Limits{MaxSize: 10}, func() time.Time { return now })
+	if err != nil {
+		t.Fatalf("open() error = %v", err)
+	}
+
+	write(t, w, "12345678\n")
+	write(t, w, "abcdefgh\n") // Exceeds 10 bytes, rotates to part 1
+	now = now.Add(24 * time.Hour)
+	write(t, w, "next day\n")
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := []string{"app-2026-01-28.1.log.gz", "app-2026-01-28.log.gz", "app-2026-01-29.log"}
+	if got := listDir(t, dir); !slices.Equal(got, want) {
+		t.Fatalf("files = %v, want %v", got, want)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "app-2026-01-28.1.log.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "12345678\n" {
+		t.Errorf("rotated content = %q, want %q", data, "12345678\n")
+	}
+}
+
+func TestWriter_Prunes(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 28, 10, 0, 0, 0, time.UTC)
+	for i, name := range []string{"app-2026-01-27.log", "app-2026-01-26.log.gz", "app-2026-01-01.log.gz", "app-cli-2026-01-01.log", "notes.txt"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := now.Add(-time.Duration(i+1) * 24 * time.Hour)
+		if name == "app-2026-01-01.log.gz" {
+			modTime = now.Add(-27 * 24 * time.Hour)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		limits Limits
+		want   []string
+	}{
+		{
+			name:   "by age",
+			limits: Limits{MaxAge: 7 * 24 * time.Hour},
+			want:   []string{"app-2026-01-26.log.gz", "app-2026-01-27.log.gz", "app-2026-01-28.log", "app-cli-2026-01-01.log", "notes.txt"},
+		},
+		{
+			name:   "by count",
+			limits: Limits{MaxFiles: 2},
+			want:   []string{"app-2026-01-27.log.gz", "app-2026-01-28.log", "app-cli-2026-01-01.log", "notes.txt"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := open(dir, "app", tt.limits, func() time.Time { return now })
+			if err != nil {
+				t.Fatalf("open() error = %v", err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if got := listDir(t, dir); !slices.Equal(got, tt.want) {
+				t.Errorf("files = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
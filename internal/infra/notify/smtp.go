@@ -0,0 +1,259 @@
+// Package notify provides email delivery for run notifications.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Connection security modes, matching config.NotificationConfig.Security.
+const (
+	SecurityStartTLS = "starttls"
+	SecurityTLS      = "tls"
+	SecurityNone     = "none"
+)
+
+// defaultTimeout bounds a whole SMTP conversation.
+const defaultTimeout = 30 * time.Second
+
+// SMTPConfig holds the SMTP server settings.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Security string // starttls, tls or none
+	Username string // Empty = no authentication
+	Password string
+}
+
+// Attachment is a file attached to a message.
+type Attachment struct {
+	Name        string
+	ContentType string // Defaults to application/octet-stream
+	Data        []byte
+}
+
+// Message is a plain text email with optional attachments.
+type Message struct {
+	From        string
+	To          []string
+	Subject     string
+	Body        string
+	Attachments []Attachment
+}
+
+// Mailer sends email messages.
+type Mailer interface {
+	Send(ctx context.Context, cfg SMTPConfig, msg *Message) error
+}
+
+// SMTPMailer sends email through an SMTP server.
+type SMTPMailer struct {
+	Timeout time.Duration // Bound on the whole conversation (default 30s)
+}
+
+// NewSMTPMailer creates a new SMTP mailer.
+func NewSMTPMailer() *SMTPMailer {
+	return &SMTPMailer{Timeout: defaultTimeout}
+}
+
+// Send delivers msg through the SMTP server described by cfg.
+func (m *SMTPMailer) Send(ctx context.Context, cfg SMTPConfig, msg *Message) error {
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return fmt.Errorf("invalid from address: %w", err)
+	}
+	var recipients []string
+	for _, to := range msg.To {
+		addr, err := mail.ParseAddress(to)
+		if err != nil {
+			return fmt.Errorf("invalid recipient address %q: %w", to, err)
+		}
+		recipients = append(recipients, addr.Address)
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("no recipients")
+	}
+
+	data, err := BuildMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	timeout := m.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := dial(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, cfg.Host)
+	if err != nil {
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer client.Close()
+
+	if cfg.Security == SecurityStartTLS {
+		if err := client.StartTLS(&tls.Config{ServerName: cfg.Host}); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+	if cfg.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("smtp mail from: %w", err)
+	}
+	for _, to := range recipients {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("smtp rcpt to %s: %w", to, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return fmt.Errorf("smtp write: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// dial connects to the SMTP server, with TLS from the start for implicit TLS.
+func dial(ctx context.Context, cfg SMTPConfig) (net.Conn, error) {
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+
+	var conn net.Conn
+	var err error
+	switch cfg.Security {
+	case SecurityTLS:
+		dialer := &tls.Dialer{Config: &tls.Config{ServerName: cfg.Host}}
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	case SecurityStartTLS, SecurityNone:
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	default:
+		return nil, fmt.Errorf("unsupported security mode: %s", cfg.Security)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connect to %s: %w", addr, err)
+	}
+	return conn, nil
+}
+
+// BuildMessage renders msg as a MIME message. The body is sent as UTF-8
+// quoted-printable text; attachments are base64 encoded.
+func BuildMessage(msg *Message) ([]byte, error) {
+	var buf bytes.Buffer
+
+	header := func(key, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+	header("From", msg.From)
+	header("To", strings.Join(msg.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+
+	if len(msg.Attachments) == 0 {
+		header("Content-Type", "text/plain; charset=utf-8")
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, msg.Body); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	mw := multipart.NewWriter(&buf)
+	header("Content-Type", "multipart/mixed; boundary="+mw.Boundary())
+	buf.WriteString("\r\n")
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create body part: %w", err)
+	}
+	if err := writeQuotedPrintable(part, msg.Body); err != nil {
+		return nil, err
+	}
+
+	for _, att := range msg.Attachments {
+		contentType := att.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(contentType, map[string]string{"name": att.Name})},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": att.Name})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create attachment part: %w", err)
+		}
+		if err := writeBase64Lines(part, att.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("close message: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeQuotedPrintable writes text with CRLF line endings, quoted-printable encoded.
+func writeQuotedPrintable(w io.Writer, text string) error {
+	qp := quotedprintable.NewWriter(w)
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	if _, err := qp.Write([]byte(strings.ReplaceAll(text, "\n", "\r\n"))); err != nil {
+		return fmt.Errorf("encode body: %w", err)
+	}
+	return qp.Close()
+}
+
+// writeBase64Lines writes data base64 encoded in lines of 76 characters.
+func writeBase64Lines(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 0 {
+		n := min(76, len(encoded))
+		if _, err := fmt.Fprintf(w, "%s\r\n", encoded[:n]); err != nil {
+			return fmt.Errorf("encode attachment: %w", err)
+		}
+		encoded = encoded[n:]
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Package notify provides unit tests for the SMTP mailer.
+package notify
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTPServer accepts one SMTP conversation without TLS or authentication
+// and returns the recipients and message data it received.
+func fakeSMTPServer(t *testing.T) (port int, received <-chan []string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	ch := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+		tp := textproto.NewConn(conn)
+		var rcpts []string
+		tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				tp.PrintfLine("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM"):
+				tp.PrintfLine("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO"):
+				rcpts = append(rcpts, line[len("RCPT TO:"):])
+				tp.PrintfLine("250 OK")
+			case cmd == "DATA":
+				tp.PrintfLine("354 Go ahead")
+				data, err := tp.ReadDotBytes()
+				if err != nil {
+					return
+				}
+				tp.PrintfLine("250 Queued")
+				ch <- append(rcpts, string(data))
+			case cmd == "QUIT":
+				tp.PrintfLine("221 Bye")
+				return
+			default:
+				tp.PrintfLine("502 Not implemented")
+			}
+		}
+	}()
+
+	return ln.Addr().(*net.TCPAddr).Port, ch
+}
+
+// TestSMTPMailer_Send tests delivering a message with an attachment.
+func TestSMTPMailer_Send(t *testing.T) {
+	port, received := fakeSMTPServer(t)
+
+	msg := &Message{
+		From:    "DB-BenchMind <bench@example.com>",
+		To:      []string{"dba@example.com", "Ops <ops@example.com>"},
+		Subject: "Benchmark completed: OLTP",
+		Body:    "TPS: 1234.56\nLatency p95: 12.3 ms",
+		Attachments: []Attachment{
+			{Name: "report.md", ContentType: "text/markdown", Data: []byte("# Report\n")},
+		},
+	}
+	cfg := SMTPConfig{Host: "127.0.0.1", Port: port, Security: SecurityNone}
+	if err := NewSMTPMailer().Send(context.Background(), cfg, msg); err != nil {
+		t.Fatalf("Send() failed: %v", err)
+	}
+
+	var got []string
+	select {
+	case got = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("server received no message")
+	}
+	rcpts, data := got[:len(got)-1], got[len(got)-1]
+	if strings.Join(rcpts, ",") != "<dba@example.com>,<ops@example.com>" {
+		t.Errorf("recipients = %v", rcpts)
+	}
+
+	parsed, err := mail.ReadMessage(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("parse message: %v", err)
+	}
+	if subject := parsed.Header.Get("Subject"); subject != msg.Subject {
+		t.Errorf("Subject = %q, want %q", subject, msg.Subject)
+	}
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/mixed" {
+		t.Fatalf("Content-Type = %q, %v", mediaType, err)
+	}
+
+	mr := multipart.NewReader(parsed.Body, params["boundary"])
+	var parts []string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		content, _ := io.ReadAll(part)
+		parts = append(parts, part.FileName()+"|"+string(content))
+	}
+	if len(parts) != 2 {
+		t.Fatalf("got %d parts, want 2", len(parts))
+	}
+	if !strings.Contains(parts[0], "TPS: 1234.56\nLatency p95: 12.3 ms") {
+		t.Errorf("body part = %q", parts[0])
+	}
+	if !strings.HasPrefix(parts[1], "report.md|") {
+		t.Errorf("attachment part = %q", parts[1])
+	}
+}
+
+// TestSMTPMailer_Send_InvalidRecipient tests that invalid addresses fail before connecting.
+func TestSMTPMailer_Send_InvalidRecipient(t *testing.T) {
+	msg := &Message{From: "bench@example.com", To: []string{"not an address"}}
+	cfg := SMTPConfig{Host: "127.0.0.1", Port: 1, Security: SecurityNone}
+	err := NewSMTPMailer().Send(context.Background(), cfg, msg)
+	if err == nil || !strings.Contains(err.Error(), "invalid recipient") {
+		t.Errorf("Send() error = %v, want invalid recipient", err)
+	}
+}
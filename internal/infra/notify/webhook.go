@@ -0,0 +1,232 @@
+// Package notify provides webhook delivery for run notifications.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Webhook payload formats, matching config.WebhookConfig.Format.
+const (
+	FormatGeneric = "generic"
+	FormatSlack   = "slack"
+	FormatTeams   = "teams"
+)
+
+// defaultWebhookTimeout bounds a single webhook request.
+const defaultWebhookTimeout = 15 * time.Second
+
+// WebhookMetrics holds the summary metrics of a finished run.
+type WebhookMetrics struct {
+	TPS        float64 `json:"tps"`
+	QPS        float64 `json:"qps"`
+	LatencyAvg float64 `json:"latency_avg_ms"`
+	LatencyP95 float64 `json:"latency_p95_ms"`
+	LatencyP99 float64 `json:"latency_p99_ms"`
+	Errors     int64   `json:"errors"`
+	Reconnects int64   `json:"reconnects"`
+}
+
+// WebhookTrend describes how far a run deviates from the trend of the
+// earlier runs with the same configuration.
+type WebhookTrend struct {
+	Fingerprint       string  `json:"fingerprint"`
+	Baseline          int     `json:"baseline"` // Earlier runs averaged
+	TPSPercent        float64 `json:"tps_percent"`
+	LatencyP95Percent float64 `json:"latency_p95_percent"`
+	Threshold         float64 `json:"threshold_percent"`
+}
+
+// WebhookEvent describes a run lifecycle event. It is the JSON body of generic
+// webhooks and the data of webhook templates.
+type WebhookEvent struct {
+	Event      string          `json:"event"` // started, completed, failed, stopped or trend_alert
+	RunID      string          `json:"run_id"`
+	State      string          `json:"state"`
+	Template   string          `json:"template"`
+	Connection string          `json:"connection"`
+	Timestamp  time.Time       `json:"timestamp"`
+	Duration   string          `json:"duration,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Metrics    *WebhookMetrics `json:"metrics,omitempty"` // Only for runs with a result
+	Trend      *WebhookTrend   `json:"trend,omitempty"`   // Only for trend alerts
+}
+
+// Title returns a one-line summary of the event.
+func (e *WebhookEvent) Title() string {
+	return fmt.Sprintf("Benchmark %s: %s on %s", e.Event, e.Template, e.Connection)
+}
+
+// WebhookPoster posts webhook payloads.
+type WebhookPoster interface {
+	Post(ctx context.Context, url string, payload []byte) error
+}
+
+// HTTPWebhookPoster posts webhook payloads as JSON over HTTP.
+type HTTPWebhookPoster struct {
+	Client *http.Client
+}
+
+// NewHTTPWebhookPoster creates a new HTTP webhook poster.
+func NewHTTPWebhookPoster() *HTTPWebhookPoster {
+	return &HTTPWebhookPoster{Client: &http.Client{Timeout: defaultWebhookTimeout}}
+}
+
+// Post sends payload to url and fails unless the response status is 2xx.
+func (p *HTTPWebhookPoster) Post(ctx context.Context, url string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "DB-BenchMind")
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// BuildWebhookPayload renders the payload of event in the given format.
+// A non-empty tmpl is a Go text/template executed with event: for generic
+// webhooks it renders the whole body, for Slack and Teams the message text.
+func BuildWebhookPayload(format, tmpl string, event *WebhookEvent) ([]byte, error) {
+	var text string
+	if tmpl != "" {
+		t, err := template.New("webhook").Option("missingkey=zero").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("parse webhook template: %w", err)
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, event); err != nil {
+			return nil, fmt.Errorf("render webhook template: %w", err)
+		}
+		text = buf.String()
+	}
+
+	switch format {
+	case FormatGeneric:
+		if tmpl != "" {
+			return []byte(text), nil
+		}
+		return json.Marshal(event)
+	case FormatSlack:
+		if tmpl == "" {
+			text = slackText(event)
+		}
+		return json.Marshal(map[string]string{"text": text})
+	case FormatTeams:
+		return json.Marshal(teamsCard(event, text))
+	default:
+		return nil, fmt.Errorf("unsupported webhook format: %s", format)
+	}
+}
+
+// eventFacts lists the details of event as name/value pairs.
+func eventFacts(e *WebhookEvent) [][2]string {
+	facts := [][2]string{
+		{"Run ID", e.RunID},
+		{"State", e.State},
+	}
+	if e.Duration != "" {
+		facts = append(facts, [2]string{"Duration", e.Duration})
+	}
+	if e.Error != "" {
+		facts = append(facts, [2]string{"Error", e.Error})
+	}
+	if m := e.Metrics; m != nil {
+		facts = append(facts,
+			[2]string{"TPS", fmt.Sprintf("%.2f", m.TPS)},
+			[2]string{"QPS", fmt.Sprintf("%.2f", m.QPS)},
+			[2]string{"Latency avg/p95/p99", fmt.Sprintf("%.2f / %.2f / %.2f ms", m.LatencyAvg, m.LatencyP95, m.LatencyP99)},
+			[2]string{"Errors", fmt.Sprintf("%d", m.Errors)},
+			[2]string{"Reconnects", fmt.Sprintf("%d", m.Reconnects)},
+		)
+	}
+	if tr := e.Trend; tr != nil {
+		facts = append(facts,
+			[2]string{"TPS vs trend", fmt.Sprintf("%+.1f%%", tr.TPSPercent)},
+			[2]string{"p95 latency vs trend", fmt.Sprintf("%+.1f%%", tr.LatencyP95Percent)},
+			[2]string{"Trend", fmt.Sprintf("mean of %d earlier runs, threshold %g%%", tr.Baseline, tr.Threshold)},
+		)
+	}
+	return facts
+}
+
+// slackText renders the default Slack message (mrkdwn).
+func slackText(e *WebhookEvent) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s *%s*", eventEmoji(e.Event), e.Title())
+	for _, fact := range eventFacts(e) {
+		fmt.Fprintf(&b, "\n• %s: `%s`", fact[0], fact[1])
+	}
+	return b.String()
+}
+
+// teamsCard builds a Microsoft Teams MessageCard. Without text the card lists
+// the event details as facts.
+func teamsCard(e *WebhookEvent, text string) map[string]any {
+	card := map[string]any{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    e.Title(),
+		"title":      e.Title(),
+		"themeColor": eventColor(e.Event),
+	}
+	if text != "" {
+		card["text"] = text
+		return card
+	}
+
+	var facts []map[string]string
+	for _, fact := range eventFacts(e) {
+		facts = append(facts, map[string]string{"name": fact[0], "value": fact[1]})
+	}
+	card["sections"] = []map[string]any{{"facts": facts}}
+	return card
+}
+
+// eventEmoji returns the Slack emoji of a lifecycle event.
+func eventEmoji(event string) string {
+	switch event {
+	case "completed":
+		return ":white_check_mark:"
+	case "failed":
+		return ":x:"
+	case "stopped":
+		return ":octagonal_sign:"
+	case "trend_alert":
+		return ":warning:"
+	default:
+		return ":arrow_forward:"
+	}
+}
+
+// eventColor returns the Teams theme color of a lifecycle event.
+func eventColor(event string) string {
+	switch event {
+	case "completed":
+		return "2EB886"
+	case "failed":
+		return "D40E0D"
+	case "stopped", "trend_alert":
+		return "F2C744"
+	default:
+		return "0076D7"
+	}
+}
@@ -0,0 +1,123 @@
+// Package notify provides unit tests for webhook delivery.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testWebhookEvent returns a completed run event with metrics.
+func testWebhookEvent() *WebhookEvent {
+	return &WebhookEvent{
+		Event:      "completed",
+		RunID:      "run-1",
+		State:      "completed",
+		Template:   "OLTP Read Write",
+		Connection: "mysql-prod",
+		Timestamp:  time.Date(2026, 1, 29, 10, 0, 0, 0, time.UTC),
+		Duration:   "5m0s",
+		Metrics:    &WebhookMetrics{TPS: 1234.5, LatencyP95: 12.5},
+	}
+}
+
+// TestBuildWebhookPayload tests the generic, Slack and Teams payload formats.
+func TestBuildWebhookPayload(t *testing.T) {
+	event := testWebhookEvent()
+
+	t.Run("generic", func(t *testing.T) {
+		payload, err := BuildWebhookPayload(FormatGeneric, "", event)
+		if err != nil {
+			t.Fatalf("BuildWebhookPayload() failed: %v", err)
+		}
+		var got WebhookEvent
+		if err := json.Unmarshal(payload, &got); err != nil {
+			t.Fatalf("payload is not JSON: %v", err)
+		}
+		if got.RunID != "run-1" || got.Metrics == nil || got.Metrics.TPS != 1234.5 {
+			t.Errorf("payload = %s", payload)
+		}
+	})
+
+	t.Run("generic template", func(t *testing.T) {
+		payload, err := BuildWebhookPayload(FormatGeneric, `{"msg":"{{.Template}} {{.Event}}{{with .Metrics}} {{printf "%.1f" .TPS}}{{end}}"}`, event)
+		if err != nil {
+			t.Fatalf("BuildWebhookPayload() failed: %v", err)
+		}
+		if string(payload) != `{"msg":"OLTP Read Write completed 1234.5"}` {
+			t.Errorf("payload = %s", payload)
+		}
+	})
+
+	t.Run("slack", func(t *testing.T) {
+		payload, err := BuildWebhookPayload(FormatSlack, "", event)
+		if err != nil {
+			t.Fatalf("BuildWebhookPayload() failed: %v", err)
+		}
+		var msg struct{ Text string }
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			t.Fatalf("payload is not JSON: %v", err)
+		}
+		if !strings.Contains(msg.Text, "Benchmark completed: OLTP Read Write on mysql-prod") || !strings.Contains(msg.Text, "TPS: `1234.50`") {
+			t.Errorf("text = %q", msg.Text)
+		}
+	})
+
+	t.Run("teams", func(t *testing.T) {
+		payload, err := BuildWebhookPayload(FormatTeams, "", event)
+		if err != nil {
+			t.Fatalf("BuildWebhookPayload() failed: %v", err)
+		}
+		var card struct {
+			Type     string `json:"@type"`
+			Title    string `json:"title"`
+			Sections []struct {
+				Facts []struct{ Name, Value string }
+			} `json:"sections"`
+		}
+		if err := json.Unmarshal(payload, &card); err != nil {
+			t.Fatalf("payload is not JSON: %v", err)
+		}
+		if card.Type != "MessageCard" || len(card.Sections) != 1 || len(card.Sections[0].Facts) == 0 {
+			t.Errorf("card = %s", payload)
+		}
+	})
+
+	if _, err := BuildWebhookPayload("discord", "", event); err == nil {
+		t.Errorf("BuildWebhookPayload(discord) succeeded, want error")
+	}
+}
+
+// TestHTTPWebhookPoster_Post tests posting payloads and reporting error responses.
+func TestHTTPWebhookPoster_Post(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		if strings.Contains(received, "bad") {
+			http.Error(w, "invalid_payload", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	poster := NewHTTPWebhookPoster()
+	if err := poster.Post(context.Background(), server.URL, []byte(`{"text":"ok"}`)); err != nil {
+		t.Fatalf("Post() failed: %v", err)
+	}
+	if received != `{"text":"ok"}` {
+		t.Errorf("received %q", received)
+	}
+
+	err := poster.Post(context.Background(), server.URL, []byte(`{"text":"bad"}`))
+	if err == nil || !strings.Contains(err.Error(), "invalid_payload") {
+		t.Errorf("Post() error = %v, want the response body", err)
+	}
+}
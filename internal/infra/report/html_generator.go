@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/heatmap"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/report"
 )
 
@@ -349,6 +350,30 @@ func (g *HTMLGenerator) writeCharts(sb *strings.Builder, data *report.GenerateCo
 		sb.WriteString(latencyChart)
 		sb.WriteString(`</pre></div>`)
 	}
+
+	if h := latencyHeatmap(data.Samples); h != nil {
+		sb.WriteString(`<h3>Latency Heatmap</h3>`)
+		sb.WriteString(`<p>Per-second p95 and p99 latencies by time; darker cells hold more samples. Recurring cells far above the band show periodic stalls.</p>`)
+		sb.WriteString(`<div class="chart-container">`)
+		sb.WriteString(h.RenderSVG("P95/P99 latency over time", 1000, 320))
+		sb.WriteString(`</div>`)
+	}
+}
+
+// latencyHeatmap returns the heatmap of the p95 and p99 latencies of
+// samples, or nil if they have none.
+func latencyHeatmap(samples []report.MetricSample) *heatmap.Heatmap {
+	if len(samples) == 0 {
+		return nil
+	}
+	points := make([]heatmap.Sample, len(samples))
+	for i, s := range samples {
+		points[i] = heatmap.Sample{
+			Offset:    s.Timestamp.Sub(samples[0].Timestamp),
+			Latencies: []float64{s.LatencyP95, s.LatencyP99},
+		}
+	}
+	return heatmap.New(points, 200, heatmap.DefaultRows)
 }
 
 // writeTimeSeries writes the time series data section.
@@ -89,6 +89,9 @@ func TestHTMLGenerator_Generate(t *testing.T) {
 	if !strings.Contains(content, "metric-card") {
 		t.Error("Content should contain metric cards")
 	}
+	if !strings.Contains(content, "Latency Heatmap") || !strings.Contains(content, "<svg") {
+		t.Error("Content should contain the latency heatmap")
+	}
 }
 
 // TestHTMLGenerator_GenerateFailedRun tests report generation for failed run.
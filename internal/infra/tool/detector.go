@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"os/exec"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
 )
@@ -18,6 +20,9 @@ var (
 	detectorMutex sync.Mutex
 )
 
+// versionTimeout limits how long a version command may run.
+const versionTimeout = 10 * time.Second
+
 // Detector provides tool detection capabilities.
 type Detector struct{}
 
@@ -51,13 +56,29 @@ func (d *Detector) GetToolVersion(ctx context.Context, toolType config.ToolType)
 	detectorMutex.Lock()
 	defer detectorMutex.Unlock()
 
+	return d.versionAt(ctx, toolType, d.getExecutableName(toolType))
+}
+
+// GetToolVersionAt detects the version of the tool executable at path.
+func (d *Detector) GetToolVersionAt(ctx context.Context, toolType config.ToolType, path string) (string, error) {
+	detectorMutex.Lock()
+	defer detectorMutex.Unlock()
+
+	return d.versionAt(ctx, toolType, path)
+}
+
+// versionAt runs the version command of a tool with the given executable.
+func (d *Detector) versionAt(ctx context.Context, toolType config.ToolType, executable string) (string, error) {
 	// Get version command for tool
 	cmdArgs := d.getVersionCommand(toolType)
 	if cmdArgs == nil {
 		return "", fmt.Errorf("unsupported tool type: %s", toolType)
 	}
+	cmdArgs[0] = executable
 
-	// Execute command
+	// Execute command; interactive tools must not block the caller
+	ctx, cancel := context.WithTimeout(ctx, versionTimeout)
+	defer cancel()
 	cmd := exec.CommandContext(ctx, cmdArgs[0], cmdArgs[1:]...)
 	output, err := cmd.Output()
 	if err != nil {
@@ -83,13 +104,7 @@ func (d *Detector) DetectAllTools(ctx context.Context) map[config.ToolType]*Tool
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 
-	tools := []config.ToolType{
-		config.ToolTypeSysbench,
-		config.ToolTypeSwingbench,
-		config.ToolTypeHammerDB,
-	}
-
-	for _, toolType := range tools {
+	for _, toolType := range config.ToolTypes() {
 		wg.Add(1)
 		go func(tt config.ToolType) {
 			defer wg.Done()
@@ -108,15 +123,8 @@ func (d *Detector) DetectAllTools(ctx context.Context) map[config.ToolType]*Tool
 				info.Path = path
 
 				// Try to get version
-				cmdArgs := d.getVersionCommand(tt)
-				if cmdArgs != nil {
-					// Use absolute path
-					cmdArgs[0] = path
-					cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...)
-					output, err := cmd.Output()
-					if err == nil {
-						info.Version = d.parseVersion(tt, string(output))
-					}
+				if version, err := d.versionAt(ctx, tt, path); err == nil {
+					info.Version = version
 				}
 			}
 
@@ -151,6 +159,10 @@ func (d *Detector) getExecutableName(toolType config.ToolType) string {
 			return "hammerdbcli.bat"
 		}
 		return "hammerdbcli"
+	case config.ToolTypePsql:
+		return "psql"
+	case config.ToolTypeMySQL:
+		return "mysql"
 	default:
 		return ""
 	}
@@ -170,6 +182,10 @@ func (d *Detector) getVersionCommand(toolType config.ToolType) []string {
 			return []string{"hammerdbcli", "v"}
 		}
 		return []string{"hammerdbcli", "v"}
+	case config.ToolTypePsql:
+		return []string{"psql", "--version"}
+	case config.ToolTypeMySQL:
+		return []string{"mysql", "--version"}
 	default:
 		return nil
 	}
@@ -220,6 +236,25 @@ func (d *Detector) parseVersion(toolType config.ToolType, output string) string
 				}
 			}
 		}
+
+	case config.ToolTypePsql:
+		// Output: "psql (PostgreSQL) 16.2 (Ubuntu 16.2-1.pgdg22.04+1)"
+		for _, field := range strings.Fields(output) {
+			if field[0] >= '0' && field[0] <= '9' {
+				return field
+			}
+		}
+
+	case config.ToolTypeMySQL:
+		// Output: "mysql  Ver 8.0.36 for Linux on x86_64 (MySQL Community Server - GPL)",
+		// or for MariaDB "mysql  Ver 15.1 Distrib 10.6.12-MariaDB, for debian-linux-gnu"
+		// and "mysql from 11.4.2-MariaDB, client 15.2 for debian-linux-gnu"
+		fields := strings.Fields(output)
+		for _, marker := range []string{"Distrib", "from", "Ver"} {
+			if i := slices.Index(fields, marker); i != -1 && i+1 < len(fields) {
+				return strings.TrimSuffix(fields[i+1], ",")
+			}
+		}
 	}
 
 	return ""
@@ -243,17 +278,12 @@ func (d *Detector) CheckAvailability(path string) error {
 
 // DetectToolsAsync detects all tools asynchronously and returns results through a channel.
 func (d *Detector) DetectToolsAsync(ctx context.Context) <-chan *ToolInfo {
-	resultCh := make(chan *ToolInfo, 3)
+	tools := config.ToolTypes()
+	resultCh := make(chan *ToolInfo, len(tools))
 
 	go func() {
 		defer close(resultCh)
 
-		tools := []config.ToolType{
-			config.ToolTypeSysbench,
-			config.ToolTypeSwingbench,
-			config.ToolTypeHammerDB,
-		}
-
 		for _, toolType := range tools {
 			info := &ToolInfo{
 				Type:  toolType,
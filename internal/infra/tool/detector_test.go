@@ -3,7 +3,9 @@ package tool
 
 import (
 	"context"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"testing"
 
@@ -22,6 +24,8 @@ func TestDetector_getExecutableName(t *testing.T) {
 		{"sysbench", config.ToolTypeSysbench, "sysbench"},
 		{"swingbench", config.ToolTypeSwingbench, "swingbench"},
 		{"hammerdb linux", config.ToolTypeHammerDB, "hammerdbcli"},
+		{"psql", config.ToolTypePsql, "psql"},
+		{"mysql client", config.ToolTypeMySQL, "mysql"},
 	}
 
 	// Add special case for Windows
@@ -73,6 +77,30 @@ func TestDetector_parseVersion(t *testing.T) {
 			output:   "Swingbench V2.5.1234",
 			want:     "2.5.1234",
 		},
+		{
+			name:     "psql version",
+			toolType: config.ToolTypePsql,
+			output:   "psql (PostgreSQL) 16.2 (Ubuntu 16.2-1.pgdg22.04+1)",
+			want:     "16.2",
+		},
+		{
+			name:     "mysql client version",
+			toolType: config.ToolTypeMySQL,
+			output:   "mysql  Ver 8.0.36 for Linux on x86_64 (MySQL Community Server - GPL)",
+			want:     "8.0.36",
+		},
+		{
+			name:     "mariadb client version",
+			toolType: config.ToolTypeMySQL,
+			output:   "mysql  Ver 15.1 Distrib 10.6.12-MariaDB, for debian-linux-gnu (x86_64)",
+			want:     "10.6.12-MariaDB",
+		},
+		{
+			name:     "mariadb 11 client version",
+			toolType: config.ToolTypeMySQL,
+			output:   "mysql from 11.4.2-MariaDB, client 15.2 for debian-linux-gnu (x86_64)",
+			want:     "11.4.2-MariaDB",
+		},
 		{
 			name:     "empty output",
 			toolType: config.ToolTypeSysbench,
@@ -135,6 +163,30 @@ func TestDetector_GetToolVersion(t *testing.T) {
 	}
 }
 
+// TestDetector_GetToolVersionAt tests version detection of a configured executable.
+func TestDetector_GetToolVersionAt(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the tool")
+	}
+	path := filepath.Join(t.TempDir(), "sysbench")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho 'sysbench 1.0.20'\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDetector()
+	version, err := d.GetToolVersionAt(context.Background(), config.ToolTypeSysbench, path)
+	if err != nil {
+		t.Fatalf("GetToolVersionAt() error = %v", err)
+	}
+	if version != "1.0.20" {
+		t.Errorf("GetToolVersionAt() = %q, want 1.0.20", version)
+	}
+
+	if _, err := d.GetToolVersionAt(context.Background(), config.ToolTypeSysbench, filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("GetToolVersionAt() of a missing executable should fail")
+	}
+}
+
 // TestDetector_DetectAllTools tests detecting all tools.
 func TestDetector_DetectAllTools(t *testing.T) {
 	ctx := context.Background()
@@ -142,8 +194,8 @@ func TestDetector_DetectAllTools(t *testing.T) {
 
 	results := d.DetectAllTools(ctx)
 
-	if len(results) != 3 {
-		t.Errorf("DetectAllTools() returned %d results, want 3", len(results))
+	if len(results) != len(config.ToolTypes()) {
+		t.Errorf("DetectAllTools() returned %d results, want %d", len(results), len(config.ToolTypes()))
 	}
 
 	for toolType, info := range results {
@@ -206,8 +258,8 @@ func TestDetector_DetectToolsAsync(t *testing.T) {
 			info.Type, info.Found, info.Path, info.Version)
 	}
 
-	if count != 3 {
-		t.Errorf("Received %d results, want 3", count)
+	if count != len(config.ToolTypes()) {
+		t.Errorf("Received %d results, want %d", count, len(config.ToolTypes()))
 	}
 }
 
@@ -0,0 +1,442 @@
+package tool
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+)
+
+// ErrChecksumMismatch is returned when a download does not match its expected SHA-256.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// ErrNoChecksum is returned for a download plan without a SHA-256 that does not allow unverified downloads.
+var ErrNoChecksum = errors.New("no checksum")
+
+// InstallMethod is how a tool is installed on a platform.
+type InstallMethod string
+
+const (
+	// InstallDownload downloads a portable build into the tools directory.
+	InstallDownload InstallMethod = "download"
+	// InstallPackage installs the tool with the commands of the system package manager.
+	InstallPackage InstallMethod = "package"
+	// InstallManual means there is no automated way; the plan note says where to get the tool.
+	InstallManual InstallMethod = "manual"
+)
+
+// hammerDBDownloadURL is the portable HammerDB build for Linux x86-64.
+const hammerDBDownloadURL = "https://github.com/TPC-Council/HammerDB/releases/download/v4.6/HammerDB-4.6-Linux.tar.gz"
+
+// InstallPlan describes how to install a tool on a platform.
+type InstallPlan struct {
+	Type   config.ToolType `json:"type"`
+	Method InstallMethod   `json:"method"`
+
+	// URL is the archive (.tar.gz or .zip) of a portable build. A manual
+	// plan may suggest one for the user to install with its checksum.
+	URL string `json:"url,omitempty"`
+	// SHA256 is the expected checksum of the archive.
+	SHA256 string `json:"sha256,omitempty"`
+	// AllowUnverified lets a plan without SHA256 download the archive; it is
+	// then only verified by running the installed tool. Only archives the
+	// user names allow it.
+	AllowUnverified bool `json:"allow_unverified,omitempty"`
+	// Executable is the file name of the tool inside the archive.
+	Executable string `json:"executable,omitempty"`
+
+	// Commands are the package manager commands, run by the user.
+	Commands []string `json:"commands,omitempty"`
+
+	// Note tells the user where to get the tool for manual installs.
+	Note string `json:"note,omitempty"`
+}
+
+// Platform is an operating system, architecture and Linux distribution family.
+type Platform struct {
+	OS   string // runtime.GOOS
+	Arch string // runtime.GOARCH
+	// Distro is the Linux distribution family: debian, rhel, suse, arch, or empty if unknown.
+	Distro string
+}
+
+// CurrentPlatform returns the platform this process runs on.
+func CurrentPlatform() Platform {
+	p := Platform{OS: runtime.GOOS, Arch: runtime.GOARCH}
+	if p.OS == "linux" {
+		p.Distro = distroFamily(readOSRelease("/etc/os-release"))
+	}
+	return p
+}
+
+// readOSRelease parses the KEY=value lines of an os-release file.
+func readOSRelease(path string) map[string]string {
+	values := make(map[string]string)
+	file, err := os.Open(path)
+	if err != nil {
+		return values
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if ok {
+			values[key] = strings.Trim(value, `"'`)
+		}
+	}
+	return values
+}
+
+// distroFamily maps the ID and ID_LIKE of os-release to a distribution family.
+func distroFamily(osRelease map[string]string) string {
+	ids := strings.Fields(osRelease["ID"] + " " + osRelease["ID_LIKE"])
+	for _, id := range ids {
+		switch id {
+		case "debian", "ubuntu":
+			return "debian"
+		case "rhel", "centos", "fedora", "rocky", "almalinux", "ol":
+			return "rhel"
+		case "suse", "opensuse", "sles":
+			return "suse"
+		case "arch":
+			return "arch"
+		}
+	}
+	return ""
+}
+
+// packageNames are the packages of the tools per package manager family.
+var packageNames = map[config.ToolType]map[string]string{
+	config.ToolTypeSysbench: {"debian": "sysbench", "rhel": "sysbench", "suse": "sysbench", "arch": "sysbench", "darwin": "sysbench"},
+	config.ToolTypePsql:     {"debian": "postgresql-client", "rhel": "postgresql", "suse": "postgresql", "arch": "postgresql", "darwin": "libpq"},
+	config.ToolTypeMySQL:    {"debian": "default-mysql-client", "rhel": "mysql", "suse": "mysql-client", "arch": "mariadb-clients", "darwin": "mysql-client"},
+}
+
+// PlanInstall returns how to install a tool on a platform.
+func PlanInstall(toolType config.ToolType, p Platform) *InstallPlan {
+	plan := &InstallPlan{Type: toolType, Method: InstallManual}
+
+	switch toolType {
+	case config.ToolTypeHammerDB:
+		// There is no checksum of the build to pin, so the user supplies the one published with the release
+		if p.OS == "linux" && p.Arch == "amd64" {
+			plan.URL = hammerDBDownloadURL
+			plan.Note = fmt.Sprintf("Install the portable build with the SHA-256 published on its release page: db-benchmind install hammerdb --url %s --sha256 CHECKSUM", hammerDBDownloadURL)
+			return plan
+		}
+		plan.Note = "Download HammerDB from https://www.hammerdb.com/download.html"
+		return plan
+	case config.ToolTypeSwingbench:
+		plan.Note = "Download Swingbench from https://www.dominicgiles.com/swingbench/ (requires Java)"
+		return plan
+	}
+
+	family := p.Distro
+	if p.OS == "darwin" {
+		family = "darwin"
+	}
+	pkg := packageNames[toolType][family]
+	switch {
+	case pkg == "":
+	case family == "debian":
+		plan.Commands = []string{"sudo apt-get update", "sudo apt-get install -y " + pkg}
+	case family == "rhel" && toolType == config.ToolTypeSysbench:
+		// sysbench is in EPEL
+		plan.Commands = []string{"sudo dnf install -y epel-release", "sudo dnf install -y " + pkg}
+	case family == "rhel":
+		plan.Commands = []string{"sudo dnf install -y " + pkg}
+	case family == "suse":
+		plan.Commands = []string{"sudo zypper install -y " + pkg}
+	case family == "arch":
+		plan.Commands = []string{"sudo pacman -S --noconfirm " + pkg}
+	case family == "darwin" && toolType == config.ToolTypePsql:
+		// libpq is keg-only, link it to put psql in PATH
+		plan.Commands = []string{"brew install " + pkg, "brew link --force " + pkg}
+	case family == "darwin":
+		plan.Commands = []string{"brew install " + pkg}
+	}
+	if len(plan.Commands) > 0 {
+		plan.Method = InstallPackage
+		return plan
+	}
+
+	switch {
+	case toolType == config.ToolTypeSysbench && p.OS == "windows":
+		plan.Note = "sysbench does not run natively on Windows; install it in WSL or on a Linux load generator"
+	case toolType == config.ToolTypeSysbench:
+		plan.Note = "Build sysbench from source: https://github.com/akopytov/sysbench#building-and-installing-from-source"
+	default:
+		plan.Note = fmt.Sprintf("Install the %s client with the package manager of your system", toolType)
+	}
+	return plan
+}
+
+// DownloadPlan returns a plan that installs a tool from the archive at url,
+// e.g. a build that PlanInstall does not know about. The user chose the
+// archive, so the plan allows it to be downloaded without a checksum.
+func DownloadPlan(toolType config.ToolType, url, sha256 string) *InstallPlan {
+	return &InstallPlan{
+		Type:            toolType,
+		Method:          InstallDownload,
+		URL:             url,
+		SHA256:          sha256,
+		AllowUnverified: sha256 == "",
+		Executable:      NewDetector().getExecutableName(toolType),
+	}
+}
+
+// Installer downloads portable tool builds.
+type Installer struct {
+	client *http.Client
+}
+
+// NewInstaller creates a new installer.
+func NewInstaller() *Installer {
+	return &Installer{client: http.DefaultClient}
+}
+
+// Download downloads the archive of a download plan, verifies its checksum
+// and extracts it into a new directory below dir, named after the archive.
+// Plans without a checksum are refused unless they allow unverified
+// downloads. An existing installation of the same archive is replaced.
+// Returns the path of the executable.
+func (i *Installer) Download(ctx context.Context, plan *InstallPlan, dir string) (string, error) {
+	if plan.Method != InstallDownload || plan.URL == "" {
+		return "", fmt.Errorf("%s is not installed by download", plan.Type)
+	}
+	if plan.SHA256 == "" && !plan.AllowUnverified {
+		return "", fmt.Errorf("%w: %s has no SHA-256 to verify", ErrNoChecksum, plan.URL)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("create tools directory: %w", err)
+	}
+
+	archive, err := os.CreateTemp(dir, ".download-*")
+	if err != nil {
+		return "", fmt.Errorf("create download file: %w", err)
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	slog.Info("Tool: Downloading", "tool", plan.Type, "url", plan.URL)
+	sum, err := i.fetch(ctx, plan.URL, archive)
+	if err != nil {
+		return "", err
+	}
+	if plan.SHA256 != "" && !strings.EqualFold(sum, plan.SHA256) {
+		return "", fmt.Errorf("%w: %s has SHA-256 %s, want %s", ErrChecksumMismatch, plan.URL, sum, plan.SHA256)
+	}
+	slog.Info("Tool: Downloaded", "tool", plan.Type, "sha256", sum, "checksum_verified", plan.SHA256 != "")
+
+	// Extract next to the final directory, then swap it in
+	name := archiveName(plan.URL)
+	staging, err := os.MkdirTemp(dir, ".extract-*")
+	if err != nil {
+		return "", fmt.Errorf("create extraction directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+	if strings.HasSuffix(strings.ToLower(plan.URL), ".zip") {
+		err = extractZip(archive, staging)
+	} else {
+		err = extractTarGz(archive, staging)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	target := filepath.Join(dir, name)
+	if err := os.RemoveAll(target); err != nil {
+		return "", fmt.Errorf("remove previous installation: %w", err)
+	}
+	if err := os.Rename(staging, target); err != nil {
+		return "", fmt.Errorf("install %s: %w", target, err)
+	}
+
+	executable, err := findExecutable(target, plan.Executable)
+	if err != nil {
+		return "", err
+	}
+	return executable, nil
+}
+
+// fetch downloads url into w and returns the hex SHA-256 of the content.
+func (i *Installer) fetch(ctx context.Context, url string, w io.Writer) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	resp, err := i.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download %s: %s", url, resp.Status)
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(w, hash), resp.Body); err != nil {
+		return "", fmt.Errorf("download %s: %w", url, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// archiveName returns the file name of an archive URL without its extension.
+func archiveName(url string) string {
+	name := path.Base(url)
+	for _, ext := range []string{".tar.gz", ".tgz", ".zip"} {
+		if strings.HasSuffix(strings.ToLower(name), ext) {
+			return name[:len(name)-len(ext)]
+		}
+	}
+	return name
+}
+
+// archivePath returns where an archive entry is extracted below dir.
+// Entries that would leave dir are rejected.
+func archivePath(dir, name string) (string, error) {
+	clean := path.Clean(strings.ReplaceAll(name, `\`, "/"))
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("archive contains invalid path %s", name)
+	}
+	return filepath.Join(dir, filepath.FromSlash(clean)), nil
+}
+
+// extractTarGz extracts the gzipped tar archive in file into dir.
+func extractTarGz(file *os.File, dir string) error {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("read archive: %w", err)
+	}
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("read archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read archive: %w", err)
+		}
+		dest, err := archivePath(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(dest, 0755); err != nil {
+				return fmt.Errorf("create directory %s: %w", hdr.Name, err)
+			}
+		case tar.TypeReg:
+			if err := writeArchiveFile(dest, tr, hdr.FileInfo().Mode()); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			// Shared libraries are linked by version; links must stay inside dir
+			if _, err := archivePath(dir, path.Join(path.Dir(hdr.Name), hdr.Linkname)); err != nil || path.IsAbs(hdr.Linkname) {
+				return fmt.Errorf("archive contains invalid link %s -> %s", hdr.Name, hdr.Linkname)
+			}
+			if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+				return fmt.Errorf("create directory for %s: %w", hdr.Name, err)
+			}
+			if err := os.Symlink(hdr.Linkname, dest); err != nil {
+				return fmt.Errorf("extract %s: %w", hdr.Name, err)
+			}
+		}
+	}
+}
+
+// extractZip extracts the zip archive in file into dir.
+func extractZip(file *os.File, dir string) error {
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("read archive: %w", err)
+	}
+	zr, err := zip.NewReader(file, info.Size())
+	if err != nil {
+		return fmt.Errorf("read archive: %w", err)
+	}
+
+	for _, f := range zr.File {
+		dest, err := archivePath(dir, f.Name)
+		if err != nil {
+			return err
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(dest, 0755); err != nil {
+				return fmt.Errorf("create directory %s: %w", f.Name, err)
+			}
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("extract %s: %w", f.Name, err)
+		}
+		err = writeArchiveFile(dest, r, f.Mode())
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeArchiveFile writes an extracted file, keeping its permission bits.
+func writeArchiveFile(dest string, r io.Reader, mode fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", dest, err)
+	}
+	file, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0600)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", dest, err)
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return fmt.Errorf("extract %s: %w", dest, err)
+	}
+	return file.Close()
+}
+
+// findExecutable returns the first regular file named name below dir.
+func findExecutable(dir, name string) (string, error) {
+	var found string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if found == "" && d.Type().IsRegular() && d.Name() == name {
+			found = p
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("search %s: %w", dir, err)
+	}
+	if found == "" {
+		return "", fmt.Errorf("%w: %s not found in the downloaded archive", config.ErrToolNotFound, name)
+	}
+	return found, nil
+}
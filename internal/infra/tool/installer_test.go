@@ -0,0 +1,192 @@
+package tool
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+)
+
+// TestPlanInstall tests the install plans per platform.
+func TestPlanInstall(t *testing.T) {
+	tests := []struct {
+		name     string
+		tool     config.ToolType
+		platform Platform
+		method   InstallMethod
+		first    string
+	}{
+		{"sysbench on ubuntu", config.ToolTypeSysbench, Platform{OS: "linux", Arch: "amd64", Distro: "debian"}, InstallPackage, "sudo apt-get update"},
+		{"sysbench on rocky", config.ToolTypeSysbench, Platform{OS: "linux", Arch: "amd64", Distro: "rhel"}, InstallPackage, "sudo dnf install -y epel-release"},
+		{"sysbench on macOS", config.ToolTypeSysbench, Platform{OS: "darwin", Arch: "arm64"}, InstallPackage, "brew install sysbench"},
+		{"sysbench on windows", config.ToolTypeSysbench, Platform{OS: "windows", Arch: "amd64"}, InstallManual, ""},
+		{"sysbench on unknown linux", config.ToolTypeSysbench, Platform{OS: "linux", Arch: "amd64"}, InstallManual, ""},
+		{"hammerdb on linux", config.ToolTypeHammerDB, Platform{OS: "linux", Arch: "amd64", Distro: "debian"}, InstallManual, "--sha256"},
+		{"hammerdb on arm64", config.ToolTypeHammerDB, Platform{OS: "linux", Arch: "arm64"}, InstallManual, ""},
+		{"psql on macOS", config.ToolTypePsql, Platform{OS: "darwin", Arch: "arm64"}, InstallPackage, "brew install libpq"},
+		{"mysql on suse", config.ToolTypeMySQL, Platform{OS: "linux", Arch: "amd64", Distro: "suse"}, InstallPackage, "sudo zypper install -y mysql-client"},
+		{"swingbench", config.ToolTypeSwingbench, Platform{OS: "linux", Arch: "amd64", Distro: "debian"}, InstallManual, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := PlanInstall(tt.tool, tt.platform)
+			if plan.Method != tt.method {
+				t.Fatalf("Method = %s, want %s", plan.Method, tt.method)
+			}
+			switch plan.Method {
+			case InstallPackage:
+				if plan.Commands[0] != tt.first {
+					t.Errorf("Commands = %v, want first %q", plan.Commands, tt.first)
+				}
+			case InstallDownload:
+				if plan.URL == "" || plan.Executable == "" {
+					t.Errorf("download plan without URL or executable: %+v", plan)
+				}
+			case InstallManual:
+				if plan.Note == "" || !strings.Contains(plan.Note, tt.first) {
+					t.Errorf("Note = %q, want a note with %q", plan.Note, tt.first)
+				}
+			}
+		})
+	}
+
+	// The GUI offers the suggested archive of a manual plan for download
+	if plan := PlanInstall(config.ToolTypeHammerDB, Platform{OS: "linux", Arch: "amd64"}); plan.URL != hammerDBDownloadURL {
+		t.Errorf("HammerDB plan URL = %q, want %q", plan.URL, hammerDBDownloadURL)
+	}
+}
+
+// TestDistroFamily tests mapping os-release IDs to distribution families.
+func TestDistroFamily(t *testing.T) {
+	tests := []struct {
+		id, idLike string
+		want       string
+	}{
+		{"ubuntu", "debian", "debian"},
+		{"rocky", "rhel centos fedora", "rhel"},
+		{"opensuse-leap", "suse opensuse", "suse"},
+		{"alpine", "", ""},
+	}
+	for _, tt := range tests {
+		if got := distroFamily(map[string]string{"ID": tt.id, "ID_LIKE": tt.idLike}); got != tt.want {
+			t.Errorf("distroFamily(%s) = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+// tarGz builds a gzipped tar archive of the given files.
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// TestInstaller_Download tests downloading, verifying and extracting a build.
+func TestInstaller_Download(t *testing.T) {
+	archive := tarGz(t, map[string]string{
+		"HammerDB-4.6/hammerdbcli": "#!/bin/sh\necho 'HammerDB CLI v4.6'\n",
+		"HammerDB-4.6/README":      "readme",
+	})
+	sum := sha256.Sum256(archive)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	plan := DownloadPlan(config.ToolTypeHammerDB, server.URL+"/HammerDB-4.6-Linux.tar.gz", hex.EncodeToString(sum[:]))
+	plan.Executable = "hammerdbcli"
+	path, err := NewInstaller().Download(context.Background(), plan, dir)
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if want := filepath.Join(dir, "HammerDB-4.6-Linux", "HammerDB-4.6", "hammerdbcli"); path != want {
+		t.Errorf("Download() = %s, want %s", path, want)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("executable lost its mode: %v", info.Mode())
+	}
+
+	plan.SHA256 = "00"
+	if _, err := NewInstaller().Download(context.Background(), plan, dir); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Download() with a wrong checksum error = %v, want ErrChecksumMismatch", err)
+	}
+}
+
+// TestInstaller_DownloadRequiresChecksum tests that plans without a checksum must allow unverified downloads.
+func TestInstaller_DownloadRequiresChecksum(t *testing.T) {
+	archive := tarGz(t, map[string]string{"HammerDB-4.6/hammerdbcli": "#!/bin/sh\n"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	plan := &InstallPlan{Type: config.ToolTypeHammerDB, Method: InstallDownload, URL: server.URL + "/HammerDB-4.6-Linux.tar.gz", Executable: "hammerdbcli"}
+	if _, err := NewInstaller().Download(context.Background(), plan, dir); !errors.Is(err, ErrNoChecksum) {
+		t.Fatalf("Download() without a checksum error = %v, want ErrNoChecksum", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "HammerDB-4.6-Linux")); !os.IsNotExist(err) {
+		t.Error("unverified archive was installed")
+	}
+
+	// Archives the user names may be installed unverified
+	plan = DownloadPlan(config.ToolTypeHammerDB, plan.URL, "")
+	plan.Executable = "hammerdbcli"
+	if !plan.AllowUnverified {
+		t.Fatal("DownloadPlan() without a checksum does not allow unverified downloads")
+	}
+	if _, err := NewInstaller().Download(context.Background(), plan, dir); err != nil {
+		t.Errorf("Download() of an unverified user archive error = %v", err)
+	}
+}
+
+// TestInstaller_DownloadRejectsEscapingPaths tests that archive entries stay in the tools directory.
+func TestInstaller_DownloadRejectsEscapingPaths(t *testing.T) {
+	archive := tarGz(t, map[string]string{"../evil": "x"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(archive)
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "tools")
+	plan := DownloadPlan(config.ToolTypeHammerDB, server.URL+"/evil.tar.gz", "")
+	if _, err := NewInstaller().Download(context.Background(), plan, dir); err == nil {
+		t.Fatal("Download() should reject an entry outside the tools directory")
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "evil")); !os.IsNotExist(err) {
+		t.Error("entry was written outside the tools directory")
+	}
+}
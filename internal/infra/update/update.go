@@ -0,0 +1,281 @@
+// Package update checks the GitHub releases of DB-BenchMind for new versions
+// and downloads their binaries.
+package update
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults of NewClient.
+const (
+	DefaultAPIURL     = "https://api.github.com"
+	DefaultRepository = "whhaicheng/DB-BenchMind"
+)
+
+// maxChecksumsSize bounds the checksum files read.
+const maxChecksumsSize = 1 << 20
+
+// checksumAssets are the names of release assets listing SHA-256 sums in the
+// "HEX  NAME" format of sha256sum.
+var checksumAssets = []string{"checksums.txt", "sha256sums", "sha256sums.txt"}
+
+var (
+	// ErrNoRelease is returned when the repository has no published release.
+	ErrNoRelease = errors.New("no release published")
+
+	// ErrChecksumMismatch is returned when a download does not match the
+	// checksum published with the release.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+)
+
+// Asset is a file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+	Size int64  `json:"size"`
+}
+
+// Release is a published GitHub release.
+type Release struct {
+	Version     string    `json:"tag_name"` // e.g. v1.2.0
+	Name        string    `json:"name"`
+	Notes       string    `json:"body"` // Release notes in Markdown
+	URL         string    `json:"html_url"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []Asset   `json:"assets"`
+}
+
+// Asset returns the binary of the release for goos and goarch, or nil if the
+// release has none. Asset names must name both, e.g.
+// db-benchmind-linux-amd64.tar.gz or db-benchmind_Darwin_arm64.zip.
+func (r *Release) Asset(goos, goarch string) *Asset {
+	osNames := map[string][]string{"darwin": {"darwin", "macos"}, "windows": {"windows", "win64"}}[goos]
+	if osNames == nil {
+		osNames = []string{goos}
+	}
+	archNames := map[string][]string{"amd64": {"amd64", "x86_64", "x64"}, "arm64": {"arm64", "aarch64"}}[goarch]
+	if archNames == nil {
+		archNames = []string{goarch}
+	}
+	containsAny := func(s string, subs []string) bool {
+		for _, sub := range subs {
+			if strings.Contains(s, sub) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, asset := range r.Assets {
+		name := strings.ToLower(asset.Name)
+		if isChecksumAsset(name) || strings.HasSuffix(name, ".sha256") || strings.HasSuffix(name, ".sig") {
+			continue
+		}
+		if containsAny(name, osNames) && containsAny(name, archNames) {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Newer reports whether version latest is newer than current. Versions are
+// compared by their dot-separated numbers, with or without a "v" prefix; a
+// release is newer than a pre-release (suffix after "-") of the same numbers.
+func Newer(latest, current string) bool {
+	latestNums, latestPre := parseVersion(latest)
+	currentNums, currentPre := parseVersion(current)
+	for i := 0; i < max(len(latestNums), len(currentNums)); i++ {
+		var l, c int
+		if i < len(latestNums) {
+			l = latestNums[i]
+		}
+		if i < len(currentNums) {
+			c = currentNums[i]
+		}
+		if l != c {
+			return l > c
+		}
+	}
+	return currentPre && !latestPre
+}
+
+// parseVersion returns the numbers of a version and whether it is a
+// pre-release. Parts that are not numbers count as 0.
+func parseVersion(version string) (nums []int, pre bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, _, _ = strings.Cut(version, "+") // Build metadata
+	version, preRelease, found := strings.Cut(version, "-")
+	for _, part := range strings.Split(version, ".") {
+		n, _ := strconv.Atoi(part)
+		nums = append(nums, n)
+	}
+	return nums, found && preRelease != ""
+}
+
+// Client queries the releases of a GitHub repository.
+type Client struct {
+	apiURL     string
+	repository string
+	client     *http.Client
+}
+
+// NewClient creates a client of the releases of repository (owner/name) at
+// the GitHub API apiURL. Empty arguments select DefaultAPIURL and
+// DefaultRepository.
+func NewClient(apiURL, repository string) *Client {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	if repository == "" {
+		repository = DefaultRepository
+	}
+	return &Client{apiURL: strings.TrimSuffix(apiURL, "/"), repository: repository, client: http.DefaultClient}
+}
+
+// Latest returns the latest published release, or ErrNoRelease.
+func (c *Client) Latest(ctx context.Context) (*Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", c.apiURL, c.repository)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "DB-BenchMind")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("query releases: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNoRelease
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("query releases: %s", resp.Status)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("parse release: %w", err)
+	}
+	return &release, nil
+}
+
+// Download downloads asset of release into dir, keeping its name, and
+// verifies it against the checksum file of the release if it has one.
+// Returns the path of the file and whether its checksum was verified.
+func (c *Client) Download(ctx context.Context, release *Release, asset *Asset, dir string) (string, bool, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", false, fmt.Errorf("create download directory: %w", err)
+	}
+
+	want, err := c.checksum(ctx, release, asset.Name)
+	if err != nil {
+		return "", false, err
+	}
+
+	file, err := os.CreateTemp(dir, ".download-*")
+	if err != nil {
+		return "", false, fmt.Errorf("create download file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	slog.Info("Update: Downloading", "version", release.Version, "url", asset.URL)
+	hash := sha256.New()
+	if err := c.fetch(ctx, asset.URL, io.MultiWriter(file, hash), 0); err != nil {
+		return "", false, err
+	}
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if want != "" && !strings.EqualFold(sum, want) {
+		return "", false, fmt.Errorf("%w: %s has SHA-256 %s, want %s", ErrChecksumMismatch, asset.Name, sum, want)
+	}
+	if err := file.Close(); err != nil {
+		return "", false, fmt.Errorf("write %s: %w", asset.Name, err)
+	}
+
+	dest := filepath.Join(dir, filepath.Base(asset.Name))
+	if err := os.Rename(file.Name(), dest); err != nil {
+		return "", false, fmt.Errorf("save %s: %w", dest, err)
+	}
+	if !strings.HasSuffix(dest, ".zip") && !strings.Contains(dest, ".tar") && !strings.HasSuffix(dest, ".tgz") {
+		os.Chmod(dest, 0755) // A bare executable
+	}
+	slog.Info("Update: Downloaded", "version", release.Version, "file", dest, "sha256", sum, "checksum_verified", want != "")
+	return dest, want != "", nil
+}
+
+// checksum returns the SHA-256 of the asset name published with release, or
+// "" if the release has no checksum file.
+func (c *Client) checksum(ctx context.Context, release *Release, name string) (string, error) {
+	for _, asset := range release.Assets {
+		lower := strings.ToLower(asset.Name)
+		if !isChecksumAsset(lower) && lower != strings.ToLower(name)+".sha256" {
+			continue
+		}
+		var b strings.Builder
+		if err := c.fetch(ctx, asset.URL, &b, maxChecksumsSize); err != nil {
+			return "", err
+		}
+		scanner := bufio.NewScanner(strings.NewReader(b.String()))
+		for scanner.Scan() {
+			fields := strings.Fields(scanner.Text())
+			// A NAME.sha256 file may hold the sum alone
+			if len(fields) == 1 && !isChecksumAsset(lower) {
+				return fields[0], nil
+			}
+			if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+				return fields[0], nil
+			}
+		}
+		return "", fmt.Errorf("%s has no checksum of %s", asset.Name, name)
+	}
+	return "", nil
+}
+
+// fetch downloads url into w. A positive limit bounds the size.
+func (c *Client) fetch(ctx context.Context, url string, w io.Writer, limit int64) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "DB-BenchMind")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download %s: %s", url, resp.Status)
+	}
+
+	var body io.Reader = resp.Body
+	if limit > 0 {
+		body = io.LimitReader(body, limit)
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		return fmt.Errorf("download %s: %w", url, err)
+	}
+	return nil
+}
+
+// isChecksumAsset reports whether the lower-case asset name is a checksum
+// file of all assets.
+func isChecksumAsset(name string) bool {
+	return slices.Contains(checksumAssets, name)
+}
@@ -0,0 +1,144 @@
+package update
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"v1.1.0", "1.0.0", true},
+		{"v1.0.0", "1.0.0", false},
+		{"1.0.1", "v1.0", true},
+		{"v1.0.0", "1.0.0-rc1", true},
+		{"v1.0.0-rc2", "1.0.0", false},
+		{"v0.9.9", "1.0.0", false},
+		{"v1.10.0", "1.9.3", true},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestRelease_Asset(t *testing.T) {
+	release := &Release{Assets: []Asset{
+		{Name: "checksums.txt"},
+		{Name: "db-benchmind-linux-amd64.tar.gz.sha256"},
+		{Name: "db-benchmind-linux-amd64.tar.gz"},
+		{Name: "db-benchmind_Darwin_arm64.zip"},
+		{Name: "db-benchmind-windows-x86_64.zip"},
+	}}
+	tests := []struct {
+		goos, goarch string
+		want         string
+	}{
+		{"linux", "amd64", "db-benchmind-linux-amd64.tar.gz"},
+		{"darwin", "arm64", "db-benchmind_Darwin_arm64.zip"},
+		{"windows", "amd64", "db-benchmind-windows-x86_64.zip"},
+		{"linux", "arm64", ""},
+	}
+	for _, tt := range tests {
+		var got string
+		if asset := release.Asset(tt.goos, tt.goarch); asset != nil {
+			got = asset.Name
+		}
+		if got != tt.want {
+			t.Errorf("Asset(%s, %s) = %q, want %q", tt.goos, tt.goarch, got, tt.want)
+		}
+	}
+}
+
+// newReleaseServer serves a latest release with a binary and, unless sum is
+// empty, a checksum file listing sum for it.
+func newReleaseServer(t *testing.T, binary []byte, sum string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	release := Release{
+		Version: "v1.2.0",
+		Notes:   "## Changes\n- Faster",
+		Assets:  []Asset{{Name: "db-benchmind-linux-amd64", URL: server.URL + "/download/bin"}},
+	}
+	if sum != "" {
+		release.Assets = append(release.Assets, Asset{Name: "checksums.txt", URL: server.URL + "/download/checksums"})
+	}
+	mux.HandleFunc("/repos/owner/repo/releases/latest", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(release)
+	})
+	mux.HandleFunc("/download/bin", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(binary)
+	})
+	mux.HandleFunc("/download/checksums", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s  other-file\n%s  db-benchmind-linux-amd64\n", sum, sum)
+	})
+	return server
+}
+
+func TestClient_LatestAndDownload(t *testing.T) {
+	ctx := context.Background()
+	binary := []byte("#!/bin/sh\necho new\n")
+	sum := sha256.Sum256(binary)
+	server := newReleaseServer(t, binary, hex.EncodeToString(sum[:]))
+	client := NewClient(server.URL, "owner/repo")
+
+	release, err := client.Latest(ctx)
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	if release.Version != "v1.2.0" || release.Notes == "" {
+		t.Errorf("Latest() = %+v, want v1.2.0 with notes", release)
+	}
+
+	dir := t.TempDir()
+	path, verified, err := client.Download(ctx, release, release.Asset("linux", "amd64"), dir)
+	if err != nil {
+		t.Fatalf("Download() error = %v", err)
+	}
+	if !verified {
+		t.Error("Download() verified = false, want true")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != string(binary) {
+		t.Errorf("downloaded %q, %v; want %q", data, err, binary)
+	}
+}
+
+func TestClient_DownloadChecksumMismatch(t *testing.T) {
+	ctx := context.Background()
+	server := newReleaseServer(t, []byte("tampered"), hex.EncodeToString(make([]byte, 32)))
+	client := NewClient(server.URL, "owner/repo")
+	release, err := client.Latest(ctx)
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	if _, _, err := client.Download(ctx, release, release.Asset("linux", "amd64"), dir); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("Download() error = %v, want ErrChecksumMismatch", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("download directory has %d files, want none", len(entries))
+	}
+}
+
+func TestClient_LatestNoRelease(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	if _, err := NewClient(server.URL, "owner/repo").Latest(context.Background()); !errors.Is(err, ErrNoRelease) {
+		t.Errorf("Latest() error = %v, want ErrNoRelease", err)
+	}
+}
@@ -0,0 +1,184 @@
+package grpcapi
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/access"
+	"github.com/whhaicheng/DB-BenchMind/pkg/api"
+)
+
+// ErrInvalidToken is returned by an Authenticator for a token it does not accept.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Authenticator authenticates the bearer token of a request and returns the
+// role the request acts as: StaticTokens for fixed tokens, OIDC for the
+// tokens of an OIDC provider.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (access.Role, error)
+}
+
+// StaticTokens authenticates requests with fixed tokens, each granting a role.
+type StaticTokens map[string]access.Role
+
+// Authenticate returns the role of token.
+func (t StaticTokens) Authenticate(_ context.Context, token string) (access.Role, error) {
+	for known, role := range t {
+		if subtle.ConstantTimeCompare([]byte(known), []byte(token)) == 1 {
+			return role, nil
+		}
+	}
+	return "", ErrInvalidToken
+}
+
+// LoadStaticTokens reads a token file: one "<role> <token>" per line, where
+// role is admin, operator or viewer; blank lines and lines starting with # are skipped.
+func LoadStaticTokens(path string) (StaticTokens, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tokens := make(StaticTokens)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%s:%d: want \"<role> <token>\"", path, n)
+		}
+		role := access.Role(fields[0])
+		if err := role.Validate(); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		tokens[fields[1]] = role
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%s: no tokens", path)
+	}
+	return tokens, nil
+}
+
+// methodPermissions are the permissions the methods of the API require.
+// Methods not listed require the admin role.
+var methodPermissions = map[string]access.Permission{
+	api.ConnectionService_ListConnections_FullMethodName: access.PermissionRunBenchmarks,
+	api.ConnectionService_GetConnection_FullMethodName:   access.PermissionRunBenchmarks,
+	api.ConnectionService_TestConnection_FullMethodName:  access.PermissionRunBenchmarks,
+	api.RunService_StartRun_FullMethodName:               access.PermissionRunBenchmarks,
+	api.RunService_StopRun_FullMethodName:                access.PermissionRunBenchmarks,
+	api.RunService_GetRun_FullMethodName:                 access.PermissionViewHistory,
+	api.RunService_ListRuns_FullMethodName:               access.PermissionViewHistory,
+	api.RunService_StreamSamples_FullMethodName:          access.PermissionViewHistory,
+	api.HistoryService_ListRecords_FullMethodName:        access.PermissionViewHistory,
+	api.HistoryService_GetRecord_FullMethodName:          access.PermissionViewHistory,
+}
+
+// roleKey is the context key of the role a request was authenticated as.
+type roleKey struct{}
+
+// RequestRole returns the role the request of ctx was authenticated as, if any.
+func RequestRole(ctx context.Context) (access.Role, bool) {
+	role, ok := ctx.Value(roleKey{}).(access.Role)
+	return role, ok
+}
+
+// ServerOptions returns the options that authenticate every request with
+// auth: the token is read from the "authorization: Bearer <token>" metadata,
+// and the role it grants must have the permission of the method.
+func ServerOptions(auth Authenticator) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			ctx, err := authorize(ctx, auth, info.FullMethod)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, err := authorize(ss.Context(), auth, info.FullMethod)
+			if err != nil {
+				return err
+			}
+			return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx})
+		}),
+	}
+}
+
+// authorize authenticates the request of ctx and checks that its role may
+// call method. The returned context carries the role.
+func authorize(ctx context.Context, auth Authenticator, method string) (context.Context, error) {
+	token := bearerToken(ctx)
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	role, err := auth.Authenticate(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	permission, ok := methodPermissions[method]
+	if (ok && !role.Can(permission)) || (!ok && role != access.RoleAdmin) {
+		return nil, status.Errorf(codes.PermissionDenied, "the %s role cannot call %s", role, method)
+	}
+	return context.WithValue(ctx, roleKey{}, role), nil
+}
+
+// bearerToken returns the bearer token of the authorization metadata of ctx.
+func bearerToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		scheme, token, ok := strings.Cut(value, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// authorizedStream is a server stream with the context of its authenticated request.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context { return s.ctx }
+
+// RequestAccess returns a checker that also applies the role of an
+// authenticated request, so that the use cases restrict a request to its
+// token's role (e.g. an operator token cannot start a cleanup) on top of next.
+func RequestAccess(next usecase.AccessChecker) usecase.AccessChecker {
+	return requestAccess{next: next}
+}
+
+// requestAccess checks the role of the request, then next.
+type requestAccess struct {
+	next usecase.AccessChecker
+}
+
+func (a requestAccess) Require(ctx context.Context, permission access.Permission) error {
+	if role, ok := RequestRole(ctx); ok && !role.Can(permission) {
+		return fmt.Errorf("%w: the %s role cannot %s", usecase.ErrPermissionDenied, role, permission)
+	}
+	if a.next == nil {
+		return nil
+	}
+	return a.next.Require(ctx, permission)
+}
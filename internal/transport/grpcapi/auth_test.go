@@ -0,0 +1,109 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/access"
+	"github.com/whhaicheng/DB-BenchMind/pkg/api"
+)
+
+func TestServerOptions(t *testing.T) {
+	tokens := StaticTokens{"admin-token": access.RoleAdmin, "op-token": access.RoleOperator}
+	ts := newTestServer(t, ServerOptions(tokens)...)
+	client := api.NewHistoryServiceClient(ts.conn)
+
+	tests := []struct {
+		name string
+		md   []string
+		want codes.Code
+	}{
+		{"no token", nil, codes.Unauthenticated},
+		{"wrong token", []string{"authorization", "Bearer nope"}, codes.Unauthenticated},
+		{"not a bearer token", []string{"authorization", "Basic op-token"}, codes.Unauthenticated},
+		{"operator", []string{"authorization", "Bearer op-token"}, codes.OK},
+		{"admin", []string{"authorization", "bearer admin-token"}, codes.OK},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.md != nil {
+			ctx = metadata.AppendToOutgoingContext(ctx, tt.md...)
+		}
+		_, err := client.ListRecords(ctx, &api.ListRecordsRequest{})
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("%s: ListRecords() error = %v, want %s", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	tokens := StaticTokens{"op-token": access.RoleOperator}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer op-token"))
+
+	authorized, err := authorize(ctx, tokens, api.RunService_StartRun_FullMethodName)
+	if err != nil {
+		t.Fatalf("authorize(StartRun) error = %v", err)
+	}
+	if role, ok := RequestRole(authorized); !ok || role != access.RoleOperator {
+		t.Errorf("RequestRole() = %q, %v, want operator", role, ok)
+	}
+
+	// A viewer may read history but not start runs
+	viewerCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer view-token"))
+	viewerTokens := StaticTokens{"view-token": access.RoleViewer}
+	if _, err := authorize(viewerCtx, viewerTokens, api.HistoryService_ListRecords_FullMethodName); err != nil {
+		t.Errorf("authorize(viewer, ListRecords) error = %v", err)
+	}
+	if _, err := authorize(viewerCtx, viewerTokens, api.RunService_StartRun_FullMethodName); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("authorize(viewer, StartRun) error = %v, want PermissionDenied", err)
+	}
+
+	// Methods without a permission are for admins only
+	if _, err := authorize(ctx, tokens, "/dbbenchmind.v1.AdminService/Reset"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("authorize(unlisted method) error = %v, want PermissionDenied", err)
+	}
+}
+
+// TestRequestAccess tests that the role of a request restricts the use cases.
+func TestRequestAccess(t *testing.T) {
+	checker := RequestAccess(nil)
+	operator := context.WithValue(context.Background(), roleKey{}, access.RoleOperator)
+
+	if err := checker.Require(operator, access.PermissionRunBenchmarks); err != nil {
+		t.Errorf("Require(run benchmarks) error = %v", err)
+	}
+	if err := checker.Require(operator, access.PermissionCleanup); !errors.Is(err, usecase.ErrPermissionDenied) {
+		t.Errorf("Require(cleanup) error = %v, want ErrPermissionDenied", err)
+	}
+	// Requests without authentication are left to the next checker
+	if err := checker.Require(context.Background(), access.PermissionCleanup); err != nil {
+		t.Errorf("Require() without a role error = %v", err)
+	}
+}
+
+func TestLoadStaticTokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens")
+	os.WriteFile(path, []byte("# API tokens\nadmin s3cr3t\n\noperator 0p3r\n"), 0600)
+	tokens, err := LoadStaticTokens(path)
+	if err != nil {
+		t.Fatalf("LoadStaticTokens() error = %v", err)
+	}
+	if len(tokens) != 2 || tokens["s3cr3t"] != access.RoleAdmin || tokens["0p3r"] != access.RoleOperator {
+		t.Errorf("LoadStaticTokens() = %v", tokens)
+	}
+
+	for _, content := range []string{"", "guest token\n", "admin\n"} {
+		os.WriteFile(path, []byte(content), 0600)
+		if _, err := LoadStaticTokens(path); err == nil {
+			t.Errorf("LoadStaticTokens(%q) error = nil", content)
+		}
+	}
+}
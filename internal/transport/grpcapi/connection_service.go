@@ -0,0 +1,79 @@
+package grpcapi
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/pkg/api"
+)
+
+// connectionService implements api.ConnectionServiceServer.
+type connectionService struct {
+	api.UnimplementedConnectionServiceServer
+	s *Server
+}
+
+// ListConnections lists the saved connections.
+func (cs *connectionService) ListConnections(ctx context.Context, _ *api.ListConnectionsRequest) (*api.ListConnectionsResponse, error) {
+	conns, err := cs.s.connUC.ListConnections(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &api.ListConnectionsResponse{Connections: make([]*api.Connection, 0, len(conns))}
+	for _, conn := range conns {
+		resp.Connections = append(resp.Connections, connectionToProto(conn))
+	}
+	return resp, nil
+}
+
+// GetConnection returns a connection by ID or name.
+func (cs *connectionService) GetConnection(ctx context.Context, req *api.GetConnectionRequest) (*api.Connection, error) {
+	conn, err := cs.s.findConnection(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return connectionToProto(conn), nil
+}
+
+// TestConnection tests a connection. A failed test is reported in the
+// response, not as an error.
+func (cs *connectionService) TestConnection(ctx context.Context, req *api.TestConnectionRequest) (*api.TestConnectionResponse, error) {
+	conn, err := cs.s.findConnection(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	result, err := cs.s.connUC.TestConnection(ctx, conn.GetID())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.TestConnectionResponse{
+		Success:         result.Success,
+		LatencyMs:       result.LatencyMs,
+		DatabaseVersion: result.DatabaseVersion,
+		Error:           result.Error,
+	}, nil
+}
+
+// findConnection returns the saved connection whose ID or name is ref, with its passwords loaded.
+func (s *Server) findConnection(ctx context.Context, ref string) (connection.Connection, error) {
+	if ref == "" {
+		return nil, status.Error(codes.InvalidArgument, "connection id is required")
+	}
+	conns, err := s.connUC.ListConnections(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	for _, conn := range conns {
+		if conn.GetID() == ref || conn.GetName() == ref {
+			conn, err := s.connUC.GetConnectionByID(ctx, conn.GetID())
+			if err != nil {
+				return nil, toStatus(err)
+			}
+			return conn, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "connection not found: %s", ref)
+}
@@ -0,0 +1,122 @@
+package grpcapi
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/pkg/api"
+)
+
+// connectionToProto converts a connection, leaving out its passwords.
+func connectionToProto(conn connection.Connection) *api.Connection {
+	return &api.Connection{
+		Id:           conn.GetID(),
+		Name:         conn.GetName(),
+		DatabaseType: string(conn.GetType()),
+		Address:      conn.Redact(),
+		Protected:    conn.IsProtected(),
+		Observer:     conn.IsObserver(),
+	}
+}
+
+// runToProto converts a run.
+func runToProto(run *execution.Run) *api.Run {
+	msg := &api.Run{
+		Id:           run.ID,
+		TaskId:       run.TaskID,
+		State:        string(run.State),
+		CreatedAt:    timestamppb.New(run.CreatedAt),
+		StartedAt:    timeToProto(run.StartedAt),
+		CompletedAt:  timeToProto(run.CompletedAt),
+		ErrorMessage: run.ErrorMessage,
+		Message:      run.Message,
+		Parameters:   run.Parameters,
+	}
+	if run.Duration != nil {
+		msg.Duration = durationpb.New(*run.Duration)
+	}
+	if r := run.Result; r != nil {
+		msg.Result = &api.RunResult{
+			Tps:               r.TPSCalculated,
+			LatencyAvgMs:      r.LatencyAvg,
+			LatencyMinMs:      r.LatencyMin,
+			LatencyMaxMs:      r.LatencyMax,
+			LatencyP95Ms:      r.LatencyP95,
+			LatencyP99Ms:      r.LatencyP99,
+			ErrorCount:        r.ErrorCount,
+			ErrorRatePercent:  r.ErrorRate,
+			TotalTransactions: r.TotalTransactions,
+			TotalQueries:      r.TotalQueries,
+		}
+	}
+	return msg
+}
+
+// sampleToProto converts a realtime sample.
+func sampleToProto(sample execution.MetricSample) *api.Sample {
+	return &api.Sample{
+		Timestamp:        timestamppb.New(sample.Timestamp),
+		Phase:            sample.Phase,
+		Tps:              sample.TPS,
+		Qps:              sample.QPS,
+		LatencyAvgMs:     sample.LatencyAvg,
+		LatencyP95Ms:     sample.LatencyP95,
+		LatencyP99Ms:     sample.LatencyP99,
+		ErrorRatePercent: sample.ErrorRate,
+	}
+}
+
+// recordToProto converts a history record, with its time series if includeSeries is set.
+func recordToProto(record *history.Record, includeSeries bool) *api.Record {
+	msg := &api.Record{
+		Id:             record.ID,
+		CreatedAt:      timestamppb.New(record.CreatedAt),
+		ConnectionName: record.ConnectionName,
+		TemplateName:   record.TemplateName,
+		DatabaseType:   record.DatabaseType,
+		Threads:        int32(record.Threads),
+		Agents:         record.Agents,
+		Tags:           record.Tags,
+		Notes:          record.Notes,
+		State:          record.State,
+		ErrorMessage:   record.ErrorMessage,
+		Valid:          record.IsValid(),
+		Parameters:     record.Parameters,
+		StartTime:      timestamppb.New(record.StartTime),
+		Duration:       durationpb.New(record.Duration),
+		Purpose:        record.Purpose,
+		Ticket:         record.Ticket,
+		Environment:    record.Environment,
+	}
+	if record.IsCompleted() {
+		msg.Result = &api.RunResult{
+			Tps:               record.TPSCalculated,
+			LatencyAvgMs:      record.LatencyAvg,
+			LatencyMinMs:      record.LatencyMin,
+			LatencyMaxMs:      record.LatencyMax,
+			LatencyP95Ms:      record.LatencyP95,
+			LatencyP99Ms:      record.LatencyP99,
+			TotalTransactions: record.TotalTransactions,
+			TotalQueries:      record.TotalQueries,
+		}
+	}
+	if includeSeries {
+		for _, sample := range record.TimeSeries {
+			msg.TimeSeries = append(msg.TimeSeries, sampleToProto(execution.MetricSample(sample)))
+		}
+	}
+	return msg
+}
+
+// timeToProto converts an optional time.
+func timeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,53 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/pkg/api"
+)
+
+// historyService implements api.HistoryServiceServer.
+type historyService struct {
+	api.UnimplementedHistoryServiceServer
+	s *Server
+}
+
+// ListRecords lists the history records matching the filters, newest first,
+// without their time series.
+func (hs *historyService) ListRecords(ctx context.Context, req *api.ListRecordsRequest) (*api.ListRecordsResponse, error) {
+	opts := &repository.ListOptions{
+		Limit:          int(req.GetLimit()),
+		Offset:         int(req.GetOffset()),
+		ConnectionName: req.GetConnectionName(),
+		TemplateName:   req.GetTemplateName(),
+		DatabaseType:   req.GetDatabaseType(),
+		Threads:        int(req.GetThreads()),
+		Search:         req.GetSearch(),
+		Tags:           req.GetTags(),
+		ValidOnly:      req.GetValidOnly(),
+	}
+	records, err := hs.s.historyUC.ListRecords(ctx, opts)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	total, err := hs.s.historyUC.CountRecords(ctx, opts)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &api.ListRecordsResponse{Records: make([]*api.Record, 0, len(records)), Total: int32(total)}
+	for _, record := range records {
+		resp.Records = append(resp.Records, recordToProto(record, false))
+	}
+	return resp, nil
+}
+
+// GetRecord returns a history record.
+func (hs *historyService) GetRecord(ctx context.Context, req *api.GetRecordRequest) (*api.Record, error) {
+	record, err := hs.s.historyUC.GetRecordByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return recordToProto(record, req.GetIncludeTimeSeries()), nil
+}
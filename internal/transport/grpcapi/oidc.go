@@ -0,0 +1,338 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/access"
+)
+
+// OIDCConfig configures authentication with the tokens of an OIDC provider
+// (e.g. Keycloak or Azure AD).
+type OIDCConfig struct {
+	// Issuer is the issuer URL of the provider, e.g.
+	// https://sso.example.com/realms/eng; its discovery document is read
+	// from Issuer + "/.well-known/openid-configuration".
+	Issuer string `json:"issuer"`
+	// Audience is the client ID the tokens must be issued for.
+	Audience string `json:"audience"`
+	// GroupsClaim is the claim listing the groups of the user; a dotted
+	// path reads a nested claim (e.g. realm_access.roles). Default "groups".
+	GroupsClaim string `json:"groups_claim,omitempty"`
+	// Groups maps groups to the role they grant. A user in several mapped
+	// groups gets the most privileged role.
+	Groups map[string]access.Role `json:"groups"`
+}
+
+// LoadOIDCConfig reads an OIDC configuration from a JSON file.
+func LoadOIDCConfig(path string) (*OIDCConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg OIDCConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Validate checks that the configuration names an issuer, an audience and
+// maps at least one group to a known role.
+func (c *OIDCConfig) Validate() error {
+	if c.Issuer == "" || c.Audience == "" {
+		return errors.New("issuer and audience are required")
+	}
+	if len(c.Groups) == 0 {
+		return errors.New("no groups are mapped to roles")
+	}
+	for group, role := range c.Groups {
+		if err := role.Validate(); err != nil {
+			return fmt.Errorf("group %s: %w", group, err)
+		}
+	}
+	return nil
+}
+
+// rolePrecedence orders the roles from the most privileged.
+var rolePrecedence = []access.Role{access.RoleAdmin, access.RoleOperator, access.RoleViewer}
+
+// jwksRefreshInterval is how often an unknown key ID may trigger reading
+// the key set again, e.g. after the provider rotated its keys.
+const jwksRefreshInterval = time.Minute
+
+// oidcSigningMethods are the signature algorithms accepted for tokens.
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// OIDC authenticates requests with the tokens of an OIDC provider: the
+// signature is checked with the keys of the provider's key set, the issuer,
+// audience and expiry are validated, and the groups claim is mapped to a role.
+type OIDC struct {
+	cfg     OIDCConfig
+	client  *http.Client
+	jwksURI string
+	parser  *jwt.Parser
+
+	mu        sync.Mutex
+	keys      map[string]crypto.PublicKey // By key ID
+	refreshed time.Time                   // When keys were last read
+}
+
+// NewOIDC reads the discovery document and the key set of the issuer of cfg.
+func NewOIDC(ctx context.Context, cfg OIDCConfig, client *http.Client) (*OIDC, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	var discovery struct {
+		Issuer  string `json:"issuer"`
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := getJSON(ctx, client, strings.TrimSuffix(cfg.Issuer, "/")+"/.well-known/openid-configuration", &discovery); err != nil {
+		return nil, fmt.Errorf("read OIDC discovery document: %w", err)
+	}
+	// The discovery document must be the issuer's own (OpenID Connect Discovery 1.0, section 4.3)
+	if discovery.Issuer != cfg.Issuer {
+		return nil, fmt.Errorf("discovery document issuer %q does not match %q", discovery.Issuer, cfg.Issuer)
+	}
+	if discovery.JWKSURI == "" {
+		return nil, errors.New("discovery document has no jwks_uri")
+	}
+
+	o := &OIDC{
+		cfg:     cfg,
+		client:  client,
+		jwksURI: discovery.JWKSURI,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods(oidcSigningMethods),
+			jwt.WithIssuer(cfg.Issuer),
+			jwt.WithAudience(cfg.Audience),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(time.Minute),
+		),
+	}
+	if err := o.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Authenticate verifies token and returns the role of its groups.
+func (o *OIDC) Authenticate(ctx context.Context, token string) (access.Role, error) {
+	parsed, err := o.parser.Parse(token, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return o.key(ctx, kid)
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	claims, _ := parsed.Claims.(jwt.MapClaims)
+	groups := claimStrings(claims, o.cfg.GroupsClaim)
+	for _, role := range rolePrecedence {
+		for _, group := range groups {
+			if o.cfg.Groups[group] == role {
+				return role, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%w: no group of the token is mapped to a role", ErrInvalidToken)
+}
+
+// key returns the public key with the key ID kid, reading the key set
+// again for an unknown ID at most once per jwksRefreshInterval.
+func (o *OIDC) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	o.mu.Lock()
+	key, ok := o.keys[kid]
+	stale := time.Since(o.refreshed) >= jwksRefreshInterval
+	o.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+	if stale {
+		if err := o.refreshKeys(ctx); err != nil {
+			return nil, err
+		}
+		o.mu.Lock()
+		key, ok = o.keys[kid]
+		o.mu.Unlock()
+		if ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// refreshKeys reads the key set of the provider.
+func (o *OIDC) refreshKeys(ctx context.Context) error {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := getJSON(ctx, o.client, o.jwksURI, &set); err != nil {
+		return fmt.Errorf("read OIDC key set: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			// Skip keys of types tokens are not signed with
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return errors.New("OIDC key set has no usable signing keys")
+	}
+
+	o.mu.Lock()
+	o.keys = keys
+	o.refreshed = time.Now()
+	o.mu.Unlock()
+	return nil
+}
+
+// jsonWebKey is an RSA or EC public key of a key set (RFC 7517).
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey decodes the key.
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: n: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: e: %w", k.Kid, err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("key %s: invalid exponent", k.Kid)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("key %s: unsupported curve %q", k.Kid, k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: x: %w", k.Kid, err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: y: %w", k.Kid, err)
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("key %s: point is not on the curve", k.Kid)
+		}
+		return key, nil
+	}
+	return nil, fmt.Errorf("key %s: unsupported key type %q", k.Kid, k.Kty)
+}
+
+// claimStrings returns the strings of the claim at the dotted path.
+func claimStrings(claims jwt.MapClaims, path string) []string {
+	var value any = map[string]any(claims)
+	for _, name := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[name]
+	}
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var values []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// getJSON decodes the JSON document at url into v.
+func getJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// Authenticators authenticates a request with the first authenticator that
+// accepts its token, e.g. static tokens for scripts and OIDC for users.
+type Authenticators []Authenticator
+
+// Authenticate returns the role of the first authenticator accepting token.
+func (a Authenticators) Authenticate(ctx context.Context, token string) (access.Role, error) {
+	err := ErrInvalidToken
+	for _, auth := range a {
+		role, authErr := auth.Authenticate(ctx, token)
+		if authErr == nil {
+			return role, nil
+		}
+		// Report why a JWT was rejected rather than that it is no static token
+		if _, static := auth.(StaticTokens); !static {
+			err = authErr
+		}
+	}
+	return "", err
+}
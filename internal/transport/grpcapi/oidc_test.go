@@ -0,0 +1,218 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/access"
+)
+
+// testProvider is an OIDC provider serving its discovery document and key set.
+type testProvider struct {
+	server *httptest.Server
+	mu     sync.Mutex
+	keys   []map[string]string
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	t.Helper()
+	p := &testProvider{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"issuer": p.server.URL, "jwks_uri": p.server.URL + "/keys"})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]any{"keys": p.keys})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// addRSAKey publishes a new RSA key and returns its private key.
+func (p *testProvider) addRSAKey(t *testing.T, kid string) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.mu.Lock()
+	p.keys = append(p.keys, map[string]string{
+		"kty": "RSA", "kid": kid, "use": "sig",
+		"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	})
+	p.mu.Unlock()
+	return key
+}
+
+// token signs claims with key, adding the issuer, audience and expiry.
+func (p *testProvider) token(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	all := jwt.MapClaims{"iss": p.server.URL, "aud": "db-benchmind", "sub": "alice", "exp": time.Now().Add(time.Hour).Unix()}
+	for name, value := range claims {
+		all[name] = value
+	}
+	token := jwt.NewWithClaims(method, all)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func (p *testProvider) config() OIDCConfig {
+	return OIDCConfig{
+		Issuer:   p.server.URL,
+		Audience: "db-benchmind",
+		Groups: map[string]access.Role{
+			"bench-admins":    access.RoleAdmin,
+			"bench-operators": access.RoleOperator,
+			"engineering":     access.RoleViewer,
+		},
+	}
+}
+
+func TestOIDC_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestProvider(t)
+	key := provider.addRSAKey(t, "k1")
+	auth, err := NewOIDC(ctx, provider.config(), nil)
+	if err != nil {
+		t.Fatalf("NewOIDC() error = %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		want  access.Role
+	}{
+		{"viewer", provider.token(t, jwt.SigningMethodRS256, key, "k1", jwt.MapClaims{"groups": []string{"engineering"}}), access.RoleViewer},
+		{"most privileged group", provider.token(t, jwt.SigningMethodRS256, key, "k1", jwt.MapClaims{"groups": []string{"engineering", "bench-operators"}}), access.RoleOperator},
+		{"admin", provider.token(t, jwt.SigningMethodRS256, key, "k1", jwt.MapClaims{"groups": []string{"bench-admins"}}), access.RoleAdmin},
+		{"no mapped group", provider.token(t, jwt.SigningMethodRS256, key, "k1", jwt.MapClaims{"groups": []string{"sales"}}), ""},
+		{"no groups", provider.token(t, jwt.SigningMethodRS256, key, "k1", nil), ""},
+		{"other audience", provider.token(t, jwt.SigningMethodRS256, key, "k1", jwt.MapClaims{"aud": "other", "groups": []string{"bench-admins"}}), ""},
+		{"other issuer", provider.token(t, jwt.SigningMethodRS256, key, "k1", jwt.MapClaims{"iss": "https://evil.example.com", "groups": []string{"bench-admins"}}), ""},
+		{"expired", provider.token(t, jwt.SigningMethodRS256, key, "k1", jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix(), "groups": []string{"bench-admins"}}), ""},
+		{"forged signature", provider.token(t, jwt.SigningMethodRS256, otherKey, "k1", jwt.MapClaims{"groups": []string{"bench-admins"}}), ""},
+		{"unsigned", provider.token(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "k1", jwt.MapClaims{"groups": []string{"bench-admins"}}), ""},
+		{"not a JWT", "op-token", ""},
+	}
+	for _, tt := range tests {
+		role, err := auth.Authenticate(ctx, tt.token)
+		if tt.want == "" {
+			if !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("%s: Authenticate() = %q, %v, want ErrInvalidToken", tt.name, role, err)
+			}
+			continue
+		}
+		if err != nil || role != tt.want {
+			t.Errorf("%s: Authenticate() = %q, %v, want %s", tt.name, role, err, tt.want)
+		}
+	}
+}
+
+func TestOIDC_KeyRotationAndNestedClaim(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestProvider(t)
+	provider.addRSAKey(t, "k1")
+	cfg := provider.config()
+	cfg.GroupsClaim = "realm_access.roles"
+	auth, err := NewOIDC(ctx, cfg, nil)
+	if err != nil {
+		t.Fatalf("NewOIDC() error = %v", err)
+	}
+
+	// A key published after start is read on first use
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.mu.Lock()
+	provider.keys = append(provider.keys, map[string]string{
+		"kty": "EC", "kid": "k2", "crv": "P-256",
+		"x": base64.RawURLEncoding.EncodeToString(ecKey.X.FillBytes(make([]byte, 32))),
+		"y": base64.RawURLEncoding.EncodeToString(ecKey.Y.FillBytes(make([]byte, 32))),
+	})
+	provider.mu.Unlock()
+	auth.refreshed = time.Time{}
+
+	token := provider.token(t, jwt.SigningMethodES256, ecKey, "k2", jwt.MapClaims{"realm_access": map[string]any{"roles": []string{"bench-operators"}}})
+	if role, err := auth.Authenticate(ctx, token); err != nil || role != access.RoleOperator {
+		t.Errorf("Authenticate() with a rotated key = %q, %v, want operator", role, err)
+	}
+}
+
+func TestNewOIDC_IssuerMismatch(t *testing.T) {
+	provider := newTestProvider(t)
+	provider.addRSAKey(t, "k1")
+	cfg := provider.config()
+	cfg.Issuer = provider.server.URL + "/"
+	if _, err := NewOIDC(context.Background(), cfg, nil); err == nil {
+		t.Error("NewOIDC() should reject a discovery document of another issuer")
+	}
+}
+
+func TestAuthenticators(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestProvider(t)
+	key := provider.addRSAKey(t, "k1")
+	oidc, err := NewOIDC(ctx, provider.config(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := Authenticators{StaticTokens{"ci-token": access.RoleOperator}, oidc}
+
+	if role, err := auth.Authenticate(ctx, "ci-token"); err != nil || role != access.RoleOperator {
+		t.Errorf("Authenticate(static token) = %q, %v, want operator", role, err)
+	}
+	token := provider.token(t, jwt.SigningMethodRS256, key, "k1", jwt.MapClaims{"groups": []string{"engineering"}})
+	if role, err := auth.Authenticate(ctx, token); err != nil || role != access.RoleViewer {
+		t.Errorf("Authenticate(OIDC token) = %q, %v, want viewer", role, err)
+	}
+	if _, err := auth.Authenticate(ctx, "nope"); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Authenticate(unknown) error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestLoadOIDCConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "oidc.json")
+	os.WriteFile(path, []byte(`{"issuer": "https://sso.example.com/realms/eng", "audience": "db-benchmind",
+		"groups": {"bench-operators": "operator", "engineering": "viewer"}}`), 0600)
+	cfg, err := LoadOIDCConfig(path)
+	if err != nil {
+		t.Fatalf("LoadOIDCConfig() error = %v", err)
+	}
+	if cfg.Groups["engineering"] != access.RoleViewer {
+		t.Errorf("Groups = %v", cfg.Groups)
+	}
+
+	os.WriteFile(path, []byte(`{"issuer": "https://sso.example.com", "audience": "db-benchmind", "groups": {"x": "guest"}}`), 0600)
+	if _, err := LoadOIDCConfig(path); err == nil {
+		t.Error("LoadOIDCConfig() should reject an unknown role")
+	}
+}
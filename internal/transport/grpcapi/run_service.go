@@ -0,0 +1,191 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/pkg/api"
+)
+
+// streamPollInterval is how often a sample stream checks whether its run has
+// ended without a finished event, e.g. after a stop before the run started.
+const streamPollInterval = 5 * time.Second
+
+// runService implements api.RunServiceServer.
+type runService struct {
+	api.UnimplementedRunServiceServer
+	s *Server
+}
+
+// StartRun starts a benchmark run in the background and returns it pending.
+func (rs *runService) StartRun(ctx context.Context, req *api.StartRunRequest) (*api.Run, error) {
+	if req.GetTemplateId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "template id is required")
+	}
+	conn, err := rs.s.findConnection(ctx, req.GetConnectionId())
+	if err != nil {
+		return nil, err
+	}
+
+	name := req.GetName()
+	if name == "" {
+		name = fmt.Sprintf("%s Benchmark", conn.GetName())
+	}
+	task := &execution.BenchmarkTask{
+		ID:           uuid.New().String(),
+		Name:         name,
+		ConnectionID: conn.GetID(),
+		TemplateID:   req.GetTemplateId(),
+		Parameters:   taskParameters(req.GetParameters().AsMap()),
+		Options:      taskOptions(req.GetOptions()),
+		Tags:         req.GetTags(),
+		Metadata: execution.RunMetadata{
+			Purpose:     req.GetPurpose(),
+			Ticket:      req.GetTicket(),
+			Environment: req.GetEnvironment(),
+		},
+		CreatedAt: time.Now(),
+	}
+
+	run, err := rs.s.benchmarkUC.StartBenchmark(ctx, task)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if len(task.Tags) > 0 {
+		rs.s.mu.Lock()
+		rs.s.tags[run.ID] = task.Tags
+		rs.s.mu.Unlock()
+	}
+	return runToProto(run), nil
+}
+
+// GetRun returns the current state of a run.
+func (rs *runService) GetRun(ctx context.Context, req *api.GetRunRequest) (*api.Run, error) {
+	run, err := rs.s.benchmarkUC.GetBenchmarkStatus(ctx, req.GetRunId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return runToProto(run), nil
+}
+
+// ListRuns lists the runs started since the server started, newest first.
+func (rs *runService) ListRuns(ctx context.Context, req *api.ListRunsRequest) (*api.ListRunsResponse, error) {
+	runs, err := rs.s.benchmarkUC.ListBenchmarks(ctx, usecase.FindOptions{SortBy: "created_at", SortOrder: "DESC"})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].CreatedAt.After(runs[j].CreatedAt) })
+
+	offset := min(int(max(req.GetOffset(), 0)), len(runs))
+	runs = runs[offset:]
+	if limit := int(req.GetLimit()); limit > 0 && limit < len(runs) {
+		runs = runs[:limit]
+	}
+
+	resp := &api.ListRunsResponse{Runs: make([]*api.Run, 0, len(runs))}
+	for _, run := range runs {
+		resp.Runs = append(resp.Runs, runToProto(run))
+	}
+	return resp, nil
+}
+
+// StopRun stops a run and returns it.
+func (rs *runService) StopRun(ctx context.Context, req *api.StopRunRequest) (*api.Run, error) {
+	if err := rs.s.benchmarkUC.StopBenchmark(ctx, req.GetRunId(), req.GetForce()); err != nil {
+		return nil, toStatus(err)
+	}
+	return rs.GetRun(ctx, &api.GetRunRequest{RunId: req.GetRunId()})
+}
+
+// StreamSamples sends the samples collected so far, then the new samples
+// until the run finishes or the client goes away.
+func (rs *runService) StreamSamples(req *api.StreamSamplesRequest, stream api.RunService_StreamSamplesServer) error {
+	ctx := stream.Context()
+	runID := req.GetRunId()
+
+	// Subscribe before reading the stored samples, so none is missed in between
+	samples := rs.s.subscribe(runID)
+	defer rs.s.unsubscribe(runID, samples)
+
+	run, err := rs.s.benchmarkUC.GetBenchmarkStatus(ctx, runID)
+	if err != nil {
+		return toStatus(err)
+	}
+	stored, err := rs.s.benchmarkUC.GetMetricSamples(ctx, runID)
+	if err != nil {
+		return toStatus(err)
+	}
+	var last time.Time
+	for _, sample := range stored {
+		if err := stream.Send(sampleToProto(sample)); err != nil {
+			return err
+		}
+		last = sample.Timestamp
+	}
+	if run.State.IsTerminal() {
+		return nil
+	}
+
+	ticker := time.NewTicker(streamPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case sample, ok := <-samples:
+			if !ok {
+				return nil
+			}
+			// Samples stored after subscribing arrive here as well
+			if !sample.Timestamp.After(last) {
+				continue
+			}
+			if err := stream.Send(sampleToProto(sample)); err != nil {
+				return err
+			}
+			last = sample.Timestamp
+		case <-ticker.C:
+			if run, err := rs.s.benchmarkUC.GetBenchmarkStatus(ctx, runID); err != nil || run.State.IsTerminal() {
+				return nil
+			}
+		}
+	}
+}
+
+// taskParameters converts the parameters of a request to task parameters.
+// Numbers arrive as float64, while adapters expect integers.
+func taskParameters(params map[string]interface{}) map[string]interface{} {
+	for name, value := range params {
+		if f, ok := value.(float64); ok && f == math.Trunc(f) {
+			params[name] = int(f)
+		}
+	}
+	return params
+}
+
+// taskOptions converts the run options of a request to task options.
+func taskOptions(opts *api.RunOptions) execution.TaskOptions {
+	options := execution.TaskOptions{
+		SkipPrepare:    opts.GetSkipPrepare(),
+		SkipCleanup:    opts.GetSkipCleanup(),
+		WarmupTime:     int(opts.GetWarmupSeconds()),
+		SampleInterval: opts.GetSampleInterval().AsDuration(),
+		RemoteWinRM:    opts.GetRemoteWinrm(),
+		KeepArtifacts:  opts.GetKeepArtifacts(),
+	}
+	if agents := opts.GetAgents(); len(agents) == 1 {
+		options.Agent = agents[0]
+	} else {
+		options.Agents = agents
+	}
+	return options
+}
@@ -0,0 +1,165 @@
+// Package grpcapi serves the gRPC API of pkg/api: connections, benchmark
+// runs with streamed samples, and run history.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/pkg/api"
+)
+
+// sampleBuffer is the number of samples buffered for each stream; samples
+// are dropped for a client that falls further behind.
+const sampleBuffer = 256
+
+// Server implements the gRPC services on top of the use cases.
+//
+// The benchmark use case reports samples and finished runs through single
+// callbacks, which the caller points at PublishSample and RunFinished.
+type Server struct {
+	connUC      *usecase.ConnectionUseCase
+	benchmarkUC *usecase.BenchmarkUseCase
+	historyUC   *usecase.HistoryUseCase
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan execution.MetricSample]struct{} // Sample streams by run ID
+	tags        map[string][]string                                 // History tags of runs started through the API
+}
+
+// NewServer creates a new gRPC API server.
+func NewServer(connUC *usecase.ConnectionUseCase, benchmarkUC *usecase.BenchmarkUseCase, historyUC *usecase.HistoryUseCase) *Server {
+	return &Server{
+		connUC:      connUC,
+		benchmarkUC: benchmarkUC,
+		historyUC:   historyUC,
+		subscribers: make(map[string]map[chan execution.MetricSample]struct{}),
+		tags:        make(map[string][]string),
+	}
+}
+
+// Register registers the connection, run and history services on gs.
+func (s *Server) Register(gs *grpc.Server) {
+	api.RegisterConnectionServiceServer(gs, &connectionService{s: s})
+	api.RegisterRunServiceServer(gs, &runService{s: s})
+	api.RegisterHistoryServiceServer(gs, &historyService{s: s})
+}
+
+// PublishSample forwards a realtime sample to the streams of its run.
+func (s *Server) PublishSample(runID string, sample execution.MetricSample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subscribers[runID] {
+		select {
+		case ch <- sample:
+		default:
+			slog.Warn("API: Sample stream is falling behind; sample dropped", "run_id", runID)
+		}
+	}
+}
+
+// RunFinished saves a finished run to history and ends the streams of the run.
+func (s *Server) RunFinished(event usecase.RunEvent) {
+	run := event.Run
+	s.saveToHistory(event)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subscribers[run.ID] {
+		close(ch)
+	}
+	delete(s.subscribers, run.ID)
+	delete(s.tags, run.ID)
+}
+
+// saveToHistory saves a completed run with its results, or a failed,
+// cancelled or timed out run with its state. Runs that only prepared or
+// cleaned up have no results and are not saved.
+func (s *Server) saveToHistory(event usecase.RunEvent) {
+	if s.historyUC == nil {
+		return
+	}
+	ctx := context.Background()
+	run := event.Run
+
+	var err error
+	switch {
+	case run.State == execution.StateCompleted && run.Result != nil:
+		err = s.historyUC.SaveRunToHistory(ctx, run)
+	case run.State != execution.StateCompleted:
+		err = s.historyUC.SaveStoppedRun(ctx, event)
+	default:
+		return
+	}
+	if err != nil {
+		slog.Error("API: Failed to save run to history", "run_id", run.ID, "state", run.State, "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	tags := s.tags[run.ID]
+	s.mu.Unlock()
+	if len(tags) > 0 {
+		if err := s.historyUC.UpdateAnnotations(ctx, run.ID, tags, ""); err != nil {
+			slog.Warn("API: Failed to tag history record", "run_id", run.ID, "error", err)
+		}
+	}
+	slog.Info("API: Saved run to history", "run_id", run.ID, "state", run.State)
+}
+
+// subscribe returns a channel receiving the samples of a run, closed when the run finishes.
+func (s *Server) subscribe(runID string) chan execution.MetricSample {
+	ch := make(chan execution.MetricSample, sampleBuffer)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subscribers[runID] == nil {
+		s.subscribers[runID] = make(map[chan execution.MetricSample]struct{})
+	}
+	s.subscribers[runID][ch] = struct{}{}
+	return ch
+}
+
+// unsubscribe stops sending the samples of a run to ch.
+func (s *Server) unsubscribe(runID string, ch chan execution.MetricSample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscribers[runID], ch)
+	if len(s.subscribers[runID]) == 0 {
+		delete(s.subscribers, runID)
+	}
+}
+
+// toStatus converts a use case error to a gRPC status error.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	code := codes.Internal
+	switch {
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, usecase.ErrAppLocked):
+		code = codes.Unauthenticated
+	case errors.Is(err, usecase.ErrPermissionDenied):
+		code = codes.PermissionDenied
+	case errors.Is(err, usecase.ErrBenchmarkNotFound), errors.Is(err, repository.ErrRecordNotFound):
+		code = codes.NotFound
+	case errors.Is(err, usecase.ErrPreCheckFailed):
+		code = codes.InvalidArgument
+	case errors.Is(err, usecase.ErrInvalidState), errors.Is(err, usecase.ErrConnectionProtected),
+		errors.Is(err, usecase.ErrConnectionObserver):
+		code = codes.FailedPrecondition
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,189 @@
+package grpcapi
+
+import (
+	"context"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/adapter"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/database/repository"
+	"github.com/whhaicheng/DB-BenchMind/pkg/api"
+)
+
+// testServer is a Server listening on an in-memory connection.
+type testServer struct {
+	*Server
+	runRepo     *usecase.MemoryRunRepository
+	connRepo    usecase.ConnectionRepository
+	historyRepo *repository.SQLiteHistoryRepository
+	conn        *grpc.ClientConn
+}
+
+func newTestServer(t *testing.T, opts ...grpc.ServerOption) *testServer {
+	t.Helper()
+	ctx := context.Background()
+	db, err := database.InitializeSQLite(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("InitializeSQLite() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	ts := &testServer{
+		runRepo:     usecase.NewMemoryRunRepository(),
+		connRepo:    repository.NewSQLiteConnectionRepository(db),
+		historyRepo: repository.NewSQLiteHistoryRepository(db),
+	}
+	connUC := usecase.NewConnectionUseCase(ts.connRepo, nil)
+	benchmarkUC := usecase.NewBenchmarkUseCase(ts.runRepo, adapter.NewAdapterRegistry(), connUC, nil)
+	ts.Server = NewServer(connUC, benchmarkUC, usecase.NewHistoryUseCase(ts.historyRepo))
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer(opts...)
+	ts.Register(gs)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	ts.conn, err = grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { ts.conn.Close() })
+	return ts
+}
+
+func TestConnectionService(t *testing.T) {
+	ts := newTestServer(t)
+	ctx := context.Background()
+	conn := &connection.MySQLConnection{
+		BaseConnection: connection.BaseConnection{ID: "conn-1", Name: "Staging", Protected: true},
+		Host:           "db.example.com",
+		Port:           3306,
+		Database:       "sbtest",
+		Username:       "bench",
+		Password:       "secret",
+	}
+	if err := ts.connRepo.Save(ctx, conn); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	client := api.NewConnectionServiceClient(ts.conn)
+
+	resp, err := client.ListConnections(ctx, &api.ListConnectionsRequest{})
+	if err != nil {
+		t.Fatalf("ListConnections() error = %v", err)
+	}
+	if len(resp.Connections) != 1 || resp.Connections[0].Name != "Staging" || !resp.Connections[0].Protected {
+		t.Fatalf("ListConnections() = %v, want the protected Staging connection", resp.Connections)
+	}
+
+	got, err := client.GetConnection(ctx, &api.GetConnectionRequest{Id: "Staging"})
+	if err != nil {
+		t.Fatalf("GetConnection(name) error = %v", err)
+	}
+	if got.Id != "conn-1" || got.DatabaseType != "mysql" {
+		t.Errorf("GetConnection(name) = %v, want conn-1 of type mysql", got)
+	}
+
+	if _, err := client.GetConnection(ctx, &api.GetConnectionRequest{Id: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetConnection(missing) error = %v, want NotFound", err)
+	}
+}
+
+func TestRunService_StreamSamples(t *testing.T) {
+	ts := newTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	run := &execution.Run{ID: "run-1", TaskID: "task-1", State: execution.StateRunning, CreatedAt: start}
+	if err := ts.runRepo.Save(ctx, run); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	stored := execution.MetricSample{Timestamp: start.Add(time.Second), Phase: "run", TPS: 100}
+	_ = ts.runRepo.SaveMetricSample(ctx, run.ID, stored)
+
+	client := api.NewRunServiceClient(ts.conn)
+	stream, err := client.StreamSamples(ctx, &api.StreamSamplesRequest{RunId: run.ID})
+	if err != nil {
+		t.Fatalf("StreamSamples() error = %v", err)
+	}
+	first, err := stream.Recv()
+	if err != nil || first.Tps != 100 {
+		t.Fatalf("first sample = %v, %v, want the stored sample", first, err)
+	}
+
+	// The stream is subscribed once the stored samples have been sent
+	ts.PublishSample(run.ID, stored) // Already sent, skipped
+	ts.PublishSample(run.ID, execution.MetricSample{Timestamp: start.Add(2 * time.Second), Phase: "run", TPS: 200})
+	second, err := stream.Recv()
+	if err != nil || second.Tps != 200 {
+		t.Fatalf("second sample = %v, %v, want the published sample", second, err)
+	}
+
+	run.State = execution.StateCompleted
+	ts.RunFinished(usecase.RunEvent{Run: run})
+	if sample, err := stream.Recv(); err != io.EOF {
+		t.Errorf("Recv() after the run finished = %v, %v, want EOF", sample, err)
+	}
+
+	if _, err := client.GetRun(ctx, &api.GetRunRequest{RunId: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetRun(missing) error = %v, want NotFound", err)
+	}
+}
+
+func TestHistoryService(t *testing.T) {
+	ts := newTestServer(t)
+	ctx := context.Background()
+	record := &history.Record{
+		ID:             "record-1",
+		CreatedAt:      time.Now(),
+		ConnectionName: "Staging",
+		TemplateName:   "Sysbench OLTP Read-Write",
+		DatabaseType:   "mysql",
+		Threads:        8,
+		StartTime:      time.Now(),
+		Duration:       time.Minute,
+		TPSCalculated:  1234.5,
+		Environment:    "staging",
+		TimeSeries:     []history.MetricSample{{Timestamp: time.Now(), Phase: "run", TPS: 1234.5}},
+	}
+	if err := ts.historyRepo.Save(ctx, record); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	client := api.NewHistoryServiceClient(ts.conn)
+
+	list, err := client.ListRecords(ctx, &api.ListRecordsRequest{ConnectionName: "Staging"})
+	if err != nil {
+		t.Fatalf("ListRecords() error = %v", err)
+	}
+	if list.Total != 1 || len(list.Records) != 1 || len(list.Records[0].TimeSeries) != 0 {
+		t.Fatalf("ListRecords() = %v, want one record without its time series", list)
+	}
+
+	got, err := client.GetRecord(ctx, &api.GetRecordRequest{Id: "record-1", IncludeTimeSeries: true})
+	if err != nil {
+		t.Fatalf("GetRecord() error = %v", err)
+	}
+	if got.Result.GetTps() != 1234.5 || len(got.TimeSeries) != 1 || got.Environment != "staging" {
+		t.Errorf("GetRecord() = %v, want the TPS, the time series and the environment", got)
+	}
+
+	if _, err := client.GetRecord(ctx, &api.GetRecordRequest{Id: "missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetRecord(missing) error = %v, want NotFound", err)
+	}
+}
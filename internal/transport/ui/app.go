@@ -5,90 +5,256 @@
 package ui
 
 import (
+	"context"
+	"log/slog"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 
 	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
 	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/pages"
 )
 
 // Application represents the Fyne GUI application.
 type Application struct {
-	app          fyne.App
-	connUC       *usecase.ConnectionUseCase
-	benchmarkUC  *usecase.BenchmarkUseCase
-	templateUC   *usecase.TemplateUseCase
-	historyUC    *usecase.HistoryUseCase
-	exportUC     *usecase.ExportUseCase
-	comparisonUC *usecase.ComparisonUseCase
+	app           fyne.App
+	connUC        *usecase.ConnectionUseCase
+	benchmarkUC   *usecase.BenchmarkUseCase
+	templateUC    *usecase.TemplateUseCase
+	historyUC     *usecase.HistoryUseCase
+	exportUC      *usecase.ExportUseCase
+	comparisonUC  *usecase.ComparisonUseCase
+	maintenanceUC *usecase.MaintenanceUseCase
+	settingsUC    *usecase.SettingsUseCase
+	notifyUC      *usecase.NotificationUseCase
+	suiteUC       *usecase.SuiteUseCase
+	repetitionUC  *usecase.RepetitionUseCase
+	accessUC      *usecase.AccessUseCase
+	diagnosticsUC *usecase.DiagnosticsUseCase
+	updateUC      *usecase.UpdateUseCase
+	demoUC        *usecase.DemoUseCase
+	raceUC        *usecase.RaceUseCase
+
+	window         fyne.Window
+	tabs           *container.AppTabs
+	connectionPage *pages.ConnectionPage
+	templatePage   *pages.TemplateManagementPage
+	historyPage    *pages.HistoryRecordPage
+	taskPage       *pages.TaskMonitorPage
+	connectionsTab *container.TabItem
+	templatesTab   *container.TabItem
+	historyTab     *container.TabItem
+	tasksTab       *container.TabItem
+	refreshTab     func(*container.TabItem) // Reloads the page of a tab
+
+	applyLogging func(config.AdvancedConfig) // Applies saved log levels, if set
 }
 
 // NewApplication creates a new Fyne application.
-func NewApplication(connUC *usecase.ConnectionUseCase, benchmarkUC *usecase.BenchmarkUseCase, templateUC *usecase.TemplateUseCase, historyUC *usecase.HistoryUseCase, exportUC *usecase.ExportUseCase, comparisonUC *usecase.ComparisonUseCase) *Application {
+func NewApplication(connUC *usecase.ConnectionUseCase, benchmarkUC *usecase.BenchmarkUseCase, templateUC *usecase.TemplateUseCase, historyUC *usecase.HistoryUseCase, exportUC *usecase.ExportUseCase, comparisonUC *usecase.ComparisonUseCase, maintenanceUC *usecase.MaintenanceUseCase, settingsUC *usecase.SettingsUseCase, notifyUC *usecase.NotificationUseCase, suiteUC *usecase.SuiteUseCase, repetitionUC *usecase.RepetitionUseCase, accessUC *usecase.AccessUseCase, diagnosticsUC *usecase.DiagnosticsUseCase, updateUC *usecase.UpdateUseCase, demoUC *usecase.DemoUseCase, raceUC *usecase.RaceUseCase) *Application {
 	return &Application{
-		app:          app.NewWithID("com.db-benchmind.app"),
-		connUC:       connUC,
-		benchmarkUC:  benchmarkUC,
-		templateUC:   templateUC,
-		historyUC:    historyUC,
-		exportUC:     exportUC,
-		comparisonUC: comparisonUC,
+		app:           app.NewWithID("com.db-benchmind.app"),
+		connUC:        connUC,
+		benchmarkUC:   benchmarkUC,
+		templateUC:    templateUC,
+		historyUC:     historyUC,
+		exportUC:      exportUC,
+		comparisonUC:  comparisonUC,
+		maintenanceUC: maintenanceUC,
+		settingsUC:    settingsUC,
+		notifyUC:      notifyUC,
+		suiteUC:       suiteUC,
+		repetitionUC:  repetitionUC,
+		accessUC:      accessUC,
+		diagnosticsUC: diagnosticsUC,
+		updateUC:      updateUC,
+		demoUC:        demoUC,
+		raceUC:        raceUC,
 	}
 }
 
 // Run starts the application.
 func (a *Application) Run() {
+	a.loadUISettings()
+
 	// Create main window
 	window := a.app.NewWindow("DB-BenchMind")
 	window.Resize(fyne.NewSize(1024, 900)) // Increased from 768 to 900 for more log display space
 	window.SetMaster()
+	a.window = window
 
 	// Set close interceptor when main window closes
 	window.SetCloseIntercept(func() {
 		a.app.Quit()
 	})
 
+	window.SetContent(a.buildContent(0))
+	a.registerQuickSearch()
+	a.registerShortcuts()
+
+	// Ask for the app lock password first; the other prompts follow the unlock
+	a.promptAppLock(window, func() {
+		// Ask for the master password if saved passwords are in the locked file fallback
+		a.promptMasterPassword(window, func() { a.connectionPage.Refresh() })
+
+		// Offer to recover runs left running when the application last exited
+		a.promptOrphanedRuns(window)
+	})
+
+	// Run main window (blocks until window is closed)
+	window.ShowAndRun()
+}
+
+// buildContent creates the pages in the current language, with the tab at
+// index selected.
+func (a *Application) buildContent(selected int) fyne.CanvasObject {
+	window := a.window
+
 	// Create history page and save reference
 	historyPage, historyPageContent := pages.NewHistoryRecordPage(window, a.historyUC, a.exportUC)
 
+	// Create trends page and save reference
+	trendPage, trendPageContent := pages.NewTrendPage(window, a.historyUC, a.settingsUC)
+
 	// Create comparison page and save reference
 	comparisonPage, comparisonPageContent := pages.NewResultComparisonPage(window, a.comparisonUC)
 
+	// Create suites page and save reference
+	suitePage, suitePageContent := pages.NewSuitePage(window, a.suiteUC, a.connUC, a.templateUC)
+
 	// Create connections page and save reference
 	connectionPage, connectionPageContent := pages.NewConnectionPage(a.connUC, window)
+	a.connectionPage = connectionPage
+
+	// Create templates page and save reference
+	templatePage, templatePageContent := pages.NewTemplatePage(window)
+	a.templatePage = templatePage
+	a.historyPage = historyPage
+
+	// Create tasks page and save reference
+	taskPage, taskPageContent := pages.NewTaskMonitorPageWithUC(window, a.connUC, a.benchmarkUC, a.templateUC, a.historyUC, a.exportUC, a.repetitionUC, a.settingsUC, a.demoUC, a.raceUC)
+	a.taskPage = taskPage
 
 	// Create tabs
+	connectionsTab := container.NewTabItem(i18n.T("Connections"), connectionPageContent)
+	suitesTab := container.NewTabItem(i18n.T("Suites"), suitePageContent)
+	historyTab := container.NewTabItem(i18n.T("History"), historyPageContent)
+	trendsTab := container.NewTabItem(i18n.T("Trends"), trendPageContent)
+	comparisonTab := container.NewTabItem(i18n.T("Comparison"), comparisonPageContent)
+	tasksTab := container.NewTabItem(i18n.T("Tasks & Monitor"), taskPageContent)
+	templatesTab := container.NewTabItem(i18n.T("Templates"), templatePageContent)
 	tabs := container.NewAppTabs(
-		container.NewTabItem("Connections", connectionPageContent),
-		container.NewTabItem("Templates", pages.NewTemplatePage(window)),
-		container.NewTabItem("Tasks & Monitor", pages.NewTaskMonitorPageWithUC(window, a.connUC, a.benchmarkUC, a.templateUC, a.historyUC)),
-		container.NewTabItem("History", historyPageContent),
-		container.NewTabItem("Comparison", comparisonPageContent),
-		container.NewTabItem("Reports", pages.NewReportPage(window)),
-		container.NewTabItem("Settings", pages.NewSettingsPage(window, a.connUC)),
+		connectionsTab,
+		templatesTab,
+		tasksTab,
+		suitesTab,
+		historyTab,
+		trendsTab,
+		comparisonTab,
+		container.NewTabItem(i18n.T("Reports"), pages.NewReportPage(window)),
+		container.NewTabItem(i18n.T("Settings"), pages.NewSettingsPage(window, a.connUC, a.maintenanceUC, a.settingsUC, a.historyUC, a.notifyUC, a.accessUC, a.diagnosticsUC, a.updateUC, a.demoUC, a.onLanguageChanged, a.onAppearanceChanged, a.onLoggingChanged, a.lockApp)),
 	)
 
 	tabs.SetTabLocation(container.TabLocationTop)
+	tabs.SelectIndex(selected)
 
-	// Add tab change listener to auto-refresh pages when selected
-	tabs.OnSelected = func(tab *container.TabItem) {
-		// Auto-refresh Connections when selected
-		if tab.Text == "Connections" {
+	// Add tab change listener to auto-refresh pages when selected (or on F5)
+	refreshTab := func(tab *container.TabItem) {
+		switch tab {
+		case connectionsTab:
 			connectionPage.Refresh()
-		}
-		// Auto-refresh History when selected
-		if tab.Text == "History" {
+		case suitesTab:
+			suitePage.Refresh()
+		case historyTab:
 			historyPage.Refresh()
-		}
-		// Auto-refresh Comparison when selected
-		if tab.Text == "Comparison" {
+		case trendsTab:
+			trendPage.Refresh()
+		case comparisonTab:
 			comparisonPage.Refresh()
 		}
 	}
+	tabs.OnSelected = refreshTab
+	a.tabs = tabs
+	a.refreshTab = refreshTab
+	a.connectionsTab = connectionsTab
+	a.templatesTab = templatesTab
+	a.historyTab = historyTab
+	a.tasksTab = tasksTab
 
-	window.SetContent(tabs)
+	return tabs
+}
 
-	// Run main window (blocks until window is closed)
-	window.ShowAndRun()
+// loadUISettings applies the language and appearance saved in the settings.
+func (a *Application) loadUISettings() {
+	if a.settingsUC == nil {
+		return
+	}
+	uiCfg, err := a.settingsUC.GetUIConfig(context.Background())
+	if err != nil {
+		slog.Warn("UI: Failed to load UI settings", "error", err)
+		return
+	}
+	i18n.SetLanguage(i18n.Parse(uiCfg.Language))
+	a.app.Settings().SetTheme(newAppTheme(*uiCfg))
+}
+
+// onAppearanceChanged applies newly saved appearance settings. Fyne redraws
+// all windows with the new theme, so no page needs rebuilding.
+func (a *Application) onAppearanceChanged(uiCfg config.UIConfig) {
+	slog.Info("UI: Applying appearance", "theme", uiCfg.Theme, "font_scale", uiCfg.Scale(), "monospace_font", uiCfg.MonospaceFont)
+	a.app.Settings().SetTheme(newAppTheme(uiCfg))
+}
+
+// SetOnLoggingChanged sets the function that applies log levels saved on the
+// settings page while running. Call it before Run.
+func (a *Application) SetOnLoggingChanged(apply func(config.AdvancedConfig)) {
+	a.applyLogging = apply
+}
+
+// onLoggingChanged applies newly saved logging settings.
+func (a *Application) onLoggingChanged(advCfg config.AdvancedConfig) {
+	if a.applyLogging == nil {
+		return
+	}
+	a.applyLogging(advCfg)
+}
+
+// onLanguageChanged switches to a newly saved language by rebuilding the
+// pages. While a benchmark runs, rebuilding would detach the monitor from
+// the run, so the language applies at the next start instead.
+func (a *Application) onLanguageChanged(lang i18n.Language) {
+	if lang == i18n.Current() {
+		return
+	}
+	if a.benchmarkActive() {
+		dialog.ShowInformation(i18n.T("Language"),
+			i18n.T("A benchmark is running. The new language applies the next time DB-BenchMind starts."), a.window)
+		return
+	}
+
+	slog.Info("UI: Switching language", "language", lang)
+	i18n.SetLanguage(lang)
+	a.window.SetContent(a.buildContent(a.tabs.SelectedIndex()))
+}
+
+// benchmarkActive reports whether a benchmark run has not finished yet.
+func (a *Application) benchmarkActive() bool {
+	if a.benchmarkUC == nil {
+		return false
+	}
+	runs, err := a.benchmarkUC.ListBenchmarks(context.Background(), usecase.FindOptions{})
+	if err != nil {
+		slog.Warn("UI: Failed to list runs", "error", err)
+		return false
+	}
+	for _, run := range runs {
+		if !run.State.IsTerminal() {
+			return true
+		}
+	}
+	return false
 }
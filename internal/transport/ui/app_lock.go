@@ -0,0 +1,79 @@
+// Package ui provides the GUI implementation using Fyne.
+// App lock prompt: the pages stay hidden until a role is unlocked.
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// promptAppLock asks for the app lock password if the app lock is enabled,
+// showing the pages once a role is unlocked. onUnlocked is called after the
+// unlock, or right away if the app is not locked.
+func (a *Application) promptAppLock(win fyne.Window, onUnlocked func()) {
+	if a.accessUC == nil {
+		onUnlocked()
+		return
+	}
+	enabled, err := a.accessUC.LockEnabled(context.Background())
+	if err != nil {
+		// Without the settings no role can be checked; the use cases deny what they must
+		slog.Error("Access: Failed to read the app lock", "error", err)
+	}
+	if !enabled {
+		onUnlocked()
+		return
+	}
+
+	win.SetContent(container.NewCenter(widget.NewLabel(i18n.T("🔒 DB-BenchMind is locked."))))
+
+	password := widget.NewPasswordEntry()
+	items := []*widget.FormItem{
+		widget.NewFormItem("", widget.NewLabel(i18n.T("Enter the admin or operator PIN or password."))),
+		widget.NewFormItem(i18n.T("Password"), password),
+	}
+	form := dialog.NewForm(i18n.T("Unlock DB-BenchMind"), i18n.T("Unlock"), i18n.T("Quit"), items, func(ok bool) {
+		if !ok {
+			slog.Info("Access: App lock prompt closed; quitting")
+			a.app.Quit()
+			return
+		}
+
+		if _, err := a.accessUC.Unlock(context.Background(), password.Text); err != nil {
+			if !errors.Is(err, usecase.ErrWrongAppPassword) {
+				err = fmt.Errorf(i18n.T("unlock: %w"), err)
+			}
+			d := dialog.NewError(err, win)
+			d.SetOnClosed(func() { a.promptAppLock(win, onUnlocked) })
+			d.Show()
+			return
+		}
+
+		win.SetContent(a.buildContent(0))
+		onUnlocked()
+	}, win)
+	form.Resize(fyne.NewSize(420, 0))
+	form.Show()
+	win.Canvas().Focus(password)
+}
+
+// lockApp locks the app and hides the pages until it is unlocked again.
+func (a *Application) lockApp() {
+	if a.benchmarkActive() {
+		dialog.ShowInformation(i18n.T("Lock"),
+			i18n.T("A benchmark is running. Lock the app after it has finished."), a.window)
+		return
+	}
+	a.accessUC.Lock()
+	a.promptAppLock(a.window, func() {})
+}
@@ -0,0 +1,125 @@
+// Package i18n provides the message catalogs of the GUI.
+// Strings are looked up by their English text, so untranslated strings fall
+// back to English. Long texts such as help pages are looked up by an ID that
+// has an entry in every catalog.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+)
+
+// Language is a UI language code, as stored in the settings (ui.language).
+type Language string
+
+const (
+	English Language = "en"
+	Chinese Language = "zh"
+)
+
+// DefaultLanguage is used for unknown language codes.
+const DefaultLanguage = English
+
+// languageNames are the names of the supported languages, in their own language.
+var languageNames = map[Language]string{
+	English: "English",
+	Chinese: "中文",
+}
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// catalogs maps each language to its messages, loaded from locales/<language>.json.
+var catalogs = loadCatalogs()
+
+// current is the catalog of the current language.
+var current atomic.Pointer[catalog]
+
+type catalog struct {
+	lang     Language
+	messages map[string]string
+}
+
+func init() {
+	SetLanguage(DefaultLanguage)
+}
+
+// loadCatalogs parses the embedded catalogs. A broken catalog is a build
+// error, so it panics.
+func loadCatalogs() map[Language]map[string]string {
+	result := make(map[Language]map[string]string, len(languageNames))
+	for lang := range languageNames {
+		data, err := localeFS.ReadFile("locales/" + string(lang) + ".json")
+		if err != nil {
+			panic(fmt.Sprintf("i18n: missing catalog for %s: %v", lang, err))
+		}
+		messages := make(map[string]string)
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: parse catalog for %s: %v", lang, err))
+		}
+		result[lang] = messages
+	}
+	return result
+}
+
+// Languages returns the supported languages, English first.
+func Languages() []Language {
+	return []Language{English, Chinese}
+}
+
+// Name returns the name of a language in that language, e.g. "中文".
+func (l Language) Name() string {
+	if name, ok := languageNames[l]; ok {
+		return name
+	}
+	return string(l)
+}
+
+// Parse returns the language of code, or DefaultLanguage if it is not supported.
+func Parse(code string) Language {
+	if _, ok := languageNames[Language(code)]; ok {
+		return Language(code)
+	}
+	return DefaultLanguage
+}
+
+// SetLanguage switches the language of subsequent lookups. Widgets that
+// are already built keep their text; the application rebuilds its pages.
+func SetLanguage(lang Language) {
+	lang = Parse(string(lang))
+	current.Store(&catalog{lang: lang, messages: catalogs[lang]})
+}
+
+// Current returns the current language.
+func Current() Language {
+	return current.Load().lang
+}
+
+// T returns the translation of msg in the current language. msg is the
+// English text, or the ID of a long text. Messages missing from the current
+// catalog fall back to the English catalog, then to msg itself.
+func T(msg string) string {
+	if s, ok := current.Load().messages[msg]; ok {
+		return s
+	}
+	if s, ok := catalogs[English][msg]; ok {
+		return s
+	}
+	return msg
+}
+
+// Tf translates format and formats it with args like fmt.Sprintf.
+func Tf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// TList translates each message of msgs, e.g. the options of a selector.
+func TList(msgs []string) []string {
+	result := make([]string, len(msgs))
+	for i, msg := range msgs {
+		result[i] = T(msg)
+	}
+	return result
+}
@@ -0,0 +1,193 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// TestT tests lookups and the fallback to English.
+func TestT(t *testing.T) {
+	defer SetLanguage(DefaultLanguage)
+
+	SetLanguage(Chinese)
+	if Current() != Chinese {
+		t.Fatalf("Current() = %s, want zh", Current())
+	}
+	if got := T("Cancel"); got != "取消" {
+		t.Errorf("T(Cancel) = %q, want 取消", got)
+	}
+	if got := T("no such message"); got != "no such message" {
+		t.Errorf("T(missing) = %q, want the message itself", got)
+	}
+	if got := Tf("Delete connection '%s'?", "prod"); got != "删除连接 'prod'？" {
+		t.Errorf("Tf() = %q", got)
+	}
+
+	SetLanguage(English)
+	if got := T("Cancel"); got != "Cancel" {
+		t.Errorf("T(Cancel) = %q in English", got)
+	}
+	// Long texts are looked up by ID in every language
+	if got := T("winrm.help"); got == "winrm.help" {
+		t.Error("T(winrm.help) has no English text")
+	}
+}
+
+// TestParse tests parsing language codes.
+func TestParse(t *testing.T) {
+	tests := []struct {
+		code string
+		want Language
+	}{
+		{"en", English},
+		{"zh", Chinese},
+		{"", English},
+		{"fr", English},
+	}
+	for _, tt := range tests {
+		if got := Parse(tt.code); got != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.code, got, tt.want)
+		}
+	}
+}
+
+// verbPattern matches the fmt verbs of a message.
+var verbPattern = regexp.MustCompile(`%[-+#0]*[\d.*]*[a-zA-Z%]`)
+
+// TestCatalogs checks that every message of the GUI is translated, with the
+// same fmt verbs, and that the catalogs have no unused entries.
+func TestCatalogs(t *testing.T) {
+	used := uiMessages(t)
+	if len(used) == 0 {
+		t.Fatal("no messages found in the GUI sources")
+	}
+
+	for _, lang := range Languages() {
+		if lang == English {
+			continue
+		}
+		for msg := range used {
+			translation, ok := catalogs[lang][msg]
+			if !ok {
+				t.Errorf("%s: missing translation of %q", lang, msg)
+				continue
+			}
+			source := msg
+			if text, isID := catalogs[English][msg]; isID {
+				source = text
+			}
+			if got, want := verbPattern.FindAllString(translation, -1), verbPattern.FindAllString(source, -1); !slices.Equal(got, want) {
+				t.Errorf("%s: %q has verbs %v, want %v", lang, msg, got, want)
+			}
+		}
+	}
+
+	for lang, messages := range catalogs {
+		for msg := range messages {
+			if !used[msg] {
+				t.Errorf("%s: unused message %q", lang, msg)
+			}
+		}
+	}
+}
+
+// uiMessages returns the messages passed to T, Tf and TList in the GUI packages.
+func uiMessages(t *testing.T) map[string]bool {
+	t.Helper()
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, pattern := range []string{"../*.go", "../pages/*.go"} {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, path := range paths {
+			f, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				t.Fatalf("parse %s: %v", path, err)
+			}
+			files = append(files, f)
+		}
+	}
+
+	// Package level constants and variables, to resolve T(rateProfileFixed)
+	values := make(map[string]ast.Expr)
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || (gen.Tok != token.CONST && gen.Tok != token.VAR) {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if i < len(vs.Values) {
+						values[name.Name] = vs.Values[i]
+					}
+				}
+			}
+		}
+	}
+
+	var resolve func(expr ast.Expr) []string
+	resolve = func(expr ast.Expr) []string {
+		switch e := expr.(type) {
+		case *ast.BasicLit:
+			if s, err := strconv.Unquote(e.Value); err == nil && e.Kind == token.STRING {
+				return []string{s}
+			}
+		case *ast.BinaryExpr:
+			left, right := resolve(e.X), resolve(e.Y)
+			if e.Op == token.ADD && len(left) == 1 && len(right) == 1 {
+				return []string{left[0] + right[0]}
+			}
+		case *ast.Ident:
+			if value, ok := values[e.Name]; ok {
+				return resolve(value)
+			}
+		case *ast.CompositeLit:
+			var result []string
+			for _, elt := range e.Elts {
+				result = append(result, resolve(elt)...)
+			}
+			return result
+		}
+		return nil
+	}
+
+	used := make(map[string]bool)
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "i18n" {
+				return true
+			}
+			switch sel.Sel.Name {
+			case "T", "Tf", "TList":
+				msgs := resolve(call.Args[0])
+				if len(msgs) == 0 {
+					// Dynamic messages are only looked up, never extracted
+					return true
+				}
+				for _, msg := range msgs {
+					used[msg] = true
+				}
+			}
+			return true
+		})
+	}
+	return used
+}
@@ -0,0 +1,92 @@
+// Package ui provides the GUI implementation using Fyne.
+// Master password prompt for the file keyring fallback.
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/keyring"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// minMasterPasswordLength is the minimum length of a new master password.
+const minMasterPasswordLength = 8
+
+// promptMasterPassword asks for the keyring master password if the keyring is locked.
+// onUnlocked is called after a successful unlock.
+func (a *Application) promptMasterPassword(win fyne.Window, onUnlocked func()) {
+	locked, masterPasswordSet := a.connUC.KeyringLocked()
+	if !locked {
+		return
+	}
+
+	password := widget.NewPasswordEntry()
+	items := []*widget.FormItem{widget.NewFormItem(i18n.T("Master Password"), password)}
+
+	var confirm *widget.Entry
+	title := i18n.T("Unlock Saved Passwords")
+	message := i18n.T("Enter the master password that protects saved database passwords.")
+	if !masterPasswordSet {
+		confirm = widget.NewPasswordEntry()
+		items = append(items, widget.NewFormItem(i18n.T("Confirm"), confirm))
+		title = i18n.T("Create Master Password")
+		message = i18n.T("The system keyring is not available.\nChoose a master password to encrypt saved database passwords.")
+	}
+	items = append([]*widget.FormItem{widget.NewFormItem("", widget.NewLabel(message))}, items...)
+
+	form := dialog.NewForm(title, i18n.T("Unlock"), i18n.T("Skip"), items, func(ok bool) {
+		if !ok {
+			slog.Warn("Keyring: Master password prompt skipped; saved passwords are unavailable")
+			dialog.ShowInformation(i18n.T("Passwords Locked"),
+				i18n.T("Saved database passwords cannot be read or stored until the master password is entered.\nRestart the application to unlock."), win)
+			return
+		}
+
+		if err := validateMasterPassword(password.Text, confirm); err != nil {
+			dialog.ShowError(err, win)
+			a.promptMasterPassword(win, onUnlocked)
+			return
+		}
+
+		if err := a.connUC.UnlockKeyring(context.Background(), password.Text); err != nil {
+			if errors.Is(err, keyring.ErrWrongMasterPassword) {
+				dialog.ShowError(err, win)
+				a.promptMasterPassword(win, onUnlocked)
+				return
+			}
+			slog.Error("Keyring: Unlock failed", "error", err)
+			dialog.ShowError(fmt.Errorf(i18n.T("unlock keyring: %w"), err), win)
+			return
+		}
+
+		if onUnlocked != nil {
+			onUnlocked()
+		}
+	}, win)
+	form.Resize(fyne.NewSize(460, 0))
+	form.Show()
+}
+
+// validateMasterPassword checks a master password; confirm is nil when unlocking.
+func validateMasterPassword(password string, confirm *widget.Entry) error {
+	if password == "" {
+		return errors.New(i18n.T("master password is required"))
+	}
+	if confirm == nil {
+		return nil
+	}
+	if len(password) < minMasterPasswordLength {
+		return fmt.Errorf(i18n.T("master password must be at least %d characters"), minMasterPasswordLength)
+	}
+	if password != confirm.Text {
+		return errors.New(i18n.T("passwords do not match"))
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// TPS and p95 latency vs thread count charts of the Result Comparison page.
+package pages
+
+import (
+	"fmt"
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/comparison"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// comparisonChart plots one metric of the comparison series against the
+// thread count, with error bars of ± one standard deviation.
+type comparisonChart struct {
+	widget.BaseWidget
+	metric comparison.ChartMetric
+	series []comparison.ChartSeries
+}
+
+// newComparisonChart creates an empty chart of metric.
+func newComparisonChart(metric comparison.ChartMetric) *comparisonChart {
+	c := &comparisonChart{metric: metric}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// SetSeries replaces the plotted series; nil clears the chart.
+func (c *comparisonChart) SetSeries(series []comparison.ChartSeries) {
+	c.series = series
+	c.Refresh()
+}
+
+// title returns the translated chart title.
+func (c *comparisonChart) title() string {
+	if c.metric == comparison.ChartLatencyP95 {
+		return i18n.T("P95 latency (ms) vs threads")
+	}
+	return i18n.T("TPS vs threads")
+}
+
+// CreateRenderer implements fyne.Widget.
+func (c *comparisonChart) CreateRenderer() fyne.WidgetRenderer {
+	return &comparisonChartRenderer{
+		chart:      c,
+		background: canvas.NewRectangle(theme.Color(theme.ColorNameInputBackground)),
+	}
+}
+
+// MinSize implements fyne.Widget.
+func (c *comparisonChart) MinSize() fyne.Size {
+	return fyne.NewSize(320, 260)
+}
+
+// comparisonChartRenderer draws a comparisonChart from lines, circles and text.
+type comparisonChartRenderer struct {
+	chartDrawing
+	chart      *comparisonChart
+	background *canvas.Rectangle
+	size       fyne.Size
+}
+
+// chartDrawing holds the canvas objects a chart renderer draws.
+type chartDrawing struct {
+	objects []fyne.CanvasObject
+}
+
+func (r *comparisonChartRenderer) Layout(size fyne.Size) {
+	r.size = size
+	r.redraw()
+}
+
+func (r *comparisonChartRenderer) MinSize() fyne.Size {
+	return r.chart.MinSize()
+}
+
+func (r *comparisonChartRenderer) Refresh() {
+	r.redraw()
+	canvas.Refresh(r.chart)
+}
+
+func (r *comparisonChartRenderer) Objects() []fyne.CanvasObject {
+	return append([]fyne.CanvasObject{r.background}, r.objects...)
+}
+
+func (r *comparisonChartRenderer) Destroy() {}
+
+// redraw rebuilds the chart for the current series and size.
+func (r *comparisonChartRenderer) redraw() {
+	size := r.size
+	r.background.Resize(size)
+	r.objects = nil
+
+	pad := theme.Padding()
+	r.addText(r.chart.title(), pad, pad, fyne.TextAlignLeading, true)
+	threads, maxValue := comparison.ChartScale(r.chart.series, r.chart.metric)
+	if len(threads) == 0 {
+		r.addText(i18n.T("Compare records to see the charts"), size.Width/2, size.Height/2, fyne.TextAlignCenter, false)
+		return
+	}
+
+	// Plot area below the title and legend, left of the value labels
+	textHeight := theme.CaptionTextSize() + 2*pad
+	left, right := float32(56), pad*2
+	top := textHeight*float32(1+len(r.chart.series)) + pad
+	bottom := textHeight + pad
+	plotW, plotH := size.Width-left-right, size.Height-top-bottom
+	if plotW <= 0 || plotH <= 0 {
+		return
+	}
+	x := func(t int) float32 {
+		if len(threads) == 1 {
+			return left + plotW/2
+		}
+		for i, v := range threads {
+			if v == t {
+				return left + plotW*float32(i)/float32(len(threads)-1)
+			}
+		}
+		return left
+	}
+	y := func(v float64) float32 { return top + plotH*(1-float32(max(v, 0)/maxValue)) }
+
+	// Axes, value ticks and thread counts
+	axis := theme.Color(theme.ColorNameDisabled)
+	r.addLine(axis, 1, left, top, left, top+plotH)
+	r.addLine(axis, 1, left, top+plotH, left+plotW, top+plotH)
+	for i := 0; i <= 2; i++ {
+		v := maxValue * float64(i) / 2
+		r.addText(fmt.Sprintf("%.0f", v), left-pad, y(v)-textHeight/2, fyne.TextAlignTrailing, false)
+	}
+	for _, t := range threads {
+		r.addText(fmt.Sprintf("%d", t), x(t), top+plotH+pad/2, fyne.TextAlignCenter, false)
+	}
+
+	for i, s := range r.chart.series {
+		c := chartColor(i)
+		r.addText("■ "+s.Label, pad, textHeight*float32(i+1), fyne.TextAlignLeading, false)
+		r.objects[len(r.objects)-1].(*canvas.Text).Color = c
+
+		for j, p := range s.Points {
+			stats := p.Stats(r.chart.metric)
+			px, py := x(p.Threads), y(stats.Mean)
+			if j > 0 {
+				prev := s.Points[j-1]
+				r.addLine(c, 2, x(prev.Threads), y(prev.Stats(r.chart.metric).Mean), px, py)
+			}
+			if stats.StdDev > 0 {
+				lo, hi := y(stats.Mean-stats.StdDev), y(stats.Mean+stats.StdDev)
+				r.addLine(c, 1, px, lo, px, hi)
+				r.addLine(c, 1, px-4, lo, px+4, lo)
+				r.addLine(c, 1, px-4, hi, px+4, hi)
+			}
+			dot := canvas.NewCircle(c)
+			dot.Resize(fyne.NewSize(6, 6))
+			dot.Move(fyne.NewPos(px-3, py-3))
+			r.objects = append(r.objects, dot)
+		}
+	}
+}
+
+// addLine adds a line segment from (x1, y1) to (x2, y2).
+func (r *chartDrawing) addLine(c color.Color, width, x1, y1, x2, y2 float32) {
+	line := canvas.NewLine(c)
+	line.StrokeWidth = width
+	line.Position1 = fyne.NewPos(x1, y1)
+	line.Position2 = fyne.NewPos(x2, y2)
+	r.objects = append(r.objects, line)
+}
+
+// addText adds a caption anchored at (x, y) with the given alignment.
+func (r *chartDrawing) addText(text string, x, y float32, align fyne.TextAlign, bold bool) {
+	t := canvas.NewText(text, theme.Color(theme.ColorNameForeground))
+	t.TextSize = theme.CaptionTextSize()
+	t.TextStyle = fyne.TextStyle{Bold: bold}
+	t.Alignment = align
+	width := t.MinSize().Width
+	switch align {
+	case fyne.TextAlignCenter:
+		x -= width / 2
+	case fyne.TextAlignTrailing:
+		x -= width
+	}
+	t.Resize(fyne.NewSize(width, t.MinSize().Height))
+	t.Move(fyne.NewPos(x, y))
+	r.objects = append(r.objects, t)
+}
+
+// chartColor returns the color of the i-th series, matching exported charts.
+func chartColor(i int) color.Color {
+	var c color.NRGBA
+	c.A = 0xff
+	fmt.Sscanf(comparison.ChartColors[i%len(comparison.ChartColors)], "#%02x%02x%02x", &c.R, &c.G, &c.B)
+	return c
+}
@@ -4,6 +4,7 @@ package pages
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/comparison"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
 )
 
 // GeneratePerformanceReport generates and displays a performance report
@@ -21,7 +23,7 @@ import (
 // This is an extension method that can be called from the existing ComparisonPage.
 func (p *ResultComparisonPage) GenerateComprehensiveReport() {
 	if p.comparisonUC == nil {
-		dialog.ShowError(fmt.Errorf("comparison use case not available"), p.win)
+		dialog.ShowError(errors.New(i18n.T("comparison use case not available")), p.win)
 		return
 	}
 
@@ -31,13 +33,13 @@ func (p *ResultComparisonPage) GenerateComprehensiveReport() {
 	refs, err := p.comparisonUC.GetAllRecords(ctx)
 	if err != nil {
 		slog.Error("Comparison: Failed to get records", "error", err)
-		dialog.ShowError(fmt.Errorf("failed to get records: %v", err), p.win)
+		dialog.ShowError(fmt.Errorf(i18n.T("failed to get records: %v"), err), p.win)
 		return
 	}
 
 	if len(refs) < 2 {
-		dialog.ShowInformation("Insufficient Data",
-			fmt.Sprintf("Need at least 2 records for comparison, found %d.\n\nPlease run more benchmarks first.", len(refs)),
+		dialog.ShowInformation(i18n.T("Insufficient Data"),
+			i18n.Tf("Need at least 2 records for comparison, found %d.\n\nPlease run more benchmarks first.", len(refs)),
 			p.win)
 		return
 	}
@@ -49,8 +51,8 @@ func (p *ResultComparisonPage) GenerateComprehensiveReport() {
 	}
 
 	// Show progress
-	progress := dialog.NewInformation("Generating Report",
-		"Analyzing benchmark data...\n\nPlease wait.", p.win)
+	progress := dialog.NewInformation(i18n.T("Generating Report"),
+		i18n.T("Analyzing benchmark data...\n\nPlease wait."), p.win)
 	progress.Show()
 
 	// Generate report in background
@@ -67,7 +69,7 @@ func (p *ResultComparisonPage) GenerateComprehensiveReport() {
 		if err != nil {
 			slog.Error("Comparison: Failed to generate report", "error", err)
 			progress.Hide()
-			dialog.ShowError(fmt.Errorf("failed to generate report: %v", err), p.win)
+			dialog.ShowError(fmt.Errorf(i18n.T("failed to generate report: %v"), err), p.win)
 			return
 		}
 
@@ -94,7 +96,7 @@ func (p *ResultComparisonPage) displayComprehensiveReport(report *comparison.Com
 	}
 
 	// Show summary dialog
-	summary := fmt.Sprintf(
+	summary := i18n.Tf(
 		"✅ Comprehensive Report Generated!\n\n"+
 			"Report ID: %s\n"+
 			"Config Groups: %d\n"+
@@ -106,7 +108,7 @@ func (p *ResultComparisonPage) displayComprehensiveReport(report *comparison.Com
 
 	if report.SanityChecks != nil {
 		if report.SanityChecks.AllPassed {
-			summary += "✅ ALL PASSED\n"
+			summary += i18n.T("✅ ALL PASSED\n")
 		} else {
 			passed := 0
 			for _, check := range report.SanityChecks.Checks {
@@ -114,21 +116,21 @@ func (p *ResultComparisonPage) displayComprehensiveReport(report *comparison.Com
 					passed++
 				}
 			}
-			summary += fmt.Sprintf("⚠️  %d/%d passed\n",
+			summary += i18n.Tf("⚠️  %d/%d passed\n",
 				passed, len(report.SanityChecks.Checks))
 		}
 	}
 
-	summary += "\nFull report is displayed below.\n\n" +
-		"You can export this report to Markdown or TXT format."
+	summary += i18n.T("\nFull report is displayed below.\n\n" +
+		"You can export this report to Markdown or TXT format.")
 
-	dialog.ShowInformation("Report Generated", summary, p.win)
+	dialog.ShowInformation(i18n.T("Report Generated"), summary, p.win)
 }
 
 // ExportComprehensiveReport exports the current comprehensive report.
 func (p *ResultComparisonPage) ExportComprehensiveReport(report *comparison.ComparisonReport) {
 	if report == nil {
-		dialog.ShowError(fmt.Errorf("no report to export"), p.win)
+		dialog.ShowError(errors.New(i18n.T("no report to export")), p.win)
 		return
 	}
 
@@ -137,14 +139,14 @@ func (p *ResultComparisonPage) ExportComprehensiveReport(report *comparison.Comp
 	formatSelect.SetSelected("Markdown")
 
 	content := container.NewVBox(
-		widget.NewLabel("Export Comprehensive Report"),
+		widget.NewLabel(i18n.T("Export Comprehensive Report")),
 		widget.NewSeparator(),
-		widget.NewLabel("Select export format:"),
+		widget.NewLabel(i18n.T("Select export format:")),
 		formatSelect,
 		widget.NewSeparator(),
 	)
 
-	dialog.ShowCustomConfirm("Export Report", "Export", "Cancel", content, func(export bool) {
+	dialog.ShowCustomConfirm(i18n.T("Export Report"), i18n.T("Export"), i18n.T("Cancel"), content, func(export bool) {
 		if !export {
 			return
 		}
@@ -164,20 +166,21 @@ func (p *ResultComparisonPage) ExportComprehensiveReport(report *comparison.Comp
 			ext = ".txt"
 		}
 		filename := fmt.Sprintf("comparison_report_%s%s", timestamp, ext)
-		filepath := fmt.Sprintf("./exports/%s", filename)
+		exportDir := p.comparisonUC.ExportDir()
+		filepath := fmt.Sprintf("%s/%s", exportDir, filename)
 
 		// Export via usecase
 		ctx := context.Background()
 		err := p.comparisonUC.ExportReport(ctx, report, format, filepath)
 		if err != nil {
 			slog.Error("Comparison: Failed to export report", "error", err)
-			dialog.ShowError(fmt.Errorf("export failed: %v", err), p.win)
+			dialog.ShowError(fmt.Errorf(i18n.T("export failed: %v"), err), p.win)
 			return
 		}
 
-		dialog.ShowInformation("Export Successful",
-			fmt.Sprintf("Report exported to:\n%s\n\nFormat: %s", filepath, format),
-			p.win)
+		showExportResult(p.win, i18n.T("Export Successful"),
+			i18n.Tf("Report exported to:\n%s\n\nFormat: %s", filepath, format),
+			exportDir)
 
 		slog.Info("Comparison: Report exported", "filepath", filepath, "format", format)
 	}, p.win)
@@ -186,7 +189,7 @@ func (p *ResultComparisonPage) ExportComprehensiveReport(report *comparison.Comp
 // AddComprehensiveReportButton adds a button to generate comprehensive reports.
 // This can be called from the comparison page initialization to add the new feature.
 func (p *ResultComparisonPage) AddComprehensiveReportButton(toolbar *fyne.Container) {
-	btnComprehensive := widget.NewButton("📊 Full Report", func() {
+	btnComprehensive := widget.NewButton(i18n.T("📊 Full Report"), func() {
 		p.GenerateComprehensiveReport()
 	})
 	btnComprehensive.Importance = widget.MediumImportance
@@ -203,7 +206,7 @@ func (p *ResultComparisonPage) AddComprehensiveReportButton(toolbar *fyne.Contai
 // This is the main reporting feature for performance analysis.
 func (p *ResultComparisonPage) GenerateSimplifiedReport() {
 	if p.comparisonUC == nil {
-		dialog.ShowError(fmt.Errorf("comparison use case not available"), p.win)
+		dialog.ShowError(errors.New(i18n.T("comparison use case not available")), p.win)
 		return
 	}
 
@@ -222,28 +225,37 @@ func (p *ResultComparisonPage) GenerateSimplifiedReport() {
 	}
 
 	if len(selectedIDs) < 2 {
-		dialog.ShowInformation("Insufficient Selection",
-			fmt.Sprintf("Please select at least 2 records to compare.\n\nCurrently selected: %d\n\nUse 'Select All' to select all records, or click checkboxes individually.",
+		dialog.ShowInformation(i18n.T("Insufficient Selection"),
+			i18n.Tf("Please select at least 2 records to compare.\n\nCurrently selected: %d\n\nUse 'Select All' to select all records, or click checkboxes individually.",
 				len(selectedIDs)),
 			p.win)
 		return
 	}
 
 	if len(selectedIDs) > 10 {
-		dialog.ShowInformation("Too Many Records",
-			fmt.Sprintf("Maximum 10 records can be compared at once.\n\nCurrently selected: %d\n\nPlease deselect some records and try again.",
+		dialog.ShowInformation(i18n.T("Too Many Records"),
+			i18n.Tf("Maximum 10 records can be compared at once.\n\nCurrently selected: %d\n\nPlease deselect some records and try again.",
 				len(selectedIDs)),
 			p.win)
 		return
 	}
 
-	// Validate all selected records are from the same database type
-	if len(selectedRefs) > 0 {
+	// Resolve grouping field (defaults to threads)
+	groupBy := comparison.GroupByThreads
+	if p.groupBySelect != nil {
+		if field, ok := comparisonGroupBy(p.groupBySelect.Selected); ok {
+			groupBy = field
+		}
+	}
+
+	// Validate all selected records are from the same database type,
+	// unless database types or versions are what is being compared
+	if groupBy != comparison.GroupByDatabaseType && groupBy != comparison.GroupByVersion && len(selectedRefs) > 0 {
 		firstDBType := selectedRefs[0].DatabaseType
 		for _, ref := range selectedRefs {
 			if ref.DatabaseType != firstDBType {
-				dialog.ShowInformation("Mixed Database Types",
-					fmt.Sprintf("All selected records must be from the same database type.\n\nFound types: %s\n\nPlease use the 'Database Type' filter to select records from a single database type.",
+				dialog.ShowInformation(i18n.T("Mixed Database Types"),
+					i18n.Tf("All selected records must be from the same database type.\n\nFound types: %s\n\nPlease use the 'Database Type' filter to select records from a single database type, or set 'Group By' to 'Database Type'.",
 						getDatabaseTypesSummary(selectedRefs)),
 					p.win)
 				return
@@ -254,19 +266,16 @@ func (p *ResultComparisonPage) GenerateSimplifiedReport() {
 	ctx := context.Background()
 
 	// Show progress
-	progress := dialog.NewInformation("Generating Comparison Report",
-		fmt.Sprintf("Analyzing %d selected records...\n\nPlease wait.", len(selectedIDs)), p.win)
+	progress := dialog.NewInformation(i18n.T("Generating Comparison Report"),
+		i18n.Tf("Analyzing %d selected records...\n\nPlease wait.", len(selectedIDs)), p.win)
 	progress.Show()
 
-	// Always group by database type for comparison
-	groupBy := comparison.GroupByDatabaseType
-
 	// Generate simplified report (synchronous for simplicity)
 	report, err := p.comparisonUC.GenerateSimplifiedReport(ctx, selectedIDs, groupBy)
 	if err != nil {
 		slog.Error("Comparison: Failed to generate simplified report", "error", err)
 		progress.Hide()
-		dialog.ShowError(fmt.Errorf("failed to generate simplified report: %v", err), p.win)
+		dialog.ShowError(fmt.Errorf(i18n.T("failed to generate simplified report: %v"), err), p.win)
 		return
 	}
 
@@ -290,6 +299,12 @@ func (p *ResultComparisonPage) displaySimplifiedReport(report *comparison.Simpli
 	if p.resultsText != nil {
 		p.resultsText.SetText(markdown)
 	}
+	p.lastReport = report
+	if p.tpsChart != nil {
+		series := report.ChartSeries()
+		p.tpsChart.SetSeries(series)
+		p.latencyChart.SetSeries(series)
+	}
 
 	// Show summary dialog
 	passed := 0
@@ -299,77 +314,77 @@ func (p *ResultComparisonPage) displaySimplifiedReport(report *comparison.Simpli
 		}
 	}
 
-	summary := fmt.Sprintf(
+	summary := i18n.Tf(
 		"✅ Simplified Report Generated!\n\n"+
 			"Report ID: %s\n"+
 			"Config Groups: %d\n"+
 			"Grouped by: %s\n"+
 			"Records: %d\n\n"+
 			"Sanity Checks: %d/%d passed\n\n"+
-			"Full report is displayed below.\n\n"+
-			"You can export this report to Markdown or TXT format.",
+			"Full report is displayed below, TPS and p95 latency charts in the Charts tab.\n\n"+
+			"You can export this report to Markdown, TXT, HTML or Excel (with charts) format.",
 		report.ReportID,
 		len(report.ConfigGroups),
 		report.GroupBy,
 		report.SelectedRecords,
 		passed, len(report.SanityChecks))
 
-	dialog.ShowInformation("Report Generated", summary, p.win)
+	dialog.ShowInformation(i18n.T("Report Generated"), summary, p.win)
 }
 
 // ExportPerformanceReport exports the current performance report.
 func (p *ResultComparisonPage) ExportSimplifiedReport(report *comparison.SimplifiedReport) {
 	if report == nil {
-		dialog.ShowError(fmt.Errorf("no report to export"), p.win)
+		dialog.ShowError(errors.New(i18n.T("no report to export")), p.win)
 		return
 	}
 
 	// Ask for format
-	formatSelect := widget.NewRadioGroup([]string{"Markdown", "TXT"}, func(selected string) {})
+	formatSelect := widget.NewRadioGroup([]string{"Markdown", "TXT", "HTML", "Excel"}, func(selected string) {})
 	formatSelect.SetSelected("Markdown")
 
 	content := container.NewVBox(
-		widget.NewLabel("Export Simplified Report"),
+		widget.NewLabel(i18n.T("Export Simplified Report")),
 		widget.NewSeparator(),
-		widget.NewLabel("Select export format:"),
+		widget.NewLabel(i18n.T("Select export format:")),
 		formatSelect,
 		widget.NewSeparator(),
 	)
 
-	dialog.ShowCustomConfirm("Export Report", "Export", "Cancel", content, func(export bool) {
+	dialog.ShowCustomConfirm(i18n.T("Export Report"), i18n.T("Export"), i18n.T("Cancel"), content, func(export bool) {
 		if !export {
 			return
 		}
 
 		// Determine format
-		format := "markdown"
-		if formatSelect.Selected == "TXT" {
-			format = "txt"
+		format, ext := "markdown", ".md"
+		switch formatSelect.Selected {
+		case "TXT":
+			format, ext = "txt", ".txt"
+		case "HTML":
+			format, ext = "html", ".html"
+		case "Excel":
+			format, ext = "xlsx", ".xlsx"
 		}
 
 		// Generate filename
 		timestamp := time.Now().Format("20060102_150405")
-		var ext string
-		if format == "markdown" {
-			ext = ".md"
-		} else {
-			ext = ".txt"
-		}
 		filename := fmt.Sprintf("simplified_report_%s%s", timestamp, ext)
-		filepath := fmt.Sprintf("./exports/%s", filename)
+		exportDir := p.comparisonUC.ExportDir()
+		filepath := fmt.Sprintf("%s/%s", exportDir, filename)
 
 		// Export via usecase
 		ctx := context.Background()
 		err := p.comparisonUC.ExportSimplifiedReport(ctx, report, format, filepath)
 		if err != nil {
 			slog.Error("Comparison: Failed to export simplified report", "error", err)
-			dialog.ShowError(fmt.Errorf("export failed: %v", err), p.win)
+			dialog.ShowError(fmt.Errorf(i18n.T("export failed: %v"), err), p.win)
 			return
 		}
 
-		dialog.ShowInformation("Export Successful",
-			fmt.Sprintf("Report exported to:\n%s\n\nFormat: %s", filepath, format),
-			p.win)
+		showExportResult(p.win, i18n.T("Export Successful"),
+			i18n.Tf("Report exported to:\n%s\n\nFormat: %s", filepath, format),
+			exportDir)
 
 		slog.Info("Comparison: Simplified report exported", "filepath", filepath, "format", format)
 	}, p.win)
@@ -378,7 +393,7 @@ func (p *ResultComparisonPage) ExportSimplifiedReport(report *comparison.Simplif
 // AddSimplifiedReportButton adds a button to generate simplified reports.
 // This can be called from the comparison page initialization to add the new feature.
 func (p *ResultComparisonPage) AddSimplifiedReportButton(toolbar *fyne.Container) {
-	btnSimplified := widget.NewButton("📋 Simple Report", func() {
+	btnSimplified := widget.NewButton(i18n.T("📋 Simple Report"), func() {
 		p.GenerateSimplifiedReport()
 	})
 	btnSimplified.Importance = widget.MediumImportance
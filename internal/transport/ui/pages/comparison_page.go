@@ -4,9 +4,11 @@ package pages
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -16,6 +18,8 @@ import (
 
 	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/comparison"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
 )
 
 // ResultComparisonPage provides the result comparison GUI.
@@ -27,8 +31,33 @@ type ResultComparisonPage struct {
 	selectedMap        map[string]bool
 	ctx                context.Context
 	resultsText        *widget.Entry
+	tpsChart           *comparisonChart
+	latencyChart       *comparisonChart
+	lastReport         *comparison.SimplifiedReport // Report shown, for HTML export
 	toggleSelectBtn    *widget.Button
 	databaseTypeSelect *widget.Select
+	groupBySelect      *widget.Select
+	tagFilterEntry     *widget.Entry
+}
+
+// comparisonGroupByOptions maps Group By selector labels (English, shown
+// translated) to grouping fields.
+var comparisonGroupByOptions = map[string]comparison.GroupByField{
+	"Threads":          comparison.GroupByThreads,
+	"Database Type":    comparison.GroupByDatabaseType,
+	"Template":         comparison.GroupByTemplate,
+	"Tag":              comparison.GroupByTag,
+	"Database Version": comparison.GroupByVersion,
+}
+
+// comparisonGroupBy returns the grouping field of a translated selector label.
+func comparisonGroupBy(label string) (comparison.GroupByField, bool) {
+	for key, field := range comparisonGroupByOptions {
+		if i18n.T(key) == label {
+			return field, true
+		}
+	}
+	return "", false
 }
 
 // NewResultComparisonPage creates a new comparison page.
@@ -45,6 +74,7 @@ func NewResultComparisonPage(win fyne.Window, comparisonUC *usecase.ComparisonUs
 
 	// Create Database Type selector
 	page.databaseTypeSelect = widget.NewSelect([]string{
+		i18n.T("All"),
 		"MySQL",
 		"PostgreSQL",
 		"Oracle",
@@ -54,41 +84,60 @@ func NewResultComparisonPage(win fyne.Window, comparisonUC *usecase.ComparisonUs
 	})
 	page.databaseTypeSelect.SetSelected("MySQL")
 
+	// Create Group By selector
+	page.groupBySelect = widget.NewSelect([]string{i18n.T("Threads"), i18n.T("Database Type"), i18n.T("Template"), i18n.T("Tag"), i18n.T("Database Version")}, nil)
+	page.groupBySelect.SetSelected(i18n.T("Threads"))
+
 	// Create toolbar
-	btnCompare := widget.NewButton("📊 Compare Records", func() {
+	btnCompare := widget.NewButton(i18n.T("📊 Compare Records"), func() {
 		page.GenerateSimplifiedReport()
 	})
-	btnExport := widget.NewButton("💾 Export Report", func() {
+	btnExport := widget.NewButton(i18n.T("💾 Export Report"), func() {
 		page.onExportReport()
 	})
-	btnClear := widget.NewButton("🗑️ Clear", func() {
+	btnDiff := widget.NewButton(i18n.T("🔍 Diff Environment"), func() {
+		page.onDiffEnvironment()
+	})
+	btnClear := widget.NewButton(i18n.T("🗑️ Clear"), func() {
 		page.resultsText.SetText("")
+		page.lastReport = nil
+		page.tpsChart.SetSeries(nil)
+		page.latencyChart.SetSeries(nil)
 		slog.Info("Comparison: Results cleared")
 	})
 
-	toolbar := container.NewHBox(btnCompare, btnExport, btnClear)
+	toolbar := container.NewHBox(btnCompare, btnExport, btnDiff, btnClear)
 
 	// Filter control buttons
-	btnRefresh := widget.NewButton("🔄 Refresh List", func() {
+	btnRefresh := widget.NewButton(i18n.T("🔄 Refresh List"), func() {
 		page.loadRecords()
 	})
-	page.toggleSelectBtn = widget.NewButton("✓ Select All", func() {
+	page.toggleSelectBtn = widget.NewButton(i18n.T("✓ Select All"), func() {
 		page.toggleSelectAll()
 	})
 	filterButtons := container.NewHBox(btnRefresh, page.toggleSelectBtn)
 
 	// Create search entry - using Form layout for better sizing
 	searchEntry := widget.NewEntry()
-	searchEntry.SetPlaceHolder("Search: MySQL, 8 threads, oltp...")
+	searchEntry.SetPlaceHolder(i18n.T("Search: MySQL, 8 threads, oltp..."))
 	searchEntry.OnChanged = func(text string) {
 		page.filterRecords(text)
 	}
 
+	// Create tag filter entry - records must carry all listed tags
+	page.tagFilterEntry = widget.NewEntry()
+	page.tagFilterEntry.SetPlaceHolder(i18n.T("Tags: baseline, innodb_buffer_pool=32G"))
+	page.tagFilterEntry.OnChanged = func(string) {
+		page.onDatabaseTypeChange(page.databaseTypeSelect.Selected)
+	}
+
 	// Use Form to create better layout with proper spacing
 	filterForm := container.NewVBox(
 		widget.NewForm(
-			widget.NewFormItem("Search Records", searchEntry),
-			widget.NewFormItem("Database Type", page.databaseTypeSelect),
+			widget.NewFormItem(i18n.T("Search Records"), searchEntry),
+			widget.NewFormItem(i18n.T("Database Type"), page.databaseTypeSelect),
+			widget.NewFormItem(i18n.T("Tags"), page.tagFilterEntry),
+			widget.NewFormItem(i18n.T("Group By"), page.groupBySelect),
 		),
 		filterButtons,
 	)
@@ -101,7 +150,7 @@ func NewResultComparisonPage(win fyne.Window, comparisonUC *usecase.ComparisonUs
 		func() fyne.CanvasObject {
 			// Create a row with checkbox and info
 			check := widget.NewCheck("", func(checked bool) {})
-			label := widget.NewLabel("Record Info")
+			label := widget.NewLabel(i18n.T("Record Info"))
 			return container.NewHBox(check, label)
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
@@ -137,20 +186,25 @@ func NewResultComparisonPage(win fyne.Window, comparisonUC *usecase.ComparisonUs
 
 			// Second object is label
 			if label, ok := hboxCont.Objects[1].(*widget.Label); ok {
-				label.SetText(fmt.Sprintf("%s | %s | %d threads | %.2f TPS | %.2f QPS | %s",
+				text := i18n.Tf("%s | %s | %d threads | %.2f TPS | %.2f QPS | %s",
 					ref.DatabaseType,
 					ref.TemplateName,
 					ref.Threads,
 					ref.TPS,
 					ref.QPS,
-					ref.StartTime.Format("2006-01-02 15:04")))
+					ref.StartTime.Format("2006-01-02 15:04"))
+				// Invalid runs can be selected but are left out of the report
+				if !ref.IsValid() {
+					text = i18n.T("⚠ INVALID (") + strings.Join(ref.FailedChecks, ", ") + ") | " + text
+				}
+				label.SetText(text)
 			}
 		},
 	)
 
 	// Create results text area
 	page.resultsText = widget.NewMultiLineEntry()
-	page.resultsText.SetText("Select 2 or more records and click 'Compare Selected' to see results.\n\nYou can group results by: Threads, Database Type, Template Name, or Date.")
+	page.resultsText.SetText(i18n.T("Select 2 or more records and click 'Compare Selected' to see results.\n\nYou can group results by: Threads, Database Type, Template Name, or Date."))
 	// ⭐ 设置最小行数，让Results向下拉伸（增加到30行）
 	page.resultsText.SetMinRowsVisible(30)
 
@@ -167,16 +221,24 @@ func NewResultComparisonPage(win fyne.Window, comparisonUC *usecase.ComparisonUs
 	)
 
 	// ⭐ 下半部分：关键修复 - 让resultsScroll直接作为Center扩展
-	resultsLabel := widget.NewLabel("Comparison Results:")
+	resultsLabel := widget.NewLabel(i18n.T("Comparison Results:"))
 	resultsScroll := container.NewScroll(page.resultsText)
 
+	// Charts of the last report, next to the text report
+	page.tpsChart = newComparisonChart(comparison.ChartTPS)
+	page.latencyChart = newComparisonChart(comparison.ChartLatencyP95)
+	resultsTabs := container.NewAppTabs(
+		container.NewTabItem(i18n.T("Report"), resultsScroll),
+		container.NewTabItem(i18n.T("Charts"), container.NewGridWithColumns(2, page.tpsChart, page.latencyChart)),
+	)
+
 	// ⭐ 重新组织：label和separator在Top，scroll在Center自动扩展
 	resultsArea := container.NewBorder(
 		container.NewVBox(toolbar, widget.NewSeparator(), resultsLabel), // Top
 		nil,           // Bottom
 		nil,           // Left
 		nil,           // Right
-		resultsScroll, // Center - 直接让scroll自动扩展
+		resultsTabs,   // Center - 直接让tabs自动扩展
 	)
 
 	// 使用2行Grid布局，上下各占约50%空间
@@ -186,7 +248,7 @@ func NewResultComparisonPage(win fyne.Window, comparisonUC *usecase.ComparisonUs
 	)
 
 	// 整体包装在 Card 中
-	finalContent := widget.NewCard("Record Selection", "", content)
+	finalContent := widget.NewCard(i18n.T("Record Selection"), "", content)
 
 	return page, finalContent
 }
@@ -202,7 +264,7 @@ func (p *ResultComparisonPage) loadRecords() {
 	refs, err := p.comparisonUC.GetRecordRefs(p.ctx)
 	if err != nil {
 		slog.Error("Comparison: Failed to load records", "error", err)
-		dialog.ShowError(fmt.Errorf("failed to load records: %v", err), p.win)
+		dialog.ShowError(fmt.Errorf(i18n.T("failed to load records: %v"), err), p.win)
 		return
 	}
 
@@ -300,6 +362,8 @@ func (p *ResultComparisonPage) filterRecords(searchText string) {
 		return
 	}
 
+	refs = p.filterByTags(refs)
+
 	// Filter by search text
 	if searchText == "" {
 		p.recordRefs = refs
@@ -320,6 +384,25 @@ func (p *ResultComparisonPage) filterRecords(searchText string) {
 	}
 }
 
+// filterByTags keeps the records that carry all tags entered in the tag filter.
+func (p *ResultComparisonPage) filterByTags(refs []*comparison.RecordRef) []*comparison.RecordRef {
+	if p.tagFilterEntry == nil {
+		return refs
+	}
+	tags := history.ParseTags(p.tagFilterEntry.Text)
+	if len(tags) == 0 {
+		return refs
+	}
+
+	var filtered []*comparison.RecordRef
+	for _, ref := range refs {
+		if ref.HasAllTags(tags) {
+			filtered = append(filtered, ref)
+		}
+	}
+	return filtered
+}
+
 // contains checks if a string contains the search text (case-insensitive).
 func contains(text, search string) bool {
 	return fmt.Sprintf("%s", text) == search || // Poor man's contains - for simplicity
@@ -341,8 +424,8 @@ func (p *ResultComparisonPage) onDatabaseTypeChange(selected string) {
 
 	// Filter by database type
 	var filtered []*comparison.RecordRef
-	for _, ref := range refs {
-		if ref.DatabaseType == selected {
+	for _, ref := range p.filterByTags(refs) {
+		if selected == i18n.T("All") || ref.DatabaseType == selected {
 			filtered = append(filtered, ref)
 		}
 	}
@@ -351,7 +434,7 @@ func (p *ResultComparisonPage) onDatabaseTypeChange(selected string) {
 	// Clear selections when filter changes
 	p.selectedMap = make(map[string]bool)
 	if p.toggleSelectBtn != nil {
-		p.toggleSelectBtn.SetText("✓ Select All")
+		p.toggleSelectBtn.SetText(i18n.T("✓ Select All"))
 	}
 
 	if p.list != nil {
@@ -387,9 +470,9 @@ func (p *ResultComparisonPage) selectAllRecords(selectAll bool) {
 	// Update button text
 	if p.toggleSelectBtn != nil {
 		if selectAll {
-			p.toggleSelectBtn.SetText("✗ Deselect All")
+			p.toggleSelectBtn.SetText(i18n.T("✗ Deselect All"))
 		} else {
-			p.toggleSelectBtn.SetText("✓ Select All")
+			p.toggleSelectBtn.SetText(i18n.T("✓ Select All"))
 		}
 	}
 
@@ -400,27 +483,113 @@ func (p *ResultComparisonPage) selectAllRecords(selectAll bool) {
 	slog.Info("Comparison: Records "+action, "count", selectedCount)
 }
 
+// onDiffEnvironment shows the template parameters and database configuration
+// of the two selected records side by side, the older record first.
+func (p *ResultComparisonPage) onDiffEnvironment() {
+	var selected []*comparison.RecordRef
+	for _, ref := range p.recordRefs {
+		if p.selectedMap[ref.ID] {
+			selected = append(selected, ref)
+		}
+	}
+	if len(selected) != 2 {
+		dialog.ShowInformation(i18n.T("Select Two Records"),
+			i18n.Tf("Please select exactly 2 records to diff their environment.\n\nCurrently selected: %d", len(selected)),
+			p.win)
+		return
+	}
+	before, after := selected[0], selected[1]
+	if after.StartTime.Before(before.StartTime) {
+		before, after = after, before
+	}
+
+	diff := comparison.DiffEnvironment(before, after)
+	slog.Info("Comparison: Environment diff", "before", before.ID, "after", after.ID, "changed", diff.Changed)
+
+	headers := []string{i18n.T("Section"), i18n.T("Key"),
+		i18n.Tf("Before (%s)", before.StartTime.Format("2006-01-02 15:04")),
+		i18n.Tf("After (%s)", after.StartTime.Format("2006-01-02 15:04"))}
+	rows := diff.ChangedRows()
+
+	table := widget.NewTable(
+		func() (int, int) { return len(rows) + 1, len(headers) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row == 0 {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.Importance = widget.MediumImportance
+				label.SetText(headers[id.Col])
+				return
+			}
+			row := rows[id.Row-1]
+			// Changed keys are highlighted
+			label.TextStyle = fyne.TextStyle{Bold: row.Changed}
+			label.Importance = widget.MediumImportance
+			if row.Changed {
+				label.Importance = widget.WarningImportance
+			}
+			label.SetText([]string{row.Section, row.Name, displayValue(row.Before), displayValue(row.After)}[id.Col])
+		},
+	)
+	for col, width := range []float32{100, 260, 240, 240} {
+		table.SetColumnWidth(col, width)
+	}
+
+	changedOnly := widget.NewCheck(i18n.T("Changed keys only"), func(checked bool) {
+		if checked {
+			rows = diff.ChangedRows()
+		} else {
+			rows = diff.Rows
+		}
+		table.Refresh()
+	})
+	changedOnly.SetChecked(true)
+
+	summary := i18n.Tf("%d of %d keys differ", diff.Changed, len(diff.Rows))
+	for _, note := range diff.Notes {
+		summary += "\n" + note
+	}
+	top := container.NewVBox(widget.NewLabel(summary), changedOnly)
+	content := container.NewBorder(top, nil, nil, nil, table)
+
+	dlg := dialog.NewCustom(i18n.T("Diff Environment"), i18n.T("Close"), content, p.win)
+	dlg.Resize(fyne.NewSize(900, 560))
+	dlg.Show()
+}
+
+// displayValue marks keys that are not set for a record.
+func displayValue(value string) string {
+	if value == "" {
+		return i18n.T("(unset)")
+	}
+	return value
+}
+
 // onExportReport exports the current performance report.
 func (p *ResultComparisonPage) onExportReport() {
 	resultsText := p.resultsText.Text
 	if resultsText == "" {
-		dialog.ShowError(fmt.Errorf("no performance report to export"), p.win)
+		dialog.ShowError(errors.New(i18n.T("no performance report to export")), p.win)
 		return
 	}
 
 	// Create export dialog content
-	formatSelect := widget.NewRadioGroup([]string{"Markdown", "TXT"}, func(selected string) {})
+	formatSelect := widget.NewRadioGroup([]string{"Markdown", "TXT", "HTML", "Excel", "ZIP"}, func(selected string) {})
 	formatSelect.SetSelected("Markdown")
+	bundleLabel := widget.NewLabel(i18n.T("ZIP bundles the report in Markdown and HTML with the export of every compared record and its kept run artifacts, to share as one file."))
+	bundleLabel.Wrapping = fyne.TextWrapWord
 
 	content := container.NewVBox(
-		widget.NewLabel("Export Performance Report"),
+		widget.NewLabel(i18n.T("Export Performance Report")),
 		widget.NewSeparator(),
-		widget.NewLabel("Select export format:"),
+		widget.NewLabel(i18n.T("Select export format:")),
 		formatSelect,
+		bundleLabel,
 		widget.NewSeparator(),
 	)
 
-	dialog.ShowCustomConfirm("Export Report", "Export", "Cancel", content, func(export bool) {
+	dialog.ShowCustomConfirm(i18n.T("Export Report"), i18n.T("Export"), i18n.T("Cancel"), content, func(export bool) {
 		if !export {
 			return
 		}
@@ -433,28 +602,49 @@ func (p *ResultComparisonPage) onExportReport() {
 		case "TXT":
 			format = "txt"
 			ext = ".txt"
+		case "HTML", "Excel", "ZIP":
+			// HTML, Excel and ZIP reports are rendered from the report with its charts
+			if p.lastReport == nil {
+				dialog.ShowError(errors.New(i18n.T("no performance report to export")), p.win)
+				return
+			}
+			format, ext = "html", ".html"
+			switch formatSelect.Selected {
+			case "Excel":
+				format, ext = "xlsx", ".xlsx"
+			case "ZIP":
+				format, ext = "zip", ".zip"
+			}
 		}
 
 		timestamp := time.Now().Format("20060102_150405")
 		filename := fmt.Sprintf("performance_report_%s%s", timestamp, ext)
-		filepath := fmt.Sprintf("./exports/%s", filename)
+		exportDir := p.comparisonUC.ExportDir()
+		filepath := fmt.Sprintf("%s/%s", exportDir, filename)
 
 		// Ensure exports directory exists
-		if err := os.MkdirAll("./exports", 0755); err != nil {
-			dialog.ShowError(fmt.Errorf("failed to create exports directory: %v", err), p.win)
+		if err := os.MkdirAll(exportDir, 0755); err != nil {
+			dialog.ShowError(fmt.Errorf(i18n.T("failed to create exports directory: %v"), err), p.win)
 			return
 		}
 
 		// Write file
-		err := os.WriteFile(filepath, []byte(resultsText), 0644)
+		var err error
+		if format == "zip" {
+			err = p.comparisonUC.ExportReportBundle(context.Background(), p.lastReport, filepath)
+		} else if format == "html" || format == "xlsx" {
+			err = p.comparisonUC.ExportSimplifiedReport(context.Background(), p.lastReport, format, filepath)
+		} else {
+			err = os.WriteFile(filepath, []byte(resultsText), 0644)
+		}
 		if err != nil {
-			dialog.ShowError(fmt.Errorf("failed to export report: %v", err), p.win)
+			dialog.ShowError(fmt.Errorf(i18n.T("failed to export report: %v"), err), p.win)
 			return
 		}
 
-		dialog.ShowInformation("Export Successful",
-			fmt.Sprintf("Report exported to:\n%s\n\nFormat: %s", filepath, format),
-			p.win)
+		showExportResult(p.win, i18n.T("Export Successful"),
+			i18n.Tf("Report exported to:\n%s\n\nFormat: %s", filepath, format),
+			exportDir)
 
 		slog.Info("Comparison: Report exported", "filepath", filepath, "format", format)
 	}, p.win)
@@ -0,0 +1,142 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// Connection dialog: live preview and checks of the connection string.
+package pages
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2/dialog"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// previewConnection returns a connection of the dialog fields for the
+// connection string preview, nil for an unknown database type.
+func (d *connectionDialog) previewConnection() connection.Connection {
+	host := strings.TrimSpace(d.hostEntry.Text)
+	port, _ := strconv.Atoi(strings.TrimSpace(d.portEntry.Text))
+	database := strings.TrimSpace(d.dbEntry.Text)
+	username := strings.TrimSpace(d.userEntry.Text)
+	password := d.passEntry.Text
+
+	switch d.dbTypeSelect.Selected {
+	case "MySQL":
+		conn := &connection.MySQLConnection{Host: host, Port: port, Database: database, Username: username, Password: password}
+		d.applyMySQLSSL(conn)
+		return conn
+	case "PostgreSQL":
+		options, _ := parseOptions(d.pgOptionsEntry.Text) // Preview the valid lines
+		return &connection.PostgreSQLConnection{Host: host, Port: port, Database: database, Username: username, Password: password,
+			Options: options}
+	case "Oracle":
+		conn := &connection.OracleConnection{Host: host, Port: port, Username: username, Password: password}
+		d.applyOracleOptions(conn, database)
+		return conn
+	case "SQL Server":
+		conn := &connection.SQLServerConnection{Host: host, Port: port, Database: database, Username: username, Password: password,
+			TrustServerCertificate: d.trustServerCertCheck.Checked, WinRM: d.winrmLogin()}
+		d.applySQLServerOptions(conn)
+		return conn
+	}
+	return nil
+}
+
+// updateDSNPreview shows the masked connection string of the dialog fields
+// and warns about characters it does not escape.
+func (d *connectionDialog) updateDSNPreview() {
+	if d.dsnPreview == nil {
+		return // Fields are still being created
+	}
+	conn := d.previewConnection()
+	if conn == nil {
+		d.dsnPreview.SetText("")
+		d.dsnWarning.Hide()
+		return
+	}
+	d.dsnPreview.SetText(connection.MaskedDSN(conn))
+
+	warnings := dsnWarningTexts(connection.CheckDSN(conn))
+	if len(warnings) == 0 {
+		d.dsnWarning.Hide()
+		return
+	}
+	d.dsnWarning.SetText("⚠️ " + strings.Join(warnings, "\n⚠️ "))
+	d.dsnWarning.Show()
+}
+
+// confirmDSNWarnings runs save at once if the connection string of the dialog
+// fields escapes everything, and after a confirmation otherwise.
+func (d *connectionDialog) confirmDSNWarnings(save func()) {
+	conn := d.previewConnection()
+	if conn == nil {
+		save()
+		return
+	}
+	warnings := dsnWarningTexts(connection.CheckDSN(conn))
+	if len(warnings) == 0 {
+		save()
+		return
+	}
+	message := strings.Join(warnings, "\n") + "\n\n" + i18n.T("The connection may fail or use different credentials. Save anyway?")
+	dialog.ShowConfirm(i18n.T("Unescaped Characters"), message, func(ok bool) {
+		if ok {
+			save()
+		}
+	}, d.win)
+}
+
+// dsnWarningTexts returns translated texts of connection string warnings.
+func dsnWarningTexts(warnings []connection.DSNWarning) []string {
+	texts := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		field := i18n.T("Password")
+		if w.Field == "username" {
+			field = i18n.T("Username")
+		}
+		chars := strings.ReplaceAll(w.Chars, " ", i18n.T("space"))
+		texts = append(texts, i18n.Tf("%s contains characters that are not escaped in %s connection strings: %s", field, w.Format, chars))
+	}
+	return texts
+}
+
+// parseOptions parses additional connection options, one NAME=VALUE per
+// line; blank lines are skipped. The options of the valid lines are returned
+// with the error of the first invalid one.
+func parseOptions(text string) (map[string]string, error) {
+	var options map[string]string
+	var firstErr error
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			if firstErr == nil {
+				firstErr = fmt.Errorf(i18n.T("invalid option %q: want NAME=VALUE"), line)
+			}
+			continue
+		}
+		if options == nil {
+			options = make(map[string]string)
+		}
+		options[name] = strings.TrimSpace(value)
+	}
+	return options, firstErr
+}
+
+// formatOptions formats additional connection options for parseOptions,
+// sorted by name.
+func formatOptions(options map[string]string) string {
+	lines := make([]string, 0, len(options))
+	for name, value := range options {
+		lines = append(lines, name+"="+value)
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,99 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// Connections page: user-defined groups, favorites and search.
+package pages
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// Fixed options of the group filter, followed by the user-defined groups.
+const (
+	groupFilterAll = iota
+	groupFilterFavorites
+	groupFilterUngrouped
+)
+
+// createFilterFields creates the search entry and the group filter shown
+// above the connection list.
+func (p *ConnectionPage) createFilterFields() {
+	p.searchEntry = widget.NewEntry()
+	p.searchEntry.SetPlaceHolder(i18n.T("Search by name, group, type, host or database"))
+	p.searchEntry.OnChanged = func(string) { p.renderConnections() }
+
+	p.groupFilter = widget.NewSelect(nil, func(string) { p.renderConnections() })
+	p.updateGroupFilter()
+	p.groupFilter.SetSelectedIndex(groupFilterAll)
+}
+
+// updateGroupFilter updates the options of the group filter to the groups of
+// the loaded connections, keeping the selection if its group still exists.
+func (p *ConnectionPage) updateGroupFilter() {
+	options := append([]string{i18n.T("All Groups"), i18n.T("⭐ Favorites"), i18n.T("Ungrouped")}, connection.Groups(p.conns)...)
+	p.groupFilter.Options = options
+	if !slices.Contains(options, p.groupFilter.Selected) {
+		p.groupFilter.Selected = options[groupFilterAll] // Without re-rendering the list
+	}
+	p.groupFilter.Refresh()
+}
+
+// filteredConnections returns the loaded connections matching the search and
+// the group filter.
+func (p *ConnectionPage) filteredConnections() []connection.Connection {
+	filter := p.groupFilter.SelectedIndex()
+	var conns []connection.Connection
+	for _, conn := range p.conns {
+		switch {
+		case filter == groupFilterFavorites && !conn.IsFavorite(),
+			filter == groupFilterUngrouped && conn.GetGroup() != "",
+			filter > groupFilterUngrouped && conn.GetGroup() != p.groupFilter.Selected:
+			continue
+		}
+		if connection.Matches(conn, p.searchEntry.Text) {
+			conns = append(conns, conn)
+		}
+	}
+	return conns
+}
+
+// onToggleFavorite stars or unstars a connection.
+func (p *ConnectionPage) onToggleFavorite(conn connection.Connection) {
+	conn.SetFavorite(!conn.IsFavorite())
+	slog.Info("Connections: Setting favorite", "name", conn.GetName(), "favorite", conn.IsFavorite())
+	if err := p.connUC.UpdateConnection(context.Background(), conn); err != nil {
+		slog.Error("Connections: Failed to set favorite", "name", conn.GetName(), "error", err)
+		dialog.ShowError(fmt.Errorf(i18n.T("update connection: %w"), err), p.win)
+	}
+	p.loadConnections()
+}
+
+// onAssignGroup moves a connection to an existing or new group, or out of
+// its group if the name is cleared.
+func (p *ConnectionPage) onAssignGroup(conn connection.Connection) {
+	groupEntry := widget.NewSelectEntry(connection.Groups(p.conns))
+	groupEntry.SetText(conn.GetGroup())
+	groupEntry.SetPlaceHolder(i18n.T("e.g. Prod EU (empty to ungroup)"))
+	items := []*widget.FormItem{
+		widget.NewFormItem(i18n.T("Group"), groupEntry),
+	}
+	dialog.ShowForm(i18n.Tf("Move '%s' to Group", conn.GetName()), i18n.T("Save"), i18n.T("Cancel"), items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		conn.SetGroup(groupEntry.Text)
+		slog.Info("Connections: Assigning group", "name", conn.GetName(), "group", conn.GetGroup())
+		if err := p.connUC.UpdateConnection(context.Background(), conn); err != nil {
+			slog.Error("Connections: Failed to assign group", "name", conn.GetName(), "error", err)
+			dialog.ShowError(fmt.Errorf(i18n.T("update connection: %w"), err), p.win)
+		}
+		p.loadConnections()
+	}, p.win)
+}
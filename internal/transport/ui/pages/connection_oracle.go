@@ -0,0 +1,81 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// Connection dialog: service name or SID, TCPS and wallet of Oracle connections.
+package pages
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// createOracleFields creates the Oracle fields, shown only for Oracle. The
+// identifier select decides whether the Database field holds a SID or a
+// service name.
+func (d *connectionDialog) createOracleFields() {
+	d.oracleIdentSelect = widget.NewSelect([]string{i18n.T("SID"), i18n.T("Service Name")}, nil)
+	d.oracleIdentSelect.SetSelectedIndex(0)
+	d.oracleTCPSCheck = widget.NewCheck(i18n.T("Use TCPS (TLS)"), func(bool) { d.updateDSNPreview() })
+	d.oracleWalletEntry = widget.NewEntry()
+	d.oracleWalletEntry.SetPlaceHolder(i18n.T("Wallet directory with cwallet.sso (optional)"))
+	d.oracleWalletEntry.OnChanged = func(string) { d.updateDSNPreview() }
+	d.oracleTrustCheck = widget.NewCheck(i18n.T("Trust Server Certificate"), func(bool) { d.updateDSNPreview() })
+
+	browse := widget.NewButton(i18n.T("Browse..."), func() {
+		dialog.ShowFolderOpen(func(dir fyne.ListableURI, err error) {
+			if err != nil || dir == nil {
+				return // Canceled
+			}
+			d.oracleWalletEntry.SetText(dir.Path())
+		}, d.win)
+	})
+	d.oracleContainer = container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem(i18n.T("Connect By"), d.oracleIdentSelect),
+			widget.NewFormItem(i18n.T("Wallet"), container.NewBorder(nil, nil, nil, browse, d.oracleWalletEntry)),
+		),
+		d.oracleTCPSCheck,
+		d.oracleTrustCheck,
+	)
+}
+
+// oracleIdentifierLabel returns the label of the Database field for Oracle.
+func (d *connectionDialog) oracleIdentifierLabel() string {
+	if d.oracleIdentSelect.SelectedIndex() == 1 {
+		return i18n.T("Service Name")
+	}
+	return i18n.T("SID")
+}
+
+// loadOracleOptions fills the Oracle fields from a saved connection.
+func (d *connectionDialog) loadOracleOptions(c *connection.OracleConnection) {
+	if c.ServiceName != "" {
+		d.oracleIdentSelect.SetSelectedIndex(1)
+		d.dbEntry.SetText(c.ServiceName)
+	} else {
+		d.dbEntry.SetText(c.SID)
+	}
+	d.oracleTCPSCheck.SetChecked(c.IsTCPS())
+	d.oracleWalletEntry.SetText(c.WalletDir)
+	d.oracleTrustCheck.SetChecked(c.TrustServerCertificate)
+}
+
+// applyOracleOptions sets identifier, the SID or service name of the
+// Database field, and the TCPS settings of the fields on c.
+func (d *connectionDialog) applyOracleOptions(c *connection.OracleConnection, identifier string) {
+	c.SID, c.ServiceName = identifier, ""
+	if d.oracleIdentSelect.SelectedIndex() == 1 {
+		c.SID, c.ServiceName = "", identifier
+	}
+	c.Protocol = ""
+	if d.oracleTCPSCheck.Checked {
+		c.Protocol = connection.OracleProtocolTCPS
+	}
+	c.WalletDir = strings.TrimSpace(d.oracleWalletEntry.Text)
+	c.TrustServerCertificate = d.oracleTrustCheck.Checked
+}
@@ -4,10 +4,12 @@
 //
 // Features Implemented:
 // - ✅ List connections grouped by database type (MySQL, PostgreSQL, Oracle, SQL Server)
+// - ✅ User-defined groups, favorites listed first, search and group filter
 // - ✅ Add new connections with database-specific field labels and defaults
 // - ✅ Edit existing connections
 // - ✅ Delete connections with confirmation
 // - ✅ Test connections with intelligent SSL/encryption detection
+// - ✅ Test all connections at once with a summary table
 // - ✅ Database-specific icons (🐬 MySQL, 🐘 PostgreSQL, 🔴 Oracle, 🔷 SQL Server)
 // - ✅ Dynamic labels: "Database" for MySQL/PostgreSQL/SQL Server, "SID" for Oracle
 // - ✅ Field validation: PostgreSQL Database and Oracle SID are required
@@ -22,6 +24,7 @@ package pages
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
@@ -34,7 +37,9 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
 )
 
 // ConnectionPage provides the connection management GUI.
@@ -43,8 +48,15 @@ type ConnectionPage struct {
 	win    fyne.Window
 	conns  []connection.Connection
 	// Group containers
-	groupContainers map[string]*fyne.Container // DB type -> container
+	groupContainers map[string]*fyne.Container // Group title -> container
 	listContainer   *fyne.Container
+	searchEntry     *widget.Entry   // Search over name, group, type and address
+	groupFilter     *widget.Select  // All, favorites, ungrouped or a user-defined group
+	scroll          *container.Scroll
+	// Connection selected by the quick search, highlighted until the next refresh
+	selectedID    string
+	selectedRow   fyne.CanvasObject
+	selectedGroup *fyne.Container
 	content         *fyne.Container // Main content container for Refresh()
 }
 
@@ -57,13 +69,19 @@ func NewConnectionPage(connUC *usecase.ConnectionUseCase, win fyne.Window) (*Con
 		listContainer:   container.NewVBox(),
 	}
 
-	// Create toolbar with only Add button
-	btnAdd := widget.NewButton("➕ Add", func() {
+	// Create toolbar with Add and Test All buttons
+	btnAdd := widget.NewButton(i18n.T("➕ Add"), func() {
 		slog.Info("Connections: Add button clicked")
 		page.onAddConnection()
 	})
+	btnTestAll := widget.NewButton(i18n.T("🔌 Test All"), func() {
+		slog.Info("Connections: Test All button clicked")
+		page.onTestAllConnections()
+	})
+	page.createFilterFields()
 	toolbar := container.NewVBox(
-		container.NewHBox(btnAdd),
+		container.NewHBox(btnAdd, btnTestAll),
+		container.NewBorder(nil, nil, nil, page.groupFilter, page.searchEntry),
 	)
 
 	// Load connections to populate the list
@@ -75,12 +93,13 @@ func NewConnectionPage(connUC *usecase.ConnectionUseCase, win fyne.Window) (*Con
 		widget.NewSeparator(),
 	)
 
+	page.scroll = container.NewScroll(page.listContainer)
 	content := container.NewBorder(
-		topArea,                                 // top - toolbar
-		nil,                                     // bottom
-		nil,                                     // left
-		nil,                                     // right
-		container.NewScroll(page.listContainer), // center - fills available space
+		topArea,     // top - toolbar
+		nil,         // bottom
+		nil,         // left
+		nil,         // right
+		page.scroll, // center - fills available space
 	)
 
 	page.content = content
@@ -91,10 +110,11 @@ func NewConnectionPage(connUC *usecase.ConnectionUseCase, win fyne.Window) (*Con
 // Refresh reloads the connection list when switching to the Connections tab.
 func (p *ConnectionPage) Refresh() {
 	slog.Info("Connections: Refreshing page")
+	p.selectedID = ""
 	p.loadConnections()
 }
 
-// loadConnections loads connections from the use case and groups them by database type.
+// loadConnections loads connections from the use case and lists them.
 func (p *ConnectionPage) loadConnections() {
 	slog.Info("Connections: Loading connections")
 	conns, err := p.connUC.ListConnections(context.Background())
@@ -104,62 +124,75 @@ func (p *ConnectionPage) loadConnections() {
 		return
 	}
 	p.conns = conns
+	p.updateGroupFilter()
+	p.renderConnections()
+}
 
-	// Group connections by database type
-	groups := make(map[string][]connection.Connection)
+// renderConnections lists the connections matching the search and the group
+// filter: favorites first, then user-defined groups, then the ungrouped
+// connections by database type.
+func (p *ConnectionPage) renderConnections() {
+	var favorites []connection.Connection
+	grouped := make(map[string][]connection.Connection)
+	byType := make(map[string][]connection.Connection)
+	conns := p.filteredConnections()
 	for _, conn := range conns {
-		dbType := string(conn.GetType())
-		// Normalize to capitalized name for display and grouping
-		displayType := dbType
-		switch dbType {
-		case "mysql":
-			displayType = "MySQL"
-		case "postgresql":
-			displayType = "PostgreSQL"
-		case "oracle":
-			displayType = "Oracle"
-		case "sqlserver":
-			displayType = "SQL Server"
+		switch {
+		case conn.IsFavorite():
+			favorites = append(favorites, conn)
+		case conn.GetGroup() != "":
+			grouped[conn.GetGroup()] = append(grouped[conn.GetGroup()], conn)
+		default:
+			byType[normalizeDBType(string(conn.GetType()))] = append(byType[normalizeDBType(string(conn.GetType()))], conn)
 		}
-		slog.Info("Connections: Found connection", "name", conn.GetName(), "db_type", dbType, "display_type", displayType)
-		groups[displayType] = append(groups[displayType], conn)
 	}
 
-	slog.Info("Connections: Groups created", "total_groups", len(groups), "group_keys", fmt.Sprintf("%v", groups))
-
 	// Clear list container
 	p.listContainer.Objects = nil
 	p.groupContainers = make(map[string]*fyne.Container)
+	p.selectedRow, p.selectedGroup = nil, nil
+
+	if len(favorites) > 0 {
+		p.createConnectionGroup("⭐", i18n.T("Favorites"), favorites)
+	}
+	for _, group := range connection.Groups(p.conns) {
+		if len(grouped[group]) > 0 {
+			p.createConnectionGroup("📁", group, grouped[group])
+		}
+	}
 
 	// Define order of database types
 	dbOrder := []string{"MySQL", "PostgreSQL", "Oracle", "SQL Server"}
 
 	// Create collapsible groups
 	for _, dbType := range dbOrder {
-		conns := groups[dbType]
-		if len(conns) == 0 {
+		if len(byType[dbType]) == 0 {
 			continue
 		}
 
-		slog.Info("Connections: Creating group", "db_type", dbType, "count", len(conns))
-		p.createConnectionGroup(dbType, conns)
+		slog.Info("Connections: Creating group", "db_type", dbType, "count", len(byType[dbType]))
+		p.createConnectionGroup("📊", dbType, byType[dbType])
 	}
 
 	p.listContainer.Refresh()
-	slog.Info("Connections: List refreshed", "total_connections", len(conns))
+	if p.selectedRow != nil {
+		scrollToRow(p.scroll, p.selectedGroup, p.selectedRow)
+	}
+	slog.Info("Connections: List refreshed", "shown_connections", len(conns), "total_connections", len(p.conns))
 }
 
-// createConnectionGroup creates a collapsible group for a database type.
-func (p *ConnectionPage) createConnectionGroup(dbType string, conns []connection.Connection) {
-	slog.Info("Connections: createConnectionGroup START", "db_type", dbType, "count", len(conns))
+// createConnectionGroup creates a collapsible group: favorites, a
+// user-defined group or a database type.
+func (p *ConnectionPage) createConnectionGroup(icon, title string, conns []connection.Connection) {
+	slog.Info("Connections: createConnectionGroup START", "group", title, "count", len(conns))
 
 	// Group header with expand/collapse button
-	headerText := fmt.Sprintf("📊 %s (%d)", dbType, len(conns))
+	headerText := fmt.Sprintf("%s %s (%d)", icon, title, len(conns))
 	headerBtn := widget.NewButton(headerText, nil)
 
 	// Container for this group's connections
 	groupContainer := container.NewVBox()
-	p.groupContainers[dbType] = groupContainer
+	p.groupContainers[title] = groupContainer
 
 	// Initially expanded
 	isExpanded := true
@@ -167,7 +200,7 @@ func (p *ConnectionPage) createConnectionGroup(dbType string, conns []connection
 	// Toggle expand/collapse
 	headerBtn.OnTapped = func() {
 		isExpanded = !isExpanded
-		slog.Info("Connections: Group header clicked", "db_type", dbType, "expanded", isExpanded)
+		slog.Info("Connections: Group header clicked", "group", title, "expanded", isExpanded)
 		if isExpanded {
 			groupContainer.Show()
 		} else {
@@ -220,27 +253,56 @@ func (p *ConnectionPage) createConnectionGroup(dbType string, conns []connection
 		if winrmEnabled {
 			tunnelIndicator = " | 🖥️ WinRM"
 		}
+		if conn.IsObserver() {
+			tunnelIndicator += " | " + i18n.T("👁 Observer")
+		} else if conn.IsProtected() {
+			tunnelIndicator += " | " + i18n.T("🛡 Protected")
+		}
 		infoText := fmt.Sprintf("%s %s  |  %s@%s:%s%s", dbIcon, connName, username, host, portStr, tunnelIndicator)
 		infoLabel := widget.NewLabel(infoText)
 
-		// Buttons for this connection: Test, Edit, Delete
-		btnTest := widget.NewButton("🔌 Test", func() {
+		// Buttons for this connection: Favorite, Group, Test, Config, Edit, Clone, Delete
+		star := "☆"
+		if conn.IsFavorite() {
+			star = "⭐"
+		}
+		btnFavorite := widget.NewButton(star, func() {
+			slog.Info("Connections: Favorite button clicked", "connection", connName)
+			p.onToggleFavorite(conn)
+		})
+		btnGroup := widget.NewButton(i18n.T("📁 Group"), func() {
+			slog.Info("Connections: Group button clicked", "connection", connName)
+			p.onAssignGroup(conn)
+		})
+		btnTest := widget.NewButton(i18n.T("🔌 Test"), func() {
 			slog.Info("Connections: Test button clicked", "connection", connName)
 			p.onTestConnection(conn)
 		})
-		btnEdit := widget.NewButton("✏️ Edit", func() {
+		btnConfig := widget.NewButton(i18n.T("📷 Config"), func() {
+			slog.Info("Connections: Config button clicked", "connection", connName)
+			p.onCaptureConfig(conn)
+		})
+		btnEdit := widget.NewButton(i18n.T("✏️ Edit"), func() {
 			slog.Info("Connections: Edit button clicked", "connection", connName)
 			p.onEditConnection(conn)
 		})
-		btnDelete := widget.NewButton("🗑️ Delete", func() {
+		btnClone := widget.NewButton(i18n.T("📑 Clone"), func() {
+			slog.Info("Connections: Clone button clicked", "connection", connName)
+			p.onCloneConnection(conn)
+		})
+		btnDelete := widget.NewButton(i18n.T("🗑️ Delete"), func() {
 			slog.Info("Connections: Delete button clicked", "connection", connName)
 			p.onDeleteConnection(conn)
 		})
-		buttonBox := container.NewHBox(btnTest, btnEdit, btnDelete)
+		buttonBox := container.NewHBox(btnFavorite, btnGroup, btnTest, btnConfig, btnEdit, btnClone, btnDelete)
 
 		// Use Border layout to align info left, buttons right
 		connRow := container.NewBorder(nil, nil, infoLabel, buttonBox)
 		groupContainer.Add(connRow)
+		if conn.GetID() == p.selectedID {
+			highlightRow(infoLabel)
+			p.selectedRow, p.selectedGroup = connRow, groupContainer
+		}
 	}
 
 	// Add header and group to main list
@@ -269,16 +331,150 @@ func (p *ConnectionPage) onAddConnection() {
 	showConnectionDialog(p.connUC, p.win, nil, p.loadConnections)
 }
 
+// AddConnection opens the dialog adding a connection (Ctrl+N).
+func (p *ConnectionPage) AddConnection() {
+	p.onAddConnection()
+}
+
+// onCaptureConfig handles the "Config" button click.
+// It captures the current database configuration, which is read-only and so
+// also available for observer connections.
+func (p *ConnectionPage) onCaptureConfig(conn connection.Connection) {
+	progress := dialog.NewCustomWithoutButtons(i18n.T("Capturing Configuration"), widget.NewProgressBarInfinite(), p.win)
+	progress.Show()
+
+	go func() {
+		snapshot, err := p.connUC.CaptureConfigSnapshot(context.Background(), conn.GetID())
+		fyne.Do(func() {
+			progress.Hide()
+			if err != nil {
+				slog.Error("Connections: Failed to capture configuration", "connection", conn.GetName(), "error", err)
+				dialog.ShowError(fmt.Errorf(i18n.T("capture configuration: %w"), err), p.win)
+				return
+			}
+
+			var b strings.Builder
+			writeConfigSnapshot(&b, snapshot)
+			dlg := dialog.NewCustom(i18n.Tf("Database Configuration - %s", conn.GetName()), i18n.T("Close"), runDetailsText(b.String()), p.win)
+			dlg.Resize(fyne.NewSize(720, 520))
+			dlg.Show()
+		})
+	}()
+}
+
 // onEditConnection handles the "Edit" button click.
 func (p *ConnectionPage) onEditConnection(conn connection.Connection) {
 	showConnectionDialog(p.connUC, p.win, conn, p.loadConnections)
 }
 
+// onCloneConnection handles the "Clone" button click.
+// It prompts for a new name and copies all other fields and stored passwords.
+func (p *ConnectionPage) onCloneConnection(conn connection.Connection) {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(i18n.Tf("%s (copy)", conn.GetName()))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(i18n.T("New Name"), nameEntry),
+	}
+	dialog.ShowForm(i18n.T("Clone Connection"), i18n.T("Clone"), i18n.T("Cancel"), items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		name := strings.TrimSpace(nameEntry.Text)
+		if name == "" {
+			dialog.ShowError(errors.New(i18n.T("name required")), p.win)
+			return
+		}
+		slog.Info("Connections: Cloning connection", "source", conn.GetName(), "name", name)
+		if _, err := p.connUC.CloneConnection(context.Background(), conn.GetID(), name); err != nil {
+			slog.Error("Connections: Failed to clone", "source", conn.GetName(), "error", err)
+			dialog.ShowError(fmt.Errorf(i18n.T("clone: %w"), err), p.win)
+			return
+		}
+		p.loadConnections()
+	}, p.win)
+}
+
+// onTestAllConnections tests every connection concurrently and shows a summary table.
+func (p *ConnectionPage) onTestAllConnections() {
+	progress := dialog.NewCustomWithoutButtons(i18n.T("Testing Connections"), widget.NewProgressBarInfinite(), p.win)
+	progress.Show()
+
+	go func() {
+		outcomes, err := p.connUC.TestAllConnections(context.Background(), usecase.DefaultTestConcurrency)
+		fyne.Do(func() {
+			progress.Hide()
+			if err != nil {
+				dialog.ShowError(fmt.Errorf(i18n.T("test connections: %w"), err), p.win)
+				return
+			}
+			if len(outcomes) == 0 {
+				dialog.ShowInformation(i18n.T("Test All"), i18n.T("No connections to test"), p.win)
+				return
+			}
+			p.showTestSummary(outcomes)
+		})
+	}()
+}
+
+// showTestSummary shows the outcomes of a bulk connection test as a table.
+func (p *ConnectionPage) showTestSummary(outcomes []usecase.ConnectionTestOutcome) {
+	headers := i18n.TList([]string{"Name", "Type", "Status", "Latency", "Version / Error"})
+	failed := 0
+	rows := make([][]string, len(outcomes))
+	for i, o := range outcomes {
+		status, latency, detail := i18n.T("✓ OK"), "-", ""
+		switch {
+		case o.Err != nil:
+			status, detail = i18n.T("✗ Failed"), o.Err.Error()
+		case !o.Result.Success:
+			status, detail = i18n.T("✗ Failed"), o.Result.Error
+			latency = fmt.Sprintf("%dms", o.Result.LatencyMs)
+		default:
+			latency = fmt.Sprintf("%dms", o.Result.LatencyMs)
+			detail = o.Result.DatabaseVersion
+		}
+		if !o.Succeeded() {
+			failed++
+		}
+		if j := strings.IndexByte(detail, '\n'); j >= 0 {
+			detail = detail[:j]
+		}
+		rows[i] = []string{o.Name, normalizeDBType(string(o.Type)), status, latency, detail}
+	}
+
+	table := widget.NewTable(
+		func() (int, int) { return len(rows) + 1, len(headers) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, obj fyne.CanvasObject) {
+			label := obj.(*widget.Label)
+			if id.Row == 0 {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.SetText(headers[id.Col])
+				return
+			}
+			label.TextStyle = fyne.TextStyle{}
+			label.SetText(rows[id.Row-1][id.Col])
+		},
+	)
+	for col, width := range []float32{180, 100, 90, 80, 360} {
+		table.SetColumnWidth(col, width)
+	}
+
+	summary := widget.NewLabel(i18n.Tf("%d tested, %d succeeded, %d failed",
+		len(outcomes), len(outcomes)-failed, failed))
+	content := container.NewBorder(nil, summary, nil, nil, table)
+
+	dlg := dialog.NewCustom(i18n.T("Connection Test Summary"), i18n.T("Close"), content, p.win)
+	dlg.Resize(fyne.NewSize(860, 420))
+	dlg.Show()
+}
+
 // onDeleteConnection handles the "Delete" button click.
 func (p *ConnectionPage) onDeleteConnection(conn connection.Connection) {
 	dialog.ShowConfirm(
-		"Delete Connection",
-		fmt.Sprintf("Delete connection '%s'?", conn.GetName()),
+		i18n.T("Delete Connection"),
+		i18n.Tf("Delete connection '%s'?", conn.GetName()),
 		func(confirmed bool) {
 			if !confirmed {
 				return
@@ -289,7 +485,7 @@ func (p *ConnectionPage) onDeleteConnection(conn connection.Connection) {
 				dialog.ShowError(err, p.win)
 				return
 			}
-			dialog.ShowInformation("Success", "Connection deleted", p.win)
+			dialog.ShowInformation(i18n.T("Success"), i18n.T("Connection deleted"), p.win)
 			p.loadConnections()
 		},
 		p.win,
@@ -310,7 +506,7 @@ func (p *ConnectionPage) onTestConnection(conn connection.Connection) {
 		connWithPasswords, err := p.connUC.GetConnectionByID(ctx, conn.GetID())
 		if err != nil {
 			slog.Error("Connections: Failed to load connection with passwords", "error", err)
-			dialog.ShowError(fmt.Errorf("failed to load connection: %w", err), win)
+			dialog.ShowError(fmt.Errorf(i18n.T("failed to load connection: %w"), err), win)
 			return
 		}
 
@@ -415,54 +611,54 @@ func (p *ConnectionPage) onTestConnection(conn connection.Connection) {
 
 		// Build comprehensive test result message
 		var msg strings.Builder
-		msg.WriteString(fmt.Sprintf("Connection Test Results: %s\n\n", conn.GetName()))
+		msg.WriteString(i18n.Tf("Connection Test Results: %s\n\n", conn.GetName()))
 
 		// SSH Tunnel section
 		if sshConfig != nil && sshConfig.Enabled {
 			msg.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-			msg.WriteString("📡 SSH TUNNEL\n")
+			msg.WriteString(i18n.T("📡 SSH TUNNEL\n"))
 			if sshSuccess {
-				msg.WriteString(fmt.Sprintf("  Status: ✓ Connected\n  Host: %s\n  Port: %d\n  User: %s\n  Latency: %dms\n",
+				msg.WriteString(i18n.Tf("  Status: ✓ Connected\n  Host: %s\n  Port: %d\n  User: %s\n  Latency: %dms\n",
 					sshConfig.Host, sshConfig.Port, sshConfig.Username, sshLatency))
 			} else {
-				msg.WriteString(fmt.Sprintf("  Status: ✗ Failed\n  Host: %s\n  Port: %d\n  User: %s\n  Error: %v\n",
+				msg.WriteString(i18n.Tf("  Status: ✗ Failed\n  Host: %s\n  Port: %d\n  User: %s\n  Error: %v\n",
 					sshConfig.Host, sshConfig.Port, sshConfig.Username, sshError))
 			}
 		}
 
 		// Database section
 		msg.WriteString("━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
-		msg.WriteString("💾 DATABASE\n")
+		msg.WriteString(i18n.T("💾 DATABASE\n"))
 		if dbSuccess {
 			if dbConnectedDirectly && (sshConfig != nil && sshConfig.Enabled) {
-				msg.WriteString(fmt.Sprintf("  Status: ✓ Connected (Direct, without SSH)\n  Version: %s\n  Latency: %dms\n  ⚠️  SSH tunnel was not used\n",
+				msg.WriteString(i18n.Tf("  Status: ✓ Connected (Direct, without SSH)\n  Version: %s\n  Latency: %dms\n  ⚠️  SSH tunnel was not used\n",
 					dbResult.DatabaseVersion, dbResult.LatencyMs))
 			} else {
-				msg.WriteString(fmt.Sprintf("  Status: ✓ Connected\n  Version: %s\n  Latency: %dms\n",
+				msg.WriteString(i18n.Tf("  Status: ✓ Connected\n  Version: %s\n  Latency: %dms\n",
 					dbResult.DatabaseVersion, dbResult.LatencyMs))
 			}
 		} else {
 			if dbConnectedDirectly {
-				msg.WriteString(fmt.Sprintf("  Status: ✗ Failed (Direct connection)\n  Error: %v\n", dbError))
+				msg.WriteString(i18n.Tf("  Status: ✗ Failed (Direct connection)\n  Error: %v\n", dbError))
 			} else {
-				msg.WriteString(fmt.Sprintf("  Status: ✗ Failed\n  Error: %v\n", dbError))
+				msg.WriteString(i18n.Tf("  Status: ✗ Failed\n  Error: %v\n", dbError))
 			}
 		}
 
 		// Add helpful note based on results
 		hasSSH := sshConfig != nil && sshConfig.Enabled
 		if hasSSH && !sshSuccess && dbSuccess && dbConnectedDirectly {
-			msg.WriteString("\n💡 Note: Database is directly accessible without SSH tunnel.\n")
+			msg.WriteString(i18n.T("\n💡 Note: Database is directly accessible without SSH tunnel.\n"))
 		} else if hasSSH && !sshSuccess && !dbSuccess {
-			msg.WriteString("\n💡 Note: SSH tunnel failed. Direct database connection also failed.\n")
+			msg.WriteString(i18n.T("\n💡 Note: SSH tunnel failed. Direct database connection also failed.\n"))
 		}
 
 		// Always show the detailed test results
-		dialog.ShowInformation("Connection Test", msg.String(), win)
+		dialog.ShowInformation(i18n.T("Connection Test"), msg.String(), win)
 
 		// Show error dialog only if both failed
 		if !dbSuccess {
-			dialog.ShowError(fmt.Errorf("database connection failed"), win)
+			dialog.ShowError(errors.New(i18n.T("database connection failed")), win)
 		}
 	}()
 }
@@ -479,6 +675,9 @@ func (p *ConnectionPage) createConnectionWithoutSSH(conn connection.Connection)
 			Username:       c.Username,
 			Password:       c.Password,
 			SSLMode:        c.SSLMode,
+			SSLCA:          c.SSLCA,
+			SSLCert:        c.SSLCert,
+			SSLKey:         c.SSLKey,
 			SSH:            nil, // Remove SSH
 		}
 	case *connection.PostgreSQLConnection:
@@ -490,10 +689,11 @@ func (p *ConnectionPage) createConnectionWithoutSSH(conn connection.Connection)
 			Username:       c.Username,
 			Password:       c.Password,
 			SSLMode:        c.SSLMode,
+			Options:        c.Options,
 			SSH:            nil, // Remove SSH
 		}
 	case *connection.OracleConnection:
-		return &connection.OracleConnection{
+		conn := &connection.OracleConnection{
 			BaseConnection: c.BaseConnection,
 			Host:           c.Host,
 			Port:           c.Port,
@@ -501,8 +701,12 @@ func (p *ConnectionPage) createConnectionWithoutSSH(conn connection.Connection)
 			SID:            c.SID,
 			Username:       c.Username,
 			Password:       c.Password,
+			Protocol:       c.Protocol,
+			WalletDir:      c.WalletDir,
 			SSH:            nil, // Remove SSH
 		}
+		conn.TrustServerCertificate = c.TrustServerCertificate
+		return conn
 	case *connection.SQLServerConnection:
 		return c // SQL Server doesn't support SSH
 	}
@@ -533,18 +737,36 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 	d.portEntry = widget.NewEntry()
 	d.portEntry.SetText("3306")
 	d.dbEntry = widget.NewEntry()
-	d.dbLabel = widget.NewLabel("Database") // Dynamic label, will be updated
+	d.dbLabel = widget.NewLabel(i18n.T("Database")) // Dynamic label, will be updated
 	d.userEntry = widget.NewEntry()
 	d.passEntry = widget.NewEntry()
 	d.passEntry.Password = true
-	d.trustServerCertCheck = widget.NewCheck("Trust Server Certificate", func(checked bool) {
+	d.trustServerCertCheck = widget.NewCheck(i18n.T("Trust Server Certificate"), func(checked bool) {
 		// Handle trust server certificate change
 	})
 	d.trustServerCertCheck.SetChecked(true) // Default to true for SQL Server (recommended)
 	d.trustServerCertCheck.Hide()          // Initially hidden, only show for SQL Server
+	d.protectedCheck = widget.NewCheck(i18n.T("Protected (block prepare and cleanup)"), nil)
+	d.observerCheck = widget.NewCheck(i18n.T("Observer (block every benchmark phase)"), nil)
+	d.pgOptionsEntry = widget.NewMultiLineEntry()
+	d.pgOptionsEntry.SetPlaceHolder("connect_timeout=10\napplication_name=db-benchmind")
+	d.pgOptionsEntry.SetMinRowsVisible(3)
+	d.pgOptionsContainer = container.NewVBox(
+		widget.NewLabel(i18n.T("Additional Options (one NAME=VALUE per line)")),
+		d.pgOptionsEntry,
+	)
+	d.replicasEntry = widget.NewEntry()
+	d.replicasEntry.SetPlaceHolder("replica-1, replica-2:3307")
+	d.replicasContainer = container.NewVBox(
+		widget.NewLabel(i18n.T("Replicas (host or host:port, comma separated; same credentials)")),
+		d.replicasEntry,
+	)
+	d.createMySQLSSLFields()
+	d.createSQLServerFields()
+	d.createOracleFields()
 
 	// Create SSH configuration fields
-	d.sshEnabledCheck = widget.NewCheck("Enable SSH Tunnel", func(checked bool) {
+	d.sshEnabledCheck = widget.NewCheck(i18n.T("Enable SSH Tunnel"), func(checked bool) {
 		// Show/hide SSH fields and update test buttons based on checkbox
 		if checked {
 			d.sshContainer.Show()
@@ -554,31 +776,31 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 	})
 	d.sshPortEntry = widget.NewEntry()
 	d.sshPortEntry.SetText("22")
-	d.sshPortEntry.SetPlaceHolder("Port")
+	d.sshPortEntry.SetPlaceHolder(i18n.T("Port"))
 	d.sshUserEntry = widget.NewEntry()
 	d.sshUserEntry.SetText("root") // Default SSH username
-	d.sshUserEntry.SetPlaceHolder("SSH username")
+	d.sshUserEntry.SetPlaceHolder(i18n.T("SSH username"))
 	d.sshPassEntry = widget.NewEntry()
 	d.sshPassEntry.Password = true
-	d.sshPassEntry.SetPlaceHolder("SSH password")
+	d.sshPassEntry.SetPlaceHolder(i18n.T("SSH password"))
 
 	// Create SSH container (initially hidden)
 	sshHeader := container.NewHBox(
-		widget.NewLabel("SSH Configuration"),
+		widget.NewLabel(i18n.T("SSH Configuration")),
 	)
 	sshForm := widget.NewForm(
-		widget.NewFormItem("SSH Port", d.sshPortEntry),
-		widget.NewFormItem("SSH Username", d.sshUserEntry),
-		widget.NewFormItem("SSH Password", d.sshPassEntry),
+		widget.NewFormItem(i18n.T("SSH Port"), d.sshPortEntry),
+		widget.NewFormItem(i18n.T("SSH Username"), d.sshUserEntry),
+		widget.NewFormItem(i18n.T("SSH Password"), d.sshPassEntry),
 	)
 	// Add help text
-	sshHelpText := widget.NewLabel("💡 SSH Host uses Database Host")
+	sshHelpText := widget.NewLabel(i18n.T("💡 SSH Host uses Database Host"))
 	sshHelpText.Importance = widget.LowImportance
 	d.sshContainer = container.NewVBox(widget.NewSeparator(), sshHeader, sshForm, sshHelpText)
 	d.sshContainer.Hide() // Initially hidden
 
 	// Create WinRM configuration fields (only for SQL Server)
-	d.winrmEnabledCheck = widget.NewCheck("Enable WinRM (Windows Remote Management)", func(checked bool) {
+	d.winrmEnabledCheck = widget.NewCheck(i18n.T("Enable WinRM (Windows Remote Management)"), func(checked bool) {
 		// Show/hide WinRM fields based on checkbox
 		if checked {
 			d.winrmContainer.Show()
@@ -588,8 +810,8 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 	})
 	d.winrmPortEntry = widget.NewEntry()
 	d.winrmPortEntry.SetText("5985")
-	d.winrmPortEntry.SetPlaceHolder("Port")
-	d.winrmHTTPSCheck = widget.NewCheck("Use HTTPS", func(checked bool) {
+	d.winrmPortEntry.SetPlaceHolder(i18n.T("Port"))
+	d.winrmHTTPSCheck = widget.NewCheck(i18n.T("Use HTTPS"), func(checked bool) {
 		// Auto-update port based on HTTPS selection
 		if checked {
 			d.winrmPortEntry.SetText("5986")
@@ -598,26 +820,26 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 		}
 	})
 	d.winrmUserEntry = widget.NewEntry()
-	d.winrmUserEntry.SetPlaceHolder("WinRM username (empty = integrated Windows auth)")
+	d.winrmUserEntry.SetPlaceHolder(i18n.T("WinRM username (empty = integrated Windows auth)"))
 	d.winrmPassEntry = widget.NewEntry()
 	d.winrmPassEntry.Password = true
-	d.winrmPassEntry.SetPlaceHolder("WinRM password")
+	d.winrmPassEntry.SetPlaceHolder(i18n.T("WinRM password"))
 
 	// Create WinRM container (initially hidden)
 	winrmHeader := container.NewHBox(
-		widget.NewLabel("WinRM Configuration"),
-		widget.NewButton("❓ 配置帮助", func() {
+		widget.NewLabel(i18n.T("WinRM Configuration")),
+		widget.NewButton(i18n.T("❓ Setup Help"), func() {
 			d.showWinRMHelpDialog()
 		}),
 	)
 	winrmForm := widget.NewForm(
-		widget.NewFormItem("WinRM Port", d.winrmPortEntry),
+		widget.NewFormItem(i18n.T("WinRM Port"), d.winrmPortEntry),
 		widget.NewFormItem("", d.winrmHTTPSCheck),
-		widget.NewFormItem("WinRM Username", d.winrmUserEntry),
-		widget.NewFormItem("WinRM Password", d.winrmPassEntry),
+		widget.NewFormItem(i18n.T("WinRM Username"), d.winrmUserEntry),
+		widget.NewFormItem(i18n.T("WinRM Password"), d.winrmPassEntry),
 	)
 	// Add help text
-	winrmHelpText := widget.NewLabel("💡 WinRM Host uses Database Host")
+	winrmHelpText := widget.NewLabel(i18n.T("💡 WinRM Host uses Database Host"))
 	winrmHelpText.Importance = widget.LowImportance
 	d.winrmContainer = container.NewVBox(widget.NewSeparator(), winrmHeader, winrmForm, winrmHelpText)
 	d.winrmContainer.Hide() // Initially hidden
@@ -626,22 +848,22 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 	updateDBLabel := func(dbType string, isAddMode bool) {
 		switch dbType {
 		case "MySQL":
-			d.dbLabel.SetText("Database")
+			d.dbLabel.SetText(i18n.T("Database"))
 			if isAddMode {
 				d.dbEntry.SetText("")
 			}
 		case "PostgreSQL":
-			d.dbLabel.SetText("Database")
+			d.dbLabel.SetText(i18n.T("Database"))
 			if isAddMode {
 				d.dbEntry.SetText("postgres")
 			}
 		case "Oracle":
-			d.dbLabel.SetText("SID")
+			d.dbLabel.SetText(d.oracleIdentifierLabel())
 			if isAddMode {
 				d.dbEntry.SetText("orcl")
 			}
 		case "SQL Server":
-			d.dbLabel.SetText("Database")
+			d.dbLabel.SetText(i18n.T("Database"))
 			if isAddMode {
 				d.dbEntry.SetText("")
 			}
@@ -666,9 +888,9 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 	}
 
 	// Determine initial label text
-	initialLabelText := "Database"
+	initialLabelText := i18n.T("Database")
 	if displayType == "Oracle" {
-		initialLabelText = "SID"
+		initialLabelText = i18n.T("SID")
 	}
 
 	// Create database type selector (will be populated with callback later)
@@ -694,6 +916,8 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 		}
 
 		d.nameEntry.SetText(d.conn.GetName())
+		d.protectedCheck.SetChecked(d.conn.IsProtected())
+		d.observerCheck.SetChecked(d.conn.IsObserver())
 
 		// Set other fields based on connection type
 		switch c := d.conn.(type) {
@@ -707,6 +931,8 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 			d.dbEntry.SetText(c.Database)
 			d.userEntry.SetText(c.Username)
 			d.passEntry.SetText(c.Password)
+			d.loadMySQLSSL(c)
+			d.replicasEntry.SetText(strings.Join(c.Replicas, ", "))
 			// Store SSH config for loading after UI is fully set up
 			if c.SSH != nil {
 				loadedSSHConfig = c.SSH
@@ -721,6 +947,8 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 			d.dbEntry.SetText(c.Database)
 			d.userEntry.SetText(c.Username)
 			d.passEntry.SetText(c.Password)
+			d.pgOptionsEntry.SetText(formatOptions(c.Options))
+			d.replicasEntry.SetText(strings.Join(c.Replicas, ", "))
 			// Store SSH config for loading after UI is fully set up
 			if c.SSH != nil {
 				loadedSSHConfig = c.SSH
@@ -732,7 +960,7 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 			} else {
 				d.portEntry.SetText("1521")
 			}
-			d.dbEntry.SetText(c.SID)
+			d.loadOracleOptions(c)
 			d.userEntry.SetText(c.Username)
 			d.passEntry.SetText(c.Password)
 			// Store SSH config for loading after UI is fully set up
@@ -746,6 +974,7 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 			d.userEntry.SetText(c.Username)
 			d.passEntry.SetText(c.Password)
 			d.trustServerCertCheck.SetChecked(c.TrustServerCertificate)
+			d.loadSQLServerOptions(c)
 			// Store WinRM config for loading after UI is fully set up
 			if c.WinRM != nil {
 				loadedWinRMConfig = c.WinRM
@@ -774,23 +1003,43 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 		}
 	}
 
+	// Live preview of the connection string
+	d.dsnPreview = widget.NewLabel("")
+	d.dsnPreview.Wrapping = fyne.TextWrapBreak
+	d.dsnWarning = widget.NewLabel("")
+	d.dsnWarning.Wrapping = fyne.TextWrapWord
+	d.dsnWarning.Importance = widget.WarningImportance
+	for _, entry := range []*widget.Entry{d.hostEntry, d.portEntry, d.dbEntry, d.userEntry, d.passEntry, d.pgOptionsEntry,
+		d.mysqlSSLCertEntry, d.mysqlSSLCAEntry} {
+		entry.OnChanged = func(string) { d.updateDSNPreview() }
+	}
+	d.updateDSNPreview()
+
 	// Determine dialog title
-	title := "Add Connection"
+	title := i18n.T("Add Connection")
 	if d.isEditMode {
-		title = "Edit Connection"
+		title = i18n.T("Edit Connection")
 	}
 
 	// Create form items with dynamic Database/SID label
 	formItems := []*widget.FormItem{
-		widget.NewFormItem("Database Type", d.dbTypeSelect),
-		widget.NewFormItem("Name", d.nameEntry),
-		widget.NewFormItem("Host", d.hostEntry),
-		widget.NewFormItem("Port", d.portEntry),
+		widget.NewFormItem(i18n.T("Database Type"), d.dbTypeSelect),
+		widget.NewFormItem(i18n.T("Name"), d.nameEntry),
+		widget.NewFormItem(i18n.T("Host"), d.hostEntry),
+		widget.NewFormItem(i18n.T("Port"), d.portEntry),
 		widget.NewFormItem(initialLabelText, d.dbEntry),
-		widget.NewFormItem("Username", d.userEntry),
-		widget.NewFormItem("Password", d.passEntry),
+		widget.NewFormItem(i18n.T("Username"), d.userEntry),
+		widget.NewFormItem(i18n.T("Password"), d.passEntry),
 	}
 
+	// Flags and connection string, below the options of the database type so
+	// that Tab moves through the connection settings first
+	flagsForm := widget.NewForm(
+		widget.NewFormItem("", d.protectedCheck),
+		widget.NewFormItem("", d.observerCheck),
+		widget.NewFormItem(i18n.T("Connection String"), container.NewVBox(d.dsnPreview, d.dsnWarning)),
+	)
+
 	// Store reference to the Database/SID FormItem so we can update its label
 	dbFormItem := formItems[4] // Index 4 is the Database/SID field
 
@@ -818,9 +1067,9 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 		// Update FormItem label text
 		switch s {
 		case "MySQL", "PostgreSQL", "SQL Server":
-			dbFormItem.Text = "Database"
+			dbFormItem.Text = i18n.T("Database")
 		case "Oracle":
-			dbFormItem.Text = "SID"
+			dbFormItem.Text = d.oracleIdentifierLabel()
 		}
 
 		// Show/hide SSH configuration based on database type
@@ -844,25 +1093,82 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 			d.winrmContainer.Hide()
 		}
 
+		// Additional options are only supported for PostgreSQL
+		if s == "PostgreSQL" {
+			d.pgOptionsContainer.Show()
+		} else {
+			d.pgOptionsContainer.Hide()
+		}
+		if s == "MySQL" {
+			d.mysqlSSLContainer.Show()
+		} else {
+			d.mysqlSSLContainer.Hide()
+		}
+		// Replicas are monitored for MySQL and PostgreSQL only
+		if s == "MySQL" || s == "PostgreSQL" {
+			d.replicasContainer.Show()
+		} else {
+			d.replicasContainer.Hide()
+		}
+		if s == "SQL Server" {
+			d.sqlServerContainer.Show()
+		} else {
+			d.sqlServerContainer.Hide()
+		}
+		if s == "Oracle" {
+			d.oracleContainer.Show()
+		} else {
+			d.oracleContainer.Hide()
+		}
+
+		d.updateDSNPreview()
 		form.Refresh() // Refresh the form to show updated label
 	}
+	if d.dbTypeSelect.Selected != "PostgreSQL" {
+		d.pgOptionsContainer.Hide()
+	}
+	if d.dbTypeSelect.Selected != "MySQL" {
+		d.mysqlSSLContainer.Hide()
+	}
+	if d.dbTypeSelect.Selected != "MySQL" && d.dbTypeSelect.Selected != "PostgreSQL" {
+		d.replicasContainer.Hide()
+	}
+	if d.dbTypeSelect.Selected != "SQL Server" {
+		d.sqlServerContainer.Hide()
+	}
+	if d.dbTypeSelect.Selected != "Oracle" {
+		d.oracleContainer.Hide()
+	}
+
+	// The Oracle identifier select relabels the SID/Service Name field
+	d.oracleIdentSelect.OnChanged = func(string) {
+		if d.dbTypeSelect.Selected == "Oracle" {
+			dbFormItem.Text = d.oracleIdentifierLabel()
+			d.dbLabel.SetText(dbFormItem.Text)
+			form.Refresh()
+		}
+		d.updateDSNPreview()
+	}
+	if displayType == "Oracle" {
+		dbFormItem.Text = d.oracleIdentifierLabel() // Service name of a loaded connection
+	}
 
 	// Create buttons first (before dialog)
 	// When SSH is enabled, show two test buttons: "Test SSH" and "Test Database"
 	// When SSH is disabled, show only "Test" button
-	btnTestSSH = widget.NewButton("Test SSH", func() {
+	btnTestSSH = widget.NewButton(i18n.T("Test SSH"), func() {
 		slog.Info("Connections: Dialog Test SSH button clicked", "name", d.nameEntry.Text)
 		d.onTestSSHConnection()
 	})
 	btnTestSSH.Importance = widget.MediumImportance
 
-	btnTestWinRM = widget.NewButton("Test WinRM", func() {
+	btnTestWinRM = widget.NewButton(i18n.T("Test WinRM"), func() {
 		slog.Info("Connections: Dialog Test WinRM button clicked", "name", d.nameEntry.Text)
 		d.onTestWinRMConnection()
 	})
 	btnTestWinRM.Importance = widget.MediumImportance
 
-	btnTestDatabase := widget.NewButton("Test Database", func() {
+	btnTestDatabase := widget.NewButton(i18n.T("Test Database"), func() {
 		slog.Info("Connections: Dialog Test Database button clicked", "name", d.nameEntry.Text, "type", d.dbTypeSelect.Selected)
 		d.onTestInDialog()
 	})
@@ -918,15 +1224,17 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 		updateTestButtons()
 	}
 
-	btnSave := widget.NewButton("Save", func() {
+	btnSave := widget.NewButton(i18n.T("Save"), func() {
 		slog.Info("Connections: Dialog Save button clicked", "name", d.nameEntry.Text, "type", d.dbTypeSelect.Selected, "mode", map[bool]string{true: "edit", false: "add"}[d.isEditMode])
-		success := d.onSave(win)
-		if success {
-			d.dialog.Hide() // Only close dialog if save was successful
-		}
+		d.confirmDSNWarnings(func() {
+			success := d.onSave(win)
+			if success {
+				d.dialog.Hide() // Only close dialog if save was successful
+			}
+		})
 	})
 	btnSave.Importance = widget.HighImportance
-	btnCancel := widget.NewButton("Cancel", func() {
+	btnCancel := widget.NewButton(i18n.T("Cancel"), func() {
 		slog.Info("Connections: Dialog Cancel button clicked", "name", d.nameEntry.Text, "type", d.dbTypeSelect.Selected)
 		// Will be set to close dialog after dialog is created
 	})
@@ -943,24 +1251,31 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 		d.winrmEnabledCheck,
 	)
 
-	// Create dialog content with buttons at bottom
+	// Create dialog content with buttons at bottom, in the order Tab moves
+	// through the fields
 	// Layout:
-	// 1. Form (database fields)
-	// 2. Test button(s)
+	// 1. Form (database fields) and the options of the database type
+	// 2. Flags and connection string
 	// 3. SSH Tunnel checkbox and container (for MySQL, PostgreSQL, Oracle)
 	// 4. WinRM checkbox and container (for SQL Server)
-	// 5. Separator
+	// 5. Test button(s)
 	// 6. Save/Cancel buttons
 	content := container.NewVBox(
 		form,
-		widget.NewSeparator(),
-		testButtonsContainer,
+		d.pgOptionsContainer,
+		d.mysqlSSLContainer,
+		d.replicasContainer,
+		d.sqlServerContainer,
+		d.oracleContainer,
+		flagsForm,
 		widget.NewSeparator(),
 		sshCheckboxRow,
 		d.sshContainer,
 		winrmCheckboxRow,
 		d.winrmContainer,
 		widget.NewSeparator(),
+		testButtonsContainer,
+		widget.NewSeparator(),
 		buttonContainer,
 	)
 
@@ -1053,6 +1368,7 @@ func showConnectionDialog(connUC *usecase.ConnectionUseCase, win fyne.Window, co
 	}
 
 	dlg.Show()
+	win.Canvas().Focus(d.nameEntry) // Start typing at the name, Tab moves on from there
 }
 
 // onSave handles the save button click.
@@ -1132,11 +1448,12 @@ func (d *connectionDialog) onSave(win fyne.Window) bool {
 		winrmPass := d.winrmPassEntry.Text
 		useHTTPS := d.winrmHTTPSCheck.Checked
 
-		// WinRM Host uses the same host as database
+		// WinRM Host uses the same host as database, without a named instance
 		if host != "" {
+			winrmHost, _, _ := strings.Cut(host, `\`)
 			winrmConfig = &connection.WinRMConfig{
 				Enabled:  true,
-				Host:     host, // Use database host
+				Host:     winrmHost, // Use database host
 				Port:     winrmPort,
 				Username: winrmUser,
 				Password: winrmPass,
@@ -1157,7 +1474,7 @@ func (d *connectionDialog) onSave(win fyne.Window) bool {
 		connWithPassword, err := d.connUC.GetConnectionByID(ctx, d.conn.GetID())
 		if err != nil {
 			slog.Error("Connections: Failed to load password from keyring", "error", err)
-			dialog.ShowError(fmt.Errorf("failed to load password: %w", err), win)
+			dialog.ShowError(fmt.Errorf(i18n.T("failed to load password: %w"), err), win)
 			return false
 		}
 		switch c := connWithPassword.(type) {
@@ -1195,10 +1512,19 @@ func (d *connectionDialog) onSave(win fyne.Window) bool {
 	var createdAt time.Time
 	if d.isEditMode && d.conn != nil {
 		id = d.conn.GetID()
-		// Get original creation time from the connection
-		// We need to extract BaseConnection, but each type embeds it
-		// For simplicity, we'll use current time in edit mode too
-		createdAt = now
+		// Keep the original creation time from the connection
+		switch c := d.conn.(type) {
+		case *connection.MySQLConnection:
+			createdAt = c.CreatedAt
+		case *connection.PostgreSQLConnection:
+			createdAt = c.CreatedAt
+		case *connection.OracleConnection:
+			createdAt = c.CreatedAt
+		case *connection.SQLServerConnection:
+			createdAt = c.CreatedAt
+		default:
+			createdAt = now
+		}
 	} else {
 		id = fmt.Sprintf("conn-%d", now.UnixNano())
 		createdAt = now
@@ -1206,14 +1532,14 @@ func (d *connectionDialog) onSave(win fyne.Window) bool {
 
 	if name == "" {
 		slog.Warn("Connections: Save validation failed", "error", "name required")
-		dialog.ShowError(fmt.Errorf("name required"), win)
+		dialog.ShowError(errors.New(i18n.T("name required")), win)
 		return false
 	}
-
-	// Delete old connection in edit mode before creating new one
-	if d.isEditMode && d.conn != nil {
-		if err := d.connUC.DeleteConnection(ctx, d.conn.GetID()); err != nil {
-			dialog.ShowError(fmt.Errorf("failed to update connection: %w", err), win)
+	var pgOptions map[string]string
+	if dbType == "PostgreSQL" {
+		if pgOptions, err = parseOptions(d.pgOptionsEntry.Text); err != nil {
+			slog.Warn("Connections: Save validation failed", "name", name, "error", err)
+			dialog.ShowError(err, win)
 			return false
 		}
 	}
@@ -1222,7 +1548,7 @@ func (d *connectionDialog) onSave(win fyne.Window) bool {
 	var conn connection.Connection
 	switch dbType {
 	case "MySQL":
-		conn = &connection.MySQLConnection{
+		mysqlConn := &connection.MySQLConnection{
 			BaseConnection: connection.BaseConnection{
 				ID:        id,
 				Name:      name,
@@ -1234,9 +1560,11 @@ func (d *connectionDialog) onSave(win fyne.Window) bool {
 			Database: database,
 			Username: username,
 			Password: password,
-			SSLMode:  "disable", // Default value
+			Replicas: connection.ParseReplicas(d.replicasEntry.Text),
 			SSH:      sshConfig,
 		}
+		d.applyMySQLSSL(mysqlConn)
+		conn = mysqlConn
 	case "PostgreSQL":
 		conn = &connection.PostgreSQLConnection{
 			BaseConnection: connection.BaseConnection{
@@ -1251,10 +1579,12 @@ func (d *connectionDialog) onSave(win fyne.Window) bool {
 			Username: username,
 			Password: password,
 			SSLMode:  "disable", // Default value
+			Options:  pgOptions,
+			Replicas: connection.ParseReplicas(d.replicasEntry.Text),
 			SSH:      sshConfig,
 		}
 	case "Oracle":
-		conn = &connection.OracleConnection{
+		oraConn := &connection.OracleConnection{
 			BaseConnection: connection.BaseConnection{
 				ID:        id,
 				Name:      name,
@@ -1263,13 +1593,14 @@ func (d *connectionDialog) onSave(win fyne.Window) bool {
 			},
 			Host:     host,
 			Port:     port,
-			SID:      database,
 			Username: username,
 			Password: password,
 			SSH:      sshConfig,
 		}
+		d.applyOracleOptions(oraConn, database)
+		conn = oraConn
 	case "SQL Server":
-		conn = &connection.SQLServerConnection{
+		sqlConn := &connection.SQLServerConnection{
 			BaseConnection: connection.BaseConnection{
 				ID:        id,
 				Name:      name,
@@ -1284,20 +1615,29 @@ func (d *connectionDialog) onSave(win fyne.Window) bool {
 			TrustServerCertificate: trustServerCert,
 			WinRM:                  winrmConfig,
 		}
+		d.applySQLServerOptions(sqlConn)
+		conn = sqlConn
 	default:
-		dialog.ShowError(fmt.Errorf("unsupported type: %s", dbType), win)
+		dialog.ShowError(fmt.Errorf(i18n.T("unsupported type: %s"), dbType), win)
 		return false
 	}
+	conn.SetProtected(d.protectedCheck.Checked)
+	conn.SetObserver(d.observerCheck.Checked)
 	// Validate
 	if err := conn.Validate(); err != nil {
 		slog.Warn("Connections: Save validation failed", "name", name, "error", err)
-		dialog.ShowError(fmt.Errorf("validation: %w", err), win)
+		dialog.ShowError(fmt.Errorf(i18n.T("validation: %w"), err), win)
 		return false
 	}
-	// Save
-	if err := d.connUC.CreateConnection(ctx, conn); err != nil {
+	// Save: update in place in edit mode, create otherwise
+	if d.isEditMode && d.conn != nil {
+		err = d.connUC.UpdateConnection(ctx, conn)
+	} else {
+		err = d.connUC.CreateConnection(ctx, conn)
+	}
+	if err != nil {
 		slog.Error("Connections: Failed to save", "name", name, "error", err)
-		dialog.ShowError(fmt.Errorf("save: %w", err), win)
+		dialog.ShowError(fmt.Errorf(i18n.T("save: %w"), err), win)
 		return false
 	}
 
@@ -1315,7 +1655,7 @@ func (d *connectionDialog) onSave(win fyne.Window) bool {
 		slog.Info("Connections: Saved as default config", "db_type", dbType, "connection", name)
 	}
 
-	dialog.ShowInformation("Success", "Connection saved", win)
+	dialog.ShowInformation(i18n.T("Success"), i18n.T("Connection saved"), win)
 
 	if d.onSuccess != nil {
 		d.onSuccess()
@@ -1331,7 +1671,7 @@ func (d *connectionDialog) onTestInDialog() {
 
 	if name == "" {
 		slog.Warn("Connections: Dialog test validation failed", "error", "name required")
-		dialog.ShowError(fmt.Errorf("name required"), d.win)
+		dialog.ShowError(errors.New(i18n.T("name required")), d.win)
 		return
 	}
 
@@ -1347,7 +1687,7 @@ func (d *connectionDialog) onTestInDialog() {
 	// SSH tunnel is tested separately by Test SSH button
 
 	if host == "" {
-		dialog.ShowError(fmt.Errorf("host required"), d.win)
+		dialog.ShowError(errors.New(i18n.T("host required")), d.win)
 		return
 	}
 	// Validate database/SID based on database type
@@ -1355,21 +1695,30 @@ func (d *connectionDialog) onTestInDialog() {
 	// PostgreSQL: Database is required
 	// Oracle: SID is required
 	if database == "" && (dbType == "PostgreSQL" || dbType == "Oracle") {
-		fieldName := "Database"
+		fieldName := i18n.T("Database")
 		if dbType == "Oracle" {
-			fieldName = "SID"
+			fieldName = d.oracleIdentifierLabel()
 		}
-		dialog.ShowError(fmt.Errorf("%s is required", fieldName), d.win)
+		dialog.ShowError(fmt.Errorf(i18n.T("%s is required"), fieldName), d.win)
 		return
 	}
-	if username == "" {
-		dialog.ShowError(fmt.Errorf("username required"), d.win)
+	// Windows authentication may log in with the WinRM account instead
+	if username == "" && !d.usesWinRMLogin() {
+		dialog.ShowError(errors.New(i18n.T("username required")), d.win)
 		return
 	}
-	if password == "" {
-		dialog.ShowError(fmt.Errorf("password required"), d.win)
+	if password == "" && !d.usesWinRMLogin() {
+		dialog.ShowError(errors.New(i18n.T("password required")), d.win)
 		return
 	}
+	var pgOptions map[string]string
+	if dbType == "PostgreSQL" {
+		var err error
+		if pgOptions, err = parseOptions(d.pgOptionsEntry.Text); err != nil {
+			dialog.ShowError(err, d.win)
+			return
+		}
+	}
 
 	// Test connection in background - always use form values (both ADD and EDIT modes)
 	go func() {
@@ -1417,7 +1766,7 @@ func (d *connectionDialog) onTestInDialog() {
 		now := time.Now()
 		switch dbType {
 		case "MySQL":
-			conn = &connection.MySQLConnection{
+			mysqlConn := &connection.MySQLConnection{
 				BaseConnection: connection.BaseConnection{
 					ID:        "temp-test",
 					Name:      name,
@@ -1429,9 +1778,10 @@ func (d *connectionDialog) onTestInDialog() {
 				Database: database,
 				Username: username,
 				Password: password,
-				SSLMode:  "disable", // Default, will be removed later
 				SSH:      nil, // No SSH for Test Database button
 			}
+			d.applyMySQLSSL(mysqlConn)
+			conn = mysqlConn
 		case "PostgreSQL":
 			conn = &connection.PostgreSQLConnection{
 				BaseConnection: connection.BaseConnection{
@@ -1446,10 +1796,11 @@ func (d *connectionDialog) onTestInDialog() {
 				Username: username,
 				Password: password,
 				SSLMode:  "disable", // Default, will be removed later
+				Options:  pgOptions,
 				SSH:      nil, // No SSH for Test Database button
 			}
 		case "Oracle":
-			conn = &connection.OracleConnection{
+			oraConn := &connection.OracleConnection{
 				BaseConnection: connection.BaseConnection{
 					ID:        "temp-test",
 					Name:      name,
@@ -1458,13 +1809,14 @@ func (d *connectionDialog) onTestInDialog() {
 				},
 				Host:     host,
 				Port:     port,
-				SID:      database,
 				Username: username,
 				Password: password,
 				SSH:      nil, // No SSH for Test Database button
 			}
+			d.applyOracleOptions(oraConn, database)
+			conn = oraConn
 		case "SQL Server":
-			conn = &connection.SQLServerConnection{
+			sqlConn := &connection.SQLServerConnection{
 				BaseConnection: connection.BaseConnection{
 					ID:        "temp-test",
 					Name:      name,
@@ -1477,16 +1829,19 @@ func (d *connectionDialog) onTestInDialog() {
 				Username:               username,
 				Password:               password,
 				TrustServerCertificate: trustServerCert,
+				WinRM:                  d.winrmLogin(),
 			}
+			d.applySQLServerOptions(sqlConn)
+			conn = sqlConn
 		default:
-			dialog.ShowError(fmt.Errorf("unsupported type: %s", dbType), d.win)
+			dialog.ShowError(fmt.Errorf(i18n.T("unsupported type: %s"), dbType), d.win)
 			return
 		}
 
 		// Validate
 		if err := conn.Validate(); err != nil {
 			slog.Warn("Connections: Dialog test validation failed", "name", name, "error", err)
-			dialog.ShowError(fmt.Errorf("validation: %w", err), d.win)
+			dialog.ShowError(fmt.Errorf(i18n.T("validation: %w"), err), d.win)
 			return
 		}
 
@@ -1504,14 +1859,14 @@ func (d *connectionDialog) onTestInDialog() {
 				"name", name,
 				"latency_ms", result.LatencyMs,
 				"version", result.DatabaseVersion)
-			msg := fmt.Sprintf("Success! Latency: %dms\nVersion: %s",
+			msg := i18n.Tf("Success! Latency: %dms\nVersion: %s",
 				result.LatencyMs, result.DatabaseVersion)
-			dialog.ShowInformation("Connection Test", msg, d.win)
+			dialog.ShowInformation(i18n.T("Connection Test"), msg, d.win)
 		} else {
 			slog.Warn("Connections: Dialog test failed",
 				"name", name,
 				"error", result.Error)
-			dialog.ShowError(fmt.Errorf("failed: %s", result.Error), d.win)
+			dialog.ShowError(fmt.Errorf(i18n.T("failed: %s"), result.Error), d.win)
 		}
 	}()
 }
@@ -1532,7 +1887,30 @@ type connectionDialog struct {
 	userEntry            *widget.Entry
 	passEntry            *widget.Entry
 	trustServerCertCheck *widget.Check // For SQL Server
+	protectedCheck       *widget.Check // Blocks prepare and cleanup
+	observerCheck        *widget.Check // Blocks every benchmark phase
 	dbTypeSelect         *widget.Select
+	dsnPreview           *widget.Label // Masked connection string of the fields
+	dsnWarning           *widget.Label // Characters the connection string does not escape
+	pgOptionsEntry       *widget.Entry // Additional options, one NAME=VALUE per line (PostgreSQL)
+	pgOptionsContainer   *fyne.Container
+	replicasEntry        *widget.Entry // Replicas of the primary, comma separated (MySQL, PostgreSQL)
+	replicasContainer    *fyne.Container
+	mysqlSSLSelect       *widget.Select // SSL mode; the first option leaves it unset (MySQL)
+	mysqlSSLCAEntry      *widget.Entry
+	mysqlSSLCertEntry    *widget.Entry
+	mysqlSSLKeyEntry     *widget.Entry
+	mysqlSSLContainer    *fyne.Container
+	sqlInstanceEntry     *widget.Entry  // Named instance (SQL Server)
+	sqlEncryptSelect     *widget.Select // Encryption; the first option leaves it unset (SQL Server)
+	sqlAuthSelect        *widget.Select // SQL Server or Windows authentication
+	sqlReadOnlyCheck     *widget.Check  // Read-only application intent (SQL Server)
+	sqlServerContainer   *fyne.Container
+	oracleIdentSelect    *widget.Select // SID or service name (Oracle)
+	oracleTCPSCheck      *widget.Check
+	oracleWalletEntry    *widget.Entry // Auto-login wallet directory (Oracle)
+	oracleTrustCheck     *widget.Check // Skip server certificate verification (Oracle TCPS)
+	oracleContainer      *fyne.Container
 
 	// SSH fields
 	sshEnabledCheck *widget.Check
@@ -1556,7 +1934,7 @@ func (d *connectionDialog) onTestSSHConnection() {
 	// SSH Host uses the database host
 	host := strings.TrimSpace(d.hostEntry.Text)
 	if host == "" {
-		dialog.ShowError(fmt.Errorf("Database host is required (used as SSH host)"), d.win)
+		dialog.ShowError(errors.New(i18n.T("Database host is required (used as SSH host)")), d.win)
 		return
 	}
 	sshPortStr := strings.TrimSpace(d.sshPortEntry.Text)
@@ -1566,11 +1944,11 @@ func (d *connectionDialog) onTestSSHConnection() {
 
 	// Validate SSH fields
 	if sshPortStr == "" || err != nil || sshPort <= 0 || sshPort > 65535 {
-		dialog.ShowError(fmt.Errorf("SSH port must be between 1 and 65535"), d.win)
+		dialog.ShowError(errors.New(i18n.T("SSH port must be between 1 and 65535")), d.win)
 		return
 	}
 	if sshUser == "" {
-		dialog.ShowError(fmt.Errorf("SSH username is required"), d.win)
+		dialog.ShowError(errors.New(i18n.T("SSH username is required")), d.win)
 		return
 	}
 
@@ -1599,7 +1977,7 @@ func (d *connectionDialog) onTestSSHConnection() {
 		tunnel, err := connection.NewSSHTunnel(ctx, sshConfig, "localhost", 22)
 		if err != nil {
 			slog.Error("Connections: SSH test failed", "error", err)
-			dialog.ShowError(fmt.Errorf("SSH connection failed: %w", err), d.win)
+			dialog.ShowError(fmt.Errorf(i18n.T("SSH connection failed: %w"), err), d.win)
 			return
 		}
 		defer tunnel.Close()
@@ -1613,9 +1991,9 @@ func (d *connectionDialog) onTestSSHConnection() {
 			"latency_ms", latency,
 			"local_port", localPort)
 
-		msg := fmt.Sprintf("SSH connection successful!\n\nLatency: %dms\nLocal Port: %d (auto-assigned)\n\nYou can now test the database connection.",
+		msg := i18n.Tf("SSH connection successful!\n\nLatency: %dms\nLocal Port: %d (auto-assigned)\n\nYou can now test the database connection.",
 			latency, localPort)
-		dialog.ShowInformation("SSH Test", msg, d.win)
+		dialog.ShowInformation(i18n.T("SSH Test"), msg, d.win)
 	}()
 }
 
@@ -1625,7 +2003,7 @@ func (d *connectionDialog) onTestWinRMConnection() {
 	// WinRM Host uses the database host
 	host := strings.TrimSpace(d.hostEntry.Text)
 	if host == "" {
-		dialog.ShowError(fmt.Errorf("Database host is required (used as WinRM host)"), d.win)
+		dialog.ShowError(errors.New(i18n.T("Database host is required (used as WinRM host)")), d.win)
 		return
 	}
 	winrmPortStr := strings.TrimSpace(d.winrmPortEntry.Text)
@@ -1636,17 +2014,17 @@ func (d *connectionDialog) onTestWinRMConnection() {
 
 	// Validate WinRM fields
 	if winrmPortStr == "" || err != nil || winrmPort <= 0 || winrmPort > 65535 {
-		dialog.ShowError(fmt.Errorf("WinRM port must be between 1 and 65535"), d.win)
+		dialog.ShowError(errors.New(i18n.T("WinRM port must be between 1 and 65535")), d.win)
 		return
 	}
 
 	// Validate standard ports
 	if useHTTPS && winrmPort != 5986 {
-		dialog.ShowError(fmt.Errorf("HTTPS requires port 5986, got %d", winrmPort), d.win)
+		dialog.ShowError(fmt.Errorf(i18n.T("HTTPS requires port 5986, got %d"), winrmPort), d.win)
 		return
 	}
 	if !useHTTPS && winrmPort != 5985 {
-		dialog.ShowError(fmt.Errorf("HTTP requires port 5985, got %d", winrmPort), d.win)
+		dialog.ShowError(fmt.Errorf(i18n.T("HTTP requires port 5985, got %d"), winrmPort), d.win)
 		return
 	}
 
@@ -1673,7 +2051,7 @@ func (d *connectionDialog) onTestWinRMConnection() {
 		if err != nil {
 			slog.Error("Connections: WinRM test failed", "error", err)
 			// Show error dialog with help button
-			d.showWinRMErrorDialog(fmt.Errorf("WinRM connection failed: %w", err), true)
+			d.showWinRMErrorDialog(fmt.Errorf(i18n.T("WinRM connection failed: %w"), err), true)
 			return
 		}
 		defer client.Close()
@@ -1682,13 +2060,13 @@ func (d *connectionDialog) onTestWinRMConnection() {
 		result, err := client.Test(ctx)
 		if err != nil {
 			slog.Error("Connections: WinRM test error", "error", err)
-			d.showWinRMErrorDialog(fmt.Errorf("WinRM test failed: %w", err), true)
+			d.showWinRMErrorDialog(fmt.Errorf(i18n.T("WinRM test failed: %w"), err), true)
 			return
 		}
 
 		if !result.Success {
 			slog.Error("Connections: WinRM test failed", "error", result.Error)
-			d.showWinRMErrorDialog(fmt.Errorf("WinRM connection failed: %s", result.Error), true)
+			d.showWinRMErrorDialog(fmt.Errorf(i18n.T("WinRM connection failed: %s"), result.Error), true)
 			return
 		}
 
@@ -1697,41 +2075,15 @@ func (d *connectionDialog) onTestWinRMConnection() {
 			"winrm_port", winrmPort,
 			"latency_ms", result.LatencyMs)
 
-		msg := fmt.Sprintf("WinRM connection successful!\n\nLatency: %dms\n\nYou can now test the database connection.",
+		msg := i18n.Tf("WinRM connection successful!\n\nLatency: %dms\n\nYou can now test the database connection.",
 			result.LatencyMs)
-		dialog.ShowInformation("WinRM Test", msg, d.win)
+		dialog.ShowInformation(i18n.T("WinRM Test"), msg, d.win)
 	}()
 }
 
-// showWinRMHelpDialog 显示 WinRM 配置帮助对话框
+// showWinRMHelpDialog 显示 WinRM 配置帮助对话框（文本为 winrm.help 条目）
 func (d *connectionDialog) showWinRMHelpDialog() {
-	helpText := `WinRM 配置（数据库宿主机开启远程采集用）
-适用：Windows Server 2012/2016/2019/2022
-
-【方案1：HTTP（最简单，测试/内网）】
-宿主机（管理员 PowerShell）执行：
-  Enable-PSRemoting -Force
-验证：
-  Test-WSMan localhost
-说明：端口 5985；多数情况下会自动放行防火墙。
-
-【方案2：HTTPS（更安全，生产）】
-宿主机（管理员 PowerShell）执行：
-  Enable-PSRemoting -Force
-  $cert = New-SelfSignedCertificate -CertStoreLocation Cert:\LocalMachine\My -DnsName $env:COMPUTERNAME
-  New-Item -Path WSMan:\localhost\Listener -Transport HTTPS -Address * -CertificateThumbprint $cert.Thumbprint -Port 5986 -Force
-验证：
-  Test-WSMan localhost -UseSSL
-
-【可选：工作组/非域时，客户端设置 TrustedHosts（在压测机上执行，不是宿主机）】
-  Set-Item WSMan:\localhost\Client\TrustedHosts -Value "宿主机IP或主机名" -Force
-
-【查看监听】
-  winrm enumerate winrm/config/listener
-
-【关闭 WinRM】
-  Disable-PSRemoting -Force
-`
+	helpText := i18n.T("winrm.help")
 
 	// 创建可选择和复制的文本框（自动换行，支持 Ctrl+A）
 	helpEntry := widget.NewMultiLineEntry()
@@ -1739,26 +2091,26 @@ func (d *connectionDialog) showWinRMHelpDialog() {
 	helpEntry.Wrapping = fyne.TextWrapWord // 自动按单词换行
 
 	// 创建对话框（不需要滚动容器，Entry 自带滚动）
-	dlg := dialog.NewCustom("WinRM 配置帮助", "关闭", helpEntry, d.win)
+	dlg := dialog.NewCustom(i18n.T("WinRM Setup Help"), i18n.T("Close"), helpEntry, d.win)
 	dlg.Resize(fyne.NewSize(650, 450))
 	dlg.Show()
 }
 
 // showWinRMErrorDialog 显示 WinRM 错误对话框，带查看帮助按钮
 func (d *connectionDialog) showWinRMErrorDialog(err error, showHelp bool) {
-	errorMsg := fmt.Sprintf("WinRM 连接失败：%v\n\n可能的原因：\n1. WinRM 服务未在 Windows Server 上启用\n2. 防火墙阻止了连接\n3. 端口配置错误（HTTP: 5985, HTTPS: 5986）\n4. 用户名或密码错误", err)
+	errorMsg := i18n.Tf("WinRM connection failed: %v\n\nPossible causes:\n1. WinRM is not enabled on the Windows Server\n2. A firewall blocks the connection\n3. Wrong port (HTTP: 5985, HTTPS: 5986)\n4. Wrong username or password", err)
 
 	// 创建错误标签
 	errorLabel := widget.NewLabel(errorMsg)
 	errorLabel.Importance = widget.MediumImportance
 
 	// 创建按钮
-	btnHelp := widget.NewButton("查看配置帮助", func() {
+	btnHelp := widget.NewButton(i18n.T("View Setup Help"), func() {
 		d.showWinRMHelpDialog()
 	})
 	btnHelp.Importance = widget.MediumImportance
 
-	btnOK := widget.NewButton("关闭", func() {
+	btnOK := widget.NewButton(i18n.T("Close"), func() {
 		// Dialog will be closed
 	})
 	btnOK.Importance = widget.HighImportance
@@ -1773,7 +2125,7 @@ func (d *connectionDialog) showWinRMErrorDialog(err error, showHelp bool) {
 	)
 
 	// 创建自定义对话框
-	dlg := dialog.NewCustomWithoutButtons("WinRM 测试失败", content, d.win)
+	dlg := dialog.NewCustomWithoutButtons(i18n.T("WinRM Test Failed"), content, d.win)
 	dlg.Resize(fyne.NewSize(500, 200))
 
 	// 设置关闭按钮动作
@@ -1788,8 +2140,9 @@ func (d *connectionDialog) showWinRMErrorDialog(err error, showHelp bool) {
 // Other Pages - Wrapper Functions
 // =============================================================================
 // NewTemplatePage creates the template management page.
-func NewTemplatePage(win fyne.Window) fyne.CanvasObject {
-	return NewTemplateManagementPage(win)
+// Returns both the page instance, for the quick search, and its canvas object.
+func NewTemplatePage(win fyne.Window) (*TemplateManagementPage, fyne.CanvasObject) {
+	return newTemplateManagementPage(win)
 }
 
 // NewTaskPage creates the task configuration and monitor page (combined).
@@ -1821,6 +2174,6 @@ func NewReportPage(win fyne.Window) fyne.CanvasObject {
 }
 
 // NewSettingsPage creates the settings page.
-func NewSettingsPage(win fyne.Window, connUC *usecase.ConnectionUseCase) fyne.CanvasObject {
-	return NewSettingsConfigurationPage(win, connUC)
+func NewSettingsPage(win fyne.Window, connUC *usecase.ConnectionUseCase, maintenanceUC *usecase.MaintenanceUseCase, settingsUC *usecase.SettingsUseCase, historyUC *usecase.HistoryUseCase, notificationUC *usecase.NotificationUseCase, accessUC *usecase.AccessUseCase, diagnosticsUC *usecase.DiagnosticsUseCase, updateUC *usecase.UpdateUseCase, demoUC *usecase.DemoUseCase, onLanguageChanged func(i18n.Language), onAppearanceChanged func(config.UIConfig), onLoggingChanged func(config.AdvancedConfig), onLock func()) fyne.CanvasObject {
+	return NewSettingsConfigurationPageWithUC(win, connUC, maintenanceUC, settingsUC, historyUC, notificationUC, accessUC, diagnosticsUC, updateUC, demoUC, onLanguageChanged, onAppearanceChanged, onLoggingChanged, onLock)
 }
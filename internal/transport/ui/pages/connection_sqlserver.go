@@ -0,0 +1,88 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// Connection dialog: instance, encryption, application intent and
+// authentication of SQL Server connections.
+package pages
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// createSQLServerFields creates the advanced SQL Server fields, shown only
+// for SQL Server. The trust server certificate check is created before.
+func (d *connectionDialog) createSQLServerFields() {
+	d.sqlInstanceEntry = widget.NewEntry()
+	d.sqlInstanceEntry.SetPlaceHolder(i18n.T("Named instance, e.g. SQLEXPRESS (optional)"))
+	d.sqlInstanceEntry.OnChanged = func(string) { d.updateDSNPreview() }
+	// The first option leaves encryption unset: tests try several settings
+	d.sqlEncryptSelect = widget.NewSelect(append([]string{i18n.T("Auto-detect")}, connection.SQLServerEncryptModes...),
+		func(string) { d.updateDSNPreview() })
+	d.sqlEncryptSelect.SetSelectedIndex(0)
+	d.sqlAuthSelect = widget.NewSelect([]string{i18n.T("SQL Server Authentication"), i18n.T("Windows Authentication")},
+		func(string) { d.updateDSNPreview() })
+	d.sqlAuthSelect.SetSelectedIndex(0)
+	d.sqlReadOnlyCheck = widget.NewCheck(i18n.T("Read-only application intent"), func(bool) { d.updateDSNPreview() })
+	d.trustServerCertCheck.OnChanged = func(bool) { d.updateDSNPreview() }
+	d.trustServerCertCheck.Show() // Shown with the container
+
+	authHint := widget.NewLabel(i18n.T("Windows authentication logs in as DOMAIN\\user, or with the WinRM account if Username is empty."))
+	authHint.Wrapping = fyne.TextWrapWord
+	d.sqlServerContainer = container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem(i18n.T("Instance"), d.sqlInstanceEntry),
+			widget.NewFormItem(i18n.T("Encrypt"), d.sqlEncryptSelect),
+			widget.NewFormItem(i18n.T("Authentication"), d.sqlAuthSelect),
+		),
+		authHint,
+		d.trustServerCertCheck,
+		d.sqlReadOnlyCheck,
+	)
+}
+
+// loadSQLServerOptions fills the advanced SQL Server fields from a saved connection.
+func (d *connectionDialog) loadSQLServerOptions(c *connection.SQLServerConnection) {
+	d.sqlInstanceEntry.SetText(c.Instance)
+	if c.Encrypt != "" {
+		d.sqlEncryptSelect.SetSelected(c.Encrypt)
+	}
+	if c.Authentication == connection.SQLServerAuthWindows {
+		d.sqlAuthSelect.SetSelectedIndex(1)
+	}
+	d.sqlReadOnlyCheck.SetChecked(c.ReadOnlyIntent)
+}
+
+// applySQLServerOptions sets the advanced SQL Server options of the fields on c.
+func (d *connectionDialog) applySQLServerOptions(c *connection.SQLServerConnection) {
+	c.Instance = strings.TrimSpace(d.sqlInstanceEntry.Text)
+	c.Encrypt = ""
+	if d.sqlEncryptSelect.SelectedIndex() > 0 {
+		c.Encrypt = d.sqlEncryptSelect.Selected
+	}
+	c.Authentication = ""
+	if d.sqlAuthSelect.SelectedIndex() == 1 {
+		c.Authentication = connection.SQLServerAuthWindows
+	}
+	c.ReadOnlyIntent = d.sqlReadOnlyCheck.Checked
+}
+
+// usesWinRMLogin reports whether the SQL Server connection of the fields logs
+// in with the WinRM account: Windows authentication without a username.
+func (d *connectionDialog) usesWinRMLogin() bool {
+	return d.dbTypeSelect.Selected == "SQL Server" && d.sqlAuthSelect.SelectedIndex() == 1 &&
+		strings.TrimSpace(d.userEntry.Text) == "" && d.winrmEnabledCheck.Checked
+}
+
+// winrmLogin returns the WinRM account of the fields for connections that
+// log in with it, nil otherwise.
+func (d *connectionDialog) winrmLogin() *connection.WinRMConfig {
+	if !d.usesWinRMLogin() {
+		return nil
+	}
+	return &connection.WinRMConfig{Username: strings.TrimSpace(d.winrmUserEntry.Text), Password: d.winrmPassEntry.Text}
+}
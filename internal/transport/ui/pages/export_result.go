@@ -0,0 +1,42 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// Export results: where exports were written, and opening them in the file manager.
+package pages
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// showExportResult shows the message of a finished export with a button that
+// opens dir, the directory the export was written to, in the file manager.
+func showExportResult(win fyne.Window, title, message, dir string) {
+	btnOpen := widget.NewButton(i18n.T("📂 Open in File Manager"), func() {
+		openInFileManager(win, dir)
+	})
+	content := container.NewVBox(widget.NewLabel(message), container.NewHBox(btnOpen))
+	dialog.ShowCustom(title, i18n.T("Close"), content, win)
+}
+
+// openInFileManager opens dir in the file manager of the system.
+func openInFileManager(win fyne.Window, dir string) {
+	abs, err := filepath.Abs(dir)
+	if err == nil {
+		path := filepath.ToSlash(abs)
+		if !strings.HasPrefix(path, "/") {
+			path = "/" + path // Windows drive letters, file:///C:/...
+		}
+		err = fyne.CurrentApp().OpenURL(&url.URL{Scheme: "file", Path: path})
+	}
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("failed to open %s: %v"), dir, err), win)
+	}
+}
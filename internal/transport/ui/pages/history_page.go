@@ -4,8 +4,12 @@ package pages
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -14,8 +18,11 @@ import (
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
 	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
 )
 
 // HistoryRecordPage provides the history records GUI.
@@ -28,8 +35,27 @@ type HistoryRecordPage struct {
 	selected     int
 	ctx          context.Context
 	summaryLabel *widget.Label // Need to keep reference to update
+
+	// Filter bar
+	searchEntry  *widget.Entry  // Connection or template name, purpose, ticket or environment contains
+	dbTypeSelect *widget.Select // Database type ("All" = any)
+	threadsEntry *widget.Entry  // Exact thread count
+	fromEntry    *widget.Entry  // Start date (YYYY-MM-DD), inclusive
+	toEntry      *widget.Entry  // End date (YYYY-MM-DD), inclusive
+	tagFilter    *widget.Entry  // Comma separated tags; records must carry all of them
+	validOnly    *widget.Check  // Hide runs that failed their sanity checks
+
+	// Pagination
+	pageIndex  int
+	totalCount int
+	pageLabel  *widget.Label
+	btnPrev    *widget.Button
+	btnNext    *widget.Button
 }
 
+// historyPageSize is the number of records shown per page.
+const historyPageSize = 50
+
 // historyRecordListItem represents a list item for display.
 type historyRecordListItem struct {
 	ID           string
@@ -63,20 +89,28 @@ func NewHistoryRecordPage(win fyne.Window, historyUC *usecase.HistoryUseCase, ex
 		},
 		func() fyne.CanvasObject {
 			// Create label and buttons for each row
-			label := widget.NewLabel("Run Record")
+			label := widget.NewLabel(i18n.T("Run Record"))
 
 			// Details button - blue theme color symbol
-			btnView := widget.NewButton("🔍 Details", nil)
+			btnView := widget.NewButton(i18n.T("🔍 Details"), nil)
 			btnView.Importance = widget.LowImportance
 
 			// Delete button - red danger symbol
-			btnDelete := widget.NewButton("❌ Delete", nil)
+			btnDelete := widget.NewButton(i18n.T("❌ Delete"), nil)
 			btnDelete.Importance = widget.LowImportance
 
 			// Export button - green action symbol
-			btnExport := widget.NewButton("📥 Export", nil)
+			btnExport := widget.NewButton(i18n.T("📥 Export"), nil)
 			btnExport.Importance = widget.LowImportance
 
+			// Annotate button - edit tags and notes
+			btnAnnotate := widget.NewButton(i18n.T("🏷️ Tags"), nil)
+			btnAnnotate.Importance = widget.LowImportance
+
+			// Logs button - view the run log
+			btnLogs := widget.NewButton(i18n.T("📜 Logs"), nil)
+			btnLogs.Importance = widget.LowImportance
+
 			// Create HBox with label (left) and buttons (right)
 			content := container.NewHBox(
 				label,
@@ -84,6 +118,8 @@ func NewHistoryRecordPage(win fyne.Window, historyUC *usecase.HistoryUseCase, ex
 				btnView,
 				btnDelete,
 				btnExport,
+				btnAnnotate,
+				btnLogs,
 			)
 
 			return content
@@ -97,16 +133,30 @@ func NewHistoryRecordPage(win fyne.Window, historyUC *usecase.HistoryUseCase, ex
 			// Get the HBox container
 			if hbox, ok := obj.(*fyne.Container); ok {
 				objects := hbox.Objects
-				if len(objects) >= 5 {
+				if len(objects) >= 7 {
 					// First object is the label
 					if label, ok := objects[0].(*widget.Label); ok {
-						label.SetText(fmt.Sprintf("%s | %s | %s | %d threads | %.2f TPS | %s",
+						text := i18n.Tf("%s | %s | %s | %d threads | %.2f TPS | %s",
 							record.ConnectionName,
 							record.TemplateName,
 							record.DatabaseType,
 							record.Threads,
 							record.TPSCalculated,
-							record.StartTime.Format("2006-01-02 15:04")))
+							record.StartTime.Format("2006-01-02 15:04"))
+						if len(record.Tags) > 0 {
+							text += " | " + strings.Join(record.Tags, ", ")
+						}
+						if outcome := record.Assertions; outcome != nil && outcome.Passed {
+							text = i18n.T("✅ SLA PASS | ") + text
+						} else if outcome != nil {
+							text = i18n.T("❌ SLA FAIL | ") + text
+						}
+						if !record.IsCompleted() {
+							text = strings.ToUpper(record.State) + " | " + text
+						} else if !record.IsValid() {
+							text = i18n.T("⚠ INVALID | ") + text
+						}
+						label.SetText(text)
 					}
 
 					// Update button handlers
@@ -135,29 +185,112 @@ func NewHistoryRecordPage(win fyne.Window, historyUC *usecase.HistoryUseCase, ex
 							page.onExport()
 						}
 					}
+
+					// Sixth object (index 5) is Annotate button
+					if btnAnnotate, ok := objects[5].(*widget.Button); ok {
+						btnAnnotate.OnTapped = func() {
+							page.selected = recordIndex
+							page.onAnnotate()
+						}
+					}
+
+					// Seventh object (index 6) is Logs button
+					if btnLogs, ok := objects[6].(*widget.Button); ok {
+						btnLogs.OnTapped = func() {
+							page.selected = recordIndex
+							page.onViewLogs()
+						}
+					}
 				}
 			}
 		},
 	)
 
 	// Create toolbar - Refresh, Delete All, Export All
-	btnRefresh := widget.NewButton("🔄 Refresh", func() {
+	btnRefresh := widget.NewButton(i18n.T("🔄 Refresh"), func() {
 		page.Refresh()
 	})
-	btnDeleteAll := widget.NewButton("🗑️ Delete All", func() {
+	btnDeleteAll := widget.NewButton(i18n.T("🗑️ Delete All"), func() {
 		page.onDeleteAll()
 	})
-	btnExportAll := widget.NewButton("💾 Export All", func() {
+	btnExportAll := widget.NewButton(i18n.T("💾 Export All"), func() {
 		page.onExportAll()
 	})
 
 	toolbar := container.NewHBox(btnRefresh, btnDeleteAll, btnExportAll)
 
+	// Filter bar - filters run in SQLite and apply to Export All / Delete All
+	page.searchEntry = widget.NewEntry()
+	page.searchEntry.SetPlaceHolder(i18n.T("Name, purpose, ticket or environment"))
+	page.dbTypeSelect = widget.NewSelect([]string{i18n.T("All"), "MySQL", "PostgreSQL", "Oracle", "SQL Server"}, nil)
+	page.dbTypeSelect.SetSelected(i18n.T("All"))
+	page.threadsEntry = widget.NewEntry()
+	page.threadsEntry.SetPlaceHolder(i18n.T("Any"))
+	page.fromEntry = widget.NewEntry()
+	page.fromEntry.SetPlaceHolder("YYYY-MM-DD")
+	page.toEntry = widget.NewEntry()
+	page.toEntry.SetPlaceHolder("YYYY-MM-DD")
+	page.tagFilter = widget.NewEntry()
+	page.tagFilter.SetPlaceHolder("baseline, innodb_buffer_pool=32G")
+	page.validOnly = widget.NewCheck(i18n.T("Valid runs only"), nil)
+
+	applyFilter := func() {
+		page.pageIndex = 0
+		page.Refresh()
+	}
+	for _, entry := range []*widget.Entry{page.searchEntry, page.threadsEntry, page.fromEntry, page.toEntry, page.tagFilter} {
+		entry.OnSubmitted = func(string) { applyFilter() }
+	}
+	page.dbTypeSelect.OnChanged = func(string) { applyFilter() }
+	page.validOnly.OnChanged = func(bool) { applyFilter() }
+
+	btnFilter := widget.NewButton(i18n.T("Apply Filter"), applyFilter)
+	btnClearFilter := widget.NewButton(i18n.T("Clear"), func() {
+		page.searchEntry.SetText("")
+		page.threadsEntry.SetText("")
+		page.fromEntry.SetText("")
+		page.toEntry.SetText("")
+		page.tagFilter.SetText("")
+		page.validOnly.SetChecked(false)
+		page.dbTypeSelect.SetSelected(i18n.T("All")) // triggers applyFilter
+	})
+
+	filterBar := container.NewVBox(
+		container.NewGridWithColumns(6,
+			widget.NewLabel(i18n.T("Search:")), page.searchEntry,
+			widget.NewLabel(i18n.T("Database:")), page.dbTypeSelect,
+			widget.NewLabel(i18n.T("Threads:")), page.threadsEntry,
+		),
+		container.NewGridWithColumns(6,
+			widget.NewLabel(i18n.T("From:")), page.fromEntry,
+			widget.NewLabel(i18n.T("To:")), page.toEntry,
+			widget.NewLabel(i18n.T("Tags:")), page.tagFilter,
+		),
+		container.NewHBox(btnFilter, btnClearFilter, page.validOnly),
+	)
+
+	// Pagination controls
+	page.btnPrev = widget.NewButton(i18n.T("◀ Prev"), func() {
+		if page.pageIndex > 0 {
+			page.pageIndex--
+			page.Refresh()
+		}
+	})
+	page.btnNext = widget.NewButton(i18n.T("Next ▶"), func() {
+		if (page.pageIndex+1)*historyPageSize < page.totalCount {
+			page.pageIndex++
+			page.Refresh()
+		}
+	})
+	page.pageLabel = widget.NewLabel("")
+	pager := container.NewHBox(layout.NewSpacer(), page.btnPrev, page.pageLabel, page.btnNext)
+
 	// Create summary label
-	page.summaryLabel = widget.NewLabel(fmt.Sprintf("Total Runs: %d", len(page.records)))
+	page.summaryLabel = widget.NewLabel(i18n.Tf("Total Runs: %d", len(page.records)))
+	page.updatePager()
 	content := container.NewBorder(
-		container.NewVBox(toolbar, widget.NewSeparator(), page.summaryLabel, widget.NewSeparator()), // top
-		nil,       // bottom
+		container.NewVBox(toolbar, filterBar, widget.NewSeparator(), page.summaryLabel, widget.NewSeparator()), // top
+		pager,     // bottom
 		nil,       // left
 		nil,       // right
 		page.list, // center - will expand to fill available space
@@ -173,26 +306,133 @@ func (p *HistoryRecordPage) loadHistory() {
 		return
 	}
 
-	records, err := p.historyUC.GetAllRecords(p.ctx)
+	opts, err := p.listOptions()
+	if err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+
+	total, err := p.historyUC.CountRecords(p.ctx, opts)
+	if err != nil {
+		slog.Error("History: Failed to count records", "error", err)
+		dialog.ShowError(fmt.Errorf(i18n.T("failed to load history: %v"), err), p.win)
+		return
+	}
+	// Step back if the current page no longer exists (e.g. after deletes)
+	if p.pageIndex > 0 && p.pageIndex*historyPageSize >= total {
+		p.pageIndex = (total - 1) / historyPageSize
+		if p.pageIndex < 0 {
+			p.pageIndex = 0
+		}
+	}
+
+	opts.Limit = historyPageSize
+	opts.Offset = p.pageIndex * historyPageSize
+	records, err := p.historyUC.ListRecords(p.ctx, opts)
 	if err != nil {
 		slog.Error("History: Failed to load records", "error", err)
-		dialog.ShowError(fmt.Errorf("failed to load history: %v", err), p.win)
+		dialog.ShowError(fmt.Errorf(i18n.T("failed to load history: %v"), err), p.win)
 		return
 	}
 
 	p.records = records
+	p.totalCount = total
 	if p.list != nil {
 		p.list.Refresh()
 	}
 
 	// Update summary label
 	if p.summaryLabel != nil {
-		p.summaryLabel.SetText(fmt.Sprintf("Total Runs: %d", len(records)))
+		p.summaryLabel.SetText(i18n.Tf("Total Runs: %d", total))
 	}
+	p.updatePager()
 
 	slog.Info("History: Loaded records", "count", len(records))
 }
 
+// historyDatabaseTypes maps the labels of the Database filter to the
+// database types history records store.
+var historyDatabaseTypes = map[string]connection.DatabaseType{
+	"MySQL":      connection.DatabaseTypeMySQL,
+	"PostgreSQL": connection.DatabaseTypePostgreSQL,
+	"Oracle":     connection.DatabaseTypeOracle,
+	"SQL Server": connection.DatabaseTypeSQLServer,
+}
+
+// listOptions builds repository list options from the filter bar.
+func (p *HistoryRecordPage) listOptions() (*repository.ListOptions, error) {
+	opts := &repository.ListOptions{}
+	if p.searchEntry == nil {
+		return opts, nil // Filter bar not built yet
+	}
+
+	opts.Search = strings.TrimSpace(p.searchEntry.Text)
+	if dbType, ok := historyDatabaseTypes[p.dbTypeSelect.Selected]; ok {
+		opts.DatabaseType = string(dbType)
+	}
+	if text := strings.TrimSpace(p.threadsEntry.Text); text != "" {
+		threads, err := strconv.Atoi(text)
+		if err != nil || threads <= 0 {
+			return nil, fmt.Errorf(i18n.T("invalid thread count: %s"), text)
+		}
+		opts.Threads = threads
+	}
+	if text := strings.TrimSpace(p.fromEntry.Text); text != "" {
+		from, err := time.ParseInLocation("2006-01-02", text, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("invalid From date (use YYYY-MM-DD): %s"), text)
+		}
+		opts.StartTimeAfter = &from
+	}
+	if text := strings.TrimSpace(p.toEntry.Text); text != "" {
+		to, err := time.ParseInLocation("2006-01-02", text, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("invalid To date (use YYYY-MM-DD): %s"), text)
+		}
+		// Inclusive: up to the end of the day
+		to = to.Add(24*time.Hour - time.Second)
+		opts.StartTimeBefore = &to
+	}
+	opts.Tags = history.ParseTags(p.tagFilter.Text)
+	opts.ValidOnly = p.validOnly.Checked
+
+	return opts, nil
+}
+
+// matchingRecords returns all records matching the filter bar, across all pages.
+func (p *HistoryRecordPage) matchingRecords() ([]*history.Record, error) {
+	if p.historyUC == nil {
+		return p.records, nil
+	}
+	opts, err := p.listOptions()
+	if err != nil {
+		return nil, err
+	}
+	return p.historyUC.ListRecords(p.ctx, opts)
+}
+
+// updatePager updates the pagination label and buttons.
+func (p *HistoryRecordPage) updatePager() {
+	if p.pageLabel == nil {
+		return
+	}
+	pages := (p.totalCount + historyPageSize - 1) / historyPageSize
+	if pages == 0 {
+		pages = 1
+	}
+	p.pageLabel.SetText(i18n.Tf("Page %d / %d", p.pageIndex+1, pages))
+	if p.pageIndex > 0 {
+		p.btnPrev.Enable()
+	} else {
+		p.btnPrev.Disable()
+	}
+	if (p.pageIndex+1)*historyPageSize < p.totalCount {
+		p.btnNext.Enable()
+	} else {
+		p.btnNext.Disable()
+	}
+}
+
 // Refresh refreshes the history list and summary.
 func (p *HistoryRecordPage) Refresh() {
 	p.loadHistory()
@@ -230,103 +470,67 @@ func (p *HistoryRecordPage) loadMockHistory() {
 	}
 }
 
-// onViewDetails shows record details.
+// onViewDetails shows the Run Details view of the selected record.
 func (p *HistoryRecordPage) onViewDetails() {
 	if p.selected < 0 || p.selected >= len(p.records) {
-		dialog.ShowError(fmt.Errorf("please select a record"), p.win)
+		dialog.ShowError(errors.New(i18n.T("please select a record")), p.win)
+		return
+	}
+	showRunDetails(p.win, p.historyUC, p.exportUC, p.records[p.selected])
+}
+
+// onAnnotate edits the tags and notes of a record.
+func (p *HistoryRecordPage) onAnnotate() {
+	if p.selected < 0 || p.selected >= len(p.records) {
+		dialog.ShowError(errors.New(i18n.T("please select a record")), p.win)
+		return
+	}
+	if p.historyUC == nil {
+		dialog.ShowError(errors.New(i18n.T("history functionality not available")), p.win)
 		return
 	}
 	record := p.records[p.selected]
 
-	// Calculate per-second rates
-	durationSec := record.Duration.Seconds()
-	qps := 0.0
-	if durationSec > 0 && record.TotalQueries > 0 {
-		qps = float64(record.TotalQueries) / durationSec
-	}
-	ignoredErrorsPerSec := 0.0
-	if durationSec > 0 {
-		ignoredErrorsPerSec = float64(record.IgnoredErrors) / durationSec
-	}
-	reconnectsPerSec := 0.0
-	if durationSec > 0 {
-		reconnectsPerSec = float64(record.Reconnects) / durationSec
-	}
-
-	// Build detailed statistics message in sysbench format
-	details := fmt.Sprintf(
-		"Connection: %s\n"+
-			"Template: %s\n"+
-			"Database Type: %s\n"+
-			"Threads: %d\n"+
-			"Start Time: %s\n"+
-			"Duration: %v\n\n"+
-			"SQL statistics:\n"+
-			"    queries performed:\n"+
-			"        read:                            %d\n"+
-			"        write:                           %d\n"+
-			"        other:                           %d\n"+
-			"        total:                           %d\n"+
-			"    transactions:                        %d  (%.2f per sec.)\n"+
-			"    queries:                             %d (%.2f per sec.)\n"+
-			"    ignored errors:                      %d      (%.2f per sec.)\n"+
-			"    reconnects:                          %d      (%.2f per sec.)\n\n"+
-			"General statistics:\n"+
-			"    total time:                          %.4fs\n"+
-			"    total number of events:              %d\n\n"+
-			"Latency (ms):\n"+
-			"         min:                                    %.2f\n"+
-			"         avg:                                   %.2f\n"+
-			"         max:                                   %.2f\n"+
-			"         95th percentile:                       %.2f\n"+
-			"         99th percentile:                       %.2f\n\n"+
-			"Threads fairness:\n"+
-			"    events (avg/stddev):           %.4f/%.2f\n"+
-			"    execution time (avg/stddev):   %.4f/%.2f",
-		record.ConnectionName,
-		record.TemplateName,
-		record.DatabaseType,
-		record.Threads,
-		record.StartTime.Format("2006-01-02 15:04:05"),
-		record.Duration,
-		record.ReadQueries,
-		record.WriteQueries,
-		record.OtherQueries,
-		record.TotalQueries,
-		record.TotalTransactions,
-		record.TPSCalculated,
-		record.TotalQueries,
-		qps,
-		record.IgnoredErrors,
-		ignoredErrorsPerSec,
-		record.Reconnects,
-		reconnectsPerSec,
-		record.TotalTime,
-		record.TotalEvents,
-		record.LatencyMin,
-		record.LatencyAvg,
-		record.LatencyMax,
-		record.LatencyP95,
-		record.LatencyP99,
-		record.EventsAvg,
-		record.EventsStddev,
-		record.ExecTimeAvg,
-		record.ExecTimeStddev,
-	)
+	tagsEntry := widget.NewEntry()
+	tagsEntry.SetPlaceHolder("baseline, innodb_buffer_pool=32G")
+	tagsEntry.SetText(strings.Join(record.Tags, ", "))
+
+	notesEntry := widget.NewMultiLineEntry()
+	notesEntry.SetPlaceHolder(i18n.T("Free-text notes about this run"))
+	notesEntry.SetText(record.Notes)
+	notesEntry.SetMinRowsVisible(5)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(i18n.T("Tags"), tagsEntry),
+		widget.NewFormItem(i18n.T("Notes"), notesEntry),
+	}
 
-	dialog.ShowInformation("Run Details", details, p.win)
+	d := dialog.NewForm(i18n.T("Edit Tags & Notes"), i18n.T("Save"), i18n.T("Cancel"), items, func(save bool) {
+		if !save {
+			return
+		}
+		tags := history.ParseTags(tagsEntry.Text)
+		if err := p.historyUC.UpdateAnnotations(p.ctx, record.ID, tags, notesEntry.Text); err != nil {
+			slog.Error("History: Failed to update annotations", "id", record.ID, "error", err)
+			dialog.ShowError(fmt.Errorf(i18n.T("failed to save tags: %v"), err), p.win)
+			return
+		}
+		p.Refresh()
+	}, p.win)
+	d.Resize(fyne.NewSize(500, 300))
+	d.Show()
 }
 
 // onDelete deletes a record.
 func (p *HistoryRecordPage) onDelete() {
 	if p.selected < 0 || p.selected >= len(p.records) {
-		dialog.ShowError(fmt.Errorf("please select a record"), p.win)
+		dialog.ShowError(errors.New(i18n.T("please select a record")), p.win)
 		return
 	}
 	record := p.records[p.selected]
 	dialog.ShowConfirm(
-		"Delete Record",
-		fmt.Sprintf("Delete run '%s' from %s?", record.TemplateName, record.StartTime.Format("2006-01-02 15:04")),
+		i18n.T("Delete Record"),
+		i18n.Tf("Delete run '%s' from %s?", record.TemplateName, record.StartTime.Format("2006-01-02 15:04")),
 		func(confirmed bool) {
 			if !confirmed {
 				return
@@ -335,7 +539,7 @@ func (p *HistoryRecordPage) onDelete() {
 			if p.historyUC != nil {
 				if err := p.historyUC.DeleteRecord(p.ctx, record.ID); err != nil {
 					slog.Error("History: Failed to delete record", "id", record.ID, "error", err)
-					dialog.ShowError(fmt.Errorf("failed to delete: %v", err), p.win)
+					dialog.ShowError(fmt.Errorf(i18n.T("failed to delete: %v"), err), p.win)
 					return
 				}
 			}
@@ -343,7 +547,7 @@ func (p *HistoryRecordPage) onDelete() {
 			p.records = append(p.records[:p.selected], p.records[p.selected+1:]...)
 			p.selected = -1
 			p.list.Refresh()
-			dialog.ShowInformation("Deleted", "Record deleted successfully", p.win)
+			dialog.ShowInformation(i18n.T("Deleted"), i18n.T("Record deleted successfully"), p.win)
 		},
 		p.win,
 	)
@@ -352,12 +556,12 @@ func (p *HistoryRecordPage) onDelete() {
 // onExport exports results.
 func (p *HistoryRecordPage) onExport() {
 	if p.selected < 0 || p.selected >= len(p.records) {
-		dialog.ShowError(fmt.Errorf("please select a record"), p.win)
+		dialog.ShowError(errors.New(i18n.T("please select a record")), p.win)
 		return
 	}
 
 	if p.exportUC == nil {
-		dialog.ShowError(fmt.Errorf("export functionality not available"), p.win)
+		dialog.ShowError(errors.New(i18n.T("export functionality not available")), p.win)
 		return
 	}
 
@@ -368,14 +572,14 @@ func (p *HistoryRecordPage) onExport() {
 	formatSelect.SetSelected("TXT") // Default to TXT
 
 	form := container.NewVBox(
-		widget.NewLabel(fmt.Sprintf("Export selected record: %s", record.TemplateName)),
-		widget.NewLabel(fmt.Sprintf("Run at: %s", record.StartTime.Format("2006-01-02 15:04"))),
+		widget.NewLabel(i18n.Tf("Export selected record: %s", record.TemplateName)),
+		widget.NewLabel(i18n.Tf("Run at: %s", record.StartTime.Format("2006-01-02 15:04"))),
 		widget.NewSeparator(),
-		widget.NewLabel("Select export format:"),
+		widget.NewLabel(i18n.T("Select export format:")),
 		formatSelect,
 	)
 
-	dialog.ShowCustomConfirm("Export One Record", "Export", "Cancel", form, func(export bool) {
+	dialog.ShowCustomConfirm(i18n.T("Export One Record"), i18n.T("Export"), i18n.T("Cancel"), form, func(export bool) {
 		if !export {
 			return
 		}
@@ -393,17 +597,17 @@ func (p *HistoryRecordPage) onExport() {
 
 		// Export immediately (in goroutine to avoid blocking UI)
 		go func() {
-			filepath, err := p.exportUC.ExportRecord(p.ctx, record, format)
+			path, err := p.exportUC.ExportRecord(p.ctx, record, format)
 			if err != nil {
 				slog.Error("History: Failed to export record", "id", record.ID, "error", err)
-				dialog.ShowError(fmt.Errorf("export failed: %v", err), p.win)
+				dialog.ShowError(fmt.Errorf(i18n.T("export failed: %v"), err), p.win)
 				return
 			}
 
-			slog.Info("History: Exported record", "id", record.ID, "format", format, "filepath", filepath)
-			dialog.ShowInformation("Export Successful",
-				fmt.Sprintf("Record exported to:\n%s\n\nFormat: %s", filepath, format),
-				p.win)
+			slog.Info("History: Exported record", "id", record.ID, "format", format, "filepath", path)
+			showExportResult(p.win, i18n.T("Export Successful"),
+				i18n.Tf("Record exported to:\n%s\n\nFormat: %s", path, format),
+				filepath.Dir(path))
 		}()
 	}, p.win)
 }
@@ -411,28 +615,33 @@ func (p *HistoryRecordPage) onExport() {
 // onExportAll exports all history records.
 func (p *HistoryRecordPage) onExportAll() {
 	if p.exportUC == nil {
-		dialog.ShowError(fmt.Errorf("export functionality not available"), p.win)
+		dialog.ShowError(errors.New(i18n.T("export functionality not available")), p.win)
 		return
 	}
 
-	if len(p.records) == 0 {
-		dialog.ShowError(fmt.Errorf("no records to export"), p.win)
+	records, err := p.matchingRecords()
+	if err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+	if len(records) == 0 {
+		dialog.ShowError(errors.New(i18n.T("no records to export")), p.win)
 		return
 	}
 
 	// Create format selection dialog
-	formatSelect := widget.NewRadioGroup([]string{"TXT", "Markdown"}, func(selected string) {})
+	formatSelect := widget.NewRadioGroup([]string{"TXT", "Markdown", "CSV"}, func(selected string) {})
 	formatSelect.SetSelected("TXT") // Default to TXT
 
 	form := container.NewVBox(
-		widget.NewLabel(fmt.Sprintf("Export ALL history records (%d records)", len(p.records))),
-		widget.NewLabel("All records will be exported to the exports directory."),
+		widget.NewLabel(i18n.Tf("Export ALL matching history records (%d records)", len(records))),
+		widget.NewLabel(i18n.Tf("All records will be exported to:\n%s", p.exportUC.ExportDir(p.ctx))),
 		widget.NewSeparator(),
-		widget.NewLabel("Select export format:"),
+		widget.NewLabel(i18n.T("Select export format:")),
 		formatSelect,
 	)
 
-	dialog.ShowCustomConfirm("Export All Records", "Export", "Cancel", form, func(export bool) {
+	dialog.ShowCustomConfirm(i18n.T("Export All Records"), i18n.T("Export"), i18n.T("Cancel"), form, func(export bool) {
 		if !export {
 			return
 		}
@@ -444,75 +653,105 @@ func (p *HistoryRecordPage) onExportAll() {
 			format = usecase.FormatTXT
 		case "Markdown":
 			format = usecase.FormatMarkdown
+		case "CSV":
+			format = usecase.FormatCSV
 		default:
 			format = usecase.FormatTXT
 		}
 
 		// Export all records immediately (in goroutine to avoid blocking UI)
 		go func() {
-			count, exportDir, err := p.exportUC.ExportAllRecords(p.ctx, p.records, format)
+			if format == usecase.FormatCSV {
+				path, err := p.exportUC.ExportCSV(p.ctx, records)
+				if err != nil {
+					slog.Error("History: Failed to export records to CSV", "error", err)
+					dialog.ShowError(fmt.Errorf(i18n.T("export failed: %v"), err), p.win)
+					return
+				}
+				showExportResult(p.win, i18n.T("Export All Successful"),
+					i18n.Tf("Successfully exported %d records to:\n%s\n\nFormat: %s", len(records), path, format),
+					filepath.Dir(path))
+				return
+			}
+
+			count, exportDir, err := p.exportUC.ExportAllRecords(p.ctx, records, format)
 			if err != nil {
 				slog.Error("History: Failed to export all records", "error", err)
 				// Show partial success message
 				if count > 0 {
-					dialog.ShowInformation("Export Partially Completed",
-						fmt.Sprintf("Successfully exported %d out of %d records to:\n%s\n\n%d records failed.\n\nCheck logs for details.",
-							count, len(p.records), exportDir, len(p.records)-count),
-						p.win)
+					showExportResult(p.win, i18n.T("Export Partially Completed"),
+						i18n.Tf("Successfully exported %d out of %d records to:\n%s\n\n%d records failed.\n\nCheck logs for details.",
+							count, len(records), exportDir, len(records)-count),
+						exportDir)
 				} else {
-					dialog.ShowError(fmt.Errorf("export failed: %v", err), p.win)
+					dialog.ShowError(fmt.Errorf(i18n.T("export failed: %v"), err), p.win)
 				}
 				return
 			}
 
 			slog.Info("History: Exported all records", "count", count, "format", format, "directory", exportDir)
-			dialog.ShowInformation("Export All Successful",
-				fmt.Sprintf("Successfully exported %d records to:\n%s\n\nFormat: %s", count, exportDir, format),
-				p.win)
+			showExportResult(p.win, i18n.T("Export All Successful"),
+				i18n.Tf("Successfully exported %d records to:\n%s\n\nFormat: %s", count, exportDir, format),
+				exportDir)
 		}()
 	}, p.win)
 }
 
 // onDeleteAll deletes all history records after confirmation.
 func (p *HistoryRecordPage) onDeleteAll() {
-	if len(p.records) == 0 {
-		dialog.ShowInformation("Delete All", "No records to delete", p.win)
+	records, err := p.matchingRecords()
+	if err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+	if len(records) == 0 {
+		dialog.ShowInformation(i18n.T("Delete All"), i18n.T("No records to delete"), p.win)
 		return
 	}
 
 	dialog.ShowConfirm(
-		"Delete All Records",
-		fmt.Sprintf("Are you sure you want to delete ALL %d history records?\n\nThis action cannot be undone!", len(p.records)),
+		i18n.T("Delete All Records"),
+		i18n.Tf("Are you sure you want to delete ALL %d matching history records?\n\nThis action cannot be undone!", len(records)),
 		func(confirmed bool) {
 			if !confirmed {
 				return
 			}
 
-			recordCount := len(p.records)
+			recordCount := len(records)
 			slog.Info("History: Deleting all records", "count", recordCount)
 
 			// Delete all records from database
 			if p.historyUC != nil {
-				for _, record := range p.records {
+				for _, record := range records {
 					if err := p.historyUC.DeleteRecord(p.ctx, record.ID); err != nil {
 						slog.Error("History: Failed to delete record", "id", record.ID, "error", err)
 					}
 				}
 			}
 
-			// Clear the list
-			p.records = []*history.Record{}
+			// Reload the list
 			p.selected = -1
-			p.list.Refresh()
-
-			// Update summary
-			p.summaryLabel.SetText("Total Runs: 0")
+			p.pageIndex = 0
+			p.Refresh()
 
 			slog.Info("History: All records deleted successfully", "count", recordCount)
-			dialog.ShowInformation("Delete All Successful",
-				fmt.Sprintf("Successfully deleted all %d records", recordCount),
+			dialog.ShowInformation(i18n.T("Delete All Successful"),
+				i18n.Tf("Successfully deleted all %d records", recordCount),
 				p.win)
 		},
 		p.win,
 	)
 }
+
+// onViewLogs shows the log of the selected record's run.
+func (p *HistoryRecordPage) onViewLogs() {
+	if p.selected < 0 || p.selected >= len(p.records) || p.historyUC == nil {
+		return
+	}
+	record := p.records[p.selected]
+
+	title := i18n.Tf("Run Log - %s (%s)", record.TemplateName, record.StartTime.Format("2006-01-02 15:04"))
+	showRunLogDialog(p.win, title, func(ctx context.Context, filter usecase.LogFilter) ([]usecase.LogEntry, error) {
+		return p.historyUC.GetRunLogs(ctx, record.ID, filter)
+	}, false)
+}
@@ -0,0 +1,47 @@
+// Package pages provides unit tests for the history filter bar.
+package pages
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// TestHistoryListOptions_DatabaseType tests that the Database filter sends
+// the database types history records store, not its labels.
+func TestHistoryListOptions_DatabaseType(t *testing.T) {
+	test.NewTempApp(t)
+	page := &HistoryRecordPage{
+		searchEntry:  widget.NewEntry(),
+		dbTypeSelect: widget.NewSelect([]string{i18n.T("All"), "MySQL", "PostgreSQL", "Oracle", "SQL Server"}, nil),
+		threadsEntry: widget.NewEntry(),
+		fromEntry:    widget.NewEntry(),
+		toEntry:      widget.NewEntry(),
+		tagFilter:    widget.NewEntry(),
+		validOnly:    widget.NewCheck("", nil),
+	}
+	tests := []struct {
+		label string
+		want  string
+	}{
+		{i18n.T("All"), ""},
+		{"MySQL", string(connection.DatabaseTypeMySQL)},
+		{"PostgreSQL", string(connection.DatabaseTypePostgreSQL)},
+		{"Oracle", string(connection.DatabaseTypeOracle)},
+		{"SQL Server", string(connection.DatabaseTypeSQLServer)},
+	}
+	for _, tt := range tests {
+		page.dbTypeSelect.SetSelected(tt.label)
+		opts, err := page.listOptions()
+		if err != nil {
+			t.Fatalf("listOptions() failed: %v", err)
+		}
+		if opts.DatabaseType != tt.want {
+			t.Errorf("listOptions() with %q: DatabaseType = %q, want %q", tt.label, opts.DatabaseType, tt.want)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// Latency heatmap of the Run Details view.
+package pages
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/heatmap"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// latencyHeatmapColumns is the most columns of the heatmap of a run; longer
+// runs have several samples in each column.
+const latencyHeatmapColumns = 240
+
+// runLatencyHeatmapView shows the heatmap of the per-second p95 and p99
+// latencies of the run phase, to spot periodic stalls.
+func runLatencyHeatmapView(record *history.Record) fyne.CanvasObject {
+	var samples []heatmap.Sample
+	var start time.Time
+	for _, sample := range record.TimeSeries {
+		if sample.Phase != "run" && sample.Phase != "" {
+			continue
+		}
+		if samples == nil {
+			start = sample.Timestamp
+		}
+		samples = append(samples, heatmap.Sample{
+			Offset:    sample.Timestamp.Sub(start),
+			Latencies: []float64{sample.LatencyP95, sample.LatencyP99},
+		})
+	}
+	h := heatmap.New(samples, latencyHeatmapColumns, heatmap.DefaultRows)
+	if h == nil {
+		return widget.NewLabel(i18n.T("No latency samples were recorded for this run."))
+	}
+
+	help := widget.NewLabel(i18n.T("Per-second p95 and p99 latencies by time; darker cells hold more samples. Recurring cells far above the band show periodic stalls such as checkpoints."))
+	help.Wrapping = fyne.TextWrapWord
+	return container.NewBorder(help, nil, nil, nil, newLatencyHeatmap(h))
+}
+
+// latencyHeatmap draws a heatmap as colored cells with latency labels on the
+// left and time labels below.
+type latencyHeatmap struct {
+	widget.BaseWidget
+	heatmap *heatmap.Heatmap
+}
+
+// newLatencyHeatmap creates the widget of h.
+func newLatencyHeatmap(h *heatmap.Heatmap) *latencyHeatmap {
+	w := &latencyHeatmap{heatmap: h}
+	w.ExtendBaseWidget(w)
+	return w
+}
+
+// CreateRenderer implements fyne.Widget.
+func (w *latencyHeatmap) CreateRenderer() fyne.WidgetRenderer {
+	r := &latencyHeatmapRenderer{
+		heatmap:    w.heatmap,
+		background: canvas.NewRectangle(theme.Color(theme.ColorNameInputBackground)),
+	}
+	for c, counts := range w.heatmap.Counts {
+		for row, n := range counts {
+			if n > 0 {
+				r.cells = append(r.cells, latencyHeatmapCell{column: c, row: row, rect: canvas.NewRectangle(heatmap.Color(w.heatmap.Density(c, row)))})
+			}
+		}
+	}
+	last := len(w.heatmap.Counts) - 1
+	r.labels = []*canvas.Text{
+		r.newLabel(heatmap.FormatLatency(w.heatmap.Bounds[w.heatmap.Rows()])),
+		r.newLabel(heatmap.FormatLatency(w.heatmap.Bounds[0])),
+		r.newLabel(w.heatmap.Starts[0].String()),
+		r.newLabel(w.heatmap.Starts[last].String()),
+	}
+	return r
+}
+
+// MinSize implements fyne.Widget.
+func (w *latencyHeatmap) MinSize() fyne.Size {
+	return fyne.NewSize(300, 200)
+}
+
+// latencyHeatmapCell is the rectangle of a non-empty cell.
+type latencyHeatmapCell struct {
+	column, row int
+	rect        *canvas.Rectangle
+}
+
+// latencyHeatmapRenderer lays out the cells of a latencyHeatmap.
+type latencyHeatmapRenderer struct {
+	heatmap    *heatmap.Heatmap
+	background *canvas.Rectangle
+	cells      []latencyHeatmapCell
+	labels     []*canvas.Text // Top and bottom latency, first and last time
+}
+
+// newLabel creates an axis label.
+func (r *latencyHeatmapRenderer) newLabel(text string) *canvas.Text {
+	label := canvas.NewText(text, theme.Color(theme.ColorNameForeground))
+	label.TextSize = theme.CaptionTextSize()
+	return label
+}
+
+func (r *latencyHeatmapRenderer) Layout(size fyne.Size) {
+	top, bottom, last := r.labels[0], r.labels[1], r.labels[3]
+	left := max(top.MinSize().Width, bottom.MinSize().Width) + theme.Padding()
+	labelHeight := last.MinSize().Height
+	plot := fyne.NewSize(size.Width-left, size.Height-labelHeight)
+
+	r.background.Move(fyne.NewPos(left, 0))
+	r.background.Resize(plot)
+	cellW := plot.Width / float32(len(r.heatmap.Counts))
+	cellH := plot.Height / float32(r.heatmap.Rows())
+	for _, cell := range r.cells {
+		cell.rect.Move(fyne.NewPos(left+cellW*float32(cell.column), plot.Height-cellH*float32(cell.row+1)))
+		cell.rect.Resize(fyne.NewSize(cellW, cellH))
+	}
+
+	top.Move(fyne.NewPos(0, 0))
+	bottom.Move(fyne.NewPos(0, plot.Height-bottom.MinSize().Height))
+	r.labels[2].Move(fyne.NewPos(left, plot.Height))
+	last.Move(fyne.NewPos(size.Width-last.MinSize().Width, plot.Height))
+}
+
+func (r *latencyHeatmapRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(300, 200)
+}
+
+func (r *latencyHeatmapRenderer) Refresh() {
+	canvas.Refresh(r.background)
+}
+
+func (r *latencyHeatmapRenderer) Objects() []fyne.CanvasObject {
+	objects := []fyne.CanvasObject{r.background}
+	for _, cell := range r.cells {
+		objects = append(objects, cell.rect)
+	}
+	for _, label := range r.labels {
+		objects = append(objects, label)
+	}
+	return objects
+}
+
+func (r *latencyHeatmapRenderer) Destroy() {}
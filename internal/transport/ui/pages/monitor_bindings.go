@@ -0,0 +1,394 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// Bound data models for the Tasks & Monitor page.
+package pages
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2/data/binding"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+// logWaitingText is shown in the log before the first output line arrives.
+const logWaitingText = "Waiting for benchmark data...\n"
+
+// warmupLinePrefix marks report lines of the warmup phase in the log.
+const warmupLinePrefix = "[warmup] "
+
+// rawLineSecondPattern extracts the elapsed second from a sysbench report line ("[ 28s ] thds: 1 tps: ...").
+var rawLineSecondPattern = regexp.MustCompile(`\[\s*(\d+)s\s*\]`)
+
+// monitorMetric is a metric shown in the monitor panel.
+// To add a metric, append an entry to newMonitorMetrics.
+type monitorMetric struct {
+	title string         // Label shown before the value
+	reset string         // Value shown before the first sample
+	value binding.String // Bound value
+	// format returns the text for a sample; ok=false keeps the previous value.
+	// Metrics without format are set directly (e.g. Threads).
+	format func(sample execution.MetricSample) (text string, ok bool)
+}
+
+// newMonitorMetrics creates the metrics of the monitor panel, in display order.
+func newMonitorMetrics() []*monitorMetric {
+	metrics := []*monitorMetric{
+		{
+			title: "TPS:",
+			reset: "--",
+			format: func(s execution.MetricSample) (string, bool) {
+				return fmt.Sprintf("%.0f", s.TPS), s.TPS > 0
+			},
+		},
+		{
+			title: "QPS:",
+			reset: "--",
+			format: func(s execution.MetricSample) (string, bool) {
+				return fmt.Sprintf("%.0f", s.QPS), s.QPS > 0
+			},
+		},
+		{
+			title: "95% Latency:",
+			reset: "--",
+			format: func(s execution.MetricSample) (string, bool) {
+				return fmt.Sprintf("%.2fms", s.LatencyP95), s.LatencyP95 > 0
+			},
+		},
+		{
+			title: "Threads:",
+			reset: "--",
+		},
+		{
+			title: "Errors/s:",
+			reset: "0.00",
+			format: func(s execution.MetricSample) (string, bool) {
+				return fmt.Sprintf("%.2f", s.ErrorRate), true
+			},
+		},
+	}
+	for _, m := range metrics {
+		m.value = binding.NewString()
+		m.value.Set(m.reset)
+	}
+	return metrics
+}
+
+// monitorBindings holds the bound data shown by the monitor panel.
+// All setters are safe to call from any goroutine; Fyne delivers the
+// changes to the bound widgets on the UI thread.
+type monitorBindings struct {
+	status   binding.String
+	threads  binding.String // Value of the Threads metric
+	progress binding.Float
+	eta      binding.String // Progress detail and estimated time left, e.g. of the prepare phase
+	metrics  []*monitorMetric
+	log      *logBuffer
+	rate     *rateSeries // TPS and planned rate of the run phase, for the chart
+	// Metrics of the connection raced against in an A/B race, shown next to
+	// metrics; its output is not logged
+	raceMetrics []*monitorMetric
+	raceThreads binding.String
+}
+
+// newMonitorBindings creates the monitor data model.
+func newMonitorBindings(maxLogLines int) *monitorBindings {
+	b := &monitorBindings{
+		status:   binding.NewString(),
+		progress: binding.NewFloat(),
+		eta:      binding.NewString(),
+		metrics:  newMonitorMetrics(),
+		log:      newLogBuffer(maxLogLines),
+		rate:     newRateSeries(),
+
+		raceMetrics: newMonitorMetrics(),
+	}
+	b.status.Set("Idle")
+	b.threads = threadsMetric(b.metrics)
+	b.raceThreads = threadsMetric(b.raceMetrics)
+	return b
+}
+
+// threadsMetric returns the value of the Threads metric of metrics.
+func threadsMetric(metrics []*monitorMetric) binding.String {
+	for _, m := range metrics {
+		if m.title == "Threads:" {
+			return m.value
+		}
+	}
+	return nil
+}
+
+// updateMetrics updates metrics from a realtime sample.
+func updateMetrics(metrics []*monitorMetric, sample execution.MetricSample) {
+	for _, m := range metrics {
+		if m.format == nil {
+			continue
+		}
+		if text, ok := m.format(sample); ok {
+			m.value.Set(text)
+		}
+	}
+}
+
+// updateRaceSample updates the metrics of the connection raced against from a realtime sample.
+func (b *monitorBindings) updateRaceSample(sample execution.MetricSample) {
+	updateMetrics(b.raceMetrics, sample)
+}
+
+// updateSample updates the metrics and log from a realtime sample.
+func (b *monitorBindings) updateSample(sample execution.MetricSample) {
+	updateMetrics(b.metrics, sample)
+	if sample.RawLine != "" {
+		line := sample.RawLine
+		if sample.Phase == "warmup" {
+			line = warmupLinePrefix + line
+		}
+		b.log.AppendReportLine(line)
+
+		// Warmup is not part of the planned rate profile
+		if matches := rawLineSecondPattern.FindStringSubmatch(sample.RawLine); sample.Phase != "warmup" && len(matches) > 1 {
+			if second, err := strconv.Atoi(matches[1]); err == nil {
+				b.rate.Add(second, sample.TPS)
+				b.rate.Observe(second, history.MetricSample{Timestamp: sample.Timestamp, TPS: sample.TPS, ErrorRate: sample.ErrorRate})
+			}
+		}
+	}
+}
+
+// reset restores all values to their initial state.
+func (b *monitorBindings) reset() {
+	b.progress.Set(0)
+	b.eta.Set("")
+	for _, m := range slices.Concat(b.metrics, b.raceMetrics) {
+		m.value.Set(m.reset)
+	}
+	b.log.Reset()
+	b.rate.Reset()
+}
+
+// logBuffer keeps the last lines of tool output and exposes them as a bound string.
+type logBuffer struct {
+	mu          sync.Mutex
+	lines       []string
+	maxLines    int
+	lastSeconds map[string]int // Last report second added per phase, to drop duplicates
+	text        binding.String
+}
+
+// newLogBuffer creates a log buffer that keeps at most maxLines lines.
+func newLogBuffer(maxLines int) *logBuffer {
+	l := &logBuffer{
+		maxLines:    maxLines,
+		lastSeconds: make(map[string]int),
+		text:        binding.NewString(),
+	}
+	l.text.Set(logWaitingText)
+	return l
+}
+
+// AppendReportLine adds a periodic report line, skipping seconds that were already added.
+// Returns false if the line was a duplicate.
+func (l *logBuffer) AppendReportLine(line string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if matches := rawLineSecondPattern.FindStringSubmatch(line); len(matches) > 1 {
+		// Warmup and run both start at 1s, so seconds are tracked per phase.
+		// Reports arrive in order, so only the last second is remembered.
+		second, _ := strconv.Atoi(matches[1])
+		phase := ""
+		if strings.HasPrefix(line, warmupLinePrefix) {
+			phase = warmupLinePrefix
+		}
+		if last, ok := l.lastSeconds[phase]; ok && second <= last {
+			return false
+		}
+		l.lastSeconds[phase] = second
+	}
+	l.appendLocked(line)
+	return true
+}
+
+// Append adds a line of tool output, e.g. of the prepare phase.
+func (l *logBuffer) Append(line string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.appendLocked(line)
+}
+
+// Reset clears the buffer and shows the waiting message.
+func (l *logBuffer) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = nil
+	l.lastSeconds = make(map[string]int)
+	l.text.Set(logWaitingText)
+}
+
+// LineCount returns the number of buffered lines.
+func (l *logBuffer) LineCount() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.lines)
+}
+
+// appendLocked adds a line, dropping the oldest lines beyond maxLines.
+func (l *logBuffer) appendLocked(line string) {
+	l.lines = append(l.lines, line)
+	if len(l.lines) > l.maxLines {
+		l.lines = l.lines[len(l.lines)-l.maxLines:]
+	}
+	l.text.Set(strings.Join(l.lines, "\n"))
+}
+
+const (
+	// rateFullResolution is the number of seconds of the run phase for which
+	// the chart keeps every report.
+	rateFullResolution = 600
+
+	// rateBucketSeconds is the width of the buckets the reports after
+	// rateFullResolution are averaged into, so that the chart of a run of
+	// several hours keeps a bounded number of points. The run's samples are
+	// saved at full resolution regardless.
+	rateBucketSeconds = 10
+)
+
+// ratePoint is the TPS reported for a second of the run phase, or averaged
+// over a bucket of seconds ending at second.
+type ratePoint struct {
+	second int
+	tps    float64
+}
+
+// rateMark is an anomaly of the run phase, from its first to its last second.
+type rateMark struct {
+	from, to int
+	kind     history.AnomalyKind
+}
+
+// rateSeries keeps the TPS of the run phase, the planned rate of its rate
+// profile, if any, and its anomalies for the realtime chart.
+type rateSeries struct {
+	mu            sync.Mutex
+	points        []ratePoint
+	bucket        int // Bucket of the last point once past rateFullResolution
+	bucketSamples int // Reports averaged into the last point, 0 if it is not a bucket
+	plan          []execution.RateStep
+	detector      *history.AnomalyDetector
+	observed      bool // Whether a sample was passed to detector
+	lastObserved  int  // Second of the last sample passed to detector
+	marks         []rateMark
+	version       binding.Int // Incremented on every change; the chart redraws on it
+}
+
+// newRateSeries creates an empty rate series.
+func newRateSeries() *rateSeries {
+	return &rateSeries{
+		detector: history.NewAnomalyDetector(history.AnomalyOptions{}),
+		version:  binding.NewInt(),
+	}
+}
+
+// SetPlan sets the planned steps of the run phase (nil for a fixed rate).
+func (r *rateSeries) SetPlan(plan []execution.RateStep) {
+	r.mu.Lock()
+	r.plan = plan
+	r.mu.Unlock()
+	r.changed()
+}
+
+// Add records the TPS of a second, skipping seconds that were already added.
+// After rateFullResolution seconds, the TPS is averaged into buckets of
+// rateBucketSeconds.
+func (r *rateSeries) Add(second int, tps float64) {
+	r.mu.Lock()
+	n := len(r.points)
+	if n > 0 && second <= r.points[n-1].second {
+		r.mu.Unlock()
+		return
+	}
+	switch bucket := (second - 1) / rateBucketSeconds; {
+	case second <= rateFullResolution:
+		r.points = append(r.points, ratePoint{second: second, tps: tps})
+	case r.bucketSamples > 0 && bucket == r.bucket:
+		last := &r.points[n-1]
+		last.tps = (last.tps*float64(r.bucketSamples) + tps) / float64(r.bucketSamples+1)
+		last.second = second
+		r.bucketSamples++
+	default:
+		r.points = append(r.points, ratePoint{second: second, tps: tps})
+		r.bucket = bucket
+		r.bucketSamples = 1
+	}
+	r.mu.Unlock()
+	r.changed()
+}
+
+// Observe passes the sample of a second of the run phase to anomaly
+// detection, skipping seconds that were already observed, and marks the
+// anomalies found so far relative to the first second of the points.
+func (r *rateSeries) Observe(second int, sample history.MetricSample) {
+	r.mu.Lock()
+	if r.observed && second <= r.lastObserved {
+		r.mu.Unlock()
+		return
+	}
+	r.observed, r.lastObserved = true, second
+	r.detector.Add(sample)
+	first := 0
+	if len(r.points) > 0 {
+		first = r.points[0].second
+	}
+	r.marks = r.marks[:0]
+	for _, a := range r.detector.Anomalies() {
+		r.marks = append(r.marks, rateMark{
+			from: first + int(a.Offset.Seconds()),
+			to:   first + int(a.EndOffset().Seconds()),
+			kind: a.Kind,
+		})
+	}
+	changed := len(r.marks) > 0
+	r.mu.Unlock()
+	if changed {
+		r.changed()
+	}
+}
+
+// Reset clears the points, the plan and the anomalies.
+func (r *rateSeries) Reset() {
+	r.mu.Lock()
+	r.points = nil
+	r.bucketSamples = 0
+	r.plan = nil
+	r.detector = history.NewAnomalyDetector(history.AnomalyOptions{})
+	r.observed = false
+	r.marks = nil
+	r.mu.Unlock()
+	r.changed()
+}
+
+// Snapshot returns copies of the points and the plan.
+func (r *rateSeries) Snapshot() ([]ratePoint, []execution.RateStep) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ratePoint(nil), r.points...), append([]execution.RateStep(nil), r.plan...)
+}
+
+// Marks returns a copy of the anomaly marks.
+func (r *rateSeries) Marks() []rateMark {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.marks)
+}
+
+// changed notifies listeners that the series changed.
+func (r *rateSeries) changed() {
+	v, _ := r.version.Get()
+	r.version.Set(v + 1)
+}
@@ -0,0 +1,179 @@
+// Package pages provides tests for the monitor data model.
+package pages
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+)
+
+func TestLogBuffer_AppendReportLine(t *testing.T) {
+	test.NewTempApp(t)
+	buf := newLogBuffer(2)
+
+	buf.AppendReportLine("[ 1s ] thds: 1 tps: 10.00")
+	if buf.AppendReportLine("[ 1s ] thds: 1 tps: 10.00") {
+		t.Error("AppendReportLine() accepted a duplicate second")
+	}
+	buf.AppendReportLine("[ 2s ] thds: 1 tps: 11.00")
+	buf.AppendReportLine("[ 3s ] thds: 1 tps: 12.00")
+
+	text, _ := buf.text.Get()
+	if text != "[ 2s ] thds: 1 tps: 11.00\n[ 3s ] thds: 1 tps: 12.00" {
+		t.Errorf("text = %q, want the last two lines", text)
+	}
+
+	buf.Reset()
+	if text, _ := buf.text.Get(); text != logWaitingText {
+		t.Errorf("text after Reset() = %q, want waiting message", text)
+	}
+	if !buf.AppendReportLine("[ 1s ] thds: 1 tps: 10.00") {
+		t.Error("AppendReportLine() rejected a line after Reset()")
+	}
+}
+
+func TestMonitorBindings_UpdateSample(t *testing.T) {
+	test.NewTempApp(t)
+	b := newMonitorBindings(10)
+	b.threads.Set("8")
+
+	b.updateSample(execution.MetricSample{TPS: 1234.4, LatencyP95: 9.2, RawLine: "[ 1s ] tps: 1234.40"})
+
+	want := map[string]string{
+		"TPS:":         "1234",
+		"QPS:":         "--", // Zero values keep the previous text
+		"95% Latency:": "9.20ms",
+		"Threads:":     "8",
+		"Errors/s:":    "0.00",
+	}
+	for _, m := range b.metrics {
+		got, _ := m.value.Get()
+		if got != want[m.title] {
+			t.Errorf("%s = %q, want %q", m.title, got, want[m.title])
+		}
+	}
+	if text, _ := b.log.text.Get(); !strings.Contains(text, "tps: 1234.40") {
+		t.Errorf("log = %q, want raw line", text)
+	}
+
+	b.reset()
+	if got, _ := b.threads.Get(); got != "--" {
+		t.Errorf("Threads after reset() = %q, want --", got)
+	}
+}
+
+func TestMonitorBindings_UpdateSample_Warmup(t *testing.T) {
+	test.NewTempApp(t)
+	b := newMonitorBindings(10)
+
+	b.updateSample(execution.MetricSample{Phase: "warmup", TPS: 10, RawLine: "[ 1s ] tps: 10.00"})
+	b.updateSample(execution.MetricSample{Phase: "run", TPS: 20, RawLine: "[ 1s ] tps: 20.00"})
+
+	if n := b.log.LineCount(); n != 2 {
+		t.Fatalf("log has %d lines, want 2 (warmup and run second 1)", n)
+	}
+	if text, _ := b.log.text.Get(); !strings.Contains(text, "[warmup] [ 1s ] tps: 10.00") {
+		t.Errorf("log = %q, want labelled warmup line", text)
+	}
+}
+
+func TestMonitorBindings_UpdateRaceSample(t *testing.T) {
+	test.NewTempApp(t)
+	b := newMonitorBindings(10)
+
+	b.updateSample(execution.MetricSample{TPS: 1000, RawLine: "[ 1s ] tps: 1000.00"})
+	b.updateRaceSample(execution.MetricSample{TPS: 1250, RawLine: "[ 1s ] tps: 1250.00"})
+
+	if got, _ := b.metrics[0].value.Get(); got != "1000" {
+		t.Errorf("TPS = %q, want 1000", got)
+	}
+	if got, _ := b.raceMetrics[0].value.Get(); got != "1250" {
+		t.Errorf("race TPS = %q, want 1250", got)
+	}
+	if n := b.log.LineCount(); n != 1 {
+		t.Errorf("log has %d lines, want only the first connection's", n)
+	}
+
+	b.reset()
+	if got, _ := b.raceMetrics[0].value.Get(); got != "--" {
+		t.Errorf("race TPS after reset() = %q, want --", got)
+	}
+}
+
+func TestMonitorBindings_UpdateSample_Rate(t *testing.T) {
+	test.NewTempApp(t)
+	b := newMonitorBindings(10)
+	plan := []execution.RateStep{{Offset: 0, Duration: 10, Rate: 100}, {Offset: 10, Duration: 10, Rate: 150}}
+	b.rate.SetPlan(plan)
+
+	b.updateSample(execution.MetricSample{Phase: "warmup", TPS: 50, RawLine: "[ 1s ] tps: 50.00"})
+	b.updateSample(execution.MetricSample{Phase: "run", TPS: 99, RawLine: "[ 1s ] tps: 99.00"})
+	b.updateSample(execution.MetricSample{Phase: "run", TPS: 99, RawLine: "[ 1s ] tps: 99.00"})
+	b.updateSample(execution.MetricSample{Phase: "run", TPS: 149, RawLine: "[ 11s ] tps: 149.00"})
+
+	points, gotPlan := b.rate.Snapshot()
+	want := []ratePoint{{second: 1, tps: 99}, {second: 11, tps: 149}}
+	if len(points) != len(want) || points[0] != want[0] || points[1] != want[1] {
+		t.Errorf("points = %+v, want %+v (run seconds only, no duplicates)", points, want)
+	}
+	if len(gotPlan) != 2 || gotPlan[1] != plan[1] {
+		t.Errorf("plan = %+v, want %+v", gotPlan, plan)
+	}
+
+	b.reset()
+	if points, plan := b.rate.Snapshot(); len(points) != 0 || len(plan) != 0 {
+		t.Errorf("after reset() points = %v, plan = %v, want empty", points, plan)
+	}
+}
+
+func TestRateSeries_Downsampling(t *testing.T) {
+	test.NewTempApp(t)
+	r := newRateSeries()
+	for second := 1; second <= rateFullResolution+100; second++ {
+		r.Add(second, float64(second))
+	}
+
+	points, _ := r.Snapshot()
+	if len(points) != rateFullResolution+100/rateBucketSeconds {
+		t.Fatalf("len(points) = %d, want every second up to %d and then one per %ds", len(points), rateFullResolution, rateBucketSeconds)
+	}
+	if p := points[rateFullResolution-1]; p != (ratePoint{second: rateFullResolution, tps: rateFullResolution}) {
+		t.Errorf("last full resolution point = %+v", p)
+	}
+	// The first bucket averages seconds 601 to 610
+	if p := points[rateFullResolution]; p != (ratePoint{second: 610, tps: 605.5}) {
+		t.Errorf("first bucket = %+v, want {610 605.5}", p)
+	}
+	if p := points[len(points)-1]; p.second != rateFullResolution+100 {
+		t.Errorf("last bucket ends at %d, want %d", p.second, rateFullResolution+100)
+	}
+}
+
+func TestRateSeries_Observe(t *testing.T) {
+	test.NewTempApp(t)
+	r := newRateSeries()
+	start := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+	tps := []float64{1000, 1000, 1000, 1000, 1000, 1000, 100, 100, 1000}
+	for i, v := range tps {
+		second := i + 1
+		sample := history.MetricSample{Timestamp: start.Add(time.Duration(i) * time.Second), TPS: v}
+		r.Add(second, v)
+		r.Observe(second, sample)
+		r.Observe(second, sample) // Repeated reports of a second are skipped
+	}
+
+	marks := r.Marks()
+	if len(marks) != 1 || marks[0] != (rateMark{from: 7, to: 8, kind: history.AnomalyTPSDrop}) {
+		t.Errorf("marks = %+v, want a drop from 7s to 8s", marks)
+	}
+
+	r.Reset()
+	if marks := r.Marks(); len(marks) != 0 {
+		t.Errorf("after Reset() marks = %+v, want none", marks)
+	}
+}
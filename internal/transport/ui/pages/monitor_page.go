@@ -3,12 +3,14 @@
 package pages
 
 import (
-	"fmt"
+	"errors"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 	"time"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
 )
 
 // RunMonitorPage provides real-time run monitoring GUI.
@@ -31,50 +33,50 @@ func NewRunMonitorPage(win fyne.Window) fyne.CanvasObject {
 		isRunning: false,
 	}
 	// Create status label
-	page.statusLabel = widget.NewLabel("Status: Idle")
+	page.statusLabel = widget.NewLabel(i18n.T("Status: Idle"))
 	page.statusLabel.TextStyle = fyne.TextStyle{Bold: true}
 	// Create metrics labels
-	page.tpsLabel = widget.NewLabel("TPS: 0")
-	page.latencyLabel = widget.NewLabel("Avg Latency: 0ms")
-	page.errorsLabel = widget.NewLabel("Errors: 0")
+	page.tpsLabel = widget.NewLabel(i18n.T("TPS: 0"))
+	page.latencyLabel = widget.NewLabel(i18n.T("Avg Latency: 0ms"))
+	page.errorsLabel = widget.NewLabel(i18n.T("Errors: 0"))
 	// Create progress bar
 	page.progressBar = widget.NewProgressBar()
 	page.progressBar.SetValue(0)
 	// Create log text area
 	page.logText = widget.NewMultiLineEntry()
-	page.logText.SetText("No active run. Start a task to see real-time metrics.\n")
+	page.logText.SetText(i18n.T("No active run. Start a task to see real-time metrics.\n"))
 	// Create metrics card
-	metricsCard := widget.NewCard("Real-time Metrics", "", container.NewVBox(
+	metricsCard := widget.NewCard(i18n.T("Real-time Metrics"), "", container.NewVBox(
 		page.statusLabel,
 		widget.NewSeparator(),
 		container.NewGridWithColumns(2,
-			widget.NewLabel("TPS:"),
+			widget.NewLabel(i18n.T("TPS:")),
 			page.tpsLabel,
-			widget.NewLabel("Avg Latency:"),
+			widget.NewLabel(i18n.T("Avg Latency:")),
 			page.latencyLabel,
-			widget.NewLabel("Errors:"),
+			widget.NewLabel(i18n.T("Errors:")),
 			page.errorsLabel,
 		),
 		widget.NewSeparator(),
-		widget.NewLabel("Progress:"),
+		widget.NewLabel(i18n.T("Progress:")),
 		page.progressBar,
 	))
 	// Create control buttons
-	btnStart := widget.NewButton("Start Monitor", func() {
+	btnStart := widget.NewButton(i18n.T("Start Monitor"), func() {
 		page.onStartMonitor()
 	})
-	btnStop := widget.NewButton("Stop Monitor", func() {
+	btnStop := widget.NewButton(i18n.T("Stop Monitor"), func() {
 		page.onStopMonitor()
 	})
-	btnClear := widget.NewButton("Clear Logs", func() {
+	btnClear := widget.NewButton(i18n.T("Clear Logs"), func() {
 		page.logText.SetText("")
 	})
-	btnRefresh := widget.NewButton("Refresh", func() {
+	btnRefresh := widget.NewButton(i18n.T("Refresh"), func() {
 		page.onRefresh()
 	})
 	toolbar := container.NewHBox(btnStart, btnStop, btnClear, btnRefresh)
 	// Create log card
-	logCard := widget.NewCard("Run Logs", "", container.NewPadded(
+	logCard := widget.NewCard(i18n.T("Run Logs"), "", container.NewPadded(
 		container.NewVBox(
 			container.NewScroll(page.logText),
 		),
@@ -87,7 +89,7 @@ func NewRunMonitorPage(win fyne.Window) fyne.CanvasObject {
 		widget.NewSeparator(),
 		container.NewGridWithColumns(1,
 			container.NewVBox(
-				widget.NewLabel("Logs:"),
+				widget.NewLabel(i18n.T("Logs:")),
 				logCard,
 			),
 		),
@@ -98,12 +100,12 @@ func NewRunMonitorPage(win fyne.Window) fyne.CanvasObject {
 // onStartMonitor starts monitoring a run.
 func (p *RunMonitorPage) onStartMonitor() {
 	if p.isRunning {
-		dialog.ShowError(fmt.Errorf("monitor already running"), p.win)
+		dialog.ShowError(errors.New(i18n.T("monitor already running")), p.win)
 		return
 	}
 	p.isRunning = true
-	p.statusLabel.SetText("Status: Monitoring")
-	p.logText.SetText("[" + time.Now().Format("15:04:05") + "] Monitor started\n")
+	p.statusLabel.SetText(i18n.T("Status: Monitoring"))
+	p.logText.SetText("[" + time.Now().Format("15:04:05") + "] " + i18n.T("Monitor started") + "\n")
 	// Simulate metrics updates (in production, this would connect to actual run)
 	go p.simulateMetrics()
 }
@@ -114,13 +116,13 @@ func (p *RunMonitorPage) onStopMonitor() {
 		return
 	}
 	p.isRunning = false
-	p.statusLabel.SetText("Status: Stopped")
-	p.appendLog("[" + time.Now().Format("15:04:05") + "] Monitor stopped\n")
+	p.statusLabel.SetText(i18n.T("Status: Stopped"))
+	p.appendLog("[" + time.Now().Format("15:04:05") + "] " + i18n.T("Monitor stopped") + "\n")
 }
 
 // onRefresh refreshes the metrics.
 func (p *RunMonitorPage) onRefresh() {
-	p.appendLog("[" + time.Now().Format("15:04:05") + "] Refreshed metrics\n")
+	p.appendLog("[" + time.Now().Format("15:04:05") + "] " + i18n.T("Refreshed metrics") + "\n")
 }
 
 // simulateMetrics simulates real-time metric updates.
@@ -139,20 +141,20 @@ func (p *RunMonitorPage) simulateMetrics() {
 			tps := int(1000 + progress*500)
 			latency := int(10 - progress*5)
 			errors := int(progress * 2)
-			p.tpsLabel.SetText(fmt.Sprintf("TPS: %d", tps))
-			p.latencyLabel.SetText(fmt.Sprintf("Avg Latency: %dms", latency))
-			p.errorsLabel.SetText(fmt.Sprintf("Errors: %d", errors))
+			p.tpsLabel.SetText(i18n.Tf("TPS: %d", tps))
+			p.latencyLabel.SetText(i18n.Tf("Avg Latency: %dms", latency))
+			p.errorsLabel.SetText(i18n.Tf("Errors: %d", errors))
 			p.progressBar.SetValue(progress)
 			if progress < 1.0 {
-				p.appendLog(fmt.Sprintf("[%s] TPS: %d, Latency: %dms, Errors: %d\n",
+				p.appendLog(i18n.Tf("[%s] TPS: %d, Latency: %dms, Errors: %d\n",
 					time.Now().Format("15:04:05"), tps, latency, errors))
 			}
 		}
 	}
 	if progress >= 1.0 {
 		p.isRunning = false
-		p.statusLabel.SetText("Status: Completed")
-		p.appendLog("[" + time.Now().Format("15:04:05") + "] Run completed\n")
+		p.statusLabel.SetText(i18n.T("Status: Completed"))
+		p.appendLog("[" + time.Now().Format("15:04:05") + "] " + i18n.T("Run completed") + "\n")
 	}
 }
 
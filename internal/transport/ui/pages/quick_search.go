@@ -0,0 +1,172 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// Quick search (Ctrl+K): finding connections, templates and history records,
+// and selecting them on their pages.
+package pages
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/repository"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
+)
+
+// quickSearchHistoryLimit is the number of most recent history records the
+// quick search looks through.
+const quickSearchHistoryLimit = 1000
+
+// QuickSearchResult is an item found by the quick search.
+type QuickSearchResult struct {
+	ID     string // Connection, template or history record ID
+	Title  string // Name of the item
+	Detail string // Address, tool or date shown after the title
+}
+
+// quickSearchMatches reports whether every word of query occurs, ignoring
+// case, in one of fields. An empty query matches nothing.
+func quickSearchMatches(query string, fields ...string) bool {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return false
+	}
+	text := strings.ToLower(strings.Join(fields, " "))
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// highlightRow marks label as the row selected by the quick search.
+func highlightRow(label *widget.Label) {
+	label.Importance = widget.HighImportance
+	label.TextStyle = fyne.TextStyle{Bold: true}
+	label.Refresh()
+}
+
+// scrollToRow scrolls to row of group, a container of rows in the list of
+// groups shown by scroll.
+func scrollToRow(scroll *container.Scroll, group *fyne.Container, row fyne.CanvasObject) {
+	scroll.ScrollToOffset(fyne.NewPos(0, group.Position().Y+row.Position().Y))
+}
+
+// QuickSearch returns the connections whose name, group, type, host or
+// database match query, favorites first.
+func (p *ConnectionPage) QuickSearch(query string) []QuickSearchResult {
+	conns := append([]connection.Connection(nil), p.conns...)
+	connection.SortFavoritesFirst(conns)
+	var results []QuickSearchResult
+	for _, conn := range conns {
+		if strings.TrimSpace(query) != "" && connection.Matches(conn, query) {
+			results = append(results, QuickSearchResult{ID: conn.GetID(), Title: conn.GetName(), Detail: conn.Redact()})
+		}
+	}
+	return results
+}
+
+// SelectConnection clears the search and group filter, and highlights and
+// scrolls to the connection with id.
+func (p *ConnectionPage) SelectConnection(id string) {
+	slog.Info("Connections: Selecting connection", "id", id)
+	p.selectedID = id
+	onChanged := p.searchEntry.OnChanged
+	p.searchEntry.OnChanged = nil // Render once below
+	p.searchEntry.SetText("")
+	p.searchEntry.OnChanged = onChanged
+	p.groupFilter.Selected = p.groupFilter.Options[groupFilterAll]
+	p.groupFilter.Refresh()
+	p.loadConnections()
+}
+
+// QuickSearch returns the templates whose name, tool or database type match query.
+func (p *TemplateManagementPage) QuickSearch(query string) []QuickSearchResult {
+	var results []QuickSearchResult
+	for _, tmpl := range p.loadTemplatesData() {
+		if quickSearchMatches(query, tmpl.Name, tmpl.Tool, tmpl.DBType) {
+			results = append(results, QuickSearchResult{ID: tmpl.ID, Title: tmpl.Name, Detail: tmpl.DBType + " / " + tmpl.Tool})
+		}
+	}
+	return results
+}
+
+// SelectTemplate highlights and scrolls to the template with id.
+func (p *TemplateManagementPage) SelectTemplate(id string) {
+	slog.Info("Templates: Selecting template", "id", id)
+	p.selectedID = id
+	p.loadTemplates()
+}
+
+// QuickSearch returns the most recent history records whose tags, start
+// time (e.g. 2026-10-15), connection or template match query.
+func (p *HistoryRecordPage) QuickSearch(query string) []QuickSearchResult {
+	if p.historyUC == nil || strings.TrimSpace(query) == "" {
+		return nil
+	}
+	records, err := p.historyUC.ListRecords(p.ctx, &repository.ListOptions{Limit: quickSearchHistoryLimit, OrderBy: "start_time DESC"})
+	if err != nil {
+		slog.Error("History: Quick search failed", "error", err)
+		return nil
+	}
+	var results []QuickSearchResult
+	for _, record := range records {
+		started := record.StartTime.Format("2006-01-02 15:04")
+		if quickSearchMatches(query, strings.Join(record.Tags, " "), started, record.ConnectionName, record.TemplateName) {
+			detail := started
+			if len(record.Tags) > 0 {
+				detail += " | " + strings.Join(record.Tags, ", ")
+			}
+			results = append(results, QuickSearchResult{
+				ID:     record.ID,
+				Title:  fmt.Sprintf("%s | %s", record.ConnectionName, record.TemplateName),
+				Detail: detail,
+			})
+		}
+	}
+	return results
+}
+
+// SelectRecord filters the list to the day of the record with id, and
+// selects and scrolls to it.
+func (p *HistoryRecordPage) SelectRecord(id string) {
+	slog.Info("History: Selecting record", "id", id)
+	record, err := p.historyUC.GetRecordByID(p.ctx, id)
+	if err != nil {
+		slog.Error("History: Failed to load record", "id", id, "error", err)
+		return
+	}
+
+	day := record.StartTime.Local().Format("2006-01-02")
+	p.searchEntry.SetText("")
+	p.threadsEntry.SetText("")
+	p.tagFilter.SetText("")
+	p.fromEntry.SetText(day)
+	p.toEntry.SetText(day)
+	onChanged := p.validOnly.OnChanged
+	p.validOnly.OnChanged = nil // Load the pages below instead
+	p.validOnly.SetChecked(false)
+	p.validOnly.OnChanged = onChanged
+	p.dbTypeSelect.Selected = p.dbTypeSelect.Options[0] // All
+	p.dbTypeSelect.Refresh()
+
+	// Page through the day's records until the record shows up
+	for p.pageIndex = 0; ; p.pageIndex++ {
+		p.loadHistory()
+		for i, r := range p.records {
+			if r.ID == id {
+				p.selected = i
+				p.list.Select(i)
+				p.list.ScrollTo(i)
+				return
+			}
+		}
+		if (p.pageIndex+1)*historyPageSize >= p.totalCount {
+			return
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Package pages provides unit tests for the quick search.
+package pages
+
+import "testing"
+
+// TestQuickSearchMatches tests matching quick search queries against the
+// fields of an item.
+func TestQuickSearchMatches(t *testing.T) {
+	fields := []string{"baseline innodb_buffer_pool=32G", "2026-10-15 09:30", "prod-mysql", "oltp_read_write"}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", false},
+		{"baseline", true},
+		{"BASELINE 2026-10-15", true},
+		{"2026-10", true},
+		{"prod-mysql oltp", true},
+		{"2026-10-16", false},
+		{"baseline staging", false},
+	}
+	for _, tt := range tests {
+		if got := quickSearchMatches(tt.query, fields...); got != tt.want {
+			t.Errorf("quickSearchMatches(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
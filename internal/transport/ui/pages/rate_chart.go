@@ -0,0 +1,173 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// Realtime TPS chart of the Tasks & Monitor page.
+package pages
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// Colors of the chart lines and anomaly marks.
+var (
+	rateChartActualColor = color.NRGBA{R: 0x21, G: 0x96, B: 0xf3, A: 0xff} // Reported TPS
+	rateChartPlanColor   = color.NRGBA{R: 0xff, G: 0x98, B: 0x00, A: 0xff} // Planned rate
+	rateChartDropColor   = color.NRGBA{R: 0xf4, G: 0x43, B: 0x36, A: 0x40} // TPS drop
+	rateChartErrorColor  = color.NRGBA{R: 0x9c, G: 0x27, B: 0xb0, A: 0x40} // Error spike
+)
+
+// rateChart plots the TPS of the run phase and, for rate profiles, the planned
+// rate of each step, with shaded bands over its anomalies. It redraws
+// whenever its series changes.
+type rateChart struct {
+	widget.BaseWidget
+	series    *rateSeries
+	minHeight float32
+}
+
+// newRateChart creates a chart of series with the given minimum height.
+func newRateChart(series *rateSeries, minHeight float32) *rateChart {
+	c := &rateChart{series: series, minHeight: minHeight}
+	c.ExtendBaseWidget(c)
+	// Listeners run on the UI thread
+	series.version.AddListener(binding.NewDataListener(c.Refresh))
+	return c
+}
+
+// CreateRenderer implements fyne.Widget.
+func (c *rateChart) CreateRenderer() fyne.WidgetRenderer {
+	r := &rateChartRenderer{
+		chart:      c,
+		background: canvas.NewRectangle(theme.Color(theme.ColorNameInputBackground)),
+		maxLabel:   canvas.NewText("", theme.Color(theme.ColorNameForeground)),
+		legend:     canvas.NewText("", theme.Color(theme.ColorNameForeground)),
+	}
+	r.maxLabel.TextSize = theme.CaptionTextSize()
+	r.legend.TextSize = theme.CaptionTextSize()
+	r.legend.Alignment = fyne.TextAlignTrailing
+	return r
+}
+
+// MinSize implements fyne.Widget.
+func (c *rateChart) MinSize() fyne.Size {
+	return fyne.NewSize(200, c.minHeight)
+}
+
+// rateChartRenderer draws a rateChart from line segments.
+type rateChartRenderer struct {
+	chart      *rateChart
+	background *canvas.Rectangle
+	maxLabel   *canvas.Text // Top of the TPS axis
+	legend     *canvas.Text
+	marks      []fyne.CanvasObject // Anomaly bands, below the lines
+	lines      []fyne.CanvasObject
+	size       fyne.Size
+}
+
+func (r *rateChartRenderer) Layout(size fyne.Size) {
+	r.size = size
+	r.redraw()
+}
+
+func (r *rateChartRenderer) MinSize() fyne.Size {
+	return r.chart.MinSize()
+}
+
+func (r *rateChartRenderer) Refresh() {
+	r.redraw()
+	canvas.Refresh(r.chart)
+}
+
+func (r *rateChartRenderer) Objects() []fyne.CanvasObject {
+	objects := []fyne.CanvasObject{r.background}
+	objects = append(objects, r.marks...)
+	objects = append(objects, r.maxLabel, r.legend)
+	return append(objects, r.lines...)
+}
+
+func (r *rateChartRenderer) Destroy() {}
+
+// redraw rebuilds the lines for the current series and size.
+func (r *rateChartRenderer) redraw() {
+	size := r.size
+	r.background.Resize(size)
+	r.lines = nil
+	r.marks = nil
+
+	points, plan := r.chart.series.Snapshot()
+	marks := r.chart.series.Marks()
+
+	// Scale the x axis to the planned run phase, or the seconds reported so far
+	maxSecond, maxTPS := 1, 0.0
+	for _, step := range plan {
+		maxSecond = max(maxSecond, step.Offset+step.Duration)
+		maxTPS = max(maxTPS, float64(step.Rate))
+	}
+	for _, p := range points {
+		maxSecond = max(maxSecond, p.second)
+		maxTPS = max(maxTPS, p.tps)
+	}
+	if maxTPS <= 0 {
+		maxTPS = 1
+	}
+	maxTPS *= 1.1
+
+	r.maxLabel.Text = i18n.Tf("%.0f TPS", maxTPS)
+	r.maxLabel.Move(fyne.NewPos(theme.Padding(), 0))
+	r.legend.Text = i18n.T("blue: TPS")
+	if len(plan) > 0 {
+		r.legend.Text += "   " + i18n.T("orange: planned rate")
+	}
+	if len(marks) > 0 {
+		r.legend.Text += "   " + i18n.T("red: TPS drop   purple: error spike")
+	}
+	r.legend.Resize(fyne.NewSize(size.Width-theme.Padding(), r.legend.MinSize().Height))
+	r.legend.Move(fyne.NewPos(0, 0))
+
+	top := r.maxLabel.MinSize().Height
+	height := size.Height - top
+	x := func(second int) float32 { return size.Width * float32(second) / float32(maxSecond) }
+	y := func(tps float64) float32 { return top + height*(1-float32(tps/maxTPS)) }
+
+	// Anomalies as bands over their seconds, at least one second wide
+	for _, mark := range marks {
+		band := canvas.NewRectangle(rateChartDropColor)
+		if mark.kind == history.AnomalyErrorSpike {
+			band.FillColor = rateChartErrorColor
+		}
+		from, to := x(mark.from), x(mark.to+1)
+		band.Move(fyne.NewPos(from, top))
+		band.Resize(fyne.NewSize(max(to-from, 2), height))
+		r.marks = append(r.marks, band)
+	}
+
+	// Planned rate as a step line
+	for i, step := range plan {
+		level := y(float64(step.Rate))
+		r.addLine(rateChartPlanColor, x(step.Offset), level, x(step.Offset+step.Duration), level)
+		if i > 0 {
+			r.addLine(rateChartPlanColor, x(step.Offset), y(float64(plan[i-1].Rate)), x(step.Offset), level)
+		}
+	}
+
+	// Reported TPS as a polyline
+	for i := 1; i < len(points); i++ {
+		r.addLine(rateChartActualColor, x(points[i-1].second), y(points[i-1].tps), x(points[i].second), y(points[i].tps))
+	}
+}
+
+// addLine adds a line segment from (x1, y1) to (x2, y2).
+func (r *rateChartRenderer) addLine(c color.Color, x1, y1, x2, y2 float32) {
+	line := canvas.NewLine(c)
+	line.StrokeWidth = 2
+	line.Position1 = fyne.NewPos(x1, y1)
+	line.Position2 = fyne.NewPos(x2, y2)
+	r.lines = append(r.lines, line)
+}
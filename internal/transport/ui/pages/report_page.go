@@ -3,11 +3,14 @@
 package pages
 
 import (
+	"errors"
 	"fmt"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
 )
 
 // ReportExportPage provides the report export GUI.
@@ -43,16 +46,16 @@ func NewReportExportPage(win fyne.Window) fyne.CanvasObject {
 	page.formatSelect.SetSelected("Markdown (.md)")
 	// Create section multi-select
 	sectionChecks := widget.NewCheckGroup([]string{
-		"Summary",
-		"Metrics",
-		"Charts",
-		"Raw Data",
-		"Configuration",
+		i18n.T("Summary"),
+		i18n.T("Metrics"),
+		i18n.T("Charts"),
+		i18n.T("Raw Data"),
+		i18n.T("Configuration"),
 	}, nil)
-	sectionChecks.SetSelected([]string{"Summary", "Metrics", "Charts"})
+	sectionChecks.SetSelected([]string{i18n.T("Summary"), i18n.T("Metrics"), i18n.T("Charts")})
 	page.includeSections = &multiselectWidget{
 		checkBox: sectionChecks,
-		options:  []string{"Summary", "Metrics", "Charts", "Raw Data", "Configuration"},
+		options:  sectionChecks.Options,
 	}
 	// Create output path entry
 	page.outputPath = widget.NewEntry()
@@ -60,32 +63,32 @@ func NewReportExportPage(win fyne.Window) fyne.CanvasObject {
 	// Create form
 	form := &widget.Form{
 		Items: []*widget.FormItem{
-			widget.NewFormItem("Run to Export", page.runSelect),
-			widget.NewFormItem("Format", page.formatSelect),
-			widget.NewFormItem("Output Path", page.outputPath),
+			widget.NewFormItem(i18n.T("Run to Export"), page.runSelect),
+			widget.NewFormItem(i18n.T("Format"), page.formatSelect),
+			widget.NewFormItem(i18n.T("Output Path"), page.outputPath),
 		},
 	}
 	// Section selection
-	sectionLabel := widget.NewLabel("Include Sections:")
+	sectionLabel := widget.NewLabel(i18n.T("Include Sections:"))
 	sectionContainer := container.NewVBox(
 		sectionLabel,
 		page.includeSections.checkBox,
 	)
 	// Create buttons
-	btnGenerate := widget.NewButton("Generate Report", func() {
+	btnGenerate := widget.NewButton(i18n.T("Generate Report"), func() {
 		page.onGenerateReport()
 	})
-	btnPreview := widget.NewButton("Preview", func() {
+	btnPreview := widget.NewButton(i18n.T("Preview"), func() {
 		page.onPreview()
 	})
-	btnBrowse := widget.NewButton("Browse...", func() {
+	btnBrowse := widget.NewButton(i18n.T("Browse..."), func() {
 		page.onBrowsePath()
 	})
 	toolbar := container.NewHBox(btnGenerate, btnPreview, btnBrowse)
 	// Help text
-	helpLabel := widget.NewLabel("Generate detailed benchmark reports in various formats.\nSelect a run, choose format, and specify which sections to include.")
+	helpLabel := widget.NewLabel(i18n.T("Generate detailed benchmark reports in various formats.\nSelect a run, choose format, and specify which sections to include."))
 	content := container.NewVBox(
-		widget.NewCard("Report Configuration", "", container.NewPadded(form)),
+		widget.NewCard(i18n.T("Report Configuration"), "", container.NewPadded(form)),
 		widget.NewSeparator(),
 		container.NewPadded(sectionContainer),
 		widget.NewSeparator(),
@@ -110,48 +113,48 @@ func (p *ReportExportPage) loadRuns() {
 // onGenerateReport generates the report.
 func (p *ReportExportPage) onGenerateReport() {
 	if p.runSelect.Selected == "" {
-		dialog.ShowError(fmt.Errorf("please select a run to export"), p.win)
+		dialog.ShowError(errors.New(i18n.T("please select a run to export")), p.win)
 		return
 	}
 	if p.outputPath.Text == "" {
-		dialog.ShowError(fmt.Errorf("please specify output path"), p.win)
+		dialog.ShowError(errors.New(i18n.T("please specify output path")), p.win)
 		return
 	}
 	// Check selected sections
 	sections := p.includeSections.checkBox.Selected
 	if len(sections) == 0 {
-		dialog.ShowError(fmt.Errorf("please select at least one section"), p.win)
+		dialog.ShowError(errors.New(i18n.T("please select at least one section")), p.win)
 		return
 	}
 	// Mock report generation
-	message := fmt.Sprintf("Report generated successfully!\n\n")
-	message += fmt.Sprintf("Run: %s\n", p.runSelect.Selected)
-	message += fmt.Sprintf("Format: %s\n", p.formatSelect.Selected)
-	message += fmt.Sprintf("Output: %s\n", p.outputPath.Text)
-	message += fmt.Sprintf("Sections: %v\n", sections)
-	dialog.ShowInformation("Report Generated", message, p.win)
+	message := i18n.T("Report generated successfully!\n\n")
+	message += i18n.Tf("Run: %s\n", p.runSelect.Selected)
+	message += i18n.Tf("Format: %s\n", p.formatSelect.Selected)
+	message += i18n.Tf("Output: %s\n", p.outputPath.Text)
+	message += i18n.Tf("Sections: %v\n", sections)
+	dialog.ShowInformation(i18n.T("Report Generated"), message, p.win)
 }
 
 // onPreview previews the report.
 func (p *ReportExportPage) onPreview() {
 	if p.runSelect.Selected == "" {
-		dialog.ShowError(fmt.Errorf("please select a run to preview"), p.win)
+		dialog.ShowError(errors.New(i18n.T("please select a run to preview")), p.win)
 		return
 	}
 	// Mock preview content
-	preview := fmt.Sprintf("# Benchmark Report\n\n")
-	preview += fmt.Sprintf("## Run: %s\n\n", p.runSelect.Selected)
-	preview += fmt.Sprintf("### Summary\n")
-	preview += fmt.Sprintf("- Tool: Sysbench\n")
-	preview += fmt.Sprintf("- Duration: 60s\n")
-	preview += fmt.Sprintf("- Threads: 4\n")
-	preview += fmt.Sprintf("- TPS: 1,250.5\n")
-	preview += fmt.Sprintf("- Avg Latency: 8.5ms\n")
-	preview += fmt.Sprintf("- Errors: 0\n\n")
-	preview += fmt.Sprintf("*(Preview shows partial content)*\n")
+	preview := i18n.T("# Benchmark Report\n\n")
+	preview += i18n.Tf("## Run: %s\n\n", p.runSelect.Selected)
+	preview += i18n.T("### Summary\n")
+	preview += i18n.T("- Tool: Sysbench\n")
+	preview += i18n.T("- Duration: 60s\n")
+	preview += i18n.T("- Threads: 4\n")
+	preview += i18n.T("- TPS: 1,250.5\n")
+	preview += i18n.T("- Avg Latency: 8.5ms\n")
+	preview += i18n.T("- Errors: 0\n\n")
+	preview += i18n.T("*(Preview shows partial content)*\n")
 	dialog.ShowCustomConfirm(
-		"Report Preview",
-		"Close",
+		i18n.T("Report Preview"),
+		i18n.T("Close"),
 		"",
 		widget.NewRichTextFromMarkdown(preview),
 		func(bool) {},
@@ -161,7 +164,7 @@ func (p *ReportExportPage) onPreview() {
 
 // onBrowsePath opens file browser dialog.
 func (p *ReportExportPage) onBrowsePath() {
-	dialog.ShowInformation("Browse", "File browser will be implemented soon", p.win)
+	dialog.ShowInformation(i18n.T("Browse"), i18n.T("File browser will be implemented soon"), p.win)
 }
 
 // getCurrentTimestamp returns current timestamp in format YYYYMMDD-HHMMSS.
@@ -0,0 +1,379 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// Run Details view: drill-down into the results, output, logs and environment of one run.
+package pages
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/dbconfig"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// showRunDetails shows the Run Details view of a record: final statistics,
+// the TPS chart and samples, the latency heatmap, the raw tool output, the log entries and the
+// environment, with export buttons. The record may be an unsaved preview of
+// a completed run (HistoryUseCase.PreviewRecord); its output and logs are
+// stored under the run ID either way. exportUC may be nil to hide exports.
+func showRunDetails(win fyne.Window, historyUC *usecase.HistoryUseCase, exportUC *usecase.ExportUseCase, record *history.Record) {
+	stop := make(chan struct{})
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem(i18n.T("Summary"), runDetailsText(formatRunSummary(record))),
+		container.NewTabItem(i18n.T("Time Series"), runTimeSeriesView(record)),
+		container.NewTabItem(i18n.T("Latency Heatmap"), runLatencyHeatmapView(record)),
+		container.NewTabItem(i18n.T("Tool Output"), runOutputView(historyUC, record)),
+	)
+	if historyUC != nil {
+		viewer, logs := newRunLogViewer(func(ctx context.Context, filter usecase.LogFilter) ([]usecase.LogEntry, error) {
+			return historyUC.GetRunLogs(ctx, record.ID, filter)
+		}, false)
+		viewer.start(stop)
+		tabs.Append(container.NewTabItem(i18n.T("Logs"), logs))
+	}
+	tabs.Append(container.NewTabItem(i18n.T("Environment"), runDetailsText(formatRunEnvironment(historyUC, record))))
+
+	content := fyne.CanvasObject(tabs)
+	if exportUC != nil {
+		buttons := container.NewHBox(layout.NewSpacer(),
+			widget.NewButton(i18n.T("📥 Export TXT"), func() { exportRunRecord(win, exportUC, record, usecase.FormatTXT) }),
+			widget.NewButton(i18n.T("📥 Export Markdown"), func() { exportRunRecord(win, exportUC, record, usecase.FormatMarkdown) }),
+		)
+		content = container.NewBorder(nil, buttons, nil, nil, tabs)
+	}
+
+	title := i18n.Tf("Run Details - %s (%s)", record.TemplateName, record.StartTime.Format("2006-01-02 15:04"))
+	dlg := dialog.NewCustom(title, i18n.T("Close"), content, win)
+	dlg.SetOnClosed(func() { close(stop) })
+	dlg.Resize(fyne.NewSize(1000, 700))
+	dlg.Show()
+}
+
+// runDetailsText shows text in a monospace, scrollable entry.
+func runDetailsText(text string) fyne.CanvasObject {
+	entry := widget.NewMultiLineEntry()
+	entry.TextStyle = fyne.TextStyle{Monospace: true}
+	entry.Wrapping = fyne.TextWrapOff
+	entry.SetText(text)
+	return entry
+}
+
+// runTimeSeriesView shows the TPS chart of the run phase, with its anomalies
+// marked, above the samples.
+func runTimeSeriesView(record *history.Record) fyne.CanvasObject {
+	if len(record.TimeSeries) == 0 {
+		return widget.NewLabel(i18n.T("No time series was recorded for this run."))
+	}
+
+	// Chart the run phase; records saved before phases were tracked have none
+	series := newRateSeries()
+	start := record.TimeSeries[0].Timestamp
+	for _, sample := range record.TimeSeries {
+		if sample.Phase == "run" || sample.Phase == "" {
+			second := int(sample.Timestamp.Sub(start).Seconds())
+			series.Add(second, sample.TPS)
+			series.Observe(second, sample)
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-10s %-8s %10s %10s %10s %10s %10s %8s\n", "time", "phase", "tps", "qps", "avg (ms)", "p95 (ms)", "p99 (ms)", "err %")
+	for _, sample := range record.TimeSeries {
+		fmt.Fprintf(&b, "%-10s %-8s %10.2f %10.2f %10.2f %10.2f %10.2f %8.2f\n",
+			sample.Timestamp.Format("15:04:05"), sample.Phase, sample.TPS, sample.QPS,
+			sample.LatencyAvg, sample.LatencyP95, sample.LatencyP99, sample.ErrorRate)
+	}
+
+	return container.NewBorder(newRateChart(series, 200), nil, nil, nil, runDetailsText(b.String()))
+}
+
+// runOutputView shows the raw tool output of the run, loaded in the background.
+// Without kept output or stdout log entries the report lines of the samples
+// are shown instead.
+func runOutputView(historyUC *usecase.HistoryUseCase, record *history.Record) fyne.CanvasObject {
+	entry := runDetailsText(i18n.T("Loading...")).(*widget.Entry)
+
+	go func() {
+		var output string
+		if historyUC != nil {
+			var err error
+			if output, err = historyUC.GetRunOutput(context.Background(), record.ID); err != nil {
+				slog.Warn("History: Failed to load run output", "id", record.ID, "error", err)
+			}
+		}
+		if output == "" {
+			var lines []string
+			for _, sample := range record.TimeSeries {
+				if sample.RawLine != "" {
+					lines = append(lines, sample.RawLine)
+				}
+			}
+			output = strings.Join(lines, "\n")
+		}
+		if output == "" {
+			output = i18n.T("No tool output was kept for this run.")
+		}
+		fyne.Do(func() { entry.SetText(output) })
+	}()
+
+	return entry
+}
+
+// formatRunSummary formats the final statistics of a record in sysbench style,
+// followed by its load generators, anomalies, errors by class, replica lag, metadata, tags, notes and sanity checks.
+// A run that did not complete is headed by its state and error, a run with
+// SLA targets by their outcome.
+func formatRunSummary(record *history.Record) string {
+	// Calculate per-second rates
+	durationSec := record.Duration.Seconds()
+	qps := 0.0
+	if durationSec > 0 && record.TotalQueries > 0 {
+		qps = float64(record.TotalQueries) / durationSec
+	}
+	ignoredErrorsPerSec := 0.0
+	if durationSec > 0 {
+		ignoredErrorsPerSec = float64(record.IgnoredErrors) / durationSec
+	}
+	reconnectsPerSec := 0.0
+	if durationSec > 0 {
+		reconnectsPerSec = float64(record.Reconnects) / durationSec
+	}
+
+	// Build detailed statistics message in sysbench format
+	details := i18n.Tf(
+		"Connection: %s\n"+
+			"Template: %s\n"+
+			"Database Type: %s\n"+
+			"Threads: %d\n"+
+			"Start Time: %s\n"+
+			"Duration: %v\n\n"+
+			"SQL statistics:\n"+
+			"    queries performed:\n"+
+			"        read:                            %d\n"+
+			"        write:                           %d\n"+
+			"        other:                           %d\n"+
+			"        total:                           %d\n"+
+			"    transactions:                        %d  (%.2f per sec.)\n"+
+			"    queries:                             %d (%.2f per sec.)\n"+
+			"    ignored errors:                      %d      (%.2f per sec.)\n"+
+			"    reconnects:                          %d      (%.2f per sec.)\n\n"+
+			"General statistics:\n"+
+			"    total time:                          %.4fs\n"+
+			"    total number of events:              %d\n\n"+
+			"Latency (ms):\n"+
+			"         min:                                    %.2f\n"+
+			"         avg:                                   %.2f\n"+
+			"         max:                                   %.2f\n"+
+			"         95th percentile:                       %.2f\n"+
+			"         99th percentile:                       %.2f\n\n"+
+			"Threads fairness:\n"+
+			"    events (avg/stddev):           %.4f/%.2f\n"+
+			"    execution time (avg/stddev):   %.4f/%.2f",
+		record.ConnectionName,
+		record.TemplateName,
+		record.DatabaseType,
+		record.Threads,
+		record.StartTime.Format("2006-01-02 15:04:05"),
+		record.Duration,
+		record.ReadQueries,
+		record.WriteQueries,
+		record.OtherQueries,
+		record.TotalQueries,
+		record.TotalTransactions,
+		record.TPSCalculated,
+		record.TotalQueries,
+		qps,
+		record.IgnoredErrors,
+		ignoredErrorsPerSec,
+		record.Reconnects,
+		reconnectsPerSec,
+		record.TotalTime,
+		record.TotalEvents,
+		record.LatencyMin,
+		record.LatencyAvg,
+		record.LatencyMax,
+		record.LatencyP95,
+		record.LatencyP99,
+		record.EventsAvg,
+		record.EventsStddev,
+		record.ExecTimeAvg,
+		record.ExecTimeStddev,
+	)
+
+	if !record.IsCompleted() {
+		stopped := i18n.Tf("Run State: %s\n", record.State)
+		if record.ErrorMessage != "" {
+			stopped += i18n.T("Error: ") + record.ErrorMessage + "\n"
+		}
+		details = stopped + "\n" + details
+	}
+	if outcome := record.Assertions; outcome != nil {
+		verdict := i18n.T("SLA: PASS")
+		if !outcome.Passed {
+			verdict = i18n.T("SLA: FAIL")
+		}
+		for _, result := range outcome.Results {
+			mark := "✓"
+			if !result.Passed {
+				mark = "✗"
+			}
+			verdict += fmt.Sprintf("\n%s %s", mark, result)
+		}
+		details = verdict + "\n\n" + details
+	}
+	if record.TpmC > 0 {
+		details += i18n.Tf("\n\nTPC-C: %.0f tpmC (new-order transactions per minute)", record.TpmC)
+	}
+	if len(record.Agents) > 0 {
+		details += i18n.T("\n\nLoad Generators: ") + strings.Join(record.Agents, ", ")
+	}
+	if anomalies := history.DetectAnomalies(record.TimeSeries, history.AnomalyOptions{}); len(anomalies) > 0 {
+		details += i18n.Tf("\n\nAnomalies (%d):", len(anomalies))
+		for _, a := range anomalies {
+			details += "\n  " + a.String()
+		}
+	}
+	if classes := record.ErrorClasses.Sorted(); len(classes) > 0 {
+		details += i18n.Tf("\n\nErrors by class (%d):", record.ErrorClasses.Total())
+		for _, class := range classes {
+			details += fmt.Sprintf("\n  %-22s %d", class, record.ErrorClasses[class])
+		}
+	}
+	if stats := record.Replication; stats != nil {
+		details += i18n.Tf("\n\nReplication: max lag %.1fs", stats.MaxLag())
+		for _, r := range stats.Replicas {
+			details += i18n.Tf("\n  %s: max %.1fs, avg %.1fs, %d samples, %d failed polls",
+				r.Replica, r.MaxLag, r.AvgLag, r.Samples, r.Failures)
+			for _, e := range r.Errors {
+				details += "\n    " + e
+			}
+		}
+	}
+	if record.Purpose != "" {
+		details += i18n.T("\n\nPurpose: ") + record.Purpose
+	}
+	if record.Ticket != "" {
+		details += i18n.T("\n\nTicket: ") + record.Ticket
+	}
+	if record.Environment != "" {
+		details += i18n.T("\n\nEnvironment: ") + record.Environment
+	}
+	if len(record.Tags) > 0 {
+		details += i18n.T("\n\nTags: ") + strings.Join(record.Tags, ", ")
+	}
+	if record.Notes != "" {
+		details += i18n.T("\n\nNotes:\n") + record.Notes
+	}
+	if record.Validity != nil {
+		details += i18n.T("\n\nSanity Checks:")
+		for _, result := range record.Validity.Results {
+			mark := "✓"
+			if !result.Passed {
+				mark = "✗"
+			}
+			details += i18n.Tf("\n%s %s (%s): %.2f%s, threshold %g%s",
+				mark, result.Name, result.Kind, result.Value, result.Kind.Unit(), result.Threshold, result.Kind.Unit())
+		}
+		if !record.Validity.Valid {
+			details += i18n.T("\nThis run is invalid and left out of comparison reports.")
+		}
+	}
+	return details
+}
+
+// formatRunEnvironment formats the template parameters, the database
+// configuration snapshot and the kept artifacts of a record.
+func formatRunEnvironment(historyUC *usecase.HistoryUseCase, record *history.Record) string {
+	var b strings.Builder
+	if len(record.Parameters) > 0 {
+		b.WriteString(i18n.T("Template Parameters:"))
+		writeSortedSettings(&b, record.Parameters)
+	}
+	if snapshot := record.ConfigSnapshot; snapshot != nil {
+		b.WriteString(i18n.T("\n\nDatabase Configuration:\n"))
+		writeConfigSnapshot(&b, snapshot)
+	}
+	b.WriteString(formatRunArtifacts(historyUC, record.ID))
+
+	if b.Len() == 0 {
+		return i18n.T("No environment information was captured for this run.")
+	}
+	return strings.TrimPrefix(b.String(), "\n\n")
+}
+
+// writeConfigSnapshot writes the server summary, the uncaptured parts and the
+// settings of a database configuration snapshot.
+func writeConfigSnapshot(b *strings.Builder, snapshot *dbconfig.Snapshot) {
+	b.WriteString(snapshot.Summary())
+	for _, e := range snapshot.Errors {
+		b.WriteString(i18n.T("\nNot captured: ") + e)
+	}
+	if len(snapshot.Settings) > 0 {
+		fmt.Fprintf(b, i18n.T("\n\nSettings (%d):"), len(snapshot.Settings))
+		writeSortedSettings(b, snapshot.Settings)
+	}
+}
+
+// writeSortedSettings writes name = value lines sorted by name.
+func writeSortedSettings(b *strings.Builder, settings map[string]string) {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(b, "\n  %s = %s", name, settings[name])
+	}
+}
+
+// formatRunArtifacts lists the artifacts kept for a run.
+func formatRunArtifacts(historyUC *usecase.HistoryUseCase, id string) string {
+	if historyUC == nil {
+		return ""
+	}
+	files, err := historyUC.ListArtifacts(id)
+	if err != nil {
+		slog.Warn("History: Failed to list run artifacts", "id", id, "error", err)
+		return ""
+	}
+	if len(files) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, i18n.T("\n\nArtifacts (%s):"), historyUC.ArtifactDir(id))
+	for _, file := range files {
+		fmt.Fprintf(&b, i18n.T("\n  %s (%d bytes)"), file.Path, file.Size)
+	}
+	return b.String()
+}
+
+// exportRunRecord exports one record in the background and reports the result.
+func exportRunRecord(win fyne.Window, exportUC *usecase.ExportUseCase, record *history.Record, format usecase.ExportFormat) {
+	go func() {
+		path, err := exportUC.ExportRecord(context.Background(), record, format)
+		fyne.Do(func() {
+			if err != nil {
+				slog.Error("History: Failed to export record", "id", record.ID, "error", err)
+				dialog.ShowError(fmt.Errorf(i18n.T("export failed: %v"), err), win)
+				return
+			}
+			slog.Info("History: Exported record", "id", record.ID, "format", format, "filepath", path)
+			showExportResult(win, i18n.T("Export Successful"),
+				i18n.Tf("Record exported to:\n%s\n\nFormat: %s", path, format),
+				filepath.Dir(path))
+		})
+	}()
+}
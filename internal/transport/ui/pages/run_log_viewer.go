@@ -0,0 +1,199 @@
+// Package pages provides the run log viewer.
+package pages
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// runLogFetcher retrieves the log entries of one run.
+type runLogFetcher func(ctx context.Context, filter usecase.LogFilter) ([]usecase.LogEntry, error)
+
+// runLogStreamAll is the stream selector option that shows every stream,
+// shown translated.
+const runLogStreamAll = "All streams"
+
+// runLogFollowInterval is how often a followed log is polled for new entries.
+const runLogFollowInterval = time.Second
+
+// runLogViewer shows the log of a run with stream filtering, search and tail/follow.
+// Fields other than the widgets are only accessed on the UI thread.
+type runLogViewer struct {
+	fetch        runLogFetcher
+	streamSelect *widget.Select
+	searchEntry  *widget.Entry
+	tailEntry    *widget.Entry // Show only the last N entries (empty = all)
+	followCheck  *widget.Check // Poll for new entries
+	text         *widget.Entry
+	status       *widget.Label
+
+	lines  []string
+	lastID int64 // ID of the newest entry shown
+	gen    int   // Incremented on every reload; discards results of outdated queries
+}
+
+// showRunLogDialog shows the log of a run. With follow set, new entries are
+// appended as the run writes them.
+func showRunLogDialog(win fyne.Window, title string, fetch runLogFetcher, follow bool) {
+	v, content := newRunLogViewer(fetch, follow)
+
+	stop := make(chan struct{})
+	dlg := dialog.NewCustom(title, i18n.T("Close"), content, win)
+	dlg.SetOnClosed(func() { close(stop) })
+	dlg.Resize(fyne.NewSize(900, 600))
+
+	v.start(stop)
+	dlg.Show()
+}
+
+// newRunLogViewer creates a log viewer and its content. It shows nothing
+// until started.
+func newRunLogViewer(fetch runLogFetcher, follow bool) (*runLogViewer, fyne.CanvasObject) {
+	v := &runLogViewer{fetch: fetch}
+
+	v.streamSelect = widget.NewSelect([]string{i18n.T(runLogStreamAll), "stdout", "stderr", "info", "error"}, func(string) {
+		v.reload()
+	})
+	v.searchEntry = widget.NewEntry()
+	v.searchEntry.SetPlaceHolder(i18n.T("Search text"))
+	v.searchEntry.OnChanged = func(string) { v.reload() }
+	v.tailEntry = widget.NewEntry()
+	v.tailEntry.SetText("500")
+	v.tailEntry.OnChanged = func(string) { v.reload() }
+	v.followCheck = widget.NewCheck(i18n.T("Follow"), nil)
+	v.followCheck.SetChecked(follow)
+
+	v.text = widget.NewMultiLineEntry()
+	v.text.TextStyle = fyne.TextStyle{Monospace: true}
+	v.text.Wrapping = fyne.TextWrapOff
+	v.status = widget.NewLabel("")
+
+	filters := container.NewBorder(nil, nil,
+		container.NewHBox(v.streamSelect, widget.NewLabel(i18n.T("Tail:")), container.NewGridWrap(fyne.NewSize(80, v.tailEntry.MinSize().Height), v.tailEntry)),
+		v.followCheck,
+		v.searchEntry)
+	return v, container.NewBorder(filters, v.status, nil, nil, v.text)
+}
+
+// start loads the log and follows it, if checked, until stop is closed.
+func (v *runLogViewer) start(stop <-chan struct{}) {
+	v.streamSelect.SetSelected(i18n.T(runLogStreamAll)) // Triggers the first load
+	go v.followLoop(stop)
+}
+
+// filter builds the log filter from the filter widgets.
+func (v *runLogViewer) filter() usecase.LogFilter {
+	var filter usecase.LogFilter
+	if stream := v.streamSelect.Selected; stream != "" && stream != i18n.T(runLogStreamAll) {
+		filter.Streams = []string{stream}
+	}
+	filter.Search = strings.TrimSpace(v.searchEntry.Text)
+	if tail, err := strconv.Atoi(strings.TrimSpace(v.tailEntry.Text)); err == nil && tail > 0 {
+		filter.Tail = tail
+	}
+	return filter
+}
+
+// reload replaces the shown entries with the result of the current filter.
+func (v *runLogViewer) reload() {
+	v.gen++
+	gen := v.gen
+	filter := v.filter()
+
+	go func() {
+		entries, err := v.fetch(context.Background(), filter)
+		fyne.Do(func() {
+			if gen != v.gen {
+				return
+			}
+			if err != nil {
+				slog.Error("Logs: Failed to load run log", "error", err)
+				v.status.SetText(i18n.Tf("Failed to load log: %v", err))
+				return
+			}
+			v.lines = v.lines[:0]
+			v.lastID = 0
+			v.append(entries, filter.Tail)
+		})
+	}()
+}
+
+// followLoop polls for entries newer than the last shown one until stop is closed.
+func (v *runLogViewer) followLoop(stop <-chan struct{}) {
+	ticker := time.NewTicker(runLogFollowInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		var filter usecase.LogFilter
+		var gen int
+		following := false
+		fyne.DoAndWait(func() {
+			following = v.followCheck.Checked
+			filter = v.filter()
+			filter.AfterID = v.lastID
+			gen = v.gen
+		})
+		if !following {
+			continue
+		}
+
+		tail := filter.Tail
+		filter.Tail = 0
+		entries, err := v.fetch(context.Background(), filter)
+		if err != nil {
+			slog.Warn("Logs: Failed to follow run log", "error", err)
+			continue
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		fyne.Do(func() {
+			if gen == v.gen {
+				v.append(entries, tail)
+			}
+		})
+	}
+}
+
+// append shows entries after the current ones, keeping at most tail lines (0 = all).
+func (v *runLogViewer) append(entries []usecase.LogEntry, tail int) {
+	for _, entry := range entries {
+		v.lines = append(v.lines, formatLogEntry(entry))
+		v.lastID = entry.ID
+	}
+	if tail > 0 && len(v.lines) > tail {
+		v.lines = v.lines[len(v.lines)-tail:]
+	}
+
+	v.text.SetText(strings.Join(v.lines, "\n"))
+	v.text.CursorRow = len(v.lines) // Scroll to the newest entry
+	v.text.Refresh()
+	v.status.SetText(i18n.Tf("%d entries shown", len(v.lines)))
+}
+
+// formatLogEntry renders a log entry as one line.
+func formatLogEntry(entry usecase.LogEntry) string {
+	ts := entry.Timestamp
+	if t, err := time.Parse(time.RFC3339, entry.Timestamp); err == nil {
+		ts = t.Format("15:04:05")
+	}
+	return fmt.Sprintf("%s [%-6s] %s", ts, entry.Stream, entry.Content)
+}
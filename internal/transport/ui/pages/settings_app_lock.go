@@ -0,0 +1,119 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// App lock card of the Settings page.
+package pages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/access"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// createAppLockCard creates the app lock card: the admin and operator
+// passwords, locking the app and disabling the lock.
+func (p *SettingsConfigurationPage) createAppLockCard() fyne.CanvasObject {
+	p.appLockStatus = widget.NewLabel("")
+	p.appLockStatus.Wrapping = fyne.TextWrapWord
+	p.refreshAppLockStatus()
+
+	btnAdmin := widget.NewButton(i18n.T("Set Admin Password"), func() {
+		p.onSetAppLockPassword(access.RoleAdmin)
+	})
+	btnOperator := widget.NewButton(i18n.T("Set Operator Password"), func() {
+		p.onSetAppLockPassword(access.RoleOperator)
+	})
+	btnLock := widget.NewButton(i18n.T("🔒 Lock Now"), func() {
+		if enabled, _ := p.accessUC.LockEnabled(context.Background()); !enabled {
+			dialog.ShowInformation(i18n.T("App Lock"), i18n.T("Set an admin password first."), p.win)
+			return
+		}
+		if p.onLock != nil {
+			p.onLock()
+		}
+	})
+	btnDisable := widget.NewButton(i18n.T("Disable Lock"), p.onDisableAppLock)
+	helpLabel := widget.NewLabel(i18n.T("With an admin password set, DB-BenchMind starts locked and is unlocked with the admin or operator password.\nThe operator role can only run benchmarks and view history; creating, changing and deleting connections and the cleanup phase need the admin role.\nThe CLI reads the password from DB_BENCHMIND_APP_PASSWORD or asks for it."))
+
+	return widget.NewCard(i18n.T("App Lock"), "", container.NewVBox(p.appLockStatus, helpLabel, container.NewHBox(btnAdmin, btnOperator, btnLock, btnDisable)))
+}
+
+// refreshAppLockStatus shows whether the app lock is enabled and the role of the session.
+func (p *SettingsConfigurationPage) refreshAppLockStatus() {
+	ctx := context.Background()
+	enabled, err := p.accessUC.LockEnabled(ctx)
+	if err != nil {
+		p.appLockStatus.SetText(i18n.Tf("The app lock could not be read: %v", err))
+		return
+	}
+	if !enabled {
+		p.appLockStatus.SetText(i18n.T("The app is not locked."))
+		return
+	}
+
+	role, _ := p.accessUC.Role(ctx)
+	roleName := i18n.T("admin")
+	if role == access.RoleOperator {
+		roleName = i18n.T("operator")
+	}
+	operatorSet, _ := p.accessUC.OperatorPasswordSet(ctx)
+	if operatorSet {
+		p.appLockStatus.SetText(i18n.Tf("The app lock is enabled; unlocked as %s. Admin and operator passwords are set.", roleName))
+	} else {
+		p.appLockStatus.SetText(i18n.Tf("The app lock is enabled; unlocked as %s. Only the admin password is set.", roleName))
+	}
+}
+
+// onSetAppLockPassword asks for and sets the password of role.
+func (p *SettingsConfigurationPage) onSetAppLockPassword(role access.Role) {
+	password := widget.NewPasswordEntry()
+	confirm := widget.NewPasswordEntry()
+	items := []*widget.FormItem{
+		widget.NewFormItem(i18n.T("Password"), password),
+		widget.NewFormItem(i18n.T("Confirm"), confirm),
+	}
+	title := i18n.T("Set Admin Password")
+	if role == access.RoleOperator {
+		title = i18n.T("Set Operator Password")
+	}
+	form := dialog.NewForm(title, i18n.T("Save"), i18n.T("Cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		if password.Text != confirm.Text {
+			dialog.ShowError(errors.New(i18n.T("passwords do not match")), p.win)
+			return
+		}
+		if err := p.accessUC.SetPassword(context.Background(), role, password.Text); err != nil {
+			slog.Error("Settings: Failed to set app lock password", "role", role, "error", err)
+			dialog.ShowError(fmt.Errorf(i18n.T("set password: %w"), err), p.win)
+			return
+		}
+		p.refreshAppLockStatus()
+		dialog.ShowInformation(i18n.T("App Lock"), i18n.T("Password saved"), p.win)
+	}, p.win)
+	form.Resize(fyne.NewSize(420, 0))
+	form.Show()
+}
+
+// onDisableAppLock removes the app lock passwords after confirmation.
+func (p *SettingsConfigurationPage) onDisableAppLock() {
+	dialog.ShowConfirm(i18n.T("Disable Lock"), i18n.T("Remove the admin and operator passwords? DB-BenchMind will no longer be locked."), func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		if err := p.accessUC.DisableLock(context.Background()); err != nil {
+			slog.Error("Settings: Failed to disable app lock", "error", err)
+			dialog.ShowError(fmt.Errorf(i18n.T("disable lock: %w"), err), p.win)
+			return
+		}
+		p.refreshAppLockStatus()
+	}, p.win)
+}
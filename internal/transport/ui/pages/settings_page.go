@@ -3,13 +3,26 @@
 package pages
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/internal/infra/tool"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
 )
 
 // SettingsConfigurationPage provides the settings configuration GUI.
@@ -18,22 +31,128 @@ type SettingsConfigurationPage struct {
 	sysbenchPath *widget.Entry
 	swingPath    *widget.Entry
 	hammerPath   *widget.Entry
+	psqlPath     *widget.Entry
+	mysqlPath    *widget.Entry
 	javaPath     *widget.Entry
 	timeoutEntry *widget.Entry
+
+	// History saving and retention
+	autoSaveCheck   *widget.Check
+	maxAgeEntry     *widget.Entry
+	maxRecordsEntry *widget.Entry
+	archiveCheck    *widget.Check
+	archiveDirEntry *widget.Entry
+	trendAlertEntry *widget.Entry // Deviation from the trend that fires trend_alert webhooks
+
+	// Exports
+	exportDirEntry  *widget.Entry
+	exportNameEntry *widget.Entry
+
+	// Email notifications
+	notifyEnabledCheck *widget.Check
+	smtpHostEntry      *widget.Entry
+	smtpPortEntry      *widget.Entry
+	smtpSecuritySelect *widget.Select
+	smtpUserEntry      *widget.Entry
+	smtpPasswordEntry  *widget.Entry
+	notifyFromEntry    *widget.Entry
+	notifyToEntry      *widget.Entry
+	notifySuccessCheck *widget.Check
+	notifyFailureCheck *widget.Check
+	notifyAttachCheck  *widget.Check
+
+	// Webhooks
+	webhooks        []config.WebhookConfig
+	webhookList     *widget.List
+	selectedWebhook int
+
+	// Sanity checks
+	sanityChecks  []history.SanityCheck
+	checkList     *widget.List
+	selectedCheck int
+
+	// UI language
+	languageSelect    *widget.Select
+	onLanguageChanged func(i18n.Language)
+
+	// Appearance
+	themeSelect         *widget.Select
+	fontScaleSelect     *widget.Select
+	monospaceFontEntry  *widget.Entry
+	onAppearanceChanged func(config.UIConfig)
+
+	// Logging
+	logFormatSelect    *widget.Select
+	logLevelSelect     *widget.Select
+	moduleLevelSelects map[string]*widget.Select
+	logSizeEntry       *widget.Entry
+	logFilesEntry      *widget.Entry
+	logAgeEntry        *widget.Entry
+	onLoggingChanged   func(config.AdvancedConfig)
+
+	// Updates
+	updateCheck       *widget.Check
+	updateStatus      *widget.Label
+	releaseNotes      *widget.RichText
+	releaseNotesBox   *fyne.Container
+	btnDownload       *widget.Button
+	btnReleasePage    *widget.Button
+	latestReleaseInfo *usecase.UpdateInfo
+
+	// App lock
+	appLockStatus *widget.Label
+	onLock        func()
+
+	maintenanceUC *usecase.MaintenanceUseCase
+	settingsUC    *usecase.SettingsUseCase
+	historyUC     *usecase.HistoryUseCase
+	notifyUC      *usecase.NotificationUseCase
+	accessUC      *usecase.AccessUseCase
+	diagnosticsUC *usecase.DiagnosticsUseCase
+	updateUC      *usecase.UpdateUseCase
+	demoUC        *usecase.DemoUseCase
 }
 
 // NewSettingsConfigurationPage creates a new settings page.
 func NewSettingsConfigurationPage(win fyne.Window, connUC interface{}) fyne.CanvasObject {
+	return NewSettingsConfigurationPageWithUC(win, connUC, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+}
+
+// NewSettingsConfigurationPageWithUC creates a new settings page with database maintenance,
+// history retention, email notification, UI language, appearance, logging, diagnostics,
+// update check, demo mode and app lock support.
+// onLanguageChanged is called after a new UI language is saved,
+// onAppearanceChanged after new appearance settings are saved,
+// onLoggingChanged after new logging settings are saved and onLock
+// when the app is to be locked.
+func NewSettingsConfigurationPageWithUC(win fyne.Window, connUC interface{}, maintenanceUC *usecase.MaintenanceUseCase, settingsUC *usecase.SettingsUseCase, historyUC *usecase.HistoryUseCase, notificationUC *usecase.NotificationUseCase, accessUC *usecase.AccessUseCase, diagnosticsUC *usecase.DiagnosticsUseCase, updateUC *usecase.UpdateUseCase, demoUC *usecase.DemoUseCase, onLanguageChanged func(i18n.Language), onAppearanceChanged func(config.UIConfig), onLoggingChanged func(config.AdvancedConfig), onLock func()) fyne.CanvasObject {
 	page := &SettingsConfigurationPage{
-		win: win,
+		win:                 win,
+		maintenanceUC:       maintenanceUC,
+		settingsUC:          settingsUC,
+		historyUC:           historyUC,
+		notifyUC:            notificationUC,
+		accessUC:            accessUC,
+		diagnosticsUC:       diagnosticsUC,
+		updateUC:            updateUC,
+		demoUC:              demoUC,
+		onLanguageChanged:   onLanguageChanged,
+		onAppearanceChanged: onAppearanceChanged,
+		onLoggingChanged:    onLoggingChanged,
+		onLock:              onLock,
 	}
-	// Create form fields
+	// Create form fields; empty tool paths are looked up in PATH
 	page.sysbenchPath = widget.NewEntry()
-	page.sysbenchPath.SetText("/usr/bin/sysbench")
 	page.swingPath = widget.NewEntry()
-	page.swingPath.SetText("/opt/swingbench/bin/oowbench")
 	page.hammerPath = widget.NewEntry()
-	page.hammerPath.SetText("/opt/HammerDB/hammerdbcli")
+	page.psqlPath = widget.NewEntry()
+	page.mysqlPath = widget.NewEntry()
+	for _, entry := range page.toolPathEntries() {
+		entry.SetPlaceHolder(i18n.T("Found in PATH"))
+	}
+	if settingsUC != nil {
+		page.loadToolPaths()
+	}
 	page.javaPath = widget.NewEntry()
 	page.javaPath.SetText("/usr/bin/java")
 	page.timeoutEntry = widget.NewEntry()
@@ -41,54 +160,1389 @@ func NewSettingsConfigurationPage(win fyne.Window, connUC interface{}) fyne.Canv
 	// Create form
 	form := &widget.Form{
 		Items: []*widget.FormItem{
-			widget.NewFormItem("Sysbench Path", page.sysbenchPath),
-			widget.NewFormItem("Swingbench Path", page.swingPath),
-			widget.NewFormItem("HammerDB Path", page.hammerPath),
-			widget.NewFormItem("Java Path", page.javaPath),
-			widget.NewFormItem("Default Timeout (sec)", page.timeoutEntry),
+			widget.NewFormItem(i18n.T("Sysbench Path"), page.sysbenchPath),
+			widget.NewFormItem(i18n.T("Swingbench Path"), page.swingPath),
+			widget.NewFormItem(i18n.T("HammerDB Path"), page.hammerPath),
+			widget.NewFormItem(i18n.T("psql Path"), page.psqlPath),
+			widget.NewFormItem(i18n.T("mysql Client Path"), page.mysqlPath),
+			widget.NewFormItem(i18n.T("Java Path"), page.javaPath),
+			widget.NewFormItem(i18n.T("Default Timeout (sec)"), page.timeoutEntry),
 		},
 	}
 	// Create buttons
-	btnDetect := widget.NewButton("Detect Tools", func() {
+	btnDetect := widget.NewButton(i18n.T("Detect Tools"), func() {
 		page.onDetectTools()
 	})
-	btnSave := widget.NewButton("Save Settings", func() {
+	btnSave := widget.NewButton(i18n.T("Save Settings"), func() {
 		page.onSaveSettings()
 	})
-	btnReset := widget.NewButton("Reset to Defaults", func() {
+	btnReset := widget.NewButton(i18n.T("Reset to Defaults"), func() {
 		page.onResetSettings()
 	})
 	toolbar := container.NewHBox(btnDetect, btnSave, btnReset)
 	// Help text
-	helpLabel := widget.NewLabel("Configure benchmark tool paths and default settings.\nClick 'Detect Tools' to automatically find installed tools.")
-	content := container.NewVBox(
-		widget.NewCard("Tool Paths", "", container.NewPadded(form)),
+	helpLabel := widget.NewLabel(i18n.T("Configure benchmark tool paths and default settings. Empty paths are found in PATH.\nThe Swingbench path is the charbench executable; oewizard must be in the same directory.\nSaving checks that each tool runs and is a supported version.\nClick 'Detect Tools' to automatically find installed tools."))
+	content := container.NewVBox()
+	if settingsUC != nil {
+		content.Add(page.createLanguageCard())
+		content.Add(widget.NewSeparator())
+		content.Add(page.createAppearanceCard())
+		content.Add(widget.NewSeparator())
+		content.Add(page.createLoggingCard())
+		content.Add(widget.NewSeparator())
+	}
+	if settingsUC != nil && updateUC != nil {
+		content.Add(page.createUpdateCard())
+		content.Add(widget.NewSeparator())
+	}
+	if settingsUC != nil && demoUC != nil {
+		content.Add(page.createDemoCard())
+		content.Add(widget.NewSeparator())
+	}
+	content.Objects = append(content.Objects,
+		widget.NewCard(i18n.T("Tool Paths"), "", container.NewPadded(form)),
 		widget.NewSeparator(),
 		helpLabel,
 		widget.NewSeparator(),
 		toolbar,
 	)
+	if maintenanceUC != nil {
+		btnCompact := widget.NewButton(i18n.T("Vacuum / Compact Database"), func() {
+			page.onCompactDatabase()
+		})
+		maintenanceLabel := widget.NewLabel(i18n.T("Recompress stored benchmark outputs and VACUUM the database to reclaim disk space."))
+		content.Add(widget.NewSeparator())
+		content.Add(widget.NewCard(i18n.T("Database Maintenance"), "", container.NewVBox(maintenanceLabel, container.NewHBox(btnCompact))))
+	}
+	if settingsUC != nil && historyUC != nil {
+		content.Add(widget.NewSeparator())
+		content.Add(page.createRetentionCard())
+		content.Add(widget.NewSeparator())
+		content.Add(page.createSanityCheckCard())
+	}
+	if settingsUC != nil {
+		content.Add(widget.NewSeparator())
+		content.Add(page.createExportCard())
+	}
+	if settingsUC != nil && notificationUC != nil {
+		content.Add(widget.NewSeparator())
+		content.Add(page.createNotificationCard())
+		content.Add(widget.NewSeparator())
+		content.Add(page.createWebhookCard())
+	}
+	if accessUC != nil {
+		content.Add(widget.NewSeparator())
+		content.Add(page.createAppLockCard())
+	}
 	return content
 }
 
+// createLanguageCard creates the UI language settings card.
+func (p *SettingsConfigurationPage) createLanguageCard() fyne.CanvasObject {
+	languages := i18n.Languages()
+	names := make([]string, len(languages))
+	for i, lang := range languages {
+		names[i] = lang.Name()
+	}
+	p.languageSelect = widget.NewSelect(names, nil)
+	p.languageSelect.SetSelectedIndex(slices.Index(languages, i18n.Current()))
+
+	btnApply := widget.NewButton(i18n.T("Apply"), func() {
+		if i := p.languageSelect.SelectedIndex(); i >= 0 {
+			p.onSaveLanguage(languages[i])
+		}
+	})
+	form := widget.NewForm(widget.NewFormItem(i18n.T("Language"), p.languageSelect))
+
+	return widget.NewCard(i18n.T("Language"), "", container.NewVBox(form, container.NewHBox(btnApply)))
+}
+
+// onSaveLanguage saves the UI language and lets the application switch to it.
+func (p *SettingsConfigurationPage) onSaveLanguage(lang i18n.Language) {
+	ctx := context.Background()
+	uiCfg, err := p.settingsUC.GetUIConfig(ctx)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("load UI settings: %w"), err), p.win)
+		return
+	}
+	uiCfg.Language = string(lang)
+	if err := p.settingsUC.UpdateUIConfig(ctx, *uiCfg); err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("save UI settings: %w"), err), p.win)
+		return
+	}
+
+	slog.Info("Settings: UI language saved", "language", lang)
+	if p.onLanguageChanged != nil {
+		p.onLanguageChanged(lang)
+	}
+}
+
+// Theme options of the appearance card, in the order of themeValues.
+var themeOptions = []string{"System", "Light", "Dark"}
+
+// themeValues are the ui.theme values of themeOptions.
+var themeValues = []string{config.ThemeAuto, config.ThemeLight, config.ThemeDark}
+
+// fontScales are the font scales offered by the appearance card.
+var fontScales = []float64{0.9, 1.0, 1.1, 1.25, 1.5, 1.75, 2.0}
+
+// createAppearanceCard creates the theme and font settings card.
+func (p *SettingsConfigurationPage) createAppearanceCard() fyne.CanvasObject {
+	p.themeSelect = widget.NewSelect(i18n.TList(themeOptions), nil)
+	scaleLabels := make([]string, len(fontScales))
+	for i, scale := range fontScales {
+		scaleLabels[i] = fmt.Sprintf("%d%%", int(scale*100))
+	}
+	p.fontScaleSelect = widget.NewSelect(scaleLabels, nil)
+	p.monospaceFontEntry = widget.NewEntry()
+	p.monospaceFontEntry.SetPlaceHolder(i18n.T("Built-in font"))
+
+	uiCfg := config.DefaultConfig().UI
+	if cfg, err := p.settingsUC.GetUIConfig(context.Background()); err == nil {
+		uiCfg = *cfg
+	} else {
+		slog.Warn("Settings: Failed to load UI settings", "error", err)
+	}
+	p.themeSelect.SetSelectedIndex(max(slices.Index(themeValues, uiCfg.Theme), 0))
+	p.fontScaleSelect.SetSelectedIndex(max(slices.Index(fontScales, uiCfg.Scale()), slices.Index(fontScales, 1.0)))
+	p.monospaceFontEntry.SetText(uiCfg.MonospaceFont)
+
+	btnApply := widget.NewButton(i18n.T("Apply"), func() {
+		p.onSaveAppearance()
+	})
+	form := widget.NewForm(
+		widget.NewFormItem(i18n.T("Theme"), p.themeSelect),
+		widget.NewFormItem(i18n.T("Font Size"), p.fontScaleSelect),
+		widget.NewFormItem(i18n.T("Log Font (TTF/OTF)"), p.monospaceFontEntry),
+	)
+	helpLabel := widget.NewLabel(i18n.T("The log font is used for the realtime log output. Leave it empty for the built-in monospace font."))
+
+	return widget.NewCard(i18n.T("Appearance"), "", container.NewVBox(form, helpLabel, container.NewHBox(btnApply)))
+}
+
+// onSaveAppearance saves the theme and font settings and lets the
+// application apply them.
+func (p *SettingsConfigurationPage) onSaveAppearance() {
+	ctx := context.Background()
+	uiCfg, err := p.settingsUC.GetUIConfig(ctx)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("load UI settings: %w"), err), p.win)
+		return
+	}
+	if i := p.themeSelect.SelectedIndex(); i >= 0 {
+		uiCfg.Theme = themeValues[i]
+	}
+	if i := p.fontScaleSelect.SelectedIndex(); i >= 0 {
+		uiCfg.FontScale = fontScales[i]
+	}
+	uiCfg.MonospaceFont = strings.TrimSpace(p.monospaceFontEntry.Text)
+	if err := p.settingsUC.UpdateUIConfig(ctx, *uiCfg); err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("save UI settings: %w"), err), p.win)
+		return
+	}
+
+	slog.Info("Settings: Appearance saved", "theme", uiCfg.Theme, "font_scale", uiCfg.FontScale, "monospace_font", uiCfg.MonospaceFont)
+	if p.onAppearanceChanged != nil {
+		p.onAppearanceChanged(*uiCfg)
+	}
+}
+
+// Log format options of the logging card, in the order of logFormatValues.
+var logFormatOptions = []string{"Text", "JSON"}
+
+// logFormatValues are the advanced.log_format values of logFormatOptions.
+var logFormatValues = []string{config.LogFormatText, config.LogFormatJSON}
+
+// logLevelValues are the logging levels offered by the logging card.
+var logLevelValues = []string{"debug", "info", "warn", "error"}
+
+// createLoggingCard creates the log format, level and log file settings
+// card, with the log folder and diagnostics bundle buttons when diagnostics
+// are available. Each module of config.LogModules logs at the global level
+// unless it has its own.
+func (p *SettingsConfigurationPage) createLoggingCard() fyne.CanvasObject {
+	p.logFormatSelect = widget.NewSelect(logFormatOptions, nil)
+	p.logLevelSelect = widget.NewSelect(logLevelValues, nil)
+	moduleOptions := append([]string{i18n.T("Global level")}, logLevelValues...)
+
+	advCfg := config.DefaultConfig().Advanced
+	if cfg, err := p.settingsUC.GetAdvancedConfig(context.Background()); err == nil {
+		advCfg = *cfg
+	} else {
+		slog.Warn("Settings: Failed to load advanced settings", "error", err)
+	}
+	p.logFormatSelect.SetSelectedIndex(max(slices.Index(logFormatValues, advCfg.LogFormat), 0))
+	p.logLevelSelect.SetSelectedIndex(max(slices.Index(logLevelValues, advCfg.LogLevel), slices.Index(logLevelValues, "info")))
+
+	form := widget.NewForm(
+		widget.NewFormItem(i18n.T("Log Format"), p.logFormatSelect),
+		widget.NewFormItem(i18n.T("Log Level"), p.logLevelSelect),
+	)
+	p.moduleLevelSelects = make(map[string]*widget.Select, len(config.LogModules))
+	for _, module := range config.LogModules {
+		sel := widget.NewSelect(moduleOptions, nil)
+		// Index 0 is the global level, so an unset module selects it
+		sel.SetSelectedIndex(slices.Index(logLevelValues, advCfg.ModuleLogLevels[module]) + 1)
+		p.moduleLevelSelects[module] = sel
+		form.Append(fmt.Sprintf(i18n.T("Level of %s"), module), sel)
+	}
+
+	p.logSizeEntry = widget.NewEntry()
+	p.logSizeEntry.SetPlaceHolder(i18n.T("0 = daily only"))
+	p.logSizeEntry.SetText(strconv.Itoa(advCfg.MaxLogSizeMB))
+	p.logFilesEntry = widget.NewEntry()
+	p.logFilesEntry.SetPlaceHolder(i18n.T("0 = unlimited"))
+	p.logFilesEntry.SetText(strconv.Itoa(advCfg.MaxLogFiles))
+	p.logAgeEntry = widget.NewEntry()
+	p.logAgeEntry.SetPlaceHolder(i18n.T("0 = keep forever"))
+	p.logAgeEntry.SetText(strconv.Itoa(advCfg.MaxLogAgeDays))
+	form.Append(i18n.T("Rotate at Size (MB)"), p.logSizeEntry)
+	form.Append(i18n.T("Max Log Files"), p.logFilesEntry)
+	form.Append(i18n.T("Max Log Age (days)"), p.logAgeEntry)
+
+	btnApply := widget.NewButton(i18n.T("Apply"), func() {
+		p.onSaveLogging()
+	})
+	buttons := container.NewHBox(btnApply)
+	if p.diagnosticsUC != nil {
+		buttons.Add(widget.NewButton(i18n.T("📂 Open Logs Folder"), func() {
+			openInFileManager(p.win, p.diagnosticsUC.LogDir())
+		}))
+		buttons.Add(widget.NewButton(i18n.T("Collect Diagnostics Bundle"), func() {
+			p.onCollectDiagnostics()
+		}))
+	}
+	helpLabel := widget.NewLabel(i18n.T("Levels and log file limits apply immediately. A new log format applies the next time DB-BenchMind starts.\nThe --log-format and --log-level options override these settings for one run.\nRotated log files are compressed; the limits count the files of the GUI and the CLI separately."))
+
+	return widget.NewCard(i18n.T("Logging"), "", container.NewVBox(form, helpLabel, buttons))
+}
+
+// onSaveLogging saves the log format and levels and lets the application
+// apply the levels.
+func (p *SettingsConfigurationPage) onSaveLogging() {
+	ctx := context.Background()
+	advCfg, err := p.settingsUC.GetAdvancedConfig(ctx)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("load advanced settings: %w"), err), p.win)
+		return
+	}
+	if i := p.logFormatSelect.SelectedIndex(); i >= 0 {
+		advCfg.LogFormat = logFormatValues[i]
+	}
+	if i := p.logLevelSelect.SelectedIndex(); i >= 0 {
+		advCfg.LogLevel = logLevelValues[i]
+	}
+	for _, field := range []struct {
+		entry *widget.Entry
+		value *int
+	}{
+		{p.logSizeEntry, &advCfg.MaxLogSizeMB},
+		{p.logFilesEntry, &advCfg.MaxLogFiles},
+		{p.logAgeEntry, &advCfg.MaxLogAgeDays},
+	} {
+		value, err := strconv.Atoi(strings.TrimSpace(field.entry.Text))
+		if err != nil || value < 0 {
+			dialog.ShowError(fmt.Errorf(i18n.T("invalid number: %q"), field.entry.Text), p.win)
+			return
+		}
+		*field.value = value
+	}
+	advCfg.ModuleLogLevels = nil
+	for module, sel := range p.moduleLevelSelects {
+		if i := sel.SelectedIndex(); i > 0 {
+			if advCfg.ModuleLogLevels == nil {
+				advCfg.ModuleLogLevels = make(map[string]string)
+			}
+			advCfg.ModuleLogLevels[module] = logLevelValues[i-1]
+		}
+	}
+	if err := p.settingsUC.UpdateAdvancedConfig(ctx, *advCfg); err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("save advanced settings: %w"), err), p.win)
+		return
+	}
+
+	slog.Info("Settings: Logging saved", "format", advCfg.LogFormat, "level", advCfg.LogLevel, "modules", advCfg.ModuleLogLevels,
+		"max_log_size_mb", advCfg.MaxLogSizeMB, "max_log_files", advCfg.MaxLogFiles, "max_log_age_days", advCfg.MaxLogAgeDays)
+	if p.onLoggingChanged != nil {
+		p.onLoggingChanged(*advCfg)
+	}
+}
+
+// onCollectDiagnostics writes a diagnostics bundle to the export directory.
+func (p *SettingsConfigurationPage) onCollectDiagnostics() {
+	dest := p.diagnosticsUC.BundlePath(context.Background())
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("create export directory: %w"), err), p.win)
+		return
+	}
+	progress := dialog.NewCustomWithoutButtons(i18n.T("Collecting Diagnostics"), widget.NewProgressBarInfinite(), p.win)
+	progress.Show()
+	go func() {
+		system, err := p.diagnosticsUC.Collect(context.Background(), dest)
+		fyne.Do(func() {
+			progress.Hide()
+			if err != nil {
+				dialog.ShowError(fmt.Errorf(i18n.T("collect diagnostics: %w"), err), p.win)
+				return
+			}
+			showExportResult(p.win, i18n.T("Diagnostics Bundle"), i18n.Tf(
+				"Diagnostics bundle written to:\n%s\n\nLog files: %d\nCrash reports: %d\nRecent runs: %d\nPasswords, webhook URL paths and secret run parameters are left out. Check the logs before sharing the bundle.",
+				dest, len(system.LogFiles), len(system.CrashReports), system.Runs), filepath.Dir(dest))
+		})
+	}()
+}
+
+// createUpdateCard creates the update check card. It checks for a new
+// version in the background when automatic checks are enabled and no check
+// was made yet.
+func (p *SettingsConfigurationPage) createUpdateCard() fyne.CanvasObject {
+	ctx := context.Background()
+	p.updateCheck = widget.NewCheck(i18n.T("Check for updates at startup"), nil)
+	p.updateCheck.SetChecked(p.updateUC.Enabled(ctx))
+	p.updateCheck.OnChanged = p.onSaveUpdateCheck
+	p.updateStatus = widget.NewLabel(i18n.T("Not checked yet."))
+	p.updateStatus.Wrapping = fyne.TextWrapWord
+	p.releaseNotes = widget.NewRichTextFromMarkdown("")
+	p.releaseNotes.Wrapping = fyne.TextWrapWord
+	notesScroll := container.NewVScroll(p.releaseNotes)
+	notesScroll.SetMinSize(fyne.NewSize(0, 160))
+	p.releaseNotesBox = container.NewVBox(widget.NewLabel(i18n.T("Release Notes")), notesScroll)
+	p.releaseNotesBox.Hide()
+
+	btnCheck := widget.NewButton(i18n.T("Check Now"), func() {
+		p.onCheckForUpdates(true)
+	})
+	p.btnDownload = widget.NewButton(i18n.T("Download"), func() {
+		p.onDownloadUpdate()
+	})
+	p.btnDownload.Disable()
+	p.btnReleasePage = widget.NewButton(i18n.T("Open Release Page"), func() {
+		p.onOpenReleasePage()
+	})
+	p.btnReleasePage.Disable()
+
+	if info := p.updateUC.Last(); info != nil {
+		p.showUpdateInfo(info)
+	} else if p.updateCheck.Checked {
+		p.onCheckForUpdates(false)
+	}
+
+	helpLabel := widget.NewLabel(i18n.T("Update checks query the releases of DB-BenchMind on GitHub. Nothing else is sent.\nDownloads are verified against the checksums published with the release and are not installed automatically."))
+	return widget.NewCard(i18n.T("Updates"), "", container.NewVBox(
+		p.updateCheck, p.updateStatus, p.releaseNotesBox, helpLabel,
+		container.NewHBox(btnCheck, p.btnDownload, p.btnReleasePage),
+	))
+}
+
+// onSaveUpdateCheck saves whether updates are checked at startup.
+func (p *SettingsConfigurationPage) onSaveUpdateCheck(checked bool) {
+	ctx := context.Background()
+	advCfg, err := p.settingsUC.GetAdvancedConfig(ctx)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("load advanced settings: %w"), err), p.win)
+		return
+	}
+	advCfg.CheckUpdates = checked
+	if err := p.settingsUC.UpdateAdvancedConfig(ctx, *advCfg); err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("save advanced settings: %w"), err), p.win)
+		return
+	}
+	slog.Info("Settings: Update check saved", "check_updates", checked)
+}
+
+// onCheckForUpdates checks for a new version in the background. Errors of
+// checks the user asked for are shown in a dialog, others in the status only.
+func (p *SettingsConfigurationPage) onCheckForUpdates(manual bool) {
+	p.updateStatus.SetText(i18n.T("Checking for updates..."))
+	go func() {
+		info, err := p.updateUC.Check(context.Background())
+		fyne.Do(func() {
+			if err != nil {
+				slog.Warn("Settings: Update check failed", "error", err)
+				p.updateStatus.SetText(i18n.Tf("Update check failed: %v", err))
+				if manual {
+					dialog.ShowError(err, p.win)
+				}
+				return
+			}
+			p.showUpdateInfo(info)
+		})
+	}()
+}
+
+// showUpdateInfo shows the result of an update check.
+func (p *SettingsConfigurationPage) showUpdateInfo(info *usecase.UpdateInfo) {
+	p.latestReleaseInfo = info
+	p.btnReleasePage.Enable()
+	if !info.Available {
+		p.updateStatus.SetText(i18n.Tf("DB-BenchMind %s is up to date (latest release %s, checked %s).",
+			info.Current, info.Release.Version, info.CheckedAt.Format("2006-01-02 15:04")))
+		p.releaseNotesBox.Hide()
+		p.btnDownload.Disable()
+		return
+	}
+
+	status := i18n.Tf("New version available: %s (running %s).", info.Release.Version, info.Current)
+	if info.Asset == nil {
+		status += " " + i18n.T("The release has no binary for this platform; download it from the release page.")
+		p.btnDownload.Disable()
+	} else {
+		p.btnDownload.Enable()
+	}
+	p.updateStatus.SetText(status)
+	notes := info.Release.Notes
+	if strings.TrimSpace(notes) == "" {
+		notes = i18n.T("The release has no release notes.")
+	}
+	p.releaseNotes.ParseMarkdown(notes)
+	p.releaseNotesBox.Show()
+}
+
+// onDownloadUpdate downloads the binary of the latest release.
+func (p *SettingsConfigurationPage) onDownloadUpdate() {
+	info := p.latestReleaseInfo
+	if info == nil {
+		return
+	}
+	progress := dialog.NewCustomWithoutButtons(i18n.Tf("Downloading %s", info.Release.Version), widget.NewProgressBarInfinite(), p.win)
+	progress.Show()
+	go func() {
+		path, verified, err := p.updateUC.Download(context.Background(), info)
+		fyne.Do(func() {
+			progress.Hide()
+			if err != nil {
+				dialog.ShowError(fmt.Errorf(i18n.T("download update: %w"), err), p.win)
+				return
+			}
+			checksum := i18n.T("The checksum of the download was verified.")
+			if !verified {
+				checksum = i18n.T("The release publishes no checksums; the download was not verified.")
+			}
+			showExportResult(p.win, i18n.T("Update Downloaded"), i18n.Tf(
+				"DB-BenchMind %s downloaded to:\n%s\n\n%s\nQuit DB-BenchMind and replace the program with the downloaded file to update.",
+				info.Release.Version, path, checksum), filepath.Dir(path))
+		})
+	}()
+}
+
+// onOpenReleasePage opens the page of the latest release in the browser.
+func (p *SettingsConfigurationPage) onOpenReleasePage() {
+	info := p.latestReleaseInfo
+	if info == nil || info.Release.URL == "" {
+		return
+	}
+	u, err := url.Parse(info.Release.URL)
+	if err == nil {
+		err = fyne.CurrentApp().OpenURL(u)
+	}
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("failed to open %s: %v"), info.Release.URL, err), p.win)
+	}
+}
+
+// createDemoCard creates the demo mode card, which also adds and removes
+// the demo history records.
+func (p *SettingsConfigurationPage) createDemoCard() fyne.CanvasObject {
+	demoCheck := widget.NewCheck(i18n.T("Demo mode: simulate runs without a database"), nil)
+	demoCheck.SetChecked(p.demoUC.Enabled(context.Background()))
+	demoCheck.OnChanged = p.onSaveDemoMode
+
+	btnSeed := widget.NewButton(i18n.T("Add Demo History"), func() {
+		p.onSeedDemoHistory()
+	})
+	btnRemove := widget.NewButton(i18n.T("Remove Demo History"), func() {
+		p.onRemoveDemoHistory()
+	})
+	helpLabel := widget.NewLabel(i18n.T("In demo mode the Run button of the Tasks tab generates realistic synthetic samples and results instead of running a benchmark tool; no connection or template is needed.\nDemo runs are saved to History tagged \"demo\", so the reports, exports and comparisons can be tried offline.\nAdd Demo History saves a thread-scaling series before and after tuning."))
+	return widget.NewCard(i18n.T("Demo Mode"), "", container.NewVBox(demoCheck, helpLabel, container.NewHBox(btnSeed, btnRemove)))
+}
+
+// onSaveDemoMode saves whether demo mode is on.
+func (p *SettingsConfigurationPage) onSaveDemoMode(enabled bool) {
+	ctx := context.Background()
+	advCfg, err := p.settingsUC.GetAdvancedConfig(ctx)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("load advanced settings: %w"), err), p.win)
+		return
+	}
+	advCfg.DemoMode = enabled
+	if err := p.settingsUC.UpdateAdvancedConfig(ctx, *advCfg); err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("save advanced settings: %w"), err), p.win)
+		return
+	}
+	slog.Info("Settings: Demo mode saved", "demo_mode", enabled)
+}
+
+// onSeedDemoHistory saves the demo history records.
+func (p *SettingsConfigurationPage) onSeedDemoHistory() {
+	n, err := p.demoUC.SeedHistory(context.Background())
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("add demo history: %w"), err), p.win)
+		return
+	}
+	dialog.ShowInformation(i18n.T("Demo History"), i18n.Tf("%d demo runs saved to History.", n), p.win)
+}
+
+// onRemoveDemoHistory deletes the history records tagged demo after confirmation.
+func (p *SettingsConfigurationPage) onRemoveDemoHistory() {
+	dialog.ShowConfirm(i18n.T("Remove Demo History"), i18n.T("Delete all History records tagged \"demo\"?"), func(ok bool) {
+		if !ok {
+			return
+		}
+		n, err := p.demoUC.RemoveHistory(context.Background())
+		if err != nil {
+			dialog.ShowError(fmt.Errorf(i18n.T("remove demo history: %w"), err), p.win)
+			return
+		}
+		dialog.ShowInformation(i18n.T("Demo History"), i18n.Tf("%d demo runs deleted from History.", n), p.win)
+	}, p.win)
+}
+
+// createRetentionCard creates the history saving and retention settings card.
+func (p *SettingsConfigurationPage) createRetentionCard() fyne.CanvasObject {
+	p.autoSaveCheck = widget.NewCheck(i18n.T("Automatically save completed runs to History"), nil)
+	p.autoSaveCheck.SetChecked(true)
+	p.maxAgeEntry = widget.NewEntry()
+	p.maxAgeEntry.SetPlaceHolder(i18n.T("0 = keep forever"))
+	p.maxRecordsEntry = widget.NewEntry()
+	p.maxRecordsEntry.SetPlaceHolder(i18n.T("0 = unlimited"))
+	p.archiveDirEntry = widget.NewEntry()
+	p.trendAlertEntry = widget.NewEntry()
+	p.trendAlertEntry.SetPlaceHolder(i18n.T("0 = off"))
+	p.archiveCheck = widget.NewCheck(i18n.T("Archive records to compressed JSON before deleting"), func(checked bool) {
+		if checked {
+			p.archiveDirEntry.Enable()
+		} else {
+			p.archiveDirEntry.Disable()
+		}
+	})
+
+	if cfg, err := p.settingsUC.GetHistoryConfig(context.Background()); err != nil {
+		slog.Warn("Settings: Failed to load history retention config", "error", err)
+	} else {
+		p.autoSaveCheck.SetChecked(cfg.AutoSave())
+		p.maxAgeEntry.SetText(strconv.Itoa(cfg.MaxAgeDays))
+		p.maxRecordsEntry.SetText(strconv.Itoa(cfg.MaxRecords))
+		p.archiveDirEntry.SetText(cfg.ArchiveDir)
+		p.archiveCheck.SetChecked(cfg.ArchiveBeforePurge)
+		p.trendAlertEntry.SetText(strconv.FormatFloat(cfg.TrendAlertPercent, 'f', -1, 64))
+	}
+
+	form := &widget.Form{
+		Items: []*widget.FormItem{
+			widget.NewFormItem("", p.autoSaveCheck),
+			widget.NewFormItem(i18n.T("Max Age (days)"), p.maxAgeEntry),
+			widget.NewFormItem(i18n.T("Max Records"), p.maxRecordsEntry),
+			widget.NewFormItem("", p.archiveCheck),
+			widget.NewFormItem(i18n.T("Archive Directory"), p.archiveDirEntry),
+			widget.NewFormItem(i18n.T("Trend Alert (%)"), p.trendAlertEntry),
+		},
+	}
+	btnSave := widget.NewButton(i18n.T("Save History Settings"), func() {
+		p.onSaveRetention()
+	})
+	btnPurge := widget.NewButton(i18n.T("Purge Now"), func() {
+		p.onPurgeNow()
+	})
+	helpLabel := widget.NewLabel(i18n.T("With automatic saving, failed and cancelled runs are saved too, with their state. Old history records are purged automatically in the background. A trend alert fires the trend_alert webhooks when the TPS or p95 latency of a run deviates from the earlier runs of its configuration by more than the given percentage."))
+	helpLabel.Wrapping = fyne.TextWrapWord
+
+	return widget.NewCard(i18n.T("History"), "", container.NewVBox(form, helpLabel, container.NewHBox(btnSave, btnPurge)))
+}
+
+// retentionConfig reads the history saving and retention settings from the form.
+func (p *SettingsConfigurationPage) retentionConfig() (*config.HistoryConfig, error) {
+	cfg, err := p.settingsUC.GetHistoryConfig(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	maxAge, err := strconv.Atoi(strings.TrimSpace(p.maxAgeEntry.Text))
+	if err != nil || maxAge < 0 {
+		return nil, fmt.Errorf(i18n.T("invalid max age: %q"), p.maxAgeEntry.Text)
+	}
+	maxRecords, err := strconv.Atoi(strings.TrimSpace(p.maxRecordsEntry.Text))
+	if err != nil || maxRecords < 0 {
+		return nil, fmt.Errorf(i18n.T("invalid max records: %q"), p.maxRecordsEntry.Text)
+	}
+	trendAlert := 0.0
+	if text := strings.TrimSpace(p.trendAlertEntry.Text); text != "" {
+		if trendAlert, err = strconv.ParseFloat(text, 64); err != nil || trendAlert < 0 {
+			return nil, fmt.Errorf(i18n.T("invalid trend alert: %q"), p.trendAlertEntry.Text)
+		}
+	}
+
+	cfg.ManualSave = !p.autoSaveCheck.Checked
+	cfg.MaxAgeDays = maxAge
+	cfg.MaxRecords = maxRecords
+	cfg.ArchiveBeforePurge = p.archiveCheck.Checked
+	cfg.ArchiveDir = strings.TrimSpace(p.archiveDirEntry.Text)
+	cfg.TrendAlertPercent = trendAlert
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// onSaveRetention saves the history saving and retention settings.
+func (p *SettingsConfigurationPage) onSaveRetention() {
+	cfg, err := p.retentionConfig()
+	if err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+	if err := p.settingsUC.UpdateHistoryConfig(context.Background(), *cfg); err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("save retention settings: %w"), err), p.win)
+		return
+	}
+	dialog.ShowInformation(i18n.T("Success"), i18n.T("History settings saved"), p.win)
+}
+
+// createExportCard creates the export directory and file name settings card.
+func (p *SettingsConfigurationPage) createExportCard() fyne.CanvasObject {
+	p.exportDirEntry = widget.NewEntry()
+	p.exportDirEntry.SetPlaceHolder(i18n.T("Default: exports in the application home directory"))
+	p.exportNameEntry = widget.NewEntry()
+	p.exportNameEntry.SetPlaceHolder(config.DefaultExportFileName)
+
+	if cfg, err := p.settingsUC.GetExportConfig(context.Background()); err != nil {
+		slog.Warn("Settings: Failed to load export config", "error", err)
+	} else {
+		p.exportDirEntry.SetText(cfg.Dir)
+		p.exportNameEntry.SetText(cfg.FileName)
+	}
+
+	form := &widget.Form{
+		Items: []*widget.FormItem{
+			widget.NewFormItem(i18n.T("Export Directory"), p.exportDirEntry),
+			widget.NewFormItem(i18n.T("File Name"), p.exportNameEntry),
+		},
+	}
+	btnSave := widget.NewButton(i18n.T("Save Export Settings"), func() {
+		p.onSaveExport()
+	})
+	helpLabel := widget.NewLabel(i18n.Tf("History records and comparison reports are exported to the export directory. The file name of exported records may use %s; the extension is added.",
+		strings.Join(config.ExportFileNamePlaceholders, " ")))
+	helpLabel.Wrapping = fyne.TextWrapWord
+
+	return widget.NewCard(i18n.T("Export"), "", container.NewVBox(form, helpLabel, container.NewHBox(btnSave)))
+}
+
+// onSaveExport saves the export settings.
+func (p *SettingsConfigurationPage) onSaveExport() {
+	cfg := config.ExportConfig{
+		Dir:      strings.TrimSpace(p.exportDirEntry.Text),
+		FileName: strings.TrimSpace(p.exportNameEntry.Text),
+	}
+	if err := p.settingsUC.UpdateExportConfig(context.Background(), cfg); err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("save export settings: %w"), err), p.win)
+		return
+	}
+	dialog.ShowInformation(i18n.T("Success"), i18n.T("Export settings saved"), p.win)
+}
+
+// onPurgeNow applies the retention settings in the form immediately.
+func (p *SettingsConfigurationPage) onPurgeNow() {
+	cfg, err := p.retentionConfig()
+	if err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+	if !cfg.Enabled() {
+		dialog.ShowInformation(i18n.T("Purge History"), i18n.T("Set a max age or max records first."), p.win)
+		return
+	}
+
+	opts := usecase.PurgeOptionsFromConfig(cfg)
+	opts.DryRun = true
+	preview, err := p.historyUC.PurgeRecords(context.Background(), opts)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("select records: %w"), err), p.win)
+		return
+	}
+	if len(preview.Records) == 0 {
+		dialog.ShowInformation(i18n.T("Purge History"), i18n.T("No history records to purge."), p.win)
+		return
+	}
+
+	message := i18n.Tf("Delete %d history record(s)?", len(preview.Records))
+	if opts.ArchiveDir != "" {
+		message += i18n.Tf("\nThey will be archived to %s first.", opts.ArchiveDir)
+	}
+	dialog.ShowConfirm(i18n.T("Purge History"), message, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		opts.DryRun = false
+		result, err := p.historyUC.PurgeRecords(context.Background(), opts)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf(i18n.T("purge history: %w"), err), p.win)
+			return
+		}
+		message := i18n.Tf("Purged %d record(s).", result.Purged)
+		if result.ArchivePath != "" {
+			message += i18n.Tf("\nArchive: %s", result.ArchivePath)
+		}
+		dialog.ShowInformation(i18n.T("Purge History"), message, p.win)
+	}, p.win)
+}
+
+// createNotificationCard creates the email notification settings card.
+func (p *SettingsConfigurationPage) createNotificationCard() fyne.CanvasObject {
+	p.notifyEnabledCheck = widget.NewCheck(i18n.T("Send email when a benchmark run finishes"), nil)
+	p.smtpHostEntry = widget.NewEntry()
+	p.smtpHostEntry.SetPlaceHolder("smtp.example.com")
+	p.smtpPortEntry = widget.NewEntry()
+	p.smtpSecuritySelect = widget.NewSelect([]string{config.SMTPSecurityStartTLS, config.SMTPSecurityTLS, config.SMTPSecurityNone}, nil)
+	p.smtpUserEntry = widget.NewEntry()
+	p.smtpUserEntry.SetPlaceHolder(i18n.T("Empty = no authentication"))
+	p.smtpPasswordEntry = widget.NewPasswordEntry()
+	p.notifyFromEntry = widget.NewEntry()
+	p.notifyFromEntry.SetPlaceHolder("benchmind@example.com")
+	p.notifyToEntry = widget.NewEntry()
+	p.notifyToEntry.SetPlaceHolder("dba@example.com, team@example.com")
+	p.notifySuccessCheck = widget.NewCheck(i18n.T("On success"), nil)
+	p.notifyFailureCheck = widget.NewCheck(i18n.T("On failure or timeout"), nil)
+	p.notifyAttachCheck = widget.NewCheck(i18n.T("Attach exported report"), nil)
+
+	ctx := context.Background()
+	if cfg, err := p.settingsUC.GetNotificationConfig(ctx); err != nil {
+		slog.Warn("Settings: Failed to load notification config", "error", err)
+	} else {
+		port, security := cfg.SMTPPort, cfg.Security
+		if port == 0 {
+			port = 587
+		}
+		if security == "" {
+			security = config.SMTPSecurityStartTLS
+		}
+		p.notifyEnabledCheck.SetChecked(cfg.Enabled)
+		p.smtpHostEntry.SetText(cfg.SMTPHost)
+		p.smtpPortEntry.SetText(strconv.Itoa(port))
+		p.smtpSecuritySelect.SetSelected(security)
+		p.smtpUserEntry.SetText(cfg.Username)
+		p.notifyFromEntry.SetText(cfg.From)
+		p.notifyToEntry.SetText(strings.Join(cfg.To, ", "))
+		p.notifySuccessCheck.SetChecked(cfg.OnSuccess)
+		p.notifyFailureCheck.SetChecked(cfg.OnFailure)
+		p.notifyAttachCheck.SetChecked(cfg.AttachReport)
+	}
+	if p.notifyUC.HasPassword(ctx) {
+		p.smtpPasswordEntry.SetPlaceHolder(i18n.T("Saved - leave empty to keep"))
+	}
+
+	form := &widget.Form{
+		Items: []*widget.FormItem{
+			widget.NewFormItem("", p.notifyEnabledCheck),
+			widget.NewFormItem(i18n.T("SMTP Host"), p.smtpHostEntry),
+			widget.NewFormItem(i18n.T("SMTP Port"), p.smtpPortEntry),
+			widget.NewFormItem(i18n.T("Security"), p.smtpSecuritySelect),
+			widget.NewFormItem(i18n.T("Username"), p.smtpUserEntry),
+			widget.NewFormItem(i18n.T("Password"), p.smtpPasswordEntry),
+			widget.NewFormItem(i18n.T("From"), p.notifyFromEntry),
+			widget.NewFormItem(i18n.T("To"), p.notifyToEntry),
+			widget.NewFormItem(i18n.T("Notify"), container.NewHBox(p.notifySuccessCheck, p.notifyFailureCheck, p.notifyAttachCheck)),
+		},
+	}
+	btnSave := widget.NewButton(i18n.T("Save Notifications"), func() {
+		p.onSaveNotifications()
+	})
+	btnTest := widget.NewButton(i18n.T("Send Test Email"), func() {
+		p.onSendTestEmail()
+	})
+	helpLabel := widget.NewLabel(i18n.T("The SMTP password is stored in the system keyring. Recipients are separated by commas."))
+
+	return widget.NewCard(i18n.T("Email Notifications"), "", container.NewVBox(form, helpLabel, container.NewHBox(btnSave, btnTest)))
+}
+
+// notificationConfig reads the email notification settings from the form.
+func (p *SettingsConfigurationPage) notificationConfig() (*config.NotificationConfig, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(p.smtpPortEntry.Text))
+	if err != nil {
+		return nil, fmt.Errorf(i18n.T("invalid SMTP port: %q"), p.smtpPortEntry.Text)
+	}
+
+	cfg := &config.NotificationConfig{
+		Enabled:      p.notifyEnabledCheck.Checked,
+		SMTPHost:     strings.TrimSpace(p.smtpHostEntry.Text),
+		SMTPPort:     port,
+		Security:     p.smtpSecuritySelect.Selected,
+		Username:     strings.TrimSpace(p.smtpUserEntry.Text),
+		From:         strings.TrimSpace(p.notifyFromEntry.Text),
+		OnSuccess:    p.notifySuccessCheck.Checked,
+		OnFailure:    p.notifyFailureCheck.Checked,
+		AttachReport: p.notifyAttachCheck.Checked,
+	}
+	for _, to := range strings.Split(p.notifyToEntry.Text, ",") {
+		if to = strings.TrimSpace(to); to != "" {
+			cfg.To = append(cfg.To, to)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// savePassword stores the SMTP password if one was typed in.
+func (p *SettingsConfigurationPage) savePassword() error {
+	password := p.smtpPasswordEntry.Text
+	if password == "" {
+		return nil
+	}
+	if err := p.notifyUC.SetPassword(context.Background(), password); err != nil {
+		return err
+	}
+	p.smtpPasswordEntry.SetText("")
+	p.smtpPasswordEntry.SetPlaceHolder(i18n.T("Saved - leave empty to keep"))
+	return nil
+}
+
+// onSaveNotifications saves the email notification settings.
+func (p *SettingsConfigurationPage) onSaveNotifications() {
+	cfg, err := p.notificationConfig()
+	if err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+	if err := p.savePassword(); err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+	if err := p.settingsUC.UpdateNotificationConfig(context.Background(), *cfg); err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("save notification settings: %w"), err), p.win)
+		return
+	}
+	dialog.ShowInformation(i18n.T("Success"), i18n.T("Email notification settings saved"), p.win)
+}
+
+// onSendTestEmail sends a test message with the settings in the form.
+func (p *SettingsConfigurationPage) onSendTestEmail() {
+	cfg, err := p.notificationConfig()
+	if err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+	if err := p.savePassword(); err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+
+	progress := dialog.NewCustomWithoutButtons(i18n.T("Send Test Email"), widget.NewProgressBarInfinite(), p.win)
+	progress.Show()
+	go func() {
+		err := p.notifyUC.SendTestEmail(context.Background(), *cfg)
+		fyne.Do(func() {
+			progress.Hide()
+			if err != nil {
+				slog.Error("Settings: Failed to send test email", "error", err)
+				dialog.ShowError(err, p.win)
+				return
+			}
+			dialog.ShowInformation(i18n.T("Send Test Email"), i18n.Tf("Test email sent to %s", strings.Join(cfg.To, ", ")), p.win)
+		})
+	}()
+}
+
+// createWebhookCard creates the run lifecycle webhook settings card.
+func (p *SettingsConfigurationPage) createWebhookCard() fyne.CanvasObject {
+	p.selectedWebhook = -1
+	if webhooks, err := p.settingsUC.GetWebhooks(context.Background()); err != nil {
+		slog.Warn("Settings: Failed to load webhooks", "error", err)
+	} else {
+		p.webhooks = webhooks
+	}
+
+	p.webhookList = widget.NewList(
+		func() int {
+			return len(p.webhooks)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel(i18n.T("Webhook"))
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			if id >= len(p.webhooks) {
+				return
+			}
+			wh := p.webhooks[id]
+			status := i18n.T("enabled")
+			if !wh.Enabled {
+				status = i18n.T("disabled")
+			}
+			obj.(*widget.Label).SetText(i18n.Tf("%s  [%s, %s]  on %s", wh.Name, wh.Format, status, strings.Join(wh.Events, ", ")))
+		},
+	)
+	p.webhookList.OnSelected = func(id widget.ListItemID) { p.selectedWebhook = id }
+	p.webhookList.OnUnselected = func(widget.ListItemID) { p.selectedWebhook = -1 }
+
+	btnAdd := widget.NewButton(i18n.T("Add"), func() {
+		p.showWebhookDialog(-1)
+	})
+	btnEdit := widget.NewButton(i18n.T("Edit"), func() {
+		if p.selectedWebhook < 0 {
+			dialog.ShowError(errors.New(i18n.T("please select a webhook")), p.win)
+			return
+		}
+		p.showWebhookDialog(p.selectedWebhook)
+	})
+	btnRemove := widget.NewButton(i18n.T("Remove"), func() {
+		p.onRemoveWebhook()
+	})
+	btnTest := widget.NewButton(i18n.T("Send Test"), func() {
+		if p.selectedWebhook < 0 {
+			dialog.ShowError(errors.New(i18n.T("please select a webhook")), p.win)
+			return
+		}
+		p.onTestWebhook(p.webhooks[p.selectedWebhook])
+	})
+	helpLabel := widget.NewLabel(i18n.T("Webhooks post run started/completed/failed/stopped events to Slack, Microsoft Teams or any HTTP endpoint."))
+
+	list := container.NewGridWrap(fyne.NewSize(700, 120), p.webhookList)
+	return widget.NewCard(i18n.T("Webhooks"), "", container.NewVBox(list, helpLabel, container.NewHBox(btnAdd, btnEdit, btnRemove, btnTest)))
+}
+
+// showWebhookDialog edits the webhook at index, or adds a new one if index is -1.
+func (p *SettingsConfigurationPage) showWebhookDialog(index int) {
+	wh := config.WebhookConfig{
+		Enabled: true,
+		Format:  config.WebhookFormatSlack,
+		Events:  []string{config.WebhookEventCompleted, config.WebhookEventFailed},
+	}
+	title := i18n.T("Add Webhook")
+	if index >= 0 {
+		wh = p.webhooks[index]
+		title = i18n.T("Edit Webhook")
+	}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(wh.Name)
+	enabledCheck := widget.NewCheck(i18n.T("Enabled"), nil)
+	enabledCheck.SetChecked(wh.Enabled)
+	urlEntry := widget.NewEntry()
+	urlEntry.SetPlaceHolder("https://hooks.slack.com/services/...")
+	urlEntry.SetText(wh.URL)
+	formatSelect := widget.NewSelect([]string{config.WebhookFormatSlack, config.WebhookFormatTeams, config.WebhookFormatGeneric}, nil)
+	formatSelect.SetSelected(wh.Format)
+	eventsGroup := widget.NewCheckGroup(config.WebhookEvents, nil)
+	eventsGroup.Horizontal = true
+	eventsGroup.SetSelected(wh.Events)
+	templateEntry := widget.NewMultiLineEntry()
+	templateEntry.SetPlaceHolder(i18n.T("Optional, e.g. {{.Template}} {{.Event}} on {{.Connection}}{{with .Metrics}}: {{printf \"%.1f\" .TPS}} TPS{{end}}"))
+	templateEntry.SetText(wh.Template)
+	templateEntry.SetMinRowsVisible(4)
+
+	// readForm builds the webhook from the dialog fields.
+	readForm := func() (config.WebhookConfig, error) {
+		edited := config.WebhookConfig{
+			Name:     strings.TrimSpace(nameEntry.Text),
+			Enabled:  enabledCheck.Checked,
+			URL:      strings.TrimSpace(urlEntry.Text),
+			Format:   formatSelect.Selected,
+			Template: templateEntry.Text,
+			Events:   eventsGroup.Selected,
+		}
+		return edited, edited.Validate()
+	}
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(i18n.T("Name"), nameEntry),
+		widget.NewFormItem("", enabledCheck),
+		widget.NewFormItem(i18n.T("URL"), urlEntry),
+		widget.NewFormItem(i18n.T("Format"), formatSelect),
+		widget.NewFormItem(i18n.T("Events"), eventsGroup),
+		widget.NewFormItem(i18n.T("Template"), templateEntry),
+		widget.NewFormItem("", widget.NewButton(i18n.T("Send Test"), func() {
+			edited, err := readForm()
+			if err != nil {
+				dialog.ShowError(err, p.win)
+				return
+			}
+			p.onTestWebhook(edited)
+		})),
+	}
+
+	d := dialog.NewForm(title, i18n.T("Save"), i18n.T("Cancel"), items, func(save bool) {
+		if !save {
+			return
+		}
+		edited, err := readForm()
+		if err != nil {
+			dialog.ShowError(err, p.win)
+			return
+		}
+		webhooks := append([]config.WebhookConfig(nil), p.webhooks...)
+		if index >= 0 {
+			webhooks[index] = edited
+		} else {
+			webhooks = append(webhooks, edited)
+		}
+		p.saveWebhooks(webhooks)
+	}, p.win)
+	d.Resize(fyne.NewSize(650, 450))
+	d.Show()
+}
+
+// onRemoveWebhook removes the selected webhook.
+func (p *SettingsConfigurationPage) onRemoveWebhook() {
+	if p.selectedWebhook < 0 {
+		dialog.ShowError(errors.New(i18n.T("please select a webhook")), p.win)
+		return
+	}
+	index := p.selectedWebhook
+	dialog.ShowConfirm(i18n.T("Remove Webhook"), i18n.Tf("Remove webhook '%s'?", p.webhooks[index].Name), func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		webhooks := append([]config.WebhookConfig(nil), p.webhooks[:index]...)
+		p.saveWebhooks(append(webhooks, p.webhooks[index+1:]...))
+	}, p.win)
+}
+
+// saveWebhooks saves the webhooks and shows them in the list.
+func (p *SettingsConfigurationPage) saveWebhooks(webhooks []config.WebhookConfig) {
+	if err := p.settingsUC.UpdateWebhooks(context.Background(), webhooks); err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("save webhooks: %w"), err), p.win)
+		return
+	}
+	p.webhooks = webhooks
+	p.webhookList.UnselectAll()
+	p.webhookList.Refresh()
+}
+
+// onTestWebhook posts a sample event to a webhook.
+func (p *SettingsConfigurationPage) onTestWebhook(wh config.WebhookConfig) {
+	progress := dialog.NewCustomWithoutButtons(i18n.T("Send Test"), widget.NewProgressBarInfinite(), p.win)
+	progress.Show()
+	go func() {
+		err := p.notifyUC.TestWebhook(context.Background(), wh)
+		fyne.Do(func() {
+			progress.Hide()
+			if err != nil {
+				slog.Error("Settings: Failed to test webhook", "webhook", wh.Name, "error", err)
+				dialog.ShowError(err, p.win)
+				return
+			}
+			dialog.ShowInformation(i18n.T("Send Test"), i18n.Tf("Test event posted to webhook '%s'", wh.Name), p.win)
+		})
+	}()
+}
+
+// createSanityCheckCard creates the run sanity check settings card.
+func (p *SettingsConfigurationPage) createSanityCheckCard() fyne.CanvasObject {
+	p.selectedCheck = -1
+	if checks, err := p.settingsUC.GetSanityChecks(context.Background()); err != nil {
+		slog.Warn("Settings: Failed to load sanity checks", "error", err)
+	} else {
+		p.sanityChecks = checks
+	}
+
+	p.checkList = widget.NewList(
+		func() int {
+			return len(p.sanityChecks)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel(i18n.T("Sanity check"))
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			if id >= len(p.sanityChecks) {
+				return
+			}
+			check := p.sanityChecks[id]
+			obj.(*widget.Label).SetText(fmt.Sprintf("%s  [%s]", check.Name, check.String()))
+		},
+	)
+	p.checkList.OnSelected = func(id widget.ListItemID) { p.selectedCheck = id }
+	p.checkList.OnUnselected = func(widget.ListItemID) { p.selectedCheck = -1 }
+
+	btnAdd := widget.NewButton(i18n.T("Add"), func() {
+		p.showSanityCheckDialog(-1)
+	})
+	btnEdit := widget.NewButton(i18n.T("Edit"), func() {
+		if p.selectedCheck < 0 {
+			dialog.ShowError(errors.New(i18n.T("please select a check")), p.win)
+			return
+		}
+		p.showSanityCheckDialog(p.selectedCheck)
+	})
+	btnRemove := widget.NewButton(i18n.T("Remove"), func() {
+		p.onRemoveSanityCheck()
+	})
+	btnRecheck := widget.NewButton(i18n.T("Re-check History"), func() {
+		p.onRecheckHistory()
+	})
+	helpLabel := widget.NewLabel(i18n.T("Every run saved to history is checked against these thresholds. Runs that fail a check are marked invalid\nand left out of comparison reports. Changes apply to new runs; use 'Re-check History' for existing ones."))
+
+	list := container.NewGridWrap(fyne.NewSize(700, 120), p.checkList)
+	return widget.NewCard(i18n.T("Sanity Checks"), "", container.NewVBox(list, helpLabel, container.NewHBox(btnAdd, btnEdit, btnRemove, btnRecheck)))
+}
+
+// showSanityCheckDialog edits the check at index, or adds a new one if index is -1.
+func (p *SettingsConfigurationPage) showSanityCheckDialog(index int) {
+	check := history.SanityCheck{Kind: history.CheckMaxErrorRate, Threshold: 1}
+	title := i18n.T("Add Sanity Check")
+	if index >= 0 {
+		check = p.sanityChecks[index]
+		title = i18n.T("Edit Sanity Check")
+	}
+
+	kinds := make([]string, len(history.CheckKinds))
+	for i, kind := range history.CheckKinds {
+		kinds[i] = string(kind)
+	}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(check.Name)
+	kindSelect := widget.NewSelect(kinds, nil)
+	kindSelect.SetSelected(string(check.Kind))
+	thresholdEntry := widget.NewEntry()
+	thresholdEntry.SetText(strconv.FormatFloat(check.Threshold, 'f', -1, 64))
+	templateEntry := widget.NewEntry()
+	templateEntry.SetPlaceHolder(i18n.T("All templates"))
+	templateEntry.SetText(check.Template)
+	unitLabel := widget.NewLabel("")
+	kindSelect.OnChanged = func(kind string) {
+		switch history.CheckKind(kind) {
+		case history.CheckMaxErrorRate:
+			unitLabel.SetText(i18n.T("Maximum ignored errors, % of transactions"))
+		case history.CheckMinDuration:
+			unitLabel.SetText(i18n.T("Minimum run duration, seconds"))
+		case history.CheckMaxReconnects:
+			unitLabel.SetText(i18n.T("Maximum reconnects per run"))
+		case history.CheckMaxCV:
+			unitLabel.SetText(i18n.T("Maximum TPS coefficient of variation across repeated runs, %"))
+		case history.CheckMaxReplicaLag:
+			unitLabel.SetText(i18n.T("Maximum replica lag during the run, seconds"))
+		}
+	}
+	kindSelect.OnChanged(kindSelect.Selected)
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(i18n.T("Name"), nameEntry),
+		widget.NewFormItem(i18n.T("Check"), kindSelect),
+		widget.NewFormItem(i18n.T("Threshold"), thresholdEntry),
+		widget.NewFormItem("", unitLabel),
+		widget.NewFormItem(i18n.T("Template"), templateEntry),
+	}
+
+	d := dialog.NewForm(title, i18n.T("Save"), i18n.T("Cancel"), items, func(save bool) {
+		if !save {
+			return
+		}
+		threshold, err := strconv.ParseFloat(strings.TrimSpace(thresholdEntry.Text), 64)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf(i18n.T("invalid threshold: %s"), thresholdEntry.Text), p.win)
+			return
+		}
+		edited := history.SanityCheck{
+			Name:      strings.TrimSpace(nameEntry.Text),
+			Kind:      history.CheckKind(kindSelect.Selected),
+			Threshold: threshold,
+			Template:  strings.TrimSpace(templateEntry.Text),
+		}
+		if err := edited.Validate(); err != nil {
+			dialog.ShowError(err, p.win)
+			return
+		}
+		checks := append([]history.SanityCheck(nil), p.sanityChecks...)
+		if index >= 0 {
+			checks[index] = edited
+		} else {
+			checks = append(checks, edited)
+		}
+		p.saveSanityChecks(checks)
+	}, p.win)
+	d.Resize(fyne.NewSize(600, 350))
+	d.Show()
+}
+
+// onRemoveSanityCheck removes the selected sanity check.
+func (p *SettingsConfigurationPage) onRemoveSanityCheck() {
+	if p.selectedCheck < 0 {
+		dialog.ShowError(errors.New(i18n.T("please select a check")), p.win)
+		return
+	}
+	index := p.selectedCheck
+	dialog.ShowConfirm(i18n.T("Remove Sanity Check"), i18n.Tf("Remove check '%s'?", p.sanityChecks[index].Name), func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		checks := append([]history.SanityCheck(nil), p.sanityChecks[:index]...)
+		p.saveSanityChecks(append(checks, p.sanityChecks[index+1:]...))
+	}, p.win)
+}
+
+// saveSanityChecks saves the sanity checks and shows them in the list.
+func (p *SettingsConfigurationPage) saveSanityChecks(checks []history.SanityCheck) {
+	if err := p.settingsUC.UpdateSanityChecks(context.Background(), checks); err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("save sanity checks: %w"), err), p.win)
+		return
+	}
+	p.sanityChecks = checks
+	p.checkList.UnselectAll()
+	p.checkList.Refresh()
+}
+
+// onRecheckHistory re-evaluates the sanity checks on all history records.
+func (p *SettingsConfigurationPage) onRecheckHistory() {
+	progress := dialog.NewCustomWithoutButtons(i18n.T("Re-check History"), widget.NewProgressBarInfinite(), p.win)
+	progress.Show()
+	go func() {
+		invalid, err := p.historyUC.EvaluateAllRecords(context.Background())
+		fyne.Do(func() {
+			progress.Hide()
+			if err != nil {
+				slog.Error("Settings: Failed to re-check history", "error", err)
+				dialog.ShowError(err, p.win)
+				return
+			}
+			dialog.ShowInformation(i18n.T("Re-check History"), i18n.Tf("All history records checked, %d invalid.", invalid), p.win)
+		})
+	}()
+}
+
+// toolPathEntries returns the path entry of each tool.
+func (p *SettingsConfigurationPage) toolPathEntries() map[config.ToolType]*widget.Entry {
+	return map[config.ToolType]*widget.Entry{
+		config.ToolTypeSysbench:   p.sysbenchPath,
+		config.ToolTypeSwingbench: p.swingPath,
+		config.ToolTypeHammerDB:   p.hammerPath,
+		config.ToolTypePsql:       p.psqlPath,
+		config.ToolTypeMySQL:      p.mysqlPath,
+	}
+}
+
+// loadToolPaths fills the tool path entries from the saved settings.
+func (p *SettingsConfigurationPage) loadToolPaths() {
+	cfg, err := p.settingsUC.GetConfig(context.Background())
+	if err != nil {
+		slog.Warn("Settings: Failed to load tool paths", "error", err)
+		return
+	}
+	for toolType, entry := range p.toolPathEntries() {
+		entry.SetText(cfg.Tools[toolType].Path)
+	}
+}
+
 // onDetectTools detects available benchmark tools.
 func (p *SettingsConfigurationPage) onDetectTools() {
+	if p.settingsUC != nil {
+		p.onDetectConfiguredTools()
+		return
+	}
 	var sb strings.Builder
-	sb.WriteString("Detected Tools:\n\n")
+	sb.WriteString(i18n.T("Detected Tools:\n\n"))
 	// Check sysbench
 	if sysbenchExists("/usr/bin/sysbench") {
-		sb.WriteString("✓ Sysbench: /usr/bin/sysbench\n")
+		sb.WriteString(i18n.T("✓ Sysbench: /usr/bin/sysbench\n"))
 	} else {
-		sb.WriteString("✗ Sysbench: Not found\n")
+		sb.WriteString(i18n.T("✗ Sysbench: Not found\n"))
 	}
 	// Check java
 	if sysbenchExists("/usr/bin/java") {
-		sb.WriteString("✓ Java: /usr/bin/java\n")
+		sb.WriteString(i18n.T("✓ Java: /usr/bin/java\n"))
 	} else {
-		sb.WriteString("✗ Java: Not found\n")
+		sb.WriteString(i18n.T("✗ Java: Not found\n"))
+	}
+	sb.WriteString(i18n.T("\nClick 'Save Settings' to update tool paths."))
+	dialog.ShowInformation(i18n.T("Tool Detection"), sb.String(), p.win)
+}
+
+// onDetectConfiguredTools looks the tools up in PATH and fills the empty
+// path entries with the tools found.
+func (p *SettingsConfigurationPage) onDetectConfiguredTools() {
+	go func() {
+		infos := p.settingsUC.DetectTools(context.Background())
+		fyne.Do(func() {
+			rows := container.NewVBox()
+			entries := p.toolPathEntries()
+			for _, toolType := range config.ToolTypes() {
+				info := infos[toolType]
+				if info == nil || !info.Found {
+					toolType := toolType
+					rows.Add(container.NewHBox(
+						widget.NewLabel(i18n.Tf("✗ %s: Not found", toolType)),
+						widget.NewButton(i18n.T("Install"), func() { p.onInstallTool(toolType) }),
+					))
+					continue
+				}
+				rows.Add(widget.NewLabel(fmt.Sprintf("✓ %s: %s %s", toolType, info.Path, info.Version)))
+				if entry := entries[toolType]; strings.TrimSpace(entry.Text) == "" {
+					entry.SetText(info.Path)
+				}
+			}
+			rows.Add(widget.NewLabel(i18n.T("Click 'Save Settings' to update tool paths.")))
+			dialog.ShowCustom(i18n.T("Tool Detection"), i18n.T("Close"), rows, p.win)
+		})
+	}()
+}
+
+// onInstallTool installs a missing tool. For package manager installs the
+// commands are shown for the user to copy; otherwise the user may download a
+// portable build into the tools directory by giving its archive and the
+// SHA-256 published with it, and its path is saved.
+func (p *SettingsConfigurationPage) onInstallTool(toolType config.ToolType) {
+	plan := p.settingsUC.ToolInstallPlan(toolType)
+	if plan.Method == tool.InstallPackage {
+		commands := widget.NewMultiLineEntry()
+		commands.SetText(strings.Join(plan.Commands, "\n"))
+		commands.SetMinRowsVisible(len(plan.Commands) + 1)
+		content := container.NewVBox(
+			widget.NewLabel(i18n.Tf("Run these commands in a terminal to install %s:", toolType)),
+			commands,
+		)
+		dialog.ShowCustom(i18n.Tf("Install %s", toolType), i18n.T("Close"), content, p.win)
+		return
+	}
+
+	note := widget.NewLabel(plan.Note)
+	note.Wrapping = fyne.TextWrapWord
+	urlEntry := widget.NewEntry()
+	urlEntry.SetText(plan.URL)
+	urlEntry.SetPlaceHolder(i18n.T("Portable build archive (.tar.gz or .zip)"))
+	shaEntry := widget.NewEntry()
+	shaEntry.SetPlaceHolder(i18n.T("Checksum published with the archive"))
+	items := []*widget.FormItem{
+		widget.NewFormItem("", note),
+		widget.NewFormItem(i18n.T("Archive URL"), urlEntry),
+		widget.NewFormItem("SHA-256", shaEntry),
 	}
-	sb.WriteString("\nClick 'Save Settings' to update tool paths.")
-	dialog.ShowInformation("Tool Detection", sb.String(), p.win)
+	form := dialog.NewForm(i18n.Tf("Install %s", toolType), i18n.T("Install"), i18n.T("Close"), items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		archiveURL, sha := strings.TrimSpace(urlEntry.Text), strings.TrimSpace(shaEntry.Text)
+		if archiveURL == "" || sha == "" {
+			dialog.ShowError(errors.New(i18n.T("archive URL and SHA-256 required")), p.win)
+			return
+		}
+		p.installTool(tool.DownloadPlan(toolType, archiveURL, sha))
+	}, p.win)
+	form.Resize(fyne.NewSize(600, form.MinSize().Height))
+	form.Show()
+}
+
+// installTool downloads a portable build into the tools directory and
+// fills in its path.
+func (p *SettingsConfigurationPage) installTool(plan *tool.InstallPlan) {
+	toolType := plan.Type
+	progress := dialog.NewCustomWithoutButtons(i18n.Tf("Installing %s", toolType), widget.NewProgressBarInfinite(), p.win)
+	progress.Show()
+	go func() {
+		toolCfg, err := p.settingsUC.InstallTool(context.Background(), plan)
+		fyne.Do(func() {
+			progress.Hide()
+			if err != nil {
+				slog.Error("Settings: Failed to install tool", "tool", toolType, "error", err)
+				dialog.ShowError(err, p.win)
+				return
+			}
+			slog.Info("Settings: Tool installed", "tool", toolType, "path", toolCfg.Path, "version", toolCfg.Version)
+			if entry := p.toolPathEntries()[toolType]; entry != nil {
+				entry.SetText(toolCfg.Path)
+			}
+			dialog.ShowInformation(i18n.T("Success"), i18n.Tf("%s %s installed to %s", toolType, toolCfg.Version, toolCfg.Path), p.win)
+		})
+	}()
 }
 
 // onSaveSettings saves the settings.
@@ -96,28 +1550,95 @@ func (p *SettingsConfigurationPage) onSaveSettings() {
 	// Validate timeout
 	timeout, err := strconv.Atoi(strings.TrimSpace(p.timeoutEntry.Text))
 	if err != nil || timeout <= 0 {
-		dialog.ShowError(fmt.Errorf("invalid timeout value"), p.win)
+		dialog.ShowError(errors.New(i18n.T("invalid timeout value")), p.win)
+		return
+	}
+	if p.settingsUC == nil {
+		dialog.ShowInformation(i18n.T("Success"), i18n.T("Settings saved successfully"), p.win)
 		return
 	}
-	// In production, save to database
-	dialog.ShowInformation("Success", "Settings saved successfully", p.win)
+
+	// Running each tool to check its version may take a moment
+	paths := make(map[config.ToolType]string)
+	for toolType, entry := range p.toolPathEntries() {
+		paths[toolType] = strings.TrimSpace(entry.Text)
+	}
+	progress := dialog.NewCustomWithoutButtons(i18n.T("Checking Tools"), widget.NewProgressBarInfinite(), p.win)
+	progress.Show()
+	go func() {
+		ctx := context.Background()
+		err := p.settingsUC.SetToolPaths(ctx, paths)
+		var cfg *config.Config
+		if err == nil {
+			cfg, err = p.settingsUC.GetConfig(ctx)
+		}
+		fyne.Do(func() {
+			progress.Hide()
+			if err != nil {
+				dialog.ShowError(fmt.Errorf(i18n.T("save tool paths: %w"), err), p.win)
+				return
+			}
+			slog.Info("Settings: Tool paths saved", "paths", paths)
+			var sb strings.Builder
+			sb.WriteString(i18n.T("Settings saved successfully"))
+			sb.WriteString("\n")
+			for _, toolType := range config.ToolTypes() {
+				if toolCfg := cfg.Tools[toolType]; toolCfg.Path != "" {
+					sb.WriteString(fmt.Sprintf("\n%s: %s %s", toolType, toolCfg.Path, toolCfg.Version))
+				}
+			}
+			dialog.ShowInformation(i18n.T("Success"), sb.String(), p.win)
+		})
+	}()
+}
+
+// onCompactDatabase compacts the database and reports the space reclaimed.
+func (p *SettingsConfigurationPage) onCompactDatabase() {
+	dialog.ShowConfirm(
+		i18n.T("Compact Database"),
+		i18n.T("Recompress stored outputs and VACUUM the database?\nThis may take a while for large databases."),
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			progress := dialog.NewCustomWithoutButtons(i18n.T("Compacting"), widget.NewProgressBarInfinite(), p.win)
+			progress.Show()
+			go func() {
+				result, err := p.maintenanceUC.CompactDatabase(context.Background())
+				fyne.Do(func() {
+					progress.Hide()
+					if err != nil {
+						dialog.ShowError(fmt.Errorf(i18n.T("compact database: %w"), err), p.win)
+						return
+					}
+					dialog.ShowInformation(i18n.T("Database Compacted"), i18n.Tf(
+						"Records recompressed: %d\nSize before: %s\nSize after: %s\nSpace reclaimed: %s",
+						result.RecordsCompacted,
+						usecase.FormatBytes(result.SizeBefore),
+						usecase.FormatBytes(result.SizeAfter),
+						usecase.FormatBytes(result.Reclaimed())), p.win)
+				})
+			}()
+		},
+		p.win,
+	)
 }
 
 // onResetSettings resets settings to defaults.
 func (p *SettingsConfigurationPage) onResetSettings() {
 	dialog.ShowConfirm(
-		"Reset Settings",
-		"Are you sure you want to reset all settings to defaults?",
+		i18n.T("Reset Settings"),
+		i18n.T("Are you sure you want to reset all settings to defaults?"),
 		func(confirmed bool) {
 			if !confirmed {
 				return
 			}
-			p.sysbenchPath.SetText("/usr/bin/sysbench")
-			p.swingPath.SetText("/opt/swingbench/bin/oowbench")
-			p.hammerPath.SetText("/opt/HammerDB/hammerdbcli")
+			for _, entry := range p.toolPathEntries() {
+				entry.SetText("")
+			}
 			p.javaPath.SetText("/usr/bin/java")
 			p.timeoutEntry.SetText("10")
-			dialog.ShowInformation("Reset", "Settings reset to defaults", p.win)
+			dialog.ShowInformation(i18n.T("Reset"), i18n.T("Settings reset to defaults"), p.win)
 		},
 		p.win,
 	)
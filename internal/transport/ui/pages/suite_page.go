@@ -0,0 +1,563 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// Suites Page implementation.
+package pages
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/comparison"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/suite"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// SuitePage provides the benchmark suites GUI.
+type SuitePage struct {
+	win        fyne.Window
+	suiteUC    *usecase.SuiteUseCase
+	connUC     *usecase.ConnectionUseCase
+	templateUC *usecase.TemplateUseCase
+	ctx        context.Context
+
+	suites    []*suite.Suite
+	selected  int
+	runs      []*suite.Run
+	connNames map[string]string // Connection ID -> name
+	tmplNames map[string]string // Template ID -> name
+
+	suiteList   *widget.List
+	runList     *widget.List
+	detailLabel *widget.Label
+	statusLabel *widget.Label
+}
+
+// suiteGroupByOptions maps Group By selector labels (English, shown
+// translated) to the grouping of the final report.
+var suiteGroupByOptions = map[string]comparison.GroupByField{
+	"Threads":       comparison.GroupByThreads,
+	"Database Type": comparison.GroupByDatabaseType,
+	"Template":      comparison.GroupByTemplate,
+}
+
+// NewSuitePage creates a new suites page.
+// Returns both the page instance and the canvas object for external refresh control.
+func NewSuitePage(win fyne.Window, suiteUC *usecase.SuiteUseCase, connUC *usecase.ConnectionUseCase, templateUC *usecase.TemplateUseCase) (*SuitePage, fyne.CanvasObject) {
+	page := &SuitePage{
+		win:        win,
+		suiteUC:    suiteUC,
+		connUC:     connUC,
+		templateUC: templateUC,
+		ctx:        context.Background(),
+		selected:   -1,
+	}
+
+	page.suiteList = widget.NewList(
+		func() int { return len(page.suites) },
+		func() fyne.CanvasObject { return widget.NewLabel(i18n.T("Suite")) },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			s := page.suites[id]
+			text := i18n.Tf("%s | %d steps | %d runs", s.Name, len(s.Steps), s.TotalRuns())
+			if page.suiteUC.IsRunning(s.ID) {
+				text += i18n.T(" | running")
+			}
+			obj.(*widget.Label).SetText(text)
+		},
+	)
+	page.suiteList.OnSelected = func(id widget.ListItemID) {
+		page.selected = id
+		page.showSelected()
+	}
+
+	page.runList = widget.NewList(
+		func() int { return len(page.runs) },
+		func() fyne.CanvasObject { return widget.NewLabel(i18n.T("Suite run")) },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(formatSuiteRun(page.runs[id]))
+		},
+	)
+	page.runList.OnSelected = func(id widget.ListItemID) {
+		page.onViewRun(page.runs[id])
+		page.runList.UnselectAll()
+	}
+
+	page.detailLabel = widget.NewLabel(i18n.T("Select a suite to see its steps."))
+	page.detailLabel.Wrapping = fyne.TextWrapWord
+	page.statusLabel = widget.NewLabel("")
+
+	toolbar := container.NewHBox(
+		widget.NewButton(i18n.T("➕ New"), func() { page.onEdit(nil) }),
+		widget.NewButton(i18n.T("✏️ Edit"), func() {
+			if s := page.selectedSuite(); s != nil {
+				page.onEdit(s)
+			}
+		}),
+		widget.NewButton(i18n.T("❌ Delete"), page.onDelete),
+		widget.NewButton(i18n.T("▶ Run"), page.onRun),
+		widget.NewButton(i18n.T("⏹ Stop"), page.onStop),
+		widget.NewButton(i18n.T("🔄 Refresh"), page.Refresh),
+	)
+
+	details := container.NewBorder(
+		container.NewVBox(widget.NewCard(i18n.T("Steps"), "", page.detailLabel), widget.NewLabel(i18n.T("Suite runs (newest first)"))),
+		nil, nil, nil,
+		page.runList,
+	)
+	split := container.NewHSplit(page.suiteList, details)
+	split.Offset = 0.35
+
+	content := container.NewBorder(toolbar, page.statusLabel, nil, nil, split)
+
+	page.Refresh()
+	return page, content
+}
+
+// Refresh reloads suites, connection and template names.
+func (p *SuitePage) Refresh() {
+	p.connNames = make(map[string]string)
+	if conns, err := p.connUC.ListConnections(p.ctx); err == nil {
+		for _, conn := range conns {
+			p.connNames[conn.GetID()] = conn.GetName()
+		}
+	}
+	p.tmplNames = make(map[string]string)
+	if tmpls, err := p.templateUC.ListTemplates(p.ctx); err == nil {
+		for _, tmpl := range tmpls {
+			p.tmplNames[tmpl.ID] = tmpl.Name
+		}
+	}
+
+	suites, err := p.suiteUC.ListSuites(p.ctx)
+	if err != nil {
+		slog.Error("Suites: Failed to load suites", "error", err)
+		p.statusLabel.SetText(i18n.Tf("Failed to load suites: %v", err))
+		return
+	}
+
+	selectedID := ""
+	if s := p.selectedSuite(); s != nil {
+		selectedID = s.ID
+	}
+	p.suites = suites
+	p.selected = -1
+	for i, s := range suites {
+		if s.ID == selectedID {
+			p.selected = i
+		}
+	}
+	p.suiteList.Refresh()
+	p.showSelected()
+	p.statusLabel.SetText(i18n.Tf("%d suites", len(suites)))
+}
+
+// selectedSuite returns the selected suite, or nil.
+func (p *SuitePage) selectedSuite() *suite.Suite {
+	if p.selected < 0 || p.selected >= len(p.suites) {
+		return nil
+	}
+	return p.suites[p.selected]
+}
+
+// showSelected shows the steps and runs of the selected suite.
+func (p *SuitePage) showSelected() {
+	s := p.selectedSuite()
+	if s == nil {
+		p.runs = nil
+		p.runList.Refresh()
+		p.detailLabel.SetText(i18n.T("Select a suite to see its steps."))
+		return
+	}
+
+	var b strings.Builder
+	if s.Description != "" {
+		b.WriteString(s.Description + "\n\n")
+	}
+	for i := range s.Steps {
+		b.WriteString(p.formatStep(i, &s.Steps[i]) + "\n")
+	}
+	fmt.Fprintf(&b, i18n.T("\nComparison report grouped by %s"), s.GroupBy)
+	p.detailLabel.SetText(b.String())
+
+	runs, err := p.suiteUC.ListSuiteRuns(p.ctx, s.ID)
+	if err != nil {
+		slog.Error("Suites: Failed to load suite runs", "suite", s.Name, "error", err)
+	}
+	p.runs = runs
+	p.runList.Refresh()
+}
+
+// formatStep describes a step on one line.
+func (p *SuitePage) formatStep(index int, step *suite.Step) string {
+	conn := p.connNames[step.ConnectionID]
+	if conn == "" {
+		conn = step.ConnectionID
+	}
+	tmpl := p.tmplNames[step.TemplateID]
+	if tmpl == "" {
+		tmpl = step.TemplateID
+	}
+
+	text := i18n.Tf("%d. %s on %s", index+1, tmpl, conn)
+	if threads, ok := step.Parameters["threads"]; ok {
+		text += i18n.Tf(", %v threads", threads)
+	}
+	if runTime, ok := step.Parameters["time"]; ok {
+		text += fmt.Sprintf(", %vs", runTime)
+	}
+	text += fmt.Sprintf(", x%d", step.Repetitions)
+	if step.CooldownSeconds > 0 {
+		text += i18n.Tf(", cool-down %ds", step.CooldownSeconds)
+	}
+	return text
+}
+
+// formatSuiteRun describes a suite run on one line.
+func formatSuiteRun(run *suite.Run) string {
+	text := i18n.Tf("%s | %s | %d/%d runs completed",
+		run.StartedAt.Format("2006-01-02 15:04"), run.State, len(run.CompletedRunIDs()), run.TotalRuns)
+	if run.ReportPath != "" {
+		text += i18n.T(" | report saved")
+	}
+	return text
+}
+
+// onViewRun shows the details of a suite run.
+func (p *SuitePage) onViewRun(run *suite.Run) {
+	var b strings.Builder
+	fmt.Fprintf(&b, i18n.T("Suite run: %s\n"), run.ID)
+	fmt.Fprintf(&b, i18n.T("State: %s\n"), run.State)
+	fmt.Fprintf(&b, i18n.T("Started: %s\n"), run.StartedAt.Format(time.DateTime))
+	if run.CompletedAt != nil {
+		fmt.Fprintf(&b, i18n.T("Finished: %s\n"), run.CompletedAt.Format(time.DateTime))
+	}
+	fmt.Fprintf(&b, i18n.T("History tag: %s\n"), run.Tag())
+	if run.ReportPath != "" {
+		fmt.Fprintf(&b, i18n.T("Comparison report: %s\n"), run.ReportPath)
+	}
+	if run.ErrorMessage != "" {
+		fmt.Fprintf(&b, i18n.T("Error: %s\n"), run.ErrorMessage)
+	}
+	b.WriteString(i18n.T("\nRuns:\n"))
+	for _, ref := range run.Runs {
+		fmt.Fprintf(&b, i18n.T("  Step %d #%d  %s  %s"), ref.Step+1, ref.Repetition, ref.RunID, ref.State)
+		if ref.Error != "" {
+			fmt.Fprintf(&b, "  (%s)", ref.Error)
+		}
+		b.WriteString("\n")
+	}
+
+	text := widget.NewMultiLineEntry()
+	text.SetText(b.String())
+	text.Wrapping = fyne.TextWrapWord
+	scroll := container.NewVScroll(text)
+	scroll.SetMinSize(fyne.NewSize(700, 400))
+	dialog.ShowCustom(i18n.T("Suite Run"), i18n.T("Close"), scroll, p.win)
+}
+
+// onEdit opens the suite editor. A nil suite creates a new one.
+func (p *SuitePage) onEdit(existing *suite.Suite) {
+	edited := &suite.Suite{GroupBy: suite.DefaultGroupBy}
+	if existing != nil {
+		copied := *existing
+		copied.Steps = append([]suite.Step(nil), existing.Steps...)
+		edited = &copied
+	}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(edited.Name)
+	descEntry := widget.NewEntry()
+	descEntry.SetText(edited.Description)
+
+	groupLabels := i18n.TList([]string{"Threads", "Database Type", "Template"})
+	groupSelect := widget.NewSelect(groupLabels, nil)
+	for label, field := range suiteGroupByOptions {
+		if string(field) == edited.GroupBy {
+			groupSelect.SetSelected(i18n.T(label))
+		}
+	}
+
+	selectedStep := -1
+	stepList := widget.NewList(
+		func() int { return len(edited.Steps) },
+		func() fyne.CanvasObject { return widget.NewLabel(i18n.T("Step")) },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			obj.(*widget.Label).SetText(p.formatStep(id, &edited.Steps[id]))
+		},
+	)
+	stepList.OnSelected = func(id widget.ListItemID) { selectedStep = id }
+
+	stepButtons := container.NewHBox(
+		widget.NewButton(i18n.T("Add Step"), func() {
+			p.showStepDialog(nil, func(step suite.Step) {
+				edited.Steps = append(edited.Steps, step)
+				stepList.Refresh()
+			})
+		}),
+		widget.NewButton(i18n.T("Edit Step"), func() {
+			if selectedStep < 0 || selectedStep >= len(edited.Steps) {
+				return
+			}
+			index := selectedStep
+			p.showStepDialog(&edited.Steps[index], func(step suite.Step) {
+				edited.Steps[index] = step
+				stepList.Refresh()
+			})
+		}),
+		widget.NewButton(i18n.T("Remove Step"), func() {
+			if selectedStep < 0 || selectedStep >= len(edited.Steps) {
+				return
+			}
+			edited.Steps = append(edited.Steps[:selectedStep], edited.Steps[selectedStep+1:]...)
+			selectedStep = -1
+			stepList.UnselectAll()
+			stepList.Refresh()
+		}),
+		widget.NewButton(i18n.T("Move Up"), func() {
+			if selectedStep < 1 || selectedStep >= len(edited.Steps) {
+				return
+			}
+			edited.Steps[selectedStep-1], edited.Steps[selectedStep] = edited.Steps[selectedStep], edited.Steps[selectedStep-1]
+			stepList.Select(selectedStep - 1)
+			stepList.Refresh()
+		}),
+	)
+
+	form := widget.NewForm(
+		widget.NewFormItem(i18n.T("Name"), nameEntry),
+		widget.NewFormItem(i18n.T("Description"), descEntry),
+		widget.NewFormItem(i18n.T("Compare By"), groupSelect),
+	)
+	stepScroll := container.NewVScroll(stepList)
+	stepScroll.SetMinSize(fyne.NewSize(650, 220))
+	content := container.NewBorder(
+		container.NewVBox(form, widget.NewLabel(i18n.T("Steps (run in order)"))),
+		stepButtons, nil, nil,
+		stepScroll,
+	)
+
+	title := i18n.T("New Suite")
+	if existing != nil {
+		title = i18n.T("Edit Suite")
+	}
+	dlg := dialog.NewCustomConfirm(title, i18n.T("Save"), i18n.T("Cancel"), content, func(ok bool) {
+		if !ok {
+			return
+		}
+		edited.Name = nameEntry.Text
+		edited.Description = strings.TrimSpace(descEntry.Text)
+		for label, field := range suiteGroupByOptions {
+			if i18n.T(label) == groupSelect.Selected {
+				edited.GroupBy = string(field)
+			}
+		}
+		if err := p.suiteUC.SaveSuite(p.ctx, edited); err != nil {
+			dialog.ShowError(err, p.win)
+			return
+		}
+		p.Refresh()
+	}, p.win)
+	dlg.Resize(fyne.NewSize(750, 550))
+	dlg.Show()
+}
+
+// showStepDialog edits a step; onSave receives the edited copy.
+func (p *SuitePage) showStepDialog(existing *suite.Step, onSave func(suite.Step)) {
+	step := suite.Step{Repetitions: 1, Parameters: make(map[string]interface{})}
+	if existing != nil {
+		step = *existing
+		step.Parameters = make(map[string]interface{}, len(existing.Parameters))
+		for name, value := range existing.Parameters {
+			step.Parameters[name] = value
+		}
+	}
+
+	connIDs := make(map[string]string) // Name -> ID
+	var connOptions []string
+	for id, name := range p.connNames {
+		connIDs[name] = id
+		connOptions = append(connOptions, name)
+	}
+	tmplIDs := make(map[string]string)
+	var tmplOptions []string
+	for id, name := range p.tmplNames {
+		tmplIDs[name] = id
+		tmplOptions = append(tmplOptions, name)
+	}
+	slices.Sort(connOptions)
+	slices.Sort(tmplOptions)
+
+	connSelect := widget.NewSelect(connOptions, nil)
+	connSelect.SetSelected(p.connNames[step.ConnectionID])
+	tmplSelect := widget.NewSelect(tmplOptions, nil)
+	tmplSelect.SetSelected(p.tmplNames[step.TemplateID])
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(step.Name)
+	nameEntry.SetPlaceHolder(i18n.T("Optional"))
+	threadsEntry := intParamEntry(step.Parameters["threads"])
+	timeEntry := intParamEntry(step.Parameters["time"])
+	repsEntry := widget.NewEntry()
+	repsEntry.SetText(strconv.Itoa(step.Repetitions))
+	cooldownEntry := widget.NewEntry()
+	cooldownEntry.SetText(strconv.Itoa(step.CooldownSeconds))
+	warmupEntry := widget.NewEntry()
+	warmupEntry.SetText(strconv.Itoa(step.Options.WarmupTime))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(i18n.T("Name"), nameEntry),
+		widget.NewFormItem(i18n.T("Connection"), connSelect),
+		widget.NewFormItem(i18n.T("Template"), tmplSelect),
+		widget.NewFormItem(i18n.T("Threads"), threadsEntry),
+		widget.NewFormItem(i18n.T("Duration (seconds)"), timeEntry),
+		widget.NewFormItem(i18n.T("Warmup (seconds)"), warmupEntry),
+		widget.NewFormItem(i18n.T("Repetitions"), repsEntry),
+		widget.NewFormItem(i18n.T("Cool-down (seconds)"), cooldownEntry),
+	}
+
+	dlg := dialog.NewForm(i18n.T("Suite Step"), i18n.T("OK"), i18n.T("Cancel"), items, func(ok bool) {
+		if !ok {
+			return
+		}
+		step.Name = strings.TrimSpace(nameEntry.Text)
+		step.ConnectionID = connIDs[connSelect.Selected]
+		step.TemplateID = tmplIDs[tmplSelect.Selected]
+
+		err := setIntParam(step.Parameters, "threads", threadsEntry.Text)
+		if err == nil {
+			err = setIntParam(step.Parameters, "time", timeEntry.Text)
+		}
+		if err == nil {
+			step.Repetitions, err = atoiField("repetitions", repsEntry.Text)
+		}
+		if err == nil {
+			step.CooldownSeconds, err = atoiField("cool-down", cooldownEntry.Text)
+		}
+		if err == nil {
+			step.Options.WarmupTime, err = atoiField("warmup", warmupEntry.Text)
+		}
+		if err == nil {
+			err = step.Validate()
+		}
+		if err != nil {
+			dialog.ShowError(err, p.win)
+			return
+		}
+		onSave(step)
+	}, p.win)
+	dlg.Resize(fyne.NewSize(500, 450))
+	dlg.Show()
+}
+
+// intParamEntry creates an entry holding a numeric template parameter.
+func intParamEntry(value interface{}) *widget.Entry {
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder(i18n.T("Template default"))
+	if value != nil {
+		entry.SetText(fmt.Sprintf("%v", value))
+	}
+	return entry
+}
+
+// setIntParam sets a numeric parameter override; empty text removes it.
+func setIntParam(params map[string]interface{}, name, text string) error {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		delete(params, name)
+		return nil
+	}
+	value, err := atoiField(name, text)
+	if err != nil {
+		return err
+	}
+	params[name] = value
+	return nil
+}
+
+// atoiField parses a numeric form field.
+func atoiField(name, text string) (int, error) {
+	value, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil {
+		return 0, fmt.Errorf(i18n.T("%s must be a number"), name)
+	}
+	return value, nil
+}
+
+// onDelete deletes the selected suite after confirmation.
+func (p *SuitePage) onDelete() {
+	s := p.selectedSuite()
+	if s == nil {
+		return
+	}
+	dialog.ShowConfirm(i18n.T("Delete Suite"),
+		i18n.Tf("Delete suite %q and its run records? History records are kept.", s.Name),
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := p.suiteUC.DeleteSuite(p.ctx, s.ID); err != nil {
+				dialog.ShowError(err, p.win)
+				return
+			}
+			p.selected = -1
+			p.suiteList.UnselectAll()
+			p.Refresh()
+		}, p.win)
+}
+
+// onRun starts the selected suite in the background.
+func (p *SuitePage) onRun() {
+	s := p.selectedSuite()
+	if s == nil {
+		return
+	}
+	run, err := p.suiteUC.StartSuite(p.ctx, s.ID)
+	if err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+	p.statusLabel.SetText(i18n.Tf("Suite %s started: %d runs. Progress is shown under Suite runs.", s.Name, run.TotalRuns))
+	p.showSelected()
+	p.suiteList.Refresh()
+	go p.watchRun(s.ID)
+}
+
+// watchRun refreshes the page while a suite is running.
+func (p *SuitePage) watchRun(suiteID string) {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		running := p.suiteUC.IsRunning(suiteID)
+		fyne.Do(func() {
+			if s := p.selectedSuite(); s != nil && s.ID == suiteID {
+				p.showSelected()
+			}
+			p.suiteList.Refresh()
+		})
+		if !running {
+			return
+		}
+	}
+}
+
+// onStop stops the selected suite.
+func (p *SuitePage) onStop() {
+	s := p.selectedSuite()
+	if s == nil {
+		return
+	}
+	if err := p.suiteUC.StopSuite(s.ID); err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+	p.statusLabel.SetText(i18n.Tf("Stopping suite %s...", s.Name))
+}
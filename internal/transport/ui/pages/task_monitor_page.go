@@ -4,10 +4,11 @@ package pages
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"image/color"
 	"log/slog"
-	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -15,14 +16,20 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/google/uuid"
 	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/assertion"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/connection"
 	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/sqlmix"
 	domaintemplate "github.com/whhaicheng/DB-BenchMind/internal/domain/template"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
 )
 
 // minSizeWidget is a custom widget that wraps a child and enforces a minimum size.
@@ -86,36 +93,88 @@ type TaskMonitorPage struct {
 	win          fyne.Window
 	isRunning    bool
 	currentRunID string // Current benchmark run ID
+	// Connection and template of the current run phase, for saving a stopped run to history
+	runEvent usecase.RunEvent
 	// Use cases
 	connUC      *usecase.ConnectionUseCase
 	benchmarkUC *usecase.BenchmarkUseCase
 	templateUC  *usecase.TemplateUseCase
 	historyUC   *usecase.HistoryUseCase
+	exportUC    *usecase.ExportUseCase // Exports from the Run Details view
+	// Runs tasks with Repeat > 1 and aggregates their results
+	repetitionUC *usecase.RepetitionUseCase
+	// Lists the load-generator agents runs can be dispatched to and
+	// remembers the task parameters last used with each connection
+	settingsUC   *usecase.SettingsUseCase
+	cancelRepeat context.CancelFunc // Stops the running repeated task
+	// Simulates runs instead when demo mode is on
+	demoUC     *usecase.DemoUseCase
+	cancelDemo context.CancelFunc // Stops the running demo run
+	// Runs the task against a second connection at the same time (A/B race)
+	raceUC     *usecase.RaceUseCase
+	cancelRace context.CancelFunc // Stops the running race
+	raceRunID  string             // Run of the connection raced against
 	// Task configuration widgets
+	presetSelect   *widget.Select // Saved Task Configurations
 	connSelect     *widget.Select
+	raceSelect     *widget.Select // Connection to race against; (none) runs the task alone
 	templateSelect *widget.Select
 	// General parameters
 	threadsEntry  *widget.Entry
 	durationEntry *widget.Entry
+	warmupEntry   *widget.Entry // Warmup seconds before the measured window
 	dbNameEntry   *widget.Entry
-	// Monitor widgets
-	statusLabel     *widget.Label
-	tpsLabel        *widget.Label
-	qpsLabel        *widget.Label
-	latencyP95Label *widget.Label
-	errorsLabel     *widget.Label
-	threadsLabel    *widget.Label
-	progressBar     *widget.ProgressBar
+	// Rate profile of the run phase: fixed, staircase or linear ramp
+	rateProfileSelect *widget.Select
+	rateStartEntry    *widget.Entry // TPS of the first step
+	rateChangeEntry   *widget.Entry // TPS added per step (staircase) or target TPS (ramp)
+	rateStepEntry     *widget.Entry // Seconds per step
+	// Sample interval override in seconds (empty = adaptive)
+	sampleIntervalEntry *widget.Entry
+	// Repeat the run phase N times, flagging runs outside k·σ as outliers
+	repeatEntry  *widget.Entry
+	outlierEntry *widget.Entry
+	// Run the tool on the SQL Server host via WinRM
+	remoteCheck *widget.Check
+	// Run the tool on a load-generator agent, or on all of them at once, instead of this machine
+	agentSelect *widget.Select
+	agentNames  []string // Configured agents, in the order of the selector
+	// Keep the work directory (tool output, generated configs) under data/runs
+	keepArtifactsCheck *widget.Check
+	// Poll the replica lag of the connection's replicas during the run phase
+	monitorReplicasCheck *widget.Check
+	serverLogCheck       *widget.Check
+	// Why and where the benchmark is run, saved with its history records
+	purposeEntry     *widget.Entry
+	ticketEntry      *widget.Entry // Change ticket or PR link
+	environmentEntry *widget.Entry // e.g. staging, prod-replica
+	// SLA targets of each run, overriding the template's (empty = not asserted)
+	minTPSEntry       *widget.Entry
+	maxLatencyEntry   *widget.Entry // p95 latency in ms
+	maxErrorRateEntry *widget.Entry // Percent of transactions
+	// Monitor data model; widgets below are bound to it and must not be set directly
+	monitor     *monitorBindings
+	statusLabel *widget.Label
+	progressBar *widget.ProgressBar
+	rateChart   *rateChart
+	// Metrics of a single run, or of both connections side by side during a race
+	metricsGrid *fyne.Container
+	raceGrid    *fyne.Container
+	raceHeaderA *widget.Label
+	raceHeaderB *widget.Label
 	// Real-time log for sysbench output
-	logEntry     *widget.Entry
-	maxLogLines  int
-	lastLogCount int             // Track number of samples already added to log
-	addedSeconds map[string]bool // Track which seconds have been added to prevent duplicates
+	logEntry *widget.Entry
 	// Control buttons
 	btnPrepare *widget.Button
 	btnRun     *widget.Button
 	btnCleanup *widget.Button
 	btnStop    *widget.Button
+	btnDryRun  *widget.Button
+	btnLogs    *widget.Button
+	// Restores the default task parameters of the selected connection
+	btnResetDefaults *widget.Button
+	// Installs the swingbench SOE schema; enabled for Oracle connections
+	btnInstallSOE *widget.Button
 	// Template data
 	templates []templateInfo
 	// Connection data by ID
@@ -124,11 +183,12 @@ type TaskMonitorPage struct {
 
 // NewTaskMonitorPage creates a new combined task configuration and monitor page.
 func NewTaskMonitorPage(win fyne.Window) fyne.CanvasObject {
-	return NewTaskMonitorPageWithUC(win, nil, nil, nil, nil)
+	_, content := NewTaskMonitorPageWithUC(win, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	return content
 }
 
 // NewTaskMonitorPageWithUC creates a new combined task configuration and monitor page with use cases.
-func NewTaskMonitorPageWithUC(win fyne.Window, connUC *usecase.ConnectionUseCase, benchmarkUC *usecase.BenchmarkUseCase, templateUC *usecase.TemplateUseCase, historyUC *usecase.HistoryUseCase) fyne.CanvasObject {
+func NewTaskMonitorPageWithUC(win fyne.Window, connUC *usecase.ConnectionUseCase, benchmarkUC *usecase.BenchmarkUseCase, templateUC *usecase.TemplateUseCase, historyUC *usecase.HistoryUseCase, exportUC *usecase.ExportUseCase, repetitionUC *usecase.RepetitionUseCase, settingsUC *usecase.SettingsUseCase, demoUC *usecase.DemoUseCase, raceUC *usecase.RaceUseCase) (*TaskMonitorPage, fyne.CanvasObject) {
 	slog.Info("Tasks: NewTaskMonitorPageWithUC called", "has_connUC", connUC != nil, "has_benchmarkUC", benchmarkUC != nil, "has_templateUC", templateUC != nil, "has_historyUC", historyUC != nil)
 	page := &TaskMonitorPage{
 		win:          win,
@@ -138,6 +198,11 @@ func NewTaskMonitorPageWithUC(win fyne.Window, connUC *usecase.ConnectionUseCase
 		benchmarkUC:  benchmarkUC,
 		templateUC:   templateUC,
 		historyUC:    historyUC,
+		exportUC:     exportUC,
+		repetitionUC: repetitionUC,
+		settingsUC:   settingsUC,
+		demoUC:       demoUC,
+		raceUC:       raceUC,
 		connections:  make(map[string]connection.Connection),
 	}
 
@@ -148,6 +213,10 @@ func NewTaskMonitorPageWithUC(win fyne.Window, connUC *usecase.ConnectionUseCase
 		page.onConnectionChanged()
 	}
 
+	// The same task runs against the second connection at the same time
+	page.raceSelect = widget.NewSelect([]string{i18n.T(raceNone)}, nil)
+	page.raceSelect.SetSelected(i18n.T(raceNone))
+
 	// Load connections from database
 	if page.connUC != nil {
 		page.loadConnections()
@@ -164,16 +233,88 @@ func NewTaskMonitorPageWithUC(win fyne.Window, connUC *usecase.ConnectionUseCase
 
 	// Create general parameter entries
 	page.threadsEntry = widget.NewEntry()
-	page.threadsEntry.SetText("1")
+	page.threadsEntry.SetText(strconv.Itoa(config.DefaultTaskThreads))
 
 	page.durationEntry = widget.NewEntry()
-	page.durationEntry.SetText("60")
+	page.durationEntry.SetText(strconv.Itoa(config.DefaultTaskDuration))
+
+	page.warmupEntry = widget.NewEntry()
+	page.warmupEntry.SetText("0")
 
 	page.dbNameEntry = widget.NewEntry()
-	page.dbNameEntry.SetText("sbtest")
+	page.dbNameEntry.SetText(config.DefaultTaskDBName)
+
+	page.rateStartEntry = widget.NewEntry()
+	page.rateStartEntry.SetPlaceHolder(i18n.T("start TPS"))
+	page.rateChangeEntry = widget.NewEntry()
+	page.rateStepEntry = widget.NewEntry()
+	page.rateStepEntry.SetPlaceHolder(i18n.T("seconds per step"))
+	page.rateProfileSelect = widget.NewSelect(i18n.TList(rateProfileOptions), page.onRateProfileChanged)
+	page.rateProfileSelect.SetSelected(i18n.T(rateProfileFixed))
+
+	page.sampleIntervalEntry = widget.NewEntry()
+	page.sampleIntervalEntry.SetPlaceHolder(i18n.T("auto (1s <10min, 5s <1h, 30s beyond)"))
+
+	page.repeatEntry = widget.NewEntry()
+	page.repeatEntry.SetText("1")
+
+	page.outlierEntry = widget.NewEntry()
+	page.outlierEntry.SetPlaceHolder(i18n.Tf("%.0f (runs with TPS outside mean ± k·σ)", execution.DefaultOutlierSigma))
+
+	// Remote execution is only available for SQL Server connections with WinRM
+	page.remoteCheck = widget.NewCheck(i18n.T("Run tool on database host (WinRM)"), func(checked bool) {
+		if checked {
+			page.agentSelect.SetSelected(i18n.T(localLoadGenerator))
+		}
+	})
+	page.remoteCheck.Disable()
+
+	// Agents generate the load on another machine, e.g. one next to the database
+	page.agentSelect = widget.NewSelect(nil, func(selected string) {
+		if selected != i18n.T(localLoadGenerator) {
+			page.remoteCheck.SetChecked(false)
+		}
+	})
+	page.loadAgents()
+
+	page.keepArtifactsCheck = widget.NewCheck(i18n.T("Keep run artifacts (data/runs/<run-id>)"), nil)
+
+	// Replica monitoring is only available for connections with replicas
+	page.monitorReplicasCheck = widget.NewCheck(i18n.T("Monitor replica lag during the run"), nil)
+	page.monitorReplicasCheck.Disable()
+
+	// The error log is read over the SSH tunnel or WinRM of the connection
+	page.serverLogCheck = widget.NewCheck(i18n.T("Save the database error log of the run to its artifacts"), nil)
+	page.serverLogCheck.Disable()
+
+	page.purposeEntry = widget.NewEntry()
+	page.purposeEntry.SetPlaceHolder(i18n.T("Why this benchmark is run, e.g. index change on orders"))
+	page.ticketEntry = widget.NewEntry()
+	page.ticketEntry.SetPlaceHolder(i18n.T("Change ticket or PR link"))
+	page.environmentEntry = widget.NewEntry()
+	page.environmentEntry.SetPlaceHolder("staging, prod-replica")
+
+	page.minTPSEntry = widget.NewEntry()
+	page.minTPSEntry.SetPlaceHolder(i18n.T("Min TPS"))
+	page.maxLatencyEntry = widget.NewEntry()
+	page.maxLatencyEntry.SetPlaceHolder(i18n.T("Max p95 (ms)"))
+	page.maxErrorRateEntry = widget.NewEntry()
+	page.maxErrorRateEntry.SetPlaceHolder(i18n.T("Max error rate (%)"))
+
+	// Presets fill the whole form, so recurring setups are one click
+	page.presetSelect = widget.NewSelect(nil, page.onPresetSelected)
+	page.presetSelect.PlaceHolder = i18n.T("(none)")
+	page.loadPresets()
+	btnSavePreset := widget.NewButton(i18n.T("💾 Save Preset"), func() {
+		page.onSavePreset()
+	})
+	btnDeletePreset := widget.NewButton(i18n.T("🗑 Delete Preset"), func() {
+		page.onDeletePreset()
+	})
+	presetRow := container.NewBorder(nil, nil, nil, container.NewHBox(btnSavePreset, btnDeletePreset), page.presetSelect)
 
 	// Create refresh button for templates
-	btnRefreshTemplate := widget.NewButton("🔄 Refresh Templates", func() {
+	btnRefreshTemplate := widget.NewButton(i18n.T("🔄 Refresh Templates"), func() {
 		slog.Info("Tasks: Refresh templates button clicked")
 		if page.connSelect.Selected != "" {
 			// Save current selection
@@ -210,73 +351,110 @@ func NewTaskMonitorPageWithUC(win fyne.Window, connUC *usecase.ConnectionUseCase
 	// Create simplified form with general parameters
 	form := &widget.Form{
 		Items: []*widget.FormItem{
-			widget.NewFormItem("Connection", page.connSelect),
-			widget.NewFormItem("Template", templateRow),
-			widget.NewFormItem("Threads", page.threadsEntry),
-			widget.NewFormItem("Duration (seconds)", page.durationEntry),
-			widget.NewFormItem("Database Name", page.dbNameEntry),
+			widget.NewFormItem(i18n.T("Preset"), presetRow),
+			widget.NewFormItem(i18n.T("Connection"), page.connSelect),
+			widget.NewFormItem(i18n.T("Race Against"), page.raceSelect),
+			widget.NewFormItem(i18n.T("Template"), templateRow),
+			widget.NewFormItem(i18n.T("Threads"), page.threadsEntry),
+			widget.NewFormItem(i18n.T("Duration (seconds)"), page.durationEntry),
+			widget.NewFormItem(i18n.T("Warmup (seconds)"), page.warmupEntry),
+			widget.NewFormItem(i18n.T("Database Name"), page.dbNameEntry),
+			widget.NewFormItem(i18n.T("Rate Profile"), container.NewGridWithColumns(4,
+				page.rateProfileSelect, page.rateStartEntry, page.rateChangeEntry, page.rateStepEntry)),
+			widget.NewFormItem(i18n.T("Sample Interval (seconds)"), page.sampleIntervalEntry),
+			widget.NewFormItem(i18n.T("Repeat Run (times)"), page.repeatEntry),
+			widget.NewFormItem(i18n.T("Outlier k (σ)"), page.outlierEntry),
+			widget.NewFormItem(i18n.T("Execution"), page.remoteCheck),
+			widget.NewFormItem(i18n.T("Load Generator"), page.agentSelect),
+			widget.NewFormItem(i18n.T("Artifacts"), page.keepArtifactsCheck),
+			widget.NewFormItem(i18n.T("Replicas"), page.monitorReplicasCheck),
+			widget.NewFormItem(i18n.T("Server Log"), page.serverLogCheck),
+			widget.NewFormItem(i18n.T("Purpose"), page.purposeEntry),
+			widget.NewFormItem(i18n.T("Ticket / PR"), page.ticketEntry),
+			widget.NewFormItem(i18n.T("Environment"), page.environmentEntry),
+			widget.NewFormItem(i18n.T("SLA Targets"), container.NewGridWithColumns(3,
+				page.minTPSEntry, page.maxLatencyEntry, page.maxErrorRateEntry)),
 		},
 	}
 
-	// Create monitor widgets
-	page.statusLabel = widget.NewLabel("Idle")
+	// Create monitor widgets bound to the monitor data model
+	page.monitor = newMonitorBindings(60) // Keep max 60 lines history
+	page.statusLabel = widget.NewLabelWithData(page.monitor.status)
 	page.statusLabel.TextStyle = fyne.TextStyle{Bold: true}
 
-	page.tpsLabel = widget.NewLabel("--")
-	page.qpsLabel = widget.NewLabel("--")
-	page.latencyP95Label = widget.NewLabel("--")
-	page.errorsLabel = widget.NewLabel("0.00")
-	page.threadsLabel = widget.NewLabel("--")
-
-	page.progressBar = widget.NewProgressBar()
-	page.progressBar.SetValue(0)
+	page.progressBar = widget.NewProgressBarWithData(page.monitor.progress)
+	page.rateChart = newRateChart(page.monitor.rate, 120)
 
 	// Initialize log entry for sysbench output
-	page.maxLogLines = 60 // Keep max 60 lines history
 	page.logEntry = widget.NewMultiLineEntry()
+	page.logEntry.Bind(page.monitor.log.text)
 	page.logEntry.Disable()
-	page.logEntry.SetText("Waiting for benchmark data...\n")
+	// Scroll to bottom on new output (listeners run on the UI thread)
+	page.monitor.log.text.AddListener(binding.NewDataListener(func() {
+		page.logEntry.CursorRow = page.monitor.log.LineCount()
+	}))
 
 	// Create control buttons for each phase
-	page.btnPrepare = widget.NewButton("📦 Prepare", func() {
+	page.btnPrepare = widget.NewButton(i18n.T("📦 Prepare"), func() {
 		page.onPreparePhase()
 	})
 	page.btnPrepare.Importance = widget.MediumImportance
 
-	page.btnRun = widget.NewButton("▶ Run", func() {
+	page.btnRun = widget.NewButton(i18n.T("▶ Run"), func() {
 		page.onRunPhase()
 	})
 	page.btnRun.Importance = widget.HighImportance
 
-	page.btnCleanup = widget.NewButton("🧹 Cleanup", func() {
+	page.btnCleanup = widget.NewButton(i18n.T("🧹 Cleanup"), func() {
 		page.onCleanupPhase()
 	})
 	page.btnCleanup.Importance = widget.MediumImportance
 
-	page.btnStop = widget.NewButton("■ Stop", func() {
+	page.btnStop = widget.NewButton(i18n.T("■ Stop"), func() {
 		page.onStopTask()
 	})
 	page.btnStop.Disable() // Disabled initially
 
-	// Toolbar with Prepare, Run, Cleanup and Stop buttons
-	toolbar := container.NewHBox(page.btnPrepare, page.btnRun, page.btnCleanup, page.btnStop)
+	page.btnDryRun = widget.NewButton(i18n.T("🔍 Dry Run"), func() {
+		page.onDryRun()
+	})
+
+	page.btnLogs = widget.NewButton(i18n.T("📜 Logs"), func() {
+		page.onViewLogs()
+	})
+
+	page.btnResetDefaults = widget.NewButton(i18n.T("↺ Reset to Defaults"), func() {
+		page.onResetTaskDefaults()
+	})
+
+	page.btnInstallSOE = widget.NewButton(i18n.T("🗄 Install SOE Schema"), func() {
+		page.onInstallSOESchema()
+	})
+	page.btnInstallSOE.Disable() // Enabled when an Oracle connection is selected
+
+	// Toolbar with Prepare, Run, Cleanup, Stop, Dry Run, Logs, Reset to Defaults and Install SOE Schema buttons
+	toolbar := container.NewHBox(page.btnPrepare, page.btnRun, page.btnCleanup, page.btnStop, page.btnDryRun, page.btnLogs, page.btnResetDefaults, page.btnInstallSOE)
 
 	// Task configuration card (top section)
-	taskCard := widget.NewCard("Task Configuration", "", container.NewPadded(form))
+	taskCard := widget.NewCard(i18n.T("Task Configuration"), "", container.NewPadded(form))
 
 	// Monitor metrics card (middle section)
-	metricsGrid := container.NewGridWithColumns(4,
-		widget.NewLabel("TPS:"),
-		page.tpsLabel,
-		widget.NewLabel("QPS:"),
-		page.qpsLabel,
-		widget.NewLabel("95% Latency:"),
-		page.latencyP95Label,
-		widget.NewLabel("Threads:"),
-		page.threadsLabel,
-		widget.NewLabel("Errors/s:"),
-		page.errorsLabel,
-	)
+	page.metricsGrid = container.NewGridWithColumns(4)
+	for _, m := range page.monitor.metrics {
+		page.metricsGrid.Add(widget.NewLabel(m.title))
+		page.metricsGrid.Add(widget.NewLabelWithData(m.value))
+	}
+
+	// During a race the metrics of both connections are shown in two columns
+	page.raceHeaderA = widget.NewLabelWithStyle("A", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	page.raceHeaderB = widget.NewLabelWithStyle("B", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	page.raceGrid = container.NewGridWithColumns(3, widget.NewLabel(""), page.raceHeaderA, page.raceHeaderB)
+	for i, m := range page.monitor.metrics {
+		page.raceGrid.Add(widget.NewLabel(m.title))
+		page.raceGrid.Add(widget.NewLabelWithData(m.value))
+		page.raceGrid.Add(widget.NewLabelWithData(page.monitor.raceMetrics[i].value))
+	}
+	page.raceGrid.Hide()
 
 	statusRow := container.NewHBox(page.statusLabel)
 
@@ -284,14 +462,17 @@ func NewTaskMonitorPageWithUC(win fyne.Window, connUC *usecase.ConnectionUseCase
 	topSection := container.NewVBox(
 		statusRow,
 		widget.NewSeparator(),
-		metricsGrid,
+		page.metricsGrid,
+		page.raceGrid,
 		widget.NewSeparator(),
 		container.NewHBox(
-			widget.NewLabel("Progress:"),
+			widget.NewLabel(i18n.T("Progress:")),
 			page.progressBar,
+			widget.NewLabelWithData(page.monitor.eta),
 		),
+		page.rateChart,
 		widget.NewSeparator(),
-		widget.NewLabel("Real-time Output:"),
+		widget.NewLabel(i18n.T("Real-time Output:")),
 	)
 
 	// Wrap logEntry in custom widget that enforces minimum height of 240px (10 lines)
@@ -301,7 +482,7 @@ func NewTaskMonitorPageWithUC(win fyne.Window, connUC *usecase.ConnectionUseCase
 	// The center object in Border fills all available space
 	logContainer := container.NewBorder(topSection, nil, nil, nil, logWrapper)
 
-	monitorCard := widget.NewCard("Real-time Monitor", "", logContainer)
+	monitorCard := widget.NewCard(i18n.T("Real-time Monitor"), "", logContainer)
 
 	monitorToolbar := container.NewHBox(page.btnStop)
 
@@ -317,7 +498,7 @@ func NewTaskMonitorPageWithUC(win fyne.Window, connUC *usecase.ConnectionUseCase
 		monitorCard,
 	)
 
-	return topContent
+	return page, topContent
 }
 
 // loadConnections loads connections from the database.
@@ -331,7 +512,7 @@ func (p *TaskMonitorPage) loadConnections() {
 	conns, err := p.connUC.ListConnections(ctx)
 	if err != nil {
 		slog.Error("Tasks: Failed to load connections", "err", err)
-		dialog.ShowError(fmt.Errorf("failed to load connections: %w", err), p.win)
+		dialog.ShowError(fmt.Errorf(i18n.T("failed to load connections: %w"), err), p.win)
 		return
 	}
 
@@ -345,6 +526,10 @@ func (p *TaskMonitorPage) loadConnections() {
 	}
 
 	p.connSelect.Options = connectionNames
+	p.raceSelect.Options = append([]string{i18n.T(raceNone)}, connectionNames...)
+	if _, ok := p.connections[p.raceSelect.Selected]; !ok {
+		p.raceSelect.SetSelected(i18n.T(raceNone))
+	}
 
 	slog.Info("Tasks: Connections loaded", "count", len(connectionNames))
 }
@@ -354,6 +539,10 @@ func (p *TaskMonitorPage) onConnectionChanged() {
 	selectedName := p.connSelect.Selected
 	if selectedName == "" {
 		// Clear template selector
+		p.updateRemoteCheck(nil)
+		p.updateReplicaCheck(nil)
+		p.updateServerLogCheck(nil)
+		p.btnInstallSOE.Disable()
 		p.templateSelect.Options = []string{}
 		p.templateSelect.SetSelected("")
 		slog.Info("Tasks: Connection cleared, templates reset")
@@ -374,10 +563,172 @@ func (p *TaskMonitorPage) onConnectionChanged() {
 
 	slog.Info("Tasks: Connection changed", "connection", selectedName, "db_type", normalizedDBType)
 
+	// Enable remote execution only when the connection has WinRM configured
+	p.updateRemoteCheck(conn)
+	p.updateReplicaCheck(conn)
+	p.updateServerLogCheck(conn)
+	p.loadAgents()
+
+	// The SOE schema can only be installed on Oracle
+	if conn.GetType() == connection.DatabaseTypeOracle {
+		p.btnInstallSOE.Enable()
+	} else {
+		p.btnInstallSOE.Disable()
+	}
+
+	// Start from the parameters last used with this connection
+	p.loadTaskDefaults(conn)
+
 	// Load templates for this database type
 	p.loadTemplatesForDBType(normalizedDBType)
 }
 
+// loadTaskDefaults fills the general parameters with the task defaults of conn.
+func (p *TaskMonitorPage) loadTaskDefaults(conn connection.Connection) {
+	defaults := config.DefaultTaskDefaults()
+	if p.settingsUC != nil {
+		saved, err := p.settingsUC.GetTaskDefaults(context.Background(), conn.GetID())
+		if err != nil {
+			slog.Warn("Tasks: Failed to load task defaults", "connection", conn.GetName(), "err", err)
+		} else {
+			defaults = saved
+		}
+	}
+	p.setTaskDefaults(defaults)
+}
+
+// setTaskDefaults sets the threads, duration and database name entries.
+func (p *TaskMonitorPage) setTaskDefaults(defaults config.TaskDefaults) {
+	p.threadsEntry.SetText(strconv.Itoa(defaults.Threads))
+	p.durationEntry.SetText(strconv.Itoa(defaults.Duration))
+	p.dbNameEntry.SetText(defaults.DBName)
+}
+
+// saveTaskDefaults remembers the parameters of task for its connection.
+func (p *TaskMonitorPage) saveTaskDefaults(task *execution.BenchmarkTask) {
+	if p.settingsUC == nil {
+		return
+	}
+	threads, _ := strconv.Atoi(strings.TrimSpace(p.threadsEntry.Text))
+	duration, _ := strconv.Atoi(strings.TrimSpace(p.durationEntry.Text))
+	defaults := config.TaskDefaults{Threads: threads, Duration: duration, DBName: strings.TrimSpace(p.dbNameEntry.Text)}
+	if err := p.settingsUC.SaveTaskDefaults(context.Background(), task.ConnectionID, defaults); err != nil {
+		slog.Warn("Tasks: Failed to save task defaults", "connection_id", task.ConnectionID, "err", err)
+	}
+}
+
+// onResetTaskDefaults restores the default parameters and forgets the ones
+// saved for the selected connection.
+func (p *TaskMonitorPage) onResetTaskDefaults() {
+	p.setTaskDefaults(config.DefaultTaskDefaults())
+
+	conn, ok := p.connections[p.connSelect.Selected]
+	if !ok || p.settingsUC == nil {
+		return
+	}
+	if err := p.settingsUC.ResetTaskDefaults(context.Background(), conn.GetID()); err != nil {
+		slog.Error("Tasks: Failed to reset task defaults", "connection", conn.GetName(), "err", err)
+		dialog.ShowError(fmt.Errorf(i18n.T("failed to reset task defaults: %w"), err), p.win)
+		return
+	}
+	slog.Info("Tasks: Task defaults reset", "connection", conn.GetName())
+}
+
+// updateRemoteCheck enables the WinRM execution option for SQL Server
+// connections that have WinRM enabled, and disables it otherwise.
+func (p *TaskMonitorPage) updateRemoteCheck(conn connection.Connection) {
+	if sqlConn, ok := conn.(*connection.SQLServerConnection); ok {
+		if cfg := sqlConn.GetWinRMConfig(); cfg != nil && cfg.Enabled {
+			p.remoteCheck.Enable()
+			return
+		}
+	}
+	p.remoteCheck.SetChecked(false)
+	p.remoteCheck.Disable()
+}
+
+// updateReplicaCheck enables the replica monitoring option for connections
+// with replicas, and disables it otherwise.
+func (p *TaskMonitorPage) updateReplicaCheck(conn connection.Connection) {
+	if replicated, ok := conn.(connection.Replicated); ok && len(replicated.ReplicaAddresses()) > 0 {
+		p.monitorReplicasCheck.Enable()
+		return
+	}
+	p.monitorReplicasCheck.SetChecked(false)
+	p.monitorReplicasCheck.Disable()
+}
+
+// updateServerLogCheck enables the server log option for connections with an
+// SSH tunnel or, for SQL Server, WinRM, and disables it otherwise.
+func (p *TaskMonitorPage) updateServerLogCheck(conn connection.Connection) {
+	var ssh *connection.SSHTunnelConfig
+	switch c := conn.(type) {
+	case *connection.MySQLConnection:
+		ssh = c.SSH
+	case *connection.PostgreSQLConnection:
+		ssh = c.SSH
+	case *connection.OracleConnection:
+		ssh = c.SSH
+	case *connection.SQLServerConnection:
+		if cfg := c.GetWinRMConfig(); cfg != nil && cfg.Enabled {
+			p.serverLogCheck.Enable()
+			return
+		}
+	}
+	if ssh != nil && ssh.Enabled {
+		p.serverLogCheck.Enable()
+		return
+	}
+	p.serverLogCheck.SetChecked(false)
+	p.serverLogCheck.Disable()
+}
+
+// Load generator options besides the configured agents.
+const (
+	localLoadGenerator     = "This machine"            // Run tools on this machine
+	allAgentsLoadGenerator = "All agents (aggregated)" // Run on every agent at once, as one run
+)
+
+// loadAgents lists the configured agents in the load generator selector,
+// keeping the selected agent if it still exists.
+func (p *TaskMonitorPage) loadAgents() {
+	local := i18n.T(localLoadGenerator)
+	options := []string{local}
+	p.agentNames = nil
+	if p.settingsUC != nil {
+		agents, err := p.settingsUC.GetAgents(context.Background())
+		if err != nil {
+			slog.Warn("Tasks: Failed to load agents", "error", err)
+		}
+		for _, a := range agents {
+			p.agentNames = append(p.agentNames, a.Name)
+		}
+	}
+	options = append(options, p.agentNames...)
+	if len(p.agentNames) > 1 {
+		options = append(options, i18n.T(allAgentsLoadGenerator))
+	}
+
+	selected := p.agentSelect.Selected
+	p.agentSelect.Options = options
+	if !slices.Contains(options, selected) {
+		selected = local
+	}
+	p.agentSelect.SetSelected(selected)
+	p.agentSelect.Refresh()
+}
+
+// selectedAgents returns the agents the task runs on, or nil for this machine.
+func (p *TaskMonitorPage) selectedAgents() []string {
+	switch p.agentSelect.Selected {
+	case i18n.T(localLoadGenerator):
+		return nil
+	case i18n.T(allAgentsLoadGenerator):
+		return slices.Clone(p.agentNames)
+	}
+	return []string{p.agentSelect.Selected}
+}
+
 // loadTemplatesForDBType loads templates for a specific database type.
 func (p *TaskMonitorPage) loadTemplatesForDBType(dbType string) {
 	slog.Info("Tasks: loadTemplatesForDBType called", "db_type", dbType)
@@ -444,6 +795,11 @@ func (p *TaskMonitorPage) loadTemplatesData() []templateInfo {
 		TableSize: 10000000,
 	}
 
+	// TPC-C templates: one table set; sysbench-tpcc has no table size
+	tpccParams := &OLTPParameters{
+		Tables: 1,
+	}
+
 	builtinTemplates := []templateInfo{
 		// MySQL templates
 		{
@@ -476,6 +832,16 @@ func (p *TaskMonitorPage) loadTemplatesData() []templateInfo {
 			IsDefault:   false,
 			Parameters:  diskBoundParams,
 		},
+		{
+			ID:          "sysbench-tpcc",
+			Name:        "TPC-C (sysbench-tpcc)",
+			Description: "TPC-C style workload for MySQL through the Percona sysbench-tpcc scripts (10 warehouses), reporting tpmC",
+			Tool:        "sysbench",
+			DBType:      "MySQL",
+			IsBuiltin:   true,
+			IsDefault:   false,
+			Parameters:  tpccParams,
+		},
 		// PostgreSQL templates
 		{
 			ID:          "sysbench-postgresql-test",
@@ -507,6 +873,16 @@ func (p *TaskMonitorPage) loadTemplatesData() []templateInfo {
 			IsDefault:   false,
 			Parameters:  diskBoundParams,
 		},
+		{
+			ID:          "sysbench-tpcc",
+			Name:        "TPC-C (sysbench-tpcc)",
+			Description: "TPC-C style workload for PostgreSQL through the Percona sysbench-tpcc scripts (10 warehouses), reporting tpmC",
+			Tool:        "sysbench",
+			DBType:      "PostgreSQL",
+			IsBuiltin:   true,
+			IsDefault:   false,
+			Parameters:  tpccParams,
+		},
 	}
 
 	// Load custom templates from global storage
@@ -539,8 +915,9 @@ func (p *TaskMonitorPage) loadTemplatesData() []templateInfo {
 		}
 	}
 
-	// Combine built-in and custom templates
-	allTemplates := append(builtinTemplates, customTemplates...)
+	// Combine built-in, SQL mix and custom templates
+	allTemplates := append(builtinTemplates, p.sqlMixTemplates()...)
+	allTemplates = append(allTemplates, customTemplates...)
 	slog.Info("Tasks: Total templates loaded", "builtin", len(builtinTemplates), "custom", len(customTemplates), "total", len(allTemplates))
 
 	// Sync custom templates to repository if templateUC is available (run in background to avoid UI blocking)
@@ -551,6 +928,38 @@ func (p *TaskMonitorPage) loadTemplatesData() []templateInfo {
 	return allTemplates
 }
 
+// sqlMixTemplates returns the templates of the SQL mix runner, built in or
+// from the templates directory, once for each of their database types.
+func (p *TaskMonitorPage) sqlMixTemplates() []templateInfo {
+	if p.templateUC == nil {
+		return nil
+	}
+	templates, err := p.templateUC.ListTemplates(context.Background())
+	if err != nil {
+		slog.Warn("Tasks: Failed to list SQL mix templates", "error", err)
+		return nil
+	}
+
+	var infos []templateInfo
+	for _, tmpl := range templates {
+		if tmpl.Tool != sqlmix.Tool {
+			continue
+		}
+		for _, dbType := range tmpl.DatabaseTypes {
+			infos = append(infos, templateInfo{
+				ID:          tmpl.ID,
+				Name:        tmpl.Name + " (SQL mix)",
+				Description: tmpl.Description,
+				Tool:        tmpl.Tool,
+				DBType:      normalizeDBType(dbType),
+				IsBuiltin:   true,
+			})
+		}
+	}
+	slices.SortFunc(infos, func(a, b templateInfo) int { return strings.Compare(a.Name, b.Name) })
+	return infos
+}
+
 // syncCustomTemplatesToRepository saves custom templates to the TemplateRepository.
 // This ensures that custom templates created in the GUI can be used by BenchmarkUseCase.
 func (p *TaskMonitorPage) syncCustomTemplatesToRepository(customTemplates []templateInfo) {
@@ -658,56 +1067,195 @@ func (p *TaskMonitorPage) onRunPhase() {
 	p.validateAndExecutePhase("run")
 }
 
+// Run starts the run phase of the configured task (Ctrl+R), unless a phase
+// is already running.
+func (p *TaskMonitorPage) Run() {
+	if p.btnRun.Disabled() {
+		return
+	}
+	p.onRunPhase()
+}
+
 // onCleanupPhase executes the cleanup phase.
 func (p *TaskMonitorPage) onCleanupPhase() {
 	slog.Info("Tasks: onCleanupPhase called")
 	p.validateAndExecutePhase("cleanup")
 }
 
-// validateAndExecutePhase validates inputs and executes a specific phase.
-func (p *TaskMonitorPage) validateAndExecutePhase(phase string) {
-	// Validate
+// onDryRun previews the commands of a full benchmark (prepare, warmup, run, cleanup)
+// and the pre-check results, without executing anything.
+func (p *TaskMonitorPage) onDryRun() {
+	slog.Info("Tasks: onDryRun called")
+
 	if p.connSelect.Selected == "" {
-		slog.Warn("Tasks: No connection selected")
-		dialog.ShowError(fmt.Errorf("please select a connection"), p.win)
+		dialog.ShowError(errors.New(i18n.T("please select a connection")), p.win)
 		return
 	}
-
 	if p.templateSelect.Selected == "" {
-		slog.Warn("Tasks: No template selected")
-		dialog.ShowError(fmt.Errorf("please select a template"), p.win)
+		dialog.ShowError(errors.New(i18n.T("please select a template")), p.win)
+		return
+	}
+	if p.benchmarkUC == nil {
+		dialog.ShowError(errors.New(i18n.T("benchmark use case not available - please check application configuration")), p.win)
+		return
+	}
+
+	task, err := p.buildBenchmarkTask()
+	if err != nil {
+		dialog.ShowError(fmt.Errorf(i18n.T("failed to build task: %w"), err), p.win)
+		return
+	}
+	task.Options.DryRun = true
+
+	// Pre-checks test the connection, so plan off the UI thread
+	progress := dialog.NewCustomWithoutButtons(i18n.T("Dry Run"), widget.NewProgressBarInfinite(), p.win)
+	progress.Show()
+
+	go func() {
+		plan, err := p.benchmarkUC.PlanBenchmark(context.Background(), task)
+		fyne.Do(func() {
+			progress.Hide()
+			if err != nil {
+				slog.Error("Tasks: Dry run failed", "error", err)
+				dialog.ShowError(fmt.Errorf(i18n.T("dry run failed: %w"), err), p.win)
+				return
+			}
+			slog.Info("Tasks: Dry run planned", "commands", len(plan.Commands), "pre_checks_passed", plan.PreChecksPassed())
+			p.showDryRunDialog(plan)
+		})
+	}()
+}
+
+// showDryRunDialog shows a benchmark plan.
+func (p *TaskMonitorPage) showDryRunDialog(plan *usecase.BenchmarkPlan) {
+	text := widget.NewMultiLineEntry()
+	text.SetText(plan.Format())
+	text.TextStyle = fyne.TextStyle{Monospace: true}
+	text.Wrapping = fyne.TextWrapOff
+
+	status := widget.NewLabel(i18n.T("✓ All pre-checks passed. Nothing was executed."))
+	if !plan.PreChecksPassed() {
+		status.SetText(i18n.T("✗ Some pre-checks failed; a real run would not start. Nothing was executed."))
+	}
+
+	content := container.NewBorder(status, nil, nil, nil, text)
+	dlg := dialog.NewCustom(i18n.T("Dry Run"), i18n.T("Close"), content, p.win)
+	dlg.Resize(fyne.NewSize(800, 520))
+	dlg.Show()
+}
+
+// onViewLogs shows the log of the current run, following new entries.
+func (p *TaskMonitorPage) onViewLogs() {
+	runID := p.currentRunID
+	if runID == "" || p.benchmarkUC == nil {
+		dialog.ShowInformation(i18n.T("Run Log"), i18n.T("No run has been started yet."), p.win)
+		return
+	}
+
+	showRunLogDialog(p.win, i18n.T("Run Log"), func(ctx context.Context, filter usecase.LogFilter) ([]usecase.LogEntry, error) {
+		return p.benchmarkUC.GetRunLogs(ctx, runID, filter)
+	}, true)
+}
+
+// onInstallSOESchema asks for the oewizard settings and DBA credentials, then
+// installs the swingbench SOE schema on the selected Oracle connection as a
+// prepare phase, following the oewizard output in the monitor log.
+func (p *TaskMonitorPage) onInstallSOESchema() {
+	conn, ok := p.connections[p.connSelect.Selected]
+	if !ok || conn.GetType() != connection.DatabaseTypeOracle {
+		dialog.ShowError(errors.New(i18n.T("please select an Oracle connection")), p.win)
+		return
+	}
+	if p.isRunning {
+		dialog.ShowError(errors.New(i18n.T("a phase is already running")), p.win)
+		return
+	}
+	if p.benchmarkUC == nil {
+		dialog.ShowError(errors.New(i18n.T("benchmark use case not available - please check application configuration")), p.win)
+		return
+	}
+
+	// oewizard runs in the prepare phase of a swingbench template
+	var templateID string
+	for _, tmpl := range p.templates {
+		if tmpl.Name == p.templateSelect.Selected && tmpl.Tool == "swingbench" {
+			templateID = tmpl.ID
+			break
+		}
+	}
+	if templateID == "" {
+		dialog.ShowError(errors.New(i18n.T("please select a swingbench template")), p.win)
 		return
 	}
 
-	// ⭐ 关键改进：在执行前先测试数据库连接（仅失败时弹窗）
-	if p.connUC != nil {
-		// Get connection object
-		connName := p.connSelect.Selected
-		conn, ok := p.connections[connName]
+	scaleEntry := widget.NewEntry()
+	scaleEntry.SetText("1")
+	threadsEntry := widget.NewEntry()
+	threadsEntry.SetText("32")
+	dbaUserEntry := widget.NewEntry()
+	dbaUserEntry.SetText("system")
+	dbaPasswordEntry := widget.NewPasswordEntry()
+
+	items := []*widget.FormItem{
+		widget.NewFormItem(i18n.T("Scale (GB)"), scaleEntry),
+		widget.NewFormItem(i18n.T("Load Threads"), threadsEntry),
+		widget.NewFormItem(i18n.T("DBA Username"), dbaUserEntry),
+		widget.NewFormItem(i18n.T("DBA Password"), dbaPasswordEntry),
+	}
+	dlg := dialog.NewForm(i18n.T("Install SOE Schema"), i18n.T("Install"), i18n.T("Cancel"), items, func(ok bool) {
 		if !ok {
-			slog.Error("Tasks: Connection not found", "name", connName)
-			dialog.ShowError(fmt.Errorf("connection not found: %s", connName), p.win)
 			return
 		}
-
-		slog.Info("Tasks: Testing connection before benchmark execution", "connection", connName, "connection_id", conn.GetID())
-
-		// Test connection（静默测试，不弹窗）
-		testResult, err := p.connUC.TestConnection(context.Background(), conn.GetID())
-		if err != nil {
-			slog.Error("Tasks: Connection test failed", "connection", connName, "error", err)
-			dialog.ShowError(fmt.Errorf("connection test failed for %s: %w\n\nTask execution cancelled.", connName, err), p.win)
+		scale, err := strconv.Atoi(strings.TrimSpace(scaleEntry.Text))
+		if err != nil || scale < 1 {
+			dialog.ShowError(errors.New(i18n.T("invalid scale (must be >= 1)")), p.win)
+			return
+		}
+		threads, err := strconv.Atoi(strings.TrimSpace(threadsEntry.Text))
+		if err != nil || threads < 1 {
+			dialog.ShowError(errors.New(i18n.T("invalid load threads (must be >= 1)")), p.win)
 			return
 		}
 
-		if !testResult.Success {
-			slog.Error("Tasks: Connection test unsuccessful", "connection", connName, "error", testResult.Error)
-			dialog.ShowError(fmt.Errorf("connection test failed for %s:\n%s\n\nPlease check your connection settings and database availability.\n\nTask execution cancelled.", connName, testResult.Error), p.win)
+		ctx := context.Background()
+		task, err := p.benchmarkUC.SOESchemaTask(ctx, usecase.SOESchemaInstall{
+			ConnectionID: conn.GetID(),
+			TemplateID:   templateID,
+			Scale:        scale,
+			Threads:      threads,
+			DBAUsername:  strings.TrimSpace(dbaUserEntry.Text),
+			DBAPassword:  dbaPasswordEntry.Text,
+		})
+		if err != nil {
+			dialog.ShowError(fmt.Errorf(i18n.T("failed to install SOE schema: %w"), err), p.win)
 			return
 		}
+		slog.Info("Tasks: Installing SOE schema", "connection", conn.GetName(), "scale", scale, "threads", threads)
+		p.launchBenchmarkPhase(ctx, task, "prepare")
+	}, p.win)
+	dlg.Resize(fyne.NewSize(420, 280))
+	dlg.Show()
+}
+
+// validateAndExecutePhase validates inputs and executes a specific phase.
+func (p *TaskMonitorPage) validateAndExecutePhase(phase string) {
+	// Demo mode simulates runs without a database or benchmark tools
+	if p.demoUC != nil && p.demoUC.Enabled(context.Background()) {
+		p.startDemoPhase(phase)
+		return
+	}
+
+	// Validate
+	if p.connSelect.Selected == "" {
+		slog.Warn("Tasks: No connection selected")
+		dialog.ShowError(errors.New(i18n.T("please select a connection")), p.win)
+		return
+	}
 
-		// 成功时只记录日志，不弹窗
-		slog.Info("Tasks: Connection test successful", "connection", connName, "latency_ms", testResult.LatencyMs, "db_version", testResult.DatabaseVersion)
+	if p.templateSelect.Selected == "" {
+		slog.Warn("Tasks: No template selected")
+		dialog.ShowError(errors.New(i18n.T("please select a template")), p.win)
+		return
 	}
 
 	slog.Info("Tasks: Building benchmark task", "connection", p.connSelect.Selected, "template", p.templateSelect.Selected, "phase", phase)
@@ -715,15 +1263,25 @@ func (p *TaskMonitorPage) validateAndExecutePhase(phase string) {
 	task, err := p.buildBenchmarkTask()
 	if err != nil {
 		slog.Error("Tasks: Failed to build task", "error", err)
-		dialog.ShowError(fmt.Errorf("failed to build task: %w", err), p.win)
+		dialog.ShowError(fmt.Errorf(i18n.T("failed to build task: %w"), err), p.win)
 		return
 	}
 
 	slog.Info("Tasks: Task built successfully", "task_id", task.ID, "connection_id", task.ConnectionID, "template_id", task.TemplateID)
+	if race := p.raceTarget(); phase == "run" && race != nil {
+		if race.ConnectionID == task.ConnectionID {
+			dialog.ShowError(errors.New(i18n.T("please select a different connection to race against")), p.win)
+			return
+		}
+		if task.Options.Repetitions() > 1 {
+			dialog.ShowError(errors.New(i18n.T("a race runs once - set Repeat Run to 1")), p.win)
+			return
+		}
+	}
 	// Check if BenchmarkUseCase is available
 	if p.benchmarkUC == nil {
 		slog.Error("Tasks: BenchmarkUseCase is nil")
-		dialog.ShowError(fmt.Errorf("benchmark use case not available - please check application configuration"), p.win)
+		dialog.ShowError(errors.New(i18n.T("benchmark use case not available - please check application configuration")), p.win)
 		return
 	}
 
@@ -737,85 +1295,155 @@ func (p *TaskMonitorPage) onRunTask() {
 	p.validateAndExecutePhase("run")
 }
 
-// startSimulatedBenchmark starts a simulated benchmark (for debugging).
-func (p *TaskMonitorPage) startSimulatedBenchmark(task *execution.BenchmarkTask) {
-	slog.Info("Tasks: Starting SIMULATED benchmark (debug mode)", "task_id", task.ID)
+// startDemoPhase simulates a phase in demo mode: the run phase shows
+// synthetic samples and saves a history record like a real run; prepare and
+// cleanup have nothing to do.
+func (p *TaskMonitorPage) startDemoPhase(phase string) {
+	if phase != "run" {
+		dialog.ShowInformation(i18n.Tf("%s Completed", phaseTitle(phase)), i18n.T("Demo mode is on: no database is used, so there is nothing to prepare or clean up."), p.win)
+		return
+	}
+	opts, err := p.demoOptions()
+	if err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+	slog.Info("Tasks: Starting demo run", "connection", opts.ConnectionName, "threads", opts.Threads, "duration", opts.Duration)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancelDemo = cancel
+	p.currentRunID = ""
 
 	// Lock task form during execution
 	p.setTaskFormEnabled(false)
-
-	// Start monitoring
 	p.isRunning = true
-	p.statusLabel.SetText("Status: Running (Simulated)")
-	p.statusLabel.TextStyle = fyne.TextStyle{Bold: true}
-
+	p.monitor.status.Set(i18n.T("Status: Run (Demo)"))
+	p.btnPrepare.Disable()
 	p.btnRun.Disable()
+	p.btnCleanup.Disable()
 	p.btnStop.Enable()
-
-	// Get parameters
-	threads, _ := task.Parameters["threads"].(int)
-	duration, _ := task.Parameters["time"].(int)
-	rateLimit, _ := task.Parameters["rate"].(int)
-
-	// Start simulated execution
-	go p.simulateExecution(threads, duration, rateLimit)
-}
-
-// simulateExecution simulates running a benchmark task.
-func (p *TaskMonitorPage) simulateExecution(threads, duration, rateLimit int) {
-	startTime := time.Now()
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		// Check if stopped at the beginning of each iteration
-		if !p.isRunning {
-			slog.Info("Tasks: SimulateExecution detected stop signal, exiting")
+	p.monitor.threads.Set(strconv.Itoa(opts.Threads))
+	p.monitor.log.Reset()
+	p.monitor.eta.Set("")
+	p.monitor.rate.Reset()
+
+	total := (opts.Warmup + opts.Duration).Seconds()
+	start := time.Now()
+	go func() {
+		run, err := p.demoUC.Run(ctx, opts, func(sample execution.MetricSample) {
+			if !p.isRunning {
+				return // Don't update if stopped
+			}
+			p.monitor.updateSample(sample)
+			p.monitor.progress.Set(min(time.Since(start).Seconds()/total, 0.95))
+		})
+		if ctx.Err() != nil {
+			return // Stopped; the Stop button resets the page
+		}
+		p.cancelDemo = nil
+		cancel()
+		if err != nil {
+			p.handleBenchmarkError(context.Background(), "", err, phase)
 			return
 		}
+		p.handleBenchmarkCompleted(context.Background(), run, phase)
+	}()
+}
 
-		select {
-		case <-ticker.C:
-			// Check again before processing
-			if !p.isRunning {
-				slog.Info("Tasks: SimulateExecution detected stop signal in ticker, exiting")
-				return
-			}
+// demoOptions returns the options of a demo run from the task form. The
+// selected connection and template only name the run; neither is required.
+func (p *TaskMonitorPage) demoOptions() (usecase.DemoOptions, error) {
+	threads, err := strconv.Atoi(strings.TrimSpace(p.threadsEntry.Text))
+	if err != nil || threads < 1 {
+		return usecase.DemoOptions{}, errors.New(i18n.T("invalid threads value (must be >= 1)"))
+	}
+	duration, err := strconv.Atoi(strings.TrimSpace(p.durationEntry.Text))
+	if err != nil || duration <= 0 {
+		return usecase.DemoOptions{}, errors.New(i18n.T("invalid duration value"))
+	}
+	warmup := 0
+	if text := strings.TrimSpace(p.warmupEntry.Text); text != "" {
+		warmup, err = strconv.Atoi(text)
+		if err != nil || warmup < 0 {
+			return usecase.DemoOptions{}, errors.New(i18n.T("invalid warmup value (must be >= 0)"))
+		}
+	}
 
-			elapsed := int(time.Since(startTime).Seconds())
-			if elapsed >= duration {
-				break
-			}
+	opts := usecase.DemoOptions{
+		ConnectionName: p.connSelect.Selected,
+		TemplateName:   p.templateSelect.Selected,
+		Threads:        threads,
+		Duration:       time.Duration(duration) * time.Second,
+		Warmup:         time.Duration(warmup) * time.Second,
+		Metadata: execution.RunMetadata{
+			Purpose:     strings.TrimSpace(p.purposeEntry.Text),
+			Ticket:      strings.TrimSpace(p.ticketEntry.Text),
+			Environment: strings.TrimSpace(p.environmentEntry.Text),
+		},
+	}
+	if conn, ok := p.connections[p.connSelect.Selected]; ok {
+		opts.DatabaseType = string(conn.GetType())
+	}
+	return opts, nil
+}
 
-			progress := float64(elapsed) / float64(duration)
-			p.progressBar.SetValue(progress)
+// Rate profile choices of the task form (English, shown translated).
+const (
+	rateProfileFixed     = "Fixed"
+	rateProfileStaircase = "Staircase"
+	rateProfileRamp      = "Linear Ramp"
+)
 
-			// Simulate metrics
-			tps := 1000 + int(progress*500)
-			qps := tps * 20 // Simulate QPS as 20x TPS
-			if qps < 1 {
-				qps = 1
-			}
-			errors := int(progress * 2)
+var rateProfileOptions = []string{rateProfileFixed, rateProfileStaircase, rateProfileRamp}
 
-			p.tpsLabel.SetText(fmt.Sprintf("%d", tps))
-			p.qpsLabel.SetText(fmt.Sprintf("%d", qps))
-			p.errorsLabel.SetText(fmt.Sprintf("%d", errors))
+// onRateProfileChanged enables the rate profile entries that apply to the selection.
+func (p *TaskMonitorPage) onRateProfileChanged(selected string) {
+	entries := []*widget.Entry{p.rateStartEntry, p.rateChangeEntry, p.rateStepEntry}
+	for _, entry := range entries {
+		if selected == i18n.T(rateProfileFixed) {
+			entry.Disable()
+		} else {
+			entry.Enable()
 		}
 	}
+	if selected == i18n.T(rateProfileRamp) {
+		p.rateChangeEntry.SetPlaceHolder(i18n.T("target TPS"))
+	} else {
+		p.rateChangeEntry.SetPlaceHolder(i18n.T("+TPS per step"))
+	}
+}
 
-	// Task completed
-	if p.isRunning {
-		p.isRunning = false
-		p.statusLabel.SetText("Status: Completed (Simulated)")
-		p.progressBar.SetValue(1.0)
+// buildRateProfile builds the rate profile of the task form; nil for a fixed rate.
+func (p *TaskMonitorPage) buildRateProfile() (*execution.RateProfile, error) {
+	var kind execution.RateProfileKind
+	switch p.rateProfileSelect.Selected {
+	case i18n.T(rateProfileStaircase):
+		kind = execution.RateProfileStaircase
+	case i18n.T(rateProfileRamp):
+		kind = execution.RateProfileRamp
+	default:
+		return nil, nil
+	}
 
-		p.btnRun.Enable()
-		p.btnStop.Disable()
-		p.setTaskFormEnabled(true)
+	values := make([]int, 3)
+	for i, entry := range []*widget.Entry{p.rateStartEntry, p.rateChangeEntry, p.rateStepEntry} {
+		v, err := strconv.Atoi(strings.TrimSpace(entry.Text))
+		if err != nil {
+			return nil, fmt.Errorf(i18n.T("invalid rate profile: %q is not a number"), entry.Text)
+		}
+		values[i] = v
+	}
 
-		slog.Info("Tasks: Simulated benchmark completed")
+	profile := &execution.RateProfile{Kind: kind, StartRate: values[0], StepTime: values[2]}
+	if kind == execution.RateProfileRamp {
+		profile.TargetRate = values[1]
+	} else {
+		profile.StepRate = values[1]
 	}
+	if err := profile.Validate(); err != nil {
+		return nil, fmt.Errorf(i18n.T("invalid rate profile: %w"), err)
+	}
+	return profile, nil
 }
 
 // buildBenchmarkTask creates a BenchmarkTask from UI inputs.
@@ -824,22 +1452,83 @@ func (p *TaskMonitorPage) buildBenchmarkTask() (*execution.BenchmarkTask, error)
 	connName := p.connSelect.Selected
 	conn, ok := p.connections[connName]
 	if !ok {
-		return nil, fmt.Errorf("connection not found: %s", connName)
+		return nil, fmt.Errorf(i18n.T("connection not found: %s"), connName)
 	}
 
 	// Parse and validate general parameters
 	threads, err := strconv.Atoi(strings.TrimSpace(p.threadsEntry.Text))
 	if err != nil || threads < 1 {
-		return nil, fmt.Errorf("invalid threads value (must be >= 1)")
+		return nil, errors.New(i18n.T("invalid threads value (must be >= 1)"))
 	}
 
 	duration, err := strconv.Atoi(strings.TrimSpace(p.durationEntry.Text))
 	if err != nil || duration <= 0 {
-		return nil, fmt.Errorf("invalid duration value")
+		return nil, errors.New(i18n.T("invalid duration value"))
+	}
+
+	// Warmup samples are kept separately and excluded from results
+	warmup := 0
+	if text := strings.TrimSpace(p.warmupEntry.Text); text != "" {
+		warmup, err = strconv.Atoi(text)
+		if err != nil || warmup < 0 {
+			return nil, errors.New(i18n.T("invalid warmup value (must be >= 0)"))
+		}
 	}
 
 	dbName := strings.TrimSpace(p.dbNameEntry.Text)
 
+	rateProfile, err := p.buildRateProfile()
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty sample interval means adaptive (chosen from duration by the use case)
+	sampleInterval := 0
+	if text := strings.TrimSpace(p.sampleIntervalEntry.Text); text != "" && text != "auto" {
+		sampleInterval, err = strconv.Atoi(text)
+		if err != nil || sampleInterval < 1 {
+			return nil, errors.New(i18n.T("invalid sample interval (must be >= 1 second, or empty for auto)"))
+		}
+	}
+
+	// Repeat applies to the run phase; 1 runs the task once
+	repeat := 1
+	if text := strings.TrimSpace(p.repeatEntry.Text); text != "" {
+		repeat, err = strconv.Atoi(text)
+		if err != nil || repeat < 1 || repeat > execution.MaxRepeat {
+			return nil, fmt.Errorf(i18n.T("invalid repeat value (must be between 1 and %d)"), execution.MaxRepeat)
+		}
+	}
+
+	outlierSigma := 0.0
+	if text := strings.TrimSpace(p.outlierEntry.Text); text != "" {
+		outlierSigma, err = strconv.ParseFloat(text, 64)
+		if err != nil || outlierSigma <= 0 {
+			return nil, fmt.Errorf(i18n.T("invalid outlier k (must be > 0, or empty for %.0f)"), execution.DefaultOutlierSigma)
+		}
+	}
+
+	// Empty SLA targets are not asserted, or left to the template
+	var assertions assertion.Targets
+	for _, target := range []struct {
+		entry *widget.Entry
+		limit **float64
+	}{
+		{p.minTPSEntry, &assertions.MinTPS},
+		{p.maxLatencyEntry, &assertions.MaxLatencyP95},
+		{p.maxErrorRateEntry, &assertions.MaxErrorRate},
+	} {
+		text := strings.TrimSpace(target.entry.Text)
+		if text == "" {
+			continue
+		}
+		limit, err := strconv.ParseFloat(text, 64)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf(i18n.T("invalid SLA target %q (must be >= 0, or empty for none)"), text)
+		}
+		*target.limit = &limit
+	}
+
 	// Get OLTP parameters and template ID from selected template
 	var tables, tableSize int
 	var templateID string
@@ -878,14 +1567,26 @@ func (p *TaskMonitorPage) buildBenchmarkTask() (*execution.BenchmarkTask, error)
 	options := execution.TaskOptions{
 		SkipPrepare:    false,
 		SkipCleanup:    false,
-		WarmupTime:     0,
-		SampleInterval: 10 * time.Second, // Default 10 seconds
-		DryRun:         false,            // Set to true for testing without actually running
+		WarmupTime:     warmup,
+		SampleInterval: time.Duration(sampleInterval) * time.Second,
+		DryRun:         false, // Set to true for testing without actually running
 		PrepareTimeout: 30 * time.Minute,
 		// Set timeout to 2x duration as a safety net to prevent hangs
 		// Sysbench will control its own execution time via --time parameter
 		// We should wait for it to complete naturally, not force kill it
-		RunTimeout: time.Duration(duration*2) * time.Second,
+		RunTimeout:       time.Duration(duration*2) * time.Second,
+		RemoteWinRM:      p.remoteCheck.Checked,
+		KeepArtifacts:    p.keepArtifactsCheck.Checked,
+		Repeat:           repeat,
+		OutlierSigma:     outlierSigma,
+		RateProfile:      rateProfile,
+		MonitorReplicas:  p.monitorReplicasCheck.Checked,
+		CollectServerLog: p.serverLogCheck.Checked,
+	}
+	if agents := p.selectedAgents(); len(agents) == 1 {
+		options.Agent = agents[0]
+	} else {
+		options.Agents = agents
 	}
 
 	// Create task
@@ -897,7 +1598,13 @@ func (p *TaskMonitorPage) buildBenchmarkTask() (*execution.BenchmarkTask, error)
 		Parameters:   parameters,
 		Options:      options,
 		Tags:         []string{"gui", string(conn.GetType())},
-		CreatedAt:    time.Now(),
+		Metadata: execution.RunMetadata{
+			Purpose:     strings.TrimSpace(p.purposeEntry.Text),
+			Ticket:      strings.TrimSpace(p.ticketEntry.Text),
+			Environment: strings.TrimSpace(p.environmentEntry.Text),
+		},
+		Assertions: assertions,
+		CreatedAt:  time.Now(),
 	}
 
 	slog.Info("Tasks: Built benchmark task",
@@ -905,18 +1612,24 @@ func (p *TaskMonitorPage) buildBenchmarkTask() (*execution.BenchmarkTask, error)
 		"connection_id", task.ConnectionID,
 		"threads", threads,
 		"duration", duration,
-		"db_name", dbName)
+		"warmup", warmup,
+		"db_name", dbName,
+		"repeat", repeat,
+		"remote_winrm", options.RemoteWinRM,
+		"agents", options.LoadGenerators())
 
 	return task, nil
 }
 
-// startBenchmarkPhase starts a specific benchmark phase (prepare/run/cleanup).
+// startBenchmarkPhase configures the task for a phase (prepare/run/cleanup) and
+// starts it once its pre-checks pass.
 func (p *TaskMonitorPage) startBenchmarkPhase(task *execution.BenchmarkTask, phase string) {
 	ctx := context.Background()
 
 	// Configure task options based on phase
 	switch phase {
 	case "prepare":
+		task.Options.Repeat = 0
 		task.Options.SkipPrepare = false
 		task.Options.SkipCleanup = true
 		task.Options.WarmupTime = 0
@@ -934,6 +1647,7 @@ func (p *TaskMonitorPage) startBenchmarkPhase(task *execution.BenchmarkTask, pha
 		}
 
 	case "cleanup":
+		task.Options.Repeat = 0
 		task.Options.SkipPrepare = true
 		task.Options.SkipCleanup = false
 		task.Options.WarmupTime = 0
@@ -942,15 +1656,210 @@ func (p *TaskMonitorPage) startBenchmarkPhase(task *execution.BenchmarkTask, pha
 		// Don't save _original_time for cleanup - this signals cleanup-only mode
 	}
 
+	// The connection, tool, disk space and schema are checked before anything starts
+	progress := dialog.NewCustomWithoutButtons(i18n.T("Pre-checks"), widget.NewProgressBarInfinite(), p.win)
+	progress.Show()
+
+	race := p.raceTarget()
+	go func() {
+		checks, err := p.benchmarkUC.PreCheckBenchmark(ctx, task)
+		// A race also checks the connection it races against
+		if phase == "run" && race != nil && err == nil {
+			raceTask := *task
+			raceTask.ConnectionID = race.ConnectionID
+			var raceChecks []usecase.PreCheckResult
+			raceChecks, err = p.benchmarkUC.PreCheckBenchmark(ctx, &raceTask)
+			for _, check := range raceChecks {
+				check.Name = fmt.Sprintf("%s (%s)", check.Name, race.ConnectionName)
+				checks = append(checks, check)
+			}
+		}
+		fyne.Do(func() {
+			progress.Hide()
+			if err != nil {
+				slog.Error("Tasks: Pre-checks failed to run", "phase", phase, "error", err)
+				dialog.ShowError(fmt.Errorf(i18n.T("pre-checks failed: %w"), err), p.win)
+				return
+			}
+			for _, check := range checks {
+				if !check.Passed() {
+					slog.Warn("Tasks: Pre-check failed", "phase", phase, "check", check.Name, "error", check.Err)
+					p.showPreCheckDialog(phase, checks)
+					return
+				}
+			}
+			if phase == "prepare" {
+				p.confirmPrepare(ctx, task)
+				return
+			}
+			if phase == "run" {
+				p.confirmDataset(ctx, task)
+				return
+			}
+			if phase == "cleanup" {
+				p.confirmCleanup(ctx, task)
+				return
+			}
+			p.launchBenchmarkPhase(ctx, task, phase)
+		})
+	}()
+}
+
+// confirmDataset compares the run with the data set prepared on the connection
+// and, if they differ, asks before running on data that may invalidate the results.
+func (p *TaskMonitorPage) confirmDataset(ctx context.Context, task *execution.BenchmarkTask) {
+	mismatch, err := p.benchmarkUC.DatasetMismatch(ctx, task)
+	if err != nil {
+		slog.Warn("Tasks: Failed to compare the prepared data set", "error", err)
+	}
+	if mismatch == "" {
+		p.launchBenchmarkPhase(ctx, task, "run")
+		return
+	}
+
+	slog.Warn("Tasks: Prepared data set does not match the run", "mismatch", mismatch)
+	message := widget.NewLabel(i18n.Tf("The data set prepared on this connection does not match the run:\n%s\n\n"+
+		"Results may be invalid unless the data is prepared again. Run anyway?", strings.ReplaceAll(mismatch, "; ", "\n")))
+	message.Wrapping = fyne.TextWrapWord
+	message.Importance = widget.WarningImportance
+	dlg := dialog.NewCustomConfirm(i18n.T("Data Set Mismatch"), i18n.T("Run Anyway"), i18n.T("Cancel"), message, func(ok bool) {
+		if ok {
+			p.launchBenchmarkPhase(ctx, task, "run")
+		}
+	}, p.win)
+	dlg.Resize(fyne.NewSize(560, 260))
+	dlg.Show()
+}
+
+// confirmCleanup lists the tables the cleanup phase drops, queried from the
+// benchmark database, and starts it once confirmed.
+func (p *TaskMonitorPage) confirmCleanup(ctx context.Context, task *execution.BenchmarkTask) {
+	progress := dialog.NewCustomWithoutButtons(i18n.T("Listing Tables"), widget.NewProgressBarInfinite(), p.win)
+	progress.Show()
+
+	go func() {
+		dbName, tables, err := p.benchmarkUC.CleanupTables(ctx, task)
+		fyne.Do(func() {
+			progress.Hide()
+
+			var text string
+			switch {
+			case err != nil && dbName == "":
+				slog.Warn("Tasks: Failed to list the tables to drop", "error", err)
+				text = i18n.Tf("The tables to drop could not be listed: %v", err)
+			case err != nil:
+				slog.Warn("Tasks: Failed to list the tables to drop", "error", err)
+				text = i18n.Tf("The tables to drop could not be listed: %v\n\nCleanup drops the benchmark tables of the template in database %s.", err, dbName)
+			case len(tables) == 0:
+				text = i18n.Tf("No benchmark tables were found in database %s; cleanup has nothing to drop.", dbName)
+			default:
+				slog.Info("Tasks: Cleanup tables listed", "count", len(tables))
+				text = i18n.Tf("Cleanup drops these %d tables:\n\n%s", len(tables), strings.Join(tables, "\n"))
+			}
+
+			message := widget.NewLabel(text + "\n\n" + i18n.T("Start the cleanup phase?"))
+			message.Wrapping = fyne.TextWrapWord
+			message.Importance = widget.DangerImportance
+			dlg := dialog.NewCustomConfirm(i18n.T("Cleanup"), i18n.T("Drop Tables"), i18n.T("Cancel"),
+				container.NewVScroll(message), func(ok bool) {
+					if ok {
+						p.launchBenchmarkPhase(ctx, task, "cleanup")
+					}
+				}, p.win)
+			dlg.Resize(fyne.NewSize(560, 360))
+			dlg.Show()
+		})
+	}()
+}
+
+// confirmPrepare shows the estimated data volume and load time of the prepare
+// phase and starts it once confirmed.
+func (p *TaskMonitorPage) confirmPrepare(ctx context.Context, task *execution.BenchmarkTask) {
+	progress := dialog.NewCustomWithoutButtons(i18n.T("Estimating Prepare"), widget.NewProgressBarInfinite(), p.win)
+	progress.Show()
+
+	go func() {
+		estimate, err := p.benchmarkUC.EstimatePrepare(ctx, task)
+		fyne.Do(func() {
+			progress.Hide()
+
+			var text string
+			if err != nil {
+				slog.Warn("Tasks: Failed to estimate prepare", "error", err)
+				text = i18n.Tf("The data volume could not be estimated: %v\n", err)
+			} else {
+				slog.Info("Tasks: Prepare estimated", "rows", estimate.Rows, "bytes", estimate.DataBytes,
+					"load_time", estimate.LoadTime, "free_bytes", estimate.FreeBytes)
+				text = estimate.Format()
+			}
+
+			message := widget.NewLabel(text + "\n" + i18n.T("Start the prepare phase?"))
+			message.Wrapping = fyne.TextWrapWord
+			if estimate != nil && estimate.ExceedsFreeSpace() {
+				message.Importance = widget.DangerImportance
+			}
+			dlg := dialog.NewCustomConfirm(i18n.T("Prepare"), i18n.T("Start"), i18n.T("Cancel"), message, func(ok bool) {
+				if ok {
+					p.launchBenchmarkPhase(ctx, task, "prepare")
+				}
+			}, p.win)
+			dlg.Resize(fyne.NewSize(560, 300))
+			dlg.Show()
+		})
+	}()
+}
+
+// showPreCheckDialog shows the pre-checks of a phase as a checklist, with the
+// suggested fix of every failed check.
+func (p *TaskMonitorPage) showPreCheckDialog(phase string, checks []usecase.PreCheckResult) {
+	list := container.NewVBox()
+	for _, check := range checks {
+		if check.Passed() {
+			list.Add(widget.NewLabel("✓ " + check.Name))
+			continue
+		}
+		name := widget.NewLabelWithStyle("✗ "+check.Name, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		name.Importance = widget.DangerImportance
+		detail := widget.NewLabel(i18n.Tf("%v\nFix: %s", check.Err, check.Fix))
+		detail.Wrapping = fyne.TextWrapWord
+		list.Add(name)
+		list.Add(container.NewPadded(detail))
+	}
+
+	status := widget.NewLabel(i18n.Tf("The %s phase was not started. Fix the failed checks and try again.", phase))
+	content := container.NewBorder(status, nil, nil, nil, container.NewVScroll(list))
+	dlg := dialog.NewCustom("Pre-checks", i18n.T("Close"), content, p.win)
+	dlg.Resize(fyne.NewSize(640, 420))
+	dlg.Show()
+}
+
+// launchBenchmarkPhase starts a phase whose task options are configured and
+// whose pre-checks passed.
+func (p *TaskMonitorPage) launchBenchmarkPhase(ctx context.Context, task *execution.BenchmarkTask, phase string) {
+	p.saveTaskDefaults(task)
+
+	if race := p.raceTarget(); phase == "run" && race != nil {
+		p.startRace(task, *race)
+		return
+	}
+	if phase == "run" && task.Options.Repetitions() > 1 {
+		p.startRepeatedRun(task)
+		return
+	}
+
 	// Start benchmark with configured options
 	run, err := p.benchmarkUC.StartBenchmark(ctx, task)
 	if err != nil {
-		dialog.ShowError(fmt.Errorf("failed to start %s phase: %w", phase, err), p.win)
+		dialog.ShowError(fmt.Errorf(i18n.T("failed to start %s phase: %w"), phase, err), p.win)
 		return
 	}
 
 	// Store run ID for later reference
 	p.currentRunID = run.ID
+	p.runEvent = usecase.RunEvent{ConnectionName: p.connSelect.Selected, TemplateName: p.templateSelect.Selected}
+	if conn, ok := p.connections[p.connSelect.Selected]; ok {
+		p.runEvent.DatabaseType = string(conn.GetType())
+	}
 	slog.Info("Tasks: Benchmark phase started", "phase", phase, "run_id", run.ID, "task_id", task.ID)
 
 	// Lock task form during execution
@@ -958,69 +1867,45 @@ func (p *TaskMonitorPage) startBenchmarkPhase(task *execution.BenchmarkTask, pha
 
 	// Start monitoring
 	p.isRunning = true
-	p.statusLabel.SetText(fmt.Sprintf("Status: %s (Running)", strings.Title(phase)))
-	p.statusLabel.TextStyle = fyne.TextStyle{Bold: true}
+	p.monitor.status.Set(i18n.Tf("Status: %s (Running)", phaseTitle(phase)))
 
 	p.btnPrepare.Disable()
 	p.btnRun.Disable()
 	p.btnCleanup.Disable()
 	p.btnStop.Enable()
 
-	// Reset log counter and map for new run
-	p.lastLogCount = 0
-	p.addedSeconds = make(map[string]bool)
+	// Show the thread count of this run and start a fresh log
+	if threads := p.threadsEntry.Text; threads != "" {
+		p.monitor.threads.Set(threads)
+	}
+	p.monitor.log.Reset()
+	p.monitor.eta.Set("")
+	p.monitor.rate.Reset()
+	if profile := task.Options.RateProfile; phase == "run" && profile != nil {
+		duration, _ := task.Parameters["time"].(int)
+		p.monitor.rate.SetPlan(profile.Steps(duration))
+	}
 
 	// Set realtime callback to receive samples directly (streaming, no polling)
-	// This provides zero-delay UI updates compared to database polling
+	// This provides zero-delay UI updates compared to database polling.
+	// Bindings are goroutine-safe, so the callback updates them directly.
 	if phase == "run" {
 		p.benchmarkUC.SetRealtimeCallback(func(runID string, sample execution.MetricSample) {
-			// Update UI in main thread using fyne.Do
-			fyne.Do(func() {
-				if !p.isRunning {
-					return // Don't update if benchmark stopped
-				}
-
-				// Update metrics labels
-				if sample.TPS > 0 {
-					p.tpsLabel.SetText(fmt.Sprintf("%.0f", sample.TPS))
-				}
-				if sample.QPS > 0 {
-					p.qpsLabel.SetText(fmt.Sprintf("%.0f", sample.QPS))
-				}
-				if sample.LatencyP95 > 0 {
-					p.latencyP95Label.SetText(fmt.Sprintf("%.2fms", sample.LatencyP95))
-				}
-				p.errorsLabel.SetText(fmt.Sprintf("%.2f", sample.ErrorRate))
-
-				// Update thread count from form
-				threads := p.threadsEntry.Text
-				if threads != "" {
-					p.threadsLabel.SetText(threads)
-				}
-
-				// Update log with raw output line (with deduplication)
-				if sample.RawLine != "" {
-					// Extract second from raw line to prevent duplicates
-					// Format: "[ 28s ] thds: 1 tps: ..."
-					re := regexp.MustCompile(`\[\s*(\d+)s\s*\]`)
-					matches := re.FindStringSubmatch(sample.RawLine)
-					if len(matches) > 1 {
-						secondKey := matches[1] + "s"
-						if !p.addedSeconds[secondKey] {
-							p.appendLogLine(sample.RawLine)
-							p.addedSeconds[secondKey] = true
-							slog.Info("Tasks: Realtime sample added", "second", secondKey, "run_id", runID)
-						}
-					} else {
-						// No second marker, just add it
-						p.appendLogLine(sample.RawLine)
-					}
-				}
-			})
+			if !p.isRunning {
+				return // Don't update if benchmark stopped
+			}
+			p.monitor.updateSample(sample)
 		})
+		p.benchmarkUC.SetLogCallback(nil)
 	} else {
 		// Clear callback for non-run phases
 		p.benchmarkUC.SetRealtimeCallback(nil)
+		// Prepare and cleanup have no samples; follow the tool output instead
+		p.benchmarkUC.SetLogCallback(func(runID string, entry usecase.LogEntry) {
+			if runID == run.ID {
+				p.monitor.log.Append(entry.Content)
+			}
+		})
 	}
 
 	// Start monitoring goroutine (only for status tracking, not metrics)
@@ -1028,12 +1913,387 @@ func (p *TaskMonitorPage) startBenchmarkPhase(task *execution.BenchmarkTask, pha
 	go p.monitorBenchmarkProgress(ctx, run.ID, phase)
 }
 
+// ReattachRun re-attaches to a run left running when the application exited
+// and monitors it like a run started from this page.
+func (p *TaskMonitorPage) ReattachRun(orphan usecase.OrphanedRun) error {
+	if p.benchmarkUC == nil {
+		return fmt.Errorf("benchmark use case not available")
+	}
+	if p.isRunning {
+		return fmt.Errorf("%s", i18n.T("a benchmark is already running"))
+	}
+
+	ctx := context.Background()
+	run, err := p.benchmarkUC.ReattachRun(ctx, orphan)
+	if err != nil {
+		return err
+	}
+	p.currentRunID = run.ID
+	p.runEvent = orphan.RunEvent(nil)
+	slog.Info("Tasks: Re-attached to orphaned run", "run_id", run.ID, "pid", orphan.PID)
+
+	p.setTaskFormEnabled(false)
+	p.isRunning = true
+	p.monitor.status.Set(i18n.Tf("Status: %s (Re-attached)", phaseTitle("run")))
+
+	p.btnPrepare.Disable()
+	p.btnRun.Disable()
+	p.btnCleanup.Disable()
+	p.btnStop.Enable()
+
+	// The progress follows the duration of the run, whose warmup is over
+	if duration := orphan.Recovery.Parameters["time"]; duration != "" {
+		p.durationEntry.SetText(duration)
+		p.warmupEntry.SetText("0")
+	}
+	p.monitor.threads.Set(strconv.Itoa(orphan.Recovery.Threads))
+	p.monitor.log.Reset()
+	p.monitor.eta.Set("")
+	p.monitor.rate.Reset()
+
+	p.benchmarkUC.SetRealtimeCallback(func(runID string, sample execution.MetricSample) {
+		if !p.isRunning {
+			return
+		}
+		p.monitor.updateSample(sample)
+	})
+	p.benchmarkUC.SetLogCallback(nil)
+
+	go p.monitorBenchmarkProgress(ctx, run.ID, "run")
+	return nil
+}
+
 // startRealBenchmark starts the actual benchmark execution (all phases).
 // Deprecated: Use startBenchmarkPhase for individual phase control.
 func (p *TaskMonitorPage) startRealBenchmark(task *execution.BenchmarkTask) {
 	p.startBenchmarkPhase(task, "run")
 }
 
+// startRepeatedRun runs the run phase task.Options.Repeat times in the background.
+// Each run is saved to history; the aggregate is shown when the series ends.
+func (p *TaskMonitorPage) startRepeatedRun(task *execution.BenchmarkTask) {
+	if p.repetitionUC == nil {
+		dialog.ShowError(errors.New(i18n.T("repetition use case not available - please check application configuration")), p.win)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancelRepeat = cancel
+	total := task.Options.Repetitions()
+	slog.Info("Tasks: Repeated run started", "task_id", task.ID, "repeat", total)
+
+	p.setTaskFormEnabled(false)
+	p.isRunning = true
+	p.monitor.status.Set(i18n.Tf("Status: Run 1/%d (Starting)", total))
+	p.monitor.progress.Set(0)
+
+	p.btnPrepare.Disable()
+	p.btnRun.Disable()
+	p.btnCleanup.Disable()
+	p.btnStop.Enable()
+
+	if threads := p.threadsEntry.Text; threads != "" {
+		p.monitor.threads.Set(threads)
+	}
+	p.monitor.log.Reset()
+	p.benchmarkUC.SetRealtimeCallback(func(runID string, sample execution.MetricSample) {
+		if !p.isRunning {
+			return
+		}
+		p.monitor.updateSample(sample)
+	})
+
+	go func() {
+		agg, err := p.repetitionUC.RunRepeated(ctx, task, func(rep, total int, run *execution.Run) {
+			p.currentRunID = run.ID
+			p.monitor.status.Set(i18n.Tf("Status: Run %d/%d (Running)", rep, total))
+			p.monitor.progress.Set(float64(rep-1) / float64(total))
+		})
+		p.finishRepeatedRun(ctx, agg, err)
+	}()
+}
+
+// finishRepeatedRun resets the page after a repeated run and shows its aggregate.
+func (p *TaskMonitorPage) finishRepeatedRun(ctx context.Context, agg *history.Aggregate, err error) {
+	stopped := ctx.Err() != nil
+	p.isRunning = false
+	p.cancelRepeat = nil
+	p.benchmarkUC.SetRealtimeCallback(nil)
+
+	switch {
+	case stopped:
+		p.monitor.status.Set(i18n.T("Status: Stopped"))
+	case err != nil:
+		p.monitor.status.Set(i18n.T("Status: Error"))
+	default:
+		p.monitor.status.Set(i18n.Tf("Status: Run Completed (%d/%d)", len(agg.RunIDs), agg.Repeat))
+		p.monitor.progress.Set(1.0)
+	}
+	if err != nil {
+		slog.Error("Tasks: Repeated run ended early", "stopped", stopped, "error", err)
+	}
+
+	fyne.DoAndWait(func() {
+		if agg != nil {
+			p.showAggregateDialog(agg, err)
+		} else if err != nil && !stopped {
+			dialog.ShowError(fmt.Errorf(i18n.T("repeated run failed: %w"), err), p.win)
+		}
+
+		p.btnPrepare.Enable()
+		p.btnRun.Enable()
+		p.btnCleanup.Enable()
+		p.btnStop.Disable()
+		p.setTaskFormEnabled(true)
+	})
+}
+
+// showAggregateDialog shows the statistics of a repeated run.
+func (p *TaskMonitorPage) showAggregateDialog(agg *history.Aggregate, err error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, i18n.T("%d of %d runs completed and saved to History.\n"), len(agg.RunIDs), agg.Repeat)
+	if err != nil {
+		fmt.Fprintf(&b, i18n.T("The series ended early: %v\n"), err)
+	}
+	fmt.Fprintf(&b, i18n.T("\nStatistics (outliers outside ±%.1fσ of TPS excluded):\n"), agg.OutlierSigma)
+	for _, m := range []struct {
+		name  string
+		stats history.MetricStats
+	}{
+		{"TPS", agg.TPS},
+		{"QPS", agg.QPS},
+		{i18n.T("Latency avg (ms)"), agg.LatencyAvg},
+		{i18n.T("Latency p95 (ms)"), agg.LatencyP95},
+		{i18n.T("Latency p99 (ms)"), agg.LatencyP99},
+	} {
+		fmt.Fprintf(&b, i18n.T("  %-17s %10.2f ± %-8.2f (CV %.1f%%)\n"), m.name, m.stats.Mean, m.stats.StdDev, m.stats.CV*100)
+	}
+
+	if len(agg.Outliers) == 0 {
+		b.WriteString(i18n.T("\nOutliers: none\n"))
+	} else {
+		b.WriteString(i18n.T("\nOutliers:\n"))
+		for i, id := range agg.RunIDs {
+			if agg.IsOutlier(id) {
+				fmt.Fprintf(&b, i18n.T("  Run %d (%s)\n"), i+1, id)
+			}
+		}
+	}
+	fmt.Fprintf(&b, i18n.T("\nHistory tag: %s"), agg.Tag())
+
+	label := widget.NewLabel(b.String())
+	label.TextStyle = fyne.TextStyle{Monospace: true}
+	d := dialog.NewCustom(i18n.T("Repeated Run Completed"), i18n.T("OK"), label, p.win)
+	d.Resize(fyne.NewSize(560, 420))
+	d.Show()
+}
+
+// raceNone is the Race Against choice that runs the task alone.
+const raceNone = "(none)"
+
+// raceTarget returns the connection selected to race against, or nil if none.
+func (p *TaskMonitorPage) raceTarget() *usecase.RaceTarget {
+	conn, ok := p.connections[p.raceSelect.Selected]
+	if !ok {
+		return nil
+	}
+	return &usecase.RaceTarget{
+		ConnectionID:   conn.GetID(),
+		ConnectionName: conn.GetName(),
+		DatabaseType:   string(conn.GetType()),
+	}
+}
+
+// startRace runs the run phase against the selected connection and the one
+// raced against at the same time, showing their metrics side by side. Both
+// runs are saved to history; the comparison is shown when they end.
+func (p *TaskMonitorPage) startRace(task *execution.BenchmarkTask, b usecase.RaceTarget) {
+	if p.raceUC == nil {
+		dialog.ShowError(errors.New(i18n.T("race use case not available - please check application configuration")), p.win)
+		return
+	}
+
+	a := usecase.RaceTarget{ConnectionID: task.ConnectionID, ConnectionName: p.connSelect.Selected}
+	if conn, ok := p.connections[a.ConnectionName]; ok {
+		a.DatabaseType = string(conn.GetType())
+	}
+	templateName := p.templateSelect.Selected
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancelRace = cancel
+	p.raceRunID = ""
+	slog.Info("Tasks: Race started", "task_id", task.ID, "a", a.ConnectionName, "b", b.ConnectionName)
+
+	p.setTaskFormEnabled(false)
+	p.isRunning = true
+	p.monitor.status.Set(i18n.Tf("Status: Race %s vs %s (Starting)", a.ConnectionName, b.ConnectionName))
+	p.monitor.progress.Set(0)
+
+	p.btnPrepare.Disable()
+	p.btnRun.Disable()
+	p.btnCleanup.Disable()
+	p.btnStop.Enable()
+
+	if threads := p.threadsEntry.Text; threads != "" {
+		p.monitor.threads.Set(threads)
+		p.monitor.raceThreads.Set(threads)
+	}
+	p.monitor.log.Reset()
+	p.monitor.eta.Set("")
+	p.monitor.rate.Reset()
+	p.raceHeaderA.SetText("A: " + a.ConnectionName)
+	p.raceHeaderB.SetText("B: " + b.ConnectionName)
+	p.showRaceMetrics(true)
+
+	// The log and chart follow A; B only updates its metrics column
+	p.benchmarkUC.SetRealtimeCallback(func(runID string, sample execution.MetricSample) {
+		if !p.isRunning {
+			return
+		}
+		if runID == p.raceRunID {
+			p.monitor.updateRaceSample(sample)
+			return
+		}
+		p.monitor.updateSample(sample)
+	})
+	p.benchmarkUC.SetLogCallback(nil)
+
+	done := make(chan struct{})
+	duration, _ := task.Parameters["time"].(int)
+	go p.trackRaceProgress(done, time.Duration(task.Options.WarmupTime+duration)*time.Second)
+
+	go func() {
+		race, err := p.raceUC.RunRace(ctx, task, templateName, a, b, func(side string, run *execution.Run) {
+			if side == usecase.RaceSideB {
+				p.raceRunID = run.ID
+				p.monitor.status.Set(i18n.Tf("Status: Race %s vs %s (Running)", a.ConnectionName, b.ConnectionName))
+				return
+			}
+			p.currentRunID = run.ID
+		})
+		close(done)
+		p.finishRace(ctx, race, err)
+	}()
+}
+
+// trackRaceProgress advances the progress bar over the planned duration of a
+// race until done is closed.
+func (p *TaskMonitorPage) trackRaceProgress(done <-chan struct{}, planned time.Duration) {
+	start := time.Now()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if planned > 0 {
+				p.monitor.progress.Set(min(time.Since(start).Seconds()/planned.Seconds(), 0.95))
+			}
+		}
+	}
+}
+
+// finishRace resets the page after a race and shows the comparison of its runs.
+func (p *TaskMonitorPage) finishRace(ctx context.Context, race *history.Race, err error) {
+	stopped := ctx.Err() != nil
+	p.isRunning = false
+	p.cancelRace = nil
+	p.raceRunID = ""
+	p.benchmarkUC.SetRealtimeCallback(nil)
+
+	switch {
+	case stopped:
+		p.monitor.status.Set(i18n.T("Status: Stopped"))
+	case err != nil:
+		p.monitor.status.Set(i18n.T("Status: Error"))
+	default:
+		p.monitor.status.Set(i18n.T("Status: Race Completed"))
+		p.monitor.progress.Set(1.0)
+	}
+	if err != nil {
+		slog.Error("Tasks: Race ended early", "stopped", stopped, "error", err)
+	}
+
+	fyne.DoAndWait(func() {
+		p.showRaceMetrics(false)
+		if race != nil {
+			p.showRaceDialog(race, err)
+		} else if err != nil && !stopped {
+			dialog.ShowError(fmt.Errorf(i18n.T("race failed: %w"), err), p.win)
+		}
+
+		p.btnPrepare.Enable()
+		p.btnRun.Enable()
+		p.btnCleanup.Enable()
+		p.btnStop.Disable()
+		p.setTaskFormEnabled(true)
+	})
+}
+
+// showRaceMetrics switches the monitor between the metrics of a single run
+// and the two columns of a race.
+func (p *TaskMonitorPage) showRaceMetrics(show bool) {
+	if show {
+		p.metricsGrid.Hide()
+		p.raceGrid.Show()
+		return
+	}
+	p.raceGrid.Hide()
+	p.metricsGrid.Show()
+}
+
+// showRaceDialog shows the metrics of both runs of a race and which was faster.
+func (p *TaskMonitorPage) showRaceDialog(race *history.Race, err error) {
+	var b strings.Builder
+	if err != nil {
+		fmt.Fprintf(&b, i18n.T("The race ended early: %v\n\n"), err)
+	}
+	fmt.Fprintf(&b, "A: %s\nB: %s\n\n", race.A.ConnectionName, race.B.ConnectionName)
+	fmt.Fprintf(&b, "%-17s %12s %12s %10s\n", "", "A", "B", i18n.T("B vs A"))
+	for _, m := range []struct {
+		name string
+		a, b float64
+	}{
+		{"TPS", race.A.TPS, race.B.TPS},
+		{"QPS", race.A.QPS, race.B.QPS},
+		{i18n.T("Latency avg (ms)"), race.A.LatencyAvg, race.B.LatencyAvg},
+		{i18n.T("Latency p95 (ms)"), race.A.LatencyP95, race.B.LatencyP95},
+		{i18n.T("Error rate (%)"), race.A.ErrorRate, race.B.ErrorRate},
+	} {
+		delta := "--"
+		if m.a != 0 {
+			delta = fmt.Sprintf("%+.1f%%", (m.b-m.a)/m.a*100)
+		}
+		fmt.Fprintf(&b, "%-17s %12.2f %12.2f %10s\n", m.name, m.a, m.b, delta)
+	}
+
+	b.WriteString("\n")
+	for _, side := range []struct {
+		name string
+		side history.RaceSide
+	}{{"A", race.A}, {"B", race.B}} {
+		if !side.side.Completed() {
+			fmt.Fprintf(&b, i18n.T("%s did not complete (%s): %s\n"), side.name, side.side.State, side.side.ErrorMessage)
+		}
+	}
+	switch winner := race.Winner(); {
+	case winner == usecase.RaceSideA:
+		fmt.Fprintf(&b, i18n.T("Winner: A (%s), %.1f%% more TPS\n"), race.A.ConnectionName, (race.A.TPS-race.B.TPS)/race.B.TPS*100)
+	case winner == usecase.RaceSideB:
+		fmt.Fprintf(&b, i18n.T("Winner: B (%s), %.1f%% more TPS\n"), race.B.ConnectionName, race.TPSDelta())
+	case race.Completed():
+		b.WriteString(i18n.T("Tie: TPS within 1%\n"))
+	}
+	fmt.Fprintf(&b, i18n.T("\nBoth runs were saved to History with tag: %s"), race.Tag())
+
+	label := widget.NewLabel(b.String())
+	label.TextStyle = fyne.TextStyle{Monospace: true}
+	d := dialog.NewCustom(i18n.T("Race Completed"), i18n.T("OK"), label, p.win)
+	d.Resize(fyne.NewSize(640, 420))
+	d.Show()
+}
+
 // onStopTask stops the running task.
 func (p *TaskMonitorPage) onStopTask() {
 	if !p.isRunning {
@@ -1042,8 +2302,17 @@ func (p *TaskMonitorPage) onStopTask() {
 
 	slog.Info("Tasks: Stop button clicked, stopping task")
 
-	// Stop the actual benchmark if running
-	if p.currentRunID != "" && p.benchmarkUC != nil {
+	if p.cancelDemo != nil {
+		p.cancelDemo() // A demo run stops itself
+		p.cancelDemo = nil
+	}
+
+	// Stop the actual benchmark if running; a repeated task or race stops its runs itself
+	if p.cancelRace != nil {
+		p.cancelRace()
+	} else if p.cancelRepeat != nil {
+		p.cancelRepeat()
+	} else if p.currentRunID != "" && p.benchmarkUC != nil {
 		ctx := context.Background()
 		err := p.benchmarkUC.StopBenchmark(ctx, p.currentRunID, false)
 		if err != nil {
@@ -1055,8 +2324,7 @@ func (p *TaskMonitorPage) onStopTask() {
 
 	// Reset UI state immediately
 	p.isRunning = false
-	p.statusLabel.SetText("Status: Stopped")
-	p.statusLabel.TextStyle = fyne.TextStyle{Bold: true}
+	p.monitor.status.Set(i18n.T("Status: Stopped"))
 
 	// Reset all metrics and progress
 	p.resetTaskMetrics()
@@ -1083,6 +2351,18 @@ func (p *TaskMonitorPage) monitorBenchmarkProgress(ctx context.Context, runID st
 	// For prepare and cleanup, only set progress once to avoid Fyne warnings
 	progressSet := false
 
+	// The run phase progress covers warmup and the measured window
+	duration := 60.0 // Default
+	if dur, err := strconv.Atoi(p.durationEntry.Text); err == nil {
+		duration = float64(dur)
+	}
+	warmup := 0.0
+	if w, err := strconv.Atoi(strings.TrimSpace(p.warmupEntry.Text)); err == nil && w > 0 {
+		warmup = float64(w)
+	}
+	var warmupStart time.Time
+	measuring := false
+
 	for p.isRunning {
 		select {
 		case <-ticker.C:
@@ -1090,7 +2370,7 @@ func (p *TaskMonitorPage) monitorBenchmarkProgress(ctx context.Context, runID st
 			run, err := p.benchmarkUC.GetBenchmarkStatus(ctx, runID)
 			if err != nil {
 				slog.Error("Tasks: Failed to get benchmark status", "error", err)
-				p.handleBenchmarkError(ctx, runID, fmt.Errorf("failed to get status: %w", err), phase)
+				p.handleBenchmarkError(ctx, runID, fmt.Errorf(i18n.T("failed to get status: %w"), err), phase)
 				return
 			}
 
@@ -1108,26 +2388,38 @@ func (p *TaskMonitorPage) monitorBenchmarkProgress(ctx context.Context, runID st
 				return
 			}
 
-			// Update progress bar based on time (only for run phase)
+			// Update progress based on time (only for run phase)
 			// Note: Metrics are updated via realtime callback, not here
-			fyne.Do(func() {
-				if phase == "run" && run.StartedAt != nil {
-					elapsed := time.Since(*run.StartedAt).Seconds()
-					duration := 60.0 // Default
-					if dur, err := strconv.Atoi(p.durationEntry.Text); err == nil {
-						duration = float64(dur)
-					}
-					progress := elapsed / duration
-					if progress > 0.95 {
-						progress = 0.95
-					}
-					p.progressBar.SetValue(progress)
-				} else if phase != "run" && !progressSet {
-					// For prepare and cleanup, only set progress once
-					p.progressBar.SetValue(0.5) // Halfway to show activity
-					progressSet = true
+			if phase == "run" && run.State == execution.StateWarmingUp {
+				if warmupStart.IsZero() {
+					warmupStart = time.Now()
+					p.monitor.status.Set(i18n.T("Status: Run (Warming up)"))
 				}
-			})
+				progress := time.Since(warmupStart).Seconds() / (warmup + duration)
+				if warmup > 0 && progress > warmup/(warmup+duration) {
+					progress = warmup / (warmup + duration)
+				}
+				p.monitor.progress.Set(progress)
+			} else if phase == "run" && run.StartedAt != nil {
+				if warmup > 0 && !measuring {
+					measuring = true
+					p.monitor.status.Set(i18n.T("Status: Run (Measuring)"))
+				}
+				elapsed := time.Since(*run.StartedAt).Seconds()
+				progress := (warmup + elapsed) / (warmup + duration)
+				if progress > 0.95 {
+					progress = 0.95
+				}
+				p.monitor.progress.Set(progress)
+			} else if status, ok := p.benchmarkUC.GetPrepareStatus(runID); phase == "prepare" && ok {
+				// Sysbench reports each table it creates and loads
+				p.monitor.progress.Set(status.Fraction)
+				p.monitor.eta.Set(formatPrepareStatus(status))
+			} else if phase != "run" && !progressSet {
+				// For prepare and cleanup, only set progress once
+				p.monitor.progress.Set(0.5) // Halfway to show activity
+				progressSet = true
+			}
 
 		case <-ctx.Done():
 			return
@@ -1135,14 +2427,37 @@ func (p *TaskMonitorPage) monitorBenchmarkProgress(ctx context.Context, runID st
 	}
 }
 
+// formatPrepareStatus formats the loaded tables and estimated time left of a prepare.
+func formatPrepareStatus(status *usecase.PrepareStatus) string {
+	text := i18n.Tf("%d/%d tables loaded", status.Loaded, status.Tables)
+	if status.Remaining > 0 {
+		return text + i18n.Tf(", ETA %s", status.Remaining)
+	}
+	return text + i18n.T(", ETA estimating...")
+}
+
+// phaseTitle returns the translated name of a benchmark phase.
+func phaseTitle(phase string) string {
+	switch phase {
+	case "prepare":
+		return i18n.T("Prepare")
+	case "run":
+		return i18n.T("Run")
+	case "cleanup":
+		return i18n.T("Cleanup")
+	}
+	return phase
+}
+
 // handleBenchmarkCompleted handles benchmark phase completion.
 func (p *TaskMonitorPage) handleBenchmarkCompleted(ctx context.Context, run *execution.Run, phase string) {
 	// Update UI state safely on main thread
 	p.isRunning = false
 
-	// Clear realtime callback to free resources
+	// Clear realtime and log callbacks to free resources
 	if p.benchmarkUC != nil {
 		p.benchmarkUC.SetRealtimeCallback(nil)
+		p.benchmarkUC.SetLogCallback(nil)
 	}
 
 	slog.Info("Tasks: handleBenchmarkCompleted called",
@@ -1151,18 +2466,31 @@ func (p *TaskMonitorPage) handleBenchmarkCompleted(ctx context.Context, run *exe
 		"has_result", run.Result != nil,
 		"state", run.State)
 
-	duration := "unknown"
+	duration := i18n.T("unknown")
 	if run.Duration != nil {
-		duration = fmt.Sprintf("%.1f seconds", run.Duration.Seconds())
+		duration = i18n.Tf("%.1f seconds", run.Duration.Seconds())
 	} else if run.StartedAt != nil && run.CompletedAt != nil {
-		duration = fmt.Sprintf("%.1f seconds", run.CompletedAt.Sub(*run.StartedAt).Seconds())
+		duration = i18n.Tf("%.1f seconds", run.CompletedAt.Sub(*run.StartedAt).Seconds())
+	}
+
+	p.monitor.status.Set(i18n.Tf("Status: %s Completed", phaseTitle(phase)))
+	p.monitor.progress.Set(1.0) // Show completion
+	p.monitor.eta.Set("")
+
+	// Save the run before the dialog unless the user saves runs manually
+	saved := false
+	var saveErr error
+	if phase == "run" && run.Result != nil && p.historyUC != nil && p.autoSaveRuns() {
+		if saveErr = p.saveRunToHistory(ctx, run); saveErr != nil {
+			slog.Error("Tasks: Failed to save to history", "run_id", run.ID, "error", saveErr)
+		} else {
+			saved = true
+			slog.Info("Tasks: Saved to history", "run_id", run.ID)
+		}
 	}
 
 	// Update UI elements on main thread
 	fyne.DoAndWait(func() {
-		p.statusLabel.SetText(fmt.Sprintf("Status: %s Completed", strings.Title(phase)))
-		p.progressBar.SetValue(1.0) // Show completion
-
 		// Build completion message with detailed statistics
 		var message string
 		if run.Message != "" {
@@ -1180,7 +2508,7 @@ func (p *TaskMonitorPage) handleBenchmarkCompleted(ctx context.Context, run *exe
 					latencySumMs = result.Duration.Seconds() * 1000
 				}
 
-				message = fmt.Sprintf("Benchmark completed successfully!\n\n"+
+				message = i18n.Tf("Benchmark completed successfully!\n\n"+
 					"Duration: %s\n\n"+
 					"Transactions: %20d  (%.2f per sec.)\n"+
 					"Queries:      %20d  (%.2f per sec.)\n\n"+
@@ -1202,20 +2530,23 @@ func (p *TaskMonitorPage) handleBenchmarkCompleted(ctx context.Context, run *exe
 					latencySumMs)
 			} else {
 				// No result available, show simple message
-				message = fmt.Sprintf("Benchmark completed successfully!\n\nDuration: %s\n\n(Note: Final statistics not available)", duration)
+				message = i18n.Tf("Benchmark completed successfully!\n\nDuration: %s\n\n(Note: Final statistics not available)", duration)
 				slog.Warn("Tasks: Run completed but Result is nil")
 			}
 		} else {
-			message = fmt.Sprintf("%s phase completed successfully!\n\nDuration: %s",
-				strings.Title(phase), duration)
+			message = i18n.Tf("%s phase completed successfully!\n\nDuration: %s",
+				phaseTitle(phase), duration)
 		}
 
-		// Show Save/OK dialog for successful run completion
+		// Show the completion dialog, with a Save button if the run was not saved
 		if phase == "run" && run.Result != nil && p.historyUC != nil {
-			p.showCompletionDialog(ctx, run, message)
+			p.showCompletionDialog(ctx, run, message, saved)
+			if saveErr != nil {
+				dialog.ShowError(fmt.Errorf(i18n.T("Failed to save to history: %v"), saveErr), p.win)
+			}
 		} else {
 			// For prepare/cleanup phases or no history use case, show simple dialog
-			dialog.ShowInformation(strings.Title(phase)+" Completed", message, p.win)
+			dialog.ShowInformation(i18n.Tf("%s Completed", phaseTitle(phase)), message, p.win)
 		}
 
 		// Re-enable all phase buttons, disable stop
@@ -1231,20 +2562,39 @@ func (p *TaskMonitorPage) handleBenchmarkCompleted(ctx context.Context, run *exe
 	// Don't reset metrics - keep final TPS/QPS displayed
 }
 
-// showCompletionDialog shows a completion dialog with Save and OK buttons.
-func (p *TaskMonitorPage) showCompletionDialog(ctx context.Context, run *execution.Run, message string) {
+// showCompletionDialog shows a completion dialog with Save and OK buttons,
+// or only OK if the run was already saved to history.
+func (p *TaskMonitorPage) showCompletionDialog(ctx context.Context, run *execution.Run, message string, saved bool) {
+	// Details of the run before it is saved
+	btnDetails := widget.NewButton(i18n.T("🔍 Run Details"), func() {
+		if record := p.historyUC.PreviewRecord(run); record != nil {
+			showRunDetails(p.win, p.historyUC, p.exportUC, record)
+		}
+	})
+
+	if saved {
+		savedLabel := widget.NewLabel(i18n.T("✅ Saved to History"))
+		d := dialog.NewCustom(i18n.T("Benchmark Completed"), i18n.T("OK"),
+			container.NewBorder(nil, container.NewHBox(btnDetails, savedLabel), nil, nil, widget.NewLabel(message)),
+			p.win,
+		)
+		d.Resize(fyne.NewSize(500, 400))
+		d.Show()
+		return
+	}
+
 	// Create custom dialog with Save and OK buttons
-	d := dialog.NewCustomConfirm("Benchmark Completed", "Save", "OK",
-		widget.NewLabel(message),
+	d := dialog.NewCustomConfirm(i18n.T("Benchmark Completed"), i18n.T("Save"), i18n.T("OK"),
+		container.NewBorder(nil, container.NewHBox(btnDetails), nil, nil, widget.NewLabel(message)),
 		func(save bool) {
 			if save && p.historyUC != nil {
 				// Save to history
-				if err := p.historyUC.SaveRunToHistory(ctx, run); err != nil {
+				if err := p.saveRunToHistory(ctx, run); err != nil {
 					slog.Error("Tasks: Failed to save to history", "run_id", run.ID, "error", err)
-					dialog.ShowError(fmt.Errorf("Failed to save to history: %v", err), p.win)
+					dialog.ShowError(fmt.Errorf(i18n.T("Failed to save to history: %v"), err), p.win)
 				} else {
 					slog.Info("Tasks: Saved to history", "run_id", run.ID)
-					dialog.ShowInformation("Saved", "✅ Run saved to History!\n\nGo to History tab to view details.", p.win)
+					dialog.ShowInformation(i18n.T("Saved"), i18n.T("✅ Run saved to History!\n\nGo to History tab to view details."), p.win)
 				}
 			}
 			// OK button does nothing - just dismisses the dialog
@@ -1255,23 +2605,63 @@ func (p *TaskMonitorPage) showCompletionDialog(ctx context.Context, run *executi
 	d.Show()
 }
 
+// saveRunToHistory saves a completed run to history; demo runs are tagged
+// as such.
+func (p *TaskMonitorPage) saveRunToHistory(ctx context.Context, run *execution.Run) error {
+	if p.demoUC != nil && usecase.IsDemoRun(run) {
+		return p.demoUC.SaveRun(ctx, run)
+	}
+	return p.historyUC.SaveRunToHistory(ctx, run)
+}
+
+// autoSaveRuns reports whether finished runs are saved to history without
+// asking; true unless turned off in the settings.
+func (p *TaskMonitorPage) autoSaveRuns() bool {
+	if p.settingsUC == nil {
+		return true
+	}
+	cfg, err := p.settingsUC.GetHistoryConfig(context.Background())
+	if err != nil {
+		slog.Warn("Tasks: Failed to load history config", "error", err)
+		return true
+	}
+	return cfg.AutoSave()
+}
+
 // handleBenchmarkStopped handles benchmark stop/cancellation.
 func (p *TaskMonitorPage) handleBenchmarkStopped(ctx context.Context, run *execution.Run, phase string) {
 	p.isRunning = false
 
-	// Clear realtime callback
+	// Clear realtime and log callbacks
 	if p.benchmarkUC != nil {
 		p.benchmarkUC.SetRealtimeCallback(nil)
+		p.benchmarkUC.SetLogCallback(nil)
+	}
+
+	p.monitor.status.Set(i18n.Tf("Status: %s", run.State))
+	p.monitor.eta.Set("")
+
+	// Keep failed and cancelled runs in history for later investigation
+	var saveErr error
+	if phase == "run" && p.historyUC != nil && p.autoSaveRuns() {
+		event := p.runEvent
+		event.Run = run
+		if saveErr = p.historyUC.SaveStoppedRun(ctx, event); saveErr != nil {
+			slog.Error("Tasks: Failed to save stopped run to history", "run_id", run.ID, "state", run.State, "error", saveErr)
+		} else {
+			slog.Info("Tasks: Saved stopped run to history", "run_id", run.ID, "state", run.State)
+		}
 	}
 
 	// Update UI on main thread
 	fyne.DoAndWait(func() {
-		p.statusLabel.SetText(fmt.Sprintf("Status: %s", run.State))
-
 		// Check if there's a user-friendly message to display
 		if run.Message != "" {
 			dialog.ShowError(fmt.Errorf("%s", run.Message), p.win)
 		}
+		if saveErr != nil {
+			dialog.ShowError(fmt.Errorf(i18n.T("Failed to save to history: %v"), saveErr), p.win)
+		}
 
 		// Re-enable all phase buttons, disable stop
 		p.btnPrepare.Enable()
@@ -1286,12 +2676,14 @@ func (p *TaskMonitorPage) handleBenchmarkStopped(ctx context.Context, run *execu
 func (p *TaskMonitorPage) handleBenchmarkError(ctx context.Context, runID string, err error, phase string) {
 	p.isRunning = false
 
-	// Clear realtime callback
+	// Clear realtime and log callbacks
 	if p.benchmarkUC != nil {
 		p.benchmarkUC.SetRealtimeCallback(nil)
+		p.benchmarkUC.SetLogCallback(nil)
 	}
 
-	p.statusLabel.SetText("Status: Error")
+	p.monitor.status.Set(i18n.T("Status: Error"))
+	p.monitor.eta.Set("")
 
 	// Re-enable all phase buttons, disable stop
 	fyne.Do(func() {
@@ -1303,7 +2695,7 @@ func (p *TaskMonitorPage) handleBenchmarkError(ctx context.Context, runID string
 	})
 
 	// Show error dialog
-	dialog.ShowError(fmt.Errorf("%s phase failed: %v", strings.Title(phase), err), p.win)
+	dialog.ShowError(fmt.Errorf(i18n.T("%s phase failed: %v"), phaseTitle(phase), err), p.win)
 	slog.Error("Tasks: Benchmark phase failed", "phase", phase, "error", err)
 }
 
@@ -1313,49 +2705,7 @@ func (p *TaskMonitorPage) setTaskFormEnabled(enabled bool) {
 	// We'll rely on button states and user feedback
 }
 
-// appendLog appends a log message.
 // resetTaskMetrics resets all task metrics to initial state.
 func (p *TaskMonitorPage) resetTaskMetrics() {
-	p.progressBar.SetValue(0)
-	p.tpsLabel.SetText("--")
-	p.qpsLabel.SetText("--")
-	p.latencyP95Label.SetText("--")
-	p.errorsLabel.SetText("0.00")
-	p.threadsLabel.SetText("--")
-	// Clear log
-	p.logEntry.SetText("Waiting for benchmark data...\n")
-	// Reset log counter
-	p.lastLogCount = 0
-	// Reset added seconds map
-	p.addedSeconds = make(map[string]bool)
-}
-
-// appendLogLine appends a new line to the log output.
-// Keeps only the last maxLogLines lines.
-func (p *TaskMonitorPage) appendLogLine(line string) {
-	currentText := p.logEntry.Text
-
-	// If this is the first data line, clear the "Waiting..." message
-	if strings.Contains(currentText, "Waiting for benchmark data") {
-		currentText = ""
-	}
-
-	// Append new line
-	newText := currentText
-	if newText != "" && !strings.HasSuffix(newText, "\n") {
-		newText += "\n"
-	}
-	newText += line
-
-	// Split into lines and keep only last maxLogLines
-	lines := strings.Split(newText, "\n")
-	if len(lines) > p.maxLogLines {
-		lines = lines[len(lines)-p.maxLogLines:]
-	}
-
-	// Join back and set
-	p.logEntry.SetText(strings.Join(lines, "\n"))
-
-	// Scroll to bottom
-	p.logEntry.CursorRow = len(lines)
+	p.monitor.reset()
 }
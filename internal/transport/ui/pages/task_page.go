@@ -3,7 +3,7 @@
 package pages
 
 import (
-	"fmt"
+	"errors"
 	"strconv"
 	"strings"
 
@@ -11,6 +11,8 @@ import (
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
 )
 
 // TaskConfigurationPage provides the simplified task configuration GUI.
@@ -55,30 +57,30 @@ func NewTaskConfigurationPage(win fyne.Window) fyne.CanvasObject {
 	// Create form
 	form := &widget.Form{
 		Items: []*widget.FormItem{
-			widget.NewFormItem("Connection", page.connSelect),
-			widget.NewFormItem("Tool", page.toolSelect),
-			widget.NewFormItem("Template", page.templateSelect),
-			widget.NewFormItem("Duration (seconds)", page.durationEntry),
-			widget.NewFormItem("Rate Limit (0=unlimited)", page.rateLimitEntry),
+			widget.NewFormItem(i18n.T("Connection"), page.connSelect),
+			widget.NewFormItem(i18n.T("Tool"), page.toolSelect),
+			widget.NewFormItem(i18n.T("Template"), page.templateSelect),
+			widget.NewFormItem(i18n.T("Duration (seconds)"), page.durationEntry),
+			widget.NewFormItem(i18n.T("Rate Limit (0=unlimited)"), page.rateLimitEntry),
 		},
 	}
 
 	// Create buttons
-	btnRun := widget.NewButton("Run Task", func() {
+	btnRun := widget.NewButton(i18n.T("Run Task"), func() {
 		page.onRunTask()
 	})
 
-	btnRefresh := widget.NewButton("Refresh Connections", func() {
+	btnRefresh := widget.NewButton(i18n.T("Refresh Connections"), func() {
 		page.loadConnections()
 	})
 
 	toolbar := container.NewHBox(btnRun, btnRefresh)
 
 	// Help text
-	helpLabel := widget.NewLabel("Configure and run a benchmark task.\nSelect a connection, tool, and template, then set duration.")
+	helpLabel := widget.NewLabel(i18n.T("Configure and run a benchmark task.\nSelect a connection, tool, and template, then set duration."))
 
 	content := container.NewVBox(
-		widget.NewCard("Task Configuration", "", container.NewPadded(form)),
+		widget.NewCard(i18n.T("Task Configuration"), "", container.NewPadded(form)),
 		widget.NewSeparator(),
 		helpLabel,
 		widget.NewSeparator(),
@@ -135,37 +137,37 @@ func (p *TaskConfigurationPage) updateTemplates(tool string) {
 func (p *TaskConfigurationPage) onRunTask() {
 	// Validate
 	if p.connSelect.Selected == "" {
-		dialog.ShowError(fmt.Errorf("please select a connection"), p.win)
+		dialog.ShowError(errors.New(i18n.T("please select a connection")), p.win)
 		return
 	}
 
 	if p.toolSelect.Selected == "" {
-		dialog.ShowError(fmt.Errorf("please select a tool"), p.win)
+		dialog.ShowError(errors.New(i18n.T("please select a tool")), p.win)
 		return
 	}
 
 	if p.templateSelect.Selected == "" {
-		dialog.ShowError(fmt.Errorf("please select a template"), p.win)
+		dialog.ShowError(errors.New(i18n.T("please select a template")), p.win)
 		return
 	}
 
 	duration, err := strconv.Atoi(strings.TrimSpace(p.durationEntry.Text))
 	if err != nil || duration <= 0 {
-		dialog.ShowError(fmt.Errorf("invalid duration value"), p.win)
+		dialog.ShowError(errors.New(i18n.T("invalid duration value")), p.win)
 		return
 	}
 
 	// Show task summary
 	var sb strings.Builder
-	sb.WriteString("Task Configuration Summary\n\n")
-	sb.WriteString(fmt.Sprintf("Connection: %s\n", p.connSelect.Selected))
-	sb.WriteString(fmt.Sprintf("Tool: %s\n", p.toolSelect.Selected))
-	sb.WriteString(fmt.Sprintf("Template: %s\n", p.templateSelect.Selected))
-	sb.WriteString(fmt.Sprintf("Duration: %d seconds\n", duration))
-	sb.WriteString(fmt.Sprintf("Rate Limit: %s\n", p.rateLimitEntry.Text))
+	sb.WriteString(i18n.T("Task Configuration Summary\n\n"))
+	sb.WriteString(i18n.Tf("Connection: %s\n", p.connSelect.Selected))
+	sb.WriteString(i18n.Tf("Tool: %s\n", p.toolSelect.Selected))
+	sb.WriteString(i18n.Tf("Template: %s\n", p.templateSelect.Selected))
+	sb.WriteString(i18n.Tf("Duration: %d seconds\n", duration))
+	sb.WriteString(i18n.Tf("Rate Limit: %s\n", p.rateLimitEntry.Text))
 
-	sb.WriteString("\nTask is ready to run!\n")
-	sb.WriteString("(Full task execution will be implemented soon)")
+	sb.WriteString(i18n.T("\nTask is ready to run!\n"))
+	sb.WriteString(i18n.T("(Full task execution will be implemented soon)"))
 
-	dialog.ShowInformation("Task Ready", sb.String(), p.win)
+	dialog.ShowInformation(i18n.T("Task Ready"), sb.String(), p.win)
 }
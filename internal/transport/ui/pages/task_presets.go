@@ -0,0 +1,231 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// Task Configuration presets of the Tasks & Monitor page.
+package pages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/execution"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// loadPresets fills the preset selector with the saved Task Configurations.
+func (p *TaskMonitorPage) loadPresets() {
+	if p.settingsUC == nil {
+		return
+	}
+	presets, err := p.settingsUC.GetTaskPresets(context.Background())
+	if err != nil {
+		slog.Warn("Tasks: Failed to load task presets", "error", err)
+		return
+	}
+
+	names := make([]string, len(presets))
+	for i := range presets {
+		names[i] = presets[i].Name
+	}
+	p.presetSelect.Options = names
+	if !slices.Contains(names, p.presetSelect.Selected) {
+		p.presetSelect.ClearSelected()
+	}
+	p.presetSelect.Refresh()
+}
+
+// onPresetSelected fills the task form from the selected preset.
+func (p *TaskMonitorPage) onPresetSelected(name string) {
+	if name == "" || p.settingsUC == nil {
+		return
+	}
+	preset, err := p.settingsUC.GetTaskPreset(context.Background(), name)
+	if err != nil {
+		slog.Error("Tasks: Failed to load task preset", "preset", name, "error", err)
+		dialog.ShowError(fmt.Errorf(i18n.T("load preset: %w"), err), p.win)
+		return
+	}
+	if err := p.applyPreset(preset); err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+	slog.Info("Tasks: Task preset applied", "preset", name)
+}
+
+// applyPreset fills the task form from preset.
+func (p *TaskMonitorPage) applyPreset(preset *config.TaskPreset) error {
+	connName := ""
+	for name, conn := range p.connections {
+		if conn.GetID() == preset.ConnectionID {
+			connName = name
+			break
+		}
+	}
+	if connName == "" {
+		return fmt.Errorf(i18n.T("the connection of preset %s no longer exists"), preset.Name)
+	}
+	// Selecting the connection loads its templates and task defaults,
+	// which the preset then overrides
+	if p.connSelect.Selected == connName {
+		p.onConnectionChanged()
+	} else {
+		p.connSelect.SetSelected(connName)
+	}
+
+	for _, tmpl := range p.templates {
+		if tmpl.ID == preset.TemplateID {
+			p.templateSelect.SetSelected(tmpl.Name)
+			break
+		}
+	}
+
+	p.setTaskDefaults(config.TaskDefaults{Threads: preset.Threads, Duration: preset.Duration, DBName: preset.DBName})
+	p.warmupEntry.SetText(strconv.Itoa(preset.Warmup))
+
+	p.rateProfileSelect.SetSelected(i18n.T(rateProfileFixed))
+	p.rateStartEntry.SetText("")
+	p.rateChangeEntry.SetText("")
+	p.rateStepEntry.SetText("")
+	if profile := preset.RateProfile; profile != nil {
+		change := profile.StepRate
+		if profile.Kind == execution.RateProfileRamp {
+			p.rateProfileSelect.SetSelected(i18n.T(rateProfileRamp))
+			change = profile.TargetRate
+		} else {
+			p.rateProfileSelect.SetSelected(i18n.T(rateProfileStaircase))
+		}
+		p.rateStartEntry.SetText(strconv.Itoa(profile.StartRate))
+		p.rateChangeEntry.SetText(strconv.Itoa(change))
+		p.rateStepEntry.SetText(strconv.Itoa(profile.StepTime))
+	}
+
+	p.sampleIntervalEntry.SetText("")
+	if preset.SampleInterval > 0 {
+		p.sampleIntervalEntry.SetText(strconv.Itoa(preset.SampleInterval))
+	}
+	p.repeatEntry.SetText(strconv.Itoa(max(preset.Repeat, 1)))
+	p.outlierEntry.SetText("")
+	if preset.OutlierSigma > 0 {
+		p.outlierEntry.SetText(strconv.FormatFloat(preset.OutlierSigma, 'f', -1, 64))
+	}
+
+	// Options the connection or the configured agents no longer support are left off
+	p.remoteCheck.SetChecked(preset.Remote && !p.remoteCheck.Disabled())
+	switch agents := preset.Agents; {
+	case len(agents) == 0:
+		p.agentSelect.SetSelected(i18n.T(localLoadGenerator))
+	case len(agents) == 1 && slices.Contains(p.agentNames, agents[0]):
+		p.agentSelect.SetSelected(agents[0])
+	case len(agents) > 1 && slices.Contains(p.agentSelect.Options, i18n.T(allAgentsLoadGenerator)):
+		p.agentSelect.SetSelected(i18n.T(allAgentsLoadGenerator))
+	default:
+		p.agentSelect.SetSelected(i18n.T(localLoadGenerator))
+	}
+	p.keepArtifactsCheck.SetChecked(preset.KeepArtifacts)
+	p.monitorReplicasCheck.SetChecked(preset.MonitorReplicas && !p.monitorReplicasCheck.Disabled())
+	p.serverLogCheck.SetChecked(preset.CollectServerLog && !p.serverLogCheck.Disabled())
+
+	for entry, limit := range map[*widget.Entry]*float64{
+		p.minTPSEntry:       preset.Assertions.MinTPS,
+		p.maxLatencyEntry:   preset.Assertions.MaxLatencyP95,
+		p.maxErrorRateEntry: preset.Assertions.MaxErrorRate,
+	} {
+		entry.SetText("")
+		if limit != nil {
+			entry.SetText(strconv.FormatFloat(*limit, 'f', -1, 64))
+		}
+	}
+	return nil
+}
+
+// onSavePreset saves the task form as a named preset, replacing the preset
+// of the same name.
+func (p *TaskMonitorPage) onSavePreset() {
+	if p.settingsUC == nil {
+		dialog.ShowError(errors.New(i18n.T("settings use case not available - please check application configuration")), p.win)
+		return
+	}
+	task, err := p.buildBenchmarkTask()
+	if err != nil {
+		dialog.ShowError(err, p.win)
+		return
+	}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(p.presetSelect.Selected)
+	items := []*widget.FormItem{
+		widget.NewFormItem(i18n.T("Preset Name"), nameEntry),
+	}
+	dialog.ShowForm(i18n.T("Save Preset"), i18n.T("Save"), i18n.T("Cancel"), items, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		name := strings.TrimSpace(nameEntry.Text)
+		if name == "" {
+			dialog.ShowError(errors.New(i18n.T("name required")), p.win)
+			return
+		}
+		if err := p.settingsUC.SaveTaskPreset(context.Background(), presetFromTask(name, task)); err != nil {
+			slog.Error("Tasks: Failed to save task preset", "preset", name, "error", err)
+			dialog.ShowError(fmt.Errorf(i18n.T("save preset: %w"), err), p.win)
+			return
+		}
+		slog.Info("Tasks: Task preset saved", "preset", name)
+		p.loadPresets()
+		p.presetSelect.SetSelected(name)
+	}, p.win)
+}
+
+// onDeletePreset deletes the selected preset after confirmation.
+func (p *TaskMonitorPage) onDeletePreset() {
+	name := p.presetSelect.Selected
+	if name == "" || p.settingsUC == nil {
+		return
+	}
+	dialog.ShowConfirm(i18n.T("Delete Preset"), i18n.Tf("Delete preset %s?", name), func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		if err := p.settingsUC.DeleteTaskPreset(context.Background(), name); err != nil {
+			slog.Error("Tasks: Failed to delete task preset", "preset", name, "error", err)
+			dialog.ShowError(fmt.Errorf(i18n.T("delete preset: %w"), err), p.win)
+			return
+		}
+		slog.Info("Tasks: Task preset deleted", "preset", name)
+		p.loadPresets()
+	}, p.win)
+}
+
+// presetFromTask returns the preset named name of a task built from the task form.
+func presetFromTask(name string, task *execution.BenchmarkTask) config.TaskPreset {
+	threads, _ := task.Parameters["threads"].(int)
+	duration, _ := task.Parameters["time"].(int)
+	dbName, _ := task.Parameters["db_name"].(string)
+	return config.TaskPreset{
+		Name:             name,
+		ConnectionID:     task.ConnectionID,
+		TemplateID:       task.TemplateID,
+		Threads:          threads,
+		Duration:         duration,
+		Warmup:           task.Options.WarmupTime,
+		DBName:           dbName,
+		RateProfile:      task.Options.RateProfile,
+		SampleInterval:   int(task.Options.SampleInterval / time.Second),
+		Repeat:           task.Options.Repeat,
+		OutlierSigma:     task.Options.OutlierSigma,
+		Remote:           task.Options.RemoteWinRM,
+		Agents:           task.Options.LoadGenerators(),
+		KeepArtifacts:    task.Options.KeepArtifacts,
+		MonitorReplicas:  task.Options.MonitorReplicas,
+		CollectServerLog: task.Options.CollectServerLog,
+		Assertions:       task.Assertions,
+	}
+}
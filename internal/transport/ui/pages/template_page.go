@@ -3,6 +3,7 @@
 package pages
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -13,6 +14,8 @@ import (
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
 )
 
 // Global storage for custom templates (persists across page recreations)
@@ -35,6 +38,11 @@ type TemplateManagementPage struct {
 	defaultIndex    int                        // Index of default template
 	listContainer   *fyne.Container            // Use VBox for dynamic list (like Connections)
 	groupContainers map[string]*fyne.Container // DB type -> container
+	scroll          *container.Scroll
+	// Template selected by the quick search, highlighted until the page is recreated
+	selectedID    string
+	selectedRow   fyne.CanvasObject
+	selectedGroup *fyne.Container
 }
 
 // templateInfo represents display info for a template.
@@ -58,6 +66,13 @@ type OLTPParameters struct {
 
 // NewTemplateManagementPage creates a new template management page.
 func NewTemplateManagementPage(win fyne.Window) fyne.CanvasObject {
+	_, content := newTemplateManagementPage(win)
+	return content
+}
+
+// newTemplateManagementPage creates a new template management page and
+// returns it with its canvas object.
+func newTemplateManagementPage(win fyne.Window) (*TemplateManagementPage, fyne.CanvasObject) {
 	slog.Info("Templates: NewTemplateManagementPage called - creating new page instance")
 
 	page := &TemplateManagementPage{
@@ -67,12 +82,13 @@ func NewTemplateManagementPage(win fyne.Window) fyne.CanvasObject {
 		groupContainers: make(map[string]*fyne.Container),
 		listContainer:   container.NewVBox(),
 	}
+	page.scroll = container.NewScroll(page.listContainer)
 
 	// Load templates to populate the list
 	page.loadTemplates()
 
 	// Create toolbar with only Add button
-	btnAdd := widget.NewButton("➕ Add Template", func() {
+	btnAdd := widget.NewButton(i18n.T("➕ Add Template"), func() {
 		slog.Info("Templates: Add Template button clicked")
 		page.onAddTemplate()
 	})
@@ -89,14 +105,14 @@ func NewTemplateManagementPage(win fyne.Window) fyne.CanvasObject {
 
 	// Use Border layout
 	content := container.NewBorder(
-		topArea,                                 // top - toolbar
-		nil,                                     // bottom
-		nil,                                     // left
-		nil,                                     // right
-		container.NewScroll(page.listContainer), // center - fills available space
+		topArea,     // top - toolbar
+		nil,         // bottom
+		nil,         // left
+		nil,         // right
+		page.scroll, // center - fills available space
 	)
 
-	return content
+	return page, content
 }
 
 // loadTemplatesData loads and returns template information.
@@ -120,6 +136,11 @@ func (p *TemplateManagementPage) loadTemplatesData() []templateInfo {
 		TableSize: 10000000,
 	}
 
+	// TPC-C templates: one table set; sysbench-tpcc has no table size
+	tpccParams := &OLTPParameters{
+		Tables: 1,
+	}
+
 	// Create builtin templates (initially all IsDefault=false, will be set below)
 	builtinTemplates := []templateInfo{
 		// MySQL templates
@@ -153,6 +174,16 @@ func (p *TemplateManagementPage) loadTemplatesData() []templateInfo {
 			IsDefault:   false,
 			Parameters:  diskBoundParams,
 		},
+		{
+			ID:          "sysbench-tpcc",
+			Name:        "TPC-C (sysbench-tpcc)",
+			Description: "TPC-C style workload for MySQL through the Percona sysbench-tpcc scripts (10 warehouses), reporting tpmC",
+			Tool:        "sysbench",
+			DBType:      "MySQL",
+			IsBuiltin:   true,
+			IsDefault:   false,
+			Parameters:  tpccParams,
+		},
 		// PostgreSQL templates
 		{
 			ID:          "sysbench-postgresql-test",
@@ -184,6 +215,16 @@ func (p *TemplateManagementPage) loadTemplatesData() []templateInfo {
 			IsDefault:   false,
 			Parameters:  diskBoundParams,
 		},
+		{
+			ID:          "sysbench-tpcc",
+			Name:        "TPC-C (sysbench-tpcc)",
+			Description: "TPC-C style workload for PostgreSQL through the Percona sysbench-tpcc scripts (10 warehouses), reporting tpmC",
+			Tool:        "sysbench",
+			DBType:      "PostgreSQL",
+			IsBuiltin:   true,
+			IsDefault:   false,
+			Parameters:  tpccParams,
+		},
 		// Oracle templates
 		{
 			ID:          "swingbench-oracle-test",
@@ -267,6 +308,7 @@ func (p *TemplateManagementPage) loadTemplates() {
 	// Clear list container
 	p.listContainer.Objects = nil
 	p.groupContainers = make(map[string]*fyne.Container)
+	p.selectedRow, p.selectedGroup = nil, nil
 
 	// Define order of database types
 	dbOrder := []string{"MySQL", "PostgreSQL", "Oracle", "SQL Server"}
@@ -283,6 +325,9 @@ func (p *TemplateManagementPage) loadTemplates() {
 	}
 
 	p.listContainer.Refresh()
+	if p.selectedRow != nil {
+		scrollToRow(p.scroll, p.selectedGroup, p.selectedRow)
+	}
 	slog.Info("Templates: List refreshed", "total_templates", len(p.templates))
 }
 
@@ -333,29 +378,35 @@ func (p *TemplateManagementPage) createTemplateGroup(dbType string, templates []
 		// Built-in templates: Details, Set Default
 		if tmpl.IsBuiltin {
 			// Details button (first for built-in templates)
-			btnDetails := widget.NewButton("📋 Details", func() {
+			btnDetails := widget.NewButton(i18n.T("📋 Details"), func() {
 				slog.Info("Templates: Details button clicked", "template", tmpl.Name)
 				p.showTemplateDetails(tmpl)
 			})
 			buttons = append(buttons, btnDetails)
 
 			// Set Default button (second for built-in templates)
-			btnSetDefault := widget.NewButton("⭐ Set Default", func() {
+			btnSetDefault := widget.NewButton(i18n.T("⭐ Set Default"), func() {
 				slog.Info("Templates: Set Default button clicked", "template", tmpl.Name, "db_type", tmpl.DBType)
 				p.onSetDefault(tmpl, dbType)
 			})
 			buttons = append(buttons, btnSetDefault)
+
+			btnSaveAs := widget.NewButton(i18n.T("📑 Save As"), func() {
+				slog.Info("Templates: Save As button clicked", "template", tmpl.Name)
+				p.onSaveAsTemplate(tmpl)
+			})
+			buttons = append(buttons, btnSaveAs)
 		} else {
 			// Custom templates: Edit, Delete, Set Default
 			// Edit button (first for custom templates)
-			btnEdit := widget.NewButton("✏️ Edit", func() {
+			btnEdit := widget.NewButton(i18n.T("✏️ Edit"), func() {
 				slog.Info("Templates: Edit button clicked", "template", tmpl.Name)
 				p.onEditTemplate(tmpl)
 			})
 			buttons = append(buttons, btnEdit)
 
 			// Delete button (second for custom templates) - RED WARNING to stand out
-			btnDelete := widget.NewButton("⚠️ 🗑️ Delete", func() {
+			btnDelete := widget.NewButton(i18n.T("⚠️ 🗑️ Delete"), func() {
 				slog.Info("Templates: Delete button clicked", "template", tmpl.Name)
 				p.onDeleteTemplate(tmpl)
 			})
@@ -364,17 +415,27 @@ func (p *TemplateManagementPage) createTemplateGroup(dbType string, templates []
 			buttons = append(buttons, btnDelete)
 
 			// Set Default button (third for custom templates)
-			btnSetDefault := widget.NewButton("⭐ Set Default", func() {
+			btnSetDefault := widget.NewButton(i18n.T("⭐ Set Default"), func() {
 				slog.Info("Templates: Set Default button clicked", "template", tmpl.Name, "db_type", tmpl.DBType)
 				p.onSetDefault(tmpl, dbType)
 			})
 			buttons = append(buttons, btnSetDefault)
+
+			btnSaveAs := widget.NewButton(i18n.T("📑 Save As"), func() {
+				slog.Info("Templates: Save As button clicked", "template", tmpl.Name)
+				p.onSaveAsTemplate(tmpl)
+			})
+			buttons = append(buttons, btnSaveAs)
 		}
 
 		// Use Border layout to align info left, buttons right
 		buttonBox := container.NewHBox(buttons...)
 		templateRow := container.NewBorder(nil, nil, infoLabel, buttonBox)
 		groupContainer.Add(templateRow)
+		if tmpl.ID == p.selectedID {
+			highlightRow(infoLabel)
+			p.selectedRow, p.selectedGroup = templateRow, groupContainer
+		}
 	}
 
 	// Add header and group to main list
@@ -385,7 +446,7 @@ func (p *TemplateManagementPage) createTemplateGroup(dbType string, templates []
 // onAddTemplate adds a new custom template.
 func (p *TemplateManagementPage) onAddTemplate() {
 	slog.Info("Templates: Add Template button clicked")
-	showTemplateDialog(p.win, "Add Template", nil, "", func(params *OLTPParameters, name string, dbType string) {
+	showTemplateDialog(p.win, i18n.T("Add Template"), nil, "", func(params *OLTPParameters, name string, dbType string) {
 		slog.Info("Templates: Creating new template", "name", name, "db_type", dbType)
 
 		// Create new template
@@ -410,7 +471,36 @@ func (p *TemplateManagementPage) onAddTemplate() {
 		p.loadTemplates()
 
 		slog.Info("Templates: Template added successfully", "name", name, "total_templates", len(p.templates))
-		dialog.ShowInformation("Success", "Template added successfully", p.win)
+		dialog.ShowInformation(i18n.T("Success"), i18n.T("Template added successfully"), p.win)
+	})
+}
+
+// onSaveAsTemplate saves a copy of a template as a new custom template.
+func (p *TemplateManagementPage) onSaveAsTemplate(tmpl templateInfo) {
+	slog.Info("Templates: Saving template as new", "source", tmpl.Name, "db_type", tmpl.DBType)
+
+	showTemplateDialogWithDBType(p.win, i18n.T("Save Template As"), tmpl.Parameters, i18n.Tf("%s (copy)", tmpl.Name), tmpl.DBType, func(params *OLTPParameters, name string, dbType string) {
+		newTemplate := templateInfo{
+			ID:          fmt.Sprintf("custom-%d", time.Now().UnixNano()),
+			Name:        name,
+			Description: fmt.Sprintf("Derived from %s", tmpl.Name),
+			Tool:        tmpl.Tool,
+			DBType:      dbType,
+			IsBuiltin:   false,
+			IsDefault:   false,
+			Parameters:  params,
+		}
+
+		// Save to global storage
+		customTemplatesMutex.Lock()
+		customTemplates = append(customTemplates, newTemplate)
+		slog.Info("Templates: Saved to global storage", "name", name, "source", tmpl.Name, "total_custom", len(customTemplates))
+		customTemplatesMutex.Unlock()
+
+		// Reload
+		p.loadTemplates()
+
+		dialog.ShowInformation(i18n.T("Success"), i18n.Tf("Template saved as '%s'", name), p.win)
 	})
 }
 
@@ -420,7 +510,7 @@ func (p *TemplateManagementPage) onEditTemplate(tmpl templateInfo) {
 	if tmpl.IsBuiltin {
 		slog.Warn("Templates: Attempted to edit built-in template", "name", tmpl.Name)
 		dialog.ShowError(
-			fmt.Errorf("cannot edit built-in template '%s'", tmpl.Name),
+			fmt.Errorf(i18n.T("cannot edit built-in template '%s'"), tmpl.Name),
 			p.win,
 		)
 		return
@@ -429,7 +519,7 @@ func (p *TemplateManagementPage) onEditTemplate(tmpl templateInfo) {
 	slog.Info("Templates: Editing template", "name", tmpl.Name, "db_type", tmpl.DBType)
 
 	// Show dialog with existing parameters and DB type
-	showTemplateDialogWithDBType(p.win, "Edit Template", tmpl.Parameters, tmpl.Name, tmpl.DBType, func(params *OLTPParameters, newName string, newDBType string) {
+	showTemplateDialogWithDBType(p.win, i18n.T("Edit Template"), tmpl.Parameters, tmpl.Name, tmpl.DBType, func(params *OLTPParameters, newName string, newDBType string) {
 		slog.Info("Templates: Updating template", "old_name", tmpl.Name, "new_name", newName, "old_db_type", tmpl.DBType, "new_db_type", newDBType)
 
 		// Update in global storage
@@ -449,7 +539,7 @@ func (p *TemplateManagementPage) onEditTemplate(tmpl templateInfo) {
 		p.loadTemplates()
 
 		slog.Info("Templates: Template updated successfully", "name", newName)
-		dialog.ShowInformation("Success", "Template updated successfully", p.win)
+		dialog.ShowInformation(i18n.T("Success"), i18n.T("Template updated successfully"), p.win)
 	})
 }
 
@@ -459,15 +549,15 @@ func (p *TemplateManagementPage) onDeleteTemplate(tmpl templateInfo) {
 	if tmpl.IsBuiltin {
 		slog.Warn("Templates: Attempted to delete built-in template", "name", tmpl.Name)
 		dialog.ShowError(
-			fmt.Errorf("cannot delete built-in template '%s'", tmpl.Name),
+			fmt.Errorf(i18n.T("cannot delete built-in template '%s'"), tmpl.Name),
 			p.win,
 		)
 		return
 	}
 
 	dialog.ShowConfirm(
-		"Delete Template",
-		fmt.Sprintf("Delete custom template '%s'?", tmpl.Name),
+		i18n.T("Delete Template"),
+		i18n.Tf("Delete custom template '%s'?", tmpl.Name),
 		func(confirmed bool) {
 			if !confirmed {
 				return
@@ -488,7 +578,7 @@ func (p *TemplateManagementPage) onDeleteTemplate(tmpl templateInfo) {
 			// Reload
 			p.loadTemplates()
 
-			dialog.ShowInformation("Deleted", "Template deleted", p.win)
+			dialog.ShowInformation(i18n.T("Deleted"), i18n.T("Template deleted"), p.win)
 		},
 		p.win,
 	)
@@ -523,10 +613,10 @@ func (p *TemplateManagementPage) onSetDefault(tmpl templateInfo, dbType string)
 	p.loadTemplates()
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Default template for %s changed to: %s\n\n", dbType, tmpl.Name))
-	sb.WriteString("This template will be auto-selected in Tasks page.")
+	sb.WriteString(i18n.Tf("Default template for %s changed to: %s\n\n", dbType, tmpl.Name))
+	sb.WriteString(i18n.T("This template will be auto-selected in Tasks page."))
 
-	dialog.ShowInformation("Default Set", sb.String(), p.win)
+	dialog.ShowInformation(i18n.T("Default Set"), sb.String(), p.win)
 }
 
 // showTemplateDetails shows template details with all parameters.
@@ -536,61 +626,63 @@ func (p *TemplateManagementPage) showTemplateDetails(tmpl templateInfo) {
 	sb.WriteString("## ")
 	sb.WriteString(tmpl.Name)
 	if tmpl.IsDefault {
-		sb.WriteString(" ⭐ (Default)")
+		sb.WriteString(i18n.T(" ⭐ (Default)"))
 	}
 	sb.WriteString("\n\n")
 
 	if tmpl.Description != "" {
-		sb.WriteString("**Description:** ")
+		sb.WriteString(i18n.T("**Description:** "))
 		sb.WriteString(tmpl.Description)
 		sb.WriteString("\n\n")
 	}
 
-	sb.WriteString("**Tool:** `")
+	sb.WriteString(i18n.T("**Tool:** `"))
 	sb.WriteString(tmpl.Tool)
 	sb.WriteString("`\n\n")
-	sb.WriteString("**Database Type:** `")
+	sb.WriteString(i18n.T("**Database Type:** `"))
 	sb.WriteString(tmpl.DBType)
 	sb.WriteString("`\n\n")
 
-	sb.WriteString("**Type:** 📦 Built-in Template\n")
-	sb.WriteString("**Actions:** Can be set as default\n\n")
+	sb.WriteString(i18n.T("**Type:** 📦 Built-in Template\n"))
+	sb.WriteString(i18n.T("**Actions:** Can be set as default\n\n"))
 
 	// Show parameters
 	if tmpl.Parameters != nil {
 		sb.WriteString("---\n\n")
-		sb.WriteString("### Parameters\n\n")
+		sb.WriteString(i18n.T("### Parameters\n\n"))
 
-		sb.WriteString("**General Parameters:**\n\n")
-		sb.WriteString(fmt.Sprintf("- `--tables=%d` - Number of tables\n", tmpl.Parameters.Tables))
-		sb.WriteString(fmt.Sprintf("- `--table-size=%d` - Rows per table\n", tmpl.Parameters.TableSize))
-
-		sb.WriteString("\n**OLTP Test Parameters** (for reference, currently not used in execution):\n\n")
-		sb.WriteString("The following OLTP parameters can be configured in the Add/Edit dialog,\n")
-		sb.WriteString("but are currently not passed to sysbench. The benchmark uses sysbench defaults.\n\n")
-		sb.WriteString("- `--db-ps-mode` - Prepared statement mode (disable/auto/no_ps)\n")
-		sb.WriteString("- `--oltp-test-mode` - Test mode (complex/simple/nontrx/specific)\n")
-		sb.WriteString("- `--oltp-point-selects` - Point select ratio\n")
-		sb.WriteString("- `--oltp-simple-ranges` - Simple range ratio\n")
-		sb.WriteString("- `--oltp-sum-ranges` - Sum range ratio\n")
-		sb.WriteString("- `--oltp-order-ranges` - Order range ratio\n")
-		sb.WriteString("- `--oltp-distinct-ranges` - Distinct range ratio\n")
-		sb.WriteString("- `--oltp-index-updates` - Index update ratio\n")
-		sb.WriteString("- `--oltp-non-index-updates` - Non-index update ratio\n")
-		sb.WriteString("- `--oltp-delete-inserts` - Delete-insert ratio\n")
+		sb.WriteString(i18n.T("**General Parameters:**\n\n"))
+		sb.WriteString(i18n.Tf("- `--tables=%d` - Number of tables\n", tmpl.Parameters.Tables))
+		if tmpl.Parameters.TableSize > 0 {
+			sb.WriteString(i18n.Tf("- `--table-size=%d` - Rows per table\n", tmpl.Parameters.TableSize))
+		}
 
-		sb.WriteString("\n**Note:** Additional parameters (threads, time, rate) are configured in the Tasks page when running the benchmark.\n")
+		sb.WriteString(i18n.T("\n**OLTP Test Parameters** (for reference, currently not used in execution):\n\n"))
+		sb.WriteString(i18n.T("The following OLTP parameters can be configured in the Add/Edit dialog,\n"))
+		sb.WriteString(i18n.T("but are currently not passed to sysbench. The benchmark uses sysbench defaults.\n\n"))
+		sb.WriteString(i18n.T("- `--db-ps-mode` - Prepared statement mode (disable/auto/no_ps)\n"))
+		sb.WriteString(i18n.T("- `--oltp-test-mode` - Test mode (complex/simple/nontrx/specific)\n"))
+		sb.WriteString(i18n.T("- `--oltp-point-selects` - Point select ratio\n"))
+		sb.WriteString(i18n.T("- `--oltp-simple-ranges` - Simple range ratio\n"))
+		sb.WriteString(i18n.T("- `--oltp-sum-ranges` - Sum range ratio\n"))
+		sb.WriteString(i18n.T("- `--oltp-order-ranges` - Order range ratio\n"))
+		sb.WriteString(i18n.T("- `--oltp-distinct-ranges` - Distinct range ratio\n"))
+		sb.WriteString(i18n.T("- `--oltp-index-updates` - Index update ratio\n"))
+		sb.WriteString(i18n.T("- `--oltp-non-index-updates` - Non-index update ratio\n"))
+		sb.WriteString(i18n.T("- `--oltp-delete-inserts` - Delete-insert ratio\n"))
+
+		sb.WriteString(i18n.T("\n**Note:** Additional parameters (threads, time, rate) are configured in the Tasks page when running the benchmark.\n"))
 	}
 
 	// Show transaction weights for Oracle Swingbench templates
 	if tmpl.Tool == "swingbench" && tmpl.DBType == "Oracle" {
 		sb.WriteString("---\n\n")
-		sb.WriteString("### Transaction Mix (Proportions)\n\n")
+		sb.WriteString(i18n.T("### Transaction Mix (Proportions)\n\n"))
 
 		// Load the actual template to get transaction weights
 		weights := p.getTransactionWeights(tmpl.ID)
 		if weights != nil {
-			sb.WriteString("**Transaction Distribution:**\n\n")
+			sb.WriteString(i18n.T("**Transaction Distribution:**\n\n"))
 			for name, weight := range weights {
 				sb.WriteString(fmt.Sprintf("- %s：**%d**\n", name, weight))
 			}
@@ -600,8 +692,8 @@ func (p *TemplateManagementPage) showTemplateDetails(tmpl templateInfo) {
 	content := widget.NewRichTextFromMarkdown(sb.String())
 
 	dlg := dialog.NewCustomConfirm(
-		"Template Details",
-		"Close",
+		i18n.T("Template Details"),
+		i18n.T("Close"),
 		"",
 		content,
 		func(bool) {},
@@ -742,7 +834,7 @@ func showTemplateDialogWithDBType(win fyne.Window, title string, existingParams
 
 	// Create common form fields
 	d.nameEntry = widget.NewEntry()
-	d.nameEntry.SetPlaceHolder("My Custom Template")
+	d.nameEntry.SetPlaceHolder(i18n.T("My Custom Template"))
 	if existingName != "" {
 		d.nameEntry.SetText(existingName)
 	}
@@ -802,13 +894,13 @@ func showTemplateDialogWithDBType(win fyne.Window, title string, existingParams
 	d.usernameEntry.SetText(defaultUsername)
 
 	d.passwordEntry = widget.NewEntry()
-	d.passwordEntry.SetPlaceHolder("Schema password")
+	d.passwordEntry.SetPlaceHolder(i18n.T("Schema password"))
 
 	d.dbaUsernameEntry = widget.NewEntry()
 	d.dbaUsernameEntry.SetText(defaultDBAUsername)
 
 	d.dbaPasswordEntry = widget.NewEntry()
-	d.dbaPasswordEntry.SetPlaceHolder("DBA password")
+	d.dbaPasswordEntry.SetPlaceHolder(i18n.T("DBA password"))
 
 	d.configFileEntry = widget.NewEntry()
 	d.configFileEntry.SetText(defaultConfigFile)
@@ -826,23 +918,23 @@ func showTemplateDialogWithDBType(win fyne.Window, title string, existingParams
 
 		if dbType == "Oracle" {
 			// Show message: Oracle custom templates not supported yet
-			msgLabel := widget.NewLabel("Oracle templates use Swingbench with different parameters.\n\nCurrently, only built-in Oracle templates are supported.\n\nPlease use the built-in Oracle templates:\n- Test (Swingbench)\n- CPU Bound (Swingbench)\n- Disk Bound (Swingbench)")
+			msgLabel := widget.NewLabel(i18n.T("Oracle templates use Swingbench with different parameters.\n\nCurrently, only built-in Oracle templates are supported.\n\nPlease use the built-in Oracle templates:\n- Test (Swingbench)\n- CPU Bound (Swingbench)\n- Disk Bound (Swingbench)"))
 			d.formContainer.Add(container.NewVBox(msgLabel))
 		} else {
 			// Show Sysbench parameters
 			formItems := []*widget.FormItem{
-				widget.NewFormItem("Tables (N)", d.tablesEntry),
-				widget.NewFormItem("Table Size (N)", d.tableSizeEntry),
-				widget.NewFormItem("DB PS Mode", d.dbPSModeEntry),
-				widget.NewFormItem("OLTP Test Mode", d.oltpTestModeEntry),
-				widget.NewFormItem("Point Selects", d.oltpPointSelects),
-				widget.NewFormItem("Simple Ranges", d.oltpSimpleRanges),
-				widget.NewFormItem("Sum Ranges", d.oltpSumRanges),
-				widget.NewFormItem("Order Ranges", d.oltpOrderRanges),
-				widget.NewFormItem("Distinct Ranges", d.oltpDistinctRanges),
-				widget.NewFormItem("Index Updates", d.oltpIndexUpdates),
-				widget.NewFormItem("Non-Index Updates", d.oltpNonIndexUpdates),
-				widget.NewFormItem("Delete Inserts", d.oltpDeleteInserts),
+				widget.NewFormItem(i18n.T("Tables (N)"), d.tablesEntry),
+				widget.NewFormItem(i18n.T("Table Size (N)"), d.tableSizeEntry),
+				widget.NewFormItem(i18n.T("DB PS Mode"), d.dbPSModeEntry),
+				widget.NewFormItem(i18n.T("OLTP Test Mode"), d.oltpTestModeEntry),
+				widget.NewFormItem(i18n.T("Point Selects"), d.oltpPointSelects),
+				widget.NewFormItem(i18n.T("Simple Ranges"), d.oltpSimpleRanges),
+				widget.NewFormItem(i18n.T("Sum Ranges"), d.oltpSumRanges),
+				widget.NewFormItem(i18n.T("Order Ranges"), d.oltpOrderRanges),
+				widget.NewFormItem(i18n.T("Distinct Ranges"), d.oltpDistinctRanges),
+				widget.NewFormItem(i18n.T("Index Updates"), d.oltpIndexUpdates),
+				widget.NewFormItem(i18n.T("Non-Index Updates"), d.oltpNonIndexUpdates),
+				widget.NewFormItem(i18n.T("Delete Inserts"), d.oltpDeleteInserts),
 			}
 			form := widget.NewForm(formItems...)
 			d.formContainer.Add(form)
@@ -860,7 +952,7 @@ func showTemplateDialogWithDBType(win fyne.Window, title string, existingParams
 	updateFormFields(initialDBType)
 
 	// Create buttons
-	btnSave := widget.NewButton("Save", func() {
+	btnSave := widget.NewButton(i18n.T("Save"), func() {
 		if d.onSave() {
 			d.dialog.Hide()
 		}
@@ -868,7 +960,7 @@ func showTemplateDialogWithDBType(win fyne.Window, title string, existingParams
 	})
 	btnSave.Importance = widget.HighImportance
 
-	btnCancel := widget.NewButton("Cancel", func() {
+	btnCancel := widget.NewButton(i18n.T("Cancel"), func() {
 		// Will be set to close dialog after dialog is created
 	})
 
@@ -876,8 +968,8 @@ func showTemplateDialogWithDBType(win fyne.Window, title string, existingParams
 
 	// Create static form items (Database Type and Template Name)
 	staticForm := widget.NewForm(
-		widget.NewFormItem("Database Type", d.dbTypeSelect),
-		widget.NewFormItem("Template Name", d.nameEntry),
+		widget.NewFormItem(i18n.T("Database Type"), d.dbTypeSelect),
+		widget.NewFormItem(i18n.T("Template Name"), d.nameEntry),
 	)
 
 	// Create dialog content with buttons at bottom
@@ -906,7 +998,7 @@ func (d *templateDialog) onSave() bool {
 	// Check if Oracle is selected (not supported for custom templates yet)
 	if dbType == "Oracle" {
 		slog.Warn("Templates: Cannot create custom Oracle templates")
-		dialog.ShowError(fmt.Errorf("custom Oracle templates are not supported yet\n\nPlease use the built-in Oracle templates"), d.win)
+		dialog.ShowError(errors.New(i18n.T("custom Oracle templates are not supported yet\n\nPlease use the built-in Oracle templates")), d.win)
 		return false
 	}
 
@@ -914,7 +1006,7 @@ func (d *templateDialog) onSave() bool {
 	name := strings.TrimSpace(d.nameEntry.Text)
 	if name == "" {
 		slog.Warn("Templates: Template name is empty")
-		dialog.ShowError(fmt.Errorf("template name is required"), d.win)
+		dialog.ShowError(errors.New(i18n.T("template name is required")), d.win)
 		return false
 	}
 
@@ -929,7 +1021,7 @@ func (d *templateDialog) onSave() bool {
 		if tmpl.Name == name {
 			customTemplatesMutex.RUnlock()
 			slog.Warn("Templates: Template name already exists", "name", name)
-			dialog.ShowError(fmt.Errorf("template name '%s' already exists", name), d.win)
+			dialog.ShowError(fmt.Errorf(i18n.T("template name '%s' already exists"), name), d.win)
 			return false
 		}
 	}
@@ -938,7 +1030,7 @@ func (d *templateDialog) onSave() bool {
 	// Also check built-in templates
 	if name == "OLTP Read-Write (Sysbench)" {
 		slog.Warn("Templates: Template name conflicts with built-in template", "name", name)
-		dialog.ShowError(fmt.Errorf("template name '%s' conflicts with built-in template", name), d.win)
+		dialog.ShowError(fmt.Errorf(i18n.T("template name '%s' conflicts with built-in template"), name), d.win)
 		return false
 	}
 
@@ -0,0 +1,188 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// TPS and p95 latency over time charts of the Trends page.
+package pages
+
+import (
+	"fmt"
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// trendChart plots the TPS or p95 latency of the runs of a trend over
+// calendar time, with the rolling mean and the change points.
+type trendChart struct {
+	widget.BaseWidget
+	latency bool // Plot p95 latency instead of TPS
+	trend   *history.Trend
+}
+
+// newTrendChart creates an empty chart of TPS, or of p95 latency if latency is set.
+func newTrendChart(latency bool) *trendChart {
+	c := &trendChart{latency: latency}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// SetTrend replaces the plotted trend; nil clears the chart.
+func (c *trendChart) SetTrend(trend *history.Trend) {
+	c.trend = trend
+	c.Refresh()
+}
+
+// title returns the translated chart title.
+func (c *trendChart) title() string {
+	if c.latency {
+		return i18n.T("P95 latency (ms) over time")
+	}
+	return i18n.T("TPS over time")
+}
+
+// values returns the plotted value and its rolling mean of p.
+func (c *trendChart) values(p history.TrendPoint) (float64, float64) {
+	if c.latency {
+		return p.LatencyP95, p.LatencyP95Mean
+	}
+	return p.TPS, p.TPSMean
+}
+
+// CreateRenderer implements fyne.Widget.
+func (c *trendChart) CreateRenderer() fyne.WidgetRenderer {
+	return &trendChartRenderer{
+		chart:      c,
+		background: canvas.NewRectangle(theme.Color(theme.ColorNameInputBackground)),
+	}
+}
+
+// MinSize implements fyne.Widget.
+func (c *trendChart) MinSize() fyne.Size {
+	return fyne.NewSize(320, 240)
+}
+
+// trendChartRenderer draws a trendChart from lines, circles and text.
+type trendChartRenderer struct {
+	chartDrawing
+	chart      *trendChart
+	background *canvas.Rectangle
+	size       fyne.Size
+}
+
+func (r *trendChartRenderer) Layout(size fyne.Size) {
+	r.size = size
+	r.redraw()
+}
+
+func (r *trendChartRenderer) MinSize() fyne.Size {
+	return r.chart.MinSize()
+}
+
+func (r *trendChartRenderer) Refresh() {
+	r.redraw()
+	canvas.Refresh(r.chart)
+}
+
+func (r *trendChartRenderer) Objects() []fyne.CanvasObject {
+	return append([]fyne.CanvasObject{r.background}, r.objects...)
+}
+
+func (r *trendChartRenderer) Destroy() {}
+
+// redraw rebuilds the chart for the current trend and size.
+func (r *trendChartRenderer) redraw() {
+	size := r.size
+	r.background.Resize(size)
+	r.objects = nil
+
+	pad := theme.Padding()
+	r.addText(r.chart.title(), pad, pad, fyne.TextAlignLeading, true)
+	trend := r.chart.trend
+	if trend == nil || len(trend.Points) == 0 {
+		r.addText(i18n.T("Select a trend to see the charts"), size.Width/2, size.Height/2, fyne.TextAlignCenter, false)
+		return
+	}
+
+	// Plot area below the title and legend, left of the value labels
+	textHeight := theme.CaptionTextSize() + 2*pad
+	left, right := float32(56), pad*4
+	top := textHeight*2 + pad
+	bottom := textHeight + pad
+	plotW, plotH := size.Width-left-right, size.Height-top-bottom
+	if plotW <= 0 || plotH <= 0 {
+		return
+	}
+	maxValue := 0.0
+	for _, p := range trend.Points {
+		v, mean := r.chart.values(p)
+		maxValue = max(maxValue, v, mean)
+	}
+	if maxValue <= 0 {
+		maxValue = 1
+	}
+	first, last := trend.Points[0].StartTime, trend.Latest().StartTime
+	span := last.Sub(first)
+	x := func(p history.TrendPoint) float32 {
+		if span <= 0 {
+			return left + plotW/2
+		}
+		return left + plotW*float32(p.StartTime.Sub(first))/float32(span)
+	}
+	y := func(v float64) float32 { return top + plotH*(1-float32(max(v, 0)/maxValue)) }
+
+	// Axes, value ticks and the dates of the first and last runs
+	axis := theme.Color(theme.ColorNameDisabled)
+	r.addLine(axis, 1, left, top, left, top+plotH)
+	r.addLine(axis, 1, left, top+plotH, left+plotW, top+plotH)
+	for i := 0; i <= 2; i++ {
+		v := maxValue * float64(i) / 2
+		r.addText(fmt.Sprintf("%.0f", v), left-pad, y(v)-textHeight/2, fyne.TextAlignTrailing, false)
+	}
+	r.addText(first.Format("2006-01-02"), left, top+plotH+pad/2, fyne.TextAlignLeading, false)
+	if span > 0 {
+		r.addText(last.Format("2006-01-02"), left+plotW, top+plotH+pad/2, fyne.TextAlignTrailing, false)
+	}
+
+	// Change points behind the runs, so both stay visible
+	warning := theme.Color(theme.ColorNameWarning)
+	for _, p := range trend.Points {
+		if p.ChangePoint {
+			r.addLine(warning, 1, x(p), top, x(p), top+plotH)
+		}
+	}
+
+	runColor, meanColor := chartColor(0), chartColor(1)
+	legendX := pad
+	for _, item := range []struct {
+		text  string
+		color color.Color
+	}{
+		{"■ " + i18n.T("Runs"), runColor},
+		{"■ " + fmt.Sprintf(i18n.T("Rolling mean of %d runs"), trend.Window), meanColor},
+		{"│ " + i18n.T("Change point"), warning},
+	} {
+		r.addText(item.text, legendX, textHeight, fyne.TextAlignLeading, false)
+		text := r.objects[len(r.objects)-1].(*canvas.Text)
+		text.Color = item.color
+		legendX += text.Size().Width + 2*pad
+	}
+
+	for i, p := range trend.Points {
+		v, mean := r.chart.values(p)
+		px := x(p)
+		if i > 0 {
+			prev := trend.Points[i-1]
+			pv, pmean := r.chart.values(prev)
+			r.addLine(runColor, 1, x(prev), y(pv), px, y(v))
+			r.addLine(meanColor, 2, x(prev), y(pmean), px, y(mean))
+		}
+		dot := canvas.NewCircle(runColor)
+		dot.Resize(fyne.NewSize(6, 6))
+		dot.Move(fyne.NewPos(px-3, y(v)-3))
+		r.objects = append(r.objects, dot)
+	}
+}
@@ -0,0 +1,195 @@
+// Package pages provides GUI pages for DB-BenchMind.
+// Trends Page implementation.
+package pages
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/history"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// TrendPage follows the results of recurring runs of the same configuration
+// over time.
+type TrendPage struct {
+	win        fyne.Window
+	historyUC  *usecase.HistoryUseCase
+	settingsUC *usecase.SettingsUseCase
+	ctx        context.Context
+
+	trends    []*history.Trend
+	threshold float64 // Trend alert threshold in percent, 0 = off
+	selected  int
+
+	list         *widget.List
+	summaryLabel *widget.Label
+	tpsChart     *trendChart
+	p95Chart     *trendChart
+}
+
+// NewTrendPage creates a new trends page.
+// Returns both the canvas object and the page instance for external refresh control.
+func NewTrendPage(win fyne.Window, historyUC *usecase.HistoryUseCase, settingsUC *usecase.SettingsUseCase) (*TrendPage, fyne.CanvasObject) {
+	page := &TrendPage{
+		win:          win,
+		historyUC:    historyUC,
+		settingsUC:   settingsUC,
+		ctx:          context.Background(),
+		selected:     -1,
+		summaryLabel: widget.NewLabel(i18n.T("Select a trend to see its runs")),
+		tpsChart:     newTrendChart(false),
+		p95Chart:     newTrendChart(true),
+	}
+	page.summaryLabel.Wrapping = fyne.TextWrapWord
+
+	page.list = widget.NewList(
+		func() int {
+			return len(page.trends)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel(i18n.T("Trend"))
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			if id >= len(page.trends) {
+				return
+			}
+			trend := page.trends[id]
+			text := i18n.Tf("%s | %d runs", trend.Label(), len(trend.Points))
+			if page.alerts(trend) {
+				text = "⚠ " + text
+			}
+			obj.(*widget.Label).SetText(text)
+		},
+	)
+	page.list.OnSelected = func(id widget.ListItemID) {
+		page.selected = id
+		page.showTrend()
+	}
+
+	helpLabel := widget.NewLabel(fmt.Sprintf(i18n.T("Completed runs of the same connection, template, threads, load generators and parameters form a trend once there are %d of them. Change points mark where the level of TPS or p95 latency shifts; ⚠ marks trends whose latest run deviates from the mean of the runs before it by more than the trend alert threshold in Settings → History."), history.MinTrendRuns))
+	helpLabel.Wrapping = fyne.TextWrapWord
+	btnRefresh := widget.NewButton(i18n.T("🔄 Refresh"), page.Refresh)
+
+	details := container.NewBorder(
+		page.summaryLabel, nil, nil, nil,
+		container.NewGridWithRows(2, page.tpsChart, page.p95Chart),
+	)
+	split := container.NewHSplit(page.list, details)
+	split.SetOffset(0.35)
+
+	page.loadTrends()
+
+	content := container.NewBorder(
+		container.NewVBox(helpLabel, container.NewHBox(btnRefresh)),
+		nil, nil, nil,
+		split,
+	)
+	return page, content
+}
+
+// Refresh reloads the trends from history.
+func (p *TrendPage) Refresh() {
+	p.loadTrends()
+}
+
+// loadTrends loads the trends and the alert threshold, keeping the selected
+// trend selected if it still exists.
+func (p *TrendPage) loadTrends() {
+	if p.historyUC == nil {
+		slog.Warn("Trends: historyUC is nil")
+		return
+	}
+
+	p.threshold = 0
+	if p.settingsUC != nil {
+		if cfg, err := p.settingsUC.GetHistoryConfig(p.ctx); err != nil {
+			slog.Warn("Trends: Failed to load history settings", "error", err)
+		} else {
+			p.threshold = cfg.TrendAlertPercent
+		}
+	}
+
+	var fingerprint string
+	if p.selected >= 0 && p.selected < len(p.trends) {
+		fingerprint = p.trends[p.selected].Fingerprint
+	}
+	trends, err := p.historyUC.ListTrends(p.ctx)
+	if err != nil {
+		slog.Error("Trends: Failed to list trends", "error", err)
+		dialog.ShowError(fmt.Errorf(i18n.T("failed to load trends: %v"), err), p.win)
+		return
+	}
+	p.trends = trends
+
+	p.selected = -1
+	for i, t := range trends {
+		if t.Fingerprint == fingerprint {
+			p.selected = i
+		}
+	}
+	if p.list != nil {
+		p.list.Refresh()
+		if p.selected >= 0 {
+			p.list.Select(p.selected)
+		} else {
+			p.list.UnselectAll()
+		}
+	}
+	p.showTrend()
+}
+
+// alerts reports whether the latest run of trend deviates by more than the
+// trend alert threshold.
+func (p *TrendPage) alerts(trend *history.Trend) bool {
+	if p.threshold <= 0 {
+		return false
+	}
+	dev, ok := trend.LatestDeviation()
+	return ok && dev.Exceeds(p.threshold)
+}
+
+// showTrend shows the summary and charts of the selected trend.
+func (p *TrendPage) showTrend() {
+	if p.selected < 0 || p.selected >= len(p.trends) {
+		p.summaryLabel.SetText(i18n.T("Select a trend to see its runs"))
+		p.tpsChart.SetTrend(nil)
+		p.p95Chart.SetTrend(nil)
+		return
+	}
+	trend := p.trends[p.selected]
+
+	var b strings.Builder
+	first, latest := trend.Points[0], trend.Latest()
+	fmt.Fprintf(&b, i18n.T("%s\n%d runs from %s to %s"), trend.Label(), len(trend.Points),
+		first.StartTime.Format("2006-01-02"), latest.StartTime.Format("2006-01-02"))
+	fmt.Fprintf(&b, "\n"+i18n.T("Latest run: %.2f TPS, p95 %.2f ms"), latest.TPS, latest.LatencyP95)
+	if dev, ok := trend.LatestDeviation(); ok {
+		fmt.Fprintf(&b, "\n"+i18n.T("Against the mean of the %d runs before it: TPS %+.1f%%, p95 %+.1f%%"),
+			dev.Baseline, dev.TPSPercent, dev.LatencyP95Percent)
+	}
+	if times := trend.ChangePointTimes(); len(times) > 0 {
+		dates := make([]string, len(times))
+		for i, t := range times {
+			dates[i] = t.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(&b, "\n"+i18n.T("Change points: %s"), strings.Join(dates, ", "))
+	} else {
+		b.WriteString("\n" + i18n.T("Change points: none"))
+	}
+	if p.alerts(trend) {
+		fmt.Fprintf(&b, "\n⚠ "+i18n.T("The latest run deviates by more than %g%%"), p.threshold)
+	}
+
+	p.summaryLabel.SetText(b.String())
+	p.tpsChart.SetTrend(trend)
+	p.p95Chart.SetTrend(trend)
+}
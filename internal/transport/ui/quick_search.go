@@ -0,0 +1,114 @@
+// Package ui provides the GUI implementation using Fyne.
+// Quick search (Ctrl+K) across connections, templates and history records.
+package ui
+
+import (
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/pages"
+)
+
+// quickSearchLimit is the maximum number of results shown per kind of item.
+const quickSearchLimit = 8
+
+// quickSearchItem is a result of the quick search with the action opening it.
+type quickSearchItem struct {
+	kind   string // Connection, template or history record
+	result pages.QuickSearchResult
+	open   func()
+}
+
+// registerQuickSearch opens the quick search with Ctrl+K (Cmd+K on macOS).
+func (a *Application) registerQuickSearch() {
+	shortcut := &desktop.CustomShortcut{KeyName: fyne.KeyK, Modifier: fyne.KeyModifierShortcutDefault}
+	a.window.Canvas().AddShortcut(shortcut, func(fyne.Shortcut) {
+		a.showQuickSearch()
+	})
+}
+
+// quickSearch returns the connections, templates and history records
+// matching query, with actions switching to their page and selecting them.
+func (a *Application) quickSearch(query string) []quickSearchItem {
+	var items []quickSearchItem
+	add := func(kind string, results []pages.QuickSearchResult, open func(id string)) {
+		for i, result := range results {
+			if i == quickSearchLimit {
+				break
+			}
+			id := result.ID
+			items = append(items, quickSearchItem{kind: kind, result: result, open: func() { open(id) }})
+		}
+	}
+	add(i18n.T("Connection"), a.connectionPage.QuickSearch(query), func(id string) {
+		a.tabs.Select(a.connectionsTab)
+		a.connectionPage.SelectConnection(id)
+	})
+	add(i18n.T("Template"), a.templatePage.QuickSearch(query), func(id string) {
+		a.tabs.Select(a.templatesTab)
+		a.templatePage.SelectTemplate(id)
+	})
+	add(i18n.T("History"), a.historyPage.QuickSearch(query), func(id string) {
+		a.tabs.Select(a.historyTab)
+		a.historyPage.SelectRecord(id)
+	})
+	return items
+}
+
+// showQuickSearch shows the quick search overlay. Enter or a click opens a
+// result on its page.
+func (a *Application) showQuickSearch() {
+	if !a.shortcutsEnabled() {
+		return // Locked behind the app lock, or a dialog is open
+	}
+	var items []quickSearchItem
+	var dlg dialog.Dialog
+
+	open := func(item quickSearchItem) {
+		slog.Info("UI: Opening quick search result", "kind", item.kind, "id", item.result.ID)
+		dlg.Hide()
+		item.open()
+	}
+
+	results := widget.NewList(
+		func() int { return len(items) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			item := items[id]
+			obj.(*widget.Label).SetText(item.kind + ": " + item.result.Title + "  —  " + item.result.Detail)
+		},
+	)
+	results.OnSelected = func(id widget.ListItemID) { open(items[id]) }
+
+	status := widget.NewLabel(i18n.T("Find connections by name or host, templates by name, history by tag or date (e.g. 2026-10-15)"))
+	status.Wrapping = fyne.TextWrapWord
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder(i18n.T("Search connections, templates and history..."))
+	entry.OnChanged = func(query string) {
+		items = a.quickSearch(query)
+		results.UnselectAll()
+		results.Refresh()
+		if query != "" && len(items) == 0 {
+			status.SetText(i18n.T("No matches"))
+		} else {
+			status.SetText(i18n.Tf("%d matches", len(items)))
+		}
+	}
+	entry.OnSubmitted = func(string) {
+		if len(items) > 0 {
+			open(items[0])
+		}
+	}
+
+	content := container.NewBorder(container.NewVBox(entry, status), nil, nil, nil, results)
+	dlg = dialog.NewCustom(i18n.T("Quick Search"), i18n.T("Close"), content, a.window)
+	dlg.Resize(fyne.NewSize(720, 480))
+	dlg.Show()
+	a.window.Canvas().Focus(entry)
+}
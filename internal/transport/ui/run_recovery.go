@@ -0,0 +1,92 @@
+// Package ui provides the GUI implementation using Fyne.
+// Recovery of benchmark runs left running when the application exited.
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/app/usecase"
+	"github.com/whhaicheng/DB-BenchMind/internal/transport/ui/i18n"
+)
+
+// promptOrphanedRuns offers to re-attach to or terminate each run left
+// running when the application exited, e.g. because it crashed.
+func (a *Application) promptOrphanedRuns(win fyne.Window) {
+	if a.benchmarkUC == nil {
+		return
+	}
+	orphans, err := a.benchmarkUC.OrphanedRuns(context.Background())
+	if err != nil {
+		slog.Error("UI: Failed to find orphaned runs", "error", err)
+		return
+	}
+	a.promptOrphanedRun(win, orphans)
+}
+
+// promptOrphanedRun asks what to do with the first of orphans, then with the rest.
+func (a *Application) promptOrphanedRun(win fyne.Window, orphans []usecase.OrphanedRun) {
+	if len(orphans) == 0 {
+		return
+	}
+	orphan, rest := orphans[0], orphans[1:]
+
+	status := i18n.T("The tool process has exited; its output can still be collected.")
+	if orphan.Running {
+		status = i18n.T("The tool process is still running.")
+	}
+	message := i18n.Tf("A benchmark run was left behind when DB-BenchMind exited.\n\nTemplate: %s\nConnection: %s\nStarted: %s\nProcess: %d\n\n%s",
+		orphan.Recovery.TemplateName, orphan.Recovery.ConnectionName,
+		orphan.Recovery.StartedAt.Format("2006-01-02 15:04:05"), orphan.PID, status)
+
+	confirm := dialog.NewCustomConfirm(i18n.T("Orphaned Benchmark Run"), i18n.T("Re-attach"), i18n.T("Terminate"),
+		widget.NewLabel(message), func(reattach bool) {
+			if reattach {
+				a.reattachRun(win, orphan)
+			} else {
+				a.terminateOrphanedRun(win, orphan)
+			}
+			a.promptOrphanedRun(win, rest)
+		}, win)
+	confirm.Show()
+}
+
+// reattachRun monitors an orphaned run on the Tasks & Monitor page.
+func (a *Application) reattachRun(win fyne.Window, orphan usecase.OrphanedRun) {
+	if err := a.taskPage.ReattachRun(orphan); err != nil {
+		slog.Error("UI: Failed to re-attach run", "run_id", orphan.RunID, "error", err)
+		dialog.ShowError(fmt.Errorf(i18n.T("re-attach run: %w"), err), win)
+		return
+	}
+	a.tabs.Select(a.tasksTab)
+}
+
+// terminateOrphanedRun kills an orphaned run and, with auto-save on, keeps
+// it in history as force stopped.
+func (a *Application) terminateOrphanedRun(win fyne.Window, orphan usecase.OrphanedRun) {
+	ctx := context.Background()
+	run, err := a.benchmarkUC.TerminateOrphanedRun(ctx, orphan)
+	if err != nil {
+		slog.Error("UI: Failed to terminate orphaned run", "run_id", orphan.RunID, "error", err)
+		dialog.ShowError(fmt.Errorf(i18n.T("terminate run: %w"), err), win)
+		return
+	}
+
+	if a.historyUC == nil {
+		return
+	}
+	if a.settingsUC != nil {
+		if cfg, err := a.settingsUC.GetHistoryConfig(ctx); err == nil && !cfg.AutoSave() {
+			return
+		}
+	}
+	if err := a.historyUC.SaveStoppedRun(ctx, orphan.RunEvent(run)); err != nil {
+		slog.Error("UI: Failed to save terminated run to history", "run_id", run.ID, "error", err)
+		dialog.ShowError(fmt.Errorf(i18n.T("Failed to save to history: %v"), err), win)
+	}
+}
@@ -0,0 +1,63 @@
+// Package ui provides the GUI implementation using Fyne.
+// Keyboard shortcuts of the main window: Ctrl+N, Ctrl+R, F5 and Esc.
+package ui
+
+import (
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// registerShortcuts adds the keyboard shortcuts of the main window. Ctrl+N
+// (Cmd+N on macOS) adds a connection and Ctrl+R runs the configured task.
+// Without a modifier, F5 and Esc reach the window only while no entry has
+// the focus: F5 refreshes the current page and Esc closes the top dialog.
+func (a *Application) registerShortcuts() {
+	canvas := a.window.Canvas()
+	canvas.AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyN, Modifier: fyne.KeyModifierShortcutDefault}, func(fyne.Shortcut) {
+		if !a.shortcutsEnabled() {
+			return
+		}
+		slog.Info("UI: Shortcut new connection")
+		a.tabs.Select(a.connectionsTab)
+		a.connectionPage.AddConnection()
+	})
+	canvas.AddShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyR, Modifier: fyne.KeyModifierShortcutDefault}, func(fyne.Shortcut) {
+		if !a.shortcutsEnabled() {
+			return
+		}
+		slog.Info("UI: Shortcut run task")
+		a.tabs.Select(a.tasksTab)
+		a.taskPage.Run()
+	})
+	canvas.SetOnTypedKey(func(ev *fyne.KeyEvent) {
+		switch ev.Name {
+		case fyne.KeyF5:
+			if a.shortcutsEnabled() {
+				slog.Info("UI: Shortcut refresh", "tab", a.tabs.Selected().Text)
+				a.refreshTab(a.tabs.Selected())
+			}
+		case fyne.KeyEscape:
+			a.closeTopDialog()
+		}
+	})
+}
+
+// shortcutsEnabled reports whether the pages are shown without a dialog
+// above them, so that a shortcut does not act behind a dialog or the app lock.
+func (a *Application) shortcutsEnabled() bool {
+	return a.tabs != nil && a.window.Content() == a.tabs && a.window.Canvas().Overlays().Top() == nil
+}
+
+// closeTopDialog closes the top dialog or pop-up without answering it. The
+// app lock prompt stays open, as the pages are hidden while it is shown.
+func (a *Application) closeTopDialog() {
+	top := a.window.Canvas().Overlays().Top()
+	if top == nil || a.tabs == nil || a.window.Content() != a.tabs {
+		return
+	}
+	slog.Info("UI: Shortcut close dialog")
+	top.Hide()
+	a.window.Canvas().Overlays().Remove(top)
+}
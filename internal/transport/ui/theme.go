@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"image/color"
+	"log/slog"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/whhaicheng/DB-BenchMind/internal/domain/config"
+)
+
+// hitTargetPadding is added to the inner padding of buttons, entries and
+// selects, making them larger targets for the mouse and touch.
+const hitTargetPadding = 2
+
+// appTheme applies the appearance settings (ui.theme, ui.font_scale and
+// ui.monospace_font) on top of the default Fyne theme.
+type appTheme struct {
+	fyne.Theme
+	variant   fyne.ThemeVariant
+	forced    bool
+	scale     float32
+	monospace fyne.Resource
+}
+
+// newAppTheme creates the theme for the UI settings. A font file that cannot
+// be loaded is logged and the built-in monospace font is used instead.
+func newAppTheme(cfg config.UIConfig) *appTheme {
+	t := &appTheme{Theme: theme.DefaultTheme(), scale: float32(cfg.Scale())}
+	switch cfg.Theme {
+	case config.ThemeDark:
+		t.variant, t.forced = theme.VariantDark, true
+	case config.ThemeLight:
+		t.variant, t.forced = theme.VariantLight, true
+	}
+	if cfg.MonospaceFont != "" {
+		font, err := fyne.LoadResourceFromPath(cfg.MonospaceFont)
+		if err != nil {
+			slog.Warn("UI: Failed to load monospace font", "path", cfg.MonospaceFont, "error", err)
+		} else {
+			t.monospace = font
+		}
+	}
+	return t
+}
+
+// Color returns the colors of the configured variant, or of the system
+// variant for the auto theme.
+func (t *appTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	if t.forced {
+		variant = t.variant
+	}
+	return t.Theme.Color(name, variant)
+}
+
+// Font returns the configured monospace font for monospace text.
+func (t *appTheme) Font(style fyne.TextStyle) fyne.Resource {
+	if style.Monospace && t.monospace != nil {
+		return t.monospace
+	}
+	return t.Theme.Font(style)
+}
+
+// Size scales the text sizes by the font scale and enlarges the inner
+// padding for larger hit targets.
+func (t *appTheme) Size(name fyne.ThemeSizeName) float32 {
+	size := t.Theme.Size(name)
+	switch name {
+	case theme.SizeNameText, theme.SizeNameHeadingText, theme.SizeNameSubHeadingText, theme.SizeNameCaptionText:
+		return size * t.scale
+	case theme.SizeNameInnerPadding:
+		return size + hitTargetPadding
+	}
+	return size
+}
@@ -0,0 +1,1816 @@
+// gRPC API of DB-BenchMind: connections, benchmark runs with streamed
+// samples, and run history. Served by `db-benchmind-cli serve`.
+//
+// Regenerate the Go code in this directory with `make proto`.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: dbbenchmind.proto
+
+package api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Connection is a saved database connection. Passwords are never returned.
+type Connection struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DatabaseType  string                 `protobuf:"bytes,3,opt,name=database_type,json=databaseType,proto3" json:"database_type,omitempty"` // mysql, postgresql, oracle or sqlserver
+	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`                               // Redacted connection string
+	Protected     bool                   `protobuf:"varint,5,opt,name=protected,proto3" json:"protected,omitempty"`                          // Prepare and cleanup are blocked
+	Observer      bool                   `protobuf:"varint,6,opt,name=observer,proto3" json:"observer,omitempty"`                            // Every benchmark phase is blocked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Connection) Reset() {
+	*x = Connection{}
+	mi := &file_dbbenchmind_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Connection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Connection) ProtoMessage() {}
+
+func (x *Connection) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Connection.ProtoReflect.Descriptor instead.
+func (*Connection) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Connection) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Connection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Connection) GetDatabaseType() string {
+	if x != nil {
+		return x.DatabaseType
+	}
+	return ""
+}
+
+func (x *Connection) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Connection) GetProtected() bool {
+	if x != nil {
+		return x.Protected
+	}
+	return false
+}
+
+func (x *Connection) GetObserver() bool {
+	if x != nil {
+		return x.Observer
+	}
+	return false
+}
+
+type ListConnectionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConnectionsRequest) Reset() {
+	*x = ListConnectionsRequest{}
+	mi := &file_dbbenchmind_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConnectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConnectionsRequest) ProtoMessage() {}
+
+func (x *ListConnectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConnectionsRequest.ProtoReflect.Descriptor instead.
+func (*ListConnectionsRequest) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{1}
+}
+
+type ListConnectionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Connections   []*Connection          `protobuf:"bytes,1,rep,name=connections,proto3" json:"connections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConnectionsResponse) Reset() {
+	*x = ListConnectionsResponse{}
+	mi := &file_dbbenchmind_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConnectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConnectionsResponse) ProtoMessage() {}
+
+func (x *ListConnectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConnectionsResponse.ProtoReflect.Descriptor instead.
+func (*ListConnectionsResponse) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListConnectionsResponse) GetConnections() []*Connection {
+	if x != nil {
+		return x.Connections
+	}
+	return nil
+}
+
+type GetConnectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Connection ID or name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConnectionRequest) Reset() {
+	*x = GetConnectionRequest{}
+	mi := &file_dbbenchmind_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConnectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConnectionRequest) ProtoMessage() {}
+
+func (x *GetConnectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConnectionRequest.ProtoReflect.Descriptor instead.
+func (*GetConnectionRequest) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetConnectionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type TestConnectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Connection ID or name
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TestConnectionRequest) Reset() {
+	*x = TestConnectionRequest{}
+	mi := &file_dbbenchmind_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestConnectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestConnectionRequest) ProtoMessage() {}
+
+func (x *TestConnectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestConnectionRequest.ProtoReflect.Descriptor instead.
+func (*TestConnectionRequest) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TestConnectionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type TestConnectionResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	LatencyMs       int64                  `protobuf:"varint,2,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	DatabaseVersion string                 `protobuf:"bytes,3,opt,name=database_version,json=databaseVersion,proto3" json:"database_version,omitempty"`
+	Error           string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TestConnectionResponse) Reset() {
+	*x = TestConnectionResponse{}
+	mi := &file_dbbenchmind_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestConnectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestConnectionResponse) ProtoMessage() {}
+
+func (x *TestConnectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestConnectionResponse.ProtoReflect.Descriptor instead.
+func (*TestConnectionResponse) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TestConnectionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TestConnectionResponse) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *TestConnectionResponse) GetDatabaseVersion() string {
+	if x != nil {
+		return x.DatabaseVersion
+	}
+	return ""
+}
+
+func (x *TestConnectionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// RunOptions are the execution options of a run.
+type RunOptions struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SkipPrepare    bool                   `protobuf:"varint,1,opt,name=skip_prepare,json=skipPrepare,proto3" json:"skip_prepare,omitempty"`
+	SkipCleanup    bool                   `protobuf:"varint,2,opt,name=skip_cleanup,json=skipCleanup,proto3" json:"skip_cleanup,omitempty"`
+	WarmupSeconds  int32                  `protobuf:"varint,3,opt,name=warmup_seconds,json=warmupSeconds,proto3" json:"warmup_seconds,omitempty"`
+	SampleInterval *durationpb.Duration   `protobuf:"bytes,4,opt,name=sample_interval,json=sampleInterval,proto3" json:"sample_interval,omitempty"` // Unset = adaptive
+	RemoteWinrm    bool                   `protobuf:"varint,5,opt,name=remote_winrm,json=remoteWinrm,proto3" json:"remote_winrm,omitempty"`
+	Agents         []string               `protobuf:"bytes,6,rep,name=agents,proto3" json:"agents,omitempty"` // Load-generator agents, one or more
+	KeepArtifacts  bool                   `protobuf:"varint,7,opt,name=keep_artifacts,json=keepArtifacts,proto3" json:"keep_artifacts,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RunOptions) Reset() {
+	*x = RunOptions{}
+	mi := &file_dbbenchmind_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunOptions) ProtoMessage() {}
+
+func (x *RunOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunOptions.ProtoReflect.Descriptor instead.
+func (*RunOptions) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RunOptions) GetSkipPrepare() bool {
+	if x != nil {
+		return x.SkipPrepare
+	}
+	return false
+}
+
+func (x *RunOptions) GetSkipCleanup() bool {
+	if x != nil {
+		return x.SkipCleanup
+	}
+	return false
+}
+
+func (x *RunOptions) GetWarmupSeconds() int32 {
+	if x != nil {
+		return x.WarmupSeconds
+	}
+	return 0
+}
+
+func (x *RunOptions) GetSampleInterval() *durationpb.Duration {
+	if x != nil {
+		return x.SampleInterval
+	}
+	return nil
+}
+
+func (x *RunOptions) GetRemoteWinrm() bool {
+	if x != nil {
+		return x.RemoteWinrm
+	}
+	return false
+}
+
+func (x *RunOptions) GetAgents() []string {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *RunOptions) GetKeepArtifacts() bool {
+	if x != nil {
+		return x.KeepArtifacts
+	}
+	return false
+}
+
+type StartRunRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ConnectionId string                 `protobuf:"bytes,1,opt,name=connection_id,json=connectionId,proto3" json:"connection_id,omitempty"` // Connection ID or name
+	TemplateId   string                 `protobuf:"bytes,2,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // Task name; defaults to "<connection> Benchmark"
+	// Template parameter overrides, e.g. {"threads": 16, "time": 300}
+	Parameters *structpb.Struct `protobuf:"bytes,4,opt,name=parameters,proto3" json:"parameters,omitempty"`
+	Options    *RunOptions      `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+	Tags       []string         `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Why and where the run is made, saved with its history record
+	Purpose       string `protobuf:"bytes,7,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	Ticket        string `protobuf:"bytes,8,opt,name=ticket,proto3" json:"ticket,omitempty"`           // Change ticket or PR link
+	Environment   string `protobuf:"bytes,9,opt,name=environment,proto3" json:"environment,omitempty"` // e.g. staging, prod-replica
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartRunRequest) Reset() {
+	*x = StartRunRequest{}
+	mi := &file_dbbenchmind_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartRunRequest) ProtoMessage() {}
+
+func (x *StartRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartRunRequest.ProtoReflect.Descriptor instead.
+func (*StartRunRequest) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StartRunRequest) GetConnectionId() string {
+	if x != nil {
+		return x.ConnectionId
+	}
+	return ""
+}
+
+func (x *StartRunRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *StartRunRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StartRunRequest) GetParameters() *structpb.Struct {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *StartRunRequest) GetOptions() *RunOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *StartRunRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *StartRunRequest) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *StartRunRequest) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *StartRunRequest) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+// Run is a benchmark run started by this server.
+type Run struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"` // pending, preparing, ..., completed, failed, cancelled
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	Duration      *durationpb.Duration   `protobuf:"bytes,7,opt,name=duration,proto3" json:"duration,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Message       string                 `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`
+	Parameters    map[string]string      `protobuf:"bytes,10,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Result        *RunResult             `protobuf:"bytes,11,opt,name=result,proto3" json:"result,omitempty"` // Set once the run has completed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Run) Reset() {
+	*x = Run{}
+	mi := &file_dbbenchmind_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Run) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Run) ProtoMessage() {}
+
+func (x *Run) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Run.ProtoReflect.Descriptor instead.
+func (*Run) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Run) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Run) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *Run) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Run) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Run) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *Run) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+func (x *Run) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *Run) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Run) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Run) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *Run) GetResult() *RunResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type RunResult struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Tps               float64                `protobuf:"fixed64,1,opt,name=tps,proto3" json:"tps,omitempty"`
+	LatencyAvgMs      float64                `protobuf:"fixed64,2,opt,name=latency_avg_ms,json=latencyAvgMs,proto3" json:"latency_avg_ms,omitempty"`
+	LatencyMinMs      float64                `protobuf:"fixed64,3,opt,name=latency_min_ms,json=latencyMinMs,proto3" json:"latency_min_ms,omitempty"`
+	LatencyMaxMs      float64                `protobuf:"fixed64,4,opt,name=latency_max_ms,json=latencyMaxMs,proto3" json:"latency_max_ms,omitempty"`
+	LatencyP95Ms      float64                `protobuf:"fixed64,5,opt,name=latency_p95_ms,json=latencyP95Ms,proto3" json:"latency_p95_ms,omitempty"`
+	LatencyP99Ms      float64                `protobuf:"fixed64,6,opt,name=latency_p99_ms,json=latencyP99Ms,proto3" json:"latency_p99_ms,omitempty"`
+	ErrorCount        int64                  `protobuf:"varint,7,opt,name=error_count,json=errorCount,proto3" json:"error_count,omitempty"`
+	ErrorRatePercent  float64                `protobuf:"fixed64,8,opt,name=error_rate_percent,json=errorRatePercent,proto3" json:"error_rate_percent,omitempty"`
+	TotalTransactions int64                  `protobuf:"varint,9,opt,name=total_transactions,json=totalTransactions,proto3" json:"total_transactions,omitempty"`
+	TotalQueries      int64                  `protobuf:"varint,10,opt,name=total_queries,json=totalQueries,proto3" json:"total_queries,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RunResult) Reset() {
+	*x = RunResult{}
+	mi := &file_dbbenchmind_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunResult) ProtoMessage() {}
+
+func (x *RunResult) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunResult.ProtoReflect.Descriptor instead.
+func (*RunResult) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RunResult) GetTps() float64 {
+	if x != nil {
+		return x.Tps
+	}
+	return 0
+}
+
+func (x *RunResult) GetLatencyAvgMs() float64 {
+	if x != nil {
+		return x.LatencyAvgMs
+	}
+	return 0
+}
+
+func (x *RunResult) GetLatencyMinMs() float64 {
+	if x != nil {
+		return x.LatencyMinMs
+	}
+	return 0
+}
+
+func (x *RunResult) GetLatencyMaxMs() float64 {
+	if x != nil {
+		return x.LatencyMaxMs
+	}
+	return 0
+}
+
+func (x *RunResult) GetLatencyP95Ms() float64 {
+	if x != nil {
+		return x.LatencyP95Ms
+	}
+	return 0
+}
+
+func (x *RunResult) GetLatencyP99Ms() float64 {
+	if x != nil {
+		return x.LatencyP99Ms
+	}
+	return 0
+}
+
+func (x *RunResult) GetErrorCount() int64 {
+	if x != nil {
+		return x.ErrorCount
+	}
+	return 0
+}
+
+func (x *RunResult) GetErrorRatePercent() float64 {
+	if x != nil {
+		return x.ErrorRatePercent
+	}
+	return 0
+}
+
+func (x *RunResult) GetTotalTransactions() int64 {
+	if x != nil {
+		return x.TotalTransactions
+	}
+	return 0
+}
+
+func (x *RunResult) GetTotalQueries() int64 {
+	if x != nil {
+		return x.TotalQueries
+	}
+	return 0
+}
+
+type GetRunRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRunRequest) Reset() {
+	*x = GetRunRequest{}
+	mi := &file_dbbenchmind_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRunRequest) ProtoMessage() {}
+
+func (x *GetRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRunRequest.ProtoReflect.Descriptor instead.
+func (*GetRunRequest) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetRunRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+type ListRunsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRunsRequest) Reset() {
+	*x = ListRunsRequest{}
+	mi := &file_dbbenchmind_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRunsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRunsRequest) ProtoMessage() {}
+
+func (x *ListRunsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRunsRequest.ProtoReflect.Descriptor instead.
+func (*ListRunsRequest) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListRunsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRunsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListRunsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Runs          []*Run                 `protobuf:"bytes,1,rep,name=runs,proto3" json:"runs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRunsResponse) Reset() {
+	*x = ListRunsResponse{}
+	mi := &file_dbbenchmind_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRunsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRunsResponse) ProtoMessage() {}
+
+func (x *ListRunsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRunsResponse.ProtoReflect.Descriptor instead.
+func (*ListRunsResponse) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListRunsResponse) GetRuns() []*Run {
+	if x != nil {
+		return x.Runs
+	}
+	return nil
+}
+
+type StopRunRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	Force         bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"` // Kill the benchmark tool instead of stopping it gracefully
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopRunRequest) Reset() {
+	*x = StopRunRequest{}
+	mi := &file_dbbenchmind_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopRunRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopRunRequest) ProtoMessage() {}
+
+func (x *StopRunRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopRunRequest.ProtoReflect.Descriptor instead.
+func (*StopRunRequest) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StopRunRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *StopRunRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type StreamSamplesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RunId         string                 `protobuf:"bytes,1,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamSamplesRequest) Reset() {
+	*x = StreamSamplesRequest{}
+	mi := &file_dbbenchmind_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamSamplesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSamplesRequest) ProtoMessage() {}
+
+func (x *StreamSamplesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSamplesRequest.ProtoReflect.Descriptor instead.
+func (*StreamSamplesRequest) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StreamSamplesRequest) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+// Sample is one realtime metric sample of a run.
+type Sample struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp        *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Phase            string                 `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"` // warmup or run
+	Tps              float64                `protobuf:"fixed64,3,opt,name=tps,proto3" json:"tps,omitempty"`
+	Qps              float64                `protobuf:"fixed64,4,opt,name=qps,proto3" json:"qps,omitempty"`
+	LatencyAvgMs     float64                `protobuf:"fixed64,5,opt,name=latency_avg_ms,json=latencyAvgMs,proto3" json:"latency_avg_ms,omitempty"`
+	LatencyP95Ms     float64                `protobuf:"fixed64,6,opt,name=latency_p95_ms,json=latencyP95Ms,proto3" json:"latency_p95_ms,omitempty"`
+	LatencyP99Ms     float64                `protobuf:"fixed64,7,opt,name=latency_p99_ms,json=latencyP99Ms,proto3" json:"latency_p99_ms,omitempty"`
+	ErrorRatePercent float64                `protobuf:"fixed64,8,opt,name=error_rate_percent,json=errorRatePercent,proto3" json:"error_rate_percent,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Sample) Reset() {
+	*x = Sample{}
+	mi := &file_dbbenchmind_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sample) ProtoMessage() {}
+
+func (x *Sample) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sample.ProtoReflect.Descriptor instead.
+func (*Sample) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Sample) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Sample) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *Sample) GetTps() float64 {
+	if x != nil {
+		return x.Tps
+	}
+	return 0
+}
+
+func (x *Sample) GetQps() float64 {
+	if x != nil {
+		return x.Qps
+	}
+	return 0
+}
+
+func (x *Sample) GetLatencyAvgMs() float64 {
+	if x != nil {
+		return x.LatencyAvgMs
+	}
+	return 0
+}
+
+func (x *Sample) GetLatencyP95Ms() float64 {
+	if x != nil {
+		return x.LatencyP95Ms
+	}
+	return 0
+}
+
+func (x *Sample) GetLatencyP99Ms() float64 {
+	if x != nil {
+		return x.LatencyP99Ms
+	}
+	return 0
+}
+
+func (x *Sample) GetErrorRatePercent() float64 {
+	if x != nil {
+		return x.ErrorRatePercent
+	}
+	return 0
+}
+
+// Record is a saved history record.
+type Record struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ConnectionName string                 `protobuf:"bytes,3,opt,name=connection_name,json=connectionName,proto3" json:"connection_name,omitempty"`
+	TemplateName   string                 `protobuf:"bytes,4,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	DatabaseType   string                 `protobuf:"bytes,5,opt,name=database_type,json=databaseType,proto3" json:"database_type,omitempty"`
+	Threads        int32                  `protobuf:"varint,6,opt,name=threads,proto3" json:"threads,omitempty"`
+	Agents         []string               `protobuf:"bytes,7,rep,name=agents,proto3" json:"agents,omitempty"`
+	Tags           []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	Notes          string                 `protobuf:"bytes,9,opt,name=notes,proto3" json:"notes,omitempty"`
+	State          string                 `protobuf:"bytes,10,opt,name=state,proto3" json:"state,omitempty"` // Empty for completed runs
+	ErrorMessage   string                 `protobuf:"bytes,11,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	Valid          bool                   `protobuf:"varint,12,opt,name=valid,proto3" json:"valid,omitempty"` // Passed the sanity checks, or not checked
+	Parameters     map[string]string      `protobuf:"bytes,13,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	StartTime      *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	Duration       *durationpb.Duration   `protobuf:"bytes,15,opt,name=duration,proto3" json:"duration,omitempty"`
+	Result         *RunResult             `protobuf:"bytes,16,opt,name=result,proto3" json:"result,omitempty"`
+	TimeSeries     []*Sample              `protobuf:"bytes,17,rep,name=time_series,json=timeSeries,proto3" json:"time_series,omitempty"` // Only with include_time_series
+	Purpose        string                 `protobuf:"bytes,18,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	Ticket         string                 `protobuf:"bytes,19,opt,name=ticket,proto3" json:"ticket,omitempty"`
+	Environment    string                 `protobuf:"bytes,20,opt,name=environment,proto3" json:"environment,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	mi := &file_dbbenchmind_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+func (x *Record) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Record) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Record) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Record) GetConnectionName() string {
+	if x != nil {
+		return x.ConnectionName
+	}
+	return ""
+}
+
+func (x *Record) GetTemplateName() string {
+	if x != nil {
+		return x.TemplateName
+	}
+	return ""
+}
+
+func (x *Record) GetDatabaseType() string {
+	if x != nil {
+		return x.DatabaseType
+	}
+	return ""
+}
+
+func (x *Record) GetThreads() int32 {
+	if x != nil {
+		return x.Threads
+	}
+	return 0
+}
+
+func (x *Record) GetAgents() []string {
+	if x != nil {
+		return x.Agents
+	}
+	return nil
+}
+
+func (x *Record) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Record) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Record) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Record) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Record) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *Record) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+func (x *Record) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *Record) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *Record) GetResult() *RunResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *Record) GetTimeSeries() []*Sample {
+	if x != nil {
+		return x.TimeSeries
+	}
+	return nil
+}
+
+func (x *Record) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *Record) GetTicket() string {
+	if x != nil {
+		return x.Ticket
+	}
+	return ""
+}
+
+func (x *Record) GetEnvironment() string {
+	if x != nil {
+		return x.Environment
+	}
+	return ""
+}
+
+type ListRecordsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Limit          int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset         int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	ConnectionName string                 `protobuf:"bytes,3,opt,name=connection_name,json=connectionName,proto3" json:"connection_name,omitempty"`
+	TemplateName   string                 `protobuf:"bytes,4,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	DatabaseType   string                 `protobuf:"bytes,5,opt,name=database_type,json=databaseType,proto3" json:"database_type,omitempty"`
+	Threads        int32                  `protobuf:"varint,6,opt,name=threads,proto3" json:"threads,omitempty"`
+	Search         string                 `protobuf:"bytes,7,opt,name=search,proto3" json:"search,omitempty"` // Name, purpose, ticket or environment contains
+	Tags           []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	ValidOnly      bool                   `protobuf:"varint,9,opt,name=valid_only,json=validOnly,proto3" json:"valid_only,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListRecordsRequest) Reset() {
+	*x = ListRecordsRequest{}
+	mi := &file_dbbenchmind_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecordsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecordsRequest) ProtoMessage() {}
+
+func (x *ListRecordsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecordsRequest.ProtoReflect.Descriptor instead.
+func (*ListRecordsRequest) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListRecordsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRecordsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListRecordsRequest) GetConnectionName() string {
+	if x != nil {
+		return x.ConnectionName
+	}
+	return ""
+}
+
+func (x *ListRecordsRequest) GetTemplateName() string {
+	if x != nil {
+		return x.TemplateName
+	}
+	return ""
+}
+
+func (x *ListRecordsRequest) GetDatabaseType() string {
+	if x != nil {
+		return x.DatabaseType
+	}
+	return ""
+}
+
+func (x *ListRecordsRequest) GetThreads() int32 {
+	if x != nil {
+		return x.Threads
+	}
+	return 0
+}
+
+func (x *ListRecordsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListRecordsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListRecordsRequest) GetValidOnly() bool {
+	if x != nil {
+		return x.ValidOnly
+	}
+	return false
+}
+
+type ListRecordsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Records       []*Record              `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Records matching the filters, ignoring limit and offset
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecordsResponse) Reset() {
+	*x = ListRecordsResponse{}
+	mi := &file_dbbenchmind_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecordsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecordsResponse) ProtoMessage() {}
+
+func (x *ListRecordsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecordsResponse.ProtoReflect.Descriptor instead.
+func (*ListRecordsResponse) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListRecordsResponse) GetRecords() []*Record {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+func (x *ListRecordsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetRecordRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeTimeSeries bool                   `protobuf:"varint,2,opt,name=include_time_series,json=includeTimeSeries,proto3" json:"include_time_series,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetRecordRequest) Reset() {
+	*x = GetRecordRequest{}
+	mi := &file_dbbenchmind_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecordRequest) ProtoMessage() {}
+
+func (x *GetRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dbbenchmind_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecordRequest.ProtoReflect.Descriptor instead.
+func (*GetRecordRequest) Descriptor() ([]byte, []int) {
+	return file_dbbenchmind_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetRecordRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetRecordRequest) GetIncludeTimeSeries() bool {
+	if x != nil {
+		return x.IncludeTimeSeries
+	}
+	return false
+}
+
+var File_dbbenchmind_proto protoreflect.FileDescriptor
+
+const file_dbbenchmind_proto_rawDesc = "" +
+	"\n" +
+	"\x11dbbenchmind.proto\x12\x0edbbenchmind.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa9\x01\n" +
+	"\n" +
+	"Connection\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12#\n" +
+	"\rdatabase_type\x18\x03 \x01(\tR\fdatabaseType\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x1c\n" +
+	"\tprotected\x18\x05 \x01(\bR\tprotected\x12\x1a\n" +
+	"\bobserver\x18\x06 \x01(\bR\bobserver\"\x18\n" +
+	"\x16ListConnectionsRequest\"W\n" +
+	"\x17ListConnectionsResponse\x12<\n" +
+	"\vconnections\x18\x01 \x03(\v2\x1a.dbbenchmind.v1.ConnectionR\vconnections\"&\n" +
+	"\x14GetConnectionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"'\n" +
+	"\x15TestConnectionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x92\x01\n" +
+	"\x16TestConnectionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x02 \x01(\x03R\tlatencyMs\x12)\n" +
+	"\x10database_version\x18\x03 \x01(\tR\x0fdatabaseVersion\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\"\x9f\x02\n" +
+	"\n" +
+	"RunOptions\x12!\n" +
+	"\fskip_prepare\x18\x01 \x01(\bR\vskipPrepare\x12!\n" +
+	"\fskip_cleanup\x18\x02 \x01(\bR\vskipCleanup\x12%\n" +
+	"\x0ewarmup_seconds\x18\x03 \x01(\x05R\rwarmupSeconds\x12B\n" +
+	"\x0fsample_interval\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x0esampleInterval\x12!\n" +
+	"\fremote_winrm\x18\x05 \x01(\bR\vremoteWinrm\x12\x16\n" +
+	"\x06agents\x18\x06 \x03(\tR\x06agents\x12%\n" +
+	"\x0ekeep_artifacts\x18\a \x01(\bR\rkeepArtifacts\"\xc2\x02\n" +
+	"\x0fStartRunRequest\x12#\n" +
+	"\rconnection_id\x18\x01 \x01(\tR\fconnectionId\x12\x1f\n" +
+	"\vtemplate_id\x18\x02 \x01(\tR\n" +
+	"templateId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x127\n" +
+	"\n" +
+	"parameters\x18\x04 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"parameters\x124\n" +
+	"\aoptions\x18\x05 \x01(\v2\x1a.dbbenchmind.v1.RunOptionsR\aoptions\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x18\n" +
+	"\apurpose\x18\a \x01(\tR\apurpose\x12\x16\n" +
+	"\x06ticket\x18\b \x01(\tR\x06ticket\x12 \n" +
+	"\venvironment\x18\t \x01(\tR\venvironment\"\xa6\x04\n" +
+	"\x03Run\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
+	"\fcompleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x125\n" +
+	"\bduration\x18\a \x01(\v2\x19.google.protobuf.DurationR\bduration\x12#\n" +
+	"\rerror_message\x18\b \x01(\tR\ferrorMessage\x12\x18\n" +
+	"\amessage\x18\t \x01(\tR\amessage\x12C\n" +
+	"\n" +
+	"parameters\x18\n" +
+	" \x03(\v2#.dbbenchmind.v1.Run.ParametersEntryR\n" +
+	"parameters\x121\n" +
+	"\x06result\x18\v \x01(\v2\x19.dbbenchmind.v1.RunResultR\x06result\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xfe\x02\n" +
+	"\tRunResult\x12\x10\n" +
+	"\x03tps\x18\x01 \x01(\x01R\x03tps\x12$\n" +
+	"\x0elatency_avg_ms\x18\x02 \x01(\x01R\flatencyAvgMs\x12$\n" +
+	"\x0elatency_min_ms\x18\x03 \x01(\x01R\flatencyMinMs\x12$\n" +
+	"\x0elatency_max_ms\x18\x04 \x01(\x01R\flatencyMaxMs\x12$\n" +
+	"\x0elatency_p95_ms\x18\x05 \x01(\x01R\flatencyP95Ms\x12$\n" +
+	"\x0elatency_p99_ms\x18\x06 \x01(\x01R\flatencyP99Ms\x12\x1f\n" +
+	"\verror_count\x18\a \x01(\x03R\n" +
+	"errorCount\x12,\n" +
+	"\x12error_rate_percent\x18\b \x01(\x01R\x10errorRatePercent\x12-\n" +
+	"\x12total_transactions\x18\t \x01(\x03R\x11totalTransactions\x12#\n" +
+	"\rtotal_queries\x18\n" +
+	" \x01(\x03R\ftotalQueries\"&\n" +
+	"\rGetRunRequest\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\"?\n" +
+	"\x0fListRunsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\";\n" +
+	"\x10ListRunsResponse\x12'\n" +
+	"\x04runs\x18\x01 \x03(\v2\x13.dbbenchmind.v1.RunR\x04runs\"=\n" +
+	"\x0eStopRunRequest\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"-\n" +
+	"\x14StreamSamplesRequest\x12\x15\n" +
+	"\x06run_id\x18\x01 \x01(\tR\x05runId\"\x9c\x02\n" +
+	"\x06Sample\x128\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x14\n" +
+	"\x05phase\x18\x02 \x01(\tR\x05phase\x12\x10\n" +
+	"\x03tps\x18\x03 \x01(\x01R\x03tps\x12\x10\n" +
+	"\x03qps\x18\x04 \x01(\x01R\x03qps\x12$\n" +
+	"\x0elatency_avg_ms\x18\x05 \x01(\x01R\flatencyAvgMs\x12$\n" +
+	"\x0elatency_p95_ms\x18\x06 \x01(\x01R\flatencyP95Ms\x12$\n" +
+	"\x0elatency_p99_ms\x18\a \x01(\x01R\flatencyP99Ms\x12,\n" +
+	"\x12error_rate_percent\x18\b \x01(\x01R\x10errorRatePercent\"\xac\x06\n" +
+	"\x06Record\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12'\n" +
+	"\x0fconnection_name\x18\x03 \x01(\tR\x0econnectionName\x12#\n" +
+	"\rtemplate_name\x18\x04 \x01(\tR\ftemplateName\x12#\n" +
+	"\rdatabase_type\x18\x05 \x01(\tR\fdatabaseType\x12\x18\n" +
+	"\athreads\x18\x06 \x01(\x05R\athreads\x12\x16\n" +
+	"\x06agents\x18\a \x03(\tR\x06agents\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12\x14\n" +
+	"\x05notes\x18\t \x01(\tR\x05notes\x12\x14\n" +
+	"\x05state\x18\n" +
+	" \x01(\tR\x05state\x12#\n" +
+	"\rerror_message\x18\v \x01(\tR\ferrorMessage\x12\x14\n" +
+	"\x05valid\x18\f \x01(\bR\x05valid\x12F\n" +
+	"\n" +
+	"parameters\x18\r \x03(\v2&.dbbenchmind.v1.Record.ParametersEntryR\n" +
+	"parameters\x129\n" +
+	"\n" +
+	"start_time\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bduration\x18\x0f \x01(\v2\x19.google.protobuf.DurationR\bduration\x121\n" +
+	"\x06result\x18\x10 \x01(\v2\x19.dbbenchmind.v1.RunResultR\x06result\x127\n" +
+	"\vtime_series\x18\x11 \x03(\v2\x16.dbbenchmind.v1.SampleR\n" +
+	"timeSeries\x12\x18\n" +
+	"\apurpose\x18\x12 \x01(\tR\apurpose\x12\x16\n" +
+	"\x06ticket\x18\x13 \x01(\tR\x06ticket\x12 \n" +
+	"\venvironment\x18\x14 \x01(\tR\venvironment\x1a=\n" +
+	"\x0fParametersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9a\x02\n" +
+	"\x12ListRecordsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12'\n" +
+	"\x0fconnection_name\x18\x03 \x01(\tR\x0econnectionName\x12#\n" +
+	"\rtemplate_name\x18\x04 \x01(\tR\ftemplateName\x12#\n" +
+	"\rdatabase_type\x18\x05 \x01(\tR\fdatabaseType\x12\x18\n" +
+	"\athreads\x18\x06 \x01(\x05R\athreads\x12\x16\n" +
+	"\x06search\x18\a \x01(\tR\x06search\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12\x1d\n" +
+	"\n" +
+	"valid_only\x18\t \x01(\bR\tvalidOnly\"]\n" +
+	"\x13ListRecordsResponse\x120\n" +
+	"\arecords\x18\x01 \x03(\v2\x16.dbbenchmind.v1.RecordR\arecords\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"R\n" +
+	"\x10GetRecordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x13include_time_series\x18\x02 \x01(\bR\x11includeTimeSeries2\xab\x02\n" +
+	"\x11ConnectionService\x12b\n" +
+	"\x0fListConnections\x12&.dbbenchmind.v1.ListConnectionsRequest\x1a'.dbbenchmind.v1.ListConnectionsResponse\x12Q\n" +
+	"\rGetConnection\x12$.dbbenchmind.v1.GetConnectionRequest\x1a\x1a.dbbenchmind.v1.Connection\x12_\n" +
+	"\x0eTestConnection\x12%.dbbenchmind.v1.TestConnectionRequest\x1a&.dbbenchmind.v1.TestConnectionResponse2\xec\x02\n" +
+	"\n" +
+	"RunService\x12@\n" +
+	"\bStartRun\x12\x1f.dbbenchmind.v1.StartRunRequest\x1a\x13.dbbenchmind.v1.Run\x12<\n" +
+	"\x06GetRun\x12\x1d.dbbenchmind.v1.GetRunRequest\x1a\x13.dbbenchmind.v1.Run\x12M\n" +
+	"\bListRuns\x12\x1f.dbbenchmind.v1.ListRunsRequest\x1a .dbbenchmind.v1.ListRunsResponse\x12>\n" +
+	"\aStopRun\x12\x1e.dbbenchmind.v1.StopRunRequest\x1a\x13.dbbenchmind.v1.Run\x12O\n" +
+	"\rStreamSamples\x12$.dbbenchmind.v1.StreamSamplesRequest\x1a\x16.dbbenchmind.v1.Sample0\x012\xaf\x01\n" +
+	"\x0eHistoryService\x12V\n" +
+	"\vListRecords\x12\".dbbenchmind.v1.ListRecordsRequest\x1a#.dbbenchmind.v1.ListRecordsResponse\x12E\n" +
+	"\tGetRecord\x12 .dbbenchmind.v1.GetRecordRequest\x1a\x16.dbbenchmind.v1.RecordB0Z.github.com/whhaicheng/DB-BenchMind/pkg/api;apib\x06proto3"
+
+var (
+	file_dbbenchmind_proto_rawDescOnce sync.Once
+	file_dbbenchmind_proto_rawDescData []byte
+)
+
+func file_dbbenchmind_proto_rawDescGZIP() []byte {
+	file_dbbenchmind_proto_rawDescOnce.Do(func() {
+		file_dbbenchmind_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dbbenchmind_proto_rawDesc), len(file_dbbenchmind_proto_rawDesc)))
+	})
+	return file_dbbenchmind_proto_rawDescData
+}
+
+var file_dbbenchmind_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_dbbenchmind_proto_goTypes = []any{
+	(*Connection)(nil),              // 0: dbbenchmind.v1.Connection
+	(*ListConnectionsRequest)(nil),  // 1: dbbenchmind.v1.ListConnectionsRequest
+	(*ListConnectionsResponse)(nil), // 2: dbbenchmind.v1.ListConnectionsResponse
+	(*GetConnectionRequest)(nil),    // 3: dbbenchmind.v1.GetConnectionRequest
+	(*TestConnectionRequest)(nil),   // 4: dbbenchmind.v1.TestConnectionRequest
+	(*TestConnectionResponse)(nil),  // 5: dbbenchmind.v1.TestConnectionResponse
+	(*RunOptions)(nil),              // 6: dbbenchmind.v1.RunOptions
+	(*StartRunRequest)(nil),         // 7: dbbenchmind.v1.StartRunRequest
+	(*Run)(nil),                     // 8: dbbenchmind.v1.Run
+	(*RunResult)(nil),               // 9: dbbenchmind.v1.RunResult
+	(*GetRunRequest)(nil),           // 10: dbbenchmind.v1.GetRunRequest
+	(*ListRunsRequest)(nil),         // 11: dbbenchmind.v1.ListRunsRequest
+	(*ListRunsResponse)(nil),        // 12: dbbenchmind.v1.ListRunsResponse
+	(*StopRunRequest)(nil),          // 13: dbbenchmind.v1.StopRunRequest
+	(*StreamSamplesRequest)(nil),    // 14: dbbenchmind.v1.StreamSamplesRequest
+	(*Sample)(nil),                  // 15: dbbenchmind.v1.Sample
+	(*Record)(nil),                  // 16: dbbenchmind.v1.Record
+	(*ListRecordsRequest)(nil),      // 17: dbbenchmind.v1.ListRecordsRequest
+	(*ListRecordsResponse)(nil),     // 18: dbbenchmind.v1.ListRecordsResponse
+	(*GetRecordRequest)(nil),        // 19: dbbenchmind.v1.GetRecordRequest
+	nil,                             // 20: dbbenchmind.v1.Run.ParametersEntry
+	nil,                             // 21: dbbenchmind.v1.Record.ParametersEntry
+	(*durationpb.Duration)(nil),     // 22: google.protobuf.Duration
+	(*structpb.Struct)(nil),         // 23: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),   // 24: google.protobuf.Timestamp
+}
+var file_dbbenchmind_proto_depIdxs = []int32{
+	0,  // 0: dbbenchmind.v1.ListConnectionsResponse.connections:type_name -> dbbenchmind.v1.Connection
+	22, // 1: dbbenchmind.v1.RunOptions.sample_interval:type_name -> google.protobuf.Duration
+	23, // 2: dbbenchmind.v1.StartRunRequest.parameters:type_name -> google.protobuf.Struct
+	6,  // 3: dbbenchmind.v1.StartRunRequest.options:type_name -> dbbenchmind.v1.RunOptions
+	24, // 4: dbbenchmind.v1.Run.created_at:type_name -> google.protobuf.Timestamp
+	24, // 5: dbbenchmind.v1.Run.started_at:type_name -> google.protobuf.Timestamp
+	24, // 6: dbbenchmind.v1.Run.completed_at:type_name -> google.protobuf.Timestamp
+	22, // 7: dbbenchmind.v1.Run.duration:type_name -> google.protobuf.Duration
+	20, // 8: dbbenchmind.v1.Run.parameters:type_name -> dbbenchmind.v1.Run.ParametersEntry
+	9,  // 9: dbbenchmind.v1.Run.result:type_name -> dbbenchmind.v1.RunResult
+	8,  // 10: dbbenchmind.v1.ListRunsResponse.runs:type_name -> dbbenchmind.v1.Run
+	24, // 11: dbbenchmind.v1.Sample.timestamp:type_name -> google.protobuf.Timestamp
+	24, // 12: dbbenchmind.v1.Record.created_at:type_name -> google.protobuf.Timestamp
+	21, // 13: dbbenchmind.v1.Record.parameters:type_name -> dbbenchmind.v1.Record.ParametersEntry
+	24, // 14: dbbenchmind.v1.Record.start_time:type_name -> google.protobuf.Timestamp
+	22, // 15: dbbenchmind.v1.Record.duration:type_name -> google.protobuf.Duration
+	9,  // 16: dbbenchmind.v1.Record.result:type_name -> dbbenchmind.v1.RunResult
+	15, // 17: dbbenchmind.v1.Record.time_series:type_name -> dbbenchmind.v1.Sample
+	16, // 18: dbbenchmind.v1.ListRecordsResponse.records:type_name -> dbbenchmind.v1.Record
+	1,  // 19: dbbenchmind.v1.ConnectionService.ListConnections:input_type -> dbbenchmind.v1.ListConnectionsRequest
+	3,  // 20: dbbenchmind.v1.ConnectionService.GetConnection:input_type -> dbbenchmind.v1.GetConnectionRequest
+	4,  // 21: dbbenchmind.v1.ConnectionService.TestConnection:input_type -> dbbenchmind.v1.TestConnectionRequest
+	7,  // 22: dbbenchmind.v1.RunService.StartRun:input_type -> dbbenchmind.v1.StartRunRequest
+	10, // 23: dbbenchmind.v1.RunService.GetRun:input_type -> dbbenchmind.v1.GetRunRequest
+	11, // 24: dbbenchmind.v1.RunService.ListRuns:input_type -> dbbenchmind.v1.ListRunsRequest
+	13, // 25: dbbenchmind.v1.RunService.StopRun:input_type -> dbbenchmind.v1.StopRunRequest
+	14, // 26: dbbenchmind.v1.RunService.StreamSamples:input_type -> dbbenchmind.v1.StreamSamplesRequest
+	17, // 27: dbbenchmind.v1.HistoryService.ListRecords:input_type -> dbbenchmind.v1.ListRecordsRequest
+	19, // 28: dbbenchmind.v1.HistoryService.GetRecord:input_type -> dbbenchmind.v1.GetRecordRequest
+	2,  // 29: dbbenchmind.v1.ConnectionService.ListConnections:output_type -> dbbenchmind.v1.ListConnectionsResponse
+	0,  // 30: dbbenchmind.v1.ConnectionService.GetConnection:output_type -> dbbenchmind.v1.Connection
+	5,  // 31: dbbenchmind.v1.ConnectionService.TestConnection:output_type -> dbbenchmind.v1.TestConnectionResponse
+	8,  // 32: dbbenchmind.v1.RunService.StartRun:output_type -> dbbenchmind.v1.Run
+	8,  // 33: dbbenchmind.v1.RunService.GetRun:output_type -> dbbenchmind.v1.Run
+	12, // 34: dbbenchmind.v1.RunService.ListRuns:output_type -> dbbenchmind.v1.ListRunsResponse
+	8,  // 35: dbbenchmind.v1.RunService.StopRun:output_type -> dbbenchmind.v1.Run
+	15, // 36: dbbenchmind.v1.RunService.StreamSamples:output_type -> dbbenchmind.v1.Sample
+	18, // 37: dbbenchmind.v1.HistoryService.ListRecords:output_type -> dbbenchmind.v1.ListRecordsResponse
+	16, // 38: dbbenchmind.v1.HistoryService.GetRecord:output_type -> dbbenchmind.v1.Record
+	29, // [29:39] is the sub-list for method output_type
+	19, // [19:29] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_dbbenchmind_proto_init() }
+func file_dbbenchmind_proto_init() {
+	if File_dbbenchmind_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dbbenchmind_proto_rawDesc), len(file_dbbenchmind_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_dbbenchmind_proto_goTypes,
+		DependencyIndexes: file_dbbenchmind_proto_depIdxs,
+		MessageInfos:      file_dbbenchmind_proto_msgTypes,
+	}.Build()
+	File_dbbenchmind_proto = out.File
+	file_dbbenchmind_proto_goTypes = nil
+	file_dbbenchmind_proto_depIdxs = nil
+}